@@ -1,30 +1,58 @@
 package main
 
 import (
-	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"os"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/term"
 	"github.com/spf13/cobra"
 
-	"github.com/gophercloud/gophercloud/openstack"
-	"github.com/gophercloud/gophercloud/v2"
-	openstackV2 "github.com/gophercloud/gophercloud/v2/openstack"
+	gophercloud1 "github.com/gophercloud/gophercloud"
 	"log"
-	"time"
-
-	"golang.org/x/sync/errgroup"
 
 	"ostui/internal/client"
 	"ostui/internal/config"
+	"ostui/internal/demo"
+	"ostui/internal/i18n"
+	"ostui/internal/keyring"
+	"ostui/internal/tfstate"
 	"ostui/internal/ui"
+	"ostui/internal/ui/compute"
+	"ostui/internal/ui/events"
+	"ostui/internal/ui/search"
+	"ostui/internal/ui/softlock"
+	"ostui/internal/ui/theme"
 )
 
 var (
 	cloudName   string
 	projectName string
 	debug       bool
+	recordPath  string
+	replayPath  string
+	demoMode    bool
+	// maxConcurrent caps parallel API requests (client.Requests).
+	maxConcurrent int
+	// tfstatePaths are Terraform state files used to annotate resources.
+	tfstatePaths []string
+	// Secret prompting: see completeSecrets.
+	useKeyring   bool
+	forgetSecret bool
+	askPasscode  bool
+	// noTokenCache keeps tokens off disk, for accounts shared between users.
+	noTokenCache bool
+	// transport overrides the proxy and TLS settings of clouds.yaml.
+	transport client.TransportOptions
+	// eventsListen is the address notifications are POSTed to, if any.
+	eventsListen string
+	eventsToken  string
+	// palette is the color mode; empty follows NO_COLOR.
+	palette string
+	// allocationRatios apply to hypervisors placement reports none for.
+	allocationRatios = compute.DefaultAllocationRatios()
 )
 
 func main() {
@@ -37,7 +65,32 @@ func main() {
 	rootCmd.PersistentFlags().StringVar(&cloudName, "cloud", os.Getenv("OS_CLOUD"), "Name of the cloud configuration in clouds.yaml")
 	rootCmd.PersistentFlags().BoolVar(&debug, "debug", false, "Enable debug output")
 	rootCmd.PersistentFlags().StringVar(&projectName, "project", "", "Name of the project (optional)")
-	_ = rootCmd.MarkPersistentFlagRequired("cloud")
+	rootCmd.PersistentFlags().Float64Var(&allocationRatios.CPU, "cpu-allocation-ratio", allocationRatios.CPU, "vCPU overcommit ratio used for hypervisor capacity")
+	rootCmd.PersistentFlags().Float64Var(&allocationRatios.RAM, "ram-allocation-ratio", allocationRatios.RAM, "RAM overcommit ratio used for hypervisor capacity")
+	rootCmd.PersistentFlags().Float64Var(&compute.UtilWarnPct, "util-warn", compute.UtilWarnPct, "Hypervisor utilisation (% of effective capacity) flagged as a warning")
+	rootCmd.PersistentFlags().Float64Var(&compute.UtilCritPct, "util-critical", compute.UtilCritPct, "Hypervisor utilisation (% of effective capacity) flagged as critical")
+	rootCmd.PersistentFlags().StringVar(&recordPath, "record", "", "Record all API responses to this session file")
+	rootCmd.PersistentFlags().StringVar(&replayPath, "replay", "", "Run against a recorded session file without cloud access")
+	rootCmd.PersistentFlags().IntVar(&client.HTTPRetry.MaxRetries, "max-retries", client.HTTPRetry.MaxRetries, "Retries for rate-limited (429/503) or failed API requests")
+	rootCmd.PersistentFlags().DurationVar(&client.HTTPRetry.MaxDelay, "retry-max-wait", client.HTTPRetry.MaxDelay, "Longest wait between API retries, including Retry-After")
+	rootCmd.PersistentFlags().DurationVar(&client.TokenRenewBefore, "token-renew-before", client.TokenRenewBefore, "Renew the Keystone token in the background when less than this remains")
+	rootCmd.PersistentFlags().BoolVar(&noTokenCache, "no-token-cache", false, "Neither read nor write the token cache; log in on every launch")
+	rootCmd.PersistentFlags().IntVar(&maxConcurrent, "max-concurrent-requests", client.Requests.Stats().Limit, "Cap on parallel API requests (0 = unlimited)")
+	rootCmd.PersistentFlags().StringSliceVar(&tfstatePaths, "tfstate", nil, "Terraform state file(s) used to mark managed and unmanaged resources")
+	rootCmd.PersistentFlags().BoolVar(&useKeyring, "keyring", false, "Read a prompted password or secret from the OS keyring, and save it there after prompting")
+	rootCmd.PersistentFlags().BoolVar(&forgetSecret, "forget-secret", false, "Delete the cloud's secret from the OS keyring and prompt again")
+	rootCmd.PersistentFlags().BoolVar(&askPasscode, "passcode", false, "Prompt for a TOTP passcode (multi-factor authentication)")
+	rootCmd.PersistentFlags().StringVar(&transport.ProxyURL, "proxy", "", "Proxy URL for API requests (default: HTTPS_PROXY/HTTP_PROXY)")
+	rootCmd.PersistentFlags().StringVar(&transport.CACertFile, "cacert", "", "PEM CA bundle trusted in addition to the system roots (default: cacert from clouds.yaml or OS_CACERT)")
+	rootCmd.PersistentFlags().BoolVar(&transport.Insecure, "insecure", false, "Skip TLS certificate verification (unsafe)")
+	rootCmd.PersistentFlags().BoolVar(&softlock.Enabled, "soft-lock", false, "Ask for the account password, or the PIN in OSTUI_LOCK_PIN, before revealing token IDs and secret payloads")
+	rootCmd.PersistentFlags().DurationVar(&softlock.UnlockFor, "soft-lock-timeout", softlock.UnlockFor, "How long a correct password or PIN keeps sensitive values revealable")
+	rootCmd.PersistentFlags().DurationVar(&softlock.IdleLock, "idle-lock", 0, "Hide the screen after this long without input until a key, and the password or PIN under --soft-lock, resumes (0 = never)")
+	rootCmd.PersistentFlags().DurationVar(&softlock.IdleExit, "idle-exit", 0, "Quit after this long without input (0 = never)")
+	rootCmd.PersistentFlags().BoolVar(&demoMode, "demo", false, "Explore a generated in-memory demo cloud without credentials")
+	rootCmd.PersistentFlags().StringVar(&eventsListen, "events-listen", "", "Receive OpenStack notifications POSTed as JSON on this address (e.g. 127.0.0.1:8089) for the Events view")
+	rootCmd.PersistentFlags().StringVar(&eventsToken, "events-token", os.Getenv("OSTUI_EVENTS_TOKEN"), "Bearer token the event listener requires")
+	rootCmd.PersistentFlags().StringVar(&palette, "palette", "", "Colors: color, mono or colorblind (status symbols and a blue/orange palette); default mono when NO_COLOR is set, else color")
 
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintln(os.Stderr, err)
@@ -47,139 +100,307 @@ func main() {
 
 func run(cmd *cobra.Command, args []string) error {
 	if debug {
-		fmt.Println("debug mode enabled")
+		// The TUI owns the terminal, so debug output goes to a file.
+		f, err := tea.LogToFile("ostui-debug.log", "debug")
+		if err != nil {
+			return fmt.Errorf("failed to open debug log: %w", err)
+		}
+		defer f.Close()
+		fmt.Println("debug mode enabled, logging to ostui-debug.log")
+		client.HTTPRetry.Logf = log.Printf
 	}
 
-	// Load authentication options for the selected cloud
-	cloudsPath := os.Getenv("OS_CLIENT_CONFIG_FILE")
-	authOpts, err := config.LoadAuthOptions(cloudName, cloudsPath)
+	client.Requests.SetLimit(maxConcurrent)
+
+	if err := theme.Apply(palette); err != nil {
+		return err
+	}
+	settings, err := applySettings()
 	if err != nil {
-		return fmt.Errorf("failed to load cloud config: %w", err)
+		return err
+	}
+	if !cmd.Flags().Changed("idle-lock") {
+		softlock.IdleLock = settings.IdleLock
+	}
+	if !cmd.Flags().Changed("idle-exit") {
+		softlock.IdleExit = settings.IdleExit
+	}
+
+	if len(tfstatePaths) > 0 {
+		ix, err := tfstate.Load(tfstatePaths...)
+		if err != nil {
+			return fmt.Errorf("failed to load Terraform state: %w", err)
+		}
+		tfstate.Active = ix
 	}
 
-	// Try to load cached token
-	usedCache := false
-	if tokenID, ok := client.LoadCachedToken(cloudName); ok {
-		authOpts.TokenID = tokenID
-		usedCache = true
+	if eventsListen != "" {
+		feed, err := events.Listen(eventsListen, eventsToken)
+		if err != nil {
+			return err
+		}
+		events.Active = feed
 	}
 
-	// Authenticate with OpenStack (placeholder – further service clients can be created from this provider)
-	provider, err := openstack.AuthenticatedClient(authOpts)
-	if err != nil && usedCache {
-		// Cached token likely invalid, clear and retry
-		client.ClearCachedToken(cloudName)
-		authOpts.TokenID = ""
-		provider, err = openstack.AuthenticatedClient(authOpts)
+	if recordPath != "" && replayPath != "" {
+		return fmt.Errorf("--record and --replay cannot be used together")
 	}
-	if err != nil {
-		return fmt.Errorf("failed to authenticate with OpenStack: %w", err)
-	}
-
-	// Create a v2 provider for DNS and Load Balancer services.
-	var providerV2 *gophercloud.ProviderClient
-	// Convert v1 AuthOptions to v2 AuthOptions.
-	v2AuthOpts := gophercloud.AuthOptions{
-		IdentityEndpoint: authOpts.IdentityEndpoint,
-		Username:         authOpts.Username,
-		UserID:           authOpts.UserID,
-		Password:         authOpts.Password,
-		Passcode:         authOpts.Passcode,
-		DomainID:         authOpts.DomainID,
-		DomainName:       authOpts.DomainName,
-		TenantID:         authOpts.TenantID,
-		TenantName:       authOpts.TenantName,
-		AllowReauth:      authOpts.AllowReauth,
-		TokenID:          authOpts.TokenID,
-		// Scope omitted for simplicity.
-	}
-	providerV2, err = openstackV2.AuthenticatedClient(context.Background(), v2AuthOpts)
-	if err != nil {
-		log.Printf("warning: failed to create v2 provider for DNS/LB: %v", err)
-		// Continue with nil DNS/LB clients.
+	if demoMode {
+		softlock.Verify = lockVerifier(nil)
+		// Fake clients over a generated cloud; no authentication at all.
+		dc := demo.New(1, demo.DefaultSize)
+		services := client.NewServiceSetFromClients(client.Clients{Compute: dc.Compute(), Network: dc.Network(), Storage: dc.Storage(), Identity: dc.Identity(), Image: dc.Image(), Limits: dc.Limits(), DNS: dc.DNS(), LoadBalancer: dc.LoadBalancer(), SharedFS: dc.SharedFS(), KeyManager: dc.KeyManager(), ContainerInfra: dc.ContainerInfra()})
+		if err := applyCloudColor(settings, "demo"); err != nil {
+			return err
+		}
+		if err := applySearchCache(settings, "demo"); err != nil {
+			return err
+		}
+		p := tea.NewProgram(ui.NewModel("demo", services, ui.Options{AllocationRatios: allocationRatios}))
+		if _, err := p.Run(); err != nil {
+			return fmt.Errorf("error running TUI: %w", err)
+		}
+		return nil
 	}
 
-	// Create other service clients in parallel.
 	var (
-		computeClient  client.ComputeClient
-		networkClient  client.NetworkClient
-		storageClient  client.StorageClient
-		identityClient client.IdentityClient
-		imageClient    client.ImageClient
-		limitsClient   client.LimitsClient
+		authOpts gophercloud1.AuthOptions
+		recorder *client.Recorder
 	)
+	if replayPath != "" {
+		// Serve every API call from the recorded session.
+		replayer, err := client.LoadReplayer(replayPath)
+		if err != nil {
+			return err
+		}
+		http.DefaultTransport = replayer
+		if cloudName == "" {
+			cloudName = replayer.Session.Cloud
+		}
+		authOpts = gophercloud1.AuthOptions{IdentityEndpoint: replayer.Session.IdentityEndpoint, Username: "replay", Password: "replay", DomainName: "Default"}
+	} else {
+		if cloudName == "" {
+			return fmt.Errorf(`required flag(s) "cloud" not set (or use --demo / --replay)`)
+		}
+		// Load authentication options for the selected cloud
+		cloudsPath := os.Getenv("OS_CLIENT_CONFIG_FILE")
+		authOpts, err = config.LoadAuthOptions(cloudName, cloudsPath)
+		if err != nil {
+			return fmt.Errorf("failed to load cloud config: %w", err)
+		}
+		if err := setupTransport(cloudsPath); err != nil {
+			return err
+		}
+		if err := completeSecrets(&authOpts, recordPath == "" && !noTokenCache); err != nil {
+			return err
+		}
+		if recordPath != "" {
+			recorder = client.NewRecorder(http.DefaultTransport, cloudName, authOpts.IdentityEndpoint)
+			http.DefaultTransport = recorder
+		}
+	}
 
-	g, _ := errgroup.WithContext(context.Background())
-	g.Go(func() error {
-		var err error
-		computeClient, err = client.NewComputeClient(authOpts)
-		return err
-	})
-	g.Go(func() error {
-		var err error
-		networkClient, err = client.NewNetworkClient(authOpts)
-		return err
-	})
-	g.Go(func() error {
-		var err error
-		storageClient, err = client.NewStorageClient(authOpts)
+	// Clients are created lazily; the splash screen drives authentication and
+	// client creation so progress and errors are visible. Recording and replay
+	// always authenticate with credentials so the session contains (and can
+	// serve) the token request, as does --no-token-cache.
+	services := client.NewServiceSet(cloudName, authOpts, recordPath == "" && replayPath == "" && !noTokenCache)
+	softlock.Verify = lockVerifier(&authOpts)
+
+	if err := applyCloudColor(settings, cloudName); err != nil {
 		return err
-	})
-	g.Go(func() error {
-		var err error
-		identityClient, err = client.NewIdentityClient(authOpts)
+	}
+	if err := applySearchCache(settings, cloudName); err != nil {
 		return err
-	})
-	g.Go(func() error {
-		var err error
-		imageClient, err = client.NewImageClient(authOpts)
+	}
+
+	// Start the Bubble Tea TUI
+	p := tea.NewProgram(ui.NewSplashModel(cloudName, services, ui.Options{AllocationRatios: allocationRatios}))
+
+	final, runErr := p.Run()
+	if recorder != nil {
+		if err := recorder.Save(recordPath); err != nil {
+			log.Printf("warning: %v", err)
+		}
+	}
+	if runErr != nil {
+		return fmt.Errorf("error running TUI: %w", runErr)
+	}
+	if splash, ok := final.(ui.SplashModel); ok && splash.Err() != nil {
+		return splash.Err()
+	}
+	return nil
+}
+
+// setupTransport installs http.DefaultTransport with the proxy and TLS
+// settings of the cloud, overridden by --proxy, --cacert and --insecure.
+func setupTransport(cloudsPath string) error {
+	tlsSettings, err := config.LoadTLSSettings(cloudName, cloudsPath)
+	if err != nil {
 		return err
-	})
-	g.Go(func() error {
-		var err error
-		limitsClient, err = client.NewLimitsClient(authOpts)
+	}
+	opts := transport
+	if opts.CACertFile == "" {
+		opts.CACertFile = tlsSettings.CACertFile
+	}
+	opts.ClientCertFile, opts.ClientKeyFile = tlsSettings.ClientCertFile, tlsSettings.ClientKeyFile
+	opts.Insecure = opts.Insecure || tlsSettings.Insecure
+	t, err := client.NewHTTPTransport(opts)
+	if err != nil {
 		return err
-	})
-
-	if err := g.Wait(); err != nil {
-		return fmt.Errorf("failed to create service clients: %w", err)
 	}
+	http.DefaultTransport = t
+	// The :! shell passthrough runs the openstack CLI, which reads these.
+	if opts.CACertFile != "" {
+		_ = os.Setenv("OS_CACERT", opts.CACertFile)
+	}
+	if opts.ProxyURL != "" {
+		_ = os.Setenv("HTTPS_PROXY", opts.ProxyURL)
+	}
+	if opts.Insecure {
+		fmt.Fprintln(os.Stderr, "WARNING: TLS certificate verification is disabled; API traffic and credentials can be intercepted.")
+	}
+	return nil
+}
 
-	// Start the Bubble Tea TUI
-	// Initialize DNS and Load Balancer clients, handling errors gracefully.
-	var dnsClient client.DNSClient
-	var lbClient client.LoadBalancerClient
+// completeSecrets fills in a password or application credential secret that
+// clouds.yaml leaves out, from the OS keyring (--keyring) or a masked
+// prompt, and asks for a TOTP passcode with --passcode. Nothing is asked
+// while a cached token is still valid.
+func completeSecrets(opts *gophercloud1.AuthOptions, cacheTokens bool) error {
+	kind := config.MissingSecret(*opts)
+	account := cloudName + "/" + kind
+	if forgetSecret && kind != "" {
+		if err := keyring.Delete(account); err != nil {
+			fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+		}
+	}
+	if kind == "" && !askPasscode {
+		return nil
+	}
+	if _, ok := client.LoadCachedToken(cloudName); ok && cacheTokens && !forgetSecret {
+		return nil
+	}
 
-	if providerV2 != nil {
-		dnsClient, err = client.NewDNSClient(providerV2, gophercloud.EndpointOpts{})
-		if err != nil {
-			log.Printf("warning: failed to create DNS client: %v", err)
-			dnsClient = nil
+	if kind != "" {
+		fromKeyring := false
+		if useKeyring {
+			secret, err := keyring.Get(account)
+			switch {
+			case err == nil:
+				config.SetSecret(opts, kind, secret)
+				fromKeyring = true
+			case !errors.Is(err, keyring.ErrNotFound):
+				fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+			}
 		}
-		lbClient, err = client.NewLoadBalancerClient(providerV2, gophercloud.EndpointOpts{})
-		if err != nil {
-			log.Printf("warning: failed to create Load Balancer client: %v", err)
-			lbClient = nil
-		}
-		// Save token to cache
-		if tokenID := providerV2.Token(); tokenID != "" {
-			expiresAt := time.Now().Add(1 * time.Hour) // fallback
-			if tokenInfo, err := identityClient.GetTokenInfo(); err == nil && tokenInfo != nil {
-				expiresAt = tokenInfo.ExpiresAt
-			} else {
-				log.Printf("warning: failed to get token expiry, using fallback: %v", err)
+		if !fromKeyring {
+			secret, err := promptSecret(fmt.Sprintf("%s for %s", kind, cloudName))
+			if err != nil {
+				return err
 			}
-			if err := client.SaveCachedToken(cloudName, tokenID, expiresAt); err != nil {
-				log.Printf("warning: failed to save token cache: %v", err)
+			config.SetSecret(opts, kind, secret)
+			// Only keep secrets that work; a TOTP passcode cannot be
+			// spent on a test, so the check is skipped then.
+			if useKeyring && !askPasscode {
+				if _, err := client.CheckAuth(*opts); err != nil {
+					return fmt.Errorf("authentication failed, %s not saved: %w", kind, err)
+				}
+				if err := keyring.Set(account, secret); err != nil {
+					fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+				}
 			}
 		}
 	}
-	// Start the Bubble Tea TUI
-	p := tea.NewProgram(ui.NewModel(provider, cloudName, computeClient, networkClient, storageClient, identityClient, imageClient, limitsClient, dnsClient, lbClient))
+	if askPasscode {
+		code, err := promptSecret(fmt.Sprintf("passcode for %s", cloudName))
+		if err != nil {
+			return err
+		}
+		opts.Passcode = code
+	}
+	return nil
+}
 
-	if _, err := p.Run(); err != nil {
-		return fmt.Errorf("error running TUI: %w", err)
+// lockVerifier returns the soft-lock check: the PIN in OSTUI_LOCK_PIN when
+// set, otherwise the account password or application credential secret,
+// checked by authenticating with it. Without either (demo mode) there is
+// nothing to check against.
+func lockVerifier(opts *gophercloud1.AuthOptions) func(string) error {
+	if pin := os.Getenv("OSTUI_LOCK_PIN"); pin != "" {
+		return softlock.PIN(pin)
+	}
+	if opts == nil {
+		return nil
+	}
+	base := *opts
+	return func(secret string) error {
+		o := base
+		// A TOTP passcode cannot be reused; set a PIN on clouds with MFA.
+		o.TokenID, o.Passcode = "", ""
+		if o.ApplicationCredentialID != "" || o.ApplicationCredentialName != "" {
+			o.ApplicationCredentialSecret = secret
+		} else {
+			o.Password = secret
+		}
+		_, err := client.CheckAuth(o)
+		return err
+	}
+}
+
+// promptSecret reads a secret from the terminal without echoing it.
+func promptSecret(label string) (string, error) {
+	fd := os.Stdin.Fd()
+	if !term.IsTerminal(fd) {
+		return "", fmt.Errorf("%s is not set in clouds.yaml and stdin is not a terminal to ask for it", label)
+	}
+	fmt.Fprintf(os.Stderr, "%s: ", label)
+	b, err := term.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", fmt.Errorf("reading %s: %w", label, err)
+	}
+	return string(b), nil
+}
+
+// applySettings applies the settings file ($OSTUI_CONFIG or
+// ~/.config/ostui/config.yaml): the locale of the UI. The settings are
+// returned for those that depend on the cloud.
+func applySettings() (config.Settings, error) {
+	path, err := config.SettingsPath(os.Getenv("OSTUI_CONFIG"))
+	if err != nil {
+		return config.Settings{}, err
+	}
+	settings, err := config.LoadSettings(path)
+	if err != nil {
+		return settings, err
+	}
+	if err := i18n.SetLocale(settings.Locale); err != nil {
+		return settings, fmt.Errorf("%s: %w", path, err)
+	}
+	return settings, nil
+}
+
+// applyCloudColor gives the session the color set for cloud in the
+// settings, if any.
+func applyCloudColor(settings config.Settings, cloud string) error {
+	if err := theme.SetCloudColor(settings.CloudColors[cloud]); err != nil {
+		return fmt.Errorf("cloud_colors: %s: %w", cloud, err)
+	}
+	return nil
+}
+
+// applySearchCache loads the search index of cloud from the cache
+// directory when the settings keep it there.
+func applySearchCache(settings config.Settings, cloud string) error {
+	if !settings.SearchCache {
+		return nil
+	}
+	path, err := search.IndexPath(cloud)
+	if err != nil {
+		return fmt.Errorf("search_cache: %w", err)
 	}
+	search.Active = search.LoadIndex(path)
 	return nil
 }
 
@@ -0,0 +1,81 @@
+package client
+
+import (
+	"context"
+
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/agents"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/bgp/peers"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/bgp/speakers"
+)
+
+// Type aliases for the neutron-dynamic-routing resources.
+type BGPSpeaker = speakers.BGPSpeaker
+type BGPPeer = peers.BGPPeer
+type BGPRoute = speakers.AdvertisedRoute
+type NetworkAgent = agents.Agent
+
+// AgentTypeBGP is the agent type of BGP dynamic routing agents (dragents).
+const AgentTypeBGP = "BGP dynamic routing agent"
+
+// ListNetworkAgents returns the neutron agents of the given type, or all
+// agents when agentType is empty. Listing agents requires admin rights.
+func (c *networkClient) ListNetworkAgents(ctx context.Context, agentType string) ([]NetworkAgent, error) {
+	_ = ctx // ctx currently unused
+	allPages, err := agents.List(c.client, agents.ListOpts{AgentType: agentType}).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	return agents.ExtractAgents(allPages)
+}
+
+// ListBGPSpeakers returns the BGP speakers.
+func (c *networkClient) ListBGPSpeakers(ctx context.Context) ([]BGPSpeaker, error) {
+	_ = ctx // ctx currently unused
+	allPages, err := speakers.List(c.client).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	return speakers.ExtractBGPSpeakers(allPages)
+}
+
+// ListBGPPeers returns the BGP peers.
+func (c *networkClient) ListBGPPeers(ctx context.Context) ([]BGPPeer, error) {
+	_ = ctx // ctx currently unused
+	allPages, err := peers.List(c.client).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	return peers.ExtractBGPPeers(allPages)
+}
+
+// ListBGPAdvertisedRoutes returns the routes a BGP speaker advertises.
+func (c *networkClient) ListBGPAdvertisedRoutes(ctx context.Context, speakerID string) ([]BGPRoute, error) {
+	_ = ctx // ctx currently unused
+	allPages, err := speakers.GetAdvertisedRoutes(c.client, speakerID).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	return speakers.ExtractAdvertisedRoutes(allPages)
+}
+
+// ListBGPSpeakerAgents returns the dragents hosting a BGP speaker.
+func (c *networkClient) ListBGPSpeakerAgents(ctx context.Context, speakerID string) ([]NetworkAgent, error) {
+	_ = ctx // ctx currently unused
+	allPages, err := agents.ListDRAgentHostingBGPSpeakers(c.client, speakerID).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	return agents.ExtractAgents(allPages)
+}
+
+// ScheduleBGPSpeaker schedules a BGP speaker on a dragent.
+func (c *networkClient) ScheduleBGPSpeaker(ctx context.Context, agentID, speakerID string) error {
+	_ = ctx // ctx currently unused
+	return agents.ScheduleBGPSpeaker(c.client, agentID, agents.ScheduleBGPSpeakerOpts{SpeakerID: speakerID}).ExtractErr()
+}
+
+// UnscheduleBGPSpeaker removes a BGP speaker from a dragent.
+func (c *networkClient) UnscheduleBGPSpeaker(ctx context.Context, agentID, speakerID string) error {
+	_ = ctx // ctx currently unused
+	return agents.RemoveBGPSpeaker(c.client, agentID, speakerID).ExtractErr()
+}
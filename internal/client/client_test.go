@@ -1,11 +1,35 @@
 package client
 
 import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/blockstorage/v3/volumes"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
+	gcv2 "github.com/gophercloud/gophercloud/v2"
 )
 
 // newTestClient returns a ServiceClient pointing to a test server that always returns 500.
@@ -64,3 +88,777 @@ func TestIdentityClient_ListProjects_Error(t *testing.T) {
 		t.Fatalf("expected error, got nil")
 	}
 }
+
+// TestRecorderReplay records responses from a live server and replays them without it.
+func TestRecorderReplay(t *testing.T) {
+	calls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("X-Subject-Token", "secret")
+		w.Write([]byte(fmt.Sprintf(`{"call": %d}`, calls)))
+	}))
+	rec := NewRecorder(ts.Client().Transport, "test", ts.URL)
+	hc := &http.Client{Transport: rec}
+	for i := 0; i < 2; i++ {
+		resp, err := hc.Get(ts.URL + "/servers")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		resp.Body.Close()
+	}
+	ts.Close()
+
+	path := filepath.Join(t.TempDir(), "session.json")
+	if err := rec.Save(path); err != nil {
+		t.Fatalf("save failed: %v", err)
+	}
+	rp, err := LoadReplayer(path)
+	if err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+	hc = &http.Client{Transport: rp}
+	for _, want := range []string{`{"call": 1}`, `{"call": 2}`, `{"call": 2}`} {
+		resp, err := hc.Get(ts.URL + "/servers")
+		if err != nil {
+			t.Fatalf("replay failed: %v", err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if string(body) != want {
+			t.Fatalf("expected %s, got %s", want, body)
+		}
+		if tok := resp.Header.Get("X-Subject-Token"); tok != replayToken {
+			t.Fatalf("expected token to be replaced, got %q", tok)
+		}
+	}
+	if _, err := hc.Get(ts.URL + "/networks"); err == nil {
+		t.Fatalf("expected error for unrecorded request")
+	}
+}
+
+func TestServiceSet_LazyAuthError(t *testing.T) {
+	var calls int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+	}))
+	defer ts.Close()
+
+	s := NewServiceSet("test", gophercloud.AuthOptions{IdentityEndpoint: ts.URL + "/v3", Username: "u", Password: "p", DomainName: "Default"}, false)
+	if calls != 0 {
+		t.Fatalf("expected no requests before first use, got %d", calls)
+	}
+	if _, err := s.Compute().ListInstances(); err == nil {
+		t.Fatal("expected authentication error from compute")
+	}
+	before := calls
+	if _, err := s.Network().ListNetworks(); err == nil {
+		t.Fatal("expected authentication error from network")
+	}
+	if calls != before {
+		t.Errorf("expected authentication to be attempted once, got %d extra requests", calls-before)
+	}
+}
+
+func TestLazyRetriesAfterFailure(t *testing.T) {
+	var l lazy[string]
+	builds := 0
+	build := func(results ...error) func() (string, error) {
+		return func() (string, error) {
+			err := results[builds]
+			builds++
+			if err != nil {
+				return "", err
+			}
+			return "client", nil
+		}
+	}
+	b := build(errors.New("catalog unreachable"), nil, errors.New("unused"))
+	if _, err := l.get(b); err == nil {
+		t.Fatal("expected the first build to fail")
+	}
+	if v, err := l.get(b); err != nil || v != "client" {
+		t.Fatalf("expected a new build after the failure, got %q %v", v, err)
+	}
+	if v, err := l.get(b); err != nil || v != "client" || builds != 2 {
+		t.Fatalf("expected the client kept, got %q %v after %d builds", v, err, builds)
+	}
+
+	// Refused credentials are not sent again.
+	var refused lazy[string]
+	builds = 0
+	b = build(gophercloud.ErrDefault401{ErrUnexpectedResponseCode: gophercloud.ErrUnexpectedResponseCode{Actual: 401}}, nil)
+	refused.get(b)
+	if _, err := refused.get(b); err == nil || builds != 1 {
+		t.Fatalf("expected the refused login kept, got %v after %d builds", err, builds)
+	}
+}
+
+func TestRetryTransport(t *testing.T) {
+	var calls int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != "payload" {
+			t.Errorf("attempt %d: body = %q", calls, body)
+		}
+		if calls < 3 {
+			w.Header().Set("Retry-After", "0")
+			http.Error(w, "slow down", http.StatusTooManyRequests)
+			return
+		}
+		fmt.Fprint(w, "ok")
+	}))
+	defer ts.Close()
+
+	var logged []string
+	policy := RetryPolicy{MaxRetries: 3, MaxDelay: time.Second, Logf: func(format string, args ...any) {
+		logged = append(logged, fmt.Sprintf(format, args...))
+	}}
+	hc := http.Client{Transport: NewRetryTransport(nil, policy)}
+	resp, err := hc.Post(ts.URL, "text/plain", strings.NewReader("payload"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || calls != 3 {
+		t.Errorf("status %d after %d calls, want 200 after 3", resp.StatusCode, calls)
+	}
+	if len(logged) != 2 {
+		t.Errorf("expected 2 retry log lines, got %v", logged)
+	}
+
+	// Retries are exhausted: the last 429 is returned to the caller.
+	calls = 0
+	policy.MaxRetries = 1
+	hc = http.Client{Transport: NewRetryTransport(nil, policy)}
+	resp, err = hc.Post(ts.URL, "text/plain", strings.NewReader("payload"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusTooManyRequests || calls != 2 {
+		t.Errorf("status %d after %d calls, want 429 after 2", resp.StatusCode, calls)
+	}
+}
+
+// closeBody records whether a response body was closed.
+type closeBody struct {
+	io.Reader
+	closed bool
+}
+
+func (b *closeBody) Close() error {
+	b.closed = true
+	return nil
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+func TestRetryTransportLeavesRequestAlone(t *testing.T) {
+	var sent []*http.Request
+	var bodies []*closeBody
+	next := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		sent = append(sent, r)
+		b := &closeBody{Reader: strings.NewReader("slow down")}
+		bodies = append(bodies, b)
+		return &http.Response{StatusCode: http.StatusServiceUnavailable, Status: "503 Service Unavailable", Header: http.Header{}, Body: b}, nil
+	})
+	rt := &retryTransport{next: next, policy: RetryPolicy{MaxRetries: 2}, sleep: func(context.Context, time.Duration) error { return nil }}
+	req, _ := http.NewRequest(http.MethodPost, "http://api.test/servers", strings.NewReader("payload"))
+	body := req.Body
+	resp, err := rt.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusServiceUnavailable || len(sent) != 3 {
+		t.Fatalf("expected the last 503 after 3 attempts, got %v, %v after %d", resp, err, len(sent))
+	}
+	if req.Body != body {
+		t.Error("the caller's request body must not be replaced")
+	}
+	for i, r := range sent[1:] {
+		if r == req || r == sent[i] {
+			t.Errorf("attempt %d: expected a clone of the request", i+2)
+		}
+	}
+	for i, b := range bodies[:2] {
+		if !b.closed {
+			t.Errorf("attempt %d: expected the retried response closed", i+1)
+		}
+	}
+	if bodies[2].closed {
+		t.Error("the returned response must be left open for the caller")
+	}
+
+	// A cancelled backoff still closes the response it retried.
+	sent, bodies = nil, nil
+	rt.sleep = func(context.Context, time.Duration) error { return context.Canceled }
+	req, _ = http.NewRequest(http.MethodGet, "http://api.test/servers", nil)
+	if _, err := rt.RoundTrip(req); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected the cancellation, got %v", err)
+	}
+	if len(bodies) != 1 || !bodies[0].closed {
+		t.Error("expected the response closed when the backoff is cancelled")
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		in   string
+		want time.Duration
+		ok   bool
+	}{
+		{"", 0, false},
+		{"7", 7 * time.Second, true},
+		{"Mon, 01 Jan 2024 12:00:30 GMT", 30 * time.Second, true},
+		{"soon", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseRetryAfter(tt.in, now)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("parseRetryAfter(%q) = %v, %v; want %v, %v", tt.in, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestRequestLimiter(t *testing.T) {
+	var mu sync.Mutex
+	active, peak := 0, 0
+	release := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		active++
+		peak = max(peak, active)
+		mu.Unlock()
+		<-release
+		mu.Lock()
+		active--
+		mu.Unlock()
+	}))
+	defer ts.Close()
+
+	limiter := NewRequestLimiter(2)
+	hc := http.Client{Transport: &limitTransport{limiter: limiter}}
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if resp, err := hc.Get(ts.URL); err == nil {
+				resp.Body.Close()
+			}
+		}()
+	}
+	// Wait until two requests hold slots and the rest are queued.
+	deadline := time.Now().Add(5 * time.Second)
+	for s := limiter.Stats(); s.InFlight != 2 || s.Queued != 4; s = limiter.Stats() {
+		if time.Now().After(deadline) {
+			t.Fatalf("limiter never saturated: %+v", s)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	close(release)
+	wg.Wait()
+
+	if peak > 2 {
+		t.Errorf("peak concurrency %d exceeds limit 2", peak)
+	}
+	if s := limiter.Stats(); s.Total != 6 || s.InFlight != 0 || s.Queued != 0 {
+		t.Errorf("unexpected final stats %+v", s)
+	}
+}
+
+func TestSaveImageData(t *testing.T) {
+	dir := t.TempDir()
+	data := "image-bytes"
+	want := expectedChecksums("b0e4a4b8d3e1d1ba4ab8ae81f4c8e5c8", map[string]interface{}{
+		"os_hash_algo":  "sha256",
+		"os_hash_value": "not-the-real-hash",
+	})
+	if len(want) != 2 {
+		t.Fatalf("expected md5 and sha256 checksums, got %+v", want)
+	}
+
+	// Wrong checksums: the file is discarded and the mismatch reported.
+	path := filepath.Join(dir, "bad.img")
+	_, err := saveImageData("img", strings.NewReader(data), path, int64(len(data)), want, nil)
+	var mismatch *ChecksumMismatchError
+	if !errors.As(err, &mismatch) || mismatch.Checksum.Algorithm != "md5" {
+		t.Fatalf("expected md5 mismatch, got %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("mismatched download should not be kept")
+	}
+	if _, err := os.Stat(path + ".part"); !os.IsNotExist(err) {
+		t.Errorf("partial file should be removed")
+	}
+
+	// Correct checksums: the file is saved and progress reported.
+	md5sum := md5.Sum([]byte(data))
+	want = []ImageChecksum{{Algorithm: "md5", Expected: hex.EncodeToString(md5sum[:])}}
+	path = filepath.Join(dir, "good.img")
+	var lastDone, lastTotal int64
+	result, err := saveImageData("img", strings.NewReader(data), path, int64(len(data)), want, func(done, total int64) {
+		lastDone, lastTotal = done, total
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Bytes != int64(len(data)) || len(result.Checksums) != 1 || !result.Checksums[0].OK() {
+		t.Errorf("unexpected result %+v", result)
+	}
+	if lastDone != int64(len(data)) || lastTotal != int64(len(data)) {
+		t.Errorf("progress = %d/%d", lastDone, lastTotal)
+	}
+	if b, err := os.ReadFile(path); err != nil || string(b) != data {
+		t.Errorf("saved file = %q, %v", b, err)
+	}
+
+	// A cancelled download stops and leaves nothing behind.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	path = filepath.Join(dir, "cancelled.img")
+	if _, err := saveImageData("img", ctxReader{ctx: ctx, r: strings.NewReader(data)}, path, int64(len(data)), want, nil); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected the cancellation, got %v", err)
+	}
+	if _, err := os.Stat(path + ".part"); !os.IsNotExist(err) {
+		t.Errorf("partial file of a cancelled download should be removed")
+	}
+}
+
+func TestVolumeTransition(t *testing.T) {
+	for _, tc := range []struct {
+		status      string
+		multiattach bool
+		action      VolumeAction
+		next        string
+		hint        string
+	}{
+		{status: "available", action: VolumeDelete, next: "deleting"},
+		{status: "available", action: VolumeAttach, next: "attaching"},
+		{status: "in-use", action: VolumeDelete, hint: "detach it first"},
+		{status: "in-use", action: VolumeForceDelete, next: "deleting"},
+		{status: "in-use", action: VolumeAttach, hint: "not multiattach"},
+		{status: "in-use", multiattach: true, action: VolumeAttach, next: "attaching"},
+		{status: "in-use", action: VolumeSnapshot, next: "in-use"},
+		{status: "attaching", action: VolumeAttach, hint: "no longer attaching"},
+		{status: "available", action: VolumeDetach, hint: "not attached"},
+		{status: "error", action: VolumeDelete, next: "deleting"},
+		{status: "error", action: VolumeSnapshot},
+	} {
+		next, err := VolumeTransition(volumes.Volume{Status: tc.status, Multiattach: tc.multiattach}, tc.action)
+		if next != tc.next {
+			t.Errorf("%s on %s: next = %q, want %q", tc.action, tc.status, next, tc.next)
+		}
+		var stateErr *VolumeStateError
+		if tc.next == "" && (!errors.As(err, &stateErr) || !strings.Contains(err.Error(), tc.hint)) {
+			t.Errorf("%s on %s: expected a refusal mentioning %q, got %v", tc.action, tc.status, tc.hint, err)
+		}
+	}
+}
+
+func TestPreserveEphemeralRebuildOpts(t *testing.T) {
+	opts := PreserveEphemeralRebuildOpts{RebuildOpts: servers.RebuildOpts{ImageRef: "img-1"}, PreserveEphemeral: true}
+	b, err := opts.ToServerRebuildMap()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	r, ok := b["rebuild"].(map[string]interface{})
+	if !ok || r["imageRef"] != "img-1" || r["preserve_ephemeral"] != true {
+		t.Errorf("unexpected rebuild body %v", b)
+	}
+}
+
+func TestUserDataRebuildOpts(t *testing.T) {
+	for userData, want := range map[string]interface{}{"#cloud-config\n": "I2Nsb3VkLWNvbmZpZwo=", "": nil} {
+		b, err := userDataRebuildOpts{RebuildOpts: servers.RebuildOpts{ImageRef: "img-1"}, userData: userData}.ToServerRebuildMap()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		r, ok := b["rebuild"].(map[string]interface{})
+		if v, set := r["user_data"]; !ok || !set || v != want {
+			t.Errorf("user data %q: unexpected rebuild body %v", userData, b)
+		}
+	}
+	if _, err := decodeUserData(nil); !errors.Is(err, ErrUserDataHidden) {
+		t.Errorf("expected ErrUserDataHidden for a missing attribute, got %v", err)
+	}
+	encoded := "I2Nsb3VkLWNvbmZpZwo="
+	if ud, err := decodeUserData(&encoded); err != nil || ud != "#cloud-config\n" {
+		t.Errorf("decodeUserData = %q, %v", ud, err)
+	}
+}
+
+func TestRenewTokenRequiresCredentials(t *testing.T) {
+	s := NewServiceSet("test", gophercloud.AuthOptions{IdentityEndpoint: "http://127.0.0.1:1/v3", TokenID: "abc"}, false)
+	if err := s.RenewToken(); !errors.Is(err, ErrTokenNotRenewable) {
+		t.Fatalf("expected ErrTokenNotRenewable, got %v", err)
+	}
+	if _, ok := s.TokenExpiry(); ok {
+		t.Errorf("expected no expiry before authentication")
+	}
+}
+
+func TestParseDiagnosticsFormats(t *testing.T) {
+	var standard, legacy map[string]interface{}
+	if err := json.Unmarshal([]byte(`{"state":"running","driver":"libvirt","uptime":3600,
+		"cpu_details":[{"id":0,"time":2000000000}],"memory_details":{"maximum":2048,"used":512},
+		"nic_details":[{"mac_address":"fa:16:3e:00:00:01","rx_octets":100,"tx_octets":200,"rx_drop":1}],
+		"disk_details":[{"read_bytes":10,"write_bytes":20,"errors_count":0}]}`), &standard); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(`{"cpu1_time":5,"cpu0_time":2000000000,"memory":2097152,"memory-rss":524288,
+		"tap1a2b_rx":100,"tap1a2b_tx":200,"tap1a2b_rx_drop":1,"vda_read":10,"vda_write":20,"vda_read_req":3,"vda_errors":-1}`), &legacy); err != nil {
+		t.Fatal(err)
+	}
+	for name, raw := range map[string]map[string]interface{}{"standard": standard, "legacy": legacy} {
+		d := parseDiagnostics(raw)
+		if len(d.CPUs) == 0 || d.CPUs[0].ID != 0 || d.CPUs[0].TimeNS != 2000000000 {
+			t.Errorf("%s: cpus %+v", name, d.CPUs)
+		}
+		if d.MemoryMaxMB != 2048 || d.MemoryUsedMB != 512 {
+			t.Errorf("%s: memory %d/%d", name, d.MemoryUsedMB, d.MemoryMaxMB)
+		}
+		if len(d.NICs) != 1 || d.NICs[0].RxBytes != 100 || d.NICs[0].TxBytes != 200 || d.NICs[0].RxDrop != 1 {
+			t.Errorf("%s: nics %+v", name, d.NICs)
+		}
+		if len(d.Disks) != 1 || d.Disks[0].ReadBytes != 10 || d.Disks[0].WriteBytes != 20 {
+			t.Errorf("%s: disks %+v", name, d.Disks)
+		}
+	}
+}
+
+func TestNewHTTPTransport(t *testing.T) {
+	defer func() { InsecureTLS = false }()
+	bad := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(bad, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewHTTPTransport(TransportOptions{CACertFile: bad}); err == nil {
+		t.Error("expected an error for a bundle without certificates")
+	}
+	if _, err := NewHTTPTransport(TransportOptions{ProxyURL: "::"}); err == nil {
+		t.Error("expected an error for an invalid proxy URL")
+	}
+	tr, err := NewHTTPTransport(TransportOptions{ProxyURL: "http://proxy.corp:3128", Insecure: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	req, _ := http.NewRequest("GET", "https://keystone.example.com:5000/v3", nil)
+	if u, err := tr.Proxy(req); err != nil || u.Host != "proxy.corp:3128" {
+		t.Errorf("proxy = %v, %v", u, err)
+	}
+	if !tr.TLSClientConfig.InsecureSkipVerify || !InsecureTLS {
+		t.Error("insecure mode must disable verification and be reported")
+	}
+}
+
+func TestDecryptServerPassword(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := rsa.EncryptPKCS1v15(rand.Reader, &key.PublicKey, []byte("S3cret!"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	encrypted := base64.StdEncoding.EncodeToString(data)
+	pkcs8, _ := x509.MarshalPKCS8PrivateKey(key)
+	for name, keyPEM := range map[string][]byte{
+		"pkcs1": pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}),
+		"pkcs8": pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8}),
+	} {
+		got, err := DecryptServerPassword(encrypted, keyPEM)
+		if err != nil || got != "S3cret!" {
+			t.Errorf("%s: got %q, %v", name, got, err)
+		}
+	}
+	other, _ := rsa.GenerateKey(rand.Reader, 2048)
+	otherPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(other)})
+	if _, err := DecryptServerPassword(encrypted, otherPEM); err == nil {
+		t.Error("expected another key pair to fail")
+	}
+	if _, err := DecryptServerPassword(encrypted, []byte("not a key")); err == nil {
+		t.Error("expected a missing PEM block to fail")
+	}
+}
+
+func TestDialConsole(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Upgrade") != "websocket" || r.URL.Query().Get("token") != "abc" || r.Header.Get("Origin") == "" {
+			http.Error(w, "bad handshake", http.StatusBadRequest)
+			return
+		}
+		sum := sha1.Sum([]byte(r.Header.Get("Sec-WebSocket-Key") + websocketGUID))
+		conn, rw, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		fmt.Fprintf(rw, "HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\nSec-WebSocket-Accept: %s\r\nSec-WebSocket-Protocol: binary\r\n\r\n", base64.StdEncoding.EncodeToString(sum[:]))
+		rw.Write(append([]byte{0x80 | opBinary, 7}, "login: "...))
+		rw.Flush()
+		// Echo the keystrokes back, then close.
+		peer := &wsConn{conn: conn, br: rw.Reader}
+		op, payload, err := peer.readFrame()
+		if err != nil || op != opBinary {
+			return
+		}
+		rw.Write(append([]byte{0x80 | opBinary, byte(len(payload))}, payload...))
+		rw.Write([]byte{0x80 | opClose, 0})
+		rw.Flush()
+	}))
+	defer srv.Close()
+
+	if _, err := DialConsole(context.Background(), "ws"+strings.TrimPrefix(srv.URL, "http")+"/?token=wrong"); err == nil {
+		t.Fatal("expected a refused handshake to fail")
+	}
+	con, err := DialConsole(context.Background(), "ws"+strings.TrimPrefix(srv.URL, "http")+"/?token=abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer con.Close()
+	if _, err := con.Write([]byte("root\r")); err != nil {
+		t.Fatal(err)
+	}
+	out, err := io.ReadAll(con)
+	if err != nil || string(out) != "login: root\r" {
+		t.Fatalf("got %q, %v", out, err)
+	}
+}
+
+func TestConsoleFragmentedBase64(t *testing.T) {
+	// "login: " is bG9naW46IA==, sent in fragments that split its groups.
+	var frames bytes.Buffer
+	for i, frag := range []string{"bG9na", "W46", "IA=="} {
+		op := byte(opContinuation)
+		if i == 0 {
+			op = opText
+		}
+		if i == 2 {
+			op |= 0x80
+		}
+		frames.Write(append([]byte{op, byte(len(frag))}, frag...))
+	}
+	con := &wsConn{br: bufio.NewReader(&frames), base64: true}
+	out, err := io.ReadAll(con)
+	if err != nil || string(out) != "login: " {
+		t.Fatalf("got %q, %v", out, err)
+	}
+}
+
+func TestListRecordSetPage(t *testing.T) {
+	var query string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"recordsets": [{"id": "rs-1", "name": "www.example.org.", "type": "A", "ttl": 300, "records": ["203.0.113.1"]}, {"id": "rs-2", "name": "www2.example.org.", "type": "A", "records": ["203.0.113.2"]}],
+			"links": {"self": "%[1]s/zones/z/recordsets", "next": "%[1]s/zones/z/recordsets?marker=rs-2"}, "metadata": {"total_count": 12345}}`, "http://"+r.Host)
+	}))
+	defer ts.Close()
+	dc := &DNSClientImpl{client: &gcv2.ServiceClient{ProviderClient: &gcv2.ProviderClient{HTTPClient: *ts.Client()}, Endpoint: ts.URL + "/"}}
+	page, err := dc.ListRecordSetPage(context.Background(), "z", RecordSetQuery{Name: "www*", Type: "A", Data: "203.0.113.1", Limit: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.RecordSets) != 2 || page.Total != 12345 || page.NextMarker != "rs-2" {
+		t.Fatalf("unexpected page %+v", page)
+	}
+	if !strings.Contains(query, "name=www%2A") || !strings.Contains(query, "type=A") || !strings.Contains(query, "limit=2") || !strings.Contains(query, "data=203.0.113.1") {
+		t.Errorf("filters not sent to Designate: %s", query)
+	}
+}
+
+func TestListPortsUpTo(t *testing.T) {
+	var queries []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+		page := len(queries)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"ports": [{"id": "p-%[2]d-1"}, {"id": "p-%[2]d-2"}, {"id": "p-%[2]d-3"}],
+			"ports_links": [{"rel": "next", "href": "%[1]s/ports?limit=3&marker=p-%[2]d-3"}]}`, "http://"+r.Host, page)
+	}))
+	defer ts.Close()
+	nc := &networkClient{client: &gophercloud.ServiceClient{ProviderClient: &gophercloud.ProviderClient{HTTPClient: *ts.Client()}, Endpoint: ts.URL + "/"}}
+	ps, more, err := nc.ListPortsUpTo(context.Background(), 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ps) != 4 || ps[3].ID != "p-2-1" || !more {
+		t.Fatalf("expected the first 4 ports and more, got %d ports, more=%v", len(ps), more)
+	}
+	if len(queries) != 2 || queries[0] != "limit=4" {
+		t.Errorf("expected two pages of at most 4 ports, got %v", queries)
+	}
+}
+
+func TestBorrowedProviderV2(t *testing.T) {
+	var tokens []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := r.Header.Get("X-Auth-Token")
+		tokens = append(tokens, token)
+		if token != "renewed" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"loadbalancers": [{"id": "lb-1", "name": "edge", "provisioning_status": "ACTIVE"}]}`)
+	}))
+	defer ts.Close()
+
+	// The v1 session: its catalog and a token that has expired meanwhile.
+	v1 := &gophercloud.ProviderClient{}
+	v1.SetToken("expired")
+	v1.EndpointLocator = func(eo gophercloud.EndpointOpts) (string, error) {
+		if eo.Type != "load-balancer" {
+			return "", fmt.Errorf("no %s endpoint", eo.Type)
+		}
+		return ts.URL + "/", nil
+	}
+	v1.ReauthFunc = func() error {
+		v1.SetToken("renewed")
+		return nil
+	}
+
+	lb, err := NewLoadBalancerClient(borrowedProviderV2(v1), gcv2.EndpointOpts{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	list, err := lb.ListLoadBalancers(context.Background())
+	if err != nil || len(list) != 1 || list[0].Name != "edge" {
+		t.Fatalf("unexpected load balancers %+v, %v", list, err)
+	}
+	if strings.Join(tokens, ",") != "expired,renewed" {
+		t.Errorf("expected v1's token, then the one it renewed, got %v", tokens)
+	}
+	if _, err := NewDNSClient(borrowedProviderV2(v1), gcv2.EndpointOpts{}); err == nil {
+		t.Error("expected a missing DNS endpoint reported")
+	}
+}
+
+func TestTokenCacheFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token-prod.json")
+	now := time.Now()
+	write := func(data string) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(data), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := saveTokenFile(path, cachedToken{Version: tokenCacheVersion, TokenID: "tok", ExpiresAt: now.Add(time.Hour), CloudName: "prod"}); err != nil {
+		t.Fatal(err)
+	}
+	if tok, ok := loadTokenFile(path, "prod", now); !ok || tok != "tok" {
+		t.Fatalf("expected the saved token, got %q %v", tok, ok)
+	}
+	if st, err := os.Stat(path); err != nil || st.Mode().Perm() != 0600 {
+		t.Fatalf("expected a 0600 cache file, got %v %v", st, err)
+	}
+	if unlock, err := lockTokenFile(path); err != nil {
+		t.Fatalf("expected the lock to be released, got %v", err)
+	} else {
+		unlock()
+	}
+	if _, ok := loadTokenFile(path, "prod", now.Add(56*time.Minute)); ok {
+		t.Fatal("a token with less than five minutes left must not be used")
+	}
+
+	// Files written before the version field are still read.
+	write(`{"token_id":"old","expires_at":"` + now.Add(time.Hour).Format(time.RFC3339) + `","cloud_name":"prod"}`)
+	if tok, ok := loadTokenFile(path, "prod", now); !ok || tok != "old" {
+		t.Fatalf("expected the unversioned token, got %q %v", tok, ok)
+	}
+
+	// A file of a newer schema is skipped and left alone.
+	write(`{"version":99,"token":{"id":"new"}}`)
+	if _, ok := loadTokenFile(path, "prod", now); ok {
+		t.Fatal("a newer schema must not be read")
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("expected a newer schema to be kept, got %v", err)
+	}
+
+	// Corrupt files are removed.
+	for _, bad := range []string{`{"token_id":"to`, `{"version":1,"cloud_name":"prod"}`, `{"version":1,"token_id":"x","cloud_name":"dev"}`} {
+		write(bad)
+		if _, ok := loadTokenFile(path, "prod", now); ok {
+			t.Fatalf("%s: expected no token", bad)
+		}
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			t.Fatalf("%s: expected the corrupt file to be removed, got %v", bad, err)
+		}
+	}
+}
+
+func TestTokenCacheLock(t *testing.T) {
+	wait := tokenCacheLockWait
+	tokenCacheLockWait = 50 * time.Millisecond
+	t.Cleanup(func() { tokenCacheLockWait = wait })
+	path := filepath.Join(t.TempDir(), "token-prod.json")
+	ct := cachedToken{Version: tokenCacheVersion, TokenID: "tok", ExpiresAt: time.Now().Add(time.Hour), CloudName: "prod"}
+
+	unlock, err := lockTokenFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := saveTokenFile(path, ct); !errors.Is(err, errTokenCacheBusy) {
+		t.Fatalf("expected a held lock to make the save wait and fail, got %v", err)
+	}
+	unlock()
+	if err := saveTokenFile(path, ct); err != nil {
+		t.Fatalf("expected the save to go through once released, got %v", err)
+	}
+
+	// A lock file left by a process that died does not hold the lock, and
+	// writers racing for it still take turns.
+	if err := os.WriteFile(path+".lock", []byte("1"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	old := time.Now().Add(-2 * time.Minute)
+	if err := os.Chtimes(path+".lock", old, old); err != nil {
+		t.Fatal(err)
+	}
+	var wg sync.WaitGroup
+	var holders, overlaps atomic.Int32
+	tokenCacheLockWait = 5 * time.Second
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			unlock, err := lockTokenFile(path)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if holders.Add(1) > 1 {
+				overlaps.Add(1)
+			}
+			time.Sleep(5 * time.Millisecond)
+			holders.Add(-1)
+			unlock()
+		}()
+	}
+	wg.Wait()
+	if n := overlaps.Load(); n > 0 {
+		t.Fatalf("expected one holder of a stale lock at a time, %d overlapped", n)
+	}
+
+	// Concurrent writers each leave a whole file behind.
+	for i := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c := ct
+			c.TokenID = fmt.Sprintf("tok-%d", i)
+			if err := saveTokenFile(path, c); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	if tok, ok := loadTokenFile(path, "prod", time.Now()); !ok || !strings.HasPrefix(tok, "tok-") {
+		t.Fatalf("expected one writer's token, got %q %v", tok, ok)
+	}
+}
@@ -0,0 +1,84 @@
+// Package clienttest provides fakes of the service clients for the tests of
+// the views. A fake holds its fixtures in exported fields, records the calls
+// that change something and fails the calls a test asks it to fail.
+//
+// Each fake embeds the interface it implements, so it keeps compiling when
+// the interface grows; calling a method the fake does not implement panics.
+// A test needing one more call embeds the fake in its own type and adds the
+// method there, rather than rewriting a whole mock:
+//
+//	type compute struct{ *clienttest.Compute }
+//
+//	func (c compute) GetConsoleLog(id string, lines int) (string, error) { … }
+package clienttest
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Recorder records calls and serves the errors set with Fail. The fakes
+// embed it; views may call them from several commands at once.
+type Recorder struct {
+	mu    sync.Mutex
+	calls []string
+	errs  map[string]error
+}
+
+// Fail makes the calls of method, e.g. "ListInstances", return err. A
+// recorded call, e.g. "ScheduleRouter l3-1 r-1", fails only that call.
+func (r *Recorder) Fail(method string, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.errs == nil {
+		r.errs = map[string]error{}
+	}
+	r.errs[method] = err
+}
+
+// Calls returns the recorded calls, e.g. "StopInstance s-1", in order.
+func (r *Recorder) Calls() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.calls...)
+}
+
+// Called reports whether call was recorded.
+func (r *Recorder) Called(call string) bool {
+	for _, c := range r.Calls() {
+		if c == call {
+			return true
+		}
+	}
+	return false
+}
+
+// err returns the error set for method.
+func (r *Recorder) err(method string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.errs[method]
+}
+
+// record notes a call of method with args, then returns its error.
+func (r *Recorder) record(method string, args ...any) error {
+	call := method
+	for _, a := range args {
+		call += " " + fmt.Sprint(a)
+	}
+	call = strings.TrimSpace(call)
+	r.mu.Lock()
+	r.calls = append(r.calls, call)
+	err, ok := r.errs[call]
+	r.mu.Unlock()
+	if ok {
+		return err
+	}
+	return r.err(method)
+}
+
+// notFound is the error of a Get for an ID the fixtures do not hold.
+func notFound(kind, id string) error {
+	return fmt.Errorf("%s %s not found", kind, id)
+}
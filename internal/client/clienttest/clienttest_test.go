@@ -0,0 +1,68 @@
+package clienttest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"ostui/internal/client"
+)
+
+var (
+	_ client.ComputeClient      = (*Compute)(nil)
+	_ client.NetworkClient      = (*Network)(nil)
+	_ client.StorageClient      = (*Storage)(nil)
+	_ client.LoadBalancerClient = (*LoadBalancer)(nil)
+)
+
+func TestComputeRecordsAndFails(t *testing.T) {
+	c := NewCompute(Server("s-1", "web", "ACTIVE"), Server("s-2", "db", "ACTIVE"))
+	if err := c.StopInstance("s-1"); err != nil {
+		t.Fatal(err)
+	}
+	if s, _ := c.GetInstance("s-1"); s.Status != "SHUTOFF" {
+		t.Errorf("expected the server stopped, got %s", s.Status)
+	}
+	c.Fail("PauseInstance", errors.New("conflict"))
+	if err := c.PauseInstance(context.Background(), "s-2"); err == nil {
+		t.Error("expected the pause to fail")
+	}
+	if s, _ := c.GetInstance("s-2"); s.Status != "ACTIVE" {
+		t.Errorf("a failed pause must not change the server, got %s", s.Status)
+	}
+	if err := c.DeleteInstance("s-1"); err != nil || len(c.Servers) != 1 {
+		t.Errorf("expected s-1 deleted, got %v, %v", c.Servers, err)
+	}
+	if !c.Called("StopInstance s-1") || !c.Called("PauseInstance s-2") || len(c.Calls()) != 3 {
+		t.Errorf("unexpected calls %v", c.Calls())
+	}
+	if _, err := c.GetInstance("s-9"); err == nil {
+		t.Error("expected an unknown server not found")
+	}
+}
+
+func TestNetworkFilters(t *testing.T) {
+	n := NewNetwork(Net("n-1", "private")).WithPorts(
+		Port("p-1", "s-1", "compute:nova", "ACTIVE"),
+		Port("p-2", "r-1", "network:router_interface", "DOWN"),
+	)
+	ps, err := n.ListPortsByServer(context.Background(), "s-1")
+	if err != nil || len(ps) != 1 || ps[0].ID != "p-1" {
+		t.Errorf("unexpected ports %v, %v", ps, err)
+	}
+	if _, err := n.GetNetwork(context.Background(), "n-1"); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestFailOneCall(t *testing.T) {
+	n := NewNetwork().WithAgents(client.NetworkAgent{ID: "l3-1"}, client.NetworkAgent{ID: "l3-2"})
+	n.Fail("ScheduleRouter l3-1 r-1", errors.New("refused"))
+	ctx := context.Background()
+	if err := n.ScheduleRouter(ctx, "l3-1", "r-1"); err == nil {
+		t.Error("expected l3-1 to refuse the router")
+	}
+	if err := n.ScheduleRouter(ctx, "l3-2", "r-1"); err != nil || len(n.L3Hosts["r-1"]) != 1 {
+		t.Errorf("expected the router on l3-2, got %v, %v", n.L3Hosts, err)
+	}
+}
@@ -0,0 +1,179 @@
+package clienttest
+
+import (
+	"context"
+
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/hypervisors"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/flavors"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
+	"ostui/internal/client"
+)
+
+// Compute is a fake client.ComputeClient over a list of servers. The
+// lifecycle actions record the call and move the server to the status nova
+// would settle in; DeleteInstance removes it.
+type Compute struct {
+	client.ComputeClient
+	Recorder
+	Servers     []servers.Server
+	Flavors     []flavors.Flavor
+	Hypervisors []hypervisors.Hypervisor
+	Services    []client.ComputeService
+}
+
+// NewCompute returns a fake holding servers.
+func NewCompute(srvs ...servers.Server) *Compute {
+	return &Compute{Servers: srvs}
+}
+
+// WithFlavors adds flavors to the fixtures.
+func (c *Compute) WithFlavors(fl ...flavors.Flavor) *Compute {
+	c.Flavors = append(c.Flavors, fl...)
+	return c
+}
+
+// WithServices adds nova services, e.g. nova-compute on a host.
+func (c *Compute) WithServices(svcs ...client.ComputeService) *Compute {
+	c.Services = append(c.Services, svcs...)
+	return c
+}
+
+// WithHypervisors adds hypervisors to the fixtures.
+func (c *Compute) WithHypervisors(hvs ...hypervisors.Hypervisor) *Compute {
+	c.Hypervisors = append(c.Hypervisors, hvs...)
+	return c
+}
+
+func (c *Compute) ListInstances() ([]servers.Server, error) {
+	if err := c.err("ListInstances"); err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]servers.Server(nil), c.Servers...), nil
+}
+
+func (c *Compute) GetInstance(id string) (servers.Server, error) {
+	if err := c.err("GetInstance"); err != nil {
+		return servers.Server{}, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, s := range c.Servers {
+		if s.ID == id {
+			return s, nil
+		}
+	}
+	return servers.Server{}, notFound("server", id)
+}
+
+// transition records method on server id and, when it succeeds, sets the
+// status of the server.
+func (c *Compute) transition(method, id, status string) error {
+	if err := c.record(method, id); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i := range c.Servers {
+		if c.Servers[i].ID == id {
+			if status != "" {
+				c.Servers[i].Status = status
+			}
+			return nil
+		}
+	}
+	return notFound("server", id)
+}
+
+func (c *Compute) StartInstance(id string) error { return c.transition("StartInstance", id, "ACTIVE") }
+func (c *Compute) StopInstance(id string) error  { return c.transition("StopInstance", id, "SHUTOFF") }
+
+func (c *Compute) RebootInstance(ctx context.Context, id string, hard bool) error {
+	method := "RebootInstance"
+	if hard {
+		method = "HardRebootInstance"
+	}
+	return c.transition(method, id, "ACTIVE")
+}
+
+func (c *Compute) PauseInstance(ctx context.Context, id string) error {
+	return c.transition("PauseInstance", id, "PAUSED")
+}
+
+func (c *Compute) UnpauseInstance(ctx context.Context, id string) error {
+	return c.transition("UnpauseInstance", id, "ACTIVE")
+}
+
+func (c *Compute) SuspendInstance(ctx context.Context, id string) error {
+	return c.transition("SuspendInstance", id, "SUSPENDED")
+}
+
+func (c *Compute) ResumeInstance(ctx context.Context, id string) error {
+	return c.transition("ResumeInstance", id, "ACTIVE")
+}
+
+func (c *Compute) ShelveInstance(ctx context.Context, id string) error {
+	return c.transition("ShelveInstance", id, "SHELVED_OFFLOADED")
+}
+
+func (c *Compute) UnshelveInstance(ctx context.Context, id string) error {
+	return c.transition("UnshelveInstance", id, "ACTIVE")
+}
+
+func (c *Compute) LockInstance(ctx context.Context, id string) error {
+	return c.transition("LockInstance", id, "")
+}
+
+func (c *Compute) UnlockInstance(ctx context.Context, id string) error {
+	return c.transition("UnlockInstance", id, "")
+}
+
+func (c *Compute) DeleteInstance(id string) error {
+	if err := c.record("DeleteInstance", id); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, s := range c.Servers {
+		if s.ID == id {
+			c.Servers = append(c.Servers[:i:i], c.Servers[i+1:]...)
+			return nil
+		}
+	}
+	return notFound("server", id)
+}
+
+func (c *Compute) ListFlavors() ([]flavors.Flavor, error) {
+	return c.Flavors, c.err("ListFlavors")
+}
+
+func (c *Compute) GetFlavor(ctx context.Context, id string) (flavors.Flavor, error) {
+	if err := c.err("GetFlavor"); err != nil {
+		return flavors.Flavor{}, err
+	}
+	for _, f := range c.Flavors {
+		if f.ID == id {
+			return f, nil
+		}
+	}
+	return flavors.Flavor{}, notFound("flavor", id)
+}
+
+func (c *Compute) ListHypervisors(ctx context.Context) ([]hypervisors.Hypervisor, error) {
+	return c.Hypervisors, c.err("ListHypervisors")
+}
+
+// ListComputeServices returns the services on host, or all of them.
+func (c *Compute) ListComputeServices(ctx context.Context, host string) ([]client.ComputeService, error) {
+	if err := c.err("ListComputeServices"); err != nil {
+		return nil, err
+	}
+	var out []client.ComputeService
+	for _, s := range c.Services {
+		if host == "" || s.Host == host {
+			out = append(out, s)
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,32 @@
+package clienttest
+
+import (
+	"github.com/gophercloud/gophercloud/openstack/blockstorage/v3/volumes"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/networks"
+	"ostui/internal/client"
+)
+
+// The builders below fill in what the views show for a resource; tests set
+// any other field on the value they return.
+
+// Server returns a server with a name and status.
+func Server(id, name, status string) servers.Server {
+	return servers.Server{ID: id, Name: name, Status: status}
+}
+
+// Net returns an up network.
+func Net(id, name string) networks.Network {
+	return networks.Network{ID: id, Name: name, Status: "ACTIVE", AdminStateUp: true}
+}
+
+// Port returns a port of deviceID with its owner, e.g. compute:nova or
+// network:router_interface.
+func Port(id, deviceID, owner, status string) client.Port {
+	return client.Port{ID: id, DeviceID: deviceID, DeviceOwner: owner, Status: status, AdminStateUp: true}
+}
+
+// Volume returns a volume of size GB.
+func Volume(id, name, status string, size int) volumes.Volume {
+	return volumes.Volume{ID: id, Name: name, Status: status, Size: size}
+}
@@ -0,0 +1,278 @@
+package clienttest
+
+import (
+	"context"
+
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/quotas"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/security/groups"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/networks"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/subnets"
+	"ostui/internal/client"
+)
+
+// Network is a fake client.NetworkClient. The core resources are below;
+// routing and the service extensions are in network_routing.go and
+// network_services.go. Updates record the call and keep their options in
+// NetworkUpdates and SubnetUpdates for the test to inspect.
+type Network struct {
+	client.NetworkClient
+	Recorder
+	Networks []networks.Network
+	// External are the networks routers can use as their gateway.
+	External []networks.Network
+	// Details holds the extension attributes by network ID.
+	Details       map[string]client.NetworkDetail
+	Subnets       []subnets.Subnet
+	Ports         []client.Port
+	Agents        []client.NetworkAgent
+	SubnetPools   []client.SubnetPool
+	AddressScopes []client.AddressScope
+	Zones         []client.NetworkAZ
+	// Placements holds the zone placement by network or router ID.
+	Placements map[string]client.AZPlacement
+	Quota      quotas.QuotaDetailSet
+
+	NetworkUpdates []networks.UpdateOpts
+	SubnetUpdates  []subnets.UpdateOpts
+
+	FloatingIPs        []client.FloatingIP
+	FloatingIPUpdates  []client.FloatingIPUpdate
+	SecurityGroups     []groups.SecGroup
+	SecurityGroupRules []client.SecurityGroupRule
+	Routers            []client.RouterDetail
+	RouterCreates      []client.RouterCreateOpts
+	// L3Hosts holds the agents hosting each router.
+	L3Hosts map[string][]client.L3Agent
+
+	TapServices       []client.TapService
+	TapFlows          []client.TapFlow
+	VPNServices       []client.VPNService
+	IKEPolicies       []client.IKEPolicy
+	IPSecPolicies     []client.IPSecPolicy
+	VPNConnections    []client.VPNConnection
+	VPNEndpointGroups []client.VPNEndpointGroup
+	FirewallGroups    []client.FirewallGroup
+	FirewallPolicies  []client.FirewallPolicy
+	FirewallRules     []client.FirewallRule
+	BGPSpeakers       []client.BGPSpeaker
+	BGPPeers          []client.BGPPeer
+	// BGPRoutes and BGPAgents hold the advertised routes and the IDs of
+	// the hosting agents by speaker ID.
+	BGPRoutes map[string][]client.BGPRoute
+	BGPAgents map[string][]string
+	// DHCPAgents holds the agents serving each network.
+	DHCPAgents map[string][]client.NetworkAgent
+	DHCPPorts  []client.DHCPPort
+}
+
+// NewNetwork returns a fake holding nets.
+func NewNetwork(nets ...networks.Network) *Network {
+	return &Network{Networks: nets}
+}
+
+// WithSubnets adds subnets to the fixtures.
+func (n *Network) WithSubnets(subs ...subnets.Subnet) *Network {
+	n.Subnets = append(n.Subnets, subs...)
+	return n
+}
+
+// WithPorts adds ports to the fixtures.
+func (n *Network) WithPorts(ps ...client.Port) *Network {
+	n.Ports = append(n.Ports, ps...)
+	return n
+}
+
+// WithAgents adds neutron agents to the fixtures.
+func (n *Network) WithAgents(as ...client.NetworkAgent) *Network {
+	n.Agents = append(n.Agents, as...)
+	return n
+}
+
+func (n *Network) ListNetworks() ([]networks.Network, error) {
+	return n.Networks, n.err("ListNetworks")
+}
+
+func (n *Network) ListExternalNetworks(ctx context.Context) ([]networks.Network, error) {
+	return n.External, n.err("ListExternalNetworks")
+}
+
+func (n *Network) GetNetwork(ctx context.Context, id string) (*networks.Network, error) {
+	if err := n.err("GetNetwork"); err != nil {
+		return nil, err
+	}
+	for _, net := range n.Networks {
+		if net.ID == id {
+			return &net, nil
+		}
+	}
+	return nil, notFound("network", id)
+}
+
+func (n *Network) GetNetworkDetail(ctx context.Context, id string) (client.NetworkDetail, error) {
+	return n.Details[id], n.err("GetNetworkDetail")
+}
+
+func (n *Network) UpdateNetwork(ctx context.Context, id string, opts networks.UpdateOpts) error {
+	if err := n.record("UpdateNetwork", id); err != nil {
+		return err
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.NetworkUpdates = append(n.NetworkUpdates, opts)
+	return nil
+}
+
+// CreateNetwork records the name and returns the network without adding
+// it to the fixtures.
+func (n *Network) CreateNetwork(ctx context.Context, opts networks.CreateOpts) (*networks.Network, error) {
+	if err := n.record("CreateNetwork", opts.Name); err != nil {
+		return nil, err
+	}
+	return &networks.Network{ID: "net-new", Name: opts.Name, AvailabilityZoneHints: opts.AvailabilityZoneHints}, nil
+}
+
+func (n *Network) ListSubnets() ([]subnets.Subnet, error) {
+	return n.Subnets, n.err("ListSubnets")
+}
+
+func (n *Network) GetSubnet(ctx context.Context, id string) (*subnets.Subnet, error) {
+	if err := n.err("GetSubnet"); err != nil {
+		return nil, err
+	}
+	for _, s := range n.Subnets {
+		if s.ID == id {
+			return &s, nil
+		}
+	}
+	return nil, notFound("subnet", id)
+}
+
+func (n *Network) UpdateSubnet(ctx context.Context, id string, opts subnets.UpdateOpts) error {
+	if err := n.record("UpdateSubnet", id); err != nil {
+		return err
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.SubnetUpdates = append(n.SubnetUpdates, opts)
+	return nil
+}
+
+// CreateSubnet records the network and returns the subnet without adding
+// it to the fixtures.
+func (n *Network) CreateSubnet(ctx context.Context, opts subnets.CreateOpts) (*subnets.Subnet, error) {
+	if err := n.record("CreateSubnet", opts.NetworkID); err != nil {
+		return nil, err
+	}
+	return &subnets.Subnet{ID: "sub-new", NetworkID: opts.NetworkID, CIDR: opts.CIDR}, nil
+}
+
+func (n *Network) ListSubnetPools(ctx context.Context) ([]client.SubnetPool, error) {
+	return n.SubnetPools, n.err("ListSubnetPools")
+}
+
+func (n *Network) ListAddressScopes(ctx context.Context) ([]client.AddressScope, error) {
+	return n.AddressScopes, n.err("ListAddressScopes")
+}
+
+func (n *Network) ListPorts(ctx context.Context) ([]client.Port, error) {
+	if err := n.err("ListPorts"); err != nil {
+		return nil, err
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return append([]client.Port(nil), n.Ports...), nil
+}
+
+func (n *Network) ListPortsUpTo(ctx context.Context, limit int) ([]client.Port, bool, error) {
+	if err := n.err("ListPortsUpTo"); err != nil {
+		return nil, false, err
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if len(n.Ports) > limit {
+		return append([]client.Port(nil), n.Ports[:limit]...), true, nil
+	}
+	return append([]client.Port(nil), n.Ports...), false, nil
+}
+
+func (n *Network) GetPort(ctx context.Context, id string) (*client.Port, error) {
+	if err := n.err("GetPort"); err != nil {
+		return nil, err
+	}
+	for _, p := range n.Ports {
+		if p.ID == id {
+			return &p, nil
+		}
+	}
+	return nil, notFound("port", id)
+}
+
+func (n *Network) ListPortsByServer(ctx context.Context, serverID string) ([]client.Port, error) {
+	return n.portsWhere("ListPortsByServer", func(p client.Port) bool { return p.DeviceID == serverID })
+}
+
+func (n *Network) ListPortsByNetwork(ctx context.Context, networkID string) ([]client.Port, error) {
+	return n.portsWhere("ListPortsByNetwork", func(p client.Port) bool { return p.NetworkID == networkID })
+}
+
+// portsWhere returns the ports matching keep, or the error set for method.
+func (n *Network) portsWhere(method string, keep func(client.Port) bool) ([]client.Port, error) {
+	if err := n.err(method); err != nil {
+		return nil, err
+	}
+	var out []client.Port
+	for _, p := range n.Ports {
+		if keep(p) {
+			out = append(out, p)
+		}
+	}
+	return out, nil
+}
+
+// ListNetworkAgents returns the agents of agentType, or all of them.
+func (n *Network) ListNetworkAgents(ctx context.Context, agentType string) ([]client.NetworkAgent, error) {
+	if err := n.err("ListNetworkAgents"); err != nil {
+		return nil, err
+	}
+	var out []client.NetworkAgent
+	for _, a := range n.Agents {
+		if agentType == "" || a.AgentType == agentType {
+			out = append(out, a)
+		}
+	}
+	return out, nil
+}
+
+// agent returns the agent with id.
+func (n *Network) agent(id string) (client.NetworkAgent, bool) {
+	for _, a := range n.Agents {
+		if a.ID == id {
+			return a, true
+		}
+	}
+	return client.NetworkAgent{}, false
+}
+
+func (n *Network) ListNetworkAZs(ctx context.Context) ([]client.NetworkAZ, error) {
+	return n.Zones, n.err("ListNetworkAZs")
+}
+
+func (n *Network) GetNetworkAZs(ctx context.Context, id string) (client.AZPlacement, error) {
+	return n.Placements[id], n.err("GetNetworkAZs")
+}
+
+func (n *Network) GetRouterAZs(ctx context.Context, id string) (client.AZPlacement, error) {
+	return n.Placements[id], n.err("GetRouterAZs")
+}
+
+func (n *Network) GetQuota(ctx context.Context, projectID string) (*quotas.QuotaDetailSet, error) {
+	if err := n.err("GetQuota"); err != nil {
+		return nil, err
+	}
+	q := n.Quota
+	return &q, nil
+}
+
+func (n *Network) UpdateQuota(ctx context.Context, projectID string, opts quotas.UpdateOpts) error {
+	return n.record("UpdateQuota", projectID)
+}
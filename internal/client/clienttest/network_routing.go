@@ -0,0 +1,277 @@
+package clienttest
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/layer3/floatingips"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/security/groups"
+	"ostui/internal/client"
+)
+
+// The floating IP, security group, router and L3 agent calls of Network.
+
+func (n *Network) ListFloatingIPs() ([]floatingips.FloatingIP, error) {
+	if err := n.err("ListFloatingIPs"); err != nil {
+		return nil, err
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	out := make([]floatingips.FloatingIP, len(n.FloatingIPs))
+	for i, f := range n.FloatingIPs {
+		out[i] = f.FloatingIP
+	}
+	return out, nil
+}
+
+func (n *Network) ListFloatingIPDetails(ctx context.Context) ([]client.FloatingIP, error) {
+	if err := n.err("ListFloatingIPDetails"); err != nil {
+		return nil, err
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return append([]client.FloatingIP(nil), n.FloatingIPs...), nil
+}
+
+// AllocateFloatingIP adds a DOWN floating IP on the network of opts.
+func (n *Network) AllocateFloatingIP(opts floatingips.CreateOptsBuilder) (floatingips.FloatingIP, error) {
+	var create floatingips.CreateOpts
+	if o, ok := opts.(floatingips.CreateOpts); ok {
+		create = o
+	}
+	if err := n.record("AllocateFloatingIP", create.FloatingNetworkID); err != nil {
+		return floatingips.FloatingIP{}, err
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	f := floatingips.FloatingIP{ID: fmt.Sprintf("fip-%d", len(n.FloatingIPs)+1), FloatingNetworkID: create.FloatingNetworkID, PortID: create.PortID, Status: "DOWN"}
+	n.FloatingIPs = append(n.FloatingIPs, client.FloatingIP{FloatingIP: f})
+	return f, nil
+}
+
+func (n *Network) ReleaseFloatingIP(id string) error {
+	if err := n.record("ReleaseFloatingIP", id); err != nil {
+		return err
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for i, f := range n.FloatingIPs {
+		if f.ID == id {
+			n.FloatingIPs = append(n.FloatingIPs[:i:i], n.FloatingIPs[i+1:]...)
+			return nil
+		}
+	}
+	return notFound("floating IP", id)
+}
+
+func (n *Network) AssociateFloatingIP(fipID, portID string) (floatingips.FloatingIP, error) {
+	return n.setFloatingIPPort("AssociateFloatingIP", fipID, portID)
+}
+
+func (n *Network) DisassociateFloatingIP(fipID string) (floatingips.FloatingIP, error) {
+	return n.setFloatingIPPort("DisassociateFloatingIP", fipID, "")
+}
+
+// setFloatingIPPort records method and points floating IP id at portID.
+func (n *Network) setFloatingIPPort(method, id, portID string) (floatingips.FloatingIP, error) {
+	if err := n.record(method, id, portID); err != nil {
+		return floatingips.FloatingIP{}, err
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for i := range n.FloatingIPs {
+		if n.FloatingIPs[i].ID == id {
+			n.FloatingIPs[i].PortID = portID
+			return n.FloatingIPs[i].FloatingIP, nil
+		}
+	}
+	return floatingips.FloatingIP{}, notFound("floating IP", id)
+}
+
+func (n *Network) UpdateFloatingIP(ctx context.Context, id string, opts client.FloatingIPUpdate) error {
+	if err := n.record("UpdateFloatingIP", id); err != nil {
+		return err
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.FloatingIPUpdates = append(n.FloatingIPUpdates, opts)
+	return nil
+}
+
+func (n *Network) ListSecurityGroups() ([]groups.SecGroup, error) {
+	return n.SecurityGroups, n.err("ListSecurityGroups")
+}
+
+func (n *Network) ListSecurityGroupRules(ctx context.Context, sgID string) ([]client.SecurityGroupRule, error) {
+	if err := n.err("ListSecurityGroupRules"); err != nil {
+		return nil, err
+	}
+	var out []client.SecurityGroupRule
+	for _, r := range n.SecurityGroupRules {
+		if r.SecGroupID == sgID {
+			out = append(out, r)
+		}
+	}
+	return out, nil
+}
+
+func (n *Network) CreateSecurityGroupRule(ctx context.Context, sgID string, rule client.SecurityGroupRuleInput) (*client.SecurityGroupRule, error) {
+	if err := n.record("CreateSecurityGroupRule", sgID, rule.Direction); err != nil {
+		return nil, err
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	r := client.SecurityGroupRule{
+		ID: fmt.Sprintf("sgr-%d", len(n.SecurityGroupRules)+1), SecGroupID: sgID,
+		Direction: string(rule.Direction), EtherType: string(rule.EtherType), Protocol: string(rule.Protocol),
+		PortRangeMin: rule.PortRangeMin, PortRangeMax: rule.PortRangeMax,
+		RemoteIPPrefix: rule.RemoteIPPrefix, RemoteGroupID: rule.RemoteGroupID,
+	}
+	n.SecurityGroupRules = append(n.SecurityGroupRules, r)
+	return &r, nil
+}
+
+func (n *Network) DeleteSecurityGroupRule(ctx context.Context, id string) error {
+	if err := n.record("DeleteSecurityGroupRule", id); err != nil {
+		return err
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for i, r := range n.SecurityGroupRules {
+		if r.ID == id {
+			n.SecurityGroupRules = append(n.SecurityGroupRules[:i:i], n.SecurityGroupRules[i+1:]...)
+			return nil
+		}
+	}
+	return notFound("security group rule", id)
+}
+
+func (n *Network) ListRouters(ctx context.Context) ([]client.Router, error) {
+	if err := n.err("ListRouters"); err != nil {
+		return nil, err
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	out := make([]client.Router, len(n.Routers))
+	for i, r := range n.Routers {
+		out[i] = r.Router
+	}
+	return out, nil
+}
+
+func (n *Network) ListRouterDetails(ctx context.Context) ([]client.RouterDetail, error) {
+	if err := n.err("ListRouterDetails"); err != nil {
+		return nil, err
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return append([]client.RouterDetail(nil), n.Routers...), nil
+}
+
+func (n *Network) GetRouter(ctx context.Context, id string) (*client.Router, error) {
+	if err := n.err("GetRouter"); err != nil {
+		return nil, err
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for _, r := range n.Routers {
+		if r.ID == id {
+			return &r.Router, nil
+		}
+	}
+	return nil, notFound("router", id)
+}
+
+// GetRouterInterfaces returns the interface ports of router id.
+func (n *Network) GetRouterInterfaces(ctx context.Context, id string) ([]client.RouterInterface, error) {
+	return n.portsWhere("GetRouterInterfaces", func(p client.Port) bool {
+		return p.DeviceID == id && strings.HasPrefix(p.DeviceOwner, "network:router_interface")
+	})
+}
+
+// CreateRouter adds the router, keeping its options in RouterCreates.
+func (n *Network) CreateRouter(ctx context.Context, opts client.RouterCreateOpts) (*client.Router, error) {
+	if err := n.record("CreateRouter", opts.Name); err != nil {
+		return nil, err
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.RouterCreates = append(n.RouterCreates, opts)
+	r := client.Router{ID: fmt.Sprintf("r-new-%d", len(n.RouterCreates)), Name: opts.Name, Status: "ACTIVE", AdminStateUp: true}
+	r.GatewayInfo.NetworkID = opts.ExternalNetworkID
+	n.Routers = append(n.Routers, client.RouterDetail{Router: r})
+	return &r, nil
+}
+
+func (n *Network) DeleteRouter(ctx context.Context, id string) error {
+	if err := n.record("DeleteRouter", id); err != nil {
+		return err
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for i, r := range n.Routers {
+		if r.ID == id {
+			n.Routers = append(n.Routers[:i:i], n.Routers[i+1:]...)
+			return nil
+		}
+	}
+	return notFound("router", id)
+}
+
+func (n *Network) AddRouterInterface(ctx context.Context, routerID, subnetID string) error {
+	return n.record("AddRouterInterface", routerID, subnetID)
+}
+
+func (n *Network) RemoveRouterInterface(ctx context.Context, routerID, subnetID string) error {
+	return n.record("RemoveRouterInterface", routerID, subnetID)
+}
+
+func (n *Network) ListRouterL3Agents(ctx context.Context, routerID string) ([]client.L3Agent, error) {
+	if err := n.err("ListRouterL3Agents"); err != nil {
+		return nil, err
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return append([]client.L3Agent(nil), n.L3Hosts[routerID]...), nil
+}
+
+// ScheduleRouter adds the agent to the hosts of the router, as standby
+// when another agent hosts it already.
+func (n *Network) ScheduleRouter(ctx context.Context, agentID, routerID string) error {
+	if err := n.record("ScheduleRouter", agentID, routerID); err != nil {
+		return err
+	}
+	a, ok := n.agent(agentID)
+	if !ok {
+		return notFound("agent", agentID)
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.L3Hosts == nil {
+		n.L3Hosts = map[string][]client.L3Agent{}
+	}
+	hosts := n.L3Hosts[routerID]
+	state := ""
+	if len(hosts) > 0 {
+		state = "standby"
+	}
+	n.L3Hosts[routerID] = append(hosts, client.L3Agent{ID: a.ID, Host: a.Host, Alive: a.Alive, AdminStateUp: a.AdminStateUp, HAState: state})
+	return nil
+}
+
+func (n *Network) UnscheduleRouter(ctx context.Context, agentID, routerID string) error {
+	if err := n.record("UnscheduleRouter", agentID, routerID); err != nil {
+		return err
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	hosts := n.L3Hosts[routerID]
+	for i, a := range hosts {
+		if a.ID == agentID {
+			n.L3Hosts[routerID] = append(hosts[:i:i], hosts[i+1:]...)
+			return nil
+		}
+	}
+	return notFound("router hosting on agent", agentID)
+}
@@ -0,0 +1,246 @@
+package clienttest
+
+import (
+	"context"
+	"fmt"
+
+	"ostui/internal/client"
+)
+
+// The tap-as-a-service, VPN, firewall, BGP and DHCP agent calls of Network.
+
+func (n *Network) ListTapServices(ctx context.Context) ([]client.TapService, error) {
+	if err := n.err("ListTapServices"); err != nil {
+		return nil, err
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return append([]client.TapService(nil), n.TapServices...), nil
+}
+
+func (n *Network) CreateTapService(ctx context.Context, ts client.TapService) (*client.TapService, error) {
+	if err := n.record("CreateTapService", ts.Name); err != nil {
+		return nil, err
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	ts.ID = fmt.Sprintf("ts-%d", len(n.TapServices)+1)
+	n.TapServices = append(n.TapServices, ts)
+	return &ts, nil
+}
+
+func (n *Network) DeleteTapService(ctx context.Context, id string) error {
+	if err := n.record("DeleteTapService", id); err != nil {
+		return err
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for i, ts := range n.TapServices {
+		if ts.ID == id {
+			n.TapServices = append(n.TapServices[:i:i], n.TapServices[i+1:]...)
+			return nil
+		}
+	}
+	return notFound("tap service", id)
+}
+
+// ListTapFlows returns the flows of tapServiceID, or all of them.
+func (n *Network) ListTapFlows(ctx context.Context, tapServiceID string) ([]client.TapFlow, error) {
+	if err := n.err("ListTapFlows"); err != nil {
+		return nil, err
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	var out []client.TapFlow
+	for _, f := range n.TapFlows {
+		if tapServiceID == "" || f.TapServiceID == tapServiceID {
+			out = append(out, f)
+		}
+	}
+	return out, nil
+}
+
+func (n *Network) CreateTapFlow(ctx context.Context, tf client.TapFlow) (*client.TapFlow, error) {
+	if err := n.record("CreateTapFlow", tf.Name); err != nil {
+		return nil, err
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	tf.ID = fmt.Sprintf("tf-%d", len(n.TapFlows)+1)
+	n.TapFlows = append(n.TapFlows, tf)
+	return &tf, nil
+}
+
+func (n *Network) DeleteTapFlow(ctx context.Context, id string) error {
+	if err := n.record("DeleteTapFlow", id); err != nil {
+		return err
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for i, f := range n.TapFlows {
+		if f.ID == id {
+			n.TapFlows = append(n.TapFlows[:i:i], n.TapFlows[i+1:]...)
+			return nil
+		}
+	}
+	return notFound("tap flow", id)
+}
+
+func (n *Network) ListVPNServices(ctx context.Context) ([]client.VPNService, error) {
+	return n.VPNServices, n.err("ListVPNServices")
+}
+
+func (n *Network) ListIKEPolicies(ctx context.Context) ([]client.IKEPolicy, error) {
+	return n.IKEPolicies, n.err("ListIKEPolicies")
+}
+
+func (n *Network) ListIPSecPolicies(ctx context.Context) ([]client.IPSecPolicy, error) {
+	return n.IPSecPolicies, n.err("ListIPSecPolicies")
+}
+
+func (n *Network) ListVPNConnections(ctx context.Context) ([]client.VPNConnection, error) {
+	return n.VPNConnections, n.err("ListVPNConnections")
+}
+
+func (n *Network) GetVPNConnection(ctx context.Context, id string) (*client.VPNConnection, error) {
+	if err := n.err("GetVPNConnection"); err != nil {
+		return nil, err
+	}
+	for _, c := range n.VPNConnections {
+		if c.ID == id {
+			return &c, nil
+		}
+	}
+	return nil, notFound("VPN connection", id)
+}
+
+func (n *Network) ListVPNEndpointGroups(ctx context.Context) ([]client.VPNEndpointGroup, error) {
+	return n.VPNEndpointGroups, n.err("ListVPNEndpointGroups")
+}
+
+func (n *Network) ListFirewallGroups(ctx context.Context) ([]client.FirewallGroup, error) {
+	return n.FirewallGroups, n.err("ListFirewallGroups")
+}
+
+func (n *Network) ListFirewallPolicies(ctx context.Context) ([]client.FirewallPolicy, error) {
+	return n.FirewallPolicies, n.err("ListFirewallPolicies")
+}
+
+func (n *Network) ListFirewallRules(ctx context.Context) ([]client.FirewallRule, error) {
+	if err := n.err("ListFirewallRules"); err != nil {
+		return nil, err
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return append([]client.FirewallRule(nil), n.FirewallRules...), nil
+}
+
+// CreateFirewallRule adds the rule, in policyID when set.
+func (n *Network) CreateFirewallRule(ctx context.Context, rule client.FirewallRuleInput, policyID string) (*client.FirewallRule, error) {
+	if err := n.record("CreateFirewallRule", rule.Name, policyID); err != nil {
+		return nil, err
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	r := client.FirewallRule{
+		ID: fmt.Sprintf("fwr-%d", len(n.FirewallRules)+1), Name: rule.Name, Action: string(rule.Action), Protocol: string(rule.Protocol),
+		SourceIPAddress: rule.SourceIPAddress, DestinationIPAddress: rule.DestinationIPAddress,
+		SourcePort: rule.SourcePort, DestinationPort: rule.DestinationPort,
+	}
+	if policyID != "" {
+		r.FirewallPolicyID = []string{policyID}
+	}
+	n.FirewallRules = append(n.FirewallRules, r)
+	return &r, nil
+}
+
+func (n *Network) DeleteFirewallRule(ctx context.Context, id string) error {
+	if err := n.record("DeleteFirewallRule", id); err != nil {
+		return err
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for i, r := range n.FirewallRules {
+		if r.ID == id {
+			n.FirewallRules = append(n.FirewallRules[:i:i], n.FirewallRules[i+1:]...)
+			return nil
+		}
+	}
+	return notFound("firewall rule", id)
+}
+
+func (n *Network) ListBGPSpeakers(ctx context.Context) ([]client.BGPSpeaker, error) {
+	return n.BGPSpeakers, n.err("ListBGPSpeakers")
+}
+
+func (n *Network) ListBGPPeers(ctx context.Context) ([]client.BGPPeer, error) {
+	return n.BGPPeers, n.err("ListBGPPeers")
+}
+
+func (n *Network) ListBGPAdvertisedRoutes(ctx context.Context, speakerID string) ([]client.BGPRoute, error) {
+	return n.BGPRoutes[speakerID], n.err("ListBGPAdvertisedRoutes")
+}
+
+// ListBGPSpeakerAgents returns the agents in BGPAgents of speakerID.
+func (n *Network) ListBGPSpeakerAgents(ctx context.Context, speakerID string) ([]client.NetworkAgent, error) {
+	if err := n.err("ListBGPSpeakerAgents"); err != nil {
+		return nil, err
+	}
+	n.mu.Lock()
+	ids := append([]string(nil), n.BGPAgents[speakerID]...)
+	n.mu.Unlock()
+	var out []client.NetworkAgent
+	for _, id := range ids {
+		if a, ok := n.agent(id); ok {
+			out = append(out, a)
+		}
+	}
+	return out, nil
+}
+
+func (n *Network) ScheduleBGPSpeaker(ctx context.Context, agentID, speakerID string) error {
+	if err := n.record("ScheduleBGPSpeaker", agentID, speakerID); err != nil {
+		return err
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.BGPAgents == nil {
+		n.BGPAgents = map[string][]string{}
+	}
+	n.BGPAgents[speakerID] = append(n.BGPAgents[speakerID], agentID)
+	return nil
+}
+
+func (n *Network) UnscheduleBGPSpeaker(ctx context.Context, agentID, speakerID string) error {
+	if err := n.record("UnscheduleBGPSpeaker", agentID, speakerID); err != nil {
+		return err
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	ids := n.BGPAgents[speakerID]
+	for i, id := range ids {
+		if id == agentID {
+			n.BGPAgents[speakerID] = append(ids[:i:i], ids[i+1:]...)
+			return nil
+		}
+	}
+	return notFound("speaker hosting on agent", agentID)
+}
+
+func (n *Network) ListNetworkDHCPAgents(ctx context.Context, networkID string) ([]client.NetworkAgent, error) {
+	return n.DHCPAgents[networkID], n.err("ListNetworkDHCPAgents")
+}
+
+// ListDHCPPorts returns the DHCP ports on networkID.
+func (n *Network) ListDHCPPorts(ctx context.Context, networkID string) ([]client.DHCPPort, error) {
+	if err := n.err("ListDHCPPorts"); err != nil {
+		return nil, err
+	}
+	var out []client.DHCPPort
+	for _, p := range n.DHCPPorts {
+		if p.NetworkID == networkID {
+			out = append(out, p)
+		}
+	}
+	return out, nil
+}
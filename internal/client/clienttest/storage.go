@@ -0,0 +1,53 @@
+package clienttest
+
+import (
+	"context"
+
+	"github.com/gophercloud/gophercloud/openstack/blockstorage/v3/volumes"
+	"ostui/internal/client"
+)
+
+// Storage is a fake client.StorageClient over a list of volumes.
+type Storage struct {
+	client.StorageClient
+	Recorder
+	Volumes []volumes.Volume
+}
+
+// NewStorage returns a fake holding vols.
+func NewStorage(vols ...volumes.Volume) *Storage {
+	return &Storage{Volumes: vols}
+}
+
+func (s *Storage) ListVolumes() ([]volumes.Volume, error) {
+	return s.Volumes, s.err("ListVolumes")
+}
+
+func (s *Storage) GetVolume(id string) (volumes.Volume, error) {
+	if err := s.err("GetVolume"); err != nil {
+		return volumes.Volume{}, err
+	}
+	for _, v := range s.Volumes {
+		if v.ID == id {
+			return v, nil
+		}
+	}
+	return volumes.Volume{}, notFound("volume", id)
+}
+
+// LoadBalancer is a fake client.LoadBalancerClient over a list of load
+// balancers.
+type LoadBalancer struct {
+	client.LoadBalancerClient
+	Recorder
+	LoadBalancers []client.LoadBalancer
+}
+
+// NewLoadBalancer returns a fake holding lbs.
+func NewLoadBalancer(lbs ...client.LoadBalancer) *LoadBalancer {
+	return &LoadBalancer{LoadBalancers: lbs}
+}
+
+func (l *LoadBalancer) ListLoadBalancers(ctx context.Context) ([]client.LoadBalancer, error) {
+	return l.LoadBalancers, l.err("ListLoadBalancers")
+}
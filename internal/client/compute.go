@@ -2,18 +2,40 @@ package client
 
 import (
 	"context"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
 	"fmt"
 	"github.com/gophercloud/gophercloud"
 	"github.com/gophercloud/gophercloud/openstack"
 	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/attachinterfaces"
 	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/availabilityzones"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/diagnostics"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/evacuate"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/extendedserverattributes"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/extendedstatus"
 	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/hypervisors"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/instanceactions"
 	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/keypairs"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/lockunlock"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/migrate"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/pauseunpause"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/quotasets"
 	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/remoteconsoles"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/rescueunrescue"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/servergroups"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/services"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/shelveunshelve"
 	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/startstop"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/suspendresume"
 	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/volumeattach"
 	"github.com/gophercloud/gophercloud/openstack/compute/v2/flavors"
 	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
+	"io"
+	"sort"
+	"strings"
 )
 
 // ComputeClient defines the methods for interacting with OpenStack Compute (Nova) service.
@@ -23,19 +45,81 @@ type ComputeClient interface {
 	StartInstance(id string) error
 	StopInstance(id string) error
 	DeleteInstance(id string) error
+	CreateInstance(ctx context.Context, opts servers.CreateOptsBuilder) (servers.Server, error)
 	ListFlavors() ([]flavors.Flavor, error)
 	ListKeypairs() ([]keypairs.KeyPair, error)
 	GetConsoleLog(id string, lines int) (string, error)
 	GetConsoleURL(ctx context.Context, id, consoleType string) (string, error)
+	OpenSerialConsole(ctx context.Context, id string) (io.ReadWriteCloser, error)
 	ListHypervisors(ctx context.Context) ([]hypervisors.Hypervisor, error)
 	GetHypervisor(ctx context.Context, id string) (*hypervisors.Hypervisor, error)
+	ListAllocationRatios(ctx context.Context) (map[string]AllocationRatios, error)
 	ListAvailabilityZones(ctx context.Context) ([]availabilityzones.AvailabilityZone, error)
 	GetFlavor(ctx context.Context, flavorID string) (flavors.Flavor, error)
 	GetKeypair(ctx context.Context, name string) (keypairs.KeyPair, error)
 	ListServerInterfaces(ctx context.Context, serverID string) ([]ServerInterface, error)
 	ListServerVolumes(ctx context.Context, serverID string) ([]ServerVolume, error)
+	AttachVolume(ctx context.Context, serverID, volumeID string) error
+	DetachVolume(ctx context.Context, serverID, volumeID string) error
+	RebootInstance(ctx context.Context, id string, hard bool) error
+	RebuildInstance(ctx context.Context, id string, opts servers.RebuildOptsBuilder) error
+	// GetServerUserData returns the user data a server was booted with;
+	// RebuildInstanceUserData rebuilds it from imageRef with new user data.
+	GetServerUserData(ctx context.Context, id string) (string, error)
+	RebuildInstanceUserData(ctx context.Context, id, imageRef, userData string) error
+	ResizeInstance(ctx context.Context, id, flavorID string) error
+	// ConfirmResize keeps a server in VERIFY_RESIZE on its new flavor;
+	// RevertResize moves it back to the old flavor and host.
+	ConfirmResize(ctx context.Context, id string) error
+	RevertResize(ctx context.Context, id string) error
+	// Admin recovery operations
+	EvacuateInstance(ctx context.Context, id, host string, onSharedStorage bool) error
+	GetServerState(ctx context.Context, id string) (ServerState, error)
+	GetServerDiagnostics(ctx context.Context, id string) (ServerDiagnostics, error)
+	ListInstanceActions(ctx context.Context, id string) ([]instanceactions.InstanceAction, error)
+	GetInstanceAction(ctx context.Context, id, requestID string) (instanceactions.InstanceActionDetail, error)
+	UpdateInstance(ctx context.Context, id string, opts servers.UpdateOpts) error
+	ResetInstanceMetadata(ctx context.Context, id string, metadata map[string]string) error
+	UpdateInstanceMetadata(ctx context.Context, id string, metadata map[string]string) error
+	// Server groups and placement
+	ListServerGroups(ctx context.Context) ([]ServerGroup, error)
+	GetServerHypervisor(ctx context.Context, id string) (string, error)
+	ListServerZones(ctx context.Context) (map[string]string, error)
+	// Quota operations
+	GetQuotaSet(ctx context.Context, projectID string) (quotasets.QuotaDetailSet, error)
+	UpdateQuotaSet(ctx context.Context, projectID string, opts quotasets.UpdateOpts) error
+	// Host maintenance (admin)
+	ListComputeServices(ctx context.Context, host string) ([]ComputeService, error)
+	SetComputeServiceEnabled(ctx context.Context, id string, enabled bool, reason string) error
+	ListHostInstances(ctx context.Context, host string) ([]servers.Server, error)
+	LiveMigrateInstance(ctx context.Context, id, host string) error
+	// Guest passwords (os-server-password)
+	GetServerPassword(ctx context.Context, id string) (string, error)
+	ChangeAdminPassword(ctx context.Context, id, password string) error
+	// CreateServerImage snapshots a server to a new image (createImage) and
+	// returns the image ID; Glance reports the image as saving until the
+	// upload is done.
+	CreateServerImage(ctx context.Context, id, name string, metadata map[string]string) (string, error)
+	// Lifecycle actions beyond start/stop
+	PauseInstance(ctx context.Context, id string) error
+	UnpauseInstance(ctx context.Context, id string) error
+	SuspendInstance(ctx context.Context, id string) error
+	ResumeInstance(ctx context.Context, id string) error
+	ShelveInstance(ctx context.Context, id string) error
+	UnshelveInstance(ctx context.Context, id string) error
+	LockInstance(ctx context.Context, id string) error
+	UnlockInstance(ctx context.Context, id string) error
+	// RescueInstance boots a server from a rescue image, the image given
+	// or the cloud's default when it is empty, with its disk attached, and
+	// returns the password of the rescue system.
+	RescueInstance(ctx context.Context, id, imageID string) (string, error)
+	UnrescueInstance(ctx context.Context, id string) error
 }
 
+// ComputeService is a nova-compute service record; its ID is the service
+// UUID on microversion 2.53 and later.
+type ComputeService = services.Service
+
 type ServerInterface struct {
 	PortID     string
 	NetworkID  string
@@ -43,10 +127,94 @@ type ServerInterface struct {
 	MACAddress string
 }
 
+// ServerGroup is a Nova server group with its placement policy normalised
+// across microversions (policy vs. the older policies list).
+type ServerGroup struct {
+	ID      string
+	Name    string
+	Policy  string
+	Members []string
+}
+
+// ServerState is the extended status of a server, including the task in
+// progress (e.g. rebuilding, rebuild_spawning) while an action runs.
+type ServerState struct {
+	Status     string
+	TaskState  string
+	VMState    string
+	PowerState int
+	// Locked is nil when the cloud does not report the lock (before
+	// microversion 2.9).
+	Locked *bool
+}
+
+// ServerDiagnostics is the hypervisor's view of a server's resource usage,
+// normalised from the standard format of microversion 2.48+ and the older
+// driver-specific keys (e.g. libvirt's cpu0_time, vda_read, tapXXX_rx).
+// Counters are cumulative since the domain started.
+type ServerDiagnostics struct {
+	State  string
+	Driver string
+	// Uptime is in seconds; 0 when unknown.
+	Uptime int64
+	CPUs   []CPUDiagnostics
+	// MemoryMaxMB and MemoryUsedMB are 0 when unknown.
+	MemoryMaxMB  int64
+	MemoryUsedMB int64
+	NICs         []NICDiagnostics
+	Disks        []DiskDiagnostics
+}
+
+// CPUDiagnostics is the cumulative CPU time of one vCPU.
+type CPUDiagnostics struct {
+	ID     int
+	TimeNS uint64
+}
+
+// NICDiagnostics are the traffic counters of one interface, named by MAC
+// address or tap device.
+type NICDiagnostics struct {
+	Name                 string
+	RxBytes, TxBytes     uint64
+	RxPackets, TxPackets uint64
+	RxErrors, TxErrors   uint64
+	RxDrop, TxDrop       uint64
+}
+
+// DiskDiagnostics are the I/O counters of one disk.
+type DiskDiagnostics struct {
+	Name                        string
+	ReadBytes, WriteBytes       uint64
+	ReadRequests, WriteRequests uint64
+	Errors                      uint64
+}
+
+// PreserveEphemeralRebuildOpts rebuilds a server keeping its ephemeral disk
+// (supported by bare-metal and some local-disk drivers).
+type PreserveEphemeralRebuildOpts struct {
+	servers.RebuildOpts
+	PreserveEphemeral bool
+}
+
+// ToServerRebuildMap implements servers.RebuildOptsBuilder.
+func (o PreserveEphemeralRebuildOpts) ToServerRebuildMap() (map[string]interface{}, error) {
+	b, err := o.RebuildOpts.ToServerRebuildMap()
+	if err != nil {
+		return nil, err
+	}
+	if r, ok := b["rebuild"].(map[string]interface{}); ok {
+		r["preserve_ephemeral"] = o.PreserveEphemeral
+	}
+	return b, nil
+}
+
 type ServerVolume struct {
 	ID       string
 	VolumeID string
 	Device   string
+	// DeleteOnTermination reports whether deleting the server deletes the
+	// volume; nil when the cloud predates microversion 2.79.
+	DeleteOnTermination *bool
 }
 
 // computeClient is a concrete implementation of ComputeClient using gophercloud.
@@ -57,10 +225,15 @@ type computeClient struct {
 // NewComputeClient creates a new ComputeClient given authentication options.
 // It authenticates with OpenStack and returns a client ready to call Compute APIs.
 func NewComputeClient(authOpts gophercloud.AuthOptions) (ComputeClient, error) {
-	provider, err := openstack.AuthenticatedClient(authOpts)
+	provider, err := authenticatedClient(authOpts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to authenticate with OpenStack: %w", err)
 	}
+	return newComputeClient(provider)
+}
+
+// newComputeClient creates a ComputeClient from an already authenticated provider.
+func newComputeClient(provider *gophercloud.ProviderClient) (ComputeClient, error) {
 	client, err := openstack.NewComputeV2(provider, gophercloud.EndpointOpts{})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create compute client: %w", err)
@@ -102,6 +275,579 @@ func (c *computeClient) DeleteInstance(id string) error {
 	return servers.Delete(c.client, id).ExtractErr()
 }
 
+// CreateInstance boots a new server. The server is returned in BUILD; its
+// addresses appear once it is active.
+func (c *computeClient) CreateInstance(ctx context.Context, opts servers.CreateOptsBuilder) (servers.Server, error) {
+	_ = ctx // ctx currently unused
+	s, err := servers.Create(c.client, opts).Extract()
+	if err != nil {
+		return servers.Server{}, err
+	}
+	return *s, nil
+}
+
+// UpdateInstance changes the updatable attributes (e.g. name) of a server.
+func (c *computeClient) UpdateInstance(ctx context.Context, id string, opts servers.UpdateOpts) error {
+	_ = ctx // ctx currently unused
+	_, err := servers.Update(c.client, id, opts).Extract()
+	return err
+}
+
+// ResetInstanceMetadata replaces all metadata of a server.
+func (c *computeClient) ResetInstanceMetadata(ctx context.Context, id string, metadata map[string]string) error {
+	_ = ctx // ctx currently unused
+	_, err := servers.ResetMetadata(c.client, id, servers.MetadataOpts(metadata)).Extract()
+	return err
+}
+
+// UpdateInstanceMetadata sets the given metadata keys of a server, keeping
+// the other keys.
+func (c *computeClient) UpdateInstanceMetadata(ctx context.Context, id string, metadata map[string]string) error {
+	_ = ctx // ctx currently unused
+	_, err := servers.UpdateMetadata(c.client, id, servers.MetadataOpts(metadata)).Extract()
+	return err
+}
+
+// RebootInstance reboots the specified server; hard selects a power cycle instead of an OS reboot.
+func (c *computeClient) RebootInstance(ctx context.Context, id string, hard bool) error {
+	_ = ctx // ctx currently unused
+	method := servers.SoftReboot
+	if hard {
+		method = servers.HardReboot
+	}
+	return servers.Reboot(c.client, id, servers.RebootOpts{Type: method}).ExtractErr()
+}
+
+// RebuildInstance reprovisions the specified server with the given options.
+func (c *computeClient) RebuildInstance(ctx context.Context, id string, opts servers.RebuildOptsBuilder) error {
+	_ = ctx // ctx currently unused
+	_, err := servers.Rebuild(c.client, id, opts).Extract()
+	return err
+}
+
+// ErrUserDataHidden is returned by GetServerUserData when the response
+// leaves the user data out: reading it is admin only by default policy.
+var ErrUserDataHidden = errors.New("user data not readable with the current roles")
+
+// GetServerUserData returns the user data of a server, decoded from base64.
+// It needs microversion 2.3, where the extended attributes gained it.
+func (c *computeClient) GetServerUserData(ctx context.Context, id string) (string, error) {
+	_ = ctx // ctx currently unused
+	sc := *c.client
+	sc.Microversion = "2.3"
+	var srv struct {
+		UserData *string `json:"OS-EXT-SRV-ATTR:user_data"`
+	}
+	if err := servers.Get(&sc, id).ExtractInto(&srv); err != nil {
+		return "", err
+	}
+	return decodeUserData(srv.UserData)
+}
+
+// decodeUserData decodes the user data attribute of a server; nil means the
+// attribute was left out and "" that the server has no user data.
+func decodeUserData(ud *string) (string, error) {
+	if ud == nil {
+		return "", ErrUserDataHidden
+	}
+	b, err := base64.StdEncoding.DecodeString(*ud)
+	if err != nil {
+		return "", fmt.Errorf("user data is not valid base64: %w", err)
+	}
+	return string(b), nil
+}
+
+// userDataRebuildOpts rebuilds a server replacing its user data, which
+// microversion 2.57 allows; null removes it.
+type userDataRebuildOpts struct {
+	servers.RebuildOpts
+	userData string
+}
+
+// ToServerRebuildMap implements servers.RebuildOptsBuilder.
+func (o userDataRebuildOpts) ToServerRebuildMap() (map[string]interface{}, error) {
+	b, err := o.RebuildOpts.ToServerRebuildMap()
+	if err != nil {
+		return nil, err
+	}
+	if r, ok := b["rebuild"].(map[string]interface{}); ok {
+		r["user_data"] = nil
+		if o.userData != "" {
+			r["user_data"] = base64.StdEncoding.EncodeToString([]byte(o.userData))
+		}
+	}
+	return b, nil
+}
+
+// RebuildInstanceUserData rebuilds a server from imageRef with userData,
+// or none when it is empty. Clouds older than microversion 2.57 refuse it.
+func (c *computeClient) RebuildInstanceUserData(ctx context.Context, id, imageRef, userData string) error {
+	_ = ctx // ctx currently unused
+	sc := *c.client
+	sc.Microversion = "2.57"
+	_, err := servers.Rebuild(&sc, id, userDataRebuildOpts{RebuildOpts: servers.RebuildOpts{ImageRef: imageRef}, userData: userData}).Extract()
+	return err
+}
+
+// ResizeInstance migrates the specified server to a new flavor. The server
+// ends up in VERIFY_RESIZE until the resize is confirmed or reverted.
+func (c *computeClient) ResizeInstance(ctx context.Context, id, flavorID string) error {
+	_ = ctx // ctx currently unused
+	return servers.Resize(c.client, id, servers.ResizeOpts{FlavorRef: flavorID}).ExtractErr()
+}
+
+// ConfirmResize confirms a resize or cold migration waiting in
+// VERIFY_RESIZE, releasing the resources held on the source host.
+func (c *computeClient) ConfirmResize(ctx context.Context, id string) error {
+	_ = ctx // ctx currently unused
+	return servers.ConfirmResize(c.client, id).ExtractErr()
+}
+
+// RevertResize brings a server waiting in VERIFY_RESIZE back to its old
+// flavor on the source host.
+func (c *computeClient) RevertResize(ctx context.Context, id string) error {
+	_ = ctx // ctx currently unused
+	return servers.RevertResize(c.client, id).ExtractErr()
+}
+
+// EvacuateInstance rebuilds a server from a failed host on another host. An
+// empty host lets the scheduler choose the target.
+func (c *computeClient) EvacuateInstance(ctx context.Context, id, host string, onSharedStorage bool) error {
+	_ = ctx // ctx currently unused
+	return evacuate.Evacuate(c.client, id, evacuate.EvacuateOpts{Host: host, OnSharedStorage: onSharedStorage}).Err
+}
+
+// computeServiceMicroversion addresses services by UUID when enabling and
+// disabling them.
+const computeServiceMicroversion = "2.53"
+
+// ListComputeServices returns the nova-compute services, optionally only
+// the one on host.
+func (c *computeClient) ListComputeServices(ctx context.Context, host string) ([]ComputeService, error) {
+	_ = ctx // ctx currently unused
+	sc := *c.client
+	sc.Microversion = computeServiceMicroversion
+	allPages, err := services.List(&sc, services.ListOpts{Binary: "nova-compute", Host: host}).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	return services.ExtractServices(allPages)
+}
+
+// SetComputeServiceEnabled enables or disables scheduling to a compute
+// service; reason is recorded when disabling.
+func (c *computeClient) SetComputeServiceEnabled(ctx context.Context, id string, enabled bool, reason string) error {
+	_ = ctx // ctx currently unused
+	sc := *c.client
+	sc.Microversion = computeServiceMicroversion
+	opts := services.UpdateOpts{Status: services.ServiceEnabled}
+	if !enabled {
+		opts = services.UpdateOpts{Status: services.ServiceDisabled, DisabledReason: reason}
+	}
+	return services.Update(&sc, id, opts).Err
+}
+
+// ListHostInstances returns the servers of all projects running on a
+// compute host.
+func (c *computeClient) ListHostInstances(ctx context.Context, host string) ([]servers.Server, error) {
+	_ = ctx // ctx currently unused
+	allPages, err := servers.List(c.client, servers.ListOpts{Host: host, AllTenants: true}).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	return servers.ExtractServers(allPages)
+}
+
+// autoLiveMigrateOpts lets nova pick block or shared storage migration
+// (block_migration "auto", microversion 2.25+).
+type autoLiveMigrateOpts struct{ host string }
+
+// ToLiveMigrateMap implements migrate.LiveMigrateOptsBuilder.
+func (o autoLiveMigrateOpts) ToLiveMigrateMap() (map[string]interface{}, error) {
+	var host interface{}
+	if o.host != "" {
+		host = o.host
+	}
+	return map[string]interface{}{"os-migrateLive": map[string]interface{}{"host": host, "block_migration": "auto"}}, nil
+}
+
+// LiveMigrateInstance moves a running server to another host without
+// downtime. An empty host lets the scheduler choose the target.
+func (c *computeClient) LiveMigrateInstance(ctx context.Context, id, host string) error {
+	_ = ctx // ctx currently unused
+	sc := *c.client
+	sc.Microversion = "2.25"
+	return migrate.LiveMigrate(&sc, id, autoLiveMigrateOpts{host: host}).ExtractErr()
+}
+
+// GetServerPassword returns the admin password the guest posted to the
+// metadata service (cloudbase-init does so on Windows), still encrypted
+// with the server's key pair and base64 encoded. It is "" when none was
+// posted; DecryptServerPassword recovers the clear text.
+func (c *computeClient) GetServerPassword(ctx context.Context, id string) (string, error) {
+	_ = ctx // ctx currently unused
+	return servers.GetPassword(c.client, id).ExtractPassword(nil)
+}
+
+// ChangeAdminPassword sets the admin password of a running server. The
+// hypervisor and a guest agent must support it; otherwise nova answers
+// with a conflict or not implemented error.
+func (c *computeClient) ChangeAdminPassword(ctx context.Context, id, password string) error {
+	_ = ctx // ctx currently unused
+	return servers.ChangeAdminPassword(c.client, id, password).ExtractErr()
+}
+
+// CreateServerImage snapshots a server to a new image with the given
+// metadata. Volume-backed servers get an image pointing to volume snapshots.
+func (c *computeClient) CreateServerImage(ctx context.Context, id, name string, metadata map[string]string) (string, error) {
+	_ = ctx // ctx currently unused
+	return servers.CreateImage(c.client, id, servers.CreateImageOpts{Name: name, Metadata: metadata}).ExtractImageID()
+}
+
+// DecryptServerPassword decrypts a password returned by GetServerPassword
+// with the PEM encoded RSA private key of the server's key pair, in PKCS#1
+// or PKCS#8 form.
+func DecryptServerPassword(encrypted string, keyPEM []byte) (string, error) {
+	block, _ := pem.Decode(keyPEM)
+	if block == nil {
+		return "", errors.New("no PEM encoded private key found")
+	}
+	if x509.IsEncryptedPEMBlock(block) { //nolint:staticcheck // only detecting, not decrypting
+		return "", errors.New("private key is passphrase protected; decrypt it first")
+	}
+	var key *rsa.PrivateKey
+	if k, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		key = k
+	} else {
+		parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return "", fmt.Errorf("failed to parse private key: %w", err)
+		}
+		k, ok := parsed.(*rsa.PrivateKey)
+		if !ok {
+			return "", errors.New("private key is not an RSA key")
+		}
+		key = k
+	}
+	data, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encrypted))
+	if err != nil {
+		return "", fmt.Errorf("failed to decode password: %w", err)
+	}
+	password, err := rsa.DecryptPKCS1v15(nil, key, data)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt password; is this the server's key pair? %w", err)
+	}
+	return string(password), nil
+}
+
+// PauseInstance freezes a running server in hypervisor memory.
+func (c *computeClient) PauseInstance(ctx context.Context, id string) error {
+	_ = ctx // ctx currently unused
+	return pauseunpause.Pause(c.client, id).ExtractErr()
+}
+
+// UnpauseInstance resumes a paused server.
+func (c *computeClient) UnpauseInstance(ctx context.Context, id string) error {
+	_ = ctx // ctx currently unused
+	return pauseunpause.Unpause(c.client, id).ExtractErr()
+}
+
+// SuspendInstance saves the memory of a running server to disk and stops it.
+func (c *computeClient) SuspendInstance(ctx context.Context, id string) error {
+	_ = ctx // ctx currently unused
+	return suspendresume.Suspend(c.client, id).ExtractErr()
+}
+
+// ResumeInstance restores a suspended server.
+func (c *computeClient) ResumeInstance(ctx context.Context, id string) error {
+	_ = ctx // ctx currently unused
+	return suspendresume.Resume(c.client, id).ExtractErr()
+}
+
+// ShelveInstance stops a server and snapshots it so its host resources can
+// be released (offloaded).
+func (c *computeClient) ShelveInstance(ctx context.Context, id string) error {
+	_ = ctx // ctx currently unused
+	return shelveunshelve.Shelve(c.client, id).ExtractErr()
+}
+
+// UnshelveInstance boots a shelved server again, on a host the scheduler
+// picks when it was offloaded.
+func (c *computeClient) UnshelveInstance(ctx context.Context, id string) error {
+	_ = ctx // ctx currently unused
+	return shelveunshelve.Unshelve(c.client, id, shelveunshelve.UnshelveOpts{}).ExtractErr()
+}
+
+// RescueInstance puts a server in rescue mode, booted from imageID or the
+// default rescue image.
+func (c *computeClient) RescueInstance(ctx context.Context, id, imageID string) (string, error) {
+	_ = ctx // ctx currently unused
+	return rescueunrescue.Rescue(c.client, id, rescueunrescue.RescueOpts{RescueImageRef: imageID}).Extract()
+}
+
+// UnrescueInstance boots a rescued server from its own disk again.
+func (c *computeClient) UnrescueInstance(ctx context.Context, id string) error {
+	_ = ctx // ctx currently unused
+	return rescueunrescue.Unrescue(c.client, id).ExtractErr()
+}
+
+// LockInstance prevents non-admin users from acting on a server.
+func (c *computeClient) LockInstance(ctx context.Context, id string) error {
+	_ = ctx // ctx currently unused
+	return lockunlock.Lock(c.client, id).ExtractErr()
+}
+
+// UnlockInstance lifts a lock set by LockInstance.
+func (c *computeClient) UnlockInstance(ctx context.Context, id string) error {
+	_ = ctx // ctx currently unused
+	return lockunlock.Unlock(c.client, id).ExtractErr()
+}
+
+// GetServerState returns the extended status of a server.
+func (c *computeClient) GetServerState(ctx context.Context, id string) (ServerState, error) {
+	_ = ctx // ctx currently unused
+	var srv struct {
+		servers.Server
+		extendedstatus.ServerExtendedStatusExt
+		Locked *bool `json:"locked"`
+	}
+	sc := *c.client
+	sc.Microversion = "2.9"
+	if err := servers.Get(&sc, id).ExtractInto(&srv); err != nil {
+		if err := servers.Get(c.client, id).ExtractInto(&srv); err != nil {
+			return ServerState{}, err
+		}
+	}
+	return ServerState{Status: srv.Status, TaskState: srv.TaskState, VMState: srv.VmState, PowerState: int(srv.PowerState), Locked: srv.Locked}, nil
+}
+
+// GetServerDiagnostics returns the resource usage counters of a server. It
+// asks for the standard format first and falls back to the driver-specific
+// one on clouds older than microversion 2.48. Admin only by default policy.
+func (c *computeClient) GetServerDiagnostics(ctx context.Context, id string) (ServerDiagnostics, error) {
+	_ = ctx // ctx currently unused
+	sc := *c.client
+	sc.Microversion = "2.48"
+	raw, err := diagnostics.Get(&sc, id).Extract()
+	if err != nil {
+		var legacyErr error
+		if raw, legacyErr = diagnostics.Get(c.client, id).Extract(); legacyErr != nil {
+			return ServerDiagnostics{}, err
+		}
+	}
+	return parseDiagnostics(raw), nil
+}
+
+// parseDiagnostics converts a diagnostics response in either format.
+func parseDiagnostics(raw map[string]interface{}) ServerDiagnostics {
+	d := ServerDiagnostics{}
+	d.State, _ = raw["state"].(string)
+	d.Driver, _ = raw["driver"].(string)
+	d.Uptime = int64(diagNumber(raw["uptime"]))
+	if _, ok := raw["cpu_details"]; ok {
+		for i, c := range diagList(raw["cpu_details"]) {
+			id := i
+			if v, ok := c["id"]; ok && v != nil {
+				id = int(diagNumber(v))
+			}
+			d.CPUs = append(d.CPUs, CPUDiagnostics{ID: id, TimeNS: uint64(diagNumber(c["time"]))})
+		}
+		if mem, ok := raw["memory_details"].(map[string]interface{}); ok {
+			d.MemoryMaxMB = int64(diagNumber(mem["maximum"]))
+			d.MemoryUsedMB = int64(diagNumber(mem["used"]))
+		}
+		for i, n := range diagList(raw["nic_details"]) {
+			name, _ := n["mac_address"].(string)
+			if name == "" {
+				name = fmt.Sprintf("nic%d", i)
+			}
+			d.NICs = append(d.NICs, NICDiagnostics{
+				Name:    name,
+				RxBytes: uint64(diagNumber(n["rx_octets"])), TxBytes: uint64(diagNumber(n["tx_octets"])),
+				RxPackets: uint64(diagNumber(n["rx_packets"])), TxPackets: uint64(diagNumber(n["tx_packets"])),
+				RxErrors: uint64(diagNumber(n["rx_errors"])), TxErrors: uint64(diagNumber(n["tx_errors"])),
+				RxDrop: uint64(diagNumber(n["rx_drop"])), TxDrop: uint64(diagNumber(n["tx_drop"])),
+			})
+		}
+		for i, k := range diagList(raw["disk_details"]) {
+			d.Disks = append(d.Disks, DiskDiagnostics{
+				Name:      fmt.Sprintf("disk%d", i),
+				ReadBytes: uint64(diagNumber(k["read_bytes"])), WriteBytes: uint64(diagNumber(k["write_bytes"])),
+				ReadRequests: uint64(diagNumber(k["read_requests"])), WriteRequests: uint64(diagNumber(k["write_requests"])),
+				Errors: uint64(diagNumber(k["errors_count"])),
+			})
+		}
+		return d
+	}
+	// Driver-specific keys, as reported by libvirt.
+	nics := map[string]*NICDiagnostics{}
+	disks := map[string]*DiskDiagnostics{}
+	nic := func(name string) *NICDiagnostics {
+		if nics[name] == nil {
+			nics[name] = &NICDiagnostics{Name: name}
+		}
+		return nics[name]
+	}
+	disk := func(name string) *DiskDiagnostics {
+		if disks[name] == nil {
+			disks[name] = &DiskDiagnostics{Name: name}
+		}
+		return disks[name]
+	}
+	for k, v := range raw {
+		n := uint64(diagNumber(v))
+		var cpu int
+		if _, err := fmt.Sscanf(k, "cpu%d_time", &cpu); err == nil && strings.HasSuffix(k, "_time") {
+			d.CPUs = append(d.CPUs, CPUDiagnostics{ID: cpu, TimeNS: n})
+			continue
+		}
+		switch {
+		case k == "memory":
+			d.MemoryMaxMB = int64(n / 1024)
+		case k == "memory-rss":
+			d.MemoryUsedMB = int64(n / 1024)
+		case strings.Contains(k, "_rx") || strings.Contains(k, "_tx"):
+			i := strings.LastIndex(k, "_rx")
+			if j := strings.LastIndex(k, "_tx"); j > i {
+				i = j
+			}
+			c, counter := nic(k[:i]), k[i+1:]
+			switch counter {
+			case "rx":
+				c.RxBytes = n
+			case "tx":
+				c.TxBytes = n
+			case "rx_packets":
+				c.RxPackets = n
+			case "tx_packets":
+				c.TxPackets = n
+			case "rx_errors":
+				c.RxErrors = n
+			case "tx_errors":
+				c.TxErrors = n
+			case "rx_drop":
+				c.RxDrop = n
+			case "tx_drop":
+				c.TxDrop = n
+			}
+		case strings.HasSuffix(k, "_read_req"):
+			disk(strings.TrimSuffix(k, "_read_req")).ReadRequests = n
+		case strings.HasSuffix(k, "_write_req"):
+			disk(strings.TrimSuffix(k, "_write_req")).WriteRequests = n
+		case strings.HasSuffix(k, "_read"):
+			disk(strings.TrimSuffix(k, "_read")).ReadBytes = n
+		case strings.HasSuffix(k, "_write"):
+			disk(strings.TrimSuffix(k, "_write")).WriteBytes = n
+		case strings.HasSuffix(k, "_errors"):
+			disk(strings.TrimSuffix(k, "_errors")).Errors = n
+		}
+	}
+	sort.Slice(d.CPUs, func(i, j int) bool { return d.CPUs[i].ID < d.CPUs[j].ID })
+	for _, c := range nics {
+		d.NICs = append(d.NICs, *c)
+	}
+	sort.Slice(d.NICs, func(i, j int) bool { return d.NICs[i].Name < d.NICs[j].Name })
+	for _, c := range disks {
+		d.Disks = append(d.Disks, *c)
+	}
+	sort.Slice(d.Disks, func(i, j int) bool { return d.Disks[i].Name < d.Disks[j].Name })
+	return d
+}
+
+// diagNumber reads a JSON number, treating anything else as 0. Drivers
+// report -1 for counters they do not support, which also becomes 0.
+func diagNumber(v interface{}) float64 {
+	f, _ := v.(float64)
+	return max(f, 0)
+}
+
+// diagList reads a JSON array of objects.
+func diagList(v interface{}) []map[string]interface{} {
+	items, _ := v.([]interface{})
+	out := make([]map[string]interface{}, 0, len(items))
+	for _, it := range items {
+		if m, ok := it.(map[string]interface{}); ok {
+			out = append(out, m)
+		}
+	}
+	return out
+}
+
+// ListInstanceActions returns the actions recorded for a server, newest first.
+func (c *computeClient) ListInstanceActions(ctx context.Context, id string) ([]instanceactions.InstanceAction, error) {
+	_ = ctx // ctx currently unused
+	allPages, err := instanceactions.List(c.client, id, nil).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	return instanceactions.ExtractInstanceActions(allPages)
+}
+
+// GetInstanceAction retrieves a single server action, including its events.
+func (c *computeClient) GetInstanceAction(ctx context.Context, id, requestID string) (instanceactions.InstanceActionDetail, error) {
+	_ = ctx // ctx currently unused
+	return instanceactions.Get(c.client, id, requestID).Extract()
+}
+
+// ListServerGroups returns the server groups of the project.
+func (c *computeClient) ListServerGroups(ctx context.Context) ([]ServerGroup, error) {
+	_ = ctx // ctx currently unused
+	allPages, err := servergroups.List(c.client, nil).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	sgs, err := servergroups.ExtractServerGroups(allPages)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]ServerGroup, 0, len(sgs))
+	for _, g := range sgs {
+		sg := ServerGroup{ID: g.ID, Name: g.Name, Members: g.Members}
+		if g.Policy != nil {
+			sg.Policy = *g.Policy
+		} else if len(g.Policies) > 0 {
+			sg.Policy = g.Policies[0]
+		}
+		out = append(out, sg)
+	}
+	return out, nil
+}
+
+// GetServerHypervisor returns the hypervisor hostname of a server. The
+// attribute is admin-only; for other users it is empty and callers should
+// fall back to the project-scoped HostID.
+func (c *computeClient) GetServerHypervisor(ctx context.Context, id string) (string, error) {
+	_ = ctx // ctx currently unused
+	var srv struct {
+		extendedserverattributes.ServerAttributesExt
+	}
+	if err := servers.Get(c.client, id).ExtractInto(&srv); err != nil {
+		return "", err
+	}
+	return srv.HypervisorHostname, nil
+}
+
+// ListServerZones returns the availability zone of every server, keyed by server ID.
+func (c *computeClient) ListServerZones(ctx context.Context) (map[string]string, error) {
+	_ = ctx // ctx currently unused
+	allPages, err := servers.List(c.client, servers.ListOpts{}).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	var list []struct {
+		ID string `json:"id"`
+		availabilityzones.ServerAvailabilityZoneExt
+	}
+	if err := servers.ExtractServersInto(allPages, &list); err != nil {
+		return nil, err
+	}
+	zones := make(map[string]string, len(list))
+	for _, s := range list {
+		zones[s.ID] = s.AvailabilityZone
+	}
+	return zones, nil
+}
+
 // ListFlavors returns the list of available flavors (instance types).
 func (c *computeClient) ListFlavors() ([]flavors.Flavor, error) {
 	allPages, err := flavors.ListDetail(c.client, nil).AllPages()
@@ -172,7 +918,14 @@ func (c *computeClient) ListServerInterfaces(ctx context.Context, serverID strin
 }
 
 func (c *computeClient) ListServerVolumes(ctx context.Context, serverID string) ([]ServerVolume, error) {
-	allPages, err := volumeattach.List(c.client, serverID).AllPages()
+	// delete_on_termination is only reported from 2.79; older clouds
+	// reject the microversion, so retry without it.
+	sc := *c.client
+	sc.Microversion = "2.79"
+	allPages, err := volumeattach.List(&sc, serverID).AllPages()
+	if err != nil {
+		allPages, err = volumeattach.List(c.client, serverID).AllPages()
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -183,14 +936,30 @@ func (c *computeClient) ListServerVolumes(ctx context.Context, serverID string)
 	var result []ServerVolume
 	for _, v := range vols {
 		result = append(result, ServerVolume{
-			ID:       v.ID,
-			VolumeID: v.VolumeID,
-			Device:   v.Device,
+			ID:                  v.ID,
+			VolumeID:            v.VolumeID,
+			Device:              v.Device,
+			DeleteOnTermination: v.DeleteOnTermination,
 		})
 	}
 	return result, nil
 }
 
+// AttachVolume attaches a volume to a server on the next free device. Nova
+// returns before the volume is in-use.
+func (c *computeClient) AttachVolume(ctx context.Context, serverID, volumeID string) error {
+	_ = ctx // ctx currently unused
+	_, err := volumeattach.Create(c.client, serverID, volumeattach.CreateOpts{VolumeID: volumeID}).Extract()
+	return err
+}
+
+// DetachVolume detaches a volume from a server. Nova returns before the
+// volume is available again.
+func (c *computeClient) DetachVolume(ctx context.Context, serverID, volumeID string) error {
+	_ = ctx // ctx currently unused
+	return volumeattach.Delete(c.client, serverID, volumeID).ExtractErr()
+}
+
 // GetConsoleLog fetches the console output for the given server ID.
 // It uses the OpenStack Nova API via gophercloud's ShowConsoleOutput call.
 // The `lines` argument maps to the `Length` field of the request options –
@@ -250,3 +1019,16 @@ func (c *computeClient) ListAvailabilityZones(ctx context.Context) ([]availabili
 	}
 	return availabilityzones.ExtractAvailabilityZones(allPages)
 }
+
+// GetQuotaSet returns the compute quota limits and current usage for a project.
+func (c *computeClient) GetQuotaSet(ctx context.Context, projectID string) (quotasets.QuotaDetailSet, error) {
+	_ = ctx // ctx currently unused
+	return quotasets.GetDetail(c.client, projectID).Extract()
+}
+
+// UpdateQuotaSet applies new compute quota limits to a project (admin only).
+func (c *computeClient) UpdateQuotaSet(ctx context.Context, projectID string, opts quotasets.UpdateOpts) error {
+	_ = ctx // ctx currently unused
+	_, err := quotasets.Update(c.client, projectID, opts).Extract()
+	return err
+}
@@ -0,0 +1,194 @@
+package client
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack"
+	"github.com/gophercloud/gophercloud/openstack/containerinfra/v1/certificates"
+	"github.com/gophercloud/gophercloud/openstack/containerinfra/v1/clusters"
+	"github.com/gophercloud/gophercloud/openstack/containerinfra/v1/clustertemplates"
+	"gopkg.in/yaml.v2"
+)
+
+// containerInfraMicroversion is the Magnum API microversion requested by the
+// client; cluster resize needs 1.7.
+const containerInfraMicroversion = "1.7"
+
+// Type aliases for the Container Infra (Magnum) resources.
+type Cluster = clusters.Cluster
+type ClusterTemplate = clustertemplates.ClusterTemplate
+
+// ContainerInfraClient defines the methods for interacting with the
+// OpenStack Container Infra (Magnum) service.
+type ContainerInfraClient interface {
+	// ListClusters returns the clusters visible to the project.
+	ListClusters(ctx context.Context) ([]Cluster, error)
+	// GetCluster returns one cluster.
+	GetCluster(ctx context.Context, id string) (*Cluster, error)
+	// GetClusterTemplate returns one cluster template.
+	GetClusterTemplate(ctx context.Context, id string) (*ClusterTemplate, error)
+	// ResizeCluster changes the worker node count of a cluster.
+	ResizeCluster(ctx context.Context, id string, nodeCount int) error
+	// GetClusterCA returns the PEM CA certificate of a cluster.
+	GetClusterCA(ctx context.Context, clusterID string) (string, error)
+	// SignClusterCSR has the cluster CA sign a PEM certificate request and
+	// returns the PEM certificate.
+	SignClusterCSR(ctx context.Context, clusterID, csr string) (string, error)
+}
+
+type containerInfraClient struct {
+	client *gophercloud.ServiceClient
+}
+
+// newContainerInfraClient creates a ContainerInfraClient from an already authenticated provider.
+func newContainerInfraClient(provider *gophercloud.ProviderClient) (ContainerInfraClient, error) {
+	client, err := openstack.NewContainerInfraV1(provider, gophercloud.EndpointOpts{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create container infra client: %w", err)
+	}
+	client.Microversion = containerInfraMicroversion
+	return &containerInfraClient{client: client}, nil
+}
+
+// ListClusters returns the clusters visible to the project.
+func (c *containerInfraClient) ListClusters(ctx context.Context) ([]Cluster, error) {
+	_ = ctx // ctx currently unused
+	allPages, err := clusters.ListDetail(c.client, clusters.ListOpts{}).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	return clusters.ExtractClusters(allPages)
+}
+
+// GetCluster returns one cluster.
+func (c *containerInfraClient) GetCluster(ctx context.Context, id string) (*Cluster, error) {
+	_ = ctx // ctx currently unused
+	return clusters.Get(c.client, id).Extract()
+}
+
+// GetClusterTemplate returns one cluster template.
+func (c *containerInfraClient) GetClusterTemplate(ctx context.Context, id string) (*ClusterTemplate, error) {
+	_ = ctx // ctx currently unused
+	return clustertemplates.Get(c.client, id).Extract()
+}
+
+// ResizeCluster changes the worker node count of a cluster.
+func (c *containerInfraClient) ResizeCluster(ctx context.Context, id string, nodeCount int) error {
+	_ = ctx // ctx currently unused
+	_, err := clusters.Resize(c.client, id, clusters.ResizeOpts{NodeCount: &nodeCount}).Extract()
+	return err
+}
+
+// GetClusterCA returns the PEM CA certificate of a cluster.
+func (c *containerInfraClient) GetClusterCA(ctx context.Context, clusterID string) (string, error) {
+	_ = ctx // ctx currently unused
+	cert, err := certificates.Get(c.client, clusterID).Extract()
+	if err != nil {
+		return "", err
+	}
+	return cert.PEM, nil
+}
+
+// SignClusterCSR has the cluster CA sign a certificate request.
+func (c *containerInfraClient) SignClusterCSR(ctx context.Context, clusterID, csr string) (string, error) {
+	_ = ctx // ctx currently unused
+	cert, err := certificates.Create(c.client, certificates.CreateOpts{ClusterUUID: clusterID, CSR: csr}).Extract()
+	if err != nil {
+		return "", err
+	}
+	return cert.PEM, nil
+}
+
+// Ensure containerInfraClient implements the ContainerInfraClient interface.
+var _ ContainerInfraClient = (*containerInfraClient)(nil)
+
+// kubeconfig is the subset of the kubectl configuration file written by
+// Kubeconfig.
+type kubeconfig struct {
+	APIVersion     string              `yaml:"apiVersion"`
+	Kind           string              `yaml:"kind"`
+	Clusters       []kubeconfigCluster `yaml:"clusters"`
+	Users          []kubeconfigUser    `yaml:"users"`
+	Contexts       []kubeconfigContext `yaml:"contexts"`
+	CurrentContext string              `yaml:"current-context"`
+}
+
+type kubeconfigCluster struct {
+	Name    string `yaml:"name"`
+	Cluster struct {
+		Server                   string `yaml:"server"`
+		CertificateAuthorityData string `yaml:"certificate-authority-data,omitempty"`
+	} `yaml:"cluster"`
+}
+
+type kubeconfigUser struct {
+	Name string `yaml:"name"`
+	User struct {
+		ClientCertificateData string `yaml:"client-certificate-data,omitempty"`
+		ClientKeyData         string `yaml:"client-key-data,omitempty"`
+	} `yaml:"user"`
+}
+
+type kubeconfigContext struct {
+	Name    string `yaml:"name"`
+	Context struct {
+		Cluster string `yaml:"cluster"`
+		User    string `yaml:"user"`
+	} `yaml:"context"`
+}
+
+// Kubeconfig builds a kubectl configuration for a Kubernetes cluster, the
+// way "openstack coe cluster config" does: a new key is generated locally
+// and its certificate request, for the admin user in the system:masters
+// group, is signed by the cluster CA. Only the signed certificate leaves
+// Magnum; the key never does. Clusters with TLS disabled get a plain
+// configuration without credentials.
+func Kubeconfig(ctx context.Context, c ContainerInfraClient, cluster Cluster, tlsDisabled bool) ([]byte, error) {
+	if cluster.APIAddress == "" {
+		return nil, fmt.Errorf("cluster %s has no API address yet (status %s)", cluster.Name, cluster.Status)
+	}
+	doc := kubeconfig{APIVersion: "v1", Kind: "Config", CurrentContext: cluster.Name}
+	kc := kubeconfigCluster{Name: cluster.Name}
+	kc.Cluster.Server = cluster.APIAddress
+	user := kubeconfigUser{Name: "admin"}
+	if !tlsDisabled {
+		ca, err := c.GetClusterCA(ctx, cluster.UUID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the cluster CA: %w", err)
+		}
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			return nil, err
+		}
+		der, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{Subject: pkix.Name{CommonName: "admin", Organization: []string{"system:masters"}}}, key)
+		if err != nil {
+			return nil, err
+		}
+		cert, err := c.SignClusterCSR(ctx, cluster.UUID, string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der})))
+		if err != nil {
+			return nil, fmt.Errorf("failed to sign the client certificate: %w", err)
+		}
+		keyDER, err := x509.MarshalECPrivateKey(key)
+		if err != nil {
+			return nil, err
+		}
+		kc.Cluster.CertificateAuthorityData = base64.StdEncoding.EncodeToString([]byte(ca))
+		user.User.ClientCertificateData = base64.StdEncoding.EncodeToString([]byte(cert))
+		user.User.ClientKeyData = base64.StdEncoding.EncodeToString(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+	}
+	ctxEntry := kubeconfigContext{Name: cluster.Name}
+	ctxEntry.Context.Cluster, ctxEntry.Context.User = cluster.Name, user.Name
+	doc.Clusters = []kubeconfigCluster{kc}
+	doc.Users = []kubeconfigUser{user}
+	doc.Contexts = []kubeconfigContext{ctxEntry}
+	return yaml.Marshal(doc)
+}
@@ -0,0 +1,46 @@
+package client
+
+import (
+	"context"
+
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/portsbinding"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/ports"
+)
+
+// AgentTypeDHCP is the agent type of DHCP agents.
+const AgentTypeDHCP = "DHCP agent"
+
+// DeviceOwnerDHCP is the device owner of the ports dnsmasq listens on.
+const DeviceOwnerDHCP = "network:dhcp"
+
+// DHCPPort is a port a DHCP agent serves a network from, with the host it
+// is bound to.
+type DHCPPort struct {
+	ports.Port
+	portsbinding.PortsBindingExt
+}
+
+// ListNetworkDHCPAgents returns the DHCP agents serving a network. Requires
+// admin rights. gophercloud v1 only lists the other direction, so the
+// dhcp-agents sub-resource is read directly.
+func (c *networkClient) ListNetworkDHCPAgents(ctx context.Context, networkID string) ([]NetworkAgent, error) {
+	_ = ctx // ctx currently unused
+	var body struct {
+		Agents []NetworkAgent `json:"agents"`
+	}
+	_, err := c.client.Get(c.client.ServiceURL("networks", networkID, "dhcp-agents"), &body, nil)
+	return body.Agents, err
+}
+
+// ListDHCPPorts returns the DHCP ports of a network with their binding
+// host; the host is only visible to admins.
+func (c *networkClient) ListDHCPPorts(ctx context.Context, networkID string) ([]DHCPPort, error) {
+	_ = ctx // ctx currently unused
+	allPages, err := ports.List(c.client, ports.ListOpts{NetworkID: networkID, DeviceOwner: DeviceOwnerDHCP}).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	var out []DHCPPort
+	err = ports.ExtractPortsInto(allPages, &out)
+	return out, err
+}
@@ -3,11 +3,15 @@ package client
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/gophercloud/gophercloud/v2"
 	"github.com/gophercloud/gophercloud/v2/openstack"
 	dnsRecordsets "github.com/gophercloud/gophercloud/v2/openstack/dns/v2/recordsets"
+	transferAccept "github.com/gophercloud/gophercloud/v2/openstack/dns/v2/transfer/accept"
+	transferRequest "github.com/gophercloud/gophercloud/v2/openstack/dns/v2/transfer/request"
 	dnsZones "github.com/gophercloud/gophercloud/v2/openstack/dns/v2/zones"
+	"github.com/gophercloud/gophercloud/v2/pagination"
 )
 
 // Zone represents a DNS zone in a simplified form used by the application.
@@ -30,12 +34,74 @@ type RecordSet struct {
 	Records []string
 }
 
+// RecordSetQuery selects one page of the record sets of a zone. Name, Type
+// and Data are Designate filters: Type is exact, Name is exact unless it
+// holds "*" wildcards, and Data matches one of the records exactly, e.g.
+// an address.
+type RecordSetQuery struct {
+	Name   string
+	Type   string
+	Data   string
+	Marker string
+	Limit  int
+}
+
+// RecordSetPage is one page of record sets, with the number of record sets
+// matching the query. NextMarker is empty on the last page.
+type RecordSetPage struct {
+	RecordSets []RecordSet
+	Total      int
+	NextMarker string
+}
+
+// ZoneTransfer is a request to move a zone to another project. Designate
+// lists the requests of the project's zones and those offered to it; the
+// key is only shown to the project that owns the zone.
+type ZoneTransfer struct {
+	ID       string
+	ZoneID   string
+	ZoneName string
+	// TargetProjectID restricts who may accept; empty lets any project
+	// holding the ID and key accept.
+	TargetProjectID string
+	Key             string
+	Description     string
+	Status          string
+	CreatedAt       time.Time
+}
+
+// ZoneTransferAccept is the result of accepting a zone transfer.
+type ZoneTransferAccept struct {
+	ID     string
+	ZoneID string
+	Status string
+}
+
 // DNSClient defines the methods for interacting with the OpenStack Designate (DNS) service.
 type DNSClient interface {
 	// ListZones returns all DNS zones visible to the authenticated project.
 	ListZones(ctx context.Context) ([]Zone, error)
 	// ListRecordSets returns all record sets for a given zone ID.
 	ListRecordSets(ctx context.Context, zoneID string) ([]RecordSet, error)
+	// ListRecordSetPage returns one page of the record sets of a zone,
+	// filtered on the server, for zones too large to list at once.
+	ListRecordSetPage(ctx context.Context, zoneID string, q RecordSetQuery) (RecordSetPage, error)
+	// CreateRecordSet creates a record set in a zone from the name, type,
+	// TTL and records of rs; a zero TTL takes the zone's.
+	CreateRecordSet(ctx context.Context, zoneID string, rs RecordSet) (*RecordSet, error)
+	// UpdateRecordSet replaces the records of a record set, and its TTL
+	// when ttl is not zero.
+	UpdateRecordSet(ctx context.Context, zoneID, id string, records []string, ttl int) (*RecordSet, error)
+	// ListZoneTransfers returns the transfer requests of the project's
+	// zones and those offered to the project.
+	ListZoneTransfers(ctx context.Context) ([]ZoneTransfer, error)
+	// CreateZoneTransfer offers a zone to targetProjectID, or to any project
+	// given the key when it is empty.
+	CreateZoneTransfer(ctx context.Context, zoneID, targetProjectID, description string) (*ZoneTransfer, error)
+	// DeleteZoneTransfer cancels a transfer request.
+	DeleteZoneTransfer(ctx context.Context, id string) error
+	// AcceptZoneTransfer takes over the zone of a transfer request.
+	AcceptZoneTransfer(ctx context.Context, id, key string) (*ZoneTransferAccept, error)
 }
 
 // DNSClientImpl is the concrete implementation of DNSClient using gophercloud.
@@ -90,17 +156,122 @@ func (c *DNSClientImpl) ListRecordSets(ctx context.Context, zoneID string) ([]Re
 	}
 	recsets := make([]RecordSet, len(gopherRS))
 	for i, rs := range gopherRS {
-		recsets[i] = RecordSet{
-			ID:      rs.ID,
-			Name:    rs.Name,
-			Type:    rs.Type,
-			TTL:     rs.TTL,
-			Status:  rs.Status,
-			Records: rs.Records,
-		}
+		recsets[i] = recordSetFrom(rs)
 	}
 	return recsets, nil
 }
 
+// recordSetFrom converts a gophercloud record set.
+func recordSetFrom(rs dnsRecordsets.RecordSet) RecordSet {
+	return RecordSet{
+		ID:      rs.ID,
+		Name:    rs.Name,
+		Type:    rs.Type,
+		TTL:     rs.TTL,
+		Status:  rs.Status,
+		Records: rs.Records,
+	}
+}
+
+// ListRecordSetPage fetches the first page of the query only; the total
+// comes from the collection metadata of Designate.
+func (c *DNSClientImpl) ListRecordSetPage(ctx context.Context, zoneID string, q RecordSetQuery) (RecordSetPage, error) {
+	opts := dnsRecordsets.ListOpts{Name: q.Name, Type: q.Type, Data: q.Data, Marker: q.Marker, Limit: q.Limit}
+	var out RecordSetPage
+	err := dnsRecordsets.ListByZone(c.client, zoneID, opts).EachPage(ctx, func(_ context.Context, page pagination.Page) (bool, error) {
+		gopherRS, err := dnsRecordsets.ExtractRecordSets(page)
+		if err != nil {
+			return false, err
+		}
+		for _, rs := range gopherRS {
+			out.RecordSets = append(out.RecordSets, recordSetFrom(rs))
+		}
+		var meta struct {
+			Metadata struct {
+				TotalCount int `json:"total_count"`
+			} `json:"metadata"`
+		}
+		if err := page.(dnsRecordsets.RecordSetPage).ExtractInto(&meta); err != nil {
+			return false, err
+		}
+		out.Total = meta.Metadata.TotalCount
+		if next, _ := page.NextPageURL(); next != "" && len(gopherRS) > 0 {
+			out.NextMarker = gopherRS[len(gopherRS)-1].ID
+		}
+		return false, nil
+	})
+	return out, err
+}
+
+// CreateRecordSet creates a record set in a zone.
+func (c *DNSClientImpl) CreateRecordSet(ctx context.Context, zoneID string, rs RecordSet) (*RecordSet, error) {
+	created, err := dnsRecordsets.Create(ctx, c.client, zoneID, dnsRecordsets.CreateOpts{Name: rs.Name, Type: rs.Type, TTL: rs.TTL, Records: rs.Records}).Extract()
+	if err != nil {
+		return nil, err
+	}
+	out := recordSetFrom(*created)
+	return &out, nil
+}
+
+// UpdateRecordSet replaces the records of a record set.
+func (c *DNSClientImpl) UpdateRecordSet(ctx context.Context, zoneID, id string, records []string, ttl int) (*RecordSet, error) {
+	opts := dnsRecordsets.UpdateOpts{Records: records}
+	if ttl > 0 {
+		opts.TTL = &ttl
+	}
+	updated, err := dnsRecordsets.Update(ctx, c.client, zoneID, id, opts).Extract()
+	if err != nil {
+		return nil, err
+	}
+	out := recordSetFrom(*updated)
+	return &out, nil
+}
+
+// zoneTransferFrom converts a gophercloud transfer request.
+func zoneTransferFrom(tr transferRequest.TransferRequest) ZoneTransfer {
+	return ZoneTransfer{ID: tr.ID, ZoneID: tr.ZoneID, ZoneName: tr.ZoneName, TargetProjectID: tr.TargetProjectID, Key: tr.Key, Description: tr.Description, Status: tr.Status, CreatedAt: tr.CreatedAt}
+}
+
+// ListZoneTransfers returns the zone transfer requests visible to the project.
+func (c *DNSClientImpl) ListZoneTransfers(ctx context.Context) ([]ZoneTransfer, error) {
+	allPages, err := transferRequest.List(c.client, nil).AllPages(ctx)
+	if err != nil {
+		return nil, err
+	}
+	gopherTR, err := transferRequest.ExtractTransferRequests(allPages)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]ZoneTransfer, len(gopherTR))
+	for i, tr := range gopherTR {
+		out[i] = zoneTransferFrom(tr)
+	}
+	return out, nil
+}
+
+// CreateZoneTransfer creates a transfer request for a zone.
+func (c *DNSClientImpl) CreateZoneTransfer(ctx context.Context, zoneID, targetProjectID, description string) (*ZoneTransfer, error) {
+	tr, err := transferRequest.Create(ctx, c.client, zoneID, transferRequest.CreateOpts{TargetProjectID: targetProjectID, Description: description}).Extract()
+	if err != nil {
+		return nil, err
+	}
+	zt := zoneTransferFrom(*tr)
+	return &zt, nil
+}
+
+// DeleteZoneTransfer deletes a transfer request.
+func (c *DNSClientImpl) DeleteZoneTransfer(ctx context.Context, id string) error {
+	return transferRequest.Delete(ctx, c.client, id).ExtractErr()
+}
+
+// AcceptZoneTransfer accepts a transfer request with its key.
+func (c *DNSClientImpl) AcceptZoneTransfer(ctx context.Context, id, key string) (*ZoneTransferAccept, error) {
+	ta, err := transferAccept.Create(ctx, c.client, transferAccept.CreateOpts{ZoneTransferRequestID: id, Key: key}).Extract()
+	if err != nil {
+		return nil, err
+	}
+	return &ZoneTransferAccept{ID: ta.ID, ZoneID: ta.ZoneID, Status: ta.Status}, nil
+}
+
 // Ensure DNSClientImpl implements DNSClient.
 var _ DNSClient = (*DNSClientImpl)(nil)
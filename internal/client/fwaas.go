@@ -0,0 +1,89 @@
+package client
+
+import (
+	"context"
+
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/fwaas_v2/groups"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/fwaas_v2/policies"
+	fwrules "github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/fwaas_v2/rules"
+)
+
+// Type aliases for the FWaaS v2 resources.
+type FirewallGroup = groups.Group
+type FirewallPolicy = policies.Policy
+type FirewallRule = fwrules.Rule
+type FirewallRuleInput = fwrules.CreateOpts
+
+// ListFirewallGroups returns the firewall groups visible to the project.
+func (c *networkClient) ListFirewallGroups(ctx context.Context) ([]FirewallGroup, error) {
+	_ = ctx // ctx currently unused
+	allPages, err := groups.List(c.client, groups.ListOpts{}).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	return groups.ExtractGroups(allPages)
+}
+
+// ListFirewallPolicies returns the firewall policies visible to the project;
+// each lists its rule IDs in evaluation order.
+func (c *networkClient) ListFirewallPolicies(ctx context.Context) ([]FirewallPolicy, error) {
+	_ = ctx // ctx currently unused
+	allPages, err := policies.List(c.client, policies.ListOpts{}).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	return policies.ExtractPolicies(allPages)
+}
+
+// ListFirewallRules returns the firewall rules visible to the project.
+func (c *networkClient) ListFirewallRules(ctx context.Context) ([]FirewallRule, error) {
+	_ = ctx // ctx currently unused
+	allPages, err := fwrules.List(c.client, fwrules.ListOpts{}).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	return fwrules.ExtractRules(allPages)
+}
+
+// CreateFirewallRule creates a rule and, when policyID is set, appends it to
+// that policy.
+func (c *networkClient) CreateFirewallRule(ctx context.Context, rule FirewallRuleInput, policyID string) (*FirewallRule, error) {
+	_ = ctx // ctx currently unused
+	r, err := fwrules.Create(c.client, rule).Extract()
+	if err != nil {
+		return nil, err
+	}
+	if policyID == "" {
+		return r, nil
+	}
+	p, err := policies.Get(c.client, policyID).Extract()
+	if err != nil {
+		return r, err
+	}
+	// Without a position neutron inserts at the top; append instead.
+	opts := policies.InsertRuleOpts{ID: r.ID}
+	if n := len(p.Rules); n > 0 {
+		opts.InsertAfter = p.Rules[n-1]
+	}
+	if _, err := policies.InsertRule(c.client, policyID, opts).Extract(); err != nil {
+		return r, err
+	}
+	r.FirewallPolicyID = append(r.FirewallPolicyID, policyID)
+	return r, nil
+}
+
+// DeleteFirewallRule removes a rule from the policies using it, which
+// neutron requires, and deletes it.
+func (c *networkClient) DeleteFirewallRule(ctx context.Context, id string) error {
+	_ = ctx // ctx currently unused
+	r, err := fwrules.Get(c.client, id).Extract()
+	if err != nil {
+		return err
+	}
+	for _, policyID := range r.FirewallPolicyID {
+		if _, err := policies.RemoveRule(c.client, policyID, id).Extract(); err != nil {
+			return err
+		}
+	}
+	return fwrules.Delete(c.client, id).ExtractErr()
+}
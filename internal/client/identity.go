@@ -4,6 +4,9 @@ import (
 	"fmt"
 	"github.com/gophercloud/gophercloud"
 	"github.com/gophercloud/gophercloud/openstack"
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/domains"
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/extensions/ec2credentials"
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/extensions/trusts"
 	"github.com/gophercloud/gophercloud/openstack/identity/v3/projects"
 	"github.com/gophercloud/gophercloud/openstack/identity/v3/tokens"
 	"github.com/gophercloud/gophercloud/openstack/identity/v3/users"
@@ -15,18 +18,45 @@ type IdentityClient interface {
 	GetCurrentProject() (projects.Project, error)
 	ListUsers() ([]users.User, error)
 	GetTokenInfo() (*tokens.Token, error)
+	GetTokenRoles() ([]string, error)
+	// Domains
+	ListDomains() ([]domains.Domain, error)
+	ListProjectsInDomain(domainID string) ([]projects.Project, error)
+	ListUsersInDomain(domainID string) ([]users.User, error)
+	// Trusts
+	GetTokenUserID() (string, error)
+	ListTrusts(opts trusts.ListOpts) ([]Trust, error)
+	CreateTrust(opts trusts.CreateOpts) (Trust, error)
+	DeleteTrust(id string) error
+	// EC2 credentials
+	ListEC2Credentials(userID string) ([]EC2Credential, error)
+	CreateEC2Credential(userID, projectID string) (EC2Credential, error)
+	DeleteEC2Credential(userID, access string) error
 }
 
+// Trust delegates roles on a project from the trustor to the trustee, as
+// Heat and other services do for deferred operations.
+type Trust = trusts.Trust
+
+// EC2Credential is an access/secret key pair scoped to a project, for the
+// S3 and EC2 compatibility layers (swift s3api, radosgw, ec2-api).
+type EC2Credential = ec2credentials.Credential
+
 type identityClient struct {
 	client *gophercloud.ServiceClient
 }
 
 // NewIdentityClient creates a new IdentityClient given authentication options.
 func NewIdentityClient(authOpts gophercloud.AuthOptions) (IdentityClient, error) {
-	provider, err := openstack.AuthenticatedClient(authOpts)
+	provider, err := authenticatedClient(authOpts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to authenticate with OpenStack: %w", err)
 	}
+	return newIdentityClient(provider)
+}
+
+// newIdentityClient creates a IdentityClient from an already authenticated provider.
+func newIdentityClient(provider *gophercloud.ProviderClient) (IdentityClient, error) {
 	client, err := openstack.NewIdentityV3(provider, gophercloud.EndpointOpts{})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create identity client: %w", err)
@@ -75,6 +105,95 @@ func (c *identityClient) ListUsers() ([]users.User, error) {
 	return users.ExtractUsers(allPages)
 }
 
+// ListDomains returns the identity domains. Listing them needs the admin
+// role, or a domain-scoped token which only sees its own domain.
+func (c *identityClient) ListDomains() ([]domains.Domain, error) {
+	allPages, err := domains.List(c.client, nil).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	return domains.ExtractDomains(allPages)
+}
+
+// ListProjectsInDomain returns the projects of one domain.
+func (c *identityClient) ListProjectsInDomain(domainID string) ([]projects.Project, error) {
+	allPages, err := projects.List(c.client, projects.ListOpts{DomainID: domainID}).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	return projects.ExtractProjects(allPages)
+}
+
+// ListUsersInDomain returns the users of one domain. Domains backed by a
+// domain-specific driver such as LDAP only list their users this way.
+func (c *identityClient) ListUsersInDomain(domainID string) ([]users.User, error) {
+	allPages, err := users.List(c.client, users.ListOpts{DomainID: domainID}).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	return users.ExtractUsers(allPages)
+}
+
+// GetTokenUserID returns the ID of the user the current token belongs to.
+func (c *identityClient) GetTokenUserID() (string, error) {
+	tokenID := c.client.ProviderClient.TokenID
+	if tokenID == "" {
+		return "", fmt.Errorf("no token ID available")
+	}
+	u, err := tokens.Get(c.client, tokenID).ExtractUser()
+	if err != nil {
+		return "", err
+	}
+	return u.ID, nil
+}
+
+// ListTrusts returns the trusts matching opts. Without the admin role
+// Keystone requires filtering on the token's user as trustor or trustee.
+func (c *identityClient) ListTrusts(opts trusts.ListOpts) ([]Trust, error) {
+	allPages, err := trusts.List(c.client, opts).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	return trusts.ExtractTrusts(allPages)
+}
+
+// CreateTrust creates a trust; the trustor must be the token's user.
+func (c *identityClient) CreateTrust(opts trusts.CreateOpts) (Trust, error) {
+	t, err := trusts.Create(c.client, opts).Extract()
+	if err != nil {
+		return Trust{}, err
+	}
+	return *t, nil
+}
+
+// DeleteTrust revokes a trust.
+func (c *identityClient) DeleteTrust(id string) error {
+	return trusts.Delete(c.client, id).ExtractErr()
+}
+
+// ListEC2Credentials returns the EC2 credentials of a user.
+func (c *identityClient) ListEC2Credentials(userID string) ([]EC2Credential, error) {
+	allPages, err := ec2credentials.List(c.client, userID).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	return ec2credentials.ExtractCredentials(allPages)
+}
+
+// CreateEC2Credential creates an access/secret pair for a user on a project.
+func (c *identityClient) CreateEC2Credential(userID, projectID string) (EC2Credential, error) {
+	cred, err := ec2credentials.Create(c.client, userID, ec2credentials.CreateOpts{TenantID: projectID}).Extract()
+	if err != nil {
+		return EC2Credential{}, err
+	}
+	return *cred, nil
+}
+
+// DeleteEC2Credential deletes the credential with the given access key.
+func (c *identityClient) DeleteEC2Credential(userID, access string) error {
+	return ec2credentials.Delete(c.client, userID, access).ExtractErr()
+}
+
 // GetTokenInfo retrieves information about the current token.
 func (c *identityClient) GetTokenInfo() (*tokens.Token, error) {
 	tokenID := c.client.ProviderClient.TokenID
@@ -85,5 +204,23 @@ func (c *identityClient) GetTokenInfo() (*tokens.Token, error) {
 	return result.ExtractToken()
 }
 
+// GetTokenRoles returns the names of the roles in the current token,
+// including the roles they imply.
+func (c *identityClient) GetTokenRoles() ([]string, error) {
+	tokenID := c.client.ProviderClient.TokenID
+	if tokenID == "" {
+		return nil, fmt.Errorf("no token ID available")
+	}
+	roles, err := tokens.Get(c.client, tokenID).ExtractRoles()
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(roles))
+	for _, r := range roles {
+		names = append(names, r.Name)
+	}
+	return names, nil
+}
+
 // Ensure identityClient implements IdentityClient.
 var _ IdentityClient = (*identityClient)(nil)
@@ -2,10 +2,22 @@ package client
 
 import (
 	"context"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
 	"fmt"
+	"hash"
+	"io"
+	"os"
+	"strings"
+
 	"github.com/gophercloud/gophercloud"
 	"github.com/gophercloud/gophercloud/openstack"
 	"github.com/gophercloud/gophercloud/openstack/compute/v2/images"
+	"github.com/gophercloud/gophercloud/openstack/imageservice/v2/imagedata"
+	glanceimages "github.com/gophercloud/gophercloud/openstack/imageservice/v2/images"
 )
 
 // ImageClient defines methods for interacting with OpenStack Image (Glance) service via Compute API.
@@ -13,23 +25,69 @@ type ImageClient interface {
 	ListImages(ctx context.Context) ([]images.Image, error)
 	GetImage(ctx context.Context, id string) (*images.Image, error)
 	DeleteImage(ctx context.Context, id string) error
+	// DownloadImage streams the image data to path and verifies it against the
+	// checksums reported by Glance. progress, if non-nil, is called as data
+	// arrives with the bytes written so far and the expected total (0 if unknown).
+	DownloadImage(ctx context.Context, id, path string, progress func(done, total int64)) (*ImageDownload, error)
+	// ListImageDetails returns the images from the Image service (v2), which
+	// reports the size, visibility, owner and protection the compute API omits.
+	ListImageDetails(ctx context.Context) ([]glanceimages.Image, error)
+}
+
+// ImageChecksum is one hash reported by Glance and the value computed locally.
+type ImageChecksum struct {
+	Algorithm string
+	Expected  string
+	Actual    string
+}
+
+// OK reports whether the computed hash matches the expected one.
+func (c ImageChecksum) OK() bool { return strings.EqualFold(c.Expected, c.Actual) }
+
+// ImageDownload describes a finished image download.
+type ImageDownload struct {
+	Path  string
+	Bytes int64
+	// Checksums is empty when Glance reported no hash for the image.
+	Checksums []ImageChecksum
+}
+
+// ChecksumMismatchError is returned by DownloadImage when the downloaded data
+// does not match a hash reported by Glance. The partial file is removed.
+type ChecksumMismatchError struct {
+	ImageID  string
+	Checksum ImageChecksum
+}
+
+func (e *ChecksumMismatchError) Error() string {
+	return fmt.Sprintf("checksum mismatch for image %s: %s expected %s, got %s", e.ImageID, e.Checksum.Algorithm, e.Checksum.Expected, e.Checksum.Actual)
 }
 
 type imageClient struct {
 	client *gophercloud.ServiceClient
+	// glance is the Image service (v2) client used for downloads; it is nil
+	// when the catalog has no image endpoint, with the reason in glanceErr.
+	glance    *gophercloud.ServiceClient
+	glanceErr error
 }
 
 // NewImageClient creates a new ImageClient given authentication options.
 func NewImageClient(authOpts gophercloud.AuthOptions) (ImageClient, error) {
-	provider, err := openstack.AuthenticatedClient(authOpts)
+	provider, err := authenticatedClient(authOpts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to authenticate with OpenStack: %w", err)
 	}
+	return newImageClient(provider)
+}
+
+// newImageClient creates a ImageClient from an already authenticated provider.
+func newImageClient(provider *gophercloud.ProviderClient) (ImageClient, error) {
 	client, err := openstack.NewComputeV2(provider, gophercloud.EndpointOpts{})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create compute client for images: %w", err)
 	}
-	return &imageClient{client: client}, nil
+	glance, glanceErr := openstack.NewImageServiceV2(provider, gophercloud.EndpointOpts{})
+	return &imageClient{client: client, glance: glance, glanceErr: glanceErr}, nil
 }
 
 // ListImages returns all images visible to the authenticated project.
@@ -54,11 +112,141 @@ func (c *imageClient) GetImage(ctx context.Context, id string) (*images.Image, e
 	return img, nil
 }
 
+// ListImageDetails returns every image visible to the project from Glance.
+func (c *imageClient) ListImageDetails(ctx context.Context) ([]glanceimages.Image, error) {
+	_ = ctx
+	if c.glance == nil {
+		return nil, fmt.Errorf("image service unavailable: %w", c.glanceErr)
+	}
+	// Without a visibility filter Glance leaves out community images.
+	pages, err := glanceimages.List(c.glance, glanceimages.ListOpts{Visibility: "all"}).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	return glanceimages.ExtractImages(pages)
+}
+
 // DeleteImage removes the specified image.
 func (c *imageClient) DeleteImage(ctx context.Context, id string) error {
 	_ = ctx
 	return images.Delete(c.client, id).ExtractErr()
 }
 
+// DownloadImage streams the image data to path and verifies its checksums.
+func (c *imageClient) DownloadImage(ctx context.Context, id, path string, progress func(done, total int64)) (*ImageDownload, error) {
+	if c.glance == nil {
+		return nil, fmt.Errorf("image service unavailable: %w", c.glanceErr)
+	}
+	img, err := glanceimages.Get(c.glance, id).Extract()
+	if err != nil {
+		return nil, err
+	}
+	body, err := imagedata.Download(c.glance, id).Extract()
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+	return saveImageData(id, ctxReader{ctx: ctx, r: body}, path, img.SizeBytes, expectedChecksums(img.Checksum, img.Properties), progress)
+}
+
+// expectedChecksums returns the hashes Glance reports for an image: the
+// legacy MD5 checksum and the multihash (os_hash_algo/os_hash_value).
+func expectedChecksums(md5sum string, props map[string]interface{}) []ImageChecksum {
+	var out []ImageChecksum
+	if md5sum != "" {
+		out = append(out, ImageChecksum{Algorithm: "md5", Expected: md5sum})
+	}
+	algo, _ := props["os_hash_algo"].(string)
+	value, _ := props["os_hash_value"].(string)
+	if algo != "" && value != "" && newHash(algo) != nil {
+		out = append(out, ImageChecksum{Algorithm: strings.ToLower(algo), Expected: value})
+	}
+	return out
+}
+
+// newHash returns a hash for a Glance hash algorithm name, or nil if unsupported.
+func newHash(algo string) hash.Hash {
+	switch strings.ToLower(algo) {
+	case "md5":
+		return md5.New()
+	case "sha1":
+		return sha1.New()
+	case "sha256":
+		return sha256.New()
+	case "sha384":
+		return sha512.New384()
+	case "sha512":
+		return sha512.New()
+	}
+	return nil
+}
+
+// progressWriter reports the running byte count to a callback.
+type progressWriter struct {
+	done, total int64
+	fn          func(done, total int64)
+}
+
+func (w *progressWriter) Write(p []byte) (int, error) {
+	w.done += int64(len(p))
+	if w.fn != nil {
+		w.fn(w.done, w.total)
+	}
+	return len(p), nil
+}
+
+// ctxReader stops a copy from r once ctx is done, e.g. when the view that
+// started a download is closed.
+type ctxReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (r ctxReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return r.r.Read(p)
+}
+
+// saveImageData copies r to path, hashing it on the way, and checks the
+// result against want. Data is written to path+".part" and only renamed into
+// place once every checksum matches.
+func saveImageData(id string, r io.Reader, path string, total int64, want []ImageChecksum, progress func(done, total int64)) (*ImageDownload, error) {
+	tmp := path + ".part"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return nil, err
+	}
+	hashes := make([]hash.Hash, len(want))
+	writers := []io.Writer{f, &progressWriter{total: total, fn: progress}}
+	for i, c := range want {
+		hashes[i] = newHash(c.Algorithm)
+		writers = append(writers, hashes[i])
+	}
+	n, err := io.Copy(io.MultiWriter(writers...), r)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return nil, fmt.Errorf("failed to download image %s: %w", id, err)
+	}
+	result := &ImageDownload{Path: path, Bytes: n}
+	for i, c := range want {
+		c.Actual = hex.EncodeToString(hashes[i].Sum(nil))
+		result.Checksums = append(result.Checksums, c)
+		if !c.OK() {
+			os.Remove(tmp)
+			return result, &ChecksumMismatchError{ImageID: id, Checksum: c}
+		}
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return nil, err
+	}
+	return result, nil
+}
+
 // Ensure imageClient implements ImageClient.
 var _ ImageClient = (*imageClient)(nil)
@@ -0,0 +1,96 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"path"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack"
+	"github.com/gophercloud/gophercloud/openstack/keymanager/v1/containers"
+	"github.com/gophercloud/gophercloud/openstack/keymanager/v1/secrets"
+)
+
+// Type aliases for the Key Manager (Barbican) resources.
+type Secret = secrets.Secret
+type SecretContainer = containers.Container
+type SecretCreateInput = secrets.CreateOpts
+
+// BarbicanID returns the ID at the end of a Barbican secret or container
+// reference URL.
+func BarbicanID(ref string) string { return path.Base(ref) }
+
+// KeyManagerClient defines the methods for interacting with the OpenStack
+// Key Manager (Barbican) service. Listing returns metadata only; payloads
+// are fetched one at a time with GetSecretPayload.
+type KeyManagerClient interface {
+	// ListSecrets returns the secret metadata visible to the project.
+	ListSecrets(ctx context.Context) ([]Secret, error)
+	// GetSecretPayload returns the decrypted payload of a secret.
+	GetSecretPayload(ctx context.Context, id string) (string, error)
+	// CreateSecret stores a secret.
+	CreateSecret(ctx context.Context, in SecretCreateInput) (*Secret, error)
+	// DeleteSecret deletes a secret.
+	DeleteSecret(ctx context.Context, id string) error
+	// ListSecretContainers returns the containers visible to the project,
+	// with their secret references and consumers.
+	ListSecretContainers(ctx context.Context) ([]SecretContainer, error)
+}
+
+type keyManagerClient struct {
+	client *gophercloud.ServiceClient
+}
+
+// newKeyManagerClient creates a KeyManagerClient from an already authenticated provider.
+func newKeyManagerClient(provider *gophercloud.ProviderClient) (KeyManagerClient, error) {
+	client, err := openstack.NewKeyManagerV1(provider, gophercloud.EndpointOpts{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create key manager client: %w", err)
+	}
+	return &keyManagerClient{client: client}, nil
+}
+
+// ListSecrets returns the secret metadata visible to the project.
+func (c *keyManagerClient) ListSecrets(ctx context.Context) ([]Secret, error) {
+	_ = ctx // ctx currently unused
+	allPages, err := secrets.List(c.client, secrets.ListOpts{}).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	return secrets.ExtractSecrets(allPages)
+}
+
+// GetSecretPayload returns the decrypted payload of a secret.
+func (c *keyManagerClient) GetSecretPayload(ctx context.Context, id string) (string, error) {
+	_ = ctx // ctx currently unused
+	payload, err := secrets.GetPayload(c.client, id, nil).Extract()
+	if err != nil {
+		return "", err
+	}
+	return string(payload), nil
+}
+
+// CreateSecret stores a secret.
+func (c *keyManagerClient) CreateSecret(ctx context.Context, in SecretCreateInput) (*Secret, error) {
+	_ = ctx // ctx currently unused
+	return secrets.Create(c.client, in).Extract()
+}
+
+// DeleteSecret deletes a secret.
+func (c *keyManagerClient) DeleteSecret(ctx context.Context, id string) error {
+	_ = ctx // ctx currently unused
+	return secrets.Delete(c.client, id).ExtractErr()
+}
+
+// ListSecretContainers returns the containers visible to the project.
+func (c *keyManagerClient) ListSecretContainers(ctx context.Context) ([]SecretContainer, error) {
+	_ = ctx // ctx currently unused
+	allPages, err := containers.List(c.client, containers.ListOpts{}).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	return containers.ExtractContainers(allPages)
+}
+
+// Ensure keyManagerClient implements the KeyManagerClient interface.
+var _ KeyManagerClient = (*keyManagerClient)(nil)
@@ -0,0 +1,38 @@
+package client
+
+import (
+	"context"
+
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/agents"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/layer3/routers"
+)
+
+// L3Agent is an L3 agent hosting a router, with the HA state of the
+// router's instance on it.
+type L3Agent = routers.L3Agent
+
+// AgentTypeL3 is the agent type of L3 agents.
+const AgentTypeL3 = "L3 agent"
+
+// ListRouterL3Agents returns the L3 agents hosting a router. Requires admin
+// rights.
+func (c *networkClient) ListRouterL3Agents(ctx context.Context, routerID string) ([]L3Agent, error) {
+	_ = ctx // ctx currently unused
+	allPages, err := routers.ListL3Agents(c.client, routerID).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	return routers.ExtractL3Agents(allPages)
+}
+
+// ScheduleRouter schedules a router on an L3 agent.
+func (c *networkClient) ScheduleRouter(ctx context.Context, agentID, routerID string) error {
+	_ = ctx // ctx currently unused
+	return agents.ScheduleL3Router(c.client, agentID, agents.ScheduleL3RouterOpts{RouterID: routerID}).ExtractErr()
+}
+
+// UnscheduleRouter removes a router from an L3 agent.
+func (c *networkClient) UnscheduleRouter(ctx context.Context, agentID, routerID string) error {
+	_ = ctx // ctx currently unused
+	return agents.RemoveL3Router(c.client, agentID, routerID).ExtractErr()
+}
@@ -0,0 +1,117 @@
+package client
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+// RequestStats is a snapshot of the request limiter's queue metrics.
+type RequestStats struct {
+	Limit    int
+	InFlight int
+	Queued   int
+	Total    int64
+	// TotalWait and MaxWait measure time spent queued for a slot.
+	TotalWait time.Duration
+	MaxWait   time.Duration
+}
+
+// RequestLimiter caps the number of concurrent API requests across every
+// client, so fan-out views (topology, search, limits) do not trip the rate
+// limits of small clouds. A limit of 0 disables the cap.
+type RequestLimiter struct {
+	mu    sync.Mutex
+	slots chan struct{}
+	stats RequestStats
+}
+
+// NewRequestLimiter creates a limiter allowing limit concurrent requests.
+func NewRequestLimiter(limit int) *RequestLimiter {
+	l := &RequestLimiter{}
+	l.SetLimit(limit)
+	return l
+}
+
+// SetLimit changes the concurrency cap. It must be called before requests
+// are in flight (i.e. at startup).
+func (l *RequestLimiter) SetLimit(limit int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.stats.Limit = limit
+	l.slots = nil
+	if limit > 0 {
+		l.slots = make(chan struct{}, limit)
+	}
+}
+
+// Stats returns the current queue metrics.
+func (l *RequestLimiter) Stats() RequestStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.stats
+}
+
+// Requests is the limiter shared by every client created by this package.
+var Requests = NewRequestLimiter(8)
+
+// acquire waits for a free slot and returns the function releasing it.
+func (l *RequestLimiter) acquire(req *http.Request) (func(), error) {
+	l.mu.Lock()
+	slots := l.slots
+	l.stats.Queued++
+	l.mu.Unlock()
+
+	start := time.Now()
+	var err error
+	if slots != nil {
+		select {
+		case slots <- struct{}{}:
+		case <-req.Context().Done():
+			err = req.Context().Err()
+		}
+	}
+	wait := time.Since(start)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.stats.Queued--
+	if err != nil {
+		return nil, err
+	}
+	l.stats.InFlight++
+	l.stats.Total++
+	l.stats.TotalWait += wait
+	l.stats.MaxWait = max(l.stats.MaxWait, wait)
+	return func() {
+		l.mu.Lock()
+		l.stats.InFlight--
+		l.mu.Unlock()
+		if slots != nil {
+			<-slots
+		}
+	}, nil
+}
+
+// limitTransport is an http.RoundTripper holding a limiter slot for the
+// duration of each request.
+type limitTransport struct {
+	// next is the wrapped transport; nil means http.DefaultTransport.
+	next    http.RoundTripper
+	limiter *RequestLimiter
+}
+
+// RoundTrip implements http.RoundTripper. The slot is held until the
+// response headers arrive; bodies are small JSON documents read right after.
+func (t *limitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	next := t.next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	release, err := t.limiter.acquire(req)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return next.RoundTrip(req)
+}
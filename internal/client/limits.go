@@ -13,6 +13,10 @@ import (
 type Limits struct {
 	Compute *cLimits.Limits
 	Volume  *vLimits.Limits
+	// ComputeRate holds the rate limits of the compute API, which
+	// cLimits.Limits leaves out; Volume.Rate holds those of block storage.
+	// Both are usually empty, as rate limiting moved to the API gateway.
+	ComputeRate []vLimits.Rate
 }
 
 // LimitsClient defines a method to retrieve limits for both compute and volume services.
@@ -27,10 +31,15 @@ type limitsClient struct {
 
 // NewLimitsClient creates a new LimitsClient given authentication options.
 func NewLimitsClient(authOpts gophercloud.AuthOptions) (LimitsClient, error) {
-	provider, err := openstack.AuthenticatedClient(authOpts)
+	provider, err := authenticatedClient(authOpts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to authenticate with OpenStack: %w", err)
 	}
+	return newLimitsClient(provider)
+}
+
+// newLimitsClient creates a LimitsClient from an already authenticated provider.
+func newLimitsClient(provider *gophercloud.ProviderClient) (LimitsClient, error) {
 	computeClient, err := openstack.NewComputeV2(provider, gophercloud.EndpointOpts{})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create compute client for limits: %w", err)
@@ -52,13 +61,22 @@ func (c *limitsClient) GetLimits(ctx context.Context) (*Limits, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to get compute limits: %w", err)
 	}
+	// Nova reports rate limits in the same shape as Cinder.
+	var rate struct {
+		Limits struct {
+			Rate []vLimits.Rate `json:"rate"`
+		} `json:"limits"`
+	}
+	if err := compRes.ExtractInto(&rate); err != nil {
+		return nil, fmt.Errorf("failed to get compute rate limits: %w", err)
+	}
 	// Volume limits
 	volRes := vLimits.Get(c.volume)
 	volLimits, err := volRes.Extract()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get volume limits: %w", err)
 	}
-	return &Limits{Compute: compLimits, Volume: volLimits}, nil
+	return &Limits{Compute: compLimits, Volume: volLimits, ComputeRate: rate.Limits.Rate}, nil
 }
 
 // Ensure limitsClient implements LimitsClient.
@@ -29,6 +29,13 @@ type Listener struct {
 	Protocol           string
 	ProtocolPort       int
 	ProvisioningStatus string
+	// DefaultTLSContainerRef is the Barbican reference of the certificate
+	// of a TERMINATED_HTTPS listener; SNIContainerRefs are the certificates
+	// served by host name.
+	DefaultTLSContainerRef string
+	SNIContainerRefs       []string
+	// DefaultPoolID is the pool requests go to when no L7 policy matches.
+	DefaultPoolID string
 }
 
 // Pool represents a simplified pool.
@@ -40,11 +47,25 @@ type Pool struct {
 	ProvisioningStatus string
 }
 
+// MemberStatus is a pool member with the health reported by the status
+// tree of its load balancer.
+type MemberStatus struct {
+	ID              string
+	Name            string
+	PoolID          string
+	Address         string
+	ProtocolPort    int
+	OperatingStatus string
+}
+
 // LoadBalancerClient defines methods for interacting with Octavia load balancer service.
 type LoadBalancerClient interface {
 	ListLoadBalancers(ctx context.Context) ([]LoadBalancer, error)
 	ListListeners(ctx context.Context, lbID string) ([]Listener, error)
 	ListPools(ctx context.Context, lbID string) ([]Pool, error)
+	// ListMemberStatuses returns the members of all pools of a load
+	// balancer with their operating status, from one status tree call.
+	ListMemberStatuses(ctx context.Context, lbID string) ([]MemberStatus, error)
 }
 
 // LoadBalancerClientImpl is the concrete implementation using gophercloud.
@@ -100,11 +121,14 @@ func (c *LoadBalancerClientImpl) ListListeners(ctx context.Context, lbID string)
 	lst := make([]Listener, len(gopherListeners))
 	for i, gl := range gopherListeners {
 		lst[i] = Listener{
-			ID:                 gl.ID,
-			Name:               gl.Name,
-			Protocol:           gl.Protocol,
-			ProtocolPort:       gl.ProtocolPort,
-			ProvisioningStatus: gl.ProvisioningStatus,
+			ID:                     gl.ID,
+			Name:                   gl.Name,
+			Protocol:               gl.Protocol,
+			ProtocolPort:           gl.ProtocolPort,
+			ProvisioningStatus:     gl.ProvisioningStatus,
+			DefaultTLSContainerRef: gl.DefaultTlsContainerRef,
+			SNIContainerRefs:       gl.SniContainerRefs,
+			DefaultPoolID:          gl.DefaultPoolID,
 		}
 	}
 	return lst, nil
@@ -134,5 +158,34 @@ func (c *LoadBalancerClientImpl) ListPools(ctx context.Context, lbID string) ([]
 	return ps, nil
 }
 
+// ListMemberStatuses returns the pool members of a load balancer from its
+// status tree. A pool shared by several listeners appears once per listener
+// in the tree; its members are returned once.
+func (c *LoadBalancerClientImpl) ListMemberStatuses(ctx context.Context, lbID string) ([]MemberStatus, error) {
+	tree, err := loadbalancers.GetStatuses(ctx, c.client, lbID).Extract()
+	if err != nil {
+		return nil, err
+	}
+	if tree == nil || tree.Loadbalancer == nil {
+		return nil, nil
+	}
+	treePools := tree.Loadbalancer.Pools
+	for _, l := range tree.Loadbalancer.Listeners {
+		treePools = append(treePools, l.Pools...)
+	}
+	var out []MemberStatus
+	seen := map[string]bool{}
+	for _, p := range treePools {
+		for _, mb := range p.Members {
+			if seen[mb.ID] {
+				continue
+			}
+			seen[mb.ID] = true
+			out = append(out, MemberStatus{ID: mb.ID, Name: mb.Name, PoolID: p.ID, Address: mb.Address, ProtocolPort: mb.ProtocolPort, OperatingStatus: mb.OperatingStatus})
+		}
+	}
+	return out, nil
+}
+
 // Ensure LoadBalancerClientImpl implements LoadBalancerClient.
 var _ LoadBalancerClient = (*LoadBalancerClientImpl)(nil)
@@ -0,0 +1,61 @@
+package client
+
+import (
+	"context"
+
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/layer3/routers"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/networks"
+)
+
+// AZPlacement is where a network or router lives: the availability zones
+// requested at creation (hints) and the zones Neutron scheduled its DHCP or
+// L3 agents to. Without hints a resource may be scheduled to any zone.
+type AZPlacement struct {
+	Hints []string `json:"availability_zone_hints"`
+	Zones []string `json:"availability_zones"`
+}
+
+// NetworkAZ is an availability zone of the network service. Resource is
+// "network" for zones with DHCP agents and "router" for zones with L3
+// agents.
+type NetworkAZ struct {
+	Name     string `json:"name"`
+	Resource string `json:"resource"`
+	State    string `json:"state"`
+}
+
+// gophercloud v1 neither lists network availability zones nor extracts the
+// scheduled zones of networks and routers, so they are read directly.
+
+// ListNetworkAZs returns the availability zones of the network service.
+func (c *networkClient) ListNetworkAZs(ctx context.Context) ([]NetworkAZ, error) {
+	_ = ctx // ctx currently unused
+	var body struct {
+		AZs []NetworkAZ `json:"availability_zones"`
+	}
+	_, err := c.client.Get(c.client.ServiceURL("availability_zones"), &body, nil)
+	return body.AZs, err
+}
+
+// GetNetworkAZs returns the zone hints and scheduled zones of a network.
+func (c *networkClient) GetNetworkAZs(ctx context.Context, id string) (AZPlacement, error) {
+	_ = ctx // ctx currently unused
+	var az AZPlacement
+	err := networks.Get(c.client, id).ExtractIntoStructPtr(&az, "network")
+	return az, err
+}
+
+// GetRouterAZs returns the zone hints and scheduled zones of a router.
+func (c *networkClient) GetRouterAZs(ctx context.Context, id string) (AZPlacement, error) {
+	_ = ctx // ctx currently unused
+	var az AZPlacement
+	err := routers.Get(c.client, id).ExtractIntoStructPtr(&az, "router")
+	return az, err
+}
+
+// CreateNetwork creates a network; opts.AvailabilityZoneHints restricts
+// its DHCP agents to the given zones.
+func (c *networkClient) CreateNetwork(ctx context.Context, opts networks.CreateOpts) (*networks.Network, error) {
+	_ = ctx // ctx currently unused
+	return networks.Create(c.client, opts).Extract()
+}
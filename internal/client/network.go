@@ -5,13 +5,18 @@ import (
 	"fmt"
 	"github.com/gophercloud/gophercloud"
 	"github.com/gophercloud/gophercloud/openstack"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/dns"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/external"
 	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/layer3/floatingips"
 	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/layer3/routers"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/quotas"
 	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/security/groups"
 	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/security/rules"
 	"github.com/gophercloud/gophercloud/openstack/networking/v2/networks"
 	"github.com/gophercloud/gophercloud/openstack/networking/v2/ports"
 	"github.com/gophercloud/gophercloud/openstack/networking/v2/subnets"
+	"github.com/gophercloud/gophercloud/pagination"
+	"time"
 )
 
 // NetworkClient defines the methods for interacting with OpenStack Networking (Neutron) service.
@@ -22,34 +27,138 @@ type Port = ports.Port
 type SecurityGroupRule = rules.SecGroupRule
 type SecurityGroupRuleInput = rules.CreateOpts
 
+// FloatingIP is a floating IP with its DNS integration attributes, which
+// are empty when the dns-integration extension is not enabled.
+type FloatingIP struct {
+	floatingips.FloatingIP
+	DNSName   string
+	DNSDomain string
+}
+
+// RouterDetail is a router with its creation time, a standard attribute
+// neutron reports that Router does not decode. CreatedAt is zero when the
+// cloud leaves it out.
+type RouterDetail struct {
+	Router
+	CreatedAt time.Time
+}
+
+// FloatingIPUpdate holds the floating IP attributes editable besides the
+// port association. Nil fields are left unchanged.
+type FloatingIPUpdate struct {
+	Description *string
+	DNSName     *string
+	DNSDomain   *string
+}
+
+// ToFloatingIPUpdateMap implements floatingips.UpdateOptsBuilder.
+func (o FloatingIPUpdate) ToFloatingIPUpdateMap() (map[string]interface{}, error) {
+	b := map[string]interface{}{}
+	if o.Description != nil {
+		b["description"] = *o.Description
+	}
+	if o.DNSName != nil {
+		b["dns_name"] = *o.DNSName
+	}
+	if o.DNSDomain != nil {
+		b["dns_domain"] = *o.DNSDomain
+	}
+	return map[string]interface{}{"floatingip": b}, nil
+}
+
 type NetworkClient interface {
 	ListNetworks() ([]networks.Network, error)
+	// ListExternalNetworks returns the networks with router:external set,
+	// which routers can use as their gateway.
+	ListExternalNetworks(ctx context.Context) ([]networks.Network, error)
 	ListSubnets() ([]subnets.Subnet, error)
 	GetSubnet(ctx context.Context, subnetID string) (*subnets.Subnet, error)
+	// UpdateSubnet changes the updatable attributes of a subnet, such as
+	// its DNS nameservers and host routes.
+	UpdateSubnet(ctx context.Context, id string, opts subnets.UpdateOpts) error
 	ListFloatingIPs() ([]floatingips.FloatingIP, error)
 	AllocateFloatingIP(opts floatingips.CreateOptsBuilder) (floatingips.FloatingIP, error)
 	ReleaseFloatingIP(id string) error
 	AssociateFloatingIP(fipID string, portID string) (floatingips.FloatingIP, error)
 	DisassociateFloatingIP(fipID string) (floatingips.FloatingIP, error)
+	ListFloatingIPDetails(ctx context.Context) ([]FloatingIP, error)
+	UpdateFloatingIP(ctx context.Context, id string, opts FloatingIPUpdate) error
 	ListSecurityGroups() ([]groups.SecGroup, error)
 	// Router operations
 	ListRouters(ctx context.Context) ([]Router, error)
+	// ListRouterDetails lists the routers with their creation time.
+	ListRouterDetails(ctx context.Context) ([]RouterDetail, error)
 	GetRouter(ctx context.Context, id string) (*Router, error)
 	GetRouterInterfaces(ctx context.Context, id string) ([]RouterInterface, error)
-	CreateRouter(ctx context.Context, name, externalNetID string) (*Router, error)
+	CreateRouter(ctx context.Context, opts RouterCreateOpts) (*Router, error)
 	DeleteRouter(ctx context.Context, id string) error
 	AddRouterInterface(ctx context.Context, routerID, subnetID string) error
 	RemoveRouterInterface(ctx context.Context, routerID, subnetID string) error
 	// Port operations
 	ListPorts(ctx context.Context) ([]Port, error)
+	// ListPortsUpTo lists at most limit ports and reports whether the
+	// project has more.
+	ListPortsUpTo(ctx context.Context, limit int) ([]Port, bool, error)
 	GetPort(ctx context.Context, id string) (*Port, error)
 	ListPortsByServer(ctx context.Context, serverID string) ([]Port, error)
 	ListPortsByNetwork(ctx context.Context, networkID string) ([]Port, error)
 	GetNetwork(ctx context.Context, id string) (*networks.Network, error)
+	// GetNetworkDetail returns the extension attributes of a network: MTU,
+	// router:external, provider segmentation and RBAC grants.
+	GetNetworkDetail(ctx context.Context, id string) (NetworkDetail, error)
+	UpdateNetwork(ctx context.Context, id string, opts networks.UpdateOpts) error
+	CreateNetwork(ctx context.Context, opts networks.CreateOpts) (*networks.Network, error)
+	// Availability zone operations
+	ListNetworkAZs(ctx context.Context) ([]NetworkAZ, error)
+	GetNetworkAZs(ctx context.Context, id string) (AZPlacement, error)
+	GetRouterAZs(ctx context.Context, id string) (AZPlacement, error)
 	// Security group rule operations
 	ListSecurityGroupRules(ctx context.Context, sgID string) ([]SecurityGroupRule, error)
 	CreateSecurityGroupRule(ctx context.Context, sgID string, rule SecurityGroupRuleInput) (*SecurityGroupRule, error)
 	DeleteSecurityGroupRule(ctx context.Context, id string) error
+	// Subnet pool and address scope operations
+	ListSubnetPools(ctx context.Context) ([]SubnetPool, error)
+	ListAddressScopes(ctx context.Context) ([]AddressScope, error)
+	CreateSubnet(ctx context.Context, opts subnets.CreateOpts) (*subnets.Subnet, error)
+	// Quota operations
+	GetQuota(ctx context.Context, projectID string) (*quotas.QuotaDetailSet, error)
+	UpdateQuota(ctx context.Context, projectID string, opts quotas.UpdateOpts) error
+	// Tap-as-a-service (port mirroring) operations
+	ListTapServices(ctx context.Context) ([]TapService, error)
+	CreateTapService(ctx context.Context, ts TapService) (*TapService, error)
+	DeleteTapService(ctx context.Context, id string) error
+	ListTapFlows(ctx context.Context, tapServiceID string) ([]TapFlow, error)
+	CreateTapFlow(ctx context.Context, tf TapFlow) (*TapFlow, error)
+	DeleteTapFlow(ctx context.Context, id string) error
+	// VPN-as-a-service operations
+	ListVPNServices(ctx context.Context) ([]VPNService, error)
+	ListIKEPolicies(ctx context.Context) ([]IKEPolicy, error)
+	ListIPSecPolicies(ctx context.Context) ([]IPSecPolicy, error)
+	ListVPNConnections(ctx context.Context) ([]VPNConnection, error)
+	GetVPNConnection(ctx context.Context, id string) (*VPNConnection, error)
+	ListVPNEndpointGroups(ctx context.Context) ([]VPNEndpointGroup, error)
+	// Firewall-as-a-service (FWaaS v2) operations
+	ListFirewallGroups(ctx context.Context) ([]FirewallGroup, error)
+	ListFirewallPolicies(ctx context.Context) ([]FirewallPolicy, error)
+	ListFirewallRules(ctx context.Context) ([]FirewallRule, error)
+	CreateFirewallRule(ctx context.Context, rule FirewallRuleInput, policyID string) (*FirewallRule, error)
+	DeleteFirewallRule(ctx context.Context, id string) error
+	// Agent operations (admin)
+	ListNetworkAgents(ctx context.Context, agentType string) ([]NetworkAgent, error)
+	// BGP dynamic routing operations (admin)
+	ListBGPSpeakers(ctx context.Context) ([]BGPSpeaker, error)
+	ListBGPPeers(ctx context.Context) ([]BGPPeer, error)
+	ListBGPAdvertisedRoutes(ctx context.Context, speakerID string) ([]BGPRoute, error)
+	ListBGPSpeakerAgents(ctx context.Context, speakerID string) ([]NetworkAgent, error)
+	ScheduleBGPSpeaker(ctx context.Context, agentID, speakerID string) error
+	UnscheduleBGPSpeaker(ctx context.Context, agentID, speakerID string) error
+	// L3 agent scheduling operations (admin)
+	ListRouterL3Agents(ctx context.Context, routerID string) ([]L3Agent, error)
+	ScheduleRouter(ctx context.Context, agentID, routerID string) error
+	UnscheduleRouter(ctx context.Context, agentID, routerID string) error
+	// DHCP agent operations (admin)
+	ListNetworkDHCPAgents(ctx context.Context, networkID string) ([]NetworkAgent, error)
+	ListDHCPPorts(ctx context.Context, networkID string) ([]DHCPPort, error)
 }
 
 type networkClient struct {
@@ -58,10 +167,15 @@ type networkClient struct {
 
 // NewNetworkClient creates a new NetworkClient given authentication options.
 func NewNetworkClient(authOpts gophercloud.AuthOptions) (NetworkClient, error) {
-	provider, err := openstack.AuthenticatedClient(authOpts)
+	provider, err := authenticatedClient(authOpts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to authenticate with OpenStack: %w", err)
 	}
+	return newNetworkClient(provider)
+}
+
+// newNetworkClient creates a NetworkClient from an already authenticated provider.
+func newNetworkClient(provider *gophercloud.ProviderClient) (NetworkClient, error) {
 	client, err := openstack.NewNetworkV2(provider, gophercloud.EndpointOpts{})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create network client: %w", err)
@@ -78,6 +192,18 @@ func (c *networkClient) ListNetworks() ([]networks.Network, error) {
 	return networks.ExtractNetworks(allPages)
 }
 
+// ListExternalNetworks returns the networks with router:external set.
+func (c *networkClient) ListExternalNetworks(ctx context.Context) ([]networks.Network, error) {
+	_ = ctx
+	ext := true
+	opts := external.ListOptsExt{ListOptsBuilder: networks.ListOpts{}, External: &ext}
+	allPages, err := networks.List(c.client, opts).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	return networks.ExtractNetworks(allPages)
+}
+
 // ListSubnets returns all subnets visible to the authenticated project.
 func (c *networkClient) ListSubnets() ([]subnets.Subnet, error) {
 	allPages, err := subnets.List(c.client, nil).AllPages()
@@ -97,6 +223,13 @@ func (c *networkClient) GetSubnet(ctx context.Context, subnetID string) (*subnet
 	return s, nil
 }
 
+// UpdateSubnet changes the updatable attributes of a subnet.
+func (c *networkClient) UpdateSubnet(ctx context.Context, id string, opts subnets.UpdateOpts) error {
+	_ = ctx
+	_, err := subnets.Update(c.client, id, opts).Extract()
+	return err
+}
+
 // ListFloatingIPs returns all floating IPs visible to the authenticated project.
 func (c *networkClient) ListFloatingIPs() ([]floatingips.FloatingIP, error) {
 	allPages, err := floatingips.List(c.client, nil).AllPages()
@@ -144,6 +277,39 @@ func (c *networkClient) DisassociateFloatingIP(fipID string) (floatingips.Floati
 	return *fip, nil
 }
 
+// ListFloatingIPDetails returns all floating IPs with their DNS attributes.
+func (c *networkClient) ListFloatingIPDetails(ctx context.Context) ([]FloatingIP, error) {
+	_ = ctx // ctx currently unused
+	allPages, err := floatingips.List(c.client, nil).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	base, err := floatingips.ExtractFloatingIPs(allPages)
+	if err != nil {
+		return nil, err
+	}
+	// FloatingIP has its own UnmarshalJSON, so the DNS extension fields are
+	// extracted separately rather than through an embedding struct.
+	var ext []dns.FloatingIPDNSExt
+	if err := floatingips.ExtractFloatingIPsInto(allPages, &ext); err != nil {
+		return nil, err
+	}
+	out := make([]FloatingIP, len(base))
+	for i, f := range base {
+		out[i] = FloatingIP{FloatingIP: f}
+		if i < len(ext) {
+			out[i].DNSName, out[i].DNSDomain = ext[i].DNSName, ext[i].DNSDomain
+		}
+	}
+	return out, nil
+}
+
+// UpdateFloatingIP changes the description or DNS attributes of a floating IP.
+func (c *networkClient) UpdateFloatingIP(ctx context.Context, id string, opts FloatingIPUpdate) error {
+	_ = ctx // ctx currently unused
+	return floatingips.Update(c.client, id, opts).Err
+}
+
 // ListSecurityGroups returns all security groups visible to the authenticated project.
 func (c *networkClient) ListSecurityGroups() ([]groups.SecGroup, error) {
 	allPages, err := groups.List(c.client, groups.ListOpts{}).AllPages()
@@ -163,6 +329,44 @@ func (c *networkClient) ListRouters(ctx context.Context) ([]Router, error) {
 	return routers.ExtractRouters(allPages)
 }
 
+// ListRouterDetails returns all routers with their creation time.
+func (c *networkClient) ListRouterDetails(ctx context.Context) ([]RouterDetail, error) {
+	_ = ctx // ctx currently unused
+	allPages, err := routers.List(c.client, routers.ListOpts{}).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	base, err := routers.ExtractRouters(allPages)
+	if err != nil {
+		return nil, err
+	}
+	var stamps []struct {
+		CreatedAt string `json:"created_at"`
+	}
+	if err := allPages.(routers.RouterPage).ExtractIntoSlicePtr(&stamps, "routers"); err != nil {
+		return nil, err
+	}
+	out := make([]RouterDetail, len(base))
+	for i, r := range base {
+		out[i] = RouterDetail{Router: r}
+		if i < len(stamps) {
+			out[i].CreatedAt = parseNeutronTime(stamps[i].CreatedAt)
+		}
+	}
+	return out, nil
+}
+
+// parseNeutronTime reads a standard attribute timestamp, which older
+// releases send without the zone; it is UTC either way.
+func parseNeutronTime(s string) time.Time {
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.UTC()
+		}
+	}
+	return time.Time{}
+}
+
 func (c *networkClient) GetRouter(ctx context.Context, id string) (*Router, error) {
 	_ = ctx
 	r, err := routers.Get(c.client, id).Extract()
@@ -178,9 +382,40 @@ func (c *networkClient) GetRouterInterfaces(ctx context.Context, id string) ([]R
 	return []RouterInterface{}, nil
 }
 
-func (c *networkClient) CreateRouter(ctx context.Context, name, externalNetID string) (*Router, error) {
+// RouterCreateOpts describes a new router. Nil flags are left to the
+// Neutron defaults.
+type RouterCreateOpts struct {
+	Name string
+	// ExternalNetworkID sets the gateway; empty creates a router without one.
+	ExternalNetworkID string
+	// EnableSNAT only applies with a gateway.
+	EnableSNAT *bool
+	// Distributed and HA are admin-only by default policy.
+	Distributed *bool
+	HA          *bool
+	AZHints     []string
+}
+
+// ToRouterCreateMap implements routers.CreateOptsBuilder, adding the ha
+// attribute of the l3-ha extension.
+func (o RouterCreateOpts) ToRouterCreateMap() (map[string]interface{}, error) {
+	opts := routers.CreateOpts{Name: o.Name, Distributed: o.Distributed, AvailabilityZoneHints: o.AZHints}
+	if o.ExternalNetworkID != "" {
+		opts.GatewayInfo = &routers.GatewayInfo{NetworkID: o.ExternalNetworkID, EnableSNAT: o.EnableSNAT}
+	}
+	b, err := opts.ToRouterCreateMap()
+	if err != nil {
+		return nil, err
+	}
+	if r, ok := b["router"].(map[string]interface{}); ok && o.HA != nil {
+		r["ha"] = *o.HA
+	}
+	return b, nil
+}
+
+func (c *networkClient) CreateRouter(ctx context.Context, opts RouterCreateOpts) (*Router, error) {
 	_ = ctx
-	r, err := routers.Create(c.client, routers.CreateOpts{Name: name, GatewayInfo: &routers.GatewayInfo{NetworkID: externalNetID}}).Extract()
+	r, err := routers.Create(c.client, opts).Extract()
 	if err != nil {
 		return nil, err
 	}
@@ -216,6 +451,39 @@ func (c *networkClient) ListPorts(ctx context.Context) ([]Port, error) {
 	return ports.ExtractPorts(allPages)
 }
 
+// portPageSize is the page size of ListPortsUpTo.
+const portPageSize = 500
+
+// ListPortsUpTo follows the pages of the port listing until it holds limit
+// ports. A cloud without pagination returns everything in one page, which
+// is cut to limit.
+func (c *networkClient) ListPortsUpTo(ctx context.Context, limit int) ([]Port, bool, error) {
+	_ = ctx
+	var out []Port
+	more := false
+	err := ports.List(c.client, ports.ListOpts{Limit: min(limit, portPageSize)}).EachPage(func(page pagination.Page) (bool, error) {
+		ps, err := ports.ExtractPorts(page)
+		if err != nil {
+			return false, err
+		}
+		out = append(out, ps...)
+		switch {
+		case len(out) > limit:
+			out, more = out[:limit], true
+			return false, nil
+		case len(out) == limit:
+			next, _ := page.NextPageURL()
+			more = next != ""
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return out, more, nil
+}
+
 func (c *networkClient) ListPortsByServer(ctx context.Context, serverID string) ([]Port, error) {
 	_ = ctx
 	allPages, err := ports.List(c.client, ports.ListOpts{DeviceID: serverID}).AllPages()
@@ -255,6 +523,13 @@ func (c *networkClient) GetNetwork(ctx context.Context, id string) (*networks.Ne
 	return n, nil
 }
 
+// UpdateNetwork changes the updatable attributes of a network.
+func (c *networkClient) UpdateNetwork(ctx context.Context, id string, opts networks.UpdateOpts) error {
+	_ = ctx // ctx currently unused
+	_, err := networks.Update(c.client, id, opts).Extract()
+	return err
+}
+
 // Security group rule operations
 func (c *networkClient) ListSecurityGroupRules(ctx context.Context, sgID string) ([]SecurityGroupRule, error) {
 	_ = ctx
@@ -279,5 +554,18 @@ func (c *networkClient) DeleteSecurityGroupRule(ctx context.Context, id string)
 	return rules.Delete(c.client, id).ExtractErr()
 }
 
+// GetQuota returns the networking quota limits and current usage for a project.
+func (c *networkClient) GetQuota(ctx context.Context, projectID string) (*quotas.QuotaDetailSet, error) {
+	_ = ctx
+	return quotas.GetDetail(c.client, projectID).Extract()
+}
+
+// UpdateQuota applies new networking quota limits to a project (admin only).
+func (c *networkClient) UpdateQuota(ctx context.Context, projectID string, opts quotas.UpdateOpts) error {
+	_ = ctx
+	_, err := quotas.Update(c.client, projectID, opts).Extract()
+	return err
+}
+
 // Ensure NetworkClient implements the interface.
 var _ NetworkClient = (*networkClient)(nil)
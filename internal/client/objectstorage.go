@@ -19,7 +19,7 @@ type objectStorageClient struct {
 
 // NewObjectStorageClient creates a new ObjectStorageClient given authentication options.
 func NewObjectStorageClient(authOpts gophercloud.AuthOptions) (ObjectStorageClient, error) {
-	provider, err := openstack.AuthenticatedClient(authOpts)
+	provider, err := authenticatedClient(authOpts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to authenticate with OpenStack: %w", err)
 	}
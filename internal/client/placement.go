@@ -0,0 +1,53 @@
+package client
+
+import (
+	"context"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack"
+	"github.com/gophercloud/gophercloud/openstack/placement/v1/resourceproviders"
+)
+
+// AllocationRatios are the overcommit ratios and reserved amounts the
+// Placement service applies to the vCPUs and RAM of a compute node. A zero
+// ratio means placement reports no inventory of that class.
+type AllocationRatios struct {
+	CPU           float64
+	RAM           float64
+	ReservedVCPUs int
+	ReservedMB    int
+}
+
+// ListAllocationRatios returns the allocation ratios of the compute nodes,
+// keyed by resource provider name, which is the hypervisor hostname.
+// Providers with neither VCPU nor MEMORY_MB inventory (e.g. shared storage
+// pools) are left out.
+func (c *computeClient) ListAllocationRatios(ctx context.Context) (map[string]AllocationRatios, error) {
+	_ = ctx // ctx currently unused
+	pc, err := openstack.NewPlacementV1(c.client.ProviderClient, gophercloud.EndpointOpts{})
+	if err != nil {
+		return nil, err
+	}
+	pages, err := resourceproviders.List(pc, nil).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	providers, err := resourceproviders.ExtractResourceProviders(pages)
+	if err != nil {
+		return nil, err
+	}
+	out := map[string]AllocationRatios{}
+	for _, p := range providers {
+		inv, err := resourceproviders.GetInventories(pc, p.UUID).Extract()
+		if err != nil {
+			return nil, err
+		}
+		cpu, hasCPU := inv.Inventories["VCPU"]
+		mem, hasMem := inv.Inventories["MEMORY_MB"]
+		if !hasCPU && !hasMem {
+			continue
+		}
+		out[p.Name] = AllocationRatios{CPU: float64(cpu.AllocationRatio), RAM: float64(mem.AllocationRatio), ReservedVCPUs: cpu.Reserved, ReservedMB: mem.Reserved}
+	}
+	return out, nil
+}
@@ -0,0 +1,227 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack"
+	gophercloudv2 "github.com/gophercloud/gophercloud/v2"
+	openstackv2 "github.com/gophercloud/gophercloud/v2/openstack"
+)
+
+// RetryPolicy controls how API requests are retried on rate limiting (429),
+// unavailable services (503) and transient network errors.
+type RetryPolicy struct {
+	// MaxRetries is the number of retries after the first attempt; 0 disables retries.
+	MaxRetries int
+	// BaseDelay is the first backoff delay; it doubles on every retry.
+	BaseDelay time.Duration
+	// MaxDelay caps both the backoff and any Retry-After the server asks for.
+	MaxDelay time.Duration
+	// Logf, when set, receives one line per retry (used by --debug).
+	Logf func(format string, args ...any)
+}
+
+// HTTPRetry is the retry policy applied to every client created by this
+// package. It is set from command-line flags before any client is created.
+var HTTPRetry = RetryPolicy{MaxRetries: 3, BaseDelay: 500 * time.Millisecond, MaxDelay: 30 * time.Second}
+
+// retryTransport is an http.RoundTripper applying a RetryPolicy.
+type retryTransport struct {
+	// next is the wrapped transport; nil means http.DefaultTransport at
+	// request time, so record/replay transports installed later still apply.
+	next   http.RoundTripper
+	policy RetryPolicy
+	sleep  func(ctx context.Context, d time.Duration) error
+}
+
+// NewRetryTransport wraps next with the given retry policy.
+func NewRetryTransport(next http.RoundTripper, policy RetryPolicy) http.RoundTripper {
+	return &retryTransport{next: next, policy: policy, sleep: sleepContext}
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	next := t.next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	// A RoundTripper must not modify req, so every retry sends a clone with
+	// a fresh body.
+	r := req
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 {
+			r = req.Clone(req.Context())
+			if req.Body != nil && req.GetBody != nil {
+				body, err := req.GetBody()
+				if err != nil {
+					return nil, err
+				}
+				r.Body = body
+			}
+		}
+		resp, err := next.RoundTrip(r)
+		if attempt >= t.policy.MaxRetries || !t.retryable(req, resp, err) {
+			return resp, err
+		}
+		delay := t.backoff(attempt, resp)
+		reason := ""
+		if err != nil {
+			reason = err.Error()
+		} else {
+			reason = resp.Status
+		}
+		if resp != nil {
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		if t.policy.Logf != nil {
+			t.policy.Logf("retry %d/%d %s %s after %s: %s", attempt+1, t.policy.MaxRetries, req.Method, req.URL.Redacted(), delay, reason)
+		}
+		if err := t.sleep(req.Context(), delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// retryable reports whether the outcome of req is worth another attempt.
+// Rate limiting and 503 mean the request was not processed, so any method
+// may be retried; network errors are retried only for idempotent methods.
+func (t *retryTransport) retryable(req *http.Request, resp *http.Response, err error) bool {
+	if req.Body != nil && req.GetBody == nil {
+		return false
+	}
+	if err != nil {
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return false
+		}
+		var netErr net.Error
+		if !errors.As(err, &netErr) && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+			return false
+		}
+		switch req.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+			return true
+		}
+		return false
+	}
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable
+}
+
+// backoff returns the delay before the next attempt, honouring Retry-After.
+func (t *retryTransport) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if d, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+			return min(d, t.policy.MaxDelay)
+		}
+	}
+	d := t.policy.BaseDelay << attempt
+	// Up to 20% jitter so parallel loaders do not retry in lockstep.
+	if d > 0 {
+		d += time.Duration(rand.Int63n(int64(d)/5 + 1))
+	}
+	return min(d, t.policy.MaxDelay)
+}
+
+// parseRetryAfter parses a Retry-After header in seconds or HTTP-date form.
+func parseRetryAfter(v string, now time.Time) (time.Duration, bool) {
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+	if at, err := http.ParseTime(v); err == nil {
+		if d := at.Sub(now); d > 0 {
+			return d, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// apiTransport returns the transport used by every provider: retries on top
+// of the shared request limiter, so a request waiting out a backoff does not
+// hold a slot.
+func apiTransport() http.RoundTripper {
+	return NewRetryTransport(&limitTransport{limiter: Requests}, HTTPRetry)
+}
+
+// authenticatedClient is openstack.AuthenticatedClient with apiTransport
+// installed on the provider's HTTP client.
+func authenticatedClient(opts gophercloud.AuthOptions) (*gophercloud.ProviderClient, error) {
+	provider, err := openstack.NewClient(opts.IdentityEndpoint)
+	if err != nil {
+		return nil, err
+	}
+	provider.HTTPClient = http.Client{Transport: apiTransport()}
+	if err := openstack.Authenticate(provider, opts); err != nil {
+		return nil, err
+	}
+	return provider, nil
+}
+
+// authenticatedClientV2 is the gophercloud v2 counterpart of authenticatedClient.
+func authenticatedClientV2(ctx context.Context, opts gophercloudv2.AuthOptions) (*gophercloudv2.ProviderClient, error) {
+	provider, err := openstackv2.NewClient(opts.IdentityEndpoint)
+	if err != nil {
+		return nil, err
+	}
+	provider.HTTPClient = http.Client{Transport: apiTransport()}
+	if err := openstackv2.Authenticate(ctx, provider, opts); err != nil {
+		return nil, err
+	}
+	return provider, nil
+}
+
+// borrowedProviderV2 is a gophercloud v2 provider riding on the session of
+// v1: it sends v1's token and finds endpoints in v1's catalog. It stands in
+// when the cloud refuses the v2 login, so DNS and load balancers work on
+// whatever v1 could authenticate with. A request refused with 401 takes the
+// token v1 holds by then, reauthenticating v1 first if it still has the
+// refused one.
+func borrowedProviderV2(v1 *gophercloud.ProviderClient) *gophercloudv2.ProviderClient {
+	p := &gophercloudv2.ProviderClient{
+		IdentityBase:     v1.IdentityBase,
+		IdentityEndpoint: v1.IdentityEndpoint,
+		HTTPClient:       http.Client{Transport: apiTransport()},
+	}
+	p.UseTokenLock()
+	p.SetToken(v1.Token())
+	p.EndpointLocator = func(eo gophercloudv2.EndpointOpts) (string, error) {
+		return v1.EndpointLocator(gophercloud.EndpointOpts{
+			Type:         eo.Type,
+			Name:         eo.Name,
+			Region:       eo.Region,
+			Availability: gophercloud.Availability(eo.Availability),
+		})
+	}
+	p.ReauthFunc = func(ctx context.Context) error {
+		if refused := p.Token(); v1.Token() == refused {
+			if err := v1.Reauthenticate(refused); err != nil {
+				return err
+			}
+		}
+		p.SetToken(v1.Token())
+		return nil
+	}
+	return p
+}
@@ -0,0 +1,272 @@
+package client
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/remoteconsoles"
+)
+
+// remoteConsoleMicroversion is the first microversion of the remote
+// consoles API.
+const remoteConsoleMicroversion = "2.6"
+
+// OpenSerialConsole creates a serial console for the server and connects to
+// it. Reads return the guest's console output and writes are keystrokes.
+func (c *computeClient) OpenSerialConsole(ctx context.Context, id string) (io.ReadWriteCloser, error) {
+	sc := *c.client
+	sc.Microversion = remoteConsoleMicroversion
+	rc, err := remoteconsoles.Create(&sc, id, remoteconsoles.CreateOpts{
+		Protocol: remoteconsoles.ConsoleProtocolSerial,
+		Type:     remoteconsoles.ConsoleTypeSerial,
+	}).Extract()
+	if err != nil {
+		return nil, err
+	}
+	return DialConsole(ctx, rc.URL)
+}
+
+// consoleTLS is the TLS configuration of API requests, so the console proxy
+// is trusted like the APIs are. It is set by NewHTTPTransport.
+var consoleTLS *tls.Config
+
+// websocketGUID is the key suffix of the RFC 6455 opening handshake.
+const websocketGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+// Websocket opcodes used by the console proxy.
+const (
+	opContinuation = 0x0
+	opText         = 0x1
+	opBinary       = 0x2
+	opClose        = 0x8
+	opPing         = 0x9
+	opPong         = 0xA
+)
+
+// wsConn is a client websocket carrying the byte stream of a console. Text
+// frames are base64 encoded when the proxy chose the base64 subprotocol.
+type wsConn struct {
+	conn    net.Conn
+	br      *bufio.Reader
+	base64  bool
+	pending []byte
+	// carry holds the base64 characters of a fragment past its last whole
+	// group of four, decoded with the next one.
+	carry []byte
+	wmu   sync.Mutex
+}
+
+// DialConsole opens the websocket of a console proxy (nova-serialproxy) at
+// rawURL, a ws:// or wss:// URL carrying the console token.
+func DialConsole(ctx context.Context, rawURL string) (io.ReadWriteCloser, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid console URL: %w", err)
+	}
+	var secure bool
+	switch u.Scheme {
+	case "ws", "http":
+	case "wss", "https":
+		secure = true
+	default:
+		return nil, fmt.Errorf("unsupported console URL scheme %q", u.Scheme)
+	}
+	host := u.Host
+	if u.Port() == "" {
+		if secure {
+			host = net.JoinHostPort(u.Hostname(), "443")
+		} else {
+			host = net.JoinHostPort(u.Hostname(), "80")
+		}
+	}
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", host)
+	if err != nil {
+		return nil, err
+	}
+	if secure {
+		cfg := &tls.Config{MinVersion: tls.VersionTLS12}
+		if consoleTLS != nil {
+			cfg = consoleTLS.Clone()
+		}
+		cfg.ServerName = u.Hostname()
+		tc := tls.Client(conn, cfg)
+		if err := tc.HandshakeContext(ctx); err != nil {
+			conn.Close()
+			return nil, err
+		}
+		conn = tc
+	}
+	ws, err := handshake(conn, u, secure)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return ws, nil
+}
+
+// handshake sends the opening request and checks the proxy accepted it. The
+// proxy compares the Origin with its own host, so it is set to the URL's.
+func handshake(conn net.Conn, u *url.URL, secure bool) (*wsConn, error) {
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	key := base64.StdEncoding.EncodeToString(nonce)
+	origin := "http://" + u.Host
+	if secure {
+		origin = "https://" + u.Host
+	}
+	req := fmt.Sprintf("GET %s HTTP/1.1\r\nHost: %s\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n"+
+		"Sec-WebSocket-Key: %s\r\nSec-WebSocket-Version: 13\r\nSec-WebSocket-Protocol: binary, base64\r\nOrigin: %s\r\n\r\n",
+		u.RequestURI(), u.Host, key, origin)
+	if _, err := io.WriteString(conn, req); err != nil {
+		return nil, err
+	}
+	br := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(br, &http.Request{Method: http.MethodGet})
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusSwitchingProtocols {
+		return nil, fmt.Errorf("console proxy refused the connection: %s", resp.Status)
+	}
+	sum := sha1.Sum([]byte(key + websocketGUID))
+	if resp.Header.Get("Sec-WebSocket-Accept") != base64.StdEncoding.EncodeToString(sum[:]) {
+		return nil, errors.New("console proxy sent an invalid handshake")
+	}
+	return &wsConn{conn: conn, br: br, base64: strings.EqualFold(resp.Header.Get("Sec-WebSocket-Protocol"), "base64")}, nil
+}
+
+// Read returns console output, answering pings on the way.
+func (c *wsConn) Read(p []byte) (int, error) {
+	for len(c.pending) == 0 {
+		op, payload, err := c.readFrame()
+		if err != nil {
+			return 0, err
+		}
+		switch op {
+		case opText, opBinary, opContinuation:
+			if c.base64 {
+				c.carry = append(c.carry, payload...)
+				whole := len(c.carry) / 4 * 4
+				if payload, err = base64.StdEncoding.DecodeString(string(c.carry[:whole])); err != nil {
+					return 0, err
+				}
+				c.carry = append(c.carry[:0], c.carry[whole:]...)
+			}
+			c.pending = payload
+		case opPing:
+			if err := c.writeFrame(opPong, payload); err != nil {
+				return 0, err
+			}
+		case opClose:
+			_ = c.writeFrame(opClose, nil)
+			return 0, io.EOF
+		}
+	}
+	n := copy(p, c.pending)
+	c.pending = c.pending[n:]
+	return n, nil
+}
+
+// Write sends keystrokes.
+func (c *wsConn) Write(p []byte) (int, error) {
+	op, payload := byte(opBinary), p
+	if c.base64 {
+		op, payload = opText, []byte(base64.StdEncoding.EncodeToString(p))
+	}
+	if err := c.writeFrame(op, payload); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Close sends a close frame and closes the connection.
+func (c *wsConn) Close() error {
+	_ = c.writeFrame(opClose, nil)
+	return c.conn.Close()
+}
+
+func (c *wsConn) readFrame() (byte, []byte, error) {
+	var hdr [2]byte
+	if _, err := io.ReadFull(c.br, hdr[:]); err != nil {
+		return 0, nil, err
+	}
+	op := hdr[0] & 0x0f
+	masked := hdr[1]&0x80 != 0
+	n := uint64(hdr[1] & 0x7f)
+	switch n {
+	case 126:
+		var ext [2]byte
+		if _, err := io.ReadFull(c.br, ext[:]); err != nil {
+			return 0, nil, err
+		}
+		n = uint64(binary.BigEndian.Uint16(ext[:]))
+	case 127:
+		var ext [8]byte
+		if _, err := io.ReadFull(c.br, ext[:]); err != nil {
+			return 0, nil, err
+		}
+		n = binary.BigEndian.Uint64(ext[:])
+	}
+	if n > 1<<20 {
+		return 0, nil, fmt.Errorf("console frame of %d bytes is too large", n)
+	}
+	var mask [4]byte
+	if masked {
+		if _, err := io.ReadFull(c.br, mask[:]); err != nil {
+			return 0, nil, err
+		}
+	}
+	payload := make([]byte, n)
+	if _, err := io.ReadFull(c.br, payload); err != nil {
+		return 0, nil, err
+	}
+	if masked {
+		for i := range payload {
+			payload[i] ^= mask[i%4]
+		}
+	}
+	return op, payload, nil
+}
+
+// writeFrame sends one final frame; client frames are always masked.
+func (c *wsConn) writeFrame(op byte, payload []byte) error {
+	c.wmu.Lock()
+	defer c.wmu.Unlock()
+	frame := []byte{0x80 | op}
+	switch n := len(payload); {
+	case n < 126:
+		frame = append(frame, 0x80|byte(n))
+	case n <= 0xffff:
+		frame = append(frame, 0x80|126)
+		frame = binary.BigEndian.AppendUint16(frame, uint16(n))
+	default:
+		frame = append(frame, 0x80|127)
+		frame = binary.BigEndian.AppendUint64(frame, uint64(n))
+	}
+	var mask [4]byte
+	if _, err := rand.Read(mask[:]); err != nil {
+		return err
+	}
+	frame = append(frame, mask[:]...)
+	for i, b := range payload {
+		frame = append(frame, b^mask[i%4])
+	}
+	_, err := c.conn.Write(frame)
+	return err
+}
@@ -0,0 +1,1967 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/blockstorage/extensions/quotasets"
+	"github.com/gophercloud/gophercloud/openstack/blockstorage/v3/snapshots"
+	"github.com/gophercloud/gophercloud/openstack/blockstorage/v3/volumes"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/availabilityzones"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/hypervisors"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/instanceactions"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/keypairs"
+	computequotas "github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/quotasets"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/flavors"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/images"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/domains"
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/extensions/trusts"
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/projects"
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/tokens"
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/users"
+	glanceimages "github.com/gophercloud/gophercloud/openstack/imageservice/v2/images"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/layer3/floatingips"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/quotas"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/security/groups"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/networks"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/subnets"
+	gophercloudv2 "github.com/gophercloud/gophercloud/v2"
+)
+
+// ServiceSet is the single dependency through which the UI reaches every
+// OpenStack service. Clients are created lazily on their first API call and
+// share one authenticated provider, so startup does not wait for every
+// service endpoint of a far-away cloud.
+type ServiceSet struct {
+	cloud    string
+	authOpts gophercloud.AuthOptions
+	// cacheTokens enables reuse of a cached Keystone token for cloud.
+	cacheTokens bool
+
+	provider   lazy[*gophercloud.ProviderClient]
+	providerV2 lazy[*gophercloudv2.ProviderClient]
+
+	// tokenMu guards the token expiry and v2Ready, the v2 provider once it
+	// exists, used by RenewToken. v2Borrowed is set when v2Ready reuses the
+	// v1 session because the v2 login failed; see borrowedProviderV2.
+	tokenMu      sync.Mutex
+	tokenExpires time.Time
+	v2Ready      *gophercloudv2.ProviderClient
+	v2Borrowed   bool
+
+	compute  lazy[ComputeClient]
+	network  lazy[NetworkClient]
+	storage  lazy[StorageClient]
+	identity lazy[IdentityClient]
+	image    lazy[ImageClient]
+	limits   lazy[LimitsClient]
+	dns      lazy[DNSClient]
+	lb       lazy[LoadBalancerClient]
+	shares   lazy[SharedFSClient]
+	keys     lazy[KeyManagerClient]
+	coe      lazy[ContainerInfraClient]
+
+	// Clients handed out to callers: lazy proxies, or fixed clients for
+	// NewServiceSetFromClients.
+	clients Clients
+}
+
+// Clients groups one client per service.
+type Clients struct {
+	Compute        ComputeClient
+	Network        NetworkClient
+	Storage        StorageClient
+	Identity       IdentityClient
+	Image          ImageClient
+	Limits         LimitsClient
+	DNS            DNSClient
+	LoadBalancer   LoadBalancerClient
+	SharedFS       SharedFSClient
+	KeyManager     KeyManagerClient
+	ContainerInfra ContainerInfraClient
+}
+
+// lazy holds a value built on first use. A failure is not kept, so after
+// Keystone or the catalog was briefly unreachable the next call builds
+// again instead of leaving the service missing for the session. Only a
+// refused login is final: sending the same credentials again cannot
+// succeed and could lock the account.
+type lazy[T any] struct {
+	mu   sync.Mutex
+	done bool
+	val  T
+	err  error
+}
+
+func (l *lazy[T]) get(build func() (T, error)) (T, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.done {
+		return l.val, l.err
+	}
+	val, err := build()
+	if err != nil && !loginRefused(err) {
+		return val, err
+	}
+	l.val, l.err, l.done = val, err, true
+	return val, err
+}
+
+// loginRefused reports whether err is Keystone rejecting the credentials.
+// Errors of both gophercloud versions carry the status code.
+func loginRefused(err error) bool {
+	var sc interface{ GetStatusCode() int }
+	return errors.As(err, &sc) && sc.GetStatusCode() == http.StatusUnauthorized
+}
+
+// NewServiceSet returns a ServiceSet that authenticates with authOpts on the
+// first API call. When cacheTokens is set, a cached token for cloud is tried
+// first and the new token is cached after a successful authentication.
+func NewServiceSet(cloud string, authOpts gophercloud.AuthOptions, cacheTokens bool) *ServiceSet {
+	s := &ServiceSet{cloud: cloud, authOpts: authOpts, cacheTokens: cacheTokens}
+	s.clients = Clients{
+		Compute:        lazyComputeClient{s},
+		Network:        lazyNetworkClient{s},
+		Storage:        lazyStorageClient{s},
+		Identity:       lazyIdentityClient{s},
+		Image:          lazyImageClient{s},
+		Limits:         lazyLimitsClient{s},
+		DNS:            lazyDNSClient{s},
+		LoadBalancer:   lazyLoadBalancerClient{s},
+		SharedFS:       lazySharedFSClient{s},
+		KeyManager:     lazyKeyManagerClient{s},
+		ContainerInfra: lazyContainerInfraClient{s},
+	}
+	return s
+}
+
+// NewServiceSetFromClients returns a ServiceSet serving the given, already
+// created clients (used by demo mode and tests).
+func NewServiceSetFromClients(c Clients) *ServiceSet {
+	return &ServiceSet{clients: c}
+}
+
+// Cloud returns the cloud name the set was created for.
+func (s *ServiceSet) Cloud() string { return s.cloud }
+
+// Service getters. The returned clients are cheap to obtain; errors from
+// authentication or endpoint lookup surface on their first API call.
+func (s *ServiceSet) Compute() ComputeClient               { return s.clients.Compute }
+func (s *ServiceSet) Network() NetworkClient               { return s.clients.Network }
+func (s *ServiceSet) Storage() StorageClient               { return s.clients.Storage }
+func (s *ServiceSet) Identity() IdentityClient             { return s.clients.Identity }
+func (s *ServiceSet) Image() ImageClient                   { return s.clients.Image }
+func (s *ServiceSet) Limits() LimitsClient                 { return s.clients.Limits }
+func (s *ServiceSet) DNS() DNSClient                       { return s.clients.DNS }
+func (s *ServiceSet) LoadBalancer() LoadBalancerClient     { return s.clients.LoadBalancer }
+func (s *ServiceSet) SharedFS() SharedFSClient             { return s.clients.SharedFS }
+func (s *ServiceSet) KeyManager() KeyManagerClient         { return s.clients.KeyManager }
+func (s *ServiceSet) ContainerInfra() ContainerInfraClient { return s.clients.ContainerInfra }
+
+// Provider returns the shared authenticated provider, authenticating on first use.
+func (s *ServiceSet) Provider() (*gophercloud.ProviderClient, error) {
+	return s.provider.get(func() (*gophercloud.ProviderClient, error) {
+		opts := s.authOpts
+		usedCache := false
+		if s.cacheTokens {
+			if tokenID, ok := LoadCachedToken(s.cloud); ok {
+				opts.TokenID = tokenID
+				usedCache = true
+			}
+		}
+		provider, err := authenticatedClient(opts)
+		if err != nil && usedCache {
+			// Cached token likely invalid, clear and retry
+			ClearCachedToken(s.cloud)
+			opts.TokenID = ""
+			provider, err = authenticatedClient(opts)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to authenticate with OpenStack: %w", err)
+		}
+		s.setTokenExpiry(authResultExpiry(provider))
+		if s.cacheTokens && !usedCache {
+			s.saveToken(provider)
+		}
+		return provider, nil
+	})
+}
+
+// saveToken caches the provider's token with its expiry.
+func (s *ServiceSet) saveToken(provider *gophercloud.ProviderClient) {
+	tokenID := provider.Token()
+	if tokenID == "" {
+		return
+	}
+	expiresAt := time.Now().Add(1 * time.Hour) // fallback
+	if ic, err := newIdentityClient(provider); err == nil {
+		if tokenInfo, err := ic.GetTokenInfo(); err == nil && tokenInfo != nil {
+			expiresAt = tokenInfo.ExpiresAt
+		} else {
+			log.Printf("warning: failed to get token expiry, using fallback: %v", err)
+		}
+	}
+	if err := SaveCachedToken(s.cloud, tokenID, expiresAt); err != nil {
+		log.Printf("warning: failed to save token cache: %v", err)
+	}
+}
+
+// providerV2Client returns the gophercloud v2 provider used by DNS and Load
+// Balancer, reusing the v1 token so Keystone is not asked twice.
+func (s *ServiceSet) providerV2Client() (*gophercloudv2.ProviderClient, error) {
+	return s.providerV2.get(func() (*gophercloudv2.ProviderClient, error) {
+		provider, err := s.Provider()
+		if err != nil {
+			return nil, err
+		}
+		v2AuthOpts := s.v2AuthOptions(provider.Token())
+		providerV2, err := authenticatedClientV2(context.Background(), v2AuthOpts)
+		if err != nil && v2AuthOpts.Password != "" {
+			// The token may not be reusable (e.g. app credentials); fall back to credentials.
+			v2AuthOpts.TokenID = ""
+			providerV2, err = authenticatedClientV2(context.Background(), v2AuthOpts)
+		}
+		borrowed := err != nil
+		if borrowed {
+			// DNS and load balancers would be lost to a login the v1
+			// session does not need (e.g. a spent TOTP passcode); they
+			// borrow the v1 token and catalog instead.
+			log.Printf("warning: v2 authentication failed, DNS and load balancers reuse the v1 session: %v", err)
+			providerV2 = borrowedProviderV2(provider)
+		}
+		s.tokenMu.Lock()
+		s.v2Ready, s.v2Borrowed = providerV2, borrowed
+		s.tokenMu.Unlock()
+		return providerV2, nil
+	})
+}
+
+// v2AuthOptions converts the auth options for gophercloud v2, reusing token.
+func (s *ServiceSet) v2AuthOptions(token string) gophercloudv2.AuthOptions {
+	return gophercloudv2.AuthOptions{
+		IdentityEndpoint: s.authOpts.IdentityEndpoint,
+		Username:         s.authOpts.Username,
+		UserID:           s.authOpts.UserID,
+		Password:         s.authOpts.Password,
+		Passcode:         s.authOpts.Passcode,
+		DomainID:         s.authOpts.DomainID,
+		DomainName:       s.authOpts.DomainName,
+		TenantID:         s.authOpts.TenantID,
+		TenantName:       s.authOpts.TenantName,
+		AllowReauth:      s.authOpts.AllowReauth,
+		TokenID:          token,
+		// Scope omitted for simplicity.
+	}
+}
+
+// InitStep is one named startup step of a ServiceSet.
+type InitStep struct {
+	Name string
+	// Required steps abort startup when they fail; the others only leave
+	// their service unavailable.
+	Required bool
+	Run      func() error
+}
+
+// InitSteps returns the steps that authenticate and create every client, in
+// order. Running them is optional (clients are still created on first use)
+// but lets the caller report progress. A set built from existing clients has
+// no steps.
+func (s *ServiceSet) InitSteps() []InitStep {
+	if _, ok := s.clients.Compute.(lazyComputeClient); !ok {
+		return nil
+	}
+	return []InitStep{
+		{Name: "Authenticating with Keystone", Required: true, Run: func() error { _, err := s.Provider(); return err }},
+		{Name: "Compute (Nova)", Required: true, Run: func() error { _, err := s.getCompute(); return err }},
+		{Name: "Network (Neutron)", Required: false, Run: func() error { _, err := s.getNetwork(); return err }},
+		{Name: "Block Storage (Cinder)", Required: false, Run: func() error { _, err := s.getStorage(); return err }},
+		{Name: "Identity (Keystone)", Required: false, Run: func() error { _, err := s.getIdentity(); return err }},
+		{Name: "Image (Glance)", Required: false, Run: func() error { _, err := s.getImage(); return err }},
+		{Name: "Limits", Required: false, Run: func() error { _, err := s.getLimits(); return err }},
+		{Name: "DNS (Designate)", Required: false, Run: func() error { _, err := s.getDNS(); return err }},
+		{Name: "Load Balancer (Octavia)", Required: false, Run: func() error { _, err := s.getLoadBalancer(); return err }},
+		{Name: "Shared File Systems (Manila)", Required: false, Run: func() error { _, err := s.getSharedFS(); return err }},
+		{Name: "Key Manager (Barbican)", Required: false, Run: func() error { _, err := s.getKeyManager(); return err }},
+		{Name: "Container Infra (Magnum)", Required: false, Run: func() error { _, err := s.getContainerInfra(); return err }},
+	}
+}
+
+func (s *ServiceSet) getCompute() (ComputeClient, error) {
+	return s.compute.get(func() (ComputeClient, error) {
+		p, err := s.Provider()
+		if err != nil {
+			return nil, err
+		}
+		return newComputeClient(p)
+	})
+}
+
+func (s *ServiceSet) getNetwork() (NetworkClient, error) {
+	return s.network.get(func() (NetworkClient, error) {
+		p, err := s.Provider()
+		if err != nil {
+			return nil, err
+		}
+		return newNetworkClient(p)
+	})
+}
+
+func (s *ServiceSet) getStorage() (StorageClient, error) {
+	return s.storage.get(func() (StorageClient, error) {
+		p, err := s.Provider()
+		if err != nil {
+			return nil, err
+		}
+		return newStorageClient(p)
+	})
+}
+
+func (s *ServiceSet) getIdentity() (IdentityClient, error) {
+	return s.identity.get(func() (IdentityClient, error) {
+		p, err := s.Provider()
+		if err != nil {
+			return nil, err
+		}
+		return newIdentityClient(p)
+	})
+}
+
+func (s *ServiceSet) getImage() (ImageClient, error) {
+	return s.image.get(func() (ImageClient, error) {
+		p, err := s.Provider()
+		if err != nil {
+			return nil, err
+		}
+		return newImageClient(p)
+	})
+}
+
+func (s *ServiceSet) getLimits() (LimitsClient, error) {
+	return s.limits.get(func() (LimitsClient, error) {
+		p, err := s.Provider()
+		if err != nil {
+			return nil, err
+		}
+		return newLimitsClient(p)
+	})
+}
+
+func (s *ServiceSet) getDNS() (DNSClient, error) {
+	return s.dns.get(func() (DNSClient, error) {
+		p, err := s.providerV2Client()
+		if err != nil {
+			return nil, err
+		}
+		return NewDNSClient(p, gophercloudv2.EndpointOpts{})
+	})
+}
+
+func (s *ServiceSet) getLoadBalancer() (LoadBalancerClient, error) {
+	return s.lb.get(func() (LoadBalancerClient, error) {
+		p, err := s.providerV2Client()
+		if err != nil {
+			return nil, err
+		}
+		return NewLoadBalancerClient(p, gophercloudv2.EndpointOpts{})
+	})
+}
+
+func (s *ServiceSet) getSharedFS() (SharedFSClient, error) {
+	return s.shares.get(func() (SharedFSClient, error) {
+		p, err := s.Provider()
+		if err != nil {
+			return nil, err
+		}
+		return newSharedFSClient(p)
+	})
+}
+
+func (s *ServiceSet) getKeyManager() (KeyManagerClient, error) {
+	return s.keys.get(func() (KeyManagerClient, error) {
+		p, err := s.Provider()
+		if err != nil {
+			return nil, err
+		}
+		return newKeyManagerClient(p)
+	})
+}
+
+func (s *ServiceSet) getContainerInfra() (ContainerInfraClient, error) {
+	return s.coe.get(func() (ContainerInfraClient, error) {
+		p, err := s.Provider()
+		if err != nil {
+			return nil, err
+		}
+		return newContainerInfraClient(p)
+	})
+}
+
+// lazyComputeClient creates the underlying ComputeClient on its first call.
+type lazyComputeClient struct{ s *ServiceSet }
+
+func (l lazyComputeClient) ListInstances() ([]servers.Server, error) {
+	c, err := l.s.getCompute()
+	if err != nil {
+		return nil, err
+	}
+	return c.ListInstances()
+}
+
+func (l lazyComputeClient) GetInstance(id string) (servers.Server, error) {
+	c, err := l.s.getCompute()
+	if err != nil {
+		return servers.Server{}, err
+	}
+	return c.GetInstance(id)
+}
+
+func (l lazyComputeClient) StartInstance(id string) error {
+	c, err := l.s.getCompute()
+	if err != nil {
+		return err
+	}
+	return c.StartInstance(id)
+}
+
+func (l lazyComputeClient) StopInstance(id string) error {
+	c, err := l.s.getCompute()
+	if err != nil {
+		return err
+	}
+	return c.StopInstance(id)
+}
+
+func (l lazyComputeClient) DeleteInstance(id string) error {
+	c, err := l.s.getCompute()
+	if err != nil {
+		return err
+	}
+	return c.DeleteInstance(id)
+}
+
+func (l lazyComputeClient) ListFlavors() ([]flavors.Flavor, error) {
+	c, err := l.s.getCompute()
+	if err != nil {
+		return nil, err
+	}
+	return c.ListFlavors()
+}
+
+func (l lazyComputeClient) ListKeypairs() ([]keypairs.KeyPair, error) {
+	c, err := l.s.getCompute()
+	if err != nil {
+		return nil, err
+	}
+	return c.ListKeypairs()
+}
+
+func (l lazyComputeClient) GetConsoleLog(id string, lines int) (string, error) {
+	c, err := l.s.getCompute()
+	if err != nil {
+		return "", err
+	}
+	return c.GetConsoleLog(id, lines)
+}
+
+func (l lazyComputeClient) GetConsoleURL(ctx context.Context, id, consoleType string) (string, error) {
+	c, err := l.s.getCompute()
+	if err != nil {
+		return "", err
+	}
+	return c.GetConsoleURL(ctx, id, consoleType)
+}
+
+func (l lazyComputeClient) OpenSerialConsole(ctx context.Context, id string) (io.ReadWriteCloser, error) {
+	c, err := l.s.getCompute()
+	if err != nil {
+		return nil, err
+	}
+	return c.OpenSerialConsole(ctx, id)
+}
+
+func (l lazyComputeClient) ListHypervisors(ctx context.Context) ([]hypervisors.Hypervisor, error) {
+	c, err := l.s.getCompute()
+	if err != nil {
+		return nil, err
+	}
+	return c.ListHypervisors(ctx)
+}
+
+func (l lazyComputeClient) GetHypervisor(ctx context.Context, id string) (*hypervisors.Hypervisor, error) {
+	c, err := l.s.getCompute()
+	if err != nil {
+		return nil, err
+	}
+	return c.GetHypervisor(ctx, id)
+}
+
+func (l lazyComputeClient) ListAllocationRatios(ctx context.Context) (map[string]AllocationRatios, error) {
+	c, err := l.s.getCompute()
+	if err != nil {
+		return nil, err
+	}
+	return c.ListAllocationRatios(ctx)
+}
+
+func (l lazyComputeClient) ListAvailabilityZones(ctx context.Context) ([]availabilityzones.AvailabilityZone, error) {
+	c, err := l.s.getCompute()
+	if err != nil {
+		return nil, err
+	}
+	return c.ListAvailabilityZones(ctx)
+}
+
+func (l lazyComputeClient) GetFlavor(ctx context.Context, flavorID string) (flavors.Flavor, error) {
+	c, err := l.s.getCompute()
+	if err != nil {
+		return flavors.Flavor{}, err
+	}
+	return c.GetFlavor(ctx, flavorID)
+}
+
+func (l lazyComputeClient) GetKeypair(ctx context.Context, name string) (keypairs.KeyPair, error) {
+	c, err := l.s.getCompute()
+	if err != nil {
+		return keypairs.KeyPair{}, err
+	}
+	return c.GetKeypair(ctx, name)
+}
+
+func (l lazyComputeClient) ListServerInterfaces(ctx context.Context, serverID string) ([]ServerInterface, error) {
+	c, err := l.s.getCompute()
+	if err != nil {
+		return nil, err
+	}
+	return c.ListServerInterfaces(ctx, serverID)
+}
+
+func (l lazyComputeClient) ListServerVolumes(ctx context.Context, serverID string) ([]ServerVolume, error) {
+	c, err := l.s.getCompute()
+	if err != nil {
+		return nil, err
+	}
+	return c.ListServerVolumes(ctx, serverID)
+}
+
+func (l lazyComputeClient) AttachVolume(ctx context.Context, serverID, volumeID string) error {
+	c, err := l.s.getCompute()
+	if err != nil {
+		return err
+	}
+	return c.AttachVolume(ctx, serverID, volumeID)
+}
+
+func (l lazyComputeClient) DetachVolume(ctx context.Context, serverID, volumeID string) error {
+	c, err := l.s.getCompute()
+	if err != nil {
+		return err
+	}
+	return c.DetachVolume(ctx, serverID, volumeID)
+}
+
+func (l lazyComputeClient) RebootInstance(ctx context.Context, id string, hard bool) error {
+	c, err := l.s.getCompute()
+	if err != nil {
+		return err
+	}
+	return c.RebootInstance(ctx, id, hard)
+}
+
+func (l lazyComputeClient) RebuildInstance(ctx context.Context, id string, opts servers.RebuildOptsBuilder) error {
+	c, err := l.s.getCompute()
+	if err != nil {
+		return err
+	}
+	return c.RebuildInstance(ctx, id, opts)
+}
+
+func (l lazyComputeClient) ResizeInstance(ctx context.Context, id, flavorID string) error {
+	c, err := l.s.getCompute()
+	if err != nil {
+		return err
+	}
+	return c.ResizeInstance(ctx, id, flavorID)
+}
+
+func (l lazyComputeClient) ConfirmResize(ctx context.Context, id string) error {
+	c, err := l.s.getCompute()
+	if err != nil {
+		return err
+	}
+	return c.ConfirmResize(ctx, id)
+}
+
+func (l lazyComputeClient) RevertResize(ctx context.Context, id string) error {
+	c, err := l.s.getCompute()
+	if err != nil {
+		return err
+	}
+	return c.RevertResize(ctx, id)
+}
+
+func (l lazyComputeClient) ListServerZones(ctx context.Context) (map[string]string, error) {
+	c, err := l.s.getCompute()
+	if err != nil {
+		return nil, err
+	}
+	return c.ListServerZones(ctx)
+}
+
+func (l lazyComputeClient) EvacuateInstance(ctx context.Context, id, host string, onSharedStorage bool) error {
+	c, err := l.s.getCompute()
+	if err != nil {
+		return err
+	}
+	return c.EvacuateInstance(ctx, id, host, onSharedStorage)
+}
+
+func (l lazyComputeClient) GetServerState(ctx context.Context, id string) (ServerState, error) {
+	c, err := l.s.getCompute()
+	if err != nil {
+		return ServerState{}, err
+	}
+	return c.GetServerState(ctx, id)
+}
+
+func (l lazyComputeClient) GetServerDiagnostics(ctx context.Context, id string) (ServerDiagnostics, error) {
+	c, err := l.s.getCompute()
+	if err != nil {
+		return ServerDiagnostics{}, err
+	}
+	return c.GetServerDiagnostics(ctx, id)
+}
+
+func (l lazyComputeClient) ListInstanceActions(ctx context.Context, id string) ([]instanceactions.InstanceAction, error) {
+	c, err := l.s.getCompute()
+	if err != nil {
+		return nil, err
+	}
+	return c.ListInstanceActions(ctx, id)
+}
+
+func (l lazyComputeClient) GetInstanceAction(ctx context.Context, id, requestID string) (instanceactions.InstanceActionDetail, error) {
+	c, err := l.s.getCompute()
+	if err != nil {
+		return instanceactions.InstanceActionDetail{}, err
+	}
+	return c.GetInstanceAction(ctx, id, requestID)
+}
+
+func (l lazyComputeClient) UpdateInstance(ctx context.Context, id string, opts servers.UpdateOpts) error {
+	c, err := l.s.getCompute()
+	if err != nil {
+		return err
+	}
+	return c.UpdateInstance(ctx, id, opts)
+}
+
+func (l lazyComputeClient) ResetInstanceMetadata(ctx context.Context, id string, metadata map[string]string) error {
+	c, err := l.s.getCompute()
+	if err != nil {
+		return err
+	}
+	return c.ResetInstanceMetadata(ctx, id, metadata)
+}
+
+func (l lazyComputeClient) UpdateInstanceMetadata(ctx context.Context, id string, metadata map[string]string) error {
+	c, err := l.s.getCompute()
+	if err != nil {
+		return err
+	}
+	return c.UpdateInstanceMetadata(ctx, id, metadata)
+}
+
+func (l lazyComputeClient) CreateInstance(ctx context.Context, opts servers.CreateOptsBuilder) (servers.Server, error) {
+	c, err := l.s.getCompute()
+	if err != nil {
+		return servers.Server{}, err
+	}
+	return c.CreateInstance(ctx, opts)
+}
+
+func (l lazyComputeClient) ListServerGroups(ctx context.Context) ([]ServerGroup, error) {
+	c, err := l.s.getCompute()
+	if err != nil {
+		return nil, err
+	}
+	return c.ListServerGroups(ctx)
+}
+
+func (l lazyComputeClient) GetServerHypervisor(ctx context.Context, id string) (string, error) {
+	c, err := l.s.getCompute()
+	if err != nil {
+		return "", err
+	}
+	return c.GetServerHypervisor(ctx, id)
+}
+
+func (l lazyComputeClient) GetQuotaSet(ctx context.Context, projectID string) (computequotas.QuotaDetailSet, error) {
+	c, err := l.s.getCompute()
+	if err != nil {
+		return computequotas.QuotaDetailSet{}, err
+	}
+	return c.GetQuotaSet(ctx, projectID)
+}
+
+func (l lazyComputeClient) UpdateQuotaSet(ctx context.Context, projectID string, opts computequotas.UpdateOpts) error {
+	c, err := l.s.getCompute()
+	if err != nil {
+		return err
+	}
+	return c.UpdateQuotaSet(ctx, projectID, opts)
+}
+
+func (l lazyComputeClient) ListComputeServices(ctx context.Context, host string) ([]ComputeService, error) {
+	c, err := l.s.getCompute()
+	if err != nil {
+		return nil, err
+	}
+	return c.ListComputeServices(ctx, host)
+}
+
+func (l lazyComputeClient) SetComputeServiceEnabled(ctx context.Context, id string, enabled bool, reason string) error {
+	c, err := l.s.getCompute()
+	if err != nil {
+		return err
+	}
+	return c.SetComputeServiceEnabled(ctx, id, enabled, reason)
+}
+
+func (l lazyComputeClient) ListHostInstances(ctx context.Context, host string) ([]servers.Server, error) {
+	c, err := l.s.getCompute()
+	if err != nil {
+		return nil, err
+	}
+	return c.ListHostInstances(ctx, host)
+}
+
+func (l lazyComputeClient) LiveMigrateInstance(ctx context.Context, id, host string) error {
+	c, err := l.s.getCompute()
+	if err != nil {
+		return err
+	}
+	return c.LiveMigrateInstance(ctx, id, host)
+}
+
+func (l lazyComputeClient) GetServerPassword(ctx context.Context, id string) (string, error) {
+	c, err := l.s.getCompute()
+	if err != nil {
+		return "", err
+	}
+	return c.GetServerPassword(ctx, id)
+}
+
+func (l lazyComputeClient) GetServerUserData(ctx context.Context, id string) (string, error) {
+	c, err := l.s.getCompute()
+	if err != nil {
+		return "", err
+	}
+	return c.GetServerUserData(ctx, id)
+}
+
+func (l lazyComputeClient) RebuildInstanceUserData(ctx context.Context, id, imageRef, userData string) error {
+	c, err := l.s.getCompute()
+	if err != nil {
+		return err
+	}
+	return c.RebuildInstanceUserData(ctx, id, imageRef, userData)
+}
+
+func (l lazyComputeClient) ChangeAdminPassword(ctx context.Context, id, password string) error {
+	c, err := l.s.getCompute()
+	if err != nil {
+		return err
+	}
+	return c.ChangeAdminPassword(ctx, id, password)
+}
+
+func (l lazyComputeClient) CreateServerImage(ctx context.Context, id, name string, metadata map[string]string) (string, error) {
+	c, err := l.s.getCompute()
+	if err != nil {
+		return "", err
+	}
+	return c.CreateServerImage(ctx, id, name, metadata)
+}
+
+func (l lazyComputeClient) PauseInstance(ctx context.Context, id string) error {
+	c, err := l.s.getCompute()
+	if err != nil {
+		return err
+	}
+	return c.PauseInstance(ctx, id)
+}
+
+func (l lazyComputeClient) UnpauseInstance(ctx context.Context, id string) error {
+	c, err := l.s.getCompute()
+	if err != nil {
+		return err
+	}
+	return c.UnpauseInstance(ctx, id)
+}
+
+func (l lazyComputeClient) SuspendInstance(ctx context.Context, id string) error {
+	c, err := l.s.getCompute()
+	if err != nil {
+		return err
+	}
+	return c.SuspendInstance(ctx, id)
+}
+
+func (l lazyComputeClient) ResumeInstance(ctx context.Context, id string) error {
+	c, err := l.s.getCompute()
+	if err != nil {
+		return err
+	}
+	return c.ResumeInstance(ctx, id)
+}
+
+func (l lazyComputeClient) ShelveInstance(ctx context.Context, id string) error {
+	c, err := l.s.getCompute()
+	if err != nil {
+		return err
+	}
+	return c.ShelveInstance(ctx, id)
+}
+
+func (l lazyComputeClient) RescueInstance(ctx context.Context, id, imageID string) (string, error) {
+	c, err := l.s.getCompute()
+	if err != nil {
+		return "", err
+	}
+	return c.RescueInstance(ctx, id, imageID)
+}
+
+func (l lazyComputeClient) UnrescueInstance(ctx context.Context, id string) error {
+	c, err := l.s.getCompute()
+	if err != nil {
+		return err
+	}
+	return c.UnrescueInstance(ctx, id)
+}
+
+func (l lazyComputeClient) UnshelveInstance(ctx context.Context, id string) error {
+	c, err := l.s.getCompute()
+	if err != nil {
+		return err
+	}
+	return c.UnshelveInstance(ctx, id)
+}
+
+func (l lazyComputeClient) LockInstance(ctx context.Context, id string) error {
+	c, err := l.s.getCompute()
+	if err != nil {
+		return err
+	}
+	return c.LockInstance(ctx, id)
+}
+
+func (l lazyComputeClient) UnlockInstance(ctx context.Context, id string) error {
+	c, err := l.s.getCompute()
+	if err != nil {
+		return err
+	}
+	return c.UnlockInstance(ctx, id)
+}
+
+// lazyNetworkClient creates the underlying NetworkClient on its first call.
+type lazyNetworkClient struct{ s *ServiceSet }
+
+func (l lazyNetworkClient) ListNetworks() ([]networks.Network, error) {
+	c, err := l.s.getNetwork()
+	if err != nil {
+		return nil, err
+	}
+	return c.ListNetworks()
+}
+
+func (l lazyNetworkClient) ListExternalNetworks(ctx context.Context) ([]networks.Network, error) {
+	c, err := l.s.getNetwork()
+	if err != nil {
+		return nil, err
+	}
+	return c.ListExternalNetworks(ctx)
+}
+
+func (l lazyNetworkClient) ListSubnets() ([]subnets.Subnet, error) {
+	c, err := l.s.getNetwork()
+	if err != nil {
+		return nil, err
+	}
+	return c.ListSubnets()
+}
+
+func (l lazyNetworkClient) GetSubnet(ctx context.Context, subnetID string) (*subnets.Subnet, error) {
+	c, err := l.s.getNetwork()
+	if err != nil {
+		return nil, err
+	}
+	return c.GetSubnet(ctx, subnetID)
+}
+
+func (l lazyNetworkClient) UpdateSubnet(ctx context.Context, id string, opts subnets.UpdateOpts) error {
+	c, err := l.s.getNetwork()
+	if err != nil {
+		return err
+	}
+	return c.UpdateSubnet(ctx, id, opts)
+}
+
+func (l lazyNetworkClient) ListFloatingIPs() ([]floatingips.FloatingIP, error) {
+	c, err := l.s.getNetwork()
+	if err != nil {
+		return nil, err
+	}
+	return c.ListFloatingIPs()
+}
+
+func (l lazyNetworkClient) AllocateFloatingIP(opts floatingips.CreateOptsBuilder) (floatingips.FloatingIP, error) {
+	c, err := l.s.getNetwork()
+	if err != nil {
+		return floatingips.FloatingIP{}, err
+	}
+	return c.AllocateFloatingIP(opts)
+}
+
+func (l lazyNetworkClient) ReleaseFloatingIP(id string) error {
+	c, err := l.s.getNetwork()
+	if err != nil {
+		return err
+	}
+	return c.ReleaseFloatingIP(id)
+}
+
+func (l lazyNetworkClient) AssociateFloatingIP(fipID string, portID string) (floatingips.FloatingIP, error) {
+	c, err := l.s.getNetwork()
+	if err != nil {
+		return floatingips.FloatingIP{}, err
+	}
+	return c.AssociateFloatingIP(fipID, portID)
+}
+
+func (l lazyNetworkClient) DisassociateFloatingIP(fipID string) (floatingips.FloatingIP, error) {
+	c, err := l.s.getNetwork()
+	if err != nil {
+		return floatingips.FloatingIP{}, err
+	}
+	return c.DisassociateFloatingIP(fipID)
+}
+
+func (l lazyNetworkClient) ListFloatingIPDetails(ctx context.Context) ([]FloatingIP, error) {
+	c, err := l.s.getNetwork()
+	if err != nil {
+		return nil, err
+	}
+	return c.ListFloatingIPDetails(ctx)
+}
+
+func (l lazyNetworkClient) UpdateFloatingIP(ctx context.Context, id string, opts FloatingIPUpdate) error {
+	c, err := l.s.getNetwork()
+	if err != nil {
+		return err
+	}
+	return c.UpdateFloatingIP(ctx, id, opts)
+}
+
+func (l lazyNetworkClient) ListSecurityGroups() ([]groups.SecGroup, error) {
+	c, err := l.s.getNetwork()
+	if err != nil {
+		return nil, err
+	}
+	return c.ListSecurityGroups()
+}
+
+func (l lazyNetworkClient) ListRouters(ctx context.Context) ([]Router, error) {
+	c, err := l.s.getNetwork()
+	if err != nil {
+		return nil, err
+	}
+	return c.ListRouters(ctx)
+}
+
+func (l lazyNetworkClient) ListRouterDetails(ctx context.Context) ([]RouterDetail, error) {
+	c, err := l.s.getNetwork()
+	if err != nil {
+		return nil, err
+	}
+	return c.ListRouterDetails(ctx)
+}
+
+func (l lazyNetworkClient) GetRouter(ctx context.Context, id string) (*Router, error) {
+	c, err := l.s.getNetwork()
+	if err != nil {
+		return nil, err
+	}
+	return c.GetRouter(ctx, id)
+}
+
+func (l lazyNetworkClient) GetRouterInterfaces(ctx context.Context, id string) ([]RouterInterface, error) {
+	c, err := l.s.getNetwork()
+	if err != nil {
+		return nil, err
+	}
+	return c.GetRouterInterfaces(ctx, id)
+}
+
+func (l lazyNetworkClient) CreateRouter(ctx context.Context, opts RouterCreateOpts) (*Router, error) {
+	c, err := l.s.getNetwork()
+	if err != nil {
+		return nil, err
+	}
+	return c.CreateRouter(ctx, opts)
+}
+
+func (l lazyNetworkClient) DeleteRouter(ctx context.Context, id string) error {
+	c, err := l.s.getNetwork()
+	if err != nil {
+		return err
+	}
+	return c.DeleteRouter(ctx, id)
+}
+
+func (l lazyNetworkClient) AddRouterInterface(ctx context.Context, routerID, subnetID string) error {
+	c, err := l.s.getNetwork()
+	if err != nil {
+		return err
+	}
+	return c.AddRouterInterface(ctx, routerID, subnetID)
+}
+
+func (l lazyNetworkClient) RemoveRouterInterface(ctx context.Context, routerID, subnetID string) error {
+	c, err := l.s.getNetwork()
+	if err != nil {
+		return err
+	}
+	return c.RemoveRouterInterface(ctx, routerID, subnetID)
+}
+
+func (l lazyNetworkClient) ListPorts(ctx context.Context) ([]Port, error) {
+	c, err := l.s.getNetwork()
+	if err != nil {
+		return nil, err
+	}
+	return c.ListPorts(ctx)
+}
+
+func (l lazyNetworkClient) ListPortsUpTo(ctx context.Context, limit int) ([]Port, bool, error) {
+	c, err := l.s.getNetwork()
+	if err != nil {
+		return nil, false, err
+	}
+	return c.ListPortsUpTo(ctx, limit)
+}
+
+func (l lazyNetworkClient) GetPort(ctx context.Context, id string) (*Port, error) {
+	c, err := l.s.getNetwork()
+	if err != nil {
+		return nil, err
+	}
+	return c.GetPort(ctx, id)
+}
+
+func (l lazyNetworkClient) ListPortsByServer(ctx context.Context, serverID string) ([]Port, error) {
+	c, err := l.s.getNetwork()
+	if err != nil {
+		return nil, err
+	}
+	return c.ListPortsByServer(ctx, serverID)
+}
+
+func (l lazyNetworkClient) ListPortsByNetwork(ctx context.Context, networkID string) ([]Port, error) {
+	c, err := l.s.getNetwork()
+	if err != nil {
+		return nil, err
+	}
+	return c.ListPortsByNetwork(ctx, networkID)
+}
+
+func (l lazyNetworkClient) GetNetwork(ctx context.Context, id string) (*networks.Network, error) {
+	c, err := l.s.getNetwork()
+	if err != nil {
+		return nil, err
+	}
+	return c.GetNetwork(ctx, id)
+}
+
+func (l lazyNetworkClient) GetNetworkDetail(ctx context.Context, id string) (NetworkDetail, error) {
+	c, err := l.s.getNetwork()
+	if err != nil {
+		return NetworkDetail{}, err
+	}
+	return c.GetNetworkDetail(ctx, id)
+}
+
+func (l lazyNetworkClient) UpdateNetwork(ctx context.Context, id string, opts networks.UpdateOpts) error {
+	c, err := l.s.getNetwork()
+	if err != nil {
+		return err
+	}
+	return c.UpdateNetwork(ctx, id, opts)
+}
+
+func (l lazyNetworkClient) ListSecurityGroupRules(ctx context.Context, sgID string) ([]SecurityGroupRule, error) {
+	c, err := l.s.getNetwork()
+	if err != nil {
+		return nil, err
+	}
+	return c.ListSecurityGroupRules(ctx, sgID)
+}
+
+func (l lazyNetworkClient) CreateSecurityGroupRule(ctx context.Context, sgID string, rule SecurityGroupRuleInput) (*SecurityGroupRule, error) {
+	c, err := l.s.getNetwork()
+	if err != nil {
+		return nil, err
+	}
+	return c.CreateSecurityGroupRule(ctx, sgID, rule)
+}
+
+func (l lazyNetworkClient) DeleteSecurityGroupRule(ctx context.Context, id string) error {
+	c, err := l.s.getNetwork()
+	if err != nil {
+		return err
+	}
+	return c.DeleteSecurityGroupRule(ctx, id)
+}
+
+func (l lazyNetworkClient) ListSubnetPools(ctx context.Context) ([]SubnetPool, error) {
+	c, err := l.s.getNetwork()
+	if err != nil {
+		return nil, err
+	}
+	return c.ListSubnetPools(ctx)
+}
+
+func (l lazyNetworkClient) ListAddressScopes(ctx context.Context) ([]AddressScope, error) {
+	c, err := l.s.getNetwork()
+	if err != nil {
+		return nil, err
+	}
+	return c.ListAddressScopes(ctx)
+}
+
+func (l lazyNetworkClient) CreateSubnet(ctx context.Context, opts subnets.CreateOpts) (*subnets.Subnet, error) {
+	c, err := l.s.getNetwork()
+	if err != nil {
+		return nil, err
+	}
+	return c.CreateSubnet(ctx, opts)
+}
+
+func (l lazyNetworkClient) CreateNetwork(ctx context.Context, opts networks.CreateOpts) (*networks.Network, error) {
+	c, err := l.s.getNetwork()
+	if err != nil {
+		return nil, err
+	}
+	return c.CreateNetwork(ctx, opts)
+}
+
+func (l lazyNetworkClient) ListNetworkAZs(ctx context.Context) ([]NetworkAZ, error) {
+	c, err := l.s.getNetwork()
+	if err != nil {
+		return nil, err
+	}
+	return c.ListNetworkAZs(ctx)
+}
+
+func (l lazyNetworkClient) GetNetworkAZs(ctx context.Context, id string) (AZPlacement, error) {
+	c, err := l.s.getNetwork()
+	if err != nil {
+		return AZPlacement{}, err
+	}
+	return c.GetNetworkAZs(ctx, id)
+}
+
+func (l lazyNetworkClient) GetRouterAZs(ctx context.Context, id string) (AZPlacement, error) {
+	c, err := l.s.getNetwork()
+	if err != nil {
+		return AZPlacement{}, err
+	}
+	return c.GetRouterAZs(ctx, id)
+}
+
+func (l lazyNetworkClient) GetQuota(ctx context.Context, projectID string) (*quotas.QuotaDetailSet, error) {
+	c, err := l.s.getNetwork()
+	if err != nil {
+		return nil, err
+	}
+	return c.GetQuota(ctx, projectID)
+}
+
+func (l lazyNetworkClient) UpdateQuota(ctx context.Context, projectID string, opts quotas.UpdateOpts) error {
+	c, err := l.s.getNetwork()
+	if err != nil {
+		return err
+	}
+	return c.UpdateQuota(ctx, projectID, opts)
+}
+
+func (l lazyNetworkClient) ListTapServices(ctx context.Context) ([]TapService, error) {
+	c, err := l.s.getNetwork()
+	if err != nil {
+		return nil, err
+	}
+	return c.ListTapServices(ctx)
+}
+
+func (l lazyNetworkClient) CreateTapService(ctx context.Context, ts TapService) (*TapService, error) {
+	c, err := l.s.getNetwork()
+	if err != nil {
+		return nil, err
+	}
+	return c.CreateTapService(ctx, ts)
+}
+
+func (l lazyNetworkClient) DeleteTapService(ctx context.Context, id string) error {
+	c, err := l.s.getNetwork()
+	if err != nil {
+		return err
+	}
+	return c.DeleteTapService(ctx, id)
+}
+
+func (l lazyNetworkClient) ListTapFlows(ctx context.Context, tapServiceID string) ([]TapFlow, error) {
+	c, err := l.s.getNetwork()
+	if err != nil {
+		return nil, err
+	}
+	return c.ListTapFlows(ctx, tapServiceID)
+}
+
+func (l lazyNetworkClient) CreateTapFlow(ctx context.Context, tf TapFlow) (*TapFlow, error) {
+	c, err := l.s.getNetwork()
+	if err != nil {
+		return nil, err
+	}
+	return c.CreateTapFlow(ctx, tf)
+}
+
+func (l lazyNetworkClient) DeleteTapFlow(ctx context.Context, id string) error {
+	c, err := l.s.getNetwork()
+	if err != nil {
+		return err
+	}
+	return c.DeleteTapFlow(ctx, id)
+}
+
+func (l lazyNetworkClient) ListVPNServices(ctx context.Context) ([]VPNService, error) {
+	c, err := l.s.getNetwork()
+	if err != nil {
+		return nil, err
+	}
+	return c.ListVPNServices(ctx)
+}
+
+func (l lazyNetworkClient) ListIKEPolicies(ctx context.Context) ([]IKEPolicy, error) {
+	c, err := l.s.getNetwork()
+	if err != nil {
+		return nil, err
+	}
+	return c.ListIKEPolicies(ctx)
+}
+
+func (l lazyNetworkClient) ListIPSecPolicies(ctx context.Context) ([]IPSecPolicy, error) {
+	c, err := l.s.getNetwork()
+	if err != nil {
+		return nil, err
+	}
+	return c.ListIPSecPolicies(ctx)
+}
+
+func (l lazyNetworkClient) ListVPNConnections(ctx context.Context) ([]VPNConnection, error) {
+	c, err := l.s.getNetwork()
+	if err != nil {
+		return nil, err
+	}
+	return c.ListVPNConnections(ctx)
+}
+
+func (l lazyNetworkClient) GetVPNConnection(ctx context.Context, id string) (*VPNConnection, error) {
+	c, err := l.s.getNetwork()
+	if err != nil {
+		return nil, err
+	}
+	return c.GetVPNConnection(ctx, id)
+}
+
+func (l lazyNetworkClient) ListVPNEndpointGroups(ctx context.Context) ([]VPNEndpointGroup, error) {
+	c, err := l.s.getNetwork()
+	if err != nil {
+		return nil, err
+	}
+	return c.ListVPNEndpointGroups(ctx)
+}
+
+func (l lazyNetworkClient) ListFirewallGroups(ctx context.Context) ([]FirewallGroup, error) {
+	c, err := l.s.getNetwork()
+	if err != nil {
+		return nil, err
+	}
+	return c.ListFirewallGroups(ctx)
+}
+
+func (l lazyNetworkClient) ListFirewallPolicies(ctx context.Context) ([]FirewallPolicy, error) {
+	c, err := l.s.getNetwork()
+	if err != nil {
+		return nil, err
+	}
+	return c.ListFirewallPolicies(ctx)
+}
+
+func (l lazyNetworkClient) ListFirewallRules(ctx context.Context) ([]FirewallRule, error) {
+	c, err := l.s.getNetwork()
+	if err != nil {
+		return nil, err
+	}
+	return c.ListFirewallRules(ctx)
+}
+
+func (l lazyNetworkClient) CreateFirewallRule(ctx context.Context, rule FirewallRuleInput, policyID string) (*FirewallRule, error) {
+	c, err := l.s.getNetwork()
+	if err != nil {
+		return nil, err
+	}
+	return c.CreateFirewallRule(ctx, rule, policyID)
+}
+
+func (l lazyNetworkClient) DeleteFirewallRule(ctx context.Context, id string) error {
+	c, err := l.s.getNetwork()
+	if err != nil {
+		return err
+	}
+	return c.DeleteFirewallRule(ctx, id)
+}
+
+func (l lazyNetworkClient) ListNetworkAgents(ctx context.Context, agentType string) ([]NetworkAgent, error) {
+	c, err := l.s.getNetwork()
+	if err != nil {
+		return nil, err
+	}
+	return c.ListNetworkAgents(ctx, agentType)
+}
+
+func (l lazyNetworkClient) ListBGPSpeakers(ctx context.Context) ([]BGPSpeaker, error) {
+	c, err := l.s.getNetwork()
+	if err != nil {
+		return nil, err
+	}
+	return c.ListBGPSpeakers(ctx)
+}
+
+func (l lazyNetworkClient) ListBGPPeers(ctx context.Context) ([]BGPPeer, error) {
+	c, err := l.s.getNetwork()
+	if err != nil {
+		return nil, err
+	}
+	return c.ListBGPPeers(ctx)
+}
+
+func (l lazyNetworkClient) ListBGPAdvertisedRoutes(ctx context.Context, speakerID string) ([]BGPRoute, error) {
+	c, err := l.s.getNetwork()
+	if err != nil {
+		return nil, err
+	}
+	return c.ListBGPAdvertisedRoutes(ctx, speakerID)
+}
+
+func (l lazyNetworkClient) ListBGPSpeakerAgents(ctx context.Context, speakerID string) ([]NetworkAgent, error) {
+	c, err := l.s.getNetwork()
+	if err != nil {
+		return nil, err
+	}
+	return c.ListBGPSpeakerAgents(ctx, speakerID)
+}
+
+func (l lazyNetworkClient) ScheduleBGPSpeaker(ctx context.Context, agentID, speakerID string) error {
+	c, err := l.s.getNetwork()
+	if err != nil {
+		return err
+	}
+	return c.ScheduleBGPSpeaker(ctx, agentID, speakerID)
+}
+
+func (l lazyNetworkClient) UnscheduleBGPSpeaker(ctx context.Context, agentID, speakerID string) error {
+	c, err := l.s.getNetwork()
+	if err != nil {
+		return err
+	}
+	return c.UnscheduleBGPSpeaker(ctx, agentID, speakerID)
+}
+
+func (l lazyNetworkClient) ListRouterL3Agents(ctx context.Context, routerID string) ([]L3Agent, error) {
+	c, err := l.s.getNetwork()
+	if err != nil {
+		return nil, err
+	}
+	return c.ListRouterL3Agents(ctx, routerID)
+}
+
+func (l lazyNetworkClient) ScheduleRouter(ctx context.Context, agentID, routerID string) error {
+	c, err := l.s.getNetwork()
+	if err != nil {
+		return err
+	}
+	return c.ScheduleRouter(ctx, agentID, routerID)
+}
+
+func (l lazyNetworkClient) UnscheduleRouter(ctx context.Context, agentID, routerID string) error {
+	c, err := l.s.getNetwork()
+	if err != nil {
+		return err
+	}
+	return c.UnscheduleRouter(ctx, agentID, routerID)
+}
+
+func (l lazyNetworkClient) ListNetworkDHCPAgents(ctx context.Context, networkID string) ([]NetworkAgent, error) {
+	c, err := l.s.getNetwork()
+	if err != nil {
+		return nil, err
+	}
+	return c.ListNetworkDHCPAgents(ctx, networkID)
+}
+
+func (l lazyNetworkClient) ListDHCPPorts(ctx context.Context, networkID string) ([]DHCPPort, error) {
+	c, err := l.s.getNetwork()
+	if err != nil {
+		return nil, err
+	}
+	return c.ListDHCPPorts(ctx, networkID)
+}
+
+// lazyStorageClient creates the underlying StorageClient on its first call.
+type lazyStorageClient struct{ s *ServiceSet }
+
+func (l lazyStorageClient) ListVolumes() ([]volumes.Volume, error) {
+	c, err := l.s.getStorage()
+	if err != nil {
+		return nil, err
+	}
+	return c.ListVolumes()
+}
+
+func (l lazyStorageClient) GetVolume(id string) (volumes.Volume, error) {
+	c, err := l.s.getStorage()
+	if err != nil {
+		return volumes.Volume{}, err
+	}
+	return c.GetVolume(id)
+}
+
+func (l lazyStorageClient) DeleteVolume(id string) error {
+	c, err := l.s.getStorage()
+	if err != nil {
+		return err
+	}
+	return c.DeleteVolume(id)
+}
+
+func (l lazyStorageClient) ForceDeleteVolume(id string) error {
+	c, err := l.s.getStorage()
+	if err != nil {
+		return err
+	}
+	return c.ForceDeleteVolume(id)
+}
+
+func (l lazyStorageClient) UpdateVolume(id string, opts volumes.UpdateOpts) error {
+	c, err := l.s.getStorage()
+	if err != nil {
+		return err
+	}
+	return c.UpdateVolume(id, opts)
+}
+
+func (l lazyStorageClient) ListSnapshots() ([]snapshots.Snapshot, error) {
+	c, err := l.s.getStorage()
+	if err != nil {
+		return nil, err
+	}
+	return c.ListSnapshots()
+}
+
+func (l lazyStorageClient) CreateSnapshot(opts snapshots.CreateOptsBuilder) (snapshots.Snapshot, error) {
+	c, err := l.s.getStorage()
+	if err != nil {
+		return snapshots.Snapshot{}, err
+	}
+	return c.CreateSnapshot(opts)
+}
+
+func (l lazyStorageClient) CreateVolume(opts volumes.CreateOpts) (volumes.Volume, error) {
+	c, err := l.s.getStorage()
+	if err != nil {
+		return volumes.Volume{}, err
+	}
+	return c.CreateVolume(opts)
+}
+
+func (l lazyStorageClient) GetQuotaSet(projectID string) (quotasets.QuotaUsageSet, error) {
+	c, err := l.s.getStorage()
+	if err != nil {
+		return quotasets.QuotaUsageSet{}, err
+	}
+	return c.GetQuotaSet(projectID)
+}
+
+func (l lazyStorageClient) UpdateQuotaSet(projectID string, opts quotasets.UpdateOpts) error {
+	c, err := l.s.getStorage()
+	if err != nil {
+		return err
+	}
+	return c.UpdateQuotaSet(projectID, opts)
+}
+
+// lazyIdentityClient creates the underlying IdentityClient on its first call.
+type lazyIdentityClient struct{ s *ServiceSet }
+
+func (l lazyIdentityClient) ListProjects() ([]projects.Project, error) {
+	c, err := l.s.getIdentity()
+	if err != nil {
+		return nil, err
+	}
+	return c.ListProjects()
+}
+
+func (l lazyIdentityClient) GetCurrentProject() (projects.Project, error) {
+	c, err := l.s.getIdentity()
+	if err != nil {
+		return projects.Project{}, err
+	}
+	return c.GetCurrentProject()
+}
+
+func (l lazyIdentityClient) ListUsers() ([]users.User, error) {
+	c, err := l.s.getIdentity()
+	if err != nil {
+		return nil, err
+	}
+	return c.ListUsers()
+}
+
+func (l lazyIdentityClient) GetTokenInfo() (*tokens.Token, error) {
+	c, err := l.s.getIdentity()
+	if err != nil {
+		return nil, err
+	}
+	return c.GetTokenInfo()
+}
+
+func (l lazyIdentityClient) GetTokenRoles() ([]string, error) {
+	c, err := l.s.getIdentity()
+	if err != nil {
+		return nil, err
+	}
+	return c.GetTokenRoles()
+}
+
+func (l lazyIdentityClient) ListDomains() ([]domains.Domain, error) {
+	c, err := l.s.getIdentity()
+	if err != nil {
+		return nil, err
+	}
+	return c.ListDomains()
+}
+
+func (l lazyIdentityClient) ListProjectsInDomain(domainID string) ([]projects.Project, error) {
+	c, err := l.s.getIdentity()
+	if err != nil {
+		return nil, err
+	}
+	return c.ListProjectsInDomain(domainID)
+}
+
+func (l lazyIdentityClient) ListUsersInDomain(domainID string) ([]users.User, error) {
+	c, err := l.s.getIdentity()
+	if err != nil {
+		return nil, err
+	}
+	return c.ListUsersInDomain(domainID)
+}
+
+func (l lazyIdentityClient) GetTokenUserID() (string, error) {
+	c, err := l.s.getIdentity()
+	if err != nil {
+		return "", err
+	}
+	return c.GetTokenUserID()
+}
+
+func (l lazyIdentityClient) ListTrusts(opts trusts.ListOpts) ([]Trust, error) {
+	c, err := l.s.getIdentity()
+	if err != nil {
+		return nil, err
+	}
+	return c.ListTrusts(opts)
+}
+
+func (l lazyIdentityClient) CreateTrust(opts trusts.CreateOpts) (Trust, error) {
+	c, err := l.s.getIdentity()
+	if err != nil {
+		return Trust{}, err
+	}
+	return c.CreateTrust(opts)
+}
+
+func (l lazyIdentityClient) DeleteTrust(id string) error {
+	c, err := l.s.getIdentity()
+	if err != nil {
+		return err
+	}
+	return c.DeleteTrust(id)
+}
+
+func (l lazyIdentityClient) ListEC2Credentials(userID string) ([]EC2Credential, error) {
+	c, err := l.s.getIdentity()
+	if err != nil {
+		return nil, err
+	}
+	return c.ListEC2Credentials(userID)
+}
+
+func (l lazyIdentityClient) CreateEC2Credential(userID, projectID string) (EC2Credential, error) {
+	c, err := l.s.getIdentity()
+	if err != nil {
+		return EC2Credential{}, err
+	}
+	return c.CreateEC2Credential(userID, projectID)
+}
+
+func (l lazyIdentityClient) DeleteEC2Credential(userID, access string) error {
+	c, err := l.s.getIdentity()
+	if err != nil {
+		return err
+	}
+	return c.DeleteEC2Credential(userID, access)
+}
+
+// lazyImageClient creates the underlying ImageClient on its first call.
+type lazyImageClient struct{ s *ServiceSet }
+
+func (l lazyImageClient) ListImages(ctx context.Context) ([]images.Image, error) {
+	c, err := l.s.getImage()
+	if err != nil {
+		return nil, err
+	}
+	return c.ListImages(ctx)
+}
+
+func (l lazyImageClient) GetImage(ctx context.Context, id string) (*images.Image, error) {
+	c, err := l.s.getImage()
+	if err != nil {
+		return nil, err
+	}
+	return c.GetImage(ctx, id)
+}
+
+func (l lazyImageClient) DeleteImage(ctx context.Context, id string) error {
+	c, err := l.s.getImage()
+	if err != nil {
+		return err
+	}
+	return c.DeleteImage(ctx, id)
+}
+
+func (l lazyImageClient) DownloadImage(ctx context.Context, id, path string, progress func(done, total int64)) (*ImageDownload, error) {
+	c, err := l.s.getImage()
+	if err != nil {
+		return nil, err
+	}
+	return c.DownloadImage(ctx, id, path, progress)
+}
+
+func (l lazyImageClient) ListImageDetails(ctx context.Context) ([]glanceimages.Image, error) {
+	c, err := l.s.getImage()
+	if err != nil {
+		return nil, err
+	}
+	return c.ListImageDetails(ctx)
+}
+
+// lazyLimitsClient creates the underlying LimitsClient on its first call.
+type lazyLimitsClient struct{ s *ServiceSet }
+
+func (l lazyLimitsClient) GetLimits(ctx context.Context) (*Limits, error) {
+	c, err := l.s.getLimits()
+	if err != nil {
+		return nil, err
+	}
+	return c.GetLimits(ctx)
+}
+
+// lazyDNSClient creates the underlying DNSClient on its first call.
+type lazyDNSClient struct{ s *ServiceSet }
+
+func (l lazyDNSClient) ListZones(ctx context.Context) ([]Zone, error) {
+	c, err := l.s.getDNS()
+	if err != nil {
+		return nil, err
+	}
+	return c.ListZones(ctx)
+}
+
+func (l lazyDNSClient) ListRecordSets(ctx context.Context, zoneID string) ([]RecordSet, error) {
+	c, err := l.s.getDNS()
+	if err != nil {
+		return nil, err
+	}
+	return c.ListRecordSets(ctx, zoneID)
+}
+
+func (l lazyDNSClient) ListRecordSetPage(ctx context.Context, zoneID string, q RecordSetQuery) (RecordSetPage, error) {
+	c, err := l.s.getDNS()
+	if err != nil {
+		return RecordSetPage{}, err
+	}
+	return c.ListRecordSetPage(ctx, zoneID, q)
+}
+
+func (l lazyDNSClient) CreateRecordSet(ctx context.Context, zoneID string, rs RecordSet) (*RecordSet, error) {
+	c, err := l.s.getDNS()
+	if err != nil {
+		return nil, err
+	}
+	return c.CreateRecordSet(ctx, zoneID, rs)
+}
+
+func (l lazyDNSClient) UpdateRecordSet(ctx context.Context, zoneID, id string, records []string, ttl int) (*RecordSet, error) {
+	c, err := l.s.getDNS()
+	if err != nil {
+		return nil, err
+	}
+	return c.UpdateRecordSet(ctx, zoneID, id, records, ttl)
+}
+
+func (l lazyDNSClient) ListZoneTransfers(ctx context.Context) ([]ZoneTransfer, error) {
+	c, err := l.s.getDNS()
+	if err != nil {
+		return nil, err
+	}
+	return c.ListZoneTransfers(ctx)
+}
+
+func (l lazyDNSClient) CreateZoneTransfer(ctx context.Context, zoneID, targetProjectID, description string) (*ZoneTransfer, error) {
+	c, err := l.s.getDNS()
+	if err != nil {
+		return nil, err
+	}
+	return c.CreateZoneTransfer(ctx, zoneID, targetProjectID, description)
+}
+
+func (l lazyDNSClient) DeleteZoneTransfer(ctx context.Context, id string) error {
+	c, err := l.s.getDNS()
+	if err != nil {
+		return err
+	}
+	return c.DeleteZoneTransfer(ctx, id)
+}
+
+func (l lazyDNSClient) AcceptZoneTransfer(ctx context.Context, id, key string) (*ZoneTransferAccept, error) {
+	c, err := l.s.getDNS()
+	if err != nil {
+		return nil, err
+	}
+	return c.AcceptZoneTransfer(ctx, id, key)
+}
+
+// lazyLoadBalancerClient creates the underlying LoadBalancerClient on its first call.
+type lazyLoadBalancerClient struct{ s *ServiceSet }
+
+func (l lazyLoadBalancerClient) ListLoadBalancers(ctx context.Context) ([]LoadBalancer, error) {
+	c, err := l.s.getLoadBalancer()
+	if err != nil {
+		return nil, err
+	}
+	return c.ListLoadBalancers(ctx)
+}
+
+func (l lazyLoadBalancerClient) ListListeners(ctx context.Context, lbID string) ([]Listener, error) {
+	c, err := l.s.getLoadBalancer()
+	if err != nil {
+		return nil, err
+	}
+	return c.ListListeners(ctx, lbID)
+}
+
+func (l lazyLoadBalancerClient) ListPools(ctx context.Context, lbID string) ([]Pool, error) {
+	c, err := l.s.getLoadBalancer()
+	if err != nil {
+		return nil, err
+	}
+	return c.ListPools(ctx, lbID)
+}
+
+func (l lazyLoadBalancerClient) ListMemberStatuses(ctx context.Context, lbID string) ([]MemberStatus, error) {
+	c, err := l.s.getLoadBalancer()
+	if err != nil {
+		return nil, err
+	}
+	return c.ListMemberStatuses(ctx, lbID)
+}
+
+// lazySharedFSClient creates the underlying SharedFSClient on its first call.
+type lazySharedFSClient struct{ s *ServiceSet }
+
+func (l lazySharedFSClient) ListShares(ctx context.Context) ([]Share, error) {
+	c, err := l.s.getSharedFS()
+	if err != nil {
+		return nil, err
+	}
+	return c.ListShares(ctx)
+}
+
+func (l lazySharedFSClient) GetShare(ctx context.Context, id string) (*Share, error) {
+	c, err := l.s.getSharedFS()
+	if err != nil {
+		return nil, err
+	}
+	return c.GetShare(ctx, id)
+}
+
+func (l lazySharedFSClient) CreateShare(ctx context.Context, in ShareCreateInput) (*Share, error) {
+	c, err := l.s.getSharedFS()
+	if err != nil {
+		return nil, err
+	}
+	return c.CreateShare(ctx, in)
+}
+
+func (l lazySharedFSClient) DeleteShare(ctx context.Context, id string) error {
+	c, err := l.s.getSharedFS()
+	if err != nil {
+		return err
+	}
+	return c.DeleteShare(ctx, id)
+}
+
+func (l lazySharedFSClient) ListExportLocations(ctx context.Context, shareID string) ([]ShareExportLocation, error) {
+	c, err := l.s.getSharedFS()
+	if err != nil {
+		return nil, err
+	}
+	return c.ListExportLocations(ctx, shareID)
+}
+
+func (l lazySharedFSClient) ListAccessRules(ctx context.Context, shareID string) ([]ShareAccessRule, error) {
+	c, err := l.s.getSharedFS()
+	if err != nil {
+		return nil, err
+	}
+	return c.ListAccessRules(ctx, shareID)
+}
+
+func (l lazySharedFSClient) GrantAccess(ctx context.Context, shareID, accessType, accessTo, level string) (*ShareAccessRule, error) {
+	c, err := l.s.getSharedFS()
+	if err != nil {
+		return nil, err
+	}
+	return c.GrantAccess(ctx, shareID, accessType, accessTo, level)
+}
+
+func (l lazySharedFSClient) RevokeAccess(ctx context.Context, shareID, ruleID string) error {
+	c, err := l.s.getSharedFS()
+	if err != nil {
+		return err
+	}
+	return c.RevokeAccess(ctx, shareID, ruleID)
+}
+
+// lazyKeyManagerClient creates the underlying KeyManagerClient on its first call.
+type lazyKeyManagerClient struct{ s *ServiceSet }
+
+func (l lazyKeyManagerClient) ListSecrets(ctx context.Context) ([]Secret, error) {
+	c, err := l.s.getKeyManager()
+	if err != nil {
+		return nil, err
+	}
+	return c.ListSecrets(ctx)
+}
+
+func (l lazyKeyManagerClient) GetSecretPayload(ctx context.Context, id string) (string, error) {
+	c, err := l.s.getKeyManager()
+	if err != nil {
+		return "", err
+	}
+	return c.GetSecretPayload(ctx, id)
+}
+
+func (l lazyKeyManagerClient) CreateSecret(ctx context.Context, in SecretCreateInput) (*Secret, error) {
+	c, err := l.s.getKeyManager()
+	if err != nil {
+		return nil, err
+	}
+	return c.CreateSecret(ctx, in)
+}
+
+func (l lazyKeyManagerClient) DeleteSecret(ctx context.Context, id string) error {
+	c, err := l.s.getKeyManager()
+	if err != nil {
+		return err
+	}
+	return c.DeleteSecret(ctx, id)
+}
+
+func (l lazyKeyManagerClient) ListSecretContainers(ctx context.Context) ([]SecretContainer, error) {
+	c, err := l.s.getKeyManager()
+	if err != nil {
+		return nil, err
+	}
+	return c.ListSecretContainers(ctx)
+}
+
+// lazyContainerInfraClient creates the underlying ContainerInfraClient on its first call.
+type lazyContainerInfraClient struct{ s *ServiceSet }
+
+func (l lazyContainerInfraClient) ListClusters(ctx context.Context) ([]Cluster, error) {
+	c, err := l.s.getContainerInfra()
+	if err != nil {
+		return nil, err
+	}
+	return c.ListClusters(ctx)
+}
+
+func (l lazyContainerInfraClient) GetCluster(ctx context.Context, id string) (*Cluster, error) {
+	c, err := l.s.getContainerInfra()
+	if err != nil {
+		return nil, err
+	}
+	return c.GetCluster(ctx, id)
+}
+
+func (l lazyContainerInfraClient) GetClusterTemplate(ctx context.Context, id string) (*ClusterTemplate, error) {
+	c, err := l.s.getContainerInfra()
+	if err != nil {
+		return nil, err
+	}
+	return c.GetClusterTemplate(ctx, id)
+}
+
+func (l lazyContainerInfraClient) ResizeCluster(ctx context.Context, id string, nodeCount int) error {
+	c, err := l.s.getContainerInfra()
+	if err != nil {
+		return err
+	}
+	return c.ResizeCluster(ctx, id, nodeCount)
+}
+
+func (l lazyContainerInfraClient) GetClusterCA(ctx context.Context, clusterID string) (string, error) {
+	c, err := l.s.getContainerInfra()
+	if err != nil {
+		return "", err
+	}
+	return c.GetClusterCA(ctx, clusterID)
+}
+
+func (l lazyContainerInfraClient) SignClusterCSR(ctx context.Context, clusterID, csr string) (string, error) {
+	c, err := l.s.getContainerInfra()
+	if err != nil {
+		return "", err
+	}
+	return c.SignClusterCSR(ctx, clusterID, csr)
+}
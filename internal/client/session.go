@@ -0,0 +1,164 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+)
+
+// replayToken replaces recorded Keystone tokens so session files can be shared.
+const replayToken = "recorded-session-token"
+
+// sensitiveHeaders are dropped from recorded responses or replaced with a placeholder.
+var sensitiveHeaders = map[string]bool{"X-Subject-Token": true, "Set-Cookie": true}
+
+// Interaction is one recorded API response.
+type Interaction struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Status int         `json:"status"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body"`
+}
+
+// Session is the on-disk format of a recorded session.
+type Session struct {
+	Cloud            string        `json:"cloud"`
+	IdentityEndpoint string        `json:"identity_endpoint"`
+	Interactions     []Interaction `json:"interactions"`
+}
+
+// Recorder is an http.RoundTripper that forwards requests to the next
+// transport and captures every response for later replay.
+type Recorder struct {
+	next    http.RoundTripper
+	mu      sync.Mutex
+	session Session
+}
+
+// NewRecorder creates a Recorder wrapping next (http.DefaultTransport when nil).
+func NewRecorder(next http.RoundTripper, cloud, identityEndpoint string) *Recorder {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &Recorder{next: next, session: Session{Cloud: cloud, IdentityEndpoint: identityEndpoint}}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := r.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	header := http.Header{}
+	for k, v := range resp.Header {
+		if sensitiveHeaders[k] {
+			if k == "X-Subject-Token" {
+				header.Set(k, replayToken)
+			}
+			continue
+		}
+		header[k] = v
+	}
+	r.mu.Lock()
+	r.session.Interactions = append(r.session.Interactions, Interaction{Method: req.Method, URL: req.URL.String(), Status: resp.StatusCode, Header: header, Body: string(body)})
+	r.mu.Unlock()
+	return resp, nil
+}
+
+// Save writes the recorded session to path.
+func (r *Recorder) Save(path string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	b, err := json.MarshalIndent(r.session, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode session: %w", err)
+	}
+	if err := os.WriteFile(path, b, 0o600); err != nil {
+		return fmt.Errorf("failed to write session %s: %w", path, err)
+	}
+	return nil
+}
+
+// Replayer is an http.RoundTripper that serves responses from a recorded
+// session without any network access. Responses are matched by method and
+// URL; repeated requests receive the recorded responses in order, and the
+// last one is repeated once they are exhausted.
+type Replayer struct {
+	mu        sync.Mutex
+	Session   Session
+	responses map[string][]Interaction
+	served    map[string]int
+}
+
+// LoadReplayer reads a session recorded with Recorder.Save.
+func LoadReplayer(path string) (*Replayer, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read session %s: %w", path, err)
+	}
+	var s Session
+	if err := json.Unmarshal(b, &s); err != nil {
+		return nil, fmt.Errorf("failed to parse session %s: %w", path, err)
+	}
+	return NewReplayer(s), nil
+}
+
+// NewReplayer creates a Replayer serving the given session.
+func NewReplayer(s Session) *Replayer {
+	r := &Replayer{Session: s, responses: map[string][]Interaction{}, served: map[string]int{}}
+	for _, in := range s.Interactions {
+		k := in.Method + " " + in.URL
+		r.responses[k] = append(r.responses[k], in)
+	}
+	return r
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+	k := req.Method + " " + req.URL.String()
+	r.mu.Lock()
+	list := r.responses[k]
+	if len(list) == 0 {
+		r.mu.Unlock()
+		return nil, fmt.Errorf("replay: no recorded response for %s", k)
+	}
+	i := r.served[k]
+	if i >= len(list) {
+		i = len(list) - 1
+	} else {
+		r.served[k]++
+	}
+	in := list[i]
+	r.mu.Unlock()
+
+	header := in.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", in.Status, http.StatusText(in.Status)),
+		StatusCode:    in.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader([]byte(in.Body))),
+		ContentLength: int64(len(in.Body)),
+		Request:       req,
+	}, nil
+}
@@ -0,0 +1,112 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack"
+	"github.com/gophercloud/gophercloud/openstack/sharedfilesystems/v2/shares"
+)
+
+// sharedFSMicroversion is the Manila API microversion requested by the
+// client: cephx access rules need 2.13 and the preferred flag of export
+// locations 2.14.
+const sharedFSMicroversion = "2.14"
+
+// Type aliases for the Shared File Systems (Manila) resources.
+type Share = shares.Share
+type ShareExportLocation = shares.ExportLocation
+type ShareAccessRule = shares.AccessRight
+type ShareCreateInput = shares.CreateOpts
+
+// SharedFSClient defines the methods for interacting with the OpenStack
+// Shared File Systems (Manila) service.
+type SharedFSClient interface {
+	// ListShares returns the shares visible to the project, with details.
+	ListShares(ctx context.Context) ([]Share, error)
+	// GetShare returns one share.
+	GetShare(ctx context.Context, id string) (*Share, error)
+	// CreateShare creates a share; it is usable once its status is available.
+	CreateShare(ctx context.Context, in ShareCreateInput) (*Share, error)
+	// DeleteShare deletes a share.
+	DeleteShare(ctx context.Context, id string) error
+	// ListExportLocations returns the paths a share is mounted from.
+	ListExportLocations(ctx context.Context, shareID string) ([]ShareExportLocation, error)
+	// ListAccessRules returns the access rules of a share.
+	ListAccessRules(ctx context.Context, shareID string) ([]ShareAccessRule, error)
+	// GrantAccess adds an access rule; accessType is ip, cert, user or cephx
+	// and level is rw or ro.
+	GrantAccess(ctx context.Context, shareID, accessType, accessTo, level string) (*ShareAccessRule, error)
+	// RevokeAccess removes an access rule.
+	RevokeAccess(ctx context.Context, shareID, ruleID string) error
+}
+
+type sharedFSClient struct {
+	client *gophercloud.ServiceClient
+}
+
+// newSharedFSClient creates a SharedFSClient from an already authenticated provider.
+func newSharedFSClient(provider *gophercloud.ProviderClient) (SharedFSClient, error) {
+	client, err := openstack.NewSharedFileSystemV2(provider, gophercloud.EndpointOpts{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create shared file systems client: %w", err)
+	}
+	client.Microversion = sharedFSMicroversion
+	return &sharedFSClient{client: client}, nil
+}
+
+// ListShares returns the shares visible to the project, with details.
+func (c *sharedFSClient) ListShares(ctx context.Context) ([]Share, error) {
+	_ = ctx // ctx currently unused
+	allPages, err := shares.ListDetail(c.client, shares.ListOpts{}).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	return shares.ExtractShares(allPages)
+}
+
+// GetShare returns one share.
+func (c *sharedFSClient) GetShare(ctx context.Context, id string) (*Share, error) {
+	_ = ctx // ctx currently unused
+	return shares.Get(c.client, id).Extract()
+}
+
+// CreateShare creates a share.
+func (c *sharedFSClient) CreateShare(ctx context.Context, in ShareCreateInput) (*Share, error) {
+	_ = ctx // ctx currently unused
+	return shares.Create(c.client, in).Extract()
+}
+
+// DeleteShare deletes a share.
+func (c *sharedFSClient) DeleteShare(ctx context.Context, id string) error {
+	_ = ctx // ctx currently unused
+	return shares.Delete(c.client, id).ExtractErr()
+}
+
+// ListExportLocations returns the paths a share is mounted from.
+func (c *sharedFSClient) ListExportLocations(ctx context.Context, shareID string) ([]ShareExportLocation, error) {
+	_ = ctx // ctx currently unused
+	return shares.ListExportLocations(c.client, shareID).Extract()
+}
+
+// ListAccessRules returns the access rules of a share.
+func (c *sharedFSClient) ListAccessRules(ctx context.Context, shareID string) ([]ShareAccessRule, error) {
+	_ = ctx // ctx currently unused
+	return shares.ListAccessRights(c.client, shareID).Extract()
+}
+
+// GrantAccess adds an access rule to a share.
+func (c *sharedFSClient) GrantAccess(ctx context.Context, shareID, accessType, accessTo, level string) (*ShareAccessRule, error) {
+	_ = ctx // ctx currently unused
+	return shares.GrantAccess(c.client, shareID, shares.GrantAccessOpts{AccessType: accessType, AccessTo: accessTo, AccessLevel: level}).Extract()
+}
+
+// RevokeAccess removes an access rule from a share.
+func (c *sharedFSClient) RevokeAccess(ctx context.Context, shareID, ruleID string) error {
+	_ = ctx // ctx currently unused
+	return shares.RevokeAccess(c.client, shareID, shares.RevokeAccessOpts{AccessID: ruleID}).ExtractErr()
+}
+
+// Ensure sharedFSClient implements the SharedFSClient interface.
+var _ SharedFSClient = (*sharedFSClient)(nil)
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"github.com/gophercloud/gophercloud"
 	"github.com/gophercloud/gophercloud/openstack"
+	"github.com/gophercloud/gophercloud/openstack/blockstorage/extensions/quotasets"
+	"github.com/gophercloud/gophercloud/openstack/blockstorage/extensions/volumeactions"
 	"github.com/gophercloud/gophercloud/openstack/blockstorage/v3/snapshots"
 	"github.com/gophercloud/gophercloud/openstack/blockstorage/v3/volumes"
 )
@@ -12,9 +14,15 @@ import (
 type StorageClient interface {
 	ListVolumes() ([]volumes.Volume, error)
 	GetVolume(id string) (volumes.Volume, error)
+	CreateVolume(opts volumes.CreateOpts) (volumes.Volume, error)
 	DeleteVolume(id string) error
+	// ForceDeleteVolume deletes a volume in any status (admin).
+	ForceDeleteVolume(id string) error
+	UpdateVolume(id string, opts volumes.UpdateOpts) error
 	ListSnapshots() ([]snapshots.Snapshot, error)
 	CreateSnapshot(opts snapshots.CreateOptsBuilder) (snapshots.Snapshot, error)
+	GetQuotaSet(projectID string) (quotasets.QuotaUsageSet, error)
+	UpdateQuotaSet(projectID string, opts quotasets.UpdateOpts) error
 }
 
 type storageClient struct {
@@ -23,10 +31,15 @@ type storageClient struct {
 
 // NewStorageClient creates a new StorageClient given authentication options.
 func NewStorageClient(authOpts gophercloud.AuthOptions) (StorageClient, error) {
-	provider, err := openstack.AuthenticatedClient(authOpts)
+	provider, err := authenticatedClient(authOpts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to authenticate with OpenStack: %w", err)
 	}
+	return newStorageClient(provider)
+}
+
+// newStorageClient creates a StorageClient from an already authenticated provider.
+func newStorageClient(provider *gophercloud.ProviderClient) (StorageClient, error) {
 	client, err := openstack.NewBlockStorageV3(provider, gophercloud.EndpointOpts{})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create block storage client: %w", err)
@@ -53,11 +66,32 @@ func (c *storageClient) GetVolume(id string) (volumes.Volume, error) {
 	return *vol, nil
 }
 
+// CreateVolume creates an empty volume with the provided options.
+func (c *storageClient) CreateVolume(opts volumes.CreateOpts) (volumes.Volume, error) {
+	v, err := volumes.Create(c.client, opts).Extract()
+	if err != nil {
+		return volumes.Volume{}, err
+	}
+	return *v, nil
+}
+
 // DeleteVolume removes the specified volume.
 func (c *storageClient) DeleteVolume(id string) error {
 	return volumes.Delete(c.client, id, nil).ExtractErr()
 }
 
+// ForceDeleteVolume deletes a volume whatever its status, with the
+// os-force_delete admin action.
+func (c *storageClient) ForceDeleteVolume(id string) error {
+	return volumeactions.ForceDelete(c.client, id).ExtractErr()
+}
+
+// UpdateVolume changes the name, description or metadata of a volume.
+func (c *storageClient) UpdateVolume(id string, opts volumes.UpdateOpts) error {
+	_, err := volumes.Update(c.client, id, opts).Extract()
+	return err
+}
+
 // ListSnapshots returns all volume snapshots visible to the authenticated project.
 func (c *storageClient) ListSnapshots() ([]snapshots.Snapshot, error) {
 	allPages, err := snapshots.List(c.client, nil).AllPages()
@@ -77,5 +111,16 @@ func (c *storageClient) CreateSnapshot(opts snapshots.CreateOptsBuilder) (snapsh
 	return *snap, nil
 }
 
+// GetQuotaSet returns the block storage quota limits and current usage for a project.
+func (c *storageClient) GetQuotaSet(projectID string) (quotasets.QuotaUsageSet, error) {
+	return quotasets.GetUsage(c.client, projectID).Extract()
+}
+
+// UpdateQuotaSet applies new block storage quota limits to a project (admin only).
+func (c *storageClient) UpdateQuotaSet(projectID string, opts quotasets.UpdateOpts) error {
+	_, err := quotasets.Update(c.client, projectID, opts).Extract()
+	return err
+}
+
 // Ensure storageClient implements the StorageClient interface.
 var _ StorageClient = (*storageClient)(nil)
@@ -0,0 +1,44 @@
+package client
+
+import (
+	"context"
+
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/layer3/addressscopes"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/subnetpools"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/subnets"
+)
+
+// SubnetPool is a set of prefixes subnets are allocated from, so tenants
+// get non-overlapping CIDRs without picking them.
+type SubnetPool = subnetpools.SubnetPool
+
+// AddressScope groups subnet pools whose prefixes are routed without NAT:
+// routers only forward between subnets of the same scope.
+type AddressScope = addressscopes.AddressScope
+
+// ListSubnetPools returns the subnet pools visible to the project.
+func (c *networkClient) ListSubnetPools(ctx context.Context) ([]SubnetPool, error) {
+	_ = ctx // ctx currently unused
+	allPages, err := subnetpools.List(c.client, subnetpools.ListOpts{}).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	return subnetpools.ExtractSubnetPools(allPages)
+}
+
+// ListAddressScopes returns the address scopes visible to the project.
+func (c *networkClient) ListAddressScopes(ctx context.Context) ([]AddressScope, error) {
+	_ = ctx // ctx currently unused
+	allPages, err := addressscopes.List(c.client, addressscopes.ListOpts{}).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	return addressscopes.ExtractAddressScopes(allPages)
+}
+
+// CreateSubnet creates a subnet, with an explicit CIDR or allocated from
+// the subnet pool in opts.
+func (c *networkClient) CreateSubnet(ctx context.Context, opts subnets.CreateOpts) (*subnets.Subnet, error) {
+	_ = ctx // ctx currently unused
+	return subnets.Create(c.client, opts).Extract()
+}
@@ -0,0 +1,108 @@
+package client
+
+import (
+	"context"
+	neturl "net/url"
+
+	"github.com/gophercloud/gophercloud"
+)
+
+// TapService is a tap-as-a-service (taas) mirror destination: traffic of
+// its tap flows is copied to PortID.
+type TapService struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	PortID      string `json:"port_id"`
+	Status      string `json:"status"`
+	ProjectID   string `json:"project_id"`
+}
+
+// TapFlow mirrors the traffic of SourcePort into a tap service.
+type TapFlow struct {
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	Description  string `json:"description"`
+	TapServiceID string `json:"tap_service_id"`
+	SourcePort   string `json:"source_port"`
+	// Direction is IN, OUT or BOTH, seen from the source port's VM.
+	Direction  string `json:"direction"`
+	VLANFilter string `json:"vlan_filter,omitempty"`
+	Status     string `json:"status"`
+}
+
+// Tap flow directions.
+var TapFlowDirections = []string{"BOTH", "IN", "OUT"}
+
+// The taas extension is not covered by gophercloud v1, so its REST API is
+// called directly on the network service client.
+
+// ListTapServices returns the tap services of the project.
+func (c *networkClient) ListTapServices(ctx context.Context) ([]TapService, error) {
+	_ = ctx // ctx currently unused
+	var body struct {
+		TapServices []TapService `json:"tap_services"`
+	}
+	_, err := c.client.Get(c.client.ServiceURL("taas", "tap_services"), &body, nil)
+	return body.TapServices, err
+}
+
+// CreateTapService creates a tap service mirroring into ts.PortID.
+func (c *networkClient) CreateTapService(ctx context.Context, ts TapService) (*TapService, error) {
+	_ = ctx // ctx currently unused
+	req := map[string]interface{}{"tap_service": map[string]string{"name": ts.Name, "description": ts.Description, "port_id": ts.PortID}}
+	var body struct {
+		TapService TapService `json:"tap_service"`
+	}
+	_, err := c.client.Post(c.client.ServiceURL("taas", "tap_services"), req, &body, &gophercloud.RequestOpts{OkCodes: []int{201}})
+	if err != nil {
+		return nil, err
+	}
+	return &body.TapService, nil
+}
+
+// DeleteTapService deletes a tap service and its tap flows.
+func (c *networkClient) DeleteTapService(ctx context.Context, id string) error {
+	_ = ctx // ctx currently unused
+	_, err := c.client.Delete(c.client.ServiceURL("taas", "tap_services", id), nil)
+	return err
+}
+
+// ListTapFlows returns the tap flows of a tap service, or all tap flows
+// when tapServiceID is empty.
+func (c *networkClient) ListTapFlows(ctx context.Context, tapServiceID string) ([]TapFlow, error) {
+	_ = ctx // ctx currently unused
+	var body struct {
+		TapFlows []TapFlow `json:"tap_flows"`
+	}
+	url := c.client.ServiceURL("taas", "tap_flows")
+	if tapServiceID != "" {
+		url += "?tap_service_id=" + neturl.QueryEscape(tapServiceID)
+	}
+	_, err := c.client.Get(url, &body, nil)
+	return body.TapFlows, err
+}
+
+// CreateTapFlow starts mirroring tf.SourcePort into tf.TapServiceID.
+func (c *networkClient) CreateTapFlow(ctx context.Context, tf TapFlow) (*TapFlow, error) {
+	_ = ctx // ctx currently unused
+	flow := map[string]string{"name": tf.Name, "description": tf.Description, "tap_service_id": tf.TapServiceID, "source_port": tf.SourcePort, "direction": tf.Direction}
+	if tf.VLANFilter != "" {
+		flow["vlan_filter"] = tf.VLANFilter
+	}
+	var body struct {
+		TapFlow TapFlow `json:"tap_flow"`
+	}
+	_, err := c.client.Post(c.client.ServiceURL("taas", "tap_flows"), map[string]interface{}{"tap_flow": flow}, &body, &gophercloud.RequestOpts{OkCodes: []int{201}})
+	if err != nil {
+		return nil, err
+	}
+	return &body.TapFlow, nil
+}
+
+// DeleteTapFlow deletes a tap flow.
+func (c *networkClient) DeleteTapFlow(ctx context.Context, id string) error {
+	_ = ctx // ctx currently unused
+	_, err := c.client.Delete(c.client.ServiceURL("taas", "tap_flows", id), nil)
+	return err
+}
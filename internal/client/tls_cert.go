@@ -0,0 +1,80 @@
+package client
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// CertInfo is what ostui shows of the certificate of a TLS listener.
+type CertInfo struct {
+	CommonName string
+	SANs       []string
+	NotAfter   time.Time
+}
+
+// ParseCertPEM reads the first certificate of a PEM payload; further
+// blocks, such as intermediates or the private key, are ignored.
+func ParseCertPEM(payload string) (CertInfo, error) {
+	rest := []byte(payload)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			return CertInfo{}, errors.New("no PEM certificate in the payload")
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return CertInfo{}, fmt.Errorf("invalid certificate: %w", err)
+		}
+		info := CertInfo{CommonName: cert.Subject.CommonName, NotAfter: cert.NotAfter}
+		info.SANs = append(info.SANs, cert.DNSNames...)
+		for _, ip := range cert.IPAddresses {
+			info.SANs = append(info.SANs, ip.String())
+		}
+		return info, nil
+	}
+}
+
+// ResolveTLSCert returns the certificate behind the TLS reference of an
+// Octavia listener. A container reference is looked up in conts, the
+// containers of the project, for its "certificate" secret; a secret
+// reference is read directly. Octavia stores the latter as a PKCS#12
+// bundle, which is reported as such rather than decoded.
+func ResolveTLSCert(ctx context.Context, km KeyManagerClient, ref string, conts []SecretContainer) (CertInfo, error) {
+	secretID := BarbicanID(ref)
+	if strings.Contains(ref, "/containers/") {
+		secretID = ""
+		for _, ct := range conts {
+			if BarbicanID(ct.ContainerRef) != BarbicanID(ref) {
+				continue
+			}
+			for _, sr := range ct.SecretRefs {
+				if sr.Name == "certificate" {
+					secretID = BarbicanID(sr.SecretRef)
+				}
+			}
+			if secretID == "" {
+				return CertInfo{}, fmt.Errorf("container %s has no certificate secret", ct.Name)
+			}
+		}
+		if secretID == "" {
+			return CertInfo{}, fmt.Errorf("container %s not found", BarbicanID(ref))
+		}
+	}
+	payload, err := km.GetSecretPayload(ctx, secretID)
+	if err != nil {
+		return CertInfo{}, err
+	}
+	if !strings.Contains(payload, "-----BEGIN") {
+		return CertInfo{}, errors.New("PKCS#12 bundle, certificate details not decoded")
+	}
+	return ParseCertPEM(payload)
+}
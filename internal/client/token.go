@@ -0,0 +1,116 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/tokens"
+)
+
+// TokenRenewBefore is how long before expiry the token is renewed in the
+// background. It is set from command-line flags.
+var TokenRenewBefore = 10 * time.Minute
+
+// ErrTokenNotRenewable is returned by RenewToken when the cloud is
+// configured with a bare token and no credentials to obtain a new one.
+var ErrTokenNotRenewable = errors.New("token cannot be renewed: no credentials configured")
+
+// authResultExpiry returns the expiry of the token the provider obtained, or
+// the zero time when the auth result does not carry a v3 token.
+func authResultExpiry(provider *gophercloud.ProviderClient) time.Time {
+	r, ok := provider.GetAuthResult().(interface {
+		ExtractToken() (*tokens.Token, error)
+	})
+	if !ok {
+		return time.Time{}
+	}
+	t, err := r.ExtractToken()
+	if err != nil || t == nil {
+		return time.Time{}
+	}
+	return t.ExpiresAt
+}
+
+// setTokenExpiry records the expiry of the current token.
+func (s *ServiceSet) setTokenExpiry(t time.Time) {
+	s.tokenMu.Lock()
+	defer s.tokenMu.Unlock()
+	s.tokenExpires = t
+}
+
+// TokenExpiry returns when the current token expires. ok is false before
+// authentication and for sets built from existing clients (demo mode).
+func (s *ServiceSet) TokenExpiry() (time.Time, bool) {
+	s.tokenMu.Lock()
+	defer s.tokenMu.Unlock()
+	return s.tokenExpires, !s.tokenExpires.IsZero()
+}
+
+// RenewToken authenticates again with the configured credentials and
+// installs the new token on the shared providers, so every client keeps
+// working without being recreated.
+func (s *ServiceSet) RenewToken() error {
+	opts := s.authOpts
+	if opts.Password == "" && opts.ApplicationCredentialSecret == "" && opts.Passcode == "" {
+		return ErrTokenNotRenewable
+	}
+	provider, err := s.Provider()
+	if err != nil {
+		return err
+	}
+	opts.TokenID = ""
+	fresh, err := authenticatedClient(opts)
+	if err != nil {
+		return fmt.Errorf("renew token: %w", err)
+	}
+	provider.CopyTokenFrom(fresh)
+	expires := authResultExpiry(fresh)
+	s.setTokenExpiry(expires)
+
+	s.tokenMu.Lock()
+	v2, borrowed := s.v2Ready, s.v2Borrowed
+	s.tokenMu.Unlock()
+	if v2 != nil && borrowed {
+		v2.SetToken(fresh.Token())
+	} else if v2 != nil {
+		freshV2, err := authenticatedClientV2(context.Background(), s.v2AuthOptions(fresh.Token()))
+		if err != nil {
+			return fmt.Errorf("renew token for DNS/load balancer clients: %w", err)
+		}
+		v2.CopyTokenFrom(freshV2)
+	}
+	if s.cacheTokens && !expires.IsZero() {
+		if err := SaveCachedToken(s.cloud, fresh.Token(), expires); err != nil {
+			return fmt.Errorf("cache renewed token: %w", err)
+		}
+	}
+	return nil
+}
+
+// AuthCheck is the outcome of a standalone authentication attempt.
+type AuthCheck struct {
+	Elapsed time.Duration
+	Expires time.Time
+	// Services is the number of services in the token's catalog.
+	Services int
+}
+
+// CheckAuth authenticates with opts on a throwaway provider, without
+// touching the active session or the token cache.
+func CheckAuth(opts gophercloud.AuthOptions) (AuthCheck, error) {
+	start := time.Now()
+	provider, err := authenticatedClient(opts)
+	if err != nil {
+		return AuthCheck{Elapsed: time.Since(start)}, err
+	}
+	check := AuthCheck{Elapsed: time.Since(start), Expires: authResultExpiry(provider)}
+	if r, ok := provider.GetAuthResult().(tokens.CreateResult); ok {
+		if cat, err := r.ExtractServiceCatalog(); err == nil {
+			check.Services = len(cat.Entries)
+		}
+	}
+	return check, nil
+}
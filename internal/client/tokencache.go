@@ -2,52 +2,183 @@ package client
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
 	"os"
 	"path/filepath"
 	"time"
 )
 
+// tokenCacheVersion is the schema of the token cache files. Files written
+// before the version field read as 0 and are still used; files from a newer
+// ostui are left for it and not read.
+const tokenCacheVersion = 1
+
 type cachedToken struct {
+	Version   int       `json:"version"`
 	TokenID   string    `json:"token_id"`
 	ExpiresAt time.Time `json:"expires_at"`
 	CloudName string    `json:"cloud_name"`
 }
 
+// Several ostui processes may share a cache file. Writers hold an OS lock
+// (flock, or LockFileEx on Windows) on a lock file next to it and replace
+// the cache by renaming a complete file over it, so a reader sees the old
+// token or the new one and never half of a write. The OS drops the lock of
+// a process that dies, so a lock is never left behind; the lock file itself
+// stays.
+var tokenCacheLockWait = 2 * time.Second
+
+// errTokenCacheBusy reports a cache lock held past tokenCacheLockWait.
+var errTokenCacheBusy = errors.New("token cache is locked by another ostui")
+
 func tokenCachePath(cloudName string) string {
 	dir, _ := os.UserCacheDir()
 	return filepath.Join(dir, "ostui", "token-"+cloudName+".json")
 }
 
+// LoadCachedToken returns the cached token of cloudName while it has more
+// than five minutes left. A corrupt cache file is removed.
 func LoadCachedToken(cloudName string) (string, bool) {
+	return loadTokenFile(tokenCachePath(cloudName), cloudName, time.Now())
+}
+
+// SaveCachedToken caches tokenID for cloudName until expiresAt.
+func SaveCachedToken(cloudName, tokenID string, expiresAt time.Time) error {
+	return saveTokenFile(tokenCachePath(cloudName), cachedToken{Version: tokenCacheVersion, TokenID: tokenID, ExpiresAt: expiresAt, CloudName: cloudName})
+}
+
+// ClearCachedToken removes the cached token of cloudName.
+func ClearCachedToken(cloudName string) {
 	path := tokenCachePath(cloudName)
+	unlock, err := lockTokenFile(path)
+	if err != nil {
+		log.Printf("warning: clearing token cache: %v", err)
+		return
+	}
+	defer unlock()
+	os.Remove(path)
+}
+
+// loadTokenFile reads the token cached at path for cloudName.
+func loadTokenFile(path, cloudName string, now time.Time) (string, bool) {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return "", false
 	}
-	var ct cachedToken
-	if err := json.Unmarshal(data, &ct); err != nil {
+	ct, err := parseTokenFile(data, cloudName)
+	if err != nil {
+		healTokenFile(path, cloudName, err)
+		return "", false
+	}
+	if ct.Version > tokenCacheVersion {
 		return "", false
 	}
 	// Consider token valid if it expires more than 5 minutes from now
-	if time.Until(ct.ExpiresAt) < 5*time.Minute {
+	if ct.ExpiresAt.Sub(now) < 5*time.Minute {
 		return "", false
 	}
 	return ct.TokenID, true
 }
 
-func SaveCachedToken(cloudName, tokenID string, expiresAt time.Time) error {
-	path := tokenCachePath(cloudName)
-	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+// parseTokenFile decodes a cache file, refusing one that cannot have been
+// written for cloudName.
+func parseTokenFile(data []byte, cloudName string) (cachedToken, error) {
+	var ct cachedToken
+	if err := json.Unmarshal(data, &ct); err != nil {
+		return ct, err
+	}
+	switch {
+	case ct.Version > tokenCacheVersion:
+		return ct, nil
+	case ct.TokenID == "":
+		return ct, errors.New("no token")
+	case ct.CloudName != cloudName:
+		return ct, fmt.Errorf("token of cloud %q", ct.CloudName)
+	}
+	return ct, nil
+}
+
+// healTokenFile removes a corrupt cache file, unless another process has
+// replaced it with a good one since it was read.
+func healTokenFile(path, cloudName string, cause error) {
+	unlock, err := lockTokenFile(path)
+	if err != nil {
+		return
+	}
+	defer unlock()
+	if data, err := os.ReadFile(path); err == nil {
+		if _, err := parseTokenFile(data, cloudName); err == nil {
+			return
+		}
+	}
+	log.Printf("warning: removing corrupt token cache %s: %v", path, cause)
+	os.Remove(path)
+}
+
+// saveTokenFile writes ct to path atomically under the cache lock.
+func saveTokenFile(path string, ct cachedToken) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0700); err != nil {
 		return err
 	}
-	ct := cachedToken{TokenID: tokenID, ExpiresAt: expiresAt, CloudName: cloudName}
 	data, err := json.Marshal(ct)
 	if err != nil {
 		return err
 	}
-	return os.WriteFile(path, data, 0600)
+	unlock, err := lockTokenFile(path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	tmp, err := os.CreateTemp(dir, filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if err := tmp.Chmod(0600); err != nil {
+		tmp.Close()
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
 }
 
-func ClearCachedToken(cloudName string) {
-	os.Remove(tokenCachePath(cloudName))
+// lockTokenFile takes the lock of the cache file at path, waiting for
+// another process to release it, and returns its release.
+func lockTokenFile(path string) (func(), error) {
+	f, err := os.OpenFile(path+".lock", os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return nil, err
+	}
+	deadline := time.Now().Add(tokenCacheLockWait)
+	for {
+		ok, err := tryLockFile(f)
+		if err != nil {
+			f.Close()
+			return nil, err
+		}
+		if ok {
+			return func() {
+				unlockFile(f)
+				f.Close()
+			}, nil
+		}
+		if time.Now().After(deadline) {
+			f.Close()
+			return nil, errTokenCacheBusy
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
 }
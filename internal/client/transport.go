@@ -0,0 +1,70 @@
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+)
+
+// TransportOptions configures proxying and TLS verification of API
+// requests, for corporate networks with TLS interception.
+type TransportOptions struct {
+	// ProxyURL is the proxy for all requests; empty uses HTTPS_PROXY,
+	// HTTP_PROXY and NO_PROXY from the environment.
+	ProxyURL string
+	// CACertFile is a PEM bundle trusted in addition to the system roots.
+	CACertFile string
+	// ClientCertFile and ClientKeyFile are an optional client certificate.
+	ClientCertFile string
+	ClientKeyFile  string
+	// Insecure disables certificate verification.
+	Insecure bool
+}
+
+// InsecureTLS reports that certificate verification is disabled, so the UI
+// can warn about it. It is set by NewHTTPTransport.
+var InsecureTLS bool
+
+// NewHTTPTransport builds the base transport for API requests from o. The
+// caller installs it as http.DefaultTransport, which every client, the
+// recorder and the limiter end up using.
+func NewHTTPTransport(o TransportOptions) (*http.Transport, error) {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	if o.ProxyURL != "" {
+		u, err := url.Parse(o.ProxyURL)
+		if err != nil || u.Host == "" {
+			return nil, fmt.Errorf("invalid proxy URL %q", o.ProxyURL)
+		}
+		t.Proxy = http.ProxyURL(u)
+	}
+	cfg := &tls.Config{MinVersion: tls.VersionTLS12}
+	if o.CACertFile != "" {
+		pem, err := os.ReadFile(o.CACertFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading CA bundle: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no PEM certificates found in %s", o.CACertFile)
+		}
+		cfg.RootCAs = pool
+	}
+	if o.ClientCertFile != "" {
+		cert, err := tls.LoadX509KeyPair(o.ClientCertFile, o.ClientKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("loading client certificate: %w", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+	cfg.InsecureSkipVerify = o.Insecure
+	consoleTLS = cfg
+	InsecureTLS = o.Insecure
+	t.TLSClientConfig = cfg
+	return t, nil
+}
@@ -0,0 +1,89 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gophercloud/gophercloud/openstack/blockstorage/v3/volumes"
+)
+
+// VolumeAction is a change of a volume that Cinder only accepts in some
+// statuses.
+type VolumeAction string
+
+// Volume actions checked by VolumeTransition.
+const (
+	VolumeDelete      VolumeAction = "delete"
+	VolumeForceDelete VolumeAction = "force delete"
+	VolumeAttach      VolumeAction = "attach"
+	VolumeDetach      VolumeAction = "detach"
+	VolumeSnapshot    VolumeAction = "snapshot"
+)
+
+// volumeTransitions maps a volume status to the actions Cinder accepts in
+// it and the status each one moves the volume to. A force delete is an
+// admin action accepted in any status; attaching an in-use volume is only
+// possible when it is multiattach. Statuses missing here (creating,
+// attaching, deleting, ...) accept nothing but a force delete.
+var volumeTransitions = map[string]map[VolumeAction]string{
+	"available":       {VolumeDelete: "deleting", VolumeAttach: "attaching", VolumeSnapshot: "available"},
+	"in-use":          {VolumeDetach: "detaching", VolumeSnapshot: "in-use"},
+	"error":           {VolumeDelete: "deleting"},
+	"error_restoring": {VolumeDelete: "deleting"},
+	"error_extending": {VolumeDelete: "deleting"},
+	"error_managing":  {VolumeDelete: "deleting"},
+}
+
+// VolumeStateError says why Cinder refuses an action in the volume's status.
+type VolumeStateError struct {
+	Action VolumeAction
+	Status string
+	// Hint says what makes the action possible, if anything.
+	Hint string
+}
+
+func (e *VolumeStateError) Error() string {
+	msg := fmt.Sprintf("cannot %s a volume that is %s", e.Action, e.Status)
+	if e.Hint != "" {
+		msg += "; " + e.Hint
+	}
+	return msg
+}
+
+// VolumeTransition returns the status v moves to when action is applied,
+// or a *VolumeStateError when Cinder would refuse it in v's status.
+func VolumeTransition(v volumes.Volume, action VolumeAction) (string, error) {
+	status := strings.ToLower(v.Status)
+	switch {
+	case action == VolumeForceDelete:
+		return "deleting", nil
+	case action == VolumeAttach && status == "in-use" && v.Multiattach:
+		return "attaching", nil
+	}
+	if next, ok := volumeTransitions[status][action]; ok {
+		return next, nil
+	}
+	return "", &VolumeStateError{Action: action, Status: v.Status, Hint: volumeHint(status, action)}
+}
+
+// volumeHint suggests how to get a volume in status to accept action.
+func volumeHint(status string, action VolumeAction) string {
+	_, stable := volumeTransitions[status]
+	switch {
+	case !stable:
+		return "wait until it is no longer " + status + ", or force delete it (admin)"
+	case action == VolumeDelete && status == "in-use":
+		return "detach it first, or force delete it (admin)"
+	case action == VolumeAttach && status == "in-use":
+		return "it is not multiattach"
+	case action == VolumeDetach:
+		return "it is not attached"
+	}
+	return ""
+}
+
+// SnapshotNeedsForce reports whether a snapshot of v must be forced, as
+// Cinder requires for an attached volume.
+func SnapshotNeedsForce(v volumes.Volume) bool {
+	return strings.EqualFold(v.Status, "in-use")
+}
@@ -0,0 +1,75 @@
+package client
+
+import (
+	"context"
+
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/vpnaas/endpointgroups"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/vpnaas/ikepolicies"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/vpnaas/ipsecpolicies"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/vpnaas/services"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/vpnaas/siteconnections"
+)
+
+// Type aliases for the VPN-as-a-service resources.
+type VPNService = services.Service
+type IKEPolicy = ikepolicies.Policy
+type IPSecPolicy = ipsecpolicies.Policy
+type VPNConnection = siteconnections.Connection
+type VPNEndpointGroup = endpointgroups.EndpointGroup
+
+// ListVPNServices returns the VPN services visible to the project.
+func (c *networkClient) ListVPNServices(ctx context.Context) ([]VPNService, error) {
+	_ = ctx // ctx currently unused
+	allPages, err := services.List(c.client, services.ListOpts{}).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	return services.ExtractServices(allPages)
+}
+
+// ListIKEPolicies returns the IKE policies visible to the project.
+func (c *networkClient) ListIKEPolicies(ctx context.Context) ([]IKEPolicy, error) {
+	_ = ctx // ctx currently unused
+	allPages, err := ikepolicies.List(c.client, ikepolicies.ListOpts{}).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	return ikepolicies.ExtractPolicies(allPages)
+}
+
+// ListIPSecPolicies returns the IPsec policies visible to the project.
+func (c *networkClient) ListIPSecPolicies(ctx context.Context) ([]IPSecPolicy, error) {
+	_ = ctx // ctx currently unused
+	allPages, err := ipsecpolicies.List(c.client, ipsecpolicies.ListOpts{}).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	return ipsecpolicies.ExtractPolicies(allPages)
+}
+
+// ListVPNConnections returns the IPsec site connections visible to the project.
+func (c *networkClient) ListVPNConnections(ctx context.Context) ([]VPNConnection, error) {
+	_ = ctx // ctx currently unused
+	allPages, err := siteconnections.List(c.client, siteconnections.ListOpts{}).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	return siteconnections.ExtractConnections(allPages)
+}
+
+// GetVPNConnection returns one IPsec site connection.
+func (c *networkClient) GetVPNConnection(ctx context.Context, id string) (*VPNConnection, error) {
+	_ = ctx // ctx currently unused
+	return siteconnections.Get(c.client, id).Extract()
+}
+
+// ListVPNEndpointGroups returns the VPN endpoint groups, which hold the local
+// subnets and peer CIDRs of connections that do not set peer_cidrs.
+func (c *networkClient) ListVPNEndpointGroups(ctx context.Context) ([]VPNEndpointGroup, error) {
+	_ = ctx // ctx currently unused
+	allPages, err := endpointgroups.List(c.client, endpointgroups.ListOpts{}).AllPages()
+	if err != nil {
+		return nil, err
+	}
+	return endpointgroups.ExtractEndpointGroups(allPages)
+}
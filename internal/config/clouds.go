@@ -4,35 +4,94 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sync"
 
 	"github.com/gophercloud/gophercloud"
 	"github.com/gophercloud/utils/openstack/clientconfig"
 )
 
-// LoadAuthOptions loads the authentication options for the given cloud name
-// from the clouds.yaml file. If cloudsPath is empty it defaults to
-// $HOME/.config/openstack/clouds.yaml.
-func LoadAuthOptions(cloudName, cloudsPath string) (gophercloud.AuthOptions, error) {
-	if cloudsPath == "" {
-		home, err := os.UserHomeDir()
-		if err != nil {
-			return gophercloud.AuthOptions{}, fmt.Errorf("cannot determine home directory: %w", err)
-		}
-		cloudsPath = filepath.Join(home, ".config", "openstack", "clouds.yaml")
+// envMu serialises the temporary OS_CLIENT_CONFIG_FILE override, since
+// clouds can be loaded concurrently (e.g. connection tests).
+var envMu sync.Mutex
+
+// CloudsPath returns cloudsPath, or $HOME/.config/openstack/clouds.yaml
+// when it is empty.
+func CloudsPath(cloudsPath string) (string, error) {
+	if cloudsPath != "" {
+		return cloudsPath, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("cannot determine home directory: %w", err)
 	}
+	return filepath.Join(home, ".config", "openstack", "clouds.yaml"), nil
+}
 
-	// Set OS_CLIENT_CONFIG_FILE to point to the custom clouds.yaml
+// withCloudsFile runs fn with OS_CLIENT_CONFIG_FILE pointing to
+// cloudsPath, which is how clientconfig is told which file to read.
+func withCloudsFile(cloudsPath string, fn func() error) error {
+	envMu.Lock()
+	defer envMu.Unlock()
 	orig := os.Getenv("OS_CLIENT_CONFIG_FILE")
 	_ = os.Setenv("OS_CLIENT_CONFIG_FILE", cloudsPath)
 	defer os.Setenv("OS_CLIENT_CONFIG_FILE", orig)
+	return fn()
+}
+
+// LoadAuthOptions loads the authentication options for the given cloud name
+// from the clouds.yaml file. If cloudsPath is empty it defaults to
+// $HOME/.config/openstack/clouds.yaml.
+func LoadAuthOptions(cloudName, cloudsPath string) (gophercloud.AuthOptions, error) {
+	cloudsPath, err := CloudsPath(cloudsPath)
+	if err != nil {
+		return gophercloud.AuthOptions{}, err
+	}
 
 	// Build client options
 	clientOpts := &clientconfig.ClientOpts{Cloud: cloudName}
 
 	// Get gophercloud.AuthOptions
-	authOptsPtr, err := clientconfig.AuthOptions(clientOpts)
+	var authOptsPtr *gophercloud.AuthOptions
+	err = withCloudsFile(cloudsPath, func() (err error) {
+		authOptsPtr, err = clientconfig.AuthOptions(clientOpts)
+		return err
+	})
 	if err != nil {
 		return gophercloud.AuthOptions{}, fmt.Errorf("failed to load auth options for cloud %q: %w", cloudName, err)
 	}
 	return *authOptsPtr, nil
 }
+
+// TLSSettings are the TLS options of a clouds.yaml entry.
+type TLSSettings struct {
+	CACertFile     string
+	ClientCertFile string
+	ClientKeyFile  string
+	// Insecure is set by "verify: false".
+	Insecure bool
+}
+
+// LoadTLSSettings reads the cacert, cert, key and verify options of the
+// given cloud. OS_CACERT applies when the cloud sets no cacert.
+func LoadTLSSettings(cloudName, cloudsPath string) (TLSSettings, error) {
+	cloudsPath, err := CloudsPath(cloudsPath)
+	if err != nil {
+		return TLSSettings{}, err
+	}
+	var cloud *clientconfig.Cloud
+	err = withCloudsFile(cloudsPath, func() (err error) {
+		cloud, err = clientconfig.GetCloudFromYAML(&clientconfig.ClientOpts{Cloud: cloudName})
+		return err
+	})
+	if err != nil {
+		return TLSSettings{}, fmt.Errorf("failed to load TLS settings for cloud %q: %w", cloudName, err)
+	}
+	s := TLSSettings{CACertFile: cloud.CACertFile, ClientCertFile: cloud.ClientCertFile, ClientKeyFile: cloud.ClientKeyFile}
+	if s.CACertFile == "" {
+		s.CACertFile = os.Getenv("OS_CACERT")
+	}
+	if cloud.Verify != nil && !*cloud.Verify {
+		s.Insecure = true
+	}
+	return s, nil
+}
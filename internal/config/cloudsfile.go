@@ -0,0 +1,199 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Auth types written by AddCloud, as named in clouds.yaml.
+const (
+	AuthPassword              = "password"
+	AuthApplicationCredential = "v3applicationcredential"
+)
+
+// CloudSummary describes a cloud entry of clouds.yaml.
+type CloudSummary struct {
+	Name     string
+	AuthType string
+	AuthURL  string
+	Region   string
+	Project  string
+}
+
+// cloudsFile is the subset of clouds.yaml read by ListClouds.
+type cloudsFile struct {
+	Clouds map[string]struct {
+		AuthType   string `yaml:"auth_type"`
+		RegionName string `yaml:"region_name"`
+		Auth       struct {
+			AuthURL                 string `yaml:"auth_url"`
+			ProjectName             string `yaml:"project_name"`
+			ProjectID               string `yaml:"project_id"`
+			ApplicationCredentialID string `yaml:"application_credential_id"`
+		} `yaml:"auth"`
+	} `yaml:"clouds"`
+}
+
+// ListClouds returns the clouds defined in the clouds.yaml at cloudsPath
+// (see CloudsPath), sorted by name. A missing file yields no clouds.
+func ListClouds(cloudsPath string) ([]CloudSummary, error) {
+	path, err := CloudsPath(cloudsPath)
+	if err != nil {
+		return nil, err
+	}
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var f cloudsFile
+	if err := yaml.Unmarshal(b, &f); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", path, err)
+	}
+	var out []CloudSummary
+	for name, c := range f.Clouds {
+		s := CloudSummary{Name: name, AuthType: c.AuthType, AuthURL: c.Auth.AuthURL, Region: c.RegionName, Project: c.Auth.ProjectName}
+		if s.Project == "" {
+			s.Project = c.Auth.ProjectID
+		}
+		if s.AuthType == "" {
+			s.AuthType = AuthPassword
+			if c.Auth.ApplicationCredentialID != "" {
+				s.AuthType = AuthApplicationCredential
+			}
+		}
+		out = append(out, s)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out, nil
+}
+
+// CloudEntry is a new cloud to add to clouds.yaml. Username and Password
+// hold the application credential ID and secret for
+// AuthApplicationCredential.
+type CloudEntry struct {
+	Name          string
+	AuthURL       string
+	AuthType      string
+	Username      string
+	Password      string
+	ProjectName   string
+	UserDomain    string
+	ProjectDomain string
+	Region        string
+}
+
+// Validate checks the fields required by the entry's auth type.
+func (e CloudEntry) Validate() error {
+	if strings.TrimSpace(e.Name) == "" {
+		return errors.New("name is required")
+	}
+	u, err := url.Parse(e.AuthURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("auth URL %q must be an http(s) URL", e.AuthURL)
+	}
+	switch e.AuthType {
+	case AuthPassword:
+		if e.Username == "" || e.ProjectName == "" {
+			return errors.New("username and project are required for password auth")
+		}
+	case AuthApplicationCredential:
+		if e.Username == "" || e.Password == "" {
+			return errors.New("application credential ID and secret are required")
+		}
+	default:
+		return fmt.Errorf("auth type must be %s or %s", AuthPassword, AuthApplicationCredential)
+	}
+	return nil
+}
+
+// yamlEntry renders the entry as a clouds.yaml cloud.
+func (e CloudEntry) yamlEntry() yaml.MapSlice {
+	var auth yaml.MapSlice
+	add := func(k, v string) {
+		if v != "" {
+			auth = append(auth, yaml.MapItem{Key: k, Value: v})
+		}
+	}
+	add("auth_url", e.AuthURL)
+	if e.AuthType == AuthApplicationCredential {
+		add("application_credential_id", e.Username)
+		add("application_credential_secret", e.Password)
+	} else {
+		add("username", e.Username)
+		add("password", e.Password)
+		add("project_name", e.ProjectName)
+		add("user_domain_name", e.UserDomain)
+		add("project_domain_name", e.ProjectDomain)
+	}
+	entry := yaml.MapSlice{{Key: "auth", Value: auth}, {Key: "auth_type", Value: e.AuthType}}
+	if e.Region != "" {
+		entry = append(entry, yaml.MapItem{Key: "region_name", Value: e.Region})
+	}
+	return append(entry, yaml.MapItem{Key: "identity_api_version", Value: 3})
+}
+
+// AddCloud appends a cloud to the clouds.yaml at cloudsPath, creating the
+// file if needed. The previous file is kept as clouds.yaml.bak, since
+// rewriting it drops YAML comments.
+func AddCloud(cloudsPath string, e CloudEntry) error {
+	if err := e.Validate(); err != nil {
+		return err
+	}
+	path, err := CloudsPath(cloudsPath)
+	if err != nil {
+		return err
+	}
+	var doc yaml.MapSlice
+	old, err := os.ReadFile(path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+			return err
+		}
+	case err != nil:
+		return err
+	default:
+		if err := yaml.Unmarshal(old, &doc); err != nil {
+			return fmt.Errorf("invalid %s: %w", path, err)
+		}
+	}
+
+	idx := -1
+	for i, item := range doc {
+		if item.Key == "clouds" {
+			idx = i
+		}
+	}
+	if idx < 0 {
+		doc = append(doc, yaml.MapItem{Key: "clouds", Value: yaml.MapSlice{}})
+		idx = len(doc) - 1
+	}
+	clouds, _ := doc[idx].Value.(yaml.MapSlice)
+	for _, c := range clouds {
+		if c.Key == e.Name {
+			return fmt.Errorf("cloud %q already exists in %s", e.Name, path)
+		}
+	}
+	doc[idx].Value = append(clouds, yaml.MapItem{Key: e.Name, Value: e.yamlEntry()})
+
+	out, err := yaml.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	if old != nil {
+		if err := os.WriteFile(path+".bak", old, 0o600); err != nil {
+			return fmt.Errorf("backing up %s: %w", path, err)
+		}
+	}
+	return os.WriteFile(path, out, 0o600)
+}
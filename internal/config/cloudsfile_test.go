@@ -0,0 +1,64 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestAddCloudKeepsExistingClouds(t *testing.T) {
+	cloudsPath := filepath.Join(t.TempDir(), "clouds.yaml")
+	existing := `clouds:
+  prod:
+    auth:
+      auth_url: https://prod.example.com:5000/v3
+      application_credential_id: abc
+      application_credential_secret: s3cret
+    region_name: RegionOne
+`
+	if err := os.WriteFile(cloudsPath, []byte(existing), 0600); err != nil {
+		t.Fatalf("write clouds.yaml: %v", err)
+	}
+	entry := CloudEntry{Name: "lab", AuthURL: "http://lab.example.com:5000/v3", AuthType: AuthPassword, Username: "alice", Password: "pw", ProjectName: "demo", UserDomain: "Default", ProjectDomain: "Default"}
+	if err := AddCloud(cloudsPath, entry); err != nil {
+		t.Fatalf("AddCloud: %v", err)
+	}
+	if err := AddCloud(cloudsPath, entry); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("expected a duplicate error, got %v", err)
+	}
+
+	clouds, err := ListClouds(cloudsPath)
+	if err != nil {
+		t.Fatalf("ListClouds: %v", err)
+	}
+	if len(clouds) != 2 || clouds[0].Name != "lab" || clouds[1].Name != "prod" {
+		t.Fatalf("unexpected clouds: %+v", clouds)
+	}
+	if clouds[1].AuthType != AuthApplicationCredential || clouds[1].Region != "RegionOne" {
+		t.Errorf("prod summary wrong: %+v", clouds[1])
+	}
+	opts, err := LoadAuthOptions("lab", cloudsPath)
+	if err != nil {
+		t.Fatalf("LoadAuthOptions: %v", err)
+	}
+	if opts.Username != "alice" || opts.TenantName != "demo" {
+		t.Errorf("unexpected auth options: %+v", opts)
+	}
+	if _, err := os.Stat(cloudsPath + ".bak"); err != nil {
+		t.Errorf("expected a backup of the previous file: %v", err)
+	}
+}
+
+func TestCloudEntryValidate(t *testing.T) {
+	for _, e := range []CloudEntry{
+		{Name: "", AuthURL: "https://x", AuthType: AuthPassword, Username: "u", ProjectName: "p"},
+		{Name: "a", AuthURL: "keystone:5000", AuthType: AuthPassword, Username: "u", ProjectName: "p"},
+		{Name: "a", AuthURL: "https://x", AuthType: AuthApplicationCredential, Username: "id"},
+		{Name: "a", AuthURL: "https://x", AuthType: "token"},
+	} {
+		if err := e.Validate(); err == nil {
+			t.Errorf("expected %+v to be rejected", e)
+		}
+	}
+}
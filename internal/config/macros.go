@@ -0,0 +1,105 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Macro is a named sequence of actions replayed against a server or volume.
+// Steps are stored the way they are typed, e.g. "wait available".
+type Macro struct {
+	Name  string   `yaml:"name"`
+	Kind  string   `yaml:"kind"`
+	Steps []string `yaml:"steps"`
+}
+
+// macrosFile is the document of the macros file.
+type macrosFile struct {
+	Macros []Macro `yaml:"macros"`
+}
+
+// MacrosPath returns macrosPath, or macros.yaml in the ostui directory of
+// the user's configuration directory (e.g. $HOME/.config/ostui) when it is
+// empty.
+func MacrosPath(macrosPath string) (string, error) {
+	if macrosPath != "" {
+		return macrosPath, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("cannot determine configuration directory: %w", err)
+	}
+	return filepath.Join(dir, "ostui", "macros.yaml"), nil
+}
+
+// LoadMacros returns the macros stored at path, sorted by name. A missing
+// file yields no macros.
+func LoadMacros(path string) ([]Macro, error) {
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var f macrosFile
+	if err := yaml.Unmarshal(b, &f); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", path, err)
+	}
+	sort.Slice(f.Macros, func(i, j int) bool { return f.Macros[i].Name < f.Macros[j].Name })
+	return f.Macros, nil
+}
+
+// SaveMacro stores m at path, replacing a macro of the same name.
+func SaveMacro(path string, m Macro) error {
+	list, err := LoadMacros(path)
+	if err != nil {
+		return err
+	}
+	replaced := false
+	for i := range list {
+		if list[i].Name == m.Name {
+			list[i], replaced = m, true
+		}
+	}
+	if !replaced {
+		list = append(list, m)
+	}
+	return writeMacros(path, list)
+}
+
+// DeleteMacro removes the macro called name from path.
+func DeleteMacro(path, name string) error {
+	list, err := LoadMacros(path)
+	if err != nil {
+		return err
+	}
+	out := list[:0]
+	for _, m := range list {
+		if m.Name != name {
+			out = append(out, m)
+		}
+	}
+	if len(out) == len(list) {
+		return fmt.Errorf("no macro named %q", name)
+	}
+	return writeMacros(path, out)
+}
+
+// writeMacros replaces the macros file, creating its directory.
+func writeMacros(path string, list []Macro) error {
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	b, err := yaml.Marshal(macrosFile{Macros: list})
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(path, b, 0o600)
+}
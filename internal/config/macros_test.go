@@ -0,0 +1,41 @@
+package config
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSaveAndDeleteMacros(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ostui", "macros.yaml")
+	if list, err := LoadMacros(path); err != nil || list != nil {
+		t.Fatalf("expected no macros from a missing file, got %v, %v", list, err)
+	}
+	backup := Macro{Name: "backup", Kind: "volume", Steps: []string{"snapshot", "detach", "wait available"}}
+	if err := SaveMacro(path, backup); err != nil {
+		t.Fatalf("SaveMacro: %v", err)
+	}
+	if err := SaveMacro(path, Macro{Name: "bounce", Kind: "server", Steps: []string{"stop", "wait SHUTOFF", "start"}}); err != nil {
+		t.Fatalf("SaveMacro: %v", err)
+	}
+	backup.Steps = []string{"snapshot"}
+	if err := SaveMacro(path, backup); err != nil {
+		t.Fatalf("SaveMacro: %v", err)
+	}
+	list, err := LoadMacros(path)
+	if err != nil {
+		t.Fatalf("LoadMacros: %v", err)
+	}
+	if len(list) != 2 || list[0].Name != "backup" || len(list[0].Steps) != 1 || list[1].Steps[1] != "wait SHUTOFF" {
+		t.Fatalf("unexpected macros %+v", list)
+	}
+	if err := DeleteMacro(path, "backup"); err != nil {
+		t.Fatalf("DeleteMacro: %v", err)
+	}
+	if err := DeleteMacro(path, "backup"); err == nil || !strings.Contains(err.Error(), "no macro") {
+		t.Errorf("expected a missing macro error, got %v", err)
+	}
+	if list, _ := LoadMacros(path); len(list) != 1 || list[0].Name != "bounce" {
+		t.Errorf("unexpected macros after delete %+v", list)
+	}
+}
@@ -0,0 +1,73 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v2"
+)
+
+// ServerSchedule stops and/or starts a server at fixed times of the day
+// while ostui runs. Times are local HH:MM; an empty time skips that action.
+type ServerSchedule struct {
+	// Cloud is the clouds.yaml entry the server belongs to; schedules of
+	// other clouds are kept but not run.
+	Cloud string `yaml:"cloud"`
+	// Server is the name or ID of the server.
+	Server string `yaml:"server"`
+	Stop   string `yaml:"stop,omitempty"`
+	Start  string `yaml:"start,omitempty"`
+	// Days are the weekdays the schedule runs on, e.g. [mon, tue]; empty
+	// means every day.
+	Days    []string `yaml:"days,omitempty"`
+	Enabled bool     `yaml:"enabled"`
+}
+
+// schedulesFile is the document of the schedules file.
+type schedulesFile struct {
+	Schedules []ServerSchedule `yaml:"schedules"`
+}
+
+// SchedulesPath returns schedulesPath, or schedules.yaml in the ostui
+// directory of the user's configuration directory when it is empty.
+func SchedulesPath(schedulesPath string) (string, error) {
+	if schedulesPath != "" {
+		return schedulesPath, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("cannot determine configuration directory: %w", err)
+	}
+	return filepath.Join(dir, "ostui", "schedules.yaml"), nil
+}
+
+// LoadSchedules returns the schedules stored at path, in file order. A
+// missing file yields no schedules.
+func LoadSchedules(path string) ([]ServerSchedule, error) {
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var f schedulesFile
+	if err := yaml.Unmarshal(b, &f); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", path, err)
+	}
+	return f.Schedules, nil
+}
+
+// SaveSchedules replaces the schedules file, creating its directory.
+func SaveSchedules(path string, list []ServerSchedule) error {
+	b, err := yaml.Marshal(schedulesFile{Schedules: list})
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(path, b, 0o600)
+}
@@ -0,0 +1,27 @@
+package config
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestSaveAndLoadSchedules(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ostui", "schedules.yaml")
+	if list, err := LoadSchedules(path); err != nil || list != nil {
+		t.Fatalf("expected no schedules from a missing file, got %v, %v", list, err)
+	}
+	want := []ServerSchedule{
+		{Cloud: "dev", Server: "web-dev", Stop: "19:00", Start: "08:00", Days: []string{"mon", "fri"}, Enabled: true},
+		{Cloud: "dev", Server: "db-dev", Stop: "20:00"},
+	}
+	if err := SaveSchedules(path, want); err != nil {
+		t.Fatalf("SaveSchedules: %v", err)
+	}
+	list, err := LoadSchedules(path)
+	if err != nil {
+		t.Fatalf("LoadSchedules: %v", err)
+	}
+	if len(list) != 2 || list[0].Server != "web-dev" || len(list[0].Days) != 2 || !list[0].Enabled || list[1].Start != "" || list[1].Enabled {
+		t.Fatalf("unexpected schedules %+v", list)
+	}
+}
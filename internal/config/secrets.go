@@ -0,0 +1,36 @@
+package config
+
+import "github.com/gophercloud/gophercloud"
+
+// Secret kinds reported by MissingSecret.
+const (
+	SecretPassword              = "password"
+	SecretApplicationCredential = "application credential secret"
+)
+
+// MissingSecret returns the kind of secret authentication with opts still
+// needs, or "" when opts are complete. clouds.yaml often omits passwords so
+// that they are not stored in plain text.
+func MissingSecret(opts gophercloud.AuthOptions) string {
+	switch {
+	case opts.TokenID != "":
+		return ""
+	case opts.ApplicationCredentialID != "" || opts.ApplicationCredentialName != "":
+		if opts.ApplicationCredentialSecret == "" {
+			return SecretApplicationCredential
+		}
+	case opts.Password == "" && (opts.Username != "" || opts.UserID != ""):
+		return SecretPassword
+	}
+	return ""
+}
+
+// SetSecret stores secret in the field of opts that MissingSecret reported
+// as kind.
+func SetSecret(opts *gophercloud.AuthOptions, kind, secret string) {
+	if kind == SecretApplicationCredential {
+		opts.ApplicationCredentialSecret = secret
+		return
+	}
+	opts.Password = secret
+}
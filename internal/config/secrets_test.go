@@ -0,0 +1,28 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/gophercloud/gophercloud"
+)
+
+func TestMissingSecret(t *testing.T) {
+	cases := []struct {
+		opts gophercloud.AuthOptions
+		want string
+	}{
+		{gophercloud.AuthOptions{Username: "alice"}, SecretPassword},
+		{gophercloud.AuthOptions{Username: "alice", Password: "pw"}, ""},
+		{gophercloud.AuthOptions{ApplicationCredentialID: "id"}, SecretApplicationCredential},
+		{gophercloud.AuthOptions{Username: "alice", TokenID: "tok"}, ""},
+	}
+	for _, c := range cases {
+		if got := MissingSecret(c.opts); got != c.want {
+			t.Errorf("MissingSecret(%+v) = %q, want %q", c.opts, got, c.want)
+		}
+		SetSecret(&c.opts, c.want, "s3cret")
+		if c.want != "" && MissingSecret(c.opts) != "" {
+			t.Errorf("SetSecret(%q) did not complete %+v", c.want, c.opts)
+		}
+	}
+}
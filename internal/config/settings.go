@@ -0,0 +1,59 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Settings are the user preferences read from the settings file.
+type Settings struct {
+	// Locale selects the language of the UI, e.g. "it"; empty is English.
+	Locale string `yaml:"locale"`
+	// CloudColors colors clouds by name, e.g. prod: red, so the layout
+	// shows which one a session is on. Red clouds also guard destructive
+	// confirmations.
+	CloudColors map[string]string `yaml:"cloud_colors"`
+	// SearchCache keeps the index of the global search in the cache
+	// directory, so a new session searches at once what the last one
+	// listed.
+	SearchCache bool `yaml:"search_cache"`
+	// IdleLock and IdleExit, e.g. 15m, lock the screen and quit after that
+	// long without input; --idle-lock and --idle-exit override them.
+	IdleLock time.Duration `yaml:"idle_lock"`
+	IdleExit time.Duration `yaml:"idle_exit"`
+}
+
+// SettingsPath returns settingsPath, or config.yaml in the ostui directory
+// of the user's configuration directory when it is empty.
+func SettingsPath(settingsPath string) (string, error) {
+	if settingsPath != "" {
+		return settingsPath, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("cannot determine configuration directory: %w", err)
+	}
+	return filepath.Join(dir, "ostui", "config.yaml"), nil
+}
+
+// LoadSettings reads the settings at path. A missing file yields the
+// defaults.
+func LoadSettings(path string) (Settings, error) {
+	var s Settings
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return s, err
+	}
+	if err := yaml.UnmarshalStrict(b, &s); err != nil {
+		return s, fmt.Errorf("invalid %s: %w", path, err)
+	}
+	return s, nil
+}
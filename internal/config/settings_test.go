@@ -0,0 +1,41 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoadSettings(t *testing.T) {
+	dir := t.TempDir()
+	if s, err := LoadSettings(filepath.Join(dir, "missing.yaml")); err != nil || s.Locale != "" {
+		t.Fatalf("expected defaults from a missing file, got %+v, %v", s, err)
+	}
+	path := filepath.Join(dir, "config.yaml")
+	if err := os.WriteFile(path, []byte("locale: it\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if s, err := LoadSettings(path); err != nil || s.Locale != "it" {
+		t.Errorf("got %+v, %v", s, err)
+	}
+	if err := os.WriteFile(path, []byte("cloud_colors:\n  prod: red\n  staging: yellow\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if s, err := LoadSettings(path); err != nil || s.CloudColors["prod"] != "red" || s.CloudColors["staging"] != "yellow" {
+		t.Errorf("got %+v, %v", s, err)
+	}
+	if err := os.WriteFile(path, []byte("idle_lock: 15m\nidle_exit: 8h\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if s, err := LoadSettings(path); err != nil || s.IdleLock != 15*time.Minute || s.IdleExit != 8*time.Hour {
+		t.Errorf("got %+v, %v", s, err)
+	}
+	if err := os.WriteFile(path, []byte("langauge: it\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadSettings(path); err == nil || !strings.Contains(err.Error(), "invalid") {
+		t.Errorf("expected an error for an unknown key, got %v", err)
+	}
+}
@@ -0,0 +1,125 @@
+package demo
+
+import (
+	"context"
+	"fmt"
+
+	"ostui/internal/client"
+)
+
+func (c networkClient) ListNetworkAgents(ctx context.Context, agentType string) ([]client.NetworkAgent, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var out []client.NetworkAgent
+	for _, a := range c.agents {
+		if agentType == "" || a.AgentType == agentType {
+			out = append(out, a)
+		}
+	}
+	return out, nil
+}
+
+func (c networkClient) ListBGPSpeakers(ctx context.Context) ([]client.BGPSpeaker, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]client.BGPSpeaker(nil), c.bgpSpeakers...), nil
+}
+
+func (c networkClient) ListBGPPeers(ctx context.Context) ([]client.BGPPeer, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]client.BGPPeer(nil), c.bgpPeers...), nil
+}
+
+// ListBGPAdvertisedRoutes derives the routes like neutron does: the tenant
+// subnets behind routers with a gateway on a speaker network, and the
+// floating IP host routes, both via the router's external IP.
+func (c networkClient) ListBGPAdvertisedRoutes(ctx context.Context, speakerID string) ([]client.BGPRoute, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var spk *client.BGPSpeaker
+	for i := range c.bgpSpeakers {
+		if c.bgpSpeakers[i].ID == speakerID {
+			spk = &c.bgpSpeakers[i]
+		}
+	}
+	if spk == nil {
+		return nil, notFound("bgp speaker", speakerID)
+	}
+	nextHop := map[string]string{} // router ID -> external IP
+	for _, rt := range c.routers {
+		for _, n := range spk.Networks {
+			if rt.GatewayInfo.NetworkID == n && len(rt.GatewayInfo.ExternalFixedIPs) > 0 {
+				nextHop[rt.ID] = rt.GatewayInfo.ExternalFixedIPs[0].IPAddress
+			}
+		}
+	}
+	var routes []client.BGPRoute
+	if spk.AdvertiseTenantNetworks {
+		for _, p := range c.ports {
+			hop, ok := nextHop[p.DeviceID]
+			if p.DeviceOwner != "network:router_interface" || !ok || len(p.FixedIPs) == 0 {
+				continue
+			}
+			for _, s := range c.subnets {
+				if s.ID == p.FixedIPs[0].SubnetID && s.IPVersion == spk.IPVersion {
+					routes = append(routes, client.BGPRoute{Destination: s.CIDR, NextHop: hop})
+				}
+			}
+		}
+	}
+	if spk.AdvertiseFloatingIPHostRoutes && spk.IPVersion == 4 {
+		for _, f := range c.fips {
+			if hop, ok := nextHop[f.RouterID]; ok && f.PortID != "" {
+				routes = append(routes, client.BGPRoute{Destination: f.FloatingIP + "/32", NextHop: hop})
+			}
+		}
+	}
+	return routes, nil
+}
+
+func (c networkClient) ListBGPSpeakerAgents(ctx context.Context, speakerID string) ([]client.NetworkAgent, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var out []client.NetworkAgent
+	for _, id := range c.bgpAgents[speakerID] {
+		for _, a := range c.agents {
+			if a.ID == id {
+				out = append(out, a)
+			}
+		}
+	}
+	return out, nil
+}
+
+func (c networkClient) ScheduleBGPSpeaker(ctx context.Context, agentID, speakerID string) error {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, id := range c.bgpAgents[speakerID] {
+		if id == agentID {
+			return fmt.Errorf("bgp speaker %s is already hosted by agent %s", speakerID, agentID)
+		}
+	}
+	c.bgpAgents[speakerID] = append(c.bgpAgents[speakerID], agentID)
+	return nil
+}
+
+func (c networkClient) UnscheduleBGPSpeaker(ctx context.Context, agentID, speakerID string) error {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ids := c.bgpAgents[speakerID]
+	for i, id := range ids {
+		if id == agentID {
+			c.bgpAgents[speakerID] = append(ids[:i:i], ids[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("bgp speaker %s is not hosted by agent %s", speakerID, agentID)
+}
@@ -0,0 +1,1032 @@
+package demo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/blockstorage/v3/volumes"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/availabilityzones"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/hypervisors"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/instanceactions"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/keypairs"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/quotasets"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/flavors"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/images"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/ports"
+	"ostui/internal/client"
+)
+
+// computeClient implements client.ComputeClient on top of a demo Cloud.
+type computeClient struct{ *Cloud }
+
+// Compute returns a ComputeClient backed by the demo cloud.
+func (c *Cloud) Compute() client.ComputeClient { return computeClient{c} }
+
+func (c computeClient) ListInstances() ([]servers.Server, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.settleMigrations()
+	return append([]servers.Server(nil), c.servers...), nil
+}
+
+func (c computeClient) GetInstance(id string) (servers.Server, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.settleMigrations()
+	if i := indexOfServer(c.servers, id); i >= 0 {
+		return c.servers[i], nil
+	}
+	return servers.Server{}, notFound("server", id)
+}
+
+func (c computeClient) setStatus(id, status string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	i := indexOfServer(c.servers, id)
+	if i < 0 {
+		return notFound("server", id)
+	}
+	c.servers[i].Status = status
+	c.servers[i].Fault = servers.Fault{}
+	c.servers[i].Updated = time.Now().UTC()
+	return nil
+}
+
+func (c computeClient) StartInstance(id string) error { return c.setStatus(id, "ACTIVE") }
+
+func (c computeClient) StopInstance(id string) error { return c.setStatus(id, "SHUTOFF") }
+
+func (c computeClient) DeleteInstance(id string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	i := indexOfServer(c.servers, id)
+	if i < 0 {
+		return notFound("server", id)
+	}
+	c.servers = append(c.servers[:i], c.servers[i+1:]...)
+	// Like Nova: the ports go, their floating IPs are disassociated and
+	// the volumes detached.
+	ports := c.ports[:0]
+	for _, p := range c.ports {
+		if p.DeviceID != id {
+			ports = append(ports, p)
+			continue
+		}
+		for j := range c.fips {
+			if c.fips[j].PortID == p.ID {
+				c.fips[j].PortID, c.fips[j].FixedIP, c.fips[j].Status = "", "", "DOWN"
+			}
+		}
+	}
+	c.ports = ports
+	for j := range c.volumes {
+		v := &c.volumes[j]
+		var atts []volumes.Attachment
+		for _, a := range v.Attachments {
+			if a.ServerID != id {
+				atts = append(atts, a)
+			}
+		}
+		v.Attachments = atts
+		if len(atts) == 0 && v.Status == "in-use" {
+			v.Status = "available"
+		}
+	}
+	return nil
+}
+
+// CreateInstance boots a server on the first enabled hypervisor with a port
+// on each requested network. Unlike Nova the server is active at once.
+func (c computeClient) CreateInstance(ctx context.Context, opts servers.CreateOptsBuilder) (servers.Server, error) {
+	_ = ctx // ctx currently unused
+	co, ok := opts.(servers.CreateOpts)
+	if !ok {
+		return servers.Server{}, fmt.Errorf("unsupported create options %T", opts)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	fi := slices.IndexFunc(c.flavors, func(f flavors.Flavor) bool { return f.ID == co.FlavorRef })
+	if fi < 0 {
+		return servers.Server{}, notFound("flavor", co.FlavorRef)
+	}
+	fl := c.flavors[fi]
+	host := slices.IndexFunc(c.hypervisors, func(h hypervisors.Hypervisor) bool { return h.Status == "enabled" })
+	if host < 0 {
+		return servers.Server{}, fmt.Errorf("no valid host was found")
+	}
+	zone := co.AvailabilityZone
+	if zone == "" {
+		zone = c.zones[0]
+	}
+	c.seq++
+	now := time.Now().UTC()
+	srv := servers.Server{
+		ID: fmt.Sprintf("00000000-0000-4000-9000-%012x", c.seq), Name: co.Name, Status: "ACTIVE",
+		TenantID: c.projectID, Created: now, Updated: now,
+		Flavor:    map[string]interface{}{"id": fl.ID, "original_name": fl.Name, "vcpus": fl.VCPUs, "ram": fl.RAM},
+		Image:     map[string]interface{}{"id": co.ImageRef},
+		Addresses: map[string]interface{}{},
+		Metadata:  co.Metadata,
+	}
+	c.userData[srv.ID] = string(co.UserData)
+	var nets []servers.Network
+	if ns, ok := co.Networks.([]servers.Network); ok {
+		nets = ns
+	}
+	for _, n := range nets {
+		ni := indexOfNetwork(c.networks, n.UUID)
+		if ni < 0 {
+			return servers.Server{}, notFound("network", n.UUID)
+		}
+		net := c.networks[ni]
+		ip := fmt.Sprintf("10.%d.1.%d", ni, c.seq%250+1)
+		srv.Addresses[net.Name] = []interface{}{map[string]interface{}{"addr": ip, "version": 4, "OS-EXT-IPS:type": "fixed"}}
+		var fixed []ports.IP
+		if len(net.Subnets) > 0 {
+			fixed = fixedIP(net.Subnets[0], ip)
+		}
+		c.ports = append(c.ports, client.Port{ID: fmt.Sprintf("00000000-0000-4000-9100-%012x", c.seq), NetworkID: net.ID, Status: "ACTIVE", AdminStateUp: true,
+			DeviceOwner: "compute:" + zone, DeviceID: srv.ID, MACAddress: fmt.Sprintf("fa:16:3e:00:%02x:%02x", c.seq>>8&0xff, c.seq&0xff), FixedIPs: fixed})
+	}
+	c.servers = append(c.servers, srv)
+	c.serverHosts[srv.ID] = c.hypervisors[host].HypervisorHostname
+	c.serverZones[srv.ID] = zone
+	h := &c.hypervisors[host]
+	h.VCPUsUsed += fl.VCPUs
+	h.MemoryMBUsed += fl.RAM
+	h.LocalGBUsed += fl.Disk
+	return srv, nil
+}
+
+func (c computeClient) ListFlavors() ([]flavors.Flavor, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]flavors.Flavor(nil), c.flavors...), nil
+}
+
+func (c computeClient) ListKeypairs() ([]keypairs.KeyPair, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]keypairs.KeyPair(nil), c.keypairs...), nil
+}
+
+func (c computeClient) GetConsoleLog(id string, lines int) (string, error) {
+	srv, err := c.GetInstance(id)
+	if err != nil {
+		return "", err
+	}
+	var sb strings.Builder
+	start := srv.Created
+	for i := 0; i < lines && i < 60; i++ {
+		ts := start.Add(time.Duration(i) * 350 * time.Millisecond)
+		sb.WriteString(fmt.Sprintf("[%10.6f] %s\n", ts.Sub(start).Seconds(), bootLog[i%len(bootLog)]))
+	}
+	sb.WriteString(fmt.Sprintf("\n%s login: ", srv.Name))
+	return sb.String(), nil
+}
+
+var bootLog = []string{
+	"Linux version 6.8.0-demo (buildd@demo) #1 SMP PREEMPT_DYNAMIC",
+	"Command line: BOOT_IMAGE=/vmlinuz root=LABEL=cloudimg-rootfs ro console=ttyS0",
+	"KVM setup async PF for cpu 0",
+	"virtio_net virtio1 ens3: renamed from eth0",
+	"EXT4-fs (vda1): mounted filesystem with ordered data mode",
+	"systemd[1]: Reached target Network is Online.",
+	"cloud-init[612]: Cloud-init v. 24.1 running 'init' at boot.",
+	"cloud-init[612]: ci-info: +++++++++++++++Net device info++++++++++++++++",
+	"cloud-init[890]: Generating public/private ed25519 key pair.",
+	"cloud-init[1012]: Cloud-init v. 24.1 finished. Datasource DataSourceOpenStackLocal.",
+}
+
+func (c computeClient) GetConsoleURL(ctx context.Context, id, consoleType string) (string, error) {
+	_ = ctx // ctx currently unused
+	if _, err := c.GetInstance(id); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("https://console.demo.invalid/%s_auto.html?path=%%3Ftoken%%3D%s", consoleType, id), nil
+}
+
+func (c computeClient) ListHypervisors(ctx context.Context) ([]hypervisors.Hypervisor, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]hypervisors.Hypervisor(nil), c.hypervisors...), nil
+}
+
+func (c computeClient) GetHypervisor(ctx context.Context, id string) (*hypervisors.Hypervisor, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, h := range c.hypervisors {
+		if h.ID == id {
+			return &h, nil
+		}
+	}
+	return nil, notFound("hypervisor", id)
+}
+
+// ListAllocationRatios reports Nova's default ratios, except on the first
+// hosts, kept for pinned workloads without overcommit.
+func (c computeClient) ListAllocationRatios(ctx context.Context) (map[string]client.AllocationRatios, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	out := map[string]client.AllocationRatios{}
+	for i, hv := range c.hypervisors {
+		r := client.AllocationRatios{CPU: 16, RAM: 1.5, ReservedMB: 4096}
+		if i < 2 {
+			r.CPU, r.RAM = 1, 1
+		}
+		out[hv.HypervisorHostname] = r
+	}
+	return out, nil
+}
+
+func (c computeClient) ListAvailabilityZones(ctx context.Context) ([]availabilityzones.AvailabilityZone, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var out []availabilityzones.AvailabilityZone
+	for i, z := range c.zones {
+		hosts := availabilityzones.Hosts{}
+		for j, h := range c.hypervisors {
+			if j%len(c.zones) == i {
+				hosts[h.Service.Host] = availabilityzones.Services{"nova-compute": availabilityzones.ServiceState{Active: true, Available: h.Status == "enabled", UpdatedAt: time.Now().UTC()}}
+			}
+		}
+		out = append(out, availabilityzones.AvailabilityZone{ZoneName: z, ZoneState: availabilityzones.ZoneState{Available: true}, Hosts: hosts})
+	}
+	return out, nil
+}
+
+func (c computeClient) GetFlavor(ctx context.Context, flavorID string) (flavors.Flavor, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, f := range c.flavors {
+		if f.ID == flavorID {
+			return f, nil
+		}
+	}
+	return flavors.Flavor{}, notFound("flavor", flavorID)
+}
+
+func (c computeClient) GetKeypair(ctx context.Context, name string) (keypairs.KeyPair, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, k := range c.keypairs {
+		if k.Name == name {
+			return k, nil
+		}
+	}
+	return keypairs.KeyPair{}, notFound("keypair", name)
+}
+
+func (c computeClient) ListServerInterfaces(ctx context.Context, serverID string) ([]client.ServerInterface, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var out []client.ServerInterface
+	for _, p := range c.ports {
+		if p.DeviceID != serverID {
+			continue
+		}
+		var ips []string
+		for _, ip := range p.FixedIPs {
+			ips = append(ips, ip.IPAddress)
+		}
+		out = append(out, client.ServerInterface{PortID: p.ID, NetworkID: p.NetworkID, FixedIPs: ips, MACAddress: p.MACAddress})
+	}
+	return out, nil
+}
+
+func (c computeClient) ListServerVolumes(ctx context.Context, serverID string) ([]client.ServerVolume, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var out []client.ServerVolume
+	for _, v := range c.volumes {
+		for _, a := range v.Attachments {
+			if a.ServerID == serverID {
+				// Demo volumes are attached after boot, which Nova never
+				// deletes with the server.
+				keep := false
+				out = append(out, client.ServerVolume{ID: v.ID, VolumeID: v.ID, Device: a.Device, DeleteOnTermination: &keep})
+			}
+		}
+	}
+	return out, nil
+}
+
+func (c computeClient) AttachVolume(ctx context.Context, serverID, volumeID string) error {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	si := indexOfServer(c.servers, serverID)
+	if si < 0 {
+		return notFound("server", serverID)
+	}
+	for i := range c.volumes {
+		v := &c.volumes[i]
+		if v.ID != volumeID {
+			continue
+		}
+		if _, err := client.VolumeTransition(*v, client.VolumeAttach); err != nil {
+			return err
+		}
+		srv := &c.servers[si]
+		device := fmt.Sprintf("/dev/vd%c", 'b'+rune(len(srv.AttachedVolumes)))
+		v.Attachments = append(v.Attachments, volumes.Attachment{ID: v.ID, VolumeID: v.ID, ServerID: serverID, Device: device, AttachedAt: time.Now().UTC()})
+		v.Status = "in-use"
+		srv.AttachedVolumes = append(srv.AttachedVolumes, servers.AttachedVolume{ID: volumeID})
+		return nil
+	}
+	return notFound("volume", volumeID)
+}
+
+func (c computeClient) DetachVolume(ctx context.Context, serverID, volumeID string) error {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	si := indexOfServer(c.servers, serverID)
+	if si < 0 {
+		return notFound("server", serverID)
+	}
+	for i := range c.volumes {
+		v := &c.volumes[i]
+		if v.ID != volumeID {
+			continue
+		}
+		atts := v.Attachments[:0]
+		for _, a := range v.Attachments {
+			if a.ServerID != serverID {
+				atts = append(atts, a)
+			}
+		}
+		if len(atts) == len(v.Attachments) {
+			return fmt.Errorf("volume %s is not attached to server %s", volumeID, serverID)
+		}
+		v.Attachments = atts
+		if len(atts) == 0 {
+			v.Status = "available"
+		}
+		srv := &c.servers[si]
+		srv.AttachedVolumes = slices.DeleteFunc(srv.AttachedVolumes, func(a servers.AttachedVolume) bool { return a.ID == volumeID })
+		return nil
+	}
+	return notFound("volume", volumeID)
+}
+
+func (c computeClient) RebootInstance(ctx context.Context, id string, hard bool) error {
+	_ = ctx // ctx currently unused
+	return c.setStatus(id, "ACTIVE")
+}
+
+func (c computeClient) RebuildInstance(ctx context.Context, id string, opts servers.RebuildOptsBuilder) error {
+	_ = ctx // ctx currently unused
+	return c.setStatus(id, "ACTIVE")
+}
+
+// GetServerUserData returns the user data set on a server, or a cloud-config
+// naming the host for the generated servers.
+func (c computeClient) GetServerUserData(ctx context.Context, id string) (string, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	i := indexOfServer(c.servers, id)
+	if i < 0 {
+		return "", notFound("server", id)
+	}
+	if ud, ok := c.userData[id]; ok {
+		return ud, nil
+	}
+	return fmt.Sprintf("#cloud-config\nhostname: %s\npackage_update: true\npackages:\n  - chrony\n", c.servers[i].Name), nil
+}
+
+func (c computeClient) RebuildInstanceUserData(ctx context.Context, id, imageRef, userData string) error {
+	_ = ctx // ctx currently unused
+	if err := c.setStatus(id, "ACTIVE"); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.userData[id] = userData
+	return nil
+}
+
+func (c computeClient) ListServerZones(ctx context.Context) (map[string]string, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	zones := make(map[string]string, len(c.serverZones))
+	for id, z := range c.serverZones {
+		zones[id] = z
+	}
+	return zones, nil
+}
+
+func (c computeClient) EvacuateInstance(ctx context.Context, id, host string, onSharedStorage bool) error {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	i := indexOfServer(c.servers, id)
+	if i < 0 {
+		return notFound("server", id)
+	}
+	if host == "" {
+		// Pick the first other hypervisor, as the scheduler would.
+		for _, h := range c.hypervisors {
+			if h.HypervisorHostname != c.serverHosts[id] {
+				host = h.HypervisorHostname
+				break
+			}
+		}
+	}
+	c.serverHosts[id] = host
+	c.servers[i].Status = "ACTIVE"
+	c.servers[i].Fault = servers.Fault{}
+	c.servers[i].Updated = time.Now().UTC()
+	return nil
+}
+
+func (c computeClient) GetServerState(ctx context.Context, id string) (client.ServerState, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.settleMigrations()
+	i := indexOfServer(c.servers, id)
+	if i < 0 {
+		return client.ServerState{}, notFound("server", id)
+	}
+	s := c.servers[i]
+	if _, ok := c.migrating[id]; ok {
+		locked := c.locked[id]
+		return client.ServerState{Status: s.Status, TaskState: "migrating", VMState: "active", PowerState: 1, Locked: &locked}, nil
+	}
+	locked := c.locked[id]
+	return client.ServerState{Status: s.Status, VMState: strings.ToLower(s.Status), PowerState: 1, Locked: &locked}, nil
+}
+
+// GetServerDiagnostics derives counters from the server's age, so they grow
+// between refreshes like on a busy hypervisor.
+func (c computeClient) GetServerDiagnostics(ctx context.Context, id string) (client.ServerDiagnostics, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	i := indexOfServer(c.servers, id)
+	if i < 0 {
+		return client.ServerDiagnostics{}, notFound("server", id)
+	}
+	s := c.servers[i]
+	if s.Status != "ACTIVE" {
+		return client.ServerDiagnostics{}, fmt.Errorf("server %s is %s: diagnostics need a running domain", id, s.Status)
+	}
+	vcpus, ram := 1, 1024
+	for _, f := range c.flavors {
+		if f.ID == s.Flavor["id"] {
+			vcpus, ram = f.VCPUs, f.RAM
+		}
+	}
+	up := uint64(time.Since(s.Created).Seconds())
+	load := uint64(10 + (i*17)%60) // percent of one vCPU
+	d := client.ServerDiagnostics{State: "running", Driver: "libvirt", Uptime: int64(up), MemoryMaxMB: int64(ram), MemoryUsedMB: int64(ram) * int64(40+(i*13)%50) / 100}
+	for n := 0; n < vcpus; n++ {
+		d.CPUs = append(d.CPUs, client.CPUDiagnostics{ID: n, TimeNS: up * load * 1e7 / uint64(n+1)})
+	}
+	for _, p := range c.ports {
+		if p.DeviceID == id {
+			d.NICs = append(d.NICs, client.NICDiagnostics{Name: p.MACAddress, RxBytes: up * 52000 * load / 10, TxBytes: up * 31000 * load / 10, RxPackets: up * 60 * load, TxPackets: up * 45 * load})
+		}
+	}
+	d.Disks = append(d.Disks, client.DiskDiagnostics{Name: "vda", ReadBytes: up * 8192 * load, WriteBytes: up * 20480 * load, ReadRequests: up * 2 * load, WriteRequests: up * 5 * load})
+	return d, nil
+}
+
+// ResizeInstance moves the server to the flavor at once and leaves it in
+// VERIFY_RESIZE, as nova does without an automatic confirmation.
+func (c computeClient) ResizeInstance(ctx context.Context, id, flavorID string) error {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	i := indexOfServer(c.servers, id)
+	if i < 0 {
+		return notFound("server", id)
+	}
+	if st := c.servers[i].Status; st != "ACTIVE" && st != "SHUTOFF" {
+		return fmt.Errorf("cannot resize server %s while it is %s", id, st)
+	}
+	for _, fl := range c.flavors {
+		if fl.ID == flavorID {
+			c.resizedFrom[id] = c.servers[i].Flavor
+			c.servers[i].Flavor = map[string]interface{}{"id": fl.ID, "original_name": fl.Name, "vcpus": fl.VCPUs, "ram": fl.RAM}
+			c.servers[i].Status = "VERIFY_RESIZE"
+			c.servers[i].Updated = time.Now().UTC()
+			return nil
+		}
+	}
+	return notFound("flavor", flavorID)
+}
+
+func (c computeClient) ConfirmResize(ctx context.Context, id string) error {
+	_ = ctx // ctx currently unused
+	if err := c.transition(id, "confirm the resize of", "ACTIVE", "VERIFY_RESIZE"); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	delete(c.resizedFrom, id)
+	c.mu.Unlock()
+	return nil
+}
+
+// RevertResize restores the flavor the server had before the resize.
+func (c computeClient) RevertResize(ctx context.Context, id string) error {
+	_ = ctx // ctx currently unused
+	if err := c.transition(id, "revert the resize of", "ACTIVE", "VERIFY_RESIZE"); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if old, ok := c.resizedFrom[id]; ok {
+		c.servers[indexOfServer(c.servers, id)].Flavor = old
+		delete(c.resizedFrom, id)
+	}
+	return nil
+}
+
+func (c computeClient) ListInstanceActions(ctx context.Context, id string) ([]instanceactions.InstanceAction, error) {
+	srv, err := c.GetInstance(id)
+	if err != nil {
+		return nil, err
+	}
+	_ = ctx // ctx currently unused
+	return []instanceactions.InstanceAction{{Action: "create", InstanceUUID: srv.ID, RequestID: "req-" + srv.ID, UserID: srv.UserID, ProjectID: srv.TenantID, StartTime: srv.Created}}, nil
+}
+
+func (c computeClient) GetInstanceAction(ctx context.Context, id, requestID string) (instanceactions.InstanceActionDetail, error) {
+	srv, err := c.GetInstance(id)
+	if err != nil {
+		return instanceactions.InstanceActionDetail{}, err
+	}
+	_ = ctx // ctx currently unused
+	result := "Success"
+	var traceback string
+	if srv.Status == "ERROR" {
+		result = "Error"
+		traceback = "Traceback (most recent call last):\n  File \"nova/conductor/manager.py\", line 1580, in schedule_and_build_instances\nnova.exception.NoValidHost: No valid host was found."
+	}
+	events := []instanceactions.Event{{Event: "conductor_schedule_and_build_instances", Result: result, StartTime: srv.Created, FinishTime: srv.Created.Add(3 * time.Second), Traceback: traceback}}
+	return instanceactions.InstanceActionDetail{Action: "create", InstanceUUID: srv.ID, RequestID: requestID, UserID: srv.UserID, ProjectID: srv.TenantID, StartTime: srv.Created, Message: srv.Fault.Message, Events: &events}, nil
+}
+
+func (c computeClient) UpdateInstance(ctx context.Context, id string, opts servers.UpdateOpts) error {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	i := indexOfServer(c.servers, id)
+	if i < 0 {
+		return notFound("server", id)
+	}
+	if opts.Name != "" {
+		c.servers[i].Name = opts.Name
+	}
+	c.servers[i].Updated = time.Now().UTC()
+	return nil
+}
+
+func (c computeClient) ResetInstanceMetadata(ctx context.Context, id string, metadata map[string]string) error {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	i := indexOfServer(c.servers, id)
+	if i < 0 {
+		return notFound("server", id)
+	}
+	c.servers[i].Metadata = metadata
+	return nil
+}
+
+func (c computeClient) UpdateInstanceMetadata(ctx context.Context, id string, metadata map[string]string) error {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	i := indexOfServer(c.servers, id)
+	if i < 0 {
+		return notFound("server", id)
+	}
+	md := map[string]string{}
+	for k, v := range c.servers[i].Metadata {
+		md[k] = v
+	}
+	for k, v := range metadata {
+		md[k] = v
+	}
+	c.servers[i].Metadata = md
+	return nil
+}
+
+func (c computeClient) ListServerGroups(ctx context.Context) ([]client.ServerGroup, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]client.ServerGroup(nil), c.serverGroups...), nil
+}
+
+func (c computeClient) GetServerHypervisor(ctx context.Context, id string) (string, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.settleMigrations()
+	if indexOfServer(c.servers, id) < 0 {
+		return "", notFound("server", id)
+	}
+	return c.serverHosts[id], nil
+}
+
+func (c computeClient) GetQuotaSet(ctx context.Context, projectID string) (quotasets.QuotaDetailSet, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	cores, ram := 0, 0
+	for _, s := range c.servers {
+		cores += flavorInt(s.Flavor["vcpus"])
+		ram += flavorInt(s.Flavor["ram"])
+	}
+	return quotasets.QuotaDetailSet{
+		ID:             projectID,
+		Instances:      quotasets.QuotaDetail{InUse: len(c.servers), Limit: 500},
+		Cores:          quotasets.QuotaDetail{InUse: cores, Limit: 2000},
+		RAM:            quotasets.QuotaDetail{InUse: ram, Limit: 8 * 1024 * 1024},
+		KeyPairs:       quotasets.QuotaDetail{InUse: len(c.keypairs), Limit: 100},
+		ServerGroups:   quotasets.QuotaDetail{Limit: 10},
+		MetadataItems:  quotasets.QuotaDetail{Limit: 128},
+		SecurityGroups: quotasets.QuotaDetail{InUse: len(c.secGroups), Limit: 50},
+	}, nil
+}
+
+func (c computeClient) UpdateQuotaSet(ctx context.Context, projectID string, opts quotasets.UpdateOpts) error {
+	_ = ctx // ctx currently unused
+	return nil
+}
+
+func flavorInt(v interface{}) int {
+	if i, ok := v.(int); ok {
+		return i
+	}
+	return 0
+}
+
+// missingError is the error of a demo resource that does not exist. It
+// reads as a gophercloud 404, so the views tell a deleted resource from a
+// failure as they do against a cloud.
+type missingError struct{ msg string }
+
+func (e missingError) Error() string { return e.msg }
+
+// As lets errors.As match the error as a gophercloud 404.
+func (e missingError) As(target any) bool {
+	t, ok := target.(*gophercloud.ErrDefault404)
+	if ok {
+		t.Actual, t.Body = http.StatusNotFound, []byte(e.msg)
+	}
+	return ok
+}
+
+func notFound(kind, id string) error {
+	return missingError{msg: fmt.Sprintf("%s %s not found", kind, id)}
+}
+
+// liveMigrationTime is how long a demo live migration stays in progress.
+const liveMigrationTime = 3 * time.Second
+
+// liveMigration is a live migration in progress.
+type liveMigration struct {
+	target string // hypervisor hostname
+	done   time.Time
+}
+
+// imageUploadTime is how long the upload of a demo server snapshot takes.
+const imageUploadTime = 6 * time.Second
+
+// imageUpload is a server snapshot being uploaded to the image service.
+type imageUpload struct {
+	started, done time.Time
+}
+
+// settleImageUploads advances the snapshots being uploaded and activates
+// those whose time has come; the caller holds c.mu.
+func (c *Cloud) settleImageUploads() {
+	now := time.Now()
+	for id, up := range c.imageUploads {
+		i := slices.IndexFunc(c.images, func(img images.Image) bool { return img.ID == id })
+		if i < 0 {
+			delete(c.imageUploads, id)
+			continue
+		}
+		img := &c.images[i]
+		img.Updated = now.UTC().Format(time.RFC3339)
+		if now.Before(up.done) {
+			img.Progress = int(100 * now.Sub(up.started) / up.done.Sub(up.started))
+			continue
+		}
+		delete(c.imageUploads, id)
+		img.Status, img.Progress = "ACTIVE", 100
+	}
+}
+
+// hypervisorByService returns the index of the hypervisor of a compute
+// service host, or -1.
+func (c *Cloud) hypervisorByService(host string) int {
+	for i, h := range c.hypervisors {
+		if h.Service.Host == host || h.HypervisorHostname == host {
+			return i
+		}
+	}
+	return -1
+}
+
+// settleMigrations completes the live migrations whose time has come; the
+// caller holds c.mu.
+func (c *Cloud) settleMigrations() {
+	now := time.Now()
+	for id, lm := range c.migrating {
+		if now.Before(lm.done) {
+			continue
+		}
+		delete(c.migrating, id)
+		i := indexOfServer(c.servers, id)
+		if i < 0 {
+			continue
+		}
+		vcpus, ram := 0, 0
+		for _, f := range c.flavors {
+			if f.ID == c.servers[i].Flavor["id"] {
+				vcpus, ram = f.VCPUs, f.RAM
+			}
+		}
+		if from := c.hypervisorByService(c.serverHosts[id]); from >= 0 {
+			h := &c.hypervisors[from]
+			h.RunningVMs, h.VCPUsUsed, h.MemoryMBUsed = h.RunningVMs-1, h.VCPUsUsed-vcpus, h.MemoryMBUsed-ram
+			h.FreeRamMB = h.MemoryMB - h.MemoryMBUsed
+		}
+		if to := c.hypervisorByService(lm.target); to >= 0 {
+			h := &c.hypervisors[to]
+			h.RunningVMs, h.VCPUsUsed, h.MemoryMBUsed = h.RunningVMs+1, h.VCPUsUsed+vcpus, h.MemoryMBUsed+ram
+			h.FreeRamMB = h.MemoryMB - h.MemoryMBUsed
+		}
+		c.serverHosts[id] = lm.target
+		c.servers[i].Status = "ACTIVE"
+		c.servers[i].Updated = now.UTC()
+	}
+}
+
+func (c computeClient) ListComputeServices(ctx context.Context, host string) ([]client.ComputeService, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var out []client.ComputeService
+	for i, h := range c.hypervisors {
+		if host != "" && h.Service.Host != host {
+			continue
+		}
+		out = append(out, client.ComputeService{ID: h.Service.ID, Binary: "nova-compute", Host: h.Service.Host, Zone: c.zones[i%len(c.zones)], State: h.State, Status: h.Status, DisabledReason: h.Service.DisabledReason, UpdatedAt: time.Now().UTC()})
+	}
+	return out, nil
+}
+
+func (c computeClient) SetComputeServiceEnabled(ctx context.Context, id string, enabled bool, reason string) error {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i := range c.hypervisors {
+		h := &c.hypervisors[i]
+		if h.Service.ID != id {
+			continue
+		}
+		h.Status, h.Service.DisabledReason = "enabled", ""
+		if !enabled {
+			h.Status, h.Service.DisabledReason = "disabled", reason
+		}
+		return nil
+	}
+	return notFound("compute service", id)
+}
+
+func (c computeClient) ListHostInstances(ctx context.Context, host string) ([]servers.Server, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.settleMigrations()
+	hv := c.hypervisorByService(host)
+	if hv < 0 {
+		return nil, notFound("compute host", host)
+	}
+	var out []servers.Server
+	for _, s := range c.servers {
+		if c.serverHosts[s.ID] == c.hypervisors[hv].HypervisorHostname {
+			out = append(out, s)
+		}
+	}
+	return out, nil
+}
+
+// LiveMigrateInstance starts a live migration that completes after
+// liveMigrationTime. Without a target host the least loaded enabled
+// hypervisor is picked, as the scheduler would.
+func (c computeClient) LiveMigrateInstance(ctx context.Context, id, host string) error {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.settleMigrations()
+	i := indexOfServer(c.servers, id)
+	if i < 0 {
+		return notFound("server", id)
+	}
+	if s := c.servers[i].Status; s != "ACTIVE" && s != "PAUSED" {
+		return fmt.Errorf("cannot live-migrate server %s while it is %s", id, s)
+	}
+	source := c.serverHosts[id]
+	target := -1
+	if host != "" {
+		if target = c.hypervisorByService(host); target < 0 {
+			return notFound("compute host", host)
+		}
+	} else {
+		for j, h := range c.hypervisors {
+			if h.HypervisorHostname != source && h.Status == "enabled" && h.State == "up" && (target < 0 || h.RunningVMs < c.hypervisors[target].RunningVMs) {
+				target = j
+			}
+		}
+		if target < 0 {
+			return fmt.Errorf("no valid host was found: there are not enough hosts available")
+		}
+	}
+	if c.hypervisors[target].HypervisorHostname == source {
+		return fmt.Errorf("server %s is already on %s", id, source)
+	}
+	c.migrating[id] = liveMigration{target: c.hypervisors[target].HypervisorHostname, done: time.Now().Add(liveMigrationTime)}
+	c.servers[i].Status = "MIGRATING"
+	c.servers[i].Updated = time.Now().UTC()
+	return nil
+}
+
+// GetServerPassword reports that no demo guest posted a password; demo
+// servers boot Linux images without cloudbase-init.
+func (c computeClient) GetServerPassword(ctx context.Context, id string) (string, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if indexOfServer(c.servers, id) < 0 {
+		return "", notFound("server", id)
+	}
+	return "", nil
+}
+
+// CreateServerImage adds a saving image of the server that becomes active
+// once its upload is done.
+func (c computeClient) CreateServerImage(ctx context.Context, id, name string, metadata map[string]string) (string, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	i := indexOfServer(c.servers, id)
+	if i < 0 {
+		return "", notFound("server", id)
+	}
+	if s := c.servers[i].Status; s != "ACTIVE" && s != "SHUTOFF" && s != "PAUSED" && s != "SUSPENDED" {
+		return "", fmt.Errorf("cannot create an image of server %s while it is %s", id, s)
+	}
+	meta := map[string]any{"image_type": "snapshot", "instance_uuid": id}
+	for k, v := range metadata {
+		meta[k] = v
+	}
+	c.seq++
+	now := time.Now()
+	img := images.Image{ID: fmt.Sprintf("00000000-0000-4000-9200-%012x", c.seq), Name: name, Status: "SAVING", Created: now.UTC().Format(time.RFC3339), Updated: now.UTC().Format(time.RFC3339), Metadata: meta}
+	if base := c.servers[i].Image["id"]; base != nil {
+		for _, b := range c.images {
+			if b.ID == base {
+				img.MinDisk, img.MinRAM = b.MinDisk, b.MinRAM
+			}
+		}
+	}
+	c.images = append(c.images, img)
+	c.imageUploads[img.ID] = imageUpload{started: now, done: now.Add(imageUploadTime)}
+	return img.ID, nil
+}
+
+// ChangeAdminPassword accepts a new password for running servers, as a
+// libvirt hypervisor with the guest agent does.
+func (c computeClient) ChangeAdminPassword(ctx context.Context, id, password string) error {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	i := indexOfServer(c.servers, id)
+	if i < 0 {
+		return notFound("server", id)
+	}
+	if s := c.servers[i].Status; s != "ACTIVE" {
+		return fmt.Errorf("cannot change the admin password of server %s while it is %s", id, s)
+	}
+	return nil
+}
+
+// transition moves a server from one of the from states to status, with
+// the conflict nova returns for other states or a locked server.
+func (c computeClient) transition(id, action, status string, from ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	i := indexOfServer(c.servers, id)
+	if i < 0 {
+		return notFound("server", id)
+	}
+	if c.locked[id] {
+		return fmt.Errorf("server %s is locked", id)
+	}
+	if !slices.Contains(from, c.servers[i].Status) {
+		return fmt.Errorf("cannot %s server %s while it is %s", action, id, c.servers[i].Status)
+	}
+	c.servers[i].Status = status
+	c.servers[i].Updated = time.Now().UTC()
+	return nil
+}
+
+func (c computeClient) PauseInstance(ctx context.Context, id string) error {
+	_ = ctx // ctx currently unused
+	return c.transition(id, "pause", "PAUSED", "ACTIVE")
+}
+
+func (c computeClient) UnpauseInstance(ctx context.Context, id string) error {
+	_ = ctx // ctx currently unused
+	return c.transition(id, "unpause", "ACTIVE", "PAUSED")
+}
+
+func (c computeClient) SuspendInstance(ctx context.Context, id string) error {
+	_ = ctx // ctx currently unused
+	return c.transition(id, "suspend", "SUSPENDED", "ACTIVE")
+}
+
+func (c computeClient) ResumeInstance(ctx context.Context, id string) error {
+	_ = ctx // ctx currently unused
+	return c.transition(id, "resume", "ACTIVE", "SUSPENDED")
+}
+
+// ShelveInstance offloads right away, like nova's default
+// shelved_offload_time of 0.
+func (c computeClient) ShelveInstance(ctx context.Context, id string) error {
+	_ = ctx // ctx currently unused
+	return c.transition(id, "shelve", "SHELVED_OFFLOADED", "ACTIVE", "SHUTOFF", "PAUSED", "SUSPENDED")
+}
+
+func (c computeClient) UnshelveInstance(ctx context.Context, id string) error {
+	_ = ctx // ctx currently unused
+	return c.transition(id, "unshelve", "ACTIVE", "SHELVED", "SHELVED_OFFLOADED")
+}
+
+// RescueInstance accepts the servers nova rescues and refuses an unknown
+// rescue image; the password is derived from the server ID.
+func (c computeClient) RescueInstance(ctx context.Context, id, imageID string) (string, error) {
+	_ = ctx // ctx currently unused
+	if imageID != "" {
+		c.mu.Lock()
+		known := slices.ContainsFunc(c.images, func(img images.Image) bool { return img.ID == imageID })
+		c.mu.Unlock()
+		if !known {
+			return "", notFound("image", imageID)
+		}
+	}
+	if err := c.transition(id, "rescue", "RESCUE", "ACTIVE", "SHUTOFF", "ERROR"); err != nil {
+		return "", err
+	}
+	return "rescue-" + id[len(id)-6:], nil
+}
+
+func (c computeClient) UnrescueInstance(ctx context.Context, id string) error {
+	_ = ctx // ctx currently unused
+	return c.transition(id, "unrescue", "ACTIVE", "RESCUE")
+}
+
+func (c computeClient) LockInstance(ctx context.Context, id string) error {
+	_ = ctx // ctx currently unused
+	return c.setLocked(id, true)
+}
+
+func (c computeClient) UnlockInstance(ctx context.Context, id string) error {
+	_ = ctx // ctx currently unused
+	return c.setLocked(id, false)
+}
+
+func (c computeClient) setLocked(id string, locked bool) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if indexOfServer(c.servers, id) < 0 {
+		return notFound("server", id)
+	}
+	c.locked[id] = locked
+	return nil
+}
@@ -0,0 +1,125 @@
+package demo
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+// OpenSerialConsole returns a console with a login prompt that echoes what
+// is typed and answers a few shell commands.
+func (c computeClient) OpenSerialConsole(ctx context.Context, id string) (io.ReadWriteCloser, error) {
+	_ = ctx // ctx currently unused
+	srv, err := c.GetInstance(id)
+	if err != nil {
+		return nil, err
+	}
+	if srv.Status != "ACTIVE" {
+		return nil, fmt.Errorf("server %s is %s: the serial console needs a running server", srv.Name, srv.Status)
+	}
+	con := &serialConsole{host: srv.Name}
+	con.cond = sync.NewCond(&con.mu)
+	con.out = []byte(fmt.Sprintf("\r\nUbuntu 22.04.4 LTS %s ttyS0\r\n\r\n%s login: ", srv.Name, srv.Name))
+	return con, nil
+}
+
+// serialConsole is the guest side of a demo serial console.
+type serialConsole struct {
+	mu     sync.Mutex
+	cond   *sync.Cond
+	host   string
+	user   string
+	line   []byte
+	out    []byte
+	closed bool
+}
+
+// Read blocks until there is output or the console is closed.
+func (s *serialConsole) Read(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for len(s.out) == 0 && !s.closed {
+		s.cond.Wait()
+	}
+	if len(s.out) == 0 {
+		return 0, io.EOF
+	}
+	n := copy(p, s.out)
+	s.out = s.out[n:]
+	return n, nil
+}
+
+// Write takes keystrokes: printable ones are echoed, backspace edits the
+// line and enter runs it.
+func (s *serialConsole) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return 0, io.ErrClosedPipe
+	}
+	for _, b := range p {
+		switch {
+		case b == '\r':
+			s.out = append(s.out, "\r\n"...)
+			s.run(strings.TrimSpace(string(s.line)))
+			s.line = nil
+		case b == 0x7f || b == '\b':
+			if len(s.line) > 0 {
+				s.line = s.line[:len(s.line)-1]
+				s.out = append(s.out, "\b \b"...)
+			}
+		case b == 0x03:
+			s.line = nil
+			s.out = append(s.out, "^C\r\n"...)
+			s.prompt()
+		case b >= 0x20 && b < 0x7f:
+			s.line = append(s.line, b)
+			s.out = append(s.out, b)
+		}
+	}
+	s.cond.Broadcast()
+	return len(p), nil
+}
+
+// run answers a line: the first one is the login name.
+func (s *serialConsole) run(cmd string) {
+	switch {
+	case s.user == "":
+		if cmd != "" {
+			s.user = cmd
+			s.out = append(s.out, "Welcome to Ubuntu 22.04.4 LTS\r\n\r\n"...)
+		}
+	case cmd == "":
+	case cmd == "hostname":
+		s.out = append(s.out, s.host+"\r\n"...)
+	case cmd == "whoami":
+		s.out = append(s.out, s.user+"\r\n"...)
+	case cmd == "uptime":
+		s.out = append(s.out, " 12:00:00 up 3 days,  2:14,  1 user,  load average: 0.08, 0.03, 0.01\r\n"...)
+	case cmd == "exit" || cmd == "logout":
+		s.user = ""
+		s.out = append(s.out, "\r\n"...)
+	default:
+		s.out = append(s.out, fmt.Sprintf("%s: command not found\r\n", strings.Fields(cmd)[0])...)
+	}
+	s.prompt()
+}
+
+func (s *serialConsole) prompt() {
+	if s.user == "" {
+		s.out = append(s.out, s.host+" login: "...)
+		return
+	}
+	s.out = append(s.out, fmt.Sprintf("%s@%s:~$ ", s.user, s.host)...)
+}
+
+// Close ends the console and wakes a pending read.
+func (s *serialConsole) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	s.cond.Broadcast()
+	return nil
+}
@@ -0,0 +1,100 @@
+package demo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"ostui/internal/client"
+)
+
+// containerInfraClient implements client.ContainerInfraClient on top of a demo Cloud.
+type containerInfraClient struct{ *Cloud }
+
+// ContainerInfra returns a ContainerInfraClient backed by the demo cloud.
+func (c *Cloud) ContainerInfra() client.ContainerInfraClient { return containerInfraClient{c} }
+
+// nodeAddresses returns n demo addresses in the given /24.
+func nodeAddresses(prefix string, first, n int) []string {
+	out := make([]string, n)
+	for i := range out {
+		out[i] = fmt.Sprintf("%s.%d", prefix, first+i)
+	}
+	return out
+}
+
+func (c containerInfraClient) ListClusters(ctx context.Context) ([]client.Cluster, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]client.Cluster(nil), c.clusters...), nil
+}
+
+func (c containerInfraClient) GetCluster(ctx context.Context, id string) (*client.Cluster, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, cl := range c.clusters {
+		if cl.UUID == id {
+			return &cl, nil
+		}
+	}
+	return nil, notFound("cluster", id)
+}
+
+func (c containerInfraClient) GetClusterTemplate(ctx context.Context, id string) (*client.ClusterTemplate, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, t := range c.clusterTpls {
+		if t.UUID == id {
+			return &t, nil
+		}
+	}
+	return nil, notFound("cluster template", id)
+}
+
+func (c containerInfraClient) ResizeCluster(ctx context.Context, id string, nodeCount int) error {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if nodeCount < 1 {
+		return fmt.Errorf("node count must be at least 1")
+	}
+	for i, cl := range c.clusters {
+		if cl.UUID != id {
+			continue
+		}
+		if cl.Status != "CREATE_COMPLETE" && cl.Status != "UPDATE_COMPLETE" {
+			return fmt.Errorf("cluster %s cannot be resized in status %s", cl.Name, cl.Status)
+		}
+		cl.NodeCount = nodeCount
+		cl.NodeAddresses = nodeAddresses(fmt.Sprintf("10.20.%d", i), 20, nodeCount)
+		cl.Status, cl.StatusReason = "UPDATE_COMPLETE", "Stack UPDATE completed successfully"
+		cl.UpdatedAt = time.Now().UTC()
+		c.clusters[i] = cl
+		return nil
+	}
+	return notFound("cluster", id)
+}
+
+func (c containerInfraClient) GetClusterCA(ctx context.Context, clusterID string) (string, error) {
+	_ = ctx // ctx currently unused
+	cl, err := c.GetCluster(ctx, clusterID)
+	if err != nil {
+		return "", err
+	}
+	return demoPEM("CERTIFICATE", cl.Name+"-ca"), nil
+}
+
+func (c containerInfraClient) SignClusterCSR(ctx context.Context, clusterID, csr string) (string, error) {
+	_ = ctx // ctx currently unused
+	cl, err := c.GetCluster(ctx, clusterID)
+	if err != nil {
+		return "", err
+	}
+	if csr == "" {
+		return "", fmt.Errorf("empty certificate request")
+	}
+	return demoPEM("CERTIFICATE", "admin@"+cl.Name), nil
+}
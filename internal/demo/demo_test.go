@@ -0,0 +1,201 @@
+package demo
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gophercloud/gophercloud/openstack/blockstorage/v3/volumes"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/subnets"
+	"ostui/internal/client"
+)
+
+func TestNewIsDeterministic(t *testing.T) {
+	a := New(42, DefaultSize)
+	b := New(42, DefaultSize)
+	sa, _ := a.Compute().ListInstances()
+	sb, _ := b.Compute().ListInstances()
+	if len(sa) != DefaultSize.Servers || len(sb) != DefaultSize.Servers {
+		t.Fatalf("expected %d servers, got %d and %d", DefaultSize.Servers, len(sa), len(sb))
+	}
+	for i := range sa {
+		if sa[i].ID != sb[i].ID || sa[i].Name != sb[i].Name {
+			t.Fatalf("server %d differs between runs with the same seed", i)
+		}
+	}
+}
+
+func TestServerPortsResolve(t *testing.T) {
+	c := New(1, Size{Servers: 20, Networks: 3, Volumes: 10})
+	srvs, _ := c.Compute().ListInstances()
+	for _, s := range srvs {
+		ifaces, err := c.Compute().ListServerInterfaces(context.Background(), s.ID)
+		if err != nil || len(ifaces) != 1 {
+			t.Fatalf("expected one interface for %s, got %d (%v)", s.Name, len(ifaces), err)
+		}
+		if _, err := c.Network().GetNetwork(context.Background(), ifaces[0].NetworkID); err != nil {
+			t.Fatalf("interface of %s references unknown network: %v", s.Name, err)
+		}
+	}
+	if err := c.Compute().StopInstance(srvs[0].ID); err != nil {
+		t.Fatalf("stop failed: %v", err)
+	}
+	if s, _ := c.Compute().GetInstance(srvs[0].ID); s.Status != "SHUTOFF" {
+		t.Fatalf("expected SHUTOFF after stop, got %s", s.Status)
+	}
+}
+
+func TestSubnetPoolAllocation(t *testing.T) {
+	c := New(1, DefaultSize)
+	nc := c.Network()
+	pools, _ := nc.ListSubnetPools(context.Background())
+	nets, _ := nc.ListNetworks()
+	// The seeded pool subnet holds 10.128.0.0/24; the next /24 follows it,
+	// and a /23 must skip past both.
+	for _, want := range []struct {
+		bits int
+		cidr string
+	}{{0, "10.128.1.0/24"}, {23, "10.128.2.0/23"}, {24, "10.128.4.0/24"}} {
+		s, err := nc.CreateSubnet(context.Background(), subnets.CreateOpts{NetworkID: nets[2].ID, SubnetPoolID: pools[0].ID, Prefixlen: want.bits})
+		if err != nil || s.CIDR != want.cidr {
+			t.Fatalf("/%d: expected %s, got %v (%v)", want.bits, want.cidr, s, err)
+		}
+	}
+	if _, err := nc.CreateSubnet(context.Background(), subnets.CreateOpts{NetworkID: nets[2].ID, SubnetPoolID: pools[0].ID, Prefixlen: 30}); err == nil {
+		t.Fatal("expected a prefix length outside the pool range to be refused")
+	}
+}
+
+func TestL3AgentScheduling(t *testing.T) {
+	nc := New(1, DefaultSize).Network()
+	ctx := context.Background()
+	rts, _ := nc.ListRouters(ctx)
+	hosts, _ := nc.ListRouterL3Agents(ctx, rts[0].ID)
+	if len(hosts) != 2 || hosts[0].HAState != "active" || hosts[1].Alive {
+		t.Fatalf("expected an HA router with its standby on a dead agent, got %+v", hosts)
+	}
+	// Legacy routers run on a single agent.
+	legacy, _ := nc.ListRouterL3Agents(ctx, rts[1].ID)
+	if err := nc.ScheduleRouter(ctx, hosts[1].ID, rts[1].ID); err == nil || len(legacy) != 1 {
+		t.Fatalf("expected a second agent refused for a legacy router, got %v", err)
+	}
+	// Removing the active instance leaves no alive agent to promote.
+	if err := nc.UnscheduleRouter(ctx, hosts[0].ID, rts[0].ID); err != nil {
+		t.Fatal(err)
+	}
+	if left, _ := nc.ListRouterL3Agents(ctx, rts[0].ID); len(left) != 1 || left[0].HAState != "standby" {
+		t.Fatalf("expected the dead standby left as is, got %+v", left)
+	}
+}
+
+func TestDHCPAgents(t *testing.T) {
+	nc := New(1, DefaultSize).Network()
+	ctx := context.Background()
+	nets, _ := nc.ListNetworks()
+	first, _ := nc.ListNetworkDHCPAgents(ctx, nets[1].ID)
+	last, _ := nc.ListNetworkDHCPAgents(ctx, nets[len(nets)-1].ID)
+	if len(first) != 1 || !first[0].Alive || len(last) != 1 || last[0].Alive {
+		t.Fatalf("expected an alive agent on the first network and a dead one on the last, got %+v and %+v", first, last)
+	}
+	dp, _ := nc.ListDHCPPorts(ctx, nets[1].ID)
+	if len(dp) != 1 || dp[0].HostID != first[0].Host {
+		t.Fatalf("expected the DHCP port bound to %s, got %+v", first[0].Host, dp)
+	}
+}
+
+func TestCreateInstance(t *testing.T) {
+	c := New(1, DefaultSize)
+	cc, nc := c.Compute(), c.Network()
+	ctx := context.Background()
+	fl, _ := cc.ListFlavors()
+	nets, _ := nc.ListNetworks()
+	srv, err := cc.CreateInstance(ctx, servers.CreateOpts{Name: "batch-1", FlavorRef: fl[0].ID, ImageRef: "img", Networks: []servers.Network{{UUID: nets[1].ID}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ports, _ := nc.ListPortsByNetwork(ctx, nets[1].ID)
+	found := false
+	for _, p := range ports {
+		found = found || p.DeviceID == srv.ID
+	}
+	if srv.Status != "ACTIVE" || !found {
+		t.Fatalf("expected an active server with a port on %s, got %+v", nets[1].Name, srv)
+	}
+	if _, err := cc.CreateInstance(ctx, servers.CreateOpts{Name: "x", FlavorRef: "missing"}); err == nil {
+		t.Error("expected an unknown flavor to fail")
+	}
+}
+
+func TestDetachVolume(t *testing.T) {
+	c := New(1, DefaultSize)
+	cc, sc := c.Compute(), c.Storage()
+	ctx := context.Background()
+	vols, _ := sc.ListVolumes()
+	var v volumes.Volume
+	for _, x := range vols {
+		if len(x.Attachments) == 1 {
+			v = x
+			break
+		}
+	}
+	if v.ID == "" {
+		t.Skip("no attached volume in the demo data")
+	}
+	serverID := v.Attachments[0].ServerID
+	if err := cc.DetachVolume(ctx, serverID, v.ID); err != nil {
+		t.Fatal(err)
+	}
+	got, _ := sc.GetVolume(v.ID)
+	srv, _ := cc.GetInstance(serverID)
+	if got.Status != "available" || len(got.Attachments) != 0 || slices.ContainsFunc(srv.AttachedVolumes, func(a servers.AttachedVolume) bool { return a.ID == v.ID }) {
+		t.Fatalf("expected %s detached from %s, got %+v and %+v", v.ID, serverID, got, srv.AttachedVolumes)
+	}
+	if err := cc.DetachVolume(ctx, serverID, v.ID); err == nil {
+		t.Error("expected a second detach to fail")
+	}
+}
+
+func TestListenerCertificates(t *testing.T) {
+	c := New(1, DefaultSize)
+	ctx := context.Background()
+	lbs, _ := c.LoadBalancer().ListLoadBalancers(ctx)
+	km := c.KeyManager()
+	conts, err := km.ListSecretContainers(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var expiring int
+	for _, lb := range lbs[:2] {
+		lst, _ := c.LoadBalancer().ListListeners(ctx, lb.ID)
+		l := lst[0]
+		if l.Protocol != "TERMINATED_HTTPS" || l.DefaultTLSContainerRef == "" {
+			t.Fatalf("expected %s to terminate TLS, got %+v", lb.Name, l)
+		}
+		cert, err := client.ResolveTLSCert(ctx, km, l.DefaultTLSContainerRef, conts)
+		if err != nil {
+			t.Fatalf("%s: %v", lb.Name, err)
+		}
+		if cert.CommonName != lb.Name+".demo.example" || len(cert.SANs) != 2 {
+			t.Errorf("%s: unexpected certificate %+v", lb.Name, cert)
+		}
+		if time.Until(cert.NotAfter) < 30*24*time.Hour {
+			expiring++
+		}
+	}
+	if expiring != 1 {
+		t.Errorf("expected one certificate due for renewal, got %d", expiring)
+	}
+	if _, err := client.ResolveTLSCert(ctx, km, "https://barbican.demo.example:9311/v1/containers/missing", conts); err == nil {
+		t.Error("expected an unknown container to fail")
+	}
+	s, err := km.CreateSecret(ctx, client.SecretCreateInput{Name: "p12", Payload: "\x30\x82binary"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.ResolveTLSCert(ctx, km, s.SecretRef, conts); err == nil || !strings.Contains(err.Error(), "PKCS#12") {
+		t.Errorf("expected a PKCS#12 secret to be reported, got %v", err)
+	}
+}
@@ -0,0 +1,70 @@
+package demo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/portsbinding"
+	"ostui/internal/client"
+)
+
+// addDHCPAgents adds a DHCP agent in each zone on the network nodes, the
+// last one dead, and binds the DHCP port of every tenant network to one of
+// them. The last tenant network is only served by the dead agent, so its
+// instances get no address.
+func (c *Cloud) addDHCPAgents(now time.Time) {
+	var agents []client.NetworkAgent
+	for i, z := range c.zones {
+		a := client.NetworkAgent{ID: fmt.Sprintf("00000000-0000-4000-a400-%012x", i+1), AgentType: client.AgentTypeDHCP, Binary: "neutron-dhcp-agent",
+			Host: fmt.Sprintf("network-%02d", i+1), AvailabilityZone: z, AdminStateUp: true, Alive: i < 2, Topic: "dhcp_agent",
+			HeartbeatTimestamp: now.Add(-time.Duration(10+i*i*1800) * time.Second)}
+		agents = append(agents, a)
+		c.agents = append(c.agents, a)
+	}
+	c.dhcpAgents = map[string][]string{}
+	c.portHosts = map[string]string{}
+	var dhcp []int
+	for i, p := range c.ports {
+		if p.DeviceOwner == client.DeviceOwnerDHCP {
+			dhcp = append(dhcp, i)
+		}
+	}
+	for n, i := range dhcp {
+		a := agents[n%2]
+		if n == len(dhcp)-1 {
+			a = agents[len(agents)-1]
+		}
+		p := c.ports[i]
+		c.dhcpAgents[p.NetworkID] = append(c.dhcpAgents[p.NetworkID], a.ID)
+		c.portHosts[p.ID] = a.Host
+	}
+}
+
+func (c networkClient) ListNetworkDHCPAgents(ctx context.Context, networkID string) ([]client.NetworkAgent, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var out []client.NetworkAgent
+	for _, id := range c.dhcpAgents[networkID] {
+		for _, a := range c.agents {
+			if a.ID == id {
+				out = append(out, a)
+			}
+		}
+	}
+	return out, nil
+}
+
+func (c networkClient) ListDHCPPorts(ctx context.Context, networkID string) ([]client.DHCPPort, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var out []client.DHCPPort
+	for _, p := range c.ports {
+		if p.NetworkID == networkID && p.DeviceOwner == client.DeviceOwnerDHCP {
+			out = append(out, client.DHCPPort{Port: p, PortsBindingExt: portsbinding.PortsBindingExt{HostID: c.portHosts[p.ID]}})
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,126 @@
+package demo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"ostui/internal/client"
+)
+
+// zoneTransfer is a demo transfer request with the key it is accepted
+// with; the key is only listed for transfers of the project's own zones.
+type zoneTransfer struct {
+	client.ZoneTransfer
+	key string
+	// incoming marks a transfer offered to the project by another one.
+	incoming bool
+}
+
+// partnerTransferKey accepts the incoming transfer of the demo cloud.
+const partnerTransferKey = "PARTNER1"
+
+// addIncomingTransfer offers a zone of a partner project to the demo
+// project; the caller is generating the cloud.
+func (c *Cloud) addIncomingTransfer(id string, created time.Time) {
+	c.zoneTransfers = append(c.zoneTransfers, zoneTransfer{
+		ZoneTransfer: client.ZoneTransfer{ID: id, ZoneID: "00000000-0000-4000-d000-000000000001", ZoneName: "partner.example.com.", TargetProjectID: c.projectID, Description: "handover from the partner project, key " + partnerTransferKey, Status: "ACTIVE", CreatedAt: created},
+		key:          partnerTransferKey,
+		incoming:     true,
+	})
+}
+
+func (c dnsClient) ListZoneTransfers(ctx context.Context) ([]client.ZoneTransfer, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var out []client.ZoneTransfer
+	for _, t := range c.zoneTransfers {
+		if t.Status != "ACTIVE" {
+			continue
+		}
+		zt := t.ZoneTransfer
+		if !t.incoming {
+			zt.Key = t.key
+		}
+		out = append(out, zt)
+	}
+	return out, nil
+}
+
+func (c dnsClient) CreateZoneTransfer(ctx context.Context, zoneID, targetProjectID, description string) (*client.ZoneTransfer, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var zone *client.Zone
+	for i := range c.dnsZones {
+		if c.dnsZones[i].ID == zoneID {
+			zone = &c.dnsZones[i]
+		}
+	}
+	if zone == nil {
+		return nil, notFound("zone", zoneID)
+	}
+	for _, t := range c.zoneTransfers {
+		if t.ZoneID == zoneID && t.Status == "ACTIVE" {
+			return nil, fmt.Errorf("zone %s already has a pending transfer request", zone.Name)
+		}
+	}
+	c.seq++
+	t := zoneTransfer{
+		ZoneTransfer: client.ZoneTransfer{ID: fmt.Sprintf("00000000-0000-4000-d000-%012x", c.seq), ZoneID: zoneID, ZoneName: zone.Name, TargetProjectID: targetProjectID, Description: description, Status: "ACTIVE", CreatedAt: time.Now().UTC()},
+		key:          fmt.Sprintf("%08X", uint32(c.seq)*2654435761),
+	}
+	t.Key = t.key
+	c.zoneTransfers = append(c.zoneTransfers, t)
+	zt := t.ZoneTransfer
+	return &zt, nil
+}
+
+func (c dnsClient) DeleteZoneTransfer(ctx context.Context, id string) error {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, t := range c.zoneTransfers {
+		if t.ID == id && t.Status == "ACTIVE" {
+			if t.incoming {
+				return errors.New("only the project owning the zone can delete its transfer request")
+			}
+			c.zoneTransfers = append(c.zoneTransfers[:i], c.zoneTransfers[i+1:]...)
+			return nil
+		}
+	}
+	return notFound("zone transfer request", id)
+}
+
+// AcceptZoneTransfer takes over an incoming zone, with SOA and NS records
+// like a new zone. A transfer of one of the project's own zones is refused
+// since the zone would not change hands.
+func (c dnsClient) AcceptZoneTransfer(ctx context.Context, id, key string) (*client.ZoneTransferAccept, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i := range c.zoneTransfers {
+		t := &c.zoneTransfers[i]
+		if t.ID != id || t.Status != "ACTIVE" {
+			continue
+		}
+		if t.key != key {
+			return nil, errors.New("invalid transfer key")
+		}
+		if !t.incoming {
+			return nil, errors.New("the zone already belongs to this project")
+		}
+		t.Status = "COMPLETE"
+		z := client.Zone{ID: t.ZoneID, Name: t.ZoneName, Email: "hostmaster@" + t.ZoneName[:len(t.ZoneName)-1], Status: "ACTIVE", TTL: 3600, Description: "transferred zone"}
+		c.dnsZones = append(c.dnsZones, z)
+		c.recordSets[z.ID] = []client.RecordSet{
+			{ID: t.ZoneID + "-soa", Name: z.Name, Type: "SOA", TTL: 3600, Status: "ACTIVE", Records: []string{"ns1.demo.local. " + z.Email + ". 1 3600 600 86400 3600"}},
+			{ID: t.ZoneID + "-ns", Name: z.Name, Type: "NS", TTL: 3600, Status: "ACTIVE", Records: []string{"ns1.demo.local."}},
+		}
+		c.seq++
+		return &client.ZoneTransferAccept{ID: fmt.Sprintf("00000000-0000-4000-d100-%012x", c.seq), ZoneID: z.ID, Status: "COMPLETE"}, nil
+	}
+	return nil, notFound("zone transfer request", id)
+}
@@ -0,0 +1,83 @@
+package demo
+
+import (
+	"context"
+	"fmt"
+
+	"ostui/internal/client"
+)
+
+func (c networkClient) ListFirewallGroups(ctx context.Context) ([]client.FirewallGroup, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]client.FirewallGroup(nil), c.fwGroups...), nil
+}
+
+func (c networkClient) ListFirewallPolicies(ctx context.Context) ([]client.FirewallPolicy, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	out := make([]client.FirewallPolicy, len(c.fwPolicies))
+	for i, p := range c.fwPolicies {
+		p.Rules = append([]string(nil), p.Rules...)
+		out[i] = p
+	}
+	return out, nil
+}
+
+func (c networkClient) ListFirewallRules(ctx context.Context) ([]client.FirewallRule, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]client.FirewallRule(nil), c.fwRules...), nil
+}
+
+func (c networkClient) CreateFirewallRule(ctx context.Context, in client.FirewallRuleInput, policyID string) (*client.FirewallRule, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	policy := -1
+	for i, p := range c.fwPolicies {
+		if p.ID == policyID {
+			policy = i
+		}
+	}
+	if policyID != "" && policy < 0 {
+		return nil, notFound("firewall policy", policyID)
+	}
+	c.seq++
+	rule := client.FirewallRule{ID: fmt.Sprintf("00000000-0000-4000-f000-%012x", c.seq), Name: in.Name, Description: in.Description, Protocol: string(in.Protocol), Action: string(in.Action), IPVersion: int(in.IPVersion), SourceIPAddress: in.SourceIPAddress, DestinationIPAddress: in.DestinationIPAddress, SourcePort: in.SourcePort, DestinationPort: in.DestinationPort, Enabled: true, ProjectID: c.projectID, TenantID: c.projectID}
+	if rule.Protocol == "any" {
+		rule.Protocol = ""
+	}
+	if policy >= 0 {
+		c.fwPolicies[policy].Rules = append(c.fwPolicies[policy].Rules, rule.ID)
+		rule.FirewallPolicyID = []string{policyID}
+	}
+	c.fwRules = append(c.fwRules, rule)
+	return &rule, nil
+}
+
+func (c networkClient) DeleteFirewallRule(ctx context.Context, id string) error {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, rule := range c.fwRules {
+		if rule.ID != id {
+			continue
+		}
+		for j := range c.fwPolicies {
+			var kept []string
+			for _, r := range c.fwPolicies[j].Rules {
+				if r != id {
+					kept = append(kept, r)
+				}
+			}
+			c.fwPolicies[j].Rules = kept
+		}
+		c.fwRules = append(c.fwRules[:i], c.fwRules[i+1:]...)
+		return nil
+	}
+	return notFound("firewall rule", id)
+}
@@ -0,0 +1,141 @@
+package demo
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/gophercloud/gophercloud/openstack/keymanager/v1/containers"
+	"ostui/internal/client"
+)
+
+// keyManagerClient implements client.KeyManagerClient on top of a demo Cloud.
+type keyManagerClient struct{ *Cloud }
+
+// KeyManager returns a KeyManagerClient backed by the demo cloud.
+func (c *Cloud) KeyManager() client.KeyManagerClient { return keyManagerClient{c} }
+
+// barbicanURL is the endpoint of the demo key manager in secret references.
+const barbicanURL = "https://barbican.demo.example:9311/v1"
+
+func secretRef(id string) string    { return barbicanURL + "/secrets/" + id }
+func containerRef(id string) string { return barbicanURL + "/containers/" + id }
+
+// demoPEM returns a placeholder PEM block; the body is not valid key
+// material.
+func demoPEM(kind, subject string) string {
+	body := base64.StdEncoding.EncodeToString([]byte("ostui demo " + strings.ToLower(kind) + " for " + subject))
+	return fmt.Sprintf("-----BEGIN %s-----\n%s\n-----END %s-----\n", kind, body, kind)
+}
+
+// demoCertPEM returns a real self-signed certificate for host, so listener
+// certificates resolve to a common name, SANs and an expiry. It falls back
+// to a placeholder when the key cannot be generated.
+func demoCertPEM(host string, notBefore, notAfter time.Time) string {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return demoPEM("CERTIFICATE", host)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(notAfter.Unix()),
+		Subject:      pkix.Name{CommonName: host, Organization: []string{"ostui demo"}},
+		DNSNames:     []string{host, "www." + host},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		return demoPEM("CERTIFICATE", host)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+// addSecret stores a secret and its payload; the caller holds c.mu or is
+// generating the cloud.
+func (c *Cloud) addSecret(id, name, secretType, algorithm string, bits int, payload string, created time.Time) client.Secret {
+	s := client.Secret{SecretRef: secretRef(id), Name: name, SecretType: secretType, Algorithm: algorithm, BitLength: bits, Mode: "cbc", Status: "ACTIVE", CreatorID: c.projectID, Created: created, Updated: created, ContentTypes: map[string]string{"default": "text/plain"}}
+	c.secrets = append(c.secrets, s)
+	c.payloads[id] = payload
+	return s
+}
+
+func (c keyManagerClient) ListSecrets(ctx context.Context) ([]client.Secret, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]client.Secret(nil), c.secrets...), nil
+}
+
+func (c keyManagerClient) GetSecretPayload(ctx context.Context, id string) (string, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	p, ok := c.payloads[id]
+	if !ok {
+		return "", notFound("secret", id)
+	}
+	return p, nil
+}
+
+func (c keyManagerClient) CreateSecret(ctx context.Context, in client.SecretCreateInput) (*client.Secret, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.seq++
+	id := fmt.Sprintf("00000000-0000-4000-b000-%012x", c.seq)
+	secretType := string(in.SecretType)
+	if secretType == "" {
+		secretType = "opaque"
+	}
+	s := c.addSecret(id, in.Name, secretType, in.Algorithm, in.BitLength, in.Payload, time.Now().UTC())
+	if in.Expiration != nil {
+		c.secrets[len(c.secrets)-1].Expiration = *in.Expiration
+		s.Expiration = *in.Expiration
+	}
+	return &s, nil
+}
+
+func (c keyManagerClient) DeleteSecret(ctx context.Context, id string) error {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, s := range c.secrets {
+		if client.BarbicanID(s.SecretRef) == id {
+			// Like Barbican, containers keep their now dangling references.
+			c.secrets = append(c.secrets[:i], c.secrets[i+1:]...)
+			delete(c.payloads, id)
+			return nil
+		}
+	}
+	return notFound("secret", id)
+}
+
+func (c keyManagerClient) ListSecretContainers(ctx context.Context) ([]client.SecretContainer, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]client.SecretContainer(nil), c.keyContainers...), nil
+}
+
+// newCertificateContainer builds a certificate container over the given
+// secrets, consumed by the listeners.
+func newCertificateContainer(id, name string, refs map[string]client.Secret, consumers []containers.ConsumerRef, created time.Time) client.SecretContainer {
+	ct := client.SecretContainer{ContainerRef: containerRef(id), Name: name, Type: "certificate", Status: "ACTIVE", Consumers: consumers, Created: created, Updated: created}
+	for _, role := range []string{"certificate", "private_key", "intermediates"} {
+		if s, ok := refs[role]; ok {
+			ct.SecretRefs = append(ct.SecretRefs, containers.SecretRef{Name: role, SecretRef: s.SecretRef})
+		}
+	}
+	return ct
+}
@@ -0,0 +1,122 @@
+package demo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"ostui/internal/client"
+)
+
+// l3Binding is a router scheduled on an L3 agent; haState is empty for
+// legacy routers.
+type l3Binding struct {
+	agentID string
+	haState string
+}
+
+// addL3Agents adds three L3 agents on the network nodes, the last one dead,
+// and schedules the routers: the first as an HA router whose standby sits on
+// the dead agent, the others as legacy routers.
+func (c *Cloud) addL3Agents(now time.Time) {
+	var ids []string
+	for i := 0; i < 3; i++ {
+		a := client.NetworkAgent{ID: fmt.Sprintf("00000000-0000-4000-a300-%012x", i+1), AgentType: client.AgentTypeL3, Binary: "neutron-l3-agent",
+			Host: fmt.Sprintf("network-%02d", i+1), AvailabilityZone: c.zones[i%2], AdminStateUp: true, Alive: i < 2, Topic: "l3_agent",
+			HeartbeatTimestamp: now.Add(-time.Duration(10+i*i*1800) * time.Second)}
+		c.agents = append(c.agents, a)
+		ids = append(ids, a.ID)
+	}
+	c.l3Bindings = map[string][]l3Binding{}
+	for i, rt := range c.routers {
+		if i == 0 {
+			c.l3Bindings[rt.ID] = []l3Binding{{ids[0], "active"}, {ids[2], "standby"}}
+			continue
+		}
+		c.l3Bindings[rt.ID] = []l3Binding{{agentID: ids[i%2]}}
+	}
+}
+
+func (c networkClient) ListRouterL3Agents(ctx context.Context, routerID string) ([]client.L3Agent, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var out []client.L3Agent
+	for _, b := range c.l3Bindings[routerID] {
+		for _, a := range c.agents {
+			if a.ID == b.agentID {
+				out = append(out, client.L3Agent{ID: a.ID, AdminStateUp: a.AdminStateUp, AgentType: a.AgentType, Alive: a.Alive, AvailabilityZone: a.AvailabilityZone,
+					Binary: a.Binary, Host: a.Host, Topic: a.Topic, HeartbeatTimestamp: a.HeartbeatTimestamp, HAState: b.haState})
+			}
+		}
+	}
+	return out, nil
+}
+
+// ScheduleRouter adds the router to an agent. Like Neutron, a legacy router
+// runs on one agent only; an HA router gains a standby instance.
+func (c networkClient) ScheduleRouter(ctx context.Context, agentID, routerID string) error {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	known := false
+	for _, a := range c.agents {
+		known = known || (a.ID == agentID && a.AgentType == client.AgentTypeL3)
+	}
+	if !known {
+		return notFound("L3 agent", agentID)
+	}
+	bs := c.l3Bindings[routerID]
+	ha := false
+	for _, b := range bs {
+		if b.agentID == agentID {
+			return fmt.Errorf("router %s is already hosted by L3 agent %s", routerID, agentID)
+		}
+		ha = ha || b.haState != ""
+	}
+	if len(bs) > 0 && !ha {
+		return fmt.Errorf("the router %s has been already hosted by the L3 agent %s", routerID, bs[0].agentID)
+	}
+	state := ""
+	if ha {
+		state = "standby"
+	}
+	c.l3Bindings[routerID] = append(bs, l3Binding{agentID: agentID, haState: state})
+	return nil
+}
+
+// UnscheduleRouter removes the router from an agent; removing the active
+// instance of an HA router promotes an alive standby.
+func (c networkClient) UnscheduleRouter(ctx context.Context, agentID, routerID string) error {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	bs := c.l3Bindings[routerID]
+	for i, b := range bs {
+		if b.agentID != agentID {
+			continue
+		}
+		bs = append(bs[:i:i], bs[i+1:]...)
+		if b.haState == "active" {
+			for j := range bs {
+				if c.agentAlive(bs[j].agentID) {
+					bs[j].haState = "active"
+					break
+				}
+			}
+		}
+		c.l3Bindings[routerID] = bs
+		return nil
+	}
+	return fmt.Errorf("router %s is not hosted by L3 agent %s", routerID, agentID)
+}
+
+// agentAlive reports whether the agent reports its heartbeats.
+func (c *Cloud) agentAlive(id string) bool {
+	for _, a := range c.agents {
+		if a.ID == id {
+			return a.Alive
+		}
+	}
+	return false
+}
@@ -0,0 +1,86 @@
+package demo
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/networks"
+	"ostui/internal/client"
+)
+
+// networkAZs are the zones of the demo network service: DHCP agents run in
+// every compute zone, L3 agents only in the first two.
+func (c *Cloud) networkAZs() []client.NetworkAZ {
+	var out []client.NetworkAZ
+	for _, z := range c.zones {
+		out = append(out, client.NetworkAZ{Name: z, Resource: "network", State: "available"})
+	}
+	for _, z := range c.zones[:2] {
+		out = append(out, client.NetworkAZ{Name: z, Resource: "router", State: "available"})
+	}
+	return out
+}
+
+// placement schedules a resource like Neutron: to its hinted zones, or to
+// every zone with agents for the resource.
+func (c *Cloud) placement(hints []string, resource string) client.AZPlacement {
+	p := client.AZPlacement{Hints: hints, Zones: hints}
+	if len(hints) == 0 {
+		p.Zones = nil
+		for _, az := range c.networkAZs() {
+			if az.Resource == resource {
+				p.Zones = append(p.Zones, az.Name)
+			}
+		}
+	}
+	return p
+}
+
+func (c networkClient) ListNetworkAZs(ctx context.Context) ([]client.NetworkAZ, error) {
+	_ = ctx // ctx currently unused
+	return c.networkAZs(), nil
+}
+
+func (c networkClient) GetNetworkAZs(ctx context.Context, id string) (client.AZPlacement, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, n := range c.networks {
+		if n.ID == id {
+			return c.placement(n.AvailabilityZoneHints, "network"), nil
+		}
+	}
+	return client.AZPlacement{}, notFound("network", id)
+}
+
+func (c networkClient) GetRouterAZs(ctx context.Context, id string) (client.AZPlacement, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, r := range c.routers {
+		if r.ID == id {
+			return c.placement(r.AvailabilityZoneHints, "router"), nil
+		}
+	}
+	return client.AZPlacement{}, notFound("router", id)
+}
+
+// CreateNetwork refuses hints naming zones without DHCP agents, as Neutron
+// does.
+func (c networkClient) CreateNetwork(ctx context.Context, opts networks.CreateOpts) (*networks.Network, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	known := c.placement(nil, "network").Zones
+	for _, h := range opts.AvailabilityZoneHints {
+		if !slices.Contains(known, h) {
+			return nil, fmt.Errorf("AvailabilityZone %s could not be found", h)
+		}
+	}
+	c.seq++
+	n := networks.Network{ID: fmt.Sprintf("00000000-0000-4000-c100-%012x", c.seq), Name: opts.Name, Status: "ACTIVE", AdminStateUp: true,
+		TenantID: c.projectID, ProjectID: c.projectID, AvailabilityZoneHints: opts.AvailabilityZoneHints}
+	c.networks = append(c.networks, n)
+	return &n, nil
+}
@@ -0,0 +1,465 @@
+package demo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/layer3/floatingips"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/quotas"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/security/groups"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/security/rules"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/networks"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/subnets"
+	"ostui/internal/client"
+)
+
+// networkClient implements client.NetworkClient on top of a demo Cloud.
+type networkClient struct{ *Cloud }
+
+// Network returns a NetworkClient backed by the demo cloud.
+func (c *Cloud) Network() client.NetworkClient { return networkClient{c} }
+
+func (c networkClient) ListNetworks() ([]networks.Network, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]networks.Network(nil), c.networks...), nil
+}
+
+func (c networkClient) ListExternalNetworks(ctx context.Context) ([]networks.Network, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var out []networks.Network
+	for _, n := range c.networks {
+		if n.ID == c.externalNetID {
+			out = append(out, n)
+		}
+	}
+	return out, nil
+}
+
+func (c networkClient) ListSubnets() ([]subnets.Subnet, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]subnets.Subnet(nil), c.subnets...), nil
+}
+
+func (c networkClient) GetSubnet(ctx context.Context, subnetID string) (*subnets.Subnet, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, s := range c.subnets {
+		if s.ID == subnetID {
+			return &s, nil
+		}
+	}
+	return nil, notFound("subnet", subnetID)
+}
+
+func (c networkClient) UpdateSubnet(ctx context.Context, id string, opts subnets.UpdateOpts) error {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i := range c.subnets {
+		if c.subnets[i].ID != id {
+			continue
+		}
+		s := &c.subnets[i]
+		if opts.Name != nil {
+			s.Name = *opts.Name
+		}
+		if opts.DNSNameservers != nil {
+			s.DNSNameservers = *opts.DNSNameservers
+		}
+		if opts.HostRoutes != nil {
+			s.HostRoutes = *opts.HostRoutes
+		}
+		if opts.EnableDHCP != nil {
+			s.EnableDHCP = *opts.EnableDHCP
+		}
+		return nil
+	}
+	return notFound("subnet", id)
+}
+
+func (c networkClient) ListFloatingIPs() ([]floatingips.FloatingIP, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]floatingips.FloatingIP(nil), c.fips...), nil
+}
+
+func (c networkClient) AllocateFloatingIP(opts floatingips.CreateOptsBuilder) (floatingips.FloatingIP, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.seq++
+	fip := floatingips.FloatingIP{ID: fmt.Sprintf("00000000-0000-4000-a000-%012x", c.seq), FloatingIP: fmt.Sprintf("198.51.100.%d", c.seq%250+1), Status: "DOWN", TenantID: c.projectID, ProjectID: c.projectID, CreatedAt: time.Now().UTC()}
+	if co, ok := opts.(floatingips.CreateOpts); ok {
+		fip.FloatingNetworkID = co.FloatingNetworkID
+		fip.Description = co.Description
+	}
+	c.fips = append(c.fips, fip)
+	return fip, nil
+}
+
+func (c networkClient) ReleaseFloatingIP(id string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, f := range c.fips {
+		if f.ID == id {
+			c.fips = append(c.fips[:i], c.fips[i+1:]...)
+			return nil
+		}
+	}
+	return notFound("floating IP", id)
+}
+
+func (c networkClient) setFIPPort(fipID, portID string) (floatingips.FloatingIP, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i := range c.fips {
+		if c.fips[i].ID != fipID {
+			continue
+		}
+		c.fips[i].PortID, c.fips[i].FixedIP, c.fips[i].Status = "", "", "DOWN"
+		for _, p := range c.ports {
+			if p.ID == portID && len(p.FixedIPs) > 0 {
+				c.fips[i].PortID, c.fips[i].FixedIP, c.fips[i].Status = p.ID, p.FixedIPs[0].IPAddress, "ACTIVE"
+			}
+		}
+		return c.fips[i], nil
+	}
+	return floatingips.FloatingIP{}, notFound("floating IP", fipID)
+}
+
+func (c networkClient) AssociateFloatingIP(fipID string, portID string) (floatingips.FloatingIP, error) {
+	return c.setFIPPort(fipID, portID)
+}
+
+func (c networkClient) DisassociateFloatingIP(fipID string) (floatingips.FloatingIP, error) {
+	return c.setFIPPort(fipID, "")
+}
+
+func (c networkClient) ListFloatingIPDetails(ctx context.Context) ([]client.FloatingIP, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	out := make([]client.FloatingIP, len(c.fips))
+	for i, f := range c.fips {
+		d := c.fipDNS[f.ID]
+		out[i] = client.FloatingIP{FloatingIP: f, DNSName: d[0], DNSDomain: d[1]}
+	}
+	return out, nil
+}
+
+func (c networkClient) UpdateFloatingIP(ctx context.Context, id string, opts client.FloatingIPUpdate) error {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i := range c.fips {
+		if c.fips[i].ID != id {
+			continue
+		}
+		if opts.Description != nil {
+			c.fips[i].Description = *opts.Description
+		}
+		d := c.fipDNS[id]
+		if opts.DNSName != nil {
+			d[0] = *opts.DNSName
+		}
+		if opts.DNSDomain != nil {
+			d[1] = *opts.DNSDomain
+		}
+		c.fipDNS[id] = d
+		return nil
+	}
+	return notFound("floating IP", id)
+}
+
+func (c networkClient) ListSecurityGroups() ([]groups.SecGroup, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]groups.SecGroup(nil), c.secGroups...), nil
+}
+
+func (c networkClient) ListRouters(ctx context.Context) ([]client.Router, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]client.Router(nil), c.routers...), nil
+}
+
+func (c networkClient) ListRouterDetails(ctx context.Context) ([]client.RouterDetail, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	out := make([]client.RouterDetail, len(c.routers))
+	for i, r := range c.routers {
+		out[i] = client.RouterDetail{Router: r, CreatedAt: c.routerCreated[r.ID]}
+	}
+	return out, nil
+}
+
+func (c networkClient) GetRouter(ctx context.Context, id string) (*client.Router, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, r := range c.routers {
+		if r.ID == id {
+			return &r, nil
+		}
+	}
+	return nil, notFound("router", id)
+}
+
+func (c networkClient) GetRouterInterfaces(ctx context.Context, id string) ([]client.RouterInterface, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var out []client.RouterInterface
+	for _, p := range c.ports {
+		if p.DeviceID == id && p.DeviceOwner == "network:router_interface" {
+			out = append(out, p)
+		}
+	}
+	return out, nil
+}
+
+func (c networkClient) CreateRouter(ctx context.Context, opts client.RouterCreateOpts) (*client.Router, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.seq++
+	r := client.Router{ID: fmt.Sprintf("00000000-0000-4000-b000-%012x", c.seq), Name: opts.Name, Status: "ACTIVE", AdminStateUp: true, TenantID: c.projectID, AvailabilityZoneHints: opts.AZHints}
+	if opts.Distributed != nil {
+		r.Distributed = *opts.Distributed
+	}
+	if opts.ExternalNetworkID != "" {
+		r.GatewayInfo.NetworkID = opts.ExternalNetworkID
+		r.GatewayInfo.EnableSNAT = opts.EnableSNAT
+	}
+	c.routers = append(c.routers, r)
+	c.routerCreated[r.ID] = time.Now().UTC()
+	return &r, nil
+}
+
+func (c networkClient) DeleteRouter(ctx context.Context, id string) error {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, p := range c.ports {
+		if p.DeviceID == id && strings.HasPrefix(p.DeviceOwner, "network:router_interface") {
+			return fmt.Errorf("router %s still has ports", id)
+		}
+	}
+	for i, r := range c.routers {
+		if r.ID == id {
+			c.routers = append(c.routers[:i], c.routers[i+1:]...)
+			delete(c.l3Bindings, id)
+			delete(c.routerCreated, id)
+			return nil
+		}
+	}
+	return notFound("router", id)
+}
+
+func (c networkClient) AddRouterInterface(ctx context.Context, routerID, subnetID string) error {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, s := range c.subnets {
+		if s.ID == subnetID {
+			c.seq++
+			c.ports = append(c.ports, client.Port{ID: fmt.Sprintf("00000000-0000-4000-c000-%012x", c.seq), NetworkID: s.NetworkID, Status: "ACTIVE", AdminStateUp: true, DeviceOwner: "network:router_interface", DeviceID: routerID, FixedIPs: fixedIP(s.ID, s.GatewayIP)})
+			return nil
+		}
+	}
+	return notFound("subnet", subnetID)
+}
+
+func (c networkClient) RemoveRouterInterface(ctx context.Context, routerID, subnetID string) error {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, p := range c.ports {
+		if p.DeviceID == routerID && len(p.FixedIPs) > 0 && p.FixedIPs[0].SubnetID == subnetID {
+			c.ports = append(c.ports[:i], c.ports[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("router %s has no interface on subnet %s", routerID, subnetID)
+}
+
+func (c networkClient) ListPorts(ctx context.Context) ([]client.Port, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]client.Port(nil), c.ports...), nil
+}
+
+func (c networkClient) ListPortsUpTo(ctx context.Context, limit int) ([]client.Port, bool, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.ports) > limit {
+		return append([]client.Port(nil), c.ports[:limit]...), true, nil
+	}
+	return append([]client.Port(nil), c.ports...), false, nil
+}
+
+func (c networkClient) GetPort(ctx context.Context, id string) (*client.Port, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, p := range c.ports {
+		if p.ID == id {
+			return &p, nil
+		}
+	}
+	return nil, notFound("port", id)
+}
+
+func (c networkClient) ListPortsByServer(ctx context.Context, serverID string) ([]client.Port, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var out []client.Port
+	for _, p := range c.ports {
+		if p.DeviceID == serverID {
+			out = append(out, p)
+		}
+	}
+	return out, nil
+}
+
+func (c networkClient) ListPortsByNetwork(ctx context.Context, networkID string) ([]client.Port, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var out []client.Port
+	for _, p := range c.ports {
+		if p.NetworkID == networkID {
+			out = append(out, p)
+		}
+	}
+	return out, nil
+}
+
+func (c networkClient) GetNetwork(ctx context.Context, id string) (*networks.Network, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, n := range c.networks {
+		if n.ID == id {
+			return &n, nil
+		}
+	}
+	return nil, notFound("network", id)
+}
+
+// GetNetworkDetail describes the external network as a flat provider
+// network and the project networks as VXLAN tunnels, numbered in order.
+func (c networkClient) GetNetworkDetail(ctx context.Context, id string) (client.NetworkDetail, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, n := range c.networks {
+		if n.ID != id {
+			continue
+		}
+		if n.ID == c.externalNetID {
+			return client.NetworkDetail{MTU: 1500, External: true, NetworkType: "flat", PhysicalNetwork: "physnet1",
+				Grants: []client.NetworkGrant{{ID: "rbac-" + n.ID[:8], Action: "access_as_external", Target: "*"}}}, nil
+		}
+		vni := 1000 + i
+		d := client.NetworkDetail{MTU: 1450, NetworkType: "vxlan", SegmentationID: &vni}
+		if n.Shared {
+			d.Grants = []client.NetworkGrant{{ID: "rbac-" + n.ID[:8], Action: "access_as_shared", Target: "*"}}
+		}
+		return d, nil
+	}
+	return client.NetworkDetail{}, notFound("network", id)
+}
+
+func (c networkClient) UpdateNetwork(ctx context.Context, id string, opts networks.UpdateOpts) error {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i := range c.networks {
+		if c.networks[i].ID != id {
+			continue
+		}
+		n := &c.networks[i]
+		if opts.Name != nil {
+			n.Name = *opts.Name
+		}
+		if opts.Description != nil {
+			n.Description = *opts.Description
+		}
+		if opts.AdminStateUp != nil {
+			n.AdminStateUp = *opts.AdminStateUp
+		}
+		if opts.Shared != nil {
+			n.Shared = *opts.Shared
+		}
+		return nil
+	}
+	return notFound("network", id)
+}
+
+func (c networkClient) ListSecurityGroupRules(ctx context.Context, sgID string) ([]client.SecurityGroupRule, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var out []client.SecurityGroupRule
+	for _, r := range c.rules {
+		if r.SecGroupID == sgID {
+			out = append(out, r)
+		}
+	}
+	return out, nil
+}
+
+func (c networkClient) CreateSecurityGroupRule(ctx context.Context, sgID string, rule client.SecurityGroupRuleInput) (*client.SecurityGroupRule, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.seq++
+	r := rules.SecGroupRule{ID: fmt.Sprintf("00000000-0000-4000-d000-%012x", c.seq), Direction: string(rule.Direction), EtherType: string(rule.EtherType), Protocol: string(rule.Protocol), PortRangeMin: rule.PortRangeMin, PortRangeMax: rule.PortRangeMax, RemoteIPPrefix: rule.RemoteIPPrefix, RemoteGroupID: rule.RemoteGroupID, Description: rule.Description, SecGroupID: sgID, TenantID: c.projectID, ProjectID: c.projectID}
+	c.rules = append(c.rules, r)
+	return &r, nil
+}
+
+func (c networkClient) DeleteSecurityGroupRule(ctx context.Context, id string) error {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, r := range c.rules {
+		if r.ID == id {
+			c.rules = append(c.rules[:i], c.rules[i+1:]...)
+			return nil
+		}
+	}
+	return notFound("security group rule", id)
+}
+
+func (c networkClient) GetQuota(ctx context.Context, projectID string) (*quotas.QuotaDetailSet, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return &quotas.QuotaDetailSet{
+		Network:           quotas.QuotaDetail{Used: len(c.networks), Limit: 100},
+		Subnet:            quotas.QuotaDetail{Used: len(c.subnets), Limit: 100},
+		Port:              quotas.QuotaDetail{Used: len(c.ports), Limit: 1000},
+		Router:            quotas.QuotaDetail{Used: len(c.routers), Limit: 20},
+		FloatingIP:        quotas.QuotaDetail{Used: len(c.fips), Limit: 100},
+		SecurityGroup:     quotas.QuotaDetail{Used: len(c.secGroups), Limit: 50},
+		SecurityGroupRule: quotas.QuotaDetail{Used: len(c.rules), Limit: 500},
+	}, nil
+}
+
+func (c networkClient) UpdateQuota(ctx context.Context, projectID string, opts quotas.UpdateOpts) error {
+	_ = ctx // ctx currently unused
+	return nil
+}
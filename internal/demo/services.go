@@ -0,0 +1,591 @@
+package demo
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	vLimits "github.com/gophercloud/gophercloud/openstack/blockstorage/extensions/limits"
+	"github.com/gophercloud/gophercloud/openstack/blockstorage/extensions/quotasets"
+	"github.com/gophercloud/gophercloud/openstack/blockstorage/v3/snapshots"
+	"github.com/gophercloud/gophercloud/openstack/blockstorage/v3/volumes"
+	cLimits "github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/limits"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/images"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/domains"
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/extensions/trusts"
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/projects"
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/tokens"
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/users"
+	glanceimages "github.com/gophercloud/gophercloud/openstack/imageservice/v2/images"
+	"ostui/internal/client"
+)
+
+// storageClient implements client.StorageClient on top of a demo Cloud.
+type storageClient struct{ *Cloud }
+
+// Storage returns a StorageClient backed by the demo cloud.
+func (c *Cloud) Storage() client.StorageClient { return storageClient{c} }
+
+func (c storageClient) ListVolumes() ([]volumes.Volume, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]volumes.Volume(nil), c.volumes...), nil
+}
+
+func (c storageClient) GetVolume(id string) (volumes.Volume, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, v := range c.volumes {
+		if v.ID == id {
+			return v, nil
+		}
+	}
+	return volumes.Volume{}, notFound("volume", id)
+}
+
+func (c storageClient) CreateVolume(opts volumes.CreateOpts) (volumes.Volume, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if opts.Size <= 0 {
+		return volumes.Volume{}, fmt.Errorf("invalid volume size %d", opts.Size)
+	}
+	c.seq++
+	now := time.Now().UTC()
+	v := volumes.Volume{ID: fmt.Sprintf("00000000-0000-4000-9200-%012x", c.seq), Name: opts.Name, Description: opts.Description, Size: opts.Size,
+		Status: "available", VolumeType: opts.VolumeType, AvailabilityZone: opts.AvailabilityZone, Metadata: opts.Metadata, CreatedAt: now, UpdatedAt: now, Bootable: "false",
+		SnapshotID: opts.SnapshotID, SourceVolID: opts.SourceVolID}
+	if v.VolumeType == "" {
+		v.VolumeType = "standard"
+	}
+	if v.AvailabilityZone == "" {
+		v.AvailabilityZone = c.zones[0]
+	}
+	c.volumes = append(c.volumes, v)
+	return v, nil
+}
+
+func (c storageClient) DeleteVolume(id string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, v := range c.volumes {
+		if v.ID == id {
+			if _, err := client.VolumeTransition(v, client.VolumeDelete); err != nil {
+				return err
+			}
+			c.volumes = append(c.volumes[:i], c.volumes[i+1:]...)
+			return nil
+		}
+	}
+	return notFound("volume", id)
+}
+
+// ForceDeleteVolume deletes the volume whatever its status, detaching it
+// from its servers.
+func (c storageClient) ForceDeleteVolume(id string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, v := range c.volumes {
+		if v.ID != id {
+			continue
+		}
+		for _, a := range v.Attachments {
+			if si := indexOfServer(c.servers, a.ServerID); si >= 0 {
+				srv := &c.servers[si]
+				srv.AttachedVolumes = slices.DeleteFunc(srv.AttachedVolumes, func(av servers.AttachedVolume) bool { return av.ID == id })
+			}
+		}
+		c.volumes = append(c.volumes[:i], c.volumes[i+1:]...)
+		return nil
+	}
+	return notFound("volume", id)
+}
+
+func (c storageClient) UpdateVolume(id string, opts volumes.UpdateOpts) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i := range c.volumes {
+		if c.volumes[i].ID != id {
+			continue
+		}
+		v := &c.volumes[i]
+		if opts.Name != nil {
+			v.Name = *opts.Name
+		}
+		if opts.Description != nil {
+			v.Description = *opts.Description
+		}
+		if opts.Metadata != nil {
+			v.Metadata = opts.Metadata
+		}
+		return nil
+	}
+	return notFound("volume", id)
+}
+
+func (c storageClient) ListSnapshots() ([]snapshots.Snapshot, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]snapshots.Snapshot(nil), c.snapshots...), nil
+}
+
+func (c storageClient) CreateSnapshot(opts snapshots.CreateOptsBuilder) (snapshots.Snapshot, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.seq++
+	s := snapshots.Snapshot{ID: fmt.Sprintf("00000000-0000-4000-e000-%012x", c.seq), Status: "available", CreatedAt: time.Now().UTC()}
+	if co, ok := opts.(snapshots.CreateOpts); ok {
+		s.Name, s.Description, s.VolumeID = co.Name, co.Description, co.VolumeID
+		for _, v := range c.volumes {
+			if v.ID != co.VolumeID {
+				continue
+			}
+			if _, err := client.VolumeTransition(v, client.VolumeSnapshot); err != nil {
+				return snapshots.Snapshot{}, err
+			}
+			if client.SnapshotNeedsForce(v) && !co.Force {
+				return snapshots.Snapshot{}, fmt.Errorf("volume %s is in-use; snapshotting it needs force", v.ID)
+			}
+			s.Size = v.Size
+		}
+	}
+	c.snapshots = append(c.snapshots, s)
+	return s, nil
+}
+
+func (c storageClient) GetQuotaSet(projectID string) (quotasets.QuotaUsageSet, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	gb := 0
+	for _, v := range c.volumes {
+		gb += v.Size
+	}
+	return quotasets.QuotaUsageSet{
+		ID:        projectID,
+		Volumes:   quotasets.QuotaUsage{InUse: len(c.volumes), Limit: 500},
+		Snapshots: quotasets.QuotaUsage{InUse: len(c.snapshots), Limit: 200},
+		Gigabytes: quotasets.QuotaUsage{InUse: gb, Limit: 100000},
+		Backups:   quotasets.QuotaUsage{Limit: 50},
+	}, nil
+}
+
+func (c storageClient) UpdateQuotaSet(projectID string, opts quotasets.UpdateOpts) error {
+	return nil
+}
+
+// identityClient implements client.IdentityClient on top of a demo Cloud.
+type identityClient struct{ *Cloud }
+
+// Identity returns an IdentityClient backed by the demo cloud.
+func (c *Cloud) Identity() client.IdentityClient { return identityClient{c} }
+
+func (c identityClient) ListProjects() ([]projects.Project, error) {
+	return append([]projects.Project(nil), c.projects...), nil
+}
+
+func (c identityClient) GetCurrentProject() (projects.Project, error) {
+	return c.projects[0], nil
+}
+
+func (c identityClient) ListUsers() ([]users.User, error) {
+	return append([]users.User(nil), c.users...), nil
+}
+
+// GetTokenUserID reports the first demo user as the token's user.
+func (c identityClient) GetTokenUserID() (string, error) {
+	return c.users[0].ID, nil
+}
+
+func (c identityClient) ListTrusts(opts trusts.ListOpts) ([]client.Trust, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var out []client.Trust
+	for _, t := range c.trusts {
+		if (opts.TrustorUserID == "" || t.TrustorUserID == opts.TrustorUserID) && (opts.TrusteeUserID == "" || t.TrusteeUserID == opts.TrusteeUserID) {
+			out = append(out, t)
+		}
+	}
+	return out, nil
+}
+
+// CreateTrust checks what Keystone checks: the trustor is the token's
+// user, the trustee exists and the trustor holds the delegated roles.
+func (c identityClient) CreateTrust(opts trusts.CreateOpts) (client.Trust, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if opts.TrustorUserID != c.users[0].ID {
+		return client.Trust{}, fmt.Errorf("the trustor must be the token's user")
+	}
+	found := false
+	for _, u := range c.users {
+		found = found || u.ID == opts.TrusteeUserID
+	}
+	if !found {
+		return client.Trust{}, notFound("user", opts.TrusteeUserID)
+	}
+	for _, r := range opts.Roles {
+		if r.Name != "admin" && r.Name != "member" && r.Name != "reader" {
+			return client.Trust{}, fmt.Errorf("the trustor does not have role %s", r.Name)
+		}
+	}
+	c.seq++
+	t := client.Trust{ID: fmt.Sprintf("%032x", c.seq), TrustorUserID: opts.TrustorUserID, TrusteeUserID: opts.TrusteeUserID, ProjectID: opts.ProjectID,
+		Impersonation: opts.Impersonation, Roles: opts.Roles, RemainingUses: opts.RemainingUses}
+	if opts.ExpiresAt != nil {
+		t.ExpiresAt = *opts.ExpiresAt
+	}
+	c.trusts = append(c.trusts, t)
+	return t, nil
+}
+
+func (c identityClient) DeleteTrust(id string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, t := range c.trusts {
+		if t.ID == id {
+			c.trusts = append(c.trusts[:i], c.trusts[i+1:]...)
+			return nil
+		}
+	}
+	return notFound("trust", id)
+}
+
+func (c identityClient) ListEC2Credentials(userID string) ([]client.EC2Credential, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var out []client.EC2Credential
+	for _, cred := range c.ec2Creds {
+		if cred.UserID == userID {
+			out = append(out, cred)
+		}
+	}
+	return out, nil
+}
+
+func (c identityClient) CreateEC2Credential(userID, projectID string) (client.EC2Credential, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	found := false
+	for _, p := range c.projects {
+		found = found || p.ID == projectID
+	}
+	if !found {
+		return client.EC2Credential{}, notFound("project", projectID)
+	}
+	c.seq++
+	cred := client.EC2Credential{UserID: userID, TenantID: projectID,
+		Access: fmt.Sprintf("%032x", c.seq), Secret: fmt.Sprintf("%032x", c.seq*2654435761)}
+	c.ec2Creds = append(c.ec2Creds, cred)
+	return cred, nil
+}
+
+func (c identityClient) DeleteEC2Credential(userID, access string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, cred := range c.ec2Creds {
+		if cred.UserID == userID && cred.Access == access {
+			c.ec2Creds = append(c.ec2Creds[:i], c.ec2Creds[i+1:]...)
+			return nil
+		}
+	}
+	return notFound("EC2 credential", access)
+}
+
+func (c identityClient) ListDomains() ([]domains.Domain, error) {
+	return append([]domains.Domain(nil), c.domains...), nil
+}
+
+func (c identityClient) ListProjectsInDomain(domainID string) ([]projects.Project, error) {
+	var out []projects.Project
+	for _, p := range c.projects {
+		if p.DomainID == domainID {
+			out = append(out, p)
+		}
+	}
+	return out, nil
+}
+
+func (c identityClient) ListUsersInDomain(domainID string) ([]users.User, error) {
+	var out []users.User
+	for _, u := range c.users {
+		if u.DomainID == domainID {
+			out = append(out, u)
+		}
+	}
+	return out, nil
+}
+
+func (c identityClient) GetTokenInfo() (*tokens.Token, error) {
+	return &tokens.Token{ID: "demo-token", ExpiresAt: time.Now().Add(8 * time.Hour)}, nil
+}
+
+// GetTokenRoles reports the demo user as an administrator, so every action
+// is available.
+func (c identityClient) GetTokenRoles() ([]string, error) {
+	return []string{"admin", "member", "reader"}, nil
+}
+
+// imageClient implements client.ImageClient on top of a demo Cloud.
+type imageClient struct{ *Cloud }
+
+// Image returns an ImageClient backed by the demo cloud.
+func (c *Cloud) Image() client.ImageClient { return imageClient{c} }
+
+func (c imageClient) ListImages(ctx context.Context) ([]images.Image, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.settleImageUploads()
+	return append([]images.Image(nil), c.images...), nil
+}
+
+func (c imageClient) GetImage(ctx context.Context, id string) (*images.Image, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.settleImageUploads()
+	for _, img := range c.images {
+		if img.ID == id {
+			return &img, nil
+		}
+	}
+	return nil, notFound("image", id)
+}
+
+func (c imageClient) DeleteImage(ctx context.Context, id string) error {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, img := range c.images {
+		if img.ID == id {
+			c.images = append(c.images[:i], c.images[i+1:]...)
+			return nil
+		}
+	}
+	return notFound("image", id)
+}
+
+func (c imageClient) DownloadImage(ctx context.Context, id, path string, progress func(done, total int64)) (*client.ImageDownload, error) {
+	img, err := c.GetImage(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	// A small deterministic payload stands in for the image data.
+	data := []byte(strings.Repeat(img.ID+"\n", 4096))
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return nil, err
+	}
+	if progress != nil {
+		progress(int64(len(data)), int64(len(data)))
+	}
+	sum := md5.Sum(data)
+	h := hex.EncodeToString(sum[:])
+	return &client.ImageDownload{Path: path, Bytes: int64(len(data)), Checksums: []client.ImageChecksum{{Algorithm: "md5", Expected: h, Actual: h}}}, nil
+}
+
+// demoImageVisibility gives a few demo images something other than public.
+var demoImageVisibility = map[string]glanceimages.ImageVisibility{
+	"windows-2022": glanceimages.ImageVisibilityPrivate,
+	"cirros-0.6":   glanceimages.ImageVisibilityShared,
+	"alpine-3.20":  glanceimages.ImageVisibilityCommunity,
+}
+
+// ListImageDetails returns the demo images as Glance reports them. Public
+// images belong to the first project; the others to the second.
+func (c imageClient) ListImageDetails(ctx context.Context) ([]glanceimages.Image, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.settleImageUploads()
+	out := make([]glanceimages.Image, 0, len(c.images))
+	for _, img := range c.images {
+		vis, ok := demoImageVisibility[img.Name]
+		owner := c.projects[0].ID
+		if !ok {
+			vis = glanceimages.ImageVisibilityPublic
+		} else {
+			owner = c.projects[1].ID
+		}
+		out = append(out, glanceimages.Image{ID: img.ID, Name: img.Name, Status: glanceimages.ImageStatus(strings.ToLower(img.Status)), Visibility: vis, Owner: owner,
+			Protected: vis == glanceimages.ImageVisibilityPublic && strings.HasPrefix(img.Name, "ubuntu"), MinDiskGigabytes: img.MinDisk, MinRAMMegabytes: img.MinRAM,
+			SizeBytes: int64(img.MinDisk) << 28, DiskFormat: "qcow2", ContainerFormat: "bare", Properties: img.Metadata})
+	}
+	return out, nil
+}
+
+// limitsClient implements client.LimitsClient on top of a demo Cloud.
+type limitsClient struct{ *Cloud }
+
+// Limits returns a LimitsClient backed by the demo cloud.
+func (c *Cloud) Limits() client.LimitsClient { return limitsClient{c} }
+
+func (c limitsClient) GetLimits(ctx context.Context) (*client.Limits, error) {
+	q, _ := computeClient(c).GetQuotaSet(ctx, c.projectID)
+	v, _ := storageClient(c).GetQuotaSet(c.projectID)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	used := 0
+	for _, f := range c.fips {
+		if f.PortID != "" {
+			used++
+		}
+	}
+	return &client.Limits{
+		Compute: &cLimits.Limits{Absolute: cLimits.Absolute{
+			MaxTotalCores: q.Cores.Limit, TotalCoresUsed: q.Cores.InUse,
+			MaxTotalInstances: q.Instances.Limit, TotalInstancesUsed: q.Instances.InUse,
+			MaxTotalRAMSize: q.RAM.Limit, TotalRAMUsed: q.RAM.InUse,
+			MaxTotalKeypairs:  q.KeyPairs.Limit,
+			MaxSecurityGroups: q.SecurityGroups.Limit, TotalSecurityGroupsUsed: q.SecurityGroups.InUse,
+			MaxTotalFloatingIps: 100, TotalFloatingIpsUsed: used,
+		}},
+		Volume: &vLimits.Limits{Absolute: vLimits.Absolute{
+			MaxTotalVolumes: v.Volumes.Limit, TotalVolumesUsed: v.Volumes.InUse,
+			MaxTotalSnapshots: v.Snapshots.Limit, TotalSnapshotsUsed: v.Snapshots.InUse,
+			MaxTotalVolumeGigabytes: v.Gigabytes.Limit, TotalGigabytesUsed: v.Gigabytes.InUse,
+			MaxTotalBackups: v.Backups.Limit,
+		}, Rate: []vLimits.Rate{{URI: "*", Regex: ".*", Limit: []vLimits.Limit{
+			{Verb: "POST", Value: 120, Unit: "MINUTE", Remaining: 117, NextAvailable: time.Now().UTC().Format(time.RFC3339)},
+			{Verb: "DELETE", Value: 60, Unit: "MINUTE", Remaining: 60},
+		}}}},
+	}, nil
+}
+
+// dnsClient implements client.DNSClient on top of a demo Cloud.
+type dnsClient struct{ *Cloud }
+
+// DNS returns a DNSClient backed by the demo cloud.
+func (c *Cloud) DNS() client.DNSClient { return dnsClient{c} }
+
+func (c dnsClient) ListZones(ctx context.Context) ([]client.Zone, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]client.Zone(nil), c.dnsZones...), nil
+}
+
+func (c dnsClient) ListRecordSets(ctx context.Context, zoneID string) ([]client.RecordSet, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]client.RecordSet(nil), c.recordSets[zoneID]...), nil
+}
+
+// ListRecordSetPage filters like Designate: the type exactly, the name
+// exactly or with "*" wildcards, both ignoring case, and the data as one
+// of the records.
+func (c dnsClient) ListRecordSetPage(ctx context.Context, zoneID string, q client.RecordSetQuery) (client.RecordSetPage, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var matched []client.RecordSet
+	for _, rs := range c.recordSets[zoneID] {
+		if q.Type != "" && !strings.EqualFold(rs.Type, q.Type) {
+			continue
+		}
+		if q.Name != "" {
+			if ok, _ := path.Match(strings.ToLower(q.Name), strings.ToLower(rs.Name)); !ok {
+				continue
+			}
+		}
+		if q.Data != "" && !slices.Contains(rs.Records, q.Data) {
+			continue
+		}
+		matched = append(matched, rs)
+	}
+	start := 0
+	if q.Marker != "" {
+		start = slices.IndexFunc(matched, func(rs client.RecordSet) bool { return rs.ID == q.Marker }) + 1
+		if start == 0 {
+			return client.RecordSetPage{}, notFound("marker", q.Marker)
+		}
+	}
+	end := len(matched)
+	if q.Limit > 0 && start+q.Limit < end {
+		end = start + q.Limit
+	}
+	page := client.RecordSetPage{RecordSets: append([]client.RecordSet(nil), matched[start:end]...), Total: len(matched)}
+	if end < len(matched) {
+		page.NextMarker = matched[end-1].ID
+	}
+	return page, nil
+}
+
+// CreateRecordSet refuses names outside the zone and a second record set
+// of the same name and type, as Designate does.
+func (c dnsClient) CreateRecordSet(ctx context.Context, zoneID string, rs client.RecordSet) (*client.RecordSet, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	i := slices.IndexFunc(c.dnsZones, func(z client.Zone) bool { return z.ID == zoneID })
+	if i < 0 {
+		return nil, notFound("zone", zoneID)
+	}
+	zone := c.dnsZones[i]
+	if !strings.HasSuffix(rs.Name, "."+zone.Name) {
+		return nil, fmt.Errorf("invalid recordset name %s: not in zone %s", rs.Name, zone.Name)
+	}
+	for _, other := range c.recordSets[zoneID] {
+		if strings.EqualFold(other.Name, rs.Name) && other.Type == rs.Type {
+			return nil, fmt.Errorf("duplicate recordset %s %s", rs.Name, rs.Type)
+		}
+	}
+	if rs.TTL == 0 {
+		rs.TTL = zone.TTL
+	}
+	c.seq++
+	rs.ID, rs.Status = fmt.Sprintf("00000000-0000-4000-d100-%012x", c.seq), "ACTIVE"
+	rs.Records = append([]string(nil), rs.Records...)
+	c.recordSets[zoneID] = append(c.recordSets[zoneID], rs)
+	return &rs, nil
+}
+
+func (c dnsClient) UpdateRecordSet(ctx context.Context, zoneID, id string, records []string, ttl int) (*client.RecordSet, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, rs := range c.recordSets[zoneID] {
+		if rs.ID != id {
+			continue
+		}
+		rs.Records = append([]string(nil), records...)
+		if ttl > 0 {
+			rs.TTL = ttl
+		}
+		c.recordSets[zoneID][i] = rs
+		return &rs, nil
+	}
+	return nil, notFound("recordset", id)
+}
+
+// loadBalancerClient implements client.LoadBalancerClient on top of a demo Cloud.
+type loadBalancerClient struct{ *Cloud }
+
+// LoadBalancer returns a LoadBalancerClient backed by the demo cloud.
+func (c *Cloud) LoadBalancer() client.LoadBalancerClient { return loadBalancerClient{c} }
+
+func (c loadBalancerClient) ListLoadBalancers(ctx context.Context) ([]client.LoadBalancer, error) {
+	_ = ctx // ctx currently unused
+	return append([]client.LoadBalancer(nil), c.lbs...), nil
+}
+
+func (c loadBalancerClient) ListListeners(ctx context.Context, lbID string) ([]client.Listener, error) {
+	_ = ctx // ctx currently unused
+	return append([]client.Listener(nil), c.listeners[lbID]...), nil
+}
+
+func (c loadBalancerClient) ListPools(ctx context.Context, lbID string) ([]client.Pool, error) {
+	_ = ctx // ctx currently unused
+	return append([]client.Pool(nil), c.pools[lbID]...), nil
+}
+
+func (c loadBalancerClient) ListMemberStatuses(ctx context.Context, lbID string) ([]client.MemberStatus, error) {
+	_ = ctx // ctx currently unused
+	return append([]client.MemberStatus(nil), c.members[lbID]...), nil
+}
@@ -0,0 +1,161 @@
+package demo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"ostui/internal/client"
+)
+
+// sharedFSClient implements client.SharedFSClient on top of a demo Cloud.
+type sharedFSClient struct{ *Cloud }
+
+// SharedFS returns a SharedFSClient backed by the demo cloud.
+func (c *Cloud) SharedFS() client.SharedFSClient { return sharedFSClient{c} }
+
+// cephMonitors are the monitor addresses in the demo CephFS export paths.
+const cephMonitors = "10.30.0.11:6789,10.30.0.12:6789,10.30.0.13:6789"
+
+// exportPath returns the export location of a share of the given protocol.
+func exportPath(proto, id string) string {
+	if strings.EqualFold(proto, "CEPHFS") {
+		return fmt.Sprintf("%s:/volumes/_nogroup/%s", cephMonitors, id)
+	}
+	return fmt.Sprintf("10.30.0.20:/shares/share-%s", id)
+}
+
+func (c sharedFSClient) findShare(id string) int {
+	for i, s := range c.shares {
+		if s.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func (c sharedFSClient) ListShares(ctx context.Context) ([]client.Share, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]client.Share(nil), c.shares...), nil
+}
+
+func (c sharedFSClient) GetShare(ctx context.Context, id string) (*client.Share, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	i := c.findShare(id)
+	if i < 0 {
+		return nil, notFound("share", id)
+	}
+	s := c.shares[i]
+	return &s, nil
+}
+
+func (c sharedFSClient) CreateShare(ctx context.Context, in client.ShareCreateInput) (*client.Share, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	proto := strings.ToUpper(in.ShareProto)
+	if proto != "CEPHFS" && proto != "NFS" {
+		return nil, fmt.Errorf("share protocol %q is not supported by this cloud (CEPHFS, NFS)", in.ShareProto)
+	}
+	if in.Size < 1 {
+		return nil, fmt.Errorf("share size must be at least 1 GB")
+	}
+	c.seq++
+	s := client.Share{ID: fmt.Sprintf("00000000-0000-4000-f000-%012x", c.seq), Name: in.Name, Description: in.Description, ShareProto: proto, Size: in.Size, ShareTypeName: in.ShareType, AvailabilityZone: "nova", Status: "available", ProjectID: c.projectID, Metadata: in.Metadata, CreatedAt: time.Now().UTC()}
+	if s.ShareTypeName == "" {
+		s.ShareTypeName = "default"
+	}
+	c.shares = append(c.shares, s)
+	c.shareExports[s.ID] = []client.ShareExportLocation{{ID: fmt.Sprintf("00000000-0000-4000-f001-%012x", c.seq), Path: exportPath(proto, s.ID), Preferred: true}}
+	return &s, nil
+}
+
+func (c sharedFSClient) DeleteShare(ctx context.Context, id string) error {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	i := c.findShare(id)
+	if i < 0 {
+		return notFound("share", id)
+	}
+	c.shares = append(c.shares[:i], c.shares[i+1:]...)
+	delete(c.shareExports, id)
+	delete(c.shareRules, id)
+	return nil
+}
+
+func (c sharedFSClient) ListExportLocations(ctx context.Context, shareID string) ([]client.ShareExportLocation, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.findShare(shareID) < 0 {
+		return nil, notFound("share", shareID)
+	}
+	return append([]client.ShareExportLocation(nil), c.shareExports[shareID]...), nil
+}
+
+func (c sharedFSClient) ListAccessRules(ctx context.Context, shareID string) ([]client.ShareAccessRule, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.findShare(shareID) < 0 {
+		return nil, notFound("share", shareID)
+	}
+	return append([]client.ShareAccessRule(nil), c.shareRules[shareID]...), nil
+}
+
+func (c sharedFSClient) GrantAccess(ctx context.Context, shareID, accessType, accessTo, level string) (*client.ShareAccessRule, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	i := c.findShare(shareID)
+	if i < 0 {
+		return nil, notFound("share", shareID)
+	}
+	if level != "rw" && level != "ro" {
+		return nil, fmt.Errorf("invalid access level %q (rw or ro)", level)
+	}
+	// The CephFS native driver only understands cephx rules, the NFS
+	// drivers only ip rules.
+	want := "ip"
+	if c.shares[i].ShareProto == "CEPHFS" {
+		want = "cephx"
+	}
+	if accessType != want {
+		return nil, fmt.Errorf("%s shares only accept %s access rules", c.shares[i].ShareProto, want)
+	}
+	for _, r := range c.shareRules[shareID] {
+		if r.AccessType == accessType && r.AccessTo == accessTo {
+			return nil, fmt.Errorf("share already has an access rule for %s", accessTo)
+		}
+	}
+	c.seq++
+	r := client.ShareAccessRule{ID: fmt.Sprintf("00000000-0000-4000-f002-%012x", c.seq), ShareID: shareID, AccessType: accessType, AccessTo: accessTo, AccessLevel: level, State: "active"}
+	if accessType == "cephx" {
+		r.AccessKey = fmt.Sprintf("AQD%012xdemo%012x==", c.seq, c.seq*7919)
+	}
+	c.shareRules[shareID] = append(c.shareRules[shareID], r)
+	return &r, nil
+}
+
+func (c sharedFSClient) RevokeAccess(ctx context.Context, shareID, ruleID string) error {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.findShare(shareID) < 0 {
+		return notFound("share", shareID)
+	}
+	rules := c.shareRules[shareID]
+	for i, r := range rules {
+		if r.ID == ruleID {
+			c.shareRules[shareID] = append(rules[:i], rules[i+1:]...)
+			return nil
+		}
+	}
+	return notFound("access rule", ruleID)
+}
@@ -0,0 +1,153 @@
+package demo
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/subnets"
+	"ostui/internal/client"
+)
+
+// addSubnetPools adds an IPv4 and an IPv6 subnet pool in address scopes,
+// with one tenant subnet allocated from the IPv4 pool.
+func (c *Cloud) addSubnetPools() {
+	c.addressScopes = []client.AddressScope{
+		{ID: "5c0e1f52-3a4b-4c6d-8e9f-a0b1c2d3e401", Name: "corp-v4", IPVersion: 4, Shared: true, ProjectID: c.projectID},
+		{ID: "5c0e1f52-3a4b-4c6d-8e9f-a0b1c2d3e402", Name: "corp-v6", IPVersion: 6, Shared: true, ProjectID: c.projectID},
+	}
+	c.subnetPools = []client.SubnetPool{
+		{ID: "7a1d2e63-4b5c-4d7e-9f0a-b1c2d3e4f501", Name: "shared-v4", Prefixes: []string{"10.128.0.0/12"}, DefaultPrefixLen: 24, MinPrefixLen: 16, MaxPrefixLen: 28,
+			AddressScopeID: c.addressScopes[0].ID, IPversion: 4, Shared: true, IsDefault: true, Description: "Routable corporate IPv4 space", ProjectID: c.projectID},
+		{ID: "7a1d2e63-4b5c-4d7e-9f0a-b1c2d3e4f502", Name: "shared-v6", Prefixes: []string{"fd10::/48"}, DefaultPrefixLen: 64, MinPrefixLen: 48, MaxPrefixLen: 64,
+			AddressScopeID: c.addressScopes[1].ID, IPversion: 6, Shared: true, Description: "Routable corporate IPv6 space", ProjectID: c.projectID},
+	}
+	if len(c.networks) > 1 {
+		n := c.networks[1]
+		c.subnets = append(c.subnets, subnets.Subnet{ID: "7a1d2e63-4b5c-4d7e-9f0a-b1c2d3e4f5a1", Name: n.Name + "-pool", NetworkID: n.ID, CIDR: "10.128.0.0/24",
+			GatewayIP: "10.128.0.1", IPVersion: 4, EnableDHCP: true, SubnetPoolID: c.subnetPools[0].ID})
+	}
+}
+
+func (c networkClient) ListSubnetPools(ctx context.Context) ([]client.SubnetPool, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]client.SubnetPool(nil), c.subnetPools...), nil
+}
+
+func (c networkClient) ListAddressScopes(ctx context.Context) ([]client.AddressScope, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]client.AddressScope(nil), c.addressScopes...), nil
+}
+
+// CreateSubnet creates a subnet with the given CIDR, or allocates the first
+// free prefix of the pool like Neutron does.
+func (c networkClient) CreateSubnet(ctx context.Context, opts subnets.CreateOpts) (*subnets.Subnet, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	found := false
+	for _, n := range c.networks {
+		found = found || n.ID == opts.NetworkID
+	}
+	if !found {
+		return nil, notFound("network", opts.NetworkID)
+	}
+	s := subnets.Subnet{NetworkID: opts.NetworkID, Name: opts.Name, CIDR: opts.CIDR, IPVersion: int(opts.IPVersion), SubnetPoolID: opts.SubnetPoolID, EnableDHCP: true}
+	if opts.SubnetPoolID != "" && opts.CIDR == "" {
+		cidr, err := c.allocateFromPool(opts.SubnetPoolID, opts.Prefixlen)
+		if err != nil {
+			return nil, err
+		}
+		s.CIDR = cidr
+	}
+	prefix, err := netip.ParsePrefix(s.CIDR)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CIDR %q", s.CIDR)
+	}
+	for _, other := range c.subnets {
+		if o, err := netip.ParsePrefix(other.CIDR); err == nil && other.NetworkID == s.NetworkID && o.Overlaps(prefix) {
+			return nil, fmt.Errorf("requested subnet with cidr: %s for network: %s overlaps with another subnet", s.CIDR, s.NetworkID)
+		}
+	}
+	if prefix.Addr().Is4() {
+		s.IPVersion = 4
+	} else {
+		s.IPVersion = 6
+	}
+	s.GatewayIP = prefix.Masked().Addr().Next().String()
+	c.seq++
+	s.ID = fmt.Sprintf("00000000-0000-4000-f000-%012x", c.seq)
+	c.subnets = append(c.subnets, s)
+	return &s, nil
+}
+
+// allocateFromPool returns the first prefix of length bits (the pool's
+// default when 0) that overlaps no subnet allocated from the pool.
+func (c networkClient) allocateFromPool(poolID string, bits int) (string, error) {
+	for _, p := range c.subnetPools {
+		if p.ID != poolID {
+			continue
+		}
+		if bits == 0 {
+			bits = p.DefaultPrefixLen
+		}
+		if bits < p.MinPrefixLen || bits > p.MaxPrefixLen {
+			return "", fmt.Errorf("prefix length /%d is outside the pool's /%d-/%d", bits, p.MinPrefixLen, p.MaxPrefixLen)
+		}
+		var used []netip.Prefix
+		for _, s := range c.subnets {
+			if u, err := netip.ParsePrefix(s.CIDR); err == nil && s.SubnetPoolID == poolID {
+				used = append(used, u)
+			}
+		}
+		for _, raw := range p.Prefixes {
+			pool, err := netip.ParsePrefix(raw)
+			if err != nil || bits < pool.Bits() {
+				continue
+			}
+			// Walk the candidates of the pool prefix; the demo pools are
+			// small enough not to bound the walk.
+			for cand := netip.PrefixFrom(pool.Addr(), bits); pool.Contains(cand.Addr()); {
+				free := true
+				for _, u := range used {
+					free = free && !u.Overlaps(cand)
+				}
+				if free {
+					return cand.String(), nil
+				}
+				next, ok := nextPrefix(cand)
+				if !ok {
+					break
+				}
+				cand = next
+			}
+		}
+		return "", fmt.Errorf("no free /%d prefix left in subnet pool %s", bits, p.Name)
+	}
+	return "", notFound("subnet pool", poolID)
+}
+
+// nextPrefix returns the prefix of the same length following p.
+func nextPrefix(p netip.Prefix) (netip.Prefix, bool) {
+	b := p.Addr().AsSlice()
+	// Add one at the last bit of the prefix, carrying leftwards.
+	bit := p.Bits() - 1
+	for i := bit / 8; i >= 0; i-- {
+		inc := byte(1)
+		if i == bit/8 {
+			inc = byte(1) << (7 - uint(bit%8))
+		}
+		sum := b[i] + inc
+		carry := sum < b[i]
+		b[i] = sum
+		if !carry {
+			addr, _ := netip.AddrFromSlice(b)
+			return netip.PrefixFrom(addr, p.Bits()), true
+		}
+	}
+	return netip.Prefix{}, false
+}
@@ -0,0 +1,101 @@
+package demo
+
+import (
+	"context"
+	"fmt"
+
+	"ostui/internal/client"
+)
+
+func (c networkClient) ListTapServices(ctx context.Context) ([]client.TapService, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]client.TapService(nil), c.tapServices...), nil
+}
+
+func (c networkClient) CreateTapService(ctx context.Context, ts client.TapService) (*client.TapService, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.hasPort(ts.PortID) {
+		return nil, notFound("port", ts.PortID)
+	}
+	c.seq++
+	ts.ID = fmt.Sprintf("00000000-0000-4000-e000-%012x", c.seq)
+	ts.Status = "ACTIVE"
+	ts.ProjectID = c.projectID
+	c.tapServices = append(c.tapServices, ts)
+	return &ts, nil
+}
+
+func (c networkClient) DeleteTapService(ctx context.Context, id string) error {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, ts := range c.tapServices {
+		if ts.ID == id {
+			c.tapServices = append(c.tapServices[:i], c.tapServices[i+1:]...)
+			// Neutron deletes the flows of a tap service with it.
+			var flows []client.TapFlow
+			for _, f := range c.tapFlows {
+				if f.TapServiceID != id {
+					flows = append(flows, f)
+				}
+			}
+			c.tapFlows = flows
+			return nil
+		}
+	}
+	return notFound("tap service", id)
+}
+
+func (c networkClient) ListTapFlows(ctx context.Context, tapServiceID string) ([]client.TapFlow, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var out []client.TapFlow
+	for _, f := range c.tapFlows {
+		if tapServiceID == "" || f.TapServiceID == tapServiceID {
+			out = append(out, f)
+		}
+	}
+	return out, nil
+}
+
+func (c networkClient) CreateTapFlow(ctx context.Context, tf client.TapFlow) (*client.TapFlow, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.hasPort(tf.SourcePort) {
+		return nil, notFound("port", tf.SourcePort)
+	}
+	c.seq++
+	tf.ID = fmt.Sprintf("00000000-0000-4000-e100-%012x", c.seq)
+	tf.Status = "ACTIVE"
+	c.tapFlows = append(c.tapFlows, tf)
+	return &tf, nil
+}
+
+func (c networkClient) DeleteTapFlow(ctx context.Context, id string) error {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, f := range c.tapFlows {
+		if f.ID == id {
+			c.tapFlows = append(c.tapFlows[:i], c.tapFlows[i+1:]...)
+			return nil
+		}
+	}
+	return notFound("tap flow", id)
+}
+
+// hasPort reports whether a port exists; the caller holds c.mu.
+func (c networkClient) hasPort(id string) bool {
+	for _, p := range c.ports {
+		if p.ID == id {
+			return true
+		}
+	}
+	return false
+}
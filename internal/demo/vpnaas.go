@@ -0,0 +1,54 @@
+package demo
+
+import (
+	"context"
+
+	"ostui/internal/client"
+)
+
+func (c networkClient) ListVPNServices(ctx context.Context) ([]client.VPNService, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]client.VPNService(nil), c.vpnServices...), nil
+}
+
+func (c networkClient) ListIKEPolicies(ctx context.Context) ([]client.IKEPolicy, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]client.IKEPolicy(nil), c.ikePolicies...), nil
+}
+
+func (c networkClient) ListIPSecPolicies(ctx context.Context) ([]client.IPSecPolicy, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]client.IPSecPolicy(nil), c.ipsecPolicies...), nil
+}
+
+func (c networkClient) ListVPNConnections(ctx context.Context) ([]client.VPNConnection, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]client.VPNConnection(nil), c.vpnConns...), nil
+}
+
+func (c networkClient) GetVPNConnection(ctx context.Context, id string) (*client.VPNConnection, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, conn := range c.vpnConns {
+		if conn.ID == id {
+			return &conn, nil
+		}
+	}
+	return nil, notFound("ipsec site connection", id)
+}
+
+func (c networkClient) ListVPNEndpointGroups(ctx context.Context) ([]client.VPNEndpointGroup, error) {
+	_ = ctx // ctx currently unused
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]client.VPNEndpointGroup(nil), c.vpnGroups...), nil
+}
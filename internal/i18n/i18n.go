@@ -0,0 +1,68 @@
+// Package i18n translates the user-facing strings of the UI. A message is
+// identified by its English text, so untranslated messages, and every message
+// in the English locale, are shown as written in the code.
+package i18n
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Default is the locale messages are written in.
+const Default = "en"
+
+// catalogs maps a locale to its translations, keyed by the English message.
+var catalogs = map[string]map[string]string{
+	Default: {},
+	"it":    italian,
+}
+
+// active is the catalog of the selected locale.
+var (
+	locale = Default
+	active = catalogs[Default]
+)
+
+// Locales returns the supported locales, sorted.
+func Locales() []string {
+	out := make([]string, 0, len(catalogs))
+	for l := range catalogs {
+		out = append(out, l)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// Locale returns the selected locale.
+func Locale() string { return locale }
+
+// SetLocale selects the locale messages are translated to. POSIX forms such
+// as it_IT.UTF-8 select their language; an empty tag selects English.
+func SetLocale(tag string) error {
+	lang := strings.ToLower(tag)
+	if i := strings.IndexAny(lang, "_-.@"); i >= 0 {
+		lang = lang[:i]
+	}
+	if lang == "" || lang == "c" || lang == "posix" {
+		lang = Default
+	}
+	c, ok := catalogs[lang]
+	if !ok {
+		return fmt.Errorf("unsupported locale %q: use one of %s", tag, strings.Join(Locales(), ", "))
+	}
+	locale, active = lang, c
+	return nil
+}
+
+// T translates msg to the selected locale. With args, msg is a format
+// string and the translation keeps its verbs in the same order.
+func T(msg string, args ...any) string {
+	if tr, ok := active[msg]; ok {
+		msg = tr
+	}
+	if len(args) > 0 {
+		return fmt.Sprintf(msg, args...)
+	}
+	return msg
+}
@@ -0,0 +1,230 @@
+package i18n
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"unicode"
+)
+
+func TestSetLocale(t *testing.T) {
+	t.Cleanup(func() { _ = SetLocale(Default) })
+	if err := SetLocale("it_IT.UTF-8"); err != nil || Locale() != "it" {
+		t.Fatalf("got locale %s, %v", Locale(), err)
+	}
+	if got := T("Servers"); got != "Server" {
+		t.Errorf("T(Servers) = %q", got)
+	}
+	if got := T("Terraform: %d resources from %s", 3, "main.tfstate"); got != "Terraform: 3 risorse da main.tfstate" {
+		t.Errorf("formatted message = %q", got)
+	}
+	if got := T("not in any catalog"); got != "not in any catalog" {
+		t.Errorf("untranslated message = %q", got)
+	}
+	if err := SetLocale("xx"); err == nil || Locale() != "it" {
+		t.Errorf("expected an error keeping the locale, got %v and %s", err, Locale())
+	}
+	if err := SetLocale(""); err != nil || T("Servers") != "Servers" {
+		t.Errorf("empty locale should be English, got %s, %v", Locale(), err)
+	}
+}
+
+var verbRe = regexp.MustCompile(`%[-+# 0]*[0-9]*(?:\.[0-9]+)?[a-zA-Z%]`)
+
+// TestVerbs checks that translations keep the format verbs of the message.
+func TestVerbs(t *testing.T) {
+	for msg, tr := range italian {
+		want, got := verbRe.FindAllString(msg, -1), verbRe.FindAllString(tr, -1)
+		if strings.Join(want, " ") != strings.Join(got, " ") {
+			t.Errorf("%q: translation has verbs %v, want %v", msg, got, want)
+		}
+	}
+}
+
+// untranslated are messages that read the same in every locale.
+var untranslated = map[string]bool{
+	"VPN": true, "BGP": true, "ID": true, "IP": true, "CIDR": true, "COE": true, "DHCP": true,
+	"DNS": true, "MAC": true, "MTU": true, "OS": true, "PFS": true, "RAM": true, "RBAC": true,
+	"TLS": true, "TTL": true, "Terraform": true, "OSTUI – OpenStack TUI": true,
+}
+
+var uiField = regexp.MustCompile(`(?i)(status|message|title)$`)
+
+// shown reports whether a field or variable named name holds text shown to
+// the user: status lines, messages and titles. Status fields of resources
+// hold the state the API reports and are left as they are.
+func shown(name string) bool {
+	return name != "Status" && uiField.MatchString(name)
+}
+
+// TestCoverage checks that the Italian catalog translates every i18n.T
+// call of the UI, and the help entries (key and section) and sidebar items
+// of the shell. It also flags text left outside i18n.T where the UI keeps
+// its user-facing strings: status, message and title fields, column titles,
+// detail rows and fields, and form labels.
+func TestCoverage(t *testing.T) {
+	var files []string
+	filepath.WalkDir("../ui", func(path string, d fs.DirEntry, err error) error {
+		if err == nil && !d.IsDir() && strings.HasSuffix(path, ".go") && !strings.HasSuffix(path, "_test.go") {
+			files = append(files, path)
+		}
+		return err
+	})
+	fset := token.NewFileSet()
+	for _, path := range files {
+		shell := path == filepath.Join("..", "ui", "app.go") || filepath.Dir(path) == filepath.Join("..", "ui", "common")
+		f, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		check := func(e ast.Expr) {
+			lit, ok := e.(*ast.BasicLit)
+			if !ok || lit.Kind != token.STRING {
+				return
+			}
+			msg, _ := strconv.Unquote(lit.Value)
+			if _, ok := italian[msg]; !ok && msg != "" && !untranslated[msg] {
+				t.Errorf("%s: no Italian translation for %q", fset.Position(lit.Pos()), msg)
+			}
+		}
+		// literal flags text in e that is not passed through i18n.T.
+		var literal func(e ast.Expr)
+		literal = func(e ast.Expr) {
+			switch e := e.(type) {
+			case *ast.BasicLit:
+				msg, _ := strconv.Unquote(e.Value)
+				text := verbRe.ReplaceAllString(msg, "")
+				if e.Kind == token.STRING && strings.IndexFunc(text, unicode.IsLetter) >= 0 && !untranslated[strings.TrimSpace(msg)] {
+					t.Errorf("%s: %q is not wrapped in i18n.T", fset.Position(e.Pos()), msg)
+				}
+			case *ast.BinaryExpr:
+				literal(e.X)
+				literal(e.Y)
+			case *ast.ParenExpr:
+				literal(e.X)
+			case *ast.CallExpr:
+				if fn, ok := e.Fun.(*ast.SelectorExpr); ok && isPkg(fn, "fmt", "Sprintf") && len(e.Args) > 0 {
+					literal(e.Args[0])
+				}
+			}
+		}
+		ast.Inspect(f, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.AssignStmt:
+				if len(n.Lhs) != len(n.Rhs) {
+					break
+				}
+				for i, lhs := range n.Lhs {
+					if shown(name(lhs)) {
+						literal(n.Rhs[i])
+					}
+					// The labels of a detail view.
+					if c, ok := n.Rhs[i].(*ast.CompositeLit); ok && name(lhs) == "fields" {
+						for _, el := range c.Elts {
+							if kv, ok := el.(*ast.KeyValueExpr); ok {
+								literal(kv.Key)
+							}
+						}
+					}
+				}
+			case *ast.GenDecl:
+				if n.Tok != token.VAR {
+					break
+				}
+				for _, spec := range n.Specs {
+					vs := spec.(*ast.ValueSpec)
+					for i, id := range vs.Names {
+						if i >= len(vs.Values) {
+							continue
+						}
+						if shown(id.Name) {
+							literal(vs.Values[i])
+						}
+						// Tab titles are translated when drawn.
+						if c, ok := vs.Values[i].(*ast.CompositeLit); ok && strings.HasSuffix(id.Name, "Titles") {
+							for _, el := range c.Elts {
+								if kv, ok := el.(*ast.KeyValueExpr); ok {
+									check(kv.Value)
+								}
+							}
+						}
+					}
+				}
+			case *ast.CallExpr:
+				switch fn := n.Fun.(type) {
+				case *ast.SelectorExpr:
+					if isPkg(fn, "i18n", "T") {
+						check(n.Args[0])
+					}
+					if isPkg(fn, "common", "NewForm") || isPkg(fn, "common", "NewDetail") {
+						for _, a := range n.Args {
+							if c, ok := a.(*ast.CompositeLit); ok {
+								for _, el := range c.Elts {
+									if kv, ok := el.(*ast.KeyValueExpr); ok {
+										el = kv.Key
+									}
+									literal(el)
+								}
+							} else {
+								literal(a)
+							}
+						}
+					}
+				case *ast.Ident:
+					if !shell {
+						break
+					}
+					if fn.Name == "key" && len(n.Args) == 2 {
+						check(n.Args[1])
+					} else if fn.Name == "section" && len(n.Args) == 1 {
+						check(n.Args[0])
+					}
+				}
+			case *ast.CompositeLit:
+				if typ, ok := n.Type.(*ast.SelectorExpr); ok && isPkg(typ, "table", "Row") {
+					for _, el := range n.Elts {
+						literal(el)
+					}
+				}
+				if typ, ok := n.Type.(*ast.Ident); ok && shell && (typ.Name == "item" || typ.Name == "confirmItem") {
+					// Sidebar items are translated when drawn.
+					for _, el := range n.Elts {
+						if kv, ok := el.(*ast.KeyValueExpr); ok {
+							check(kv.Value)
+						}
+					}
+					break
+				}
+				for _, el := range n.Elts {
+					if kv, ok := el.(*ast.KeyValueExpr); ok && shown(name(kv.Key)) {
+						literal(kv.Value)
+					}
+				}
+			}
+			return true
+		})
+	}
+}
+
+// isPkg reports whether fn is the selector pkg.sel.
+func isPkg(fn *ast.SelectorExpr, pkg, sel string) bool {
+	id, ok := fn.X.(*ast.Ident)
+	return ok && id.Name == pkg && fn.Sel.Name == sel
+}
+
+// name returns the name of an identifier or the field of a selector.
+func name(e ast.Expr) string {
+	switch e := e.(type) {
+	case *ast.Ident:
+		return e.Name
+	case *ast.SelectorExpr:
+		return e.Sel.Name
+	}
+	return ""
+}
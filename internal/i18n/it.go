@@ -0,0 +1,1209 @@
+package i18n
+
+// italian holds the Italian translations. OpenStack resource and service
+// names (VPN, BGP, Magnum, ...) and key names are kept as they are.
+var italian = map[string]string{
+	// Sidebar sections.
+	"=== COMPUTE ===":    "=== CALCOLO ===",
+	"=== NETWORK ===":    "=== RETE ===",
+	"=== STORAGE ===":    "=== ARCHIVIAZIONE ===",
+	"=== TOPOLOGY ===":   "=== TOPOLOGIA ===",
+	"=== IDENTITY ===":   "=== IDENTITÀ ===",
+	"=== DNS ===":        "=== DNS ===",
+	"Servers":            "Server",
+	"Images":             "Immagini",
+	"Flavors":            "Flavor",
+	"Keypairs":           "Coppie di chiavi",
+	"Hypervisors":        "Hypervisor",
+	"Availability Zones": "Zone di disponibilità",
+	"Limits":             "Limiti",
+	"AZ Consistency":     "Coerenza delle AZ",
+	"Clusters":           "Cluster",
+	"Networks":           "Reti",
+	"Subnets":            "Subnet",
+	"Subnet Pools":       "Pool di subnet",
+	"Routers":            "Router",
+	"Ports":              "Porte",
+	"Floating IPs":       "IP floating",
+	"Security Groups":    "Gruppi di sicurezza",
+	"Load Balancers":     "Bilanciatori di carico",
+	"Tap Services":       "Servizi tap",
+	"Firewalls":          "Firewall",
+	"Volumes":            "Volumi",
+	"Snapshots":          "Snapshot",
+	"Shares":             "Condivisioni",
+	"Topology":           "Topologia",
+	"Problems":           "Problemi",
+	"Projects":           "Progetti",
+	"Users":              "Utenti",
+	"Domains":            "Domini",
+	"Trusts":             "Trust",
+	"EC2 Credentials":    "Credenziali EC2",
+	"Token":              "Token",
+	"Secrets":            "Segreti",
+	"Clouds":             "Cloud",
+	"Jobs":               "Attività",
+	"Schedules":          "Pianificazioni",
+	"Events":             "Eventi",
+	"Zones":              "Zone",
+	"Zone Transfers":     "Trasferimenti di zone",
+	"Exit":               "Esci",
+
+	// Sidebar descriptions.
+	"List and manage servers":                                     "Elenca e gestisci i server",
+	"List and manage images":                                      "Elenca e gestisci le immagini",
+	"List and manage flavors":                                     "Elenca e gestisci i flavor",
+	"List and manage keypairs":                                    "Elenca e gestisci le coppie di chiavi",
+	"List hypervisors":                                            "Elenca gli hypervisor",
+	"Availability zones":                                          "Zone di disponibilità",
+	"Show compute and volume quotas":                              "Mostra le quote di calcolo e dei volumi",
+	"Servers and volumes in different zones":                      "Server e volumi in zone diverse",
+	"Magnum clusters, scaling and kubeconfig":                     "Cluster Magnum, scalatura e kubeconfig",
+	"List and manage networks":                                    "Elenca e gestisci le reti",
+	"List and manage subnets":                                     "Elenca e gestisci le subnet",
+	"Subnet pools and address scopes":                             "Pool di subnet e address scope",
+	"List and manage routers":                                     "Elenca e gestisci i router",
+	"List and manage ports":                                       "Elenca e gestisci le porte",
+	"List and manage floating IPs":                                "Elenca e gestisci gli IP floating",
+	"List and manage security groups":                             "Elenca e gestisci i gruppi di sicurezza",
+	"List load balancers":                                         "Elenca i bilanciatori di carico",
+	"Port mirroring (tap services and flows)":                     "Mirroring delle porte (servizi e flussi tap)",
+	"Site-to-site VPN connections and policies":                   "Connessioni VPN site-to-site e policy",
+	"FWaaS v2 firewall groups, policies and rules":                "Gruppi, policy e regole firewall FWaaS v2",
+	"BGP speakers, advertised routes and dragents (admin)":        "Speaker BGP, rotte annunciate e dragent (admin)",
+	"List and manage volumes":                                     "Elenca e gestisci i volumi",
+	"List and manage snapshots":                                   "Elenca e gestisci gli snapshot",
+	"Manila shares, export locations and access rules":            "Condivisioni Manila, percorsi di export e regole di accesso",
+	"View topology of resources":                                  "Mostra la topologia delle risorse",
+	"Everything unhealthy in the project (!)":                     "Tutto ciò che non funziona nel progetto (!)",
+	"List OpenStack projects":                                     "Elenca i progetti OpenStack",
+	"List OpenStack users":                                        "Elenca gli utenti OpenStack",
+	"List identity domains":                                       "Elenca i domini di identità",
+	"Keystone trusts granted and received":                        "Trust Keystone concessi e ricevuti",
+	"Access/secret pairs for S3 and EC2 APIs":                     "Coppie access/secret per le API S3 ed EC2",
+	"Show token info":                                             "Mostra le informazioni sul token",
+	"Barbican secrets and containers":                             "Segreti e contenitori Barbican",
+	"clouds.yaml entries and connection tests":                    "Voci di clouds.yaml e test di connessione",
+	"Actions scheduled with :at and :every":                       "Azioni pianificate con :at e :every",
+	"Queued operations and actions scheduled with :at and :every": "Operazioni in coda e azioni pianificate con :at e :every",
+	"Stop and start servers at fixed times":                       "Arresta e avvia i server a orari fissi",
+	"Move zones between projects":                                 "Sposta le zone tra progetti",
+	"Live notifications (--events-listen)":                        "Notifiche in tempo reale (--events-listen)",
+	"List DNS zones":                                              "Elenca le zone DNS",
+	"Quit the application":                                        "Esci dall'applicazione",
+
+	// Overview, footer and banners.
+	"[%s] Press : for command mode  [T] topology  [/] search": "[%s] Premi : per la modalità comando  [T] topologia  [/] cerca",
+	"Cloud: ":                                        "Cloud: ",
+	"Navigation":                                     "Navigazione",
+	"  ↑/k  up          ↓/j  down":                   "  ↑/k  su          ↓/j  giù",
+	"  enter  open      esc  back":                   "  enter  apri      esc  indietro",
+	"Global keys":                                    "Tasti globali",
+	"  ?   help         c   switch cloud":            "  ?   aiuto        c   cambia cloud",
+	"  T   topology     :   command mode":            "  T   topologia    :   modalità comando",
+	"  g   graph        y   JSON view":               "  g   grafo        y   vista JSON",
+	"  i   inspect      l   logs (servers)":          "  i   ispeziona    l   log (server)",
+	"Commands":                                       "Comandi",
+	"  :!<cmd>  → openstack CLI":                     "  :!<cmd>  → CLI openstack",
+	"%s view – press esc to return":                  "Vista %s – premi esc per tornare",
+	"[Modal] Press esc to close":                     "[Finestra] Premi esc per chiudere",
+	" ⚠ TLS VERIFICATION DISABLED ":                  " ⚠ VERIFICA TLS DISATTIVATA ",
+	"Terraform: %d resources from %s":                "Terraform: %d risorse da %s",
+	"Terraform: %s (not in the configured state)":    "Terraform: %s (non presente nello state configurato)",
+	"unlimited":                                      "illimitate",
+	"max %d":                                         "max %d",
+	"API: %d in flight, %d queued (%s), %d requests": "API: %d in corso, %d in coda (%s), %d richieste",
+	", max wait %s":                                  ", attesa massima %s",
+	"select a server or volume first":                "seleziona prima un server o un volume",
+
+	// Help.
+	"[?] close help":               "[?] chiudi l'aiuto",
+	"Global":                       "Globale",
+	"List view":                    "Vista elenco",
+	"Servers (detail)":             "Server (dettaglio)",
+	"Servers (list)":               "Server (elenco)",
+	"AZ consistency":               "Coerenza delle AZ",
+	"Tap services":                 "Servizi tap",
+	"Subnet pools":                 "Pool di subnet",
+	"EC2 credentials":              "Credenziali EC2",
+	"Detail view":                  "Vista dettaglio",
+	"Log viewer":                   "Visualizzatore log",
+	"Sidebar":                      "Barra laterale",
+	"Command mode":                 "Modalità comando",
+	"Quit":                         "Esci",
+	"Toggle help":                  "Mostra / nascondi l'aiuto",
+	"Switch cloud":                 "Cambia cloud",
+	"Global search (from sidebar)": "Ricerca globale (dalla barra laterale)",
+	"Problems: everything unhealthy in the project": "Problemi: tutto ciò che non funziona nel progetto",
+	"Move down / up":  "Sposta giù / su",
+	"Open detail":     "Apri il dettaglio",
+	"Filter":          "Filtra",
+	"Back to sidebar": "Torna alla barra laterale",
+	"Refresh":         "Aggiorna",
+	"View logs":       "Mostra i log",
+	"Inspect":         "Ispeziona",
+	"JSON view":       "Vista JSON",
+	"Console URL":     "URL della console",
+	"Console URL as QR code (in console view)":                                 "URL della console come codice QR (nella vista console)",
+	"Last instance action (ERROR)":                                             "Ultima azione sull'istanza (ERROR)",
+	"Hard reboot / delete after a pre-flight (ERROR)":                          "Riavvio forzato / eliminazione dopo un controllo preliminare (ERROR)",
+	"Rebuild from the image, reviewing the user data and its diff":             "Ricostruisci dall'immagine, rivedendo i dati utente e le differenze",
+	"Lifecycle: start, stop, pause, suspend, shelve, lock (valid ones only)":   "Ciclo di vita: avvia, ferma, pausa, sospendi, shelve, blocca (solo quelle valide)",
+	"Decrypt the admin password posted by the guest":                           "Decifra la password di amministratore inviata dal guest",
+	"Change the admin password":                                                "Cambia la password di amministratore",
+	"Group by status / AZ / flavor / metadata key":                             "Raggruppa per stato / AZ / flavor / chiave di metadati",
+	"Collapse / expand group (on a header)":                                    "Comprimi / espandi il gruppo (su un'intestazione)",
+	"Bulk rename / set metadata on the servers matching the filter":            "Rinomina / imposta metadati in blocco sui server che corrispondono al filtro",
+	"Next domain (multi-domain clouds)":                                        "Dominio successivo (cloud multi-dominio)",
+	"Cycle the visibility filter: public / private / community / shared":       "Scorri il filtro di visibilità: public / private / community / shared",
+	"Toggle most loaded first":                                                 "Alterna i più carichi per primi",
+	"Drain host: disable nova-compute and live-migrate its servers":            "Svuota l'host: disattiva nova-compute e migra a caldo i suoi server",
+	"Toggle all attachments / mismatches only":                                 "Alterna tutti i collegamenti / solo le discrepanze",
+	"Open server detail":                                                       "Apri il dettaglio del server",
+	"Open volume detail":                                                       "Apri il dettaglio del volume",
+	"Edit project quotas (admin)":                                              "Modifica le quote del progetto (admin)",
+	"Usage by user: servers, vCPUs, RAM and volumes":                           "Uso per utente: server, vCPU, RAM e volumi",
+	"Test the selected / all clouds without switching":                         "Prova il cloud selezionato / tutti i cloud senza cambiare",
+	"Add a cloud to clouds.yaml":                                               "Aggiungi un cloud a clouds.yaml",
+	"Reload clouds.yaml":                                                       "Ricarica clouds.yaml",
+	"Show the tap flows of the service":                                        "Mostra i flussi tap del servizio",
+	"Create / delete a tap service":                                            "Crea / elimina un servizio tap",
+	"New network, optionally pinned to availability zones":                     "Nuova rete, eventualmente vincolata a zone di disponibilità",
+	"New subnet, with a CIDR or allocated from a subnet pool":                  "Nuova subnet, con un CIDR o allocata da un pool di subnet",
+	"New router: external network, SNAT, AZ hints (HA/distributed for admins)": "Nuovo router: rete esterna, SNAT, AZ hint (HA/distribuito per gli admin)",
+	"Delete the router after a dependency preview":                             "Elimina il router dopo un'anteprima delle dipendenze",
+	"L3 agents hosting the router, with HA state (admin)":                      "Agenti L3 che ospitano il router, con lo stato HA (admin)",
+	"Subnet pools / address scopes":                                            "Pool di subnet / address scope",
+	"Pool detail: prefixes, prefix lengths, allocated subnets":                 "Dettaglio del pool: prefissi, lunghezze dei prefissi, subnet allocate",
+	"Connections / services / IKE / IPsec policies":                            "Connessioni / servizi / policy IKE / IPsec",
+	"Connection detail: peer CIDRs, DPD, policies":                             "Dettaglio della connessione: CIDR remoti, DPD, policy",
+	"Groups / policies / rules":                                                "Gruppi / policy / regole",
+	"New rule, appended to a policy":                                           "Nuova regola, aggiunta in coda a una policy",
+	"Delete rule (removed from its policies first)":                            "Elimina la regola (prima rimossa dalle sue policy)",
+	"Delegate roles on a project to another user":                              "Delega ruoli su un progetto a un altro utente",
+	"Delete a trust you granted":                                               "Elimina un trust che hai concesso",
+	"Reveal / hide the secret of the selected credential":                      "Mostra / nascondi il secret della credenziale selezionata",
+	"Create / delete a credential":                                             "Crea / elimina una credenziale",
+	"Reveal / hide the token ID":                                               "Mostra / nascondi l'ID del token",
+	"Secrets / containers":                                                     "Segreti / contenitori",
+	"Reveal the payload of a secret (asks first, then the soft-lock)":          "Mostra il contenuto di un segreto (chiede conferma, poi il soft-lock)",
+	"Store / delete a secret":                                                  "Salva / elimina un segreto",
+	"Export locations and access rules":                                        "Percorsi di export e regole di accesso",
+	"Create / delete a share":                                                  "Crea / elimina una condivisione",
+	"Open the resource, or the hypervisor of a down agent":                     "Apri la risorsa, o l'hypervisor di un agente fermo",
+	"Check again": "Controlla di nuovo",
+	"Filter by type, publisher, priority, resource or project":      "Filtra per tipo, publisher, priorità, risorsa o progetto",
+	"Pause / resume the feed":                                       "Metti in pausa / riprendi il flusso",
+	"Show / hide the payload of the selected event":                 "Mostra / nascondi il payload dell'evento selezionato",
+	"Clear the received events":                                     "Cancella gli eventi ricevuti",
+	"Cancel the selected pending job":                               "Annulla l'attività in attesa selezionata",
+	"Switch between the operations and the scheduled jobs":          "Passa dalle operazioni alle attività pianificate",
+	"Show / hide the log of the selected operation":                 "Mostra / nascondi il log dell'operazione selezionata",
+	"Retry the selected failed operation":                           "Riprova l'operazione fallita selezionata",
+	"New schedule: stop and/or start a server at fixed times":       "Nuova pianificazione: arresta e/o avvia un server a orari fissi",
+	"Record sets of the zone":                                       "Record set della zona",
+	"Transfer the zone to another project":                          "Trasferisci la zona a un altro progetto",
+	"Search record sets by name and type on the server":             "Cerca i record set per nome e tipo sul server",
+	"Previous / next page of record sets":                           "Pagina precedente / successiva dei record set",
+	"New server, checked against the instance, vCPU and RAM quotas": "Nuovo server, verificato rispetto alle quote di istanze, vCPU e RAM",
+	"New volume, checked against the volume and gigabyte quotas":    "Nuovo volume, verificato rispetto alle quote di volumi e gigabyte",
+	"Allocate a floating IP, checked against its quota":             "Alloca un floating IP, verificato rispetto alla sua quota",
+	"Accept a transfer with its ID and key":                         "Accetta un trasferimento con il suo ID e la chiave",
+	"Cancel an outgoing transfer request":                           "Annulla una richiesta di trasferimento in uscita",
+	"Offer the zone again, e.g. to another project":                 "Offri di nuovo la zona, ad es. a un altro progetto",
+	"Enable / disable the selected schedule":                        "Attiva / disattiva la pianificazione selezionata",
+	"Delete the selected schedule":                                  "Elimina la pianificazione selezionata",
+	"Cluster detail with its template":                              "Dettaglio del cluster con il suo template",
+	"Scale the worker node count":                                   "Cambia il numero di nodi worker",
+	"Write a kubeconfig (default ~/.kube/<cluster>.config)":         "Scrivi un kubeconfig (predefinito ~/.kube/<cluster>.config)",
+	"Scroll":                             "Scorri",
+	"Back to list":                       "Torna all'elenco",
+	"Download image and verify checksum": "Scarica l'immagine e verifica il checksum",
+	"Delete / force delete the volume (force: admin, any status)":                    "Elimina / forza l'eliminazione del volume (forzata: admin, qualsiasi stato)",
+	"Attach the volume to a server":                                                  "Collega il volume a un server",
+	"Snapshot the volume (forced when attached)":                                     "Snapshot del volume (forzato se collegato)",
+	"On Attached: open the server (pick one when several)":                           "Su Attached: apre il server (da scegliere se sono più d'uno)",
+	"On NetworkID / DeviceID: open the network / server or router":                   "Su NetworkID / DeviceID: apre la rete / il server o il router",
+	"On PortID / FloatingNetworkID: open the port / network":                         "Su PortID / FloatingNetworkID: apre la porta / la rete",
+	"Diagnostics: CPU, memory, NIC and disk counters (r refreshes)":                  "Diagnostica: contatori di CPU, memoria, NIC e disco (r aggiorna)",
+	"Resize: pick a new flavor":                                                      "Ridimensiona: scegli un nuovo flavor",
+	"Interactive serial console (ctrl+] closes it)":                                  "Console seriale interattiva (ctrl+] la chiude)",
+	"Create an image of the server; o opens it":                                      "Crea un'immagine del server; o la apre",
+	"DNS records resolving to the server; create or repoint one":                     "Record DNS che risolvono al server; creane o ripuntane uno",
+	"Session locked after %s without input":                                          "Sessione bloccata dopo %s senza input",
+	"Press any key to resume":                                                        "Premi un tasto per riprendere",
+	"ostui exits at %s without input":                                                "ostui termina alle %s senza input",
+	"Unrescue a server in rescue mode":                                               "Esci dalla modalità rescue del server",
+	"Confirm / revert the resize of a server in VERIFY_RESIZE":                       "Conferma / annulla il ridimensionamento di un server in VERIFY_RESIZE",
+	"Filter by age: > or <, in m, h, d, w or y":                                      "Filtra per età: > o <, in m, h, d, w o y",
+	"Point an A/AAAA record at an address of the server":                             "Punta un record A/AAAA a un indirizzo del server",
+	"Evacuate from a failed host (admin)":                                            "Evacua da un host guasto (admin)",
+	"Rebuild preserving ephemeral disk (admin)":                                      "Ricostruisci mantenendo il disco effimero (admin)",
+	"DHCP agents serving the network and its address leases":                         "Agenti DHCP che servono la rete e i suoi lease di indirizzi",
+	"Subnets / details: MTU, provider segmentation, RBAC grants":                     "Subnet / dettagli: MTU, segmentazione provider, grant RBAC",
+	"Toggle the admin state of the network (details)":                                "Attiva o disattiva lo stato amministrativo della rete (dettagli)",
+	"DHCP agents / leases":                                                           "Agenti DHCP / lease",
+	"Schedule / unschedule the router on the selected L3 agent":                      "Assegna / rimuovi il router sull'agente L3 selezionato",
+	"Move the router from the selected agent to another one":                         "Sposta il router dall'agente selezionato a un altro",
+	"Advertised routes / peers / DR agents":                                          "Rotte annunciate / peer / agenti DR",
+	"Schedule / unschedule the speaker on the selected DR agent":                     "Assegna / rimuovi lo speaker sull'agente DR selezionato",
+	"Grant / revoke access (ip, cephx, user, cert)":                                  "Concedi / revoca l'accesso (ip, cephx, user, cert)",
+	"Apply the previewed changes, one server at a time":                              "Applica le modifiche in anteprima, un server alla volta",
+	"Edit the name pattern and metadata":                                             "Modifica lo schema dei nomi e i metadati",
+	"Create the planned resources one at a time; after a run, retry the failed ones": "Crea le risorse pianificate una alla volta; dopo un'esecuzione, riprova quelle fallite",
+	"Reload the file (until something was created)":                                  "Ricarica il file (finché non è stato creato nulla)",
+	"Run the highlighted action and add it to the macro":                             "Esegui l'azione evidenziata e aggiungila alla macro",
+	"Drop the last recorded step":                                                    "Scarta l'ultimo passo registrato",
+	"Save the macro":                                                                 "Salva la macro",
+	"Run the steps; the replay stops at the first failure":                           "Esegui i passi; la riproduzione si ferma al primo errore",
+	"Delete the selected macro":                                                      "Elimina la macro selezionata",
+	"Disable nova-compute with a reason":                                             "Disattiva nova-compute indicando un motivo",
+	"Live-migrate the servers away one by one / pause":                               "Migra a caldo i server uno alla volta / pausa",
+	"Re-enable nova-compute":                                                         "Riattiva nova-compute",
+	"Create / delete a tap flow":                                                     "Crea / elimina un flusso tap",
+	"%s is a guarded cloud. Type its name to confirm:":                               "%s è un cloud protetto. Digita il suo nome per confermare:",
+	"does not match":                "non corrisponde",
+	"[enter] confirm  [esc] cancel": "[enter] conferma  [esc] annulla",
+	"Jump:":                         "Salta:",
+	"[tab] next match  [enter] done  [esc] back":               "[tab] corrispondenza successiva  [enter] fatto  [esc] indietro",
+	"Jump to a row by name (tab: next match)":                  "Salta a una riga per nome (tab: corrispondenza successiva)",
+	"Actions of the selected row or resource (type to filter)": "Azioni della riga o risorsa selezionata (digita per filtrare)",
+	"Edit mutable fields as YAML":                              "Modifica i campi modificabili come YAML",
+	"Export as Terraform / CLI commands":                       "Esporta come Terraform / comandi CLI",
+	"Terraform / CLI commands":                                 "Terraform / comandi CLI",
+	"Write to the current directory":                           "Scrivi nella directory corrente",
+	"Top / bottom":                                             "Inizio / fine",
+	"Pause / resume streaming":                                 "Metti in pausa / riprendi lo streaming",
+	"Increase / decrease interval":                             "Aumenta / diminuisci l'intervallo",
+	"Back":                                                     "Indietro",
+	"Move":                                                     "Sposta",
+	"Expand / collapse the network":                            "Espandi / comprimi la rete",
+	"Expand / collapse all":                                    "Espandi / comprimi tutto",
+	"Cycle the network / status / project / zone filter":       "Scorri il filtro per rete / stato / progetto / zona",
+	"Match server, network and volume names":                   "Cerca nei nomi di server, reti e volumi",
+	"Collapse networks without servers":                        "Comprimi le reti senza server",
+	"Order servers by status or name":                          "Ordina i server per stato o nome",
+	"Clear filters":                                            "Azzera i filtri",
+	"Auto-refresh, marking what appeared, disappeared or changed status": "Aggiornamento automatico, evidenziando ciò che è comparso, sparito o ha cambiato stato",
+	"Relationship graph":                     "Grafo delle relazioni",
+	"Autocomplete (cycle)":                   "Completamento automatico (a rotazione)",
+	"Execute command":                        "Esegui il comando",
+	"Cancel":                                 "Annulla",
+	"DNS Zones":                              "Zone DNS",
+	"Topology diff with <cloud>[/<project>]": "Differenze di topologia con <cloud>[/<progetto>]",
+	"Find the owner of an IP address":        "Trova il proprietario di un indirizzo IP",
+	"Create servers, volumes and floating IPs from a CSV or YAML file": "Crea server, volumi e IP floating da un file CSV o YAML",
+	"Replay or record a macro on the selected server or volume":        "Riproduci o registra una macro sul server o volume selezionato",
+	"Repeat an action, e.g. every 5m refresh servers":                  "Ripeti un'azione, ad es. every 5m refresh servers",
+	"Run once, e.g. at 22:00 stop server web-test":                     "Esegui una volta, ad es. at 22:00 stop server web-test",
+	"Scheduled jobs":                           "Attività pianificate",
+	"Server stop/start schedules":              "Pianificazioni di arresto/avvio dei server",
+	"Live notification feed (--events-listen)": "Flusso di notifiche in tempo reale (--events-listen)",
+	"Everything unhealthy in the project":      "Tutto ciò che non funziona nel progetto",
+	"Open section":                             "Apri la sezione",
+	// Views: footers, key hints, loading, empty and error messages.
+	"[tab/↑↓] move  [enter] next/save  [ctrl+s] save  [esc] cancel": "[tab/↑↓] sposta  [enter] avanti/salva  [ctrl+s] salva  [esc] annulla",
+	"Loading %s":                "Caricamento di %s",
+	"[n] new cloud  [r] reload": "[n] nuovo cloud  [r] ricarica",
+	"No clouds defined yet. Press n to add one.":                   "Nessun cloud definito. Premi n per aggiungerne uno.",
+	"[t] test connection  [a] test all  [n] new cloud  [r] reload": "[t] prova la connessione  [a] prova tutti  [n] nuovo cloud  [r] ricarica",
+	"[enter] open %s": "[enter] apri %s",
+	"Showing %s  [a] toggle all  [enter] server detail  [v] volume detail":                  "Mostra %s  [a] tutti/nessuno  [enter] dettaglio server  [v] dettaglio volume",
+	"Disable nova-compute on %s – the scheduler stops placing servers there":                "Disabilita nova-compute su %s – lo scheduler smette di collocarvi server",
+	"[enter] disable  [esc] cancel":                                                         "[enter] disabilita  [esc] annulla",
+	"[d] disable service  [m] migrate servers away  [e] re-enable  [r] refresh  [esc] back": "[d] disabilita il servizio  [m] migra via i server  [e] riabilita  [r] aggiorna  [esc] indietro",
+	"[p] pause after the current migration  [esc] back (migrations in flight continue)":     "[p] pausa dopo la migrazione in corso  [esc] indietro (le migrazioni avviate proseguono)",
+	"%s\n[esc] back": "%s\n[esc] indietro",
+	"No host over %.0f%% of its effective capacity":                                    "Nessun host oltre il %.0f%% della capacità effettiva",
+	"%s\n %3.f%% | [j/k] scroll  [esc] close":                                          "%s\n %3.f%% | [j/k] scorri  [esc] chiudi",
+	"%s\n[y] json  [i] inspect  [esc] back":                                            "%s\n[y] json  [i] ispeziona  [esc] indietro",
+	"%s\nFilter: %s  [/] edit\n%s":                                                     "%s\nFiltro: %s  [/] modifica\n%s",
+	"%d hosts  |  ratios: %s  |  sort: %s  |  [s] toggle sort  [D] drain host":         "%d host  |  rapporti: %s  |  ordine: %s  |  [s] cambia ordine  [D] svuota host",
+	"Target host: %s\nOn shared storage: %s  [tab] toggle  [enter] next  [esc] cancel": "Host di destinazione: %s\nSu storage condiviso: %s  [tab] alterna  [enter] avanti  [esc] annulla",
+	"Type the server name to confirm: %s\n[enter] submit  [esc] cancel":                "Digita il nome del server per confermare: %s\n[enter] invia  [esc] annulla",
+	"[enter] preview  [esc] cancel":                                                    "[enter] anteprima  [esc] annulla",
+	"No edit planned.\n[e] edit  [esc] back":                                           "Nessuna modifica prevista.\n[e] modifica  [esc] indietro",
+	"Apply to %d servers?":                                                             "Applicare a %d server?",
+	"[y] apply":                                                                        "[y] applica",
+	"[e] edit  [esc] back":                                                             "[e] modifica  [esc] indietro",
+	"%s\n %3.f%% | [j/k] scroll  [a] close":                                            "%s\n %3.f%% | [j/k] scorri  [a] chiudi",
+	"%s\n%s[r] refresh  [j/k] scroll  [d] close":                                       "%s\n%s[r] aggiorna  [j/k] scorri  [d] chiudi",
+	"Error fetching console URL: %s\nPress any key to return":                          "Errore nel recupero dell'URL della console: %s\nPremi un tasto per tornare",
+	"%s\n[l] logs  [y] json  [i] inspect  [v] console  [S] serial  [d] diagnostics  [g] graph  %s  %s  [esc] back": "%s\n[l] log  [y] json  [i] ispeziona  [v] console  [S] seriale  [d] diagnostica  [g] grafo  %s  %s  [esc] indietro",
+	"[F] resize":                                       "[F] ridimensiona",
+	"[L] lifecycle":                                    "[L] ciclo di vita",
+	"[W] decrypt admin password":                       "[W] decifra la password di amministratore",
+	"[C] change admin password":                        "[C] cambia la password di amministratore",
+	"[I] create image":                                 "[I] crea immagine",
+	"[N] DNS records":                                  "[N] record DNS",
+	"[R] rebuild":                                      "[R] ricostruisci",
+	"[X] evacuate":                                     "[X] evacua",
+	"[P] rebuild preserving ephemeral":                 "[P] ricostruisci mantenendo l'effimero",
+	"[a] last action":                                  "[a] ultima azione",
+	"[H] hard reboot":                                  "[H] riavvio forzato",
+	"[D] delete":                                       "[D] elimina",
+	"Image %s: %s  [o] open image":                     "Immagine %s: %s  [o] apri l'immagine",
+	"Image %s is active  [o] open image":               "L'immagine %s è attiva  [o] apri l'immagine",
+	"Image %s requested…  [o] open image":              "Immagine %s richiesta…  [o] apri l'immagine",
+	"Image %s: %s %d%% …  [o] open image":              "Immagine %s: %s %d%% …  [o] apri l'immagine",
+	"Failed to load the server state: %s\n[esc] close": "Impossibile caricare lo stato del server: %s\n[esc] chiudi",
+	"Lifecycle of %s (%s)\n%s  [esc] close":            "Ciclo di vita di %s (%s)\n%s  [esc] chiudi",
+	"[v] show/hide  any other key forgets it":          "[v] mostra/nascondi  qualsiasi altro tasto la dimentica",
+	"[ctrl+s] review  [esc] cancel":                    "[ctrl+s] rivedi  [esc] annulla",
+	"Rebuild server %s from image %s, keeping its user data? [y/N]  [e] edit user data":                        "Ricostruire il server %s dall'immagine %s mantenendo i suoi user data? [y/N]  [e] modifica gli user data",
+	"Rebuild server %s from image %s with the new user data? [y/N]  [e] edit":                                  "Ricostruire il server %s dall'immagine %s con i nuovi user data? [y/N]  [e] modifica",
+	"No instance actions recorded for this server.":                                                            "Nessuna azione registrata per questo server.",
+	"⚠ RESCUE  %s runs the rescue system; its own disk is attached as a second disk  [U] unrescue":             "⚠ RESCUE  %s esegue il sistema di ripristino; il suo disco è collegato come secondo disco  [U] esci dal ripristino",
+	"⚠ VERIFY_RESIZE  %s runs on flavor %s until the resize is confirmed or reverted  [A] confirm  [V] revert": "⚠ VERIFY_RESIZE  %s usa il flavor %s finché il ridimensionamento non è confermato o annullato  [A] conferma  [V] annulla",
+	"⚠ %d %s for a resize to be confirmed: %s  [A] confirm  [V] revert the selected one":                       "⚠ %d %s con un ridimensionamento da confermare: %s  [A] conferma  [V] annulla quello selezionato",
+	"Grouped by %s  [G] next grouping  [enter] collapse/expand":                                                "Raggruppati per %s  [G] raggruppamento successivo  [enter] comprimi/espandi",
+	"[enter] edit  [esc] cancel":                                   "[enter] modifica  [esc] annulla",
+	"Error loading limits: %s":                                     "Errore nel caricamento dei limiti: %s",
+	"No quota data available.":                                     "Nessun dato di quota disponibile.",
+	"[u] usage by user  [r] refresh  [esc] back":                   "[u] utilizzo per utente  [r] aggiorna  [esc] indietro",
+	"[e] edit quotas (admin)":                                      "[e] modifica le quote (admin)",
+	" %3.f%% | [j/k] scroll [g/G] top/bottom [p] pause [esc] back": " %3.f%% | [j/k] scorri [g/G] inizio/fine [p] pausa [esc] indietro",
+	"-1 = unlimited   [tab/↑↓] move  [enter] apply  [esc] cancel":  "-1 = illimitato   [tab/↑↓] sposta  [enter] applica  [esc] annulla",
+	"%s\n\nFailed to open the serial console: %v\nThe cloud needs the serial console enabled in nova ([serial_console] enabled = true).\n\n[esc] back": "%s\n\nImpossibile aprire la console seriale: %v\nIl cloud deve avere la console seriale abilitata in nova ([serial_console] enabled = true).\n\n[esc] indietro",
+	"Disconnected. [esc] back":     "Disconnesso. [esc] indietro",
+	"Disconnected: %v. [esc] back": "Disconnesso: %v. [esc] indietro",
+	"[g] close  [j/k] scroll":      "[g] chiudi  [j/k] scorri",
+	"[enter] details":              "[enter] dettagli",
+	"[s] scale":                    "[s] scala",
+	"[K] kubeconfig  [r] refresh":  "[K] kubeconfig  [r] aggiorna",
+	"[esc] back":                   "[esc] indietro",
+	"Search: %s\n%s\n[enter] search on the server  [esc] cancel":        "Cerca: %s\n%s\n[enter] cerca sul server  [esc] annulla",
+	"Error: %s\n[/] search  [esc] back":                                 "Errore: %s\n[/] cerca  [esc] indietro",
+	"[i] inspect  [/] search":                                           "[i] ispeziona  [/] cerca",
+	"%s\n%s\n%s  [esc] back":                                            "%s\n%s\n%s  [esc] indietro",
+	"No addresses: the server has no ports":                             "Nessun indirizzo: il server non ha porte",
+	"[tab] next field  [enter] next/submit  [esc] cancel":               "[tab] campo successivo  [enter] avanti/invia  [esc] annulla",
+	"No record set of the project's zones resolves to these addresses.": "Nessun record set delle zone del progetto risolve a questi indirizzi.",
+	"[n] create / repoint record":                                       "[n] crea / reindirizza il record",
+	"[r] refresh  [esc] back":                                           "[r] aggiorna  [esc] indietro",
+	"No pending zone transfers.":                                        "Nessun trasferimento di zona in sospeso.",
+	"It accepts them with [a] in its zone transfers view (:transfers). The zone stays yours until then.": "Il destinatario li accetta con [a] nella sua vista dei trasferimenti di zona (:transfers). Fino ad allora la zona resta tua.",
+	"[a] accept":                      "[a] accetta",
+	"[x] cancel request  [r] refresh": "[x] annulla la richiesta  [r] aggiorna",
+	"[n] offer %s":                    "[n] offri %s",
+	"[ctrl+s] validate & apply  [ctrl+e] open in $EDITOR  [esc] cancel": "[ctrl+s] valida e applica  [ctrl+e] apri in $EDITOR  [esc] annulla",
+	"No changes":                               "Nessuna modifica",
+	"No event listener is running.":            "Nessun listener di eventi in esecuzione.",
+	"[enter] keep filter  [esc] clear":         "[enter] mantieni il filtro  [esc] azzera",
+	"[p] pause":                                "[p] pausa",
+	"[p] resume":                               "[p] riprendi",
+	"[/] filter":                               "[/] filtra",
+	"[enter] payload  [x] clear":               "[enter] payload  [x] svuota",
+	"[a] auto-refresh  [r] refresh  [g] close": "[a] aggiornamento automatico  [r] aggiorna  [g] chiudi",
+	"[tab] %s  [w] write %s  [j/k] scroll  [esc] back":                  "[tab] %s  [w] scrivi %s  [j/k] scorri  [esc] indietro",
+	"Domain: %s  [D] next domain":                                       "Dominio: %s  [D] dominio successivo",
+	"[enter] create  [esc] cancel":                                      "[enter] crea  [esc] annulla",
+	"[v] show/hide secret  [n] new credential  [x] delete  [r] refresh": "[v] mostra/nascondi il segreto  [n] nuova credenziale  [x] elimina  [r] aggiorna",
+	"[v] hide token ID":                                                 "[v] nascondi l'ID del token",
+	"[v] reveal token ID":                                               "[v] mostra l'ID del token",
+	"[tab] next field  [enter] next/create  [esc] cancel":               "[tab] campo successivo  [enter] avanti/crea  [esc] annulla",
+	"[n] new trust":                                                     "[n] nuovo trust",
+	"[x] delete  [r] refresh":                                           "[x] elimina  [r] aggiorna",
+	"Visibility: %s (%d of %d)  [v] next":                               "Visibilità: %s (%d di %d)  [v] successiva",
+	"[D]ownload [esc] back":                                             "[D] scarica [esc] indietro",
+	"Download to: %s\n[enter] confirm  [esc] cancel":                    "Scarica in: %s\n[enter] conferma  [esc] annulla",
+	"[r] reload  [esc] back":                                            "[r] ricarica  [esc] indietro",
+	"Fix the file and reload.\n[r] reload  [esc] back":                  "Correggi il file e ricarica.\n[r] ricarica  [esc] indietro",
+	"Create %d resources?":                                              "Creare %d risorse?",
+	"[y] create":                                                        "[y] crea",
+	"[y] retry failed":                                                  "[y] riprova le fallite",
+	"No server schedules.":                                              "Nessuna pianificazione dei server.",
+	"Press n to stop and start a server at fixed times, e.g. a dev server outside office hours.": "Premi n per arrestare e avviare un server a orari fissi, ad es. un server di sviluppo fuori dall'orario d'ufficio.",
+	"Schedules run while ostui is open; times passed while it was closed are skipped. %s":        "Le pianificazioni girano mentre ostui è aperto; gli orari passati mentre era chiuso vengono saltati. %s",
+	"[n] new  [e] enable/disable  [x] delete":                                                    "[n] nuova  [e] abilita/disabilita  [x] elimina",
+	"[tab] operations / scheduled":                                                               "[tab] operazioni / pianificate",
+	"No operations yet.":                                                                         "Ancora nessuna operazione.",
+	"Creates, deletes and resizes are queued here and followed until the cloud finishes them.":   "Creazioni, eliminazioni e ridimensionamenti vengono accodati qui e seguiti finché il cloud non li completa.",
+	"No scheduled jobs.": "Nessuna attività pianificata.",
+	"Schedule one with :every 5m refresh servers or :at 22:00 stop server web-test": "Pianificane una con :every 5m refresh servers o :at 22:00 stop server web-test",
+	"Operations run while ostui is open.":                                           "Le operazioni girano mentre ostui è aperto.",
+	"[enter] log  [R] retry":                                                        "[enter] log  [R] riprova",
+	"Jobs run while ostui is open.":                                                 "Le attività girano mentre ostui è aperto.",
+	"[x] cancel":                                                                    "[x] annulla",
+	"New secret – the payload is sent to Barbican and not shown":                    "Nuovo segreto – il payload viene inviato a Barbican e non mostrato",
+	"[tab] next field  [enter] next/store  [esc] cancel":                            "[tab] campo successivo  [enter] avanti/salva  [esc] annulla",
+	"[tab] switch list":                                                             "[tab] cambia elenco",
+	"[n] new secret":                                                                "[n] nuovo segreto",
+	"[r] refresh":                                                                   "[r] aggiorna",
+	"[tab] switch list  [v] reveal payload":                                         "[tab] cambia elenco  [v] mostra il payload",
+	"[tab] switch  [i] inspect  [g] graph  [esc] back":                              "[tab] cambia  [i] ispeziona  [g] grafo  [esc] indietro",
+	"No macros yet. Select a server or volume and run :macro record <name>.\n[esc] back": "Ancora nessuna macro. Seleziona un server o un volume ed esegui :macro record <nome>.\n[esc] indietro",
+	"Replay one with :macro <name> on a selected resource.\n[x] delete  [esc] back":      "Riproducine una con :macro <nome> su una risorsa selezionata.\n[x] elimina  [esc] indietro",
+	"[enter] run and record  [esc] cancel":                                               "[enter] esegui e registra  [esc] annulla",
+	"[enter] run and record":                                                             "[enter] esegui e registra",
+	"[u] drop last step  [s] save  [esc] back":                                           "[u] scarta l'ultimo passo  [s] salva  [esc] indietro",
+	"Run %d steps on %s?":                                                                "Eseguire %d passi su %s?",
+	"[y] run":                                                                            "[y] esegui",
+	"[enter] routes, peers and dragents  [r] refresh":                                    "[enter] rotte, peer e dragent  [r] aggiorna",
+	"[tab] switch list  [r] refresh  [esc] back":                                         "[tab] cambia elenco  [r] aggiorna  [esc] indietro",
+	"[s] schedule / unschedule on agent":                                                 "[s] assegna / rimuovi dall'agent",
+	"[tab] agents / leases  [r] refresh  [esc] back":                                     "[tab] agent / lease  [r] aggiorna  [esc] indietro",
+	"No rules":                     "Nessuna regola",
+	"New firewall rule":            "Nuova regola firewall",
+	"[n] new rule":                 "[n] nuova regola",
+	"[x] delete rule  [r] refresh": "[x] elimina la regola  [r] aggiorna",
+	"[y] json  [i] inspect  [g] graph  [E] edit  [esc] back": "[y] json  [i] ispeziona  [g] grafo  [E] modifica  [esc] indietro",
+	"[a] toggle admin state":                                 "[a] cambia lo stato amministrativo",
+	"[g] graph  [d] DHCP  [esc] back":                        "[g] grafo  [d] DHCP  [esc] indietro",
+	"%s\n[g] graph  [d] DHCP  [esc] back":                    "%s\n[g] grafo  [d] DHCP  [esc] indietro",
+	"[tab] subnets / details":                                "[tab] subnet / dettagli",
+	"[enter] subnets  [/] filter":                            "[enter] subnet  [/] filtra",
+	"[n] new network":                                        "[n] nuova rete",
+	"%s\n[L] L3 agents  [esc] back":                          "%s\n[L] agent L3  [esc] indietro",
+	"Moving the router away from %s: select the target agent  [enter] move  [esc] cancel": "Spostamento del router da %s: scegli l'agent di destinazione  [enter] sposta  [esc] annulla",
+	"[s] schedule / unschedule":                  "[s] assegna / rimuovi",
+	"[m] move to another agent":                  "[m] sposta su un altro agent",
+	"[enter] details  [L] L3 agents  [/] filter": "[enter] dettagli  [L] agent L3  [/] filtra",
+	"[n] new router":                             "[n] nuovo router",
+	"[d] delete":                                 "[d] elimina",
+	"Loading ports...":                           "Caricamento delle porte...",
+	"No ports use this security group.":          "Nessuna porta usa questo security group.",
+	"%s\n\nUsed by (%d ports):\n%s\n[u] rules [r] refresh [esc] back":            "%s\n\nUsato da (%d porte):\n%s\n[u] regole [r] aggiorna [esc] indietro",
+	"[n]ew [d]elete [I]mport [E]xport [u]sed by [y] json [i] inspect [esc] back": "[n] nuova [d] elimina [I] importa [E] esporta [u] usato da [y] json [i] ispeziona [esc] indietro",
+	"%s rules file: %s\n[enter] confirm  [esc] cancel":                           "File delle regole da %s: %s\n[enter] conferma  [esc] annulla",
+	"%s\n[E] edit nameservers and routes  [esc] back":                            "%s\n[E] modifica nameserver e rotte  [esc] indietro",
+	"[enter] pool detail  [tab] switch list  [r] refresh":                        "[enter] dettaglio del pool  [tab] cambia elenco  [r] aggiorna",
+	"[tab] switch list  [r] refresh":                                             "[tab] cambia elenco  [r] aggiorna",
+	"[enter] detail":                                                             "[enter] dettaglio",
+	"[n] new subnet":                                                             "[n] nuova subnet",
+	"[enter] tap flows":                                                          "[enter] tap flow",
+	"[n] new tap service":                                                        "[n] nuovo tap service",
+	"[n] new tap flow":                                                           "[n] nuovo tap flow",
+	"[x] delete  [r] refresh  [esc] back":                                        "[x] elimina  [r] aggiorna  [esc] indietro",
+	"No site connections":                                                        "Nessuna connessione tra siti",
+	"[enter] connection detail":                                                  "[enter] dettaglio della connessione",
+	"No detail view for %s on a host without a hypervisor":                       "Nessuna vista di dettaglio per %s su un host senza hypervisor",
+	"[enter] open  [r] recheck":                                                  "[enter] apri  [r] ricontrolla",
+	"[n] new  [esc] back":                                                        "[n] nuovo  [esc] indietro",
+	"[n] create another  [esc] back":                                             "[n] creane un altro  [esc] indietro",
+	"No results for '%s'":                                                        "Nessun risultato per '%s'",
+	" %3.f%% | [j/k] scroll  [esc] close":                                        " %3.f%% | [j/k] scorri  [esc] chiudi",
+	"Sensitive value locked.":                                                    "Valore sensibile bloccato.",
+	"[enter] unlock  [esc] cancel":                                               "[enter] sblocca  [esc] annulla",
+	"Startup failed.":                                                            "Avvio non riuscito.",
+	"[q] quit":                                                                   "[q] esci",
+	"Some services are unavailable.  [enter] continue  [q] quit":                 "Alcuni servizi non sono disponibili.  [enter] continua  [q] esci",
+	"New share":                "Nuova share",
+	"[enter] exports & access": "[enter] export e accessi",
+	"[n] new share":            "[n] nuova share",
+	"[tab] next field  [enter] next/grant  [esc] cancel": "[tab] campo successivo  [enter] avanti/concedi  [esc] annulla",
+	"[a] grant access":                                            "[a] concedi l'accesso",
+	"[x] revoke  [r] refresh  [esc] back":                         "[x] revoca  [r] aggiorna  [esc] indietro",
+	"Snapshot %s":                                                 "Snapshot di %s",
+	"[y] json  [i] inspect  [g] graph  [esc] back":                "[y] json  [i] ispeziona  [g] grafo  [esc] indietro",
+	"[enter] open server":                                         "[enter] apri il server",
+	"No differences: both contexts have the same resource names.": "Nessuna differenza: i due contesti hanno gli stessi nomi di risorse.",
+	"Error: %s\n[esc] close":                                      "Errore: %s\n[esc] chiudi",
+	"Loading topology...":                                         "Caricamento della topologia...",
+	" %3.f%% | [j/k] move  [enter] expand  [+/-] all  [n]etwork [s]tatus [p]roject [z]one  [/] match  [e] collapse empty  [o] order  [x] clear  [a] auto-refresh  [r] refresh  [esc] close": " %3.f%% | [j/k] sposta  [enter] espandi  [+/-] tutti  [n] rete [s] stato [p] progetto [z] zona  [/] cerca  [e] comprimi vuoti  [o] ordine  [x] azzera  [a] aggiornamento automatico  [r] aggiorna  [esc] chiudi",
+	"only the first %d ports are shown": "sono mostrate solo le prime %d porte",
+	"⚠ Partial topology: %s":            "⚠ Topologia parziale: %s",
+	"[enter] keep  [esc] cancel":        "[enter] mantieni  [esc] annulla",
+
+	// Shared components.
+	"You selected: %s": "Hai scelto: %s",
+	"Yes":              "Sì",
+	"No":               "No",
+	"Loading…":         "Caricamento…",
+	"Error: %s":        "Errore: %s",
+	"[esc] cancel":     "[esc] annulla",
+	"no matches":       "nessun risultato",
+	"Page %d/%d · %d of %d  [↑/↓] move  [←/→] page  [enter] select  [esc] cancel": "Pagina %d/%d · %d di %d  [↑/↓] sposta  [←/→] pagina  [enter] scegli  [esc] annulla",
+	"[Submitted]": "[Inviato]",
+	// Action palette
+	"Actions":                "Azioni",
+	"type to filter actions": "digita per filtrare le azioni",
+	"[↑/↓] move  [enter] run  [esc] cancel": "[↑/↓] sposta  [enter] esegui  [esc] annulla",
+
+	// Views: column titles, detail rows, form labels and status messages.
+	"  %d / %d MB used (%d%%)":                         "  %d / %d MB usati (%d%%)",
+	"  %d MB, usage not reported":                      "  %d MB, uso non riportato",
+	"  %d/%d servers  ":                                "  %d/%d server  ",
+	"  %s, on %d agents":                               "  %s, su %d agent",
+	"  (taken %s":                                      "  (rilevato alle %s",
+	"  AS %d, IPv%d, %d networks":                      "  AS %d, IPv%d, %d reti",
+	"  DELETE volume %s at %s (delete_on_termination)": "  ELIMINA il volume %s su %s (delete_on_termination)",
+	"  VPN service %s":                                 "  servizio VPN %s",
+	"  detach volume %s at %s; delete_on_termination is not reported by this cloud": "  scollega il volume %s su %s; questo cloud non riporta delete_on_termination",
+	"  detach volume %s at %s; it is left available":                                "  scollega il volume %s su %s; resta disponibile",
+	"  disassociate floating IP %s; it stays allocated to the project":              "  dissocia l'IP floating %s; resta allocato al progetto",
+	"  fits %d of %d flavors (min_ram %d MB, min_disk %d GB)":                       "  adatta a %d flavor su %d (min_ram %d MB, min_disk %d GB)",
+	"  flavors unavailable: %s":                                                     "  flavor non disponibili: %s",
+	"  floating IP %s → %s":                                                         "  IP floating %s → %s",
+	"  release its external gateway %s":                                             "  rilascia il gateway esterno %s",
+	"  remove port %s(%s)":                                                          "  rimuovi la porta %s(%s)",
+	"  remove the interface %s on subnet %s":                                        "  rimuovi l'interfaccia %s sulla subnet %s",
+	"  vCPU%-3d %10.1fs":                                                            "  vCPU%-3d %10.1fs",
+	"  … and %d more":                                                               "  … e altri %d",
+	"  ⟳ every %s":                                                                  "  ⟳ ogni %s",
+	" +%d unmonitored":                                                              " +%d non monitorati",
+	" Comparing %s with %s...":                                                      " Confronto di %s con %s...",
+	" MULTI(%d)":                                                                    " MULTI(%d)",
+	" matching %q":                                                                  " corrispondenti a %q",
+	"%-8s %-7s %-20.20s %d/%s, %d left":                                             "%-8s %-7s %-20.20s %d/%s, %d rimasti",
+	"%.1f/%.1f GiB (%.0f%%)  %.1f physical × %s":                                    "%.1f/%.1f GiB (%.0f%%)  %.1f fisici × %s",
+	"%d %s over %.0f%%: %s":                                                         "%d %s oltre il %.0f%%: %s",
+	"%d GB":                                                                         "%d GB",
+	"%d addresses per project":                                                      "%d indirizzi per progetto",
+	"%d agents report active: traffic may take asymmetric paths":                    "%d agent risultano attivi: il traffico può seguire percorsi asimmetrici",
+	"%d changed":                                                                    "%d modificati",
+	"%d created, %d failed":                                                         "%d creati, %d falliti",
+	"%d fail":                                                                       "%d bloccanti",
+	"%d gone":                                                                       "%d spariti",
+	"%d members, no monitor":                                                        "%d membri, nessun monitor",
+	"%d migrated, %d failed, %d skipped":                                            "%d migrati, %d falliti, %d saltati",
+	"%d networks without servers: %s":                                               "%d reti senza server: %s",
+	"%d of %d  esc: clear":                                                          "%d di %d  esc: cancella",
+	"%d of %d attachments cross availability zones":                                 "%d collegamenti su %d attraversano zone di disponibilità",
+	"%d servers would be named %q; add {n} to number them":                          "%d server si chiamerebbero %q; aggiungi {n} per numerarli",
+	"%d servers":                                                                    "%d server",
+	"%d updated, %d failed, %d unchanged":                                           "%d aggiornati, %d falliti, %d invariati",
+	"%d uses left":                                                                  "%d usi rimasti",
+	"%d warn":                                                                       "%d avvisi",
+	"%d/%.0f (%.0f%%)  %d physical × %s":                                            "%d/%.0f (%.0f%%)  %d fisici × %s",
+	"%d/%d connections ACTIVE":                                                      "%d/%d connessioni ACTIVE",
+	"%d/%d members up":                                                              "%d/%d membri attivi",
+	"%s  %s  network %s":                                                            "%s  %s  rete %s",
+	"%s %d/%d handled · %s":                                                         "%s %d/%d gestiti · %s",
+	"%s (not available)":                                                            "%s (non disponibile)",
+	"%s Downloading %s":                                                             "%s Download %s",
+	"%s done":                                                                       "%s completato",
+	"%s failed":                                                                     "%s fallito",
+	"%s failed, not recorded: %s":                                                   "%s fallito, non registrato: %s",
+	"%s failed: %s":                                                                 "%s fallito: %s",
+	"%s macro %s on %s":                                                             "%s macro %s su %s",
+	"%s not checked: %s":                                                            "%s non controllati: %s",
+	"%s on %s":                                                                      "%s su %s",
+	"%s queued; follow it with :jobs":                                               "%s in coda; seguilo con :jobs",
+	"%s requested":                                                                  "%s richiesto",
+	"%s server %s? [y/N]":                                                           "%s il server %s? [y/N]",
+	"%s via %s":                                                                     "%s via %s",
+	"%s: %d in use + %d to create exceeds the quota of %d":                          "%s: %d in uso + %d da creare superano la quota di %d",
+	"%s\nPress 'y' or 'esc' to close":                                               "%s\nPremi 'y' o 'esc' per chiudere",
+	"%s\n\n%s Connecting...":                                                        "%s\n\n%s Connessione...",
+	"%s\n\nRules:\n%s\n%s":                                                          "%s\n\nRegole:\n%s\n%s",
+	"(not associated with a port)":                                                  "(non associato a una porta)",
+	"(port %s not found)":                                                           "(porta %s non trovata)",
+	"* some servers have a flavor that is no longer listed":                         "* alcuni server hanno un flavor che non è più elencato",
+	"+%d more":                                                                      "+%d altri",
+	", %d left":                                                                     ", %d rimasti",
+	", %d services":                                                                 ", %d servizi",
+	", rates over %s":                                                               ", tassi su %s",
+	"- only in %s   + only in %s   ~ count differs":                                 "- solo in %s   + solo in %s   ~ conteggio diverso",
+	"=== Floating IP: %s ===\nID: %s\nFloatingNetworkID: %s\nFixedIP: %s\nPortID: %s\nStatus: %s\nDescription: %s\nDNSName: %s\nDNSDomain: %s":                                                                                                                 "=== IP floating: %s ===\nID: %s\nRete floating: %s\nIP fisso: %s\nID porta: %s\nStato: %s\nDescrizione: %s\nNome DNS: %s\nDominio DNS: %s",
+	"=== Hypervisor: %s ===\nID: %s\nHostname: %s\nState: %s\nStatus: %s\nVCPUs: %d\nVCPUs Used: %d\nRAM MB: %d\nRAM Used: %d\nDisk GB: %d\nDisk Used: %d\nFree RAM MB: %d\nFree Disk GB: %d\nHost IP: %s\nCurrent Workload: %d\nRunning VMs: %d\nFetched: %s": "=== Hypervisor: %s ===\nID: %s\nHostname: %s\nStato: %s\nAbilitazione: %s\nvCPU: %d\nvCPU usate: %d\nRAM MB: %d\nRAM usata: %d\nDisco GB: %d\nDisco usato: %d\nRAM libera MB: %d\nDisco libero GB: %d\nIP host: %s\nCarico attuale: %d\nVM in esecuzione: %d\nRilevato: %s",
+	"=== Instance: %s ===\nID: %s\nName: %s\nStatus: %s\nFlavor: %s\nImage: %s\nCreated: %s\nUpdated: %s\nHostID: %s\nKeyName: %s\nUserID: %s\nTenantID: %s":                                                                                                   "=== Istanza: %s ===\nID: %s\nNome: %s\nStato: %s\nFlavor: %s\nImmagine: %s\nCreato: %s\nAggiornato: %s\nID host: %s\nKeypair: %s\nID utente: %s\nID tenant: %s",
+	"=== Last action: %s ===": "=== Ultima azione: %s ===",
+	"=== Listener: %s ===\nID: %s\nName: %s\nProtocol: %s\nPort: %d\nStatus: %s":                  "=== Listener: %s ===\nID: %s\nNome: %s\nProtocollo: %s\nPorta: %d\nStato: %s",
+	"=== Pool: %s ===\nID: %s\nName: %s\nProtocol: %s\nAlgorithm: %s\nStatus: %s":                 "=== Pool: %s ===\nID: %s\nNome: %s\nProtocollo: %s\nAlgoritmo: %s\nStato: %s",
+	"=== Port: %s ===\nID: %s\nName: %s\nNetworkID: %s\nStatus: %v\nMACAddress: %s\nDeviceID: %s": "=== Porta: %s ===\nID: %s\nNome: %s\nID rete: %s\nStato: %v\nIndirizzo MAC: %s\nID dispositivo: %s",
+	"=== Project: %s ===\nID: %s\nName: %s\nDomainID: %s\nEnabled: %v":                            "=== Progetto: %s ===\nID: %s\nNome: %s\nID dominio: %s\nAbilitato: %v",
+	"=== RecordSet: %s ===\nID: %s\nName: %s\nType: %s\nTTL: %d\nStatus: %s\nRecords: %s":         "=== Record set: %s ===\nID: %s\nNome: %s\nTipo: %s\nTTL: %d\nStato: %s\nRecord: %s",
+	"=== Security Group: %s ===\nID: %s\nName: %s\nDescription: %s\nStateful: %v\nRules: %d":      "=== Gruppo di sicurezza: %s ===\nID: %s\nNome: %s\nDescrizione: %s\nStateful: %v\nRegole: %d",
+	"=== Snapshot: %s ===\nID: %s\nName: %s\nVolumeID: %s\nSize: %d\nStatus: %s\nCreatedAt: %s":   "=== Snapshot: %s ===\nID: %s\nNome: %s\nID volume: %s\nDimensione: %d\nStato: %s\nCreato il: %s",
+	"=== User: %s ===\nID: %s\nName: %s\nEmail: %s\nDomainID: %s\nEnabled: %v":                    "=== Utente: %s ===\nID: %s\nNome: %s\nEmail: %s\nID dominio: %s\nAbilitato: %v",
+	"=== Volume: %s ===\nID: %s\nName: %s\nSize: %d\nStatus: %s\nDescription: %s":                 "=== Volume: %s ===\nID: %s\nNome: %s\nDimensione: %d\nStato: %s\nDescrizione: %s",
+	"A record needs a zone of the project and an address of the server":                           "Un record richiede una zona del progetto e un indirizzo del server",
+	"AZ hints (comma-separated, empty for any zone)":                                              "Suggerimenti AZ (separati da virgola, vuoto per qualsiasi zona)",
+	"AZ hints: %s  Scheduled in: %s":                                                              "Suggerimenti AZ: %s  Pianificata in: %s",
+	"Accept a zone transfer – enter the ID and key given by the zone's owner":                     "Accetta un trasferimento di zona – inserisci l'ID e la chiave forniti dal proprietario della zona",
+	"Accepted the transfer of %s (%s)":                                                            "Trasferimento di %s accettato (%s)",
+	"Access rules":                                                                                "Regole di accesso",
+	"Access to (IP/CIDR, cephx user, ...)":                                                        "Accesso a (IP/CIDR, utente cephx, ...)",
+	"Access to":                                                                                   "Accesso a",
+	"Access type (%s)":                                                                            "Tipo di accesso (%s)",
+	"Access":                                                                                      "Accesso",
+	"Action (allow, deny, reject)":                                                                "Azione (allow, deny, reject)",
+	"Action":                                                                                      "Azione",
+	"Added %s; testing the connection":                                                            "Aggiunto %s; verifica della connessione",
+	"Address scope":                                                                               "Address scope",
+	"Address scopes":                                                                              "Address scope",
+	"Address":                                                                                     "Indirizzo",
+	"Admin password changed":                                                                      "Password di amministratore cambiata",
+	"Admin password of %s: %s":                                                                    "Password di amministratore di %s: %s",
+	"Admin state":                                                                                 "Stato amministrativo",
+	"Advertised routes":                                                                           "Rotte annunciate",
+	"Advertises":                                                                                  "Annuncia",
+	"Age":                                                                                         "Età",
+	"Algorithm (optional)":                                                                        "Algoritmo (facoltativo)",
+	"Algorithm":                                                                                   "Algoritmo",
+	"Alive":                                                                                       "Attivo",
+	"Allocate a floating IP":                                                                      "Alloca un IP floating",
+	"Allocated %s from %s":                                                                        "Allocato %s da %s",
+	"Append to policy (name or ID)":                                                               "Aggiungi alla policy (nome o ID)",
+	"Applying…":                                                                                   "Applicazione…",
+	"Attached":                                                                                    "Collegato",
+	"Attaching volume %s to server %s":                                                            "Collegamento del volume %s al server %s",
+	"Attachments (%d) – MULTI-ATTACH: detaching or migrating affects every server below": "Collegamenti (%d) – MULTI-ATTACH: scollegare o migrare coinvolge tutti i server sotto",
+	"Attachments (%d)":      "Collegamenti (%d)",
+	"Audited":               "Verificato",
+	"Auth URL":              "URL di autenticazione",
+	"Auth type":             "Tipo di autenticazione",
+	"Auth":                  "Autenticazione",
+	"Available":             "Disponibile",
+	"Backup GB":             "GB di backup",
+	"Bit length (optional)": "Lunghezza in bit (facoltativo)",
+	"Boot compatibility":    "Compatibilità di avvio",
+	"Boot":                  "Avvio",
+	"Bytes":                 "Byte",
+	"CHECKSUM MISMATCH (%s): expected %s, got %s; file discarded": "CHECKSUM NON CORRISPONDENTE (%s): atteso %s, ottenuto %s; file scartato",
+	"CIDR (empty to allocate from a pool)":                        "CIDR (vuoto per allocare da un pool)",
+	"Cancelled job %d":                                            "Job %d annullato",
+	"Cancelled transfer request %s":                               "Richiesta di trasferimento %s annullata",
+	"Cannot create an image of a server that is %s; it must be active, shut off, paused or suspended": "Impossibile creare un'immagine di un server in stato %s; deve essere attivo, spento, in pausa o sospeso",
+	"Cannot rebuild a server that is %s; it must be active, shut off or in error":                     "Impossibile ricostruire un server in stato %s; deve essere attivo, spento o in errore",
+	"Cert CN":                 "CN certificato",
+	"Certificate details: %s": "Dettagli del certificato: %s",
+	"Certificate details: key manager unavailable":                         "Dettagli del certificato: key manager non disponibile",
+	"Change admin password failed: %s":                                     "Cambio della password di amministratore fallito: %s",
+	"Change the admin password (needs hypervisor and guest agent support)": "Cambia la password di amministratore (richiede il supporto di hypervisor e guest agent)",
+	"Changing the admin password...":                                       "Cambio della password di amministratore...",
+	"Check":                                                                "Controllo",
+	"Checking what uses router %s…":                                        "Verifica di cosa usa il router %s…",
+	"Checking…":                                                            "Verifica…",
+	"Common name: %s":                                                      "Common name: %s",
+	"Compute":                                                              "Compute",
+	"Confirm password":                                                     "Conferma la password",
+	"Connection":                                                           "Connessione",
+	"Console URL: %s\nPress 'o' to open in browser, 'Q' for a QR code, any other key to return":             "URL della console: %s\nPremi 'o' per aprirlo nel browser, 'Q' per un codice QR, qualsiasi altro tasto per tornare",
+	"Console URL: %s\n\n%s\nPress 'Q' to hide the QR code, 'o' to open in browser, any other key to return": "URL della console: %s\n\n%s\nPremi 'Q' per nascondere il codice QR, 'o' per aprirlo nel browser, qualsiasi altro tasto per tornare",
+	"Consumers":  "Consumatori",
+	"Containers": "Container",
+	"Count":      "Numero",
+	"Create an image of server %s (createImage)\n%s":      "Crea un'immagine del server %s (createImage)\n%s",
+	"Create image failed: %s":                             "Creazione dell'immagine fallita: %s",
+	"Created %s %s → %s":                                  "Creato %s %s → %s",
+	"Created EC2 credential %s; reveal the secret with v": "Credenziale EC2 %s creata; mostra il segreto con v",
+	"Created by":                       "Creato da",
+	"Created firewall rule %s":         "Regola firewall %s creata",
+	"Created router %s":                "Router %s creato",
+	"Created server %s (%s), building": "Server %s creato (%s), in costruzione",
+	"Created share %s (%s)":            "Share %s creata (%s)",
+	"Created subnet %s (%s)":           "Subnet %s creata (%s)",
+	"Created tap flow %s":              "Tap flow %s creato",
+	"Created tap service %s":           "Tap service %s creato",
+	"Created trust %s":                 "Trust %s creato",
+	"Created volume %s (%d GB)":        "Volume %s creato (%d GB)",
+	"Created":                          "Creato",
+	"Creating %d of %d…":               "Creazione %d di %d…",
+	"Creating image %s...":             "Creazione dell'immagine %s...",
+	"Creating snapshot %s":             "Creazione dello snapshot %s",
+	"Current":                          "Attuale",
+	"DHCP agents":                      "Agent DHCP",
+	"DHCP port":                        "Porta DHCP",
+	"DNS record for server %s – an existing record set of the name and type is repointed\nZones: %s\n%s": "Record DNS per il server %s – un record set esistente con lo stesso nome e tipo viene reindirizzato\nZone: %s\n%s",
+	"DNSNameservers": "Nameserver DNS",
+	"DR agents":      "Agent DR",
+	"Days (daily, weekdays, weekends or mon,tue,…)": "Giorni (daily, weekdays, weekends o mon,tue,…)",
+	"Days":                    "Giorni",
+	"Default certificate: %s": "Certificato predefinito: %s",
+	"Delete %s %s? [y/N]":     "Eliminare %s %s? [y/N]",
+	"Delete EC2 credential %s? Clients using it stop working. [y/N]": "Eliminare la credenziale EC2 %s? I client che la usano smettono di funzionare. [y/N]",
+	"Delete failed: %s":                                             "Eliminazione fallita: %s",
+	"Delete macro %s? [y/N]":                                        "Eliminare la macro %s? [y/N]",
+	"Delete secret %s? It is still referenced by %s.":               "Eliminare il segreto %s? È ancora referenziato da %s.",
+	"Delete server %s? [y/N]":                                       "Eliminare il server %s? [y/N]",
+	"Delete trust %s? The trustee loses the delegated roles. [y/N]": "Eliminare il trust %s? Il trustee perde i ruoli delegati. [y/N]",
+	"Delete volume %s":                                              "Elimina il volume %s",
+	"Deleted EC2 credential %s":                                     "Credenziale EC2 %s eliminata",
+	"Deleted firewall rule %s":                                      "Regola firewall %s eliminata",
+	"Deleted macro %s":                                              "Macro %s eliminata",
+	"Deleted router %s":                                             "Router %s eliminato",
+	"Deleted secret %s":                                             "Segreto %s eliminato",
+	"Deleted tap flow %s":                                           "Tap flow %s eliminato",
+	"Deleted tap service %s":                                        "Tap service %s eliminato",
+	"Deleted trust %s":                                              "Trust %s eliminato",
+	"Deleting %s will:":                                             "L'eliminazione di %s:",
+	"Deleting router %s will:":                                      "L'eliminazione del router %s:",
+	"Deleting router %s…":                                           "Eliminazione del router %s…",
+	"Deleting share %s":                                             "Eliminazione della share %s",
+	"Description (optional)":                                        "Descrizione (facoltativa)",
+	"Description":                                                   "Descrizione",
+	"Destination CIDR":                                              "CIDR di destinazione",
+	"Destination port ID":                                           "ID della porta di destinazione",
+	"Destination port":                                              "Porta di destinazione",
+	"Destination":                                                   "Destinazione",
+	"Detail":                                                        "Dettaglio",
+	"Device":                                                        "Dispositivo",
+	"Direction (BOTH, IN, OUT)":                                     "Direzione (BOTH, IN, OUT)",
+	"Direction":                                                     "Direzione",
+	"Disable reason":                                                "Motivo della disabilitazione",
+	"Disable the compute service first (d) so nothing is scheduled back onto the host": "Disabilita prima il servizio compute (d) così nulla viene ripianificato sull'host",
+	"Disabled nova-compute on %s": "nova-compute disabilitato su %s",
+	"Disk (GB)":                   "Disco (GB)",
+	"Disk GB":                     "Disco GB",
+	"Disk Used":                   "Disco usato",
+	"Disk":                        "Disco",
+	"Disks":                       "Dischi",
+	"Distributed (yes/no, empty for default)": "Distribuito (yes/no, vuoto per il predefinito)",
+	"Docker volume":                         "Volume Docker",
+	"Domain":                                "Dominio",
+	"Download failed: %s":                   "Download fallito: %s",
+	"Drain":                                 "Svuotamento",
+	"Edit cancelled":                        "Modifica annullata",
+	"Edit quotas for project %s":            "Modifica le quote del progetto %s",
+	"Egress policy":                         "Policy in uscita",
+	"Enabled nova-compute on %s":            "nova-compute abilitato su %s",
+	"Enabled":                               "Abilitato",
+	"Encryption":                            "Cifratura",
+	"Error":                                 "Errore",
+	"EtherType":                             "EtherType",
+	"Event":                                 "Evento",
+	"Events:":                               "Eventi:",
+	"Expires (YYYY-MM-DD, empty for never)": "Scadenza (AAAA-MM-GG, vuoto per mai)",
+	"Expires (YYYY-MM-DD, optional)":        "Scadenza (AAAA-MM-GG, facoltativa)",
+	"Expires At":                            "Scade il",
+	"Expires":                               "Scadenza",
+	"Expires: %s":                           "Scadenza: %s",
+	"Export failed: %s":                     "Esportazione fallita: %s",
+	"Exported %d rules to %s":               "%d regole esportate in %s",
+	"External (router:external)":            "Esterna (router:external)",
+	"External IP":                           "IP esterno",
+	"External network (router gateway)":     "Rete esterna (gateway del router)",
+	"External network":                      "Rete esterna",
+	"External network\n%s":                  "Rete esterna\n%s",
+	"FIP: %s (not associated)":              "FIP: %s (non associato)",
+	"Failed to delete image %s: %s":         "Eliminazione dell'immagine %s fallita: %s",
+	"Failed to fetch diagnostics: %s":       "Lettura della diagnostica fallita: %s",
+	"Failed to get instance: %s":            "Lettura dell'istanza fallita: %s",
+	"Failed to get volume: %s":              "Lettura del volume fallita: %s",
+	"Failed to list buckets: %s":            "Elenco dei bucket fallito: %s",
+	"Failed to list floating IPs: %s":       "Elenco degli IP floating fallito: %s",
+	"Failed to list instances: %s":          "Elenco delle istanze fallito: %s",
+	"Failed to list networks: %s":           "Elenco delle reti fallito: %s",
+	"Failed to list projects: %s":           "Elenco dei progetti fallito: %s",
+	"Failed to list security groups: %s":    "Elenco dei gruppi di sicurezza fallito: %s",
+	"Failed to list snapshots: %s":          "Elenco degli snapshot fallito: %s",
+	"Failed to list subnets: %s":            "Elenco delle subnet fallito: %s",
+	"Failed to list volumes: %s":            "Elenco dei volumi fallito: %s",
+	"Failed to load instance actions: %s":   "Caricamento delle azioni dell'istanza fallito: %s",
+	"Fault":                                 "Guasto",
+	"Fetched":                               "Rilevato",
+	"Fetching diagnostics...":               "Lettura della diagnostica...",
+	"Fetching the admin password...":        "Lettura della password di amministratore...",
+	"Field":                                 "Campo",
+	"Filter: %s":                            "Filtro: %s",
+	"Fingerprint":                           "Impronta",
+	"Firewall groups":                       "Gruppi firewall",
+	"First number":                          "Primo numero",
+	"Fixed IPs":                             "IP fissi",
+	"FixedIP":                               "IP fisso",
+	"Flags":                                 "Flag",
+	"Flavor":                                "Flavor",
+	"Floating IP %s released successfully.": "IP floating %s rilasciato.",
+	"FloatingIP\n%s":                        "IP floating\n%s",
+	"FloatingIP\n%s\n%s":                    "IP floating\n%s\n%s",
+	"FloatingNetworkID":                     "Rete floating",
+	"Flows":                                 "Flussi",
+	"Force delete volume %s":                "Elimina forzatamente il volume %s",
+	"Get password failed: %s":               "Lettura della password fallita: %s",
+	"Global Search":                         "Ricerca globale",
+	"Gone since the last refresh (%d):":     "Spariti dall'ultimo aggiornamento (%d):",
+	"Gone since the last refresh:":          "Spariti dall'ultimo aggiornamento:",
+	"Granted %s access to %s (%s)":          "Concesso accesso %s a %s (%s)",
+	"Graph not available for %s":            "Grafo non disponibile per %s",
+	"Group by metadata key: %s\n%s\nenter: apply  esc: cancel": "Raggruppa per chiave di metadati: %s\n%s\nenter: applica  esc: annulla",
+	"Group: %s\nPolicy: %s\nThis server on: %s":                "Gruppo: %s\nPolicy: %s\nQuesto server su: %s",
+	"Grouping data unavailable: %s":                            "Dati di raggruppamento non disponibili: %s",
+	"HA (yes/no, empty for default)":                           "HA (yes/no, vuoto per il predefinito)",
+	"Health":                                                   "Salute",
+	"History":                                                  "Storico",
+	"Host":                                                     "Host",
+	"HostID":                                                   "ID host",
+	"HostRoutes":                                               "Rotte host",
+	"Hostname":                                                 "Hostname",
+	"Hosts speaker":                                            "Ospita lo speaker",
+	"IKE policies":                                             "Policy IKE",
+	"IP Lookup":                                                "Ricerca IP",
+	"IP version":                                               "Versione IP",
+	"IPVer":                                                    "Ver. IP",
+	"IPsec policies":                                           "Policy IPsec",
+	"IPv%d":                                                    "IPv%d",
+	"Image %s deleted successfully.":                           "Immagine %s eliminata.",
+	"Image name":                                               "Nome dell'immagine",
+	"Image":                                                    "Immagine",
+	"Imp.":                                                     "Imp.",
+	"Impersonation (yes/no)":                                   "Impersonificazione (yes/no)",
+	"Import failed after %d rules: %s":                         "Importazione fallita dopo %d regole: %s",
+	"Imported %d rules, skipped %d duplicates":                  "%d regole importate, %d duplicati saltati",
+	"In use – Neutron refuses the delete until these are gone:": "In uso – Neutron rifiuta l'eliminazione finché questi non sono rimossi:",
+	"In use":                "In uso",
+	"Indexing the project…": "Indicizzazione del progetto…",
+	"Ingress policy":        "Policy in ingresso",
+	"Instance Details":      "Dettagli dell'istanza",
+	"Instances":             "Istanze",
+	"Interface ID":          "ID interfaccia",
+	"Job %d is not pending": "Il job %d non è in attesa",
+	"Key":                   "Chiave",
+	"KeyName":               "Keypair",
+	"Kind":                  "Tipo",
+	"Kubeconfig – a new admin client certificate is signed by the cluster CA": "Kubeconfig – la CA del cluster firma un nuovo certificato client di amministratore",
+	"Labels":          "Etichette",
+	"Last result":     "Ultimo risultato",
+	"Last run":        "Ultima esecuzione",
+	"Leases":          "Lease",
+	"Level (rw, ro)":  "Livello (rw, ro)",
+	"Level":           "Livello",
+	"Lifetime":        "Durata",
+	"Line":            "Riga",
+	"Listener %s:%d":  "Listener %s:%d",
+	"Listener\n%s:%d": "Listener\n%s:%d",
+	"Listening on %s · %d received · %d shown": "In ascolto su %s · %d ricevute · %d mostrate",
+	"Live-migrating %s…":                       "Migrazione live di %s…",
+	"LoadBalancer\n%s":                         "Load balancer\n%s",
+	"Local AS":                                 "AS locale",
+	"Log of operation %d: %s":                  "Log dell'operazione %d: %s",
+	"MULTI(%d)":                                "MULTI(%d)",
+	"MULTI-ATTACH ×%d":                         "MULTI-ATTACH ×%d",
+	"Macro %s finished on %s":                  "Macro %s completata su %s",
+	"Master LB":                                "LB dei master",
+	"Masters":                                  "Master",
+	"Memory":                                   "Memoria",
+	"Message: %s":                              "Messaggio: %s",
+	"Metadata (key=value, comma-separated)":    "Metadati (chiave=valore, separati da virgola)",
+	"Metadata key":                             "Chiave dei metadati",
+	"Metadata value":                           "Valore dei metadati",
+	"Metadata":                                 "Metadati",
+	"Min disk":                                 "Disco min.",
+	"Moved the router from %s to %s":           "Router spostato da %s a %s",
+	"Name (relative to the zone, or ending with a dot)": "Nome (relativo alla zona, o terminato da un punto)",
+	"Name pattern ({name}, {n}, {n:3})":                 "Schema del nome ({name}, {n}, {n:3})",
+	"Name":                                              "Nome",
+	"Network %s is administratively %s":                 "La rete %s è amministrativamente %s",
+	"Network ID":                                        "ID rete",
+	"Network driver":                                    "Driver di rete",
+	"Network type":                                      "Tipo di rete",
+	"Network":                                           "Rete",
+	"Network: %s (%s)":                                  "Rete: %s (%s)",
+	"Network\n%s":                                       "Rete\n%s",
+	"New admin password":                                "Nuova password di amministratore",
+	"New cloud":                                         "Nuovo cloud",
+	"New limit":                                         "Nuovo limite",
+	"New name":                                          "Nuovo nome",
+	"New network – AZ hints pin its DHCP agents to zones (known: %s)":          "Nuova rete – i suggerimenti AZ vincolano i suoi agent DHCP alle zone (note: %s)",
+	"New router – %s; AZ hints pin it to L3 agents in those zones (known: %s)": "Nuovo router – %s; i suggerimenti AZ lo vincolano agli agent L3 di quelle zone (note: %s)",
+	"New server": "Nuovo server",
+	"New volume": "Nuovo volume",
+	"Next hop":   "Next hop",
+	"Next in":    "Prossima tra",
+	"Next":       "Prossima",
+	"Node count": "Numero di nodi",
+	"Nodes":      "Nodi",
+	"None reported by compute or block storage.": "Nessuno riportato da compute o block storage.",
+	"Not in any container":                       "In nessun container",
+	"Nothing left to migrate: %s":                "Nulla da migrare: %s",
+	"Nothing recorded yet":                       "Ancora nulla di registrato",
+	"Nova deletes the ports it created; ports passed in at boot are detached and kept.": "Nova elimina le porte che ha creato; le porte passate all'avvio vengono scollegate e mantenute.",
+	"Offer zone %s to another project":                                                  "Offri la zona %s a un altro progetto",
+	"Only the project owning the zone can cancel its transfer":                          "Solo il progetto proprietario della zona può annullarne il trasferimento",
+	"Only the trustor can delete a trust":                                               "Solo il trustor può eliminare un trust",
+	"Operating":                                                                         "Operativo",
+	"Operation %d has not failed":                                                       "L'operazione %d non è fallita",
+	"Operation":                                                                         "Operazione",
+	"Owner":                                                                             "Proprietario",
+	"Pausing after the current migration…":                                              "Pausa dopo la migrazione in corso…",
+	"Payload (\\n for newlines)":                                                        "Contenuto (\\n per andare a capo)",
+	"Peer CIDRs":                                                                        "CIDR del peer",
+	"Peer IP":                                                                           "IP del peer",
+	"Peer":                                                                              "Peer",
+	"Peers":                                                                             "Peer",
+	"Physical network":                                                                  "Rete fisica",
+	"Pick an agent that does not host the router":                                       "Scegli un agent che non ospita il router",
+	"Pointing %s at %s…":                                                                "Puntamento di %s a %s…",
+	"Policies":                                                                          "Policy",
+	"Pool\n%s":                                                                          "Pool\n%s",
+	"Port %s details (press esc to go back)":                                            "Dettagli della porta %s (esc per tornare indietro)",
+	"Port":                                                                              "Porta",
+	"Port: %s":                                                                          "Porta: %s",
+	"PortID":                                                                            "ID porta",
+	"PortRange":                                                                         "Intervallo porte",
+	"Port\n%s":                                                                          "Porta\n%s",
+	"Port\n%s\n%s":                                                                      "Porta\n%s\n%s",
+	"Port\nIP: %s":                                                                      "Porta\nIP: %s",
+	"Prefix len":                                                                        "Lungh. prefisso",
+	"Prefix length (empty for the pool default)": "Lunghezza del prefisso (vuoto per il predefinito del pool)",
+	"Prefixes": "Prefissi",
+	"Press t on a zone in the zone list to offer it": "Premi t su una zona nell'elenco delle zone per offrirla",
+	"Priority":                        "Priorità",
+	"Private key file":                "File della chiave privata",
+	"Project ID":                      "ID progetto",
+	"Project":                         "Progetto",
+	"Prot.":                           "Prot.",
+	"Protocol (%s)":                   "Protocollo (%s)",
+	"Protocol (tcp, udp, icmp, any)":  "Protocollo (tcp, udp, icmp, any)",
+	"Protocol":                        "Protocollo",
+	"Provider":                        "Provider",
+	"Provisioning":                    "Provisioning",
+	"Publisher":                       "Mittente",
+	"Queued %s; follow it with :jobs": "%s in coda; seguilo con :jobs",
+	"Queued new network %s; follow it with :jobs": "Nuova rete %s in coda; seguila con :jobs",
+	"Quotas updated for project %s":               "Quote aggiornate per il progetto %s",
+	"RAM (MB)":                                    "RAM (MB)",
+	"RAM Free":                                    "RAM libera",
+	"RAM MB":                                      "RAM MB",
+	"RAM Used":                                    "RAM usata",
+	"RX pkts":                                     "Pacch. RX",
+	"Rate limits":                                 "Limiti di frequenza",
+	"Re-enable nova-compute on %s? %d servers were not migrated.": "Riabilitare nova-compute su %s? %d server non sono stati migrati.",
+	"Read":                                "Letti",
+	"Reading the user data failed: %s":    "Lettura degli user data fallita: %s",
+	"Reading the user data of server %s…": "Lettura degli user data del server %s…",
+	"Reads":                               "Letture",
+	"Rebuild server %s from image %s. User data to boot it with:": "Ricostruisci il server %s dall'immagine %s. User data con cui avviarlo:",
+	"Records":                   "Record",
+	"Region":                    "Regione",
+	"Reload service status: %s": "Ricarica dello stato del servizio: %s",
+	"Remaining":                 "Rimanente",
+	"Remote AS":                 "AS remoto",
+	"RemoteGroup":               "Gruppo remoto",
+	"RemoteIP":                  "IP remoto",
+	"Remove the router from the agent on %s? [y/N]":              "Rimuovere il router dall'agent su %s? [y/N]",
+	"Remove the speaker from agent %s? [y/N]":                    "Rimuovere lo speaker dall'agent %s? [y/N]",
+	"Removed %s from the macro; the action itself is not undone": "%s rimosso dalla macro; l'azione stessa non viene annullata",
+	"Removed speaker from agent %s":                              "Speaker rimosso dall'agent %s",
+	"Removed the router from %s":                                 "Router rimosso da %s",
+	"Request ID: %s":                                             "ID richiesta: %s",
+	"Requesting the transfer of %s...":                           "Richiesta del trasferimento di %s...",
+	"Rescue server %s from image %s? [y/N]":                      "Avviare il server %s in modalità rescue dall'immagine %s? [y/N]",
+	"Resize server %s to %s":                                     "Ridimensiona il server %s a %s",
+	"Resize server %s to flavor %s? [y/N]":                       "Ridimensionare il server %s al flavor %s? [y/N]",
+	"Resizing %s to %d nodes":                                    "Ridimensionamento di %s a %d nodi",
+	"Resource ID":                                                "ID risorsa",
+	"Resource":                                                   "Risorsa",
+	"Retrying operation %d":                                      "Nuovo tentativo dell'operazione %d",
+	"Revert the resize of server %s? [y/N]":                      "Annullare il ridimensionamento del server %s? [y/N]",
+	"Revoked access rule %s":                                     "Regola di accesso %s revocata",
+	"Roles (comma-separated)":                                    "Ruoli (separati da virgola)",
+	"Roles":                                                      "Ruoli",
+	"Router %s interfaces (press esc to go back)":                "Interfacce del router %s (esc per tornare indietro)",
+	"Router":                     "Router",
+	"Router: %s":                 "Router: %s",
+	"Router\n%s\nNAT %s → %s":    "Router\n%s\nNAT %s → %s",
+	"Rules":                      "Regole",
+	"Running %s…":                "Esecuzione di %s…",
+	"Runs":                       "Esecuzioni",
+	"SANs: %s":                   "SAN: %s",
+	"SNI certificate: %s":        "Certificato SNI: %s",
+	"Save failed: %s":            "Salvataggio fallito: %s",
+	"Saved %s (%s); %s verified": "%s salvato (%s); %s verificato",
+	"Saved %s (%s); not verified: Glance reported no checksum": "%s salvato (%s); non verificato: Glance non ha riportato alcun checksum",
+	"Saved macro %s with %d steps; replay it with :macro %s":   "Macro %s salvata con %d passi; rieseguila con :macro %s",
+	"Scale cluster – changes the worker node count":            "Scala il cluster – cambia il numero di nodi worker",
+	"Schedule":                      "Pianificazione",
+	"Scheduled speaker on agent %s": "Speaker assegnato all'agent %s",
+	"Scheduled the router on %s":    "Router assegnato a %s",
+	"Security Group Details":        "Dettagli del gruppo di sicurezza",
+	"Segmentation ID":               "ID di segmentazione",
+	"Select Cloud":                  "Seleziona il cloud",
+	"Select an agent hosting the router to move it away": "Seleziona un agent che ospita il router per spostarlo altrove",
+	"Serial console: %s":  "Console seriale: %s",
+	"Server (name or ID)": "Server (nome o ID)",
+	"Server AZ":           "AZ del server",
+	"Server ID":           "ID server",
+	"Server":              "Server",
+	"Server: %s [%s]":     "Server: %s [%s]",
+	"Server: %s | Streaming: %t | Interval: %s": "Server: %s | Streaming: %t | Intervallo: %s",
+	"Server: %s":     "Server: %s",
+	"Server\n%s":     "Server\n%s",
+	"Server\n%s\n%s": "Server\n%s\n%s",
+	"Service":        "Servizio",
+	"Set the admin state of network %s %s? [y/N]": "Impostare lo stato amministrativo della rete %s a %s? [y/N]",
+	"Setting the admin state %s…":                 "Impostazione dello stato amministrativo a %s…",
+	"Share type (empty = default)":                "Tipo di share (vuoto = predefinito)",
+	"Shared":                                      "Condivisa",
+	"Site connections":                            "Connessioni di sito",
+	"Size (GB)":                                   "Dimensione (GB)",
+	"Size":                                        "Dimensione",
+	"Size, visibility and owner unavailable: %s": "Dimensione, visibilità e proprietario non disponibili: %s",
+	"Snapshot name":                    "Nome dello snapshot",
+	"Source CIDR":                      "CIDR di origine",
+	"Source port ID":                   "ID della porta di origine",
+	"Source port":                      "Porta di origine",
+	"Source":                           "Origine",
+	"Spec":                             "Specifica",
+	"Start at (HH:MM, empty for none)": "Inizio alle (HH:MM, vuoto per nessuno)",
+	"Start ostui with --events-listen 127.0.0.1:8089 and POST OpenStack notifications to it as JSON.": "Avvia ostui con --events-listen 127.0.0.1:8089 e invia le notifiche OpenStack in POST come JSON.",
+	"Start":                           "Inizio",
+	"Started":                         "Avviato",
+	"Started: %s":                     "Avviato: %s",
+	"State":                           "Stato",
+	"Stateful":                        "Stateful",
+	"Status":                          "Stato",
+	"Step":                            "Passo",
+	"Steps":                           "Passi",
+	"Stop at (HH:MM, empty for none)": "Fine alle (HH:MM, vuoto per nessuna)",
+	"Stop":                            "Fine",
+	"Stopped at step %d of %d":        "Interrotta al passo %d di %d",
+	"Stored secret %s":                "Secret %s memorizzato",
+	"Submitting %s of %s...":          "Invio di %s di %s...",
+	"Submitting %s...":                "Invio di %s...",
+	"Subnet ID":                       "ID subnet",
+	"Subnet pool (name or ID)":        "Subnet pool (nome o ID)",
+	"Subnets (%d)":                    "Subnet (%d)",
+	"TLS Container":                   "Container TLS",
+	"TTL (empty: the zone's)":         "TTL (vuoto: quello della zona)",
+	"TX pkts":                         "Pacch. TX",
+	"Target project ID (empty: any project given the key)": "ID del progetto di destinazione (vuoto: qualsiasi progetto con la chiave)",
+	"Target project": "Progetto di destinazione",
+	"Template":       "Template",
+	"TenantID":       "ID tenant",
+	"The current user data cannot be read with your roles; it is kept unless you type new user data.": "Gli user data attuali non sono leggibili con i tuoi ruoli; vengono mantenuti a meno che tu non ne scriva di nuovi.",
+	"The previous file is kept as clouds.yaml.bak; comments are not preserved.":                       "Il file precedente viene mantenuto come clouds.yaml.bak; i commenti non vengono conservati.",
+	"Time":                      "Ora",
+	"Token ID":                  "ID token",
+	"Token Info":                "Informazioni sul token",
+	"Took":                      "Durata",
+	"Topology diff: %s ↔ %s":    "Differenze di topologia: %s ↔ %s",
+	"Transfer request ID":       "ID della richiesta di trasferimento",
+	"Transform":                 "Trasformazione",
+	"Trust":                     "Trust",
+	"Trustee (user name or ID)": "Trustee (nome utente o ID)",
+	"Trustee":                   "Trustee",
+	"Trustor":                   "Trustor",
+	"Try":                       "Tentativo",
+	"Type (opaque, passphrase, symmetric, certificate, private, public)": "Tipo (opaque, passphrase, symmetric, certificate, private, public)",
+	"Type":                             "Tipo",
+	"Updated %s %s: %s → %s":           "%s %s aggiornato: %s → %s",
+	"Updated":                          "Aggiornato",
+	"Updating %d of %d…":               "Aggiornamento %d di %d…",
+	"Usage by user":                    "Utilizzo per utente",
+	"Usage":                            "Utilizzo",
+	"Used by":                          "Usato da",
+	"Used/Total":                       "Usati/Totale",
+	"User ID: %s":                      "ID utente: %s",
+	"User data changes:":               "Modifiche agli user data:",
+	"User":                             "Utente",
+	"UserID":                           "ID utente",
+	"VCPUs Used":                       "VCPU usate",
+	"VCPUs":                            "VCPU",
+	"VIP Address":                      "Indirizzo VIP",
+	"VLAN filter (e.g. 10,20-25)":      "Filtro VLAN (es. 10,20-25)",
+	"VLAN filter":                      "Filtro VLAN",
+	"VPN service":                      "Servizio VPN",
+	"VPN services":                     "Servizi VPN",
+	"Value":                            "Valore",
+	"Version":                          "Versione",
+	"Visibility":                       "Visibilità",
+	"Vol: %s %dGB (available)":         "Vol: %s %dGB (disponibile)",
+	"Volume %s deleted successfully.":  "Volume %s eliminato.",
+	"Volume AZ":                        "AZ del volume",
+	"Volume Details":                   "Dettagli del volume",
+	"Volume GB":                        "Volume GB",
+	"Volume ID":                        "ID volume",
+	"Volume driver":                    "Driver dei volumi",
+	"Volume":                           "Volume",
+	"VolumeID":                         "ID volume",
+	"Volume\n%s":                       "Volume\n%s",
+	"Watching %d %s in transition: %s": "Monitoraggio di %d %s in transizione: %s",
+	"Write kubeconfig to":              "Scrivi il kubeconfig in",
+	"Writes":                           "Scritture",
+	"Written":                          "Scritti",
+	"Wrote kubeconfig for %s to %s":    "Kubeconfig di %s scritto in %s",
+	"Zone":                             "Zona",
+	"[admin] %s server %s":             "[admin] %s server %s",
+	"[ctrl+]] close — every other key goes to the server": "[ctrl+]] chiudi — ogni altro tasto va al server",
+	"action %s, interval %ds, timeout %ds":                "azione %s, intervallo %ds, timeout %ds",
+	"admin only":                                          "solo admin",
+	"architecture %s: schedules only to %s compute hosts": "architettura %s: pianificato solo su host compute %s",
+	"attempt %d: sending the request":                     "tentativo %d: invio della richiesta",
+	"below usage":                                         "sotto l'utilizzo",
+	"by status":                                           "per stato",
+	"cephx key of %s: %s":                                 "chiave cephx di %s: %s",
+	"collapsed":                                           "compresso",
+	"compute services":                                    "servizi compute",
+	"disk %d GB < min_disk %d GB":                         "disco %d GB < min_disk %d GB",
+	"done":                                                "completato",
+	"drops":                                               "scarti",
+	"errors":                                              "errori",
+	"expired %s ago":                                      "scaduto da %s",
+	"expires in %d days":                                  "scade tra %d giorni",
+	"failed: %s":                                          "fallito: %s",
+	"fault %d: %s":                                        "errore %d: %s",
+	"fixed IP (%s)":                                       "IP fisso (%s)",
+	"fixed IP behind %s":                                  "IP fisso dietro %s",
+	"fixed IP on port %s":                                 "IP fisso sulla porta %s",
+	"floating IP %s":                                      "IP floating %s",
+	"hidden (admin only)":                                 "nascosto (solo admin)",
+	"hw_cdrom_bus ide with machine type %s: config drive and rescue fail":               "hw_cdrom_bus ide con machine type %s: config drive e rescue falliscono",
+	"hw_disk_bus %q is not a valid disk bus; the boot fails":                            "hw_disk_bus %q non è un bus disco valido; l'avvio fallisce",
+	"hw_disk_bus ide with machine type %s: q35 has no IDE controller":                   "hw_disk_bus ide con machine type %s: q35 non ha un controller IDE",
+	"hw_disk_bus ide: at most 4 disks, emulated and slow":                               "hw_disk_bus ide: al massimo 4 dischi, emulato e lento",
+	"hw_disk_bus scsi without hw_scsi_model=virtio-scsi uses an emulated controller":    "hw_disk_bus scsi senza hw_scsi_model=virtio-scsi usa un controller emulato",
+	"hw_firmware_type uefi: needs hosts with UEFI (OVMF) firmware":                      "hw_firmware_type uefi: richiede host con firmware UEFI (OVMF)",
+	"hw_mem_page_size %s: the flavor must set hw:mem_page_size to large, any or a size": "hw_mem_page_size %s: il flavor deve impostare hw:mem_page_size a large, any o una dimensione",
+	"img_config_drive mandatory: a config drive is always attached":                     "img_config_drive mandatory: viene sempre collegato un config drive",
+	"img_hv_type %s: schedules only to %s hypervisors":                                  "img_hv_type %s: pianificato solo su hypervisor %s",
+	"load balancer %s":         "load balancer %s",
+	"load balancers":           "load balancer",
+	"network %s":               "rete %s",
+	"network agents":           "agent di rete",
+	"no CPU counters reported": "nessun contatore CPU riportato",
+	"no alive DHCP agent serves this network: instances get no address": "nessun agent DHCP attivo serve questa rete: le istanze non ricevono un indirizzo",
+	"no alive agent is active: the HA router does not forward":          "nessun agent attivo è active: il router HA non inoltra il traffico",
+	"no constraining image properties":                                  "nessuna proprietà dell'immagine vincolante",
+	"no disks reported":                                                 "nessun disco riportato",
+	"no grants listed":                                                  "nessun permesso elencato",
+	"no interfaces reported":                                            "nessuna interfaccia riportata",
+	"none (any zone)":                                                   "nessuna (qualsiasi zona)",
+	"none allocated":                                                    "nessuno allocato",
+	"none yet – the share is not exported":                              "ancora nessuno – lo share non è esportato",
+	"none yet":                                                          "ancora nessuno",
+	"none – nobody can mount the share":                                 "nessuna – nessuno può montare lo share",
+	"none":                                                              "nessuno",
+	"not reported":                                                      "non riportato",
+	"os_secure_boot required without hw_firmware_type=uefi":             "os_secure_boot required senza hw_firmware_type=uefi",
+	"os_secure_boot required: needs secure-boot capable hosts":          "os_secure_boot required: richiede host con secure boot",
+	"page %d of %d":                                                     "pagina %d di %d",
+	"page %d":                                                           "pagina %d",
+	"poll failed: %s; polling again in %s":                              "interrogazione fallita: %s; nuovo tentativo tra %s",
+	"ports":                                                             "porte",
+	"queued":                                                            "in coda",
+	"read/s  write/s  IOPS":                                             "lett/s  scritt/s  IOPS",
+	"redelegation allowed (depth %d)":                                   "ridelega consentita (profondità %d)",
+	"refreshing…":                                                       "aggiornamento…",
+	"release no ports, floating IPs or volumes":                         "non rilascia porte, IP floating o volumi",
+	"remove no interfaces":                                              "non rimuove interfacce",
+	"retry requested":                                                   "nuovo tentativo richiesto",
+	"retry requested: following it again":                               "nuovo tentativo richiesto: lo si segue di nuovo",
+	"security group %s":                                                 "gruppo di sicurezza %s",
+	"server %s":                                                         "server %s",
+	"server was not booted from an image; rebuild not possible": "il server non è stato avviato da un'immagine; ricostruzione impossibile",
+	"servers and ports":      "server e porte",
+	"snapshot %s  %d GB  %s": "snapshot %s  %d GB  %s",
+	"status %s":              "stato %s",
+	"subnet %s":              "subnet %s",
+	"tenant nets":            "reti tenant",
+	"the DHCP agent on %s has no DHCP port on the network yet":     "l'agent DHCP su %s non ha ancora una porta DHCP sulla rete",
+	"the DHCP agent on %s is down":                                 "l'agent DHCP su %s è down",
+	"the agent on %s is down but still reported active":            "l'agent su %s è down ma risulta ancora active",
+	"the hosting agent on %s is down: the router does not forward": "l'agent ospitante su %s è down: il router non inoltra il traffico",
+	"the standby on %s is down: no failover there":                 "lo standby su %s è down: nessun failover lì",
+	"this will exceed your %s quota by %d%s":                       "questo supererà la quota %s di %d%s",
+	"vCPU Free":                                                    "vCPU libere",
+	"vCPUs":                                                        "vCPU",
+	"volume %s  %d GB  %s":                                         "volume %s  %d GB  %s",
+	"volume %s (deleted)":                                          "volume %s (eliminato)",
+	"volume %s":                                                    "volume %s",
+	"volumes":                                                      "volumi",
+	"· paused":                                                     "· in pausa",
+	"… %d more lines":                                              "… altre %d righe",
+	"⏰ %d schedules":                                               "⏰ %d pianificazioni",
+	"⏱ %d jobs":                                                    "⏱ %d job",
+	"▸ %s  %d servers, %d routers":                                 "▸ %s  %d server, %d router",
+	"▸ Unattached resources  %d FIPs, %d volumes":                  "▸ Risorse non collegate  %d FIP, %d volumi",
+	"● disabled":                                                   "● disabilitato",
+	"● enabled":                                                    "● abilitato",
+	"⚠ no alive DR agent hosts this speaker: its routes are not announced": "⚠ nessun agent DR attivo ospita questo speaker: le sue rotte non vengono annunciate",
+	"✓ ok in %s":                          "✓ ok in %s",
+	"✔ Nothing unhealthy found":           "✔ Nessun problema trovato",
+	"✖ ERROR  fault %d: %s":               "✖ ERRORE  errore %d: %s",
+	"%s not open, skipped":                "%s non aperta, saltata",
+	"%s cannot refresh in place, skipped": "%s non si aggiorna sul posto, saltata",
+	"%s busy, skipped":                    "%s occupata, saltata",
+	"refreshed %s":                        "%s aggiornata",
+}
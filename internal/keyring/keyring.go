@@ -0,0 +1,161 @@
+// Package keyring keeps secrets in the operating system keyring through its
+// command-line tool: secret-tool (libsecret) on Linux and security on macOS.
+package keyring
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// service is the keyring service name entries are stored under.
+const service = "ostui"
+
+// itemNotFound is the exit status of security for a missing item
+// (errSecItemNotFound).
+const itemNotFound = 44
+
+var (
+	// ErrNotFound is returned by Get when no secret is stored.
+	ErrNotFound = errors.New("secret not found in keyring")
+	// ErrUnsupported is returned when no keyring tool is available.
+	ErrUnsupported = errors.New("no supported keyring tool (secret-tool or security) found")
+)
+
+// toolError is a failed run of a keyring tool with what it wrote to its
+// standard error.
+type toolError struct {
+	name   string
+	stderr string
+	err    error
+}
+
+func (e *toolError) Error() string {
+	if e.stderr != "" {
+		return fmt.Sprintf("%s: %s: %v", e.name, e.stderr, e.err)
+	}
+	return fmt.Sprintf("%s: %v", e.name, e.err)
+}
+
+func (e *toolError) Unwrap() error { return e.err }
+
+// run executes a keyring tool with stdin and returns its standard output.
+// A failure is a *toolError keeping the exit status of the tool. It is
+// replaced in tests.
+var run = func(stdin, name string, args ...string) (string, error) {
+	if _, err := exec.LookPath(name); err != nil {
+		return "", ErrUnsupported
+	}
+	cmd := exec.Command(name, args...)
+	cmd.Stdin = strings.NewReader(stdin)
+	var out, errOut bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &out, &errOut
+	if err := cmd.Run(); err != nil {
+		return out.String(), &toolError{name: name, stderr: strings.TrimSpace(errOut.String()), err: err}
+	}
+	return out.String(), nil
+}
+
+// Get returns the secret stored for account.
+func Get(account string) (string, error) {
+	switch runtime.GOOS {
+	case "darwin":
+		return getSecurity(account)
+	case "linux", "freebsd", "openbsd":
+		return getSecretTool(account)
+	}
+	return "", ErrUnsupported
+}
+
+// getSecretTool reads the secret of account with secret-tool, which exits
+// with status 1 and prints nothing for a missing entry. Any other failure,
+// such as a locked collection or no secret service on the session bus, is
+// returned as secret-tool reports it.
+func getSecretTool(account string) (string, error) {
+	out, err := run("", "secret-tool", "lookup", "service", service, "account", account)
+	if errors.Is(err, ErrUnsupported) {
+		return "", err
+	}
+	var tool *toolError
+	var exit interface{ ExitCode() int }
+	if errors.As(err, &tool) && tool.stderr == "" && out == "" && errors.As(err, &exit) && exit.ExitCode() == 1 {
+		return "", ErrNotFound
+	}
+	if err != nil {
+		return "", fmt.Errorf("looking up %s in the keyring: %w", account, err)
+	}
+	secret := strings.TrimRight(out, "\n")
+	if secret == "" {
+		return "", fmt.Errorf("looking up %s in the keyring: secret-tool returned an empty secret", account)
+	}
+	return secret, nil
+}
+
+// getSecurity reads the secret of account from the macOS keychain. Only a
+// missing item is ErrNotFound; a locked keychain or a denied access is
+// returned as security reports it.
+func getSecurity(account string) (string, error) {
+	out, err := run("", "security", "find-generic-password", "-s", service, "-a", account, "-w")
+	var exit interface{ ExitCode() int }
+	if errors.As(err, &exit) && exit.ExitCode() == itemNotFound {
+		return "", ErrNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(out, "\n"), nil
+}
+
+// Set stores secret for account, replacing any previous one.
+func Set(account, secret string) error {
+	switch runtime.GOOS {
+	case "darwin":
+		return setSecurity(account, secret)
+	case "linux", "freebsd", "openbsd":
+		_, err := run(secret, "secret-tool", "store", "--label", service+" "+account, "service", service, "account", account)
+		return err
+	}
+	return ErrUnsupported
+}
+
+// setSecurity stores the secret of account in the macOS keychain. The
+// command goes to security -i on its standard input, with the secret in
+// hex, so that it never appears in an argument list other users can read
+// with ps. Interactive mode does not fail on a refused command, so the
+// secret is read back.
+func setSecurity(account, secret string) error {
+	cmd := fmt.Sprintf("add-generic-password -U -s %s -a %s -X %s\n", service, securityQuote(account), hex.EncodeToString([]byte(secret)))
+	if _, err := run(cmd, "security", "-i"); err != nil {
+		return err
+	}
+	got, err := getSecurity(account)
+	if err != nil {
+		return err
+	}
+	if got != secret {
+		return errors.New("security did not store the secret")
+	}
+	return nil
+}
+
+// securityQuote quotes s as an argument of a security -i command.
+func securityQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// Delete removes the secret stored for account, if any.
+func Delete(account string) error {
+	switch runtime.GOOS {
+	case "darwin":
+		_, err := run("", "security", "delete-generic-password", "-s", service, "-a", account)
+		return err
+	case "linux", "freebsd", "openbsd":
+		_, err := run("", "secret-tool", "clear", "service", service, "account", account)
+		return err
+	}
+	return ErrUnsupported
+}
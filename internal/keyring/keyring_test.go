@@ -0,0 +1,133 @@
+package keyring
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestGetAndSetUseTheKeyringTool(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("exercises the secret-tool backend")
+	}
+	stored := map[string]string{}
+	orig := run
+	defer func() { run = orig }()
+	run = func(stdin, name string, args ...string) (string, error) {
+		account := args[len(args)-1]
+		switch args[0] {
+		case "store":
+			stored[account] = stdin
+			return "", nil
+		case "lookup":
+			if s, ok := stored[account]; ok {
+				return s + "\n", nil
+			}
+			return "", &toolError{name: name, err: exitError(1)}
+		}
+		return "", nil
+	}
+
+	if _, err := Get("lab/password"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+	if err := Set("lab/password", "pw"); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := Get("lab/password"); err != nil || got != "pw" {
+		t.Fatalf("Get = %q, %v", got, err)
+	}
+}
+
+// exitError is a failed run of a tool with its exit status.
+type exitError int
+
+func (e exitError) Error() string { return fmt.Sprintf("exit status %d", int(e)) }
+func (e exitError) ExitCode() int { return int(e) }
+
+func TestSecretToolLookup(t *testing.T) {
+	orig := run
+	defer func() { run = orig }()
+	tests := []struct {
+		name     string
+		out      string
+		err      error
+		want     string
+		notFound bool
+	}{
+		{name: "stored", out: "pw\n", want: "pw"},
+		{name: "missing", err: &toolError{name: "secret-tool", err: exitError(1)}, notFound: true},
+		{name: "no secret service", err: &toolError{name: "secret-tool", stderr: "Cannot autolaunch D-Bus without X11 $DISPLAY", err: exitError(1)}},
+		{name: "output on failure", out: "partial", err: &toolError{name: "secret-tool", err: exitError(1)}},
+		{name: "other status", err: &toolError{name: "secret-tool", err: exitError(2)}},
+		{name: "empty secret", out: "\n"},
+	}
+	for _, tt := range tests {
+		run = func(string, string, ...string) (string, error) { return tt.out, tt.err }
+		got, err := getSecretTool("lab/password")
+		switch {
+		case tt.want != "":
+			if err != nil || got != tt.want {
+				t.Errorf("%s: got %q, %v", tt.name, got, err)
+			}
+		case tt.notFound:
+			if !errors.Is(err, ErrNotFound) {
+				t.Errorf("%s: expected ErrNotFound, got %v", tt.name, err)
+			}
+		default:
+			if err == nil || errors.Is(err, ErrNotFound) {
+				t.Errorf("%s: expected the error reported, got %q, %v", tt.name, got, err)
+			}
+			if tt.err != nil && !errors.Is(err, tt.err) {
+				t.Errorf("%s: expected %v wrapped, got %v", tt.name, tt.err, err)
+			}
+		}
+	}
+}
+
+func TestSecurityBackend(t *testing.T) {
+	stored := map[string]string{}
+	locked := false
+	orig := run
+	defer func() { run = orig }()
+	run = func(stdin, name string, args ...string) (string, error) {
+		if strings.Contains(strings.Join(args, " "), "pw") || strings.Contains(strings.Join(args, " "), hex.EncodeToString([]byte("pw"))) {
+			t.Fatalf("the secret must not be an argument: %q", args)
+		}
+		switch args[0] {
+		case "-i":
+			// add-generic-password -U -s ostui -a "lab/password" -X <hex>
+			f := strings.Fields(stdin)
+			secret, _ := hex.DecodeString(f[len(f)-1])
+			stored[strings.Trim(f[5], `"`)] = string(secret)
+			return "", nil
+		case "find-generic-password":
+			if locked {
+				return "", fmt.Errorf("security: User interaction is not allowed.: %w", exitError(36))
+			}
+			if s, ok := stored[args[4]]; ok {
+				return s + "\n", nil
+			}
+			return "", fmt.Errorf("security: The specified item could not be found in the keychain.: %w", exitError(itemNotFound))
+		}
+		return "", nil
+	}
+
+	if _, err := getSecurity("lab/password"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+	if err := setSecurity("lab/password", "pw"); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := getSecurity("lab/password"); err != nil || got != "pw" {
+		t.Fatalf("Get = %q, %v", got, err)
+	}
+	// A locked keychain is reported, not taken for a missing secret.
+	locked = true
+	if _, err := getSecurity("lab/password"); err == nil || errors.Is(err, ErrNotFound) || !strings.Contains(err.Error(), "interaction") {
+		t.Fatalf("expected the keychain error, got %v", err)
+	}
+}
@@ -0,0 +1,120 @@
+// Package tfstate reads Terraform state files so that resources managed by
+// Terraform can be told apart from ones created by hand.
+package tfstate
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+)
+
+// Active is the state index used by the UI, or nil when no state file is
+// configured (see the --tfstate flag).
+var Active *Index
+
+// Index maps OpenStack resource IDs to the Terraform addresses managing them.
+type Index struct {
+	// Paths are the state files the index was built from.
+	Paths []string
+	byID  map[string][]string
+}
+
+// state is the subset of the Terraform state format (version 4) needed to
+// find resource IDs.
+type state struct {
+	Version   int `json:"version"`
+	Resources []struct {
+		Module    string `json:"module"`
+		Mode      string `json:"mode"`
+		Type      string `json:"type"`
+		Name      string `json:"name"`
+		Instances []struct {
+			IndexKey   interface{} `json:"index_key"`
+			Attributes struct {
+				ID string `json:"id"`
+			} `json:"attributes"`
+		} `json:"instances"`
+	} `json:"resources"`
+}
+
+// Load builds an index from one or more state files, e.g. local
+// terraform.tfstate files or the output of "terraform state pull".
+func Load(paths ...string) (*Index, error) {
+	ix := &Index{Paths: paths, byID: map[string][]string{}}
+	for _, p := range paths {
+		b, err := os.ReadFile(p)
+		if err != nil {
+			return nil, err
+		}
+		if err := ix.add(b); err != nil {
+			return nil, fmt.Errorf("%s: %w", p, err)
+		}
+	}
+	return ix, nil
+}
+
+// add indexes the managed resources of one state document.
+func (ix *Index) add(b []byte) error {
+	var st state
+	if err := json.Unmarshal(b, &st); err != nil {
+		return fmt.Errorf("invalid state: %w", err)
+	}
+	if st.Version != 4 {
+		return fmt.Errorf("unsupported state version %d (want 4)", st.Version)
+	}
+	for _, r := range st.Resources {
+		if r.Mode != "managed" {
+			continue
+		}
+		for _, inst := range r.Instances {
+			if inst.Attributes.ID == "" {
+				continue
+			}
+			addr := r.Type + "." + r.Name + indexSuffix(inst.IndexKey)
+			if r.Module != "" {
+				addr = r.Module + "." + addr
+			}
+			ix.byID[inst.Attributes.ID] = append(ix.byID[inst.Attributes.ID], addr)
+		}
+	}
+	return nil
+}
+
+// indexSuffix renders a count or for_each key as in Terraform addresses.
+func indexSuffix(key interface{}) string {
+	switch k := key.(type) {
+	case float64:
+		return fmt.Sprintf("[%d]", int(k))
+	case string:
+		return "[" + strconv.Quote(k) + "]"
+	}
+	return ""
+}
+
+// Addresses returns every address managing id; association resources (e.g.
+// a floating IP and its openstack_networking_floatingip_associate_v2) share
+// the ID of the resource they attach.
+func (ix *Index) Addresses(id string) []string {
+	if ix == nil {
+		return nil
+	}
+	return ix.byID[id]
+}
+
+// Address returns the first address managing id.
+func (ix *Index) Address(id string) (string, bool) {
+	addrs := ix.Addresses(id)
+	if len(addrs) == 0 {
+		return "", false
+	}
+	return addrs[0], true
+}
+
+// Len returns the number of indexed resource IDs.
+func (ix *Index) Len() int {
+	if ix == nil {
+		return 0
+	}
+	return len(ix.byID)
+}
@@ -0,0 +1,63 @@
+package tfstate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const sample = `{
+  "version": 4,
+  "resources": [
+    {"mode": "managed", "type": "openstack_compute_instance_v2", "name": "web",
+     "instances": [{"index_key": 0, "attributes": {"id": "srv-1"}}, {"index_key": 1, "attributes": {"id": "srv-2"}}]},
+    {"module": "module.net", "mode": "managed", "type": "openstack_networking_network_v2", "name": "private",
+     "instances": [{"index_key": "prod", "attributes": {"id": "net-1"}}]},
+    {"mode": "managed", "type": "openstack_networking_floatingip_v2", "name": "fip",
+     "instances": [{"attributes": {"id": "fip-1"}}]},
+    {"mode": "managed", "type": "openstack_networking_floatingip_associate_v2", "name": "fip",
+     "instances": [{"attributes": {"id": "fip-1"}}]},
+    {"mode": "data", "type": "openstack_images_image_v2", "name": "ubuntu",
+     "instances": [{"attributes": {"id": "img-1"}}]}
+  ]
+}`
+
+func TestLoadIndexesManagedResources(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "terraform.tfstate")
+	if err := os.WriteFile(path, []byte(sample), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	ix, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	for id, want := range map[string]string{
+		"srv-2": "openstack_compute_instance_v2.web[1]",
+		"net-1": `module.net.openstack_networking_network_v2.private["prod"]`,
+		"fip-1": "openstack_networking_floatingip_v2.fip",
+	} {
+		if got, ok := ix.Address(id); !ok || got != want {
+			t.Errorf("Address(%s) = %q, want %q", id, got, want)
+		}
+	}
+	if _, ok := ix.Address("img-1"); ok {
+		t.Errorf("data sources must not count as managed")
+	}
+	if n := len(ix.Addresses("fip-1")); n != 2 {
+		t.Errorf("expected the association as a second address, got %d", n)
+	}
+}
+
+func TestLoadRejectsOldFormat(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "old.tfstate")
+	if err := os.WriteFile(path, []byte(`{"version": 3, "modules": []}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil {
+		t.Fatal("expected an error for state version 3")
+	}
+	var ix *Index
+	if _, ok := ix.Address("x"); ok || ix.Len() != 0 {
+		t.Error("a nil index manages nothing")
+	}
+}
@@ -2,26 +2,46 @@ package ui
 
 import (
 	"fmt"
+	"os"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
-	"github.com/gophercloud/gophercloud"
 	"github.com/gophercloud/utils/openstack/clientconfig"
 	"ostui/internal/client"
+	"ostui/internal/config"
+	"ostui/internal/i18n"
+	"ostui/internal/tfstate"
+	"ostui/internal/ui/clouds"
+	"ostui/internal/ui/common"
 	"ostui/internal/ui/compute"
+	"ostui/internal/ui/containerinfra"
 	"ostui/internal/ui/dns"
+	"ostui/internal/ui/editor"
+	"ostui/internal/ui/events"
 	"ostui/internal/ui/graph"
+	"ostui/internal/ui/iac"
 	"ostui/internal/ui/identity"
 	"ostui/internal/ui/image"
+	"ostui/internal/ui/importer"
+	"ostui/internal/ui/jobs"
+	"ostui/internal/ui/keymanager"
 	"ostui/internal/ui/loadbalancer"
+	"ostui/internal/ui/macro"
 	"ostui/internal/ui/network"
+	"ostui/internal/ui/ops"
+	"ostui/internal/ui/policy"
+	"ostui/internal/ui/problems"
+	"ostui/internal/ui/quota"
 	"ostui/internal/ui/search"
 	"ostui/internal/ui/shell"
+	"ostui/internal/ui/softlock"
 	"ostui/internal/ui/storage"
+	"ostui/internal/ui/theme"
 	"ostui/internal/ui/topology"
 )
 
@@ -32,8 +52,8 @@ type item struct {
 }
 
 // item implements list.Item
-func (i item) Title() string       { return i.title }
-func (i item) Description() string { return i.description }
+func (i item) Title() string       { return i18n.T(i.title) }
+func (i item) Description() string { return i18n.T(i.description) }
 func (i item) FilterValue() string { return i.title }
 
 type cloudItem struct {
@@ -58,13 +78,28 @@ const (
 	stateShell       = "shell"
 	stateGraph       = "graph"
 	stateTopology    = "topology"
+	stateDiff        = "diff"
 	stateSearch      = "search"
+	stateEditor      = "editor"
 )
 
+// inputCapturer is implemented by submodels that temporarily need every key
+// (forms, prompts) so that global shortcuts such as q or esc are not triggered.
+type inputCapturer interface {
+	CapturingInput() bool
+}
+
+// keyPassthrough is implemented by submodels that send keystrokes on to a
+// server (the serial console) and so need ctrl+c as well.
+type keyPassthrough interface {
+	PassingKeysThrough() bool
+}
+
 // AppModel is the root model of the TUI, managing a simple state machine.
 type AppModel struct {
-	provider       *gophercloud.ProviderClient
+	services       *client.ServiceSet
 	cloudName      string
+	opts           Options
 	computeClient  client.ComputeClient
 	networkClient  client.NetworkClient
 	storageClient  client.StorageClient
@@ -73,11 +108,21 @@ type AppModel struct {
 	limitsClient   client.LimitsClient
 	dnsClient      client.DNSClient
 	lbClient       client.LoadBalancerClient
+	sharedFSClient client.SharedFSClient
+	keysClient     client.KeyManagerClient
+	coeClient      client.ContainerInfraClient
 	sidebar        list.Model
 	width          int
 	height         int
 	state          string
-	prevState      string
+	// prevState is the view below the help and command mode overlays.
+	prevState string
+	// navStack holds the views below the current one; esc pops one level.
+	navStack []view
+	// tokens maps the states with an open view to its lifecycle token,
+	// see lifecycle.go; lastToken is the last one handed out.
+	tokens    map[string]int
+	lastToken int
 	// selectedItem holds the item chosen from the sidebar when entering the main view.
 	selectedItem item
 	// modalActive indicates whether a modal overlay is shown.
@@ -89,6 +134,8 @@ type AppModel struct {
 	// sidebar entry. When no subview is active (e.g., in the sidebar state) this field
 	// is nil.
 	mainModel tea.Model
+	// section is the sidebar title of mainModel, see navigateTo.
+	section string
 	// detailModel holds the active drill-down view.
 	detailModel tea.Model
 	graphModel  tea.Model
@@ -98,7 +145,17 @@ type AppModel struct {
 	shellModel *shell.ShellModel
 	// topologyModel holds the topology view model.
 	topologyModel *topology.TopologyModel
-	searchModel   *search.SearchModel
+	// diffModel holds the topology diff between the current and another context.
+	diffModel   tea.Model
+	searchModel *search.SearchModel
+	// editorModel edits the resource of the active detail view as YAML.
+	editorModel tea.Model
+	// editStatus reports the outcome of the last edit on the detail view.
+	editStatus string
+	// Token renewal state; see checkToken.
+	tokenRenewing bool
+	tokenErr      error
+	tokenRetryAt  time.Time
 	// commandBar is the text input for command mode.
 	commandBar textinput.Model
 	// commandMap maps command strings to section titles.
@@ -106,10 +163,39 @@ type AppModel struct {
 	// tabMatches holds autocomplete suggestions for the current prefix.
 	tabMatches []string
 	tabIndex   int
+	// commandErr explains why the last command was rejected.
+	commandErr string
+	// jobs holds the actions scheduled with :at and :every.
+	jobs *jobs.Scheduler
+	// schedules stop and start servers at fixed times of the day.
+	schedules *jobs.Schedules
+	// operations are the creates, deletes and resizes sent by the views.
+	operations *ops.Queue
+	// palette is the open action palette of the list or detail view.
+	palette *common.PaletteModel
+	// jump is the open quick jump of the list or detail table.
+	jump *jumpState
+	// guard asks for the cloud's name before a destructive confirmation in
+	// a red cloud.
+	guard *guardState
+	// lastInput is the time of the last key or mouse event; idle is the lock
+	// screen shown after --idle-lock without one, see idle.go.
+	lastInput time.Time
+	idle      *idleState
 }
 
-// NewModel creates a new AppModel with a sidebar list.
-func NewModel(provider *gophercloud.ProviderClient, cloudName string, compute client.ComputeClient, network client.NetworkClient, storage client.StorageClient, identity client.IdentityClient, image client.ImageClient, limits client.LimitsClient, dns client.DNSClient, lb client.LoadBalancerClient) AppModel {
+// Options are the command-line settings the views take.
+type Options struct {
+	// AllocationRatios are the overcommit ratios applied to the capacity of
+	// the hypervisors placement reports none for.
+	AllocationRatios client.AllocationRatios
+}
+
+// NewModel creates a new AppModel with a sidebar list. Service clients are
+// taken from services, which creates them lazily on first use.
+func NewModel(cloudName string, services *client.ServiceSet, opts Options) AppModel {
+	// Without a configuration directory the schedules view explains why.
+	schedulesPath, _ := config.SchedulesPath(os.Getenv("OSTUI_SCHEDULES_FILE"))
 	items := []list.Item{
 		// Compute section
 		item{title: "=== COMPUTE ===", description: ""},
@@ -120,30 +206,48 @@ func NewModel(provider *gophercloud.ProviderClient, cloudName string, compute cl
 		item{title: "Hypervisors", description: "List hypervisors"},
 		item{title: "Availability Zones", description: "Availability zones"},
 		item{title: "Limits", description: "Show compute and volume quotas"},
+		item{title: "AZ Consistency", description: "Servers and volumes in different zones"},
+		item{title: "Clusters", description: "Magnum clusters, scaling and kubeconfig"},
 		// Network section
 		item{title: "=== NETWORK ===", description: ""},
 		item{title: "Networks", description: "List and manage networks"},
 		item{title: "Subnets", description: "List and manage subnets"},
+		item{title: "Subnet Pools", description: "Subnet pools and address scopes"},
 		item{title: "Routers", description: "List and manage routers"},
 		item{title: "Ports", description: "List and manage ports"},
 		item{title: "Floating IPs", description: "List and manage floating IPs"},
 		item{title: "Security Groups", description: "List and manage security groups"},
 		item{title: "Load Balancers", description: "List load balancers"},
+		item{title: "Tap Services", description: "Port mirroring (tap services and flows)"},
+		item{title: "VPN", description: "Site-to-site VPN connections and policies"},
+		item{title: "Firewalls", description: "FWaaS v2 firewall groups, policies and rules"},
+		item{title: "BGP", description: "BGP speakers, advertised routes and dragents (admin)"},
 		// Storage section
 		item{title: "=== STORAGE ===", description: ""},
 		item{title: "Volumes", description: "List and manage volumes"},
 		item{title: "Snapshots", description: "List and manage snapshots"},
+		item{title: "Shares", description: "Manila shares, export locations and access rules"},
 		// Topology section
 		item{title: "=== TOPOLOGY ===", description: ""},
 		item{title: "Topology", description: "View topology of resources"},
+		item{title: "Problems", description: "Everything unhealthy in the project (!)"},
 		// Identity section
 		item{title: "=== IDENTITY ===", description: ""},
 		item{title: "Projects", description: "List OpenStack projects"},
 		item{title: "Users", description: "List OpenStack users"},
+		item{title: "Domains", description: "List identity domains"},
+		item{title: "Trusts", description: "Keystone trusts granted and received"},
+		item{title: "EC2 Credentials", description: "Access/secret pairs for S3 and EC2 APIs"},
 		item{title: "Token", description: "Show token info"},
+		item{title: "Secrets", description: "Barbican secrets and containers"},
+		item{title: "Clouds", description: "clouds.yaml entries and connection tests"},
+		item{title: "Jobs", description: "Queued operations and actions scheduled with :at and :every"},
+		item{title: "Schedules", description: "Stop and start servers at fixed times"},
+		item{title: "Events", description: "Live notifications (--events-listen)"},
 		// Exit
 		item{title: "=== DNS ===", description: ""},
 		item{title: "Zones", description: "List DNS zones"},
+		item{title: "Zone Transfers", description: "Move zones between projects"},
 		item{title: "Exit", description: "Quit the application"},
 	}
 	const defaultWidth = 30
@@ -152,7 +256,7 @@ func NewModel(provider *gophercloud.ProviderClient, cloudName string, compute cl
 	l.Title = "OSTUI – OpenStack TUI"
 	l.SetShowStatusBar(false)
 	l.SetFilteringEnabled(false)
-	l.Styles.Title = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("205"))
+	l.Styles.Title = lipgloss.NewStyle().Bold(true).Foreground(theme.Accent)
 	// Initialize command mode text input.
 	cmdBar := textinput.New()
 	cmdBar.Placeholder = "command"
@@ -168,19 +272,37 @@ func NewModel(provider *gophercloud.ProviderClient, cloudName string, compute cl
 		"snapshots": "Snapshots",
 		"projects":  "Projects",
 		"users":     "Users",
-		"token":     "Token",
-		"images":    "Images", "img": "Images",
+		"domains":   "Domains",
+		"trusts":    "Trusts",
+		"ec2":       "EC2 Credentials", "ec2-credentials": "EC2 Credentials",
+		"token":  "Token",
+		"images": "Images", "img": "Images",
 		"limits": "Limits", "quota": "Limits",
 		"hypervisors": "Hypervisors", "hyp": "Hypervisors", "hv": "Hypervisors",
 		"az":      "Availability Zones",
+		"azcheck": "AZ Consistency",
 		"flavors": "Flavors", "flavor": "Flavors",
 		"keypairs": "Keypairs", "kp": "Keypairs",
 		"quit":  "__quit__",
 		"zones": "Zones", "dns": "Zones",
+		"transfers": "Zone Transfers", "zone-transfers": "Zone Transfers",
 		"lb": "Load Balancers", "loadbalancers": "Load Balancers", "topology": "Topology", "topo": "Topology",
 		"search": "__search__",
+		"clouds": "Clouds",
+		"taas":   "Tap Services", "tap": "Tap Services",
+		"vpn": "VPN", "vpnaas": "VPN",
+		"firewalls": "Firewalls", "fw": "Firewalls", "fwaas": "Firewalls",
+		"bgp":         "BGP",
+		"subnetpools": "Subnet Pools", "scopes": "Subnet Pools",
+		"shares": "Shares", "manila": "Shares",
+		"secrets": "Secrets", "barbican": "Secrets",
+		"clusters": "Clusters", "magnum": "Clusters", "coe": "Clusters",
+		"jobs":      "Jobs",
+		"schedules": "Schedules", "sched": "Schedules",
+		"events": "Events", "ev": "Events",
+		"problems": "Problems", "health": "Problems",
 	}
-	return AppModel{provider: provider, cloudName: cloudName, computeClient: compute, networkClient: network, storageClient: storage, identityClient: identity, imageClient: image, limitsClient: limits, dnsClient: dns, lbClient: lb, sidebar: l, state: stateSidebar, prevState: "", commandBar: cmdBar, commandMap: cmdMap}
+	return AppModel{services: services, cloudName: cloudName, opts: opts, computeClient: services.Compute(), networkClient: services.Network(), storageClient: services.Storage(), identityClient: services.Identity(), imageClient: services.Image(), limitsClient: services.Limits(), dnsClient: services.DNS(), lbClient: services.LoadBalancer(), sharedFSClient: services.SharedFS(), keysClient: services.KeyManager(), coeClient: services.ContainerInfra(), sidebar: l, state: stateSidebar, prevState: "", commandBar: cmdBar, commandMap: cmdMap, jobs: jobs.NewScheduler(), operations: ops.NewQueue(), schedules: jobs.NewSchedules(schedulesPath, cloudName, time.Now()), lastInput: time.Now()}
 }
 
 // navigationMap returns a map of sidebar titles to model constructors.
@@ -195,60 +317,328 @@ func (m AppModel) navigationMap() map[string]func() tea.Model {
 		"Volumes":            func() tea.Model { return storage.NewVolumesModel(m.storageClient) },
 		"Projects":           func() tea.Model { return identity.NewProjectsModel(m.identityClient) },
 		"Users":              func() tea.Model { return identity.NewUsersModel(m.identityClient) },
+		"Domains":            func() tea.Model { return identity.NewDomainsModel(m.identityClient) },
+		"Trusts":             func() tea.Model { return identity.NewTrustsModel(m.identityClient) },
+		"EC2 Credentials":    func() tea.Model { return identity.NewEC2CredentialsModel(m.identityClient) },
 		"Token":              func() tea.Model { return identity.NewTokenModel(m.identityClient) },
-		"Images":             func() tea.Model { return image.NewImagesModel(m.imageClient) },
-		"Limits":             func() tea.Model { return compute.NewLimitsModel(m.limitsClient) },
-		"Hypervisors":        func() tea.Model { return compute.NewHypervisorsModel(m.computeClient) },
+		"Images":             func() tea.Model { return image.NewImagesModel(m.imageClient, m.identityClient) },
+		"Limits":             m.newLimitsModel,
+		"Hypervisors":        func() tea.Model { return compute.NewHypervisorsModel(m.computeClient, m.opts.AllocationRatios) },
 		"Availability Zones": func() tea.Model { return compute.NewZonesModel(m.computeClient) },
+		"AZ Consistency":     func() tea.Model { return compute.NewAZReportModel(m.computeClient, m.storageClient) },
 		"Subnets":            func() tea.Model { return network.NewSubnetsModel(m.networkClient) },
+		"Subnet Pools":       func() tea.Model { return network.NewSubnetPoolsModel(m.networkClient) },
 		"Flavors":            func() tea.Model { return compute.NewFlavorsModel(m.computeClient) },
 		"Keypairs":           func() tea.Model { return compute.NewKeypairsModel(m.computeClient) },
+		"Clouds":             func() tea.Model { return clouds.NewCloudsModel(os.Getenv("OS_CLIENT_CONFIG_FILE")) },
 		"Zones":              func() tea.Model { return dns.NewZonesModel(m.dnsClient) },
-		"Load Balancers":     func() tea.Model { return loadbalancer.NewLoadBalancersModel(m.lbClient) },
+		"Zone Transfers":     func() tea.Model { return dns.NewZoneTransfersModel(m.dnsClient, "", "") },
+		"Load Balancers":     func() tea.Model { return loadbalancer.NewLoadBalancersModel(m.lbClient, m.keysClient) },
 		"Topology":           func() tea.Model { return topology.NewTopologyModel(m.computeClient, m.networkClient, m.storageClient) },
+		"Tap Services":       func() tea.Model { return network.NewTapServicesModel(m.networkClient) },
+		"VPN":                func() tea.Model { return network.NewVPNModel(m.networkClient) },
+		"Firewalls":          func() tea.Model { return network.NewFirewallModel(m.networkClient) },
+		"BGP":                func() tea.Model { return network.NewBGPModel(m.networkClient) },
+		"Shares":             func() tea.Model { return storage.NewSharesModel(m.sharedFSClient) },
+		"Secrets":            func() tea.Model { return keymanager.NewSecretsModel(m.keysClient) },
+		"Clusters":           func() tea.Model { return containerinfra.NewClustersModel(m.coeClient) },
+		"Jobs":               func() tea.Model { return jobs.NewJobsModel(m.jobs, m.operations) },
+		"Schedules":          func() tea.Model { return jobs.NewSchedulesModel(m.schedules) },
+		"Events":             func() tea.Model { return events.NewEventsModel(events.Active) },
+		"Problems":           func() tea.Model { return problems.NewProblemsModel(m.problemsClients()) },
+	}
+}
+
+// loadDiffContext creates the clients for a topology diff target of the form
+// "<cloud>" or "<cloud>/<project>" from clouds.yaml.
+func loadDiffContext(target string) (topology.ClientSet, error) {
+	cloud, project, _ := strings.Cut(target, "/")
+	authOpts, err := config.LoadAuthOptions(cloud, os.Getenv("OS_CLIENT_CONFIG_FILE"))
+	if err != nil {
+		return topology.ClientSet{}, err
+	}
+	if project != "" {
+		authOpts.TenantID = ""
+		authOpts.TenantName = project
+		authOpts.Scope = nil
+	}
+	services := client.NewServiceSet(cloud, authOpts, false)
+	if _, err := services.Provider(); err != nil {
+		return topology.ClientSet{}, err
+	}
+	return topology.ClientSet{Compute: services.Compute(), Network: services.Network(), Storage: services.Storage()}, nil
+}
+
+// detailModelFor returns the detail view for a search result, or nil when the
+// category has no detail view reachable by ID.
+func (m AppModel) detailModelFor(r search.SearchResult) tea.Model {
+	switch r.Category {
+	case "Servers":
+		return compute.NewInstanceDetailModel(m.computeClient, m.networkClient, m.storageClient, m.imageClient, r.ID)
+	case "Floating IPs":
+		return network.NewFloatingIPDetailModel(m.networkClient, r.ID)
+	case "Routers":
+		return network.NewRouterDetailModel(m.networkClient, r.ID)
+	case "Networks":
+		return network.NewNetworkSubnetsModel(m.networkClient, r.ID)
+	case "Subnets":
+		return network.NewSubnetDetailModel(m.networkClient, r.ID)
+	case "Security Groups":
+		return network.NewSecurityGroupDetailModel(m.networkClient, m.computeClient, m.lbClient, r.ID)
+	case "Ports":
+		return network.NewPortDetailModel(m.networkClient, r.ID)
+	case "Volumes":
+		return storage.NewVolumeDetailModel(m.storageClient, m.computeClient, r.ID)
+	case "Hypervisors":
+		return compute.NewHypervisorDetailModel(m.computeClient, r.ID)
+	case "Load Balancers":
+		return loadbalancer.NewLoadBalancerDetailModel(m.lbClient, m.keysClient, r.ID, r.Name)
 	}
+	return nil
+}
+
+// problemsClients returns the clients the problems view checks.
+func (m AppModel) problemsClients() problems.ClientSet {
+	return problems.ClientSet{Compute: m.computeClient, Network: m.networkClient, Storage: m.storageClient, LoadBalancer: m.lbClient}
+}
+
+// quotaSource returns the clients the create forms read the quotas from.
+func (m AppModel) quotaSource() quota.Source {
+	return quota.Source{Limits: m.limitsClient, Network: m.networkClient, Identity: m.identityClient}
+}
+
+// newLimitsModel builds the Limits view with the clients needed for quota editing.
+func (m AppModel) newLimitsModel() tea.Model {
+	return compute.NewLimitsModel(m.limitsClient, m.computeClient, m.networkClient, m.storageClient, m.identityClient)
 }
 
 // Init implements tea.Model.
 func (m AppModel) Init() tea.Cmd {
-	return tea.EnterAltScreen
+	return tea.Batch(tea.EnterAltScreen, tokenTick(), loadRolesCmd(m.identityClient), jobs.ScheduleTick())
 }
 
-// navigateTo instantiates the appropriate submodel based on the given section title.
-func (m *AppModel) navigateTo(section string) {
-	// Use navigationMap for most sections.
-	navMap := m.navigationMap()
-	if constructor, ok := navMap[section]; ok {
-		// Special handling for Topology which uses a dedicated model and state.
-		if section == "Topology" {
-			tm := topology.NewTopologyModel(m.computeClient, m.networkClient, m.storageClient)
-			m.topologyModel = &tm
-			m.state = stateTopology
-			return
+// navigateTo opens the given section title as the only view above the
+// sidebar and returns the Init command of its submodel.
+func (m *AppModel) navigateTo(section string) tea.Cmd {
+	m.resetNav()
+	// Special handling for Topology which uses a dedicated model and state.
+	if section == "Topology" {
+		tm := topology.NewTopologyModel(m.computeClient, m.networkClient, m.storageClient)
+		return m.pushView(stateTopology, &tm)
+	}
+	// Use navigationMap for most sections; unknown ones get no submodel.
+	var model tea.Model
+	if constructor, ok := m.navigationMap()[section]; ok {
+		model = constructor()
+	}
+	cmd := m.pushView(stateMain, model)
+	m.section = section
+	return cmd
+}
+
+// reloadableSections are the sections whose list reloads in place, keeping
+// its selection, filter and prompts: the ones a scheduled refresh can run on.
+var reloadableSections = map[string]bool{
+	"AZ Consistency":     true,
+	"Availability Zones": true,
+	"BGP":                true,
+	"Clusters":           true,
+	"Domains":            true,
+	"Firewalls":          true,
+	"Flavors":            true,
+	"Floating IPs":       true,
+	"Hypervisors":        true,
+	"Images":             true,
+	"Keypairs":           true,
+	"Limits":             true,
+	"Load Balancers":     true,
+	"Networks":           true,
+	"Ports":              true,
+	"Problems":           true,
+	"Projects":           true,
+	"Routers":            true,
+	"Secrets":            true,
+	"Security Groups":    true,
+	"Servers":            true,
+	"Shares":             true,
+	"Subnet Pools":       true,
+	"Subnets":            true,
+	"Tap Services":       true,
+	"Token":              true,
+	"Trusts":             true,
+	"Users":              true,
+	"VPN":                true,
+	"Volumes":            true,
+	"Zone Transfers":     true,
+	"Zones":              true,
+}
+
+// schedulableSections returns the command aliases of the sections a
+// scheduled refresh can reload, see reloadableSections.
+func (m AppModel) schedulableSections() map[string]string {
+	out := map[string]string{}
+	for alias, section := range m.commandMap {
+		if reloadableSections[section] {
+			out[alias] = section
 		}
-		m.mainModel = constructor()
-		return
 	}
-	// No submodel for unknown sections.
+	return out
 }
 
-// Update implements tea.Model.
+// macroTarget returns the server or volume a macro runs on: the open detail
+// view, or the highlighted row of the list under the command bar.
+func (m AppModel) macroTarget() (macro.Target, bool) {
+	switch m.prevState {
+	case stateDetail:
+		switch dm := m.detailModel.(type) {
+		case compute.InstanceDetailModel:
+			return macro.Target{Kind: macro.KindServer, ID: dm.ResourceID()}, true
+		case storage.VolumeDetailModel:
+			return macro.Target{Kind: macro.KindVolume, ID: dm.ResourceID(), Name: dm.ResourceName()}, true
+		}
+	case stateMain:
+		switch mm := m.mainModel.(type) {
+		case compute.InstancesModel:
+			if row := mm.Table().SelectedRow(); len(row) > 1 && !mm.OnGroupHeader() {
+				return macro.Target{Kind: macro.KindServer, ID: row[0], Name: row[1]}, true
+			}
+		case storage.VolumesModel:
+			if row := mm.Table().SelectedRow(); len(row) > 1 {
+				return macro.Target{Kind: macro.KindVolume, ID: row[0], Name: row[1]}, true
+			}
+		}
+	}
+	return macro.Target{}, false
+}
+
+// refreshSection reloads section when it is the open list. A scheduled
+// refresh never navigates away from what is on screen, and only reloads a
+// list in place, keeping its selection, filter and prompts: a list that
+// cannot, or is busy with a filter or a load, is skipped for this run.
+func (m AppModel) refreshSection(section string) (string, tea.Cmd) {
+	if m.mainModel == nil || m.section != section {
+		return i18n.T("%s not open, skipped", i18n.T(section)), nil
+	}
+	rl, ok := m.mainModel.(common.Reloader)
+	if !ok {
+		return i18n.T("%s cannot refresh in place, skipped", i18n.T(section)), nil
+	}
+	cmd := rl.Reload()
+	if cmd == nil {
+		return i18n.T("%s busy, skipped", i18n.T(section)), nil
+	}
+	return i18n.T("refreshed %s", i18n.T(section)), cmd
+}
+
+// closeCommandBar clears and blurs the command bar. The caller decides where
+// to go next.
+func (m *AppModel) closeCommandBar() {
+	m.commandBar.Blur()
+	m.commandBar.SetValue("")
+	// reset tab autocomplete state
+	m.tabMatches = nil
+	m.tabIndex = 0
+}
+
+// Update implements tea.Model. The commands returned are tagged with the
+// token of the view that was active, see lifecycle.go.
 func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if vm, ok := msg.(viewMsg); ok {
+		return m.routeViewMsg(vm)
+	}
+	token := m.activeToken()
+	updated, cmd := m.update(msg)
+	return updated, tagCmd(token, cmd)
+}
+
+// update handles a message on behalf of the active view.
+func (m AppModel) update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if m.idle != nil {
+		if _, ok := msg.(softlock.CheckedMsg); ok || isInput(msg) {
+			return m.updateIdle(msg)
+		}
+	} else if isInput(msg) {
+		m.lastInput = time.Now()
+	}
 	switch msg := msg.(type) {
 	case search.SearchDoneMsg:
-		m.state = stateSidebar
-		m.searchModel = nil
+		m.popView()
 		return m, nil
 	case search.SearchSelectedMsg:
-		navMap := m.navigationMap()
-		if constructor, ok := navMap[msg.Result.Category]; ok {
-			m.mainModel = constructor()
-			m.state = stateMain
-			m.searchModel = nil
-			return m, m.mainModel.Init()
-		}
-		m.state = stateSidebar
-		m.searchModel = nil
+		if _, ok := m.navigationMap()[msg.Result.Category]; ok {
+			cmd := m.navigateTo(msg.Result.Category)
+			if dm := m.detailModelFor(msg.Result); msg.OpenDetail && dm != nil {
+				return m, tea.Batch(cmd, m.pushView(stateDetail, dm))
+			}
+			return m, cmd
+		}
+		m.popView()
+		return m, nil
+	case tokenTickMsg:
+		var cmd tea.Cmd
+		m, cmd = m.checkToken(time.Now())
+		var idleCmd tea.Cmd
+		m, idleCmd = m.checkIdle(time.Now())
+		return m, tea.Batch(cmd, idleCmd, tokenTick())
+	case jobs.DueMsg:
+		j, ok := m.jobs.Start(msg, time.Now())
+		if !ok {
+			return m, nil
+		}
+		if j.Action.Verb == jobs.VerbRefresh {
+			result, cmd := m.refreshSection(j.Action.Target)
+			id := j.ID
+			return m, tea.Batch(cmd, func() tea.Msg { return jobs.ResultMsg{ID: id, Result: result} })
+		}
+		return m, jobs.RunServerAction(m.computeClient, *j)
+	case jobs.ResultMsg:
+		return m, m.jobs.Finish(msg, time.Now())
+	case jobs.ScheduleTickMsg:
+		cmds := []tea.Cmd{jobs.ScheduleTick()}
+		for _, run := range m.schedules.Due(msg.At) {
+			cmds = append(cmds, jobs.RunScheduled(m.computeClient, run))
+		}
+		return m, tea.Batch(cmds...)
+	case jobs.ScheduleResultMsg:
+		m.schedules.Record(msg)
+		return m, nil
+	case ops.EnqueueMsg:
+		return m, m.operations.Add(msg, time.Now())
+	case ops.Msg:
+		return m, m.operations.Update(msg, time.Now())
+	case ops.FinishedMsg:
+		// The list on screen shows what the operation changed; a detail
+		// view reacts to the operations on its resource.
+		var cmds []tea.Cmd
+		if rl, ok := m.mainModel.(common.Reloader); ok {
+			cmds = append(cmds, rl.Reload())
+		}
+		if m.detailModel != nil {
+			var cmd tea.Cmd
+			m.detailModel, cmd = m.detailModel.Update(msg)
+			cmds = append(cmds, cmd)
+		}
+		return m, tea.Batch(cmds...)
+	case tokenRenewedMsg:
+		m.tokenRenewing = false
+		m.tokenErr = msg.err
+		// Also after a success: a token lifetime shorter than the renewal
+		// window must not cause a renewal on every tick.
+		m.tokenRetryAt = time.Now().Add(tokenRenewRetry)
+		if msg.err == nil {
+			// Role assignments may have changed since the last token.
+			return m, loadRolesCmd(m.identityClient)
+		}
+		return m, nil
+	case rolesLoadedMsg:
+		policy.SetRoles(msg.roles)
+		return m, nil
+	case editor.DoneMsg:
+		m.popView()
+		m.editStatus = msg.Status
+		if msg.Changed && m.detailModel != nil {
+			return m, m.detailModel.Init()
+		}
 		return m, nil
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
@@ -261,6 +651,11 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.mainModel, cmd = m.mainModel.Update(msg)
 			cmds = append(cmds, cmd)
 		}
+		if m.state == stateEditor && m.editorModel != nil {
+			var cmd tea.Cmd
+			m.editorModel, cmd = m.editorModel.Update(msg)
+			cmds = append(cmds, cmd)
+		}
 		if m.state == stateSearch && m.searchModel != nil {
 			var cmd tea.Cmd
 			var newModel tea.Model
@@ -277,6 +672,11 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.logsModel, cmd = m.logsModel.Update(msg)
 			cmds = append(cmds, cmd)
 		}
+		if m.state == stateDiff && m.diffModel != nil {
+			var cmd tea.Cmd
+			m.diffModel, cmd = m.diffModel.Update(msg)
+			cmds = append(cmds, cmd)
+		}
 		if m.state == stateShell && m.shellModel != nil {
 			var cmd tea.Cmd
 			var newModel tea.Model
@@ -300,6 +700,62 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			return m, cmd
 		}
+		// In a red cloud, a destructive prompt also asks for the cloud's name.
+		if m.guard != nil {
+			return m.updateGuard(msg)
+		}
+		if m.needsGuard(msg) {
+			m.guard = &guardState{}
+			return m, nil
+		}
+		// The action palette takes every key while it is open.
+		if m.palette != nil {
+			return m.updatePalette(msg)
+		}
+		// So does the quick jump.
+		if m.jump != nil {
+			return m.updateJump(msg)
+		}
+		// Forward ALL keys to a submodel that is capturing input.
+		if m.state == stateMain && m.mainModel != nil {
+			if ic, ok := m.mainModel.(inputCapturer); ok && ic.CapturingInput() && msg.String() != "ctrl+c" {
+				var cmd tea.Cmd
+				m.mainModel, cmd = m.mainModel.Update(msg)
+				return m, cmd
+			}
+		}
+		// The editor consumes every key, including esc to cancel.
+		if m.state == stateEditor && m.editorModel != nil && msg.String() != "ctrl+c" {
+			var cmd tea.Cmd
+			m.editorModel, cmd = m.editorModel.Update(msg)
+			return m, cmd
+		}
+		if m.state == stateDetail && m.detailModel != nil {
+			if kp, ok := m.detailModel.(keyPassthrough); ok && kp.PassingKeysThrough() {
+				var cmd tea.Cmd
+				m.detailModel, cmd = m.detailModel.Update(msg)
+				return m, cmd
+			}
+			if ic, ok := m.detailModel.(inputCapturer); ok && ic.CapturingInput() && msg.String() != "ctrl+c" {
+				var cmd tea.Cmd
+				m.detailModel, cmd = m.detailModel.Update(msg)
+				return m, cmd
+			}
+			m.editStatus = ""
+			if ed, ok := m.detailModel.(editor.Editable); ok && msg.String() == "E" {
+				return m, m.pushView(stateEditor, editor.New(ed.EditSpec(), m.width, m.height))
+			}
+			if ex, ok := m.detailModel.(iac.Exportable); ok && msg.String() == "ctrl+x" {
+				return m, m.pushView(stateDetail, iac.New(ex.ExportSpec(), m.width, m.height))
+			}
+		}
+		if m.state == stateTopology && m.topologyModel != nil && m.topologyModel.CapturingInput() && msg.String() != "ctrl+c" {
+			newModel, cmd := m.topologyModel.Update(msg)
+			if tm, ok := newModel.(topology.TopologyModel); ok {
+				*m.topologyModel = tm
+			}
+			return m, cmd
+		}
 		switch msg.String() {
 		case "ctrl+c", "q":
 			return m, tea.Quit
@@ -309,29 +765,25 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.state = stateHelp
 			}
 		case "esc":
+			if m.state == stateCommand {
+				// Handled by command mode below.
+				break
+			}
 			if m.state == stateHelp {
 				// Return to previous state.
 				m.state = m.prevState
 				m.prevState = ""
 				return m, nil
 			}
-			// Return to sidebar from any other state.
-			if m.state == stateDetail {
-				m.state = stateMain
-				m.modalActive = false
-				return m, nil
-			} else if m.state != stateSidebar {
-				m.state = stateSidebar
-				m.modalActive = false
-				m.mainModel = nil
+			// Go back exactly one level.
+			if m.state != stateSidebar {
+				m.popView()
 				return m, nil
 			}
 		case "/":
 			if m.state == stateSidebar {
 				sm := search.NewSearchModel(m.computeClient, m.networkClient, m.storageClient, m.imageClient, m.width, m.height)
-				m.searchModel = &sm
-				m.state = stateSearch
-				return m, sm.Init()
+				return m, m.pushView(stateSearch, &sm)
 			}
 		case "c":
 			// Load cloud names and show selection list (original)
@@ -347,19 +799,48 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			const cloudListWidth = 30
 			const cloudListHeight = 10
 			l := list.New(items, list.NewDefaultDelegate(), cloudListWidth, cloudListHeight)
-			l.Title = "Select Cloud"
+			l.Title = i18n.T("Select Cloud")
 			l.SetShowStatusBar(false)
 			l.SetFilteringEnabled(false)
 			l.Styles.Title = lipgloss.NewStyle().Bold(true)
 			m.cloudList = l
-			m.state = stateCloudSelect
+			m.pushView(stateCloudSelect, nil)
 			return m, nil
+		case "!":
+			if m.state == stateCommand {
+				// Typed into the command bar, e.g. a shell passthrough.
+				break
+			}
+			// Open the problems view
+			return m, m.navigateTo("Problems")
 		case "T":
 			// Open topology view
 			tm := topology.NewTopologyModel(m.computeClient, m.networkClient, m.storageClient)
-			m.topologyModel = &tm
-			m.state = stateTopology
-			return m, m.topologyModel.Init()
+			return m, m.pushView(stateTopology, &tm)
+		case "r":
+			// Refresh the list or detail view in place, keeping its
+			// selection. Views that use r for something else in their
+			// current state return no command and get the key.
+			if m.state == stateMain || m.state == stateDetail {
+				if rl, ok := m.viewModel(m.state).(common.Reloader); ok {
+					if cmd := rl.Reload(); cmd != nil {
+						return m, cmd
+					}
+				}
+			}
+		case ".":
+			// Open the action palette of the selected row or resource.
+			if acts := m.paletteActions(); len(acts) > 0 {
+				p := common.NewPalette(i18n.T("Actions"), acts)
+				m.palette = &p
+				return m, p.Init()
+			}
+		case "f":
+			// Quick jump: type to move the cursor to the best fuzzy
+			// match among the rows of the table, without filtering.
+			if am, ok := m.startJump(); ok {
+				return am, nil
+			}
 		case ":":
 			// Enter command mode
 			m.prevState = m.state
@@ -406,9 +887,7 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					}
 				}
 				gm := graph.NewGraphModel(rt, resID, resName, m.computeClient, m.networkClient, m.storageClient, m.lbClient)
-				m.graphModel = &gm
-				m.state = stateGraph
-				return m, m.graphModel.Init()
+				return m, m.pushView(stateGraph, &gm)
 			}
 
 		case "enter":
@@ -419,25 +898,7 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					}
 					m.selectedItem = i
 					// Transition to the main view and initialise the appropriate submodel.
-					m.state = stateMain
-					navMap := m.navigationMap()
-					if constructor, ok := navMap[i.title]; ok {
-						if i.title == "Topology" {
-							// Use navigateTo for Topology to handle state and model.
-							m.navigateTo(i.title)
-						} else {
-							m.mainModel = constructor()
-							m.state = stateMain
-						}
-					} else {
-						// Fallback: no submodel – keep nil.
-					}
-
-					// If a submodel was created, invoke its Init to start async loading.
-					if m.mainModel != nil {
-						return m, m.mainModel.Init()
-					}
-					return m, nil
+					return m, m.navigateTo(i.title)
 				}
 				return m, nil
 			} else if m.state == stateMain && m.mainModel != nil {
@@ -448,103 +909,90 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					if model.Table().Rows() == nil {
 						return m, nil
 					}
+					// Group headers collapse or expand instead.
+					if model.OnGroupHeader() {
+						var cmd tea.Cmd
+						m.mainModel, cmd = m.mainModel.Update(msg)
+						return m, cmd
+					}
 					row := model.Table().SelectedRow()
 					if len(row) > 0 {
 						id := row[0]
-						m.detailModel = compute.NewInstanceDetailModel(m.computeClient, m.networkClient, m.storageClient, id)
-						m.state = stateDetail
-						return m, m.detailModel.Init()
+						return m, m.pushView(stateDetail, compute.NewInstanceDetailModel(m.computeClient, m.networkClient, m.storageClient, m.imageClient, id))
+					}
+				case compute.AZReportModel:
+					row := model.Table().SelectedRow()
+					if len(row) > 0 {
+						return m, m.pushView(stateDetail, compute.NewInstanceDetailModel(m.computeClient, m.networkClient, m.storageClient, m.imageClient, row[0]))
 					}
 				case network.NetworksModel:
 					row := model.Table().SelectedRow()
 					if len(row) > 0 {
 						id := row[0]
 						// Show subnets for this network.
-						m.detailModel = network.NewNetworkSubnetsModel(m.networkClient, id)
-						m.state = stateDetail
-						return m, m.detailModel.Init()
+						return m, m.pushView(stateDetail, network.NewNetworkSubnetsModel(m.networkClient, id))
 					}
 				case network.FloatingIPsModel:
 					row := model.Table().SelectedRow()
 					if len(row) > 0 {
 						id := row[0]
-						m.detailModel = network.NewFloatingIPDetailModel(m.networkClient, id)
-						m.state = stateDetail
-						return m, m.detailModel.Init()
+						return m, m.pushView(stateDetail, network.NewFloatingIPDetailModel(m.networkClient, id))
 					}
 				case network.SecurityGroupsModel:
 					row := model.Table().SelectedRow()
 					if len(row) > 0 {
 						id := row[0]
-						m.detailModel = network.NewSecurityGroupDetailModel(m.networkClient, id)
-						m.state = stateDetail
-						return m, m.detailModel.Init()
+						return m, m.pushView(stateDetail, network.NewSecurityGroupDetailModel(m.networkClient, m.computeClient, m.lbClient, id))
 					}
 				case storage.VolumesModel:
 					row := model.Table().SelectedRow()
 					if len(row) > 0 {
 						id := row[0]
-						m.detailModel = storage.NewVolumeDetailModel(m.storageClient, id)
-						m.state = stateDetail
-						return m, m.detailModel.Init()
+						return m, m.pushView(stateDetail, storage.NewVolumeDetailModel(m.storageClient, m.computeClient, id))
 					}
 				case storage.SnapshotsModel:
 					row := model.Table().SelectedRow()
 					if len(row) > 0 {
 						id := row[0]
-						m.detailModel = storage.NewSnapshotDetailModel(m.storageClient, id)
-						m.state = stateDetail
-						return m, m.detailModel.Init()
+						return m, m.pushView(stateDetail, storage.NewSnapshotDetailModel(m.storageClient, id))
 					}
 				case identity.ProjectsModel:
 					row := model.Table().SelectedRow()
 					if len(row) > 0 {
 						id := row[0]
-						m.detailModel = identity.NewProjectDetailModel(m.identityClient, id)
-						m.state = stateDetail
-						return m, m.detailModel.Init()
+						return m, m.pushView(stateDetail, identity.NewProjectDetailModel(m.identityClient, id))
 					}
 				case identity.UsersModel:
 					row := model.Table().SelectedRow()
 					if len(row) > 0 {
 						id := row[0]
-						m.detailModel = identity.NewUserDetailModel(m.identityClient, id)
-						m.state = stateDetail
-						return m, m.detailModel.Init()
+						return m, m.pushView(stateDetail, identity.NewUserDetailModel(m.identityClient, id))
 					}
 					return m, nil
 				case image.ImagesModel:
 					row := model.Table().SelectedRow()
 					if len(row) > 0 {
 						id := row[0]
-						m.detailModel = image.NewImageDetailModel(m.imageClient, id)
-						m.state = stateDetail
-						return m, m.detailModel.Init()
+						return m, m.pushView(stateDetail, image.NewImageDetailModel(m.imageClient, m.computeClient, id))
 					}
 				case compute.FlavorsModel:
 					row := model.Table().SelectedRow()
 					if len(row) > 0 {
 						id := row[0]
-						m.detailModel = compute.NewFlavorDetailModel(m.computeClient, id)
-						m.state = stateDetail
-						return m, m.detailModel.Init()
+						return m, m.pushView(stateDetail, compute.NewFlavorDetailModel(m.computeClient, id))
 					}
 				case compute.KeypairsModel:
 					row := model.Table().SelectedRow()
 					if len(row) > 0 {
 						name := row[0]
-						m.detailModel = compute.NewKeypairDetailModel(m.computeClient, name)
-						m.state = stateDetail
-						return m, m.detailModel.Init()
+						return m, m.pushView(stateDetail, compute.NewKeypairDetailModel(m.computeClient, name))
 					}
 				// Hypervisors drill-down
 				case compute.HypervisorsModel:
 					row := model.Table().SelectedRow()
 					if len(row) > 0 {
 						id := row[0]
-						m.detailModel = compute.NewHypervisorDetailModel(m.computeClient, id)
-						m.state = stateDetail
-						return m, m.detailModel.Init()
+						return m, m.pushView(stateDetail, compute.NewHypervisorDetailModel(m.computeClient, id))
 					}
 				// Load Balancers drill-down
 				case loadbalancer.LoadBalancersModel:
@@ -552,9 +1000,7 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					if len(row) > 0 {
 						id := row[0]
 						name := row[1]
-						m.detailModel = loadbalancer.NewLoadBalancerDetailModel(m.lbClient, id, name)
-						m.state = stateDetail
-						return m, m.detailModel.Init()
+						return m, m.pushView(stateDetail, loadbalancer.NewLoadBalancerDetailModel(m.lbClient, m.keysClient, id, name))
 					}
 				// DNS Zones drill-down
 				case dns.ZonesModel:
@@ -562,33 +1008,49 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					if len(row) > 0 {
 						id := row[0]
 						name := row[1]
-						m.detailModel = dns.NewRecordSetsModel(m.dnsClient, id, name)
-						m.state = stateDetail
-						return m, m.detailModel.Init()
+						return m, m.pushView(stateDetail, dns.NewRecordSetsModel(m.dnsClient, id, name))
 					}
 				case network.RouterModel:
 					row := model.Table().SelectedRow()
 					if len(row) > 0 {
 						id := row[0]
-						m.detailModel = network.NewRouterDetailModel(m.networkClient, id)
-						m.state = stateDetail
-						return m, m.detailModel.Init()
+						return m, m.pushView(stateDetail, network.NewRouterDetailModel(m.networkClient, id))
 					}
 				case network.SubnetsModel:
 					row := model.Table().SelectedRow()
 					if len(row) > 0 {
 						id := row[0]
-						m.detailModel = network.NewSubnetDetailModel(m.networkClient, id)
-						m.state = stateDetail
-						return m, m.detailModel.Init()
+						return m, m.pushView(stateDetail, network.NewSubnetDetailModel(m.networkClient, id))
 					}
 				case network.PortsModel:
 					row := model.Table().SelectedRow()
 					if len(row) > 0 {
 						id := row[0]
-						m.detailModel = network.NewPortDetailModel(m.networkClient, id)
-						m.state = stateDetail
-						return m, m.detailModel.Init()
+						return m, m.pushView(stateDetail, network.NewPortDetailModel(m.networkClient, id))
+					}
+				case network.TapServicesModel:
+					if ts, ok := model.Selected(); ok {
+						return m, m.pushView(stateDetail, network.NewTapFlowsModel(m.networkClient, ts))
+					}
+				case network.SubnetPoolsModel:
+					if p, ok := model.SelectedPool(); ok {
+						return m, m.pushView(stateDetail, network.NewSubnetPoolDetailModel(m.networkClient, p))
+					}
+				case network.VPNModel:
+					if c, ok := model.SelectedConnection(); ok {
+						return m, m.pushView(stateDetail, network.NewVPNConnectionDetailModel(m.networkClient, c.ID))
+					}
+				case network.BGPModel:
+					if spk, ok := model.Selected(); ok {
+						return m, m.pushView(stateDetail, network.NewBGPSpeakerDetailModel(m.networkClient, spk))
+					}
+				case storage.SharesModel:
+					if sh, ok := model.Selected(); ok {
+						return m, m.pushView(stateDetail, storage.NewShareDetailModel(m.sharedFSClient, sh))
+					}
+				case containerinfra.ClustersModel:
+					if c, ok := model.Selected(); ok {
+						return m, m.pushView(stateDetail, containerinfra.NewClusterDetailModel(m.coeClient, c.UUID))
 					}
 				}
 			}
@@ -596,31 +1058,52 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	}
 	// Handle custom messages
 	switch msg := msg.(type) {
+	case network.OpenNetworkDHCPMsg:
+		return m, m.pushView(stateDetail, network.NewNetworkDHCPModel(m.networkClient, msg.NetworkID))
+	case network.OpenRouterL3AgentsMsg:
+		return m, m.pushView(stateDetail, network.NewRouterL3AgentsModel(m.networkClient, msg.RouterID, msg.Name))
+	case problems.OpenMsg:
+		if dm := m.detailModelFor(search.SearchResult{Category: msg.Category, ID: msg.ID, Name: msg.Name}); dm != nil {
+			return m, m.pushView(stateDetail, dm)
+		}
+		return m, nil
+	case common.OpenResourceMsg:
+		// Links between detail views stack, so esc walks back along them.
+		if dm := m.detailModelFor(search.SearchResult{Category: msg.Kind, ID: msg.ID, Name: msg.Name}); dm != nil {
+			return m, m.pushView(stateDetail, dm)
+		}
+		return m, nil
+	case compute.OpenCreateServerMsg:
+		return m, m.pushView(stateDetail, quota.NewCreateModel(compute.CreateServerRequest(m.computeClient, m.imageClient, m.networkClient), m.quotaSource()))
+	case storage.OpenCreateVolumeMsg:
+		return m, m.pushView(stateDetail, quota.NewCreateModel(storage.CreateVolumeRequest(m.storageClient), m.quotaSource()))
+	case network.OpenAllocateFloatingIPMsg:
+		return m, m.pushView(stateDetail, quota.NewCreateModel(network.AllocateFloatingIPRequest(m.networkClient), m.quotaSource()))
+	case compute.OpenBulkEditMsg:
+		return m, m.pushView(stateDetail, compute.NewBulkEditModel(m.computeClient, msg.Servers, msg.Filter))
+	case compute.OpenDrainHostMsg:
+		return m, m.pushView(stateDetail, compute.NewDrainHostModel(m.computeClient, msg.Host))
+	case compute.OpenVolumeMsg:
+		return m, m.pushView(stateDetail, storage.NewVolumeDetailModel(m.storageClient, m.computeClient, msg.VolumeID))
+	case dns.OpenZoneTransfersMsg:
+		return m, m.pushView(stateDetail, dns.NewZoneTransfersModel(m.dnsClient, msg.ZoneID, msg.ZoneName))
+	case compute.OpenImageMsg:
+		return m, m.pushView(stateDetail, image.NewImageDetailModel(m.imageClient, m.computeClient, msg.ImageID))
+	case compute.OpenServerDNSMsg:
+		return m, m.pushView(stateDetail, dns.NewServerRecordsModel(m.dnsClient, m.networkClient, msg.ServerID, msg.Name))
 	case compute.OpenLogsMsg:
-		m.logsModel = compute.NewLogsModel(m.computeClient, msg.ServerID)
-		m.state = stateLogs
-		return m, m.logsModel.Init()
+		return m, m.pushView(stateLogs, compute.NewLogsModel(m.computeClient, msg.ServerID))
 	case compute.GoBackMsg:
-		if m.state == stateLogs {
-			m.state = stateDetail
-			m.logsModel = nil
+		if m.state == stateLogs || m.state == stateGraph {
+			m.popView()
 			return m, nil
 		} else if m.state == stateDetail && m.detailModel != nil {
 			var cmd tea.Cmd
 			m.detailModel, cmd = m.detailModel.Update(msg)
 			return m, cmd
-		} else if m.state == stateGraph {
-			m.state = stateDetail
-			m.graphModel = nil
-			return m, nil
 		}
-	case topology.CloseMsg:
-		m.state = stateSidebar
-		m.topologyModel = nil
-		return m, nil
-	case shell.CloseMsg:
-		m.state = stateSidebar
-		m.shellModel = nil
+	case topology.CloseMsg, shell.CloseMsg:
+		m.popView()
 		return m, nil
 	}
 	// Command mode handling
@@ -632,76 +1115,102 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				switch msg.String() {
 				case "esc":
 					// exit command mode
+					m.commandErr = ""
+					m.closeCommandBar()
 					m.state = m.prevState
 					m.prevState = ""
-					m.commandBar.Blur()
-					m.commandBar.SetValue("")
-					// reset tab autocomplete state
-					m.tabMatches = nil
-					m.tabIndex = 0
 					return m, nil
 				case "enter":
 					cmd := strings.TrimSpace(m.commandBar.Value())
+					m.commandErr = ""
+					// Scheduled actions: "at HH:MM <action>", "every <interval> <action>".
+					if strings.HasPrefix(cmd, "at ") || strings.HasPrefix(cmd, "every ") {
+						job, err := jobs.Parse(cmd, time.Now(), m.schedulableSections())
+						if err != nil {
+							m.commandErr = err.Error()
+							return m, nil
+						}
+						_, tick := m.jobs.Add(job, time.Now())
+						m.closeCommandBar()
+						m.state = m.prevState
+						m.prevState = ""
+						return m, tick
+					}
 					// Shell passthrough command mode: prefix '!'
 					if strings.HasPrefix(cmd, "!") {
 						command := strings.TrimPrefix(cmd, "!")
 						sm := shell.NewShellModel(m.cloudName, command)
-						m.shellModel = &sm
-						m.state = stateShell
-						m.commandBar.SetValue("")
-						m.commandBar.Blur()
-						// reset tab autocomplete state
-						m.tabMatches = nil
-						m.tabIndex = 0
-						return m, m.shellModel.Init()
+						m.closeCommandBar()
+						return m, m.pushView(stateShell, &sm)
 					}
-					if cmd == "topology" || cmd == "topo" {
-						// Open topology view using navigateTo
-						m.navigateTo("Topology")
-						m.commandBar.SetValue("")
-						m.commandBar.Blur()
-						// reset tab autocomplete state
-						m.tabMatches = nil
-						m.tabIndex = 0
-						if m.topologyModel != nil {
-							return m, m.topologyModel.Init()
+					// Topology diff: "diff <cloud>[/<project>]" or "diff /<project>".
+					if cmd == "diff" || strings.HasPrefix(cmd, "diff ") {
+						target := strings.TrimSpace(strings.TrimPrefix(cmd, "diff"))
+						m.closeCommandBar()
+						if target == "" {
+							m.state = m.prevState
+							m.prevState = ""
+							return m, nil
 						}
-						return m, nil
+						if strings.HasPrefix(target, "/") {
+							target = m.cloudName + target
+						}
+						current := topology.ClientSet{Compute: m.computeClient, Network: m.networkClient, Storage: m.storageClient}
+						dm := topology.NewDiffModel(current, m.cloudName, target, loadDiffContext)
+						diff, _ := dm.Update(tea.WindowSizeMsg{Width: m.width, Height: m.height})
+						return m, m.pushView(stateDiff, diff)
 					}
-					if cmd == "__search__" {
-						sm := search.NewSearchModel(m.computeClient, m.networkClient, m.storageClient, m.imageClient, m.width, m.height)
-						m.searchModel = &sm
-						m.state = stateSearch
-						m.commandBar.SetValue("")
-						m.commandBar.Blur()
-						// reset tab autocomplete state
-						m.tabMatches = nil
-						m.tabIndex = 0
-						return m, sm.Init()
+					// IP lookup: "ip <address>".
+					if cmd == "ip" || strings.HasPrefix(cmd, "ip ") {
+						addr := strings.TrimSpace(strings.TrimPrefix(cmd, "ip"))
+						sm := search.NewIPSearchModel(m.computeClient, m.networkClient, m.lbClient, addr, m.width, m.height)
+						m.closeCommandBar()
+						return m, m.pushView(stateSearch, &sm)
 					}
-					if section, ok := m.commandMap[cmd]; ok {
-						if section == "__quit__" {
-							return m, tea.Quit
+					// Batch create: "import <file>" with a CSV or YAML file.
+					if strings.HasPrefix(cmd, "import ") {
+						path := strings.TrimSpace(strings.TrimPrefix(cmd, "import"))
+						cs := importer.ClientSet{Compute: m.computeClient, Network: m.networkClient, Storage: m.storageClient,
+							Image: m.imageClient, Limits: m.limitsClient, Identity: m.identityClient}
+						m.closeCommandBar()
+						return m, m.pushView(stateDetail, importer.NewImportModel(cs, path))
+					}
+					// Macros: "macro" lists them, "macro record <name>" records one on
+					// the selected server or volume and "macro <name>" replays it.
+					if cmd == "macro" || strings.HasPrefix(cmd, "macro ") {
+						path, err := config.MacrosPath(os.Getenv("OSTUI_MACROS_FILE"))
+						if err != nil {
+							m.commandErr = err.Error()
+							return m, nil
 						}
-						m.navigateTo(section)
-						if section == "Topology" {
-							m.commandBar.SetValue("")
-							m.commandBar.Blur()
-							// reset tab autocomplete state
-							m.tabMatches = nil
-							m.tabIndex = 0
-							if m.topologyModel != nil {
-								return m, m.topologyModel.Init()
-							}
+						arg := strings.TrimSpace(strings.TrimPrefix(cmd, "macro"))
+						if arg == "" {
+							m.closeCommandBar()
+							return m, m.pushView(stateDetail, macro.NewMacrosModel(path))
+						}
+						target, ok := m.macroTarget()
+						if !ok {
+							m.commandErr = i18n.T("select a server or volume first")
 							return m, nil
 						}
-						m.state = stateMain
-						m.commandBar.SetValue("")
-						m.commandBar.Blur()
-						// reset tab autocomplete state
-						m.tabMatches = nil
-						m.tabIndex = 0
-						return m, m.mainModel.Init()
+						cs := macro.Clients{Compute: m.computeClient, Storage: m.storageClient}
+						m.closeCommandBar()
+						if name, ok := strings.CutPrefix(arg, "record "); ok {
+							return m, m.pushView(stateDetail, macro.NewRecordModel(cs, path, strings.TrimSpace(name), target))
+						}
+						return m, m.pushView(stateDetail, macro.NewReplayModel(cs, path, arg, target))
+					}
+					if section, ok := m.commandMap[cmd]; ok {
+						switch section {
+						case "__quit__":
+							return m, tea.Quit
+						case "__search__":
+							sm := search.NewSearchModel(m.computeClient, m.networkClient, m.storageClient, m.imageClient, m.width, m.height)
+							m.closeCommandBar()
+							return m, m.pushView(stateSearch, &sm)
+						}
+						m.closeCommandBar()
+						return m, m.navigateTo(section)
 					}
 
 					// unknown command: clear input
@@ -769,6 +1278,11 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.detailModel, cmd = m.detailModel.Update(msg)
 		return m, cmd
 	}
+	if m.state == stateEditor && m.editorModel != nil {
+		var cmd tea.Cmd
+		m.editorModel, cmd = m.editorModel.Update(msg)
+		return m, cmd
+	}
 	if m.state == stateGraph && m.graphModel != nil {
 		var cmd tea.Cmd
 		m.graphModel, cmd = m.graphModel.Update(msg)
@@ -783,6 +1297,11 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, cmd
 	}
+	if m.state == stateDiff && m.diffModel != nil {
+		var cmd tea.Cmd
+		m.diffModel, cmd = m.diffModel.Update(msg)
+		return m, cmd
+	}
 	if m.state == stateShell && m.shellModel != nil {
 		var cmd tea.Cmd
 		var newModel tea.Model
@@ -867,7 +1386,28 @@ func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 // View implements tea.Model.
 func (m AppModel) View() string {
-	footer := fmt.Sprintf("\n[%s] Press : for command mode  [T] topology  [/]", m.state) + " search"
+	if m.idle != nil {
+		return m.idleView()
+	}
+	footer := "\n" + i18n.T("[%s] Press : for command mode  [T] topology  [/] search", m.state)
+	if badge := m.cloudBadge(); badge != "" {
+		footer = "\n" + badge + " " + footer[1:]
+	}
+	if token := m.tokenLabel(time.Now()); token != "" {
+		footer += "  " + token
+	}
+	if client.InsecureTLS {
+		footer += "  " + insecureBanner()
+	}
+	if label := m.jobs.FooterLabel(); label != "" {
+		footer += "  " + lipgloss.NewStyle().Foreground(theme.Muted).Render(label)
+	}
+	if label := m.schedules.FooterLabel(); label != "" {
+		footer += "  " + lipgloss.NewStyle().Foreground(theme.Muted).Render(label)
+	}
+	if label := m.operations.FooterLabel(); label != "" {
+		footer += "  " + lipgloss.NewStyle().Foreground(theme.Muted).Render(label)
+	}
 	switch m.state {
 	case stateSidebar:
 		sidebarWidth := 36
@@ -881,29 +1421,31 @@ func (m AppModel) View() string {
 			Height(m.height - 4).
 			BorderRight(true).
 			BorderStyle(lipgloss.NormalBorder()).
-			BorderForeground(lipgloss.Color("240"))
+			BorderForeground(borderColor())
 		rightStyle := lipgloss.NewStyle().
 			Width(rightWidth).
 			Height(m.height - 4).
 			PaddingLeft(2).
 			PaddingTop(1)
 		help := lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render
-		accent := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("205")).Render
-		rightContent := accent("Cloud: ") + m.cloudName + "\n\n" +
-			accent("Navigation") + "\n" +
-			help("  ↑/k  up          ↓/j  down") + "\n" +
-			help("  enter  open      esc  back") + "\n\n" +
-			accent("Global keys") + "\n" +
-			help("  ?   help         c   switch cloud") + "\n" +
-			help("  T   topology     :   command mode") + "\n" +
-			help("  g   graph        y   JSON view") + "\n" +
-			help("  i   inspect      l   logs (servers)") + "\n\n" +
-			accent("Commands") + "\n" +
+		accent := lipgloss.NewStyle().Bold(true).Foreground(theme.Accent).Render
+		rightContent := accent(i18n.T("Cloud: ")) + m.cloudName + "\n" +
+			help(apiStatsLine(client.Requests.Stats())) + "\n" +
+			help(terraformStateLine()) + "\n" +
+			accent(i18n.T("Navigation")) + "\n" +
+			help(i18n.T("  ↑/k  up          ↓/j  down")) + "\n" +
+			help(i18n.T("  enter  open      esc  back")) + "\n\n" +
+			accent(i18n.T("Global keys")) + "\n" +
+			help(i18n.T("  ?   help         c   switch cloud")) + "\n" +
+			help(i18n.T("  T   topology     :   command mode")) + "\n" +
+			help(i18n.T("  g   graph        y   JSON view")) + "\n" +
+			help(i18n.T("  i   inspect      l   logs (servers)")) + "\n\n" +
+			accent(i18n.T("Commands")) + "\n" +
 			help("  :servers  :networks  :volumes") + "\n" +
 			help("  :images   :limits    :dns") + "\n" +
 			help("  :routers  :ports     :fip") + "\n" +
 			help("  :topology / :topo") + "\n" +
-			help("  :!<cmd>  → openstack CLI") + "\n\n" +
+			help(i18n.T("  :!<cmd>  → openstack CLI")) + "\n\n" +
 			lipgloss.NewStyle().Foreground(lipgloss.Color("238")).Render("ostui v0.1.0")
 		layout := lipgloss.JoinHorizontal(lipgloss.Top,
 			sideStyle.Render(m.sidebar.View()),
@@ -912,14 +1454,23 @@ func (m AppModel) View() string {
 		return layout + "\n" + footer
 	case stateMain:
 		if m.mainModel != nil {
-			return m.mainModel.View() + footer
+			return m.mainModel.View() + m.paletteView() + m.jumpView() + m.guardView() + footer
 		}
-		return fmt.Sprintf("\n%s view – press esc to return\n", m.selectedItem.title) + footer
+		return "\n" + i18n.T("%s view – press esc to return", i18n.T(m.selectedItem.title)) + "\n" + footer
 	case stateModal:
-		return "\n[Modal] Press esc to close\n" + footer
+		return "\n" + i18n.T("[Modal] Press esc to close") + "\n" + footer
 	case stateDetail:
 		if m.detailModel != nil {
-			return m.detailModel.View() + footer
+			view := m.detailModel.View() + m.terraformNote() + m.paletteView() + m.jumpView() + m.guardView()
+			if m.editStatus != "" {
+				return view + "\n" + m.editStatus + footer
+			}
+			return view + footer
+		}
+		return "" + footer
+	case stateEditor:
+		if m.editorModel != nil {
+			return m.editorModel.View() + footer
 		}
 		return "" + footer
 	case stateLogs:
@@ -939,6 +1490,11 @@ func (m AppModel) View() string {
 			return m.topologyModel.View() + footer
 		}
 		return "" + footer
+	case stateDiff:
+		if m.diffModel != nil {
+			return m.diffModel.View() + footer
+		}
+		return "" + footer
 	case stateShell:
 		if m.shellModel != nil {
 			return m.shellModel.View() + footer
@@ -959,7 +1515,7 @@ func (m AppModel) View() string {
 			if m.mainModel != nil {
 				base = m.mainModel.View()
 			} else {
-				base = fmt.Sprintf("\n%s view – press esc to return\n", m.selectedItem.title)
+				base = "\n" + i18n.T("%s view – press esc to return", i18n.T(m.selectedItem.title)) + "\n"
 			}
 		case stateDetail:
 			if m.detailModel != nil {
@@ -980,10 +1536,13 @@ func (m AppModel) View() string {
 		}
 		// Command bar view
 		view := base + "\n" + m.commandBar.View()
+		if m.commandErr != "" {
+			view += "\n" + lipgloss.NewStyle().Foreground(theme.Error).Render(m.commandErr)
+		}
 		// Show suggestions if multiple matches are available.
 		if len(m.tabMatches) > 1 {
 			suggestions := strings.Join(m.tabMatches, "  ")
-			view += "\n" + lipgloss.NewStyle().Foreground(lipgloss.Color("#666666")).Render(suggestions)
+			view += "\n" + lipgloss.NewStyle().Foreground(theme.Muted).Render(suggestions)
 		}
 		return view + footer
 	default:
@@ -992,58 +1551,377 @@ func (m AppModel) View() string {
 }
 
 // Ensure AppModel implements tea.Model.
+// insecureBanner warns that TLS certificates are not verified (--insecure
+// or "verify: false" in clouds.yaml).
+func insecureBanner() string {
+	return lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#FFFFFF")).Background(theme.Error).
+		Render(i18n.T(" ⚠ TLS VERIFICATION DISABLED "))
+}
+
+// terraformStateLine describes the loaded Terraform state for the sidebar.
+func terraformStateLine() string {
+	if tfstate.Active == nil {
+		return ""
+	}
+	return i18n.T("Terraform: %d resources from %s", tfstate.Active.Len(), strings.Join(tfstate.Active.Paths, ", "))
+}
+
+// terraformNote annotates the open detail view with the Terraform address
+// managing the resource, when a Terraform state is loaded.
+func (m AppModel) terraformNote() string {
+	rm, ok := m.detailModel.(interface{ ResourceID() string })
+	if !ok || tfstate.Active == nil {
+		return ""
+	}
+	addrs := tfstate.Active.Addresses(rm.ResourceID())
+	if len(addrs) == 0 {
+		warn := lipgloss.NewStyle().Foreground(theme.Warn).Render
+		return "\n" + warn(i18n.T("Terraform: %s (not in the configured state)", common.TerraformUnmanaged))
+	}
+	dim := lipgloss.NewStyle().Foreground(theme.Muted).Render
+	return "\n" + dim("Terraform: "+strings.Join(addrs, ", "))
+}
+
 func (m AppModel) helpView() string {
 	var b strings.Builder
 	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#AAAAAA"))
-	keyStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#5CB85C"))
+	keyStyle := lipgloss.NewStyle().Foreground(theme.OK)
 	descStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#CCCCCC"))
 
 	key := func(k, desc string) string {
-		return keyStyle.Render(fmt.Sprintf("  %-12s", k)) + descStyle.Render(desc) + "\n"
+		return keyStyle.Render(fmt.Sprintf("  %-12s", k)) + descStyle.Render(i18n.T(desc)) + "\n"
+	}
+	section := func(title string) string {
+		return titleStyle.Render("\n  "+i18n.T(title)) + "\n"
 	}
 
-	b.WriteString(titleStyle.Render("\n  Global") + "\n")
+	b.WriteString(section("Global"))
 	b.WriteString(key("q / ctrl+c", "Quit"))
 	b.WriteString(key("?", "Toggle help"))
 	b.WriteString(key("c", "Switch cloud"))
 	b.WriteString(key(":", "Command mode"))
+	b.WriteString(key(".", "Actions of the selected row or resource (type to filter)"))
 	b.WriteString(key("/", "Global search (from sidebar)"))
+	b.WriteString(key("!", "Problems: everything unhealthy in the project"))
 
 	switch m.prevState {
 	case stateMain:
-		b.WriteString(titleStyle.Render("\n  List view") + "\n")
+		b.WriteString(section("List view"))
 		b.WriteString(key("j / k", "Move down / up"))
 		b.WriteString(key("enter", "Open detail"))
 		b.WriteString(key("/", "Filter"))
+		switch m.mainModel.(type) {
+		case compute.InstancesModel, storage.VolumesModel, network.FloatingIPsModel, network.RouterModel:
+			b.WriteString(key("/age>90d", "Filter by age: > or <, in m, h, d, w or y"))
+		}
+		b.WriteString(key("f", "Jump to a row by name (tab: next match)"))
 		b.WriteString(key("esc", "Back to sidebar"))
 		b.WriteString(key("r", "Refresh"))
 		// Extra keys for Servers
 		if _, ok := m.mainModel.(compute.InstancesModel); ok {
-			b.WriteString(titleStyle.Render("\n  Servers (detail)\n") + "\n")
+			b.WriteString(section("Servers (detail)") + "\n")
 			b.WriteString(key("l", "View logs"))
 			b.WriteString(key("i", "Inspect"))
 			b.WriteString(key("y", "JSON view"))
 			b.WriteString(key("v", "Console URL"))
+			b.WriteString(key("Q", "Console URL as QR code (in console view)"))
+			b.WriteString(key("a", "Last instance action (ERROR)"))
+			b.WriteString(key("H / D", "Hard reboot / delete after a pre-flight (ERROR)"))
+			b.WriteString(key("R", "Rebuild from the image, reviewing the user data and its diff"))
+			b.WriteString(key("L", "Lifecycle: start, stop, pause, suspend, shelve, lock (valid ones only)"))
+			b.WriteString(key("W", "Decrypt the admin password posted by the guest"))
+			b.WriteString(key("C", "Change the admin password"))
+			b.WriteString(section("Servers (list)"))
+			b.WriteString(key("n", "New server, checked against the instance, vCPU and RAM quotas"))
+			b.WriteString(key("G", "Group by status / AZ / flavor / metadata key"))
+			b.WriteString(key("enter", "Collapse / expand group (on a header)"))
+			b.WriteString(key("B", "Bulk rename / set metadata on the servers matching the filter"))
+			b.WriteString(key("A / V", "Confirm / revert the resize of a server in VERIFY_RESIZE"))
+		}
+		if _, ok := m.mainModel.(identity.ProjectsModel); ok {
+			b.WriteString(section("Projects"))
+			b.WriteString(key("D", "Next domain (multi-domain clouds)"))
+		}
+		if _, ok := m.mainModel.(identity.UsersModel); ok {
+			b.WriteString(section("Users"))
+			b.WriteString(key("D", "Next domain (multi-domain clouds)"))
+		}
+		if _, ok := m.mainModel.(compute.HypervisorsModel); ok {
+			b.WriteString(section("Hypervisors"))
+			b.WriteString(key("s", "Toggle most loaded first"))
+			b.WriteString(key("D", "Drain host: disable nova-compute and live-migrate its servers"))
+		}
+		if _, ok := m.mainModel.(image.ImagesModel); ok {
+			b.WriteString(section("Images"))
+			b.WriteString(key("v", "Cycle the visibility filter: public / private / community / shared"))
+		}
+		if _, ok := m.mainModel.(compute.AZReportModel); ok {
+			b.WriteString(section("AZ consistency"))
+			b.WriteString(key("a", "Toggle all attachments / mismatches only"))
+			b.WriteString(key("enter", "Open server detail"))
+			b.WriteString(key("v", "Open volume detail"))
+		}
+		if _, ok := m.mainModel.(compute.LimitsModel); ok {
+			b.WriteString(section("Limits"))
+			b.WriteString(key("e", "Edit project quotas (admin)"))
+			b.WriteString(key("u", "Usage by user: servers, vCPUs, RAM and volumes"))
+			b.WriteString(key("r", "Refresh"))
+		}
+		if _, ok := m.mainModel.(clouds.CloudsModel); ok {
+			b.WriteString(section("Clouds"))
+			b.WriteString(key("t / a", "Test the selected / all clouds without switching"))
+			b.WriteString(key("n", "Add a cloud to clouds.yaml"))
+			b.WriteString(key("r", "Reload clouds.yaml"))
+		}
+		if _, ok := m.mainModel.(network.TapServicesModel); ok {
+			b.WriteString(section("Tap services"))
+			b.WriteString(key("enter", "Show the tap flows of the service"))
+			b.WriteString(key("n / x", "Create / delete a tap service"))
+		}
+		if _, ok := m.mainModel.(storage.VolumesModel); ok {
+			b.WriteString(section("Volumes"))
+			b.WriteString(key("n", "New volume, checked against the volume and gigabyte quotas"))
+		}
+		if _, ok := m.mainModel.(network.FloatingIPsModel); ok {
+			b.WriteString(section("Floating IPs"))
+			b.WriteString(key("n", "Allocate a floating IP, checked against its quota"))
+		}
+		if _, ok := m.mainModel.(network.NetworksModel); ok {
+			b.WriteString(section("Networks"))
+			b.WriteString(key("n", "New network, optionally pinned to availability zones"))
+		}
+		if _, ok := m.mainModel.(network.SubnetsModel); ok {
+			b.WriteString(section("Subnets"))
+			b.WriteString(key("n", "New subnet, with a CIDR or allocated from a subnet pool"))
+		}
+		if _, ok := m.mainModel.(network.RouterModel); ok {
+			b.WriteString(section("Routers"))
+			b.WriteString(key("L", "L3 agents hosting the router, with HA state (admin)"))
+			b.WriteString(key("n", "New router: external network, SNAT, AZ hints (HA/distributed for admins)"))
+			b.WriteString(key("d", "Delete the router after a dependency preview"))
+		}
+		if _, ok := m.mainModel.(network.SubnetPoolsModel); ok {
+			b.WriteString(section("Subnet pools"))
+			b.WriteString(key("tab", "Subnet pools / address scopes"))
+			b.WriteString(key("enter", "Pool detail: prefixes, prefix lengths, allocated subnets"))
+		}
+		if _, ok := m.mainModel.(network.VPNModel); ok {
+			b.WriteString(section("VPN"))
+			b.WriteString(key("tab", "Connections / services / IKE / IPsec policies"))
+			b.WriteString(key("enter", "Connection detail: peer CIDRs, DPD, policies"))
+		}
+		if _, ok := m.mainModel.(network.FirewallModel); ok {
+			b.WriteString(section("Firewalls"))
+			b.WriteString(key("tab", "Groups / policies / rules"))
+			b.WriteString(key("n", "New rule, appended to a policy"))
+			b.WriteString(key("x", "Delete rule (removed from its policies first)"))
+		}
+		if _, ok := m.mainModel.(identity.TrustsModel); ok {
+			b.WriteString(section("Trusts"))
+			b.WriteString(key("n", "Delegate roles on a project to another user"))
+			b.WriteString(key("x", "Delete a trust you granted"))
+		}
+		if _, ok := m.mainModel.(identity.EC2CredentialsModel); ok {
+			b.WriteString(section("EC2 credentials"))
+			b.WriteString(key("v", "Reveal / hide the secret of the selected credential"))
+			b.WriteString(key("n / x", "Create / delete a credential"))
+		}
+		if _, ok := m.mainModel.(identity.TokenModel); ok {
+			b.WriteString(section("Token"))
+			b.WriteString(key("v", "Reveal / hide the token ID"))
+		}
+		if _, ok := m.mainModel.(keymanager.SecretsModel); ok {
+			b.WriteString(section("Secrets"))
+			b.WriteString(key("tab", "Secrets / containers"))
+			b.WriteString(key("v", "Reveal the payload of a secret (asks first, then the soft-lock)"))
+			b.WriteString(key("n / x", "Store / delete a secret"))
+		}
+		if _, ok := m.mainModel.(dns.ZonesModel); ok {
+			b.WriteString(section("Zones"))
+			b.WriteString(key("enter", "Record sets of the zone"))
+			b.WriteString(key("t", "Transfer the zone to another project"))
+		}
+		if _, ok := m.mainModel.(dns.ZoneTransfersModel); ok {
+			b.WriteString(section("Zone Transfers"))
+			b.WriteString(key("a", "Accept a transfer with its ID and key"))
+			b.WriteString(key("x", "Cancel an outgoing transfer request"))
+		}
+		if _, ok := m.mainModel.(storage.SharesModel); ok {
+			b.WriteString(section("Shares"))
+			b.WriteString(key("enter", "Export locations and access rules"))
+			b.WriteString(key("n / x", "Create / delete a share"))
+		}
+		if _, ok := m.mainModel.(problems.ProblemsModel); ok {
+			b.WriteString(section("Problems"))
+			b.WriteString(key("enter", "Open the resource, or the hypervisor of a down agent"))
+			b.WriteString(key("r", "Check again"))
+		}
+		if _, ok := m.mainModel.(events.EventsModel); ok {
+			b.WriteString(section("Events"))
+			b.WriteString(key("/", "Filter by type, publisher, priority, resource or project"))
+			b.WriteString(key("p", "Pause / resume the feed"))
+			b.WriteString(key("enter", "Show / hide the payload of the selected event"))
+			b.WriteString(key("x", "Clear the received events"))
+		}
+		if _, ok := m.mainModel.(jobs.JobsModel); ok {
+			b.WriteString(section("Jobs"))
+			b.WriteString(key("tab", "Switch between the operations and the scheduled jobs"))
+			b.WriteString(key("enter", "Show / hide the log of the selected operation"))
+			b.WriteString(key("R", "Retry the selected failed operation"))
+			b.WriteString(key("x", "Cancel the selected pending job"))
+		}
+		if _, ok := m.mainModel.(jobs.SchedulesModel); ok {
+			b.WriteString(section("Schedules"))
+			b.WriteString(key("n", "New schedule: stop and/or start a server at fixed times"))
+			b.WriteString(key("e / space", "Enable / disable the selected schedule"))
+			b.WriteString(key("x", "Delete the selected schedule"))
+		}
+		if _, ok := m.mainModel.(containerinfra.ClustersModel); ok {
+			b.WriteString(section("Clusters"))
+			b.WriteString(key("enter", "Cluster detail with its template"))
+			b.WriteString(key("s", "Scale the worker node count"))
+			b.WriteString(key("K", "Write a kubeconfig (default ~/.kube/<cluster>.config)"))
 		}
 	case stateDetail:
-		b.WriteString(titleStyle.Render("\n  Detail view") + "\n")
+		b.WriteString(section("Detail view"))
 		b.WriteString(key("j / k", "Scroll"))
 		b.WriteString(key("i", "Inspect"))
 		b.WriteString(key("y", "JSON view"))
+		b.WriteString(key("r", "Refresh"))
 		b.WriteString(key("esc", "Back to list"))
+		if _, ok := m.detailModel.(image.ImageDetailModel); ok {
+			b.WriteString(key("D", "Download image and verify checksum"))
+		}
+		if _, ok := m.detailModel.(compute.InstanceDetailModel); ok {
+			b.WriteString(key("d", "Diagnostics: CPU, memory, NIC and disk counters (r refreshes)"))
+			b.WriteString(key("F", "Resize: pick a new flavor"))
+			b.WriteString(key("I", "Create an image of the server; o opens it"))
+			b.WriteString(key("N", "DNS records resolving to the server; create or repoint one"))
+			b.WriteString(key("S", "Interactive serial console (ctrl+] closes it)"))
+			b.WriteString(key("U", "Unrescue a server in rescue mode"))
+			b.WriteString(key("A / V", "Confirm / revert the resize of a server in VERIFY_RESIZE"))
+			b.WriteString(key("X", "Evacuate from a failed host (admin)"))
+			b.WriteString(key("P", "Rebuild preserving ephemeral disk (admin)"))
+		}
+		if _, ok := m.detailModel.(storage.VolumeDetailModel); ok {
+			b.WriteString(key("d / D", "Delete / force delete the volume (force: admin, any status)"))
+			b.WriteString(key("a", "Attach the volume to a server"))
+			b.WriteString(key("s", "Snapshot the volume (forced when attached)"))
+			b.WriteString(key("enter", "On Attached: open the server (pick one when several)"))
+		}
+		if _, ok := m.detailModel.(network.PortDetailModel); ok {
+			b.WriteString(key("enter", "On NetworkID / DeviceID: open the network / server or router"))
+		}
+		if _, ok := m.detailModel.(network.FloatingIPDetailModel); ok {
+			b.WriteString(key("enter", "On PortID / FloatingNetworkID: open the port / network"))
+		}
+		if _, ok := m.detailModel.(network.NetworkSubnetsModel); ok {
+			b.WriteString(key("tab", "Subnets / details: MTU, provider segmentation, RBAC grants"))
+			b.WriteString(key("a", "Toggle the admin state of the network (details)"))
+			b.WriteString(key("d", "DHCP agents serving the network and its address leases"))
+		}
+		if _, ok := m.detailModel.(network.NetworkDHCPModel); ok {
+			b.WriteString(key("tab", "DHCP agents / leases"))
+		}
+		if _, ok := m.detailModel.(network.RouterDetailModel); ok {
+			b.WriteString(key("L", "L3 agents hosting the router, with HA state (admin)"))
+		}
+		if _, ok := m.detailModel.(network.RouterL3AgentsModel); ok {
+			b.WriteString(key("s", "Schedule / unschedule the router on the selected L3 agent"))
+			b.WriteString(key("m", "Move the router from the selected agent to another one"))
+		}
+		if _, ok := m.detailModel.(network.BGPSpeakerDetailModel); ok {
+			b.WriteString(key("tab", "Advertised routes / peers / DR agents"))
+			b.WriteString(key("s", "Schedule / unschedule the speaker on the selected DR agent"))
+		}
+		if _, ok := m.detailModel.(storage.ShareDetailModel); ok {
+			b.WriteString(key("a / x", "Grant / revoke access (ip, cephx, user, cert)"))
+		}
+		if _, ok := m.detailModel.(compute.BulkEditModel); ok {
+			b.WriteString(key("y", "Apply the previewed changes, one server at a time"))
+			b.WriteString(key("e", "Edit the name pattern and metadata"))
+		}
+		if _, ok := m.detailModel.(importer.ImportModel); ok {
+			b.WriteString(key("y", "Create the planned resources one at a time; after a run, retry the failed ones"))
+			b.WriteString(key("r", "Reload the file (until something was created)"))
+		}
+		if mm, ok := m.detailModel.(macro.MacroModel); ok {
+			if mm.Recording() {
+				b.WriteString(key("enter", "Run the highlighted action and add it to the macro"))
+				b.WriteString(key("u", "Drop the last recorded step"))
+				b.WriteString(key("s", "Save the macro"))
+			} else {
+				b.WriteString(key("y", "Run the steps; the replay stops at the first failure"))
+			}
+		}
+		if _, ok := m.detailModel.(macro.MacrosModel); ok {
+			b.WriteString(key("x", "Delete the selected macro"))
+		}
+		if _, ok := m.detailModel.(compute.DrainHostModel); ok {
+			b.WriteString(key("d", "Disable nova-compute with a reason"))
+			b.WriteString(key("m / p", "Live-migrate the servers away one by one / pause"))
+			b.WriteString(key("e", "Re-enable nova-compute"))
+		}
+		if dm, ok := m.detailModel.(dns.ZoneTransfersModel); ok {
+			if dm.OffersZone() {
+				b.WriteString(key("n", "Offer the zone again, e.g. to another project"))
+			}
+			b.WriteString(key("a", "Accept a transfer with its ID and key"))
+			b.WriteString(key("x", "Cancel an outgoing transfer request"))
+		}
+		if _, ok := m.detailModel.(dns.ServerRecordsModel); ok {
+			b.WriteString(key("n", "Point an A/AAAA record at an address of the server"))
+			b.WriteString(key("r", "Refresh"))
+		}
+		if _, ok := m.detailModel.(dns.RecordSetsModel); ok {
+			b.WriteString(key("/", "Search record sets by name and type on the server"))
+			b.WriteString(key("[ / ]", "Previous / next page of record sets"))
+		}
+		if _, ok := m.detailModel.(network.TapFlowsModel); ok {
+			b.WriteString(key("n / x", "Create / delete a tap flow"))
+		}
+		if _, ok := m.detailModel.(editor.Editable); ok {
+			b.WriteString(key("E", "Edit mutable fields as YAML"))
+		}
+		if _, ok := m.detailModel.(iac.Exportable); ok {
+			b.WriteString(key("ctrl+x", "Export as Terraform / CLI commands"))
+		}
+		if _, ok := m.detailModel.(iac.Model); ok {
+			b.WriteString(key("tab", "Terraform / CLI commands"))
+			b.WriteString(key("w", "Write to the current directory"))
+		}
 	case stateLogs:
-		b.WriteString(titleStyle.Render("\n  Log viewer") + "\n")
+		b.WriteString(section("Log viewer"))
 		b.WriteString(key("j / k", "Scroll"))
 		b.WriteString(key("g / G", "Top / bottom"))
 		b.WriteString(key("p", "Pause / resume streaming"))
 		b.WriteString(key("+  /  -", "Increase / decrease interval"))
 		b.WriteString(key("esc", "Back"))
+	case stateTopology:
+		b.WriteString(section("Topology"))
+		b.WriteString(key("j / k", "Move"))
+		b.WriteString(key("enter", "Expand / collapse the network"))
+		b.WriteString(key("+ / -", "Expand / collapse all"))
+		b.WriteString(key("n / s / p / z", "Cycle the network / status / project / zone filter"))
+		b.WriteString(key("/", "Match server, network and volume names"))
+		b.WriteString(key("e", "Collapse networks without servers"))
+		b.WriteString(key("o", "Order servers by status or name"))
+		b.WriteString(key("x", "Clear filters"))
+		b.WriteString(key("a", "Auto-refresh, marking what appeared, disappeared or changed status"))
+		b.WriteString(key("r", "Refresh"))
+		b.WriteString(key("esc", "Back"))
+	case stateGraph:
+		b.WriteString(section("Relationship graph"))
+		b.WriteString(key("j / k", "Scroll"))
+		b.WriteString(key("a", "Auto-refresh, marking what appeared, disappeared or changed status"))
+		b.WriteString(key("r", "Refresh"))
+		b.WriteString(key("g / esc", "Back"))
 	case stateCommand:
-		b.WriteString(titleStyle.Render("\n  Command mode") + "\n")
+		b.WriteString(section("Command mode"))
 		b.WriteString(key("tab", "Autocomplete (cycle)"))
 		b.WriteString(key("enter", "Execute command"))
 		b.WriteString(key("esc", "Cancel"))
-		b.WriteString(titleStyle.Render("\n  Commands") + "\n")
+		b.WriteString(section("Commands"))
 		b.WriteString(key("servers / srv", "Servers"))
 		b.WriteString(key("networks / net", "Networks"))
 		b.WriteString(key("volumes / vol", "Volumes"))
@@ -1051,16 +1929,39 @@ func (m AppModel) helpView() string {
 		b.WriteString(key("limits / quota", "Limits"))
 		b.WriteString(key("dns / zones", "DNS Zones"))
 		b.WriteString(key("lb", "Load Balancers"))
+		b.WriteString(key("diff <ctx>", "Topology diff with <cloud>[/<project>]"))
+		b.WriteString(key("ip <addr>", "Find the owner of an IP address"))
+		b.WriteString(key("import <file>", "Create servers, volumes and floating IPs from a CSV or YAML file"))
+		b.WriteString(key("macro [record] <name>", "Replay or record a macro on the selected server or volume"))
+		b.WriteString(key("every <dur> <action>", "Repeat an action, e.g. every 5m refresh servers"))
+		b.WriteString(key("at HH:MM <action>", "Run once, e.g. at 22:00 stop server web-test"))
+		b.WriteString(key("jobs", "Scheduled jobs"))
+		b.WriteString(key("schedules / sched", "Server stop/start schedules"))
+		b.WriteString(key("events", "Live notification feed (--events-listen)"))
+		b.WriteString(key("problems", "Everything unhealthy in the project"))
 		b.WriteString(key("quit", "Exit"))
 	default:
-		b.WriteString(titleStyle.Render("\n  Sidebar") + "\n")
+		b.WriteString(section("Sidebar"))
 		b.WriteString(key("j / k", "Move down / up"))
 		b.WriteString(key("enter", "Open section"))
 	}
 
-	b.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("#666666")).Render("\n  [?] close help\n"))
+	b.WriteString(lipgloss.NewStyle().Foreground(theme.Muted).Render("\n  " + i18n.T("[?] close help") + "\n"))
 	return b.String()
 }
 
 // Ensure AppModel implements tea.Model.
 var _ tea.Model = (*AppModel)(nil)
+
+// apiStatsLine summarises the shared API request limiter for the overview.
+func apiStatsLine(s client.RequestStats) string {
+	limit := i18n.T("unlimited")
+	if s.Limit > 0 {
+		limit = i18n.T("max %d", s.Limit)
+	}
+	line := i18n.T("API: %d in flight, %d queued (%s), %d requests", s.InFlight, s.Queued, limit, s.Total)
+	if s.MaxWait > 0 {
+		line += i18n.T(", max wait %s", s.MaxWait.Round(time.Millisecond))
+	}
+	return line
+}
@@ -0,0 +1,364 @@
+// Package clouds implements the clouds.yaml management view: the defined
+// clouds, per-cloud connection tests and a form to add a cloud.
+package clouds
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"ostui/internal/client"
+	"ostui/internal/config"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/theme"
+	"ostui/internal/ui/uiconst"
+)
+
+// Column widths of the clouds table; the auth URL takes the rest.
+const (
+	nameColWidth   = 20
+	authColWidth   = 24
+	regionColWidth = 14
+	statusColWidth = 34
+)
+
+// testResult is the outcome of a connection test of one cloud.
+type testResult struct {
+	check client.AuthCheck
+	err   error
+}
+
+// CloudsModel lists the clouds of clouds.yaml and tests them without
+// switching the active session.
+type CloudsModel struct {
+	path    string
+	table   table.Model
+	loading bool
+	err     error
+	spinner spinner.Model
+	clouds  []config.CloudSummary
+	// results and testing are keyed by cloud name.
+	results map[string]testResult
+	testing map[string]bool
+	status  string
+
+	// New cloud form, opened with n.
+	form    bool
+	inputs  []textinput.Model
+	focus   int
+	formErr error
+
+	width  int
+	height int
+}
+
+// formFields are the labels of the new cloud form, in entry order.
+var formFields = []string{"Name", "Auth URL", "Auth type", "Username / app credential ID", "Password / secret", "Project", "User domain", "Project domain", "Region"}
+
+// NewCloudsModel creates the view for the clouds.yaml at path (see
+// config.CloudsPath).
+func NewCloudsModel(path string) CloudsModel {
+	s := spinner.New()
+	s.Spinner = spinner.Dot
+	t := table.New(table.WithFocused(true))
+	t.SetStyles(table.DefaultStyles())
+	return CloudsModel{path: path, table: t, loading: true, spinner: s, results: map[string]testResult{}, testing: map[string]bool{}, width: 120, height: 30}
+}
+
+type cloudsLoadedMsg struct {
+	clouds []config.CloudSummary
+	err    error
+}
+
+type testDoneMsg struct {
+	name   string
+	result testResult
+}
+
+type cloudAddedMsg struct {
+	name string
+	err  error
+}
+
+// Init loads the cloud list.
+func (m CloudsModel) Init() tea.Cmd {
+	return tea.Batch(m.spinner.Tick, m.loadCmd())
+}
+
+func (m CloudsModel) loadCmd() tea.Cmd {
+	path := m.path
+	return func() tea.Msg {
+		clouds, err := config.ListClouds(path)
+		return cloudsLoadedMsg{clouds: clouds, err: err}
+	}
+}
+
+// testCmd authenticates against one cloud on a throwaway provider.
+func (m CloudsModel) testCmd(name string) tea.Cmd {
+	path := m.path
+	return func() tea.Msg {
+		opts, err := config.LoadAuthOptions(name, path)
+		if err != nil {
+			return testDoneMsg{name: name, result: testResult{err: err}}
+		}
+		check, err := client.CheckAuth(opts)
+		return testDoneMsg{name: name, result: testResult{check: check, err: err}}
+	}
+}
+
+// Update handles messages for the model.
+func (m CloudsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case cloudsLoadedMsg:
+		m.loading = false
+		m.err = msg.err
+		m.clouds = msg.clouds
+		m.refreshRows()
+		return m, nil
+	case testDoneMsg:
+		delete(m.testing, msg.name)
+		m.results[msg.name] = msg.result
+		m.refreshRows()
+		return m, nil
+	case cloudAddedMsg:
+		if msg.err != nil {
+			m.formErr = msg.err
+			return m, nil
+		}
+		m.form = false
+		m.status = i18n.T("Added %s; testing the connection", msg.name)
+		m.testing[msg.name] = true
+		return m, tea.Batch(m.loadCmd(), m.testCmd(msg.name))
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		m.refreshRows()
+		return m, nil
+	case spinner.TickMsg:
+		if m.loading || len(m.testing) > 0 {
+			var cmd tea.Cmd
+			m.spinner, cmd = m.spinner.Update(msg)
+			m.refreshRows()
+			return m, cmd
+		}
+		return m, nil
+	case tea.KeyMsg:
+		if m.form {
+			return m.updateForm(msg)
+		}
+		if m.loading {
+			return m, nil
+		}
+		switch msg.String() {
+		case "n":
+			m.openForm()
+			return m, textinput.Blink
+		case "r":
+			m.loading = true
+			return m, tea.Batch(m.spinner.Tick, m.loadCmd())
+		case "t":
+			if row := m.table.SelectedRow(); len(row) > 0 {
+				return m, m.startTests(row[0])
+			}
+			return m, nil
+		case "a":
+			var names []string
+			for _, c := range m.clouds {
+				names = append(names, c.Name)
+			}
+			return m, m.startTests(names...)
+		}
+		var cmd tea.Cmd
+		m.table, cmd = m.table.Update(msg)
+		return m, cmd
+	}
+	return m, nil
+}
+
+// startTests marks the clouds as being tested and returns the test commands.
+func (m *CloudsModel) startTests(names ...string) tea.Cmd {
+	if len(names) == 0 {
+		return nil
+	}
+	spin := len(m.testing) == 0
+	cmds := make([]tea.Cmd, 0, len(names)+1)
+	for _, n := range names {
+		if m.testing[n] {
+			continue
+		}
+		m.testing[n] = true
+		cmds = append(cmds, m.testCmd(n))
+	}
+	m.status = ""
+	m.refreshRows()
+	if spin {
+		cmds = append(cmds, m.spinner.Tick)
+	}
+	return tea.Batch(cmds...)
+}
+
+// testLabel renders the test state of a cloud for the Status column.
+func (m CloudsModel) testLabel(name string) string {
+	if m.testing[name] {
+		return m.spinner.View() + " testing"
+	}
+	r, ok := m.results[name]
+	switch {
+	case !ok:
+		return "–"
+	case r.err != nil:
+		return "✗ failed"
+	}
+	label := i18n.T("✓ ok in %s", r.check.Elapsed.Round(10*time.Millisecond))
+	if r.check.Services > 0 {
+		label += i18n.T(", %d services", r.check.Services)
+	}
+	return label
+}
+
+// refreshRows rebuilds the table from the clouds and test results.
+func (m *CloudsModel) refreshRows() {
+	urlW := m.width - nameColWidth - authColWidth - regionColWidth - statusColWidth - uiconst.TableHeightOffset
+	if urlW < 20 {
+		urlW = 20
+	}
+	m.table.SetColumns([]table.Column{{Title: i18n.T("Name"), Width: nameColWidth}, {Title: i18n.T("Auth type"), Width: authColWidth}, {Title: i18n.T("Region"), Width: regionColWidth}, {Title: i18n.T("Auth URL"), Width: urlW}, {Title: i18n.T("Connection"), Width: statusColWidth}})
+	rows := make([]table.Row, 0, len(m.clouds))
+	for _, c := range m.clouds {
+		rows = append(rows, table.Row{c.Name, c.AuthType, c.Region, c.AuthURL, m.testLabel(c.Name)})
+	}
+	m.table.SetRows(rows)
+	m.table.SetHeight(m.height - uiconst.TableHeightOffset - 2)
+}
+
+// openForm shows an empty new cloud form.
+func (m *CloudsModel) openForm() {
+	m.inputs = make([]textinput.Model, len(formFields))
+	for i, f := range formFields {
+		ti := textinput.New()
+		ti.Prompt = fmt.Sprintf("%-30s", f+":")
+		ti.CharLimit = 256
+		ti.Width = 50
+		m.inputs[i] = ti
+	}
+	m.inputs[2].SetValue(config.AuthPassword)
+	m.inputs[2].Placeholder = config.AuthPassword + " or " + config.AuthApplicationCredential
+	m.inputs[4].EchoMode = textinput.EchoPassword
+	m.inputs[6].SetValue("Default")
+	m.inputs[7].SetValue("Default")
+	m.focus = 0
+	m.inputs[0].Focus()
+	m.form = true
+	m.formErr = nil
+}
+
+// updateForm handles keys while the new cloud form is open.
+func (m CloudsModel) updateForm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.form = false
+		return m, nil
+	case "ctrl+s":
+		return m, m.saveCmd()
+	case "enter":
+		if m.focus == len(m.inputs)-1 {
+			return m, m.saveCmd()
+		}
+		m.moveFocus(1)
+		return m, nil
+	case "tab", "down":
+		m.moveFocus(1)
+		return m, nil
+	case "shift+tab", "up":
+		m.moveFocus(-1)
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.inputs[m.focus], cmd = m.inputs[m.focus].Update(msg)
+	return m, cmd
+}
+
+func (m *CloudsModel) moveFocus(d int) {
+	m.inputs[m.focus].Blur()
+	m.focus = (m.focus + d + len(m.inputs)) % len(m.inputs)
+	m.inputs[m.focus].Focus()
+}
+
+// entry builds the cloud entry from the form.
+func (m CloudsModel) entry() config.CloudEntry {
+	v := func(i int) string { return strings.TrimSpace(m.inputs[i].Value()) }
+	e := config.CloudEntry{Name: v(0), AuthURL: v(1), AuthType: v(2), Username: v(3), Password: m.inputs[4].Value(), ProjectName: v(5), UserDomain: v(6), ProjectDomain: v(7), Region: v(8)}
+	if e.AuthType == "" {
+		e.AuthType = config.AuthPassword
+	}
+	return e
+}
+
+// saveCmd validates the form and appends the cloud to clouds.yaml.
+func (m *CloudsModel) saveCmd() tea.Cmd {
+	e := m.entry()
+	if err := e.Validate(); err != nil {
+		m.formErr = err
+		return nil
+	}
+	path := m.path
+	return func() tea.Msg {
+		return cloudAddedMsg{name: e.Name, err: config.AddCloud(path, e)}
+	}
+}
+
+// CapturingInput reports whether the new cloud form is open.
+func (m CloudsModel) CapturingInput() bool { return m.form }
+
+// View renders the cloud list or the form.
+func (m CloudsModel) View() string {
+	title := lipgloss.NewStyle().Bold(true).Foreground(theme.Accent).Render
+	dim := lipgloss.NewStyle().Foreground(theme.Muted).Render
+	errStyle := lipgloss.NewStyle().Foreground(theme.Error).Render
+	path, _ := config.CloudsPath(m.path)
+
+	if m.form {
+		var b strings.Builder
+		b.WriteString(title(i18n.T("New cloud")) + dim("  → "+path) + "\n\n")
+		for _, in := range m.inputs {
+			b.WriteString(in.View() + "\n")
+		}
+		if m.formErr != nil {
+			b.WriteString("\n" + errStyle(i18n.T("Error: %s", m.formErr)) + "\n")
+		}
+		b.WriteString("\n" + dim(i18n.T("[tab/↑↓] move  [enter] next/save  [ctrl+s] save  [esc] cancel")))
+		b.WriteString("\n" + dim(i18n.T("The previous file is kept as clouds.yaml.bak; comments are not preserved.")))
+		return b.String()
+	}
+	if m.loading {
+		return m.spinner.View() + " " + i18n.T("Loading %s", path)
+	}
+	if m.err != nil {
+		return errStyle(i18n.T("Error: %s", m.err)) + "\n" + dim(i18n.T("[n] new cloud  [r] reload"))
+	}
+
+	var b strings.Builder
+	b.WriteString(title(i18n.T("Clouds")) + dim("  "+path) + "\n")
+	if len(m.clouds) == 0 {
+		b.WriteString("\n" + i18n.T("No clouds defined yet. Press n to add one.") + "\n")
+	} else {
+		b.WriteString(m.table.View() + "\n")
+		if row := m.table.SelectedRow(); len(row) > 0 {
+			if r, ok := m.results[row[0]]; ok && r.err != nil {
+				b.WriteString(errStyle(fmt.Sprintf("%s: %s", row[0], r.err)) + "\n")
+			}
+		}
+	}
+	if m.status != "" {
+		b.WriteString(m.status + "\n")
+	}
+	b.WriteString(dim(i18n.T("[t] test connection  [a] test all  [n] new cloud  [r] reload")))
+	return b.String()
+}
+
+var _ tea.Model = (*CloudsModel)(nil)
@@ -0,0 +1,43 @@
+package clouds
+
+import (
+	"path/filepath"
+	"testing"
+
+	"ostui/internal/config"
+)
+
+func TestFormAddsCloud(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "clouds.yaml")
+	m := NewCloudsModel(path)
+	m.loading = false
+	m.openForm()
+	for i, v := range map[int]string{0: "lab", 1: "https://keystone.lab:5000/v3", 3: "alice", 4: "pw", 5: "demo"} {
+		m.inputs[i].SetValue(v)
+	}
+	if !m.CapturingInput() {
+		t.Fatal("the open form must capture input")
+	}
+	cmd := m.saveCmd()
+	if cmd == nil {
+		t.Fatalf("form rejected: %v", m.formErr)
+	}
+	msg := cmd()
+	if added, ok := msg.(cloudAddedMsg); !ok || added.err != nil {
+		t.Fatalf("unexpected result %#v", msg)
+	}
+	next, _ := m.Update(msg)
+	if next.(CloudsModel).form {
+		t.Error("form should close after saving")
+	}
+	clouds, err := config.ListClouds(path)
+	if err != nil || len(clouds) != 1 || clouds[0].Name != "lab" {
+		t.Fatalf("clouds.yaml not written: %v %+v", err, clouds)
+	}
+
+	m.openForm()
+	m.inputs[0].SetValue("broken")
+	if m.saveCmd() != nil || m.formErr == nil {
+		t.Error("an incomplete form must not be saved")
+	}
+}
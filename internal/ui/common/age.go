@@ -0,0 +1,142 @@
+package common
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/table"
+
+	"ostui/internal/i18n"
+)
+
+// AgeTitle returns the title of the Age column.
+func AgeTitle() string { return i18n.T("Age") }
+
+// Age renders how long ago t was: minutes under an hour, hours under two
+// days, days after. A zero time, from an API that does not report it, is
+// rendered empty.
+func Age(t, now time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	d := max(now.Sub(t), 0)
+	switch {
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d/time.Minute))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%dh", int(d/time.Hour))
+	}
+	return fmt.Sprintf("%dd", int(d/(24*time.Hour)))
+}
+
+// ageUnits are the units of ages in cells and filters.
+var ageUnits = map[byte]time.Duration{'m': time.Minute, 'h': time.Hour, 'd': 24 * time.Hour, 'w': 7 * 24 * time.Hour, 'y': 365 * 24 * time.Hour}
+
+// ParseAge reads an age such as 90d, 2w, 36h or 1y.
+func ParseAge(s string) (time.Duration, bool) {
+	if len(s) < 2 {
+		return 0, false
+	}
+	unit, ok := ageUnits[s[len(s)-1]]
+	n, err := strconv.Atoi(s[:len(s)-1])
+	if !ok || err != nil || n < 0 {
+		return 0, false
+	}
+	return time.Duration(n) * unit, true
+}
+
+// ageTerm is one age condition of a filter, e.g. age>90d.
+type ageTerm struct {
+	op  string
+	age time.Duration
+}
+
+// AgeQuery is a filter query with its age terms, such as age>90d or
+// age<=2w, taken out. The rest is matched as text.
+type AgeQuery struct {
+	Text  string
+	terms []ageTerm
+	aged  bool
+}
+
+// ParseAgeQuery splits the age terms off query. A term still being typed
+// (age>9) keeps every row, so the list does not empty under the cursor.
+func ParseAgeQuery(query string) AgeQuery {
+	fields := strings.Fields(query)
+	var q AgeQuery
+	var rest []string
+	for _, f := range fields {
+		lower := strings.ToLower(f)
+		if !strings.HasPrefix(lower, "age>") && !strings.HasPrefix(lower, "age<") {
+			rest = append(rest, f)
+			continue
+		}
+		q.aged = true
+		op, val := lower[3:4], lower[4:]
+		if strings.HasPrefix(val, "=") {
+			op, val = op+"=", val[1:]
+		}
+		if age, ok := ParseAge(val); ok {
+			q.terms = append(q.terms, ageTerm{op: op, age: age})
+		}
+	}
+	if !q.aged {
+		q.Text = query
+		return q
+	}
+	q.Text = strings.Join(rest, " ")
+	return q
+}
+
+// HasAge reports whether the query holds an age term, complete or not.
+func (q AgeQuery) HasAge() bool { return q.aged }
+
+// MatchAge reports whether age meets every age term of the query.
+func (q AgeQuery) MatchAge(age time.Duration) bool {
+	for _, t := range q.terms {
+		var ok bool
+		switch t.op {
+		case ">":
+			ok = age > t.age
+		case ">=":
+			ok = age >= t.age
+		case "<":
+			ok = age < t.age
+		case "<=":
+			ok = age <= t.age
+		}
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// MatchCreated reports whether a resource created at created is old enough
+// at now for the age terms. The Age cell is not used: it is truncated to
+// whole units, so 90d holds ages up to 91 days. A zero time, a resource
+// whose age is unknown, only matches a query without age terms.
+func (q AgeQuery) MatchCreated(created, now time.Time) bool {
+	if len(q.terms) == 0 {
+		return true
+	}
+	return !created.IsZero() && q.MatchAge(now.Sub(created))
+}
+
+// MatchRow reports whether a row of a resource created at created matches
+// q at now: its age meets the age terms and a cell contains the rest,
+// ignoring case.
+func MatchRow(r table.Row, created time.Time, q AgeQuery, now time.Time) bool {
+	if !q.MatchCreated(created, now) {
+		return false
+	}
+	lower := strings.ToLower(q.Text)
+	for _, c := range r {
+		if strings.Contains(strings.ToLower(c), lower) {
+			return true
+		}
+	}
+	return false
+}
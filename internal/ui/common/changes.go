@@ -0,0 +1,110 @@
+package common
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"ostui/internal/i18n"
+)
+
+// AutoRefreshInterval is how often the graph and topology views re-query
+// their relationships while auto-refresh is on.
+var AutoRefreshInterval = 10 * time.Second
+
+// FlashFrames is the number of frames changed nodes blink for after a
+// refresh, and flashRate the time between frames.
+const (
+	FlashFrames = 8
+	flashRate   = 400 * time.Millisecond
+)
+
+// FlashMsg advances the blinking of changed nodes; Seq tells the views
+// apart so a stale animation does not speed up a new one.
+type FlashMsg struct{ Seq int }
+
+// FlashCmd schedules the next frame of a blink.
+func FlashCmd(seq int) tea.Cmd {
+	return tea.Tick(flashRate, func(time.Time) tea.Msg { return FlashMsg{Seq: seq} })
+}
+
+// Node is a resource drawn by a relationship view, keyed by kind and ID
+// (e.g. "server:<id>") for change tracking.
+type Node struct {
+	Label  string
+	Status string
+}
+
+// Changes is what differs between two refreshes of a view.
+type Changes struct {
+	// Added holds the keys of the nodes that appeared.
+	Added map[string]bool
+	// Changed maps the keys of the nodes whose status changed to the
+	// previous status.
+	Changed map[string]string
+	// Removed lists the nodes that disappeared, by label.
+	Removed []Node
+}
+
+// DiffNodes compares the nodes of two refreshes. The first load (a nil
+// prev) has no changes.
+func DiffNodes(prev, cur map[string]Node) Changes {
+	c := Changes{Added: map[string]bool{}, Changed: map[string]string{}}
+	if prev == nil {
+		return c
+	}
+	for k, n := range cur {
+		p, ok := prev[k]
+		switch {
+		case !ok:
+			c.Added[k] = true
+		case p.Status != n.Status:
+			c.Changed[k] = p.Status
+		}
+	}
+	for k, n := range prev {
+		if _, ok := cur[k]; !ok {
+			c.Removed = append(c.Removed, n)
+		}
+	}
+	sort.Slice(c.Removed, func(i, j int) bool { return c.Removed[i].Label < c.Removed[j].Label })
+	return c
+}
+
+// Empty reports whether nothing changed.
+func (c Changes) Empty() bool {
+	return len(c.Added) == 0 && len(c.Changed) == 0 && len(c.Removed) == 0
+}
+
+// Mark returns the marker of a node: "+" when it appeared, "~" when its
+// status changed, "" otherwise.
+func (c Changes) Mark(key string) string {
+	if c.Added[key] {
+		return "+"
+	}
+	if _, ok := c.Changed[key]; ok {
+		return "~"
+	}
+	return ""
+}
+
+// Summary describes the changes, e.g. "2 new, 1 changed, 1 gone".
+func (c Changes) Summary() string {
+	if c.Empty() {
+		return "no changes"
+	}
+	var parts []string
+	if n := len(c.Added); n > 0 {
+		parts = append(parts, fmt.Sprintf("%d new", n))
+	}
+	if n := len(c.Changed); n > 0 {
+		parts = append(parts, i18n.T("%d changed", n))
+	}
+	if n := len(c.Removed); n > 0 {
+		parts = append(parts, i18n.T("%d gone", n))
+	}
+	return strings.Join(parts, ", ")
+}
@@ -4,13 +4,14 @@ import (
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"ostui/internal/i18n"
 )
 
 type confirmItem struct {
 	choice string
 }
 
-func (c confirmItem) Title() string       { return c.choice }
+func (c confirmItem) Title() string       { return i18n.T(c.choice) }
 func (c confirmItem) Description() string { return "" }
 func (c confirmItem) FilterValue() string { return c.choice }
 
@@ -60,7 +61,7 @@ func (m ConfirmModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 // View renders the confirm dialog.
 func (m ConfirmModel) View() string {
 	if m.done {
-		return "You selected: " + m.result
+		return i18n.T("You selected: %s", i18n.T(m.result))
 	}
 	return m.list.View()
 }
@@ -6,12 +6,18 @@ import (
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/theme"
 )
 
+// FormModel is a column of labelled text inputs. enter moves to the next
+// field and submits on the last one; esc cancels.
 type FormModel struct {
 	inputs     []textinput.Model
 	focusIndex int
 	submitted  bool
+	cancelled  bool
+	err        string
 }
 
 // NewForm creates a form with the given field placeholders.
@@ -39,8 +45,9 @@ func (m FormModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		switch msg.String() {
-		case "ctrl+c", "q":
-			return m, tea.Quit
+		case "esc":
+			m.cancelled = true
+			return m, nil
 		case "enter":
 			if m.focusIndex < len(m.inputs)-1 {
 				m.inputs[m.focusIndex].Blur()
@@ -51,9 +58,9 @@ func (m FormModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			// Last field – mark as submitted.
 			m.submitted = true
 			return m, nil
-		case "tab", "shift+tab":
+		case "tab", "shift+tab", "down", "up":
 			m.inputs[m.focusIndex].Blur()
-			if msg.String() == "tab" {
+			if msg.String() == "tab" || msg.String() == "down" {
 				m.focusIndex = (m.focusIndex + 1) % len(m.inputs)
 			} else {
 				m.focusIndex = (m.focusIndex - 1 + len(m.inputs)) % len(m.inputs)
@@ -76,11 +83,41 @@ func (m FormModel) View() string {
 		b.WriteString(m.inputs[i].View())
 		b.WriteRune('\n')
 	}
+	if m.err != "" {
+		b.WriteString("\n" + lipgloss.NewStyle().Foreground(theme.Error).Render(i18n.T("Error: %s", m.err)) + "\n")
+	}
 	if m.submitted {
-		b.WriteString("\n[Submitted]")
+		b.WriteString("\n" + i18n.T("[Submitted]"))
 	}
 	return lipgloss.NewStyle().Render(b.String())
 }
 
+// Values returns the trimmed field values in field order.
+func (m FormModel) Values() []string {
+	out := make([]string, len(m.inputs))
+	for i, in := range m.inputs {
+		out[i] = strings.TrimSpace(in.Value())
+	}
+	return out
+}
+
+// SetValue prefills field i.
+func (m *FormModel) SetValue(i int, v string) { m.inputs[i].SetValue(v) }
+
+// SetMasked hides what is typed in field i, for passwords and secrets.
+func (m *FormModel) SetMasked(i int) { m.inputs[i].EchoMode = textinput.EchoPassword }
+
+// Submitted reports whether enter was pressed on the last field.
+func (m FormModel) Submitted() bool { return m.submitted }
+
+// Cancelled reports whether esc was pressed.
+func (m FormModel) Cancelled() bool { return m.cancelled }
+
+// SetError shows a validation error and reopens the form for editing.
+func (m *FormModel) SetError(err error) {
+	m.err = err.Error()
+	m.submitted = false
+}
+
 // Ensure FormModel implements tea.Model.
 var _ tea.Model = (*FormModel)(nil)
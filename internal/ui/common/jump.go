@@ -0,0 +1,96 @@
+package common
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"ostui/internal/i18n"
+)
+
+// Tabler is implemented by the views built around a table, which the quick
+// jump moves through.
+type Tabler interface {
+	Table() table.Model
+}
+
+// FullTabler is implemented by the views whose Table holds only the rows on
+// screen, such as those over a VirtualTable; the quick jump searches the
+// FullTable instead.
+type FullTabler interface {
+	FullTable() table.Model
+}
+
+// Filterer is implemented by the list views with a / filter, so keys typed
+// into the filter do not trigger the app shortcuts.
+type Filterer interface {
+	Filtering() bool
+}
+
+// JumpMatches returns the rows of t matching query fuzzily, best first. The
+// Name column is matched when the table has one, every column otherwise.
+// Equal scores are ordered from the cursor down, wrapping around, as with
+// vim's f motion.
+func JumpMatches(t table.Model, query string) []int {
+	if strings.TrimSpace(query) == "" {
+		return nil
+	}
+	col := -1
+	for i, c := range t.Columns() {
+		if strings.EqualFold(c.Title, i18n.T("Name")) {
+			col = i
+			break
+		}
+	}
+	rows := t.Rows()
+	scores := map[int]int{}
+	var out []int
+	for i, r := range rows {
+		cells := []string(r)
+		if col >= 0 && col < len(r) {
+			cells = []string{r[col]}
+		}
+		best, hit := 0, false
+		for _, c := range cells {
+			if s, ok := FuzzyScore(query, strings.TrimSpace(c)); ok && (!hit || s > best) {
+				best, hit = s, true
+			}
+		}
+		if hit {
+			scores[i] = best
+			out = append(out, i)
+		}
+	}
+	cur := t.Cursor()
+	dist := func(i int) int { return (i - cur + len(rows)) % max(len(rows), 1) }
+	sort.SliceStable(out, func(a, b int) bool {
+		if scores[out[a]] != scores[out[b]] {
+			return scores[out[a]] > scores[out[b]]
+		}
+		return dist(out[a]) < dist(out[b])
+	})
+	return out
+}
+
+// CursorKeys returns the key presses that move the cursor of t to row to:
+// whole pages first, then single rows, so the view scrolls as if the user
+// had moved there.
+func CursorKeys(t table.Model, to int) []tea.KeyMsg {
+	page := max(t.Height(), 1)
+	delta := to - t.Cursor()
+	pageKey, rowKey := tea.KeyMsg{Type: tea.KeyPgDown}, tea.KeyMsg{Type: tea.KeyDown}
+	if delta < 0 {
+		delta = -delta
+		pageKey, rowKey = tea.KeyMsg{Type: tea.KeyPgUp}, tea.KeyMsg{Type: tea.KeyUp}
+	}
+	var keys []tea.KeyMsg
+	for ; delta >= page; delta -= page {
+		keys = append(keys, pageKey)
+	}
+	for ; delta > 0; delta-- {
+		keys = append(keys, rowKey)
+	}
+	return keys
+}
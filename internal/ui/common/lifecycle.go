@@ -0,0 +1,10 @@
+package common
+
+// Disposer is implemented by the views holding resources beyond their
+// commands, such as an open console or a running download. The app calls
+// Dispose once when the view is closed; the results its commands still
+// return are dropped, see the lifecycle tokens of the app. A message
+// carrying a resource implements it too, to release it when dropped.
+type Disposer interface {
+	Dispose()
+}
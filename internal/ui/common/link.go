@@ -0,0 +1,83 @@
+package common
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"ostui/internal/i18n"
+)
+
+// Kinds of resource a detail view can link to. They match the section
+// titles of the sidebar.
+const (
+	KindServer        = "Servers"
+	KindNetwork       = "Networks"
+	KindSubnet        = "Subnets"
+	KindPort          = "Ports"
+	KindVolume        = "Volumes"
+	KindFloatingIP    = "Floating IPs"
+	KindRouter        = "Routers"
+	KindSecurityGroup = "Security Groups"
+)
+
+// OpenResourceMsg asks the app to open the detail view of a resource on
+// top of the current one, so that esc comes back.
+type OpenResourceMsg struct {
+	Kind string
+	ID   string
+	Name string
+}
+
+// OpenResource returns a command opening the detail view of the resource.
+func OpenResource(kind, id string) tea.Cmd {
+	return func() tea.Msg { return OpenResourceMsg{Kind: kind, ID: id} }
+}
+
+// Links maps the fields of a Field/Value detail table to the kind of
+// resource whose ID they hold. Tables with two field/value pairs per row
+// are supported too.
+type Links map[string]string
+
+// selected returns the kind and ID of the first linked field with a value
+// in the selected row of t.
+func (l Links) selected(t table.Model) (kind, id string) {
+	row := t.SelectedRow()
+	for i := 0; i+1 < len(row); i += 2 {
+		if k, ok := l[row[i]]; ok && strings.TrimSpace(row[i+1]) != "" {
+			return k, strings.TrimSpace(row[i+1])
+		}
+	}
+	return "", ""
+}
+
+// Open returns the command opening the resource of the selected row of t,
+// or nil when the row links nowhere.
+func (l Links) Open(t table.Model) tea.Cmd {
+	kind, id := l.selected(t)
+	if kind == "" {
+		return nil
+	}
+	return OpenResource(kind, id)
+}
+
+// Hint returns the key hint for the selected row of t, e.g. "[enter] open
+// network", or "" when the row links nowhere.
+func (l Links) Hint(t table.Model) string {
+	kind, _ := l.selected(t)
+	if kind == "" {
+		return ""
+	}
+	return i18n.T("[enter] open %s", kindNoun(kind))
+}
+
+// kindNoun turns a kind into the singular noun shown in hints.
+func kindNoun(kind string) string {
+	switch kind {
+	case KindFloatingIP:
+		return "floating IP"
+	case KindSecurityGroup:
+		return "security group"
+	}
+	return strings.ToLower(strings.TrimSuffix(kind, "s"))
+}
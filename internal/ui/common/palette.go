@@ -0,0 +1,218 @@
+package common
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/theme"
+)
+
+// Action is an operation a view offers for its selected row or resource.
+type Action struct {
+	// Key is the binding that runs the action. Actions without a key are
+	// only reachable from the palette and reach the view as an ActionMsg.
+	Key string
+	// Name is the short name the palette filter matches, e.g. "stop".
+	Name string
+	// Help says what the action does.
+	Help string
+	// Detail marks list actions that run in the detail view of the selected
+	// row: the detail is opened first and runs the action once loaded.
+	Detail bool
+}
+
+// ActionProvider is implemented by views that list the actions valid for
+// their current selection, in menu order.
+type ActionProvider interface {
+	Actions() []Action
+}
+
+// ActionMsg runs an action without a key binding in the view offering it.
+type ActionMsg struct {
+	Action Action
+}
+
+// KeyFor returns the key press of a binding such as "enter" or "L", so an
+// action chosen from the palette runs exactly like its shortcut.
+func KeyFor(key string) tea.KeyMsg {
+	switch key {
+	case "enter":
+		return tea.KeyMsg{Type: tea.KeyEnter}
+	case "esc":
+		return tea.KeyMsg{Type: tea.KeyEsc}
+	case "tab":
+		return tea.KeyMsg{Type: tea.KeyTab}
+	case " ":
+		return tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+}
+
+// FuzzyScore matches pattern against s as a case-insensitive subsequence.
+// Consecutive characters and characters at the start of a word score
+// higher, so "sh" ranks "shelve" above "unshelve".
+func FuzzyScore(pattern, s string) (int, bool) {
+	p := []rune(strings.ToLower(pattern))
+	if len(p) == 0 {
+		return 0, true
+	}
+	score, j := 0, 0
+	prev := -2
+	runes := []rune(s)
+	for i, r := range runes {
+		if j == len(p) {
+			break
+		}
+		if unicode.ToLower(r) != p[j] {
+			continue
+		}
+		score++
+		if prev == i-1 {
+			score += 3
+		}
+		if i == 0 || !unicode.IsLetter(runes[i-1]) && !unicode.IsDigit(runes[i-1]) {
+			score += 2
+		}
+		prev = i
+		j++
+	}
+	if j < len(p) {
+		return 0, false
+	}
+	return score, true
+}
+
+// PaletteModel lists the actions of a view for quick keyboard access: typing
+// filters them fuzzily and enter picks one. The parent checks Done/Selected
+// after each update and runs the chosen action.
+type PaletteModel struct {
+	title    string
+	actions  []Action
+	filtered []int
+	cursor   int
+	filter   textinput.Model
+	done     bool
+	selected *Action
+}
+
+// NewPalette creates a palette over actions.
+func NewPalette(title string, actions []Action) PaletteModel {
+	ti := textinput.New()
+	ti.Prompt = "> "
+	ti.Placeholder = i18n.T("type to filter actions")
+	ti.CharLimit = 32
+	ti.Focus()
+	m := PaletteModel{title: title, actions: actions, filter: ti}
+	m.applyFilter()
+	return m
+}
+
+// Init implements tea.Model.
+func (m PaletteModel) Init() tea.Cmd { return textinput.Blink }
+
+// Done reports whether the palette was closed, by selecting or cancelling.
+func (m PaletteModel) Done() bool { return m.done }
+
+// Selected returns the chosen action; ok is false if the palette was
+// cancelled.
+func (m PaletteModel) Selected() (Action, bool) {
+	if m.selected == nil {
+		return Action{}, false
+	}
+	return *m.selected, true
+}
+
+// applyFilter ranks the actions matching the filter, best first; ties keep
+// the menu order.
+func (m *PaletteModel) applyFilter() {
+	q := strings.TrimSpace(m.filter.Value())
+	scores := map[int]int{}
+	m.filtered = m.filtered[:0]
+	for i, a := range m.actions {
+		best, ok := FuzzyScore(q, a.Name)
+		if s, hit := FuzzyScore(q, a.Help); hit && (!ok || s > best) {
+			best, ok = s, true
+		}
+		if q != "" && a.Key == q {
+			best, ok = best+100, true
+		}
+		if ok {
+			scores[i] = best
+			m.filtered = append(m.filtered, i)
+		}
+	}
+	sort.SliceStable(m.filtered, func(i, j int) bool { return scores[m.filtered[i]] > scores[m.filtered[j]] })
+	if m.cursor >= len(m.filtered) {
+		m.cursor = max(len(m.filtered)-1, 0)
+	}
+}
+
+// Update handles navigation, filter input and selection.
+func (m PaletteModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "esc":
+			m.done = true
+			return m, nil
+		case "enter":
+			if len(m.filtered) > 0 {
+				a := m.actions[m.filtered[m.cursor]]
+				m.selected = &a
+				m.done = true
+			}
+			return m, nil
+		case "up", "ctrl+p":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+			return m, nil
+		case "down", "ctrl+n":
+			if m.cursor < len(m.filtered)-1 {
+				m.cursor++
+			}
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.filter, cmd = m.filter.Update(msg)
+		m.cursor = 0
+		m.applyFilter()
+		return m, cmd
+	}
+	var cmd tea.Cmd
+	m.filter, cmd = m.filter.Update(msg)
+	return m, cmd
+}
+
+// View renders the filter and the matching actions with their shortcuts.
+func (m PaletteModel) View() string {
+	title := lipgloss.NewStyle().Bold(true).Foreground(theme.Accent).Render(m.title)
+	dim := lipgloss.NewStyle().Foreground(theme.Muted)
+	var b strings.Builder
+	b.WriteString(title + "\n" + m.filter.View() + "\n\n")
+	if len(m.filtered) == 0 {
+		b.WriteString(dim.Render("  "+i18n.T("no matches")) + "\n")
+	}
+	for i, idx := range m.filtered {
+		a := m.actions[idx]
+		key := a.Key
+		if key == "" {
+			key = "·"
+		}
+		line := pad(a.Name, 14) + " " + pad(a.Help, 56) + " " + dim.Render(pad(key, 5))
+		if i == m.cursor {
+			b.WriteString(lipgloss.NewStyle().Reverse(true).Render("> "+line) + "\n")
+		} else {
+			b.WriteString("  " + line + "\n")
+		}
+	}
+	b.WriteString(dim.Render("\n" + i18n.T("[↑/↓] move  [enter] run  [esc] cancel")))
+	return lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).Padding(0, 1).Render(b.String())
+}
+
+// Ensure PaletteModel implements tea.Model.
+var _ tea.Model = (*PaletteModel)(nil)
@@ -0,0 +1,214 @@
+package common
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/theme"
+)
+
+// PickerColumn is one column shown for each picker item.
+type PickerColumn struct {
+	Title string
+	Width int
+}
+
+// PickerItem is a selectable row. Cells line up with the picker columns;
+// every cell is matched by the search filter.
+type PickerItem struct {
+	ID    string
+	Cells []string
+}
+
+// pickerLoadedMsg carries the items returned by the picker's loader.
+type pickerLoadedMsg struct {
+	title string
+	items []PickerItem
+	err   error
+}
+
+// PickerModel is a searchable, paginated selection overlay for resources
+// such as flavors, images or networks. Forms embed it, forward messages to
+// it while it is open and check Done/Selected after each update.
+type PickerModel struct {
+	title    string
+	columns  []PickerColumn
+	load     func() ([]PickerItem, error)
+	items    []PickerItem
+	filtered []int
+	cursor   int
+	pageSize int
+	filter   textinput.Model
+	loading  bool
+	err      error
+	done     bool
+	selected *PickerItem
+}
+
+// NewPicker creates a picker that fills itself by calling load.
+func NewPicker(title string, columns []PickerColumn, load func() ([]PickerItem, error)) PickerModel {
+	ti := textinput.New()
+	ti.Prompt = "/ "
+	ti.Placeholder = "type to search"
+	ti.CharLimit = 64
+	ti.Focus()
+	return PickerModel{title: title, columns: columns, load: load, filter: ti, loading: true, pageSize: 10}
+}
+
+// Init loads the items.
+func (m PickerModel) Init() tea.Cmd {
+	title, load := m.title, m.load
+	return tea.Batch(textinput.Blink, func() tea.Msg {
+		items, err := load()
+		return pickerLoadedMsg{title: title, items: items, err: err}
+	})
+}
+
+// Done reports whether the picker was closed, by selecting or cancelling.
+func (m PickerModel) Done() bool { return m.done }
+
+// Selected returns the chosen item; ok is false if the picker was cancelled.
+func (m PickerModel) Selected() (PickerItem, bool) {
+	if m.selected == nil {
+		return PickerItem{}, false
+	}
+	return *m.selected, true
+}
+
+// SetPageSize sets the number of rows per page.
+func (m *PickerModel) SetPageSize(n int) {
+	if n < 1 {
+		n = 1
+	}
+	m.pageSize = n
+}
+
+// applyFilter recomputes the visible items from the search text.
+func (m *PickerModel) applyFilter() {
+	q := strings.ToLower(strings.TrimSpace(m.filter.Value()))
+	m.filtered = m.filtered[:0]
+	for i, it := range m.items {
+		if q == "" || strings.Contains(strings.ToLower(strings.Join(append([]string{it.ID}, it.Cells...), " ")), q) {
+			m.filtered = append(m.filtered, i)
+		}
+	}
+	if m.cursor >= len(m.filtered) {
+		m.cursor = max(len(m.filtered)-1, 0)
+	}
+}
+
+// Update handles navigation, search input and selection.
+func (m PickerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case pickerLoadedMsg:
+		if msg.title != m.title {
+			return m, nil
+		}
+		m.loading = false
+		m.items, m.err = msg.items, msg.err
+		m.applyFilter()
+		return m, nil
+	case tea.WindowSizeMsg:
+		m.SetPageSize(msg.Height - 10)
+		return m, nil
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc":
+			m.done = true
+			return m, nil
+		case "enter":
+			if len(m.filtered) > 0 {
+				it := m.items[m.filtered[m.cursor]]
+				m.selected = &it
+				m.done = true
+			}
+			return m, nil
+		case "up", "ctrl+p":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+			return m, nil
+		case "down", "ctrl+n":
+			if m.cursor < len(m.filtered)-1 {
+				m.cursor++
+			}
+			return m, nil
+		case "pgup", "left":
+			m.cursor = max(m.cursor-m.pageSize, 0)
+			return m, nil
+		case "pgdown", "right":
+			m.cursor = min(m.cursor+m.pageSize, max(len(m.filtered)-1, 0))
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.filter, cmd = m.filter.Update(msg)
+		m.cursor = 0
+		m.applyFilter()
+		return m, cmd
+	}
+	var cmd tea.Cmd
+	m.filter, cmd = m.filter.Update(msg)
+	return m, cmd
+}
+
+// View renders the search box, the current page and the pager.
+func (m PickerModel) View() string {
+	title := lipgloss.NewStyle().Bold(true).Foreground(theme.Accent).Render(m.title)
+	dim := lipgloss.NewStyle().Foreground(theme.Muted)
+	if m.loading {
+		return title + "\n\n" + i18n.T("Loading…")
+	}
+	if m.err != nil {
+		return title + "\n\n" + lipgloss.NewStyle().Foreground(theme.Error).Render(i18n.T("Error: %s", m.err)) + "\n" + dim.Render(i18n.T("[esc] cancel"))
+	}
+	var b strings.Builder
+	b.WriteString(title + "\n" + m.filter.View() + "\n\n")
+	header := "  "
+	for _, c := range m.columns {
+		header += pad(c.Title, c.Width) + " "
+	}
+	b.WriteString(lipgloss.NewStyle().Bold(true).Render(header) + "\n")
+	if len(m.filtered) == 0 {
+		b.WriteString(dim.Render("  "+i18n.T("no matches")) + "\n")
+	}
+	page := m.cursor / m.pageSize
+	start := page * m.pageSize
+	end := min(start+m.pageSize, len(m.filtered))
+	for i := start; i < end; i++ {
+		it := m.items[m.filtered[i]]
+		line := ""
+		for j, c := range m.columns {
+			cell := ""
+			if j < len(it.Cells) {
+				cell = it.Cells[j]
+			}
+			line += pad(cell, c.Width) + " "
+		}
+		if i == m.cursor {
+			b.WriteString(lipgloss.NewStyle().Reverse(true).Render("> "+line) + "\n")
+		} else {
+			b.WriteString("  " + line + "\n")
+		}
+	}
+	pages := max((len(m.filtered)+m.pageSize-1)/m.pageSize, 1)
+	b.WriteString(dim.Render("\n" + i18n.T("Page %d/%d · %d of %d  [↑/↓] move  [←/→] page  [enter] select  [esc] cancel", page+1, pages, len(m.filtered), len(m.items))))
+	return lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).Padding(0, 1).Render(b.String())
+}
+
+// pad truncates or pads s to exactly width runes.
+func pad(s string, width int) string {
+	r := []rune(s)
+	if len(r) > width {
+		if width > 1 {
+			return string(r[:width-1]) + "…"
+		}
+		return string(r[:width])
+	}
+	return s + strings.Repeat(" ", width-len(r))
+}
+
+// Ensure PickerModel implements tea.Model.
+var _ tea.Model = (*PickerModel)(nil)
@@ -0,0 +1,45 @@
+package common
+
+import (
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Reloader is implemented by the list and detail views that r refreshes.
+// Reload returns nil when r means something else in the current state of
+// the view (typing into a filter, a sub-view with its own refresh); the view
+// then gets the key itself.
+type Reloader interface {
+	Reload() tea.Cmd
+}
+
+// Reloaded returns the table to show after a reload: the rows of fresh in
+// the table on screen, so the scroll position is kept, with the cursor on
+// the row that was selected (found by its first column) if it still exists.
+// The first load, when cur has no rows, takes fresh as is.
+func Reloaded(cur, fresh table.Model) table.Model {
+	if len(cur.Rows()) == 0 {
+		return fresh
+	}
+	var id string
+	if row := cur.SelectedRow(); len(row) > 0 {
+		id = row[0]
+	}
+	if n := len(fresh.Columns()); n > 0 && n != len(cur.Columns()) {
+		cur.SetColumns(fresh.Columns())
+	}
+	rows := fresh.Rows()
+	cur.SetRows(rows)
+	for i, r := range rows {
+		if len(r) > 0 && r[0] == id {
+			if i != cur.Cursor() {
+				cur.SetCursor(i)
+			}
+			return cur
+		}
+	}
+	if cur.Cursor() >= len(rows) {
+		cur.SetCursor(len(rows) - 1)
+	}
+	return cur
+}
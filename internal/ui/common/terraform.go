@@ -0,0 +1,58 @@
+package common
+
+import (
+	"github.com/charmbracelet/bubbles/table"
+	"ostui/internal/tfstate"
+)
+
+// TerraformUnmanaged labels resources absent from the Terraform state; the
+// list filter matches it, so "/unmanaged" shows click-ops resources.
+const TerraformUnmanaged = "unmanaged"
+
+// terraformColWidth is the width of the Terraform address column.
+const terraformColWidth = 40
+
+// TerraformLabel returns the Terraform address managing id, or
+// TerraformUnmanaged. It returns "" when no state is loaded.
+func TerraformLabel(id string) string {
+	if tfstate.Active == nil {
+		return ""
+	}
+	if addr, ok := tfstate.Active.Address(id); ok {
+		return addr
+	}
+	return TerraformUnmanaged
+}
+
+// TerraformColumns appends the Terraform column when a state is loaded.
+func TerraformColumns(cols []table.Column) []table.Column {
+	if tfstate.Active == nil {
+		return cols
+	}
+	return append(cols, table.Column{Title: "Terraform", Width: terraformColWidth})
+}
+
+// TerraformWidth is the width taken by the Terraform column, 0 without state.
+func TerraformWidth() int {
+	if tfstate.Active == nil {
+		return 0
+	}
+	return terraformColWidth
+}
+
+// TerraformRows appends the Terraform label to rows whose first cell is the
+// resource ID, when a state is loaded. Rows without an ID (group headers)
+// get an empty cell.
+func TerraformRows(rows []table.Row) []table.Row {
+	if tfstate.Active == nil {
+		return rows
+	}
+	for i, r := range rows {
+		label := ""
+		if r[0] != "" {
+			label = TerraformLabel(r[0])
+		}
+		rows[i] = append(r, label)
+	}
+	return rows
+}
@@ -0,0 +1,226 @@
+package common
+
+import (
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// VirtualTable is a table for lists that may hold tens of thousands of
+// rows. It keeps the rows as plain cells, filters them against a lower-case
+// copy made once per load, and hands the bubbles table only the rows on
+// screen, styled as they are shown. Filtering and moving the cursor cost
+// the same whatever the length of the list.
+type VirtualTable struct {
+	// table holds the rows on screen; its cursor is the selected row.
+	table table.Model
+	rows  []table.Row
+	// keys are the cells of each row, lower-cased and joined, for Filter.
+	keys []string
+	// created holds the creation time of each row, for the age terms of
+	// Filter; nil when the rows have no age.
+	created []time.Time
+	// style renders the cells of a row on screen, e.g. colored statuses.
+	style func(table.Row) table.Row
+	query string
+	// match indexes the rows matching the query, in order.
+	match []int
+	// cursor and top index match: the selected row and the first row on
+	// screen.
+	cursor int
+	top    int
+}
+
+// NewVirtualTable wraps t, which sets the columns, height and styles. style
+// may be nil when the cells are shown as they are.
+func NewVirtualTable(t table.Model, style func(table.Row) table.Row) VirtualTable {
+	t.SetRows(nil)
+	return VirtualTable{table: t, style: style}
+}
+
+// SetRows replaces the rows, keeping the filter and, when it is still
+// listed, the selected row (found by its first column).
+func (v *VirtualTable) SetRows(rows []table.Row) { v.SetRowsCreated(rows, nil) }
+
+// SetRowsCreated replaces the rows as SetRows, with the creation time of
+// each row for the age terms of the filter.
+func (v *VirtualTable) SetRowsCreated(rows []table.Row, created []time.Time) {
+	var id string
+	if row := v.SelectedRow(); len(row) > 0 {
+		id = row[0]
+	}
+	v.rows, v.created = rows, created
+	v.keys = make([]string, len(rows))
+	for i, r := range rows {
+		v.keys[i] = strings.ToLower(strings.Join(r, "\x00"))
+	}
+	q := v.query
+	v.query, v.match = "", nil
+	v.filter(q)
+	for i, r := range v.match {
+		if id != "" && v.rows[r][0] == id {
+			v.cursor = i
+			break
+		}
+	}
+	v.refresh()
+}
+
+// Filter shows the rows with a cell containing query, ignoring case, and
+// whose creation time meets the age terms of the query (age>90d). Age terms
+// are ignored when the rows have no creation time. A query extending the
+// previous one only searches the rows it matched.
+func (v *VirtualTable) Filter(query string) {
+	v.filter(strings.ToLower(query))
+	v.cursor, v.top = 0, 0
+	v.refresh()
+}
+
+// filter sets match for the lower-case query q.
+func (v *VirtualTable) filter(q string) {
+	aq := ParseAgeQuery(q)
+	from := v.match
+	// Typing a number into an age term widens it as often as it narrows.
+	if v.match == nil || !strings.HasPrefix(q, v.query) || aq.HasAge() {
+		from = nil
+		for i := range v.rows {
+			from = append(from, i)
+		}
+	}
+	v.query = q
+	if q == "" {
+		v.match = from
+		return
+	}
+	now := time.Now()
+	match := make([]int, 0, len(from))
+	for _, i := range from {
+		if v.created != nil && !aq.MatchCreated(v.created[i], now) {
+			continue
+		}
+		if strings.Contains(v.keys[i], aq.Text) {
+			match = append(match, i)
+		}
+	}
+	v.match = match
+}
+
+// Query returns the current filter.
+func (v VirtualTable) Query() string { return v.query }
+
+// Len returns the number of rows matching the filter.
+func (v VirtualTable) Len() int { return len(v.match) }
+
+// Total returns the number of rows, filtered or not.
+func (v VirtualTable) Total() int { return len(v.rows) }
+
+// SelectedRow returns the cells of the selected row, unstyled.
+func (v VirtualTable) SelectedRow() table.Row {
+	if v.cursor < 0 || v.cursor >= len(v.match) {
+		return nil
+	}
+	return v.rows[v.match[v.cursor]]
+}
+
+// Cursor returns the position of the selected row among the rows matching
+// the filter.
+func (v VirtualTable) Cursor() int { return v.cursor }
+
+// SetCursor selects the i-th row matching the filter.
+func (v *VirtualTable) SetCursor(i int) {
+	v.cursor = i
+	v.refresh()
+}
+
+// SetHeight sets the height of the table, as table.Model.SetHeight.
+func (v *VirtualTable) SetHeight(h int) {
+	v.table.SetHeight(h)
+	v.refresh()
+}
+
+// SetColumns sets the columns of the table.
+func (v *VirtualTable) SetColumns(cols []table.Column) { v.table.SetColumns(cols) }
+
+// Columns returns the columns of the table.
+func (v VirtualTable) Columns() []table.Column { return v.table.Columns() }
+
+// page returns the number of rows on screen.
+func (v VirtualTable) page() int { return max(v.table.Height(), 1) }
+
+// refresh clamps the cursor, scrolls the screen to it and styles the rows
+// it shows.
+func (v *VirtualTable) refresh() {
+	v.cursor = max(min(v.cursor, len(v.match)-1), 0)
+	page := v.page()
+	switch {
+	case v.cursor < v.top:
+		v.top = v.cursor
+	case v.cursor >= v.top+page:
+		v.top = v.cursor - page + 1
+	}
+	v.top = max(min(v.top, len(v.match)-page), 0)
+	end := min(v.top+page, len(v.match))
+	window := make([]table.Row, 0, end-v.top)
+	for _, i := range v.match[v.top:end] {
+		r := v.rows[i]
+		if v.style != nil {
+			r = v.style(r)
+		}
+		window = append(window, r)
+	}
+	v.table.SetRows(window)
+	v.table.SetCursor(v.cursor - v.top)
+}
+
+// Update moves the cursor with the keys of the table key map.
+func (v VirtualTable) Update(msg tea.Msg) (VirtualTable, tea.Cmd) {
+	km, ok := msg.(tea.KeyMsg)
+	if !ok || !v.table.Focused() {
+		return v, nil
+	}
+	page, keys := v.page(), v.table.KeyMap
+	switch {
+	case key.Matches(km, keys.LineUp):
+		v.cursor--
+	case key.Matches(km, keys.LineDown):
+		v.cursor++
+	case key.Matches(km, keys.PageUp):
+		v.cursor -= page
+	case key.Matches(km, keys.PageDown):
+		v.cursor += page
+	case key.Matches(km, keys.HalfPageUp):
+		v.cursor -= page / 2
+	case key.Matches(km, keys.HalfPageDown):
+		v.cursor += page / 2
+	case key.Matches(km, keys.GotoTop):
+		v.cursor = 0
+	case key.Matches(km, keys.GotoBottom):
+		v.cursor = len(v.match) - 1
+	default:
+		return v, nil
+	}
+	v.refresh()
+	return v, nil
+}
+
+// Table returns the table of the rows on screen, with the selected row.
+func (v VirtualTable) Table() table.Model { return v.table }
+
+// FullTable returns a table of all the rows matching the filter, unstyled,
+// with the cursor on the selected row, for the quick jump to search.
+func (v VirtualTable) FullTable() table.Model {
+	rows := make([]table.Row, len(v.match))
+	for i, r := range v.match {
+		rows[i] = v.rows[r]
+	}
+	t := v.table
+	t.SetRows(rows)
+	t.SetCursor(v.cursor)
+	return t
+}
+
+// View renders the rows on screen.
+func (v VirtualTable) View() string { return v.table.View() }
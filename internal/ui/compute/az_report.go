@@ -0,0 +1,245 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/gophercloud/gophercloud/openstack/blockstorage/v3/volumes"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
+	"ostui/internal/client"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/common"
+	"ostui/internal/ui/theme"
+	"ostui/internal/ui/uiconst"
+)
+
+// OpenVolumeMsg asks the app to open the detail view of a volume.
+type OpenVolumeMsg struct {
+	VolumeID string
+}
+
+// azPair is one server/volume attachment with the zone of each side.
+type azPair struct {
+	ServerID, ServerName, ServerAZ string
+	VolumeID, VolumeName, VolumeAZ string
+	Device                         string
+}
+
+// Mismatch reports whether the server and volume are in different zones.
+// Unknown zones (e.g. hidden by policy) are not flagged.
+func (p azPair) Mismatch() bool {
+	return p.ServerAZ != "" && p.VolumeAZ != "" && p.ServerAZ != p.VolumeAZ
+}
+
+// azPairs cross-references volume attachments with the servers' zones,
+// sorted with mismatches first.
+func azPairs(srvs []servers.Server, zones map[string]string, vols []volumes.Volume) []azPair {
+	names := make(map[string]string, len(srvs))
+	for _, s := range srvs {
+		names[s.ID] = s.Name
+	}
+	var pairs []azPair
+	for _, v := range vols {
+		for _, a := range v.Attachments {
+			pairs = append(pairs, azPair{
+				ServerID: a.ServerID, ServerName: names[a.ServerID], ServerAZ: zones[a.ServerID],
+				VolumeID: v.ID, VolumeName: v.Name, VolumeAZ: v.AvailabilityZone,
+				Device: a.Device,
+			})
+		}
+	}
+	sort.SliceStable(pairs, func(i, j int) bool {
+		if pairs[i].Mismatch() != pairs[j].Mismatch() {
+			return pairs[i].Mismatch()
+		}
+		return pairs[i].ServerName < pairs[j].ServerName
+	})
+	return pairs
+}
+
+// AZReportModel lists server/volume attachments whose availability zones
+// differ, which breaks live migration and resize on many clouds.
+type AZReportModel struct {
+	table   table.Model
+	loading bool
+	err     error
+	spinner spinner.Model
+	compute client.ComputeClient
+	storage client.StorageClient
+	pairs   []azPair
+	// showAll lists every attachment instead of the mismatches only.
+	showAll bool
+	width   int
+	height  int
+}
+
+// NewAZReportModel creates the availability zone consistency report.
+func NewAZReportModel(cc client.ComputeClient, sc client.StorageClient) AZReportModel {
+	s := spinner.New()
+	s.Spinner = spinner.Dot
+	return AZReportModel{compute: cc, storage: sc, loading: true, spinner: s, width: 120, height: 30}
+}
+
+type azReportLoadedMsg struct {
+	pairs []azPair
+	err   error
+}
+
+// Init loads servers, their zones and volumes.
+func (m AZReportModel) Init() tea.Cmd {
+	return tea.Batch(m.spinner.Tick, m.load())
+}
+
+// Reload runs the report again, keeping the selected row.
+func (m AZReportModel) Reload() tea.Cmd {
+	if m.loading {
+		return nil
+	}
+	return m.load()
+}
+
+// load fetches the servers, their zones and the volumes.
+func (m AZReportModel) load() tea.Cmd {
+	return func() tea.Msg {
+		srvs, err := m.compute.ListInstances()
+		if err != nil {
+			return azReportLoadedMsg{err: err}
+		}
+		zones, err := m.compute.ListServerZones(context.Background())
+		if err != nil {
+			return azReportLoadedMsg{err: fmt.Errorf("server zones: %w", err)}
+		}
+		vols, err := m.storage.ListVolumes()
+		if err != nil {
+			return azReportLoadedMsg{err: fmt.Errorf("volumes: %w", err)}
+		}
+		return azReportLoadedMsg{pairs: azPairs(srvs, zones, vols)}
+	}
+}
+
+// visible returns the pairs shown in the table.
+func (m AZReportModel) visible() []azPair {
+	if m.showAll {
+		return m.pairs
+	}
+	var out []azPair
+	for _, p := range m.pairs {
+		if p.Mismatch() {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// buildTable fills the table from the visible pairs.
+func (m *AZReportModel) buildTable() {
+	nameW := max((m.width-uiconst.ColWidthUUID*2-3*12-uiconst.ColWidthType-8)/2, 10)
+	cols := []table.Column{
+		{Title: i18n.T("Server ID"), Width: uiconst.ColWidthUUID},
+		{Title: i18n.T("Server"), Width: nameW},
+		{Title: i18n.T("Server AZ"), Width: 12},
+		{Title: i18n.T("Volume ID"), Width: uiconst.ColWidthUUID},
+		{Title: i18n.T("Volume"), Width: nameW},
+		{Title: i18n.T("Volume AZ"), Width: 12},
+		{Title: i18n.T("Device"), Width: 12},
+		{Title: i18n.T("Check"), Width: uiconst.ColWidthType},
+	}
+	var rows []table.Row
+	for _, p := range m.visible() {
+		check := "ok"
+		if p.Mismatch() {
+			check = "MISMATCH"
+		}
+		rows = append(rows, table.Row{p.ServerID, p.ServerName, p.ServerAZ, p.VolumeID, p.VolumeName, p.VolumeAZ, p.Device, check})
+	}
+	m.table = table.New(
+		table.WithColumns(cols),
+		table.WithRows(rows),
+		table.WithFocused(true),
+		table.WithHeight(m.height-uiconst.TableHeightOffset-2),
+	)
+	m.table.SetStyles(table.DefaultStyles())
+}
+
+// Update handles messages for the model.
+func (m AZReportModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case azReportLoadedMsg:
+		m.loading = false
+		m.err = msg.err
+		m.pairs = msg.pairs
+		prev := m.table
+		m.buildTable()
+		m.table = common.Reloaded(prev, m.table)
+		return m, nil
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		if !m.loading {
+			m.buildTable()
+		}
+		return m, nil
+	case tea.KeyMsg:
+		if m.loading || m.err != nil {
+			return m, nil
+		}
+		switch msg.String() {
+		case "a":
+			m.showAll = !m.showAll
+			m.buildTable()
+			return m, nil
+		case "v":
+			if row := m.table.SelectedRow(); len(row) > 3 {
+				id := row[3]
+				return m, func() tea.Msg { return OpenVolumeMsg{VolumeID: id} }
+			}
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.table, cmd = m.table.Update(msg)
+		return m, cmd
+	default:
+		if m.loading {
+			var cmd tea.Cmd
+			m.spinner, cmd = m.spinner.Update(msg)
+			return m, cmd
+		}
+	}
+	return m, nil
+}
+
+// View renders the summary line and the table.
+func (m AZReportModel) View() string {
+	if m.loading {
+		return m.spinner.View() + " Cross-checking server and volume availability zones..."
+	}
+	if m.err != nil {
+		return i18n.T("Error: %s", m.err)
+	}
+	mismatches := 0
+	for _, p := range m.pairs {
+		if p.Mismatch() {
+			mismatches++
+		}
+	}
+	summary := i18n.T("%d of %d attachments cross availability zones", mismatches, len(m.pairs))
+	style := lipgloss.NewStyle().Bold(true).Foreground(theme.OK)
+	if mismatches > 0 {
+		style = style.Foreground(theme.Error)
+	}
+	scope := "mismatches"
+	if m.showAll {
+		scope = "all attachments"
+	}
+	return style.Render(summary) + "\n" + m.table.View() +
+		"\n" + i18n.T("Showing %s  [a] toggle all  [enter] server detail  [v] volume detail", scope)
+}
+
+// Table returns the underlying table model.
+func (m AZReportModel) Table() table.Model { return m.table }
+
+var _ tea.Model = (*AZReportModel)(nil)
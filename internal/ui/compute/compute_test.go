@@ -2,17 +2,37 @@ package compute
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
 	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
 
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/gophercloud/gophercloud"
+	vLimits "github.com/gophercloud/gophercloud/openstack/blockstorage/extensions/limits"
+	"github.com/gophercloud/gophercloud/openstack/blockstorage/v3/volumes"
 	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/availabilityzones"
 	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/hypervisors"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/instanceactions"
 	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/keypairs"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/quotasets"
 	"github.com/gophercloud/gophercloud/openstack/compute/v2/flavors"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/images"
 	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/layer3/floatingips"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/ports"
 	"ostui/internal/client"
+	"ostui/internal/ui/common"
+	"ostui/internal/ui/ops"
 )
 
 type mockComputeClient struct {
@@ -20,6 +40,33 @@ type mockComputeClient struct {
 	listErr       error
 	getInstance   servers.Server
 	getErr        error
+	serverGroups  []client.ServerGroup
+	hosts         map[string]string
+	flavors       []flavors.Flavor
+	zones         map[string]string
+	services      []client.ComputeService
+	migrateErr    map[string]error
+	migrated      []string
+	password      string
+	adminPassword string
+	locked        bool
+	lifecycle     []string
+	renamed       map[string]string
+	metadataSet   map[string]map[string]string
+	updateErr     map[string]error
+	console       io.ReadWriteCloser
+	// serverStates, when set, answers GetServerState per server; servers
+	// missing from it are gone (404).
+	serverStates map[string]client.ServerState
+	hypervisors  []hypervisors.Hypervisor
+	ratios       map[string]client.AllocationRatios
+	ratiosErr    error
+	imageMeta    map[string]string
+	// userData answers GetServerUserData; userDataErr stands for a role
+	// that cannot read it. rebuiltWith records RebuildInstanceUserData.
+	userData    string
+	userDataErr error
+	rebuiltWith *string
 }
 
 func (m *mockComputeClient) ListInstances() ([]servers.Server, error) {
@@ -34,22 +81,34 @@ func (m *mockComputeClient) GetInstance(id string) (servers.Server, error) {
 func (m *mockComputeClient) GetConsoleLog(id string, lines int) (string, error) { return "", nil }
 
 // Stub implementations for the remaining ComputeClient methods.
-func (m *mockComputeClient) StartInstance(id string) error             { return nil }
-func (m *mockComputeClient) StopInstance(id string) error              { return nil }
-func (m *mockComputeClient) DeleteInstance(id string) error            { return nil }
-func (m *mockComputeClient) ListFlavors() ([]flavors.Flavor, error)    { return nil, nil }
+func (m *mockComputeClient) StartInstance(id string) error  { return nil }
+func (m *mockComputeClient) StopInstance(id string) error   { return nil }
+func (m *mockComputeClient) DeleteInstance(id string) error { return nil }
+func (m *mockComputeClient) CreateInstance(ctx context.Context, opts servers.CreateOptsBuilder) (servers.Server, error) {
+	return servers.Server{}, nil
+}
+func (m *mockComputeClient) ListFlavors() ([]flavors.Flavor, error)    { return m.flavors, nil }
 func (m *mockComputeClient) ListKeypairs() ([]keypairs.KeyPair, error) { return nil, nil }
 
 // Additional stub methods for new ComputeClient interface methods.
 func (m *mockComputeClient) GetConsoleURL(ctx context.Context, id, consoleType string) (string, error) {
 	return "", nil
 }
+func (m *mockComputeClient) OpenSerialConsole(ctx context.Context, id string) (io.ReadWriteCloser, error) {
+	if m.console == nil {
+		return nil, errors.New("serial console disabled")
+	}
+	return m.console, nil
+}
 func (m *mockComputeClient) ListHypervisors(ctx context.Context) ([]hypervisors.Hypervisor, error) {
-	return nil, nil
+	return m.hypervisors, nil
 }
 func (m *mockComputeClient) GetHypervisor(ctx context.Context, id string) (*hypervisors.Hypervisor, error) {
 	return nil, nil
 }
+func (m *mockComputeClient) ListAllocationRatios(ctx context.Context) (map[string]client.AllocationRatios, error) {
+	return m.ratios, m.ratiosErr
+}
 func (m *mockComputeClient) ListAvailabilityZones(ctx context.Context) ([]availabilityzones.AvailabilityZone, error) {
 	return nil, nil
 }
@@ -73,6 +132,171 @@ func (m *mockComputeClient) ListServerInterfaces(ctx context.Context, serverID s
 func (m *mockComputeClient) ListServerVolumes(ctx context.Context, serverID string) ([]client.ServerVolume, error) {
 	return []client.ServerVolume{}, nil
 }
+func (m *mockComputeClient) AttachVolume(ctx context.Context, serverID, volumeID string) error {
+	return nil
+}
+func (m *mockComputeClient) DetachVolume(ctx context.Context, serverID, volumeID string) error {
+	return nil
+}
+
+// Remediation stubs.
+func (m *mockComputeClient) RebootInstance(ctx context.Context, id string, hard bool) error {
+	return nil
+}
+func (m *mockComputeClient) RebuildInstance(ctx context.Context, id string, opts servers.RebuildOptsBuilder) error {
+	return nil
+}
+func (m *mockComputeClient) GetServerUserData(ctx context.Context, id string) (string, error) {
+	return m.userData, m.userDataErr
+}
+func (m *mockComputeClient) RebuildInstanceUserData(ctx context.Context, id, imageRef, userData string) error {
+	m.rebuiltWith = &userData
+	return nil
+}
+func (m *mockComputeClient) ResizeInstance(ctx context.Context, id, flavorID string) error {
+	return nil
+}
+func (m *mockComputeClient) ConfirmResize(ctx context.Context, id string) error {
+	m.lifecycle = append(m.lifecycle, "confirm resize")
+	return nil
+}
+func (m *mockComputeClient) RevertResize(ctx context.Context, id string) error {
+	m.lifecycle = append(m.lifecycle, "revert resize")
+	return nil
+}
+func (m *mockComputeClient) ListServerZones(ctx context.Context) (map[string]string, error) {
+	return m.zones, nil
+}
+func (m *mockComputeClient) EvacuateInstance(ctx context.Context, id, host string, onSharedStorage bool) error {
+	return nil
+}
+func (m *mockComputeClient) GetServerState(ctx context.Context, id string) (client.ServerState, error) {
+	if m.serverStates != nil {
+		st, ok := m.serverStates[id]
+		if !ok {
+			return client.ServerState{}, gophercloud.ErrDefault404{}
+		}
+		return st, nil
+	}
+	return client.ServerState{Status: m.getInstance.Status, Locked: &m.locked}, m.getErr
+}
+func (m *mockComputeClient) GetServerDiagnostics(ctx context.Context, id string) (client.ServerDiagnostics, error) {
+	return client.ServerDiagnostics{}, nil
+}
+func (m *mockComputeClient) ListInstanceActions(ctx context.Context, id string) ([]instanceactions.InstanceAction, error) {
+	return nil, nil
+}
+func (m *mockComputeClient) GetInstanceAction(ctx context.Context, id, requestID string) (instanceactions.InstanceActionDetail, error) {
+	return instanceactions.InstanceActionDetail{}, nil
+}
+
+// Quota stubs.
+func (m *mockComputeClient) UpdateInstance(ctx context.Context, id string, opts servers.UpdateOpts) error {
+	if err := m.updateErr[id]; err != nil {
+		return err
+	}
+	if m.renamed == nil {
+		m.renamed = map[string]string{}
+	}
+	m.renamed[id] = opts.Name
+	return nil
+}
+func (m *mockComputeClient) UpdateInstanceMetadata(ctx context.Context, id string, metadata map[string]string) error {
+	if m.metadataSet == nil {
+		m.metadataSet = map[string]map[string]string{}
+	}
+	m.metadataSet[id] = metadata
+	return nil
+}
+func (m *mockComputeClient) ResetInstanceMetadata(ctx context.Context, id string, metadata map[string]string) error {
+	return nil
+}
+func (m *mockComputeClient) ListServerGroups(ctx context.Context) ([]client.ServerGroup, error) {
+	return m.serverGroups, nil
+}
+func (m *mockComputeClient) GetServerHypervisor(ctx context.Context, id string) (string, error) {
+	return m.hosts[id], nil
+}
+func (m *mockComputeClient) GetQuotaSet(ctx context.Context, projectID string) (quotasets.QuotaDetailSet, error) {
+	return quotasets.QuotaDetailSet{}, nil
+}
+func (m *mockComputeClient) UpdateQuotaSet(ctx context.Context, projectID string, opts quotasets.UpdateOpts) error {
+	return nil
+}
+
+// Host maintenance stubs.
+func (m *mockComputeClient) ListComputeServices(ctx context.Context, host string) ([]client.ComputeService, error) {
+	return m.services, nil
+}
+func (m *mockComputeClient) SetComputeServiceEnabled(ctx context.Context, id string, enabled bool, reason string) error {
+	m.services[0].Status, m.services[0].DisabledReason = "enabled", ""
+	if !enabled {
+		m.services[0].Status, m.services[0].DisabledReason = "disabled", reason
+	}
+	return nil
+}
+func (m *mockComputeClient) ListHostInstances(ctx context.Context, host string) ([]servers.Server, error) {
+	return m.listInstances, m.listErr
+}
+func (m *mockComputeClient) LiveMigrateInstance(ctx context.Context, id, host string) error {
+	if err := m.migrateErr[id]; err != nil {
+		return err
+	}
+	m.migrated = append(m.migrated, id)
+	m.hosts[id] = "compute-02.example"
+	return nil
+}
+func (m *mockComputeClient) GetServerPassword(ctx context.Context, id string) (string, error) {
+	return m.password, nil
+}
+func (m *mockComputeClient) ChangeAdminPassword(ctx context.Context, id, password string) error {
+	m.adminPassword = password
+	return nil
+}
+func (m *mockComputeClient) CreateServerImage(ctx context.Context, id, name string, metadata map[string]string) (string, error) {
+	m.imageMeta = metadata
+	return "img-" + name, nil
+}
+func (m *mockComputeClient) PauseInstance(ctx context.Context, id string) error {
+	m.lifecycle = append(m.lifecycle, "pause")
+	return nil
+}
+func (m *mockComputeClient) UnpauseInstance(ctx context.Context, id string) error {
+	m.lifecycle = append(m.lifecycle, "unpause")
+	return nil
+}
+func (m *mockComputeClient) SuspendInstance(ctx context.Context, id string) error {
+	m.lifecycle = append(m.lifecycle, "suspend")
+	return nil
+}
+func (m *mockComputeClient) ResumeInstance(ctx context.Context, id string) error {
+	m.lifecycle = append(m.lifecycle, "resume")
+	return nil
+}
+func (m *mockComputeClient) ShelveInstance(ctx context.Context, id string) error {
+	m.lifecycle = append(m.lifecycle, "shelve")
+	return nil
+}
+func (m *mockComputeClient) UnshelveInstance(ctx context.Context, id string) error {
+	m.lifecycle = append(m.lifecycle, "unshelve")
+	return nil
+}
+func (m *mockComputeClient) RescueInstance(ctx context.Context, id, imageID string) (string, error) {
+	m.lifecycle = append(m.lifecycle, "rescue "+imageID)
+	return "rescue-pass", nil
+}
+func (m *mockComputeClient) UnrescueInstance(ctx context.Context, id string) error {
+	m.lifecycle = append(m.lifecycle, "unrescue")
+	return nil
+}
+func (m *mockComputeClient) LockInstance(ctx context.Context, id string) error {
+	m.lifecycle = append(m.lifecycle, "lock")
+	return nil
+}
+func (m *mockComputeClient) UnlockInstance(ctx context.Context, id string) error {
+	m.lifecycle = append(m.lifecycle, "unlock")
+	return nil
+}
 
 func TestRenderInstancesSuccess(t *testing.T) {
 	mock := &mockComputeClient{
@@ -130,3 +354,1086 @@ func TestRenderInstanceForm(t *testing.T) {
 		t.Fatalf("expected form fields in output, got %s", out)
 	}
 }
+
+func TestBuildQuotaUpdate(t *testing.T) {
+	fields := []quotaField{
+		newQuotaField("Compute", quotaCores, "vCPUs", 4, 20),
+		newQuotaField("Network", quotaPorts, "Ports", 10, 50),
+		newQuotaField("Volume", quotaGigabytes, "Volume GB", 100, 1000),
+	}
+	fields[0].input.SetValue("40")
+	u, err := buildQuotaUpdate(fields)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if u.compute == nil || u.compute.Cores == nil || *u.compute.Cores != 40 {
+		t.Fatalf("expected compute cores update to 40, got %+v", u.compute)
+	}
+	if u.network != nil || u.volume != nil {
+		t.Fatalf("expected no network or volume update, got %+v %+v", u.network, u.volume)
+	}
+
+	fields[1].input.SetValue("abc")
+	if _, err := buildQuotaUpdate(fields); err == nil {
+		t.Fatalf("expected error for invalid limit")
+	}
+}
+
+func TestSummarizeCapacity(t *testing.T) {
+	hvList := []hypervisors.Hypervisor{
+		{VCPUs: 8, VCPUsUsed: 32, MemoryMB: 1024, MemoryMBUsed: 768},
+		{VCPUs: 8, VCPUsUsed: 0, MemoryMB: 1024, MemoryMBUsed: 0},
+	}
+	s := summarizeCapacity(hvList, 4.0, 1.0)
+	if s.vcpusCap != 64 || s.vcpusUsed != 32 || s.cpuPct != 50 {
+		t.Fatalf("unexpected vCPU summary: %+v", s)
+	}
+	if s.memMBCap != 2048 || s.memPct != 37.5 {
+		t.Fatalf("unexpected RAM summary: %+v", s)
+	}
+	if load := hypervisorLoad(hvList[0], 4.0, 1.0); load != 100 {
+		t.Fatalf("expected load 100, got %v", load)
+	}
+}
+
+func TestHypervisorEffectiveFree(t *testing.T) {
+	hvs := []hypervisors.Hypervisor{
+		// 8 pCPUs at 2.0 leave 16 vCPUs for 15 used; 8 GiB less 1 GiB reserved at 1.0.
+		{ID: "1", HypervisorHostname: "cmp-1", VCPUs: 8, VCPUsUsed: 15, MemoryMB: 8192, MemoryMBUsed: 2048},
+		// Not in placement: the configured 16.0 and 1.5 apply.
+		{ID: "2", HypervisorHostname: "cmp-2", VCPUs: 4, VCPUsUsed: 4, MemoryMB: 4096, MemoryMBUsed: 4915},
+	}
+	mock := &mockComputeClient{hypervisors: hvs, ratios: map[string]client.AllocationRatios{
+		"cmp-1": {CPU: 2, RAM: 1, ReservedMB: 1024},
+	}}
+	updated, _ := NewHypervisorsModel(mock, DefaultAllocationRatios()).Update(NewHypervisorsModel(mock, DefaultAllocationRatios()).Init()())
+	m := updated.(HypervisorsModel)
+	rows := m.Table().Rows()
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 rows, got %d", len(rows))
+	}
+	if rows[0][6] != "!! 1" || rows[0][9] != "5.0G" {
+		t.Errorf("cmp-1 free capacity: got vCPU %q, RAM %q", rows[0][6], rows[0][9])
+	}
+	if rows[1][6] != "60" || rows[1][9] != "! 1.2G" {
+		t.Errorf("cmp-2 free capacity: got vCPU %q, RAM %q", rows[1][6], rows[1][9])
+	}
+	view := m.View()
+	for _, want := range []string{"2 hosts over 75%: cmp-1 94% vCPU, cmp-2 80% RAM", "ratios: placement", "per-host ratio"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view should contain %q:\n%s", want, view)
+		}
+	}
+
+	// Without placement every host gets the configured ratios.
+	mock.ratiosErr = errors.New("forbidden")
+	updated, _ = m.Update(m.Init()())
+	m = updated.(HypervisorsModel)
+	if got := m.Table().Rows()[0][6]; got != "113" {
+		t.Errorf("cmp-1 with the configured ratio: got vCPU free %q", got)
+	}
+	if !strings.Contains(m.View(), "ratios: configured") {
+		t.Errorf("the header should say the ratios are configured:\n%s", m.View())
+	}
+}
+
+func TestHypervisorSortKeepsFilter(t *testing.T) {
+	mock := &mockComputeClient{ratiosErr: errors.New("forbidden"), hypervisors: []hypervisors.Hypervisor{
+		{ID: "1", HypervisorHostname: "cmp-1", VCPUs: 8, VCPUsUsed: 2},
+		{ID: "2", HypervisorHostname: "gpu-1", VCPUs: 8, VCPUsUsed: 8},
+		{ID: "3", HypervisorHostname: "cmp-2", VCPUs: 8, VCPUsUsed: 12},
+	}}
+	var m tea.Model = NewHypervisorsModel(mock, client.AllocationRatios{CPU: 2, RAM: 1})
+	m, _ = m.Update(m.Init()())
+	if got := m.(HypervisorsModel).Table().Rows()[0][6]; got != "14" {
+		t.Fatalf("expected the ratio of the model, 8 × 2 - 2 free, got %q", got)
+	}
+	for _, k := range []string{"/", "c", "m", "p", "enter", "s"} {
+		msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+		if k == "enter" {
+			msg = tea.KeyMsg{Type: tea.KeyEnter}
+		}
+		m, _ = m.Update(msg)
+	}
+	rows := m.(HypervisorsModel).Table().Rows()
+	if len(rows) != 2 || rows[0][1] != "cmp-2" || rows[1][1] != "cmp-1" {
+		t.Fatalf("expected the cmp hosts, most loaded first, got %v", rows)
+	}
+	if !strings.Contains(m.View(), "Filter: cmp") {
+		t.Errorf("expected the kept filter above the table:\n%s", m.View())
+	}
+}
+
+func TestRenderFaultBanner(t *testing.T) {
+	srv := servers.Server{Status: "ERROR", Fault: servers.Fault{Code: 500, Message: "No valid host was found."}}
+	out := renderFaultBanner(srv)
+	if !strings.Contains(out, "500") || !strings.Contains(out, "No valid host was found.") {
+		t.Fatalf("expected fault code and message, got %s", out)
+	}
+}
+
+func TestRenderQRCode(t *testing.T) {
+	out, err := renderQRCode("https://console.example.com/vnc_auto.html?token=abc")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	lines := strings.Split(strings.TrimRight(out, "\n"), "\n")
+	if len(lines) < 10 {
+		t.Fatalf("expected a multi-line QR code, got %d lines", len(lines))
+	}
+	width := len([]rune(lines[0]))
+	for i, l := range lines {
+		if len([]rune(l)) != width {
+			t.Fatalf("line %d has width %d, expected %d", i, len([]rune(l)), width)
+		}
+	}
+}
+
+func TestRenderServerGroups(t *testing.T) {
+	mock := &mockComputeClient{
+		getInstance:  servers.Server{Name: "db"},
+		serverGroups: []client.ServerGroup{{Name: "db-cluster", Policy: "anti-affinity", Members: []string{"a", "b", "c"}}},
+		hosts:        map[string]string{"a": "compute-01", "b": "compute-01", "c": "compute-02"},
+	}
+	out := renderServerGroups(mock, "a")
+	for _, want := range []string{"Group: db-cluster", "anti-affinity", "compute-02", "violates anti-affinity"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output, got %s", want, out)
+		}
+	}
+	if out := renderServerGroups(mock, "other"); out != "" {
+		t.Errorf("expected no output for a server outside any group, got %s", out)
+	}
+}
+
+func TestFlavorPickerSearchAndSelect(t *testing.T) {
+	mock := &mockComputeClient{flavors: []flavors.Flavor{
+		{ID: "f-large", Name: "m1.large", VCPUs: 4, RAM: 8192, Disk: 80},
+		{ID: "f-small", Name: "m1.small", VCPUs: 1, RAM: 2048, Disk: 20},
+		{ID: "f-gpu", Name: "g1.xlarge", VCPUs: 8, RAM: 16384, Disk: 160},
+	}}
+	p := NewFlavorPicker(mock, "Pick flavor")
+	var model tea.Model = p
+	// Run the loader synchronously.
+	for _, msg := range runBatch(p.Init()) {
+		model, _ = model.Update(msg)
+	}
+	out := model.View()
+	if strings.Index(out, "m1.small") > strings.Index(out, "m1.large") {
+		t.Errorf("expected flavors sorted by RAM, got %s", out)
+	}
+	for _, r := range "large" {
+		model, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyDown})
+	model, _ = model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	picker := model.(common.PickerModel)
+	it, ok := picker.Selected()
+	if !picker.Done() || !ok || it.ID != "f-gpu" {
+		t.Fatalf("expected g1.xlarge to be selected, got %+v (done=%v)", it, picker.Done())
+	}
+}
+
+// runBatch executes cmd and returns the messages it produces, flattening batches.
+func runBatch(cmd tea.Cmd) []tea.Msg {
+	if cmd == nil {
+		return nil
+	}
+	switch msg := cmd().(type) {
+	case tea.BatchMsg:
+		var out []tea.Msg
+		for _, c := range msg {
+			out = append(out, runBatch(c)...)
+		}
+		return out
+	default:
+		return []tea.Msg{msg}
+	}
+}
+
+func TestRecordTaskState(t *testing.T) {
+	var trail []string
+	var done bool
+	for i, ts := range []string{"", "rebuilding", "rebuilding", "rebuild_spawning", ""} {
+		trail, done = recordTaskState(trail, taskStateMsg{state: client.ServerState{Status: "ACTIVE", TaskState: ts}, polls: i + 1})
+		if done != (i == 4) {
+			t.Fatalf("poll %d: finished=%v", i+1, done)
+		}
+	}
+	if got := strings.Join(trail, " → "); got != "rebuilding → rebuild_spawning → active" {
+		t.Errorf("unexpected trail %q", got)
+	}
+}
+
+func TestAZPairsFlagsMismatches(t *testing.T) {
+	srvs := []servers.Server{{ID: "s1", Name: "web"}, {ID: "s2", Name: "db"}}
+	zones := map[string]string{"s1": "az1", "s2": "az2"}
+	vols := []volumes.Volume{
+		{ID: "v1", Name: "web-data", AvailabilityZone: "az1", Attachments: []volumes.Attachment{{ServerID: "s1", Device: "/dev/vdb"}}},
+		{ID: "v2", Name: "db-data", AvailabilityZone: "az1", Attachments: []volumes.Attachment{{ServerID: "s2", Device: "/dev/vdb"}}},
+		{ID: "v3", Name: "unknown", AvailabilityZone: "", Attachments: []volumes.Attachment{{ServerID: "s2", Device: "/dev/vdc"}}},
+	}
+	pairs := azPairs(srvs, zones, vols)
+	if len(pairs) != 3 {
+		t.Fatalf("expected 3 pairs, got %d", len(pairs))
+	}
+	if !pairs[0].Mismatch() || pairs[0].ServerName != "db" || pairs[0].VolumeID != "v2" {
+		t.Errorf("expected db/db-data mismatch first, got %+v", pairs[0])
+	}
+	for _, p := range pairs[1:] {
+		if p.Mismatch() {
+			t.Errorf("unexpected mismatch %+v", p)
+		}
+	}
+}
+
+func TestInstancesGroupingCollapse(t *testing.T) {
+	mock := &mockComputeClient{
+		listInstances: []servers.Server{
+			{ID: "s1", Name: "web", Status: "ACTIVE"},
+			{ID: "s2", Name: "db", Status: "ERROR"},
+			{ID: "s3", Name: "cache", Status: "ACTIVE"},
+		},
+		zones: map[string]string{"s1": "az1", "s2": "az1"},
+	}
+	var tm tea.Model = NewInstancesModel(mock)
+	tm, _ = tm.Update(tm.Init()())
+	key := func(s string) {
+		var cmd tea.Cmd
+		tm, cmd = tm.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)})
+		for _, msg := range runBatch(cmd) {
+			tm, _ = tm.Update(msg)
+		}
+	}
+
+	key("G")
+	rows := tm.(InstancesModel).Table().Rows()
+	if len(rows) != 5 || rows[0][1] != "▾ ACTIVE" || rows[0][2] != "2" || rows[3][1] != "▾ ERROR" {
+		t.Fatalf("unexpected status groups %v", rows)
+	}
+	if !tm.(InstancesModel).OnGroupHeader() {
+		t.Fatal("cursor should start on the first header")
+	}
+	tm, _ = tm.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	rows = tm.(InstancesModel).Table().Rows()
+	if len(rows) != 3 || rows[0][1] != "▸ ACTIVE" {
+		t.Fatalf("expected ACTIVE collapsed, got %v", rows)
+	}
+
+	key("G")
+	rows = tm.(InstancesModel).Table().Rows()
+	if len(rows) != 5 || rows[0][1] != "▾ az1" || rows[3][1] != "▾ (none)" {
+		t.Fatalf("unexpected zone groups %v", rows)
+	}
+}
+
+func TestInstancesListKeepsOnlyVisibleRows(t *testing.T) {
+	var list []servers.Server
+	for i := range 20000 {
+		list = append(list, servers.Server{ID: fmt.Sprintf("srv-%05d", i), Name: fmt.Sprintf("web-%d", i), Status: "ACTIVE"})
+	}
+	list[12345].Name = "needle"
+	var m tea.Model = NewInstancesModel(&mockComputeClient{listInstances: list})
+	m, _ = m.Update(m.Init()())
+	im := m.(InstancesModel)
+	if n := len(im.Table().Rows()); n == 0 || n > 30 || im.table.Total() != 20000 {
+		t.Fatalf("expected only the rows on screen in the table, got %d of %d", n, im.table.Total())
+	}
+
+	m, _ = m.Update(common.KeyFor("G"))
+	if row := m.(InstancesModel).Table().SelectedRow(); len(row) == 0 || row[0] != "" {
+		t.Fatalf("expected G to group the servers, got %v", row)
+	}
+	m, _ = m.Update(common.KeyFor("end"))
+	m, _ = m.Update(common.KeyFor("up"))
+	selected := m.(InstancesModel).Table().SelectedRow()[0]
+	m, _ = m.Update(m.Init()())
+	if got := m.(InstancesModel).Table().SelectedRow()[0]; got != selected || got != "srv-19998" {
+		t.Errorf("expected srv-19998 still selected after a reload, got %s", got)
+	}
+	if full := m.(InstancesModel).FullTable(); len(full.Rows()) != 20001 || full.Cursor() != 19999 {
+		t.Errorf("expected the jump to see every server and the header, got %d rows at %d", len(full.Rows()), full.Cursor())
+	}
+}
+
+func TestRenderDiagnosticsRates(t *testing.T) {
+	at := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	prev := diagSample{at: at, diag: client.ServerDiagnostics{
+		CPUs: []client.CPUDiagnostics{{ID: 0, TimeNS: 1e9}},
+		NICs: []client.NICDiagnostics{{Name: "tap0", RxBytes: 0, TxBytes: 0}},
+	}}
+	cur := diagSample{at: at.Add(10 * time.Second), diag: client.ServerDiagnostics{
+		Uptime: 7200, MemoryMaxMB: 1024, MemoryUsedMB: 256,
+		CPUs: []client.CPUDiagnostics{{ID: 0, TimeNS: 6e9}},
+		NICs: []client.NICDiagnostics{{Name: "tap0", RxBytes: 20480, TxBytes: 10240}},
+	}}
+	out := renderDiagnostics(cur, &prev)
+	for _, want := range []string{"up 2h 0m", "256 / 1024 MB used (25%)", " 50.0%", "2.0 KiB/s", "1.0 KiB/s", "rates over 10s"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output, got %s", want, out)
+		}
+	}
+	if first := renderDiagnostics(cur, nil); strings.Contains(first, "KiB/s") || strings.Contains(first, "50.0%") {
+		t.Errorf("no rates expected without an earlier sample, got %s", first)
+	}
+}
+
+func TestDrainHostWorkflow(t *testing.T) {
+	mock := &mockComputeClient{
+		listInstances: []servers.Server{{ID: "a", Name: "web-0", Status: "ACTIVE"}, {ID: "b", Name: "db-0", Status: "SHUTOFF"}, {ID: "c", Name: "gpu-0", Status: "ACTIVE"}},
+		hosts:         map[string]string{"a": "compute-01.example", "b": "compute-01.example", "c": "compute-01.example"},
+		services:      []client.ComputeService{{ID: "svc-1", Host: "compute-01", Status: "enabled", State: "up"}},
+		migrateErr:    map[string]error{"c": errors.New("no valid host was found")},
+	}
+	m := NewDrainHostModel(mock, "compute-01")
+	updated, _ := m.Update(m.loadCmd()())
+	m = updated.(DrainHostModel)
+
+	// Migrating needs the service disabled first.
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("m")})
+	m = updated.(DrainHostModel)
+	if cmd != nil || !strings.Contains(m.View(), "Disable the compute service first") {
+		t.Fatalf("expected a refusal while the service is enabled, got %q", m.View())
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+	m = updated.(DrainHostModel)
+	updated, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(DrainHostModel)
+	updated, cmd = m.Update(cmd())
+	m = updated.(DrainHostModel)
+	updated, _ = m.Update(cmd())
+	m = updated.(DrainHostModel)
+	if m.service.Status != "disabled" || m.service.DisabledReason != "maintenance" {
+		t.Fatalf("expected the service disabled for maintenance, got %+v", m.service)
+	}
+
+	// web-0 migrates, db-0 is skipped, gpu-0 is refused by the scheduler.
+	updated, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("m")})
+	m = updated.(DrainHostModel)
+	updated, _ = m.Update(cmd())
+	m = updated.(DrainHostModel)
+	if !m.running || m.current != 0 {
+		t.Fatalf("expected web-0 migrating, got current %d", m.current)
+	}
+	updated, cmd = m.Update(drainPollMsg{index: 0, state: client.ServerState{Status: "ACTIVE"}, host: mock.hosts["a"], polls: 1})
+	m = updated.(DrainHostModel)
+	updated, cmd = m.Update(cmd())
+	m = updated.(DrainHostModel)
+	if m.running || cmd != nil {
+		t.Fatalf("expected the drain to end")
+	}
+	if got := m.summary(); got != "1 migrated, 1 failed, 1 skipped" {
+		t.Errorf("unexpected summary %q", got)
+	}
+	if m.steps[0].detail != "→ compute-02.example" || m.steps[2].detail != "no valid host was found" {
+		t.Errorf("unexpected steps %+v", m.steps)
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")})
+	m = updated.(DrainHostModel)
+	if !strings.Contains(m.View(), "1 servers were not migrated") {
+		t.Errorf("expected the enable prompt to mention the failed server, got %q", m.View())
+	}
+}
+
+func TestDrainPollStaysOnHost(t *testing.T) {
+	st := drainStep{state: drainMigrating}
+	if finishPoll(&st, "compute-01", drainPollMsg{state: client.ServerState{Status: "MIGRATING", TaskState: "migrating"}, polls: 1}) {
+		t.Fatalf("a migrating server is not finished")
+	}
+	if !finishPoll(&st, "compute-01", drainPollMsg{state: client.ServerState{Status: "ACTIVE"}, host: "compute-01.example", polls: 2}) || st.state != drainFailed {
+		t.Errorf("a server still on the host must fail, got %+v", st)
+	}
+	if onHost("compute-10.example", "compute-1") {
+		t.Errorf("compute-10 is not compute-1")
+	}
+}
+
+func TestDeletePreflightRender(t *testing.T) {
+	yes, no := true, false
+	p := deletePreflight{
+		ports: []client.Port{{ID: "port-1", FixedIPs: []ports.IP{{IPAddress: "10.0.0.5"}}}},
+		fips:  []floatingips.FloatingIP{{FloatingIP: "203.0.113.9", PortID: "port-1"}},
+		volumes: []preflightVolume{
+			{ServerVolume: client.ServerVolume{VolumeID: "v1", Device: "/dev/vda", DeleteOnTermination: &yes}, name: "root", size: 20},
+			{ServerVolume: client.ServerVolume{VolumeID: "v2", Device: "/dev/vdb", DeleteOnTermination: &no}, name: "data", size: 100},
+			{ServerVolume: client.ServerVolume{VolumeID: "v3", Device: "/dev/vdc"}, name: "v3"},
+		},
+	}
+	out := p.render("web-1")
+	for _, want := range []string{"remove port 10.0.0.5 (port-1)", "disassociate floating IP 203.0.113.9", "DELETE volume root (20GB)", "detach volume data (100GB) at /dev/vdb; it is left available", "delete_on_termination is not reported"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in\n%s", want, out)
+		}
+	}
+}
+
+func TestDeleteWaitsForPreflight(t *testing.T) {
+	mock := &mockComputeClient{serverStates: map[string]client.ServerState{"s1": {Status: "ERROR", TaskState: "deleting"}}}
+	m := NewInstanceDetailModel(mock, nil, nil, nil, "s1")
+	m.loading = false
+	m.instance = servers.Server{ID: "s1", Name: "broken", Status: "ERROR"}
+	updated, load := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("D")})
+	m = updated.(InstanceDetailModel)
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	m = updated.(InstanceDetailModel)
+	if cmd != nil || m.pendingAction != remediationDelete {
+		t.Fatal("expected y to wait for the pre-flight")
+	}
+	updated, _ = m.Update(load())
+	m = updated.(InstanceDetailModel)
+	if !strings.Contains(m.View(), "Deleting broken will:") {
+		t.Fatalf("expected the pre-flight before the confirmation, got %q", m.View())
+	}
+	updated, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	m = updated.(InstanceDetailModel)
+	enq, ok := cmd().(ops.EnqueueMsg)
+	if !ok || enq.Resource != "s1" || !strings.Contains(m.View(), "delete queued") {
+		t.Fatalf("expected the delete queued, got %#v", enq)
+	}
+	_, poll, err := enq.Work(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if done, status, err := poll(context.Background()); done || status != "deleting" || err != nil {
+		t.Errorf("expected the delete followed while nova runs it, got %v %q %v", done, status, err)
+	}
+	delete(mock.serverStates, "s1")
+	if done, _, err := poll(context.Background()); !done || err != nil {
+		t.Errorf("expected the delete done once the server is gone, got %v %v", done, err)
+	}
+
+	// The detail of a deleted server stays as it was.
+	updated, cmd = m.Update(ops.FinishedMsg{Title: "Delete server broken", Resource: "s1", Result: "deleted"})
+	if cmd != nil || !strings.Contains(updated.View(), "Delete server broken done") {
+		t.Errorf("expected the delete reported, got %q", updated.View())
+	}
+}
+
+func TestServerActionsFollowStatus(t *testing.T) {
+	names := func(acts []common.Action) string {
+		var out []string
+		for _, a := range acts {
+			out = append(out, a.Name)
+		}
+		return strings.Join(out, ",")
+	}
+	shutoff := names(serverActions("SHUTOFF"))
+	if !strings.HasPrefix(shutoff, "start,shelve,") || strings.Contains(shutoff, "stop") || strings.Contains(shutoff, remediationDelete) {
+		t.Errorf("unexpected actions for a stopped server: %s", shutoff)
+	}
+	if broken := names(serverActions("ERROR")); !strings.Contains(broken, remediationHardReboot) || !strings.Contains(broken, remediationDelete) {
+		t.Errorf("expected the remediation actions for a server in ERROR: %s", broken)
+	}
+
+	// An action chosen on the list row runs once the detail has loaded.
+	m := NewInstanceDetailModel(&mockComputeClient{}, nil, nil, nil, "s1")
+	m = m.QueueAction(common.Action{Name: lifecycleStart, Detail: true}).(InstanceDetailModel)
+	updated, _ := m.Update(instanceDetailDataLoadedMsg{instance: servers.Server{ID: "s1", Name: "web-1", Status: "SHUTOFF"}})
+	m = updated.(InstanceDetailModel)
+	if m.pendingAction != lifecycleStart || !strings.Contains(m.View(), "Start server web-1? [y/N]") {
+		t.Fatalf("expected the start to be confirmed, got %q:\n%s", m.pendingAction, m.View())
+	}
+	updated, _ = m.Update(instanceDetailDataLoadedMsg{instance: m.instance})
+	if updated.(InstanceDetailModel).queued != nil {
+		t.Error("a queued action must run once")
+	}
+}
+
+func TestReloadKeepsSelection(t *testing.T) {
+	mock := &mockComputeClient{listInstances: []servers.Server{{ID: "a", Name: "web-1", Status: "ACTIVE"}, {ID: "b", Name: "web-2", Status: "ACTIVE"}, {ID: "c", Name: "web-3", Status: "ACTIVE"}}}
+	var m tea.Model = NewInstancesModel(mock)
+	m, _ = m.Update(m.Init()())
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	// web-1 is deleted and web-3 changes state: the cursor follows web-3.
+	mock.listInstances = []servers.Server{{ID: "b", Name: "web-2", Status: "ACTIVE"}, {ID: "c", Name: "web-3", Status: "SHUTOFF"}}
+	m, _ = m.Update(m.(InstancesModel).Reload()())
+	row := m.(InstancesModel).Table().SelectedRow()
+	if len(row) < 3 || row[0] != "c" || !strings.Contains(row[2], "SHUTOFF") {
+		t.Fatalf("expected the cursor on the reloaded web-3, got %v", row)
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+	if m.(InstancesModel).Reload() != nil {
+		t.Error("r must reach the filter while typing")
+	}
+}
+
+func TestInstancesAgeFilter(t *testing.T) {
+	now := time.Now()
+	mock := &mockComputeClient{listInstances: []servers.Server{
+		{ID: "a", Name: "web-1", Status: "ACTIVE", Created: now.AddDate(0, 0, -200), UserID: "u-alice"},
+		{ID: "b", Name: "web-2", Status: "ACTIVE", Created: now.Add(-30 * time.Minute), UserID: "u-bob"},
+		{ID: "c", Name: "db-1", Status: "SHUTOFF", Created: now.AddDate(0, 0, -95), UserID: "u-bob"},
+	}}
+	var m tea.Model = NewInstancesModel(mock)
+	m, _ = m.Update(m.Init()())
+	if v := m.View(); !strings.Contains(v, "200d") || !strings.Contains(v, "30m") || !strings.Contains(v, "u-alice") {
+		t.Fatalf("expected the age and creator of each server, got %q", v)
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+	for _, r := range "age>90d u-bob" {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	if got := m.(InstancesModel).filtered(); len(got) != 1 || got[0].ID != "c" {
+		t.Fatalf("expected only db-1, created by u-bob 95 days ago, got %v", got)
+	}
+}
+
+func TestStatusPollWatchesTransitionalServers(t *testing.T) {
+	defer func(d time.Duration) { statusPollInterval = d }(statusPollInterval)
+	statusPollInterval = time.Millisecond
+	mock := &mockComputeClient{listInstances: []servers.Server{
+		{ID: "a", Name: "web-1", Status: "ACTIVE"},
+		{ID: "b", Name: "web-2", Status: "BUILD"},
+		{ID: "c", Name: "web-3", Status: "REBOOT"},
+	}}
+	var m tea.Model = NewInstancesModel(mock)
+	m, cmd := m.Update(m.Init()())
+	if cmd == nil {
+		t.Fatal("expected a poll while servers are in transition")
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	// Only web-2 and web-3 are polled: web-2 is up, web-3 starts deleting.
+	mock.serverStates = map[string]client.ServerState{"b": {Status: "ACTIVE"}, "c": {Status: "ACTIVE", TaskState: "deleting"}}
+	seq := m.(InstancesModel).pollSeq
+	m, cmd = m.Update(statusPollTickMsg{seq: seq})
+	m, cmd = m.Update(cmd())
+	im := m.(InstancesModel)
+	if got := im.pendingServers(); len(got) != 1 || got[0] != "c" {
+		t.Fatalf("expected only web-3 to stay watched, got %v", got)
+	}
+	if cmd == nil || !strings.Contains(im.View(), "web-3 (deleting)") {
+		t.Fatalf("expected web-3 to be watched while deleting:\n%s", im.View())
+	}
+	// web-3 is gone: its row is dropped and polling stops.
+	delete(mock.serverStates, "c")
+	m, cmd = m.Update(cmd())
+	m, cmd = m.Update(cmd())
+	im = m.(InstancesModel)
+	if cmd != nil || len(im.Table().Rows()) != 2 || strings.Contains(im.View(), "Watching") {
+		t.Fatalf("expected web-3 removed and polling stopped, got %v", im.Table().Rows())
+	}
+	if row := im.Table().SelectedRow(); row[0] != "b" || !strings.Contains(row[2], "ACTIVE") {
+		t.Errorf("expected web-2 updated in place, got %v", row)
+	}
+	// Ticks of an earlier load are dropped.
+	if _, cmd := m.Update(statusPollTickMsg{seq: seq - 1}); cmd != nil {
+		t.Error("stale tick must not poll")
+	}
+}
+
+// typeKeys sends s to m one key at a time, then enter.
+func typeKeys(m InstanceDetailModel, s string) (InstanceDetailModel, tea.Cmd) {
+	for _, r := range s {
+		updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		m = updated.(InstanceDetailModel)
+	}
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	return updated.(InstanceDetailModel), cmd
+}
+
+func TestGetServerPassword(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, _ := rsa.EncryptPKCS1v15(rand.Reader, &key.PublicKey, []byte("Passw0rd"))
+	keyPath := filepath.Join(t.TempDir(), "id_rsa")
+	if err := os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	m := NewInstanceDetailModel(&mockComputeClient{password: base64.StdEncoding.EncodeToString(data)}, nil, nil, nil, "s1")
+	m.loading = false
+	m.instance = servers.Server{ID: "s1", Name: "win-1", Status: "ACTIVE"}
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("W")})
+	m = updated.(InstanceDetailModel)
+	m.passwordPrompt.form.SetValue(0, "")
+	m, cmd := typeKeys(m, keyPath)
+	updated, _ = m.Update(cmd())
+	m = updated.(InstanceDetailModel)
+	if !m.CapturingInput() || !strings.Contains(m.View(), "Admin password of win-1: Passw0rd") {
+		t.Fatalf("expected the decrypted password, got %q", m.View())
+	}
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	m = updated.(InstanceDetailModel)
+	if m.shownPassword != "" || strings.Contains(m.View(), "Passw0rd") {
+		t.Fatal("expected any key to forget the password")
+	}
+}
+
+func TestChangeAdminPassword(t *testing.T) {
+	mock := &mockComputeClient{}
+	m := NewInstanceDetailModel(mock, nil, nil, nil, "s1")
+	m.loading = false
+	m.instance = servers.Server{ID: "s1", Name: "win-1", Status: "ACTIVE"}
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("C")})
+	m = updated.(InstanceDetailModel)
+	m, _ = typeKeys(m, "one")
+	m, cmd := typeKeys(m, "two")
+	if cmd != nil || !strings.Contains(m.View(), "do not match") {
+		t.Fatalf("expected a mismatch error, got %q", m.View())
+	}
+	m.passwordPrompt.form.SetValue(1, "one")
+	m, cmd = typeKeys(m, "")
+	updated, _ = m.Update(cmd())
+	m = updated.(InstanceDetailModel)
+	if mock.adminPassword != "one" || !strings.Contains(m.View(), "Admin password changed") {
+		t.Fatalf("expected the password changed, got %q", m.View())
+	}
+}
+
+// imageStub answers GetImage with the next of statuses and ListImages with
+// list.
+type imageStub struct {
+	client.ImageClient
+	statuses []string
+	list     []images.Image
+}
+
+func (s *imageStub) ListImages(ctx context.Context) ([]images.Image, error) {
+	return s.list, nil
+}
+
+func (s *imageStub) GetImage(ctx context.Context, id string) (*images.Image, error) {
+	status := s.statuses[0]
+	s.statuses = s.statuses[1:]
+	return &images.Image{ID: id, Status: status, Progress: 50}, nil
+}
+
+func TestCreateServerImage(t *testing.T) {
+	mock := &mockComputeClient{}
+	stub := &imageStub{statuses: []string{"SAVING", "ACTIVE"}}
+	m := NewInstanceDetailModel(mock, nil, nil, stub, "s1")
+	m.loading = false
+	m.instance = servers.Server{ID: "s1", Name: "web-1", Status: "SHELVED_OFFLOADED"}
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("I")})
+	m = updated.(InstanceDetailModel)
+	if m.imageForm != nil || !strings.Contains(m.View(), "Cannot create an image") {
+		t.Fatalf("a shelved server cannot be snapshotted, got %q", m.View())
+	}
+
+	m.instance.Status = "ACTIVE"
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("I")})
+	m = updated.(InstanceDetailModel)
+	m.imageForm.SetValue(imageFieldName, "golden")
+	m, _ = typeKeys(m, "")
+	m, cmd := typeKeys(m, "bad")
+	if cmd != nil || !strings.Contains(m.View(), "not key=value") {
+		t.Fatalf("expected a metadata error, got %q", m.View())
+	}
+	m.imageForm.SetValue(imageFieldMetadata, "os_distro=ubuntu, owner = ops")
+	m, cmd = typeKeys(m, "")
+	updated, _ = m.Update(cmd())
+	m = updated.(InstanceDetailModel)
+	if mock.imageMeta["os_distro"] != "ubuntu" || mock.imageMeta["owner"] != "ops" {
+		t.Fatalf("unexpected metadata %v", mock.imageMeta)
+	}
+
+	// The image is followed until Glance reports it active.
+	for _, want := range []string{"saving 50%", "Image golden is active"} {
+		updated, _ = m.Update(pollServerImageCmd(stub, "img-golden", 0, 0)())
+		m = updated.(InstanceDetailModel)
+		if !strings.Contains(m.View(), want) {
+			t.Fatalf("expected %q, got %q", want, m.View())
+		}
+	}
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("o")})
+	if msg, ok := cmd().(OpenImageMsg); !ok || msg.ImageID != "img-golden" {
+		t.Fatalf("expected a jump to the new image, got %#v", msg)
+	}
+}
+
+func TestLifecycleActions(t *testing.T) {
+	yes, no := true, false
+	for _, tc := range []struct {
+		state client.ServerState
+		want  string
+	}{
+		{client.ServerState{Status: "ACTIVE", Locked: &no}, "stop pause suspend shelve rescue lock"},
+		{client.ServerState{Status: "SHUTOFF", Locked: &no}, "start shelve rescue lock"},
+		{client.ServerState{Status: "RESCUE", Locked: &no}, "stop unrescue lock"},
+		{client.ServerState{Status: "ERROR", Locked: &no}, "stop rescue lock"},
+		{client.ServerState{Status: "PAUSED", Locked: &no}, "unpause shelve lock"},
+		{client.ServerState{Status: "SUSPENDED", Locked: &no}, "resume shelve lock"},
+		{client.ServerState{Status: "SHELVED_OFFLOADED", Locked: &no}, "unshelve lock"},
+		{client.ServerState{Status: "ACTIVE", Locked: &yes}, "unlock"},
+		{client.ServerState{Status: "ACTIVE", TaskState: "pausing", Locked: &no}, "lock"},
+		{client.ServerState{Status: "SHUTOFF"}, "start shelve rescue lock unlock"},
+	} {
+		var got []string
+		for _, a := range lifecycleActions(tc.state) {
+			got = append(got, a.action)
+		}
+		if strings.Join(got, " ") != tc.want {
+			t.Errorf("%+v: got %v, want %s", tc.state, got, tc.want)
+		}
+	}
+}
+
+func TestLifecycleMenu(t *testing.T) {
+	mock := &mockComputeClient{getInstance: servers.Server{ID: "s1", Name: "web-1", Status: "SHUTOFF"}}
+	m := NewInstanceDetailModel(mock, nil, nil, nil, "s1")
+	m.loading = false
+	m.instance = mock.getInstance
+	updated, load := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("L")})
+	m = updated.(InstanceDetailModel)
+	updated, _ = m.Update(load())
+	m = updated.(InstanceDetailModel)
+	if !strings.Contains(m.View(), "[s] start  [h] shelve  [r] rescue  [k] lock") || strings.Contains(m.View(), "pause") {
+		t.Fatalf("expected only the SHUTOFF transitions, got %q", m.View())
+	}
+	// A key of an action that is not offered does nothing.
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("p")})
+	m = updated.(InstanceDetailModel)
+	if m.pendingAction != "" || m.lifecycle == nil {
+		t.Fatal("expected pause to be unavailable while SHUTOFF")
+	}
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("h")})
+	m = updated.(InstanceDetailModel)
+	if !strings.Contains(m.View(), "Shelve server web-1? [y/N]") {
+		t.Fatalf("expected the shelve confirmation, got %q", m.View())
+	}
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	if msg, ok := cmd().(remediationDoneMsg); !ok || msg.action != lifecycleShelve || len(mock.lifecycle) != 1 {
+		t.Fatalf("expected the shelve to run, got %#v", msg)
+	}
+}
+
+func TestRescueFromChosenImage(t *testing.T) {
+	mock := &mockComputeClient{}
+	stub := &imageStub{list: []images.Image{
+		{ID: "img-old", Name: "old", Status: "DELETED"},
+		{ID: "img-rescue", Name: "rescue-tools", Status: "ACTIVE", MinDisk: 2},
+	}}
+	m := NewInstanceDetailModel(mock, nil, nil, stub, "s1")
+	m.loading = false
+	m.instance = servers.Server{ID: "s1", Name: "web-1", Status: "ACTIVE"}
+	m.lifecycle = &lifecycleMenu{actions: lifecycleActions(client.ServerState{Status: "ACTIVE"})}
+	updated, load := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	m = updated.(InstanceDetailModel)
+	if m.rescuePicker == nil || load == nil {
+		t.Fatal("expected the rescue image picker")
+	}
+	for _, msg := range runBatch(load) {
+		updated, _ = m.Update(msg)
+		m = updated.(InstanceDetailModel)
+	}
+	if v := m.View(); !strings.Contains(v, "(cloud default)") || !strings.Contains(v, "rescue-tools") || strings.Contains(v, "img-old") {
+		t.Fatalf("expected the default and the active images, got %q", v)
+	}
+	for _, k := range []tea.KeyMsg{{Type: tea.KeyDown}, {Type: tea.KeyEnter}} {
+		updated, _ = m.Update(k)
+		m = updated.(InstanceDetailModel)
+	}
+	if !strings.Contains(m.View(), "Rescue server web-1 from image rescue-tools? [y/N]") {
+		t.Fatalf("expected the rescue confirmation, got %q", m.View())
+	}
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	if msg, ok := cmd().(rescueDoneMsg); !ok || msg.password != "rescue-pass" || strings.Join(mock.lifecycle, ",") != "rescue img-rescue" {
+		t.Fatalf("expected a rescue from the chosen image, got %#v %v", msg, mock.lifecycle)
+	}
+
+	// A server in rescue mode shows the banner, and U leaves it.
+	m.pendingAction = ""
+	m.instance.Status = "RESCUE"
+	if !strings.Contains(m.View(), "⚠ RESCUE") || !strings.Contains(m.View(), "[U] unrescue") {
+		t.Fatalf("expected the rescue banner, got %q", m.View())
+	}
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("U")})
+	if msg, ok := cmd().(remediationDoneMsg); !ok || msg.action != lifecycleUnrescue || mock.lifecycle[1] != "unrescue" {
+		t.Fatalf("expected the unrescue to run, got %#v %v", msg, mock.lifecycle)
+	}
+}
+
+func TestVerifyResizeReminders(t *testing.T) {
+	resized := servers.Server{ID: "b", Name: "db-1", Status: "VERIFY_RESIZE", Flavor: map[string]interface{}{"id": "f2", "original_name": "m1.large"}}
+	mock := &mockComputeClient{listInstances: []servers.Server{{ID: "a", Name: "web-1", Status: "ACTIVE"}, resized}}
+	var m tea.Model = NewInstancesModel(mock)
+	m, _ = m.Update(m.Init()())
+	if v := m.View(); !strings.Contains(v, "1 server waits for a resize to be confirmed: db-1 (m1.large)") {
+		t.Fatalf("expected the reminder below the list, got %q", v)
+	}
+	// A and V only act on a server waiting for its resize.
+	if _, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("A")}); cmd != nil {
+		t.Fatal("expected A to do nothing on an active server")
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("A")})
+	if msg, ok := cmd().(remediationDoneMsg); !ok || msg.action != lifecycleConfirmResize || strings.Join(mock.lifecycle, ",") != "confirm resize" {
+		t.Fatalf("expected the resize of db-1 confirmed, got %#v %v", msg, mock.lifecycle)
+	}
+	// V asks first, like the other destructive actions; n drops it.
+	m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("V")})
+	if cmd != nil || !m.(InstancesModel).ConfirmingDestructive() || !strings.Contains(m.View(), "Revert the resize of server db-1? [y/N]") {
+		t.Fatalf("expected V to ask before reverting, got %q", m.View())
+	}
+	m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	if cmd != nil || m.(InstancesModel).CapturingInput() || len(mock.lifecycle) != 1 {
+		t.Fatalf("expected n to drop the revert, got %v", mock.lifecycle)
+	}
+
+	// The detail shows a banner, and V reverts.
+	d := NewInstanceDetailModel(mock, nil, nil, nil, "b")
+	d.loading = false
+	d.instance = resized
+	if v := d.View(); !strings.Contains(v, "⚠ VERIFY_RESIZE  db-1 runs on flavor m1.large") || !strings.Contains(v, "[V] revert") {
+		t.Fatalf("expected the resize banner, got %q", v)
+	}
+	updated, cmd := d.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("V")})
+	if cmd != nil || !updated.(InstanceDetailModel).ConfirmingDestructive() {
+		t.Fatal("expected V to ask before reverting")
+	}
+	_, cmd = updated.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	if msg, ok := cmd().(remediationDoneMsg); !ok || msg.action != lifecycleRevertResize || mock.lifecycle[1] != "revert resize" {
+		t.Fatalf("expected the resize reverted, got %#v %v", msg, mock.lifecycle)
+	}
+}
+
+func TestRenderName(t *testing.T) {
+	for _, tc := range []struct {
+		pattern, want string
+		n             int
+	}{
+		{"prod-{name}", "prod-web", 1},
+		{"{name}-old", "web-old", 1},
+		{"web-{n}", "web-7", 7},
+		{"web-{n:3}", "web-007", 7},
+	} {
+		if got, err := renderName(tc.pattern, "web", tc.n); err != nil || got != tc.want {
+			t.Errorf("%s: got %q, %v", tc.pattern, got, err)
+		}
+	}
+	for _, bad := range []string{"{id}", "{n:x}", "{n:0}"} {
+		if _, err := renderName(bad, "web", 1); err == nil {
+			t.Errorf("%s: expected an error", bad)
+		}
+	}
+}
+
+func TestBulkEdit(t *testing.T) {
+	list := []servers.Server{
+		{ID: "s1", Name: "a", Metadata: map[string]string{"env": "prod"}},
+		{ID: "s2", Name: "b"},
+		{ID: "s3", Name: "web-03", Metadata: map[string]string{"env": "prod"}},
+	}
+	mock := &mockComputeClient{updateErr: map[string]error{"s2": errors.New("quota")}}
+	m := NewBulkEditModel(mock, list, "")
+	m.form.SetValue(0, "web-{n:2}")
+	m.form.SetValue(2, "env")
+	m.form.SetValue(3, "prod")
+	for i := 0; i < 4; i++ {
+		updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		m = updated.(BulkEditModel)
+	}
+	if m.form != nil {
+		t.Fatalf("expected the preview, got the form:\n%s", m.View())
+	}
+	rows := m.Table().Rows()
+	if rows[0][2] != "web-01" || rows[1][3] != "env: (unset) → prod" || rows[2][2] != "=" || rows[2][4] != bulkUnchanged {
+		t.Fatalf("unexpected preview %v", rows)
+	}
+	if mock.renamed != nil {
+		t.Fatal("expected nothing sent before y")
+	}
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	m = updated.(BulkEditModel)
+	for cmd != nil {
+		updated, cmd = m.Update(cmd())
+		m = updated.(BulkEditModel)
+	}
+	if mock.renamed["s1"] != "web-01" || mock.metadataSet["s1"] != nil || mock.metadataSet["s2"] != nil {
+		t.Fatalf("unexpected calls: renamed %v, metadata %v", mock.renamed, mock.metadataSet)
+	}
+	if !strings.Contains(m.View(), "1 updated, 1 failed, 1 unchanged") {
+		t.Fatalf("expected the summary, got:\n%s", m.View())
+	}
+}
+
+func TestUsageByUser(t *testing.T) {
+	srvs := []servers.Server{
+		{ID: "s1", UserID: "u1", Flavor: map[string]interface{}{"vcpus": float64(4), "ram": float64(8192)}},
+		{ID: "s2", UserID: "u1", Flavor: map[string]interface{}{"id": "small"}},
+		{ID: "s3", UserID: "u2", Flavor: map[string]interface{}{"id": "gone"}},
+	}
+	vols := []volumes.Volume{{ID: "v1", UserID: "u2", Size: 50}, {ID: "v2", Size: 10}}
+	fl := []flavors.Flavor{{ID: "small", VCPUs: 1, RAM: 2048}}
+	rows := usageByUser(srvs, vols, fl, map[string]string{"u1": "alice"})
+	if len(rows) != 3 {
+		t.Fatalf("expected 3 users, got %+v", rows)
+	}
+	if r := rows[0]; r.user != "alice" || r.servers != 2 || r.vcpus != 5 || r.ramMB != 10240 || r.unresized {
+		t.Errorf("unexpected first row %+v", r)
+	}
+	if r := rows[2]; r.user != "u2" || r.servers != 1 || r.volumeGB != 50 || !r.unresized {
+		t.Errorf("unexpected last row %+v", r)
+	}
+	if out := renderUserUsage(rows); !strings.Contains(out, "(unknown)") || !strings.Contains(out, "0*") {
+		t.Errorf("unexpected table:\n%s", out)
+	}
+}
+
+func TestUsageHistory(t *testing.T) {
+	t.Cleanup(func() { usageHistory = map[string][]float64{} })
+	for i := 0; i < historyLen+5; i++ {
+		recordUsage([]limitRow{{name: "vCPUs", pct: float64(i % 101)}})
+	}
+	if h := usageHistory["vCPUs"]; len(h) != historyLen || h[0] != 5 {
+		t.Fatalf("expected the last %d samples, got %v", historyLen, h)
+	}
+	if got := sparkline([]float64{0, 50, 100, 150}); got != "▁▄██" {
+		t.Errorf("sparkline = %q", got)
+	}
+}
+
+func TestRateRows(t *testing.T) {
+	l := &client.Limits{
+		ComputeRate: []vLimits.Rate{{URI: "*", Limit: []vLimits.Limit{{Verb: "POST", Value: 10, Unit: "MINUTE", Remaining: 9}}}},
+		Volume:      &vLimits.Limits{Rate: []vLimits.Rate{{URI: "/volumes", Limit: []vLimits.Limit{{Verb: "DELETE", Value: 60, Unit: "MINUTE"}}}}},
+	}
+	rows := rateRows(l)
+	if len(rows) != 2 || rows[0].service != "compute" || rows[0].remaining != 9 || rows[1].service != "volume" || rows[1].uri != "/volumes" {
+		t.Fatalf("unexpected rate rows %+v", rows)
+	}
+}
+
+func TestConsoleScreen(t *testing.T) {
+	s := newConsoleScreen()
+	s.write([]byte("login: roox\bt\r\nPassword: \r\n\x1b[1;32mok\x1b"))
+	s.write([]byte("[0m\tdone\r\nprogress 10%\rprogress 100%\r\nabc\x1b[2D\x1b[K\xc3"))
+	s.write([]byte("\xa8"))
+	want := "login: root\nPassword: \nok      done\nprogress 100%\naè"
+	if got := s.tail(10); got != want {
+		t.Fatalf("screen =\n%q\nwant\n%q", got, want)
+	}
+	if got := s.tail(2); got != "progress 100%\naè" {
+		t.Errorf("tail(2) = %q", got)
+	}
+	s.write([]byte("\x1b[2J"))
+	if got := s.tail(10); got != "" {
+		t.Errorf("expected a cleared screen, got %q", got)
+	}
+}
+
+// fakeConsole records keystrokes; reads wait until it is closed.
+type fakeConsole struct {
+	written []byte
+	done    chan struct{}
+}
+
+func (f *fakeConsole) Read(p []byte) (int, error) { <-f.done; return 0, io.EOF }
+func (f *fakeConsole) Write(p []byte) (int, error) {
+	f.written = append(f.written, p...)
+	return len(p), nil
+}
+func (f *fakeConsole) Close() error { close(f.done); return nil }
+
+func TestSerialConsoleKeys(t *testing.T) {
+	con := &fakeConsole{done: make(chan struct{})}
+	m := NewSerialConsoleModel(&mockComputeClient{console: con}, "s1", "web")
+	updated, _ := m.Update(serialConsoleOpenedMsg{conn: con})
+	m = updated.(SerialConsoleModel)
+	updated, _ = m.Update(serialConsoleOutputMsg{conn: con, data: []byte("web login: ")})
+	m = updated.(SerialConsoleModel)
+	for _, k := range []tea.KeyMsg{
+		{Type: tea.KeyRunes, Runes: []rune("q")},
+		{Type: tea.KeyEsc},
+		{Type: tea.KeyCtrlC},
+		{Type: tea.KeyUp},
+		{Type: tea.KeyEnter},
+	} {
+		updated, cmd := m.Update(k)
+		m = updated.(SerialConsoleModel)
+		if cmd != nil {
+			cmd()
+		}
+	}
+	if got := string(con.written); got != "q\x1b\x03\x1b[A\r" {
+		t.Fatalf("sent %q", got)
+	}
+	if !strings.Contains(m.View(), "web login: ") {
+		t.Fatalf("expected the console output, got:\n%s", m.View())
+	}
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlCloseBracket})
+	if _, ok := cmd().(serialConsoleClosedMsg); !ok {
+		t.Fatal("expected ctrl+] to close the console")
+	}
+	select {
+	case <-con.done:
+	default:
+		t.Error("expected the connection closed")
+	}
+
+	detail := NewInstanceDetailModel(&mockComputeClient{}, nil, nil, nil, "s1")
+	detail.loading = false
+	updated, _ = detail.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("S")})
+	detail = updated.(InstanceDetailModel)
+	if !detail.PassingKeysThrough() {
+		t.Fatal("expected S to open the serial console")
+	}
+	updated, _ = detail.Update(serialConsoleOpenedMsg{err: errors.New("serial console disabled")})
+	if v := updated.View(); !strings.Contains(v, "serial console disabled") {
+		t.Fatalf("expected the error, got:\n%s", v)
+	}
+}
+
+func TestRebuildReviewsUserData(t *testing.T) {
+	press := func(m InstanceDetailModel, msg tea.Msg) (InstanceDetailModel, tea.Cmd) {
+		updated, cmd := m.Update(msg)
+		return updated.(InstanceDetailModel), cmd
+	}
+	ctrlS := tea.KeyMsg{Type: tea.KeyCtrlS}
+	y := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")}
+
+	mock := &mockComputeClient{userData: "#cloud-config\nhostname: web-1\npackages:\n  - nginx\n"}
+	m := NewInstanceDetailModel(mock, nil, nil, nil, "s1")
+	m.loading = false
+	m.instance = servers.Server{ID: "s1", Name: "web-1", Status: "ACTIVE", Image: map[string]interface{}{"id": "img-1"}}
+	m, cmd := press(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("R")})
+	m, _ = press(m, cmd())
+	if m.rebuild == nil || m.rebuild.area.Value() != mock.userData {
+		t.Fatalf("expected the user data in the editor, got %q", m.View())
+	}
+	m.rebuild.area.SetValue("#cloud-config\nhostname: web-1\npackages:\n  - nginx\n  - chrony\n")
+	m, _ = press(m, ctrlS)
+	if !m.ConfirmingDestructive() || !strings.Contains(m.View(), "+   - chrony") || strings.Contains(m.View(), "- hostname") {
+		t.Fatalf("expected a diff adding chrony, got %q", m.View())
+	}
+	m, cmd = press(m, y)
+	if msg := cmd().(remediationDoneMsg); msg.err != nil || mock.rebuiltWith == nil || !strings.HasSuffix(*mock.rebuiltWith, "  - chrony\n") {
+		t.Fatalf("expected a rebuild with the new user data, got %v", msg.err)
+	}
+
+	// Without the role to read it, an untouched editor keeps the user data.
+	mock = &mockComputeClient{userDataErr: client.ErrUserDataHidden}
+	m = NewInstanceDetailModel(mock, nil, nil, nil, "s1")
+	m.loading = false
+	m.instance = servers.Server{ID: "s1", Name: "web-1", Status: "SHUTOFF", Image: map[string]interface{}{"id": "img-1"}}
+	m, cmd = press(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("R")})
+	m, _ = press(m, cmd())
+	m, _ = press(m, ctrlS)
+	if !strings.Contains(m.View(), "keeping its user data") {
+		t.Fatalf("expected a plain rebuild, got %q", m.View())
+	}
+	_, cmd = press(m, y)
+	if msg := cmd().(remediationDoneMsg); msg.err != nil || mock.rebuiltWith != nil {
+		t.Fatalf("expected a rebuild without user data, got %v", msg.err)
+	}
+}
+
+func TestRenderServerGroupsUnknownHosts(t *testing.T) {
+	// Without the admin role the hypervisor is hidden; a member without
+	// host ID leaves the placement unknown rather than violated.
+	mock := &mockComputeClient{
+		getInstance:  servers.Server{Name: "web"},
+		serverGroups: []client.ServerGroup{{Name: "web", Policy: "affinity", Members: []string{"a", "b", "c"}}},
+		hosts:        map[string]string{"a": "compute-01", "b": "compute-01"},
+	}
+	out := renderServerGroups(mock, "a")
+	if strings.Contains(out, "violates") || !strings.Contains(out, "placement unknown") {
+		t.Errorf("expected the placement unknown, got %s", out)
+	}
+}
@@ -0,0 +1,39 @@
+package compute
+
+import (
+	"strings"
+
+	"github.com/skip2/go-qrcode"
+)
+
+// renderQRCode renders text as a QR code using half-block characters so that
+// each terminal row holds two module rows. Light modules are drawn as filled
+// blocks, which keeps the code scannable on the usual dark terminal background.
+func renderQRCode(text string) (string, error) {
+	qr, err := qrcode.New(text, qrcode.Low)
+	if err != nil {
+		return "", err
+	}
+	bitmap := qr.Bitmap() // true = dark module; includes the quiet zone
+	light := func(row, col int) bool {
+		return row >= len(bitmap) || !bitmap[row][col]
+	}
+	var sb strings.Builder
+	for row := 0; row < len(bitmap); row += 2 {
+		for col := range bitmap[row] {
+			top, bottom := light(row, col), light(row+1, col)
+			switch {
+			case top && bottom:
+				sb.WriteString("█")
+			case top:
+				sb.WriteString("▀")
+			case bottom:
+				sb.WriteString("▄")
+			default:
+				sb.WriteString(" ")
+			}
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String(), nil
+}
@@ -0,0 +1,127 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/layer3/floatingips"
+	"ostui/internal/client"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/theme"
+)
+
+// preflightVolume is an attached volume with the details shown before a
+// delete.
+type preflightVolume struct {
+	client.ServerVolume
+	name string
+	size int
+}
+
+// deletePreflight lists what deleting a server releases, detaches or leaves
+// behind, so the confirmation is made knowing the side effects.
+type deletePreflight struct {
+	ports   []client.Port
+	fips    []floatingips.FloatingIP
+	volumes []preflightVolume
+	// errs are lookups that failed; the summary is then incomplete.
+	errs []string
+}
+
+// deletePreflightMsg carries the pre-flight of a server delete.
+type deletePreflightMsg struct{ preflight deletePreflight }
+
+// loadDeletePreflightCmd gathers the ports, floating IPs and volumes of a
+// server. A failed lookup is reported in the summary rather than blocking
+// the delete.
+func loadDeletePreflightCmd(cc client.ComputeClient, nc client.NetworkClient, sc client.StorageClient, serverID string) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		var p deletePreflight
+		if nc != nil {
+			ports, err := nc.ListPortsByServer(ctx, serverID)
+			if err != nil {
+				p.errs = append(p.errs, "ports: "+err.Error())
+			}
+			p.ports = ports
+			if len(ports) > 0 {
+				fips, err := nc.ListFloatingIPs()
+				if err != nil {
+					p.errs = append(p.errs, "floating IPs: "+err.Error())
+				}
+				onServer := make(map[string]bool)
+				for _, port := range ports {
+					onServer[port.ID] = true
+				}
+				for _, f := range fips {
+					if onServer[f.PortID] {
+						p.fips = append(p.fips, f)
+					}
+				}
+			}
+		}
+		vols, err := cc.ListServerVolumes(ctx, serverID)
+		if err != nil {
+			p.errs = append(p.errs, "volumes: "+err.Error())
+		}
+		for _, v := range vols {
+			pv := preflightVolume{ServerVolume: v, name: v.VolumeID}
+			if sc != nil {
+				if vol, err := sc.GetVolume(v.VolumeID); err == nil {
+					if vol.Name != "" {
+						pv.name = vol.Name
+					}
+					pv.size = vol.Size
+				}
+			}
+			p.volumes = append(p.volumes, pv)
+		}
+		return deletePreflightMsg{preflight: p}
+	}
+}
+
+// render summarises the side effects of deleting serverName.
+func (p deletePreflight) render(serverName string) string {
+	danger := lipgloss.NewStyle().Foreground(theme.Error)
+	warn := lipgloss.NewStyle().Foreground(theme.Warn)
+	dim := lipgloss.NewStyle().Foreground(theme.Muted)
+	var b strings.Builder
+	b.WriteString(i18n.T("Deleting %s will:", serverName) + "\n")
+	for _, port := range p.ports {
+		ip := ""
+		if len(port.FixedIPs) > 0 {
+			ip = port.FixedIPs[0].IPAddress + " "
+		}
+		b.WriteString(i18n.T("  remove port %s(%s)", ip, port.ID) + "\n")
+	}
+	if len(p.ports) > 0 {
+		b.WriteString(dim.Render("    "+i18n.T("Nova deletes the ports it created; ports passed in at boot are detached and kept.")) + "\n")
+	}
+	for _, f := range p.fips {
+		b.WriteString(warn.Render(i18n.T("  disassociate floating IP %s; it stays allocated to the project", f.FloatingIP)) + "\n")
+	}
+	for _, v := range p.volumes {
+		label := v.name
+		if v.size > 0 {
+			label += fmt.Sprintf(" (%dGB)", v.size)
+		}
+		switch {
+		case v.DeleteOnTermination == nil:
+			b.WriteString(warn.Render(i18n.T("  detach volume %s at %s; delete_on_termination is not reported by this cloud", label, v.Device)) + "\n")
+		case *v.DeleteOnTermination:
+			b.WriteString(danger.Render(i18n.T("  DELETE volume %s at %s (delete_on_termination)", label, v.Device)) + "\n")
+		default:
+			b.WriteString(i18n.T("  detach volume %s at %s; it is left available", label, v.Device) + "\n")
+		}
+	}
+	if len(p.ports) == 0 && len(p.fips) == 0 && len(p.volumes) == 0 && len(p.errs) == 0 {
+		b.WriteString("  " + i18n.T("release no ports, floating IPs or volumes") + "\n")
+	}
+	for _, e := range p.errs {
+		b.WriteString(danger.Render("  could not check "+e) + "\n")
+	}
+	return b.String()
+}
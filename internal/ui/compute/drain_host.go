@@ -0,0 +1,499 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
+	"ostui/internal/client"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/common"
+	"ostui/internal/ui/theme"
+	"ostui/internal/ui/uiconst"
+)
+
+// OpenDrainHostMsg asks the app to open the maintenance workflow of a
+// compute host, named as in the compute service list.
+type OpenDrainHostMsg struct {
+	Host string
+}
+
+// Progress of one server in a host drain.
+const (
+	drainPending   = "pending"
+	drainMigrating = "migrating"
+	drainDone      = "migrated"
+	drainFailed    = "failed"
+	drainSkipped   = "skipped"
+)
+
+// drainStep is one server on the host being drained.
+type drainStep struct {
+	server servers.Server
+	state  string
+	detail string
+}
+
+// DrainHostModel guides a compute host through maintenance: disable its
+// nova-compute service, live-migrate its servers away one at a time while
+// tracking each migration, and re-enable the service afterwards.
+type DrainHostModel struct {
+	table   table.Model
+	loading bool
+	err     error
+	spinner spinner.Model
+	client  client.ComputeClient
+	host    string
+	service *client.ComputeService
+	steps   []drainStep
+
+	// running is set while migrations are submitted; current is the step
+	// being migrated or -1. pausing stops after the current migration.
+	running bool
+	pausing bool
+	current int
+
+	form          *common.FormModel
+	pendingEnable bool
+	status        string
+	statusErr     bool
+
+	width  int
+	height int
+}
+
+// NewDrainHostModel creates the maintenance workflow for a compute host.
+func NewDrainHostModel(cc client.ComputeClient, host string) DrainHostModel {
+	s := spinner.New()
+	s.Spinner = spinner.Dot
+	return DrainHostModel{client: cc, host: host, loading: true, spinner: s, current: -1, width: 120, height: 30}
+}
+
+// ResourceID returns the compute host name.
+func (m DrainHostModel) ResourceID() string { return m.host }
+
+type drainLoadedMsg struct {
+	service *client.ComputeService
+	servers []servers.Server
+	err     error
+}
+
+// drainServiceMsg reports an enable or disable of the compute service.
+type drainServiceMsg struct {
+	status string
+	err    error
+}
+
+// drainServiceLoadedMsg carries the service record reloaded afterwards.
+type drainServiceLoadedMsg struct {
+	service client.ComputeService
+	err     error
+}
+
+// drainMigrateMsg reports whether a live migration was accepted.
+type drainMigrateMsg struct {
+	index int
+	err   error
+}
+
+// drainPollMsg carries one poll of a migrating server.
+type drainPollMsg struct {
+	index int
+	state client.ServerState
+	host  string
+	err   error
+	polls int
+}
+
+// Init loads the compute service and the servers on the host.
+func (m DrainHostModel) Init() tea.Cmd {
+	return tea.Batch(m.spinner.Tick, m.loadCmd())
+}
+
+func (m DrainHostModel) loadCmd() tea.Cmd {
+	cc, host := m.client, m.host
+	return func() tea.Msg {
+		ctx := context.Background()
+		list, err := cc.ListComputeServices(ctx, host)
+		if err != nil {
+			return drainLoadedMsg{err: err}
+		}
+		if len(list) == 0 {
+			return drainLoadedMsg{err: fmt.Errorf("no nova-compute service on host %s", host)}
+		}
+		srvs, err := cc.ListHostInstances(ctx, host)
+		if err != nil {
+			return drainLoadedMsg{err: err}
+		}
+		return drainLoadedMsg{service: &list[0], servers: srvs}
+	}
+}
+
+// onHost reports whether a hypervisor hostname belongs to a compute service
+// host, which is often its short name (compute-01 vs compute-01.example).
+func onHost(hypervisor, host string) bool {
+	return hypervisor == host || strings.HasPrefix(hypervisor, host+".")
+}
+
+// drainSteps plans the drain: running and paused servers are live-migrated;
+// others cannot be and are skipped.
+func drainSteps(list []servers.Server) []drainStep {
+	steps := make([]drainStep, 0, len(list))
+	for _, s := range list {
+		st := drainStep{server: s, state: drainPending}
+		if s.Status != "ACTIVE" && s.Status != "PAUSED" {
+			st.state, st.detail = drainSkipped, s.Status+": not live-migratable, needs a cold migration"
+		}
+		steps = append(steps, st)
+	}
+	return steps
+}
+
+// nextPending returns the index of the next server to migrate, or -1.
+func (m DrainHostModel) nextPending() int {
+	for i, st := range m.steps {
+		if st.state == drainPending {
+			return i
+		}
+	}
+	return -1
+}
+
+// migrateNext submits the next live migration, or ends the run.
+func (m DrainHostModel) migrateNext() (DrainHostModel, tea.Cmd) {
+	i := m.nextPending()
+	if i < 0 || m.pausing {
+		m.running, m.pausing, m.current = false, false, -1
+		m.status, m.statusErr = m.summary(), false
+		return m, nil
+	}
+	m.current = i
+	m.steps[i].state, m.steps[i].detail = drainMigrating, "submitted"
+	cc, id := m.client, m.steps[i].server.ID
+	return m, func() tea.Msg {
+		return drainMigrateMsg{index: i, err: cc.LiveMigrateInstance(context.Background(), id, "")}
+	}
+}
+
+// pollCmd checks a migrating server after taskPollInterval.
+func (m DrainHostModel) pollCmd(i, polls int) tea.Cmd {
+	cc, id := m.client, m.steps[i].server.ID
+	return tea.Tick(taskPollInterval, func(time.Time) tea.Msg {
+		ctx := context.Background()
+		st, err := cc.GetServerState(ctx, id)
+		if err != nil {
+			return drainPollMsg{index: i, err: err, polls: polls + 1}
+		}
+		host, err := cc.GetServerHypervisor(ctx, id)
+		return drainPollMsg{index: i, state: st, host: host, err: err, polls: polls + 1}
+	})
+}
+
+// finishPoll updates a step from a poll and reports whether its migration
+// has ended.
+func finishPoll(st *drainStep, host string, msg drainPollMsg) bool {
+	if msg.err != nil {
+		st.state, st.detail = drainFailed, msg.err.Error()
+		return true
+	}
+	if msg.state.TaskState != "" || msg.state.Status == "MIGRATING" {
+		if msg.polls >= maxTaskPolls {
+			st.state, st.detail = drainFailed, "still "+strings.ToLower(msg.state.Status)+" after the watch timed out"
+			return true
+		}
+		st.detail = "in progress"
+		if msg.state.TaskState != "" {
+			st.detail = msg.state.TaskState
+		}
+		return false
+	}
+	switch {
+	case msg.state.Status == "ERROR":
+		st.state, st.detail = drainFailed, "server went to ERROR"
+	case onHost(msg.host, host):
+		st.state, st.detail = drainFailed, "still on this host (see the instance actions)"
+	default:
+		st.state, st.detail = drainDone, "→ "+msg.host
+	}
+	return true
+}
+
+// counts returns the number of steps in each state.
+func (m DrainHostModel) counts() map[string]int {
+	n := map[string]int{}
+	for _, st := range m.steps {
+		n[st.state]++
+	}
+	return n
+}
+
+// summary describes the outcome of the drain so far.
+func (m DrainHostModel) summary() string {
+	n := m.counts()
+	s := i18n.T("%d migrated, %d failed, %d skipped", n[drainDone], n[drainFailed], n[drainSkipped])
+	if n[drainPending] > 0 {
+		s += i18n.T(", %d left", n[drainPending])
+	}
+	return s
+}
+
+// Update handles messages for the model.
+func (m DrainHostModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case drainLoadedMsg:
+		m.loading = false
+		m.err = msg.err
+		if msg.err == nil {
+			m.service = msg.service
+			m.steps = drainSteps(msg.servers)
+			m.refreshTable()
+		}
+		return m, nil
+	case drainServiceMsg:
+		m.status, m.statusErr = msg.status, msg.err != nil
+		if msg.err != nil {
+			m.status = msg.err.Error()
+			return m, nil
+		}
+		cc, host := m.client, m.host
+		return m, func() tea.Msg {
+			list, err := cc.ListComputeServices(context.Background(), host)
+			if err == nil && len(list) == 0 {
+				err = fmt.Errorf("no nova-compute service on host %s", host)
+			}
+			if err != nil {
+				return drainServiceLoadedMsg{err: err}
+			}
+			return drainServiceLoadedMsg{service: list[0]}
+		}
+	case drainServiceLoadedMsg:
+		if msg.err != nil {
+			m.status, m.statusErr = i18n.T("Reload service status: %s", msg.err), true
+			return m, nil
+		}
+		m.service = &msg.service
+		return m, nil
+	case drainMigrateMsg:
+		if msg.index != m.current {
+			return m, nil
+		}
+		if msg.err != nil {
+			m.steps[msg.index].state, m.steps[msg.index].detail = drainFailed, msg.err.Error()
+			updated, cmd := m.migrateNext()
+			updated.refreshTable()
+			return updated, cmd
+		}
+		m.steps[msg.index].detail = "accepted"
+		m.refreshTable()
+		return m, m.pollCmd(msg.index, 0)
+	case drainPollMsg:
+		if msg.index != m.current {
+			return m, nil
+		}
+		if !finishPoll(&m.steps[msg.index], m.host, msg) {
+			m.refreshTable()
+			return m, m.pollCmd(msg.index, msg.polls)
+		}
+		updated, cmd := m.migrateNext()
+		updated.refreshTable()
+		return updated, cmd
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		if !m.loading && m.err == nil {
+			m.refreshTable()
+		}
+		return m, nil
+	case tea.KeyMsg:
+		if m.form != nil {
+			return m.updateForm(msg)
+		}
+		if m.pendingEnable {
+			m.pendingEnable = false
+			if msg.String() != "y" {
+				return m, nil
+			}
+			return m, m.setEnabledCmd(true, "")
+		}
+		if m.loading || m.err != nil {
+			return m, nil
+		}
+		switch msg.String() {
+		case "d":
+			if m.service.Status == "enabled" {
+				f := common.NewForm([]string{i18n.T("Disable reason")})
+				f.SetValue(0, "maintenance")
+				m.form = &f
+				return m, f.Init()
+			}
+			return m, nil
+		case "m":
+			if m.running {
+				return m, nil
+			}
+			if m.service.Status == "enabled" {
+				m.status, m.statusErr = i18n.T("Disable the compute service first (d) so nothing is scheduled back onto the host"), true
+				return m, nil
+			}
+			if m.nextPending() < 0 {
+				m.status, m.statusErr = i18n.T("Nothing left to migrate: %s", m.summary()), false
+				return m, nil
+			}
+			m.running = true
+			m.status = ""
+			updated, cmd := m.migrateNext()
+			updated.refreshTable()
+			return updated, cmd
+		case "p":
+			if m.running {
+				m.pausing = true
+			}
+			return m, nil
+		case "e":
+			if !m.running && m.service.Status == "disabled" {
+				m.pendingEnable = true
+			}
+			return m, nil
+		case "r":
+			if m.running {
+				return m, nil
+			}
+			m.loading = true
+			return m, tea.Batch(m.spinner.Tick, m.loadCmd())
+		}
+		var cmd tea.Cmd
+		m.table, cmd = m.table.Update(msg)
+		return m, cmd
+	default:
+		if m.loading {
+			var cmd tea.Cmd
+			m.spinner, cmd = m.spinner.Update(msg)
+			return m, cmd
+		}
+	}
+	return m, nil
+}
+
+// setEnabledCmd enables or disables the compute service of the host.
+func (m DrainHostModel) setEnabledCmd(enabled bool, reason string) tea.Cmd {
+	cc, id, host := m.client, m.service.ID, m.host
+	return func() tea.Msg {
+		err := cc.SetComputeServiceEnabled(context.Background(), id, enabled, reason)
+		status := i18n.T("Disabled nova-compute on %s", host)
+		if enabled {
+			status = i18n.T("Enabled nova-compute on %s", host)
+		}
+		return drainServiceMsg{status: status, err: err}
+	}
+}
+
+// updateForm handles keys for the disable reason form.
+func (m DrainHostModel) updateForm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	fm, cmd := m.form.Update(msg)
+	f := fm.(common.FormModel)
+	m.form = &f
+	switch {
+	case f.Cancelled():
+		m.form = nil
+	case f.Submitted():
+		reason := strings.TrimSpace(f.Values()[0])
+		if reason == "" {
+			m.form.SetError(fmt.Errorf("a reason is required, it is shown to other operators"))
+			return m, nil
+		}
+		m.form = nil
+		return m, m.setEnabledCmd(false, reason)
+	}
+	return m, cmd
+}
+
+// CapturingInput reports whether the form or the enable prompt is open.
+func (m DrainHostModel) CapturingInput() bool { return m.form != nil || m.pendingEnable }
+
+// refreshTable rebuilds the table from the drain steps.
+func (m *DrainHostModel) refreshTable() {
+	idW, statusW := uiconst.ColWidthUUID, uiconst.ColWidthStatus
+	rest := m.width - idW - statusW - 10 - uiconst.TableHeightOffset
+	if rest < 50 {
+		rest = 50
+	}
+	nameW := rest / 3
+	cols := []table.Column{{Title: "ID", Width: idW}, {Title: i18n.T("Name"), Width: nameW}, {Title: i18n.T("Status"), Width: statusW}, {Title: i18n.T("Drain"), Width: 10}, {Title: i18n.T("Detail"), Width: rest - nameW}}
+	rows := make([]table.Row, 0, len(m.steps))
+	for _, st := range m.steps {
+		rows = append(rows, table.Row{st.server.ID, st.server.Name, st.server.Status, st.state, st.detail})
+	}
+	cursor := m.table.Cursor()
+	m.table = table.New(table.WithColumns(cols), table.WithFocused(true))
+	m.table.SetStyles(table.DefaultStyles())
+	m.table.SetRows(rows)
+	m.table.SetHeight(m.height - uiconst.TableHeightOffset - 6)
+	if m.current >= 0 {
+		cursor = m.current
+	}
+	if cursor < len(rows) {
+		m.table.SetCursor(cursor)
+	}
+}
+
+// View renders the service state, the drain progress and the servers.
+func (m DrainHostModel) View() string {
+	if m.form != nil {
+		return i18n.T("Disable nova-compute on %s – the scheduler stops placing servers there", m.host) + "\n\n" + m.form.View() + "\n" + i18n.T("[enter] disable  [esc] cancel")
+	}
+	if m.loading {
+		return m.spinner.View()
+	}
+	if m.err != nil {
+		return i18n.T("Error: %s", m.err)
+	}
+	title := lipgloss.NewStyle().Bold(true).Foreground(theme.Accent)
+	dim := lipgloss.NewStyle().Foreground(theme.Muted)
+	svc := lipgloss.NewStyle().Foreground(theme.OK).Render(i18n.T("● enabled"))
+	if m.service.Status != "enabled" {
+		svc = lipgloss.NewStyle().Foreground(theme.Warn).Render(i18n.T("● disabled"))
+		if m.service.DisabledReason != "" {
+			svc += dim.Render(" (" + m.service.DisabledReason + ")")
+		}
+	}
+	n := m.counts()
+	movable := len(m.steps) - n[drainSkipped]
+	pct := 0.0
+	if movable > 0 {
+		pct = float64(n[drainDone]+n[drainFailed]) / float64(movable) * 100
+	}
+	out := title.Render("Maintenance of "+m.host) + "  nova-compute " + svc + dim.Render("  state "+m.service.State) + "\n"
+	out += i18n.T("%s %d/%d handled · %s", renderBar(pct), n[drainDone]+n[drainFailed], movable, m.summary()) + "\n"
+	out += m.table.View()
+	switch {
+	case m.pendingEnable:
+		prompt := "Re-enable nova-compute on " + m.host + "?"
+		if left := n[drainPending] + n[drainFailed]; left > 0 {
+			prompt = i18n.T("Re-enable nova-compute on %s? %d servers were not migrated.", m.host, left)
+		}
+		out += "\n" + prompt + " [y/N]"
+	case m.running && m.pausing:
+		out += "\n" + dim.Render(i18n.T("Pausing after the current migration…"))
+	case m.running:
+		out += "\n" + dim.Render(i18n.T("Live-migrating %s…", m.steps[m.current].server.Name))
+	case m.statusErr:
+		out += "\n" + lipgloss.NewStyle().Foreground(theme.Error).Render(m.status)
+	case m.status != "":
+		out += "\n" + lipgloss.NewStyle().Foreground(theme.OK).Render(m.status)
+	}
+	help := i18n.T("[d] disable service  [m] migrate servers away  [e] re-enable  [r] refresh  [esc] back")
+	if m.running {
+		help = i18n.T("[p] pause after the current migration  [esc] back (migrations in flight continue)")
+	}
+	return out + "\n" + help
+}
+
+// Table returns the underlying table model.
+func (m DrainHostModel) Table() table.Model { return m.table }
+
+var _ tea.Model = (*DrainHostModel)(nil)
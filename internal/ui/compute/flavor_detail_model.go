@@ -7,6 +7,8 @@ import (
 	"github.com/charmbracelet/bubbles/table"
 	tea "github.com/charmbracelet/bubbletea"
 	"ostui/internal/client"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/common"
 	"ostui/internal/ui/uiconst"
 )
 
@@ -40,7 +42,7 @@ func (m FlavorDetailModel) Init() tea.Cmd {
 		if err != nil {
 			return flavorDetailDataLoadedMsg{err: err}
 		}
-		cols := []table.Column{{Title: "Field", Width: uiconst.ColWidthField}, {Title: "Value", Width: uiconst.ColWidthValue}}
+		cols := []table.Column{{Title: i18n.T("Field"), Width: uiconst.ColWidthField}, {Title: i18n.T("Value"), Width: uiconst.ColWidthValue}}
 		rows := []table.Row{{"ID", f.ID}, {"Name", f.Name}, {"VCPUs", fmt.Sprintf("%d", f.VCPUs)}, {"RAM (MB)", fmt.Sprintf("%d", f.RAM)}, {"Disk (GB)", fmt.Sprintf("%d", f.Disk)}, {"Swap", fmt.Sprintf("%d", f.Swap)}, {"IsPublic", fmt.Sprintf("%v", f.IsPublic)}}
 		t := table.New(
 			table.WithColumns(cols),
@@ -52,6 +54,14 @@ func (m FlavorDetailModel) Init() tea.Cmd {
 	}
 }
 
+// Reload re-fetches the flavor, keeping the selected row.
+func (m FlavorDetailModel) Reload() tea.Cmd {
+	if m.loading {
+		return nil
+	}
+	return m.Init()
+}
+
 // Update handles messages.
 func (m FlavorDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
@@ -61,7 +71,7 @@ func (m FlavorDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.err = msg.err
 			return m, nil
 		}
-		m.table = msg.tbl
+		m.table = common.Reloaded(m.table, msg.tbl)
 		return m, nil
 	case tea.WindowSizeMsg:
 		if !m.loading && len(m.table.Columns()) > 0 {
@@ -104,9 +114,9 @@ func (m FlavorDetailModel) View() string {
 		return m.spinner.View()
 	}
 	if m.err != nil {
-		return fmt.Sprintf("Error: %s", m.err)
+		return i18n.T("Error: %s", m.err)
 	}
-	return fmt.Sprintf("%s\n[esc] back", m.table.View())
+	return i18n.T("%s\n[esc] back", m.table.View())
 }
 
 // Table returns the underlying table model.
@@ -0,0 +1,39 @@
+package compute
+
+import (
+	"fmt"
+	"sort"
+
+	"ostui/internal/client"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/common"
+)
+
+// NewFlavorPicker returns a picker listing the flavors visible to the
+// project, smallest first. The selected item's ID is the flavor ID.
+func NewFlavorPicker(cc client.ComputeClient, title string) common.PickerModel {
+	cols := []common.PickerColumn{{Title: i18n.T("Name"), Width: 24}, {Title: i18n.T("vCPUs"), Width: 6}, {Title: "RAM", Width: 9}, {Title: i18n.T("Disk"), Width: 7}, {Title: "ID", Width: 36}}
+	return common.NewPicker(title, cols, func() ([]common.PickerItem, error) {
+		fl, err := cc.ListFlavors()
+		if err != nil {
+			return nil, err
+		}
+		sort.SliceStable(fl, func(i, j int) bool {
+			if fl[i].RAM != fl[j].RAM {
+				return fl[i].RAM < fl[j].RAM
+			}
+			return fl[i].VCPUs < fl[j].VCPUs
+		})
+		items := make([]common.PickerItem, 0, len(fl))
+		for _, f := range fl {
+			items = append(items, common.PickerItem{ID: f.ID, Cells: []string{
+				f.Name,
+				fmt.Sprintf("%d", f.VCPUs),
+				fmt.Sprintf("%d MB", f.RAM),
+				fmt.Sprintf("%d GB", f.Disk),
+				f.ID,
+			}})
+		}
+		return items, nil
+	})
+}
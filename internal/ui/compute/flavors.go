@@ -7,6 +7,8 @@ import (
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"ostui/internal/client"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/common"
 	"ostui/internal/ui/uiconst"
 	"strings"
 )
@@ -51,7 +53,7 @@ func (m FlavorsModel) Init() tea.Cmd {
 		if err != nil {
 			return flavorsDataLoadedMsg{err: err}
 		}
-		cols := []table.Column{{Title: "ID", Width: uiconst.ColWidthUUID}, {Title: "Name", Width: uiconst.ColWidthName}, {Title: "VCPUs", Width: uiconst.ColWidthProtocol}, {Title: "RAM (MB)", Width: uiconst.ColWidthEnabled}, {Title: "Disk (GB)", Width: uiconst.ColWidthEnabled}}
+		cols := []table.Column{{Title: "ID", Width: uiconst.ColWidthUUID}, {Title: i18n.T("Name"), Width: uiconst.ColWidthName}, {Title: i18n.T("VCPUs"), Width: uiconst.ColWidthProtocol}, {Title: i18n.T("RAM (MB)"), Width: uiconst.ColWidthEnabled}, {Title: i18n.T("Disk (GB)"), Width: uiconst.ColWidthEnabled}}
 		rows := []table.Row{}
 		for _, f := range flavorList {
 			rows = append(rows, table.Row{f.ID, f.Name, fmt.Sprintf("%d", f.VCPUs), fmt.Sprintf("%d", f.RAM), fmt.Sprintf("%d", f.Disk)})
@@ -67,6 +69,14 @@ func (m FlavorsModel) Init() tea.Cmd {
 	}
 }
 
+// Reload re-fetches the flavors, keeping the selected row.
+func (m FlavorsModel) Reload() tea.Cmd {
+	if m.loading || m.filterMode {
+		return nil
+	}
+	return m.Init()
+}
+
 // Update handles messages for the model, including data load, window resize,
 // and key handling for filtering.
 func (m FlavorsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -77,7 +87,7 @@ func (m FlavorsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.err = msg.err
 			return m, nil
 		}
-		m.table = msg.tbl
+		m.table = common.Reloaded(m.table, msg.tbl)
 		m.allRows = msg.rows
 		m.updateTableColumns()
 		m.table.SetHeight(m.height - uiconst.TableHeightOffset)
@@ -148,10 +158,10 @@ func (m FlavorsModel) View() string {
 		return m.spinner.View()
 	}
 	if m.err != nil {
-		return fmt.Sprintf("Error: %s", m.err)
+		return i18n.T("Error: %s", m.err)
 	}
 	if m.filterMode {
-		filterLine := fmt.Sprintf("Filter: %s", m.filter.View())
+		filterLine := i18n.T("Filter: %s", m.filter.View())
 		footer := "esc: clear"
 		return fmt.Sprintf("%s\n%s\n%s", filterLine, m.table.View(), footer)
 	}
@@ -169,10 +179,13 @@ func (m *FlavorsModel) updateTableColumns() {
 	if nameW < 10 {
 		nameW = 10
 	}
-	m.table.SetColumns([]table.Column{{Title: "ID", Width: idW}, {Title: "Name", Width: nameW}, {Title: "VCPUs", Width: vcpusW}, {Title: "RAM (MB)", Width: ramW}, {Title: "Disk (GB)", Width: diskW}})
+	m.table.SetColumns([]table.Column{{Title: "ID", Width: idW}, {Title: i18n.T("Name"), Width: nameW}, {Title: i18n.T("VCPUs"), Width: vcpusW}, {Title: i18n.T("RAM (MB)"), Width: ramW}, {Title: i18n.T("Disk (GB)"), Width: diskW}})
 }
 
 // Table returns the underlying table model for external callers.
 func (m FlavorsModel) Table() table.Model { return m.table }
 
+// Filtering reports whether keys are typed into the filter.
+func (m FlavorsModel) Filtering() bool { return m.filterMode }
+
 var _ tea.Model = (*FlavorsModel)(nil)
@@ -0,0 +1,148 @@
+package compute
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/hypervisors"
+	"ostui/internal/client"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/theme"
+)
+
+// UtilWarnPct and UtilCritPct are the utilisation thresholds, in percent of
+// a host's effective capacity, past which the host is flagged. They can be
+// overridden from the command line.
+var (
+	UtilWarnPct = 75.0
+	UtilCritPct = 90.0
+)
+
+// hostRatios returns the allocation ratios placement reports for hv,
+// falling back to the configured ratios for what it does not report.
+func hostRatios(hv hypervisors.Hypervisor, placement map[string]client.AllocationRatios, configured client.AllocationRatios) client.AllocationRatios {
+	r := placement[hv.HypervisorHostname]
+	if r.CPU <= 0 {
+		r.CPU = configured.CPU
+	}
+	if r.RAM <= 0 {
+		r.RAM = configured.RAM
+	}
+	return r
+}
+
+// hostCapacity is the effective capacity of a host: its physical vCPUs and
+// RAM less the reserved amounts, times the allocation ratios. Free is what
+// the scheduler can still place there.
+type hostCapacity struct {
+	cpuCap, memCap   float64
+	cpuFree, memFree float64
+	cpuPct, memPct   float64
+}
+
+// effectiveCapacity computes the capacity of hv under the ratios r.
+func effectiveCapacity(hv hypervisors.Hypervisor, r client.AllocationRatios) hostCapacity {
+	c := hostCapacity{
+		cpuCap: float64(hv.VCPUs-r.ReservedVCPUs) * r.CPU,
+		memCap: float64(hv.MemoryMB-r.ReservedMB) * r.RAM,
+	}
+	c.cpuFree = c.cpuCap - float64(hv.VCPUsUsed)
+	c.memFree = c.memCap - float64(hv.MemoryMBUsed)
+	if c.cpuCap > 0 {
+		c.cpuPct = float64(hv.VCPUsUsed) / c.cpuCap * 100
+	}
+	if c.memCap > 0 {
+		c.memPct = float64(hv.MemoryMBUsed) / c.memCap * 100
+	}
+	return c
+}
+
+// load is the higher of the vCPU and RAM utilisation.
+func (c hostCapacity) load() float64 { return max(c.cpuPct, c.memPct) }
+
+// utilMark flags a utilisation past the thresholds in a table cell: "!"
+// past the warning one, "!!" past the critical one.
+func utilMark(pct float64) string {
+	switch {
+	case pct >= UtilCritPct:
+		return "!! "
+	case pct >= UtilWarnPct:
+		return "! "
+	}
+	return ""
+}
+
+// utilColor colors a utilisation against the thresholds.
+func utilColor(pct float64) lipgloss.Color {
+	switch {
+	case pct >= UtilCritPct:
+		return theme.Error
+	case pct >= UtilWarnPct:
+		return theme.Warn
+	}
+	return theme.OK
+}
+
+// freeCells renders the effective free vCPUs and RAM of a host, flagged
+// past the thresholds. Overcommitted hosts show a negative free amount.
+func freeCells(c hostCapacity) (cpu, ram string) {
+	return utilMark(c.cpuPct) + fmt.Sprintf("%.0f", c.cpuFree), utilMark(c.memPct) + fmt.Sprintf("%.1fG", c.memFree/1024)
+}
+
+// hotHost is a host past the warning threshold.
+type hotHost struct {
+	name     string
+	resource string
+	pct      float64
+}
+
+// hotHosts returns the hosts past the warning threshold, most loaded first.
+func hotHosts(hvList []hypervisors.Hypervisor, placement map[string]client.AllocationRatios, configured client.AllocationRatios) []hotHost {
+	var out []hotHost
+	for _, hv := range hvList {
+		c := effectiveCapacity(hv, hostRatios(hv, placement, configured))
+		h := hotHost{name: hv.HypervisorHostname, resource: "vCPU", pct: c.cpuPct}
+		if c.memPct > c.cpuPct {
+			h.resource, h.pct = "RAM", c.memPct
+		}
+		if h.pct >= UtilWarnPct {
+			out = append(out, h)
+		}
+	}
+	sort.SliceStable(out, func(a, b int) bool { return out[a].pct > out[b].pct })
+	return out
+}
+
+// hotHostsLine names the hosts past the warning threshold, colored by the
+// most loaded one, e.g. "3 hosts over 75%: cmp-1 96% RAM, ...".
+func hotHostsLine(hot []hotHost) string {
+	if len(hot) == 0 {
+		return lipgloss.NewStyle().Foreground(theme.Muted).Render(i18n.T("No host over %.0f%% of its effective capacity", UtilWarnPct))
+	}
+	const shown = 4
+	parts := make([]string, 0, shown+1)
+	for i, h := range hot {
+		if i == shown {
+			parts = append(parts, i18n.T("+%d more", len(hot)-shown))
+			break
+		}
+		parts = append(parts, fmt.Sprintf("%s %.0f%% %s", h.name, h.pct, h.resource))
+	}
+	noun := "hosts"
+	if len(hot) == 1 {
+		noun = "host"
+	}
+	line := i18n.T("%d %s over %.0f%%: %s", len(hot), noun, UtilWarnPct, strings.Join(parts, ", "))
+	return lipgloss.NewStyle().Foreground(utilColor(hot[0].pct)).Render(line)
+}
+
+// ratioText renders an allocation ratio of the summary; 0 means the hosts
+// have different ratios.
+func ratioText(r float64) string {
+	if r == 0 {
+		return "per-host ratio"
+	}
+	return fmt.Sprintf("%.1f", r)
+}
@@ -10,6 +10,8 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/hypervisors"
 	"ostui/internal/client"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/common"
 	"ostui/internal/ui/uiconst"
 	"time"
 )
@@ -53,7 +55,7 @@ func (m HypervisorDetailModel) Init() tea.Cmd {
 			return hypervisorDetailDataLoadedMsg{err: err}
 		}
 		// Build a two‑column table: split fields into two columns, with resource bars for VCPUs and Memory.
-		cols := []table.Column{{Title: "Field", Width: uiconst.ColWidthField}, {Title: "Value", Width: uiconst.ColWidthValueShort}, {Title: "Field", Width: uiconst.ColWidthField}, {Title: "Value", Width: uiconst.ColWidthValueShort}}
+		cols := []table.Column{{Title: i18n.T("Field"), Width: uiconst.ColWidthField}, {Title: i18n.T("Value"), Width: uiconst.ColWidthValueShort}, {Title: i18n.T("Field"), Width: uiconst.ColWidthField}, {Title: i18n.T("Value"), Width: uiconst.ColWidthValueShort}}
 		rows := []table.Row{{"ID", hv.ID}, {"Hostname", hv.HypervisorHostname}, {"State", hv.State}, {"Status", hv.Status}, {"VCPUs", func() string {
 			if hv.VCPUs == 0 {
 				return "N/A"
@@ -72,7 +74,7 @@ func (m HypervisorDetailModel) Init() tea.Cmd {
 			return fmt.Sprintf("%s %d/%d GB", bar, usedGB, totalGB)
 		}()}, {"Disk GB", fmt.Sprintf("%d", hv.LocalGB)}, {"Disk Used", fmt.Sprintf("%d", hv.LocalGBUsed)}, {"Free RAM MB", fmt.Sprintf("%d", hv.FreeRamMB)}, {"Free Disk GB", fmt.Sprintf("%d", hv.FreeDiskGB)}, {"Host IP", hv.HostIP}, {"Current Workload", fmt.Sprintf("%d", hv.CurrentWorkload)}, {"Running VMs", fmt.Sprintf("%d", hv.RunningVMs)}}
 		// Add timestamp for when data was fetched.
-		rows = append(rows, table.Row{"Fetched", time.Now().Format(time.RFC3339)})
+		rows = append(rows, table.Row{i18n.T("Fetched"), time.Now().Format(time.RFC3339)})
 		// Split rows into two columns.
 		half := (len(rows) + 1) / 2
 		newRows := []table.Row{}
@@ -96,6 +98,14 @@ func (m HypervisorDetailModel) Init() tea.Cmd {
 	}
 }
 
+// Reload re-fetches the hypervisor, keeping the selected row.
+func (m HypervisorDetailModel) Reload() tea.Cmd {
+	if m.loading {
+		return nil
+	}
+	return m.Init()
+}
+
 // Update handles messages for the model.
 func (m HypervisorDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
@@ -105,7 +115,7 @@ func (m HypervisorDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.err = msg.err
 			return m, nil
 		}
-		m.table = msg.tbl
+		m.table = common.Reloaded(m.table, msg.tbl)
 		m.hypervisor = msg.hv
 		return m, nil
 	case tea.WindowSizeMsg:
@@ -163,7 +173,7 @@ func (m HypervisorDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		if msg.String() == "i" {
 			// Build inspect view for hypervisor.
-			content := fmt.Sprintf("=== Hypervisor: %s ===\nID: %s\nHostname: %s\nState: %s\nStatus: %s\nVCPUs: %d\nVCPUs Used: %d\nRAM MB: %d\nRAM Used: %d\nDisk GB: %d\nDisk Used: %d\nFree RAM MB: %d\nFree Disk GB: %d\nHost IP: %s\nCurrent Workload: %d\nRunning VMs: %d\nFetched: %s", m.hypervisor.ID, m.hypervisor.ID, m.hypervisor.HypervisorHostname, m.hypervisor.State, m.hypervisor.Status, m.hypervisor.VCPUs, m.hypervisor.VCPUsUsed, m.hypervisor.MemoryMB, m.hypervisor.MemoryMBUsed, m.hypervisor.LocalGB, m.hypervisor.LocalGBUsed, m.hypervisor.FreeRamMB, m.hypervisor.FreeDiskGB, m.hypervisor.HostIP, m.hypervisor.CurrentWorkload, m.hypervisor.RunningVMs, time.Now().Format(time.RFC3339))
+			content := i18n.T("=== Hypervisor: %s ===\nID: %s\nHostname: %s\nState: %s\nStatus: %s\nVCPUs: %d\nVCPUs Used: %d\nRAM MB: %d\nRAM Used: %d\nDisk GB: %d\nDisk Used: %d\nFree RAM MB: %d\nFree Disk GB: %d\nHost IP: %s\nCurrent Workload: %d\nRunning VMs: %d\nFetched: %s", m.hypervisor.ID, m.hypervisor.ID, m.hypervisor.HypervisorHostname, m.hypervisor.State, m.hypervisor.Status, m.hypervisor.VCPUs, m.hypervisor.VCPUsUsed, m.hypervisor.MemoryMB, m.hypervisor.MemoryMBUsed, m.hypervisor.LocalGB, m.hypervisor.LocalGBUsed, m.hypervisor.FreeRamMB, m.hypervisor.FreeDiskGB, m.hypervisor.HostIP, m.hypervisor.CurrentWorkload, m.hypervisor.RunningVMs, time.Now().Format(time.RFC3339))
 			m.inspectView = content
 			m.inspectViewport = viewport.New(80, 24)
 			m.inspectViewport.SetContent(m.inspectView)
@@ -199,15 +209,15 @@ func (m HypervisorDetailModel) View() string {
 		return m.spinner.View()
 	}
 	if m.inspectView != "" {
-		return fmt.Sprintf("%s\n %3.f%% | [j/k] scroll  [esc] close", m.inspectViewport.View(), m.inspectViewport.ScrollPercent()*100)
+		return i18n.T("%s\n %3.f%% | [j/k] scroll  [esc] close", m.inspectViewport.View(), m.inspectViewport.ScrollPercent()*100)
 	}
 	if m.jsonView != "" {
-		return fmt.Sprintf("%s\nPress 'y' or 'esc' to close", m.jsonViewport.View())
+		return i18n.T("%s\nPress 'y' or 'esc' to close", m.jsonViewport.View())
 	}
 	if m.err != nil {
-		return fmt.Sprintf("Error: %s", m.err)
+		return i18n.T("Error: %s", m.err)
 	}
-	return fmt.Sprintf("%s\n[y] json  [i] inspect  [esc] back", m.table.View())
+	return i18n.T("%s\n[y] json  [i] inspect  [esc] back", m.table.View())
 }
 
 // Table returns the underlying table model.
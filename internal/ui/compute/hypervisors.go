@@ -7,11 +7,87 @@ import (
 	"github.com/charmbracelet/bubbles/table"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/hypervisors"
 	"ostui/internal/client"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/common"
+	"ostui/internal/ui/theme"
 	"ostui/internal/ui/uiconst"
+	"sort"
 	"strings"
 )
 
+// DefaultAllocationRatios returns Nova's default overcommit ratios, applied
+// to hypervisor capacity unless the command line sets others.
+func DefaultAllocationRatios() client.AllocationRatios {
+	return client.AllocationRatios{CPU: 16.0, RAM: 1.5}
+}
+
+// capacitySummary aggregates vCPU and RAM capacity across hypervisors.
+type capacitySummary struct {
+	hosts     int
+	vcpus     int
+	vcpusUsed int
+	vcpusCap  float64 // (vcpus - reserved) * CPU ratio
+	memMB     int
+	memMBUsed int
+	memMBCap  float64 // (memMB - reserved) * RAM ratio
+	cpuPct    float64
+	memPct    float64
+	cpuRatio  float64
+	ramRatio  float64
+}
+
+// summarizeCapacity computes total and used capacity with overcommit ratios applied.
+func summarizeCapacity(hvList []hypervisors.Hypervisor, cpuRatio, ramRatio float64) capacitySummary {
+	return summarizeHosts(hvList, func(hypervisors.Hypervisor) client.AllocationRatios {
+		return client.AllocationRatios{CPU: cpuRatio, RAM: ramRatio}
+	})
+}
+
+// summarizeHosts computes total and used capacity with the ratios of each
+// host applied. The summary ratios are 0 when the hosts differ.
+func summarizeHosts(hvList []hypervisors.Hypervisor, ratios func(hypervisors.Hypervisor) client.AllocationRatios) capacitySummary {
+	s := capacitySummary{hosts: len(hvList)}
+	if len(hvList) == 0 {
+		r := ratios(hypervisors.Hypervisor{})
+		s.cpuRatio, s.ramRatio = r.CPU, r.RAM
+	}
+	for i, hv := range hvList {
+		r := ratios(hv)
+		c := effectiveCapacity(hv, r)
+		s.vcpus += hv.VCPUs
+		s.vcpusUsed += hv.VCPUsUsed
+		s.vcpusCap += c.cpuCap
+		s.memMB += hv.MemoryMB
+		s.memMBUsed += hv.MemoryMBUsed
+		s.memMBCap += c.memCap
+		if i == 0 {
+			s.cpuRatio, s.ramRatio = r.CPU, r.RAM
+		}
+		if r.CPU != s.cpuRatio {
+			s.cpuRatio = 0
+		}
+		if r.RAM != s.ramRatio {
+			s.ramRatio = 0
+		}
+	}
+	if s.vcpusCap > 0 {
+		s.cpuPct = float64(s.vcpusUsed) / s.vcpusCap * 100
+	}
+	if s.memMBCap > 0 {
+		s.memPct = float64(s.memMBUsed) / s.memMBCap * 100
+	}
+	return s
+}
+
+// hypervisorLoad returns the highest of the vCPU and RAM utilisation of a host,
+// as a percentage of its overcommitted capacity.
+func hypervisorLoad(hv hypervisors.Hypervisor, cpuRatio, ramRatio float64) float64 {
+	return effectiveCapacity(hv, client.AllocationRatios{CPU: cpuRatio, RAM: ramRatio}).load()
+}
+
 // HypervisorsModel implements a subview for listing OpenStack hypervisors.
 type HypervisorsModel struct {
 	table      table.Model
@@ -22,39 +98,85 @@ type HypervisorsModel struct {
 	allRows    []table.Row
 	filterMode bool
 	filter     textinput.Model
+	// Capacity summary
+	summary    capacitySummary
+	sortedRows []table.Row // rows ordered by load, most loaded first
+	listRows   []table.Row // rows in API order
+	sortByLoad bool
+	// ratios are the configured allocation ratios, for the hosts placement
+	// reports none for; ratioSource tells where the ratios come from.
+	ratios      client.AllocationRatios
+	ratioSource string
+	// hot lists the hosts past the utilisation warning threshold.
+	hot []hotHost
+	// hosts maps hypervisor IDs to their compute service host.
+	hosts map[string]string
 	// Dynamic sizing
 	width  int
 	height int
 }
 
-// NewHypervisorsModel creates a new HypervisorsModel.
-func NewHypervisorsModel(cc client.ComputeClient) HypervisorsModel {
+// NewHypervisorsModel creates a new HypervisorsModel with the configured
+// allocation ratios.
+func NewHypervisorsModel(cc client.ComputeClient, ratios client.AllocationRatios) HypervisorsModel {
 	s := spinner.New()
 	s.Spinner = spinner.Dot
 	ti := textinput.New()
 	ti.Placeholder = "filter..."
 	// Initialize with reasonable defaults.
-	return HypervisorsModel{client: cc, loading: true, spinner: s, filter: ti, width: 120, height: 30}
+	return HypervisorsModel{client: cc, ratios: ratios, loading: true, spinner: s, filter: ti, width: 120, height: 30}
 }
 
 type hypervisorsDataLoadedMsg struct {
-	tbl  table.Model
-	rows []table.Row
-	err  error
+	tbl        table.Model
+	rows       []table.Row
+	sortedRows []table.Row
+	summary    capacitySummary
+	source     string
+	hot        []hotHost
+	hosts      map[string]string
+	err        error
 }
 
 // Init starts async loading of hypervisors.
 func (m HypervisorsModel) Init() tea.Cmd {
+	configured := m.ratios
 	return func() tea.Msg {
 		hvList, err := m.client.ListHypervisors(context.Background())
 		if err != nil {
 			return hypervisorsDataLoadedMsg{err: err}
 		}
+		// Placement knows the ratios of each host; without access to it
+		// (it is admin-only by default) the configured ones apply.
+		ctx := context.Background()
+		source := "placement"
+		placement, err := m.client.ListAllocationRatios(ctx)
+		if err != nil {
+			placement, source = nil, "configured (placement unavailable)"
+		}
+		capOf := func(hv hypervisors.Hypervisor) hostCapacity {
+			return effectiveCapacity(hv, hostRatios(hv, placement, configured))
+		}
 		// Define a concise set of columns.
-		cols := []table.Column{{Title: "ID", Width: uiconst.ColWidthUUID}, {Title: "Hostname", Width: uiconst.ColWidthName}, {Title: "State", Width: uiconst.ColWidthProtocol}, {Title: "Status", Width: uiconst.ColWidthEnabled}, {Title: "VCPUs", Width: uiconst.ColWidthProtocol}, {Title: "VCPUs Used", Width: uiconst.ColWidthType}, {Title: "RAM MB", Width: uiconst.ColWidthEnabled}, {Title: "RAM Used", Width: uiconst.ColWidthRAMUsed}, {Title: "Disk GB", Width: uiconst.ColWidthEnabled}, {Title: "Disk Used", Width: uiconst.ColWidthRAMUsed}}
+		cols := []table.Column{{Title: "ID", Width: uiconst.ColWidthUUID}, {Title: i18n.T("Hostname"), Width: uiconst.ColWidthName}, {Title: i18n.T("State"), Width: uiconst.ColWidthProtocol}, {Title: i18n.T("Status"), Width: uiconst.ColWidthEnabled}, {Title: i18n.T("VCPUs"), Width: uiconst.ColWidthProtocol}, {Title: i18n.T("VCPUs Used"), Width: uiconst.ColWidthType}, {Title: i18n.T("vCPU Free"), Width: uiconst.ColWidthRAMUsed}, {Title: i18n.T("RAM MB"), Width: uiconst.ColWidthEnabled}, {Title: i18n.T("RAM Used"), Width: uiconst.ColWidthRAMUsed}, {Title: i18n.T("RAM Free"), Width: uiconst.ColWidthRAMUsed}, {Title: i18n.T("Disk GB"), Width: uiconst.ColWidthEnabled}, {Title: i18n.T("Disk Used"), Width: uiconst.ColWidthRAMUsed}}
 		rows := []table.Row{}
+		hosts := map[string]string{}
 		for _, hv := range hvList {
-			rows = append(rows, table.Row{hv.ID, hv.HypervisorHostname, hv.State, hv.Status, fmt.Sprintf("%d", hv.VCPUs), fmt.Sprintf("%d", hv.VCPUsUsed), fmt.Sprintf("%d", hv.MemoryMB), fmt.Sprintf("%d", hv.MemoryMBUsed), fmt.Sprintf("%d", hv.LocalGB), fmt.Sprintf("%d", hv.LocalGBUsed)})
+			hosts[hv.ID] = hv.Service.Host
+			cpuFree, ramFree := freeCells(capOf(hv))
+			rows = append(rows, table.Row{hv.ID, hv.HypervisorHostname, theme.Mark(hv.State), theme.Mark(hv.Status), fmt.Sprintf("%d", hv.VCPUs), fmt.Sprintf("%d", hv.VCPUsUsed), cpuFree, fmt.Sprintf("%d", hv.MemoryMB), fmt.Sprintf("%d", hv.MemoryMBUsed), ramFree, fmt.Sprintf("%d", hv.LocalGB), fmt.Sprintf("%d", hv.LocalGBUsed)})
+		}
+		// Order a copy of the rows by load for the "most loaded" toggle.
+		order := make([]int, len(hvList))
+		for i := range order {
+			order[i] = i
+		}
+		sort.SliceStable(order, func(a, b int) bool {
+			return capOf(hvList[order[a]]).load() > capOf(hvList[order[b]]).load()
+		})
+		sortedRows := make([]table.Row, 0, len(rows))
+		for _, i := range order {
+			sortedRows = append(sortedRows, rows[i])
 		}
 		t := table.New(
 			table.WithColumns(cols),
@@ -63,10 +185,19 @@ func (m HypervisorsModel) Init() tea.Cmd {
 			table.WithHeight(m.height-uiconst.TableHeightOffset),
 		)
 		t.SetStyles(table.DefaultStyles())
-		return hypervisorsDataLoadedMsg{tbl: t, rows: rows}
+		summary := summarizeHosts(hvList, func(hv hypervisors.Hypervisor) client.AllocationRatios { return hostRatios(hv, placement, configured) })
+		return hypervisorsDataLoadedMsg{tbl: t, rows: rows, sortedRows: sortedRows, summary: summary, source: source, hot: hotHosts(hvList, placement, configured), hosts: hosts}
 	}
 }
 
+// Reload re-fetches the hypervisors, keeping the selected row.
+func (m HypervisorsModel) Reload() tea.Cmd {
+	if m.loading || m.filterMode {
+		return nil
+	}
+	return m.Init()
+}
+
 // Update handles messages for the model.
 func (m HypervisorsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
@@ -76,28 +207,69 @@ func (m HypervisorsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.err = msg.err
 			return m, nil
 		}
-		m.table = msg.tbl
+		// A reload keeps the "most loaded first" ordering.
 		m.allRows = msg.rows
+		if m.sortByLoad {
+			m.allRows = msg.sortedRows
+		}
+		msg.tbl.SetRows(m.visibleRows())
+		m.table = common.Reloaded(m.table, msg.tbl)
+		m.listRows = msg.rows
+		m.sortedRows = msg.sortedRows
+		m.summary = msg.summary
+		m.ratioSource = msg.source
+		m.hot = msg.hot
+		m.hosts = msg.hosts
 		// Adjust columns and height based on current dimensions.
 		m.updateTableColumns()
-		m.table.SetHeight(m.height - uiconst.TableHeightOffset)
+		m.table.SetHeight(m.height - uiconst.TableHeightOffset - hypervisorHeaderLines)
 		return m, nil
 	case tea.WindowSizeMsg:
 		// Update stored dimensions and adjust table.
 		m.width = msg.Width
 		m.height = msg.Height
 		if m.table.Columns() != nil {
-			m.table.SetHeight(m.height - uiconst.TableHeightOffset)
+			m.table.SetHeight(m.height - uiconst.TableHeightOffset - hypervisorHeaderLines)
 			m.updateTableColumns()
 		}
 		return m, nil
 	case tea.KeyMsg:
+		if m.loading || m.err != nil {
+			return m, nil
+		}
+		// Toggle "most loaded first" ordering.
+		if !m.filterMode && msg.String() == "s" {
+			m.sortByLoad = !m.sortByLoad
+			if m.sortByLoad {
+				m.allRows = m.sortedRows
+			} else {
+				m.allRows = m.listRows
+			}
+			m.table.SetRows(m.visibleRows())
+			m.table.GotoTop()
+			return m, nil
+		}
+		// Open the maintenance workflow of the selected host.
+		if !m.filterMode && msg.String() == "D" {
+			if row := m.table.SelectedRow(); len(row) > 0 && m.hosts[row[0]] != "" {
+				host := m.hosts[row[0]]
+				return m, func() tea.Msg { return OpenDrainHostMsg{Host: host} }
+			}
+			return m, nil
+		}
 		// Filter mode handling – same pattern as InstancesModel.
 		if !m.filterMode && msg.String() == "/" {
 			m.filterMode = true
 			m.filter.Focus()
 			return m, textinput.Blink
 		}
+		// enter keeps the filter and returns to the table, where the
+		// sort and drain keys work on the matching hosts.
+		if m.filterMode && msg.String() == "enter" {
+			m.filterMode = false
+			m.filter.Blur()
+			return m, nil
+		}
 		if m.filterMode && msg.String() == "esc" {
 			m.filterMode = false
 			m.filter.Blur()
@@ -108,22 +280,7 @@ func (m HypervisorsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.filterMode {
 			var cmd tea.Cmd
 			m.filter, cmd = m.filter.Update(msg)
-			filterVal := m.filter.Value()
-			if filterVal == "" {
-				m.table.SetRows(m.allRows)
-			} else {
-				lower := strings.ToLower(filterVal)
-				filtered := []table.Row{}
-				for _, r := range m.allRows {
-					for _, c := range r {
-						if strings.Contains(strings.ToLower(c), lower) {
-							filtered = append(filtered, r)
-							break
-						}
-					}
-				}
-				m.table.SetRows(filtered)
-			}
+			m.table.SetRows(m.visibleRows())
 			return m, cmd
 		}
 		// Normal navigation.
@@ -140,15 +297,63 @@ func (m HypervisorsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// visibleRows returns the rows in the current order that match the filter.
+func (m HypervisorsModel) visibleRows() []table.Row {
+	filterVal := m.filter.Value()
+	if filterVal == "" {
+		return m.allRows
+	}
+	lower := strings.ToLower(filterVal)
+	filtered := []table.Row{}
+	for _, r := range m.allRows {
+		for _, c := range r {
+			if strings.Contains(strings.ToLower(c), lower) {
+				filtered = append(filtered, r)
+				break
+			}
+		}
+	}
+	return filtered
+}
+
 // View renders the hypervisors view.
 func (m HypervisorsModel) View() string {
 	if m.loading {
 		return m.spinner.View()
 	}
 	if m.err != nil {
-		return fmt.Sprintf("Error: %s", m.err)
+		return i18n.T("Error: %s", m.err)
+	}
+	header := m.capacityHeader()
+	if m.filterMode {
+		filterLine := i18n.T("Filter: %s", m.filter.View())
+		footer := "enter: keep  esc: clear"
+		return fmt.Sprintf("%s\n%s\n%s\n%s", header, filterLine, m.table.View(), footer)
 	}
-	return m.table.View()
+	if f := m.filter.Value(); f != "" {
+		return i18n.T("%s\nFilter: %s  [/] edit\n%s", header, f, m.table.View())
+	}
+	return fmt.Sprintf("%s\n%s", header, m.table.View())
+}
+
+// hypervisorHeaderLines is the number of lines used by the capacity header.
+const hypervisorHeaderLines = 4
+
+// capacityHeader renders the aggregate capacity summary shown above the table.
+func (m HypervisorsModel) capacityHeader() string {
+	s := m.summary
+	labelStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#AAAAAA"))
+	dimStyle := lipgloss.NewStyle().Foreground(theme.Muted)
+	cpuLine := fmt.Sprintf("%s %s %s", labelStyle.Render(fmt.Sprintf("%-6s", i18n.T("vCPUs"))), renderBar(s.cpuPct),
+		lipgloss.NewStyle().Foreground(colorForPct(s.cpuPct)).Render(i18n.T("%d/%.0f (%.0f%%)  %d physical × %s", s.vcpusUsed, s.vcpusCap, s.cpuPct, s.vcpus, ratioText(s.cpuRatio))))
+	memLine := fmt.Sprintf("%s %s %s", labelStyle.Render(fmt.Sprintf("%-6s", "RAM")), renderBar(s.memPct),
+		lipgloss.NewStyle().Foreground(colorForPct(s.memPct)).Render(i18n.T("%.1f/%.1f GiB (%.0f%%)  %.1f physical × %s", float64(s.memMBUsed)/1024, s.memMBCap/1024, s.memPct, float64(s.memMB)/1024, ratioText(s.ramRatio))))
+	order := "API order"
+	if m.sortByLoad {
+		order = "most loaded first"
+	}
+	info := dimStyle.Render(i18n.T("%d hosts  |  ratios: %s  |  sort: %s  |  [s] toggle sort  [D] drain host", s.hosts, m.ratioSource, order))
+	return fmt.Sprintf("%s\n%s\n%s\n%s", cpuLine, memLine, hotHostsLine(m.hot), info)
 }
 
 // updateTableColumns adjusts column widths based on the current width.
@@ -159,20 +364,25 @@ func (m *HypervisorsModel) updateTableColumns() {
 	statusW := uiconst.ColWidthEnabled
 	vcpusW := uiconst.ColWidthProtocol
 	vcpusUsedW := uiconst.ColWidthType
+	vcpusFreeW := uiconst.ColWidthRAMUsed
 	ramW := uiconst.ColWidthEnabled
 	ramUsedW := uiconst.ColWidthRAMUsed
+	ramFreeW := uiconst.ColWidthRAMUsed
 	diskW := uiconst.ColWidthEnabled
 	diskUsedW := uiconst.ColWidthDiskUsed
 	// Compute flexible hostname width.
-	fixedTotal := idW + stateW + statusW + vcpusW + vcpusUsedW + ramW + ramUsedW + diskW + diskUsedW + uiconst.TableHeightOffset // margin
+	fixedTotal := idW + stateW + statusW + vcpusW + vcpusUsedW + vcpusFreeW + ramW + ramUsedW + ramFreeW + diskW + diskUsedW + uiconst.TableHeightOffset // margin
 	hostnameW := m.width - fixedTotal
 	if hostnameW < 10 {
 		hostnameW = 10
 	}
-	m.table.SetColumns([]table.Column{{Title: "ID", Width: idW}, {Title: "Hostname", Width: hostnameW}, {Title: "State", Width: stateW}, {Title: "Status", Width: statusW}, {Title: "VCPUs", Width: vcpusW}, {Title: "VCPUs Used", Width: vcpusUsedW}, {Title: "RAM MB", Width: ramW}, {Title: "RAM Used", Width: ramUsedW}, {Title: "Disk GB", Width: diskW}, {Title: "Disk Used", Width: diskUsedW}})
+	m.table.SetColumns([]table.Column{{Title: "ID", Width: idW}, {Title: i18n.T("Hostname"), Width: hostnameW}, {Title: i18n.T("State"), Width: stateW}, {Title: i18n.T("Status"), Width: statusW}, {Title: i18n.T("VCPUs"), Width: vcpusW}, {Title: i18n.T("VCPUs Used"), Width: vcpusUsedW}, {Title: i18n.T("vCPU Free"), Width: vcpusFreeW}, {Title: i18n.T("RAM MB"), Width: ramW}, {Title: i18n.T("RAM Used"), Width: ramUsedW}, {Title: i18n.T("RAM Free"), Width: ramFreeW}, {Title: i18n.T("Disk GB"), Width: diskW}, {Title: i18n.T("Disk Used"), Width: diskUsedW}})
 }
 
 // Table returns the underlying table model.
 func (m HypervisorsModel) Table() table.Model { return m.table }
 
+// Filtering reports whether keys are typed into the filter.
+func (m HypervisorsModel) Filtering() bool { return m.filterMode }
+
 var _ tea.Model = (*HypervisorsModel)(nil)
@@ -0,0 +1,127 @@
+package compute
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"ostui/internal/client"
+	"ostui/internal/ui/common"
+	"ostui/internal/ui/policy"
+)
+
+// lifecycleHelp describes the lifecycle actions in the action palette.
+var lifecycleHelp = map[string]string{
+	lifecycleStart:         "Start the server",
+	lifecycleStop:          "Stop the server",
+	lifecyclePause:         "Pause the server",
+	lifecycleUnpause:       "Unpause the server",
+	lifecycleSuspend:       "Suspend the server",
+	lifecycleResume:        "Resume the server",
+	lifecycleShelve:        "Shelve the server",
+	lifecycleUnshelve:      "Unshelve the server",
+	lifecycleLock:          "Lock the server",
+	lifecycleUnlock:        "Unlock the server",
+	lifecycleRescue:        "Boot the server from a rescue image, picked next",
+	lifecycleUnrescue:      "Leave rescue mode and boot the server's own disk",
+	lifecycleConfirmResize: "Keep the new flavor and free the old host",
+	lifecycleRevertResize:  "Go back to the old flavor and host",
+}
+
+// serverActions lists what can be done to a server in status, for the
+// action palette. The lifecycle actions valid in status come first; they
+// have no key of their own and are confirmed like from the lifecycle menu.
+func serverActions(status string) []common.Action {
+	var out []common.Action
+	for _, a := range lifecycleActions(client.ServerState{Status: status}) {
+		out = append(out, common.Action{Name: a.action, Help: lifecycleHelp[a.action]})
+	}
+	if status == "ERROR" {
+		out = append(out,
+			common.Action{Key: "a", Name: "last action", Help: "Show the last instance action and its error"},
+			common.Action{Key: "H", Name: remediationHardReboot, Help: "Hard reboot the server"},
+			common.Action{Key: "D", Name: remediationDelete, Help: "Delete the server after a pre-flight"},
+		)
+	}
+	if rebuildableStatuses[status] {
+		out = append(out, common.Action{Key: "R", Name: remediationRebuild, Help: "Rebuild the server from its image, reviewing its user data"})
+	}
+	if imageableStatuses[status] {
+		out = append(out, common.Action{Key: "I", Name: actionCreateImage, Help: "Snapshot the server to a new image"})
+	}
+	return append(out,
+		common.Action{Key: "L", Name: "lifecycle", Help: "Lifecycle menu, checked against the live state"},
+		common.Action{Key: "F", Name: actionResize, Help: "Resize: pick a new flavor"},
+		common.Action{Key: "l", Name: "logs", Help: "View the console log"},
+		common.Action{Key: "v", Name: "console", Help: "Console URL"},
+		common.Action{Key: "S", Name: "serial console", Help: "Interactive serial console (ctrl+] closes it)"},
+		common.Action{Key: "d", Name: "diagnostics", Help: "CPU, memory, NIC and disk counters"},
+		common.Action{Key: "W", Name: "get password", Help: "Decrypt the admin password posted by the guest"},
+		common.Action{Key: "C", Name: "change password", Help: "Change the admin password"},
+		common.Action{Key: "g", Name: "graph", Help: "Relationship graph of the server"},
+		common.Action{Key: "i", Name: "inspect", Help: "Inspect the server"},
+		common.Action{Key: "y", Name: "json", Help: "JSON view"},
+		common.Action{Key: "X", Name: actionEvacuate, Help: "Evacuate from a failed host (admin)"},
+		common.Action{Key: "P", Name: "rebuild preserving", Help: "Rebuild preserving ephemeral disk (admin)"},
+	)
+}
+
+// Actions lists the actions valid for the server shown.
+func (m InstanceDetailModel) Actions() []common.Action {
+	if m.loading || m.err != nil {
+		return nil
+	}
+	return serverActions(m.instance.Status)
+}
+
+// QueueAction runs a from the palette of the server list once the server
+// has loaded.
+func (m InstanceDetailModel) QueueAction(a common.Action) tea.Model {
+	m.queued = &a
+	return m
+}
+
+// runAction runs a palette action: through its key when it has one,
+// otherwise as the lifecycle action it names.
+func (m InstanceDetailModel) runAction(a common.Action) (tea.Model, tea.Cmd) {
+	if a.Key != "" {
+		return m.Update(common.KeyFor(a.Key))
+	}
+	if !isLifecycleAction(a.Name) {
+		return m, nil
+	}
+	if err := policy.Check(policy.Member, a.Name); err != nil {
+		m.actionStatus = err.Error()
+		return m, nil
+	}
+	m.actionStatus = ""
+	if a.Name == lifecycleRescue {
+		return m.startRescue()
+	}
+	m.pendingAction = a.Name
+	return m, nil
+}
+
+// Actions lists the list actions and those valid for the selected server,
+// which open its detail first.
+func (m InstancesModel) Actions() []common.Action {
+	if m.loading || m.err != nil || m.filterMode {
+		return nil
+	}
+	var out []common.Action
+	if row := m.table.SelectedRow(); len(row) > 0 && !m.OnGroupHeader() {
+		out = append(out, common.Action{Key: "enter", Name: "open", Help: "Open the server detail"})
+		for _, s := range m.servers {
+			if s.ID != row[0] {
+				continue
+			}
+			for _, a := range serverActions(s.Status) {
+				a.Detail = true
+				out = append(out, a)
+			}
+			break
+		}
+	}
+	return append(out,
+		common.Action{Key: "/", Name: "filter", Help: "Filter the servers"},
+		common.Action{Key: "G", Name: "group", Help: "Group by status / AZ / flavor / metadata key"},
+		common.Action{Key: "B", Name: "bulk edit", Help: "Bulk rename / set metadata on the servers matching the filter"},
+	)
+}
@@ -0,0 +1,202 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
+	"ostui/internal/client"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/policy"
+)
+
+// Admin recovery actions. They need the server name typed back before they
+// are submitted.
+const (
+	actionEvacuate        = "evacuate"
+	actionRebuildPreserve = "rebuild (preserve ephemeral)"
+)
+
+// adminKeys maps detail view keys to admin recovery actions.
+var adminKeys = map[string]string{
+	"X": actionEvacuate,
+	"P": actionRebuildPreserve,
+}
+
+// actionRule is what an action of the detail view requires under the
+// default policies. Rebuilding is the owner's; only evacuation is admin.
+func actionRule(action string) policy.Rule {
+	if action == actionEvacuate {
+		return policy.Admin
+	}
+	return policy.Member
+}
+
+// memberKeys maps detail view keys that change the server to what they
+// do, for refusing them up front when the token lacks the member role.
+var memberKeys = map[string]string{
+	"F": actionResize,
+	"L": "the lifecycle menu",
+	"C": "changing the admin password",
+	"I": "creating an image",
+	"R": remediationRebuild,
+}
+
+// taskPollInterval is how often the task state is polled after an action.
+const taskPollInterval = 2 * time.Second
+
+// maxTaskPolls bounds how long a task is watched (about five minutes).
+const maxTaskPolls = 150
+
+// adminPrompt collects the optional target host and the typed confirmation
+// of an admin action.
+type adminPrompt struct {
+	action string
+	// askHost is set while the target host of an evacuation is entered.
+	askHost       bool
+	host          string
+	sharedStorage bool
+	input         textinput.Model
+	err           string
+}
+
+// newAdminPrompt starts the prompt for action.
+func newAdminPrompt(action string) *adminPrompt {
+	p := &adminPrompt{action: action, askHost: action == actionEvacuate}
+	p.input = textinput.New()
+	p.input.CharLimit = 255
+	p.input.Focus()
+	if p.askHost {
+		p.input.Placeholder = "empty: let the scheduler choose"
+	}
+	return p
+}
+
+// taskStateMsg carries one poll of the server's task state.
+type taskStateMsg struct {
+	state client.ServerState
+	err   error
+	polls int
+}
+
+// evacuateCmd submits an evacuation of the server.
+func evacuateCmd(cc client.ComputeClient, id, host string, shared bool) tea.Cmd {
+	return func() tea.Msg {
+		err := cc.EvacuateInstance(context.Background(), id, host, shared)
+		return remediationDoneMsg{action: actionEvacuate, err: err}
+	}
+}
+
+// rebuildPreserveCmd rebuilds the server from its image keeping the
+// ephemeral disk. It refuses flavors without an ephemeral disk, where the
+// flag has no effect.
+func rebuildPreserveCmd(cc client.ComputeClient, srv servers.Server) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		done := func(err error) tea.Msg { return remediationDoneMsg{action: actionRebuildPreserve, err: err} }
+		imageID, _ := srv.Image["id"].(string)
+		if imageID == "" {
+			return done(fmt.Errorf("server was not booted from an image; rebuild not possible"))
+		}
+		flavorID, _ := srv.Flavor["id"].(string)
+		fl, err := cc.GetFlavor(ctx, flavorID)
+		if err != nil {
+			return done(fmt.Errorf("look up flavor: %w", err))
+		}
+		if fl.Ephemeral == 0 {
+			return done(fmt.Errorf("flavor %s has no ephemeral disk; use a plain rebuild", fl.Name))
+		}
+		opts := client.PreserveEphemeralRebuildOpts{RebuildOpts: servers.RebuildOpts{ImageRef: imageID}, PreserveEphemeral: true}
+		return done(cc.RebuildInstance(ctx, srv.ID, opts))
+	}
+}
+
+// watchTaskCmd polls the server's task state after delay.
+func watchTaskCmd(cc client.ComputeClient, id string, delay time.Duration, polls int) tea.Cmd {
+	poll := func() tea.Msg {
+		st, err := cc.GetServerState(context.Background(), id)
+		return taskStateMsg{state: st, err: err, polls: polls + 1}
+	}
+	if delay == 0 {
+		return poll
+	}
+	return tea.Tick(delay, func(time.Time) tea.Msg { return poll() })
+}
+
+// recordTaskState appends the task state to trail when it changed and
+// reports whether the task has finished.
+func recordTaskState(trail []string, msg taskStateMsg) ([]string, bool) {
+	state := msg.state.TaskState
+	if state != "" && (len(trail) == 0 || trail[len(trail)-1] != state) {
+		trail = append(trail, state)
+	}
+	// Nova may not have picked the action up on the first polls; only
+	// treat an empty task state as finished once a task was seen.
+	finished := state == "" && (len(trail) > 0 || msg.polls >= 3)
+	if finished || msg.polls >= maxTaskPolls {
+		trail = append(trail, strings.ToLower(msg.state.Status))
+		return trail, true
+	}
+	return trail, false
+}
+
+// view renders the prompt below the detail table.
+func (p *adminPrompt) view(serverName string) string {
+	var b strings.Builder
+	b.WriteString("\n" + i18n.T("[admin] %s server %s", strings.ToUpper(p.action[:1])+p.action[1:], serverName) + "\n")
+	if p.askHost {
+		shared := "no"
+		if p.sharedStorage {
+			shared = "yes"
+		}
+		b.WriteString(i18n.T("Target host: %s\nOn shared storage: %s  [tab] toggle  [enter] next  [esc] cancel", p.input.View(), shared))
+	} else {
+		b.WriteString(i18n.T("Type the server name to confirm: %s\n[enter] submit  [esc] cancel", p.input.View()))
+	}
+	if p.err != "" {
+		b.WriteString("\n" + p.err)
+	}
+	return b.String()
+}
+
+// updateAdminPrompt handles keys while an admin action prompt is open.
+func (m InstanceDetailModel) updateAdminPrompt(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	p := m.admin
+	switch msg.String() {
+	case "esc":
+		m.admin = nil
+		m.actionStatus = ""
+		return m, nil
+	case "tab":
+		if p.askHost {
+			p.sharedStorage = !p.sharedStorage
+		}
+		return m, nil
+	case "enter":
+		if p.askHost {
+			p.host = strings.TrimSpace(p.input.Value())
+			p.askHost = false
+			p.input.Reset()
+			p.input.Placeholder = m.instance.Name
+			return m, nil
+		}
+		if p.input.Value() != m.instance.Name {
+			p.err = "Name does not match; nothing submitted."
+			return m, nil
+		}
+		m.admin = nil
+		m.actionStatus = i18n.T("Submitting %s...", p.action)
+		if p.action == actionEvacuate {
+			return m, evacuateCmd(m.client, m.instanceID, p.host, p.sharedStorage)
+		}
+		return m, rebuildPreserveCmd(m.client, m.instance)
+	}
+	var cmd tea.Cmd
+	p.input, cmd = p.input.Update(msg)
+	p.err = ""
+	return m, cmd
+}
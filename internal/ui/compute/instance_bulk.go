@@ -0,0 +1,398 @@
+package compute
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
+	"ostui/internal/client"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/common"
+	"ostui/internal/ui/policy"
+	"ostui/internal/ui/theme"
+	"ostui/internal/ui/uiconst"
+)
+
+// OpenBulkEditMsg asks the app to open the bulk edit of the servers matching
+// the instance filter.
+type OpenBulkEditMsg struct {
+	Servers []servers.Server
+	Filter  string
+}
+
+// State of one server in a bulk edit.
+const (
+	bulkPending   = "pending"
+	bulkDone      = "updated"
+	bulkFailed    = "failed"
+	bulkUnchanged = "unchanged"
+)
+
+// bulkEdit is what a bulk edit applies: a name pattern, numbered from first,
+// and a metadata key to set. Empty parts are left alone.
+type bulkEdit struct {
+	pattern string
+	first   int
+	key     string
+	value   string
+}
+
+// bulkStep is one server of a bulk edit with its planned change.
+type bulkStep struct {
+	server  servers.Server
+	newName string
+	state   string
+	detail  string
+}
+
+var placeholderRe = regexp.MustCompile(`\{([^}]*)\}`)
+
+// renderName expands a name pattern for a server: {name} is the current
+// name and {n} the sequence number, zero-padded to w digits with {n:w}.
+func renderName(pattern, name string, n int) (string, error) {
+	var err error
+	out := placeholderRe.ReplaceAllStringFunc(pattern, func(ph string) string {
+		inner := ph[1 : len(ph)-1]
+		switch {
+		case inner == "name":
+			return name
+		case inner == "n":
+			return strconv.Itoa(n)
+		case strings.HasPrefix(inner, "n:"):
+			w, convErr := strconv.Atoi(inner[2:])
+			if convErr != nil || w < 1 || w > 9 {
+				err = fmt.Errorf("bad padding in %s: use {n:3} for 001, 002, …", ph)
+				return ph
+			}
+			return fmt.Sprintf("%0*d", w, n)
+		}
+		err = fmt.Errorf("unknown placeholder %s: use {name}, {n} or {n:3}", ph)
+		return ph
+	})
+	return out, err
+}
+
+// parseBulkEdit reads the bulk edit form: name pattern, first number,
+// metadata key and value.
+func parseBulkEdit(v []string) (bulkEdit, error) {
+	e := bulkEdit{pattern: strings.TrimSpace(v[0]), first: 1, key: strings.TrimSpace(v[2]), value: v[3]}
+	if e.pattern == "" && e.key == "" {
+		return e, errors.New("set a name pattern, a metadata key or both")
+	}
+	if s := strings.TrimSpace(v[1]); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 0 {
+			return e, fmt.Errorf("first number %q is not a non-negative integer", s)
+		}
+		e.first = n
+	}
+	if _, err := renderName(e.pattern, "", 0); err != nil {
+		return e, err
+	}
+	return e, nil
+}
+
+// planBulkEdit computes the new name of each server in list order, marks the
+// servers the edit would not change, and warns about names given to more
+// than one server.
+func planBulkEdit(list []servers.Server, e bulkEdit) ([]bulkStep, []string) {
+	steps := make([]bulkStep, 0, len(list))
+	names := map[string]int{}
+	for i, s := range list {
+		st := bulkStep{server: s, newName: s.Name, state: bulkPending}
+		if e.pattern != "" {
+			st.newName, _ = renderName(e.pattern, s.Name, e.first+i)
+		}
+		if st.newName == s.Name && (e.key == "" || metadataValue(s, e.key) == e.value) {
+			st.state = bulkUnchanged
+		}
+		names[st.newName]++
+		steps = append(steps, st)
+	}
+	var warnings []string
+	for name, n := range names {
+		if n > 1 && e.pattern != "" {
+			warnings = append(warnings, i18n.T("%d servers would be named %q; add {n} to number them", n, name))
+		}
+	}
+	sort.Strings(warnings)
+	return steps, warnings
+}
+
+// metadataValue returns a metadata value of a server, or "" when unset.
+func metadataValue(s servers.Server, key string) string {
+	return s.Metadata[key]
+}
+
+// BulkEditModel renames the servers matching the instance filter with a
+// pattern and/or sets a metadata key on them. The changes are previewed
+// before anything is sent, then applied one server at a time.
+type BulkEditModel struct {
+	table    table.Model
+	client   client.ComputeClient
+	servers  []servers.Server
+	filter   string
+	edit     bulkEdit
+	steps    []bulkStep
+	warnings []string
+
+	form *common.FormModel
+	// running is set while the changes are sent; current is the step being
+	// applied or -1.
+	running   bool
+	current   int
+	status    string
+	statusErr bool
+
+	width  int
+	height int
+}
+
+// NewBulkEditModel creates the bulk edit of list, opening on its form.
+func NewBulkEditModel(cc client.ComputeClient, list []servers.Server, filter string) BulkEditModel {
+	e := bulkEdit{first: 1}
+	f := bulkEditForm(e)
+	return BulkEditModel{client: cc, servers: list, filter: filter, edit: e, form: &f, current: -1, width: 120, height: 30}
+}
+
+// bulkEditForm returns the edit form filled with e.
+func bulkEditForm(e bulkEdit) common.FormModel {
+	f := common.NewForm([]string{i18n.T("Name pattern ({name}, {n}, {n:3})"), i18n.T("First number"), i18n.T("Metadata key"), i18n.T("Metadata value")})
+	f.SetValue(0, e.pattern)
+	f.SetValue(1, strconv.Itoa(e.first))
+	f.SetValue(2, e.key)
+	f.SetValue(3, e.value)
+	return f
+}
+
+// Init focuses the form.
+func (m BulkEditModel) Init() tea.Cmd { return m.form.Init() }
+
+type bulkStepMsg struct {
+	index int
+	err   error
+}
+
+// Update handles messages for the model.
+func (m BulkEditModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case bulkStepMsg:
+		if msg.index != m.current {
+			return m, nil
+		}
+		st := &m.steps[msg.index]
+		if msg.err != nil {
+			policy.Record("editing servers", msg.err)
+			st.state, st.detail = bulkFailed, msg.err.Error()
+		} else {
+			st.state = bulkDone
+			// Later edits start from the new name and metadata.
+			srv := &m.servers[msg.index]
+			srv.Name = st.newName
+			if m.edit.key != "" {
+				md := map[string]string{}
+				for k, v := range srv.Metadata {
+					md[k] = v
+				}
+				md[m.edit.key] = m.edit.value
+				srv.Metadata = md
+			}
+		}
+		updated, cmd := m.applyNext()
+		updated.refreshTable()
+		return updated, cmd
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		if m.steps != nil {
+			m.refreshTable()
+		}
+		return m, nil
+	case tea.KeyMsg:
+		if m.form != nil {
+			return m.updateForm(msg)
+		}
+		if m.running {
+			return m, nil
+		}
+		switch msg.String() {
+		case "y":
+			if m.counts()[bulkPending] == 0 {
+				return m, nil
+			}
+			if err := policy.Check(policy.Member, "editing servers"); err != nil {
+				m.status, m.statusErr = err.Error(), true
+				return m, nil
+			}
+			m.running, m.status = true, ""
+			updated, cmd := m.applyNext()
+			updated.refreshTable()
+			return updated, cmd
+		case "e":
+			f := bulkEditForm(m.edit)
+			m.form = &f
+			return m, f.Init()
+		}
+		var cmd tea.Cmd
+		m.table, cmd = m.table.Update(msg)
+		return m, cmd
+	}
+	return m, nil
+}
+
+// updateForm handles keys for the edit form; submitting shows the preview.
+func (m BulkEditModel) updateForm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	fm, cmd := m.form.Update(msg)
+	f := fm.(common.FormModel)
+	m.form = &f
+	switch {
+	case f.Cancelled():
+		m.form = nil
+	case f.Submitted():
+		e, err := parseBulkEdit(f.Values())
+		if err != nil {
+			m.form.SetError(err)
+			return m, nil
+		}
+		m.form = nil
+		m.edit = e
+		m.steps, m.warnings = planBulkEdit(m.servers, e)
+		m.status, m.statusErr = "", false
+		m.refreshTable()
+	}
+	return m, cmd
+}
+
+// applyNext sends the changes of the next pending server, or ends the run.
+func (m BulkEditModel) applyNext() (BulkEditModel, tea.Cmd) {
+	i := -1
+	for j, st := range m.steps {
+		if st.state == bulkPending {
+			i = j
+			break
+		}
+	}
+	if i < 0 {
+		m.running, m.current = false, -1
+		m.status, m.statusErr = m.summary(), m.counts()[bulkFailed] > 0
+		return m, nil
+	}
+	m.current = i
+	cc, st, e := m.client, m.steps[i], m.edit
+	return m, func() tea.Msg {
+		ctx := context.Background()
+		if st.newName != st.server.Name {
+			if err := cc.UpdateInstance(ctx, st.server.ID, servers.UpdateOpts{Name: st.newName}); err != nil {
+				return bulkStepMsg{index: i, err: fmt.Errorf("rename: %w", err)}
+			}
+		}
+		if e.key != "" && metadataValue(st.server, e.key) != e.value {
+			if err := cc.UpdateInstanceMetadata(ctx, st.server.ID, map[string]string{e.key: e.value}); err != nil {
+				return bulkStepMsg{index: i, err: fmt.Errorf("metadata: %w", err)}
+			}
+		}
+		return bulkStepMsg{index: i}
+	}
+}
+
+// counts returns the number of steps in each state.
+func (m BulkEditModel) counts() map[string]int {
+	n := map[string]int{}
+	for _, st := range m.steps {
+		n[st.state]++
+	}
+	return n
+}
+
+// summary describes the outcome of the run.
+func (m BulkEditModel) summary() string {
+	n := m.counts()
+	return i18n.T("%d updated, %d failed, %d unchanged", n[bulkDone], n[bulkFailed], n[bulkUnchanged])
+}
+
+// refreshTable rebuilds the preview table.
+func (m *BulkEditModel) refreshTable() {
+	rest := m.width - uiconst.ColWidthUUID - uiconst.ColWidthStatus - uiconst.TableHeightOffset
+	if rest < 60 {
+		rest = 60
+	}
+	w := rest / 3
+	cols := []table.Column{{Title: "ID", Width: uiconst.ColWidthUUID}, {Title: i18n.T("Name"), Width: w}, {Title: i18n.T("New name"), Width: w},
+		{Title: i18n.T("Metadata"), Width: rest - 2*w}, {Title: i18n.T("State"), Width: uiconst.ColWidthStatus}}
+	var rows []table.Row
+	for _, st := range m.steps {
+		newName := st.newName
+		if newName == st.server.Name {
+			newName = "="
+		}
+		md := "-"
+		if m.edit.key != "" {
+			old := metadataValue(st.server, m.edit.key)
+			if _, ok := st.server.Metadata[m.edit.key]; !ok {
+				old = "(unset)"
+			}
+			md = fmt.Sprintf("%s: %s → %s", m.edit.key, old, m.edit.value)
+		}
+		state := st.state
+		if st.detail != "" {
+			state += ": " + st.detail
+		}
+		rows = append(rows, table.Row{st.server.ID, st.server.Name, newName, md, state})
+	}
+	cursor := m.table.Cursor()
+	m.table = table.New(table.WithColumns(cols), table.WithFocused(true))
+	m.table.SetStyles(table.DefaultStyles())
+	m.table.SetRows(rows)
+	m.table.SetHeight(m.height - uiconst.TableHeightOffset - 6 - len(m.warnings))
+	if cursor < len(rows) {
+		m.table.SetCursor(cursor)
+	}
+}
+
+// View renders the form, or the preview with the run's progress.
+func (m BulkEditModel) View() string {
+	scope := i18n.T("%d servers", len(m.servers))
+	if m.filter != "" {
+		scope += i18n.T(" matching %q", m.filter)
+	}
+	title := lipgloss.NewStyle().Bold(true).Foreground(theme.Accent).Render("Bulk edit of " + scope)
+	if m.form != nil {
+		return title + "\n\n" + m.form.View() + "\n" + i18n.T("[enter] preview  [esc] cancel")
+	}
+	if m.steps == nil {
+		return title + "\n" + i18n.T("No edit planned.\n[e] edit  [esc] back")
+	}
+	out := title + "\n"
+	warn := lipgloss.NewStyle().Foreground(theme.Error)
+	for _, w := range m.warnings {
+		out += warn.Render("⚠ "+w) + "\n"
+	}
+	out += m.table.View()
+	switch {
+	case m.running:
+		out += "\n" + i18n.T("Updating %d of %d…", m.current+1, len(m.steps))
+	case m.statusErr:
+		out += "\n" + warn.Render(m.status)
+	case m.status != "":
+		out += "\n" + lipgloss.NewStyle().Foreground(theme.OK).Render(m.status)
+	default:
+		out += "\n" + i18n.T("Apply to %d servers?", m.counts()[bulkPending]) + " " + policy.Key(policy.Member, i18n.T("[y] apply"), "editing servers") + "  " + i18n.T("[e] edit  [esc] back")
+		return out
+	}
+	return out + "\n" + i18n.T("[e] edit  [esc] back")
+}
+
+// CapturingInput reports whether the form is open or changes are being sent.
+func (m BulkEditModel) CapturingInput() bool { return m.form != nil || m.running }
+
+// Table returns the preview table.
+func (m BulkEditModel) Table() table.Model { return m.table }
+
+var _ tea.Model = (*BulkEditModel)(nil)
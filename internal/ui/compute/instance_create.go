@@ -0,0 +1,138 @@
+package compute
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/flavors"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/images"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/networks"
+	"ostui/internal/client"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/ops"
+	"ostui/internal/ui/quota"
+)
+
+// OpenCreateServerMsg asks the app to open the new server form.
+type OpenCreateServerMsg struct{}
+
+// serverChoices are the flavors, images and networks the new server form
+// resolves its fields against.
+type serverChoices struct {
+	flavors  []flavors.Flavor
+	images   []images.Image
+	networks []networks.Network
+}
+
+// resolve finds the flavor, image and network of the form values.
+func (c *serverChoices) resolve(v []string) (flavors.Flavor, images.Image, networks.Network, error) {
+	fl, err := findRef("flavor", c.flavors, v[1], func(f flavors.Flavor) (string, string) { return f.ID, f.Name })
+	if err != nil {
+		return fl, images.Image{}, networks.Network{}, err
+	}
+	img, err := findRef("image", c.images, v[2], func(i images.Image) (string, string) { return i.ID, i.Name })
+	if err != nil {
+		return fl, img, networks.Network{}, err
+	}
+	net, err := findRef("network", c.networks, v[3], func(n networks.Network) (string, string) { return n.ID, n.Name })
+	return fl, img, net, err
+}
+
+// findRef returns the element whose ID is ref, or else the only one named
+// ref.
+func findRef[T any](kind string, list []T, ref string, key func(T) (id, name string)) (T, error) {
+	var zero T
+	if ref == "" {
+		return zero, fmt.Errorf("enter the %s", kind)
+	}
+	var found []T
+	for _, e := range list {
+		id, name := key(e)
+		if id == ref {
+			return e, nil
+		}
+		if name == ref {
+			found = append(found, e)
+		}
+	}
+	switch len(found) {
+	case 0:
+		return zero, fmt.Errorf("unknown %s %q", kind, ref)
+	case 1:
+		return found[0], nil
+	}
+	return zero, fmt.Errorf("%d %ss are named %q: use the ID", len(found), kind, ref)
+}
+
+// CreateServerRequest is the new server form: a name, a flavor, an image and
+// a network, each by name or ID, checked against the instance, vCPU and RAM
+// quotas as soon as the flavor resolves.
+func CreateServerRequest(cc client.ComputeClient, ic client.ImageClient, nc client.NetworkClient) quota.Request {
+	c := &serverChoices{}
+	return quota.Request{
+		Title:  i18n.T("New server"),
+		Action: "creating a server",
+		Fields: []string{"Name", "Flavor (name or ID)", "Image (name or ID)", "Network (name or ID)"},
+		Keys:   []string{quota.Instances, quota.Cores, quota.RAM},
+		Prepare: func(ctx context.Context) error {
+			var err error
+			if c.flavors, err = cc.ListFlavors(); err != nil {
+				return fmt.Errorf("failed to list flavors: %w", err)
+			}
+			if c.images, err = ic.ListImages(ctx); err != nil {
+				return fmt.Errorf("failed to list images: %w", err)
+			}
+			if c.networks, err = nc.ListNetworks(); err != nil {
+				return fmt.Errorf("failed to list networks: %w", err)
+			}
+			return nil
+		},
+		Need: func(v []string) (map[string]int, error) {
+			// The quotas depend on the flavor only; the other fields are
+			// checked on submit.
+			fl, err := findRef("flavor", c.flavors, v[1], func(f flavors.Flavor) (string, string) { return f.ID, f.Name })
+			if err != nil {
+				return nil, err
+			}
+			return map[string]int{quota.Instances: 1, quota.Cores: fl.VCPUs, quota.RAM: fl.RAM}, nil
+		},
+		Validate: func(v []string) error {
+			if v[0] == "" {
+				return errors.New("enter the server name")
+			}
+			_, _, _, err := c.resolve(v)
+			return err
+		},
+		Create: func(ctx context.Context, v []string) (string, ops.Poll, error) {
+			fl, img, net, err := c.resolve(v)
+			if err != nil {
+				return "", nil, err
+			}
+			srv, err := cc.CreateInstance(ctx, servers.CreateOpts{Name: v[0], FlavorRef: fl.ID, ImageRef: img.ID, Networks: []servers.Network{{UUID: net.ID}}})
+			if err != nil {
+				return "", nil, err
+			}
+			return i18n.T("Created server %s (%s), building", v[0], srv.ID), serverSettled(cc, srv.ID), nil
+		},
+	}
+}
+
+// serverSettled polls a new server until it is ACTIVE, or failed in ERROR.
+func serverSettled(cc client.ComputeClient, id string) ops.Poll {
+	return func(ctx context.Context) (bool, string, error) {
+		st, err := cc.GetServerState(ctx, id)
+		if err != nil {
+			return false, "", err
+		}
+		switch strings.ToUpper(st.Status) {
+		case "ACTIVE":
+			return true, st.Status, nil
+		case "ERROR":
+			return false, st.Status, fmt.Errorf("server %s went into ERROR", id)
+		}
+		return false, st.Status, nil
+	}
+}
@@ -6,14 +6,21 @@ import (
 	"fmt"
 	"os/exec"
 	"runtime"
+	"strings"
 	"time"
 
 	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
 	"ostui/internal/client"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/common"
+	"ostui/internal/ui/ops"
+	"ostui/internal/ui/policy"
+	"ostui/internal/ui/softlock"
 	"ostui/internal/ui/uiconst"
 )
 
@@ -30,6 +37,8 @@ type InstanceDetailModel struct {
 	// network and storage clients are required for the server graph view.
 	network client.NetworkClient
 	storage client.StorageClient
+	// images follows the images created from the server.
+	images client.ImageClient
 	// instanceID identifies the instance to fetch.
 	instanceID string
 	// console handling fields
@@ -37,6 +46,7 @@ type InstanceDetailModel struct {
 	showConsole    bool
 	consoleLoading bool
 	consoleErr     error
+	consoleQR      string
 	// JSON view fields
 	jsonView     string
 	jsonViewport viewport.Model
@@ -49,6 +59,85 @@ type InstanceDetailModel struct {
 	graphModel *ServerGraphModel
 	// showGraph toggles the graph view.
 	showGraph bool
+	// remediation handling for servers in ERROR state
+	pendingAction string
+	actionStatus  string
+	// preflight summarises the side effects of a pending delete; nil while
+	// it loads.
+	preflight         *deletePreflight
+	lastActionView    string
+	lastActionLoading bool
+	lastActionVP      viewport.Model
+	// flavorPicker is open while choosing the target flavor of a resize.
+	flavorPicker *common.PickerModel
+	resizeFlavor common.PickerItem
+	// rescuePicker is open while choosing the image of a rescue.
+	rescuePicker *common.PickerModel
+	rescueImage  common.PickerItem
+	// admin is the prompt of an admin recovery action (evacuate, rebuild
+	// preserving ephemeral); taskTrail records the task states seen after
+	// an action was submitted.
+	admin        *adminPrompt
+	taskTrail    []string
+	watchingTask bool
+	// lifecycle is the open menu of state-dependent lifecycle actions.
+	lifecycle *lifecycleMenu
+	// rebuild is the open rebuild, from its user data to the confirmation.
+	rebuild *rebuildPrompt
+	// Diagnostics view; prevDiag is the sample before the last refresh and
+	// gives the rates.
+	showDiag    bool
+	diagLoading bool
+	diag        *diagSample
+	prevDiag    *diagSample
+	diagErr     error
+	diagVP      viewport.Model
+	// passwordPrompt is the open guest password form; shownPassword is a
+	// decrypted admin password, masked by passwordGuard.
+	passwordPrompt *passwordPrompt
+	shownPassword  string
+	passwordGuard  softlock.Guard
+	// imageForm is the open create image form; newImage is the last image
+	// created from the server, followed until it is active.
+	imageForm *common.FormModel
+	newImage  *serverImage
+	// serial is the open interactive serial console.
+	serial *SerialConsoleModel
+	// queued is a palette action of the server list, run once loaded.
+	queued *common.Action
+}
+
+// CapturingInput reports whether a confirmation prompt, a menu, a form or
+// the flavor picker is open.
+func (m InstanceDetailModel) CapturingInput() bool {
+	return m.pendingAction != "" || m.flavorPicker != nil || m.rescuePicker != nil || m.admin != nil || m.lifecycle != nil || m.passwordPrompt != nil || m.shownPassword != "" || m.serial != nil || m.imageForm != nil || m.rebuild != nil
+}
+
+// ConfirmingDestructive reports whether the prompt open confirms an action
+// that deletes, rebuilds or interrupts the server. A delete only counts
+// once its pre-flight is shown, as y does nothing before.
+func (m InstanceDetailModel) ConfirmingDestructive() bool {
+	if m.rebuild != nil {
+		return m.rebuild.confirming
+	}
+	switch m.pendingAction {
+	case remediationDelete:
+		return m.preflight != nil
+	case remediationHardReboot, remediationRebuild, actionResize, lifecycleStop, lifecyclePause, lifecycleSuspend, lifecycleShelve, lifecycleRescue, lifecycleRevertResize:
+		return true
+	}
+	return false
+}
+
+// PassingKeysThrough reports whether the serial console is open, so that
+// ctrl+c reaches the server instead of quitting.
+func (m InstanceDetailModel) PassingKeysThrough() bool { return m.serial != nil }
+
+// Dispose closes the serial console left open when the view is closed.
+func (m InstanceDetailModel) Dispose() {
+	if m.serial != nil {
+		m.serial.Close()
+	}
 }
 
 // IsShowingGraph returns true if the graph view is currently displayed.
@@ -65,12 +154,15 @@ type consoleURLLoadedMsg struct {
 	err error
 }
 
+// ResourceID returns the server ID.
+func (m InstanceDetailModel) ResourceID() string { return m.instanceID }
+
 // NewInstanceDetailModel creates a new InstanceDetailModel for the given instance ID.
-func NewInstanceDetailModel(cc client.ComputeClient, nc client.NetworkClient, sc client.StorageClient, instanceID string) InstanceDetailModel {
+func NewInstanceDetailModel(cc client.ComputeClient, nc client.NetworkClient, sc client.StorageClient, ic client.ImageClient, instanceID string) InstanceDetailModel {
 	s := spinner.New()
 	s.Spinner = spinner.Dot
 	// Initialise with loading true; the table will be set after data is loaded.
-	return InstanceDetailModel{client: cc, network: nc, storage: sc, loading: true, spinner: s, instanceID: instanceID}
+	return InstanceDetailModel{client: cc, network: nc, storage: sc, images: ic, loading: true, spinner: s, instanceID: instanceID}
 }
 
 // Init starts the async loading of the instance details.
@@ -81,7 +173,7 @@ func (m InstanceDetailModel) Init() tea.Cmd {
 			return instanceDetailDataLoadedMsg{err: err}
 		}
 		// Build a two‑column table: split fields into two columns.
-		cols := []table.Column{{Title: "Field", Width: uiconst.ColWidthField}, {Title: "Value", Width: uiconst.ColWidthValueShort}, {Title: "Field", Width: uiconst.ColWidthField}, {Title: "Value", Width: uiconst.ColWidthValueShort}}
+		cols := []table.Column{{Title: i18n.T("Field"), Width: uiconst.ColWidthField}, {Title: i18n.T("Value"), Width: uiconst.ColWidthValueShort}, {Title: i18n.T("Field"), Width: uiconst.ColWidthField}, {Title: i18n.T("Value"), Width: uiconst.ColWidthValueShort}}
 		rows := []table.Row{{"ID", srv.ID}, {"Name", srv.Name}, {"Status", srv.Status}, {"Flavor", fmt.Sprintf("%v", srv.Flavor["id"])}, {"Image", fmt.Sprintf("%v", srv.Image["id"])}, {"Created", srv.Created.Format(time.RFC3339)}, {"Updated", srv.Updated.Format(time.RFC3339)}, {"HostID", srv.HostID}, {"KeyName", srv.KeyName}, {"UserID", srv.UserID}, {"TenantID", srv.TenantID}}
 		half := (len(rows) + 1) / 2
 		newRows := []table.Row{}
@@ -105,6 +197,14 @@ func (m InstanceDetailModel) Init() tea.Cmd {
 	}
 }
 
+// Reload re-fetches the server. The diagnostics refresh themselves with r.
+func (m InstanceDetailModel) Reload() tea.Cmd {
+	if m.loading || m.showDiag || m.showGraph {
+		return nil
+	}
+	return m.Init()
+}
+
 // Update handles messages for the model.
 func (m InstanceDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	// If graph view is active, forward messages to the graph model.
@@ -117,6 +217,41 @@ func (m InstanceDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, cmd
 	}
+	// The serial console takes keys and its own messages while it is open.
+	if m.serial != nil {
+		switch msg.(type) {
+		case serialConsoleClosedMsg:
+			m.serial = nil
+			return m, nil
+		case tea.KeyMsg, tea.WindowSizeMsg, spinner.TickMsg, serialConsoleOpenedMsg, serialConsoleOutputMsg:
+			updated, cmd := m.serial.Update(msg)
+			sc := updated.(SerialConsoleModel)
+			m.serial = &sc
+			return m, cmd
+		}
+	}
+	// The flavor picker takes every message while it is open.
+	if m.flavorPicker != nil {
+		if _, ok := msg.(instanceDetailDataLoadedMsg); !ok {
+			newModel, cmd := m.flavorPicker.Update(msg)
+			picker := newModel.(common.PickerModel)
+			m.flavorPicker = &picker
+			if picker.Done() {
+				m.flavorPicker = nil
+				if it, ok := picker.Selected(); ok {
+					m.resizeFlavor = it
+					m.pendingAction = actionResize
+				}
+			}
+			return m, cmd
+		}
+	}
+	// So does the rescue image picker.
+	if m.rescuePicker != nil {
+		if _, ok := msg.(instanceDetailDataLoadedMsg); !ok {
+			return m.updateRescuePicker(msg)
+		}
+	}
 	switch msg := msg.(type) {
 	case instanceDetailDataLoadedMsg:
 		m.loading = false
@@ -124,8 +259,132 @@ func (m InstanceDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.err = msg.err
 			return m, nil
 		}
-		m.table = msg.tbl
+		m.table = common.Reloaded(m.table, msg.tbl)
 		m.instance = msg.instance
+		if m.queued != nil {
+			a := *m.queued
+			m.queued = nil
+			return m.runAction(a)
+		}
+		return m, nil
+	case common.ActionMsg:
+		return m.runAction(msg.Action)
+	case deletePreflightMsg:
+		if m.pendingAction == remediationDelete {
+			m.preflight = &msg.preflight
+		}
+		return m, nil
+	case lifecycleStateMsg:
+		if m.lifecycle != nil {
+			m.lifecycle.loading = false
+			m.lifecycle.state, m.lifecycle.err = msg.state, msg.err
+			m.lifecycle.actions = lifecycleActions(msg.state)
+		}
+		return m, nil
+	case serverPasswordMsg:
+		if msg.err != nil {
+			m.actionStatus = i18n.T("Get password failed: %s", msg.err)
+			return m, nil
+		}
+		m.actionStatus = ""
+		m.shownPassword = msg.password
+		m.passwordGuard = softlock.NewGuard()
+		return m, m.passwordGuard.Reveal()
+	case softlock.CheckedMsg:
+		if m.shownPassword != "" {
+			var cmd tea.Cmd
+			m.passwordGuard, cmd = m.passwordGuard.Update(msg)
+			return m, cmd
+		}
+		return m, nil
+	case serverImageCreatedMsg:
+		if msg.err != nil {
+			m.actionStatus = i18n.T("Create image failed: %s", msg.err)
+			return m, nil
+		}
+		m.actionStatus = ""
+		m.newImage = &serverImage{id: msg.id, name: msg.name}
+		return m, pollServerImageCmd(m.images, msg.id, imagePollInterval, 0)
+	case serverImageStatusMsg:
+		// A newer image replaces the one followed before.
+		if m.newImage == nil || m.newImage.id != msg.id {
+			return m, nil
+		}
+		if m.newImage.record(msg) {
+			return m, pollServerImageCmd(m.images, msg.id, imagePollInterval, msg.polls)
+		}
+		return m, nil
+	case adminPasswordChangedMsg:
+		if msg.err != nil {
+			policy.Record(memberKeys["C"], msg.err)
+			m.actionStatus = changePasswordError(msg.err)
+			return m, nil
+		}
+		m.actionStatus = i18n.T("Admin password changed")
+		return m, nil
+	case userDataLoadedMsg:
+		if m.rebuild == nil {
+			return m, nil
+		}
+		return m.updateRebuild(msg)
+	case remediationDoneMsg:
+		if msg.err != nil {
+			policy.Record(msg.action, msg.err)
+			m.actionStatus = i18n.T("%s failed: %s", msg.action, msg.err)
+			return m, nil
+		}
+		m.actionStatus = i18n.T("%s requested", msg.action)
+		// Reload to pick up the new status and follow the task it started.
+		m.loading = true
+		m.taskTrail = nil
+		m.watchingTask = true
+		return m, tea.Batch(m.Init(), watchTaskCmd(m.client, m.instanceID, taskPollInterval, 0))
+	case rescueDoneMsg:
+		return m.rescueDone(msg)
+	case ops.FinishedMsg:
+		if msg.Resource != m.instanceID {
+			return m, nil
+		}
+		if msg.Err != nil {
+			m.actionStatus = i18n.T("%s failed: %s", msg.Title, msg.Err)
+			return m, nil
+		}
+		m.actionStatus = i18n.T("%s done", msg.Title)
+		if msg.Result == "deleted" {
+			return m, nil
+		}
+		m.loading = true
+		return m, m.Init()
+	case taskStateMsg:
+		if msg.err != nil {
+			m.watchingTask = false
+			return m, nil
+		}
+		var finished bool
+		m.taskTrail, finished = recordTaskState(m.taskTrail, msg)
+		if finished {
+			m.watchingTask = false
+			return m, m.Init()
+		}
+		return m, watchTaskCmd(m.client, m.instanceID, taskPollInterval, msg.polls)
+	case lastActionLoadedMsg:
+		m.lastActionLoading = false
+		if msg.err != nil {
+			m.actionStatus = i18n.T("Failed to load instance actions: %s", msg.err)
+			return m, nil
+		}
+		m.lastActionView = msg.content
+		m.lastActionVP = viewport.New(80, 24)
+		m.lastActionVP.SetContent(m.lastActionView)
+		return m, nil
+	case diagnosticsLoadedMsg:
+		m.diagLoading = false
+		m.diagErr = msg.err
+		if msg.err == nil {
+			m.prevDiag = m.diag
+			m.diag = &msg.sample
+		}
+		m.diagVP.SetContent(m.diagnosticsContent())
 		return m, nil
 	case consoleURLLoadedMsg:
 		m.consoleLoading = false
@@ -134,6 +393,7 @@ func (m InstanceDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		} else {
 			m.consoleURL = msg.url
 		}
+		m.consoleQR = ""
 		m.showConsole = true
 		return m, nil
 	case tea.WindowSizeMsg:
@@ -164,6 +424,82 @@ func (m InstanceDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 	case tea.KeyMsg:
+		if m.admin != nil {
+			return m.updateAdminPrompt(msg)
+		}
+		if m.lifecycle != nil {
+			return m.updateLifecycleMenu(msg)
+		}
+		if m.passwordPrompt != nil {
+			return m.updatePasswordPrompt(msg)
+		}
+		if m.shownPassword != "" {
+			return m.updateShownPassword(msg)
+		}
+		if m.imageForm != nil {
+			return m.updateImageForm(msg)
+		}
+		if m.rebuild != nil {
+			return m.updateRebuild(msg)
+		}
+		// Confirmation prompt for a remediation action.
+		if m.pendingAction != "" {
+			action := m.pendingAction
+			// The delete is only confirmed after its pre-flight is shown.
+			if action == remediationDelete && m.preflight == nil && msg.String() == "y" {
+				return m, nil
+			}
+			m.pendingAction = ""
+			m.preflight = nil
+			if msg.String() == "y" {
+				switch action {
+				case actionResize:
+					m.actionStatus = i18n.T("%s queued; follow it with :jobs", action)
+					return m, queueResizeCmd(m.client, m.instance, m.resizeFlavor.ID, m.resizeFlavor.Cells[0])
+				case remediationDelete:
+					m.actionStatus = i18n.T("%s queued; follow it with :jobs", action)
+					return m, queueDeleteCmd(m.client, m.instance)
+				case lifecycleRescue:
+					m.actionStatus = i18n.T("Submitting %s...", action)
+					return m, rescueCmd(m.client, m.instanceID, m.rescueImage.ID)
+				}
+				m.actionStatus = i18n.T("Submitting %s...", action)
+				if isLifecycleAction(action) {
+					return m, runLifecycleCmd(m.client, m.instanceID, action)
+				}
+				return m, runRemediationCmd(m.client, m.instance, action)
+			}
+			m.actionStatus = ""
+			return m, nil
+		}
+		// If the diagnostics view is active, handle its keys.
+		if m.showDiag {
+			switch msg.String() {
+			case "d", "esc":
+				m.showDiag = false
+				return m, nil
+			case "r":
+				if !m.diagLoading {
+					m.diagLoading = true
+					return m, loadDiagnosticsCmd(m.client, m.instanceID)
+				}
+				return m, nil
+			}
+			var cmd tea.Cmd
+			m.diagVP, cmd = m.diagVP.Update(msg)
+			return m, cmd
+		}
+		// If last action view is active, handle its keys.
+		if m.lastActionView != "" {
+			if msg.String() == "a" || msg.String() == "esc" {
+				m.lastActionView = ""
+				m.lastActionVP = viewport.Model{}
+				return m, nil
+			}
+			var cmd tea.Cmd
+			m.lastActionVP, cmd = m.lastActionVP.Update(msg)
+			return m, cmd
+		}
 		// If Inspect view is active, handle its keys.
 		if m.inspectView != "" {
 			if msg.String() == "i" || msg.String() == "esc" {
@@ -200,6 +536,20 @@ func (m InstanceDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				_ = cmd.Start()
 				return m, nil
 			}
+			if msg.String() == "Q" && m.consoleURL != "" {
+				// Toggle the QR code for opening the URL on another device.
+				if m.consoleQR != "" {
+					m.consoleQR = ""
+					return m, nil
+				}
+				qr, err := renderQRCode(m.consoleURL)
+				if err != nil {
+					m.consoleErr = err
+					return m, nil
+				}
+				m.consoleQR = qr
+				return m, nil
+			}
 			// Any other key closes the console view.
 			m.showConsole = false
 			return m, nil
@@ -208,6 +558,93 @@ func (m InstanceDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			// Ignore key input while loading or on error.
 			return m, nil
 		}
+		// Remediation shortcuts for servers in ERROR state.
+		if m.instance.Status == "ERROR" {
+			if msg.String() == "a" {
+				m.lastActionLoading = true
+				return m, loadLastActionCmd(m.client, m.instanceID)
+			}
+			if action, ok := remediationKeys[msg.String()]; ok {
+				if err := policy.Check(policy.Member, action); err != nil {
+					m.actionStatus = err.Error()
+					return m, nil
+				}
+				m.pendingAction = action
+				if action == remediationDelete {
+					m.preflight = nil
+					return m, loadDeletePreflightCmd(m.client, m.network, m.storage, m.instanceID)
+				}
+				return m, nil
+			}
+		}
+		// Leaving rescue mode, from the banner.
+		if m.instance.Status == "RESCUE" && msg.String() == "U" {
+			if err := policy.Check(policy.Member, lifecycleUnrescue); err != nil {
+				m.actionStatus = err.Error()
+				return m, nil
+			}
+			m.actionStatus = i18n.T("Submitting %s...", lifecycleUnrescue)
+			return m, runLifecycleCmd(m.client, m.instanceID, lifecycleUnrescue)
+		}
+		// Confirming or reverting a resize, from the banner. The revert asks
+		// first, as from the lifecycle menu.
+		if action, ok := verifyResizeKeys[msg.String()]; ok && m.instance.Status == "VERIFY_RESIZE" {
+			if err := policy.Check(policy.Member, action); err != nil {
+				m.actionStatus = err.Error()
+				return m, nil
+			}
+			if action == lifecycleRevertResize {
+				m.pendingAction = action
+				m.actionStatus = ""
+				return m, nil
+			}
+			m.actionStatus = i18n.T("Submitting %s...", action)
+			return m, runLifecycleCmd(m.client, m.instanceID, action)
+		}
+		if action, ok := adminKeys[msg.String()]; ok {
+			if err := policy.Check(actionRule(action), action); err != nil {
+				m.actionStatus = err.Error()
+				return m, nil
+			}
+			m.admin = newAdminPrompt(action)
+			m.actionStatus = ""
+			return m, textinput.Blink
+		}
+		if action, ok := memberKeys[msg.String()]; ok {
+			if err := policy.Check(policy.Member, action); err != nil {
+				m.actionStatus = err.Error()
+				return m, nil
+			}
+		}
+		if msg.String() == "L" {
+			m.lifecycle = &lifecycleMenu{loading: true}
+			m.actionStatus = ""
+			return m, loadLifecycleStateCmd(m.client, m.instanceID)
+		}
+		if msg.String() == "W" || msg.String() == "C" {
+			m.passwordPrompt = newPasswordPrompt(msg.String() == "C")
+			m.actionStatus = ""
+			return m, m.passwordPrompt.form.Init()
+		}
+		if msg.String() == "I" {
+			return m.startImageForm()
+		}
+		if msg.String() == "R" {
+			return m.startRebuild()
+		}
+		if msg.String() == "N" {
+			open := OpenServerDNSMsg{ServerID: m.instanceID, Name: m.instance.Name}
+			return m, func() tea.Msg { return open }
+		}
+		if msg.String() == "o" && m.newImage != nil {
+			id := m.newImage.id
+			return m, func() tea.Msg { return OpenImageMsg{ImageID: id} }
+		}
+		if msg.String() == "F" {
+			picker := NewFlavorPicker(m.client, "Resize "+m.instance.Name+" to flavor")
+			m.flavorPicker = &picker
+			return m, picker.Init()
+		}
 		// Custom key handling for opening logs, inspect, and console.
 		if msg.String() == "l" {
 			// Emit openLogsMsg with the instance ID.
@@ -215,12 +652,20 @@ func (m InstanceDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		if msg.String() == "i" {
 			// Build inspect view for instance.
-			content := fmt.Sprintf("=== Instance: %s ===\nID: %s\nName: %s\nStatus: %s\nFlavor: %s\nImage: %s\nCreated: %s\nUpdated: %s\nHostID: %s\nKeyName: %s\nUserID: %s\nTenantID: %s", m.instance.Name, m.instance.ID, m.instance.Name, m.instance.Status, fmt.Sprintf("%v", m.instance.Flavor["id"]), fmt.Sprintf("%v", m.instance.Image["id"]), m.instance.Created.Format(time.RFC3339), m.instance.Updated.Format(time.RFC3339), m.instance.HostID, m.instance.KeyName, m.instance.UserID, m.instance.TenantID)
+			content := i18n.T("=== Instance: %s ===\nID: %s\nName: %s\nStatus: %s\nFlavor: %s\nImage: %s\nCreated: %s\nUpdated: %s\nHostID: %s\nKeyName: %s\nUserID: %s\nTenantID: %s", m.instance.Name, m.instance.ID, m.instance.Name, m.instance.Status, fmt.Sprintf("%v", m.instance.Flavor["id"]), fmt.Sprintf("%v", m.instance.Image["id"]), m.instance.Created.Format(time.RFC3339), m.instance.Updated.Format(time.RFC3339), m.instance.HostID, m.instance.KeyName, m.instance.UserID, m.instance.TenantID)
 			m.inspectView = content
 			m.inspectViewport = viewport.New(80, 24)
 			m.inspectViewport.SetContent(m.inspectView)
 			return m, nil
 		}
+		if msg.String() == "d" {
+			// Diagnostics start from a fresh sample each time they are opened.
+			m.showDiag = true
+			m.diagLoading = true
+			m.diag, m.prevDiag, m.diagErr = nil, nil, nil
+			m.diagVP = viewport.New(100, 24)
+			return m, loadDiagnosticsCmd(m.client, m.instanceID)
+		}
 		if msg.String() == "v" {
 			// Fetch console URL.
 			m.consoleLoading = true
@@ -229,6 +674,11 @@ func (m InstanceDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return consoleURLLoadedMsg{url: url, err: err}
 			}
 		}
+		if msg.String() == "S" {
+			sc := NewSerialConsoleModel(m.client, m.instanceID, m.instance.Name)
+			m.serial = &sc
+			return m, sc.Init()
+		}
 		if msg.String() == "g" {
 			// Initialize graph model if not already
 			if m.graphModel == nil {
@@ -276,25 +726,120 @@ func (m InstanceDetailModel) View() string {
 	if m.showGraph && m.graphModel != nil {
 		return m.graphModel.View()
 	}
+	if m.serial != nil {
+		return m.serial.View()
+	}
+	if m.flavorPicker != nil {
+		return m.flavorPicker.View()
+	}
+	if m.rescuePicker != nil {
+		return m.rescuePicker.View()
+	}
 	if m.jsonView != "" {
-		return fmt.Sprintf("%s\nPress 'y' or 'esc' to close", m.jsonViewport.View())
+		return i18n.T("%s\nPress 'y' or 'esc' to close", m.jsonViewport.View())
 	}
 	if m.inspectView != "" {
-		return fmt.Sprintf("%s\n %3.f%% | [j/k] scroll  [esc] close", m.inspectViewport.View(), m.inspectViewport.ScrollPercent()*100)
+		return i18n.T("%s\n %3.f%% | [j/k] scroll  [esc] close", m.inspectViewport.View(), m.inspectViewport.ScrollPercent()*100)
+	}
+	if m.lastActionView != "" {
+		return i18n.T("%s\n %3.f%% | [j/k] scroll  [a] close", m.lastActionVP.View(), m.lastActionVP.ScrollPercent()*100)
+	}
+	if m.showDiag {
+		status := ""
+		if m.diagLoading {
+			status = i18n.T("Fetching diagnostics...") + " | "
+		}
+		return i18n.T("%s\n%s[r] refresh  [j/k] scroll  [d] close", m.diagVP.View(), status)
+	}
+	if m.lastActionLoading {
+		return "Fetching instance actions..."
 	}
 	if m.consoleLoading {
 		return "Fetching console URL..."
 	}
 	if m.showConsole {
 		if m.consoleErr != nil {
-			return fmt.Sprintf("Error fetching console URL: %s\nPress any key to return", m.consoleErr)
+			return i18n.T("Error fetching console URL: %s\nPress any key to return", m.consoleErr)
+		}
+		if m.consoleQR != "" {
+			return i18n.T("Console URL: %s\n\n%s\nPress 'Q' to hide the QR code, 'o' to open in browser, any other key to return", m.consoleURL, m.consoleQR)
 		}
-		return fmt.Sprintf("Console URL: %s\nPress 'o' to open in browser, any other key to return", m.consoleURL)
+		return i18n.T("Console URL: %s\nPress 'o' to open in browser, 'Q' for a QR code, any other key to return", m.consoleURL)
 	}
 	if m.err != nil {
-		return fmt.Sprintf("Error: %s", m.err)
+		return i18n.T("Error: %s", m.err)
+	}
+	out := ""
+	switch m.instance.Status {
+	case "ERROR":
+		out = renderFaultBanner(m.instance) + "\n"
+	case "RESCUE":
+		out = renderRescueBanner(m.instance) + "\n"
+	case "VERIFY_RESIZE":
+		out = renderVerifyResizeBanner(m.instance) + "\n"
+	}
+	out += i18n.T("%s\n[l] logs  [y] json  [i] inspect  [v] console  [S] serial  [d] diagnostics  [g] graph  %s  %s  [esc] back", m.table.View(), policy.Key(policy.Member, i18n.T("[F] resize"), actionResize), policy.Key(policy.Member, i18n.T("[L] lifecycle")))
+	out += "\n" + i18n.T("[W] decrypt admin password") + "  " + policy.Key(policy.Member, i18n.T("[C] change admin password"), memberKeys["C"]) + "  " + policy.Key(policy.Member, i18n.T("[I] create image"), memberKeys["I"]) + "  " + i18n.T("[N] DNS records")
+	if rebuildableStatuses[m.instance.Status] {
+		out += "  " + policy.Key(policy.Member, i18n.T("[R] rebuild"), remediationRebuild)
+	}
+	out += "\n[admin] " + policy.Key(policy.Admin, i18n.T("[X] evacuate"), actionEvacuate) + "  " + policy.Key(policy.Member, i18n.T("[P] rebuild preserving ephemeral"), actionRebuildPreserve)
+	if m.instance.Status == "ERROR" {
+		out += "\n" + i18n.T("[a] last action") + "  " + policy.Key(policy.Member, i18n.T("[H] hard reboot"), remediationHardReboot) + "  " + policy.Key(policy.Member, i18n.T("[D] delete"), remediationDelete)
+	}
+	if m.admin != nil {
+		out += m.admin.view(m.instance.Name)
+	} else if m.lifecycle != nil {
+		out += m.lifecycle.view(m.instance.Name)
+	} else if m.rebuild != nil {
+		out += m.rebuildView()
+	} else if m.imageForm != nil {
+		out += "\n" + i18n.T("Create an image of server %s (createImage)\n%s", m.instance.Name, m.imageForm.View())
+	} else if m.passwordPrompt != nil || m.shownPassword != "" {
+		out += m.passwordView()
+	} else if m.pendingAction == actionResize {
+		out += "\n" + i18n.T("Resize server %s to flavor %s? [y/N]", m.instance.Name, m.resizeFlavor.Cells[0])
+	} else if m.pendingAction == lifecycleRescue {
+		out += "\n" + i18n.T("Rescue server %s from image %s? [y/N]", m.instance.Name, m.rescueImage.Cells[0])
+	} else if m.pendingAction == remediationDelete && m.preflight == nil {
+		out += "\nChecking what the delete releases…"
+	} else if m.pendingAction == remediationDelete {
+		out += "\n" + m.preflight.render(m.instance.Name) + i18n.T("Delete server %s? [y/N]", m.instance.Name)
+	} else if m.pendingAction != "" {
+		out += "\n" + i18n.T("%s server %s? [y/N]", strings.ToUpper(m.pendingAction[:1])+m.pendingAction[1:], m.instance.Name)
+	} else if m.actionStatus != "" {
+		out += "\n" + m.actionStatus
+	}
+	if m.newImage != nil {
+		out += m.newImage.view()
+	}
+	if len(m.taskTrail) > 0 || m.watchingTask {
+		trail := strings.Join(m.taskTrail, " → ")
+		if m.watchingTask {
+			trail += " …"
+		}
+		out += "\nTask: " + trail
+	}
+	return out
+}
+
+// diagnosticsContent renders the diagnostics view body.
+func (m InstanceDetailModel) diagnosticsContent() string {
+	if m.diagErr != nil {
+		out := i18n.T("Failed to fetch diagnostics: %s", m.diagErr)
+		if m.diag != nil {
+			out += "\nShowing the previous sample.\n\n" + renderDiagnostics(*m.diag, m.prevDiag)
+		}
+		return out
+	}
+	if m.diag == nil {
+		return ""
+	}
+	out := renderDiagnostics(*m.diag, m.prevDiag)
+	if m.prevDiag == nil {
+		out += "\nPress r to refresh; rates are computed between refreshes."
 	}
-	return fmt.Sprintf("%s\n[l] logs  [y] json  [i] inspect  [v] console  [g] graph  [esc] back", m.table.View())
+	return out
 }
 
 // Ensure InstanceDetailModel implements tea.Model.
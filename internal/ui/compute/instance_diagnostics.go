@@ -0,0 +1,154 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"ostui/internal/client"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/common"
+	"ostui/internal/ui/theme"
+)
+
+// diagSample is a diagnostics snapshot with the time it was taken.
+type diagSample struct {
+	diag client.ServerDiagnostics
+	at   time.Time
+}
+
+type diagnosticsLoadedMsg struct {
+	sample diagSample
+	err    error
+}
+
+// loadDiagnosticsCmd fetches a diagnostics snapshot of the server.
+func loadDiagnosticsCmd(cc client.ComputeClient, id string) tea.Cmd {
+	return func() tea.Msg {
+		d, err := cc.GetServerDiagnostics(context.Background(), id)
+		return diagnosticsLoadedMsg{sample: diagSample{diag: d, at: time.Now()}, err: err}
+	}
+}
+
+// rate returns the per-second increase of a counter between two samples, or
+// "" when there is no earlier sample or the counter was reset (reboot).
+func rate(cur, prev uint64, dt time.Duration, format func(float64) string) string {
+	if dt <= 0 || cur < prev {
+		return ""
+	}
+	return format(float64(cur-prev) / dt.Seconds())
+}
+
+func bytesPerSec(v float64) string { return common.FormatBytes(int64(v)) + "/s" }
+
+func perSec(v float64) string { return fmt.Sprintf("%.1f/s", v) }
+
+// formatUptime renders seconds as e.g. "3d 4h" or "12m".
+func formatUptime(sec int64) string {
+	d := time.Duration(sec) * time.Second
+	switch {
+	case d >= 24*time.Hour:
+		return fmt.Sprintf("%dd %dh", int(d.Hours())/24, int(d.Hours())%24)
+	case d >= time.Hour:
+		return fmt.Sprintf("%dh %dm", int(d.Hours()), int(d.Minutes())%60)
+	}
+	return fmt.Sprintf("%dm", int(d.Minutes()))
+}
+
+// renderDiagnostics draws the counters of cur. With an earlier sample of the
+// same server, CPU utilisation and per-second rates since then are added.
+func renderDiagnostics(cur diagSample, prev *diagSample) string {
+	title := lipgloss.NewStyle().Bold(true).Foreground(theme.Accent).Render
+	dim := lipgloss.NewStyle().Foreground(theme.Muted).Render
+	d := cur.diag
+	var dt time.Duration
+	var p client.ServerDiagnostics
+	if prev != nil {
+		dt = cur.at.Sub(prev.at)
+		p = prev.diag
+	}
+
+	var b strings.Builder
+	head := "Diagnostics"
+	var facts []string
+	for _, f := range []string{d.Driver, d.State} {
+		if f != "" {
+			facts = append(facts, f)
+		}
+	}
+	if d.Uptime > 0 {
+		facts = append(facts, "up "+formatUptime(d.Uptime))
+	}
+	if len(facts) > 0 {
+		head += " – " + strings.Join(facts, ", ")
+	}
+	b.WriteString(title(head) + dim(i18n.T("  (taken %s", cur.at.Format("15:04:05"))))
+	if prev != nil {
+		b.WriteString(dim(i18n.T(", rates over %s", dt.Round(time.Second))))
+	}
+	b.WriteString(dim(")") + "\n\n")
+
+	b.WriteString(title("CPU") + "\n")
+	if len(d.CPUs) == 0 {
+		b.WriteString("  " + i18n.T("no CPU counters reported") + "\n")
+	}
+	for _, c := range d.CPUs {
+		line := i18n.T("  vCPU%-3d %10.1fs", c.ID, float64(c.TimeNS)/1e9)
+		for _, pc := range p.CPUs {
+			if pc.ID == c.ID {
+				if u := rate(c.TimeNS, pc.TimeNS, dt, func(v float64) string { return fmt.Sprintf("%5.1f%%", v/1e7) }); u != "" {
+					line += "  " + u
+				}
+			}
+		}
+		b.WriteString(line + "\n")
+	}
+
+	b.WriteString("\n" + title(i18n.T("Memory")) + "\n")
+	switch {
+	case d.MemoryMaxMB > 0 && d.MemoryUsedMB > 0:
+		b.WriteString(i18n.T("  %d / %d MB used (%d%%)", d.MemoryUsedMB, d.MemoryMaxMB, d.MemoryUsedMB*100/d.MemoryMaxMB) + "\n")
+	case d.MemoryMaxMB > 0:
+		b.WriteString(i18n.T("  %d MB, usage not reported", d.MemoryMaxMB) + "\n")
+	default:
+		b.WriteString("  " + i18n.T("not reported") + "\n")
+	}
+
+	b.WriteString("\n" + title(i18n.T("Network")) + "\n")
+	if len(d.NICs) == 0 {
+		b.WriteString("  " + i18n.T("no interfaces reported") + "\n")
+	} else {
+		b.WriteString(dim(fmt.Sprintf("  %-18s %12s %12s %10s %10s %7s %7s  %s", "NIC", "RX", "TX", i18n.T("RX pkts"), i18n.T("TX pkts"), i18n.T("errors"), i18n.T("drops"), "RX/s  TX/s")) + "\n")
+	}
+	for _, n := range d.NICs {
+		line := fmt.Sprintf("  %-18s %12s %12s %10d %10d %7d %7d", n.Name, common.FormatBytes(int64(n.RxBytes)), common.FormatBytes(int64(n.TxBytes)), n.RxPackets, n.TxPackets, n.RxErrors+n.TxErrors, n.RxDrop+n.TxDrop)
+		for _, pn := range p.NICs {
+			if pn.Name == n.Name {
+				line += "  " + strings.TrimSpace(rate(n.RxBytes, pn.RxBytes, dt, bytesPerSec)+"  "+rate(n.TxBytes, pn.TxBytes, dt, bytesPerSec))
+			}
+		}
+		b.WriteString(line + "\n")
+	}
+
+	b.WriteString("\n" + title(i18n.T("Disks")) + "\n")
+	if len(d.Disks) == 0 {
+		b.WriteString("  " + i18n.T("no disks reported") + "\n")
+	} else {
+		b.WriteString(dim(fmt.Sprintf("  %-18s %12s %12s %10s %10s %7s  %s", i18n.T("Disk"), i18n.T("Read"), i18n.T("Written"), i18n.T("Reads"), i18n.T("Writes"), i18n.T("errors"), i18n.T("read/s  write/s  IOPS"))) + "\n")
+	}
+	for _, k := range d.Disks {
+		line := fmt.Sprintf("  %-18s %12s %12s %10d %10d %7d", k.Name, common.FormatBytes(int64(k.ReadBytes)), common.FormatBytes(int64(k.WriteBytes)), k.ReadRequests, k.WriteRequests, k.Errors)
+		for _, pk := range p.Disks {
+			if pk.Name == k.Name {
+				line += "  " + strings.TrimSpace(rate(k.ReadBytes, pk.ReadBytes, dt, bytesPerSec)+"  "+
+					rate(k.WriteBytes, pk.WriteBytes, dt, bytesPerSec)+"  "+
+					rate(k.ReadRequests+k.WriteRequests, pk.ReadRequests+pk.WriteRequests, dt, perSec))
+			}
+		}
+		b.WriteString(line + "\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,75 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
+	"ostui/internal/client"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/editor"
+)
+
+// instanceEdit holds the mutable fields of a server as edited in YAML.
+type instanceEdit struct {
+	Name     string            `yaml:"name"`
+	Metadata map[string]string `yaml:"metadata"`
+}
+
+// EditSpec implements editor.Editable for the instance detail view.
+func (m InstanceDetailModel) EditSpec() editor.Spec {
+	c, id := m.client, m.instanceID
+	return editor.Spec{
+		Title: i18n.T("server %s", id),
+		Load: func() (string, error) {
+			srv, err := c.GetInstance(id)
+			if err != nil {
+				return "", err
+			}
+			return editor.Encode(instanceEdit{Name: srv.Name, Metadata: srv.Metadata})
+		},
+		Apply: func(original, edited string) (string, error) {
+			return applyInstanceEdit(c, id, original, edited)
+		},
+	}
+}
+
+// applyInstanceEdit validates edited and sends only the changed fields.
+func applyInstanceEdit(c client.ComputeClient, id, original, edited string) (string, error) {
+	var before, after instanceEdit
+	if err := editor.Decode(original, &before); err != nil {
+		return "", err
+	}
+	if err := editor.Decode(edited, &after); err != nil {
+		return "", err
+	}
+	if after.Name == "" {
+		return "", fmt.Errorf("name must not be empty")
+	}
+	ctx := context.Background()
+	if after.Name != before.Name {
+		if err := c.UpdateInstance(ctx, id, servers.UpdateOpts{Name: after.Name}); err != nil {
+			return "", fmt.Errorf("update name: %w", err)
+		}
+	}
+	if !reflect.DeepEqual(normalizeMetadata(before.Metadata), normalizeMetadata(after.Metadata)) {
+		if err := c.ResetInstanceMetadata(ctx, id, normalizeMetadata(after.Metadata)); err != nil {
+			return "", fmt.Errorf("update metadata: %w", err)
+		}
+	}
+	return editor.Summary(editor.ChangedFields(normalizedInstanceEdit(before), normalizedInstanceEdit(after))), nil
+}
+
+// normalizeMetadata treats a missing metadata block like an empty one.
+func normalizeMetadata(md map[string]string) map[string]string {
+	if md == nil {
+		return map[string]string{}
+	}
+	return md
+}
+
+func normalizedInstanceEdit(e instanceEdit) instanceEdit {
+	e.Metadata = normalizeMetadata(e.Metadata)
+	return e
+}
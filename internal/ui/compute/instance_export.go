@@ -0,0 +1,93 @@
+package compute
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/iac"
+)
+
+// ExportSpec implements iac.Exportable for the instance detail view.
+func (m InstanceDetailModel) ExportSpec() iac.Spec {
+	c, id := m.client, m.instanceID
+	title, file := i18n.T("server %s", id), id
+	if m.instance.Name != "" {
+		title, file = i18n.T("server %s", m.instance.Name), m.instance.Name
+	}
+	return iac.Spec{
+		Title: title,
+		File:  file,
+		Load: func() (iac.Code, error) {
+			srv, err := c.GetInstance(id)
+			if err != nil {
+				return iac.Code{}, err
+			}
+			return exportServer(srv), nil
+		},
+	}
+}
+
+// exportServer renders a server as an openstack_compute_instance_v2. The
+// networks are referred to by name, as the addresses of the server only
+// give their names; a server booted from a volume has no image.
+func exportServer(srv servers.Server) iac.Code {
+	name := iac.Namer{}.Name("openstack_compute_instance_v2", srv.Name, "server")
+	flavorAttr, flavor := "flavor_id", fmt.Sprint(srv.Flavor["id"])
+	if n, ok := srv.Flavor["original_name"].(string); ok && n != "" {
+		flavorAttr, flavor = "flavor_name", n
+	}
+	image, _ := srv.Image["id"].(string)
+	var groups []string
+	seen := map[string]bool{}
+	for _, g := range srv.SecurityGroups {
+		if n, ok := g["name"].(string); ok && !seen[n] {
+			seen[n] = true
+			groups = append(groups, n)
+		}
+	}
+	nets := make([]string, 0, len(srv.Addresses))
+	for n := range srv.Addresses {
+		nets = append(nets, n)
+	}
+	sort.Strings(nets)
+
+	blk := iac.Block{
+		Type: "openstack_compute_instance_v2",
+		Name: name,
+		ID:   srv.ID,
+		Attrs: []iac.Attr{
+			{Name: "name", Value: srv.Name},
+			{Name: flavorAttr, Value: flavor},
+			{Name: "image_id", Value: image},
+			{Name: "key_pair", Value: srv.KeyName},
+			{Name: "security_groups", Value: groups},
+			{Name: "metadata", Value: srv.Metadata},
+		},
+	}
+	args := []string{"server", "create", "--flavor", flavor}
+	if image != "" {
+		args = append(args, "--image", image)
+	}
+	if srv.KeyName != "" {
+		args = append(args, "--key-name", srv.KeyName)
+	}
+	for _, g := range groups {
+		args = append(args, "--security-group", g)
+	}
+	for _, n := range nets {
+		blk.Blocks = append(blk.Blocks, iac.Block{Type: "network", Attrs: []iac.Attr{{Name: "name", Value: n}}})
+		args = append(args, "--network", n)
+	}
+	keys := make([]string, 0, len(srv.Metadata))
+	for k := range srv.Metadata {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		args = append(args, "--property", k+"="+srv.Metadata[k])
+	}
+	args = append(args, srv.Name)
+	return iac.Code{Resources: []iac.Block{blk}, Commands: []string{iac.Command(args...)}}
+}
@@ -0,0 +1,161 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
+)
+
+// groupMode selects how the server list is grouped; G cycles through them.
+type groupMode int
+
+const (
+	groupNone groupMode = iota
+	groupStatus
+	groupAZ
+	groupFlavor
+	groupMetadata
+)
+
+func (g groupMode) String() string {
+	switch g {
+	case groupStatus:
+		return "status"
+	case groupAZ:
+		return "availability zone"
+	case groupFlavor:
+		return "flavor"
+	case groupMetadata:
+		return "metadata"
+	}
+	return "none"
+}
+
+// noGroupKey labels servers without a value for the grouping key.
+const noGroupKey = "(none)"
+
+// serverGroup is one group header with its member servers.
+type serverGroup struct {
+	Key     string
+	Servers []servers.Server
+}
+
+// groupServers buckets srvs by key, sorted by group name with servers
+// lacking a key last. Servers keep their order within a group.
+func groupServers(srvs []servers.Server, key func(servers.Server) string) []serverGroup {
+	idx := map[string]int{}
+	var groups []serverGroup
+	for _, s := range srvs {
+		k := key(s)
+		if k == "" {
+			k = noGroupKey
+		}
+		i, ok := idx[k]
+		if !ok {
+			i = len(groups)
+			idx[k] = i
+			groups = append(groups, serverGroup{Key: k})
+		}
+		groups[i].Servers = append(groups[i].Servers, s)
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		if (groups[i].Key == noGroupKey) != (groups[j].Key == noGroupKey) {
+			return groups[j].Key == noGroupKey
+		}
+		return groups[i].Key < groups[j].Key
+	})
+	return groups
+}
+
+// groupedRows renders groups as a header row followed by its servers, unless
+// the group is collapsed. Header rows have an empty ID column.
+func groupedRows(groups []serverGroup, collapsed map[string]bool, now time.Time) []table.Row {
+	var rows []table.Row
+	for _, g := range groups {
+		marker := "▾"
+		if collapsed[g.Key] {
+			marker = "▸"
+		}
+		rows = append(rows, table.Row{"", fmt.Sprintf("%s %s", marker, g.Key), fmt.Sprintf("%d", len(g.Servers)), "", ""})
+		if collapsed[g.Key] {
+			continue
+		}
+		for _, s := range g.Servers {
+			rows = append(rows, serverRow(s, "  "+s.Name, now))
+		}
+	}
+	return rows
+}
+
+// flavorRef returns the flavor name embedded in the server (microversion
+// 2.47+) or else its flavor ID.
+func flavorRef(s servers.Server) (name, id string) {
+	name, _ = s.Flavor["original_name"].(string)
+	id, _ = s.Flavor["id"].(string)
+	return name, id
+}
+
+// groupLookupMsg carries the data needed for AZ and flavor grouping, which
+// is loaded the first time either mode is selected.
+type groupLookupMsg struct {
+	zones   map[string]string
+	flavors map[string]string
+	err     error
+}
+
+// loadGroupLookup fetches what mode needs and is not cached yet, or returns
+// nil when nothing is missing.
+func (m InstancesModel) loadGroupLookup(mode groupMode) tea.Cmd {
+	switch {
+	case mode == groupAZ && m.zones == nil:
+		return func() tea.Msg {
+			zones, err := m.client.ListServerZones(context.Background())
+			if err != nil {
+				return groupLookupMsg{err: fmt.Errorf("server zones: %w", err)}
+			}
+			return groupLookupMsg{zones: zones}
+		}
+	case mode == groupFlavor && m.flavorNames == nil:
+		return func() tea.Msg {
+			fl, err := m.client.ListFlavors()
+			if err != nil {
+				return groupLookupMsg{err: fmt.Errorf("flavors: %w", err)}
+			}
+			names := make(map[string]string, len(fl))
+			for _, f := range fl {
+				names[f.ID] = f.Name
+			}
+			return groupLookupMsg{flavors: names}
+		}
+	}
+	return nil
+}
+
+// groupKey returns the function extracting the current grouping key.
+func (m InstancesModel) groupKey() func(servers.Server) string {
+	switch m.groupBy {
+	case groupStatus:
+		return func(s servers.Server) string { return s.Status }
+	case groupAZ:
+		return func(s servers.Server) string { return m.zones[s.ID] }
+	case groupFlavor:
+		return func(s servers.Server) string {
+			name, id := flavorRef(s)
+			if name == "" {
+				name = m.flavorNames[id]
+			}
+			if name == "" {
+				name = id
+			}
+			return name
+		}
+	case groupMetadata:
+		return func(s servers.Server) string { return s.Metadata[m.groupMetaKey] }
+	}
+	return nil
+}
@@ -0,0 +1,190 @@
+package compute
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
+	"ostui/internal/client"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/common"
+)
+
+// OpenImageMsg asks the app to open the detail view of an image.
+type OpenImageMsg struct {
+	ImageID string
+}
+
+// actionCreateImage snapshots the server to a new image.
+const actionCreateImage = "create image"
+
+// Fields of the create image form.
+const (
+	imageFieldName = iota
+	imageFieldMetadata
+)
+
+// imagePollInterval is how often a new image is polled until it is active.
+const imagePollInterval = 3 * time.Second
+
+// maxImagePolls bounds how long a new image is followed (about half an
+// hour; the upload of a large disk takes a while).
+const maxImagePolls = 600
+
+// imageableStatuses are the server statuses nova creates an image in.
+var imageableStatuses = map[string]bool{"ACTIVE": true, "SHUTOFF": true, "PAUSED": true, "SUSPENDED": true}
+
+// newImageForm opens the form of a server snapshot, named after the server
+// and the day.
+func newImageForm(srv servers.Server) *common.FormModel {
+	f := common.NewForm([]string{i18n.T("Image name"), i18n.T("Metadata (key=value, comma-separated)")})
+	f.SetValue(imageFieldName, fmt.Sprintf("%s-snapshot-%s", srv.Name, time.Now().Format("20060102")))
+	return &f
+}
+
+// parseImageMetadata parses "k=v, k2=v2" into image metadata.
+func parseImageMetadata(s string) (map[string]string, error) {
+	md := map[string]string{}
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		k, v, ok := strings.Cut(part, "=")
+		k = strings.TrimSpace(k)
+		if !ok || k == "" {
+			return nil, fmt.Errorf("metadata %q is not key=value", part)
+		}
+		md[k] = strings.TrimSpace(v)
+	}
+	return md, nil
+}
+
+// serverImage is an image created from the server, followed until Glance
+// reports it active or failed.
+type serverImage struct {
+	id       string
+	name     string
+	status   string
+	progress int
+	err      error
+	done     bool
+}
+
+// serverImageCreatedMsg reports the result of createImage.
+type serverImageCreatedMsg struct {
+	id   string
+	name string
+	err  error
+}
+
+// serverImageStatusMsg carries a poll of the new image.
+type serverImageStatusMsg struct {
+	id       string
+	status   string
+	progress int
+	err      error
+	polls    int
+}
+
+// createServerImageCmd snapshots the server to a new image.
+func createServerImageCmd(cc client.ComputeClient, serverID, name string, metadata map[string]string) tea.Cmd {
+	return func() tea.Msg {
+		id, err := cc.CreateServerImage(context.Background(), serverID, name, metadata)
+		return serverImageCreatedMsg{id: id, name: name, err: err}
+	}
+}
+
+// pollServerImageCmd fetches the status of the new image after delay; a
+// zero delay polls right away.
+func pollServerImageCmd(ic client.ImageClient, id string, delay time.Duration, polls int) tea.Cmd {
+	poll := func() tea.Msg {
+		img, err := ic.GetImage(context.Background(), id)
+		msg := serverImageStatusMsg{id: id, err: err, polls: polls + 1}
+		if err == nil && img != nil {
+			msg.status, msg.progress = img.Status, img.Progress
+		}
+		return msg
+	}
+	if delay == 0 {
+		return poll
+	}
+	return tea.Tick(delay, func(time.Time) tea.Msg { return poll() })
+}
+
+// record applies a poll to the image and reports whether to poll again.
+func (i *serverImage) record(msg serverImageStatusMsg) bool {
+	if msg.err != nil {
+		i.err, i.done = msg.err, true
+		return false
+	}
+	i.status, i.progress = msg.status, msg.progress
+	switch strings.ToUpper(msg.status) {
+	case "ACTIVE":
+		i.done = true
+	case "ERROR", "KILLED", "DELETED":
+		i.err, i.done = fmt.Errorf("the image is %s", strings.ToLower(msg.status)), true
+	default:
+		if msg.polls >= maxImagePolls {
+			i.err, i.done = errors.New("still not active; check it in the image list"), true
+		}
+	}
+	return !i.done
+}
+
+// view renders the progress of the image below the detail table.
+func (i *serverImage) view() string {
+	switch {
+	case i.err != nil:
+		return "\n" + i18n.T("Image %s: %s  [o] open image", i.name, i.err)
+	case i.done:
+		return "\n" + i18n.T("Image %s is active  [o] open image", i.name)
+	case i.status == "":
+		return "\n" + i18n.T("Image %s requested…  [o] open image", i.name)
+	}
+	return "\n" + i18n.T("Image %s: %s %d%% …  [o] open image", i.name, strings.ToLower(i.status), i.progress)
+}
+
+// startImageForm opens the create image form when the server status allows
+// a snapshot.
+func (m InstanceDetailModel) startImageForm() (tea.Model, tea.Cmd) {
+	if !imageableStatuses[m.instance.Status] {
+		m.actionStatus = i18n.T("Cannot create an image of a server that is %s; it must be active, shut off, paused or suspended", m.instance.Status)
+		return m, nil
+	}
+	m.imageForm = newImageForm(m.instance)
+	m.actionStatus = ""
+	return m, m.imageForm.Init()
+}
+
+// updateImageForm handles messages while the create image form is open.
+func (m InstanceDetailModel) updateImageForm(msg tea.Msg) (tea.Model, tea.Cmd) {
+	fm, cmd := m.imageForm.Update(msg)
+	f := fm.(common.FormModel)
+	m.imageForm = &f
+	switch {
+	case f.Cancelled():
+		m.imageForm = nil
+		return m, nil
+	case !f.Submitted():
+		return m, cmd
+	}
+	values := f.Values()
+	name := strings.TrimSpace(values[imageFieldName])
+	if name == "" {
+		m.imageForm.SetError(errors.New("the image needs a name"))
+		return m, nil
+	}
+	md, err := parseImageMetadata(values[imageFieldMetadata])
+	if err != nil {
+		m.imageForm.SetError(err)
+		return m, nil
+	}
+	m.imageForm = nil
+	m.actionStatus = i18n.T("Creating image %s...", name)
+	return m, createServerImageCmd(m.client, m.instanceID, name, md)
+}
@@ -0,0 +1,200 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"ostui/internal/client"
+	"ostui/internal/i18n"
+)
+
+// Lifecycle actions offered by the lifecycle menu of the server detail.
+// They are confirmed like remediation actions.
+const (
+	lifecycleStart    = "start"
+	lifecycleStop     = "stop"
+	lifecyclePause    = "pause"
+	lifecycleUnpause  = "unpause"
+	lifecycleSuspend  = "suspend"
+	lifecycleResume   = "resume"
+	lifecycleShelve   = "shelve"
+	lifecycleUnshelve = "unshelve"
+	lifecycleLock     = "lock"
+	lifecycleUnlock   = "unlock"
+	// lifecycleRescue and lifecycleUnrescue are in instance_rescue.go,
+	// lifecycleConfirmResize and lifecycleRevertResize in
+	// instance_verify_resize.go.
+)
+
+// lifecycleAction is an entry of the lifecycle menu.
+type lifecycleAction struct {
+	key    string
+	action string
+}
+
+// lifecycleActions returns the actions nova accepts for a server in state,
+// so the menu only offers valid transitions. Opposite actions share a key.
+// While a task runs, or while the server is locked (nova rejects actions on
+// a locked server for non-admins), only the lock can be changed.
+func lifecycleActions(st client.ServerState) []lifecycleAction {
+	var out []lifecycleAction
+	add := func(key, action string) { out = append(out, lifecycleAction{key: key, action: action}) }
+	locked := st.Locked != nil && *st.Locked
+	if st.TaskState == "" && !locked {
+		switch st.Status {
+		case "ACTIVE":
+			add("t", lifecycleStop)
+			add("p", lifecyclePause)
+			add("u", lifecycleSuspend)
+			add("h", lifecycleShelve)
+			add("r", lifecycleRescue)
+		case "SHUTOFF":
+			add("s", lifecycleStart)
+			add("h", lifecycleShelve)
+			add("r", lifecycleRescue)
+		case "PAUSED":
+			add("p", lifecycleUnpause)
+			add("h", lifecycleShelve)
+		case "SUSPENDED":
+			add("u", lifecycleResume)
+			add("h", lifecycleShelve)
+		case "SHELVED", "SHELVED_OFFLOADED":
+			add("h", lifecycleUnshelve)
+		case "ERROR":
+			add("t", lifecycleStop)
+			add("r", lifecycleRescue)
+		case "RESCUE":
+			add("t", lifecycleStop)
+			add("r", lifecycleUnrescue)
+		case "VERIFY_RESIZE":
+			add("c", lifecycleConfirmResize)
+			add("v", lifecycleRevertResize)
+		}
+	}
+	// Clouds before microversion 2.9 do not say whether the server is
+	// locked; offer both then.
+	if st.Locked == nil || !locked {
+		add("k", lifecycleLock)
+	}
+	if st.Locked == nil || locked {
+		add("K", lifecycleUnlock)
+	}
+	return out
+}
+
+// lifecycleMenu lists the actions valid for the server's current state.
+type lifecycleMenu struct {
+	loading bool
+	state   client.ServerState
+	actions []lifecycleAction
+	err     error
+}
+
+// lifecycleStateMsg carries the server state the menu is built from.
+type lifecycleStateMsg struct {
+	state client.ServerState
+	err   error
+}
+
+// loadLifecycleStateCmd fetches the current state of the server; the
+// detail view may be stale.
+func loadLifecycleStateCmd(cc client.ComputeClient, id string) tea.Cmd {
+	return func() tea.Msg {
+		st, err := cc.GetServerState(context.Background(), id)
+		return lifecycleStateMsg{state: st, err: err}
+	}
+}
+
+// runLifecycleCmd submits a lifecycle action for the server.
+func runLifecycleCmd(cc client.ComputeClient, id, action string) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		var err error
+		switch action {
+		case lifecycleStart:
+			err = cc.StartInstance(id)
+		case lifecycleStop:
+			err = cc.StopInstance(id)
+		case lifecyclePause:
+			err = cc.PauseInstance(ctx, id)
+		case lifecycleUnpause:
+			err = cc.UnpauseInstance(ctx, id)
+		case lifecycleSuspend:
+			err = cc.SuspendInstance(ctx, id)
+		case lifecycleResume:
+			err = cc.ResumeInstance(ctx, id)
+		case lifecycleShelve:
+			err = cc.ShelveInstance(ctx, id)
+		case lifecycleUnshelve:
+			err = cc.UnshelveInstance(ctx, id)
+		case lifecycleLock:
+			err = cc.LockInstance(ctx, id)
+		case lifecycleUnlock:
+			err = cc.UnlockInstance(ctx, id)
+		case lifecycleUnrescue:
+			err = cc.UnrescueInstance(ctx, id)
+		case lifecycleConfirmResize:
+			err = cc.ConfirmResize(ctx, id)
+		case lifecycleRevertResize:
+			err = cc.RevertResize(ctx, id)
+		default:
+			err = fmt.Errorf("unknown action %q", action)
+		}
+		return remediationDoneMsg{action: action, err: err}
+	}
+}
+
+// isLifecycleAction reports whether action is one of the lifecycle menu.
+func isLifecycleAction(action string) bool {
+	switch action {
+	case lifecycleStart, lifecycleStop, lifecyclePause, lifecycleUnpause, lifecycleSuspend,
+		lifecycleResume, lifecycleShelve, lifecycleUnshelve, lifecycleLock, lifecycleUnlock, lifecycleRescue, lifecycleUnrescue,
+		lifecycleConfirmResize, lifecycleRevertResize:
+		return true
+	}
+	return false
+}
+
+// updateLifecycleMenu handles keys while the lifecycle menu is open: an
+// action key asks for confirmation, esc closes the menu.
+func (m InstanceDetailModel) updateLifecycleMenu(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if msg.String() == "esc" || msg.String() == "L" {
+		m.lifecycle = nil
+		return m, nil
+	}
+	for _, a := range m.lifecycle.actions {
+		if a.key == msg.String() {
+			m.lifecycle = nil
+			if a.action == lifecycleRescue {
+				return m.startRescue()
+			}
+			m.pendingAction = a.action
+			return m, nil
+		}
+	}
+	return m, nil
+}
+
+// view renders the menu below the detail table.
+func (l *lifecycleMenu) view(serverName string) string {
+	switch {
+	case l.loading:
+		return "\nChecking the server state…"
+	case l.err != nil:
+		return "\n" + i18n.T("Failed to load the server state: %s\n[esc] close", l.err)
+	}
+	state := l.state.Status
+	if l.state.TaskState != "" {
+		state += ", " + l.state.TaskState
+	}
+	if l.state.Locked != nil && *l.state.Locked {
+		state += ", locked"
+	}
+	var keys []string
+	for _, a := range l.actions {
+		keys = append(keys, fmt.Sprintf("[%s] %s", a.key, a.action))
+	}
+	return "\n" + i18n.T("Lifecycle of %s (%s)\n%s  [esc] close", serverName, state, strings.Join(keys, "  "))
+}
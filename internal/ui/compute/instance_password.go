@@ -0,0 +1,180 @@
+package compute
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/gophercloud/gophercloud"
+	"ostui/internal/client"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/common"
+	"ostui/internal/ui/softlock"
+)
+
+// passwordPrompt is the form of a guest password action: the private key
+// to decrypt the posted password with, or the new admin password typed
+// twice.
+type passwordPrompt struct {
+	change bool
+	form   common.FormModel
+}
+
+// newPasswordPrompt opens the form of the get-password action, or of the
+// change-password action when change is set.
+func newPasswordPrompt(change bool) *passwordPrompt {
+	if change {
+		f := common.NewForm([]string{i18n.T("New admin password"), i18n.T("Confirm password")})
+		f.SetMasked(0)
+		f.SetMasked(1)
+		return &passwordPrompt{change: true, form: f}
+	}
+	f := common.NewForm([]string{i18n.T("Private key file")})
+	f.SetValue(0, defaultPrivateKeyPath())
+	return &passwordPrompt{form: f}
+}
+
+// defaultPrivateKeyPath is where ssh-keygen puts an RSA key, or "" when the
+// home directory is unknown.
+func defaultPrivateKeyPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".ssh", "id_rsa")
+}
+
+// expandHome resolves a leading ~/ in a typed path.
+func expandHome(path string) string {
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, rest)
+		}
+	}
+	return path
+}
+
+// serverPasswordMsg carries the decrypted admin password of a server.
+type serverPasswordMsg struct {
+	password string
+	err      error
+}
+
+// adminPasswordChangedMsg reports the result of changing the admin
+// password.
+type adminPasswordChangedMsg struct{ err error }
+
+// getServerPasswordCmd fetches the password the guest posted and decrypts
+// it with the private key in keyPath.
+func getServerPasswordCmd(cc client.ComputeClient, id, keyPath string) tea.Cmd {
+	return func() tea.Msg {
+		encrypted, err := cc.GetServerPassword(context.Background(), id)
+		if err != nil {
+			return serverPasswordMsg{err: err}
+		}
+		if encrypted == "" {
+			return serverPasswordMsg{err: errors.New("the guest has not posted a password; Windows images post it with cloudbase-init after the first boot")}
+		}
+		key, err := os.ReadFile(expandHome(keyPath))
+		if err != nil {
+			return serverPasswordMsg{err: err}
+		}
+		password, err := client.DecryptServerPassword(encrypted, key)
+		return serverPasswordMsg{password: password, err: err}
+	}
+}
+
+// changeAdminPasswordCmd sets the admin password of a server.
+func changeAdminPasswordCmd(cc client.ComputeClient, id, password string) tea.Cmd {
+	return func() tea.Msg {
+		return adminPasswordChangedMsg{err: cc.ChangeAdminPassword(context.Background(), id, password)}
+	}
+}
+
+// changePasswordError explains the errors nova returns when the hypervisor
+// or the guest cannot change the password.
+func changePasswordError(err error) string {
+	var sc gophercloud.StatusCodeError
+	if errors.As(err, &sc) {
+		switch sc.GetStatusCode() {
+		case http.StatusConflict:
+			return "Change admin password failed: the server is not running or is busy with another task"
+		case http.StatusNotImplemented:
+			return "Change admin password failed: the hypervisor does not support it"
+		}
+	}
+	return i18n.T("Change admin password failed: %s", err)
+}
+
+// updatePasswordPrompt handles messages while a guest password form is
+// open.
+func (m InstanceDetailModel) updatePasswordPrompt(msg tea.Msg) (tea.Model, tea.Cmd) {
+	p := m.passwordPrompt
+	fm, cmd := p.form.Update(msg)
+	p.form = fm.(common.FormModel)
+	switch {
+	case p.form.Cancelled():
+		m.passwordPrompt = nil
+		return m, nil
+	case !p.form.Submitted():
+		return m, cmd
+	}
+	values := p.form.Values()
+	if !p.change {
+		if values[0] == "" {
+			p.form.SetError(errors.New("enter the private key of the server's key pair"))
+			return m, nil
+		}
+		m.passwordPrompt = nil
+		m.actionStatus = i18n.T("Fetching the admin password...")
+		return m, getServerPasswordCmd(m.client, m.instanceID, values[0])
+	}
+	switch {
+	case values[0] == "":
+		p.form.SetError(errors.New("the password must not be empty"))
+		return m, nil
+	case values[0] != values[1]:
+		p.form.SetError(errors.New("the passwords do not match"))
+		return m, nil
+	}
+	m.passwordPrompt = nil
+	m.actionStatus = i18n.T("Changing the admin password...")
+	return m, changeAdminPasswordCmd(m.client, m.instanceID, values[0])
+}
+
+// updateShownPassword handles keys while a decrypted password is shown:
+// the soft-lock prompt takes them first, then any key forgets the password.
+func (m InstanceDetailModel) updateShownPassword(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.passwordGuard.Prompting() {
+		var cmd tea.Cmd
+		m.passwordGuard, cmd = m.passwordGuard.Update(msg)
+		return m, cmd
+	}
+	if msg.String() == "v" {
+		return m, m.passwordGuard.Toggle()
+	}
+	m.shownPassword = ""
+	m.passwordGuard = softlock.NewGuard()
+	return m, nil
+}
+
+// passwordView renders the open form or the decrypted password.
+func (m InstanceDetailModel) passwordView() string {
+	if p := m.passwordPrompt; p != nil {
+		title := i18n.T("Decrypt the admin password posted by the guest")
+		if p.change {
+			title = i18n.T("Change the admin password (needs hypervisor and guest agent support)")
+		}
+		return fmt.Sprintf("\n%s\n%s", title, p.form.View())
+	}
+	out := "\n" + i18n.T("Admin password of %s: %s", m.instance.Name, m.passwordGuard.Value(m.shownPassword))
+	if v := m.passwordGuard.View(); v != "" {
+		out += "\n" + v
+	}
+	return out + "\n" + i18n.T("[v] show/hide  any other key forgets it")
+}
@@ -0,0 +1,144 @@
+package compute
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/gophercloud/gophercloud"
+	"ostui/internal/client"
+	"ostui/internal/i18n"
+)
+
+// statusPollInterval is how often the servers in a transitional state are
+// re-read while the list is open.
+var statusPollInterval = 5 * time.Second
+
+// transitionalStatuses are the statuses a server leaves on its own, without
+// the user acting on it. VERIFY_RESIZE waits for a confirm and is left out.
+var transitionalStatuses = map[string]bool{
+	"BUILD": true, "REBUILD": true, "REBOOT": true, "HARD_REBOOT": true,
+	"MIGRATING": true, "RESIZE": true, "REVERT_RESIZE": true, "PASSWORD": true,
+}
+
+// transitional reports whether a server is between two stable states: its
+// status is transitional or nova runs a task on it (e.g. deleting).
+func transitional(st client.ServerState) bool {
+	return transitionalStatuses[strings.ToUpper(st.Status)] || st.TaskState != ""
+}
+
+// statusPollTickMsg asks the list with the same seq to poll its
+// transitional servers; a reload bumps seq, dropping older ticks.
+type statusPollTickMsg struct{ seq int }
+
+// statusPollMsg carries one poll of the transitional servers.
+type statusPollMsg struct {
+	seq    int
+	states map[string]client.ServerState
+	// gone lists the servers that no longer exist, e.g. after a delete.
+	gone []string
+}
+
+// pendingServers returns the IDs of the listed servers still in transition.
+func (m InstancesModel) pendingServers() []string {
+	var ids []string
+	for _, s := range m.servers {
+		if transitional(client.ServerState{Status: s.Status, TaskState: m.tasks[s.ID]}) {
+			ids = append(ids, s.ID)
+		}
+	}
+	return ids
+}
+
+// schedulePoll returns the next poll tick while a server is in transition,
+// and nil once all have settled or the watch has timed out.
+func (m InstancesModel) schedulePoll() tea.Cmd {
+	if len(m.pendingServers()) == 0 || m.polls >= maxTaskPolls {
+		return nil
+	}
+	seq := m.pollSeq
+	return tea.Tick(statusPollInterval, func(time.Time) tea.Msg { return statusPollTickMsg{seq: seq} })
+}
+
+// pollCmd reads the state of the given servers. A server that fails to
+// load for another reason than a 404 keeps its row as is until the next
+// poll.
+func (m InstancesModel) pollCmd(ids []string) tea.Cmd {
+	cc, seq := m.client, m.pollSeq
+	return func() tea.Msg {
+		msg := statusPollMsg{seq: seq, states: map[string]client.ServerState{}}
+		for _, id := range ids {
+			st, err := cc.GetServerState(context.Background(), id)
+			var missing gophercloud.ErrDefault404
+			switch {
+			case errors.As(err, &missing):
+				msg.gone = append(msg.gone, id)
+			case err == nil:
+				msg.states[id] = st
+			}
+		}
+		return msg
+	}
+}
+
+// applyPoll updates the polled servers in place, dropping those that are
+// gone, and keeps the cursor on the selected server.
+func (m *InstancesModel) applyPoll(msg statusPollMsg) {
+	gone := map[string]bool{}
+	for _, id := range msg.gone {
+		gone[id] = true
+	}
+	if m.tasks == nil {
+		m.tasks = map[string]string{}
+	}
+	kept := m.servers[:0:0]
+	for _, s := range m.servers {
+		if gone[s.ID] {
+			delete(m.tasks, s.ID)
+			continue
+		}
+		if st, ok := msg.states[s.ID]; ok {
+			s.Status = st.Status
+			if st.TaskState == "" {
+				delete(m.tasks, s.ID)
+			} else {
+				m.tasks[s.ID] = st.TaskState
+			}
+		}
+		kept = append(kept, s)
+	}
+	m.servers = kept
+	m.refreshRows()
+}
+
+// pollLine describes the servers being watched, e.g.
+// "Watching 2 servers in transition: web-1 (rebooting), db-1 (BUILD)".
+func (m InstancesModel) pollLine() string {
+	ids := m.pendingServers()
+	if len(ids) == 0 || m.polls >= maxTaskPolls {
+		return ""
+	}
+	pending := map[string]bool{}
+	for _, id := range ids {
+		pending[id] = true
+	}
+	var parts []string
+	for _, s := range m.servers {
+		if !pending[s.ID] {
+			continue
+		}
+		state := s.Status
+		if t := m.tasks[s.ID]; t != "" {
+			state = t
+		}
+		parts = append(parts, fmt.Sprintf("%s (%s)", s.Name, state))
+	}
+	noun := "servers"
+	if len(parts) == 1 {
+		noun = "server"
+	}
+	return i18n.T("Watching %d %s in transition: %s", len(parts), noun, strings.Join(parts, ", "))
+}
@@ -0,0 +1,204 @@
+package compute
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/gophercloud/gophercloud"
+	"ostui/internal/client"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/common"
+	"ostui/internal/ui/theme"
+)
+
+// rebuildableStatuses are the server statuses nova rebuilds in.
+var rebuildableStatuses = map[string]bool{"ACTIVE": true, "SHUTOFF": true, "ERROR": true}
+
+// rebuildPrompt is the user data step of a rebuild. The user data the
+// server was booted with is loaded into an editor, so that a change of
+// configuration can go with the rebuild, and the rebuild is confirmed
+// against a diff of the edits.
+type rebuildPrompt struct {
+	loading bool
+	imageID string
+	// original is the user data read from the server. hidden is set when
+	// the role cannot read it: the server then keeps it unless new user
+	// data is typed.
+	original string
+	hidden   bool
+	area     textarea.Model
+	// confirming is set while the diff is shown with the [y/N] prompt.
+	confirming bool
+	diff       []string
+}
+
+// userDataLoadedMsg carries the user data of the server being rebuilt.
+type userDataLoadedMsg struct {
+	userData string
+	err      error
+}
+
+func loadUserDataCmd(cc client.ComputeClient, id string) tea.Cmd {
+	return func() tea.Msg {
+		ud, err := cc.GetServerUserData(context.Background(), id)
+		return userDataLoadedMsg{userData: ud, err: err}
+	}
+}
+
+// rebuildWithUserDataCmd rebuilds the server from its image with new user
+// data.
+func rebuildWithUserDataCmd(cc client.ComputeClient, id, imageID, userData string) tea.Cmd {
+	return func() tea.Msg {
+		err := cc.RebuildInstanceUserData(context.Background(), id, imageID, userData)
+		return remediationDoneMsg{action: remediationRebuild, err: rebuildUserDataError(err)}
+	}
+}
+
+// rebuildUserDataError explains the refusal of clouds older than compute
+// microversion 2.57, which cannot replace user data on rebuild.
+func rebuildUserDataError(err error) error {
+	var sc gophercloud.StatusCodeError
+	if errors.As(err, &sc) && sc.GetStatusCode() == http.StatusNotAcceptable {
+		return errors.New("this cloud cannot change user data on rebuild (compute API 2.57 or later); rebuild with the user data unchanged")
+	}
+	return err
+}
+
+// changed reports whether the user data was edited. When it could not be
+// read, any user data typed replaces it.
+func (r *rebuildPrompt) changed() bool {
+	if r.hidden {
+		return r.area.Value() != ""
+	}
+	return r.area.Value() != r.original
+}
+
+// startRebuild opens the rebuild of the server from its image, starting
+// with its user data.
+func (m InstanceDetailModel) startRebuild() (tea.Model, tea.Cmd) {
+	if !rebuildableStatuses[m.instance.Status] {
+		m.actionStatus = i18n.T("Cannot rebuild a server that is %s; it must be active, shut off or in error", m.instance.Status)
+		return m, nil
+	}
+	imageID, _ := m.instance.Image["id"].(string)
+	if imageID == "" {
+		m.actionStatus = i18n.T("server was not booted from an image; rebuild not possible")
+		return m, nil
+	}
+	ta := textarea.New()
+	ta.ShowLineNumbers = true
+	ta.CharLimit = 0
+	ta.SetWidth(80)
+	ta.SetHeight(10)
+	m.rebuild = &rebuildPrompt{loading: true, imageID: imageID, area: ta}
+	m.actionStatus = ""
+	return m, loadUserDataCmd(m.client, m.instanceID)
+}
+
+// updateRebuild handles messages while the rebuild prompt is open.
+func (m InstanceDetailModel) updateRebuild(msg tea.Msg) (tea.Model, tea.Cmd) {
+	r := *m.rebuild
+	m.rebuild = &r
+	if msg, ok := msg.(userDataLoadedMsg); ok {
+		r.loading = false
+		switch {
+		case errors.Is(msg.err, client.ErrUserDataHidden):
+			r.hidden = true
+		case msg.err != nil:
+			m.rebuild = nil
+			m.actionStatus = i18n.T("Reading the user data failed: %s", msg.err)
+			return m, nil
+		}
+		r.original = msg.userData
+		r.area.SetValue(msg.userData)
+		r.area.Focus()
+		return m, textarea.Blink
+	}
+	key, ok := msg.(tea.KeyMsg)
+	switch {
+	case !ok:
+		return m, nil
+	case r.loading:
+		if key.String() == "esc" {
+			m.rebuild = nil
+		}
+		return m, nil
+	case r.confirming:
+		switch key.String() {
+		case "y":
+			m.rebuild = nil
+			m.actionStatus = i18n.T("Submitting %s...", remediationRebuild)
+			if r.changed() {
+				return m, rebuildWithUserDataCmd(m.client, m.instanceID, r.imageID, r.area.Value())
+			}
+			return m, runRemediationCmd(m.client, m.instance, remediationRebuild)
+		case "e":
+			r.confirming, r.diff = false, nil
+			r.area.Focus()
+			return m, textarea.Blink
+		}
+		m.rebuild = nil
+		return m, nil
+	}
+	switch key.String() {
+	case "esc":
+		m.rebuild = nil
+		return m, nil
+	case "ctrl+s":
+		r.confirming = true
+		r.area.Blur()
+		if r.changed() {
+			before := r.original
+			if r.hidden {
+				before = ""
+			}
+			r.diff = common.LineDiff(before, r.area.Value())
+		}
+		return m, nil
+	}
+	var cmd tea.Cmd
+	r.area, cmd = r.area.Update(key)
+	return m, cmd
+}
+
+// rebuildView renders the rebuild prompt below the detail table.
+func (m InstanceDetailModel) rebuildView() string {
+	r := m.rebuild
+	dim := lipgloss.NewStyle().Foreground(theme.Muted)
+	name := m.instance.Name
+	if r.loading {
+		return "\n" + i18n.T("Reading the user data of server %s…", name)
+	}
+	if !r.confirming {
+		out := "\n" + i18n.T("Rebuild server %s from image %s. User data to boot it with:", name, r.imageID) + "\n"
+		if r.hidden {
+			out += dim.Render(i18n.T("The current user data cannot be read with your roles; it is kept unless you type new user data.")) + "\n"
+		}
+		return out + r.area.View() + "\n" + dim.Render(i18n.T("[ctrl+s] review  [esc] cancel"))
+	}
+	if !r.changed() {
+		return "\n" + i18n.T("Rebuild server %s from image %s, keeping its user data? [y/N]  [e] edit user data", name, r.imageID)
+	}
+	del := lipgloss.NewStyle().Foreground(theme.Error)
+	add := lipgloss.NewStyle().Foreground(theme.OK)
+	var b strings.Builder
+	b.WriteString("\n" + i18n.T("User data changes:") + "\n")
+	for _, line := range r.diff {
+		switch line[0] {
+		case '-':
+			b.WriteString(del.Render(line))
+		case '+':
+			b.WriteString(add.Render(line))
+		default:
+			b.WriteString(dim.Render(line))
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString(i18n.T("Rebuild server %s from image %s with the new user data? [y/N]  [e] edit", name, r.imageID))
+	return b.String()
+}
@@ -0,0 +1,199 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/instanceactions"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
+	"ostui/internal/client"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/ops"
+	"ostui/internal/ui/policy"
+	"ostui/internal/ui/theme"
+)
+
+// Remediation actions offered for servers in ERROR state.
+const (
+	remediationHardReboot = "hard reboot"
+	remediationRebuild    = "rebuild"
+	remediationDelete     = "delete"
+)
+
+// actionResize is confirmed like a remediation action once a flavor is picked.
+const actionResize = "resize"
+
+// remediationDoneMsg is emitted when a remediation action has been submitted.
+type remediationDoneMsg struct {
+	action string
+	err    error
+}
+
+// lastActionLoadedMsg carries the rendered most recent instance action.
+type lastActionLoadedMsg struct {
+	content string
+	err     error
+}
+
+// remediationKeys maps detail view keys to remediation actions. Rebuild
+// is offered in every status nova rebuilds in, from its own prompt.
+var remediationKeys = map[string]string{
+	"H": remediationHardReboot,
+	"D": remediationDelete,
+}
+
+// runRemediationCmd submits the given remediation action for the server.
+func runRemediationCmd(cc client.ComputeClient, srv servers.Server, action string) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		var err error
+		switch action {
+		case remediationHardReboot:
+			err = cc.RebootInstance(ctx, srv.ID, true)
+		case remediationRebuild:
+			imageID := fmt.Sprintf("%v", srv.Image["id"])
+			if srv.Image["id"] == nil || imageID == "" {
+				err = fmt.Errorf("server was not booted from an image; rebuild not possible")
+				break
+			}
+			err = cc.RebuildInstance(ctx, srv.ID, servers.RebuildOpts{ImageRef: imageID})
+		default:
+			err = fmt.Errorf("unknown action %q", action)
+		}
+		return remediationDoneMsg{action: action, err: err}
+	}
+}
+
+// queueDeleteCmd queues the delete of the server as an operation, followed
+// until the server is gone.
+func queueDeleteCmd(cc client.ComputeClient, srv servers.Server) tea.Cmd {
+	id := srv.ID
+	return ops.Enqueue("Delete server "+srv.Name, id, func(ctx context.Context) (string, ops.Poll, error) {
+		if err := cc.DeleteInstance(id); err != nil {
+			policy.Record(remediationDelete, err)
+			return "", nil, err
+		}
+		// A server in ERROR is deleted from ERROR: only its disappearance
+		// tells the delete is over.
+		return "delete requested", func(ctx context.Context) (bool, string, error) {
+			st, err := cc.GetServerState(ctx, id)
+			switch {
+			case ops.Gone(err):
+				return true, "deleted", nil
+			case err != nil:
+				return false, "", err
+			}
+			return false, taskOrStatus(st), nil
+		}, nil
+	})
+}
+
+// queueResizeCmd queues the resize of the server to a flavor as an
+// operation, followed until the resize waits for its confirmation.
+func queueResizeCmd(cc client.ComputeClient, srv servers.Server, flavorID, flavorName string) tea.Cmd {
+	id := srv.ID
+	return ops.Enqueue(i18n.T("Resize server %s to %s", srv.Name, flavorName), id, func(ctx context.Context) (string, ops.Poll, error) {
+		if err := cc.ResizeInstance(ctx, id, flavorID); err != nil {
+			policy.Record(actionResize, err)
+			return "", nil, err
+		}
+		return "resize requested", func(ctx context.Context) (bool, string, error) {
+			st, err := cc.GetServerState(ctx, id)
+			if err != nil {
+				return false, "", err
+			}
+			switch strings.ToUpper(st.Status) {
+			case "VERIFY_RESIZE":
+				return true, st.Status, nil
+			case "ACTIVE", "SHUTOFF":
+				// Nova confirms the resize itself when so configured, but
+				// the task is only over once it cleared.
+				return st.TaskState == "", taskOrStatus(st), nil
+			case "ERROR":
+				return false, st.Status, fmt.Errorf("server %s went into ERROR while resizing", id)
+			}
+			return false, taskOrStatus(st), nil
+		}, nil
+	})
+}
+
+// taskOrStatus names the state of a server for the log of an operation:
+// the task nova runs on it, else its status.
+func taskOrStatus(st client.ServerState) string {
+	if st.TaskState != "" {
+		return st.TaskState
+	}
+	return st.Status
+}
+
+// loadLastActionCmd fetches the most recent instance action, including its events.
+func loadLastActionCmd(cc client.ComputeClient, serverID string) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		actions, err := cc.ListInstanceActions(ctx, serverID)
+		if err != nil {
+			return lastActionLoadedMsg{err: err}
+		}
+		if len(actions) == 0 {
+			return lastActionLoadedMsg{content: i18n.T("No instance actions recorded for this server.")}
+		}
+		latest := actions[0]
+		for _, a := range actions[1:] {
+			if a.StartTime.After(latest.StartTime) {
+				latest = a
+			}
+		}
+		detail, err := cc.GetInstanceAction(ctx, serverID, latest.RequestID)
+		if err != nil {
+			// Fall back to the summary without events.
+			detail = instanceactions.InstanceActionDetail{Action: latest.Action, RequestID: latest.RequestID, UserID: latest.UserID, Message: latest.Message, StartTime: latest.StartTime}
+		}
+		return lastActionLoadedMsg{content: renderInstanceAction(detail)}
+	}
+}
+
+// renderInstanceAction formats an instance action and its events as plain text.
+func renderInstanceAction(a instanceactions.InstanceActionDetail) string {
+	var sb strings.Builder
+	sb.WriteString(i18n.T("=== Last action: %s ===", a.Action) + "\n")
+	sb.WriteString(i18n.T("Request ID: %s", a.RequestID) + "\n")
+	sb.WriteString(i18n.T("Started: %s", a.StartTime.Format(time.RFC3339)) + "\n")
+	sb.WriteString(i18n.T("User ID: %s", a.UserID) + "\n")
+	if a.Message != "" {
+		sb.WriteString(i18n.T("Message: %s", a.Message) + "\n")
+	}
+	if a.Events != nil {
+		sb.WriteString("\n" + i18n.T("Events:") + "\n")
+		for _, e := range *a.Events {
+			host := ""
+			if e.Host != nil {
+				host = *e.Host
+			}
+			sb.WriteString(fmt.Sprintf("  %-40s %-8s %s %s\n", e.Event, e.Result, e.StartTime.Format(time.RFC3339), host))
+			if e.Traceback != "" {
+				for _, line := range strings.Split(strings.TrimRight(e.Traceback, "\n"), "\n") {
+					sb.WriteString("      " + line + "\n")
+				}
+			}
+		}
+	}
+	return sb.String()
+}
+
+// renderFaultBanner renders the fault message and code of a server in ERROR state.
+func renderFaultBanner(srv servers.Server) string {
+	errStyle := lipgloss.NewStyle().Bold(true).Foreground(theme.Error)
+	msg := srv.Fault.Message
+	if msg == "" {
+		msg = "no fault information reported"
+	}
+	banner := errStyle.Render(i18n.T("✖ ERROR  fault %d: %s", srv.Fault.Code, msg))
+	if !srv.Fault.Created.IsZero() {
+		banner += lipgloss.NewStyle().Foreground(theme.Muted).Render(fmt.Sprintf("  (%s)", srv.Fault.Created.Format(time.RFC3339)))
+	}
+	return banner
+}
@@ -0,0 +1,116 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
+	"ostui/internal/client"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/common"
+	"ostui/internal/ui/softlock"
+	"ostui/internal/ui/theme"
+)
+
+// Rescue boots a server from a rescue image with its own disk attached as
+// a second disk, to repair a guest that no longer boots. The lifecycle menu
+// picks the image; U leaves rescue mode from the banner of the detail.
+const (
+	lifecycleRescue   = "rescue"
+	lifecycleUnrescue = "unrescue"
+)
+
+// rescueDoneMsg reports a rescue request with the password of the rescue
+// system.
+type rescueDoneMsg struct {
+	password string
+	err      error
+}
+
+// newRescueImagePicker lists the active images to boot the rescue system
+// from, after the cloud's default rescue image (nova's rescue_image_ref,
+// or the server's own image).
+func newRescueImagePicker(ic client.ImageClient, srv servers.Server) common.PickerModel {
+	own, _ := srv.Image["id"].(string)
+	cols := []common.PickerColumn{{Title: i18n.T("Name"), Width: 32}, {Title: i18n.T("Min disk"), Width: 9}, {Title: "ID", Width: 36}}
+	return common.NewPicker("Rescue "+srv.Name+" from image", cols, func() ([]common.PickerItem, error) {
+		imgs, err := ic.ListImages(context.Background())
+		if err != nil {
+			return nil, err
+		}
+		sort.SliceStable(imgs, func(i, j int) bool { return imgs[i].Name < imgs[j].Name })
+		items := []common.PickerItem{{Cells: []string{"(cloud default)", "", "rescue_image_ref or the server's image"}}}
+		for _, img := range imgs {
+			if img.Status != "ACTIVE" {
+				continue
+			}
+			name := img.Name
+			if img.ID == own {
+				name += " (server image)"
+			}
+			items = append(items, common.PickerItem{ID: img.ID, Cells: []string{name, fmt.Sprintf("%d GB", img.MinDisk), img.ID}})
+		}
+		return items, nil
+	})
+}
+
+// rescueCmd puts the server in rescue mode from imageID, the cloud's
+// default when empty.
+func rescueCmd(cc client.ComputeClient, id, imageID string) tea.Cmd {
+	return func() tea.Msg {
+		password, err := cc.RescueInstance(context.Background(), id, imageID)
+		return rescueDoneMsg{password: password, err: err}
+	}
+}
+
+// updateRescuePicker forwards msg to the open rescue image picker; the
+// chosen image is confirmed like the other lifecycle actions.
+func (m InstanceDetailModel) updateRescuePicker(msg tea.Msg) (tea.Model, tea.Cmd) {
+	newModel, cmd := m.rescuePicker.Update(msg)
+	picker := newModel.(common.PickerModel)
+	m.rescuePicker = &picker
+	if picker.Done() {
+		m.rescuePicker = nil
+		if it, ok := picker.Selected(); ok {
+			m.rescueImage = it
+			m.pendingAction = lifecycleRescue
+		}
+	}
+	return m, cmd
+}
+
+// startRescue opens the rescue image picker, or confirms a rescue from the
+// default image when no image client is at hand.
+func (m InstanceDetailModel) startRescue() (tea.Model, tea.Cmd) {
+	if m.images == nil {
+		m.rescueImage = common.PickerItem{Cells: []string{"(cloud default)"}}
+		m.pendingAction = lifecycleRescue
+		return m, nil
+	}
+	picker := newRescueImagePicker(m.images, m.instance)
+	m.rescuePicker = &picker
+	return m, picker.Init()
+}
+
+// rescueDone reloads the server like any action, then shows the password
+// of the rescue system behind the same guard as the admin password.
+func (m InstanceDetailModel) rescueDone(msg rescueDoneMsg) (tea.Model, tea.Cmd) {
+	updated, cmd := m.Update(remediationDoneMsg{action: lifecycleRescue, err: msg.err})
+	m = updated.(InstanceDetailModel)
+	if msg.err != nil || msg.password == "" {
+		return m, cmd
+	}
+	m.shownPassword = msg.password
+	m.passwordGuard = softlock.NewGuard()
+	return m, tea.Batch(cmd, m.passwordGuard.Reveal())
+}
+
+// renderRescueBanner warns that the server runs the rescue system, not its
+// own disk, until it is unrescued.
+func renderRescueBanner(srv servers.Server) string {
+	style := lipgloss.NewStyle().Bold(true).Foreground(theme.Warn)
+	return style.Render(i18n.T("⚠ RESCUE  %s runs the rescue system; its own disk is attached as a second disk  [U] unrescue", srv.Name))
+}
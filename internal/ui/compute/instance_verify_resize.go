@@ -0,0 +1,106 @@
+package compute
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/policy"
+	"ostui/internal/ui/theme"
+)
+
+// A resize or cold migration leaves the server in VERIFY_RESIZE, holding
+// resources on both hosts, until it is confirmed or reverted; unless the
+// cloud confirms on its own, forgotten ones stay there. The server list and
+// the detail remind of them, with A to confirm and V to revert after a y.
+const (
+	lifecycleConfirmResize = "confirm resize"
+	lifecycleRevertResize  = "revert resize"
+)
+
+// verifyResizeKeys maps the one-key actions of the reminders.
+var verifyResizeKeys = map[string]string{
+	"A": lifecycleConfirmResize,
+	"V": lifecycleRevertResize,
+}
+
+// resizeLabel names the flavor a server was resized to.
+func resizeLabel(srv servers.Server) string {
+	name, id := flavorRef(srv)
+	if name == "" {
+		return id
+	}
+	return name
+}
+
+// renderVerifyResizeBanner reminds that the resize of the server waits for
+// a decision.
+func renderVerifyResizeBanner(srv servers.Server) string {
+	style := lipgloss.NewStyle().Bold(true).Foreground(theme.Warn)
+	return style.Render(i18n.T("⚠ VERIFY_RESIZE  %s runs on flavor %s until the resize is confirmed or reverted  [A] confirm  [V] revert", srv.Name, resizeLabel(srv)))
+}
+
+// resizeReminder lists the servers waiting in VERIFY_RESIZE, e.g.
+// "⚠ 2 servers wait for a resize to be confirmed: web-1 (m1.large), db-1
+// (m1.xlarge)  [A] confirm  [V] revert the selected one".
+func (m InstancesModel) resizeReminder() string {
+	var parts []string
+	for _, s := range m.servers {
+		if s.Status == "VERIFY_RESIZE" {
+			parts = append(parts, fmt.Sprintf("%s (%s)", s.Name, resizeLabel(s)))
+		}
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	noun := "servers wait"
+	if len(parts) == 1 {
+		noun = "server waits"
+	}
+	line := i18n.T("⚠ %d %s for a resize to be confirmed: %s  [A] confirm  [V] revert the selected one", len(parts), noun, strings.Join(parts, ", "))
+	return lipgloss.NewStyle().Foreground(theme.Warn).Render(line)
+}
+
+// updateVerifyResize runs the confirm of key on the selected server when it
+// waits in VERIFY_RESIZE, or asks to confirm its revert like the other
+// destructive actions; ok is false for other keys and servers.
+func (m InstancesModel) updateVerifyResize(key string) (InstancesModel, tea.Cmd, bool) {
+	action, ok := verifyResizeKeys[key]
+	row := m.table.SelectedRow()
+	if !ok || len(row) == 0 || m.OnGroupHeader() {
+		return m, nil, false
+	}
+	for _, s := range m.servers {
+		if s.ID != row[0] || s.Status != "VERIFY_RESIZE" {
+			continue
+		}
+		if err := policy.Check(policy.Member, action); err != nil {
+			m.actionStatus = err.Error()
+			return m, nil, true
+		}
+		if action == lifecycleRevertResize {
+			srv := s
+			m.pendingRevert = &srv
+			m.actionStatus = ""
+			return m, nil, true
+		}
+		m.actionStatus = i18n.T("Submitting %s of %s...", action, s.Name)
+		return m, runLifecycleCmd(m.client, s.ID, action), true
+	}
+	return m, nil, false
+}
+
+// updateRevertPrompt reverts the resize of the pending server on y and
+// drops it on any other key.
+func (m InstancesModel) updateRevertPrompt(key string) (tea.Model, tea.Cmd) {
+	srv := m.pendingRevert
+	m.pendingRevert = nil
+	if key != "y" {
+		return m, nil
+	}
+	m.actionStatus = i18n.T("Submitting %s of %s...", lifecycleRevertResize, srv.Name)
+	return m, runLifecycleCmd(m.client, srv.ID, lifecycleRevertResize)
+}
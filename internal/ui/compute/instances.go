@@ -6,22 +6,55 @@ import (
 	"github.com/charmbracelet/bubbles/table"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
 	"ostui/internal/client"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/common"
+	"ostui/internal/ui/policy"
+	"ostui/internal/ui/search"
+	"ostui/internal/ui/theme"
 	"ostui/internal/ui/uiconst"
 	"strings"
+	"time"
 )
 
 // InstancesModel implements a subview for listing compute instances.
 type InstancesModel struct {
-	table      table.Model
-	loading    bool
-	err        error
-	spinner    spinner.Model
-	client     client.ComputeClient
-	allRows    []table.Row
+	table   common.VirtualTable // projects hold thousands of servers
+	loading bool
+	err     error
+	spinner spinner.Model
+	client  client.ComputeClient
+	servers []servers.Server
+	// matched counts the servers matching the filter.
+	matched    int
 	filterMode bool
 	filter     textinput.Model
 
+	// Grouping, cycled with G. Collapsed groups show only their header.
+	groupBy      groupMode
+	groupMetaKey string
+	collapsed    map[string]bool
+	// keyPrompt asks for the metadata key to group by.
+	keyPrompt bool
+	keyInput  textinput.Model
+	// Lookups for AZ and flavor grouping, loaded on first use.
+	zones       map[string]string
+	flavorNames map[string]string
+	groupErr    error
+
+	// Servers in a transitional state are polled every statusPollInterval
+	// until they settle. tasks holds the task state seen by the last poll;
+	// pollSeq drops the ticks of an earlier load.
+	tasks   map[string]string
+	pollSeq int
+	polls   int
+
+	// actionStatus reports the confirm or revert of a resize run from the
+	// list; pendingRevert is the server whose revert waits for y.
+	actionStatus  string
+	pendingRevert *servers.Server
+
 	// Dynamic sizing
 	width  int
 	height int
@@ -34,12 +67,29 @@ func NewInstancesModel(cc client.ComputeClient) InstancesModel {
 	// Use default style (no explicit style set).
 	ti := textinput.New()
 	ti.Placeholder = "filter..."
-	return InstancesModel{client: cc, loading: true, spinner: s, filter: ti, width: 120, height: 30}
+	ki := textinput.New()
+	ki.Placeholder = "metadata key"
+	m := InstancesModel{client: cc, loading: true, spinner: s, filter: ti, keyInput: ki, width: 120, height: 30}
+	t := table.New(table.WithFocused(true), table.WithHeight(m.height-uiconst.TableHeightOffset))
+	t.SetStyles(table.DefaultStyles())
+	m.table = common.NewVirtualTable(t, styleServerRow)
+	m.updateTableColumns()
+	return m
+}
+
+// styleServerRow marks the status of a server row on screen. Group headers
+// hold the server count there.
+func styleServerRow(r table.Row) table.Row {
+	if r[0] == "" {
+		return r
+	}
+	out := append(table.Row(nil), r...)
+	out[2] = theme.Mark(out[2])
+	return out
 }
 
 type dataLoadedMsg struct {
-	tbl  table.Model
-	rows []table.Row
+	srvs []servers.Server
 	err  error
 }
 
@@ -50,20 +100,17 @@ func (m InstancesModel) Init() tea.Cmd {
 		if err != nil {
 			return dataLoadedMsg{err: err}
 		}
-		cols := []table.Column{{Title: "ID", Width: uiconst.ColWidthUUID}, {Title: "Name", Width: uiconst.ColWidthName}, {Title: "Status", Width: uiconst.ColWidthStatus}}
-		rows := []table.Row{}
-		for _, s := range srvList {
-			rows = append(rows, table.Row{s.ID, s.Name, s.Status})
-		}
-		t := table.New(
-			table.WithColumns(cols),
-			table.WithRows(rows),
-			table.WithFocused(true),
-			table.WithHeight(m.height-uiconst.TableHeightOffset),
-		)
-		t.SetStyles(table.DefaultStyles())
-		return dataLoadedMsg{tbl: t, rows: rows}
+		search.IndexServers(srvList)
+		return dataLoadedMsg{srvs: srvList}
+	}
+}
+
+// Reload re-fetches the servers, keeping the grouping and the selected row.
+func (m InstancesModel) Reload() tea.Cmd {
+	if m.loading || m.filterMode {
+		return nil
 	}
+	return m.Init()
 }
 
 // Update handles messages for the model.
@@ -75,24 +122,63 @@ func (m InstancesModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.err = msg.err
 			return m, nil
 		}
-		m.table = msg.tbl
-		m.allRows = msg.rows
+		m.servers = msg.srvs
 		m.updateTableColumns()
-		m.table.SetHeight(m.height - uiconst.TableHeightOffset)
+		m.refreshRows()
+		m.pollSeq++
+		m.polls = 0
+		return m, m.schedulePoll()
+	case statusPollTickMsg:
+		if msg.seq != m.pollSeq {
+			return m, nil
+		}
+		ids := m.pendingServers()
+		if len(ids) == 0 {
+			return m, nil
+		}
+		return m, m.pollCmd(ids)
+	case statusPollMsg:
+		if msg.seq != m.pollSeq {
+			return m, nil
+		}
+		m.polls++
+		m.applyPoll(msg)
+		return m, m.schedulePoll()
+	case remediationDoneMsg:
+		if msg.err != nil {
+			policy.Record(msg.action, msg.err)
+			m.actionStatus = i18n.T("%s failed: %s", msg.action, msg.err)
+			return m, nil
+		}
+		m.actionStatus = i18n.T("%s requested", msg.action)
+		return m, m.Reload()
+	case groupLookupMsg:
+		m.groupErr = msg.err
+		if msg.zones != nil {
+			m.zones = msg.zones
+		}
+		if msg.flavors != nil {
+			m.flavorNames = msg.flavors
+		}
+		m.refreshRows()
 		return m, nil
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
-		if m.table.Columns() != nil {
-			m.table.SetHeight(m.height - uiconst.TableHeightOffset)
-			m.updateTableColumns()
-		}
+		m.table.SetHeight(m.height - uiconst.TableHeightOffset)
+		m.updateTableColumns()
 		return m, nil
 	case tea.KeyMsg:
 		if m.loading || m.err != nil {
 			// ignore key input while loading or on error
 			return m, nil
 		}
+		if m.keyPrompt {
+			return m.updateKeyPrompt(msg)
+		}
+		if m.pendingRevert != nil {
+			return m.updateRevertPrompt(msg.String())
+		}
 		// Filter mode handling
 		if !m.filterMode && msg.String() == "/" {
 			m.filterMode = true
@@ -104,30 +190,46 @@ func (m InstancesModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.filterMode = false
 			m.filter.Blur()
 			m.filter.SetValue("")
-			m.table.SetRows(m.allRows)
+			m.refreshRows()
 			return m, nil
 		}
 		if m.filterMode {
 			var cmd tea.Cmd
 			m.filter, cmd = m.filter.Update(msg)
-			filterVal := m.filter.Value()
-			if filterVal == "" {
-				m.table.SetRows(m.allRows)
-			} else {
-				lower := strings.ToLower(filterVal)
-				filtered := []table.Row{}
-				for _, r := range m.allRows {
-					for _, c := range r {
-						if strings.Contains(strings.ToLower(c), lower) {
-							filtered = append(filtered, r)
-							break
-						}
-					}
-				}
-				m.table.SetRows(filtered)
-			}
+			m.refreshRows()
 			return m, cmd
 		}
+		if updated, cmd, ok := m.updateVerifyResize(msg.String()); ok {
+			return updated, cmd
+		}
+		switch msg.String() {
+		case "G":
+			next := (m.groupBy + 1) % (groupMetadata + 1)
+			if next == groupMetadata {
+				m.keyPrompt = true
+				m.keyInput.SetValue(m.groupMetaKey)
+				m.keyInput.Focus()
+				return m, textinput.Blink
+			}
+			return m, m.setGrouping(next)
+		case "B":
+			// Bulk edit the servers matching the filter.
+			list := append([]servers.Server(nil), m.filtered()...)
+			if len(list) == 0 {
+				return m, nil
+			}
+			open := OpenBulkEditMsg{Servers: list, Filter: m.filter.Value()}
+			return m, func() tea.Msg { return open }
+		case "n":
+			return m, func() tea.Msg { return OpenCreateServerMsg{} }
+		case "enter", " ":
+			if m.OnGroupHeader() {
+				key := m.groupKeyAt(m.table.Cursor())
+				m.collapsed[key] = !m.collapsed[key]
+				m.refreshRows()
+				return m, nil
+			}
+		}
 		// Normal table navigation
 		var cmd tea.Cmd
 		m.table, cmd = m.table.Update(msg)
@@ -148,28 +250,180 @@ func (m InstancesModel) View() string {
 		return m.spinner.View()
 	}
 	if m.err != nil {
-		return fmt.Sprintf("Error: %s", m.err)
+		return i18n.T("Error: %s", m.err)
+	}
+	view := m.table.View()
+	if m.groupBy != groupNone {
+		by := m.groupBy.String()
+		if m.groupBy == groupMetadata {
+			by += " " + m.groupMetaKey
+		}
+		line := i18n.T("Grouped by %s  [G] next grouping  [enter] collapse/expand", by)
+		if m.groupErr != nil {
+			line += "\n" + i18n.T("Grouping data unavailable: %s", m.groupErr)
+		}
+		view = line + "\n" + view
+	}
+	if line := m.pollLine(); line != "" {
+		view += "\n" + line
+	}
+	if line := m.resizeReminder(); line != "" {
+		view += "\n" + line
+	}
+	if m.pendingRevert != nil {
+		view += "\n" + i18n.T("Revert the resize of server %s? [y/N]", m.pendingRevert.Name)
+	} else if m.actionStatus != "" {
+		view += "\n" + m.actionStatus
+	}
+	if m.keyPrompt {
+		return i18n.T("Group by metadata key: %s\n%s\nenter: apply  esc: cancel", m.keyInput.View(), view)
 	}
 	if m.filterMode {
-		filterLine := fmt.Sprintf("Filter: %s", m.filter.View())
-		footer := "esc: clear"
-		return fmt.Sprintf("%s\n%s\n%s", filterLine, m.table.View(), footer)
+		filterLine := i18n.T("Filter: %s", m.filter.View())
+		footer := i18n.T("%d of %d  esc: clear", m.matched, len(m.servers))
+		return fmt.Sprintf("%s\n%s\n%s", filterLine, view, footer)
+	}
+	return view
+}
+
+// CapturingInput reports whether the metadata key prompt or the
+// confirmation of a resize revert is open.
+func (m InstancesModel) CapturingInput() bool { return m.keyPrompt || m.pendingRevert != nil }
+
+// ConfirmingDestructive reports whether the prompt open confirms a resize
+// revert, which moves the server back to its old host.
+func (m InstancesModel) ConfirmingDestructive() bool { return m.pendingRevert != nil }
+
+// OnGroupHeader reports whether the cursor is on a group header row, which
+// enter collapses or expands instead of opening a server.
+func (m InstancesModel) OnGroupHeader() bool {
+	row := m.table.SelectedRow()
+	return m.groupBy != groupNone && len(row) > 0 && row[0] == ""
+}
+
+// updateKeyPrompt handles input for the metadata key prompt.
+func (m InstancesModel) updateKeyPrompt(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.keyPrompt = false
+		m.keyInput.Blur()
+		return m, m.setGrouping(groupNone)
+	case "enter":
+		m.keyPrompt = false
+		m.keyInput.Blur()
+		key := strings.TrimSpace(m.keyInput.Value())
+		if key == "" {
+			return m, m.setGrouping(groupNone)
+		}
+		m.groupMetaKey = key
+		return m, m.setGrouping(groupMetadata)
+	}
+	var cmd tea.Cmd
+	m.keyInput, cmd = m.keyInput.Update(msg)
+	return m, cmd
+}
+
+// setGrouping switches the grouping mode, expanding all groups, and returns
+// the command loading any data the mode needs.
+func (m *InstancesModel) setGrouping(mode groupMode) tea.Cmd {
+	m.groupBy = mode
+	m.collapsed = map[string]bool{}
+	m.groupErr = nil
+	m.refreshRows()
+	m.table.SetCursor(0)
+	return m.loadGroupLookup(mode)
+}
+
+// filtered returns the servers matching the current filter, whose age
+// terms (age>90d) are matched against the creation time.
+func (m InstancesModel) filtered() []servers.Server {
+	if m.filter.Value() == "" {
+		return m.servers
+	}
+	q := common.ParseAgeQuery(m.filter.Value())
+	lower := strings.ToLower(q.Text)
+	now := time.Now()
+	var out []servers.Server
+	for _, s := range m.servers {
+		if !q.MatchCreated(s.Created, now) {
+			continue
+		}
+		for _, c := range []string{s.ID, s.Name, s.Status, s.UserID, common.TerraformLabel(s.ID)} {
+			if strings.Contains(strings.ToLower(c), lower) {
+				out = append(out, s)
+				break
+			}
+		}
+	}
+	return out
+}
+
+// refreshRows rebuilds the table rows from the filter and grouping,
+// keeping the selected server.
+func (m *InstancesModel) refreshRows() {
+	srvs := m.filtered()
+	m.matched = len(srvs)
+	var rows []table.Row
+	now := time.Now()
+	if m.groupBy == groupNone {
+		for _, s := range srvs {
+			rows = append(rows, serverRow(s, s.Name, now))
+		}
+	} else {
+		rows = groupedRows(groupServers(srvs, m.groupKey()), m.collapsed, now)
+	}
+	m.table.SetRows(common.TerraformRows(rows))
+}
+
+// groupKeyAt returns the key of the group whose header is at row i.
+func (m InstancesModel) groupKeyAt(i int) string {
+	groups := groupServers(m.filtered(), m.groupKey())
+	n := 0
+	for _, g := range groups {
+		if n == i {
+			return g.Key
+		}
+		n++
+		if !m.collapsed[g.Key] {
+			n += len(g.Servers)
+		}
 	}
-	return m.table.View()
+	return ""
 }
 
 // updateTableColumns adjusts column widths based on the current width.
 func (m *InstancesModel) updateTableColumns() {
-	idW := uiconst.ColWidthUUID
-	statusW := uiconst.ColWidthStatus
-	nameW := m.width - idW - statusW - uiconst.TableHeightOffset
+	nameW := m.width - uiconst.ColWidthUUID - uiconst.ColWidthStatus - uiconst.ColWidthAge - uiconst.ColWidthCreatedBy - uiconst.TableHeightOffset - common.TerraformWidth()
 	if nameW < 10 {
 		nameW = 10
 	}
-	m.table.SetColumns([]table.Column{{Title: "ID", Width: idW}, {Title: "Name", Width: nameW}, {Title: "Status", Width: statusW}})
+	m.table.SetColumns(common.TerraformColumns(serverColumns(nameW)))
+}
+
+// serverColumns are the columns of the server list, the name nameW wide.
+func serverColumns(nameW int) []table.Column {
+	return []table.Column{
+		{Title: "ID", Width: uiconst.ColWidthUUID},
+		{Title: i18n.T("Name"), Width: nameW},
+		{Title: i18n.T("Status"), Width: uiconst.ColWidthStatus},
+		{Title: common.AgeTitle(), Width: uiconst.ColWidthAge},
+		{Title: i18n.T("Created by"), Width: uiconst.ColWidthCreatedBy},
+	}
 }
 
-// Ensure InstancesModel implements tea.Model.
-func (m InstancesModel) Table() table.Model { return m.table }
+// serverRow renders a server under name, which is indented in groups.
+// Nova reports the user who created the server by ID.
+func serverRow(s servers.Server, name string, now time.Time) table.Row {
+	return table.Row{s.ID, name, s.Status, common.Age(s.Created, now), s.UserID}
+}
+
+// Table returns the table of the rows on screen.
+func (m InstancesModel) Table() table.Model { return m.table.Table() }
+
+// FullTable returns all the listed servers, for the quick jump.
+func (m InstancesModel) FullTable() table.Model { return m.table.FullTable() }
+
+// Filtering reports whether keys are typed into the filter.
+func (m InstancesModel) Filtering() bool { return m.filterMode }
 
 var _ tea.Model = (*InstancesModel)(nil)
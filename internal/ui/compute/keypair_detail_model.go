@@ -2,11 +2,12 @@ package compute
 
 import (
 	"context"
-	"fmt"
 	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/table"
 	tea "github.com/charmbracelet/bubbletea"
 	"ostui/internal/client"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/common"
 	"ostui/internal/ui/uiconst"
 )
 
@@ -45,7 +46,7 @@ func (m KeypairDetailModel) Init() tea.Cmd {
 		if len(pub) > 60 {
 			pub = pub[:60] + "..."
 		}
-		cols := []table.Column{{Title: "Field", Width: uiconst.ColWidthField}, {Title: "Value", Width: uiconst.ColWidthValue}}
+		cols := []table.Column{{Title: i18n.T("Field"), Width: uiconst.ColWidthField}, {Title: i18n.T("Value"), Width: uiconst.ColWidthValue}}
 		rows := []table.Row{{"Name", kp.Name}, {"Fingerprint", kp.Fingerprint}, {"Type", kp.Type}, {"PublicKey", pub}}
 		t := table.New(
 			table.WithColumns(cols),
@@ -57,6 +58,14 @@ func (m KeypairDetailModel) Init() tea.Cmd {
 	}
 }
 
+// Reload re-fetches the key pair, keeping the selected row.
+func (m KeypairDetailModel) Reload() tea.Cmd {
+	if m.loading {
+		return nil
+	}
+	return m.Init()
+}
+
 // Update handles messages for the model.
 func (m KeypairDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
@@ -66,7 +75,7 @@ func (m KeypairDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.err = msg.err
 			return m, nil
 		}
-		m.table = msg.tbl
+		m.table = common.Reloaded(m.table, msg.tbl)
 		return m, nil
 	case tea.WindowSizeMsg:
 		if !m.loading && len(m.table.Columns()) > 0 {
@@ -109,9 +118,9 @@ func (m KeypairDetailModel) View() string {
 		return m.spinner.View()
 	}
 	if m.err != nil {
-		return fmt.Sprintf("Error: %s", m.err)
+		return i18n.T("Error: %s", m.err)
 	}
-	return fmt.Sprintf("%s\n[esc] back", m.table.View())
+	return i18n.T("%s\n[esc] back", m.table.View())
 }
 
 // Table returns the underlying table model.
@@ -7,6 +7,8 @@ import (
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"ostui/internal/client"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/common"
 	"ostui/internal/ui/uiconst"
 	"strings"
 )
@@ -51,7 +53,7 @@ func (m KeypairsModel) Init() tea.Cmd {
 		if err != nil {
 			return keypairsDataLoadedMsg{err: err}
 		}
-		cols := []table.Column{{Title: "Name", Width: uiconst.ColWidthName}, {Title: "Fingerprint", Width: uiconst.ColWidthFingerprint}, {Title: "Type", Width: uiconst.ColWidthType}, {Title: "UserID", Width: uiconst.ColWidthUUID}}
+		cols := []table.Column{{Title: i18n.T("Name"), Width: uiconst.ColWidthName}, {Title: i18n.T("Fingerprint"), Width: uiconst.ColWidthFingerprint}, {Title: i18n.T("Type"), Width: uiconst.ColWidthType}, {Title: i18n.T("UserID"), Width: uiconst.ColWidthUUID}}
 		rows := []table.Row{}
 		for _, kp := range kpList {
 			rows = append(rows, table.Row{kp.Name, kp.Fingerprint, kp.Type, kp.UserID})
@@ -67,6 +69,14 @@ func (m KeypairsModel) Init() tea.Cmd {
 	}
 }
 
+// Reload re-fetches the key pairs, keeping the selected row.
+func (m KeypairsModel) Reload() tea.Cmd {
+	if m.loading || m.filterMode {
+		return nil
+	}
+	return m.Init()
+}
+
 // Update handles messages for the model, including data load, window resize,
 // and key handling for filtering.
 func (m KeypairsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -77,7 +87,7 @@ func (m KeypairsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.err = msg.err
 			return m, nil
 		}
-		m.table = msg.tbl
+		m.table = common.Reloaded(m.table, msg.tbl)
 		m.allRows = msg.rows
 		m.updateTableColumns()
 		m.table.SetHeight(m.height - uiconst.TableHeightOffset)
@@ -148,10 +158,10 @@ func (m KeypairsModel) View() string {
 		return m.spinner.View()
 	}
 	if m.err != nil {
-		return fmt.Sprintf("Error: %s", m.err)
+		return i18n.T("Error: %s", m.err)
 	}
 	if m.filterMode {
-		filterLine := fmt.Sprintf("Filter: %s", m.filter.View())
+		filterLine := i18n.T("Filter: %s", m.filter.View())
 		footer := "esc: clear"
 		return fmt.Sprintf("%s\n%s\n%s", filterLine, m.table.View(), footer)
 	}
@@ -168,10 +178,13 @@ func (m *KeypairsModel) updateTableColumns() {
 	if nameW < 10 {
 		nameW = 10
 	}
-	m.table.SetColumns([]table.Column{{Title: "Name", Width: nameW}, {Title: "Fingerprint", Width: fingerprintW}, {Title: "Type", Width: typeW}, {Title: "UserID", Width: userIDW}})
+	m.table.SetColumns([]table.Column{{Title: i18n.T("Name"), Width: nameW}, {Title: i18n.T("Fingerprint"), Width: fingerprintW}, {Title: i18n.T("Type"), Width: typeW}, {Title: i18n.T("UserID"), Width: userIDW}})
 }
 
 // Table returns the underlying table model for external callers.
 func (m KeypairsModel) Table() table.Model { return m.table }
 
+// Filtering reports whether keys are typed into the filter.
+func (m KeypairsModel) Filtering() bool { return m.filterMode }
+
 var _ tea.Model = (*KeypairsModel)(nil)
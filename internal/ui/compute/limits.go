@@ -4,11 +4,17 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/table"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"ostui/internal/client"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/policy"
+	"ostui/internal/ui/theme"
+	"ostui/internal/ui/uiconst"
 )
 
 // limitRow holds raw data for one quota entry.
@@ -20,6 +26,7 @@ type limitRow struct {
 }
 
 // LimitsModel displays quota usage for compute and volume services.
+// Admins can press "e" to pick a project and edit its quotas.
 type LimitsModel struct {
 	rows    []limitRow
 	loading bool
@@ -27,28 +34,70 @@ type LimitsModel struct {
 	spinner spinner.Model
 	client  client.LimitsClient
 	width   int
+	height  int
+
+	// Quota editing
+	computeClient  client.ComputeClient
+	networkClient  client.NetworkClient
+	storageClient  client.StorageClient
+	identityClient client.IdentityClient
+	mode           string // "view", "projects" or "edit"
+	projectTable   table.Model
+	projectID      string
+	projectName    string
+	fields         []quotaField
+	focus          int
+	formErr        error
+	status         string
+
+	// rates are the API rate limits, usually none.
+	rates []rateRow
+	// Per-user usage, toggled with u.
+	showUsers    bool
+	usersLoading bool
+	users        []userUsage
+	usersErr     error
+	// seq tells this view's sampling ticks from those of earlier instances.
+	seq int
 }
 
 type limitsDataLoadedMsg struct {
-	rows []limitRow
-	err  error
+	rows  []limitRow
+	rates []rateRow
+	err   error
+	// sample is set for the periodic reloads, whose errors are ignored.
+	sample bool
 }
 
-// NewLimitsModel creates a new LimitsModel.
-func NewLimitsModel(lc client.LimitsClient) LimitsModel {
+// limitsSampleMsg asks the view with the same seq to sample usage again.
+type limitsSampleMsg struct{ seq int }
+
+// limitsSeq numbers the Limits views.
+var limitsSeq int
+
+// quotaProjectsLoadedMsg is emitted when the project list for quota editing has been fetched.
+type quotaProjectsLoadedMsg struct {
+	tbl table.Model
+	err error
+}
+
+// NewLimitsModel creates a new LimitsModel. The compute, network, storage and
+// identity clients are used for quota editing.
+func NewLimitsModel(lc client.LimitsClient, cc client.ComputeClient, nc client.NetworkClient, sc client.StorageClient, ic client.IdentityClient) LimitsModel {
 	s := spinner.New()
 	s.Spinner = spinner.Dot
-	return LimitsModel{client: lc, loading: true, spinner: s}
+	limitsSeq++
+	return LimitsModel{client: lc, computeClient: cc, networkClient: nc, storageClient: sc, identityClient: ic, loading: true, spinner: s, mode: "view", height: 30, seq: limitsSeq}
 }
 
 // colorForPct returns a lipgloss color based on usage percentage.
 func colorForPct(pct float64) lipgloss.Color {
 	if pct < 60 {
-		return lipgloss.Color("#5CB85C") // green
+		return theme.OK // green
 	} else if pct < 85 {
-		return lipgloss.Color("#F0AD4E") // yellow
+		return theme.Warn // yellow
 	}
-	return lipgloss.Color("#D9534F") // red
+	return theme.Error // red
 }
 
 // renderBar creates a colored bar of length 20.
@@ -63,8 +112,30 @@ func renderBar(pct float64) string {
 	return lipgloss.NewStyle().Foreground(colorForPct(pct)).Render(bar)
 }
 
-// Init fetches limits data.
+// Init fetches limits data and starts sampling usage.
 func (m LimitsModel) Init() tea.Cmd {
+	return tea.Batch(m.loadLimitsCmd(), m.sampleTick())
+}
+
+// Reload fetches the limits again, with the per-user usage when it is shown.
+func (m LimitsModel) Reload() tea.Cmd {
+	if m.loading || m.usersLoading || m.mode != "view" {
+		return nil
+	}
+	if m.showUsers {
+		return tea.Batch(m.loadLimitsCmd(), loadUserUsageCmd(m.computeClient, m.storageClient, m.identityClient))
+	}
+	return m.loadLimitsCmd()
+}
+
+// sampleTick schedules the next usage sample.
+func (m LimitsModel) sampleTick() tea.Cmd {
+	seq := m.seq
+	return tea.Tick(LimitsSampleInterval, func(time.Time) tea.Msg { return limitsSampleMsg{seq: seq} })
+}
+
+// loadLimitsCmd returns a command that fetches limits for the current project.
+func (m LimitsModel) loadLimitsCmd() tea.Cmd {
 	return func() tea.Msg {
 		limits, err := m.client.GetLimits(context.Background())
 		if err != nil {
@@ -83,39 +154,217 @@ func (m LimitsModel) Init() tea.Cmd {
 
 		if limits.Compute != nil {
 			c := limits.Compute.Absolute
-			add("Instances", c.TotalInstancesUsed, c.MaxTotalInstances)
-			add("vCPUs", c.TotalCoresUsed, c.MaxTotalCores)
+			add(i18n.T("Instances"), c.TotalInstancesUsed, c.MaxTotalInstances)
+			add(i18n.T("vCPUs"), c.TotalCoresUsed, c.MaxTotalCores)
 			add("RAM (MiB)", c.TotalRAMUsed, c.MaxTotalRAMSize)
-			add("Floating IPs", c.TotalFloatingIpsUsed, c.MaxTotalFloatingIps)
+			add(i18n.T("Floating IPs"), c.TotalFloatingIpsUsed, c.MaxTotalFloatingIps)
 		}
 
 		if limits.Volume != nil {
 			v := limits.Volume.Absolute
-			add("Volumes", v.TotalVolumesUsed, v.MaxTotalVolumes)
-			add("Volume GB", v.TotalGigabytesUsed, v.MaxTotalVolumeGigabytes)
-			add("Snapshots", v.TotalSnapshotsUsed, v.MaxTotalSnapshots)
-			add("Backup GB", v.TotalBackupGigabytesUsed, v.MaxTotalBackupGigabytes)
+			add(i18n.T("Volumes"), v.TotalVolumesUsed, v.MaxTotalVolumes)
+			add(i18n.T("Volume GB"), v.TotalGigabytesUsed, v.MaxTotalVolumeGigabytes)
+			add(i18n.T("Snapshots"), v.TotalSnapshotsUsed, v.MaxTotalSnapshots)
+			add(i18n.T("Backup GB"), v.TotalBackupGigabytesUsed, v.MaxTotalBackupGigabytes)
 		}
 
-		return limitsDataLoadedMsg{rows: rows}
+		return limitsDataLoadedMsg{rows: rows, rates: rateRows(limits)}
 	}
 }
 
+// loadProjectsCmd returns a command that lists projects for quota editing.
+func (m LimitsModel) loadProjectsCmd() tea.Cmd {
+	return func() tea.Msg {
+		projList, err := m.identityClient.ListProjects()
+		if err != nil {
+			return quotaProjectsLoadedMsg{err: err}
+		}
+		cols := []table.Column{{Title: "ID", Width: uiconst.ColWidthUUID}, {Title: i18n.T("Name"), Width: uiconst.ColWidthNameLong}}
+		rows := []table.Row{}
+		for _, p := range projList {
+			rows = append(rows, table.Row{p.ID, p.Name})
+		}
+		t := table.New(
+			table.WithColumns(cols),
+			table.WithRows(rows),
+			table.WithFocused(true),
+			table.WithHeight(m.height-uiconst.TableHeightOffset),
+		)
+		t.SetStyles(table.DefaultStyles())
+		return quotaProjectsLoadedMsg{tbl: t}
+	}
+}
+
+// CapturingInput reports whether the quota editor needs every key,
+// so global shortcuts are not triggered while it is open.
+func (m LimitsModel) CapturingInput() bool {
+	return m.mode != "view"
+}
+
+// setFocus moves the form focus to index i.
+func (m *LimitsModel) setFocus(i int) {
+	if len(m.fields) == 0 {
+		return
+	}
+	m.fields[m.focus].input.Blur()
+	m.focus = (i + len(m.fields)) % len(m.fields)
+	m.fields[m.focus].input.Focus()
+}
+
 // Update handles messages.
 func (m LimitsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case limitsDataLoadedMsg:
 		m.loading = false
+		if msg.err != nil && msg.sample {
+			return m, nil
+		}
 		m.err = msg.err
-		m.rows = msg.rows
+		m.rows, m.rates = msg.rows, msg.rates
+		recordUsage(m.rows)
+		return m, nil
+	case limitsSampleMsg:
+		if msg.seq != m.seq {
+			return m, nil
+		}
+		load := m.loadLimitsCmd()
+		sample := func() tea.Msg {
+			out := load()
+			if l, ok := out.(limitsDataLoadedMsg); ok {
+				l.sample = true
+				return l
+			}
+			return out
+		}
+		return m, tea.Batch(sample, m.sampleTick())
+	case userUsageLoadedMsg:
+		m.usersLoading = false
+		m.users, m.usersErr = msg.rows, msg.err
+		return m, nil
+	case quotaProjectsLoadedMsg:
+		m.loading = false
+		if msg.err != nil {
+			m.mode = "view"
+			m.status = i18n.T("Failed to list projects: %s", msg.err)
+			return m, nil
+		}
+		m.projectTable = msg.tbl
+		return m, nil
+	case quotaFieldsLoadedMsg:
+		m.loading = false
+		if msg.err != nil {
+			m.mode = "projects"
+			m.status = msg.err.Error()
+			return m, nil
+		}
+		m.fields = msg.fields
+		m.focus = 0
+		m.formErr = nil
+		m.mode = "edit"
 		return m, nil
+	case quotaSavedMsg:
+		m.loading = false
+		if msg.err != nil {
+			policy.Record("editing quotas", msg.err)
+			m.formErr = msg.err
+			return m, nil
+		}
+		m.mode = "view"
+		m.fields = nil
+		m.status = i18n.T("Quotas updated for project %s", m.projectName)
+		m.loading = true
+		return m, m.loadLimitsCmd()
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
+		m.height = msg.Height
+		if m.projectTable.Columns() != nil {
+			m.projectTable.SetHeight(m.height - uiconst.TableHeightOffset)
+		}
 		return m, nil
 	case tea.KeyMsg:
+		if m.loading {
+			return m, nil
+		}
+		switch m.mode {
+		case "projects":
+			switch msg.String() {
+			case "esc":
+				m.mode = "view"
+				return m, nil
+			case "enter":
+				row := m.projectTable.SelectedRow()
+				if len(row) > 0 {
+					m.projectID = row[0]
+					m.projectName = row[1]
+					m.status = ""
+					m.loading = true
+					return m, loadQuotaFieldsCmd(m.computeClient, m.networkClient, m.storageClient, m.projectID)
+				}
+				return m, nil
+			}
+			var cmd tea.Cmd
+			m.projectTable, cmd = m.projectTable.Update(msg)
+			return m, cmd
+		case "edit":
+			switch msg.String() {
+			case "esc":
+				m.mode = "projects"
+				m.fields = nil
+				return m, nil
+			case "tab", "down":
+				m.setFocus(m.focus + 1)
+				return m, nil
+			case "shift+tab", "up":
+				m.setFocus(m.focus - 1)
+				return m, nil
+			case "enter":
+				u, err := buildQuotaUpdate(m.fields)
+				if err != nil {
+					m.formErr = err
+					return m, nil
+				}
+				if u.compute == nil && u.network == nil && u.volume == nil {
+					m.formErr = fmt.Errorf("no changes to apply")
+					return m, nil
+				}
+				m.formErr = nil
+				m.loading = true
+				return m, saveQuotaCmd(m.computeClient, m.networkClient, m.storageClient, m.projectID, u)
+			}
+			var cmd tea.Cmd
+			m.fields[m.focus].input, cmd = m.fields[m.focus].input.Update(msg)
+			return m, cmd
+		}
+		switch msg.String() {
+		case "u":
+			m.showUsers = !m.showUsers
+			if m.showUsers && m.users == nil && !m.usersLoading {
+				m.usersLoading = true
+				return m, tea.Batch(m.spinner.Tick, loadUserUsageCmd(m.computeClient, m.storageClient, m.identityClient))
+			}
+			return m, nil
+		case "r":
+			m.loading = true
+			cmds := []tea.Cmd{m.spinner.Tick, m.loadLimitsCmd()}
+			if m.showUsers {
+				m.usersLoading = true
+				cmds = append(cmds, loadUserUsageCmd(m.computeClient, m.storageClient, m.identityClient))
+			}
+			return m, tea.Batch(cmds...)
+		}
+		if msg.String() == "e" && m.identityClient != nil {
+			if err := policy.Check(policy.Admin, "editing quotas"); err != nil {
+				m.status = err.Error()
+				return m, nil
+			}
+			m.mode = "projects"
+			m.status = ""
+			m.loading = true
+			return m, m.loadProjectsCmd()
+		}
 		return m, nil
 	default:
-		if m.loading {
+		if m.loading || m.usersLoading {
 			var cmd tea.Cmd
 			m.spinner, cmd = m.spinner.Update(msg)
 			return m, cmd
@@ -129,11 +378,22 @@ func (m LimitsModel) View() string {
 	if m.loading {
 		return m.spinner.View()
 	}
+	dimStyle := lipgloss.NewStyle().Foreground(theme.Muted)
+	switch m.mode {
+	case "projects":
+		out := "Select project to edit quotas\n" + m.projectTable.View() + "\n"
+		if m.status != "" {
+			out += m.status + "\n"
+		}
+		return out + dimStyle.Render(i18n.T("[enter] edit  [esc] cancel")) + "\n"
+	case "edit":
+		return renderQuotaForm(m.fields, m.focus, m.projectName, m.formErr)
+	}
 	if m.err != nil {
-		return fmt.Sprintf("Error loading limits: %s", m.err)
+		return i18n.T("Error loading limits: %s", m.err)
 	}
 	if len(m.rows) == 0 {
-		return "No quota data available."
+		return i18n.T("No quota data available.")
 	}
 
 	width := m.width
@@ -146,7 +406,7 @@ func (m LimitsModel) View() string {
 	separator := strings.Repeat("─", width)
 
 	var sb strings.Builder
-	sb.WriteString(headerStyle.Render(fmt.Sprintf("%-16s  %-22s  %12s  %6s", "Resource", "Usage", "Used/Total", "Pct")) + "\n")
+	sb.WriteString(headerStyle.Render(fmt.Sprintf("%-16s  %-22s  %12s  %6s  %s", i18n.T("Resource"), i18n.T("Usage"), i18n.T("Used/Total"), "Pct", i18n.T("History"))) + "\n")
 	sb.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("#444444")).Render(separator) + "\n")
 
 	for _, r := range m.rows {
@@ -157,17 +417,47 @@ func (m LimitsModel) View() string {
 		usedTotal := fmt.Sprintf("%d/%d", r.used, r.total)
 		pctStr := fmt.Sprintf("%.0f%%", r.pct)
 
-		line := fmt.Sprintf("%s  %s  %12s  %6s",
+		line := fmt.Sprintf("%s  %s  %12s  %6s  %s",
 			nameStyle.Render(r.name),
 			bar,
 			valueStyle.Render(usedTotal),
 			valueStyle.Render(pctStr),
+			dimStyle.Render(sparkline(usageHistory[r.name])),
 		)
 		sb.WriteString(line + "\n")
 	}
 
 	sb.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("#444444")).Render(separator) + "\n")
-	sb.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("#666666")).Render("[esc] back") + "\n")
+	sb.WriteString(headerStyle.Render(i18n.T("Rate limits")) + "\n")
+	if len(m.rates) == 0 {
+		sb.WriteString(dimStyle.Render(i18n.T("None reported by compute or block storage.")) + "\n")
+	}
+	for _, r := range m.rates {
+		line := i18n.T("%-8s %-7s %-20.20s %d/%s, %d left", r.service, r.verb, r.uri, r.value, strings.ToLower(r.unit), r.remaining)
+		if r.remaining == 0 && r.next != "" {
+			line += ", next at " + r.next
+		}
+		sb.WriteString(line + "\n")
+	}
+	if m.showUsers {
+		sb.WriteString("\n" + headerStyle.Render(i18n.T("Usage by user")) + "\n")
+		switch {
+		case m.usersLoading:
+			sb.WriteString(m.spinner.View() + " Adding up servers and volumes…\n")
+		case m.usersErr != nil:
+			sb.WriteString(i18n.T("Error: %s", m.usersErr) + "\n")
+		default:
+			sb.WriteString(renderUserUsage(m.users))
+		}
+	}
+	if m.status != "" {
+		sb.WriteString(m.status + "\n")
+	}
+	hint := i18n.T("[u] usage by user  [r] refresh  [esc] back")
+	if policy.Allowed(policy.Admin) {
+		hint = i18n.T("[e] edit quotas (admin)") + "  " + hint
+	}
+	sb.WriteString(dimStyle.Render(hint) + "\n")
 
 	return sb.String()
 }
@@ -0,0 +1,215 @@
+package compute
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	vLimits "github.com/gophercloud/gophercloud/openstack/blockstorage/extensions/limits"
+	"github.com/gophercloud/gophercloud/openstack/blockstorage/v3/volumes"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/flavors"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
+	"ostui/internal/client"
+	"ostui/internal/i18n"
+)
+
+// LimitsSampleInterval is how often the open Limits view samples usage for
+// its history sparklines.
+var LimitsSampleInterval = time.Minute
+
+// historyLen is the number of samples a sparkline shows.
+const historyLen = 30
+
+// usageHistory holds the usage percentages sampled during the session, by
+// resource. It outlives the views, so reopening Limits keeps the history.
+var usageHistory = map[string][]float64{}
+
+// recordUsage appends a sample for every row, keeping the last historyLen.
+func recordUsage(rows []limitRow) {
+	for _, r := range rows {
+		h := append(usageHistory[r.name], r.pct)
+		if len(h) > historyLen {
+			h = h[len(h)-historyLen:]
+		}
+		usageHistory[r.name] = h
+	}
+}
+
+var sparkLevels = []rune("▁▂▃▄▅▆▇█")
+
+// sparkline renders percentages (0-100) as block characters.
+func sparkline(pcts []float64) string {
+	var b strings.Builder
+	for _, p := range pcts {
+		i := int(p / 100 * float64(len(sparkLevels)-1))
+		i = min(max(i, 0), len(sparkLevels)-1)
+		b.WriteRune(sparkLevels[i])
+	}
+	return b.String()
+}
+
+// rateRow is one rate limit of a service.
+type rateRow struct {
+	service   string
+	verb      string
+	uri       string
+	value     int
+	unit      string
+	remaining int
+	next      string
+}
+
+// rateRows flattens the compute and volume rate limits.
+func rateRows(l *client.Limits) []rateRow {
+	var out []rateRow
+	add := func(service string, rates []vLimits.Rate) {
+		for _, r := range rates {
+			for _, lim := range r.Limit {
+				out = append(out, rateRow{service: service, verb: lim.Verb, uri: r.URI, value: lim.Value, unit: lim.Unit, remaining: lim.Remaining, next: lim.NextAvailable})
+			}
+		}
+	}
+	add("compute", l.ComputeRate)
+	if l.Volume != nil {
+		add("volume", l.Volume.Rate)
+	}
+	return out
+}
+
+// userUsage is what one user has running in the project.
+type userUsage struct {
+	user      string
+	servers   int
+	vcpus     int
+	ramMB     int
+	volumes   int
+	volumeGB  int
+	unresized bool
+}
+
+type userUsageLoadedMsg struct {
+	rows []userUsage
+	err  error
+}
+
+// loadUserUsageCmd adds up servers and volumes by the user who created them.
+// Flavors resolve servers listed without embedded flavor details and users
+// resolve names; both are optional.
+func loadUserUsageCmd(cc client.ComputeClient, sc client.StorageClient, ic client.IdentityClient) tea.Cmd {
+	return func() tea.Msg {
+		srvs, err := cc.ListInstances()
+		if err != nil {
+			return userUsageLoadedMsg{err: err}
+		}
+		vols, err := sc.ListVolumes()
+		if err != nil {
+			return userUsageLoadedMsg{err: err}
+		}
+		fl, _ := cc.ListFlavors()
+		names := map[string]string{}
+		if ic != nil {
+			if list, err := ic.ListUsers(); err == nil {
+				for _, u := range list {
+					names[u.ID] = u.Name
+				}
+			}
+		}
+		return userUsageLoadedMsg{rows: usageByUser(srvs, vols, fl, names)}
+	}
+}
+
+// usageByUser groups servers and volumes by user, most vCPUs first. A
+// server whose flavor is unknown counts as a server only.
+func usageByUser(srvs []servers.Server, vols []volumes.Volume, fl []flavors.Flavor, names map[string]string) []userUsage {
+	byID := map[string]flavors.Flavor{}
+	for _, f := range fl {
+		byID[f.ID] = f
+	}
+	users := map[string]*userUsage{}
+	get := func(id string) *userUsage {
+		if id == "" {
+			id = "(unknown)"
+		}
+		if u, ok := users[id]; ok {
+			return u
+		}
+		u := &userUsage{user: id}
+		if name, ok := names[id]; ok {
+			u.user = name
+		}
+		users[id] = u
+		return u
+	}
+	for _, s := range srvs {
+		u := get(s.UserID)
+		u.servers++
+		vcpus, ram, ok := serverSize(s, byID)
+		if !ok {
+			u.unresized = true
+		}
+		u.vcpus += vcpus
+		u.ramMB += ram
+	}
+	for _, v := range vols {
+		u := get(v.UserID)
+		u.volumes++
+		u.volumeGB += v.Size
+	}
+	out := make([]userUsage, 0, len(users))
+	for _, u := range users {
+		out = append(out, *u)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].vcpus != out[j].vcpus {
+			return out[i].vcpus > out[j].vcpus
+		}
+		return out[i].user < out[j].user
+	})
+	return out
+}
+
+// serverSize returns the vCPUs and RAM of a server from its embedded flavor
+// (microversion 2.47 and later) or the flavor list.
+func serverSize(s servers.Server, byID map[string]flavors.Flavor) (vcpus, ramMB int, ok bool) {
+	if v, vok := numeric(s.Flavor["vcpus"]); vok {
+		r, _ := numeric(s.Flavor["ram"])
+		return v, r, true
+	}
+	id, _ := s.Flavor["id"].(string)
+	if f, fok := byID[id]; fok {
+		return f.VCPUs, f.RAM, true
+	}
+	return 0, 0, false
+}
+
+// numeric reads a JSON number, or an int set by a fake client.
+func numeric(v interface{}) (int, bool) {
+	switch n := v.(type) {
+	case float64:
+		return int(n), true
+	case int:
+		return n, true
+	}
+	return 0, false
+}
+
+// renderUserUsage formats the per-user table.
+func renderUserUsage(rows []userUsage) string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("%-24s %8s %6s %10s %8s %10s\n", i18n.T("User"), i18n.T("Servers"), i18n.T("vCPUs"), "RAM (MiB)", i18n.T("Volumes"), i18n.T("Volume GB")))
+	partial := false
+	for _, u := range rows {
+		vcpus := fmt.Sprintf("%d", u.vcpus)
+		if u.unresized {
+			vcpus += "*"
+			partial = true
+		}
+		b.WriteString(fmt.Sprintf("%-24.24s %8d %6s %10d %8d %10d\n", u.user, u.servers, vcpus, u.ramMB, u.volumes, u.volumeGB))
+	}
+	if partial {
+		b.WriteString(i18n.T("* some servers have a flavor that is no longer listed") + "\n")
+	}
+	return b.String()
+}
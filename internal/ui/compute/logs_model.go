@@ -7,6 +7,7 @@ import (
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"ostui/internal/client"
+	"ostui/internal/i18n"
 )
 
 // LogsModel implements a streaming log viewer for a compute server.
@@ -143,10 +144,10 @@ func (m LogsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 // View renders the header and the viewport.
 func (m LogsModel) View() string {
 	if m.err != nil {
-		return fmt.Sprintf("Error: %s", m.err)
+		return i18n.T("Error: %s", m.err)
 	}
-	header := fmt.Sprintf("Server: %s | Streaming: %t | Interval: %s", m.serverID, m.streaming, m.interval)
-	footer := fmt.Sprintf(" %3.f%% | [j/k] scroll [g/G] top/bottom [p] pause [esc] back", m.viewport.ScrollPercent()*100)
+	header := i18n.T("Server: %s | Streaming: %t | Interval: %s", m.serverID, m.streaming, m.interval)
+	footer := i18n.T(" %3.f%% | [j/k] scroll [g/G] top/bottom [p] pause [esc] back", m.viewport.ScrollPercent()*100)
 	return fmt.Sprintf("%s\n%s\n%s", header, m.viewport.View(), footer)
 }
 
@@ -6,6 +6,13 @@ type OpenLogsMsg struct {
 	ServerID string
 }
 
+// OpenServerDNSMsg asks the app to open the DNS records of a server, where
+// a record can be pointed at one of its addresses.
+type OpenServerDNSMsg struct {
+	ServerID string
+	Name     string
+}
+
 // GoBackMsg signals that the logs view should be closed and the UI should return to the previous view.
 type GoBackMsg struct{}
 
@@ -0,0 +1,211 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	vQuotas "github.com/gophercloud/gophercloud/openstack/blockstorage/extensions/quotasets"
+	cQuotas "github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/quotasets"
+	nQuotas "github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/quotas"
+	"ostui/internal/client"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/theme"
+)
+
+// Quota keys understood by buildQuotaUpdate.
+const (
+	quotaInstances      = "instances"
+	quotaCores          = "cores"
+	quotaRAM            = "ram"
+	quotaPorts          = "port"
+	quotaFloatingIPs    = "floatingip"
+	quotaSecurityGroups = "security_group"
+	quotaVolumes        = "volumes"
+	quotaGigabytes      = "gigabytes"
+)
+
+// quotaField is one editable row of the quota form.
+type quotaField struct {
+	service string // "Compute", "Network" or "Volume"
+	key     string
+	label   string
+	used    int
+	limit   int
+	input   textinput.Model
+}
+
+// quotaUpdate groups the per-service update options built from the form.
+// A nil entry means nothing changed for that service.
+type quotaUpdate struct {
+	compute *cQuotas.UpdateOpts
+	network *nQuotas.UpdateOpts
+	volume  *vQuotas.UpdateOpts
+}
+
+// quotaFieldsLoadedMsg is emitted when current quotas for a project have been fetched.
+type quotaFieldsLoadedMsg struct {
+	fields []quotaField
+	err    error
+}
+
+// quotaSavedMsg is emitted once all quota updates have been applied.
+type quotaSavedMsg struct {
+	err error
+}
+
+// newQuotaField creates a form row prefilled with the current limit.
+func newQuotaField(service, key, label string, used, limit int) quotaField {
+	ti := textinput.New()
+	ti.CharLimit = 10
+	ti.Width = 10
+	ti.SetValue(strconv.Itoa(limit))
+	return quotaField{service: service, key: key, label: label, used: used, limit: limit, input: ti}
+}
+
+// loadQuotaFieldsCmd fetches compute, network and volume quotas for the project.
+func loadQuotaFieldsCmd(cc client.ComputeClient, nc client.NetworkClient, sc client.StorageClient, projectID string) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		cq, err := cc.GetQuotaSet(ctx, projectID)
+		if err != nil {
+			return quotaFieldsLoadedMsg{err: fmt.Errorf("failed to get compute quotas: %w", err)}
+		}
+		nq, err := nc.GetQuota(ctx, projectID)
+		if err != nil {
+			return quotaFieldsLoadedMsg{err: fmt.Errorf("failed to get network quotas: %w", err)}
+		}
+		vq, err := sc.GetQuotaSet(projectID)
+		if err != nil {
+			return quotaFieldsLoadedMsg{err: fmt.Errorf("failed to get volume quotas: %w", err)}
+		}
+		fields := []quotaField{
+			newQuotaField(i18n.T("Compute"), quotaInstances, i18n.T("Instances"), cq.Instances.InUse, cq.Instances.Limit),
+			newQuotaField(i18n.T("Compute"), quotaCores, i18n.T("vCPUs"), cq.Cores.InUse, cq.Cores.Limit),
+			newQuotaField(i18n.T("Compute"), quotaRAM, "RAM (MiB)", cq.RAM.InUse, cq.RAM.Limit),
+			newQuotaField(i18n.T("Network"), quotaPorts, i18n.T("Ports"), nq.Port.Used, nq.Port.Limit),
+			newQuotaField(i18n.T("Network"), quotaFloatingIPs, i18n.T("Floating IPs"), nq.FloatingIP.Used, nq.FloatingIP.Limit),
+			newQuotaField(i18n.T("Network"), quotaSecurityGroups, i18n.T("Security Groups"), nq.SecurityGroup.Used, nq.SecurityGroup.Limit),
+			newQuotaField(i18n.T("Volume"), quotaVolumes, i18n.T("Volumes"), vq.Volumes.InUse, vq.Volumes.Limit),
+			newQuotaField(i18n.T("Volume"), quotaGigabytes, i18n.T("Volume GB"), vq.Gigabytes.InUse, vq.Gigabytes.Limit),
+		}
+		fields[0].input.Focus()
+		return quotaFieldsLoadedMsg{fields: fields}
+	}
+}
+
+// buildQuotaUpdate parses the form values and returns the options for every
+// service that has at least one changed limit. A limit of -1 means unlimited.
+func buildQuotaUpdate(fields []quotaField) (quotaUpdate, error) {
+	var u quotaUpdate
+	for _, f := range fields {
+		raw := strings.TrimSpace(f.input.Value())
+		val, err := strconv.Atoi(raw)
+		if err != nil || val < -1 {
+			return quotaUpdate{}, fmt.Errorf("invalid limit %q for %s", raw, f.label)
+		}
+		if val == f.limit {
+			continue
+		}
+		v := val
+		switch f.key {
+		case quotaInstances, quotaCores, quotaRAM:
+			if u.compute == nil {
+				u.compute = &cQuotas.UpdateOpts{}
+			}
+			switch f.key {
+			case quotaInstances:
+				u.compute.Instances = &v
+			case quotaCores:
+				u.compute.Cores = &v
+			case quotaRAM:
+				u.compute.RAM = &v
+			}
+		case quotaPorts, quotaFloatingIPs, quotaSecurityGroups:
+			if u.network == nil {
+				u.network = &nQuotas.UpdateOpts{}
+			}
+			switch f.key {
+			case quotaPorts:
+				u.network.Port = &v
+			case quotaFloatingIPs:
+				u.network.FloatingIP = &v
+			case quotaSecurityGroups:
+				u.network.SecurityGroup = &v
+			}
+		case quotaVolumes, quotaGigabytes:
+			if u.volume == nil {
+				u.volume = &vQuotas.UpdateOpts{}
+			}
+			switch f.key {
+			case quotaVolumes:
+				u.volume.Volumes = &v
+			case quotaGigabytes:
+				u.volume.Gigabytes = &v
+			}
+		}
+	}
+	return u, nil
+}
+
+// saveQuotaCmd applies the quota update for the project, service by service.
+func saveQuotaCmd(cc client.ComputeClient, nc client.NetworkClient, sc client.StorageClient, projectID string, u quotaUpdate) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		if u.compute != nil {
+			if err := cc.UpdateQuotaSet(ctx, projectID, *u.compute); err != nil {
+				return quotaSavedMsg{err: fmt.Errorf("failed to update compute quotas: %w", err)}
+			}
+		}
+		if u.network != nil {
+			if err := nc.UpdateQuota(ctx, projectID, *u.network); err != nil {
+				return quotaSavedMsg{err: fmt.Errorf("failed to update network quotas: %w", err)}
+			}
+		}
+		if u.volume != nil {
+			if err := sc.UpdateQuotaSet(projectID, *u.volume); err != nil {
+				return quotaSavedMsg{err: fmt.Errorf("failed to update volume quotas: %w", err)}
+			}
+		}
+		return quotaSavedMsg{}
+	}
+}
+
+// renderQuotaForm renders the edit form showing usage against the current and new limit.
+func renderQuotaForm(fields []quotaField, focus int, projectName string, formErr error) string {
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#AAAAAA"))
+	dimStyle := lipgloss.NewStyle().Foreground(theme.Muted)
+	warnStyle := lipgloss.NewStyle().Foreground(theme.Error)
+
+	var sb strings.Builder
+	sb.WriteString(headerStyle.Render(i18n.T("Edit quotas for project %s", projectName)) + "\n\n")
+	sb.WriteString(headerStyle.Render(fmt.Sprintf("  %-8s  %-16s  %8s  %8s  %s", i18n.T("Service"), i18n.T("Resource"), i18n.T("In use"), i18n.T("Current"), i18n.T("New limit"))) + "\n")
+	for i, f := range fields {
+		cursor := "  "
+		if i == focus {
+			cursor = "▶ "
+		}
+		line := fmt.Sprintf("%s%-8s  %-16s  %8d  %8s  %s", cursor, f.service, f.label, f.used, formatQuotaLimit(f.limit), f.input.View())
+		if val, err := strconv.Atoi(strings.TrimSpace(f.input.Value())); err == nil && val >= 0 && val < f.used {
+			line += "  " + warnStyle.Render(i18n.T("below usage"))
+		}
+		sb.WriteString(line + "\n")
+	}
+	if formErr != nil {
+		sb.WriteString("\n" + warnStyle.Render(i18n.T("Error: %s", formErr)) + "\n")
+	}
+	sb.WriteString("\n" + dimStyle.Render(i18n.T("-1 = unlimited   [tab/↑↓] move  [enter] apply  [esc] cancel")) + "\n")
+	return sb.String()
+}
+
+// formatQuotaLimit renders -1 as "∞".
+func formatQuotaLimit(limit int) string {
+	if limit < 0 {
+		return "∞"
+	}
+	return strconv.Itoa(limit)
+}
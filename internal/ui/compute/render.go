@@ -12,7 +12,9 @@ import (
 	"fmt"
 	"github.com/charmbracelet/bubbles/table"
 	"ostui/internal/client"
+	"ostui/internal/i18n"
 	"ostui/internal/ui/common"
+	"ostui/internal/ui/theme"
 	"ostui/internal/ui/uiconst"
 	"time"
 )
@@ -24,12 +26,12 @@ import (
 func RenderInstances(cc client.ComputeClient) string {
 	srvList, err := cc.ListInstances()
 	if err != nil {
-		return fmt.Sprintf("Failed to list instances: %s", err)
+		return i18n.T("Failed to list instances: %s", err)
 	}
-	cols := []table.Column{{Title: "ID", Width: uiconst.ColWidthUUID}, {Title: "Name", Width: uiconst.ColWidthName}, {Title: "Status", Width: uiconst.ColWidthStatus}}
+	cols := []table.Column{{Title: "ID", Width: uiconst.ColWidthUUID}, {Title: i18n.T("Name"), Width: uiconst.ColWidthName}, {Title: i18n.T("Status"), Width: uiconst.ColWidthStatus}}
 	rows := []table.Row{}
 	for _, s := range srvList {
-		rows = append(rows, table.Row{s.ID, s.Name, s.Status})
+		rows = append(rows, table.Row{s.ID, s.Name, theme.Mark(s.Status)})
 	}
 	t := table.New(
 		table.WithColumns(cols),
@@ -47,24 +49,24 @@ func RenderInstances(cc client.ComputeClient) string {
 func RenderInstanceDetail(cc client.ComputeClient, id string) string {
 	srv, err := cc.GetInstance(id)
 	if err != nil {
-		return fmt.Sprintf("Failed to get instance: %s", err)
+		return i18n.T("Failed to get instance: %s", err)
 	}
 	// Build a map of fields similar to InstanceDetailModel.
 	fields := map[string]string{
-		"ID":       srv.ID,
-		"Name":     srv.Name,
-		"Status":   srv.Status,
-		"Flavor":   fmt.Sprintf("%v", srv.Flavor["id"]),
-		"Image":    fmt.Sprintf("%v", srv.Image["id"]),
-		"Created":  srv.Created.Format(time.RFC3339),
-		"Updated":  srv.Updated.Format(time.RFC3339),
-		"HostID":   srv.HostID,
-		"KeyName":  srv.KeyName,
-		"UserID":   srv.UserID,
-		"TenantID": srv.TenantID,
+		"ID":               srv.ID,
+		i18n.T("Name"):     srv.Name,
+		i18n.T("Status"):   srv.Status,
+		i18n.T("Flavor"):   fmt.Sprintf("%v", srv.Flavor["id"]),
+		i18n.T("Image"):    fmt.Sprintf("%v", srv.Image["id"]),
+		i18n.T("Created"):  srv.Created.Format(time.RFC3339),
+		i18n.T("Updated"):  srv.Updated.Format(time.RFC3339),
+		i18n.T("HostID"):   srv.HostID,
+		i18n.T("KeyName"):  srv.KeyName,
+		i18n.T("UserID"):   srv.UserID,
+		i18n.T("TenantID"): srv.TenantID,
 	}
 	// Use the common detail view to include a title.
-	return common.NewDetail("Instance Details", fields).View()
+	return common.NewDetail(i18n.T("Instance Details"), fields).View()
 }
 
 // RenderInstanceForm returns a simple form view for creating a new instance.
@@ -72,5 +74,5 @@ func RenderInstanceDetail(cc client.ComputeClient, id string) string {
 // output, so we construct a form with those fields using the shared FormModel.
 func RenderInstanceForm() string {
 	// The FormModel lives in the common package.
-	return common.NewForm([]string{"Name", "Image"}).View()
+	return common.NewForm([]string{i18n.T("Name"), i18n.T("Image")}).View()
 }
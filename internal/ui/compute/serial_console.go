@@ -0,0 +1,353 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"ostui/internal/client"
+	"ostui/internal/i18n"
+)
+
+// scrollback is the number of console lines kept.
+const scrollback = 1000
+
+// consoleScreen is a minimal terminal for serial console output: it moves
+// the cursor on carriage returns, line feeds, backspaces and tabs, erases
+// lines and clears the screen, and drops the other escape sequences, which
+// is enough for boot messages, login prompts and shells.
+type consoleScreen struct {
+	lines   [][]rune
+	row     int
+	col     int
+	partial []byte // an incomplete UTF-8 sequence or escape sequence
+}
+
+func newConsoleScreen() *consoleScreen {
+	return &consoleScreen{lines: [][]rune{nil}}
+}
+
+// write processes console output.
+func (s *consoleScreen) write(p []byte) {
+	data := append(s.partial, p...)
+	s.partial = nil
+	for i := 0; i < len(data); {
+		b := data[i]
+		switch {
+		case b == 0x1b:
+			n, ok := s.escape(data[i:])
+			if !ok {
+				s.partial = append([]byte(nil), data[i:]...)
+				return
+			}
+			i += n
+			continue
+		case b == '\r':
+			s.col = 0
+		case b == '\n':
+			s.row++
+			if s.row == len(s.lines) {
+				s.lines = append(s.lines, nil)
+			}
+			if len(s.lines) > scrollback {
+				drop := len(s.lines) - scrollback
+				s.lines = s.lines[drop:]
+				s.row -= drop
+			}
+		case b == '\b':
+			if s.col > 0 {
+				s.col--
+			}
+		case b == '\t':
+			for {
+				s.put(' ')
+				if s.col%8 == 0 {
+					break
+				}
+			}
+		case b < 0x20 || b == 0x7f:
+			// Bell and other controls have nothing to draw.
+		default:
+			if !utf8.FullRune(data[i:]) {
+				s.partial = append([]byte(nil), data[i:]...)
+				return
+			}
+			r, n := utf8.DecodeRune(data[i:])
+			s.put(r)
+			i += n
+			continue
+		}
+		i++
+	}
+}
+
+// put writes r at the cursor, overwriting what is there.
+func (s *consoleScreen) put(r rune) {
+	line := s.lines[s.row]
+	for len(line) < s.col {
+		line = append(line, ' ')
+	}
+	if s.col < len(line) {
+		line[s.col] = r
+	} else {
+		line = append(line, r)
+	}
+	s.lines[s.row] = line
+	s.col++
+}
+
+// escape handles the escape sequence at the start of data and returns its
+// length; ok is false when the sequence is not complete yet.
+func (s *consoleScreen) escape(data []byte) (n int, ok bool) {
+	if len(data) < 2 {
+		return 0, false
+	}
+	if data[1] != '[' {
+		return 2, true
+	}
+	for i := 2; i < len(data); i++ {
+		if data[i] >= 0x40 && data[i] <= 0x7e {
+			s.csi(string(data[2:i]), data[i])
+			return i + 1, true
+		}
+	}
+	return 0, false
+}
+
+// csi applies the control sequences that change what is on screen.
+func (s *consoleScreen) csi(params string, final byte) {
+	switch final {
+	case 'K':
+		// Erase to the end of the line (the usual case of line editing).
+		if params == "" || params == "0" {
+			if s.col < len(s.lines[s.row]) {
+				s.lines[s.row] = s.lines[s.row][:s.col]
+			}
+		} else if params == "2" {
+			s.lines[s.row] = nil
+		}
+	case 'J':
+		if params == "2" || params == "3" {
+			s.lines, s.row, s.col = [][]rune{nil}, 0, 0
+		}
+	case 'D':
+		n := 1
+		fmt.Sscanf(params, "%d", &n)
+		s.col = max(s.col-n, 0)
+	case 'C':
+		n := 1
+		fmt.Sscanf(params, "%d", &n)
+		s.col += n
+	}
+}
+
+// tail returns the last height lines.
+func (s *consoleScreen) tail(height int) string {
+	start := max(len(s.lines)-height, 0)
+	out := make([]string, 0, len(s.lines)-start)
+	for _, l := range s.lines[start:] {
+		out = append(out, string(l))
+	}
+	return strings.Join(out, "\n")
+}
+
+// keyBytes translates a key press to what a terminal sends for it; alt
+// prefixes the key with escape.
+func keyBytes(k tea.KeyMsg) []byte {
+	b := plainKeyBytes(k)
+	if k.Alt && len(b) > 0 {
+		b = append([]byte{0x1b}, b...)
+	}
+	return b
+}
+
+func plainKeyBytes(k tea.KeyMsg) []byte {
+	switch k.Type {
+	case tea.KeyRunes:
+		return []byte(string(k.Runes))
+	case tea.KeySpace:
+		return []byte{' '}
+	case tea.KeyEnter:
+		return []byte{'\r'}
+	case tea.KeyBackspace:
+		return []byte{0x7f}
+	case tea.KeyTab:
+		return []byte{'\t'}
+	case tea.KeyEsc:
+		return []byte{0x1b}
+	case tea.KeyUp:
+		return []byte("\x1b[A")
+	case tea.KeyDown:
+		return []byte("\x1b[B")
+	case tea.KeyRight:
+		return []byte("\x1b[C")
+	case tea.KeyLeft:
+		return []byte("\x1b[D")
+	case tea.KeyHome:
+		return []byte("\x1b[H")
+	case tea.KeyEnd:
+		return []byte("\x1b[F")
+	case tea.KeyDelete:
+		return []byte("\x1b[3~")
+	case tea.KeyPgUp:
+		return []byte("\x1b[5~")
+	case tea.KeyPgDown:
+		return []byte("\x1b[6~")
+	}
+	// The remaining key types below space are the control characters
+	// themselves (ctrl+a is 1, ctrl+c is 3 and so on).
+	if k.Type >= 0 && k.Type < 0x20 {
+		return []byte{byte(k.Type)}
+	}
+	return nil
+}
+
+// SerialConsoleModel is an interactive serial console of a server: console
+// output is drawn as it arrives and every key but ctrl+] is sent to the
+// guest, which is enough to debug a boot or log in without a browser.
+type SerialConsoleModel struct {
+	client   client.ComputeClient
+	serverID string
+	name     string
+	conn     io.ReadWriteCloser
+	screen   *consoleScreen
+	spinner  spinner.Model
+	err      error
+	closed   bool
+	height   int
+}
+
+type serialConsoleOpenedMsg struct {
+	conn io.ReadWriteCloser
+	err  error
+}
+
+// Dispose closes a console opened after its view was closed.
+func (msg serialConsoleOpenedMsg) Dispose() {
+	if msg.conn != nil {
+		msg.conn.Close()
+	}
+}
+
+// serialConsoleOutputMsg is a chunk of console output; err ends the session.
+type serialConsoleOutputMsg struct {
+	conn io.ReadWriteCloser
+	data []byte
+	err  error
+}
+
+// serialConsoleClosedMsg asks the parent view to drop the console.
+type serialConsoleClosedMsg struct{}
+
+// NewSerialConsoleModel creates the console of a server.
+func NewSerialConsoleModel(cc client.ComputeClient, serverID, name string) SerialConsoleModel {
+	s := spinner.New()
+	s.Spinner = spinner.Dot
+	return SerialConsoleModel{client: cc, serverID: serverID, name: name, screen: newConsoleScreen(), spinner: s, height: 24}
+}
+
+// Init opens the console.
+func (m SerialConsoleModel) Init() tea.Cmd {
+	cc, id := m.client, m.serverID
+	return tea.Batch(m.spinner.Tick, func() tea.Msg {
+		conn, err := cc.OpenSerialConsole(context.Background(), id)
+		return serialConsoleOpenedMsg{conn: conn, err: err}
+	})
+}
+
+// readConsoleCmd waits for the next chunk of output.
+func readConsoleCmd(conn io.ReadWriteCloser) tea.Cmd {
+	return func() tea.Msg {
+		buf := make([]byte, 4096)
+		n, err := conn.Read(buf)
+		return serialConsoleOutputMsg{conn: conn, data: buf[:n], err: err}
+	}
+}
+
+// Update handles console output and forwards keys.
+func (m SerialConsoleModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case serialConsoleOpenedMsg:
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		m.conn = msg.conn
+		return m, readConsoleCmd(m.conn)
+	case serialConsoleOutputMsg:
+		if msg.conn != m.conn || m.closed {
+			return m, nil
+		}
+		m.screen.write(msg.data)
+		if msg.err != nil {
+			m.closed = true
+			if msg.err != io.EOF {
+				m.err = msg.err
+			}
+			return m, nil
+		}
+		return m, readConsoleCmd(m.conn)
+	case tea.WindowSizeMsg:
+		m.height = max(msg.Height-4, 5)
+		return m, nil
+	case tea.KeyMsg:
+		key := msg.String()
+		done := m.conn == nil || m.closed
+		if key == "ctrl+]" || done && (key == "esc" || key == "q") {
+			m.Close()
+			return m, func() tea.Msg { return serialConsoleClosedMsg{} }
+		}
+		if done {
+			return m, nil
+		}
+		if b := keyBytes(msg); len(b) > 0 {
+			conn := m.conn
+			return m, func() tea.Msg {
+				if _, err := conn.Write(b); err != nil {
+					return serialConsoleOutputMsg{conn: conn, err: err}
+				}
+				return nil
+			}
+		}
+		return m, nil
+	default:
+		if m.conn == nil && m.err == nil {
+			var cmd tea.Cmd
+			m.spinner, cmd = m.spinner.Update(msg)
+			return m, cmd
+		}
+	}
+	return m, nil
+}
+
+// Close disconnects the console.
+func (m SerialConsoleModel) Close() {
+	if m.conn != nil {
+		m.conn.Close()
+	}
+}
+
+// View renders the console output and the status line.
+func (m SerialConsoleModel) View() string {
+	title := i18n.T("Serial console: %s", m.name)
+	switch {
+	case m.err != nil && m.conn == nil:
+		return i18n.T("%s\n\nFailed to open the serial console: %v\nThe cloud needs the serial console enabled in nova ([serial_console] enabled = true).\n\n[esc] back", title, m.err)
+	case m.conn == nil:
+		return i18n.T("%s\n\n%s Connecting...", title, m.spinner.View())
+	}
+	status := i18n.T("[ctrl+]] close — every other key goes to the server")
+	if m.closed {
+		status = i18n.T("Disconnected. [esc] back")
+		if m.err != nil {
+			status = i18n.T("Disconnected: %v. [esc] back", m.err)
+		}
+	}
+	return fmt.Sprintf("%s\n%s\n%s", title, m.screen.tail(m.height), status)
+}
+
+var _ tea.Model = (*SerialConsoleModel)(nil)
@@ -10,6 +10,8 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"ostui/internal/client"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/theme"
 )
 
 type graphNode struct {
@@ -66,14 +68,14 @@ func (m ServerGraphModel) buildGraph() tea.Msg {
 
 	// 4. Build boxes using lipgloss
 	boxStyle := lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).Padding(0, 1)
-	serverStyle := boxStyle.BorderForeground(lipgloss.Color("#5CB85C"))
-	portStyle := boxStyle.BorderForeground(lipgloss.Color("#F0AD4E"))
-	netStyle := boxStyle.BorderForeground(lipgloss.Color("#5BC0DE"))
+	serverStyle := boxStyle.BorderForeground(theme.OK)
+	portStyle := boxStyle.BorderForeground(theme.Warn)
+	netStyle := boxStyle.BorderForeground(theme.Info)
 	volStyle := boxStyle.BorderForeground(lipgloss.Color("#9B59B6"))
-	fipStyle := boxStyle.BorderForeground(lipgloss.Color("#E74C3C"))
+	fipStyle := boxStyle.BorderForeground(theme.Error)
 
 	// Build server box
-	serverBox := serverStyle.Render(fmt.Sprintf("Server: %s", m.serverName))
+	serverBox := serverStyle.Render(i18n.T("Server: %s", m.serverName))
 
 	// Build volume boxes
 	var volBoxes []string
@@ -89,7 +91,7 @@ func (m ServerGraphModel) buildGraph() tea.Msg {
 	// Build port+network+fip columns
 	var portCol, netCol, fipCol []string
 	for _, iface := range ifaces {
-		portBox := portStyle.Render(fmt.Sprintf("Port\nIP: %s", strings.Join(iface.FixedIPs, ", ")))
+		portBox := portStyle.Render(i18n.T("Port\nIP: %s", strings.Join(iface.FixedIPs, ", ")))
 		portCol = append(portCol, portBox)
 
 		net, _ := m.network.GetNetwork(context.Background(), iface.NetworkID)
@@ -129,7 +131,7 @@ func (m ServerGraphModel) buildGraph() tea.Msg {
 	const maxPorts = 8
 	if len(portCol) > maxPorts {
 		extra := len(portCol) - maxPorts
-		portCol = append(portCol[:maxPorts], portStyle.Render(fmt.Sprintf("+%d more", extra)))
+		portCol = append(portCol[:maxPorts], portStyle.Render(i18n.T("+%d more", extra)))
 	}
 
 	// Determine if we need to stack columns vertically based on viewport width
@@ -194,7 +196,12 @@ func (m ServerGraphModel) buildGraph() tea.Msg {
 		}
 		sb.WriteString(lipgloss.JoinHorizontal(lipgloss.Center, row...))
 	}
-	sb.WriteString("\n\n [g] close  [j/k] scroll")
+	// Server group membership: which other VMs share this failure domain.
+	if groups := renderServerGroups(m.compute, m.serverID); groups != "" {
+		sb.WriteString("\n\n")
+		sb.WriteString(groups)
+	}
+	sb.WriteString("\n\n " + i18n.T("[g] close  [j/k] scroll"))
 
 	return graphDataMsg{content: sb.String()}
 }
@@ -236,7 +243,7 @@ func (m ServerGraphModel) View() string {
 		return m.spinner.View()
 	}
 	if m.err != nil {
-		return fmt.Sprintf("Error: %s", m.err)
+		return i18n.T("Error: %s", m.err)
 	}
 	return m.viewport.View()
 }
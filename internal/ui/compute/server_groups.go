@@ -0,0 +1,119 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+	"ostui/internal/client"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/theme"
+)
+
+// groupMember is a server group member with the host it runs on.
+type groupMember struct {
+	ID   string
+	Name string
+	// Host is the hypervisor hostname, or "hostId:<prefix>" for non-admin
+	// users (the project-scoped HostID still tells whether hosts are shared).
+	Host string
+}
+
+// placementNote explains what sharing (or not sharing) a host with the
+// selected server means under the group's policy. Placement is only judged
+// when the host of every member is known: hosts can be hidden by policy,
+// and an unknown one would read as a different host.
+func placementNote(policy string, sameHost, hostsKnown bool) (string, bool) {
+	switch {
+	case !hostsKnown:
+		return "placement unknown – a member's host is not reported", false
+	case sameHost && policy == "anti-affinity":
+		return "same host – violates anti-affinity", true
+	case sameHost && policy == "soft-anti-affinity":
+		return "same host (soft-anti-affinity not met)", true
+	case sameHost:
+		return "same host", false
+	case policy == "affinity":
+		return "different host – violates affinity", true
+	case policy == "soft-affinity":
+		return "different host (soft-affinity not met)", true
+	}
+	return "different host", false
+}
+
+// resolveGroupMember looks up a member's name and host.
+func resolveGroupMember(cc client.ComputeClient, id string) groupMember {
+	gm := groupMember{ID: id, Name: id}
+	srv, err := cc.GetInstance(id)
+	if err == nil {
+		gm.Name = srv.Name
+		if len(srv.HostID) >= 8 {
+			gm.Host = "hostId:" + srv.HostID[:8]
+		}
+	}
+	if host, err := cc.GetServerHypervisor(context.Background(), id); err == nil && host != "" {
+		gm.Host = host
+	}
+	return gm
+}
+
+// renderServerGroups draws the groups serverID belongs to, with each sibling
+// instance and its host, flagging siblings that share the server's failure
+// domain. It returns "" when the server is in no group.
+func renderServerGroups(cc client.ComputeClient, serverID string) string {
+	groups, err := cc.ListServerGroups(context.Background())
+	if err != nil {
+		return ""
+	}
+	boxStyle := lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).Padding(0, 1)
+	groupStyle := boxStyle.BorderForeground(lipgloss.Color("#1ABC9C"))
+	siblingStyle := boxStyle.BorderForeground(theme.OK)
+	warnStyle := boxStyle.BorderForeground(theme.Error)
+
+	var sections []string
+	for _, g := range groups {
+		var memberIDs []string
+		isMember := false
+		for _, id := range g.Members {
+			if id == serverID {
+				isMember = true
+			} else {
+				memberIDs = append(memberIDs, id)
+			}
+		}
+		if !isMember {
+			continue
+		}
+		self := resolveGroupMember(cc, serverID)
+		groupBox := groupStyle.Render(i18n.T("Group: %s\nPolicy: %s\nThis server on: %s", g.Name, g.Policy, hostLabel(self.Host)))
+		if len(memberIDs) == 0 {
+			sections = append(sections, groupBox)
+			continue
+		}
+		siblings := make([]groupMember, len(memberIDs))
+		known := self.Host != ""
+		for i, id := range memberIDs {
+			siblings[i] = resolveGroupMember(cc, id)
+			known = known && siblings[i].Host != ""
+		}
+		var siblingBoxes []string
+		for _, sib := range siblings {
+			note, bad := placementNote(g.Policy, sib.Host == self.Host, known)
+			style := siblingStyle
+			if bad {
+				style = warnStyle
+			}
+			siblingBoxes = append(siblingBoxes, style.Render(fmt.Sprintf("%s\n@ %s\n%s", sib.Name, hostLabel(sib.Host), note)))
+		}
+		sections = append(sections, lipgloss.JoinHorizontal(lipgloss.Center, groupBox, " ── ", lipgloss.JoinVertical(lipgloss.Left, siblingBoxes...)))
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, sections...)
+}
+
+// hostLabel renders an unknown host explicitly.
+func hostLabel(host string) string {
+	if host == "" {
+		return "unknown host"
+	}
+	return host
+}
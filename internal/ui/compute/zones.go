@@ -7,6 +7,8 @@ import (
 	"github.com/charmbracelet/bubbles/table"
 	tea "github.com/charmbracelet/bubbletea"
 	"ostui/internal/client"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/common"
 	"ostui/internal/ui/uiconst"
 )
 
@@ -43,7 +45,7 @@ func (m ZonesModel) Init() tea.Cmd {
 			return zonesDataLoadedMsg{err: err}
 		}
 		// Define columns; Name will be resized dynamically.
-		cols := []table.Column{{Title: "Name", Width: uiconst.ColWidthName}, {Title: "Available", Width: uiconst.ColWidthType}}
+		cols := []table.Column{{Title: i18n.T("Name"), Width: uiconst.ColWidthName}, {Title: i18n.T("Available"), Width: uiconst.ColWidthType}}
 		rows := []table.Row{}
 		for _, z := range zones {
 			rows = append(rows, table.Row{z.ZoneName, fmt.Sprintf("%t", z.ZoneState.Available)})
@@ -59,6 +61,14 @@ func (m ZonesModel) Init() tea.Cmd {
 	}
 }
 
+// Reload re-fetches the availability zones, keeping the selected row.
+func (m ZonesModel) Reload() tea.Cmd {
+	if m.loading {
+		return nil
+	}
+	return m.Init()
+}
+
 // Update handles messages for the model.
 func (m ZonesModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
@@ -68,7 +78,7 @@ func (m ZonesModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.err = msg.err
 			return m, nil
 		}
-		m.table = msg.tbl
+		m.table = common.Reloaded(m.table, msg.tbl)
 		// Adjust columns and height based on current dimensions.
 		m.updateTableColumns()
 		m.table.SetHeight(m.height - 6)
@@ -105,7 +115,7 @@ func (m ZonesModel) View() string {
 		return m.spinner.View()
 	}
 	if m.err != nil {
-		return fmt.Sprintf("Error: %s", m.err)
+		return i18n.T("Error: %s", m.err)
 	}
 	return m.table.View()
 }
@@ -119,7 +129,7 @@ func (m *ZonesModel) updateTableColumns() {
 	if nameW < 10 {
 		nameW = 10
 	}
-	m.table.SetColumns([]table.Column{{Title: "Name", Width: nameW}, {Title: "Available", Width: availableW}})
+	m.table.SetColumns([]table.Column{{Title: i18n.T("Name"), Width: nameW}, {Title: i18n.T("Available"), Width: availableW}})
 }
 
 // Table returns the underlying table model.
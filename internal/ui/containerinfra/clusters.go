@@ -0,0 +1,506 @@
+// Package containerinfra implements the Container Infra (Magnum) views:
+// clusters with scaling and kubeconfig export, and the cluster detail with
+// its template.
+package containerinfra
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"ostui/internal/client"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/common"
+	"ostui/internal/ui/policy"
+	"ostui/internal/ui/theme"
+	"ostui/internal/ui/uiconst"
+)
+
+// Form kinds of the clusters view.
+const (
+	formScale      = "scale"
+	formKubeconfig = "kubeconfig"
+)
+
+// ClustersModel lists Magnum clusters with their status and node counts.
+type ClustersModel struct {
+	table    table.Model
+	loading  bool
+	err      error
+	spinner  spinner.Model
+	client   client.ContainerInfraClient
+	clusters []client.Cluster
+
+	form      *common.FormModel
+	formKind  string
+	clusterID string
+	status    string
+	statusErr bool
+
+	width  int
+	height int
+}
+
+// NewClustersModel creates the clusters view.
+func NewClustersModel(cc client.ContainerInfraClient) ClustersModel {
+	s := spinner.New()
+	s.Spinner = spinner.Dot
+	return ClustersModel{client: cc, loading: true, spinner: s, width: 120, height: 30}
+}
+
+type clustersLoadedMsg struct {
+	clusters []client.Cluster
+	err      error
+}
+
+type changeDoneMsg struct {
+	status string
+	// action names a failed change as checked with policy.Check.
+	action string
+	err    error
+}
+
+// Init loads the clusters.
+func (m ClustersModel) Init() tea.Cmd {
+	return tea.Batch(m.spinner.Tick, m.loadCmd())
+}
+
+// Reload re-fetches the clusters, keeping the selected row.
+func (m ClustersModel) Reload() tea.Cmd {
+	if m.loading {
+		return nil
+	}
+	return m.loadCmd()
+}
+
+func (m ClustersModel) loadCmd() tea.Cmd {
+	cc := m.client
+	return func() tea.Msg {
+		list, err := cc.ListClusters(context.Background())
+		if err != nil {
+			return clustersLoadedMsg{err: fmt.Errorf("%w (is the Container Infra service available?)", err)}
+		}
+		sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+		return clustersLoadedMsg{clusters: list}
+	}
+}
+
+// Update handles messages for the model.
+func (m ClustersModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case clustersLoadedMsg:
+		m.loading = false
+		m.err = msg.err
+		m.clusters = msg.clusters
+		if msg.err == nil {
+			prev := m.table
+			m.refreshTable()
+			m.table = common.Reloaded(prev, m.table)
+		}
+		return m, nil
+	case changeDoneMsg:
+		m.status, m.statusErr = msg.status, msg.err != nil
+		if msg.err != nil {
+			policy.Record(msg.action, msg.err)
+			m.status = msg.err.Error()
+			return m, nil
+		}
+		return m, m.loadCmd()
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		if !m.loading && m.err == nil {
+			m.refreshTable()
+		}
+		return m, nil
+	case tea.KeyMsg:
+		if m.form != nil {
+			return m.updateForm(msg)
+		}
+		if m.loading || m.err != nil {
+			return m, nil
+		}
+		switch msg.String() {
+		case "s":
+			if err := policy.Check(policy.Member, "scaling a cluster"); err != nil {
+				m.status, m.statusErr = err.Error(), true
+				return m, nil
+			}
+			if c, ok := m.Selected(); ok {
+				f := common.NewForm([]string{i18n.T("Node count")})
+				f.SetValue(0, strconv.Itoa(c.NodeCount))
+				m.form, m.formKind, m.clusterID = &f, formScale, c.UUID
+				return m, f.Init()
+			}
+			return m, nil
+		case "K":
+			if c, ok := m.Selected(); ok {
+				f := common.NewForm([]string{i18n.T("Write kubeconfig to")})
+				f.SetValue(0, defaultKubeconfigPath(c.Name))
+				m.form, m.formKind, m.clusterID = &f, formKubeconfig, c.UUID
+				return m, f.Init()
+			}
+			return m, nil
+		case "r":
+			m.loading = true
+			return m, tea.Batch(m.spinner.Tick, m.loadCmd())
+		}
+		var cmd tea.Cmd
+		m.table, cmd = m.table.Update(msg)
+		return m, cmd
+	default:
+		if m.loading {
+			var cmd tea.Cmd
+			m.spinner, cmd = m.spinner.Update(msg)
+			return m, cmd
+		}
+	}
+	return m, nil
+}
+
+// defaultKubeconfigPath returns ~/.kube/<cluster>.config.
+func defaultKubeconfigPath(name string) string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		home = "."
+	}
+	return filepath.Join(home, ".kube", name+".config")
+}
+
+// nodeCountFromForm validates the scale form value.
+func nodeCountFromForm(v string, current int) (int, error) {
+	n, err := strconv.Atoi(strings.TrimSpace(v))
+	if err != nil || n < 1 {
+		return 0, errors.New("node count must be a number of at least 1")
+	}
+	if n == current {
+		return 0, fmt.Errorf("the cluster already has %d nodes", n)
+	}
+	return n, nil
+}
+
+// writeKubeconfig fetches the credentials of a cluster and writes them to
+// path, readable by the owner only since it holds a client key.
+func writeKubeconfig(ctx context.Context, cc client.ContainerInfraClient, c client.Cluster, path string) error {
+	tpl, err := cc.GetClusterTemplate(ctx, c.ClusterTemplateID)
+	if err != nil {
+		return fmt.Errorf("failed to get the cluster template: %w", err)
+	}
+	if tpl.COE != "kubernetes" {
+		return fmt.Errorf("cluster %s runs %s, not kubernetes", c.Name, tpl.COE)
+	}
+	data, err := client.Kubeconfig(ctx, cc, c, tpl.TLSDisabled)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o600)
+}
+
+// updateForm handles keys for the scale and kubeconfig forms.
+func (m ClustersModel) updateForm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	fm, cmd := m.form.Update(msg)
+	f := fm.(common.FormModel)
+	m.form = &f
+	switch {
+	case f.Cancelled():
+		m.form = nil
+	case f.Submitted():
+		var cluster client.Cluster
+		for _, c := range m.clusters {
+			if c.UUID == m.clusterID {
+				cluster = c
+			}
+		}
+		cc := m.client
+		switch m.formKind {
+		case formScale:
+			n, err := nodeCountFromForm(f.Values()[0], cluster.NodeCount)
+			if err != nil {
+				m.form.SetError(err)
+				return m, nil
+			}
+			m.form = nil
+			return m, func() tea.Msg {
+				err := cc.ResizeCluster(context.Background(), cluster.UUID, n)
+				return changeDoneMsg{action: "scaling a cluster", status: i18n.T("Resizing %s to %d nodes", cluster.Name, n), err: err}
+			}
+		case formKubeconfig:
+			path := strings.TrimSpace(f.Values()[0])
+			if path == "" {
+				m.form.SetError(errors.New("path is required"))
+				return m, nil
+			}
+			m.form = nil
+			return m, func() tea.Msg {
+				err := writeKubeconfig(context.Background(), cc, cluster, path)
+				return changeDoneMsg{status: i18n.T("Wrote kubeconfig for %s to %s", cluster.Name, path), err: err}
+			}
+		}
+	}
+	return m, cmd
+}
+
+// CapturingInput reports whether a form is open.
+func (m ClustersModel) CapturingInput() bool { return m.form != nil }
+
+// Selected returns the selected cluster.
+func (m ClustersModel) Selected() (client.Cluster, bool) {
+	row := m.table.SelectedRow()
+	if len(row) == 0 {
+		return client.Cluster{}, false
+	}
+	for _, c := range m.clusters {
+		if c.UUID == row[0] {
+			return c, true
+		}
+	}
+	return client.Cluster{}, false
+}
+
+// refreshTable rebuilds the table from the loaded clusters.
+func (m *ClustersModel) refreshTable() {
+	idW, statusW := uiconst.ColWidthUUID, 18
+	rest := m.width - idW - statusW - uiconst.TableHeightOffset
+	if rest < 50 {
+		rest = 50
+	}
+	w := rest - 10 - 8 - 8 - 10
+	cols := []table.Column{{Title: "ID", Width: idW}, {Title: i18n.T("Name"), Width: w}, {Title: i18n.T("Status"), Width: statusW}, {Title: i18n.T("Health"), Width: 10}, {Title: i18n.T("Masters"), Width: 8}, {Title: i18n.T("Nodes"), Width: 8}, {Title: i18n.T("Version"), Width: 10}}
+	rows := make([]table.Row, 0, len(m.clusters))
+	for _, c := range m.clusters {
+		rows = append(rows, table.Row{c.UUID, c.Name, theme.Mark(c.Status), theme.Mark(orDash(c.HealthStatus)), strconv.Itoa(c.MasterCount), strconv.Itoa(c.NodeCount), orDash(c.COEVersion)})
+	}
+	m.table = table.New(table.WithColumns(cols), table.WithFocused(true))
+	m.table.SetStyles(table.DefaultStyles())
+	m.table.SetRows(rows)
+	m.table.SetHeight(m.height - uiconst.TableHeightOffset - 3)
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "–"
+	}
+	return s
+}
+
+// View renders the clusters table, the selected cluster status reason and
+// an open form.
+func (m ClustersModel) View() string {
+	if m.form != nil {
+		title := i18n.T("Scale cluster – changes the worker node count")
+		if m.formKind == formKubeconfig {
+			title = i18n.T("Kubeconfig – a new admin client certificate is signed by the cluster CA")
+		}
+		return title + "\n\n" + m.form.View() + "\n" + i18n.T("[enter] confirm  [esc] cancel")
+	}
+	if m.loading {
+		return m.spinner.View()
+	}
+	if m.err != nil {
+		return i18n.T("Error: %s", m.err)
+	}
+	dim := lipgloss.NewStyle().Foreground(theme.Muted)
+	out := m.table.View()
+	if c, ok := m.Selected(); ok && c.StatusReason != "" {
+		style := dim
+		if strings.HasSuffix(c.Status, "_FAILED") {
+			style = lipgloss.NewStyle().Foreground(theme.Error)
+		}
+		out += "\n" + style.Render(c.StatusReason)
+	}
+	switch {
+	case m.statusErr:
+		out += "\n" + lipgloss.NewStyle().Foreground(theme.Error).Render(m.status)
+	case m.status != "":
+		out += "\n" + lipgloss.NewStyle().Foreground(theme.OK).Render(m.status)
+	}
+	return out + "\n" + i18n.T("[enter] details") + "  " + policy.Key(policy.Member, i18n.T("[s] scale"), "scaling a cluster") + "  " + i18n.T("[K] kubeconfig  [r] refresh")
+}
+
+// Table returns the underlying table model.
+func (m ClustersModel) Table() table.Model { return m.table }
+
+// ClusterDetailModel shows one cluster with the settings of its template.
+type ClusterDetailModel struct {
+	table     table.Model
+	loading   bool
+	err       error
+	spinner   spinner.Model
+	client    client.ContainerInfraClient
+	clusterID string
+	status    string
+}
+
+// NewClusterDetailModel creates the detail view of a cluster.
+func NewClusterDetailModel(cc client.ContainerInfraClient, clusterID string) ClusterDetailModel {
+	s := spinner.New()
+	s.Spinner = spinner.Dot
+	return ClusterDetailModel{client: cc, loading: true, spinner: s, clusterID: clusterID}
+}
+
+// ResourceID returns the cluster ID.
+func (m ClusterDetailModel) ResourceID() string { return m.clusterID }
+
+type clusterLoadedMsg struct {
+	rows   []table.Row
+	status string
+	err    error
+}
+
+// Init loads the cluster and its template.
+func (m ClusterDetailModel) Init() tea.Cmd {
+	return tea.Batch(m.spinner.Tick, m.loadCmd())
+}
+
+// Reload re-fetches the cluster, keeping the selected field.
+func (m ClusterDetailModel) Reload() tea.Cmd {
+	if m.loading {
+		return nil
+	}
+	return m.loadCmd()
+}
+
+// loadCmd fetches the cluster and its template.
+func (m ClusterDetailModel) loadCmd() tea.Cmd {
+	cc, id := m.client, m.clusterID
+	return func() tea.Msg {
+		ctx := context.Background()
+		c, err := cc.GetCluster(ctx, id)
+		if err != nil {
+			return clusterLoadedMsg{err: err}
+		}
+		tpl, _ := cc.GetClusterTemplate(ctx, c.ClusterTemplateID)
+		return clusterLoadedMsg{rows: clusterRows(*c, tpl), status: c.Status}
+	}
+}
+
+// labelList renders labels as sorted key=value pairs.
+func labelList(labels map[string]string) string {
+	if len(labels) == 0 {
+		return "–"
+	}
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for i, k := range keys {
+		keys[i] = k + "=" + labels[k]
+	}
+	return strings.Join(keys, ", ")
+}
+
+// clusterRows lists the fields of a cluster followed by those of its
+// template; tpl is nil when the template could not be loaded.
+func clusterRows(c client.Cluster, tpl *client.ClusterTemplate) []table.Row {
+	rows := []table.Row{
+		{"ID", c.UUID},
+		{"Name", c.Name},
+		{"Status", c.Status},
+		{"Status reason", orDash(c.StatusReason)},
+		{"Health", orDash(c.HealthStatus)},
+		{"API address", orDash(c.APIAddress)},
+		{"COE version", orDash(c.COEVersion)},
+		{"Masters", fmt.Sprintf("%d × %s  %s", c.MasterCount, c.MasterFlavorID, strings.Join(c.MasterAddresses, ", "))},
+		{"Nodes", fmt.Sprintf("%d × %s  %s", c.NodeCount, c.FlavorID, strings.Join(c.NodeAddresses, ", "))},
+		{"Keypair", orDash(c.KeyPair)},
+		{"Stack", orDash(c.StackID)},
+		{"Created", c.CreatedAt.Format("2006-01-02 15:04")},
+	}
+	faults := make([]string, 0, len(c.Faults))
+	for k, v := range c.Faults {
+		faults = append(faults, k+": "+v)
+	}
+	sort.Strings(faults)
+	for _, f := range faults {
+		rows = append(rows, table.Row{i18n.T("Fault"), f})
+	}
+	if tpl == nil {
+		return append(rows, table.Row{i18n.T("Template"), i18n.T("%s (not available)", c.ClusterTemplateID)})
+	}
+	yesNo := map[bool]string{true: "yes", false: "no"}
+	return append(rows,
+		table.Row{i18n.T("Template"), tpl.Name + " (" + tpl.UUID + ")"},
+		table.Row{"COE", i18n.T("%s on %s", tpl.COE, orDash(tpl.ClusterDistro))},
+		table.Row{i18n.T("Image"), orDash(tpl.ImageID)},
+		table.Row{i18n.T("Network driver"), orDash(tpl.NetworkDriver)},
+		table.Row{i18n.T("Volume driver"), orDash(tpl.VolumeDriver)},
+		table.Row{i18n.T("Docker volume"), i18n.T("%d GB", tpl.DockerVolumeSize)},
+		table.Row{i18n.T("External network"), orDash(tpl.ExternalNetworkID)},
+		table.Row{i18n.T("Master LB"), yesNo[tpl.MasterLBEnabled]},
+		table.Row{i18n.T("Floating IPs"), yesNo[tpl.FloatingIPEnabled]},
+		table.Row{"TLS", map[bool]string{true: "disabled", false: "enabled"}[tpl.TLSDisabled]},
+		table.Row{i18n.T("Labels"), labelList(c.Labels)},
+	)
+}
+
+// Update handles messages for the model.
+func (m ClusterDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case clusterLoadedMsg:
+		m.loading = false
+		m.err = msg.err
+		if msg.err != nil {
+			return m, nil
+		}
+		m.status = msg.status
+		t := table.New(table.WithColumns([]table.Column{{Title: i18n.T("Field"), Width: uiconst.ColWidthField}, {Title: i18n.T("Value"), Width: uiconst.ColWidthValue}}), table.WithFocused(true))
+		t.SetStyles(table.DefaultStyles())
+		t.SetRows(msg.rows)
+		m.table = common.Reloaded(m.table, t)
+		m.table.SetHeight(len(msg.rows) + 1)
+		return m, nil
+	case tea.KeyMsg:
+		if m.loading || m.err != nil {
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.table, cmd = m.table.Update(msg)
+		return m, cmd
+	default:
+		if m.loading {
+			var cmd tea.Cmd
+			m.spinner, cmd = m.spinner.Update(msg)
+			return m, cmd
+		}
+	}
+	return m, nil
+}
+
+// View renders the cluster fields.
+func (m ClusterDetailModel) View() string {
+	if m.loading {
+		return m.spinner.View()
+	}
+	if m.err != nil {
+		return i18n.T("Error: %s", m.err)
+	}
+	color := theme.OK
+	switch {
+	case strings.HasSuffix(m.status, "_FAILED"):
+		color = theme.Error
+	case strings.HasSuffix(m.status, "_IN_PROGRESS"):
+		color = theme.Warn
+	}
+	status := lipgloss.NewStyle().Bold(true).Foreground(color).Render("● " + m.status)
+	return status + "\n" + m.table.View() + "\n" + i18n.T("[esc] back")
+}
+
+// Table returns the underlying table model.
+func (m ClusterDetailModel) Table() table.Model { return m.table }
+
+var (
+	_ tea.Model = (*ClustersModel)(nil)
+	_ tea.Model = (*ClusterDetailModel)(nil)
+)
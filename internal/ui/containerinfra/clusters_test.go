@@ -0,0 +1,152 @@
+package containerinfra
+
+import (
+	"context"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"gopkg.in/yaml.v2"
+	"ostui/internal/client"
+)
+
+type mockContainerInfraClient struct {
+	clusters []client.Cluster
+	template client.ClusterTemplate
+	resized  map[string]int
+	csr      string
+}
+
+func (m *mockContainerInfraClient) ListClusters(ctx context.Context) ([]client.Cluster, error) {
+	return m.clusters, nil
+}
+func (m *mockContainerInfraClient) GetCluster(ctx context.Context, id string) (*client.Cluster, error) {
+	for _, c := range m.clusters {
+		if c.UUID == id {
+			return &c, nil
+		}
+	}
+	return nil, os.ErrNotExist
+}
+func (m *mockContainerInfraClient) GetClusterTemplate(ctx context.Context, id string) (*client.ClusterTemplate, error) {
+	return &m.template, nil
+}
+func (m *mockContainerInfraClient) ResizeCluster(ctx context.Context, id string, nodeCount int) error {
+	if m.resized == nil {
+		m.resized = map[string]int{}
+	}
+	m.resized[id] = nodeCount
+	return nil
+}
+func (m *mockContainerInfraClient) GetClusterCA(ctx context.Context, clusterID string) (string, error) {
+	return "CA-PEM", nil
+}
+func (m *mockContainerInfraClient) SignClusterCSR(ctx context.Context, clusterID, csr string) (string, error) {
+	m.csr = csr
+	return "CERT-PEM", nil
+}
+
+func key(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+
+func newMock() *mockContainerInfraClient {
+	return &mockContainerInfraClient{
+		clusters: []client.Cluster{{UUID: "c-1", Name: "prod", Status: "CREATE_COMPLETE", MasterCount: 3, NodeCount: 5, ClusterTemplateID: "t-1", APIAddress: "https://10.0.0.5:6443"}},
+		template: client.ClusterTemplate{UUID: "t-1", Name: "k8s", COE: "kubernetes"},
+	}
+}
+
+func TestScaleCluster(t *testing.T) {
+	mock := newMock()
+	m := NewClustersModel(mock)
+	updated, _ := m.Update(m.loadCmd()())
+	m = updated.(ClustersModel)
+	updated, _ = m.Update(key("s"))
+	m = updated.(ClustersModel)
+	if !m.CapturingInput() {
+		t.Fatalf("expected the scale form")
+	}
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	m = updated.(ClustersModel)
+	updated, _ = m.Update(key("7"))
+	m = updated.(ClustersModel)
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatalf("expected a resize command")
+	}
+	cmd()
+	if mock.resized["c-1"] != 7 {
+		t.Fatalf("unexpected resizes %v", mock.resized)
+	}
+}
+
+func TestNodeCountFromForm(t *testing.T) {
+	if n, err := nodeCountFromForm(" 3 ", 5); err != nil || n != 3 {
+		t.Errorf("got %d, %v", n, err)
+	}
+	for _, v := range []string{"", "0", "x", "5"} {
+		if _, err := nodeCountFromForm(v, 5); err == nil {
+			t.Errorf("expected an error for %q", v)
+		}
+	}
+}
+
+func TestWriteKubeconfig(t *testing.T) {
+	mock := newMock()
+	path := filepath.Join(t.TempDir(), "kube", "prod.config")
+	if err := writeKubeconfig(context.Background(), mock, mock.clusters[0], path); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(mock.csr, "CERTIFICATE REQUEST") {
+		t.Errorf("expected a PEM certificate request, got %q", mock.csr)
+	}
+	info, err := os.Stat(path)
+	if err != nil || info.Mode().Perm() != 0o600 {
+		t.Fatalf("expected an owner-only file, got %v %v", info, err)
+	}
+	data, _ := os.ReadFile(path)
+	var cfg struct {
+		Clusters []struct {
+			Cluster struct {
+				Server string `yaml:"server"`
+				CA     string `yaml:"certificate-authority-data"`
+			} `yaml:"cluster"`
+		} `yaml:"clusters"`
+		Users []struct {
+			User struct {
+				Cert string `yaml:"client-certificate-data"`
+				Key  string `yaml:"client-key-data"`
+			} `yaml:"user"`
+		} `yaml:"users"`
+		CurrentContext string `yaml:"current-context"`
+	}
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		t.Fatalf("invalid kubeconfig: %v", err)
+	}
+	ca, _ := base64.StdEncoding.DecodeString(cfg.Clusters[0].Cluster.CA)
+	cert, _ := base64.StdEncoding.DecodeString(cfg.Users[0].User.Cert)
+	keyPEM, _ := base64.StdEncoding.DecodeString(cfg.Users[0].User.Key)
+	if cfg.Clusters[0].Cluster.Server != "https://10.0.0.5:6443" || string(ca) != "CA-PEM" || string(cert) != "CERT-PEM" || !strings.Contains(string(keyPEM), "PRIVATE KEY") || cfg.CurrentContext != "prod" {
+		t.Errorf("unexpected kubeconfig:\n%s", data)
+	}
+
+	mock.template.COE = "swarm"
+	if err := writeKubeconfig(context.Background(), mock, mock.clusters[0], path); err == nil {
+		t.Errorf("expected an error for a non-kubernetes cluster")
+	}
+}
+
+func TestClusterRowsShowFaults(t *testing.T) {
+	c := client.Cluster{UUID: "c-2", Name: "gpu", Status: "CREATE_FAILED", ClusterTemplateID: "t-9", Faults: map[string]string{"kube_minions": "No valid host was found"}}
+	rows := clusterRows(c, nil)
+	var fault, tpl bool
+	for _, r := range rows {
+		fault = fault || (r[0] == "Fault" && strings.Contains(r[1], "No valid host"))
+		tpl = tpl || (r[0] == "Template" && strings.Contains(r[1], "not available"))
+	}
+	if !fault || !tpl {
+		t.Errorf("unexpected rows %v", rows)
+	}
+}
@@ -5,14 +5,26 @@ import (
 	"fmt"
 	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"ostui/internal/client"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/common"
 	"ostui/internal/ui/uiconst"
 	"strings"
 )
 
-// RecordSetsModel displays DNS record sets for a specific zone.
+// recordSetPageSize is the number of record sets fetched per page; zones
+// can hold tens of thousands, so they are paged on the server.
+const recordSetPageSize = 200
+
+// recordTypes are the record set types the search box recognises as a
+// type filter rather than a name.
+var recordTypes = map[string]bool{"A": true, "AAAA": true, "CAA": true, "CNAME": true, "MX": true, "NAPTR": true, "NS": true, "PTR": true, "SOA": true, "SPF": true, "SRV": true, "SSHFP": true, "TXT": true}
+
+// RecordSetsModel displays DNS record sets for a specific zone, one server
+// page at a time.
 type RecordSetsModel struct {
 	table    table.Model
 	loading  bool
@@ -23,6 +35,16 @@ type RecordSetsModel struct {
 	zoneName string
 	// stored recordsets for inspect view
 	recordsets []client.RecordSet
+	// query holds the Designate name and type filters of the search box.
+	query client.RecordSetQuery
+	// markers are the markers of the pages visited, markers[page] being the
+	// current one; the first page has none.
+	markers    []string
+	page       int
+	nextMarker string
+	total      int
+	searchMode bool
+	search     textinput.Model
 	// Inspect view fields
 	inspectView     string
 	inspectViewport viewport.Model
@@ -32,25 +54,56 @@ type RecordSetsModel struct {
 func NewRecordSetsModel(dc client.DNSClient, zoneID string, zoneName string) RecordSetsModel {
 	s := spinner.New()
 	s.Spinner = spinner.Dot
-	return RecordSetsModel{client: dc, loading: true, spinner: s, zoneID: zoneID, zoneName: zoneName}
+	ti := textinput.New()
+	ti.Placeholder = "name, type or both, e.g. www A"
+	return RecordSetsModel{client: dc, loading: true, spinner: s, zoneID: zoneID, zoneName: zoneName, markers: []string{""}, search: ti}
 }
 
 type recordSetsDataLoadedMsg struct {
 	tbl        table.Model
 	err        error
 	recordsets []client.RecordSet
+	total      int
+	next       string
+}
+
+// parseRecordQuery reads the search box: a record type such as MX filters
+// on the type, anything else on the name. A name without "*" matches
+// anywhere in the record set name.
+func parseRecordQuery(s string) client.RecordSetQuery {
+	var q client.RecordSetQuery
+	for _, word := range strings.Fields(s) {
+		if recordTypes[strings.ToUpper(word)] && q.Type == "" {
+			q.Type = strings.ToUpper(word)
+			continue
+		}
+		if q.Name == "" {
+			q.Name = word
+		}
+	}
+	if q.Name != "" && !strings.Contains(q.Name, "*") {
+		q.Name = "*" + q.Name + "*"
+	}
+	return q
 }
 
-// Init starts async loading of record sets for the zone.
+// Init starts async loading of the first page of record sets.
 func (m RecordSetsModel) Init() tea.Cmd {
+	return m.fetch(m.markers[m.page])
+}
+
+// fetch loads the page of record sets starting after marker.
+func (m RecordSetsModel) fetch(marker string) tea.Cmd {
+	dc, zoneID, q := m.client, m.zoneID, m.query
+	q.Marker, q.Limit = marker, recordSetPageSize
 	return func() tea.Msg {
-		rs, err := m.client.ListRecordSets(context.Background(), m.zoneID)
+		page, err := dc.ListRecordSetPage(context.Background(), zoneID, q)
 		if err != nil {
 			return recordSetsDataLoadedMsg{err: err}
 		}
-		cols := []table.Column{{Title: "Name", Width: uiconst.ColWidthNameDNS}, {Title: "Type", Width: uiconst.ColWidthType}, {Title: "TTL", Width: uiconst.ColWidthTTL}, {Title: "Status", Width: uiconst.ColWidthStatus}, {Title: "Records", Width: uiconst.ColWidthRecords}}
+		cols := []table.Column{{Title: i18n.T("Name"), Width: uiconst.ColWidthNameDNS}, {Title: i18n.T("Type"), Width: uiconst.ColWidthType}, {Title: "TTL", Width: uiconst.ColWidthTTL}, {Title: i18n.T("Status"), Width: uiconst.ColWidthStatus}, {Title: i18n.T("Records"), Width: uiconst.ColWidthRecords}}
 		rows := []table.Row{}
-		for _, r := range rs {
+		for _, r := range page.RecordSets {
 			records := strings.Join(r.Records, ",")
 			rows = append(rows, table.Row{r.Name, r.Type, fmt.Sprintf("%d", r.TTL), r.Status, records})
 		}
@@ -60,10 +113,21 @@ func (m RecordSetsModel) Init() tea.Cmd {
 			table.WithFocused(true),
 		)
 		t.SetStyles(table.DefaultStyles())
-		return recordSetsDataLoadedMsg{tbl: t, recordsets: rs}
+		return recordSetsDataLoadedMsg{tbl: t, recordsets: page.RecordSets, total: page.Total, next: page.NextMarker}
 	}
 }
 
+// Reload re-fetches the current page, keeping the selected row.
+func (m RecordSetsModel) Reload() tea.Cmd {
+	if m.loading || m.searchMode {
+		return nil
+	}
+	return m.Init()
+}
+
+// CapturingInput reports whether keys are typed into the search box.
+func (m RecordSetsModel) CapturingInput() bool { return m.searchMode }
+
 // Update handles messages and user input.
 func (m RecordSetsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
@@ -73,8 +137,10 @@ func (m RecordSetsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.err = msg.err
 			return m, nil
 		}
-		m.table = msg.tbl
+		m.err = nil
+		m.table = common.Reloaded(m.table, msg.tbl)
 		m.recordsets = msg.recordsets
+		m.total, m.nextMarker = msg.total, msg.next
 		return m, nil
 	case tea.WindowSizeMsg:
 		// Adjust table width to fill terminal.
@@ -110,7 +176,51 @@ func (m RecordSetsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.inspectViewport, cmd = m.inspectViewport.Update(msg)
 			return m, cmd
 		}
-		if m.loading || m.err != nil {
+		if m.searchMode {
+			switch msg.String() {
+			case "esc":
+				m.searchMode = false
+				m.search.Blur()
+				return m, nil
+			case "enter":
+				// The filters apply on the server, from the first page.
+				m.searchMode = false
+				m.search.Blur()
+				m.query = parseRecordQuery(m.search.Value())
+				m.markers, m.page = []string{""}, 0
+				m.table, m.loading = table.Model{}, true
+				return m, tea.Batch(m.spinner.Tick, m.Init())
+			}
+			var cmd tea.Cmd
+			m.search, cmd = m.search.Update(msg)
+			return m, cmd
+		}
+		if m.loading {
+			return m, nil
+		}
+		switch msg.String() {
+		case "/":
+			m.searchMode = true
+			m.search.Focus()
+			return m, textinput.Blink
+		case "]":
+			if m.nextMarker == "" {
+				return m, nil
+			}
+			m.markers = append(m.markers[:m.page+1], m.nextMarker)
+			m.page++
+			// Another page starts at its top rather than keeping the row.
+			m.table, m.loading = table.Model{}, true
+			return m, tea.Batch(m.spinner.Tick, m.Init())
+		case "[":
+			if m.page == 0 {
+				return m, nil
+			}
+			m.page--
+			m.table, m.loading = table.Model{}, true
+			return m, tea.Batch(m.spinner.Tick, m.Init())
+		}
+		if m.err != nil {
 			return m, nil
 		}
 		if msg.String() == "i" {
@@ -119,11 +229,10 @@ func (m RecordSetsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if len(row) == 0 {
 				return m, nil
 			}
-			// Find the record set by name (first column).
-			name := row[0]
+			// Find the record set by name and type (first two columns).
 			var rs *client.RecordSet
 			for _, r := range m.recordsets {
-				if r.Name == name {
+				if r.Name == row[0] && r.Type == row[1] {
 					rs = &r
 					break
 				}
@@ -131,7 +240,7 @@ func (m RecordSetsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if rs == nil {
 				return m, nil
 			}
-			content := fmt.Sprintf("=== RecordSet: %s ===\nID: %s\nName: %s\nType: %s\nTTL: %d\nStatus: %s\nRecords: %s", rs.Name, rs.ID, rs.Name, rs.Type, rs.TTL, rs.Status, strings.Join(rs.Records, ", "))
+			content := i18n.T("=== RecordSet: %s ===\nID: %s\nName: %s\nType: %s\nTTL: %d\nStatus: %s\nRecords: %s", rs.Name, rs.ID, rs.Name, rs.Type, rs.TTL, rs.Status, strings.Join(rs.Records, ", "))
 			m.inspectView = content
 			m.inspectViewport = viewport.New(80, 24)
 			m.inspectViewport.SetContent(m.inspectView)
@@ -150,19 +259,57 @@ func (m RecordSetsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// header counts the record sets matching the search and locates the page,
+// e.g. "example.org. · 30002 record sets · page 2 of 151".
+func (m RecordSetsModel) header() string {
+	parts := []string{m.zoneName}
+	noun := "record sets"
+	if m.query.Name != "" || m.query.Type != "" {
+		noun = "matching record sets"
+	}
+	if m.total > 0 || m.nextMarker == "" {
+		parts = append(parts, fmt.Sprintf("%d %s", m.total, noun))
+	}
+	pages := (m.total + recordSetPageSize - 1) / recordSetPageSize
+	switch {
+	case pages > 1:
+		parts = append(parts, i18n.T("page %d of %d", m.page+1, pages))
+	case m.page > 0 || m.nextMarker != "":
+		parts = append(parts, i18n.T("page %d", m.page+1))
+	}
+	var filters []string
+	if m.query.Type != "" {
+		filters = append(filters, "type "+m.query.Type)
+	}
+	if m.query.Name != "" {
+		filters = append(filters, "name "+m.query.Name)
+	}
+	if len(filters) > 0 {
+		parts = append(parts, "filter: "+strings.Join(filters, ", "))
+	}
+	return strings.Join(parts, " · ")
+}
+
 // View renders the record sets view.
 func (m RecordSetsModel) View() string {
 	if m.loading {
 		return m.spinner.View()
 	}
 	if m.inspectView != "" {
-		return fmt.Sprintf("%s\n %3.f%% | [j/k] scroll  [esc] close", m.inspectViewport.View(), m.inspectViewport.ScrollPercent()*100)
+		return i18n.T("%s\n %3.f%% | [j/k] scroll  [esc] close", m.inspectViewport.View(), m.inspectViewport.ScrollPercent()*100)
+	}
+	if m.searchMode {
+		return i18n.T("Search: %s\n%s\n[enter] search on the server  [esc] cancel", m.search.View(), m.table.View())
 	}
 	if m.err != nil {
-		return fmt.Sprintf("Error: %s", m.err)
+		return i18n.T("Error: %s\n[/] search  [esc] back", m.err)
+	}
+	hint := i18n.T("[i] inspect  [/] search")
+	if m.page > 0 || m.nextMarker != "" {
+		hint += "  '[' / ']' previous / next page"
 	}
 	// Show table with a hint for inspect and back.
-	return fmt.Sprintf("%s\n[i] inspect  [esc] back", m.table.View())
+	return i18n.T("%s\n%s\n%s  [esc] back", m.header(), m.table.View(), hint)
 }
 
 var _ tea.Model = (*RecordSetsModel)(nil)
@@ -0,0 +1,79 @@
+package dns
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"ostui/internal/client"
+	"ostui/internal/demo"
+)
+
+func TestParseRecordQuery(t *testing.T) {
+	cases := map[string]client.RecordSetQuery{
+		"":             {},
+		"www":          {Name: "*www*"},
+		"mx":           {Type: "MX"},
+		"www A":        {Name: "*www*", Type: "A"},
+		"mail*.org. a": {Name: "mail*.org.", Type: "A"},
+	}
+	for in, want := range cases {
+		if got := parseRecordQuery(in); got != want {
+			t.Errorf("parseRecordQuery(%q) = %+v, want %+v", in, got, want)
+		}
+	}
+}
+
+// load runs the pending fetch of m and applies its result.
+func load(m RecordSetsModel) RecordSetsModel {
+	updated, _ := m.Update(m.fetch(m.markers[m.page])())
+	return updated.(RecordSetsModel)
+}
+
+func press(m RecordSetsModel, msg tea.KeyMsg) RecordSetsModel {
+	updated, _ := m.Update(msg)
+	return updated.(RecordSetsModel)
+}
+
+func TestRecordSetsPagingAndSearch(t *testing.T) {
+	dc := demo.New(1, demo.DefaultSize).DNS()
+	zones, _ := dc.ListZones(context.Background())
+	var zone client.Zone
+	for _, z := range zones {
+		if z.Name == "hosts.example.net." {
+			zone = z
+		}
+	}
+	if zone.ID == "" {
+		t.Fatal("expected the demo's large zone")
+	}
+
+	m := load(NewRecordSetsModel(dc, zone.ID, zone.Name))
+	if len(m.recordsets) != recordSetPageSize || !strings.Contains(m.header(), "page 1 of ") {
+		t.Fatalf("expected a first page of %d, got %d: %s", recordSetPageSize, len(m.recordsets), m.header())
+	}
+	first := m.recordsets[0].ID
+
+	m = load(press(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("]")}))
+	if m.page != 1 || m.recordsets[0].ID == first || !strings.Contains(m.header(), "page 2 of ") {
+		t.Fatalf("expected the second page, got %s", m.header())
+	}
+	m = load(press(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("[")}))
+	if m.page != 0 || m.recordsets[0].ID != first {
+		t.Fatalf("expected to be back on the first page, got %s", m.header())
+	}
+
+	m = press(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+	if !m.CapturingInput() {
+		t.Fatal("expected the search box to take the keys")
+	}
+	m = press(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("host-00012 txt")})
+	m = load(press(m, tea.KeyMsg{Type: tea.KeyEnter}))
+	if m.total != 1 || len(m.recordsets) != 1 || m.recordsets[0].Type != "TXT" {
+		t.Fatalf("expected one TXT record set, got %d: %+v", m.total, m.recordsets)
+	}
+	if h := m.header(); !strings.Contains(h, "1 matching record sets") || !strings.Contains(h, "type TXT") {
+		t.Errorf("unexpected header %q", h)
+	}
+}
@@ -0,0 +1,433 @@
+package dns
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"ostui/internal/client"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/common"
+	"ostui/internal/ui/policy"
+	"ostui/internal/ui/theme"
+	"ostui/internal/ui/uiconst"
+)
+
+// serverAddress is an address of a server: a fixed IP of one of its ports
+// or a floating IP associated with one.
+type serverAddress struct {
+	IP       string
+	Floating bool
+}
+
+// zonedRecordSet is a record set with the zone it belongs to.
+type zonedRecordSet struct {
+	Zone client.Zone
+	client.RecordSet
+}
+
+// Fields of the record form.
+const (
+	recordFieldZone = iota
+	recordFieldName
+	recordFieldAddress
+	recordFieldTTL
+)
+
+// ServerRecordsModel lists the record sets resolving to the addresses of a
+// server and creates, or updates, the A or AAAA record pointing a name at
+// one of them.
+type ServerRecordsModel struct {
+	table      table.Model
+	loading    bool
+	err        error
+	spinner    spinner.Model
+	client     client.DNSClient
+	network    client.NetworkClient
+	serverID   string
+	serverName string
+
+	addrs   []serverAddress
+	zones   []client.Zone
+	records []zonedRecordSet
+
+	form      *common.FormModel
+	status    string
+	statusErr bool
+
+	width  int
+	height int
+}
+
+// NewServerRecordsModel creates the DNS view of a server.
+func NewServerRecordsModel(dc client.DNSClient, nc client.NetworkClient, serverID, serverName string) ServerRecordsModel {
+	s := spinner.New()
+	s.Spinner = spinner.Dot
+	return ServerRecordsModel{client: dc, network: nc, serverID: serverID, serverName: serverName, loading: true, spinner: s, width: 120, height: 30}
+}
+
+type serverRecordsLoadedMsg struct {
+	addrs   []serverAddress
+	zones   []client.Zone
+	records []zonedRecordSet
+	err     error
+}
+
+type serverRecordDoneMsg struct {
+	status string
+	err    error
+}
+
+// Init loads the addresses of the server and the records resolving to them.
+func (m ServerRecordsModel) Init() tea.Cmd {
+	return tea.Batch(m.spinner.Tick, m.loadCmd())
+}
+
+// loadCmd reads the fixed IPs of the server ports and the floating IPs
+// associated with them, then asks every zone for the record sets holding
+// one of them; Designate filters on the record data.
+func (m ServerRecordsModel) loadCmd() tea.Cmd {
+	dc, nc, id := m.client, m.network, m.serverID
+	return func() tea.Msg {
+		ctx := context.Background()
+		ports, err := nc.ListPortsByServer(ctx, id)
+		if err != nil {
+			return serverRecordsLoadedMsg{err: err}
+		}
+		fips, err := nc.ListFloatingIPs()
+		if err != nil {
+			return serverRecordsLoadedMsg{err: err}
+		}
+		var addrs []serverAddress
+		for _, p := range ports {
+			for _, f := range fips {
+				if f.PortID == p.ID && f.FloatingIP != "" {
+					addrs = append(addrs, serverAddress{IP: f.FloatingIP, Floating: true})
+				}
+			}
+		}
+		for _, p := range ports {
+			for _, ip := range p.FixedIPs {
+				addrs = append(addrs, serverAddress{IP: ip.IPAddress})
+			}
+		}
+		zones, err := dc.ListZones(ctx)
+		if err != nil {
+			return serverRecordsLoadedMsg{err: err}
+		}
+		var records []zonedRecordSet
+		seen := map[string]bool{}
+		for _, z := range zones {
+			for _, a := range addrs {
+				page, err := dc.ListRecordSetPage(ctx, z.ID, client.RecordSetQuery{Data: a.IP, Limit: recordSetPageSize})
+				if err != nil {
+					return serverRecordsLoadedMsg{err: err}
+				}
+				for _, rs := range page.RecordSets {
+					if !seen[rs.ID] {
+						seen[rs.ID] = true
+						records = append(records, zonedRecordSet{Zone: z, RecordSet: rs})
+					}
+				}
+			}
+		}
+		return serverRecordsLoadedMsg{addrs: addrs, zones: zones, records: records}
+	}
+}
+
+// Reload re-reads the addresses and records.
+func (m ServerRecordsModel) Reload() tea.Cmd {
+	if m.loading || m.form != nil {
+		return nil
+	}
+	return m.loadCmd()
+}
+
+// CapturingInput reports whether the record form is open.
+func (m ServerRecordsModel) CapturingInput() bool { return m.form != nil }
+
+// Update handles messages for the model.
+func (m ServerRecordsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case serverRecordsLoadedMsg:
+		m.loading = false
+		m.err = msg.err
+		if msg.err == nil {
+			m.addrs, m.zones, m.records = msg.addrs, msg.zones, msg.records
+			m.refreshTable()
+		}
+		return m, nil
+	case serverRecordDoneMsg:
+		m.status, m.statusErr = msg.status, msg.err != nil
+		if msg.err != nil {
+			policy.Record("creating a DNS record", msg.err)
+			m.status = msg.err.Error()
+			return m, nil
+		}
+		return m, m.loadCmd()
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		if !m.loading && m.err == nil {
+			m.refreshTable()
+		}
+		return m, nil
+	case tea.KeyMsg:
+		if m.form != nil {
+			return m.updateForm(msg)
+		}
+		if m.loading || m.err != nil {
+			return m, nil
+		}
+		switch msg.String() {
+		case "n":
+			if err := policy.Check(policy.Member, "creating a DNS record"); err != nil {
+				m.status, m.statusErr = err.Error(), true
+				return m, nil
+			}
+			if len(m.zones) == 0 || len(m.addrs) == 0 {
+				m.status, m.statusErr = i18n.T("A record needs a zone of the project and an address of the server"), true
+				return m, nil
+			}
+			m.openForm()
+			return m, m.form.Init()
+		case "r":
+			m.loading = true
+			return m, tea.Batch(m.spinner.Tick, m.loadCmd())
+		}
+		var cmd tea.Cmd
+		m.table, cmd = m.table.Update(msg)
+		return m, cmd
+	default:
+		if m.loading {
+			var cmd tea.Cmd
+			m.spinner, cmd = m.spinner.Update(msg)
+			return m, cmd
+		}
+	}
+	return m, nil
+}
+
+// openForm opens the record form. It starts from the selected record when
+// one is, to repoint it, and otherwise from the server name in the first
+// zone, on the first floating IP, or fixed IP without one.
+func (m *ServerRecordsModel) openForm() {
+	f := common.NewForm([]string{i18n.T("Zone"), i18n.T("Name (relative to the zone, or ending with a dot)"), i18n.T("Address"), i18n.T("TTL (empty: the zone's)")})
+	f.SetValue(recordFieldZone, m.zones[0].Name)
+	f.SetValue(recordFieldName, hostLabel(m.serverName))
+	f.SetValue(recordFieldAddress, m.addrs[0].IP)
+	if rs, ok := m.selected(); ok {
+		f.SetValue(recordFieldZone, rs.Zone.Name)
+		f.SetValue(recordFieldName, rs.Name)
+	}
+	m.form = &f
+}
+
+// updateForm handles keys for the record form.
+func (m ServerRecordsModel) updateForm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	fm, cmd := m.form.Update(msg)
+	f := fm.(common.FormModel)
+	m.form = &f
+	switch {
+	case f.Cancelled():
+		m.form = nil
+		return m, nil
+	case !f.Submitted():
+		return m, cmd
+	}
+	plan, err := planRecord(m.zones, m.addrs, m.serverName, f.Values())
+	if err != nil {
+		m.form.SetError(err)
+		return m, nil
+	}
+	m.form = nil
+	m.status, m.statusErr = i18n.T("Pointing %s at %s…", plan.Name, plan.Address), false
+	return m, upsertRecordCmd(m.client, plan)
+}
+
+// recordPlan is the record set the form asks for.
+type recordPlan struct {
+	Zone    client.Zone
+	Name    string
+	Type    string
+	Address string
+	TTL     int
+}
+
+// hostLabel turns a server name into a DNS label: lower case, with the
+// characters a host name may not hold replaced by hyphens.
+func hostLabel(name string) string {
+	label := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '-':
+			return r
+		case r >= 'A' && r <= 'Z':
+			return r + 'a' - 'A'
+		}
+		return '-'
+	}, name)
+	return strings.Trim(label, "-")
+}
+
+// planRecord checks the form values: the zone must be one of the project,
+// the address one of the server, and the name in the zone. The type
+// follows the address family.
+func planRecord(zones []client.Zone, addrs []serverAddress, server string, v []string) (recordPlan, error) {
+	zoneName := strings.TrimSpace(v[recordFieldZone])
+	if !strings.HasSuffix(zoneName, ".") {
+		zoneName += "."
+	}
+	i := slices.IndexFunc(zones, func(z client.Zone) bool { return strings.EqualFold(z.Name, zoneName) })
+	if i < 0 {
+		return recordPlan{}, fmt.Errorf("no zone %s in the project", zoneName)
+	}
+	plan := recordPlan{Zone: zones[i], Address: strings.TrimSpace(v[recordFieldAddress])}
+	if !slices.ContainsFunc(addrs, func(a serverAddress) bool { return a.IP == plan.Address }) {
+		return recordPlan{}, fmt.Errorf("%s is not an address of server %s", plan.Address, server)
+	}
+	ip := net.ParseIP(plan.Address)
+	plan.Type = "AAAA"
+	if ip.To4() != nil {
+		plan.Type = "A"
+	}
+	name := strings.TrimSpace(v[recordFieldName])
+	switch {
+	case name == "":
+		return recordPlan{}, fmt.Errorf("the record needs a name")
+	case strings.HasSuffix(name, "."):
+		plan.Name = name
+	default:
+		plan.Name = name + "." + plan.Zone.Name
+	}
+	if plan.Name != plan.Zone.Name && !strings.HasSuffix(strings.ToLower(plan.Name), "."+strings.ToLower(plan.Zone.Name)) {
+		return recordPlan{}, fmt.Errorf("%s is not in zone %s", plan.Name, plan.Zone.Name)
+	}
+	if ttl := strings.TrimSpace(v[recordFieldTTL]); ttl != "" {
+		n, err := strconv.Atoi(ttl)
+		if err != nil || n <= 0 {
+			return recordPlan{}, fmt.Errorf("TTL %q is not a positive number of seconds", ttl)
+		}
+		plan.TTL = n
+	}
+	return plan, nil
+}
+
+// upsertRecordCmd points the record set of the plan at its address,
+// replacing the records of an existing one of the same name and type.
+func upsertRecordCmd(dc client.DNSClient, plan recordPlan) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		page, err := dc.ListRecordSetPage(ctx, plan.Zone.ID, client.RecordSetQuery{Name: plan.Name, Type: plan.Type, Limit: 1})
+		if err != nil {
+			return serverRecordDoneMsg{err: err}
+		}
+		if len(page.RecordSets) > 0 {
+			rs := page.RecordSets[0]
+			if _, err := dc.UpdateRecordSet(ctx, plan.Zone.ID, rs.ID, []string{plan.Address}, plan.TTL); err != nil {
+				return serverRecordDoneMsg{err: err}
+			}
+			return serverRecordDoneMsg{status: i18n.T("Updated %s %s: %s → %s", plan.Type, plan.Name, strings.Join(rs.Records, ", "), plan.Address)}
+		}
+		rs := client.RecordSet{Name: plan.Name, Type: plan.Type, TTL: plan.TTL, Records: []string{plan.Address}}
+		if _, err := dc.CreateRecordSet(ctx, plan.Zone.ID, rs); err != nil {
+			return serverRecordDoneMsg{err: err}
+		}
+		return serverRecordDoneMsg{status: i18n.T("Created %s %s → %s", plan.Type, plan.Name, plan.Address)}
+	}
+}
+
+// selected returns the record set of the selected row.
+func (m ServerRecordsModel) selected() (zonedRecordSet, bool) {
+	row := m.table.SelectedRow()
+	if len(row) == 0 {
+		return zonedRecordSet{}, false
+	}
+	for _, rs := range m.records {
+		if rs.ID == row[0] {
+			return rs, true
+		}
+	}
+	return zonedRecordSet{}, false
+}
+
+// refreshTable rebuilds the table of the records resolving to the server.
+func (m *ServerRecordsModel) refreshTable() {
+	idW, typeW, ttlW := uiconst.ColWidthUUID, 6, 6
+	rest := m.width - idW - typeW - ttlW - uiconst.TableHeightOffset
+	if rest < 60 {
+		rest = 60
+	}
+	zoneW, nameW := rest/4, rest*2/4
+	cols := []table.Column{{Title: "ID", Width: idW}, {Title: i18n.T("Zone"), Width: zoneW}, {Title: i18n.T("Name"), Width: nameW}, {Title: i18n.T("Type"), Width: typeW}, {Title: "TTL", Width: ttlW}, {Title: i18n.T("Records"), Width: rest - zoneW - nameW}}
+	var rows []table.Row
+	for _, rs := range m.records {
+		rows = append(rows, table.Row{rs.ID, rs.Zone.Name, rs.Name, rs.Type, strconv.Itoa(rs.TTL), strings.Join(rs.Records, ", ")})
+	}
+	cursor := m.table.Cursor()
+	m.table = table.New(table.WithColumns(cols), table.WithFocused(true))
+	m.table.SetStyles(table.DefaultStyles())
+	m.table.SetRows(rows)
+	m.table.SetHeight(m.height - uiconst.TableHeightOffset - 6)
+	if cursor < len(rows) {
+		m.table.SetCursor(cursor)
+	}
+}
+
+// addressLine lists the addresses of the server, floating IPs first.
+func (m ServerRecordsModel) addressLine() string {
+	if len(m.addrs) == 0 {
+		return i18n.T("No addresses: the server has no ports")
+	}
+	var parts []string
+	for _, a := range m.addrs {
+		kind := "fixed"
+		if a.Floating {
+			kind = "floating"
+		}
+		parts = append(parts, a.IP+" ("+kind+")")
+	}
+	return "Addresses: " + strings.Join(parts, ", ")
+}
+
+// View renders the records resolving to the server and the record form.
+func (m ServerRecordsModel) View() string {
+	if m.form != nil {
+		var zones []string
+		for _, z := range m.zones {
+			zones = append(zones, z.Name)
+		}
+		return i18n.T("DNS record for server %s – an existing record set of the name and type is repointed\nZones: %s\n%s", m.serverName, strings.Join(zones, ", "), m.addressLine()) + "\n\n" +
+			m.form.View() + "\n" + i18n.T("[tab] next field  [enter] next/submit  [esc] cancel")
+	}
+	if m.loading {
+		return m.spinner.View()
+	}
+	if m.err != nil {
+		return i18n.T("Error: %s", m.err)
+	}
+	dim := lipgloss.NewStyle().Foreground(theme.Muted)
+	out := "DNS records of server " + m.serverName + "\n" + dim.Render(m.addressLine()) + "\n"
+	if len(m.records) == 0 {
+		out += dim.Render(i18n.T("No record set of the project's zones resolves to these addresses."))
+	} else {
+		out += m.table.View()
+	}
+	switch {
+	case m.statusErr:
+		out += "\n" + lipgloss.NewStyle().Foreground(theme.Error).Render(m.status)
+	case m.status != "":
+		out += "\n" + lipgloss.NewStyle().Foreground(theme.OK).Render(m.status)
+	}
+	return out + "\n" + policy.Key(policy.Member, i18n.T("[n] create / repoint record"), "creating a DNS record") + "  " + i18n.T("[r] refresh  [esc] back")
+}
+
+// Table returns the records table.
+func (m ServerRecordsModel) Table() table.Model { return m.table }
+
+var _ tea.Model = (*ServerRecordsModel)(nil)
@@ -0,0 +1,117 @@
+package dns
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"ostui/internal/client"
+	"ostui/internal/demo"
+	"ostui/internal/ui/policy"
+)
+
+func TestServerRecordsCreateThenRepoint(t *testing.T) {
+	policy.SetRoles(nil)
+	c := demo.New(1, demo.DefaultSize)
+	dc, nc := c.DNS(), c.Network()
+	ctx := context.Background()
+
+	// A server with a floating IP.
+	fips, _ := nc.ListFloatingIPs()
+	var serverID string
+	for _, f := range fips {
+		if f.PortID == "" {
+			continue
+		}
+		if p, err := nc.GetPort(ctx, f.PortID); err == nil && strings.HasPrefix(p.DeviceOwner, "compute:") {
+			serverID = p.DeviceID
+			break
+		}
+	}
+	if serverID == "" {
+		t.Fatal("expected a server with a floating IP in the demo cloud")
+	}
+
+	m := NewServerRecordsModel(dc, nc, serverID, "Web_01")
+	updated, _ := m.Update(m.loadCmd()())
+	m = updated.(ServerRecordsModel)
+	if len(m.addrs) < 2 || !m.addrs[0].Floating || m.addrs[len(m.addrs)-1].Floating {
+		t.Fatalf("expected the floating IP first, then the fixed one, got %+v", m.addrs)
+	}
+	floating, fixed := m.addrs[0].IP, m.addrs[len(m.addrs)-1].IP
+
+	m, cmd := pressKey(m, "n")
+	if m.form == nil || cmd == nil {
+		t.Fatal("expected the record form")
+	}
+	if got := m.form.Values(); got[recordFieldName] != "web-01" || got[recordFieldAddress] != floating {
+		t.Fatalf("expected the form filled from the server, got %v", got)
+	}
+	m = submit(t, m)
+	if !strings.Contains(m.status, "Created A web-01."+m.zones[0].Name) {
+		t.Fatalf("expected the record created, got %q", m.status)
+	}
+	if len(m.records) == 0 {
+		t.Fatal("expected the new record listed as resolving to the server")
+	}
+
+	// The same name on the fixed IP repoints the record set.
+	m, _ = pressKey(m, "n")
+	m.form.SetValue(recordFieldZone, m.zones[0].Name)
+	m.form.SetValue(recordFieldName, "web-01")
+	m.form.SetValue(recordFieldAddress, fixed)
+	m = submit(t, m)
+	if !strings.Contains(m.status, "Updated A web-01.") || !strings.Contains(m.status, floating+" → "+fixed) {
+		t.Fatalf("expected the record repointed, got %q", m.status)
+	}
+	page, _ := dc.ListRecordSetPage(ctx, m.zones[0].ID, client.RecordSetQuery{Name: "web-01." + m.zones[0].Name})
+	if page.Total != 1 || page.RecordSets[0].Records[0] != fixed {
+		t.Fatalf("expected one record set on the fixed IP, got %+v", page)
+	}
+}
+
+func TestPlanRecord(t *testing.T) {
+	zones := []client.Zone{{ID: "z-1", Name: "example.org."}}
+	addrs := []serverAddress{{IP: "203.0.113.5", Floating: true}, {IP: "2001:db8::5"}}
+	plan, err := planRecord(zones, addrs, "web", []string{"example.org", "www", "2001:db8::5", "60"})
+	if err != nil || plan.Name != "www.example.org." || plan.Type != "AAAA" || plan.TTL != 60 {
+		t.Fatalf("unexpected plan %+v, %v", plan, err)
+	}
+	for _, v := range [][]string{
+		{"other.org.", "www", "203.0.113.5", ""},
+		{"example.org.", "www", "198.51.100.1", ""},
+		{"example.org.", "www.other.org.", "203.0.113.5", ""},
+		{"example.org.", "www", "203.0.113.5", "soon"},
+	} {
+		if _, err := planRecord(zones, addrs, "web", v); err == nil {
+			t.Errorf("expected %v refused", v)
+		}
+	}
+}
+
+func pressKey(m ServerRecordsModel, k string) (ServerRecordsModel, tea.Cmd) {
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)})
+	return updated.(ServerRecordsModel), cmd
+}
+
+// submit walks the record form to its last field, sends it and applies the
+// outcome and the reload.
+func submit(t *testing.T, m ServerRecordsModel) ServerRecordsModel {
+	t.Helper()
+	var cmd tea.Cmd
+	for i := 0; m.form != nil; i++ {
+		if i > recordFieldTTL {
+			t.Fatalf("form refused:\n%s", m.View())
+		}
+		updated, c := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		m, cmd = updated.(ServerRecordsModel), c
+	}
+	updated, cmd := m.Update(cmd())
+	m = updated.(ServerRecordsModel)
+	if cmd != nil {
+		updated, _ = m.Update(cmd())
+		m = updated.(ServerRecordsModel)
+	}
+	return m
+}
@@ -0,0 +1,422 @@
+package dns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"ostui/internal/client"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/common"
+	"ostui/internal/ui/policy"
+	"ostui/internal/ui/theme"
+	"ostui/internal/ui/uiconst"
+)
+
+// A zone transfer moves a zone to another project in two steps: the owner
+// creates a transfer request, which yields an ID and a key, and the
+// receiving project accepts it with both, possibly from another cloud
+// entry of the same Designate. The transfers view walks through both.
+
+// OpenZoneTransfersMsg asks the app to open the zone transfers view. With a
+// zone set, the view starts with the form offering that zone.
+type OpenZoneTransfersMsg struct {
+	ZoneID   string
+	ZoneName string
+}
+
+// Forms of the transfers view.
+const (
+	transferFormCreate = "create"
+	transferFormAccept = "accept"
+)
+
+// ZoneTransfersModel lists the zone transfer requests of the project, both
+// those it offers and those offered to it, and creates, cancels and accepts
+// them.
+type ZoneTransfersModel struct {
+	table     table.Model
+	loading   bool
+	err       error
+	spinner   spinner.Model
+	client    client.DNSClient
+	transfers []client.ZoneTransfer
+	// own are the IDs of the project's zones; their transfers are outgoing.
+	own map[string]bool
+	// zoneID and zoneName are the zone offered by the create form.
+	zoneID   string
+	zoneName string
+
+	form          *common.FormModel
+	formKind      string
+	pendingCancel string
+	// created is the transfer just requested, shown with its key until the
+	// next key.
+	created   *client.ZoneTransfer
+	status    string
+	statusErr bool
+
+	width  int
+	height int
+}
+
+// NewZoneTransfersModel creates the transfers view. A non-empty zoneID
+// opens the form offering that zone right away.
+func NewZoneTransfersModel(dc client.DNSClient, zoneID, zoneName string) ZoneTransfersModel {
+	s := spinner.New()
+	s.Spinner = spinner.Dot
+	m := ZoneTransfersModel{client: dc, loading: true, spinner: s, zoneID: zoneID, zoneName: zoneName, width: 120, height: 30}
+	if zoneID != "" {
+		m.openCreateForm()
+	}
+	return m
+}
+
+type zoneTransfersLoadedMsg struct {
+	transfers []client.ZoneTransfer
+	own       map[string]bool
+	err       error
+}
+
+type zoneTransferCreatedMsg struct {
+	transfer *client.ZoneTransfer
+	err      error
+}
+
+type zoneTransferDoneMsg struct {
+	status string
+	// action names a failed change as checked with policy.Check.
+	action string
+	err    error
+}
+
+// Init loads the transfers, and starts the create form when it is open.
+func (m ZoneTransfersModel) Init() tea.Cmd {
+	cmds := []tea.Cmd{m.spinner.Tick, m.loadCmd()}
+	if m.form != nil {
+		cmds = append(cmds, m.form.Init())
+	}
+	return tea.Batch(cmds...)
+}
+
+func (m ZoneTransfersModel) loadCmd() tea.Cmd {
+	dc := m.client
+	return func() tea.Msg {
+		ctx := context.Background()
+		transfers, err := dc.ListZoneTransfers(ctx)
+		if err != nil {
+			return zoneTransfersLoadedMsg{err: err}
+		}
+		zones, err := dc.ListZones(ctx)
+		if err != nil {
+			return zoneTransfersLoadedMsg{err: err}
+		}
+		own := map[string]bool{}
+		for _, z := range zones {
+			own[z.ID] = true
+		}
+		return zoneTransfersLoadedMsg{transfers: transfers, own: own}
+	}
+}
+
+// Reload re-fetches the transfers.
+func (m ZoneTransfersModel) Reload() tea.Cmd {
+	if m.loading || m.form != nil {
+		return nil
+	}
+	return m.loadCmd()
+}
+
+// OffersZone reports whether the view was opened on a zone it can offer.
+func (m ZoneTransfersModel) OffersZone() bool { return m.zoneID != "" }
+
+// CapturingInput reports whether a form or the cancel prompt is open.
+func (m ZoneTransfersModel) CapturingInput() bool {
+	return m.form != nil || m.pendingCancel != ""
+}
+
+// ConfirmingDestructive reports whether the cancel prompt is open.
+func (m ZoneTransfersModel) ConfirmingDestructive() bool { return m.pendingCancel != "" }
+
+// openCreateForm opens the form offering the zone of the view.
+func (m *ZoneTransfersModel) openCreateForm() {
+	f := common.NewForm([]string{i18n.T("Target project ID (empty: any project given the key)"), i18n.T("Description (optional)")})
+	m.form, m.formKind = &f, transferFormCreate
+}
+
+// openAcceptForm opens the accept form, filled in with the selected
+// incoming transfer.
+func (m *ZoneTransfersModel) openAcceptForm() {
+	f := common.NewForm([]string{i18n.T("Transfer request ID"), i18n.T("Key")})
+	if t, ok := m.selected(); ok && !m.own[t.ZoneID] {
+		f.SetValue(0, t.ID)
+	}
+	m.form, m.formKind = &f, transferFormAccept
+}
+
+// Update handles messages for the model.
+func (m ZoneTransfersModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case zoneTransfersLoadedMsg:
+		m.loading = false
+		m.err = msg.err
+		if msg.err == nil {
+			m.transfers, m.own = msg.transfers, msg.own
+			m.refreshTable()
+		}
+		return m, nil
+	case zoneTransferCreatedMsg:
+		if msg.err != nil {
+			policy.Record("transferring a zone", msg.err)
+			m.status, m.statusErr = msg.err.Error(), true
+			return m, nil
+		}
+		m.created, m.status = msg.transfer, ""
+		return m, m.loadCmd()
+	case zoneTransferDoneMsg:
+		m.status, m.statusErr = msg.status, msg.err != nil
+		if msg.err != nil {
+			policy.Record(msg.action, msg.err)
+			m.status = msg.err.Error()
+			return m, nil
+		}
+		return m, m.loadCmd()
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		if !m.loading && m.err == nil {
+			m.refreshTable()
+		}
+		return m, nil
+	case tea.KeyMsg:
+		if m.form != nil {
+			return m.updateForm(msg)
+		}
+		if m.pendingCancel != "" {
+			id := m.pendingCancel
+			m.pendingCancel = ""
+			if msg.String() != "y" {
+				return m, nil
+			}
+			dc := m.client
+			return m, func() tea.Msg {
+				err := dc.DeleteZoneTransfer(context.Background(), id)
+				return zoneTransferDoneMsg{status: i18n.T("Cancelled transfer request %s", id), err: err}
+			}
+		}
+		if m.loading || m.err != nil {
+			return m, nil
+		}
+		m.created = nil
+		switch msg.String() {
+		case "n":
+			if m.zoneID == "" {
+				m.status, m.statusErr = i18n.T("Press t on a zone in the zone list to offer it"), true
+				return m, nil
+			}
+			if err := policy.Check(policy.Member, "transferring a zone"); err != nil {
+				m.status, m.statusErr = err.Error(), true
+				return m, nil
+			}
+			m.openCreateForm()
+			return m, m.form.Init()
+		case "a":
+			if err := policy.Check(policy.Member, "accepting a zone transfer"); err != nil {
+				m.status, m.statusErr = err.Error(), true
+				return m, nil
+			}
+			m.openAcceptForm()
+			return m, m.form.Init()
+		case "x":
+			if t, ok := m.selected(); ok {
+				if !m.own[t.ZoneID] {
+					m.status, m.statusErr = i18n.T("Only the project owning the zone can cancel its transfer"), true
+					return m, nil
+				}
+				m.pendingCancel = t.ID
+			}
+			return m, nil
+		case "r":
+			m.loading = true
+			return m, tea.Batch(m.spinner.Tick, m.loadCmd())
+		}
+		var cmd tea.Cmd
+		m.table, cmd = m.table.Update(msg)
+		return m, cmd
+	default:
+		if m.loading {
+			var cmd tea.Cmd
+			m.spinner, cmd = m.spinner.Update(msg)
+			return m, cmd
+		}
+	}
+	return m, nil
+}
+
+// updateForm handles keys for the create and accept forms.
+func (m ZoneTransfersModel) updateForm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	fm, cmd := m.form.Update(msg)
+	f := fm.(common.FormModel)
+	m.form = &f
+	switch {
+	case f.Cancelled():
+		m.form = nil
+		return m, nil
+	case !f.Submitted():
+		return m, cmd
+	}
+	v := f.Values()
+	dc := m.client
+	if m.formKind == transferFormCreate {
+		m.form = nil
+		zoneID, target, desc := m.zoneID, strings.TrimSpace(v[0]), strings.TrimSpace(v[1])
+		m.status, m.statusErr = i18n.T("Requesting the transfer of %s...", m.zoneName), false
+		return m, func() tea.Msg {
+			t, err := dc.CreateZoneTransfer(context.Background(), zoneID, target, desc)
+			return zoneTransferCreatedMsg{transfer: t, err: err}
+		}
+	}
+	id, key := strings.TrimSpace(v[0]), strings.TrimSpace(v[1])
+	if id == "" || key == "" {
+		m.form.SetError(errors.New("the transfer request ID and the key are both needed"))
+		return m, nil
+	}
+	m.form = nil
+	name := id
+	for _, t := range m.transfers {
+		if t.ID == id && t.ZoneName != "" {
+			name = t.ZoneName
+		}
+	}
+	return m, func() tea.Msg {
+		acc, err := dc.AcceptZoneTransfer(context.Background(), id, key)
+		if err != nil {
+			return zoneTransferDoneMsg{action: "accepting a zone transfer", err: err}
+		}
+		return zoneTransferDoneMsg{status: i18n.T("Accepted the transfer of %s (%s)", name, strings.ToLower(acc.Status))}
+	}
+}
+
+// selected returns the transfer of the selected row.
+func (m ZoneTransfersModel) selected() (client.ZoneTransfer, bool) {
+	row := m.table.SelectedRow()
+	if len(row) == 0 {
+		return client.ZoneTransfer{}, false
+	}
+	for _, t := range m.transfers {
+		if t.ID == row[0] {
+			return t, true
+		}
+	}
+	return client.ZoneTransfer{}, false
+}
+
+// direction labels a transfer from the project's point of view.
+func (m ZoneTransfersModel) direction(t client.ZoneTransfer) string {
+	if m.own[t.ZoneID] {
+		return "outgoing"
+	}
+	return "incoming"
+}
+
+// refreshTable rebuilds the transfers table.
+func (m *ZoneTransfersModel) refreshTable() {
+	idW, statusW := uiconst.ColWidthUUID, uiconst.ColWidthStatus
+	rest := m.width - idW - statusW - 10 - 11 - uiconst.TableHeightOffset
+	if rest < 40 {
+		rest = 40
+	}
+	zoneW := rest / 2
+	cols := []table.Column{{Title: "ID", Width: idW}, {Title: i18n.T("Zone"), Width: zoneW}, {Title: i18n.T("Direction"), Width: 10}, {Title: i18n.T("Target project"), Width: rest - zoneW}, {Title: i18n.T("Status"), Width: statusW}, {Title: i18n.T("Created"), Width: 11}}
+	var rows []table.Row
+	for _, t := range m.transfers {
+		target := t.TargetProjectID
+		if target == "" {
+			target = "any, with the key"
+		}
+		created := "–"
+		if !t.CreatedAt.IsZero() {
+			created = t.CreatedAt.Format("2006-01-02")
+		}
+		rows = append(rows, table.Row{t.ID, t.ZoneName, m.direction(t), target, theme.Mark(t.Status), created})
+	}
+	cursor := m.table.Cursor()
+	m.table = table.New(table.WithColumns(cols), table.WithFocused(true))
+	m.table.SetStyles(table.DefaultStyles())
+	m.table.SetRows(rows)
+	m.table.SetHeight(m.height - uiconst.TableHeightOffset - 6)
+	if cursor < len(rows) {
+		m.table.SetCursor(cursor)
+	}
+}
+
+// selectionLine details the selected transfer: the key of an outgoing one,
+// the description of an incoming one.
+func (m ZoneTransfersModel) selectionLine() string {
+	t, ok := m.selected()
+	if !ok {
+		return ""
+	}
+	if m.own[t.ZoneID] {
+		if t.Key == "" {
+			return "Outgoing · key not shown by Designate"
+		}
+		return "Outgoing · key " + t.Key + " · the receiving project needs the ID and the key"
+	}
+	line := "Incoming · press a and enter the key the owner gave you"
+	if t.Description != "" {
+		line += " · " + t.Description
+	}
+	return line
+}
+
+// View renders the transfers, the key of a new request and the forms.
+func (m ZoneTransfersModel) View() string {
+	if m.form != nil {
+		title := i18n.T("Accept a zone transfer – enter the ID and key given by the zone's owner")
+		if m.formKind == transferFormCreate {
+			title = i18n.T("Offer zone %s to another project", m.zoneName)
+		}
+		return title + "\n\n" + m.form.View() + "\n" + i18n.T("[tab] next field  [enter] next/submit  [esc] cancel")
+	}
+	if m.loading {
+		return m.spinner.View()
+	}
+	if m.err != nil {
+		return i18n.T("Error: %s", m.err)
+	}
+	dim := lipgloss.NewStyle().Foreground(theme.Muted)
+	warn := lipgloss.NewStyle().Foreground(theme.Warn)
+	out := "Zone transfers\n"
+	if len(m.transfers) == 0 {
+		out += dim.Render(i18n.T("No pending zone transfers."))
+	} else {
+		out += m.table.View() + "\n" + dim.Render(m.selectionLine())
+	}
+	switch {
+	case m.created != nil:
+		out += "\n" + warn.Render("Transfer request for "+m.created.ZoneName+" created. Give both values to the receiving project:") +
+			fmt.Sprintf("\n  ID:  %s\n  Key: %s\n", m.created.ID, m.created.Key) +
+			dim.Render(i18n.T("It accepts them with [a] in its zone transfers view (:transfers). The zone stays yours until then."))
+	case m.pendingCancel != "":
+		out += "\nCancel transfer request " + m.pendingCancel + "? [y/N]"
+	case m.statusErr:
+		out += "\n" + lipgloss.NewStyle().Foreground(theme.Error).Render(m.status)
+	case m.status != "":
+		out += "\n" + lipgloss.NewStyle().Foreground(theme.OK).Render(m.status)
+	}
+	help := policy.Key(policy.Member, i18n.T("[a] accept"), "accepting a zone transfer") + "  " + i18n.T("[x] cancel request  [r] refresh")
+	if m.zoneID != "" {
+		help = policy.Key(policy.Member, i18n.T("[n] offer %s", m.zoneName), "transferring a zone") + "  " + help
+	}
+	return out + "\n" + help
+}
+
+// Table returns the transfers table.
+func (m ZoneTransfersModel) Table() table.Model { return m.table }
+
+var _ tea.Model = (*ZoneTransfersModel)(nil)
@@ -0,0 +1,72 @@
+package dns
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"ostui/internal/demo"
+)
+
+func enter(t *testing.T, m ZoneTransfersModel) (ZoneTransfersModel, tea.Cmd) {
+	t.Helper()
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	return updated.(ZoneTransfersModel), cmd
+}
+
+func TestZoneTransferWorkflow(t *testing.T) {
+	c := demo.New(1, demo.DefaultSize)
+	dc := c.DNS()
+	zones, _ := dc.ListZones(context.Background())
+	zone := zones[0]
+
+	m := NewZoneTransfersModel(dc, zone.ID, zone.Name)
+	if !m.CapturingInput() {
+		t.Fatal("expected the offer form to open on the zone")
+	}
+	updated, _ := m.Update(m.loadCmd()())
+	m = updated.(ZoneTransfersModel)
+	m, _ = enter(t, m)
+	m, cmd := enter(t, m)
+	if cmd == nil || m.form != nil {
+		t.Fatal("expected the transfer to be requested")
+	}
+	updated, cmd = m.Update(cmd())
+	m = updated.(ZoneTransfersModel)
+	if m.created == nil || m.created.Key == "" || !strings.Contains(m.View(), m.created.Key) {
+		t.Fatalf("expected the key of the new request on screen, got %+v", m.created)
+	}
+	updated, _ = m.Update(cmd())
+	m = updated.(ZoneTransfersModel)
+	if got := m.direction(m.transfers[len(m.transfers)-1]); got != "outgoing" {
+		t.Errorf("expected the new request to be outgoing, got %s", got)
+	}
+
+	// Accept the transfer offered by the partner project.
+	var incoming string
+	for _, tr := range m.transfers {
+		if m.direction(tr) == "incoming" {
+			incoming = tr.ID
+		}
+	}
+	if incoming == "" {
+		t.Fatal("expected an incoming transfer in the demo cloud")
+	}
+	for _, key := range []string{"WRONG", "PARTNER1"} {
+		m.openAcceptForm()
+		m.form.SetValue(0, incoming)
+		m.form.SetValue(1, key)
+		m, _ = enter(t, m)
+		m, cmd = enter(t, m)
+		updated, _ = m.Update(cmd())
+		m = updated.(ZoneTransfersModel)
+	}
+	if m.statusErr || !strings.Contains(m.status, "partner.example.com.") {
+		t.Fatalf("expected the partner zone accepted, got %q", m.status)
+	}
+	zones, _ = dc.ListZones(context.Background())
+	if zones[len(zones)-1].Name != "partner.example.com." {
+		t.Errorf("expected the transferred zone in the project, got %+v", zones[len(zones)-1])
+	}
+}
@@ -8,6 +8,9 @@ import (
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"ostui/internal/client"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/common"
+	"ostui/internal/ui/theme"
 	"ostui/internal/ui/uiconst"
 	"strings"
 )
@@ -52,13 +55,14 @@ func (m ZonesModel) Init() tea.Cmd {
 		if err != nil {
 			return zonesDataLoadedMsg{err: err}
 		}
-		cols := []table.Column{{Title: "ID", Width: uiconst.ColWidthUUID}, {Title: "Name", Width: uiconst.ColWidthNameDNS}, {Title: "Status", Width: uiconst.ColWidthStatus}, {Title: "TTL", Width: uiconst.ColWidthTTL}}
+		cols := []table.Column{{Title: "ID", Width: uiconst.ColWidthUUID}, {Title: i18n.T("Name"), Width: uiconst.ColWidthNameDNS}, {Title: i18n.T("Status"), Width: uiconst.ColWidthStatus}, {Title: "TTL", Width: uiconst.ColWidthTTL}}
 		rows := []table.Row{}
 		for _, z := range zones {
-			rows = append(rows, table.Row{z.ID, z.Name, z.Status, fmt.Sprintf("%d", z.TTL)})
+			rows = append(rows, table.Row{z.ID, z.Name, theme.Mark(z.Status), fmt.Sprintf("%d", z.TTL)})
 		}
+		rows = common.TerraformRows(rows)
 		t := table.New(
-			table.WithColumns(cols),
+			table.WithColumns(common.TerraformColumns(cols)),
 			table.WithRows(rows),
 			table.WithFocused(true),
 			table.WithHeight(m.height-uiconst.TableHeightOffset),
@@ -68,6 +72,14 @@ func (m ZonesModel) Init() tea.Cmd {
 	}
 }
 
+// Reload re-fetches the zones, keeping the selected row.
+func (m ZonesModel) Reload() tea.Cmd {
+	if m.loading || m.filterMode {
+		return nil
+	}
+	return m.Init()
+}
+
 // Update processes messages and user input.
 func (m ZonesModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
@@ -77,7 +89,7 @@ func (m ZonesModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.err = msg.err
 			return m, nil
 		}
-		m.table = msg.tbl
+		m.table = common.Reloaded(m.table, msg.tbl)
 		m.allRows = msg.rows
 		m.updateTableColumns()
 		m.table.SetHeight(m.height - uiconst.TableHeightOffset)
@@ -159,6 +171,15 @@ func (m ZonesModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			return m, nil
 		}
+		// t offers the selected zone to another project.
+		if msg.String() == "t" {
+			row := m.table.SelectedRow()
+			if len(row) > 1 {
+				zone := OpenZoneTransfersMsg{ZoneID: row[0], ZoneName: row[1]}
+				return m, func() tea.Msg { return zone }
+			}
+			return m, nil
+		}
 		var cmd tea.Cmd
 		m.table, cmd = m.table.Update(msg)
 		return m, cmd
@@ -178,14 +199,14 @@ func (m ZonesModel) View() string {
 		return m.spinner.View()
 	}
 	if m.err != nil {
-		return fmt.Sprintf("Error: %s", m.err)
+		return i18n.T("Error: %s", m.err)
 	}
 	if m.mode == "detail" && m.detailModel != nil {
 		// Delegate view to the detail model.
 		return m.detailModel.View()
 	}
 	if m.filterMode {
-		filterLine := fmt.Sprintf("Filter: %s", m.filter.View())
+		filterLine := i18n.T("Filter: %s", m.filter.View())
 		footer := "esc: clear"
 		return fmt.Sprintf("%s\n%s\n%s", filterLine, m.table.View(), footer)
 	}
@@ -195,16 +216,19 @@ func (m ZonesModel) View() string {
 // Table returns the primary table model (list view).
 func (m ZonesModel) Table() table.Model { return m.table }
 
+// Filtering reports whether keys are typed into the filter.
+func (m ZonesModel) Filtering() bool { return m.filterMode }
+
 func (m *ZonesModel) updateTableColumns() {
 	if len(m.table.Columns()) > 0 {
 		idW := uiconst.ColWidthUUID
 		statusW := uiconst.ColWidthStatus
 		ttlW := uiconst.ColWidthTTL
-		nameW := m.width - idW - statusW - ttlW - 6
+		nameW := m.width - idW - statusW - ttlW - 6 - common.TerraformWidth()
 		if nameW < 10 {
 			nameW = 10
 		}
-		m.table.SetColumns([]table.Column{{Title: "ID", Width: idW}, {Title: "Name", Width: nameW}, {Title: "Status", Width: statusW}, {Title: "TTL", Width: ttlW}})
+		m.table.SetColumns(common.TerraformColumns([]table.Column{{Title: "ID", Width: idW}, {Title: i18n.T("Name"), Width: nameW}, {Title: i18n.T("Status"), Width: statusW}, {Title: "TTL", Width: ttlW}}))
 	}
 }
 
@@ -0,0 +1,236 @@
+package ui
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"ostui/internal/client"
+	"ostui/internal/demo"
+	"ostui/internal/ui/common"
+	"ostui/internal/ui/compute"
+	"ostui/internal/ui/jobs"
+)
+
+// update rewrites the golden frames: go test ./internal/ui -run E2E -update
+var update = flag.Bool("update", false, "rewrite the golden frames in testdata/e2e")
+
+// settle bounds how long a command may take to count as part of a key's
+// effect. The demo answers in microseconds; the cursor blink and the
+// pollers tick later and are left out, and the faster spinner ticks are
+// dropped, so frames do not depend on timing.
+const settle = 150 * time.Millisecond
+
+// harness drives the app over the demo cloud as the terminal would: each key
+// goes through Update, the commands it returns run until they settle, and
+// the frame on screen is compared with a golden file.
+type harness struct {
+	t    *testing.T
+	m    tea.Model
+	quit bool
+}
+
+func newHarness(t *testing.T) *harness {
+	t.Helper()
+	// Keep the user's schedules out of the frames.
+	t.Setenv("OSTUI_SCHEDULES_FILE", filepath.Join(t.TempDir(), "schedules.yaml"))
+	dc := demo.New(1, demo.DefaultSize)
+	services := client.NewServiceSetFromClients(client.Clients{Compute: dc.Compute(), Network: dc.Network(), Storage: dc.Storage(), Identity: dc.Identity(), Image: dc.Image(), Limits: dc.Limits(), DNS: dc.DNS(), LoadBalancer: dc.LoadBalancer(), SharedFS: dc.SharedFS(), KeyManager: dc.KeyManager(), ContainerInfra: dc.ContainerInfra()})
+	h := &harness{t: t, m: NewModel("demo", services, Options{AllocationRatios: compute.DefaultAllocationRatios()})}
+	h.send(tea.WindowSizeMsg{Width: 120, Height: 32})
+	h.run(h.m.Init())
+	return h
+}
+
+// keys types each key: a rune, or enter, esc, tab, up, down or backspace.
+func (h *harness) keys(keys ...string) *harness {
+	named := map[string]tea.KeyType{"up": tea.KeyUp, "down": tea.KeyDown, "backspace": tea.KeyBackspace}
+	for _, k := range keys {
+		msg := common.KeyFor(k)
+		if kt, ok := named[k]; ok {
+			msg = tea.KeyMsg{Type: kt}
+		}
+		h.send(msg)
+	}
+	return h
+}
+
+// command runs cmd in command mode.
+func (h *harness) command(cmd string) *harness {
+	h.keys(":")
+	for _, r := range cmd {
+		h.keys(string(r))
+	}
+	return h.keys("enter")
+}
+
+func (h *harness) send(msg tea.Msg) {
+	if h.quit {
+		h.t.Fatalf("%T sent after the app quit", msg)
+	}
+	m, cmd := h.m.Update(msg)
+	h.m = m
+	h.run(cmd)
+}
+
+// run executes cmd and feeds back what it produces, breadth first. The
+// commands of a round run together; their messages are fed in the order
+// the commands were issued, whatever order they finish in.
+func (h *harness) run(cmd tea.Cmd) {
+	pending := []tea.Cmd{cmd}
+	for round := 0; len(pending) > 0; round++ {
+		if round > 100 {
+			h.t.Fatal("commands did not settle after 100 rounds")
+		}
+		results := make([]chan tea.Msg, len(pending))
+		for i, c := range pending {
+			results[i] = make(chan tea.Msg, 1)
+			if c == nil {
+				results[i] <- nil
+				continue
+			}
+			go func(c tea.Cmd, out chan<- tea.Msg) { out <- c() }(c, results[i])
+		}
+		deadline := time.NewTimer(settle)
+		expired := false
+		var msgs []tea.Msg
+		for _, out := range results {
+			if !expired {
+				select {
+				case msg := <-out:
+					msgs = append(msgs, msg)
+					continue
+				case <-deadline.C:
+					expired = true
+				}
+			}
+			// Past the deadline only what has finished counts; the
+			// rest are ticks.
+			select {
+			case msg := <-out:
+				msgs = append(msgs, msg)
+			default:
+			}
+		}
+		deadline.Stop()
+		pending = nil
+		for _, msg := range msgs {
+			switch msg := msg.(type) {
+			case nil, spinner.TickMsg, jobs.ScheduleTickMsg:
+			case tea.BatchMsg:
+				pending = append(pending, msg...)
+			case tea.QuitMsg:
+				h.quit = true
+				return
+			default:
+				m, c := h.m.Update(msg)
+				h.m = m
+				pending = append(pending, c)
+			}
+		}
+	}
+}
+
+// timestamp matches the times the demo sets relative to the start of the
+// test; frames show them masked, keeping their width.
+var timestamp = regexp.MustCompile(`\d{4}-\d\d-\d\dT\d\d:\d\d:\d\dZ`)
+
+// frame returns the screen without trailing blanks and with the times
+// masked.
+func (h *harness) frame() string {
+	view := timestamp.ReplaceAllString(h.m.View(), "YYYY-MM-DDThh:mm:ssZ")
+	lines := strings.Split(view, "\n")
+	for i, l := range lines {
+		lines[i] = strings.TrimRight(l, " ")
+	}
+	return strings.TrimRight(strings.Join(lines, "\n"), "\n") + "\n"
+}
+
+// golden compares the frame with testdata/e2e/name.golden.
+func (h *harness) golden(name string) string {
+	h.t.Helper()
+	got := h.frame()
+	path := filepath.Join("testdata", "e2e", name+".golden")
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			h.t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			h.t.Fatal(err)
+		}
+		return got
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		h.t.Fatalf("%v; run go test ./internal/ui -run E2E -update", err)
+	}
+	if got != string(want) {
+		h.t.Errorf("frame %s differs from %s:\n--- got\n%s--- want\n%s", name, path, got, want)
+	}
+	return got
+}
+
+// state returns the state of the app.
+func (h *harness) state() string { return h.m.(AppModel).state }
+
+func TestE2EServersNavigation(t *testing.T) {
+	h := newHarness(t)
+	sidebar := h.golden("sidebar")
+
+	h.command("servers")
+	if h.state() != stateMain {
+		t.Fatalf("expected the servers list, got state %q", h.state())
+	}
+	list := h.golden("servers")
+
+	h.keys("down", "enter")
+	if h.state() != stateDetail {
+		t.Fatalf("expected the server detail, got state %q", h.state())
+	}
+	h.golden("server_detail")
+
+	// esc goes back one level at a time, to the same frames.
+	h.keys("esc")
+	if h.state() != stateMain || h.frame() != list {
+		t.Fatalf("expected esc to return to the list as it was, got:\n%s", h.frame())
+	}
+	h.keys("esc")
+	if h.state() != stateSidebar || h.frame() != sidebar {
+		t.Fatalf("expected esc to return to the sidebar, got:\n%s", h.frame())
+	}
+}
+
+func TestE2EHelpOverlay(t *testing.T) {
+	h := newHarness(t)
+	h.command("vol")
+	list := h.frame()
+	h.keys("?")
+	if h.state() != stateHelp {
+		t.Fatalf("expected the help, got state %q", h.state())
+	}
+	h.golden("help_volumes")
+	h.keys("esc")
+	if h.state() != stateMain || h.frame() != list {
+		t.Fatalf("expected esc to close the help over the volumes, got:\n%s", h.frame())
+	}
+}
+
+func TestE2ECommandBarEsc(t *testing.T) {
+	h := newHarness(t)
+	h.command("servers")
+	list := h.frame()
+	h.keys(":", "v", "o")
+	if h.state() != stateCommand {
+		t.Fatalf("expected command mode, got state %q", h.state())
+	}
+	h.keys("esc")
+	if h.state() != stateMain || h.frame() != list {
+		t.Fatalf("expected esc to leave command mode for the list as it was, got:\n%s", h.frame())
+	}
+}
@@ -0,0 +1,279 @@
+// Package editor provides a YAML editor for the mutable fields of a resource.
+// Detail views opt in by implementing Editable; the app opens the editor on
+// 'E' and reloads the detail view once changes have been applied.
+package editor
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"reflect"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"gopkg.in/yaml.v2"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/theme"
+)
+
+// Spec describes how to edit one resource.
+type Spec struct {
+	// Title names the resource, e.g. "server web-01".
+	Title string
+	// Load returns the current values of the mutable fields as YAML.
+	Load func() (string, error)
+	// Apply validates the edited YAML against the original and applies the
+	// difference, returning a short summary of what changed.
+	Apply func(original, edited string) (string, error)
+}
+
+// Editable is implemented by detail views whose resource can be edited.
+type Editable interface {
+	EditSpec() Spec
+}
+
+// DoneMsg is emitted when the editor closes. Changed is set when an update
+// was applied and the caller should reload the resource.
+type DoneMsg struct {
+	Status  string
+	Changed bool
+}
+
+type loadedMsg struct {
+	yaml string
+	err  error
+}
+
+type appliedMsg struct {
+	status string
+	err    error
+}
+
+// externalEditMsg carries the file content after $EDITOR exited.
+type externalEditMsg struct {
+	content string
+	err     error
+}
+
+// Model is the embedded YAML editor.
+type Model struct {
+	spec     Spec
+	area     textarea.Model
+	original string
+	loading  bool
+	applying bool
+	err      error
+	width    int
+	height   int
+}
+
+// New creates an editor for spec.
+func New(spec Spec, width, height int) Model {
+	ta := textarea.New()
+	ta.ShowLineNumbers = true
+	ta.CharLimit = 0
+	ta.Focus()
+	m := Model{spec: spec, area: ta, loading: true}
+	m.resize(width, height)
+	return m
+}
+
+// CapturingInput reports that the editor consumes every key.
+func (m Model) CapturingInput() bool { return true }
+
+// Init loads the resource.
+func (m Model) Init() tea.Cmd {
+	load := m.spec.Load
+	return func() tea.Msg {
+		y, err := load()
+		return loadedMsg{yaml: y, err: err}
+	}
+}
+
+func (m *Model) resize(width, height int) {
+	m.width, m.height = width, height
+	m.area.SetWidth(max(width-4, 20))
+	m.area.SetHeight(max(height-8, 5))
+}
+
+// Update handles editing keys and async results.
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.resize(msg.Width, msg.Height)
+		return m, nil
+	case loadedMsg:
+		m.loading = false
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		m.original = msg.yaml
+		m.area.SetValue(msg.yaml)
+		return m, textarea.Blink
+	case externalEditMsg:
+		if msg.err != nil {
+			m.err = fmt.Errorf("external editor: %w", msg.err)
+			return m, nil
+		}
+		m.area.SetValue(msg.content)
+		m.err = nil
+		return m, nil
+	case appliedMsg:
+		m.applying = false
+		if msg.err != nil {
+			// Keep the edits so the user can fix them.
+			m.err = msg.err
+			return m, nil
+		}
+		return m, done(msg.status, true)
+	case tea.KeyMsg:
+		if m.applying {
+			return m, nil
+		}
+		switch msg.String() {
+		case "esc":
+			return m, done(i18n.T("Edit cancelled"), false)
+		case "ctrl+s":
+			if m.loading || m.original == "" {
+				return m, nil
+			}
+			m.applying = true
+			m.err = nil
+			apply, original, edited := m.spec.Apply, m.original, m.area.Value()
+			return m, func() tea.Msg {
+				status, err := apply(original, edited)
+				return appliedMsg{status: status, err: err}
+			}
+		case "ctrl+e":
+			if m.loading || m.original == "" {
+				return m, nil
+			}
+			return m, openExternal(m.area.Value())
+		}
+		if m.loading {
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.area, cmd = m.area.Update(msg)
+		return m, cmd
+	}
+	var cmd tea.Cmd
+	m.area, cmd = m.area.Update(msg)
+	return m, cmd
+}
+
+// View renders the editor.
+func (m Model) View() string {
+	title := lipgloss.NewStyle().Bold(true).Foreground(theme.Accent).Render("Edit " + m.spec.Title)
+	dim := lipgloss.NewStyle().Foreground(theme.Muted)
+	errStyle := lipgloss.NewStyle().Foreground(theme.Error)
+	if m.loading {
+		return title + "\n\n" + i18n.T("Loading…")
+	}
+	if m.original == "" && m.err != nil {
+		return title + "\n\n" + errStyle.Render(i18n.T("Error: %s", m.err)) + "\n" + dim.Render(i18n.T("[esc] back"))
+	}
+	var b strings.Builder
+	b.WriteString(title + "\n\n")
+	b.WriteString(m.area.View() + "\n")
+	if m.err != nil {
+		b.WriteString(errStyle.Render(m.err.Error()) + "\n")
+	}
+	if m.applying {
+		b.WriteString(dim.Render(i18n.T("Applying…")))
+	} else {
+		b.WriteString(dim.Render(i18n.T("[ctrl+s] validate & apply  [ctrl+e] open in $EDITOR  [esc] cancel")))
+	}
+	return b.String()
+}
+
+func done(status string, changed bool) tea.Cmd {
+	return func() tea.Msg { return DoneMsg{Status: status, Changed: changed} }
+}
+
+// openExternal suspends the TUI and edits content in $VISUAL/$EDITOR.
+func openExternal(content string) tea.Cmd {
+	f, err := os.CreateTemp("", "ostui-edit-*.yaml")
+	if err != nil {
+		return func() tea.Msg { return externalEditMsg{err: err} }
+	}
+	path := f.Name()
+	_, err = f.WriteString(content)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(path)
+		return func() tea.Msg { return externalEditMsg{err: err} }
+	}
+	args := editorCommand()
+	cmd := exec.Command(args[0], append(args[1:], path)...)
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		defer os.Remove(path)
+		if err != nil {
+			return externalEditMsg{err: err}
+		}
+		b, err := os.ReadFile(path)
+		return externalEditMsg{content: string(b), err: err}
+	})
+}
+
+// editorCommand returns the user's editor command split into arguments.
+func editorCommand() []string {
+	for _, env := range []string{"VISUAL", "EDITOR"} {
+		if fields := strings.Fields(os.Getenv(env)); len(fields) > 0 {
+			return fields
+		}
+	}
+	return []string{"vi"}
+}
+
+// Decode parses edited YAML strictly into v, rejecting unknown fields so
+// typos are reported instead of silently ignored.
+func Decode(data string, v interface{}) error {
+	if err := yaml.UnmarshalStrict([]byte(data), v); err != nil {
+		return fmt.Errorf("invalid YAML: %w", err)
+	}
+	return nil
+}
+
+// Encode renders v as YAML for editing.
+func Encode(v interface{}) (string, error) {
+	b, err := yaml.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// ChangedFields returns the yaml names of the top-level fields of the
+// structs before and after that differ. Both must be the same struct type.
+func ChangedFields(before, after interface{}) []string {
+	bv, av := reflect.ValueOf(before), reflect.ValueOf(after)
+	var changed []string
+	for i := 0; i < bv.NumField(); i++ {
+		if reflect.DeepEqual(bv.Field(i).Interface(), av.Field(i).Interface()) {
+			continue
+		}
+		name := strings.Split(bv.Type().Field(i).Tag.Get("yaml"), ",")[0]
+		if name == "" {
+			name = strings.ToLower(bv.Type().Field(i).Name)
+		}
+		changed = append(changed, name)
+	}
+	return changed
+}
+
+// Summary formats the result of an apply.
+func Summary(changed []string) string {
+	if len(changed) == 0 {
+		return i18n.T("No changes")
+	}
+	return "Updated " + strings.Join(changed, ", ")
+}
+
+// Ensure Model implements tea.Model.
+var _ tea.Model = (*Model)(nil)
@@ -0,0 +1,142 @@
+package events
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestParse(t *testing.T) {
+	now := time.Date(2026, 1, 5, 10, 0, 0, 0, time.UTC)
+	plain := `{"event_type": "compute.instance.create.end", "publisher_id": "compute.host-1", "priority": "info",
+		"timestamp": "2026-01-05 09:12:03.123456", "payload": {"instance_id": "i-1", "display_name": "web-1", "tenant_id": "p-1"}}`
+	list, err := Parse([]byte(plain), now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e := list[0]
+	if e.Type != "compute.instance.create.end" || e.Priority != "INFO" || e.ResourceID != "i-1" || e.ResourceName != "web-1" || e.ProjectID != "p-1" ||
+		!e.Time.Equal(time.Date(2026, 1, 5, 9, 12, 3, 123456000, time.UTC)) {
+		t.Errorf("unexpected event %+v", e)
+	}
+
+	// The messaging v2 envelope, a versioned payload and a payload nested
+	// under the resource type, in one batch.
+	batch := `[
+		{"oslo.version": "2.0", "oslo.message": "{\"event_type\": \"volume.attach.end\", \"payload\": {\"volume_id\": \"v-1\"}, \"_context_project_id\": \"p-2\"}"},
+		{"event_type": "instance.shutdown.end", "payload": {"nova_object.data": {"uuid": "i-2", "display_name": "db"}}},
+		{"event_type": "port.create.end", "timestamp": "bogus", "payload": {"port": {"id": "port-1", "name": "", "project_id": "p-3"}}}
+	]`
+	list, err = Parse([]byte(batch), now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 3 || list[0].ResourceID != "v-1" || list[0].ProjectID != "p-2" || list[1].ResourceName != "db" ||
+		list[2].ResourceID != "port-1" || list[2].ProjectID != "p-3" || !list[2].Time.Equal(now) {
+		t.Errorf("unexpected batch %+v", list)
+	}
+
+	if _, err := Parse([]byte(`{"payload": {}}`), now); err == nil || !strings.Contains(err.Error(), "no event_type") {
+		t.Errorf("expected a missing event_type error, got %v", err)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	feed := &Feed{}
+	h := Handler(feed, "s3cret")
+	post := func(token, body string) int {
+		r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		if token != "" {
+			r.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w.Code
+	}
+	body := `{"event_type": "image.upload", "payload": {"id": "img-1"}}`
+	if code := post("", body); code != http.StatusUnauthorized {
+		t.Errorf("no token: got %d", code)
+	}
+	if code := post("s3cret", "not json"); code != http.StatusBadRequest {
+		t.Errorf("bad body: got %d", code)
+	}
+	if code := post("s3cret", body); code != http.StatusAccepted {
+		t.Errorf("valid post: got %d", code)
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("GET: got %d", w.Code)
+	}
+	if list, total := feed.Snapshot(); len(list) != 1 || total != 1 || list[0].ResourceID != "img-1" {
+		t.Errorf("unexpected feed %+v", list)
+	}
+}
+
+func TestEventsView(t *testing.T) {
+	feed := &Feed{Addr: "127.0.0.1:8089"}
+	add := func(typ, id string) {
+		feed.Add(Event{Time: time.Now(), Type: typ, Publisher: "compute.host-1", ResourceID: id})
+	}
+	add("compute.instance.create.end", "i-1")
+	add("volume.attach.end", "v-1")
+	m := NewEventsModel(feed)
+	key := func(k string) {
+		msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+		if k == "enter" {
+			msg = tea.KeyMsg{Type: tea.KeyEnter}
+		}
+		updated, _ := m.Update(msg)
+		m = updated.(EventsModel)
+	}
+	if len(m.shown) != 2 || m.shown[0].ResourceID != "v-1" {
+		t.Fatalf("expected the newest event first, got %+v", m.shown)
+	}
+
+	// The cursor stays on its event as new ones arrive above it.
+	key("j")
+	add("compute.instance.delete.end", "i-1")
+	updated, _ := m.Update(viewTickMsg{})
+	m = updated.(EventsModel)
+	if row := m.table.SelectedRow(); row[2] != "compute.instance.create.end" {
+		t.Errorf("cursor moved to %v", row)
+	}
+
+	key("/")
+	for _, r := range "instance i-1" {
+		key(string(r))
+	}
+	key("enter")
+	if m.CapturingInput() || len(m.shown) != 2 || !strings.Contains(m.View(), "3 received · 2 shown") {
+		t.Errorf("unexpected filter result %+v", m.shown)
+	}
+
+	// Paused, the list does not pick up new events.
+	key("p")
+	add("compute.instance.reboot.end", "i-1")
+	updated, _ = m.Update(viewTickMsg{})
+	m = updated.(EventsModel)
+	if len(m.shown) != 2 {
+		t.Errorf("paused view changed: %+v", m.shown)
+	}
+	key("p")
+	updated, _ = m.Update(viewTickMsg{})
+	m = updated.(EventsModel)
+	if len(m.shown) != 3 {
+		t.Errorf("resumed view did not refresh: %+v", m.shown)
+	}
+}
+
+func TestFeedLimit(t *testing.T) {
+	feed := &Feed{}
+	for i := 0; i < MaxEvents+5; i++ {
+		feed.Add(Event{Type: "x"})
+	}
+	if list, total := feed.Snapshot(); len(list) != MaxEvents || total != MaxEvents+5 {
+		t.Errorf("got %d events, total %d", len(list), total)
+	}
+}
@@ -0,0 +1,227 @@
+// Package events receives OpenStack notifications posted to an HTTP listener
+// and shows them as a live, filterable feed.
+//
+// The services' notifications are not read from the message bus directly: a
+// bridge consuming the notifications topic (or any webhook sink) POSTs them
+// as JSON, either as sent by oslo.messaging, with or without the
+// "oslo.message" envelope, or as a JSON array of those.
+package events
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MaxEvents is how many events a feed keeps; older ones are dropped.
+const MaxEvents = 2000
+
+// maxBody bounds the size of one request to the listener.
+const maxBody = 4 << 20
+
+// Event is one notification.
+type Event struct {
+	// Time is the notification's timestamp, or when it was received.
+	Time      time.Time
+	Type      string
+	Publisher string
+	Priority  string
+	// ResourceID and ResourceName identify what the event is about, when the
+	// payload names it.
+	ResourceID   string
+	ResourceName string
+	ProjectID    string
+	// Payload is the raw payload, kept for the detail pane.
+	Payload json.RawMessage
+}
+
+// Feed holds the latest events, newest last. It is safe for concurrent use.
+type Feed struct {
+	mu     sync.Mutex
+	events []Event
+	// total counts every event ever added, so views can tell when it moved.
+	total int
+	// Addr is the address the listener serves on, when there is one.
+	Addr string
+}
+
+// Active is the feed of the running listener, or nil when ostui was started
+// without --events-listen.
+var Active *Feed
+
+// Add appends events, dropping the oldest beyond MaxEvents.
+func (f *Feed) Add(list ...Event) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.events = append(f.events, list...)
+	if n := len(f.events) - MaxEvents; n > 0 {
+		f.events = append([]Event(nil), f.events[n:]...)
+	}
+	f.total += len(list)
+}
+
+// Snapshot returns a copy of the events and the number ever added.
+func (f *Feed) Snapshot() ([]Event, int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]Event(nil), f.events...), f.total
+}
+
+// Clear drops the stored events.
+func (f *Feed) Clear() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.events = nil
+}
+
+// notification is the JSON form of an oslo.messaging notification.
+type notification struct {
+	EventType   string          `json:"event_type"`
+	PublisherID string          `json:"publisher_id"`
+	Priority    string          `json:"priority"`
+	Timestamp   string          `json:"timestamp"`
+	Payload     json.RawMessage `json:"payload"`
+	// Envelope of messaging v2: the notification is a JSON string.
+	OsloMessage *string `json:"oslo.message"`
+	// Context keys some services fill instead of the payload.
+	ProjectID string `json:"_context_project_id"`
+	TenantID  string `json:"_context_tenant"`
+}
+
+// timeLayouts are the timestamp formats oslo.messaging and the services use.
+var timeLayouts = []string{"2006-01-02 15:04:05.999999", "2006-01-02T15:04:05.999999", time.RFC3339Nano}
+
+// Parse reads one notification, or a JSON array of them, received at now.
+func Parse(body []byte, now time.Time) ([]Event, error) {
+	var raw []json.RawMessage
+	trimmed := strings.TrimSpace(string(body))
+	if strings.HasPrefix(trimmed, "[") {
+		if err := json.Unmarshal(body, &raw); err != nil {
+			return nil, err
+		}
+	} else {
+		raw = []json.RawMessage{body}
+	}
+	var out []Event
+	for i, r := range raw {
+		var n notification
+		if err := json.Unmarshal(r, &n); err != nil {
+			return nil, fmt.Errorf("notification %d: %w", i+1, err)
+		}
+		if n.OsloMessage != nil {
+			if err := json.Unmarshal([]byte(*n.OsloMessage), &n); err != nil {
+				return nil, fmt.Errorf("notification %d: oslo.message: %w", i+1, err)
+			}
+		}
+		if n.EventType == "" {
+			return nil, fmt.Errorf("notification %d has no event_type", i+1)
+		}
+		e := Event{Type: n.EventType, Publisher: n.PublisherID, Priority: strings.ToUpper(n.Priority), Time: now, Payload: n.Payload}
+		for _, layout := range timeLayouts {
+			if t, err := time.ParseInLocation(layout, n.Timestamp, time.UTC); err == nil {
+				e.Time = t
+				break
+			}
+		}
+		e.ResourceID, e.ResourceName, e.ProjectID = describe(n.Payload)
+		if e.ProjectID == "" {
+			e.ProjectID = n.ProjectID
+		}
+		if e.ProjectID == "" {
+			e.ProjectID = n.TenantID
+		}
+		out = append(out, e)
+	}
+	return out, nil
+}
+
+// Payload keys naming the resource, in order of preference.
+var (
+	idKeys      = []string{"instance_id", "volume_id", "snapshot_id", "image_id", "resource_id", "uuid", "id"}
+	nameKeys    = []string{"display_name", "name", "hostname"}
+	projectKeys = []string{"tenant_id", "project_id"}
+)
+
+// describe picks the resource and project out of a payload. Versioned
+// notifications wrap the fields in "nova_object.data", and some services
+// nest them under the resource type, e.g. {"port": {...}}.
+func describe(payload json.RawMessage) (id, name, project string) {
+	var fields map[string]any
+	if json.Unmarshal(payload, &fields) != nil {
+		return "", "", ""
+	}
+	if data, ok := fields["nova_object.data"].(map[string]any); ok {
+		fields = data
+	}
+	if len(fields) == 1 {
+		for _, v := range fields {
+			if inner, ok := v.(map[string]any); ok {
+				fields = inner
+			}
+		}
+	}
+	pick := func(keys []string) string {
+		for _, k := range keys {
+			if s, ok := fields[k].(string); ok && s != "" {
+				return s
+			}
+		}
+		return ""
+	}
+	return pick(idKeys), pick(nameKeys), pick(projectKeys)
+}
+
+// Handler accepts notifications POSTed as JSON into feed. With a token set,
+// requests must carry it as "Authorization: Bearer <token>".
+func Handler(feed *Feed, token string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, "POST notifications as JSON", http.StatusMethodNotAllowed)
+			return
+		}
+		if token != "" {
+			got, _ := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+				http.Error(w, "invalid token", http.StatusUnauthorized)
+				return
+			}
+		}
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBody))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+			return
+		}
+		list, err := Parse(body, time.Now().UTC())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		feed.Add(list...)
+		w.WriteHeader(http.StatusAccepted)
+	})
+}
+
+// Listen serves Handler on addr, e.g. "127.0.0.1:8089", and returns its feed.
+// It fails right away when the address cannot be bound.
+func Listen(addr, token string) (*Feed, error) {
+	if addr == "" {
+		return nil, errors.New("no listen address")
+	}
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen for events on %s: %w", addr, err)
+	}
+	feed := &Feed{Addr: ln.Addr().String()}
+	srv := &http.Server{Handler: Handler(feed, token), ReadHeaderTimeout: 10 * time.Second}
+	// The listener lives as long as the process.
+	go func() { _ = srv.Serve(ln) }()
+	return feed, nil
+}
@@ -0,0 +1,243 @@
+package events
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/theme"
+	"ostui/internal/ui/uiconst"
+)
+
+// refreshInterval is how often the view picks up new events.
+var refreshInterval = time.Second
+
+// viewTickMsg picks up the events received since the last refresh.
+type viewTickMsg struct{}
+
+// EventsModel shows the feed newest first. A filter keeps the events whose
+// type, publisher, resource or project contain every word of it.
+type EventsModel struct {
+	table  table.Model
+	feed   *Feed
+	shown  []Event
+	total  int
+	filter textinput.Model
+	// filtering is set while the filter is edited.
+	filtering bool
+	// paused freezes the list so rows do not move while reading them.
+	paused bool
+	// detail shows the payload of the selected event.
+	detail bool
+
+	width  int
+	height int
+}
+
+// NewEventsModel creates the view of feed, which is nil when no listener
+// runs.
+func NewEventsModel(feed *Feed) EventsModel {
+	ti := textinput.New()
+	ti.Placeholder = "e.g. instance.create error"
+	ti.Prompt = "/"
+	m := EventsModel{feed: feed, filter: ti, width: 120, height: 30}
+	m.refresh()
+	return m
+}
+
+func tick() tea.Cmd {
+	return tea.Tick(refreshInterval, func(time.Time) tea.Msg { return viewTickMsg{} })
+}
+
+// Init starts picking up new events.
+func (m EventsModel) Init() tea.Cmd {
+	if m.feed == nil {
+		return nil
+	}
+	return tick()
+}
+
+// Update handles messages for the model.
+func (m EventsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case viewTickMsg:
+		if !m.paused {
+			m.refresh()
+		}
+		return m, tick()
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.refresh()
+		return m, nil
+	case tea.KeyMsg:
+		if m.feed == nil {
+			return m, nil
+		}
+		if m.filtering {
+			switch msg.String() {
+			case "enter":
+				m.filtering = false
+				m.filter.Blur()
+			case "esc":
+				m.filtering = false
+				m.filter.Blur()
+				m.filter.SetValue("")
+			default:
+				var cmd tea.Cmd
+				m.filter, cmd = m.filter.Update(msg)
+				m.refresh()
+				return m, cmd
+			}
+			m.refresh()
+			return m, nil
+		}
+		switch msg.String() {
+		case "/":
+			m.filtering = true
+			m.filter.Focus()
+			return m, textinput.Blink
+		case "p", " ":
+			m.paused = !m.paused
+			m.refresh()
+			return m, nil
+		case "x":
+			m.feed.Clear()
+			m.refresh()
+			return m, nil
+		case "enter":
+			m.detail = !m.detail
+			m.refresh()
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.table, cmd = m.table.Update(msg)
+		return m, cmd
+	}
+	return m, nil
+}
+
+// matches reports whether e contains every word of the filter.
+func matches(e Event, words []string) bool {
+	hay := strings.ToLower(strings.Join([]string{e.Type, e.Publisher, e.Priority, e.ResourceID, e.ResourceName, e.ProjectID}, " "))
+	for _, w := range words {
+		if !strings.Contains(hay, w) {
+			return false
+		}
+	}
+	return true
+}
+
+// refresh reloads the feed and rebuilds the table. The cursor stays on the
+// same event as new ones arrive above it.
+func (m *EventsModel) refresh() {
+	var all []Event
+	seen := m.total
+	if m.feed != nil {
+		all, m.total = m.feed.Snapshot()
+	}
+	words := strings.Fields(strings.ToLower(m.filter.Value()))
+	// arrived counts the shown events added since the last refresh.
+	arrived := 0
+	m.shown = nil
+	for i := len(all) - 1; i >= 0; i-- {
+		if matches(all[i], words) {
+			m.shown = append(m.shown, all[i])
+			if len(all)-i <= m.total-seen {
+				arrived++
+			}
+		}
+	}
+
+	timeW, prioW, typeW, pubW := 19, 8, 32, 24
+	rest := m.width - timeW - prioW - typeW - pubW - uiconst.TableHeightOffset
+	if rest < 40 {
+		rest = 40
+	}
+	cols := []table.Column{{Title: i18n.T("Time"), Width: timeW}, {Title: i18n.T("Priority"), Width: prioW}, {Title: i18n.T("Event"), Width: typeW},
+		{Title: i18n.T("Publisher"), Width: pubW}, {Title: i18n.T("Resource"), Width: rest}}
+	rows := make([]table.Row, 0, len(m.shown))
+	for _, e := range m.shown {
+		res := e.ResourceID
+		if e.ResourceName != "" {
+			res = e.ResourceName + " (" + e.ResourceID + ")"
+		}
+		rows = append(rows, table.Row{e.Time.Local().Format("2006-01-02 15:04:05"), e.Priority, e.Type, e.Publisher, res})
+	}
+	cursor := m.table.Cursor()
+	// Keep the cursor on its event unless it is on the newest one, which
+	// follows the feed.
+	if cursor > 0 {
+		cursor += arrived
+	}
+	height := m.height - uiconst.TableHeightOffset - 3
+	if m.detail {
+		height /= 2
+	}
+	m.table = table.New(table.WithColumns(cols), table.WithFocused(true))
+	m.table.SetStyles(table.DefaultStyles())
+	m.table.SetRows(rows)
+	m.table.SetHeight(height)
+	if cursor < len(rows) {
+		m.table.SetCursor(cursor)
+	}
+}
+
+// payload renders the payload of the selected event, indented.
+func (m EventsModel) payload() string {
+	i := m.table.Cursor()
+	if i < 0 || i >= len(m.shown) {
+		return ""
+	}
+	var b bytes.Buffer
+	if json.Indent(&b, m.shown[i].Payload, "", "  ") != nil {
+		return string(m.shown[i].Payload)
+	}
+	lines := strings.Split(b.String(), "\n")
+	if limit := m.height / 2; limit > 0 && len(lines) > limit {
+		lines = append(lines[:limit], i18n.T("… %d more lines", len(lines)-limit))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// View renders the feed.
+func (m EventsModel) View() string {
+	dim := lipgloss.NewStyle().Foreground(theme.Muted)
+	if m.feed == nil {
+		return i18n.T("No event listener is running.") + "\n" +
+			dim.Render(i18n.T("Start ostui with --events-listen 127.0.0.1:8089 and POST OpenStack notifications to it as JSON."))
+	}
+	status := i18n.T("Listening on %s · %d received · %d shown", m.feed.Addr, m.total, len(m.shown))
+	if m.paused {
+		status += " " + i18n.T("· paused")
+	}
+	out := dim.Render(status) + "\n"
+	if m.filtering || m.filter.Value() != "" {
+		out += m.filter.View() + "\n"
+	}
+	out += m.table.View()
+	if m.detail {
+		out += "\n" + m.payload()
+	}
+	if m.filtering {
+		return out + "\n" + i18n.T("[enter] keep filter  [esc] clear")
+	}
+	pause := i18n.T("[p] pause")
+	if m.paused {
+		pause = i18n.T("[p] resume")
+	}
+	return out + "\n" + i18n.T("[/] filter") + "  " + pause + "  " + i18n.T("[enter] payload  [x] clear")
+}
+
+// CapturingInput reports whether the filter is being edited.
+func (m EventsModel) CapturingInput() bool { return m.filtering }
+
+// Table returns the events table.
+func (m EventsModel) Table() table.Model { return m.table }
+
+var _ tea.Model = (*EventsModel)(nil)
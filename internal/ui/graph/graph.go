@@ -4,13 +4,18 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/layer3/floatingips"
 	"ostui/internal/client"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/common"
 	"ostui/internal/ui/compute"
+	"ostui/internal/ui/theme"
 )
 
 type ResourceType string
@@ -39,13 +44,28 @@ type GraphModel struct {
 	content      string
 	spinner      spinner.Model
 	viewport     viewport.Model
+	// Auto-refresh rebuilds the graph on an interval and marks the nodes
+	// that changed; nodes are those of the last build.
+	autoRefresh bool
+	refreshing  bool
+	refreshSeq  int
+	refreshErr  error
+	nodes       map[string]common.Node
+	changes     common.Changes
+	flash       int
+	flashSeq    int
 }
 
 type graphDataMsg struct {
 	content string
+	nodes   map[string]common.Node
 	err     error
 }
 
+// graphRefreshTickMsg starts an auto-refresh; seq drops the ticks of an
+// auto-refresh that was switched off.
+type graphRefreshTickMsg struct{ seq int }
+
 func NewGraphModel(rt ResourceType, id, name string,
 	cc client.ComputeClient, nc client.NetworkClient,
 	sc client.StorageClient, lbc client.LoadBalancerClient) GraphModel {
@@ -59,24 +79,37 @@ func NewGraphModel(rt ResourceType, id, name string,
 }
 
 func (m GraphModel) Init() tea.Cmd {
-	return tea.Batch(m.spinner.Tick, func() tea.Msg {
-		content, err := m.buildGraph()
-		return graphDataMsg{content: content, err: err}
-	})
+	return tea.Batch(m.spinner.Tick, m.buildCmd())
+}
+
+// buildCmd builds the graph in the background.
+func (m GraphModel) buildCmd() tea.Cmd {
+	return func() tea.Msg {
+		content, nodes, err := m.buildGraph()
+		return graphDataMsg{content: content, nodes: nodes, err: err}
+	}
+}
+
+// buildGraph renders the graph and returns the nodes it shows; nodes new
+// or changed since the previous build are marked.
+func (m GraphModel) buildGraph() (string, map[string]common.Node, error) {
+	nb := &nodeBoxes{prev: m.nodes, cur: map[string]common.Node{}}
+	content, err := m.render(nb)
+	return content, nb.cur, err
 }
 
-func (m GraphModel) buildGraph() (string, error) {
+func (m GraphModel) render(nb *nodeBoxes) (string, error) {
 	boxStyle := lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).Padding(0, 1)
-	centerStyle := boxStyle.BorderForeground(lipgloss.Color("#5CB85C"))
-	portStyle := boxStyle.BorderForeground(lipgloss.Color("#F0AD4E"))
-	netStyle := boxStyle.BorderForeground(lipgloss.Color("#5BC0DE"))
+	centerStyle := boxStyle.BorderForeground(theme.OK)
+	portStyle := boxStyle.BorderForeground(theme.Warn)
+	netStyle := boxStyle.BorderForeground(theme.Info)
 	volStyle := boxStyle.BorderForeground(lipgloss.Color("#9B59B6"))
-	fipStyle := boxStyle.BorderForeground(lipgloss.Color("#E74C3C"))
+	fipStyle := boxStyle.BorderForeground(theme.Error)
 	lbStyle := boxStyle.BorderForeground(lipgloss.Color("#1ABC9C"))
 
 	switch m.resourceType {
 	case ResourceServer:
-		centerBox := centerStyle.Render(fmt.Sprintf("Server\n%s", m.resourceName))
+		centerBox := nb.box(centerStyle, "self", "Server "+m.resourceName, "", i18n.T("Server\n%s", m.resourceName))
 		var row []string
 		ifaces, err := m.compute.ListServerInterfaces(context.Background(), m.resourceID)
 		if err == nil && len(ifaces) > 0 {
@@ -85,14 +118,14 @@ func (m GraphModel) buildGraph() (string, error) {
 			var fipBoxes []string
 			fips, _ := m.network.ListFloatingIPs()
 			for _, iface := range ifaces {
-				portBoxes = append(portBoxes, portStyle.Render(fmt.Sprintf("Port\n%s", strings.Join(iface.FixedIPs, ","))))
+				portBoxes = append(portBoxes, nb.box(portStyle, "port:"+iface.PortID, "Port "+strings.Join(iface.FixedIPs, ","), "", i18n.T("Port\n%s", strings.Join(iface.FixedIPs, ","))))
 				net, _ := m.network.GetNetwork(context.Background(), iface.NetworkID)
 				if net != nil {
-					netBoxes = append(netBoxes, netStyle.Render(fmt.Sprintf("Net\n%s", net.Name)))
+					netBoxes = append(netBoxes, nb.box(netStyle, "network:"+net.ID, "Network "+net.Name, net.Status, fmt.Sprintf("Net\n%s", net.Name)))
 				}
 				for _, fip := range fips {
 					if fip.PortID == iface.PortID {
-						fipBoxes = append(fipBoxes, fipStyle.Render(fmt.Sprintf("FIP\n%s", fip.FloatingIP)))
+						fipBoxes = append(fipBoxes, nb.box(fipStyle, "fip:"+fip.ID, "FIP "+fip.FloatingIP, fip.Status, fmt.Sprintf("FIP\n%s", fip.FloatingIP)))
 					}
 				}
 			}
@@ -111,44 +144,114 @@ func (m GraphModel) buildGraph() (string, error) {
 		if len(vols) > 0 {
 			var volBoxes []string
 			for _, v := range vols {
-				volBoxes = append(volBoxes, volStyle.Render(fmt.Sprintf("Vol\n%s", v.Device)))
+				volBoxes = append(volBoxes, nb.box(volStyle, "volume:"+v.VolumeID, "Volume "+v.Device, "", fmt.Sprintf("Vol\n%s", v.Device)))
 			}
 			sb.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, volBoxes...) + "\n  │\n")
 		}
 		sb.WriteString(lipgloss.JoinHorizontal(lipgloss.Center, row...))
 		return sb.String(), nil
 	case ResourceNetwork:
-		centerBox := centerStyle.Render(fmt.Sprintf("Network\n%s", m.resourceName))
+		centerBox := nb.box(centerStyle, "self", "Network "+m.resourceName, "", i18n.T("Network\n%s", m.resourceName))
 		var row []string
 		row = append(row, centerBox)
 		ports, err := m.network.ListPortsByNetwork(context.Background(), m.resourceID)
 		if err == nil && len(ports) > 0 {
 			var portBoxes []string
 			for _, p := range ports[:min(5, len(ports))] {
-				portBoxes = append(portBoxes, portStyle.Render(fmt.Sprintf("Port\n%s", p.MACAddress)))
+				portBoxes = append(portBoxes, nb.box(portStyle, "port:"+p.ID, "Port "+p.MACAddress, p.Status, i18n.T("Port\n%s", p.MACAddress)))
 			}
 			row = append(row, " ── ", lipgloss.JoinVertical(lipgloss.Left, portBoxes...))
 		}
 		return lipgloss.JoinHorizontal(lipgloss.Center, row...), nil
 	case ResourceVolume:
-		centerBox := centerStyle.Render(fmt.Sprintf("Volume\n%s", m.resourceName))
-		var row []string
-		row = append(row, centerBox)
 		vol, err := m.storage.GetVolume(m.resourceID)
-		if err == nil {
-			for _, att := range vol.Attachments {
-				srv, err := m.compute.GetInstance(att.ServerID)
-				if err == nil {
-					row = append(row, " ── ", centerStyle.Render(fmt.Sprintf("Server\n%s", srv.Name)))
-				}
+		if err != nil || len(vol.Attachments) == 0 {
+			return nb.box(centerStyle, "self", "Volume "+m.resourceName, vol.Status, i18n.T("Volume\n%s", m.resourceName)), nil
+		}
+		label := i18n.T("Volume\n%s", m.resourceName)
+		if len(vol.Attachments) > 1 {
+			label += "\n" + i18n.T("MULTI-ATTACH ×%d", len(vol.Attachments))
+		}
+		// Every attachment is shown, one per line; servers that cannot be
+		// resolved still appear by ID so none is silently hidden.
+		var servers []string
+		for _, att := range vol.Attachments {
+			name, status := att.ServerID, ""
+			if srv, err := m.compute.GetInstance(att.ServerID); err == nil {
+				name, status = srv.Name, srv.Status
 			}
+			servers = append(servers, nb.box(centerStyle, "server:"+att.ServerID, "Server "+name, status, i18n.T("Server\n%s\n%s", name, att.Device)))
 		}
-		return lipgloss.JoinHorizontal(lipgloss.Center, row...), nil
+		return lipgloss.JoinHorizontal(lipgloss.Center, nb.box(centerStyle, "self", "Volume "+m.resourceName, vol.Status, label), " ── ", lipgloss.JoinVertical(lipgloss.Left, servers...)), nil
 	case ResourceFloatingIP:
-		centerBox := fipStyle.Render(fmt.Sprintf("FloatingIP\n%s", m.resourceName))
-		return centerBox, nil
+		// The north-south path, from the external network down to the
+		// server: each hop that cannot be resolved ends the path with a note.
+		ctx := context.Background()
+		fips, err := m.network.ListFloatingIPs()
+		if err != nil {
+			return "", err
+		}
+		var fip *floatingips.FloatingIP
+		for i := range fips {
+			if fips[i].ID == m.resourceID {
+				fip = &fips[i]
+				break
+			}
+		}
+		if fip == nil {
+			return nb.box(fipStyle, "self", "FIP "+m.resourceName, "", i18n.T("FloatingIP\n%s", m.resourceName)), nil
+		}
+		var hops []string
+		extName := fip.FloatingNetworkID
+		if net, err := m.network.GetNetwork(ctx, fip.FloatingNetworkID); err == nil && net != nil {
+			extName = net.Name
+		}
+		hops = append(hops, nb.box(netStyle, "network:"+fip.FloatingNetworkID, "Network "+extName, "", i18n.T("External network\n%s", extName)))
+		hops = append(hops, nb.box(fipStyle, "self", "FIP "+fip.FloatingIP, fip.Status, i18n.T("FloatingIP\n%s\n%s", fip.FloatingIP, theme.Mark(fip.Status))))
+		if fip.PortID == "" {
+			return joinPath(append(hops, i18n.T("(not associated with a port)"))), nil
+		}
+		if fip.RouterID != "" {
+			name, status := fip.RouterID, ""
+			if r, err := m.network.GetRouter(ctx, fip.RouterID); err == nil && r != nil {
+				status = r.Status
+				if r.Name != "" {
+					name = r.Name
+				}
+			}
+			hops = append(hops, nb.box(centerStyle, "router:"+fip.RouterID, "Router "+name, status, i18n.T("Router\n%s\nNAT %s → %s", name, fip.FloatingIP, fip.FixedIP)))
+		}
+		port, err := m.network.GetPort(ctx, fip.PortID)
+		if err != nil || port == nil {
+			return joinPath(append(hops, i18n.T("(port %s not found)", fip.PortID))), nil
+		}
+		netLabel := port.NetworkID
+		if net, err := m.network.GetNetwork(ctx, port.NetworkID); err == nil && net != nil {
+			netLabel = net.Name
+		}
+		for _, ip := range port.FixedIPs {
+			if ip.IPAddress != fip.FixedIP {
+				continue
+			}
+			if sub, err := m.network.GetSubnet(ctx, ip.SubnetID); err == nil && sub != nil {
+				netLabel += fmt.Sprintf("\n%s %s", sub.Name, sub.CIDR)
+			}
+		}
+		hops = append(hops, nb.box(netStyle, "network:"+port.NetworkID, "Network "+netLabel, "", i18n.T("Network\n%s", netLabel)))
+		hops = append(hops, nb.box(portStyle, "port:"+port.ID, "Port "+fip.FixedIP, port.Status, i18n.T("Port\n%s\n%s", fip.FixedIP, port.MACAddress)))
+		switch {
+		case strings.HasPrefix(port.DeviceOwner, "compute:"):
+			name, status := port.DeviceID, ""
+			if srv, err := m.compute.GetInstance(port.DeviceID); err == nil {
+				name, status = srv.Name, srv.Status
+			}
+			hops = append(hops, nb.box(centerStyle, "server:"+port.DeviceID, "Server "+name, status, i18n.T("Server\n%s", name)))
+		case port.DeviceOwner != "":
+			hops = append(hops, nb.box(lbStyle, "device:"+port.DeviceID, port.DeviceOwner+" "+port.DeviceID, "", fmt.Sprintf("%s\n%s", port.DeviceOwner, port.DeviceID)))
+		}
+		return joinPath(hops), nil
 	case ResourceLoadBalancer:
-		centerBox := lbStyle.Render(fmt.Sprintf("LoadBalancer\n%s", m.resourceName))
+		centerBox := nb.box(lbStyle, "self", "LoadBalancer "+m.resourceName, "", i18n.T("LoadBalancer\n%s", m.resourceName))
 		var sb strings.Builder
 		sb.WriteString(centerBox)
 		if m.lb != nil {
@@ -156,7 +259,7 @@ func (m GraphModel) buildGraph() (string, error) {
 			if err == nil && len(listeners) > 0 {
 				var lBoxes []string
 				for _, l := range listeners {
-					lBoxes = append(lBoxes, portStyle.Render(fmt.Sprintf("Listener\n%s:%d", l.Protocol, l.ProtocolPort)))
+					lBoxes = append(lBoxes, nb.box(portStyle, "listener:"+l.ID, i18n.T("Listener %s:%d", l.Protocol, l.ProtocolPort), l.ProvisioningStatus, i18n.T("Listener\n%s:%d", l.Protocol, l.ProtocolPort)))
 				}
 				sb.WriteString("\n  │\n")
 				sb.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, lBoxes...))
@@ -165,7 +268,7 @@ func (m GraphModel) buildGraph() (string, error) {
 			if err == nil && len(pools) > 0 {
 				var pBoxes []string
 				for _, p := range pools {
-					pBoxes = append(pBoxes, netStyle.Render(fmt.Sprintf("Pool\n%s", p.Name)))
+					pBoxes = append(pBoxes, nb.box(netStyle, "pool:"+p.ID, "Pool "+p.Name, p.ProvisioningStatus, i18n.T("Pool\n%s", p.Name)))
 				}
 				sb.WriteString("\n  │\n")
 				sb.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, pBoxes...))
@@ -173,8 +276,20 @@ func (m GraphModel) buildGraph() (string, error) {
 		}
 		return sb.String(), nil
 	default:
-		return fmt.Sprintf("Graph not available for %s", m.resourceType), nil
+		return i18n.T("Graph not available for %s", m.resourceType), nil
+	}
+}
+
+// joinPath stacks the hops of a path top to bottom.
+func joinPath(hops []string) string {
+	var parts []string
+	for i, h := range hops {
+		if i > 0 {
+			parts = append(parts, "  │")
+		}
+		parts = append(parts, h)
 	}
+	return lipgloss.JoinVertical(lipgloss.Left, parts...)
 }
 
 func min(a, b int) int {
@@ -187,10 +302,44 @@ func min(a, b int) int {
 func (m GraphModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case graphDataMsg:
+		var cmds []tea.Cmd
+		if m.refreshing {
+			m.refreshing = false
+			if m.autoRefresh {
+				seq := m.refreshSeq
+				cmds = append(cmds, tea.Tick(common.AutoRefreshInterval, func(time.Time) tea.Msg { return graphRefreshTickMsg{seq: seq} }))
+			}
+			m.refreshErr = msg.err
+			if msg.err != nil {
+				return m, tea.Batch(cmds...)
+			}
+			m.changes = common.DiffNodes(m.nodes, msg.nodes)
+			if !m.changes.Empty() {
+				m.flash = common.FlashFrames
+				m.flashSeq++
+				cmds = append(cmds, common.FlashCmd(m.flashSeq))
+			}
+		}
 		m.loading = false
 		m.err = msg.err
-		m.content = msg.content
+		m.nodes = msg.nodes
+		m.content = msg.content + removedNodes(m.changes)
 		m.viewport.SetContent(m.content)
+		return m, tea.Batch(cmds...)
+	case graphRefreshTickMsg:
+		if !m.autoRefresh || msg.seq != m.refreshSeq || m.refreshing {
+			return m, nil
+		}
+		m.refreshing = true
+		return m, m.buildCmd()
+	case common.FlashMsg:
+		if msg.Seq != m.flashSeq || m.flash == 0 {
+			return m, nil
+		}
+		m.flash--
+		if m.flash > 0 {
+			return m, common.FlashCmd(m.flashSeq)
+		}
 		return m, nil
 	case tea.WindowSizeMsg:
 		m.viewport.Width = msg.Width
@@ -201,6 +350,20 @@ func (m GraphModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		switch msg.String() {
 		case "g", "esc":
 			return m, func() tea.Msg { return compute.GoBackMsg{} }
+		case "a":
+			m.autoRefresh = !m.autoRefresh
+			m.refreshSeq++
+			if m.autoRefresh {
+				seq := m.refreshSeq
+				return m, tea.Tick(common.AutoRefreshInterval, func(time.Time) tea.Msg { return graphRefreshTickMsg{seq: seq} })
+			}
+			return m, nil
+		case "r":
+			if m.loading || m.refreshing {
+				return m, nil
+			}
+			m.refreshing = true
+			return m, m.buildCmd()
 		default:
 			var cmd tea.Cmd
 			m.viewport, cmd = m.viewport.Update(msg)
@@ -221,9 +384,31 @@ func (m GraphModel) View() string {
 		return m.spinner.View()
 	}
 	if m.err != nil {
-		return fmt.Sprintf("Error: %s", m.err)
+		return i18n.T("Error: %s", m.err)
+	}
+	return m.viewport.View() + "\n" + m.statusLine()
+}
+
+// statusLine shows the auto-refresh state and the changes of the last
+// refresh, blinking for a moment after it.
+func (m GraphModel) statusLine() string {
+	status := i18n.T("[a] auto-refresh  [r] refresh  [g] close")
+	if m.autoRefresh {
+		status += lipgloss.NewStyle().Foreground(theme.Info).Render(i18n.T("  ⟳ every %s", common.AutoRefreshInterval))
+	}
+	switch {
+	case m.refreshing:
+		status += "  " + i18n.T("refreshing…")
+	case m.refreshErr != nil:
+		status += lipgloss.NewStyle().Foreground(theme.Error).Render("  refresh failed: " + m.refreshErr.Error())
+	case !m.changes.Empty():
+		style := lipgloss.NewStyle().Foreground(theme.Warn)
+		if m.flash%2 == 1 {
+			style = style.Reverse(true)
+		}
+		status += "  " + style.Render("Δ "+m.changes.Summary())
 	}
-	return m.viewport.View()
+	return status
 }
 
 var _ tea.Model = (*GraphModel)(nil)
@@ -0,0 +1,88 @@
+package graph
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"ostui/internal/demo"
+)
+
+func TestFloatingIPPath(t *testing.T) {
+	c := demo.New(1, demo.DefaultSize)
+	fips, _ := c.Network().ListFloatingIPs()
+	var associated, free string
+	for _, f := range fips {
+		if f.PortID != "" && associated == "" {
+			associated = f.ID
+		} else if f.PortID == "" && free == "" {
+			free = f.ID
+		}
+	}
+	if associated == "" || free == "" {
+		t.Skip("demo data has no associated and free floating IPs")
+	}
+	m := NewGraphModel(ResourceFloatingIP, associated, "", c.Compute(), c.Network(), c.Storage(), nil)
+	out, _, err := m.buildGraph()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"External network", "FloatingIP", "Router", "NAT", "Network", "Port", "Server"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in the path:\n%s", want, out)
+		}
+	}
+	if strings.Index(out, "External network") > strings.Index(out, "Server") {
+		t.Errorf("expected the path to run from the external network down to the server:\n%s", out)
+	}
+
+	m = NewGraphModel(ResourceFloatingIP, free, "", c.Compute(), c.Network(), c.Storage(), nil)
+	if out, _, _ := m.buildGraph(); !strings.Contains(out, "not associated") || strings.Contains(out, "Router") {
+		t.Errorf("unexpected path of a free floating IP:\n%s", out)
+	}
+}
+
+func TestGraphRefreshMarksChanges(t *testing.T) {
+	c := demo.New(1, demo.DefaultSize)
+	vols, _ := c.Storage().ListVolumes()
+	var vol string
+	for _, v := range vols {
+		if len(v.Attachments) == 1 {
+			vol = v.ID
+			break
+		}
+	}
+	if vol == "" {
+		t.Skip("no attached volume in the demo data")
+	}
+	m := NewGraphModel(ResourceVolume, vol, "data", c.Compute(), c.Network(), c.Storage(), nil)
+	updated, _ := m.Update(m.buildCmd()())
+	m = updated.(GraphModel)
+
+	v, _ := c.Storage().GetVolume(vol)
+	if err := c.Compute().StopInstance(v.Attachments[0].ServerID); err != nil {
+		t.Fatal(err)
+	}
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	m = updated.(GraphModel)
+	updated, _ = m.Update(cmd())
+	m = updated.(GraphModel)
+	if got := m.changes.Summary(); got != "1 changed" {
+		t.Fatalf("summary = %q", got)
+	}
+	if out := m.View(); !strings.Contains(out, "~ Server") || !strings.Contains(out, "(was ACTIVE)") || !strings.Contains(out, "Δ 1 changed") {
+		t.Fatalf("expected the server marked as changed:\n%s", out)
+	}
+
+	if err := c.Compute().DetachVolume(context.Background(), v.Attachments[0].ServerID, vol); err != nil {
+		t.Fatal(err)
+	}
+	updated, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	m = updated.(GraphModel)
+	updated, _ = m.Update(cmd())
+	if out := updated.View(); !strings.Contains(out, "Gone since the last refresh") {
+		t.Fatalf("expected the detached server listed as gone:\n%s", out)
+	}
+}
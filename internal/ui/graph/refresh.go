@@ -0,0 +1,47 @@
+package graph
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/common"
+	"ostui/internal/ui/theme"
+)
+
+// nodeBoxes renders the boxes of a graph and records the node each stands
+// for. Against the previous build, a new node gets a "+" and a green thick
+// border and a node whose status changed a "~", a yellow thick border and
+// its previous status.
+type nodeBoxes struct {
+	prev map[string]common.Node
+	cur  map[string]common.Node
+}
+
+func (b *nodeBoxes) box(style lipgloss.Style, key, label, status, text string) string {
+	b.cur[key] = common.Node{Label: label, Status: status}
+	if b.prev == nil {
+		return style.Render(text)
+	}
+	p, ok := b.prev[key]
+	switch {
+	case !ok:
+		return style.Border(lipgloss.ThickBorder()).BorderForeground(theme.OK).Render("+ " + text)
+	case p.Status != status:
+		return style.Border(lipgloss.ThickBorder()).BorderForeground(theme.Warn).Render("~ " + text + "\n(was " + p.Status + ")")
+	}
+	return style.Render(text)
+}
+
+// removedNodes lists the nodes gone since the previous build.
+func removedNodes(c common.Changes) string {
+	if len(c.Removed) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString("\n\n" + i18n.T("Gone since the last refresh:") + "\n")
+	for _, n := range c.Removed {
+		b.WriteString(lipgloss.NewStyle().Foreground(theme.Error).Render("- "+n.Label) + "\n")
+	}
+	return b.String()
+}
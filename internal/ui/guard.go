@@ -0,0 +1,104 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/theme"
+)
+
+// destructiveConfirmer is implemented by views whose open [y/N] prompt
+// confirms an action that deletes or interrupts something.
+type destructiveConfirmer interface {
+	ConfirmingDestructive() bool
+}
+
+// guardState is the extra step of a destructive confirmation in a red
+// cloud: the y is only passed on to the view once the cloud's name is
+// typed, so a prompt answered by reflex on the wrong cloud does nothing.
+type guardState struct {
+	typed string
+	// wrong is set after enter on a name that does not match.
+	wrong bool
+}
+
+// needsGuard reports whether msg answers a destructive prompt of the
+// current view in a red cloud.
+func (m AppModel) needsGuard(msg tea.KeyMsg) bool {
+	if !theme.Guarded() || msg.String() != "y" || (m.state != stateMain && m.state != stateDetail) {
+		return false
+	}
+	dc, ok := m.viewModel(m.state).(destructiveConfirmer)
+	return ok && dc.ConfirmingDestructive()
+}
+
+// updateGuard handles a key while the cloud's name is asked: enter with the
+// name confirms, esc answers the view's prompt with no.
+func (m AppModel) updateGuard(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	g := *m.guard
+	switch msg.Type {
+	case tea.KeyEnter:
+		if g.typed != m.cloudName {
+			g.wrong = true
+			m.guard = &g
+			return m, nil
+		}
+		m.guard = nil
+		return m.forwardKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	case tea.KeyEsc:
+		m.guard = nil
+		return m.forwardKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	case tea.KeyBackspace:
+		if r := []rune(g.typed); len(r) > 0 {
+			g.typed = string(r[:len(r)-1])
+		}
+	case tea.KeyRunes, tea.KeySpace:
+		g.typed += string(msg.Runes)
+	default:
+		return m, nil
+	}
+	g.wrong = false
+	m.guard = &g
+	return m, nil
+}
+
+// forwardKey sends msg to the current list or detail view.
+func (m AppModel) forwardKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	model := m.viewModel(m.state)
+	if model == nil {
+		return m, nil
+	}
+	model, cmd := model.Update(msg)
+	m.setViewModel(m.state, model)
+	return m, cmd
+}
+
+// guardView renders the name prompt below the view.
+func (m AppModel) guardView() string {
+	if m.guard == nil {
+		return ""
+	}
+	danger := lipgloss.NewStyle().Bold(true).Foreground(theme.Error)
+	out := "\n" + danger.Render(i18n.T("%s is a guarded cloud. Type its name to confirm:", m.cloudName)) + " " + m.guard.typed + "█"
+	if m.guard.wrong {
+		out += "  " + lipgloss.NewStyle().Foreground(theme.Error).Render(i18n.T("does not match"))
+	}
+	return out + "\n" + lipgloss.NewStyle().Foreground(theme.Muted).Render(i18n.T("[enter] confirm  [esc] cancel"))
+}
+
+// cloudBadge renders the cloud's name in its color for the footer, or ""
+// when the cloud has no color.
+func (m AppModel) cloudBadge() string {
+	if theme.Cloud == "" {
+		return ""
+	}
+	return lipgloss.NewStyle().Bold(true).Reverse(true).Foreground(theme.Cloud).Render(" " + m.cloudName + " ")
+}
+
+// borderColor is the color of the layout's borders: the cloud's, or gray.
+func borderColor() lipgloss.Color {
+	if theme.Cloud != "" {
+		return theme.Cloud
+	}
+	return lipgloss.Color("240")
+}
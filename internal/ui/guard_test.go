@@ -0,0 +1,57 @@
+package ui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"ostui/internal/ui/theme"
+)
+
+// promptStub is a view with a delete prompt open, recording its answer.
+type promptStub struct{ answer *string }
+
+func (s promptStub) Init() tea.Cmd               { return nil }
+func (s promptStub) View() string                { return "Delete? [y/N]" }
+func (s promptStub) CapturingInput() bool        { return *s.answer == "" }
+func (s promptStub) ConfirmingDestructive() bool { return *s.answer == "" }
+func (s promptStub) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if k, ok := msg.(tea.KeyMsg); ok && *s.answer == "" {
+		*s.answer = k.String()
+	}
+	return s, nil
+}
+
+func TestGuardedConfirmation(t *testing.T) {
+	t.Cleanup(func() { _ = theme.SetCloudColor("") })
+	var answer string
+	newApp := func() tea.Model {
+		answer = ""
+		return AppModel{cloudName: "prod", state: stateDetail, detailModel: promptStub{answer: &answer}}
+	}
+
+	// Other clouds confirm with y alone.
+	if typeKeys(newApp(), "y"); answer != "y" {
+		t.Fatalf("expected y to reach the view, got %q", answer)
+	}
+
+	if err := theme.SetCloudColor("red"); err != nil {
+		t.Fatal(err)
+	}
+	m := typeKeys(newApp(), "y")
+	if answer != "" || m.(AppModel).guard == nil {
+		t.Fatalf("expected the cloud name to be asked first, got %q", answer)
+	}
+	m = typeKeys(m, "p", "r", "o", "enter")
+	if answer != "" || !m.(AppModel).guard.wrong {
+		t.Fatalf("a wrong name must not confirm, got %q", answer)
+	}
+	m = typeKeys(m, "d", "enter")
+	if answer != "y" || m.(AppModel).guard != nil {
+		t.Fatalf("expected the y passed on after the name, got %q", answer)
+	}
+
+	m = typeKeys(newApp(), "y", "esc")
+	if answer != "n" || m.(AppModel).guard != nil {
+		t.Fatalf("esc should answer the prompt with no, got %q", answer)
+	}
+}
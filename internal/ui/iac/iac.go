@@ -0,0 +1,265 @@
+// Package iac renders a resource as an approximate Terraform configuration
+// or as the openstack CLI commands that would recreate it, a starting point
+// for moving resources created by hand into code. The output is a skeleton
+// to review: what the API does not return, such as user data or passwords,
+// is left out. Detail views opt in by implementing Exportable; the app opens
+// the export on ctrl+x.
+package iac
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"ostui/internal/tfstate"
+)
+
+// Ref is an HCL expression written as is, e.g. a reference to another
+// resource of the export.
+type Ref string
+
+// Attr is an attribute of a block. Values are strings, ints, bools, Refs,
+// string slices or string maps; empty strings, slices and maps are left out.
+type Attr struct {
+	Name  string
+	Value any
+}
+
+// Block is a resource, or a nested block when it has no Name, e.g. the
+// network block of a server.
+type Block struct {
+	Type string
+	Name string
+	// ID is the OpenStack ID of a resource, written as an import block so
+	// that Terraform adopts the resource rather than creating another.
+	ID     string
+	Attrs  []Attr
+	Blocks []Block
+}
+
+// Address returns the Terraform address of the resource, e.g.
+// openstack_networking_network_v2.private.
+func (b Block) Address() string { return b.Type + "." + b.Name }
+
+// Code is an exported resource with the resources it depends on.
+type Code struct {
+	Resources []Block
+	// Commands are openstack CLI command lines creating the same
+	// resources, in order.
+	Commands []string
+}
+
+// Spec describes the export of a resource.
+type Spec struct {
+	// Title names the resource, e.g. "server web-1".
+	Title string
+	// File is the base name the export is written to.
+	File string
+	Load func() (Code, error)
+}
+
+// Exportable is implemented by detail views whose resource can be exported.
+type Exportable interface {
+	ExportSpec() Spec
+}
+
+// HCL renders the resources as Terraform configuration. Each resource is
+// preceded by an import block, or by a comment when the --tfstate index
+// shows it is already managed.
+func HCL(c Code) string {
+	var b strings.Builder
+	for i, r := range c.Resources {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		if r.ID != "" {
+			if addr, ok := tfstate.Active.Address(r.ID); ok {
+				fmt.Fprintf(&b, "# already managed by Terraform as %s\n", addr)
+			} else {
+				fmt.Fprintf(&b, "import {\n  to = %s\n  id = %s\n}\n\n", r.Address(), quote(r.ID))
+			}
+		}
+		fmt.Fprintf(&b, "resource %s %s {\n", quote(r.Type), quote(r.Name))
+		writeBody(&b, r, "  ")
+		b.WriteString("}\n")
+	}
+	return b.String()
+}
+
+// writeBody writes the attributes of blk, aligned as terraform fmt does,
+// then its nested blocks.
+func writeBody(b *strings.Builder, blk Block, indent string) {
+	width := 0
+	for _, a := range blk.Attrs {
+		if !empty(a.Value) && len(a.Name) > width {
+			width = len(a.Name)
+		}
+	}
+	for _, a := range blk.Attrs {
+		if empty(a.Value) {
+			continue
+		}
+		fmt.Fprintf(b, "%s%-*s = %s\n", indent, width, a.Name, value(a.Value, indent))
+	}
+	for _, n := range blk.Blocks {
+		if len(blk.Attrs) > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(b, "%s%s {\n", indent, n.Type)
+		writeBody(b, n, indent+"  ")
+		fmt.Fprintf(b, "%s}\n", indent)
+	}
+}
+
+func empty(v any) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case Ref:
+		return v == ""
+	case []string:
+		return len(v) == 0
+	case map[string]string:
+		return len(v) == 0
+	}
+	return false
+}
+
+// value renders an attribute value; maps span lines below indent.
+func value(v any, indent string) string {
+	switch v := v.(type) {
+	case string:
+		return quote(v)
+	case Ref:
+		return string(v)
+	case []string:
+		items := make([]string, len(v))
+		for i, s := range v {
+			items[i] = quote(s)
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	case map[string]string:
+		keys := make([]string, 0, len(v))
+		width := 0
+		for k := range v {
+			keys = append(keys, k)
+			if len(key(k)) > width {
+				width = len(key(k))
+			}
+		}
+		sort.Strings(keys)
+		out := "{\n"
+		for _, k := range keys {
+			out += fmt.Sprintf("%s  %-*s = %s\n", indent, width, key(k), quote(v[k]))
+		}
+		return out + indent + "}"
+	}
+	return fmt.Sprint(v)
+}
+
+// quote renders s as an HCL string. HCL knows fewer escapes than Go: \n,
+// \r, \t, \" and \\; other control characters are written as \uNNNN.
+// "${" and "%{" would start a template and are doubled.
+func quote(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for i, r := range s {
+		switch {
+		case r == '\n':
+			b.WriteString(`\n`)
+		case r == '\r':
+			b.WriteString(`\r`)
+		case r == '\t':
+			b.WriteString(`\t`)
+		case r == '"' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case (r == '$' || r == '%') && strings.HasPrefix(s[i+1:], "{"):
+			b.WriteRune(r)
+			b.WriteRune(r)
+		case unicode.IsControl(r):
+			fmt.Fprintf(&b, `\u%04X`, r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// key renders a map key, quoted unless it is an identifier.
+func key(k string) string {
+	if k == "" || !isIdent(k) {
+		return quote(k)
+	}
+	return k
+}
+
+func isIdent(s string) bool {
+	for i, r := range s {
+		switch {
+		case r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z'):
+		case i > 0 && (r == '-' || (r >= '0' && r <= '9')):
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// Shell renders the commands as a shell script.
+func Shell(c Code) string {
+	return "#!/bin/sh\nset -e\n\n" + strings.Join(c.Commands, "\n") + "\n"
+}
+
+// Command renders an openstack CLI command line, quoting the arguments
+// that need it.
+func Command(args ...string) string {
+	out := make([]string, 0, len(args)+1)
+	out = append(out, "openstack")
+	for _, a := range args {
+		out = append(out, shellQuote(a))
+	}
+	return strings.Join(out, " ")
+}
+
+func shellQuote(s string) string {
+	if s != "" && strings.Trim(s, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_./:=,@+") == "" {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// Namer hands out resource names, unique per resource type.
+type Namer map[string]bool
+
+// Name turns name into a Terraform identifier not yet used for typ, e.g.
+// "web-1.example" into web-1_example, falling back to fallback when name
+// has nothing usable.
+func (n Namer) Name(typ, name, fallback string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case r == '_' || r == '-' || (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9'):
+			b.WriteRune(r)
+		default:
+			b.WriteRune('_')
+		}
+	}
+	base := strings.Trim(b.String(), "_-")
+	if base == "" {
+		base = fallback
+	}
+	if c := base[0]; c == '-' || (c >= '0' && c <= '9') {
+		base = "r_" + base
+	}
+	id := base
+	for i := 2; n[typ+"."+id]; i++ {
+		id = fmt.Sprintf("%s_%d", base, i)
+	}
+	n[typ+"."+id] = true
+	return id
+}
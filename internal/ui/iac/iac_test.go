@@ -0,0 +1,89 @@
+package iac
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHCL(t *testing.T) {
+	code := Code{Resources: []Block{
+		{Type: "openstack_networking_network_v2", Name: "private", ID: "net-1", Attrs: []Attr{
+			{Name: "name", Value: "private"},
+			{Name: "description", Value: ""},
+			{Name: "admin_state_up", Value: true},
+		}},
+		{Type: "openstack_compute_instance_v2", Name: "web", Attrs: []Attr{
+			{Name: "name", Value: "web ${env}"},
+			{Name: "security_groups", Value: []string{"default", "web"}},
+			{Name: "metadata", Value: map[string]string{"role": "web", "app.tier": "front"}},
+		}, Blocks: []Block{
+			{Type: "network", Attrs: []Attr{{Name: "uuid", Value: Ref("openstack_networking_network_v2.private.id")}}},
+		}},
+	}}
+	want := `import {
+  to = openstack_networking_network_v2.private
+  id = "net-1"
+}
+
+resource "openstack_networking_network_v2" "private" {
+  name           = "private"
+  admin_state_up = true
+}
+
+resource "openstack_compute_instance_v2" "web" {
+  name            = "web $${env}"
+  security_groups = ["default", "web"]
+  metadata        = {
+    "app.tier" = "front"
+    role       = "web"
+  }
+
+  network {
+    uuid = openstack_networking_network_v2.private.id
+  }
+}
+`
+	if got := HCL(code); got != want {
+		t.Fatalf("HCL:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestQuoteHCL(t *testing.T) {
+	for in, want := range map[string]string{
+		"line 1\nline 2\tend\r":  `"line 1\nline 2\tend\r"`,
+		`say "hi" C:\tmp`:        `"say \"hi\" C:\\tmp"`,
+		"bell\a esc\x1b del\x7f": `"bell\u0007 esc\u001B del\u007F"`,
+		"${var} %{if} $5 100%":   `"$${var} %%{if} $5 100%"`,
+		"café ✓":                 `"café ✓"`,
+	} {
+		if got := quote(in); got != want {
+			t.Errorf("quote(%q) = %s, want %s", in, got, want)
+		}
+	}
+}
+
+func TestCommandQuotes(t *testing.T) {
+	got := Command("server", "create", "--property", "note=it's", "--name", "web 1", "web-1")
+	want := `openstack server create --property 'note=it'\''s' --name 'web 1' web-1`
+	if got != want {
+		t.Fatalf("Command = %q, want %q", got, want)
+	}
+	if s := Shell(Code{Commands: []string{got}}); !strings.HasPrefix(s, "#!/bin/sh\nset -e\n") {
+		t.Fatalf("Shell = %q", s)
+	}
+}
+
+func TestNamer(t *testing.T) {
+	n := Namer{}
+	for _, c := range []struct{ typ, name, want string }{
+		{"t", "Web.Example", "web_example"},
+		{"t", "web.example", "web_example_2"},
+		{"u", "web.example", "web_example"},
+		{"t", "", "fallback"},
+		{"t", "1st", "r_1st"},
+	} {
+		if got := n.Name(c.typ, c.name, "fallback"); got != c.want {
+			t.Errorf("Name(%q, %q) = %q, want %q", c.typ, c.name, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package iac
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/theme"
+)
+
+type loadedMsg struct {
+	code Code
+	err  error
+}
+
+// Model shows the export of a resource, as Terraform or as CLI commands,
+// and writes it to the current directory.
+type Model struct {
+	spec    Spec
+	code    Code
+	loading bool
+	err     error
+	// cli is set while the CLI commands are shown.
+	cli    bool
+	vp     viewport.Model
+	status string
+}
+
+// New creates the export view of spec.
+func New(spec Spec, width, height int) Model {
+	m := Model{spec: spec, loading: true}
+	m.resize(width, height)
+	return m
+}
+
+// Init loads the resource.
+func (m Model) Init() tea.Cmd {
+	load := m.spec.Load
+	return func() tea.Msg {
+		c, err := load()
+		return loadedMsg{code: c, err: err}
+	}
+}
+
+func (m *Model) resize(width, height int) {
+	m.vp.Width = max(width-4, 20)
+	m.vp.Height = max(height-8, 5)
+}
+
+// Text returns the export in the format shown.
+func (m Model) Text() string {
+	if m.cli {
+		return Shell(m.code)
+	}
+	return HCL(m.code)
+}
+
+// fileName is where the shown format is written.
+func (m Model) fileName() string {
+	if m.cli {
+		return m.spec.File + ".sh"
+	}
+	return m.spec.File + ".tf"
+}
+
+// write saves the export, refusing to overwrite a file.
+func (m Model) write() (string, error) {
+	name := m.fileName()
+	mode := os.FileMode(0o644)
+	if m.cli {
+		mode = 0o755
+	}
+	f, err := os.OpenFile(name, os.O_WRONLY|os.O_CREATE|os.O_EXCL, mode)
+	if errors.Is(err, fs.ErrExist) {
+		return "", fmt.Errorf("%s already exists", name)
+	}
+	if err != nil {
+		return "", err
+	}
+	if _, err := f.WriteString(m.Text()); err != nil {
+		f.Close()
+		return "", err
+	}
+	if err := f.Close(); err != nil {
+		return "", err
+	}
+	return "Wrote " + name, nil
+}
+
+// Update handles scrolling, switching format and writing.
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.resize(msg.Width, msg.Height)
+		return m, nil
+	case loadedMsg:
+		m.loading, m.err, m.code = false, msg.err, msg.code
+		m.vp.SetContent(m.Text())
+		return m, nil
+	case tea.KeyMsg:
+		if m.loading || m.err != nil {
+			return m, nil
+		}
+		switch msg.String() {
+		case "tab":
+			m.cli = !m.cli
+			m.status = ""
+			m.vp.SetContent(m.Text())
+			m.vp.GotoTop()
+			return m, nil
+		case "w":
+			status, err := m.write()
+			if err != nil {
+				status = i18n.T("Error: %s", err)
+			}
+			m.status = status
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.vp, cmd = m.vp.Update(msg)
+		return m, cmd
+	}
+	return m, nil
+}
+
+// View renders the export.
+func (m Model) View() string {
+	title := lipgloss.NewStyle().Bold(true).Foreground(theme.Accent).Render("Export " + m.spec.Title)
+	dim := lipgloss.NewStyle().Foreground(theme.Muted)
+	if m.loading {
+		return title + "\n\n" + i18n.T("Loading…")
+	}
+	if m.err != nil {
+		return title + "\n\n" + lipgloss.NewStyle().Foreground(theme.Error).Render(i18n.T("Error: %s", m.err)) + "\n" + dim.Render(i18n.T("[esc] back"))
+	}
+	format, other := "Terraform", "CLI"
+	if m.cli {
+		format, other = "openstack CLI", "Terraform"
+	}
+	out := title + dim.Render(" · "+format+" · approximate, review before applying") + "\n\n" + m.vp.View() + "\n"
+	if m.status != "" {
+		out += m.status + "\n"
+	}
+	return out + dim.Render(i18n.T("[tab] %s  [w] write %s  [j/k] scroll  [esc] back", other, m.fileName()))
+}
+
+var _ tea.Model = (*Model)(nil)
@@ -0,0 +1,205 @@
+package identity
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/domains"
+	"ostui/internal/client"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/common"
+	"ostui/internal/ui/theme"
+	"ostui/internal/ui/uiconst"
+)
+
+// domainIndex resolves domain IDs to names for the identity views. Listing
+// domains needs the admin role; without it the index stays empty and the
+// views show the IDs.
+type domainIndex struct {
+	list  []domains.Domain
+	names map[string]string
+}
+
+// loaded reports whether the domains were fetched, successfully or not.
+func (d domainIndex) loaded() bool { return d.names != nil }
+
+// loadDomainIndex fetches the domains sorted by name.
+func loadDomainIndex(ic client.IdentityClient) domainIndex {
+	idx := domainIndex{names: map[string]string{}}
+	list, err := ic.ListDomains()
+	if err != nil {
+		return idx
+	}
+	sort.Slice(list, func(i, j int) bool { return strings.ToLower(list[i].Name) < strings.ToLower(list[j].Name) })
+	idx.list = list
+	for _, d := range list {
+		idx.names[d.ID] = d.Name
+	}
+	return idx
+}
+
+// name returns the name of domain id, or the ID when it is unknown.
+func (d domainIndex) name(id string) string {
+	if n, ok := d.names[id]; ok {
+		return n
+	}
+	return id
+}
+
+// label returns "name (id)" for the detail views.
+func (d domainIndex) label(id string) string {
+	if n, ok := d.names[id]; ok && n != id {
+		return fmt.Sprintf("%s (%s)", n, id)
+	}
+	return id
+}
+
+// next cycles the domain filter: all domains, then each domain in turn.
+func (d domainIndex) next(cur string) string {
+	for i, dom := range d.list {
+		if dom.ID == cur {
+			if i+1 < len(d.list) {
+				return d.list[i+1].ID
+			}
+			return ""
+		}
+	}
+	if len(d.list) > 0 {
+		return d.list[0].ID
+	}
+	return ""
+}
+
+// filterLine renders the domain filter, or "" on single-domain clouds.
+func (d domainIndex) filterLine(cur string) string {
+	if len(d.list) < 2 {
+		return ""
+	}
+	shown := "all"
+	if cur != "" {
+		shown = d.name(cur)
+	}
+	return lipgloss.NewStyle().Foreground(theme.Muted).Render(i18n.T("Domain: %s  [D] next domain", shown))
+}
+
+// DomainsModel lists the identity domains.
+type DomainsModel struct {
+	table   table.Model
+	loading bool
+	err     error
+	spinner spinner.Model
+	client  client.IdentityClient
+	width   int
+	height  int
+}
+
+type domainsDataLoadedMsg struct {
+	rows []table.Row
+	err  error
+}
+
+// NewDomainsModel creates a new DomainsModel.
+func NewDomainsModel(ic client.IdentityClient) DomainsModel {
+	s := spinner.New()
+	s.Spinner = spinner.Dot
+	return DomainsModel{client: ic, loading: true, spinner: s, width: 120, height: 30}
+}
+
+// Init starts async loading.
+func (m DomainsModel) Init() tea.Cmd {
+	return func() tea.Msg {
+		list, err := m.client.ListDomains()
+		if err != nil {
+			return domainsDataLoadedMsg{err: err}
+		}
+		sort.Slice(list, func(i, j int) bool { return strings.ToLower(list[i].Name) < strings.ToLower(list[j].Name) })
+		rows := []table.Row{}
+		for _, d := range list {
+			rows = append(rows, table.Row{d.ID, d.Name, fmt.Sprintf("%t", d.Enabled), d.Description})
+		}
+		return domainsDataLoadedMsg{rows: rows}
+	}
+}
+
+// Reload re-fetches the domains, keeping the selected row.
+func (m DomainsModel) Reload() tea.Cmd {
+	if m.loading {
+		return nil
+	}
+	return m.Init()
+}
+
+// Update handles messages.
+func (m DomainsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case domainsDataLoadedMsg:
+		m.loading = false
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		fresh := table.New(table.WithRows(msg.rows), table.WithFocused(true))
+		fresh.SetStyles(table.DefaultStyles())
+		m.table = common.Reloaded(m.table, fresh)
+		m.updateTableColumns()
+		m.table.SetHeight(m.height - uiconst.TableHeightOffset)
+		return m, nil
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		if m.table.Columns() != nil {
+			m.table.SetHeight(m.height - uiconst.TableHeightOffset)
+			m.updateTableColumns()
+		}
+		return m, nil
+	case tea.KeyMsg:
+		if m.loading || m.err != nil {
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.table, cmd = m.table.Update(msg)
+		return m, cmd
+	default:
+		if m.loading {
+			var cmd tea.Cmd
+			m.spinner, cmd = m.spinner.Update(msg)
+			return m, cmd
+		}
+	}
+	return m, nil
+}
+
+// View renders.
+func (m DomainsModel) View() string {
+	if m.loading {
+		return m.spinner.View()
+	}
+	if m.err != nil {
+		cols := []table.Column{{Title: i18n.T("Error"), Width: uiconst.ColWidthError}}
+		rows := []table.Row{{"Failed to list domains: " + m.err.Error()}}
+		return common.NewTable(cols, rows).View()
+	}
+	return m.table.View()
+}
+
+// Table returns the domains table.
+func (m DomainsModel) Table() table.Model { return m.table }
+
+// updateTableColumns adjusts column widths based on the current width.
+func (m *DomainsModel) updateTableColumns() {
+	idW := uiconst.ColWidthUUID
+	nameW := uiconst.ColWidthName
+	enabledW := uiconst.ColWidthEnabled
+	descW := m.width - idW - nameW - enabledW - uiconst.TableHeightOffset
+	if descW < 10 {
+		descW = 10
+	}
+	m.table.SetColumns([]table.Column{{Title: "ID", Width: idW}, {Title: i18n.T("Name"), Width: nameW}, {Title: i18n.T("Enabled"), Width: enabledW}, {Title: i18n.T("Description"), Width: descW}})
+}
+
+var _ tea.Model = (*DomainsModel)(nil)
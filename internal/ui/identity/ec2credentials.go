@@ -0,0 +1,304 @@
+package identity
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"ostui/internal/client"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/common"
+	"ostui/internal/ui/softlock"
+	"ostui/internal/ui/theme"
+	"ostui/internal/ui/uiconst"
+)
+
+// EC2CredentialsModel lists the EC2 credentials of the current user, and
+// creates and deletes them. The secret of the selected credential is masked
+// until revealed.
+type EC2CredentialsModel struct {
+	table    table.Model
+	loading  bool
+	err      error
+	spinner  spinner.Model
+	client   client.IdentityClient
+	userID   string
+	project  string
+	creds    []client.EC2Credential
+	projects map[string]string
+	// guard masks the secret of the selected credential.
+	guard softlock.Guard
+
+	form          *common.FormModel
+	pendingDelete string
+	status        string
+	statusErr     bool
+
+	width  int
+	height int
+}
+
+// NewEC2CredentialsModel creates the EC2 credential list.
+func NewEC2CredentialsModel(ic client.IdentityClient) EC2CredentialsModel {
+	s := spinner.New()
+	s.Spinner = spinner.Dot
+	return EC2CredentialsModel{client: ic, loading: true, spinner: s, guard: softlock.NewGuard(), width: 120, height: 30}
+}
+
+type ec2CredentialsLoadedMsg struct {
+	userID   string
+	project  string
+	creds    []client.EC2Credential
+	projects map[string]string
+	err      error
+}
+
+// Init loads the credentials.
+func (m EC2CredentialsModel) Init() tea.Cmd {
+	return tea.Batch(m.spinner.Tick, m.loadCmd())
+}
+
+// loadCmd lists the credentials of the token's user. Project names only
+// label the rows, so failing to list the projects is ignored.
+func (m EC2CredentialsModel) loadCmd() tea.Cmd {
+	ic := m.client
+	return func() tea.Msg {
+		uid, err := ic.GetTokenUserID()
+		if err != nil {
+			return ec2CredentialsLoadedMsg{err: err}
+		}
+		creds, err := ic.ListEC2Credentials(uid)
+		if err != nil {
+			return ec2CredentialsLoadedMsg{err: err}
+		}
+		msg := ec2CredentialsLoadedMsg{userID: uid, creds: creds, projects: map[string]string{}}
+		if list, err := ic.ListProjects(); err == nil {
+			for _, p := range list {
+				msg.projects[p.ID] = p.Name
+			}
+		}
+		if p, err := ic.GetCurrentProject(); err == nil {
+			msg.project = p.ID
+			msg.projects[p.ID] = p.Name
+		}
+		return msg
+	}
+}
+
+// projectLabel returns "name (id)", or the ID when the name is unknown.
+func (m EC2CredentialsModel) projectLabel(id string) string {
+	if n := m.projects[id]; n != "" && n != id {
+		return fmt.Sprintf("%s (%s)", n, id)
+	}
+	return id
+}
+
+// Update handles messages for the model.
+func (m EC2CredentialsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case ec2CredentialsLoadedMsg:
+		m.loading = false
+		m.err = msg.err
+		if msg.err != nil {
+			return m, nil
+		}
+		m.userID, m.project, m.creds, m.projects = msg.userID, msg.project, msg.creds, msg.projects
+		rows := make([]table.Row, 0, len(m.creds))
+		for _, c := range m.creds {
+			trust := c.TrustID
+			if trust == "" {
+				trust = "-"
+			}
+			rows = append(rows, table.Row{c.Access, m.projectLabel(c.TenantID), trust})
+		}
+		m.table = table.New(table.WithFocused(true))
+		m.table.SetStyles(table.DefaultStyles())
+		m.updateTableColumns()
+		m.table.SetRows(rows)
+		return m, nil
+	case changeDoneMsg:
+		m.status, m.statusErr = msg.status, msg.err != nil
+		if msg.err != nil {
+			m.status = msg.err.Error()
+			return m, nil
+		}
+		return m, m.loadCmd()
+	case softlock.CheckedMsg:
+		var cmd tea.Cmd
+		m.guard, cmd = m.guard.Update(msg)
+		return m, cmd
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		if m.table.Columns() != nil {
+			m.updateTableColumns()
+		}
+		return m, nil
+	case tea.KeyMsg:
+		if m.guard.Prompting() {
+			var cmd tea.Cmd
+			m.guard, cmd = m.guard.Update(msg)
+			return m, cmd
+		}
+		if m.form != nil {
+			return m.updateForm(msg)
+		}
+		if m.pendingDelete != "" {
+			access := m.pendingDelete
+			m.pendingDelete = ""
+			if msg.String() != "y" {
+				return m, nil
+			}
+			ic, uid := m.client, m.userID
+			return m, func() tea.Msg {
+				return changeDoneMsg{status: i18n.T("Deleted EC2 credential %s", access), err: ic.DeleteEC2Credential(uid, access)}
+			}
+		}
+		if m.loading || m.err != nil {
+			return m, nil
+		}
+		switch msg.String() {
+		case "v":
+			if _, ok := m.Selected(); ok {
+				return m, m.guard.Toggle()
+			}
+			return m, nil
+		case "n":
+			f := common.NewForm([]string{i18n.T("Project ID")})
+			f.SetValue(0, m.project)
+			m.form = &f
+			return m, f.Init()
+		case "x":
+			if c, ok := m.Selected(); ok {
+				m.pendingDelete = c.Access
+			}
+			return m, nil
+		case "r":
+			m.loading = true
+			m.guard.Hide()
+			return m, tea.Batch(m.spinner.Tick, m.loadCmd())
+		}
+		// Moving to another credential masks the secret again.
+		var cmd tea.Cmd
+		cursor := m.table.Cursor()
+		m.table, cmd = m.table.Update(msg)
+		if m.table.Cursor() != cursor {
+			m.guard.Hide()
+		}
+		return m, cmd
+	default:
+		if m.loading {
+			var cmd tea.Cmd
+			m.spinner, cmd = m.spinner.Update(msg)
+			return m, cmd
+		}
+	}
+	return m, nil
+}
+
+// updateForm handles keys for the new credential form.
+func (m EC2CredentialsModel) updateForm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	fm, cmd := m.form.Update(msg)
+	f := fm.(common.FormModel)
+	m.form = &f
+	switch {
+	case f.Cancelled():
+		m.form = nil
+	case f.Submitted():
+		projectID := f.Values()[0]
+		if projectID == "" {
+			m.form.SetError(errors.New("the project ID is required"))
+			return m, nil
+		}
+		m.form = nil
+		ic, uid := m.client, m.userID
+		return m, func() tea.Msg {
+			created, err := ic.CreateEC2Credential(uid, projectID)
+			if err != nil {
+				return changeDoneMsg{err: err}
+			}
+			return changeDoneMsg{status: i18n.T("Created EC2 credential %s; reveal the secret with v", created.Access)}
+		}
+	}
+	return m, cmd
+}
+
+// CapturingInput reports whether the form, the delete prompt or the
+// soft-lock prompt is open.
+func (m EC2CredentialsModel) CapturingInput() bool {
+	return m.form != nil || m.pendingDelete != "" || m.guard.Prompting()
+}
+
+// ConfirmingDestructive reports whether the delete prompt is open.
+func (m EC2CredentialsModel) ConfirmingDestructive() bool { return m.pendingDelete != "" }
+
+// Selected returns the credential under the cursor.
+func (m EC2CredentialsModel) Selected() (client.EC2Credential, bool) {
+	row := m.table.SelectedRow()
+	if len(row) == 0 {
+		return client.EC2Credential{}, false
+	}
+	for _, c := range m.creds {
+		if c.Access == row[0] {
+			return c, true
+		}
+	}
+	return client.EC2Credential{}, false
+}
+
+// secretLine renders the selected credential as the environment variables
+// the S3 and EC2 tools read, with the secret masked until revealed.
+func (m EC2CredentialsModel) secretLine() string {
+	c, ok := m.Selected()
+	if !ok {
+		return ""
+	}
+	out := fmt.Sprintf("\nAWS_ACCESS_KEY_ID=%s\nAWS_SECRET_ACCESS_KEY=%s", c.Access, m.guard.Value(c.Secret))
+	if v := m.guard.View(); v != "" {
+		out += "\n" + v
+	}
+	return out
+}
+
+// View renders the list, the form or the delete prompt.
+func (m EC2CredentialsModel) View() string {
+	if m.form != nil {
+		return "New EC2 credential – an access/secret pair for the S3 and EC2 compatibility APIs\n\n" + m.form.View() +
+			"\n" + i18n.T("[enter] create  [esc] cancel")
+	}
+	if m.loading {
+		return m.spinner.View()
+	}
+	if m.err != nil {
+		cols := []table.Column{{Title: i18n.T("Error"), Width: uiconst.ColWidthError}}
+		rows := []table.Row{{"Failed to list EC2 credentials: " + m.err.Error()}}
+		return common.NewTable(cols, rows).View()
+	}
+	out := m.table.View() + m.secretLine()
+	switch {
+	case m.pendingDelete != "":
+		out += "\n" + i18n.T("Delete EC2 credential %s? Clients using it stop working. [y/N]", m.pendingDelete)
+	case m.statusErr:
+		out += "\n" + lipgloss.NewStyle().Foreground(theme.Error).Render(m.status)
+	case m.status != "":
+		out += "\n" + lipgloss.NewStyle().Foreground(theme.OK).Render(m.status)
+	}
+	return out + "\n" + i18n.T("[v] show/hide secret  [n] new credential  [x] delete  [r] refresh")
+}
+
+func (m *EC2CredentialsModel) updateTableColumns() {
+	accessW := 34
+	rest := m.width - accessW - uiconst.TableHeightOffset
+	if rest < 40 {
+		rest = 40
+	}
+	m.table.SetColumns([]table.Column{{Title: i18n.T("Access"), Width: accessW}, {Title: i18n.T("Project"), Width: rest / 2}, {Title: i18n.T("Trust"), Width: rest - rest/2}})
+	m.table.SetHeight(m.height - uiconst.TableHeightOffset - 5)
+}
+
+// Table returns the underlying table.
+func (m EC2CredentialsModel) Table() table.Model { return m.table }
+
+var _ tea.Model = (*EC2CredentialsModel)(nil)
@@ -9,6 +9,10 @@ import (
 
 	"github.com/charmbracelet/bubbles/table"
 	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/domains"
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/extensions/ec2credentials"
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/extensions/trusts"
 	"github.com/gophercloud/gophercloud/openstack/identity/v3/projects"
 	"github.com/gophercloud/gophercloud/openstack/identity/v3/tokens"
 	"github.com/gophercloud/gophercloud/openstack/identity/v3/users"
@@ -23,6 +27,14 @@ type mockIdentityClient struct {
 
 	token    *tokens.Token
 	tokenErr error
+
+	domains   []domains.Domain
+	domainErr error
+
+	trusts  []trusts.Trust
+	created *trusts.CreateOpts
+
+	ec2Creds []ec2credentials.Credential
 }
 
 func (m *mockIdentityClient) ListProjects() ([]projects.Project, error) {
@@ -41,6 +53,60 @@ func (m *mockIdentityClient) ListUsers() ([]users.User, error) {
 func (m *mockIdentityClient) GetTokenInfo() (*tokens.Token, error) {
 	return m.token, m.tokenErr
 }
+func (m *mockIdentityClient) GetTokenRoles() ([]string, error) {
+	return nil, nil
+}
+func (m *mockIdentityClient) ListDomains() ([]domains.Domain, error) {
+	return m.domains, m.domainErr
+}
+func (m *mockIdentityClient) ListProjectsInDomain(domainID string) ([]projects.Project, error) {
+	var out []projects.Project
+	for _, p := range m.projList {
+		if p.DomainID == domainID {
+			out = append(out, p)
+		}
+	}
+	return out, m.projErr
+}
+func (m *mockIdentityClient) ListUsersInDomain(domainID string) ([]users.User, error) {
+	var out []users.User
+	for _, u := range m.userList {
+		if u.DomainID == domainID {
+			out = append(out, u)
+		}
+	}
+	return out, m.userErr
+}
+
+func (m *mockIdentityClient) GetTokenUserID() (string, error) {
+	return "u-me", nil
+}
+func (m *mockIdentityClient) ListTrusts(opts trusts.ListOpts) ([]trusts.Trust, error) {
+	var out []trusts.Trust
+	for _, t := range m.trusts {
+		if (opts.TrustorUserID == "" || t.TrustorUserID == opts.TrustorUserID) && (opts.TrusteeUserID == "" || t.TrusteeUserID == opts.TrusteeUserID) {
+			out = append(out, t)
+		}
+	}
+	return out, nil
+}
+func (m *mockIdentityClient) CreateTrust(opts trusts.CreateOpts) (trusts.Trust, error) {
+	m.created = &opts
+	return trusts.Trust{ID: "t-new"}, nil
+}
+func (m *mockIdentityClient) DeleteTrust(id string) error {
+	return nil
+}
+
+func (m *mockIdentityClient) ListEC2Credentials(userID string) ([]ec2credentials.Credential, error) {
+	return m.ec2Creds, nil
+}
+func (m *mockIdentityClient) CreateEC2Credential(userID, projectID string) (ec2credentials.Credential, error) {
+	return ec2credentials.Credential{UserID: userID, TenantID: projectID, Access: "new"}, nil
+}
+func (m *mockIdentityClient) DeleteEC2Credential(userID, access string) error {
+	return nil
+}
 
 // Helper to create a table model for projects.
 func newProjectsTable(rows []table.Row) table.Model {
@@ -137,7 +203,11 @@ func TestTokenModelSuccess(t *testing.T) {
 	m := NewTokenModel(mock)
 	m.loading = false
 	m.token = mock.token
-	view := m.View()
+	if strings.Contains(m.View(), "token-1") {
+		t.Fatalf("expected the token ID masked until revealed")
+	}
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("v")})
+	view := updated.View()
 	if !strings.Contains(view, "token-1") {
 		t.Fatalf("expected token ID in view, got %s", view)
 	}
@@ -153,3 +223,110 @@ func TestTokenModelError(t *testing.T) {
 		t.Fatalf("expected error message in view, got %s", view)
 	}
 }
+
+func TestProjectsDomainFilter(t *testing.T) {
+	mock := &mockIdentityClient{
+		domains: []domains.Domain{{ID: "default", Name: "Default"}, {ID: "d-corp", Name: "corp"}},
+		projList: []projects.Project{
+			{ID: "proj-1", Name: "web", DomainID: "default"},
+			{ID: "proj-2", Name: "payroll", DomainID: "d-corp"},
+		},
+	}
+	m := NewProjectsModel(mock)
+	updated, _ := m.Update(m.Init()())
+	m = updated.(ProjectsModel)
+	view := m.View()
+	if !strings.Contains(view, "Default") || !strings.Contains(view, "corp") || strings.Contains(view, "d-corp") || !strings.Contains(view, "Domain: all") {
+		t.Fatalf("expected domain names and the filter line, got %s", view)
+	}
+	// all -> corp (sorted by name) -> Default -> all
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("D")})
+	m = updated.(ProjectsModel)
+	updated, _ = m.Update(cmd())
+	m = updated.(ProjectsModel)
+	if view := m.View(); strings.Contains(view, "web") || !strings.Contains(view, "payroll") || !strings.Contains(view, "Domain: corp") {
+		t.Fatalf("expected only the corp projects, got %s", view)
+	}
+}
+
+func TestDomainIndexWithoutAdmin(t *testing.T) {
+	idx := loadDomainIndex(&mockIdentityClient{domainErr: errors.New("403")})
+	if !idx.loaded() || idx.name("default") != "default" || idx.filterLine("") != "" {
+		t.Fatal("expected IDs and no filter when domains cannot be listed")
+	}
+}
+
+func TestTrustsModel(t *testing.T) {
+	mock := &mockIdentityClient{
+		userList: []users.User{{ID: "u-me", Name: "me"}, {ID: "u-bot", Name: "heat-bot"}, {ID: "u-ann", Name: "ann"}},
+		trusts: []trusts.Trust{
+			{ID: "t-1", TrustorUserID: "u-me", TrusteeUserID: "u-bot", ProjectID: "p-1", Roles: []trusts.Role{{Name: "member"}}, Impersonation: true},
+			{ID: "t-2", TrustorUserID: "u-ann", TrusteeUserID: "u-me", ProjectID: "p-2", ExpiresAt: time.Now().Add(-time.Hour)},
+		},
+	}
+	m := NewTrustsModel(mock)
+	updated, _ := m.Update(m.loadCmd()())
+	m = updated.(TrustsModel)
+	view := m.View()
+	for _, want := range []string{"granted", "received", "heat-bot", "member", "expired"} {
+		if !strings.Contains(view, want) {
+			t.Fatalf("expected %q in the view, got %s", want, view)
+		}
+	}
+	// A received trust cannot be deleted by the trustee.
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m = updated.(TrustsModel)
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	m = updated.(TrustsModel)
+	if m.CapturingInput() || !strings.Contains(m.View(), "Only the trustor") {
+		t.Fatalf("expected the delete to be refused, got %s", m.View())
+	}
+}
+
+func TestTrustFromForm(t *testing.T) {
+	users := map[string]string{"u-me": "me", "u-bot": "heat-bot"}
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.Local)
+	opts, err := trustFromForm([]string{"heat-bot", "p-1", "member, reader", "2025-07-01", "yes"}, "u-me", users, now)
+	if err != nil || opts.TrusteeUserID != "u-bot" || len(opts.Roles) != 2 || opts.ExpiresAt == nil || !opts.Impersonation {
+		t.Fatalf("unexpected %+v, %v", opts, err)
+	}
+	for _, v := range [][]string{
+		{"", "p-1", "member", "", "no"},
+		{"nobody", "p-1", "member", "", "no"},
+		{"me", "p-1", "member", "", "no"},
+		{"heat-bot", "", "member", "", "no"},
+		{"heat-bot", "p-1", "member", "2025-05-01", "no"},
+		{"heat-bot", "p-1", "member", "", "maybe"},
+	} {
+		if _, err := trustFromForm(v, "u-me", users, now); err == nil {
+			t.Errorf("expected %v to be rejected", v)
+		}
+	}
+}
+
+func TestEC2CredentialsSecretMasked(t *testing.T) {
+	mock := &mockIdentityClient{
+		projList: []projects.Project{{ID: "p-1", Name: "web"}},
+		ec2Creds: []ec2credentials.Credential{
+			{Access: "ak-1", Secret: "sk-1", TenantID: "p-1"},
+			{Access: "ak-2", Secret: "sk-2", TenantID: "p-1"},
+		},
+	}
+	m := NewEC2CredentialsModel(mock)
+	updated, _ := m.Update(m.loadCmd()())
+	m = updated.(EC2CredentialsModel)
+	if view := m.View(); !strings.Contains(view, "web (p-1)") || !strings.Contains(view, "AWS_ACCESS_KEY_ID=ak-1") || strings.Contains(view, "sk-1") {
+		t.Fatalf("expected the secret masked, got %s", view)
+	}
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("v")})
+	m = updated.(EC2CredentialsModel)
+	if !strings.Contains(m.View(), "AWS_SECRET_ACCESS_KEY=sk-1") {
+		t.Fatalf("expected the secret revealed, got %s", m.View())
+	}
+	// Moving to another credential masks the secret again.
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m = updated.(EC2CredentialsModel)
+	if view := m.View(); strings.Contains(view, "sk-2") || !strings.Contains(view, "ak-2") {
+		t.Fatalf("expected the next secret masked, got %s", view)
+	}
+}
@@ -8,6 +8,8 @@ import (
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"ostui/internal/client"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/common"
 	"ostui/internal/ui/uiconst"
 )
 
@@ -76,8 +78,8 @@ func (m ProjectDetailModel) Init() tea.Cmd {
 		if proj == nil {
 			return projectDetailDataLoadedMsg{err: fmt.Errorf("project %s not found", m.projectID)}
 		}
-		cols := []table.Column{{Title: "Field", Width: uiconst.ColWidthField}, {Title: "Value", Width: uiconst.ColWidthValueShort}, {Title: "Field", Width: uiconst.ColWidthField}, {Title: "Value", Width: uiconst.ColWidthValueShort}}
-		rows := []table.Row{{"ID", proj.ID}, {"Name", proj.Name}, {"DomainID", proj.DomainID}, {"Enabled", fmt.Sprintf("%v", proj.Enabled)}}
+		cols := []table.Column{{Title: i18n.T("Field"), Width: uiconst.ColWidthField}, {Title: i18n.T("Value"), Width: uiconst.ColWidthValueShort}, {Title: i18n.T("Field"), Width: uiconst.ColWidthField}, {Title: i18n.T("Value"), Width: uiconst.ColWidthValueShort}}
+		rows := []table.Row{{"ID", proj.ID}, {"Name", proj.Name}, {"Domain", loadDomainIndex(m.client).label(proj.DomainID)}, {"Enabled", fmt.Sprintf("%v", proj.Enabled)}}
 		half := (len(rows) + 1) / 2
 		newRows := []table.Row{}
 		for i := 0; i < half; i++ {
@@ -101,6 +103,14 @@ func (m ProjectDetailModel) Init() tea.Cmd {
 	}
 }
 
+// Reload re-fetches the project, keeping the selected row.
+func (m ProjectDetailModel) Reload() tea.Cmd {
+	if m.loading {
+		return nil
+	}
+	return m.Init()
+}
+
 // Update handles messages.
 func (m ProjectDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
@@ -110,7 +120,7 @@ func (m ProjectDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.err = msg.err
 			return m, nil
 		}
-		m.table = msg.tbl
+		m.table = common.Reloaded(m.table, msg.tbl)
 		m.project = msg.proj
 		return m, nil
 	case tea.WindowSizeMsg:
@@ -168,7 +178,7 @@ func (m ProjectDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		if msg.String() == "i" {
 			// Build inspect view for project.
-			content := fmt.Sprintf("=== Project: %s ===\nID: %s\nName: %s\nDomainID: %s\nEnabled: %v", m.project.Name, m.project.ID, m.project.Name, m.project.DomainID, m.project.Enabled)
+			content := i18n.T("=== Project: %s ===\nID: %s\nName: %s\nDomainID: %s\nEnabled: %v", m.project.Name, m.project.ID, m.project.Name, m.project.DomainID, m.project.Enabled)
 			m.inspectView = content
 			m.inspectViewport = viewport.New(80, 24)
 			m.inspectViewport.SetContent(m.inspectView)
@@ -205,17 +215,17 @@ func (m ProjectDetailModel) View() string {
 		return m.spinner.View()
 	}
 	if m.inspectView != "" {
-		return fmt.Sprintf("%s\n %3.f%% | [j/k] scroll  [esc] close", m.inspectViewport.View(), m.inspectViewport.ScrollPercent()*100)
+		return i18n.T("%s\n %3.f%% | [j/k] scroll  [esc] close", m.inspectViewport.View(), m.inspectViewport.ScrollPercent()*100)
 	}
 	if m.jsonView != "" {
-		return fmt.Sprintf("%s\nPress 'y' or 'esc' to close", m.jsonViewport.View())
+		return i18n.T("%s\nPress 'y' or 'esc' to close", m.jsonViewport.View())
 	}
 	if m.err != nil {
-		cols := []table.Column{{Title: "Error", Width: 80}}
+		cols := []table.Column{{Title: i18n.T("Error"), Width: 80}}
 		rows := []table.Row{{"Failed to load project: " + m.err.Error()}}
 		return table.New(table.WithColumns(cols), table.WithRows(rows)).View()
 	}
-	return fmt.Sprintf("%s\n[y] json  [i] inspect  [esc] back", m.table.View())
+	return i18n.T("%s\n[y] json  [i] inspect  [esc] back", m.table.View())
 }
 
 // Table returns the underlying table model.
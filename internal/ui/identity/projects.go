@@ -6,7 +6,9 @@ import (
 	"github.com/charmbracelet/bubbles/table"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/projects"
 	"ostui/internal/client"
+	"ostui/internal/i18n"
 	"ostui/internal/ui/common"
 	"ostui/internal/ui/uiconst"
 	"strings"
@@ -23,12 +25,17 @@ type ProjectsModel struct {
 	filter     textinput.Model
 	width      int
 	height     int
+	// domains resolves the Domain column; domainID filters the list to one
+	// domain.
+	domains  domainIndex
+	domainID string
 }
 
 type projectsDataLoadedMsg struct {
-	tbl  table.Model
-	rows []table.Row
-	err  error
+	tbl     table.Model
+	rows    []table.Row
+	err     error
+	domains domainIndex
 }
 
 // NewProjectsModel creates a new ProjectsModel.
@@ -43,14 +50,24 @@ func NewProjectsModel(ic client.IdentityClient) ProjectsModel {
 // Init starts async loading.
 func (m ProjectsModel) Init() tea.Cmd {
 	return func() tea.Msg {
-		projList, err := m.client.ListProjects()
+		idx := m.domains
+		if !idx.loaded() {
+			idx = loadDomainIndex(m.client)
+		}
+		var projList []projects.Project
+		var err error
+		if m.domainID != "" {
+			projList, err = m.client.ListProjectsInDomain(m.domainID)
+		} else {
+			projList, err = m.client.ListProjects()
+		}
 		if err != nil {
-			return projectsDataLoadedMsg{err: err}
+			return projectsDataLoadedMsg{err: err, domains: idx}
 		}
-		cols := []table.Column{{Title: "ID", Width: uiconst.ColWidthUUID}, {Title: "Name", Width: uiconst.ColWidthName}, {Title: "Domain ID", Width: uiconst.ColWidthName}}
+		cols := []table.Column{{Title: "ID", Width: uiconst.ColWidthUUID}, {Title: i18n.T("Name"), Width: uiconst.ColWidthName}, {Title: i18n.T("Domain"), Width: uiconst.ColWidthName}}
 		rows := []table.Row{}
 		for _, p := range projList {
-			rows = append(rows, table.Row{p.ID, p.Name, p.DomainID})
+			rows = append(rows, table.Row{p.ID, p.Name, idx.name(p.DomainID)})
 		}
 		t := table.New(
 			table.WithColumns(cols),
@@ -59,8 +76,16 @@ func (m ProjectsModel) Init() tea.Cmd {
 			table.WithHeight(m.height-uiconst.TableHeightOffset),
 		)
 		t.SetStyles(table.DefaultStyles())
-		return projectsDataLoadedMsg{tbl: t, rows: rows}
+		return projectsDataLoadedMsg{tbl: t, rows: rows, domains: idx}
+	}
+}
+
+// Reload re-fetches the projects, keeping the selected row.
+func (m ProjectsModel) Reload() tea.Cmd {
+	if m.loading || m.filterMode {
+		return nil
 	}
+	return m.Init()
 }
 
 // Update handles messages.
@@ -68,11 +93,12 @@ func (m ProjectsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case projectsDataLoadedMsg:
 		m.loading = false
+		m.domains = msg.domains
 		if msg.err != nil {
 			m.err = msg.err
 			return m, nil
 		}
-		m.table = msg.tbl
+		m.table = common.Reloaded(m.table, msg.tbl)
 		m.updateTableColumns()
 		m.table.SetHeight(m.height - 6)
 		m.allRows = msg.rows
@@ -86,6 +112,12 @@ func (m ProjectsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 	case tea.KeyMsg:
+		// The domain filter also leads out of a domain that failed to list.
+		if msg.String() == "D" && !m.loading && !m.filterMode && len(m.domains.list) > 1 {
+			m.domainID = m.domains.next(m.domainID)
+			m.loading, m.err = true, nil
+			return m, m.Init()
+		}
 		if m.loading || m.err != nil {
 			// ignore key input while loading or on error
 			return m, nil
@@ -145,21 +177,27 @@ func (m ProjectsModel) View() string {
 		return m.spinner.View()
 	}
 	if m.err != nil {
-		cols := []table.Column{{Title: "Error", Width: uiconst.ColWidthError}}
+		cols := []table.Column{{Title: i18n.T("Error"), Width: uiconst.ColWidthError}}
 		rows := []table.Row{{"Failed to list projects: " + m.err.Error()}}
-		return common.NewTable(cols, rows).View()
+		return common.NewTable(cols, rows).View() + "\n" + m.domains.filterLine(m.domainID)
 	}
 	if m.filterMode {
-		filterLine := fmt.Sprintf("Filter: %s", m.filter.View())
+		filterLine := i18n.T("Filter: %s", m.filter.View())
 		footer := "esc: clear"
 		return fmt.Sprintf("%s\n%s\n%s", filterLine, m.table.View(), footer)
 	}
+	if line := m.domains.filterLine(m.domainID); line != "" {
+		return m.table.View() + "\n" + line
+	}
 	return m.table.View()
 }
 
 // Ensure ProjectsModel implements tea.Model.
 func (m ProjectsModel) Table() table.Model { return m.table }
 
+// Filtering reports whether keys are typed into the filter.
+func (m ProjectsModel) Filtering() bool { return m.filterMode }
+
 func (m *ProjectsModel) updateTableColumns() {
 	idW := uiconst.ColWidthUUID
 	domainW := uiconst.ColWidthName
@@ -167,7 +205,7 @@ func (m *ProjectsModel) updateTableColumns() {
 	if nameW < 10 {
 		nameW = 10
 	}
-	m.table.SetColumns([]table.Column{{Title: "ID", Width: idW}, {Title: "Name", Width: nameW}, {Title: "Domain ID", Width: domainW}})
+	m.table.SetColumns([]table.Column{{Title: "ID", Width: idW}, {Title: i18n.T("Name"), Width: nameW}, {Title: i18n.T("Domain"), Width: domainW}})
 }
 
 var _ tea.Model = (*ProjectsModel)(nil)
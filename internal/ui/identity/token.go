@@ -7,7 +7,9 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/gophercloud/gophercloud/openstack/identity/v3/tokens"
 	"ostui/internal/client"
+	"ostui/internal/i18n"
 	"ostui/internal/ui/common"
+	"ostui/internal/ui/softlock"
 	"time"
 )
 
@@ -17,6 +19,8 @@ type TokenModel struct {
 	err     error
 	spinner spinner.Model
 	client  client.IdentityClient
+	// guard masks the token ID until revealed with v.
+	guard softlock.Guard
 }
 
 type tokenDataLoadedMsg struct {
@@ -28,7 +32,7 @@ type tokenDataLoadedMsg struct {
 func NewTokenModel(ic client.IdentityClient) TokenModel {
 	s := spinner.New()
 	s.Spinner = spinner.Dot
-	return TokenModel{client: ic, loading: true, spinner: s}
+	return TokenModel{client: ic, loading: true, spinner: s, guard: softlock.NewGuard()}
 }
 
 // Init starts async loading of token info.
@@ -39,6 +43,14 @@ func (m TokenModel) Init() tea.Cmd {
 	}
 }
 
+// Reload fetches the token information again.
+func (m TokenModel) Reload() tea.Cmd {
+	if m.loading || m.guard.Prompting() {
+		return nil
+	}
+	return m.Init()
+}
+
 // Update handles messages.
 func (m TokenModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
@@ -53,8 +65,19 @@ func (m TokenModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.WindowSizeMsg:
 		return m, nil
 	case tea.KeyMsg:
-		// No key handling needed for token view.
+		if m.guard.Prompting() {
+			var cmd tea.Cmd
+			m.guard, cmd = m.guard.Update(msg)
+			return m, cmd
+		}
+		if msg.String() == "v" && m.token != nil {
+			return m, m.guard.Toggle()
+		}
 		return m, nil
+	case softlock.CheckedMsg:
+		var cmd tea.Cmd
+		m.guard, cmd = m.guard.Update(msg)
+		return m, cmd
 	default:
 		if m.loading {
 			var cmd tea.Cmd
@@ -71,7 +94,7 @@ func (m TokenModel) View() string {
 		return m.spinner.View()
 	}
 	if m.err != nil {
-		cols := []table.Column{{Title: "Error", Width: 80}}
+		cols := []table.Column{{Title: i18n.T("Error"), Width: 80}}
 		rows := []table.Row{{"Failed to get token info: " + m.err.Error()}}
 		return common.NewTable(cols, rows).View()
 	}
@@ -87,12 +110,23 @@ func (m TokenModel) View() string {
 		remainingStr = "Expired"
 	}
 	fields := map[string]string{
-		"Token ID":   m.token.ID,
-		"Expires At": m.token.ExpiresAt.Format(time.RFC3339),
-		"Remaining":  remainingStr,
+		i18n.T("Token ID"):   m.guard.Value(m.token.ID),
+		i18n.T("Expires At"): m.token.ExpiresAt.Format(time.RFC3339),
+		i18n.T("Remaining"):  remainingStr,
+	}
+	out := common.NewDetail(i18n.T("Token Info"), fields).View()
+	if v := m.guard.View(); v != "" {
+		return out + "\n" + v
 	}
-	return common.NewDetail("Token Info", fields).View()
+	if m.guard.Revealed() {
+		return out + "\n" + i18n.T("[v] hide token ID")
+	}
+	return out + "\n" + i18n.T("[v] reveal token ID")
 }
 
+// CapturingInput reports whether the unlock prompt is open, so the app
+// forwards every key.
+func (m TokenModel) CapturingInput() bool { return m.guard.Prompting() }
+
 // Ensure TokenModel implements tea.Model.
 var _ tea.Model = (*TokenModel)(nil)
@@ -0,0 +1,448 @@
+package identity
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/extensions/trusts"
+	"ostui/internal/client"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/common"
+	"ostui/internal/ui/policy"
+	"ostui/internal/ui/theme"
+	"ostui/internal/ui/uiconst"
+)
+
+// TrustsModel lists the Keystone trusts the current user granted (as
+// trustor) or received (as trustee), and creates and deletes them.
+type TrustsModel struct {
+	table   table.Model
+	loading bool
+	err     error
+	spinner spinner.Model
+	client  client.IdentityClient
+
+	userID    string
+	projectID string
+	roles     []string
+	trusts    []client.Trust
+	users     map[string]string
+
+	form          *common.FormModel
+	pendingDelete string
+	status        string
+	statusErr     bool
+
+	width  int
+	height int
+}
+
+// NewTrustsModel creates the trust list.
+func NewTrustsModel(ic client.IdentityClient) TrustsModel {
+	s := spinner.New()
+	s.Spinner = spinner.Dot
+	return TrustsModel{client: ic, loading: true, spinner: s, width: 120, height: 30}
+}
+
+type trustsLoadedMsg struct {
+	userID    string
+	projectID string
+	roles     []string
+	trusts    []client.Trust
+	users     map[string]string
+	err       error
+}
+
+// changeDoneMsg reports the outcome of a create or delete.
+type changeDoneMsg struct {
+	status string
+	// action names a failed change as checked with policy.Check.
+	action string
+	err    error
+}
+
+// Init loads the trusts.
+func (m TrustsModel) Init() tea.Cmd {
+	return tea.Batch(m.spinner.Tick, m.loadCmd())
+}
+
+// Reload re-fetches the trusts, keeping the selected row.
+func (m TrustsModel) Reload() tea.Cmd {
+	if m.loading {
+		return nil
+	}
+	return m.loadCmd()
+}
+
+// loadCmd lists the trusts of the token's user in both directions. Keystone
+// only lets a user list trusts filtered on themselves, so the two lists are
+// fetched separately. The user names, the project and the roles of the
+// token are best effort: they only prefill the form and label the rows.
+func (m TrustsModel) loadCmd() tea.Cmd {
+	ic := m.client
+	return func() tea.Msg {
+		uid, err := ic.GetTokenUserID()
+		if err != nil {
+			return trustsLoadedMsg{err: err}
+		}
+		granted, err := ic.ListTrusts(trusts.ListOpts{TrustorUserID: uid})
+		if err != nil {
+			return trustsLoadedMsg{err: err}
+		}
+		received, err := ic.ListTrusts(trusts.ListOpts{TrusteeUserID: uid})
+		if err != nil {
+			return trustsLoadedMsg{err: err}
+		}
+		msg := trustsLoadedMsg{userID: uid, users: map[string]string{}}
+		seen := map[string]bool{}
+		for _, t := range append(granted, received...) {
+			if !seen[t.ID] {
+				seen[t.ID] = true
+				msg.trusts = append(msg.trusts, t)
+			}
+		}
+		if list, err := ic.ListUsers(); err == nil {
+			for _, u := range list {
+				msg.users[u.ID] = u.Name
+			}
+		}
+		if p, err := ic.GetCurrentProject(); err == nil {
+			msg.projectID = p.ID
+		}
+		msg.roles, _ = ic.GetTokenRoles()
+		return msg
+	}
+}
+
+// trustDirection tells whether the user granted or received trust t.
+func trustDirection(t client.Trust, userID string) string {
+	if t.TrustorUserID == userID {
+		return "granted"
+	}
+	return "received"
+}
+
+// trustRoles joins the names of the delegated roles.
+func trustRoles(t client.Trust) string {
+	names := make([]string, 0, len(t.Roles))
+	for _, r := range t.Roles {
+		if r.Name != "" {
+			names = append(names, r.Name)
+		} else {
+			names = append(names, r.ID)
+		}
+	}
+	if len(names) == 0 {
+		return "-"
+	}
+	return strings.Join(names, ", ")
+}
+
+// trustExpiry renders when a trust expires, relative to now.
+func trustExpiry(expires, now time.Time) string {
+	if expires.IsZero() {
+		return "never"
+	}
+	d := expires.Sub(now)
+	if d <= 0 {
+		return i18n.T("expired %s ago", shortDuration(-d))
+	}
+	return fmt.Sprintf("%s (in %s)", expires.Local().Format("2006-01-02"), shortDuration(d))
+}
+
+// shortDuration renders d in its largest whole unit.
+func shortDuration(d time.Duration) string {
+	switch {
+	case d >= 24*time.Hour:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	case d >= time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	}
+}
+
+// userName returns the name of a user, or the ID when it is unknown.
+func (m TrustsModel) userName(id string) string {
+	if n, ok := m.users[id]; ok && n != "" {
+		return n
+	}
+	return id
+}
+
+// Update handles messages for the model.
+func (m TrustsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case trustsLoadedMsg:
+		m.loading = false
+		m.err = msg.err
+		if msg.err != nil {
+			return m, nil
+		}
+		m.userID, m.projectID, m.roles, m.trusts, m.users = msg.userID, msg.projectID, msg.roles, msg.trusts, msg.users
+		now := time.Now()
+		rows := make([]table.Row, 0, len(m.trusts))
+		for _, t := range m.trusts {
+			imp := "no"
+			if t.Impersonation {
+				imp = "yes"
+			}
+			rows = append(rows, table.Row{t.ID, trustDirection(t, m.userID), m.userName(t.TrustorUserID), m.userName(t.TrusteeUserID),
+				t.ProjectID, trustRoles(t), imp, trustExpiry(t.ExpiresAt, now)})
+		}
+		prev := m.table
+		m.table = table.New(table.WithFocused(true))
+		m.table.SetStyles(table.DefaultStyles())
+		m.updateTableColumns()
+		m.table.SetRows(rows)
+		m.table = common.Reloaded(prev, m.table)
+		return m, nil
+	case changeDoneMsg:
+		m.status, m.statusErr = msg.status, msg.err != nil
+		if msg.err != nil {
+			policy.Record(msg.action, msg.err)
+			m.status = msg.err.Error()
+			return m, nil
+		}
+		return m, m.loadCmd()
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		if m.table.Columns() != nil {
+			m.updateTableColumns()
+		}
+		return m, nil
+	case tea.KeyMsg:
+		if m.form != nil {
+			return m.updateForm(msg)
+		}
+		if m.pendingDelete != "" {
+			id := m.pendingDelete
+			m.pendingDelete = ""
+			if msg.String() != "y" {
+				return m, nil
+			}
+			ic := m.client
+			return m, func() tea.Msg {
+				return changeDoneMsg{status: i18n.T("Deleted trust %s", id), err: ic.DeleteTrust(id)}
+			}
+		}
+		if m.loading || m.err != nil {
+			return m, nil
+		}
+		switch msg.String() {
+		case "n":
+			if err := policy.Check(policy.Member, "creating a trust"); err != nil {
+				m.status, m.statusErr = err.Error(), true
+				return m, nil
+			}
+			f := common.NewForm([]string{i18n.T("Trustee (user name or ID)"), i18n.T("Project ID"), i18n.T("Roles (comma-separated)"), i18n.T("Expires (YYYY-MM-DD, empty for never)"), i18n.T("Impersonation (yes/no)")})
+			f.SetValue(1, m.projectID)
+			f.SetValue(2, strings.Join(m.roles, ","))
+			f.SetValue(4, "no")
+			m.form = &f
+			return m, f.Init()
+		case "x":
+			if t, ok := m.Selected(); ok {
+				// Only the trustor may delete a trust.
+				if t.TrustorUserID != m.userID {
+					m.status, m.statusErr = i18n.T("Only the trustor can delete a trust"), true
+					return m, nil
+				}
+				m.pendingDelete = t.ID
+			}
+			return m, nil
+		case "r":
+			m.loading = true
+			return m, tea.Batch(m.spinner.Tick, m.loadCmd())
+		}
+		var cmd tea.Cmd
+		m.table, cmd = m.table.Update(msg)
+		return m, cmd
+	default:
+		if m.loading {
+			var cmd tea.Cmd
+			m.spinner, cmd = m.spinner.Update(msg)
+			return m, cmd
+		}
+	}
+	return m, nil
+}
+
+// trustFromForm validates the new trust form values. The trustee may be
+// typed by name when the user list could be loaded.
+func trustFromForm(v []string, trustorID string, users map[string]string, now time.Time) (trusts.CreateOpts, error) {
+	opts := trusts.CreateOpts{TrustorUserID: trustorID, TrusteeUserID: v[0], ProjectID: v[1]}
+	if opts.TrusteeUserID == "" {
+		return opts, errors.New("the trustee is required")
+	}
+	if _, ok := users[opts.TrusteeUserID]; !ok {
+		var ids []string
+		for id, name := range users {
+			if name == opts.TrusteeUserID {
+				ids = append(ids, id)
+			}
+		}
+		switch {
+		case len(ids) == 1:
+			opts.TrusteeUserID = ids[0]
+		case len(ids) > 1:
+			return opts, fmt.Errorf("several users are named %s; enter the user ID", v[0])
+		case len(users) > 0:
+			return opts, fmt.Errorf("user %s does not exist", v[0])
+		}
+	}
+	if opts.TrusteeUserID == trustorID {
+		return opts, errors.New("a trust cannot be granted to yourself")
+	}
+	for _, r := range strings.Split(v[2], ",") {
+		if r = strings.TrimSpace(r); r != "" {
+			opts.Roles = append(opts.Roles, trusts.Role{Name: r})
+		}
+	}
+	if len(opts.Roles) > 0 && opts.ProjectID == "" {
+		return opts, errors.New("roles can only be delegated on a project")
+	}
+	if v[3] != "" {
+		exp, err := time.ParseInLocation("2006-01-02", v[3], time.Local)
+		if err != nil {
+			return opts, errors.New("the expiry must be a date like 2025-12-31")
+		}
+		if !exp.After(now) {
+			return opts, errors.New("the expiry must be in the future")
+		}
+		opts.ExpiresAt = &exp
+	}
+	switch strings.ToLower(v[4]) {
+	case "yes", "y":
+		opts.Impersonation = true
+	case "no", "n", "":
+	default:
+		return opts, errors.New("impersonation must be yes or no")
+	}
+	return opts, nil
+}
+
+// updateForm handles keys for the new trust form.
+func (m TrustsModel) updateForm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	fm, cmd := m.form.Update(msg)
+	f := fm.(common.FormModel)
+	m.form = &f
+	switch {
+	case f.Cancelled():
+		m.form = nil
+	case f.Submitted():
+		opts, err := trustFromForm(f.Values(), m.userID, m.users, time.Now())
+		if err != nil {
+			m.form.SetError(err)
+			return m, nil
+		}
+		m.form = nil
+		ic := m.client
+		return m, func() tea.Msg {
+			created, err := ic.CreateTrust(opts)
+			if err != nil {
+				return changeDoneMsg{action: "creating a trust", err: err}
+			}
+			return changeDoneMsg{status: i18n.T("Created trust %s", created.ID)}
+		}
+	}
+	return m, cmd
+}
+
+// CapturingInput reports whether the form or the delete prompt is open.
+func (m TrustsModel) CapturingInput() bool { return m.form != nil || m.pendingDelete != "" }
+
+// Selected returns the trust under the cursor.
+func (m TrustsModel) Selected() (client.Trust, bool) {
+	row := m.table.SelectedRow()
+	if len(row) == 0 {
+		return client.Trust{}, false
+	}
+	for _, t := range m.trusts {
+		if t.ID == row[0] {
+			return t, true
+		}
+	}
+	return client.Trust{}, false
+}
+
+// ConfirmingDestructive reports whether a trust is about to be deleted.
+func (m TrustsModel) ConfirmingDestructive() bool { return m.pendingDelete != "" }
+
+// selectedLine details the trust under the cursor: what the table has no
+// room for.
+func (m TrustsModel) selectedLine() string {
+	t, ok := m.Selected()
+	if !ok {
+		return ""
+	}
+	uses := "unlimited uses"
+	if t.RemainingUses > 0 {
+		uses = i18n.T("%d uses left", t.RemainingUses)
+	}
+	redelegation := "no redelegation"
+	if t.AllowRedelegation {
+		redelegation = i18n.T("redelegation allowed (depth %d)", t.RedelegationCount)
+	}
+	line := fmt.Sprintf("%s → %s: %s, %s", m.userName(t.TrustorUserID), m.userName(t.TrusteeUserID), uses, redelegation)
+	if t.RedelegatedTrustID != "" {
+		line += ", redelegated from " + t.RedelegatedTrustID
+	}
+	return "\n" + lipgloss.NewStyle().Foreground(theme.Muted).Render(line)
+}
+
+// statusLine renders the outcome of the last change, or the delete prompt.
+func (m TrustsModel) statusLine() string {
+	switch {
+	case m.pendingDelete != "":
+		return "\n" + i18n.T("Delete trust %s? The trustee loses the delegated roles. [y/N]", m.pendingDelete)
+	case m.statusErr:
+		return "\n" + lipgloss.NewStyle().Foreground(theme.Error).Render(m.status)
+	case m.status != "":
+		return "\n" + lipgloss.NewStyle().Foreground(theme.OK).Render(m.status)
+	}
+	return ""
+}
+
+// View renders the list, the form or the delete prompt.
+func (m TrustsModel) View() string {
+	if m.form != nil {
+		return "New trust – the trustee may act with these roles on the project, as you when impersonating\n\n" + m.form.View() +
+			"\n" + i18n.T("[tab] next field  [enter] next/create  [esc] cancel")
+	}
+	if m.loading {
+		return m.spinner.View()
+	}
+	if m.err != nil {
+		cols := []table.Column{{Title: i18n.T("Error"), Width: uiconst.ColWidthError}}
+		rows := []table.Row{{"Failed to list trusts: " + m.err.Error()}}
+		return common.NewTable(cols, rows).View()
+	}
+	out := m.table.View() + m.selectedLine() + m.statusLine()
+	return out + "\n" + policy.Key(policy.Member, i18n.T("[n] new trust"), "creating a trust") + "  " + i18n.T("[x] delete  [r] refresh")
+}
+
+func (m *TrustsModel) updateTableColumns() {
+	idW := uiconst.ColWidthUUID
+	dirW := 9
+	impW := 6
+	expW := 22
+	rest := m.width - idW - dirW - impW - expW - uiconst.TableHeightOffset
+	if rest < 48 {
+		rest = 48
+	}
+	m.table.SetColumns([]table.Column{{Title: "ID", Width: idW}, {Title: i18n.T("Direction"), Width: dirW}, {Title: i18n.T("Trustor"), Width: rest / 4}, {Title: i18n.T("Trustee"), Width: rest / 4},
+		{Title: i18n.T("Project"), Width: rest / 4}, {Title: i18n.T("Roles"), Width: rest - 3*(rest/4)}, {Title: i18n.T("Imp."), Width: impW}, {Title: i18n.T("Expires"), Width: expW}})
+	m.table.SetHeight(m.height - uiconst.TableHeightOffset - 3)
+}
+
+// Table returns the underlying table.
+func (m TrustsModel) Table() table.Model { return m.table }
+
+var _ tea.Model = (*TrustsModel)(nil)
@@ -8,6 +8,8 @@ import (
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"ostui/internal/client"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/common"
 	"ostui/internal/ui/uiconst"
 )
 
@@ -80,8 +82,8 @@ func (m UserDetailModel) Init() tea.Cmd {
 		if user == nil {
 			return userDetailDataLoadedMsg{err: fmt.Errorf("user %s not found", m.userID)}
 		}
-		cols := []table.Column{{Title: "Field", Width: uiconst.ColWidthField}, {Title: "Value", Width: uiconst.ColWidthValueShort}, {Title: "Field", Width: uiconst.ColWidthField}, {Title: "Value", Width: uiconst.ColWidthValueShort}}
-		rows := []table.Row{{"ID", user.ID}, {"Name", user.Name}, {"Email", user.Email}, {"DomainID", user.DomainID}, {"Enabled", fmt.Sprintf("%v", user.Enabled)}}
+		cols := []table.Column{{Title: i18n.T("Field"), Width: uiconst.ColWidthField}, {Title: i18n.T("Value"), Width: uiconst.ColWidthValueShort}, {Title: i18n.T("Field"), Width: uiconst.ColWidthField}, {Title: i18n.T("Value"), Width: uiconst.ColWidthValueShort}}
+		rows := []table.Row{{"ID", user.ID}, {"Name", user.Name}, {"Email", user.Email}, {"Domain", loadDomainIndex(m.client).label(user.DomainID)}, {"Enabled", fmt.Sprintf("%v", user.Enabled)}}
 		half := (len(rows) + 1) / 2
 		newRows := []table.Row{}
 		for i := 0; i < half; i++ {
@@ -105,6 +107,14 @@ func (m UserDetailModel) Init() tea.Cmd {
 	}
 }
 
+// Reload re-fetches the user, keeping the selected row.
+func (m UserDetailModel) Reload() tea.Cmd {
+	if m.loading {
+		return nil
+	}
+	return m.Init()
+}
+
 // Update handles messages.
 func (m UserDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
@@ -114,7 +124,7 @@ func (m UserDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.err = msg.err
 			return m, nil
 		}
-		m.table = msg.tbl
+		m.table = common.Reloaded(m.table, msg.tbl)
 		m.user = msg.user
 		return m, nil
 	case tea.WindowSizeMsg:
@@ -172,7 +182,7 @@ func (m UserDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		if msg.String() == "i" {
 			// Build inspect view for user.
-			content := fmt.Sprintf("=== User: %s ===\nID: %s\nName: %s\nEmail: %s\nDomainID: %s\nEnabled: %v", m.user.Name, m.user.ID, m.user.Name, m.user.Email, m.user.DomainID, m.user.Enabled)
+			content := i18n.T("=== User: %s ===\nID: %s\nName: %s\nEmail: %s\nDomainID: %s\nEnabled: %v", m.user.Name, m.user.ID, m.user.Name, m.user.Email, m.user.DomainID, m.user.Enabled)
 			m.inspectView = content
 			m.inspectViewport = viewport.New(80, 24)
 			m.inspectViewport.SetContent(m.inspectView)
@@ -208,17 +218,17 @@ func (m UserDetailModel) View() string {
 		return m.spinner.View()
 	}
 	if m.inspectView != "" {
-		return fmt.Sprintf("%s\n %3.f%% | [j/k] scroll  [esc] close", m.inspectViewport.View(), m.inspectViewport.ScrollPercent()*100)
+		return i18n.T("%s\n %3.f%% | [j/k] scroll  [esc] close", m.inspectViewport.View(), m.inspectViewport.ScrollPercent()*100)
 	}
 	if m.jsonView != "" {
-		return fmt.Sprintf("%s\nPress 'y' or 'esc' to close", m.jsonViewport.View())
+		return i18n.T("%s\nPress 'y' or 'esc' to close", m.jsonViewport.View())
 	}
 	if m.err != nil {
-		cols := []table.Column{{Title: "Error", Width: 80}}
+		cols := []table.Column{{Title: i18n.T("Error"), Width: 80}}
 		rows := []table.Row{{"Failed to load user: " + m.err.Error()}}
 		return table.New(table.WithColumns(cols), table.WithRows(rows)).View()
 	}
-	return fmt.Sprintf("%s\n[y] json  [i] inspect  [esc] back", m.table.View())
+	return i18n.T("%s\n[y] json  [i] inspect  [esc] back", m.table.View())
 }
 
 // Table returns the underlying table model.
@@ -6,7 +6,9 @@ import (
 	"github.com/charmbracelet/bubbles/table"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/users"
 	"ostui/internal/client"
+	"ostui/internal/i18n"
 	"ostui/internal/ui/common"
 	"ostui/internal/ui/uiconst"
 )
@@ -20,11 +22,16 @@ type UsersModel struct {
 	filter  textinput.Model
 	width   int
 	height  int
+	// domains resolves the Domain column; domainID filters the list to one
+	// domain.
+	domains  domainIndex
+	domainID string
 }
 
 type usersDataLoadedMsg struct {
-	tbl table.Model
-	err error
+	tbl     table.Model
+	err     error
+	domains domainIndex
 }
 
 // NewUsersModel creates a new UsersModel.
@@ -39,14 +46,24 @@ func NewUsersModel(ic client.IdentityClient) UsersModel {
 // Init starts async loading.
 func (m UsersModel) Init() tea.Cmd {
 	return func() tea.Msg {
-		userList, err := m.client.ListUsers()
+		idx := m.domains
+		if !idx.loaded() {
+			idx = loadDomainIndex(m.client)
+		}
+		var userList []users.User
+		var err error
+		if m.domainID != "" {
+			userList, err = m.client.ListUsersInDomain(m.domainID)
+		} else {
+			userList, err = m.client.ListUsers()
+		}
 		if err != nil {
-			return usersDataLoadedMsg{err: err}
+			return usersDataLoadedMsg{err: err, domains: idx}
 		}
-		cols := []table.Column{{Title: "ID", Width: uiconst.ColWidthUUID}, {Title: "Name", Width: uiconst.ColWidthName}, {Title: "Domain ID", Width: uiconst.ColWidthName}, {Title: "Enabled", Width: uiconst.ColWidthEnabled}}
+		cols := []table.Column{{Title: "ID", Width: uiconst.ColWidthUUID}, {Title: i18n.T("Name"), Width: uiconst.ColWidthName}, {Title: i18n.T("Domain"), Width: uiconst.ColWidthName}, {Title: i18n.T("Enabled"), Width: uiconst.ColWidthEnabled}}
 		rows := []table.Row{}
 		for _, u := range userList {
-			rows = append(rows, table.Row{u.ID, u.Name, u.DomainID, fmt.Sprintf("%t", u.Enabled)})
+			rows = append(rows, table.Row{u.ID, u.Name, idx.name(u.DomainID), fmt.Sprintf("%t", u.Enabled)})
 		}
 		t := table.New(
 			table.WithColumns(cols),
@@ -55,20 +72,29 @@ func (m UsersModel) Init() tea.Cmd {
 			table.WithHeight(m.height-uiconst.TableHeightOffset),
 		)
 		t.SetStyles(table.DefaultStyles())
-		return usersDataLoadedMsg{tbl: t}
+		return usersDataLoadedMsg{tbl: t, domains: idx}
 	}
 }
 
+// Reload re-fetches the users, keeping the selected row.
+func (m UsersModel) Reload() tea.Cmd {
+	if m.loading {
+		return nil
+	}
+	return m.Init()
+}
+
 // Update handles messages.
 func (m UsersModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case usersDataLoadedMsg:
 		m.loading = false
+		m.domains = msg.domains
 		if msg.err != nil {
 			m.err = msg.err
 			return m, nil
 		}
-		m.table = msg.tbl
+		m.table = common.Reloaded(m.table, msg.tbl)
 		m.updateTableColumns()
 		m.table.SetHeight(m.height - uiconst.TableHeightOffset)
 		return m, nil
@@ -81,6 +107,12 @@ func (m UsersModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 	case tea.KeyMsg:
+		// The domain filter also leads out of a domain that failed to list.
+		if msg.String() == "D" && !m.loading && len(m.domains.list) > 1 {
+			m.domainID = m.domains.next(m.domainID)
+			m.loading, m.err = true, nil
+			return m, m.Init()
+		}
 		if m.loading || m.err != nil {
 			return m, nil
 		}
@@ -103,9 +135,12 @@ func (m UsersModel) View() string {
 		return m.spinner.View()
 	}
 	if m.err != nil {
-		cols := []table.Column{{Title: "Error", Width: uiconst.ColWidthError}}
+		cols := []table.Column{{Title: i18n.T("Error"), Width: uiconst.ColWidthError}}
 		rows := []table.Row{{"Failed to list users: " + m.err.Error()}}
-		return common.NewTable(cols, rows).View()
+		return common.NewTable(cols, rows).View() + "\n" + m.domains.filterLine(m.domainID)
+	}
+	if line := m.domains.filterLine(m.domainID); line != "" {
+		return m.table.View() + "\n" + line
 	}
 	return m.table.View()
 }
@@ -122,7 +157,7 @@ func (m *UsersModel) updateTableColumns() {
 	if nameW < 10 {
 		nameW = 10
 	}
-	m.table.SetColumns([]table.Column{{Title: "ID", Width: idW}, {Title: "Name", Width: nameW}, {Title: "Domain ID", Width: domainW}, {Title: "Enabled", Width: enabledW}})
+	m.table.SetColumns([]table.Column{{Title: "ID", Width: idW}, {Title: i18n.T("Name"), Width: nameW}, {Title: i18n.T("Domain"), Width: domainW}, {Title: i18n.T("Enabled"), Width: enabledW}})
 }
 
 var _ tea.Model = (*UsersModel)(nil)
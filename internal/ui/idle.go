@@ -0,0 +1,95 @@
+package ui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/softlock"
+	"ostui/internal/ui/theme"
+)
+
+// idleState is the lock screen shown after --idle-lock without input. The
+// views keep running below it; the first key asks for the password or PIN
+// when the soft-lock is on, and resumes otherwise or when there is nothing
+// to check the secret against.
+type idleState struct {
+	guard softlock.Guard
+}
+
+// isInput reports whether msg comes from the user.
+func isInput(msg tea.Msg) bool {
+	switch msg.(type) {
+	case tea.KeyMsg, tea.MouseMsg:
+		return true
+	}
+	return false
+}
+
+// checkIdle locks the session or quits once the user has been away long
+// enough; it runs on every token tick.
+func (m AppModel) checkIdle(now time.Time) (AppModel, tea.Cmd) {
+	away := now.Sub(m.lastInput)
+	if softlock.IdleExit > 0 && away >= softlock.IdleExit {
+		return m, tea.Quit
+	}
+	if softlock.IdleLock > 0 && m.idle == nil && away >= softlock.IdleLock {
+		// Values revealed before the lock need the secret again.
+		softlock.Lock()
+		m.idle = &idleState{guard: softlock.NewGuard()}
+	}
+	return m, nil
+}
+
+// updateIdle handles input and the secret check while the session is
+// locked. Nothing reaches the views; ctrl+c still quits.
+func (m AppModel) updateIdle(msg tea.Msg) (tea.Model, tea.Cmd) {
+	idle := *m.idle
+	var cmd tea.Cmd
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if msg.Type == tea.KeyCtrlC {
+			return m, tea.Quit
+		}
+		switch {
+		case idle.guard.Prompting():
+			idle.guard, cmd = idle.guard.Update(msg)
+		case softlock.Verify == nil:
+			// No password or PIN to ask for, e.g. --demo without
+			// OSTUI_LOCK_PIN: a prompt could never unlock.
+			m.idle = nil
+			m.lastInput = time.Now()
+			return m, nil
+		default:
+			cmd = idle.guard.Reveal()
+		}
+	case softlock.CheckedMsg:
+		idle.guard, cmd = idle.guard.Update(msg)
+	default:
+		// Mouse input does not unlock.
+		return m, nil
+	}
+	if idle.guard.Revealed() {
+		m.idle = nil
+		m.lastInput = time.Now()
+		return m, cmd
+	}
+	m.idle = &idle
+	return m, cmd
+}
+
+// idleView renders the lock screen in place of the views.
+func (m AppModel) idleView() string {
+	title := lipgloss.NewStyle().Bold(true).Foreground(theme.Warn).Render(i18n.T("Session locked after %s without input", softlock.IdleLock))
+	prompt := m.idle.guard.View()
+	if prompt == "" {
+		prompt = i18n.T("Press any key to resume")
+	}
+	out := title + "\n\n" + prompt
+	if softlock.IdleExit > 0 {
+		at := m.lastInput.Add(softlock.IdleExit).Format("15:04")
+		out += "\n" + lipgloss.NewStyle().Foreground(theme.Muted).Render(i18n.T("ostui exits at %s without input", at))
+	}
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, out)
+}
@@ -0,0 +1,69 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"ostui/internal/ui/common"
+	"ostui/internal/ui/softlock"
+)
+
+func TestIdleLock(t *testing.T) {
+	softlock.IdleLock, softlock.IdleExit = 10*time.Minute, time.Hour
+	softlock.Enabled, softlock.Verify = true, softlock.PIN("4711")
+	t.Cleanup(func() {
+		softlock.IdleLock, softlock.IdleExit = 0, 0
+		softlock.Enabled, softlock.Verify = false, nil
+	})
+	var answer string
+	start := time.Now()
+	m := AppModel{state: stateDetail, detailModel: promptStub{answer: &answer}, lastInput: start}
+
+	m, _ = m.checkIdle(start.Add(9 * time.Minute))
+	if m.idle != nil {
+		t.Fatal("locked too early")
+	}
+	m, _ = m.checkIdle(start.Add(10 * time.Minute))
+	if m.idle == nil || !strings.Contains(m.View(), "Session locked") || strings.Contains(m.View(), "Delete?") {
+		t.Fatalf("expected the lock screen in place of the view, got %q", m.View())
+	}
+
+	// The first key asks for the PIN and reaches no view.
+	m = typeKeys(m, "y").(AppModel)
+	if answer != "" || !m.idle.guard.Prompting() {
+		t.Fatalf("expected the PIN prompt, the view got %q", answer)
+	}
+	enterPIN := func(m AppModel, pin string) AppModel {
+		m = typeKeys(m, pin).(AppModel)
+		updated, cmd := m.Update(common.KeyFor("enter"))
+		updated, _ = updated.Update(cmd())
+		return updated.(AppModel)
+	}
+	if m = enterPIN(m, "0000"); m.idle == nil {
+		t.Fatal("a wrong PIN must not unlock")
+	}
+	m = typeKeys(m, "y").(AppModel)
+	if m = enterPIN(m, "4711"); m.idle != nil || answer != "" {
+		t.Fatalf("expected the PIN to unlock without answering the view, got %q", answer)
+	}
+
+	// Without input for --idle-exit, the session ends.
+	if _, cmd := m.checkIdle(m.lastInput.Add(time.Hour)); cmd == nil {
+		t.Fatal("expected ostui to quit")
+	} else if _, ok := cmd().(tea.QuitMsg); !ok {
+		t.Fatal("expected ostui to quit")
+	}
+
+	// Without a verifier, as in the demo without OSTUI_LOCK_PIN, any key
+	// resumes.
+	softlock.Verify = nil
+	m, _ = m.checkIdle(m.lastInput.Add(10 * time.Minute))
+	if m.idle == nil || !strings.Contains(m.View(), "Press any key") {
+		t.Fatalf("expected the lock screen, got %q", m.View())
+	}
+	if m = typeKeys(m, "y").(AppModel); m.idle != nil || answer != "" {
+		t.Fatalf("expected a key to resume without answering the view, got %q", answer)
+	}
+}
@@ -0,0 +1,195 @@
+package image
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/flavors"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/images"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/theme"
+)
+
+// HintLevel grades a boot compatibility hint.
+type HintLevel int
+
+const (
+	HintInfo HintLevel = iota
+	// HintWarn marks combinations that boot only on suitable hosts or policy.
+	HintWarn
+	// HintFail marks combinations that fail scheduling or boot.
+	HintFail
+)
+
+// BootHint is one compatibility note derived from image properties.
+type BootHint struct {
+	Level HintLevel
+	Text  string
+}
+
+// validDiskBuses are the hw_disk_bus values Nova accepts.
+var validDiskBuses = map[string]bool{"virtio": true, "scsi": true, "ide": true, "usb": true, "sata": true, "fdc": true, "lxc": true, "uml": true}
+
+// prop returns an image property as a lower-case string.
+func prop(props map[string]interface{}, key string) string {
+	v, ok := props[key]
+	if !ok || v == nil {
+		return ""
+	}
+	return strings.ToLower(strings.TrimSpace(fmt.Sprint(v)))
+}
+
+// BootHints derives compatibility hints from the image properties that
+// constrain where and how the image can boot, worst first.
+func BootHints(props map[string]interface{}) []BootHint {
+	var hints []BootHint
+	add := func(l HintLevel, text string) {
+		hints = append(hints, BootHint{Level: l, Text: text})
+	}
+	machine := prop(props, "hw_machine_type")
+	q35 := strings.Contains(machine, "q35")
+
+	switch arch := prop(props, "architecture"); arch {
+	case "", "x86_64", "i686":
+	default:
+		add(HintWarn, i18n.T("architecture %s: schedules only to %s compute hosts", arch, arch))
+	}
+	if hv := prop(props, "img_hv_type"); hv != "" {
+		add(HintWarn, i18n.T("img_hv_type %s: schedules only to %s hypervisors", hv, hv))
+	}
+	switch bus := prop(props, "hw_disk_bus"); {
+	case bus == "":
+	case !validDiskBuses[bus]:
+		add(HintFail, i18n.T("hw_disk_bus %q is not a valid disk bus; the boot fails", bus))
+	case bus == "ide" && q35:
+		add(HintFail, i18n.T("hw_disk_bus ide with machine type %s: q35 has no IDE controller", machine))
+	case bus == "ide":
+		add(HintWarn, i18n.T("hw_disk_bus ide: at most 4 disks, emulated and slow"))
+	case bus == "scsi" && prop(props, "hw_scsi_model") != "virtio-scsi":
+		add(HintWarn, i18n.T("hw_disk_bus scsi without hw_scsi_model=virtio-scsi uses an emulated controller"))
+	case bus != "virtio":
+		add(HintInfo, "hw_disk_bus "+bus)
+	}
+	if prop(props, "hw_cdrom_bus") == "ide" && q35 {
+		add(HintFail, i18n.T("hw_cdrom_bus ide with machine type %s: config drive and rescue fail", machine))
+	}
+	firmware := prop(props, "hw_firmware_type")
+	if firmware == "uefi" {
+		add(HintWarn, i18n.T("hw_firmware_type uefi: needs hosts with UEFI (OVMF) firmware"))
+	}
+	if prop(props, "os_secure_boot") == "required" {
+		if firmware != "uefi" {
+			add(HintFail, i18n.T("os_secure_boot required without hw_firmware_type=uefi"))
+		} else {
+			add(HintWarn, i18n.T("os_secure_boot required: needs secure-boot capable hosts"))
+		}
+	}
+	if ps := prop(props, "hw_mem_page_size"); ps != "" && ps != "small" && ps != "any" {
+		add(HintWarn, i18n.T("hw_mem_page_size %s: the flavor must set hw:mem_page_size to large, any or a size", ps))
+	}
+	if prop(props, "img_config_drive") == "mandatory" {
+		add(HintInfo, i18n.T("img_config_drive mandatory: a config drive is always attached"))
+	}
+	sort.SliceStable(hints, func(i, j int) bool { return hints[i].Level > hints[j].Level })
+	return hints
+}
+
+// FlavorFit checks the image's minimum RAM and disk against a flavor and
+// returns the problems, graded like BootHints.
+func FlavorFit(img images.Image, f flavors.Flavor) []BootHint {
+	var out []BootHint
+	if img.MinRAM > 0 && f.RAM < img.MinRAM {
+		out = append(out, BootHint{Level: HintFail, Text: fmt.Sprintf("RAM %d MB < min_ram %d MB", f.RAM, img.MinRAM)})
+	}
+	switch {
+	case img.MinDisk > 0 && f.Disk == 0:
+		out = append(out, BootHint{Level: HintWarn, Text: "zero-disk flavor: boot from volume, or root disk sized by the image if policy allows"})
+	case img.MinDisk > 0 && f.Disk < img.MinDisk:
+		out = append(out, BootHint{Level: HintFail, Text: i18n.T("disk %d GB < min_disk %d GB", f.Disk, img.MinDisk)})
+	}
+	return out
+}
+
+// hintSummary condenses hints into a short table cell.
+func hintSummary(hints []BootHint) string {
+	var fail, warn int
+	for _, h := range hints {
+		switch h.Level {
+		case HintFail:
+			fail++
+		case HintWarn:
+			warn++
+		}
+	}
+	var parts []string
+	if fail > 0 {
+		parts = append(parts, i18n.T("%d fail", fail))
+	}
+	if warn > 0 {
+		parts = append(parts, i18n.T("%d warn", warn))
+	}
+	if len(parts) == 0 {
+		return "ok"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// renderBootHints draws the property hints and the flavors that cannot boot
+// the image.
+func renderBootHints(img images.Image, fl []flavors.Flavor, flErr error) string {
+	title := lipgloss.NewStyle().Bold(true).Foreground(theme.Accent).Render
+	styles := map[HintLevel]lipgloss.Style{
+		HintInfo: lipgloss.NewStyle().Foreground(theme.Muted),
+		HintWarn: lipgloss.NewStyle().Foreground(theme.Warn),
+		HintFail: lipgloss.NewStyle().Foreground(theme.Error),
+	}
+	marks := map[HintLevel]string{HintInfo: "·", HintWarn: "!", HintFail: "✗"}
+
+	var b strings.Builder
+	b.WriteString(title(i18n.T("Boot compatibility")) + "\n")
+	hints := BootHints(img.Metadata)
+	if len(hints) == 0 {
+		b.WriteString(styles[HintInfo].Render("  "+i18n.T("no constraining image properties")) + "\n")
+	}
+	for _, h := range hints {
+		b.WriteString(styles[h.Level].Render(fmt.Sprintf("  %s %s", marks[h.Level], h.Text)) + "\n")
+	}
+	if flErr != nil {
+		b.WriteString(styles[HintInfo].Render(i18n.T("  flavors unavailable: %s", flErr)) + "\n")
+		return b.String()
+	}
+	var fits int
+	var failing, warned []string
+	for _, f := range fl {
+		problems := FlavorFit(img, f)
+		if len(problems) == 0 {
+			fits++
+			continue
+		}
+		texts := make([]string, len(problems))
+		level := HintWarn
+		for i, p := range problems {
+			texts[i] = p.Text
+			level = max(level, p.Level)
+		}
+		line := styles[level].Render(fmt.Sprintf("  %s %s: %s", marks[level], f.Name, strings.Join(texts, "; ")))
+		if level == HintFail {
+			failing = append(failing, line)
+		} else {
+			warned = append(warned, line)
+		}
+	}
+	bad := append(failing, warned...)
+	b.WriteString(i18n.T("  fits %d of %d flavors (min_ram %d MB, min_disk %d GB)", fits, len(fl), img.MinRAM, img.MinDisk) + "\n")
+	const maxFlavorLines = 10
+	for i, line := range bad {
+		if i == maxFlavorLines {
+			b.WriteString(styles[HintInfo].Render(i18n.T("  … and %d more", len(bad)-maxFlavorLines)) + "\n")
+			break
+		}
+		b.WriteString(line + "\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,110 @@
+package image
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/flavors"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/images"
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/projects"
+	glanceimages "github.com/gophercloud/gophercloud/openstack/imageservice/v2/images"
+	"ostui/internal/client"
+)
+
+func TestBootHintsFlagsFailingCombinations(t *testing.T) {
+	hints := BootHints(map[string]interface{}{"hw_disk_bus": "ide", "hw_machine_type": "pc-q35-8.2", "os_secure_boot": "required"})
+	if len(hints) != 2 || hints[0].Level != HintFail || hints[1].Level != HintFail {
+		t.Fatalf("expected two failures, got %+v", hints)
+	}
+	if got := hintSummary(hints); got != "2 fail" {
+		t.Errorf("summary %q", got)
+	}
+	if got := hintSummary(BootHints(map[string]interface{}{"hw_disk_bus": "virtio", "os_distro": "debian"})); got != "ok" {
+		t.Errorf("expected ok for plain virtio image, got %q", got)
+	}
+}
+
+func TestFlavorFit(t *testing.T) {
+	img := images.Image{MinRAM: 1024, MinDisk: 10}
+	cases := []struct {
+		flavor flavors.Flavor
+		want   []HintLevel
+	}{
+		{flavors.Flavor{RAM: 2048, Disk: 20}, nil},
+		{flavors.Flavor{RAM: 512, Disk: 5}, []HintLevel{HintFail, HintFail}},
+		{flavors.Flavor{RAM: 2048, Disk: 0}, []HintLevel{HintWarn}},
+	}
+	for _, c := range cases {
+		got := FlavorFit(img, c.flavor)
+		if len(got) != len(c.want) {
+			t.Fatalf("flavor %+v: got %+v", c.flavor, got)
+		}
+		for i := range got {
+			if got[i].Level != c.want[i] {
+				t.Errorf("flavor %+v: got %+v", c.flavor, got)
+			}
+		}
+	}
+	out := renderBootHints(img, []flavors.Flavor{{Name: "tiny", RAM: 512, Disk: 1}, {Name: "big", RAM: 4096, Disk: 40}}, nil)
+	if !strings.Contains(out, "fits 1 of 2 flavors") || !strings.Contains(out, "tiny: RAM 512 MB < min_ram 1024 MB") {
+		t.Errorf("unexpected render %s", out)
+	}
+}
+
+func TestImageRows(t *testing.T) {
+	list := []glanceimages.Image{
+		{ID: "i1", Name: "ubuntu", Status: "active", SizeBytes: 3 << 30, Visibility: "public", Protected: true, Owner: "p1",
+			Properties: map[string]interface{}{"os_distro": "ubuntu", "os_version": "24.04"}},
+		{ID: "i2", Name: "build-cache", Status: "queued", Visibility: "private", Owner: "p2"},
+		{ID: "i3", Name: "talos", Status: "active", SizeBytes: 512 << 20, Visibility: "community", Owner: "p3"},
+	}
+	rows := imageRows(list, map[string]string{"p1": "admin", "p2": "ci"})
+	if got := strings.Join(rows[0], "|"); got != "i1|ubuntu|active|3.0 GiB|public|yes|ubuntu 24.04|admin|ok" {
+		t.Errorf("row %s", got)
+	}
+	if rows[1][3] != "-" || rows[1][7] != "ci" || rows[2][7] != "p3" {
+		t.Errorf("unexpected size or owner in %v", rows)
+	}
+
+	if got := filterRows(rows, "community", ""); len(got) != 1 || got[0][0] != "i3" {
+		t.Errorf("community filter: %v", got)
+	}
+	if got := filterRows(rows, "", "CI"); len(got) != 1 || got[0][0] != "i2" {
+		t.Errorf("text filter: %v", got)
+	}
+	if got := filterRows(rows, "public", "talos"); len(got) != 0 {
+		t.Errorf("combined filter: %v", got)
+	}
+}
+
+// mockImage has no Glance endpoint, so the list falls back to the compute API.
+type mockImage struct{ client.ImageClient }
+
+func (mockImage) ListImageDetails(ctx context.Context) ([]glanceimages.Image, error) {
+	return nil, errors.New("no image endpoint")
+}
+
+func (mockImage) ListImages(ctx context.Context) ([]images.Image, error) {
+	return []images.Image{{ID: "i1", Name: "cirros", Status: "ACTIVE"}}, nil
+}
+
+type mockIdentity struct{ client.IdentityClient }
+
+func (mockIdentity) ListProjects() ([]projects.Project, error) { return nil, errors.New("forbidden") }
+
+func TestImagesFallbackAndVisibility(t *testing.T) {
+	m := NewImagesModel(mockImage{}, mockIdentity{})
+	updated, _ := m.Update(m.Init()())
+	m = updated.(ImagesModel)
+	if rows := m.table.Rows(); len(rows) != 1 || rows[0][3] != "-" || !strings.Contains(m.View(), "no image endpoint") {
+		t.Fatalf("unexpected fallback rows %v", rows)
+	}
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("v")})
+	m = updated.(ImagesModel)
+	if m.visibility != "public" || len(m.table.Rows()) != 0 || !strings.Contains(m.View(), "Visibility: public (0 of 1)") {
+		t.Errorf("unexpected visibility filter %q with %v", m.visibility, m.table.Rows())
+	}
+}
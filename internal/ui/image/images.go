@@ -2,16 +2,39 @@ package image
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/table"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/images"
+	glanceimages "github.com/gophercloud/gophercloud/openstack/imageservice/v2/images"
 	"ostui/internal/client"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/common"
+	"ostui/internal/ui/theme"
 	"ostui/internal/ui/uiconst"
 	"strings"
 )
 
+// Widths of the fixed image list columns.
+const (
+	bootColWidth       = 16
+	sizeColWidth       = 10
+	visibilityColWidth = 10
+	protectedColWidth  = 5
+	osColWidth         = 16
+	ownerColWidth      = 16
+)
+
+// visibilityCol is the index of the visibility column in a row.
+const visibilityCol = 4
+
+// visibilityFilters are the visibilities v cycles through; empty shows all.
+var visibilityFilters = []string{"", "public", "private", "community", "shared"}
+
 // ImagesModel implements a subview for listing OpenStack images.
 type ImagesModel struct {
 	table      table.Model
@@ -19,51 +42,140 @@ type ImagesModel struct {
 	err        error
 	spinner    spinner.Model
 	client     client.ImageClient
+	identity   client.IdentityClient
 	allRows    []table.Row
 	filterMode bool
 	filter     textinput.Model
+	// visibility restricts the list to one visibility; empty shows all.
+	visibility string
+	// note explains why columns are empty, e.g. without an image endpoint.
+	note string
 	// Dynamic sizing
 	width  int
 	height int
 }
 
-// NewImagesModel creates a new ImagesModel with the given image client.
-func NewImagesModel(ic client.ImageClient) ImagesModel {
+// NewImagesModel creates a new ImagesModel with the given image client. The
+// identity client resolves owner project names; it may be nil.
+func NewImagesModel(ic client.ImageClient, idc client.IdentityClient) ImagesModel {
 	s := spinner.New()
 	s.Spinner = spinner.Dot
 	ti := textinput.New()
 	ti.Placeholder = "filter..."
 	// Initialize with reasonable defaults.
-	return ImagesModel{client: ic, loading: true, spinner: s, filter: ti, width: 120, height: 30}
+	return ImagesModel{client: ic, identity: idc, loading: true, spinner: s, filter: ti, width: 120, height: 30}
 }
 
 type imagesDataLoadedMsg struct {
-	tbl  table.Model
 	rows []table.Row
+	note string
 	err  error
 }
 
 // Init starts async loading of images.
 func (m ImagesModel) Init() tea.Cmd {
+	ic, idc := m.client, m.identity
 	return func() tea.Msg {
-		imgList, err := m.client.ListImages(context.Background())
+		ctx := context.Background()
+		note := ""
+		imgList, err := ic.ListImageDetails(ctx)
 		if err != nil {
-			return imagesDataLoadedMsg{err: err}
+			// The compute API still lists images, without the Glance fields.
+			legacy, lerr := ic.ListImages(ctx)
+			if lerr != nil {
+				return imagesDataLoadedMsg{err: lerr}
+			}
+			imgList = fromComputeImages(legacy)
+			note = i18n.T("Size, visibility and owner unavailable: %s", err)
 		}
-		cols := []table.Column{{Title: "ID", Width: uiconst.ColWidthUUID}, {Title: "Name", Width: uiconst.ColWidthName}, {Title: "Status", Width: uiconst.ColWidthStatus}}
-		rows := []table.Row{}
-		for _, img := range imgList {
-			rows = append(rows, table.Row{img.ID, img.Name, img.Status})
+		owners := map[string]string{}
+		if idc != nil {
+			// Only admins see every project; other owners stay IDs.
+			if list, err := idc.ListProjects(); err == nil {
+				for _, p := range list {
+					owners[p.ID] = p.Name
+				}
+			}
 		}
-		t := table.New(
-			table.WithColumns(cols),
-			table.WithRows(rows),
-			table.WithFocused(true),
-			table.WithHeight(m.height-uiconst.TableHeightOffset),
-		)
-		t.SetStyles(table.DefaultStyles())
-		return imagesDataLoadedMsg{tbl: t, rows: rows}
+		return imagesDataLoadedMsg{rows: common.TerraformRows(imageRows(imgList, owners)), note: note}
+	}
+}
+
+// fromComputeImages converts images listed through the compute API.
+func fromComputeImages(list []images.Image) []glanceimages.Image {
+	out := make([]glanceimages.Image, 0, len(list))
+	for _, img := range list {
+		out = append(out, glanceimages.Image{ID: img.ID, Name: img.Name, Status: glanceimages.ImageStatus(img.Status),
+			MinDiskGigabytes: img.MinDisk, MinRAMMegabytes: img.MinRAM, Properties: img.Metadata})
 	}
+	return out
+}
+
+// imageRows builds one row per image; owners maps project IDs to names.
+func imageRows(list []glanceimages.Image, owners map[string]string) []table.Row {
+	rows := make([]table.Row, 0, len(list))
+	for _, img := range list {
+		size := "-"
+		if img.SizeBytes > 0 {
+			size = common.FormatBytes(img.SizeBytes)
+		}
+		protected := ""
+		if img.Protected {
+			protected = "yes"
+		}
+		owner := img.Owner
+		if name, ok := owners[owner]; ok && name != "" {
+			owner = name
+		}
+		rows = append(rows, table.Row{img.ID, img.Name, theme.Mark(string(img.Status)), size, string(img.Visibility), protected,
+			osLabel(img.Properties), owner, hintSummary(BootHints(img.Properties))})
+	}
+	return rows
+}
+
+// osLabel joins the os_distro and os_version properties, e.g. "ubuntu 24.04".
+func osLabel(props map[string]interface{}) string {
+	return strings.TrimSpace(prop(props, "os_distro") + " " + prop(props, "os_version"))
+}
+
+// filterRows keeps the rows of the given visibility (any when empty) that
+// contain text in some column.
+func filterRows(rows []table.Row, visibility, text string) []table.Row {
+	lower := strings.ToLower(text)
+	out := []table.Row{}
+	for _, r := range rows {
+		if visibility != "" && r[visibilityCol] != visibility {
+			continue
+		}
+		if lower == "" {
+			out = append(out, r)
+			continue
+		}
+		for _, c := range r {
+			if strings.Contains(strings.ToLower(c), lower) {
+				out = append(out, r)
+				break
+			}
+		}
+	}
+	return out
+}
+
+// applyFilters shows the rows matching the visibility and text filters.
+func (m *ImagesModel) applyFilters() {
+	m.table.SetRows(filterRows(m.allRows, m.visibility, m.filter.Value()))
+	if m.table.Cursor() >= len(m.table.Rows()) {
+		m.table.SetCursor(max(len(m.table.Rows())-1, 0))
+	}
+}
+
+// Reload re-fetches the images, keeping the selected row and the
+// visibility filter.
+func (m ImagesModel) Reload() tea.Cmd {
+	if m.loading || m.filterMode {
+		return nil
+	}
+	return m.Init()
 }
 
 // Update handles messages for the model.
@@ -75,11 +187,14 @@ func (m ImagesModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.err = msg.err
 			return m, nil
 		}
-		m.table = msg.tbl
-		m.allRows = msg.rows
-		// Adjust columns and height based on current dimensions.
+		m.allRows, m.note = msg.rows, msg.note
+		prev := m.table
+		m.table = table.New(table.WithFocused(true))
+		m.table.SetStyles(table.DefaultStyles())
 		m.updateTableColumns()
 		m.table.SetHeight(m.height - 6)
+		m.applyFilters()
+		m.table = common.Reloaded(prev, m.table)
 		return m, nil
 	case tea.WindowSizeMsg:
 		// Update stored dimensions and adjust table.
@@ -104,29 +219,24 @@ func (m ImagesModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.filterMode = false
 			m.filter.Blur()
 			m.filter.SetValue("")
-			m.table.SetRows(m.allRows)
+			m.applyFilters()
 			return m, nil
 		}
 		if m.filterMode {
 			var cmd tea.Cmd
 			m.filter, cmd = m.filter.Update(msg)
-			filterVal := m.filter.Value()
-			if filterVal == "" {
-				m.table.SetRows(m.allRows)
-			} else {
-				lower := strings.ToLower(filterVal)
-				filtered := []table.Row{}
-				for _, r := range m.allRows {
-					for _, c := range r {
-						if strings.Contains(strings.ToLower(c), lower) {
-							filtered = append(filtered, r)
-							break
-						}
-					}
+			m.applyFilters()
+			return m, cmd
+		}
+		if msg.String() == "v" {
+			for i, v := range visibilityFilters {
+				if v == m.visibility {
+					m.visibility = visibilityFilters[(i+1)%len(visibilityFilters)]
+					break
 				}
-				m.table.SetRows(filtered)
 			}
-			return m, cmd
+			m.applyFilters()
+			return m, nil
 		}
 		// Normal table navigation
 		var cmd tea.Cmd
@@ -148,53 +258,99 @@ func (m ImagesModel) View() string {
 		return m.spinner.View()
 	}
 	if m.err != nil {
-		return fmt.Sprintf("Error: %s", m.err)
+		return i18n.T("Error: %s", m.err)
+	}
+	header := ""
+	if m.visibility != "" {
+		header = i18n.T("Visibility: %s (%d of %d)  [v] next", m.visibility, len(m.table.Rows()), len(m.allRows)) + "\n"
+	}
+	if m.note != "" {
+		header += lipgloss.NewStyle().Foreground(theme.Warn).Render(m.note) + "\n"
 	}
 	if m.filterMode {
-		filterLine := fmt.Sprintf("Filter: %s", m.filter.View())
+		filterLine := i18n.T("Filter: %s", m.filter.View())
 		footer := "esc: clear"
-		return fmt.Sprintf("%s\n%s\n%s", filterLine, m.table.View(), footer)
+		return fmt.Sprintf("%s%s\n%s\n%s", header, filterLine, m.table.View(), footer)
 	}
-	return m.table.View()
+	return header + m.table.View()
 }
 
 // updateTableColumns adjusts column widths based on the current width.
 func (m *ImagesModel) updateTableColumns() {
 	idW := uiconst.ColWidthUUID
 	statusW := uiconst.ColWidthStatus
+	fixed := idW + statusW + sizeColWidth + visibilityColWidth + protectedColWidth + osColWidth + ownerColWidth + bootColWidth
 	// Compute flexible name width.
-	nameW := m.width - idW - statusW - uiconst.TableHeightOffset
-	if nameW < 10 {
-		nameW = 10
+	nameW := m.width - fixed - uiconst.TableHeightOffset - common.TerraformWidth()
+	if nameW < 16 {
+		nameW = 16
 	}
-	m.table.SetColumns([]table.Column{{Title: "ID", Width: idW}, {Title: "Name", Width: nameW}, {Title: "Status", Width: statusW}})
+	m.table.SetColumns(common.TerraformColumns([]table.Column{{Title: "ID", Width: idW}, {Title: i18n.T("Name"), Width: nameW}, {Title: i18n.T("Status"), Width: statusW},
+		{Title: i18n.T("Size"), Width: sizeColWidth}, {Title: i18n.T("Visibility"), Width: visibilityColWidth}, {Title: i18n.T("Prot."), Width: protectedColWidth},
+		{Title: "OS", Width: osColWidth}, {Title: i18n.T("Owner"), Width: ownerColWidth}, {Title: i18n.T("Boot"), Width: bootColWidth}}))
 }
 
 // Table returns the underlying table model.
 func (m ImagesModel) Table() table.Model { return m.table }
 
+// Filtering reports whether keys are typed into the filter.
+func (m ImagesModel) Filtering() bool { return m.filterMode }
+
 var _ tea.Model = (*ImagesModel)(nil)
 
 // ImageDetailModel displays detailed information for a single image.
 type ImageDetailModel struct {
-	table   table.Model
-	loading bool
-	err     error
-	spinner spinner.Model
-	client  client.ImageClient
-	imageID string
+	table     table.Model
+	loading   bool
+	err       error
+	spinner   spinner.Model
+	client    client.ImageClient
+	compute   client.ComputeClient
+	imageID   string
+	imageName string
+	// hints is the boot compatibility section.
+	hints string
+	// Download to a local file
+	prompting   bool
+	pathInput   textinput.Model
+	downloading bool
+	progress    downloadProgressMsg
+	progressCh  chan downloadProgressMsg
+	// cancel stops the running download.
+	cancel context.CancelFunc
+	status string
 }
 
 type imageDetailDataLoadedMsg struct {
-	tbl table.Model
-	err error
+	tbl   table.Model
+	name  string
+	hints string
+	err   error
 }
 
+// downloadProgressMsg carries the byte count of a running download.
+type downloadProgressMsg struct {
+	done, total int64
+}
+
+// downloadDoneMsg is emitted when a download has finished or failed.
+type downloadDoneMsg struct {
+	result *client.ImageDownload
+	err    error
+}
+
+// CapturingInput reports whether the download path prompt is open.
+func (m ImageDetailModel) CapturingInput() bool { return m.prompting }
+
+// ResourceID returns the image ID.
+func (m ImageDetailModel) ResourceID() string { return m.imageID }
+
 // NewImageDetailModel creates a new ImageDetailModel for the given image ID.
-func NewImageDetailModel(ic client.ImageClient, imageID string) ImageDetailModel {
+// The compute client provides the flavors checked against the image.
+func NewImageDetailModel(ic client.ImageClient, cc client.ComputeClient, imageID string) ImageDetailModel {
 	s := spinner.New()
 	s.Spinner = spinner.Dot
-	return ImageDetailModel{client: ic, loading: true, spinner: s, imageID: imageID}
+	return ImageDetailModel{client: ic, compute: cc, loading: true, spinner: s, imageID: imageID}
 }
 
 // Init starts async loading of image details.
@@ -204,7 +360,7 @@ func (m ImageDetailModel) Init() tea.Cmd {
 		if err != nil {
 			return imageDetailDataLoadedMsg{err: err}
 		}
-		cols := []table.Column{{Title: "Field", Width: uiconst.ColWidthField}, {Title: "Value", Width: uiconst.ColWidthValue}}
+		cols := []table.Column{{Title: i18n.T("Field"), Width: uiconst.ColWidthField}, {Title: i18n.T("Value"), Width: uiconst.ColWidthValue}}
 		rows := []table.Row{{"ID", img.ID}, {"Name", img.Name}, {"Status", img.Status}, {"MinDisk (GB)", fmt.Sprintf("%d", img.MinDisk)}, {"MinRAM (MB)", fmt.Sprintf("%d", img.MinRAM)}, {"Created", img.Created}, {"Updated", img.Updated}}
 		t := table.New(
 			table.WithColumns(cols),
@@ -212,10 +368,19 @@ func (m ImageDetailModel) Init() tea.Cmd {
 			table.WithFocused(true),
 		)
 		t.SetStyles(table.DefaultStyles())
-		return imageDetailDataLoadedMsg{tbl: t}
+		fl, flErr := m.compute.ListFlavors()
+		return imageDetailDataLoadedMsg{tbl: t, name: img.Name, hints: renderBootHints(*img, fl, flErr)}
 	}
 }
 
+// Reload re-fetches the image, keeping the selected row.
+func (m ImageDetailModel) Reload() tea.Cmd {
+	if m.loading {
+		return nil
+	}
+	return m.Init()
+}
+
 // Update handles messages.
 func (m ImageDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
@@ -225,7 +390,19 @@ func (m ImageDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.err = msg.err
 			return m, nil
 		}
-		m.table = msg.tbl
+		m.table = common.Reloaded(m.table, msg.tbl)
+		m.imageName = msg.name
+		m.hints = msg.hints
+		return m, nil
+	case downloadProgressMsg:
+		m.progress = msg
+		return m, waitDownloadProgress(m.progressCh)
+	case downloadDoneMsg:
+		m.downloading = false
+		if m.cancel != nil {
+			m.cancel()
+		}
+		m.status = downloadStatus(msg.result, msg.err)
 		return m, nil
 	case tea.WindowSizeMsg:
 		// Adjust table width to fill the terminal width.
@@ -251,14 +428,54 @@ func (m ImageDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 	case tea.KeyMsg:
+		if m.prompting {
+			switch msg.String() {
+			case "esc":
+				m.prompting = false
+				m.pathInput.Blur()
+				return m, nil
+			case "enter":
+				m.prompting = false
+				m.pathInput.Blur()
+				path := strings.TrimSpace(m.pathInput.Value())
+				if path == "" {
+					return m, nil
+				}
+				m.downloading = true
+				m.progress = downloadProgressMsg{}
+				m.status = ""
+				m.progressCh = make(chan downloadProgressMsg, 1)
+				var ctx context.Context
+				ctx, m.cancel = context.WithCancel(context.Background())
+				return m, tea.Batch(m.spinner.Tick, downloadImageCmd(ctx, m.client, m.imageID, path, m.progressCh), waitDownloadProgress(m.progressCh))
+			}
+			var cmd tea.Cmd
+			m.pathInput, cmd = m.pathInput.Update(msg)
+			return m, cmd
+		}
 		if m.loading || m.err != nil {
 			return m, nil
 		}
+		if msg.String() == "D" && !m.downloading {
+			ti := textinput.New()
+			ti.Placeholder = "image.qcow2"
+			ti.Width = 60
+			name := m.imageName
+			if name == "" {
+				name = m.imageID
+			}
+			ti.SetValue(name + ".img")
+			ti.Focus()
+			m.pathInput = ti
+			m.prompting = true
+			m.status = ""
+			return m, textinput.Blink
+		}
 		var cmd tea.Cmd
 		m.table, cmd = m.table.Update(msg)
 		return m, cmd
 	default:
-		if m.loading {
+		if m.loading || m.downloading {
 			var cmd tea.Cmd
 			m.spinner, cmd = m.spinner.Update(msg)
 			return m, cmd
@@ -267,15 +484,86 @@ func (m ImageDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// Dispose stops a download still running when the view is closed.
+func (m ImageDetailModel) Dispose() {
+	if m.cancel != nil {
+		m.cancel()
+	}
+}
+
 // View renders the image detail view.
 func (m ImageDetailModel) View() string {
 	if m.loading {
 		return m.spinner.View()
 	}
 	if m.err != nil {
-		return fmt.Sprintf("Error: %s", m.err)
+		return i18n.T("Error: %s", m.err)
+	}
+	footer := i18n.T("[D]ownload [esc] back")
+	switch {
+	case m.prompting:
+		footer = i18n.T("Download to: %s\n[enter] confirm  [esc] cancel", m.pathInput.View())
+	case m.downloading:
+		footer = i18n.T("%s Downloading %s", m.spinner.View(), progressBar(m.progress.done, m.progress.total, 30))
+	case m.status != "":
+		footer = m.status + "\n" + footer
+	}
+	return fmt.Sprintf("%s\n%s\n%s", m.table.View(), m.hints, footer)
+}
+
+// downloadImageCmd downloads the image in the background, sending progress
+// updates on ch (dropping them when the UI is behind) and closing it when done.
+func downloadImageCmd(ctx context.Context, ic client.ImageClient, imageID, path string, ch chan downloadProgressMsg) tea.Cmd {
+	return func() tea.Msg {
+		defer close(ch)
+		result, err := ic.DownloadImage(ctx, imageID, path, func(done, total int64) {
+			select {
+			case ch <- downloadProgressMsg{done: done, total: total}:
+			default:
+			}
+		})
+		return downloadDoneMsg{result: result, err: err}
+	}
+}
+
+// waitDownloadProgress waits for the next progress update.
+func waitDownloadProgress(ch chan downloadProgressMsg) tea.Cmd {
+	return func() tea.Msg {
+		p, ok := <-ch
+		if !ok {
+			return nil
+		}
+		return p
+	}
+}
+
+// downloadStatus describes the outcome of a download, including checksum results.
+func downloadStatus(result *client.ImageDownload, err error) string {
+	var mismatch *client.ChecksumMismatchError
+	if errors.As(err, &mismatch) {
+		return i18n.T("CHECKSUM MISMATCH (%s): expected %s, got %s; file discarded", mismatch.Checksum.Algorithm, mismatch.Checksum.Expected, mismatch.Checksum.Actual)
+	}
+	if err != nil {
+		return i18n.T("Download failed: %s", err)
+	}
+	if len(result.Checksums) == 0 {
+		return i18n.T("Saved %s (%s); not verified: Glance reported no checksum", result.Path, common.FormatBytes(result.Bytes))
+	}
+	var algos []string
+	for _, c := range result.Checksums {
+		algos = append(algos, c.Algorithm)
+	}
+	return i18n.T("Saved %s (%s); %s verified", result.Path, common.FormatBytes(result.Bytes), strings.Join(algos, ", "))
+}
+
+// progressBar renders a text progress bar, or a byte count when the size is unknown.
+func progressBar(done, total int64, width int) string {
+	if total <= 0 {
+		return common.FormatBytes(done)
 	}
-	return fmt.Sprintf("%s\n[esc] back", m.table.View())
+	filled := int(float64(width) * float64(done) / float64(total))
+	filled = min(max(filled, 0), width)
+	return fmt.Sprintf("[%s%s] %3d%% %s / %s", strings.Repeat("█", filled), strings.Repeat("░", width-filled), done*100/total, common.FormatBytes(done), common.FormatBytes(total))
 }
 
 // Table returns the underlying table model.
@@ -287,7 +575,7 @@ var _ tea.Model = (*ImageDetailModel)(nil)
 func DeleteImage(ic client.ImageClient, imageID string) string {
 	err := ic.DeleteImage(context.Background(), imageID)
 	if err != nil {
-		return fmt.Sprintf("Failed to delete image %s: %s", imageID, err)
+		return i18n.T("Failed to delete image %s: %s", imageID, err)
 	}
-	return fmt.Sprintf("Image %s deleted successfully.", imageID)
+	return i18n.T("Image %s deleted successfully.", imageID)
 }
@@ -0,0 +1,380 @@
+// Package importer creates a batch of servers, volumes and floating IPs
+// described in a CSV or YAML file, after checking it against the project's
+// quotas and showing the plan.
+package importer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/gophercloud/gophercloud/openstack/blockstorage/v3/volumes"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/layer3/floatingips"
+	"ostui/internal/client"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/policy"
+	"ostui/internal/ui/theme"
+	"ostui/internal/ui/uiconst"
+)
+
+// ClientSet holds the clients an import reads from and creates with.
+type ClientSet struct {
+	Compute  client.ComputeClient
+	Network  client.NetworkClient
+	Storage  client.StorageClient
+	Image    client.ImageClient
+	Limits   client.LimitsClient
+	Identity client.IdentityClient
+}
+
+// ImportModel reads an import file, shows the resources it would create with
+// the problems that block it, and creates them one at a time.
+type ImportModel struct {
+	table    table.Model
+	loading  bool
+	err      error
+	spinner  spinner.Model
+	clients  ClientSet
+	path     string
+	inv      inventory
+	steps    []step
+	problems []string
+
+	// running is set while resources are created; current is the step being
+	// created or -1. started is set once anything was sent, after which the
+	// file can no longer be reloaded.
+	running   bool
+	started   bool
+	current   int
+	status    string
+	statusErr bool
+
+	width  int
+	height int
+}
+
+// NewImportModel creates the import of the file at path; a leading ~/ is
+// the home directory.
+func NewImportModel(cs ClientSet, path string) ImportModel {
+	s := spinner.New()
+	s.Spinner = spinner.Dot
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(home, rest)
+		}
+	}
+	return ImportModel{clients: cs, path: path, loading: true, spinner: s, current: -1, width: 120, height: 30}
+}
+
+type importLoadedMsg struct {
+	specs []spec
+	inv   inventory
+	err   error
+}
+
+type importStepMsg struct {
+	index int
+	id    string
+	err   error
+}
+
+// Init reads the file and the inventory.
+func (m ImportModel) Init() tea.Cmd {
+	return tea.Batch(m.spinner.Tick, m.loadCmd())
+}
+
+// loadCmd reads the file, then the flavors, images and networks its rows
+// refer to and the quotas they use. Quotas that cannot be read are reported
+// and left unchecked.
+func (m ImportModel) loadCmd() tea.Cmd {
+	cs, path := m.clients, m.path
+	return func() tea.Msg {
+		specs, err := readSpecFile(path)
+		if err != nil {
+			return importLoadedMsg{err: err}
+		}
+		ctx := context.Background()
+		inv := inventory{quotas: map[string]quota{}}
+		if inv.flavors, err = cs.Compute.ListFlavors(); err != nil {
+			return importLoadedMsg{err: fmt.Errorf("failed to list flavors: %w", err)}
+		}
+		if inv.images, err = cs.Image.ListImages(ctx); err != nil {
+			return importLoadedMsg{err: fmt.Errorf("failed to list images: %w", err)}
+		}
+		if inv.networks, err = cs.Network.ListNetworks(); err != nil {
+			return importLoadedMsg{err: fmt.Errorf("failed to list networks: %w", err)}
+		}
+		if l, err := cs.Limits.GetLimits(ctx); err != nil {
+			inv.warnings = append(inv.warnings, "compute and volume quotas not checked: "+err.Error())
+		} else {
+			c, v := l.Compute.Absolute, l.Volume.Absolute
+			inv.quotas[quotaInstances] = quota{"Instances", c.TotalInstancesUsed, c.MaxTotalInstances}
+			inv.quotas[quotaCores] = quota{"vCPUs", c.TotalCoresUsed, c.MaxTotalCores}
+			inv.quotas[quotaRAM] = quota{"RAM (MiB)", c.TotalRAMUsed, c.MaxTotalRAMSize}
+			inv.quotas[quotaVolumes] = quota{"Volumes", v.TotalVolumesUsed, v.MaxTotalVolumes}
+			inv.quotas[quotaGigabytes] = quota{"Volume GB", v.TotalGigabytesUsed, v.MaxTotalVolumeGigabytes}
+		}
+		if err := floatingIPQuota(ctx, cs, inv.quotas); err != nil {
+			inv.warnings = append(inv.warnings, "floating IP quota not checked: "+err.Error())
+		}
+		return importLoadedMsg{specs: specs, inv: inv}
+	}
+}
+
+// floatingIPQuota reads the floating IP quota of the current project into q.
+func floatingIPQuota(ctx context.Context, cs ClientSet, q map[string]quota) error {
+	p, err := cs.Identity.GetCurrentProject()
+	if err != nil {
+		return err
+	}
+	nq, err := cs.Network.GetQuota(ctx, p.ID)
+	if err != nil {
+		return err
+	}
+	q[quotaFloatingIPs] = quota{"Floating IPs", nq.FloatingIP.Used, nq.FloatingIP.Limit}
+	return nil
+}
+
+// Update handles messages for the model.
+func (m ImportModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case importLoadedMsg:
+		m.loading = false
+		m.err = msg.err
+		if msg.err != nil {
+			return m, nil
+		}
+		m.inv = msg.inv
+		m.steps, m.problems = planImport(msg.specs, msg.inv)
+		m.status, m.statusErr = "", false
+		m.refreshTable()
+		return m, nil
+	case importStepMsg:
+		if msg.index != m.current {
+			return m, nil
+		}
+		st := &m.steps[msg.index]
+		if msg.err != nil {
+			policy.Record("creating resources", msg.err)
+			st.state, st.detail = stateFailed, msg.err.Error()
+		} else {
+			st.state, st.id = stateCreated, msg.id
+		}
+		updated, cmd := m.createNext()
+		updated.refreshTable()
+		return updated, cmd
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		if !m.loading && m.err == nil {
+			m.refreshTable()
+		}
+		return m, nil
+	case tea.KeyMsg:
+		if m.loading || m.running {
+			return m, nil
+		}
+		switch msg.String() {
+		case "y":
+			if m.err != nil || len(m.problems) > 0 {
+				return m, nil
+			}
+			if err := policy.Check(policy.Member, "creating resources"); err != nil {
+				m.status, m.statusErr = err.Error(), true
+				return m, nil
+			}
+			// After a run, y retries the resources that failed.
+			if m.counts()[statePending] == 0 {
+				for i := range m.steps {
+					if m.steps[i].state == stateFailed {
+						m.steps[i].state, m.steps[i].detail = statePending, ""
+					}
+				}
+				if m.counts()[statePending] == 0 {
+					return m, nil
+				}
+			}
+			m.running, m.started, m.status = true, true, ""
+			updated, cmd := m.createNext()
+			updated.refreshTable()
+			return updated, cmd
+		case "r":
+			// Reloading after a partial run would create the rows again.
+			if m.started {
+				return m, nil
+			}
+			m.loading = true
+			return m, tea.Batch(m.spinner.Tick, m.loadCmd())
+		}
+		if m.err != nil {
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.table, cmd = m.table.Update(msg)
+		return m, cmd
+	default:
+		if m.loading {
+			var cmd tea.Cmd
+			m.spinner, cmd = m.spinner.Update(msg)
+			return m, cmd
+		}
+	}
+	return m, nil
+}
+
+// createNext creates the next pending resource, or ends the run.
+func (m ImportModel) createNext() (ImportModel, tea.Cmd) {
+	i := -1
+	for j, st := range m.steps {
+		if st.state == statePending {
+			i = j
+			break
+		}
+	}
+	if i < 0 {
+		m.running, m.current = false, -1
+		n := m.counts()
+		m.status, m.statusErr = i18n.T("%d created, %d failed", n[stateCreated], n[stateFailed]), n[stateFailed] > 0
+		return m, nil
+	}
+	m.current = i
+	cs, st := m.clients, m.steps[i]
+	return m, func() tea.Msg {
+		id, err := create(cs, st)
+		return importStepMsg{index: i, id: id, err: err}
+	}
+}
+
+// create sends the request creating one resource and returns its ID.
+func create(cs ClientSet, st step) (string, error) {
+	switch st.kind {
+	case kindServer:
+		s, err := cs.Compute.CreateInstance(context.Background(), servers.CreateOpts{
+			Name: st.name, FlavorRef: st.flavor.ID, ImageRef: st.image.ID,
+			Networks: []servers.Network{{UUID: st.network.ID}}, AvailabilityZone: st.zone,
+		})
+		return s.ID, err
+	case kindVolume:
+		v, err := cs.Storage.CreateVolume(volumes.CreateOpts{Name: st.name, Size: st.size, AvailabilityZone: st.zone})
+		return v.ID, err
+	case kindFloatingIP:
+		f, err := cs.Network.AllocateFloatingIP(floatingips.CreateOpts{FloatingNetworkID: st.network.ID, Description: st.name})
+		return f.ID, err
+	}
+	return "", fmt.Errorf("unknown kind %q", st.kind)
+}
+
+// counts returns the number of steps in each state.
+func (m ImportModel) counts() map[string]int {
+	n := map[string]int{}
+	for _, st := range m.steps {
+		n[st.state]++
+	}
+	return n
+}
+
+// quotaLine summarises the quotas the import uses as "used+new/limit".
+func (m ImportModel) quotaLine() string {
+	need := usage(m.steps)
+	var parts []string
+	for _, key := range quotaOrder {
+		q, ok := m.inv.quotas[key]
+		if !ok || need[key] == 0 {
+			continue
+		}
+		limit := strconv.Itoa(q.limit)
+		if q.limit < 0 {
+			limit = "∞"
+		}
+		parts = append(parts, fmt.Sprintf("%s %d+%d/%s", q.label, q.used, need[key], limit))
+	}
+	return strings.Join(parts, " · ")
+}
+
+// refreshTable rebuilds the plan table.
+func (m *ImportModel) refreshTable() {
+	lineW, kindW := 6, 12
+	rest := m.width - lineW - kindW - uiconst.ColWidthUUID - uiconst.TableHeightOffset
+	if rest < 60 {
+		rest = 60
+	}
+	w := rest / 3
+	cols := []table.Column{{Title: i18n.T("Line"), Width: lineW}, {Title: i18n.T("Kind"), Width: kindW}, {Title: i18n.T("Name"), Width: w}, {Title: i18n.T("Spec"), Width: w},
+		{Title: "ID", Width: uiconst.ColWidthUUID}, {Title: i18n.T("State"), Width: rest - 2*w}}
+	rows := make([]table.Row, 0, len(m.steps))
+	for _, st := range m.steps {
+		name, id, state := st.name, st.id, st.state
+		if name == "" {
+			name = "-"
+		}
+		if id == "" {
+			id = "-"
+		}
+		if st.detail != "" {
+			state += ": " + st.detail
+		}
+		rows = append(rows, table.Row{strconv.Itoa(st.line), st.kind, name, st.describe(), id, state})
+	}
+	cursor := m.table.Cursor()
+	m.table = table.New(table.WithColumns(cols), table.WithFocused(true))
+	m.table.SetStyles(table.DefaultStyles())
+	m.table.SetRows(rows)
+	m.table.SetHeight(m.height - uiconst.TableHeightOffset - 7 - len(m.problems) - len(m.inv.warnings))
+	if cursor < len(rows) {
+		m.table.SetCursor(cursor)
+	}
+}
+
+// View renders the problems, the plan and the run's progress.
+func (m ImportModel) View() string {
+	out := lipgloss.NewStyle().Bold(true).Foreground(theme.Accent).Render("Import of "+m.path) + "\n"
+	if m.loading {
+		return out + m.spinner.View()
+	}
+	if m.err != nil {
+		return out + i18n.T("Error: %s", m.err) + "\n" + i18n.T("[r] reload  [esc] back")
+	}
+	warn := lipgloss.NewStyle().Foreground(theme.Error)
+	dim := lipgloss.NewStyle().Foreground(theme.Muted)
+	for _, p := range m.problems {
+		out += warn.Render("✗ "+p) + "\n"
+	}
+	for _, w := range m.inv.warnings {
+		out += dim.Render("⚠ "+w) + "\n"
+	}
+	if q := m.quotaLine(); q != "" {
+		out += dim.Render("Quota: "+q) + "\n"
+	}
+	out += m.table.View()
+	switch {
+	case m.running:
+		return out + "\n" + i18n.T("Creating %d of %d…", m.current+1, len(m.steps))
+	case m.statusErr:
+		out += "\n" + warn.Render(m.status)
+	case m.status != "":
+		out += "\n" + lipgloss.NewStyle().Foreground(theme.OK).Render(m.status)
+	case len(m.problems) > 0:
+		return out + "\n" + i18n.T("Fix the file and reload.\n[r] reload  [esc] back")
+	default:
+		return out + "\n" + i18n.T("Create %d resources?", m.counts()[statePending]) + " " + policy.Key(policy.Member, i18n.T("[y] create"), "creating resources") + "  " + i18n.T("[r] reload  [esc] back")
+	}
+	if m.counts()[stateFailed] > 0 {
+		return out + "\n" + policy.Key(policy.Member, i18n.T("[y] retry failed"), "creating resources") + "  " + i18n.T("[esc] back")
+	}
+	return out + "\n" + i18n.T("[esc] back")
+}
+
+// CapturingInput reports whether resources are being created.
+func (m ImportModel) CapturingInput() bool { return m.running }
+
+// Table returns the plan table.
+func (m ImportModel) Table() table.Model { return m.table }
+
+var _ tea.Model = (*ImportModel)(nil)
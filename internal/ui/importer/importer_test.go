@@ -0,0 +1,220 @@
+package importer
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	vLimits "github.com/gophercloud/gophercloud/openstack/blockstorage/extensions/limits"
+	"github.com/gophercloud/gophercloud/openstack/blockstorage/v3/volumes"
+	cLimits "github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/limits"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/flavors"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/images"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
+	"github.com/gophercloud/gophercloud/openstack/identity/v3/projects"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/layer3/floatingips"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/quotas"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/networks"
+	"ostui/internal/client"
+)
+
+func TestParseCSV(t *testing.T) {
+	in := "# batch for the load test\nKind,name,count,size\nvolume,data-{n:2},3,20\n\nfip,,2,\n"
+	list, err := parseCSV(strings.NewReader(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 2 || list[0].Kind != "volume" || list[0].Count != 3 || list[0].Size != 20 || list[0].line != 3 || list[1].line != 5 {
+		t.Fatalf("unexpected rows %+v", list)
+	}
+	if _, err := parseCSV(strings.NewReader("kind,disk\nvolume,20\n")); err == nil || !strings.Contains(err.Error(), `unknown column "disk"`) {
+		t.Errorf("expected an unknown column error, got %v", err)
+	}
+	if _, err := parseCSV(strings.NewReader("kind,count\nvolume,lots\n")); err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("expected a line number in %v", err)
+	}
+}
+
+func TestExpandName(t *testing.T) {
+	for _, tc := range []struct {
+		pattern  string
+		n, count int
+		want     string
+	}{
+		{"web", 1, 1, "web"},
+		{"web", 2, 3, "web-2"},
+		{"web-{n:3}", 7, 10, "web-007"},
+		{"{n}-node-{n}", 4, 5, "4-node-4"},
+		{"", 2, 3, ""},
+	} {
+		if got := expandName(tc.pattern, tc.n, tc.count); got != tc.want {
+			t.Errorf("expandName(%q, %d, %d) = %q, want %q", tc.pattern, tc.n, tc.count, got, tc.want)
+		}
+	}
+}
+
+func testInventory() inventory {
+	return inventory{
+		flavors:  []flavors.Flavor{{ID: "f1", Name: "m1.small", VCPUs: 2, RAM: 2048}, {ID: "f2", Name: "m1.large", VCPUs: 8, RAM: 16384}},
+		images:   []images.Image{{ID: "i1", Name: "ubuntu"}, {ID: "i2", Name: "debian"}, {ID: "i3", Name: "debian"}},
+		networks: []networks.Network{{ID: "n1", Name: "private"}, {ID: "n2", Name: "public"}},
+		quotas: map[string]quota{
+			quotaInstances:   {"Instances", 8, 10},
+			quotaCores:       {"vCPUs", 10, 30},
+			quotaVolumes:     {"Volumes", 0, -1},
+			quotaFloatingIPs: {"Floating IPs", 1, 50},
+		},
+	}
+}
+
+func TestPlanImport(t *testing.T) {
+	steps, problems := planImport([]spec{
+		{Kind: "server", Name: "web-{n}", Count: 2, Flavor: "m1.small", Image: "ubuntu", Network: "private", line: 2},
+		{Kind: "volumes", Name: "data", Count: 30, Size: 10, line: 3},
+		{Kind: "fip", Count: 2, Network: "n2", line: 4},
+	}, testInventory())
+	if len(problems) != 0 {
+		t.Fatalf("unexpected problems %v", problems)
+	}
+	if len(steps) != 34 || steps[1].name != "web-2" || steps[1].flavor.ID != "f1" || steps[31].name != "data-30" || steps[33].network.ID != "n2" {
+		t.Fatalf("unexpected steps %+v", steps)
+	}
+
+	_, problems = planImport([]spec{
+		{Kind: "server", Name: "db", Count: 3, Flavor: "m1.large", Image: "ubuntu", Network: "private", line: 2},
+		{Kind: "server", Name: "x", Flavor: "m1.small", Image: "debian", Network: "private", line: 3},
+		{Kind: "volume", Name: "v", line: 4},
+		{Kind: "router", line: 5},
+	}, testInventory())
+	want := []string{
+		`line 3: 2 images are named "debian": use the ID`,
+		"line 4: volumes need a size in GB",
+		`line 5: unknown kind "router"`,
+		"Instances: 8 in use + 3 to create exceeds the quota of 10",
+		"vCPUs: 10 in use + 24 to create exceeds the quota of 30",
+	}
+	if len(problems) != len(want) {
+		t.Fatalf("got problems %q, want %q", problems, want)
+	}
+	for i, w := range want {
+		if !strings.HasPrefix(problems[i], w) {
+			t.Errorf("problem %d = %q, want prefix %q", i, problems[i], w)
+		}
+	}
+}
+
+// mockCompute, mockStorage and mockNetwork implement the calls made by an
+// import; the embedded interfaces panic on anything else.
+type mockCompute struct {
+	client.ComputeClient
+	created []string
+}
+
+func (m *mockCompute) ListFlavors() ([]flavors.Flavor, error) { return testInventory().flavors, nil }
+func (m *mockCompute) CreateInstance(ctx context.Context, opts servers.CreateOptsBuilder) (servers.Server, error) {
+	o := opts.(servers.CreateOpts)
+	m.created = append(m.created, o.Name+"/"+o.FlavorRef+"/"+o.ImageRef+"/"+o.Networks.([]servers.Network)[0].UUID)
+	return servers.Server{ID: "srv-" + o.Name}, nil
+}
+
+type mockStorage struct {
+	client.StorageClient
+	failures int
+}
+
+func (m *mockStorage) CreateVolume(opts volumes.CreateOpts) (volumes.Volume, error) {
+	if m.failures > 0 {
+		m.failures--
+		return volumes.Volume{}, errors.New("VolumeLimitExceeded")
+	}
+	return volumes.Volume{ID: "vol-" + opts.Name}, nil
+}
+
+type mockNetwork struct {
+	client.NetworkClient
+	allocated []string
+}
+
+func (m *mockNetwork) ListNetworks() ([]networks.Network, error) {
+	return testInventory().networks, nil
+}
+func (m *mockNetwork) GetQuota(ctx context.Context, projectID string) (*quotas.QuotaDetailSet, error) {
+	return &quotas.QuotaDetailSet{FloatingIP: quotas.QuotaDetail{Used: 1, Limit: 50}}, nil
+}
+func (m *mockNetwork) AllocateFloatingIP(opts floatingips.CreateOptsBuilder) (floatingips.FloatingIP, error) {
+	o := opts.(floatingips.CreateOpts)
+	m.allocated = append(m.allocated, o.FloatingNetworkID)
+	return floatingips.FloatingIP{ID: "fip-" + o.Description}, nil
+}
+
+type mockImage struct{ client.ImageClient }
+
+func (mockImage) ListImages(ctx context.Context) ([]images.Image, error) {
+	return testInventory().images, nil
+}
+
+type mockLimits struct{}
+
+func (mockLimits) GetLimits(ctx context.Context) (*client.Limits, error) {
+	return &client.Limits{
+		Compute: &cLimits.Limits{Absolute: cLimits.Absolute{MaxTotalInstances: 10, MaxTotalCores: 40, MaxTotalRAMSize: 65536}},
+		Volume:  &vLimits.Limits{Absolute: vLimits.Absolute{MaxTotalVolumes: 10, MaxTotalVolumeGigabytes: 1000}},
+	}, nil
+}
+
+type mockIdentity struct{ client.IdentityClient }
+
+func (mockIdentity) GetCurrentProject() (projects.Project, error) {
+	return projects.Project{ID: "p1"}, nil
+}
+
+func TestImportRun(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "batch.yaml")
+	doc := `resources:
+- {kind: server, name: "web-{n}", count: 2, flavor: m1.small, image: ubuntu, network: private}
+- {kind: volume, name: data, size: 50}
+- {kind: floatingip, name: ingress, network: public}
+`
+	if err := os.WriteFile(path, []byte(doc), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cc, sc, nc := &mockCompute{}, &mockStorage{failures: 1}, &mockNetwork{}
+	m := NewImportModel(ClientSet{Compute: cc, Network: nc, Storage: sc, Image: mockImage{}, Limits: mockLimits{}, Identity: mockIdentity{}}, path)
+	updated, _ := m.Update(m.loadCmd()())
+	m = updated.(ImportModel)
+	if m.err != nil || len(m.problems) != 0 || len(m.steps) != 4 || len(m.inv.warnings) != 0 {
+		t.Fatalf("unexpected plan: err %v, problems %v, steps %d, warnings %v", m.err, m.problems, len(m.steps), m.inv.warnings)
+	}
+	if v := m.View(); !strings.Contains(v, "Instances 0+2/10") || !strings.Contains(v, "Floating IPs 1+1/50") || !strings.Contains(v, "Create 4 resources?") {
+		t.Errorf("unexpected view %q", v)
+	}
+
+	run := func(key string) {
+		updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+		m = updated.(ImportModel)
+		for cmd != nil {
+			updated, cmd = m.Update(cmd())
+			m = updated.(ImportModel)
+		}
+	}
+	run("y")
+	if got := strings.Join(cc.created, ","); got != "web-1/f1/i1/n1,web-2/f1/i1/n1" {
+		t.Errorf("created servers %s", got)
+	}
+	if len(nc.allocated) != 1 || nc.allocated[0] != "n2" {
+		t.Errorf("allocated %v", nc.allocated)
+	}
+	if m.steps[2].state != stateFailed || m.steps[3].id != "fip-ingress" || m.status != "3 created, 1 failed" {
+		t.Fatalf("unexpected run: %+v, status %q", m.steps, m.status)
+	}
+
+	// Only the failed volume is sent again.
+	run("y")
+	if m.steps[2].state != stateCreated || m.steps[2].id != "vol-data" || len(cc.created) != 2 || m.status != "4 created, 0 failed" {
+		t.Fatalf("unexpected retry: %+v, status %q", m.steps, m.status)
+	}
+}
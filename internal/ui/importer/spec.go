@@ -0,0 +1,367 @@
+package importer
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/flavors"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/images"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/networks"
+	"gopkg.in/yaml.v2"
+
+	"ostui/internal/i18n"
+)
+
+// Resource kinds an import file can create.
+const (
+	kindServer     = "server"
+	kindVolume     = "volume"
+	kindFloatingIP = "floatingip"
+)
+
+// kindAliases maps the spellings accepted in the kind column to a kind.
+var kindAliases = map[string]string{
+	"server": kindServer, "servers": kindServer, "instance": kindServer, "instances": kindServer,
+	"volume": kindVolume, "volumes": kindVolume,
+	"floatingip": kindFloatingIP, "floatingips": kindFloatingIP, "floating_ip": kindFloatingIP, "fip": kindFloatingIP,
+}
+
+// maxCount bounds the count of one row so a typo cannot create thousands of
+// resources.
+const maxCount = 100
+
+// spec is one row of an import file: count resources of a kind, named from
+// name. Fields a kind does not use are ignored.
+type spec struct {
+	Kind    string `yaml:"kind"`
+	Name    string `yaml:"name"`
+	Count   int    `yaml:"count"`
+	Size    int    `yaml:"size"`
+	Flavor  string `yaml:"flavor"`
+	Image   string `yaml:"image"`
+	Network string `yaml:"network"`
+	Zone    string `yaml:"zone"`
+	// line is the line of the row in a CSV file, or its position in a YAML
+	// list, for error messages.
+	line int
+}
+
+// specFile is the top-level document of a YAML import file.
+type specFile struct {
+	Resources []spec `yaml:"resources"`
+}
+
+// csvColumns are the columns a CSV import file may have, in any order.
+var csvColumns = []string{"kind", "name", "count", "size", "flavor", "image", "network", "zone"}
+
+// isCSVPath reports whether the file should be read as CSV rather than YAML.
+func isCSVPath(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), ".csv")
+}
+
+// readSpecFile reads a CSV (".csv") or YAML (anything else) import file.
+func readSpecFile(path string) ([]spec, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	var list []spec
+	if isCSVPath(path) {
+		list, err = parseCSV(bytes.NewReader(b))
+	} else {
+		list, err = parseYAML(b)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if len(list) == 0 {
+		return nil, fmt.Errorf("%s describes no resources", path)
+	}
+	return list, nil
+}
+
+// parseYAML reads a "resources:" list.
+func parseYAML(b []byte) ([]spec, error) {
+	var doc specFile
+	if err := yaml.UnmarshalStrict(b, &doc); err != nil {
+		return nil, err
+	}
+	for i := range doc.Resources {
+		doc.Resources[i].line = i + 1
+	}
+	return doc.Resources, nil
+}
+
+// parseCSV reads a CSV file whose first row names the columns. Blank lines
+// and lines starting with # are skipped.
+func parseCSV(r io.Reader) ([]spec, error) {
+	cr := csv.NewReader(r)
+	cr.Comment = '#'
+	cr.TrimLeadingSpace = true
+	header, err := cr.Read()
+	if err == io.EOF {
+		return nil, errors.New("the file is empty")
+	}
+	if err != nil {
+		return nil, err
+	}
+	cols := map[string]int{}
+	for i, h := range header {
+		h = strings.ToLower(strings.TrimSpace(h))
+		known := false
+		for _, c := range csvColumns {
+			known = known || c == h
+		}
+		if !known {
+			return nil, fmt.Errorf("unknown column %q: use %s", h, strings.Join(csvColumns, ", "))
+		}
+		cols[h] = i
+	}
+	if _, ok := cols["kind"]; !ok {
+		return nil, errors.New("the header has no kind column")
+	}
+	var list []spec
+	for {
+		rec, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := cr.FieldPos(0)
+		get := func(col string) string {
+			if i, ok := cols[col]; ok && i < len(rec) {
+				return strings.TrimSpace(rec[i])
+			}
+			return ""
+		}
+		s := spec{Kind: get("kind"), Name: get("name"), Flavor: get("flavor"), Image: get("image"), Network: get("network"), Zone: get("zone"), line: line}
+		for col, dst := range map[string]*int{"count": &s.Count, "size": &s.Size} {
+			if v := get(col); v != "" {
+				n, err := strconv.Atoi(v)
+				if err != nil {
+					return nil, fmt.Errorf("line %d: %s %q is not a number", line, col, v)
+				}
+				*dst = n
+			}
+		}
+		list = append(list, s)
+	}
+	return list, nil
+}
+
+var counterRe = regexp.MustCompile(`\{n(?::(\d))?\}`)
+
+// expandName returns the name of the n-th resource of a row: {n} is the
+// number, zero-padded to w digits with {n:w}. Rows creating more than one
+// resource without a placeholder get "-n" appended.
+func expandName(pattern string, n, count int) string {
+	if !counterRe.MatchString(pattern) {
+		if count == 1 || pattern == "" {
+			return pattern
+		}
+		pattern += "-{n}"
+	}
+	return counterRe.ReplaceAllStringFunc(pattern, func(ph string) string {
+		w := 0
+		if m := counterRe.FindStringSubmatch(ph); m[1] != "" {
+			w, _ = strconv.Atoi(m[1])
+		}
+		return fmt.Sprintf("%0*d", w, n)
+	})
+}
+
+// quota is the usage and limit of one quota; a limit of -1 is unlimited.
+type quota struct {
+	label string
+	used  int
+	limit int
+}
+
+// Quota keys checked by planImport.
+const (
+	quotaInstances   = "instances"
+	quotaCores       = "cores"
+	quotaRAM         = "ram"
+	quotaVolumes     = "volumes"
+	quotaGigabytes   = "gigabytes"
+	quotaFloatingIPs = "floatingip"
+)
+
+// quotaOrder is the order quotas are listed in.
+var quotaOrder = []string{quotaInstances, quotaCores, quotaRAM, quotaVolumes, quotaGigabytes, quotaFloatingIPs}
+
+// inventory is what an import file is resolved and checked against. A quota
+// missing from quotas could not be read and is not checked.
+type inventory struct {
+	flavors  []flavors.Flavor
+	images   []images.Image
+	networks []networks.Network
+	quotas   map[string]quota
+	// warnings explain the quotas that could not be read.
+	warnings []string
+}
+
+// lookup finds the element whose ID is ref, or else the only one named ref.
+func lookup[T any](kind string, list []T, ref string, id, name func(T) string) (T, error) {
+	var zero T
+	if ref == "" {
+		return zero, fmt.Errorf("no %s given", kind)
+	}
+	var found []T
+	for _, e := range list {
+		if id(e) == ref {
+			return e, nil
+		}
+		if name(e) == ref {
+			found = append(found, e)
+		}
+	}
+	switch len(found) {
+	case 0:
+		return zero, fmt.Errorf("unknown %s %q", kind, ref)
+	case 1:
+		return found[0], nil
+	}
+	return zero, fmt.Errorf("%d %ss are named %q: use the ID", len(found), kind, ref)
+}
+
+// State of one resource of an import.
+const (
+	statePending = "pending"
+	stateCreated = "created"
+	stateFailed  = "failed"
+)
+
+// step is one resource to create, resolved against the inventory.
+type step struct {
+	line    int
+	kind    string
+	name    string
+	size    int
+	flavor  flavors.Flavor
+	image   images.Image
+	network networks.Network
+	zone    string
+	state   string
+	detail  string
+	// id is set once the resource exists.
+	id string
+}
+
+// describe summarises what the step creates besides its name.
+func (s step) describe() string {
+	switch s.kind {
+	case kindServer:
+		return fmt.Sprintf("%s, %s on %s", s.flavor.Name, s.image.Name, s.network.Name)
+	case kindVolume:
+		return fmt.Sprintf("%d GB", s.size)
+	case kindFloatingIP:
+		return "from " + s.network.Name
+	}
+	return ""
+}
+
+// planImport expands the rows into one step per resource and returns the
+// problems that block the import: rows that do not resolve and quotas the
+// import would exceed.
+func planImport(list []spec, inv inventory) ([]step, []string) {
+	var steps []step
+	var problems []string
+	for _, sp := range list {
+		st, n, err := resolve(sp, inv)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("line %d: %s", sp.line, err))
+			continue
+		}
+		for i := 1; i <= n; i++ {
+			s := st
+			s.name = expandName(sp.Name, i, n)
+			steps = append(steps, s)
+		}
+	}
+	need := usage(steps)
+	for _, key := range quotaOrder {
+		q, ok := inv.quotas[key]
+		if !ok || need[key] == 0 || q.limit < 0 {
+			continue
+		}
+		if q.used+need[key] > q.limit {
+			problems = append(problems, i18n.T("%s: %d in use + %d to create exceeds the quota of %d", q.label, q.used, need[key], q.limit))
+		}
+	}
+	return steps, problems
+}
+
+// usage returns how much of each quota the steps use.
+func usage(steps []step) map[string]int {
+	need := map[string]int{}
+	for _, s := range steps {
+		switch s.kind {
+		case kindServer:
+			need[quotaInstances]++
+			need[quotaCores] += s.flavor.VCPUs
+			need[quotaRAM] += s.flavor.RAM
+		case kindVolume:
+			need[quotaVolumes]++
+			need[quotaGigabytes] += s.size
+		case kindFloatingIP:
+			need[quotaFloatingIPs]++
+		}
+	}
+	return need
+}
+
+// resolve checks one row and returns its step template with the number of
+// resources it creates.
+func resolve(sp spec, inv inventory) (step, int, error) {
+	kind, ok := kindAliases[strings.ToLower(strings.TrimSpace(sp.Kind))]
+	if !ok {
+		return step{}, 0, fmt.Errorf("unknown kind %q: use server, volume or floatingip", sp.Kind)
+	}
+	n := sp.Count
+	if n == 0 {
+		n = 1
+	}
+	if n < 0 || n > maxCount {
+		return step{}, 0, fmt.Errorf("count %d is not between 1 and %d", sp.Count, maxCount)
+	}
+	st := step{line: sp.line, kind: kind, zone: sp.Zone, state: statePending}
+	netID := func(n networks.Network) string { return n.ID }
+	netName := func(n networks.Network) string { return n.Name }
+	var err error
+	switch kind {
+	case kindServer:
+		if sp.Name == "" {
+			return step{}, 0, errors.New("servers need a name")
+		}
+		if st.flavor, err = lookup("flavor", inv.flavors, sp.Flavor, func(f flavors.Flavor) string { return f.ID }, func(f flavors.Flavor) string { return f.Name }); err != nil {
+			return step{}, 0, err
+		}
+		if st.image, err = lookup("image", inv.images, sp.Image, func(i images.Image) string { return i.ID }, func(i images.Image) string { return i.Name }); err != nil {
+			return step{}, 0, err
+		}
+		if st.network, err = lookup("network", inv.networks, sp.Network, netID, netName); err != nil {
+			return step{}, 0, err
+		}
+	case kindVolume:
+		if sp.Size <= 0 {
+			return step{}, 0, errors.New("volumes need a size in GB")
+		}
+		st.size = sp.Size
+	case kindFloatingIP:
+		if st.network, err = lookup("network", inv.networks, sp.Network, netID, netName); err != nil {
+			return step{}, 0, fmt.Errorf("floating IPs need the external network: %w", err)
+		}
+	}
+	return st, n, nil
+}
@@ -0,0 +1,328 @@
+// Package jobs implements the command mode scheduler: ":at" and ":every"
+// run a section refresh or a server action later while the TUI is open, and
+// the jobs view lists them.
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
+	"ostui/internal/client"
+	"ostui/internal/i18n"
+)
+
+// Job states.
+const (
+	StatePending   = "pending"
+	StateRunning   = "running"
+	StateDone      = "done"
+	StateFailed    = "failed"
+	StateCancelled = "cancelled"
+)
+
+// Action verbs. Refresh targets a section; the others a server.
+const (
+	VerbRefresh = "refresh"
+	VerbStart   = "start"
+	VerbStop    = "stop"
+	VerbReboot  = "reboot"
+)
+
+// MinInterval is the shortest period accepted by ":every", so a typo does not
+// hammer the API.
+const MinInterval = 30 * time.Second
+
+// Action is what a job runs.
+type Action struct {
+	Verb string
+	// Target is a section title for refresh, or a server name or ID.
+	Target string
+}
+
+// String renders the action the way it is typed.
+func (a Action) String() string {
+	if a.Verb == VerbRefresh {
+		return "refresh " + a.Target
+	}
+	return a.Verb + " server " + a.Target
+}
+
+// Job is one scheduled action.
+type Job struct {
+	ID     int
+	Action Action
+	// Every is the period of a repeating job; 0 for a one-shot ":at" job.
+	Every      time.Duration
+	Next       time.Time
+	State      string
+	Runs       int
+	LastRun    time.Time
+	LastResult string
+	Created    time.Time
+}
+
+// Schedule renders when the job runs, e.g. "every 5m" or "at 22:00".
+func (j Job) Schedule() string {
+	if j.Every > 0 {
+		return "every " + j.Every.String()
+	}
+	return "at " + j.Next.Format("15:04")
+}
+
+// Parse reads an ":at" or ":every" command, e.g. "every 5m refresh servers"
+// or "at 22:00 stop server web-test". sections resolves command aliases to
+// section titles for refresh. An ":at" time that has passed today means
+// tomorrow.
+func Parse(input string, now time.Time, sections map[string]string) (Job, error) {
+	fields := strings.Fields(input)
+	if len(fields) < 3 {
+		return Job{}, errors.New("usage: at HH:MM <action> | every <duration> <action>")
+	}
+	job := Job{State: StatePending, Created: now}
+	switch fields[0] {
+	case "at":
+		t, err := time.ParseInLocation("15:04", fields[1], now.Location())
+		if err != nil {
+			return Job{}, fmt.Errorf("invalid time %q, expected HH:MM", fields[1])
+		}
+		next := time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), 0, 0, now.Location())
+		if !next.After(now) {
+			next = next.AddDate(0, 0, 1)
+		}
+		job.Next = next
+	case "every":
+		d, err := time.ParseDuration(fields[1])
+		if err != nil {
+			return Job{}, fmt.Errorf("invalid interval %q, expected e.g. 30s, 5m or 1h", fields[1])
+		}
+		if d < MinInterval {
+			return Job{}, fmt.Errorf("interval must be at least %s", MinInterval)
+		}
+		job.Every, job.Next = d, now.Add(d)
+	default:
+		return Job{}, errors.New("a schedule starts with at or every")
+	}
+	action, err := parseAction(fields[2:], sections)
+	if err != nil {
+		return Job{}, err
+	}
+	job.Action = action
+	return job, nil
+}
+
+// parseAction reads "refresh <section>" or "<start|stop|reboot> server <name>".
+func parseAction(fields []string, sections map[string]string) (Action, error) {
+	switch verb := fields[0]; verb {
+	case VerbRefresh:
+		if len(fields) != 2 {
+			return Action{}, errors.New("usage: refresh <section>, e.g. refresh servers")
+		}
+		section, ok := sections[fields[1]]
+		if !ok || strings.HasPrefix(section, "__") {
+			return Action{}, fmt.Errorf("unknown section %q", fields[1])
+		}
+		return Action{Verb: VerbRefresh, Target: section}, nil
+	case VerbStart, VerbStop, VerbReboot:
+		if len(fields) != 3 || fields[1] != "server" {
+			return Action{}, fmt.Errorf("usage: %s server <name or ID>", verb)
+		}
+		return Action{Verb: verb, Target: fields[2]}, nil
+	default:
+		return Action{}, fmt.Errorf("unknown action %q: use refresh, start, stop or reboot", verb)
+	}
+}
+
+// DueMsg is delivered when a job is due. Runs guards against a stale tick of
+// a job that was cancelled or already ran.
+type DueMsg struct {
+	ID   int
+	Runs int
+}
+
+// ResultMsg reports the outcome of one run of a job.
+type ResultMsg struct {
+	ID     int
+	Result string
+	Err    error
+}
+
+// Scheduler holds the jobs of the session. It is shared by pointer between
+// the app, which runs the jobs, and the jobs view.
+type Scheduler struct {
+	jobs   []*Job
+	nextID int
+	// last is the most recent result, shown in the footer.
+	last string
+}
+
+// NewScheduler creates an empty scheduler.
+func NewScheduler() *Scheduler { return &Scheduler{nextID: 1} }
+
+// Add registers a parsed job and returns the command waiting for it.
+func (s *Scheduler) Add(job Job, now time.Time) (*Job, tea.Cmd) {
+	job.ID = s.nextID
+	s.nextID++
+	j := &job
+	s.jobs = append(s.jobs, j)
+	return j, s.wait(j, now)
+}
+
+// wait ticks when j is next due.
+func (s *Scheduler) wait(j *Job, now time.Time) tea.Cmd {
+	id, runs := j.ID, j.Runs
+	d := j.Next.Sub(now)
+	if d < 0 {
+		d = 0
+	}
+	return tea.Tick(d, func(time.Time) tea.Msg { return DueMsg{ID: id, Runs: runs} })
+}
+
+// Get returns a job by ID.
+func (s *Scheduler) Get(id int) (*Job, bool) {
+	for _, j := range s.jobs {
+		if j.ID == id {
+			return j, true
+		}
+	}
+	return nil, false
+}
+
+// Jobs returns the jobs in creation order.
+func (s *Scheduler) Jobs() []Job {
+	out := make([]Job, 0, len(s.jobs))
+	for _, j := range s.jobs {
+		out = append(out, *j)
+	}
+	return out
+}
+
+// Start marks a due job as running and reports whether it should run; stale
+// ticks and cancelled jobs are ignored.
+func (s *Scheduler) Start(msg DueMsg, now time.Time) (*Job, bool) {
+	j, ok := s.Get(msg.ID)
+	if !ok || j.State != StatePending || j.Runs != msg.Runs {
+		return nil, false
+	}
+	j.State, j.LastRun = StateRunning, now
+	return j, true
+}
+
+// Finish records the outcome of a run. A repeating job is rescheduled and
+// the returned command waits for its next run.
+func (s *Scheduler) Finish(msg ResultMsg, now time.Time) tea.Cmd {
+	j, ok := s.Get(msg.ID)
+	if !ok || j.State != StateRunning {
+		return nil
+	}
+	j.Runs++
+	j.LastResult = msg.Result
+	if msg.Err != nil {
+		j.LastResult = msg.Err.Error()
+	}
+	s.last = fmt.Sprintf("job %d: %s", j.ID, j.LastResult)
+	if j.Every > 0 {
+		// A failed run does not stop a repeating job.
+		j.State = StatePending
+		for !j.Next.After(now) {
+			j.Next = j.Next.Add(j.Every)
+		}
+		return s.wait(j, now)
+	}
+	j.State = StateDone
+	if msg.Err != nil {
+		j.State = StateFailed
+	}
+	return nil
+}
+
+// Cancel stops a pending job.
+func (s *Scheduler) Cancel(id int) bool {
+	j, ok := s.Get(id)
+	if !ok || j.State != StatePending {
+		return false
+	}
+	j.State = StateCancelled
+	return true
+}
+
+// Pending returns the number of jobs waiting to run.
+func (s *Scheduler) Pending() int {
+	n := 0
+	for _, j := range s.jobs {
+		if j.State == StatePending || j.State == StateRunning {
+			n++
+		}
+	}
+	return n
+}
+
+// FooterLabel summarises the scheduler for the status line, or "" when no
+// job was ever scheduled.
+func (s *Scheduler) FooterLabel() string {
+	if len(s.jobs) == 0 {
+		return ""
+	}
+	label := i18n.T("⏱ %d jobs", s.Pending())
+	if s.last != "" {
+		label += " · " + s.last
+	}
+	return label
+}
+
+// RunServerAction runs a start, stop or reboot job against the server
+// named or identified by the job target.
+func RunServerAction(cc client.ComputeClient, j Job) tea.Cmd {
+	id, a := j.ID, j.Action
+	return func() tea.Msg {
+		srv, err := findServer(cc, a.Target)
+		if err != nil {
+			return ResultMsg{ID: id, Err: err}
+		}
+		result, err := serverAction(cc, srv.ID, a)
+		return ResultMsg{ID: id, Result: result, Err: err}
+	}
+}
+
+// findServer returns the server with the ID or the unique name target.
+func findServer(cc client.ComputeClient, target string) (servers.Server, error) {
+	list, err := cc.ListInstances()
+	if err != nil {
+		return servers.Server{}, err
+	}
+	var matches []servers.Server
+	for _, s := range list {
+		if s.ID == target {
+			return s, nil
+		}
+		if s.Name == target {
+			matches = append(matches, s)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return servers.Server{}, fmt.Errorf("no server named %s", target)
+	case 1:
+		return matches[0], nil
+	}
+	return servers.Server{}, fmt.Errorf("%d servers are named %s; use the ID", len(matches), target)
+}
+
+// serverAction starts, stops or reboots the server id and describes it.
+func serverAction(cc client.ComputeClient, id string, a Action) (string, error) {
+	var err error
+	switch a.Verb {
+	case VerbStart:
+		err = cc.StartInstance(id)
+	case VerbStop:
+		err = cc.StopInstance(id)
+	case VerbReboot:
+		err = cc.RebootInstance(context.Background(), id, false)
+	}
+	past := map[string]string{VerbStart: "started", VerbStop: "stopped", VerbReboot: "rebooted"}[a.Verb]
+	return past + " " + a.Target, err
+}
@@ -0,0 +1,115 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"ostui/internal/client/clienttest"
+	"ostui/internal/ui/ops"
+)
+
+var sections = map[string]string{"servers": "Servers", "srv": "Servers", "vol": "Volumes", "quit": "__quit__"}
+
+func TestParse(t *testing.T) {
+	now := time.Date(2026, 3, 1, 23, 0, 0, 0, time.Local)
+	j, err := Parse("every 5m refresh srv", now, sections)
+	if err != nil || j.Every != 5*time.Minute || !j.Next.Equal(now.Add(5*time.Minute)) || j.Action != (Action{Verb: VerbRefresh, Target: "Servers"}) {
+		t.Fatalf("unexpected job %+v, %v", j, err)
+	}
+	j, err = Parse("at 22:00 stop server web-test", now, sections)
+	if err != nil || j.Next.Day() != 2 || j.Next.Hour() != 22 || j.Action.String() != "stop server web-test" || j.Schedule() != "at 22:00" {
+		t.Fatalf("expected tomorrow 22:00, got %+v, %v", j, err)
+	}
+	for _, in := range []string{
+		"every 5s refresh servers",
+		"every soon refresh servers",
+		"at 25:00 stop server web",
+		"at 22:00 stop web",
+		"every 5m refresh quit",
+		"every 5m refresh nothing",
+		"every 5m delete server web",
+		"later 5m refresh servers",
+	} {
+		if _, err := Parse(in, now, sections); err == nil {
+			t.Errorf("expected an error for %q", in)
+		}
+	}
+}
+
+func TestSchedulerLifecycle(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	s := NewScheduler()
+	every, _ := Parse("every 1m refresh servers", now, sections)
+	once, _ := Parse("at 12:30 stop server web", now, sections)
+	e, cmd := s.Add(every, now)
+	o, _ := s.Add(once, now)
+	if cmd == nil || e.ID != 1 || o.ID != 2 || s.Pending() != 2 {
+		t.Fatalf("unexpected jobs %+v %+v", e, o)
+	}
+
+	later := now.Add(time.Minute)
+	if _, ok := s.Start(DueMsg{ID: 1}, later); !ok {
+		t.Fatalf("expected the due job to start")
+	}
+	if _, ok := s.Start(DueMsg{ID: 1}, later); ok {
+		t.Fatalf("a running job must not start twice")
+	}
+	if next := s.Finish(ResultMsg{ID: 1, Err: errors.New("boom")}, later); next == nil {
+		t.Fatalf("a repeating job must be rescheduled after a failure")
+	}
+	if j, _ := s.Get(1); j.State != StatePending || j.Runs != 1 || !j.Next.Equal(now.Add(2*time.Minute)) {
+		t.Errorf("unexpected job after its first run %+v", j)
+	}
+	// The tick of the first run is stale now.
+	if _, ok := s.Start(DueMsg{ID: 1, Runs: 0}, later); ok {
+		t.Errorf("a stale tick must be ignored")
+	}
+
+	if !s.Cancel(2) || s.Cancel(2) {
+		t.Errorf("expected exactly one cancel")
+	}
+	if _, ok := s.Start(DueMsg{ID: 2}, later); ok {
+		t.Errorf("a cancelled job must not run")
+	}
+	if s.Pending() != 1 || !strings.Contains(s.FooterLabel(), "job 1: boom") {
+		t.Errorf("unexpected footer %q", s.FooterLabel())
+	}
+}
+
+func TestRunServerAction(t *testing.T) {
+	mock := clienttest.NewCompute(clienttest.Server("s-1", "web", ""), clienttest.Server("s-2", "db", ""), clienttest.Server("s-3", "db", ""))
+	res := RunServerAction(mock, Job{ID: 7, Action: Action{Verb: VerbStop, Target: "web"}})().(ResultMsg)
+	if res.Err != nil || res.Result != "stopped web" || strings.Join(mock.Calls(), ",") != "StopInstance s-1" {
+		t.Fatalf("unexpected result %+v, calls %v", res, mock.Calls())
+	}
+	if res := RunServerAction(mock, Job{Action: Action{Verb: VerbStop, Target: "db"}})().(ResultMsg); res.Err == nil {
+		t.Errorf("an ambiguous name must fail")
+	}
+	if res := RunServerAction(mock, Job{Action: Action{Verb: VerbStop, Target: "s-3"}})().(ResultMsg); res.Err != nil || !mock.Called("StopInstance s-3") {
+		t.Errorf("an ID must resolve, got %+v", res)
+	}
+}
+
+func TestJobsViewListsOperations(t *testing.T) {
+	q := ops.NewQueue()
+	q.Add(ops.EnqueueMsg{Title: "New volume: data", Work: func(context.Context) (string, ops.Poll, error) {
+		return "", nil, errors.New("quota exceeded")
+	}}, time.Now())
+	m := NewJobsModel(NewScheduler(), q)
+	if !strings.Contains(m.View(), "New volume: data") {
+		t.Fatalf("expected the operations tab first, got:\n%s", m.View())
+	}
+	// The request is still out: there is nothing to retry yet.
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("R")})
+	if cmd != nil || !strings.Contains(updated.View(), "Operation 1 has not failed") {
+		t.Errorf("expected a running operation not retried, got:\n%s", updated.View())
+	}
+	updated, _ = updated.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if !strings.Contains(updated.View(), "No scheduled jobs") {
+		t.Errorf("expected tab to show the scheduled jobs, got:\n%s", updated.View())
+	}
+}
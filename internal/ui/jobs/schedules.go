@@ -0,0 +1,342 @@
+package jobs
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"ostui/internal/client"
+	"ostui/internal/config"
+	"ostui/internal/i18n"
+)
+
+// Server schedules stop and start servers at fixed times on chosen days, a
+// poor man's instance scheduler for dev clouds. They are kept in the
+// schedules file and checked every minute while ostui runs; a time passed
+// while ostui was closed is not caught up.
+
+// weekdays are the day names of schedules, in time.Weekday order.
+var weekdays = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+
+// Day sets accepted by ParseDays besides a list of days.
+var (
+	workDays    = []string{"mon", "tue", "wed", "thu", "fri"}
+	weekendDays = []string{"sat", "sun"}
+)
+
+// ParseDays reads the days of a schedule: "daily" (or empty), "weekdays",
+// "weekends", or a comma-separated list such as "mon,wed,fri".
+func ParseDays(s string) ([]string, error) {
+	switch s = strings.ToLower(strings.TrimSpace(s)); s {
+	case "", "daily":
+		return nil, nil
+	case "weekdays":
+		return slices.Clone(workDays), nil
+	case "weekends":
+		return slices.Clone(weekendDays), nil
+	}
+	var days []string
+	for _, d := range strings.Split(s, ",") {
+		d = strings.TrimSpace(d)
+		if len(d) > 3 {
+			d = d[:3]
+		}
+		if !slices.Contains(weekdays, d) {
+			return nil, fmt.Errorf("unknown day %q: use mon…sun, daily, weekdays or weekends", d)
+		}
+		if !slices.Contains(days, d) {
+			days = append(days, d)
+		}
+	}
+	return days, nil
+}
+
+// FormatDays renders days the way ParseDays reads them.
+func FormatDays(days []string) string {
+	set := func(want []string) bool {
+		if len(days) != len(want) {
+			return false
+		}
+		for _, d := range want {
+			if !slices.Contains(days, d) {
+				return false
+			}
+		}
+		return true
+	}
+	switch {
+	case len(days) == 0:
+		return "daily"
+	case set(workDays):
+		return "weekdays"
+	case set(weekendDays):
+		return "weekends"
+	}
+	return strings.Join(days, ",")
+}
+
+// parseClock reads a HH:MM time of day.
+func parseClock(s string) (time.Time, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return t, fmt.Errorf("invalid time %q, expected HH:MM", s)
+	}
+	return t, nil
+}
+
+// ValidateSchedule checks the server, times and days of a schedule.
+func ValidateSchedule(sc config.ServerSchedule) error {
+	if strings.TrimSpace(sc.Server) == "" {
+		return errors.New("name the server to schedule")
+	}
+	if sc.Stop == "" && sc.Start == "" {
+		return errors.New("set a stop time, a start time or both")
+	}
+	for _, clock := range []string{sc.Stop, sc.Start} {
+		if clock == "" {
+			continue
+		}
+		if _, err := parseClock(clock); err != nil {
+			return err
+		}
+	}
+	if sc.Stop == sc.Start {
+		return errors.New("the stop and start times must differ")
+	}
+	for _, d := range sc.Days {
+		if !slices.Contains(weekdays, d) {
+			return fmt.Errorf("unknown day %q", d)
+		}
+	}
+	return nil
+}
+
+// ScheduledRun is an action of a schedule that came due.
+type ScheduledRun struct {
+	Server string
+	Verb   string
+	At     time.Time
+}
+
+// runsOn reports whether sc runs on the day of t.
+func runsOn(sc config.ServerSchedule, t time.Time) bool {
+	return len(sc.Days) == 0 || slices.Contains(sc.Days, weekdays[t.Weekday()])
+}
+
+// scheduleRuns returns the actions of sc in (from, to], in time order.
+func scheduleRuns(sc config.ServerSchedule, from, to time.Time) []ScheduledRun {
+	var out []ScheduledRun
+	day := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, from.Location())
+	for ; !day.After(to); day = day.AddDate(0, 0, 1) {
+		for _, a := range []struct{ verb, clock string }{{VerbStop, sc.Stop}, {VerbStart, sc.Start}} {
+			if a.clock == "" {
+				continue
+			}
+			c, err := parseClock(a.clock)
+			if err != nil {
+				continue
+			}
+			at := time.Date(day.Year(), day.Month(), day.Day(), c.Hour(), c.Minute(), 0, 0, day.Location())
+			if at.After(from) && !at.After(to) && runsOn(sc, at) {
+				out = append(out, ScheduledRun{Server: sc.Server, Verb: a.verb, At: at})
+			}
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].At.Before(out[j].At) })
+	return out
+}
+
+// NextRun returns the next action of sc after now, looking a week ahead.
+func NextRun(sc config.ServerSchedule, now time.Time) (ScheduledRun, bool) {
+	runs := scheduleRuns(sc, now, now.AddDate(0, 0, 8))
+	if len(runs) == 0 {
+		return ScheduledRun{}, false
+	}
+	return runs[0], true
+}
+
+// ScheduleTickMsg asks the app to run the schedules due by At.
+type ScheduleTickMsg struct{ At time.Time }
+
+// ScheduleTick ticks on the next minute of the clock.
+func ScheduleTick() tea.Cmd {
+	return tea.Every(time.Minute, func(t time.Time) tea.Msg { return ScheduleTickMsg{At: t} })
+}
+
+// ScheduleResultMsg reports the outcome of a scheduled action.
+type ScheduleResultMsg struct {
+	Run    ScheduledRun
+	Result string
+	Err    error
+}
+
+// RunScheduled stops or starts the server of run. A server already in the
+// wanted state is left alone, so a schedule tolerates manual changes.
+func RunScheduled(cc client.ComputeClient, run ScheduledRun) tea.Cmd {
+	return func() tea.Msg {
+		srv, err := findServer(cc, run.Server)
+		if err != nil {
+			return ScheduleResultMsg{Run: run, Err: err}
+		}
+		switch {
+		case run.Verb == VerbStop && srv.Status == "SHUTOFF":
+			return ScheduleResultMsg{Run: run, Result: run.Server + " already stopped"}
+		case run.Verb == VerbStart && srv.Status == "ACTIVE":
+			return ScheduleResultMsg{Run: run, Result: run.Server + " already running"}
+		}
+		result, err := serverAction(cc, srv.ID, Action{Verb: run.Verb, Target: run.Server})
+		return ScheduleResultMsg{Run: run, Result: result, Err: err}
+	}
+}
+
+// Schedules holds the server schedules of the session's cloud and their
+// last results. It is shared by pointer between the app, which runs them,
+// and the schedules view; changes are written to the schedules file.
+type Schedules struct {
+	path  string
+	cloud string
+	// all are the schedules of every cloud, in file order.
+	all []config.ServerSchedule
+	// checked is when the schedules were last checked; later times are due.
+	checked time.Time
+	last    map[string]string
+	// latest is the most recent result, shown in the footer.
+	latest string
+	err    error
+}
+
+// NewSchedules loads the schedules at path for cloud. A load error, or an
+// empty path when no configuration directory is known, is kept for the
+// view and leaves no schedules.
+func NewSchedules(path, cloud string, now time.Time) *Schedules {
+	s := &Schedules{path: path, cloud: cloud, checked: now, last: map[string]string{}}
+	if path == "" {
+		s.err = errors.New("no configuration directory; set OSTUI_SCHEDULES_FILE")
+		return s
+	}
+	s.all, s.err = config.LoadSchedules(path)
+	return s
+}
+
+// Err returns the error of loading the schedules file.
+func (s *Schedules) Err() error { return s.err }
+
+// Path returns the schedules file.
+func (s *Schedules) Path() string { return s.path }
+
+// indexes returns the positions in all of the schedules of the cloud.
+func (s *Schedules) indexes() []int {
+	var out []int
+	for i, sc := range s.all {
+		if sc.Cloud == s.cloud {
+			out = append(out, i)
+		}
+	}
+	return out
+}
+
+// List returns the schedules of the cloud.
+func (s *Schedules) List() []config.ServerSchedule {
+	var out []config.ServerSchedule
+	for _, i := range s.indexes() {
+		out = append(out, s.all[i])
+	}
+	return out
+}
+
+// save writes the schedules, restoring prev when that fails. A file that
+// could not be read is not overwritten.
+func (s *Schedules) save(prev []config.ServerSchedule) error {
+	if s.err != nil {
+		s.all = prev
+		return fmt.Errorf("not overwriting %s: %w", s.path, s.err)
+	}
+	if err := config.SaveSchedules(s.path, s.all); err != nil {
+		s.all = prev
+		return err
+	}
+	return nil
+}
+
+// Add validates sc and stores it, enabled, for the cloud.
+func (s *Schedules) Add(sc config.ServerSchedule) error {
+	if err := ValidateSchedule(sc); err != nil {
+		return err
+	}
+	sc.Cloud, sc.Enabled = s.cloud, true
+	prev := slices.Clone(s.all)
+	s.all = append(s.all, sc)
+	return s.save(prev)
+}
+
+// SetEnabled turns the i-th schedule of the cloud on or off.
+func (s *Schedules) SetEnabled(i int, on bool) error {
+	idx := s.indexes()
+	if i < 0 || i >= len(idx) {
+		return fmt.Errorf("no schedule %d", i+1)
+	}
+	prev := slices.Clone(s.all)
+	s.all[idx[i]].Enabled = on
+	return s.save(prev)
+}
+
+// Delete removes the i-th schedule of the cloud.
+func (s *Schedules) Delete(i int) error {
+	idx := s.indexes()
+	if i < 0 || i >= len(idx) {
+		return fmt.Errorf("no schedule %d", i+1)
+	}
+	prev := slices.Clone(s.all)
+	s.all = slices.Delete(s.all, idx[i], idx[i]+1)
+	return s.save(prev)
+}
+
+// Due returns the actions of the enabled schedules that came due since the
+// last check.
+func (s *Schedules) Due(now time.Time) []ScheduledRun {
+	var out []ScheduledRun
+	for _, sc := range s.List() {
+		if sc.Enabled {
+			out = append(out, scheduleRuns(sc, s.checked, now)...)
+		}
+	}
+	s.checked = now
+	return out
+}
+
+// Record keeps the outcome of a scheduled action as the last result of
+// its server.
+func (s *Schedules) Record(msg ScheduleResultMsg) {
+	result := msg.Result
+	if msg.Err != nil {
+		result = i18n.T("%s failed: %s", msg.Run.Verb, msg.Err)
+	}
+	s.last[msg.Run.Server] = msg.Run.At.Format("Mon 15:04") + " " + result
+	s.latest = result
+}
+
+// LastResult returns the last scheduled result of server, or "".
+func (s *Schedules) LastResult(server string) string { return s.last[server] }
+
+// FooterLabel summarises the enabled schedules and the latest scheduled
+// result for the status line, or "" without enabled schedules.
+func (s *Schedules) FooterLabel() string {
+	n := 0
+	for _, sc := range s.List() {
+		if sc.Enabled {
+			n++
+		}
+	}
+	if n == 0 {
+		return ""
+	}
+	label := i18n.T("⏰ %d schedules", n)
+	if s.latest != "" {
+		label += " · " + s.latest
+	}
+	return label
+}
@@ -0,0 +1,83 @@
+package jobs
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"ostui/internal/client/clienttest"
+	"ostui/internal/config"
+)
+
+func TestParseDays(t *testing.T) {
+	for in, want := range map[string]string{"": "daily", "Weekdays": "weekdays", "sat, sun": "weekends", "monday,wed,mon": "mon,wed"} {
+		days, err := ParseDays(in)
+		if err != nil || FormatDays(days) != want {
+			t.Errorf("%q: got %v (%s), %v; want %s", in, days, FormatDays(days), err, want)
+		}
+	}
+	if _, err := ParseDays("mon,someday"); err == nil {
+		t.Errorf("expected an unknown day error")
+	}
+}
+
+func TestSchedulesDue(t *testing.T) {
+	// Friday 18:30.
+	now := time.Date(2026, 3, 6, 18, 30, 0, 0, time.Local)
+	s := NewSchedules(filepath.Join(t.TempDir(), "schedules.yaml"), "dev", now)
+	for _, sc := range []config.ServerSchedule{
+		{Server: "web-dev", Stop: "19:00", Start: "08:00", Days: []string{"mon", "tue", "wed", "thu", "fri"}},
+		{Server: "db-dev", Stop: "18:45"},
+	} {
+		if err := s.Add(sc); err != nil {
+			t.Fatalf("Add %s: %v", sc.Server, err)
+		}
+	}
+	if err := s.Add(config.ServerSchedule{Server: "x", Stop: "7pm"}); err == nil {
+		t.Errorf("expected an invalid time error")
+	}
+	if err := s.SetEnabled(1, false); err != nil {
+		t.Fatal(err)
+	}
+	// The disabled schedule does not run; the weekday one stops on Friday
+	// evening and does not start on Saturday morning.
+	runs := s.Due(now.Add(15 * time.Hour))
+	if len(runs) != 1 || runs[0].Server != "web-dev" || runs[0].Verb != VerbStop || runs[0].At.Hour() != 19 {
+		t.Fatalf("unexpected runs %+v", runs)
+	}
+	if runs := s.Due(now.Add(16 * time.Hour)); len(runs) != 0 {
+		t.Errorf("a checked period must not run again, got %+v", runs)
+	}
+	if run, ok := NextRun(s.List()[0], now.Add(16*time.Hour)); !ok || run.Verb != VerbStart || run.At.Weekday() != time.Monday {
+		t.Errorf("expected Monday's start next, got %+v", run)
+	}
+
+	// The schedules are kept per cloud in the file.
+	other := NewSchedules(s.Path(), "prod", now)
+	if err := other.Add(config.ServerSchedule{Server: "batch", Start: "06:00"}); err != nil {
+		t.Fatal(err)
+	}
+	reloaded := NewSchedules(s.Path(), "dev", now)
+	if list := reloaded.List(); len(list) != 2 || list[1].Enabled || reloaded.Delete(0) != nil || len(reloaded.List()) != 1 {
+		t.Errorf("unexpected schedules of dev %+v", list)
+	}
+	if list, _ := config.LoadSchedules(s.Path()); len(list) != 2 || list[1].Cloud != "prod" {
+		t.Errorf("the other cloud's schedule must be kept, got %+v", list)
+	}
+}
+
+func TestRunScheduled(t *testing.T) {
+	mock := clienttest.NewCompute(clienttest.Server("s-1", "web", "ACTIVE"), clienttest.Server("s-2", "db", "SHUTOFF"))
+	s := NewSchedules(filepath.Join(t.TempDir(), "schedules.yaml"), "dev", time.Now())
+	for _, name := range []string{"web", "db"} {
+		res := RunScheduled(mock, ScheduledRun{Server: name, Verb: VerbStop, At: time.Now()})().(ScheduleResultMsg)
+		s.Record(res)
+	}
+	if calls := mock.Calls(); len(calls) != 1 || calls[0] != "StopInstance s-1" {
+		t.Fatalf("only the running server should be stopped, got %v", calls)
+	}
+	if !strings.HasSuffix(s.LastResult("db"), "db already stopped") || !strings.HasSuffix(s.LastResult("web"), "stopped web") {
+		t.Errorf("unexpected results %q, %q", s.LastResult("web"), s.LastResult("db"))
+	}
+}
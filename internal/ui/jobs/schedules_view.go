@@ -0,0 +1,211 @@
+package jobs
+
+import (
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"ostui/internal/config"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/common"
+	"ostui/internal/ui/theme"
+	"ostui/internal/ui/uiconst"
+)
+
+// Fields of the new schedule form.
+const (
+	scheduleFieldServer = iota
+	scheduleFieldStop
+	scheduleFieldStart
+	scheduleFieldDays
+)
+
+// SchedulesModel lists the server schedules of the cloud with their next
+// action and last result.
+type SchedulesModel struct {
+	table     table.Model
+	schedules *Schedules
+	form      *common.FormModel
+	status    string
+	statusErr bool
+
+	width  int
+	height int
+}
+
+// NewSchedulesModel creates the schedules view over the session schedules.
+func NewSchedulesModel(s *Schedules) SchedulesModel {
+	m := SchedulesModel{schedules: s, width: 120, height: 30}
+	m.refreshTable(time.Now())
+	return m
+}
+
+// Init starts the refresh of the next actions.
+func (m SchedulesModel) Init() tea.Cmd { return tick() }
+
+// CapturingInput reports whether the new schedule form is open.
+func (m SchedulesModel) CapturingInput() bool { return m.form != nil }
+
+// newScheduleForm opens the form of a new schedule with office hours
+// filled in.
+func newScheduleForm() *common.FormModel {
+	f := common.NewForm([]string{i18n.T("Server (name or ID)"), i18n.T("Stop at (HH:MM, empty for none)"), i18n.T("Start at (HH:MM, empty for none)"), i18n.T("Days (daily, weekdays, weekends or mon,tue,…)")})
+	f.SetValue(scheduleFieldStop, "19:00")
+	f.SetValue(scheduleFieldStart, "08:00")
+	f.SetValue(scheduleFieldDays, "weekdays")
+	return &f
+}
+
+// Update handles messages for the model.
+func (m SchedulesModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if m.form != nil {
+		if _, ok := msg.(tea.KeyMsg); ok {
+			return m.updateForm(msg)
+		}
+	}
+	switch msg := msg.(type) {
+	case viewTickMsg:
+		m.refreshTable(time.Now())
+		return m, tick()
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.refreshTable(time.Now())
+		return m, nil
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "n":
+			m.form = newScheduleForm()
+			m.status = ""
+			return m, m.form.Init()
+		case "e", " ":
+			if i := m.table.Cursor(); i < len(m.schedules.List()) {
+				sc := m.schedules.List()[i]
+				done := "Enabled"
+				if sc.Enabled {
+					done = "Disabled"
+				}
+				m.report(m.schedules.SetEnabled(i, !sc.Enabled), done+" the schedule of "+sc.Server)
+			}
+			return m, nil
+		case "x":
+			if i := m.table.Cursor(); i < len(m.schedules.List()) {
+				sc := m.schedules.List()[i]
+				m.report(m.schedules.Delete(i), "Deleted the schedule of "+sc.Server)
+			}
+			return m, nil
+		}
+		m.refreshTable(time.Now())
+		var cmd tea.Cmd
+		m.table, cmd = m.table.Update(msg)
+		return m, cmd
+	}
+	return m, nil
+}
+
+// updateForm handles keys while the new schedule form is open.
+func (m SchedulesModel) updateForm(msg tea.Msg) (tea.Model, tea.Cmd) {
+	fm, cmd := m.form.Update(msg)
+	f := fm.(common.FormModel)
+	m.form = &f
+	switch {
+	case f.Cancelled():
+		m.form = nil
+		return m, nil
+	case !f.Submitted():
+		return m, cmd
+	}
+	v := f.Values()
+	days, err := ParseDays(v[scheduleFieldDays])
+	if err != nil {
+		m.form.SetError(err)
+		return m, nil
+	}
+	sc := config.ServerSchedule{Server: strings.TrimSpace(v[scheduleFieldServer]), Stop: strings.TrimSpace(v[scheduleFieldStop]), Start: strings.TrimSpace(v[scheduleFieldStart]), Days: days}
+	if err := m.schedules.Add(sc); err != nil {
+		m.form.SetError(err)
+		return m, nil
+	}
+	m.form = nil
+	m.report(nil, "Scheduled "+sc.Server)
+	return m, nil
+}
+
+// report shows the outcome of a change and refreshes the table.
+func (m *SchedulesModel) report(err error, done string) {
+	m.status, m.statusErr = done, false
+	if err != nil {
+		m.status, m.statusErr = err.Error(), true
+	}
+	m.refreshTable(time.Now())
+}
+
+// refreshTable rebuilds the rows, keeping the cursor.
+func (m *SchedulesModel) refreshTable(now time.Time) {
+	rest := m.width - 6 - 6 - 10 - 9 - 16 - uiconst.TableHeightOffset
+	if rest < 40 {
+		rest = 40
+	}
+	serverW := rest / 3
+	cols := []table.Column{{Title: i18n.T("Server"), Width: serverW}, {Title: i18n.T("Stop"), Width: 6}, {Title: i18n.T("Start"), Width: 6}, {Title: i18n.T("Days"), Width: 10}, {Title: i18n.T("State"), Width: 9}, {Title: i18n.T("Next"), Width: 16}, {Title: i18n.T("Last result"), Width: rest - serverW}}
+	var rows []table.Row
+	for _, sc := range m.schedules.List() {
+		state, next := "enabled", "–"
+		if !sc.Enabled {
+			state = "disabled"
+		} else if run, ok := NextRun(sc, now); ok {
+			next = run.Verb + " " + run.At.Format("Mon 15:04")
+		}
+		last := m.schedules.LastResult(sc.Server)
+		if last == "" {
+			last = "–"
+		}
+		rows = append(rows, table.Row{sc.Server, dash(sc.Stop), dash(sc.Start), FormatDays(sc.Days), state, next, last})
+	}
+	cursor := m.table.Cursor()
+	m.table = table.New(table.WithColumns(cols), table.WithFocused(true))
+	m.table.SetStyles(table.DefaultStyles())
+	m.table.SetRows(rows)
+	m.table.SetHeight(m.height - uiconst.TableHeightOffset - 4)
+	if cursor < len(rows) {
+		m.table.SetCursor(cursor)
+	}
+}
+
+// dash renders an unset time of a schedule.
+func dash(s string) string {
+	if s == "" {
+		return "–"
+	}
+	return s
+}
+
+// View renders the schedules table.
+func (m SchedulesModel) View() string {
+	dim := lipgloss.NewStyle().Foreground(theme.Muted)
+	var out string
+	if err := m.schedules.Err(); err != nil {
+		out = lipgloss.NewStyle().Foreground(theme.Error).Render("Cannot read the schedules: "+err.Error()) + "\n"
+	}
+	if len(m.schedules.List()) == 0 && m.form == nil {
+		out += i18n.T("No server schedules.") + "\n" + dim.Render(i18n.T("Press n to stop and start a server at fixed times, e.g. a dev server outside office hours."))
+	} else {
+		out += m.table.View()
+	}
+	if m.form != nil {
+		out += "\nNew server schedule\n" + m.form.View()
+	} else if m.status != "" {
+		color := theme.OK
+		if m.statusErr {
+			color = theme.Error
+		}
+		out += "\n" + lipgloss.NewStyle().Foreground(color).Render(m.status)
+	}
+	return out + "\n" + dim.Render(i18n.T("Schedules run while ostui is open; times passed while it was closed are skipped. %s", m.schedules.Path())) + "\n" + i18n.T("[n] new  [e] enable/disable  [x] delete")
+}
+
+// Table returns the underlying table model.
+func (m SchedulesModel) Table() table.Model { return m.table }
+
+var _ tea.Model = (*SchedulesModel)(nil)
@@ -0,0 +1,265 @@
+package jobs
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/ops"
+	"ostui/internal/ui/theme"
+	"ostui/internal/ui/uiconst"
+)
+
+// viewTickMsg re-renders the countdowns of the jobs view.
+type viewTickMsg struct{}
+
+// Tabs of the jobs view.
+const (
+	tabOperations = iota
+	tabScheduled
+)
+
+// JobsModel lists the operations of the session, with their state and
+// log, and the scheduled jobs with their next run and last result.
+type JobsModel struct {
+	table     table.Model
+	opsTable  table.Model
+	scheduler *Scheduler
+	queue     *ops.Queue
+	tab       int
+	// showLog shows the log of the selected operation below the table.
+	showLog bool
+	status  string
+
+	width  int
+	height int
+}
+
+// NewJobsModel creates the jobs view over the session scheduler and
+// operation queue. It opens on the operations, unless only jobs were
+// scheduled.
+func NewJobsModel(s *Scheduler, q *ops.Queue) JobsModel {
+	m := JobsModel{scheduler: s, queue: q, width: 120, height: 30}
+	if len(q.Operations()) == 0 && len(s.Jobs()) > 0 {
+		m.tab = tabScheduled
+	}
+	m.refreshTable(time.Now())
+	return m
+}
+
+func tick() tea.Cmd {
+	return tea.Tick(time.Second, func(time.Time) tea.Msg { return viewTickMsg{} })
+}
+
+// Init starts the countdown refresh.
+func (m JobsModel) Init() tea.Cmd { return tick() }
+
+// Update handles messages for the model.
+func (m JobsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case viewTickMsg:
+		m.refreshTable(time.Now())
+		return m, tick()
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.refreshTable(time.Now())
+		return m, nil
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "tab":
+			m.tab = 1 - m.tab
+			m.status, m.showLog = "", false
+			m.refreshTable(time.Now())
+			return m, nil
+		case "x":
+			if m.tab != tabScheduled {
+				break
+			}
+			if row := m.table.SelectedRow(); len(row) > 0 {
+				id, _ := strconv.Atoi(row[0])
+				if m.scheduler.Cancel(id) {
+					m.status = i18n.T("Cancelled job %d", id)
+				} else {
+					m.status = i18n.T("Job %d is not pending", id)
+				}
+				m.refreshTable(time.Now())
+			}
+			return m, nil
+		case "enter":
+			if m.tab == tabOperations {
+				m.showLog = !m.showLog
+				m.refreshTable(time.Now())
+				return m, nil
+			}
+		case "R":
+			if m.tab != tabOperations {
+				break
+			}
+			if row := m.opsTable.SelectedRow(); len(row) > 0 {
+				id, _ := strconv.Atoi(row[0])
+				cmd, ok := m.queue.Retry(id, time.Now())
+				if ok {
+					m.status = i18n.T("Retrying operation %d", id)
+				} else {
+					m.status = i18n.T("Operation %d has not failed", id)
+				}
+				m.refreshTable(time.Now())
+				return m, cmd
+			}
+			return m, nil
+		}
+		// Countdowns stop while command mode covers the view; catch up.
+		m.refreshTable(time.Now())
+		var cmd tea.Cmd
+		if m.tab == tabOperations {
+			m.opsTable, cmd = m.opsTable.Update(msg)
+		} else {
+			m.table, cmd = m.table.Update(msg)
+		}
+		return m, cmd
+	}
+	return m, nil
+}
+
+// countdown renders the time until t, e.g. "4m12s".
+func countdown(t, now time.Time) string {
+	d := t.Sub(now).Round(time.Second)
+	if d < 0 {
+		d = 0
+	}
+	return d.String()
+}
+
+// refreshTable rebuilds the rows of both tabs, keeping the cursors.
+func (m *JobsModel) refreshTable(now time.Time) {
+	m.refreshOperations(now)
+	rest := m.width - 4 - 14 - 10 - 10 - 6 - 10 - uiconst.TableHeightOffset
+	if rest < 40 {
+		rest = 40
+	}
+	actionW := rest / 2
+	cols := []table.Column{{Title: "ID", Width: 4}, {Title: i18n.T("Schedule"), Width: 14}, {Title: i18n.T("Action"), Width: actionW}, {Title: i18n.T("State"), Width: 10}, {Title: i18n.T("Next in"), Width: 10}, {Title: i18n.T("Runs"), Width: 6}, {Title: i18n.T("Last run"), Width: 10}, {Title: i18n.T("Last result"), Width: rest - actionW}}
+	var rows []table.Row
+	for _, j := range m.scheduler.Jobs() {
+		next, last := "–", "–"
+		if j.State == StatePending {
+			next = countdown(j.Next, now)
+		}
+		if !j.LastRun.IsZero() {
+			last = j.LastRun.Format("15:04:05")
+		}
+		rows = append(rows, table.Row{strconv.Itoa(j.ID), j.Schedule(), j.Action.String(), j.State, next, strconv.Itoa(j.Runs), last, j.LastResult})
+	}
+	cursor := m.table.Cursor()
+	m.table = table.New(table.WithColumns(cols), table.WithFocused(true))
+	m.table.SetStyles(table.DefaultStyles())
+	m.table.SetRows(rows)
+	m.table.SetHeight(m.height - uiconst.TableHeightOffset - 3)
+	if cursor < len(rows) {
+		m.table.SetCursor(cursor)
+	}
+}
+
+// refreshOperations rebuilds the operations table. With the log open, the
+// table gives it half of the height.
+func (m *JobsModel) refreshOperations(now time.Time) {
+	rest := m.width - 4 - 10 - 4 - 10 - 10 - uiconst.TableHeightOffset
+	if rest < 40 {
+		rest = 40
+	}
+	titleW := rest / 2
+	cols := []table.Column{{Title: "ID", Width: 4}, {Title: i18n.T("Operation"), Width: titleW}, {Title: i18n.T("State"), Width: 10}, {Title: i18n.T("Try"), Width: 4}, {Title: i18n.T("Started"), Width: 10}, {Title: i18n.T("Took"), Width: 10}, {Title: i18n.T("Status"), Width: rest - titleW}}
+	var rows []table.Row
+	for _, op := range m.queue.Operations() {
+		end := now
+		if !op.Finished.IsZero() {
+			end = op.Finished
+		}
+		status := op.Status
+		if op.Err != nil {
+			status = op.Err.Error()
+		}
+		rows = append(rows, table.Row{strconv.Itoa(op.ID), op.Title, op.State, strconv.Itoa(op.Attempts), op.Created.Format("15:04:05"), end.Sub(op.Created).Round(time.Second).String(), status})
+	}
+	cursor := m.opsTable.Cursor()
+	m.opsTable = table.New(table.WithColumns(cols), table.WithFocused(true))
+	m.opsTable.SetStyles(table.DefaultStyles())
+	m.opsTable.SetRows(rows)
+	h := m.height - uiconst.TableHeightOffset - 3
+	if m.showLog {
+		h /= 2
+	}
+	m.opsTable.SetHeight(h)
+	if cursor < len(rows) {
+		m.opsTable.SetCursor(cursor)
+	}
+}
+
+// selectedLog renders the log of the selected operation.
+func (m JobsModel) selectedLog() string {
+	row := m.opsTable.SelectedRow()
+	if len(row) == 0 {
+		return ""
+	}
+	id, _ := strconv.Atoi(row[0])
+	for _, op := range m.queue.Operations() {
+		if op.ID != id {
+			continue
+		}
+		var b strings.Builder
+		b.WriteString(i18n.T("Log of operation %d: %s", op.ID, op.Title) + "\n")
+		for _, l := range op.Log {
+			fmt.Fprintf(&b, "  %s  %s\n", l.Time.Format("15:04:05"), l.Text)
+		}
+		return b.String()
+	}
+	return ""
+}
+
+// View renders the table of the current tab.
+func (m JobsModel) View() string {
+	dim := lipgloss.NewStyle().Foreground(theme.Muted)
+	tabs := dim.Render(i18n.T("[tab] operations / scheduled"))
+	if m.tab == tabOperations {
+		tabs = "Operations  " + tabs
+	} else {
+		tabs = "Scheduled jobs  " + tabs
+	}
+	var out string
+	switch {
+	case m.tab == tabOperations && len(m.queue.Operations()) == 0:
+		out = i18n.T("No operations yet.") + "\n" + dim.Render(i18n.T("Creates, deletes and resizes are queued here and followed until the cloud finishes them."))
+	case m.tab == tabOperations:
+		out = m.opsTable.View()
+		if m.showLog {
+			out += "\n" + m.selectedLog()
+		}
+	case len(m.scheduler.Jobs()) == 0:
+		out = i18n.T("No scheduled jobs.") + "\n" + dim.Render(i18n.T("Schedule one with :every 5m refresh servers or :at 22:00 stop server web-test"))
+	default:
+		out = m.table.View()
+	}
+	out = tabs + "\n" + out
+	if m.status != "" {
+		out += "\n" + lipgloss.NewStyle().Foreground(theme.OK).Render(m.status)
+	}
+	if m.tab == tabOperations {
+		return out + "\n" + dim.Render(i18n.T("Operations run while ostui is open.")) + "  " + i18n.T("[enter] log  [R] retry")
+	}
+	return out + "\n" + dim.Render(i18n.T("Jobs run while ostui is open.")) + "  " + i18n.T("[x] cancel")
+}
+
+// Table returns the table of the current tab.
+func (m JobsModel) Table() table.Model {
+	if m.tab == tabOperations {
+		return m.opsTable
+	}
+	return m.table
+}
+
+var _ tea.Model = (*JobsModel)(nil)
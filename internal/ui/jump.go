@@ -0,0 +1,140 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/common"
+	"ostui/internal/ui/theme"
+)
+
+// jumpState is the quick jump started with f: each typed character moves
+// the cursor of the table to the best fuzzy match among its rows. Unlike the
+// filter, no row is hidden.
+type jumpState struct {
+	query string
+	// origin is the row the jump started from, where esc returns.
+	origin  int
+	matches []int
+	// current indexes matches; tab moves on to the next one.
+	current int
+}
+
+// jumpTable returns the table of the current list or detail view, if any.
+func (m AppModel) jumpTable() (common.Tabler, bool) {
+	if m.state != stateMain && m.state != stateDetail {
+		return nil, false
+	}
+	model := m.viewModel(m.state)
+	if f, ok := model.(common.Filterer); ok && f.Filtering() {
+		return nil, false
+	}
+	t, ok := model.(common.Tabler)
+	if !ok || len(jumpRows(t).Rows()) == 0 {
+		return nil, false
+	}
+	return t, true
+}
+
+// jumpRows returns the table whose rows the quick jump searches: all the
+// rows of a virtualized table, not only those on screen.
+func jumpRows(t common.Tabler) table.Model {
+	if f, ok := t.(common.FullTabler); ok {
+		return f.FullTable()
+	}
+	return t.Table()
+}
+
+// startJump opens the quick jump on the table of the current view.
+func (m AppModel) startJump() (AppModel, bool) {
+	t, ok := m.jumpTable()
+	if !ok {
+		return m, false
+	}
+	m.jump = &jumpState{origin: jumpRows(t).Cursor()}
+	return m, true
+}
+
+// updateJump handles a key while the quick jump is open.
+func (m AppModel) updateJump(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	j := *m.jump
+	switch msg.Type {
+	case tea.KeyEnter:
+		m.jump = nil
+		return m, nil
+	case tea.KeyEsc:
+		m.jump = nil
+		return m.moveCursor(j.origin)
+	case tea.KeyTab, tea.KeyCtrlN:
+		if len(j.matches) == 0 {
+			return m, nil
+		}
+		j.current = (j.current + 1) % len(j.matches)
+		m.jump = &j
+		return m.moveCursor(j.matches[j.current])
+	case tea.KeyBackspace:
+		if j.query == "" {
+			return m, nil
+		}
+		r := []rune(j.query)
+		j.query = string(r[:len(r)-1])
+	case tea.KeyRunes, tea.KeySpace:
+		j.query += string(msg.Runes)
+	default:
+		return m, nil
+	}
+	t, ok := m.jumpTable()
+	if !ok {
+		m.jump = nil
+		return m, nil
+	}
+	// Rank from the starting row, so that typing more characters refines
+	// the jump instead of moving on from the last match.
+	tbl := jumpRows(t)
+	tbl.SetCursor(j.origin)
+	j.matches, j.current = common.JumpMatches(tbl, j.query), 0
+	m.jump = &j
+	if len(j.matches) == 0 {
+		return m, nil
+	}
+	return m.moveCursor(j.matches[0])
+}
+
+// moveCursor moves the cursor of the current view's table to row to, by
+// sending the view the keys that get it there.
+func (m AppModel) moveCursor(to int) (tea.Model, tea.Cmd) {
+	model := m.viewModel(m.state)
+	t, ok := model.(common.Tabler)
+	if !ok {
+		return m, nil
+	}
+	var cmds []tea.Cmd
+	for _, k := range common.CursorKeys(jumpRows(t), to) {
+		var cmd tea.Cmd
+		model, cmd = model.Update(k)
+		cmds = append(cmds, cmd)
+	}
+	m.setViewModel(m.state, model)
+	return m, tea.Batch(cmds...)
+}
+
+// jumpView renders the quick jump prompt below the view.
+func (m AppModel) jumpView() string {
+	if m.jump == nil {
+		return ""
+	}
+	prompt := lipgloss.NewStyle().Bold(true).Foreground(theme.Accent).Render(i18n.T("Jump:")) + " " + m.jump.query + "█"
+	count := ""
+	switch {
+	case m.jump.query == "":
+	case len(m.jump.matches) == 0:
+		count = i18n.T("no matches")
+	default:
+		count = fmt.Sprintf("%d/%d", m.jump.current+1, len(m.jump.matches))
+	}
+	hint := lipgloss.NewStyle().Foreground(theme.Muted).Render(i18n.T("[tab] next match  [enter] done  [esc] back"))
+	return "\n" + prompt + "  " + count + "  " + hint
+}
@@ -0,0 +1,63 @@
+package ui
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// tableStub is a list view over a plain table.
+type tableStub struct {
+	table     table.Model
+	filtering bool
+}
+
+func (s tableStub) Init() tea.Cmd      { return nil }
+func (s tableStub) View() string       { return s.table.View() }
+func (s tableStub) Table() table.Model { return s.table }
+func (s tableStub) Filtering() bool    { return s.filtering }
+func (s tableStub) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	s.table, cmd = s.table.Update(msg)
+	return s, cmd
+}
+
+func TestQuickJump(t *testing.T) {
+	var rows []table.Row
+	for i := range 40 {
+		rows = append(rows, table.Row{fmt.Sprintf("id-%d", i), fmt.Sprintf("web-%02d", i)})
+	}
+	rows[33][1] = "cache-1"
+	rows[7][1] = "cache-2"
+	tbl := table.New(table.WithColumns([]table.Column{{Title: "ID", Width: 8}, {Title: "Name", Width: 10}}), table.WithRows(rows), table.WithFocused(true), table.WithHeight(6))
+	tbl.SetCursor(10)
+	var m tea.Model = AppModel{state: stateMain, mainModel: tableStub{table: tbl}}
+	cursor := func() int { return m.(AppModel).mainModel.(tableStub).table.Cursor() }
+
+	// From row 10, the equally good match below the cursor comes first.
+	m = typeKeys(m, "f", "c", "a", "c")
+	if cursor() != 33 {
+		t.Fatalf("expected the jump to cache-1 (row 33), got row %d", cursor())
+	}
+	m = typeKeys(m, "tab")
+	if cursor() != 7 {
+		t.Fatalf("tab should move to cache-2 (row 7), got row %d", cursor())
+	}
+	// esc returns to where the jump started; no rows are hidden.
+	m = typeKeys(m, "esc")
+	if am := m.(AppModel); am.jump != nil || am.state != stateMain || cursor() != 10 || len(am.mainModel.(tableStub).table.Rows()) != 40 {
+		t.Fatalf("esc should close the jump at row 10 on the list, got row %d in %q", cursor(), am.state)
+	}
+	m = typeKeys(m, "f", "3", "9", "enter")
+	if cursor() != 39 || m.(AppModel).jump != nil {
+		t.Fatalf("expected web-39 selected and the jump closed, got row %d", cursor())
+	}
+
+	// f is typed into a filter.
+	m = AppModel{state: stateMain, mainModel: tableStub{table: tbl, filtering: true}}
+	if m = typeKeys(m, "f"); m.(AppModel).jump != nil {
+		t.Fatal("f must not start a jump while filtering")
+	}
+}
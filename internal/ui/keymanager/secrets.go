@@ -0,0 +1,538 @@
+// Package keymanager implements the Key Manager (Barbican) views: secret
+// metadata, payload reveal on request and secret containers.
+package keymanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/gophercloud/gophercloud/openstack/keymanager/v1/secrets"
+	"ostui/internal/client"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/common"
+	"ostui/internal/ui/policy"
+	"ostui/internal/ui/softlock"
+	"ostui/internal/ui/theme"
+	"ostui/internal/ui/uiconst"
+)
+
+// View modes, cycled with tab.
+const (
+	modeSecrets    = "secrets"
+	modeContainers = "containers"
+)
+
+var modes = []string{modeSecrets, modeContainers}
+
+var modeTitles = map[string]string{
+	modeSecrets:    "Secrets",
+	modeContainers: "Containers",
+}
+
+// secretTypes are the Barbican secret types accepted by the new secret form.
+var secretTypes = []secrets.SecretType{secrets.OpaqueSecret, secrets.PassphraseSecret, secrets.SymmetricSecret, secrets.CertificateSecret, secrets.PrivateSecret, secrets.PublicSecret}
+
+// expiryWarning is how close to its expiration a secret is flagged.
+const expiryWarning = 14 * 24 * time.Hour
+
+// SecretsModel lists Barbican secrets (metadata only) and containers. A
+// payload is only fetched after an explicit confirmation.
+type SecretsModel struct {
+	table      table.Model
+	loading    bool
+	err        error
+	spinner    spinner.Model
+	client     client.KeyManagerClient
+	secrets    []client.Secret
+	containers []client.SecretContainer
+	mode       string
+
+	form          *common.FormModel
+	pendingDelete string
+	pendingReveal string
+	revealedID    string
+	payload       string
+	// guard asks for the password or PIN before a confirmed reveal when
+	// the soft-lock is on; unlockFor is the secret waiting for it.
+	guard     softlock.Guard
+	unlockFor string
+	status    string
+	statusErr bool
+
+	width  int
+	height int
+}
+
+// NewSecretsModel creates the key manager view, showing secrets first.
+func NewSecretsModel(kc client.KeyManagerClient) SecretsModel {
+	s := spinner.New()
+	s.Spinner = spinner.Dot
+	return SecretsModel{client: kc, loading: true, spinner: s, mode: modeSecrets, guard: softlock.NewGuard(), width: 120, height: 30}
+}
+
+type secretsLoadedMsg struct {
+	secrets    []client.Secret
+	containers []client.SecretContainer
+	err        error
+}
+
+type changeDoneMsg struct {
+	status string
+	// action names a failed change as checked with policy.Check.
+	action string
+	err    error
+}
+
+type payloadMsg struct {
+	id      string
+	payload string
+	err     error
+}
+
+// Init loads the secrets and containers.
+func (m SecretsModel) Init() tea.Cmd {
+	return tea.Batch(m.spinner.Tick, m.loadCmd())
+}
+
+// Reload re-fetches the secrets and containers, keeping the mode and the
+// selected row.
+func (m SecretsModel) Reload() tea.Cmd {
+	if m.loading {
+		return nil
+	}
+	return m.loadCmd()
+}
+
+func (m SecretsModel) loadCmd() tea.Cmd {
+	kc := m.client
+	return func() tea.Msg {
+		ctx := context.Background()
+		list, err := kc.ListSecrets(ctx)
+		if err != nil {
+			return secretsLoadedMsg{err: fmt.Errorf("%w (is the Key Manager service available?)", err)}
+		}
+		containers, err := kc.ListSecretContainers(ctx)
+		if err != nil {
+			return secretsLoadedMsg{err: err}
+		}
+		return secretsLoadedMsg{secrets: list, containers: containers}
+	}
+}
+
+// Update handles messages for the model.
+func (m SecretsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if m.unlockFor != "" {
+		switch msg.(type) {
+		case tea.KeyMsg, softlock.CheckedMsg:
+			return m.updateUnlock(msg)
+		}
+	}
+	switch msg := msg.(type) {
+	case secretsLoadedMsg:
+		m.loading = false
+		m.err = msg.err
+		m.secrets, m.containers = msg.secrets, msg.containers
+		if msg.err == nil {
+			prev := m.table
+			m.refreshTable()
+			m.table = common.Reloaded(prev, m.table)
+		}
+		return m, nil
+	case changeDoneMsg:
+		m.status, m.statusErr = msg.status, msg.err != nil
+		if msg.err != nil {
+			policy.Record(msg.action, msg.err)
+			m.status = msg.err.Error()
+			return m, nil
+		}
+		return m, m.loadCmd()
+	case payloadMsg:
+		if msg.err != nil {
+			m.status, m.statusErr = msg.err.Error(), true
+			return m, nil
+		}
+		m.revealedID, m.payload = msg.id, msg.payload
+		return m, nil
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		if !m.loading && m.err == nil {
+			m.refreshTable()
+		}
+		return m, nil
+	case tea.KeyMsg:
+		if m.form != nil {
+			return m.updateForm(msg)
+		}
+		if m.pendingDelete != "" {
+			id := m.pendingDelete
+			m.pendingDelete = ""
+			if msg.String() != "y" {
+				return m, nil
+			}
+			kc := m.client
+			return m, func() tea.Msg {
+				err := kc.DeleteSecret(context.Background(), id)
+				return changeDoneMsg{status: i18n.T("Deleted secret %s", id), err: err}
+			}
+		}
+		if m.pendingReveal != "" {
+			id := m.pendingReveal
+			m.pendingReveal = ""
+			if msg.String() != "y" {
+				return m, nil
+			}
+			cmd := m.guard.Reveal()
+			if m.guard.Revealed() {
+				m.guard.Hide()
+				return m, m.fetchPayload(id)
+			}
+			m.unlockFor = id
+			return m, cmd
+		}
+		if m.loading || m.err != nil {
+			return m, nil
+		}
+		// Any key hides a revealed payload again.
+		if m.revealedID != "" {
+			m.revealedID, m.payload = "", ""
+			if msg.String() == "esc" {
+				return m, nil
+			}
+		}
+		switch msg.String() {
+		case "tab", "shift+tab":
+			step := 1
+			if msg.String() == "shift+tab" {
+				step = len(modes) - 1
+			}
+			for i, mode := range modes {
+				if mode == m.mode {
+					m.mode = modes[(i+step)%len(modes)]
+					break
+				}
+			}
+			m.refreshTable()
+			return m, nil
+		case "n":
+			if err := policy.Check(policy.SecretCreator, "creating a secret"); err != nil {
+				m.status, m.statusErr = err.Error(), true
+				return m, nil
+			}
+			f := common.NewForm([]string{i18n.T("Name"), i18n.T("Type (opaque, passphrase, symmetric, certificate, private, public)"), i18n.T("Payload (\\n for newlines)"), i18n.T("Algorithm (optional)"), i18n.T("Bit length (optional)"), i18n.T("Expires (YYYY-MM-DD, optional)")})
+			f.SetValue(1, string(secrets.OpaqueSecret))
+			f.SetMasked(2)
+			m.form = &f
+			return m, f.Init()
+		case "x":
+			if row := m.table.SelectedRow(); m.mode == modeSecrets && len(row) > 0 {
+				m.pendingDelete = row[0]
+			}
+			return m, nil
+		case "v":
+			if row := m.table.SelectedRow(); m.mode == modeSecrets && len(row) > 0 {
+				m.pendingReveal = row[0]
+			}
+			return m, nil
+		case "r":
+			m.loading = true
+			return m, tea.Batch(m.spinner.Tick, m.loadCmd())
+		}
+		var cmd tea.Cmd
+		m.table, cmd = m.table.Update(msg)
+		return m, cmd
+	default:
+		if m.loading {
+			var cmd tea.Cmd
+			m.spinner, cmd = m.spinner.Update(msg)
+			return m, cmd
+		}
+	}
+	return m, nil
+}
+
+// secretFromForm validates the new secret form values.
+func secretFromForm(v []string, now time.Time) (client.SecretCreateInput, error) {
+	in := client.SecretCreateInput{Name: v[0], SecretType: secrets.SecretType(strings.ToLower(v[1])), Payload: strings.ReplaceAll(v[2], `\n`, "\n"), PayloadContentType: "text/plain", Algorithm: v[3]}
+	known := false
+	for _, t := range secretTypes {
+		known = known || in.SecretType == t
+	}
+	if !known {
+		return in, errors.New("type must be opaque, passphrase, symmetric, certificate, private or public")
+	}
+	if in.Payload == "" {
+		return in, errors.New("payload is required")
+	}
+	if v[4] != "" {
+		bits, err := strconv.Atoi(v[4])
+		if err != nil || bits <= 0 {
+			return in, errors.New("bit length must be a positive number")
+		}
+		in.BitLength = bits
+	}
+	if v[5] != "" {
+		exp, err := time.Parse("2006-01-02", v[5])
+		if err != nil {
+			return in, errors.New("expiration must be a date like 2030-01-31")
+		}
+		if !exp.After(now) {
+			return in, errors.New("expiration must be in the future")
+		}
+		in.Expiration = &exp
+	}
+	return in, nil
+}
+
+// updateForm handles keys for the new secret form.
+func (m SecretsModel) updateForm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	fm, cmd := m.form.Update(msg)
+	f := fm.(common.FormModel)
+	m.form = &f
+	switch {
+	case f.Cancelled():
+		m.form = nil
+	case f.Submitted():
+		in, err := secretFromForm(f.Values(), time.Now())
+		if err != nil {
+			m.form.SetError(err)
+			return m, nil
+		}
+		m.form = nil
+		kc := m.client
+		return m, func() tea.Msg {
+			created, err := kc.CreateSecret(context.Background(), in)
+			if err != nil {
+				return changeDoneMsg{action: "creating a secret", err: err}
+			}
+			return changeDoneMsg{status: i18n.T("Stored secret %s", client.BarbicanID(created.SecretRef))}
+		}
+	}
+	return m, cmd
+}
+
+// CapturingInput reports whether the form or a prompt is open.
+func (m SecretsModel) CapturingInput() bool {
+	return m.form != nil || m.pendingDelete != "" || m.pendingReveal != "" || m.unlockFor != ""
+}
+
+// ConfirmingDestructive reports whether a secret delete waits for y; showing
+// a payload is not destructive.
+func (m SecretsModel) ConfirmingDestructive() bool { return m.pendingDelete != "" }
+
+// fetchPayload loads the payload of a secret for display.
+func (m SecretsModel) fetchPayload(id string) tea.Cmd {
+	kc := m.client
+	return func() tea.Msg {
+		p, err := kc.GetSecretPayload(context.Background(), id)
+		return payloadMsg{id: id, payload: p, err: err}
+	}
+}
+
+// updateUnlock runs the soft-lock prompt of a confirmed reveal and fetches
+// the payload once it is unlocked.
+func (m SecretsModel) updateUnlock(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	m.guard, cmd = m.guard.Update(msg)
+	if m.guard.Prompting() {
+		return m, cmd
+	}
+	id := m.unlockFor
+	m.unlockFor = ""
+	if m.guard.Revealed() {
+		m.guard.Hide()
+		return m, m.fetchPayload(id)
+	}
+	if err := m.guard.Err(); err != nil {
+		m.status, m.statusErr = err.Error(), true
+	}
+	return m, nil
+}
+
+// secretName returns the name of a secret by ID, or the ID when unknown.
+func (m SecretsModel) secretName(id string) string {
+	for _, s := range m.secrets {
+		if client.BarbicanID(s.SecretRef) == id {
+			if s.Name != "" {
+				return s.Name
+			}
+			break
+		}
+	}
+	return id
+}
+
+// containersOf returns "container (role)" for each container referencing a
+// secret.
+func (m SecretsModel) containersOf(id string) []string {
+	var out []string
+	for _, c := range m.containers {
+		for _, ref := range c.SecretRefs {
+			if client.BarbicanID(ref.SecretRef) == id {
+				out = append(out, fmt.Sprintf("%s (%s)", c.Name, ref.Name))
+			}
+		}
+	}
+	return out
+}
+
+// consumerLabel renders a container consumer, e.g. "lbaas listeners/<id>".
+func consumerLabel(c client.SecretContainer, i int) string {
+	ref := c.Consumers[i]
+	parts := strings.Split(strings.TrimSuffix(ref.URL, "/"), "/")
+	if len(parts) >= 2 {
+		return ref.Name + " " + parts[len(parts)-2] + "/" + parts[len(parts)-1]
+	}
+	return ref.Name + " " + ref.URL
+}
+
+func formatDate(t time.Time) string {
+	if t.IsZero() {
+		return "–"
+	}
+	return t.Format("2006-01-02")
+}
+
+// refreshTable rebuilds the table for the current mode.
+func (m *SecretsModel) refreshTable() {
+	idW, statusW := uiconst.ColWidthUUID, uiconst.ColWidthStatus
+	rest := m.width - idW - statusW - uiconst.TableHeightOffset
+	if rest < 60 {
+		rest = 60
+	}
+	var cols []table.Column
+	var rows []table.Row
+	switch m.mode {
+	case modeSecrets:
+		w := rest - 12 - 12 - 11 - 11
+		cols = []table.Column{{Title: "ID", Width: idW}, {Title: i18n.T("Name"), Width: w}, {Title: i18n.T("Type"), Width: 12}, {Title: i18n.T("Algorithm"), Width: 12}, {Title: i18n.T("Created"), Width: 11}, {Title: i18n.T("Expires"), Width: 11}, {Title: i18n.T("Status"), Width: statusW}}
+		for _, s := range m.secrets {
+			alg := s.Algorithm
+			if s.BitLength > 0 {
+				alg = fmt.Sprintf("%s %d", alg, s.BitLength)
+			}
+			rows = append(rows, table.Row{client.BarbicanID(s.SecretRef), s.Name, s.SecretType, strings.TrimSpace(alg), formatDate(s.Created), formatDate(s.Expiration), s.Status})
+		}
+	case modeContainers:
+		w := (rest - 12 - 8) / 2
+		cols = []table.Column{{Title: "ID", Width: idW}, {Title: i18n.T("Name"), Width: w}, {Title: i18n.T("Type"), Width: 12}, {Title: i18n.T("Secrets"), Width: 8}, {Title: i18n.T("Consumers"), Width: rest - w - 20}, {Title: i18n.T("Status"), Width: statusW}}
+		for _, c := range m.containers {
+			consumers := make([]string, 0, len(c.Consumers))
+			for i := range c.Consumers {
+				consumers = append(consumers, consumerLabel(c, i))
+			}
+			rows = append(rows, table.Row{client.BarbicanID(c.ContainerRef), c.Name, c.Type, fmt.Sprintf("%d", len(c.SecretRefs)), strings.Join(consumers, ", "), c.Status})
+		}
+	}
+	m.table = table.New(table.WithColumns(cols), table.WithFocused(true))
+	m.table.SetStyles(table.DefaultStyles())
+	m.table.SetRows(rows)
+	m.table.SetHeight(m.height - uiconst.TableHeightOffset - 5)
+}
+
+// selectionLine details the selected row: the containers referencing a
+// secret and its expiry, or the secrets and consumers of a container.
+func (m SecretsModel) selectionLine(now time.Time) string {
+	row := m.table.SelectedRow()
+	if len(row) == 0 {
+		return ""
+	}
+	switch m.mode {
+	case modeSecrets:
+		var parts []string
+		for _, s := range m.secrets {
+			if client.BarbicanID(s.SecretRef) != row[0] || s.Expiration.IsZero() {
+				continue
+			}
+			switch left := s.Expiration.Sub(now); {
+			case left <= 0:
+				parts = append(parts, "EXPIRED")
+			case left < expiryWarning:
+				parts = append(parts, i18n.T("expires in %d days", int(left.Hours()/24)+1))
+			}
+		}
+		if in := m.containersOf(row[0]); len(in) > 0 {
+			parts = append(parts, "In containers: "+strings.Join(in, ", "))
+		} else {
+			parts = append(parts, i18n.T("Not in any container"))
+		}
+		return strings.Join(parts, " · ")
+	case modeContainers:
+		for _, c := range m.containers {
+			if client.BarbicanID(c.ContainerRef) != row[0] {
+				continue
+			}
+			refs := make([]string, 0, len(c.SecretRefs))
+			for _, ref := range c.SecretRefs {
+				refs = append(refs, ref.Name+" → "+m.secretName(client.BarbicanID(ref.SecretRef)))
+			}
+			line := "Secrets: " + strings.Join(refs, ", ")
+			if len(c.Consumers) == 0 {
+				line += " · no consumers"
+			}
+			return line
+		}
+	}
+	return ""
+}
+
+// View renders the tab bar, the current table, the selection details and a
+// revealed payload.
+func (m SecretsModel) View() string {
+	if m.form != nil {
+		return i18n.T("New secret – the payload is sent to Barbican and not shown") + "\n\n" + m.form.View() + "\n" + i18n.T("[tab] next field  [enter] next/store  [esc] cancel")
+	}
+	if m.loading {
+		return m.spinner.View()
+	}
+	if m.err != nil {
+		return i18n.T("Error: %s", m.err)
+	}
+	active := lipgloss.NewStyle().Bold(true).Foreground(theme.Accent)
+	dim := lipgloss.NewStyle().Foreground(theme.Muted)
+	warn := lipgloss.NewStyle().Foreground(theme.Warn)
+	var tabs []string
+	for _, mode := range modes {
+		if mode == m.mode {
+			tabs = append(tabs, active.Render("["+i18n.T(modeTitles[mode])+"]"))
+		} else {
+			tabs = append(tabs, dim.Render(" "+i18n.T(modeTitles[mode])+" "))
+		}
+	}
+	out := strings.Join(tabs, " ") + "\n" + m.table.View() + "\n" + dim.Render(m.selectionLine(time.Now()))
+	switch {
+	case m.unlockFor != "":
+		out += "\n" + m.guard.View()
+	case m.revealedID != "":
+		out += "\n" + warn.Render("Payload of "+m.secretName(m.revealedID)+" (any key hides it):") + "\n" + m.payload
+	case m.pendingReveal != "":
+		out += "\n" + warn.Render("Show the payload of secret "+m.secretName(m.pendingReveal)+" on screen?") + " [y/N]"
+	case m.pendingDelete != "":
+		prompt := "Delete secret " + m.secretName(m.pendingDelete) + "?"
+		if in := m.containersOf(m.pendingDelete); len(in) > 0 {
+			prompt = i18n.T("Delete secret %s? It is still referenced by %s.", m.secretName(m.pendingDelete), strings.Join(in, ", "))
+		}
+		out += "\n" + prompt + " [y/N]"
+	case m.statusErr:
+		out += "\n" + lipgloss.NewStyle().Foreground(theme.Error).Render(m.status)
+	case m.status != "":
+		out += "\n" + lipgloss.NewStyle().Foreground(theme.OK).Render(m.status)
+	}
+	help := i18n.T("[tab] switch list") + "  " + policy.Key(policy.SecretCreator, i18n.T("[n] new secret"), "creating a secret") + "  " + i18n.T("[r] refresh")
+	if m.mode == modeSecrets {
+		help = i18n.T("[tab] switch list  [v] reveal payload") + "  " + policy.Key(policy.SecretCreator, i18n.T("[n] new secret"), "creating a secret") + "  " + i18n.T("[x] delete  [r] refresh")
+	}
+	return out + "\n" + help
+}
+
+// Table returns the table of the current mode.
+func (m SecretsModel) Table() table.Model { return m.table }
+
+var _ tea.Model = (*SecretsModel)(nil)
@@ -0,0 +1,118 @@
+package keymanager
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/gophercloud/gophercloud/openstack/keymanager/v1/containers"
+	"ostui/internal/client"
+)
+
+const refBase = "https://barbican.example:9311/v1/"
+
+type mockKeyManagerClient struct {
+	secrets      []client.Secret
+	containers   []client.SecretContainer
+	payloadCalls int
+	deleted      []string
+}
+
+func (m *mockKeyManagerClient) ListSecrets(ctx context.Context) ([]client.Secret, error) {
+	return m.secrets, nil
+}
+func (m *mockKeyManagerClient) GetSecretPayload(ctx context.Context, id string) (string, error) {
+	m.payloadCalls++
+	if id == "sec-1" {
+		return "hunter2", nil
+	}
+	return "", errors.New("not found")
+}
+func (m *mockKeyManagerClient) CreateSecret(ctx context.Context, in client.SecretCreateInput) (*client.Secret, error) {
+	s := client.Secret{SecretRef: refBase + "secrets/sec-new", Name: in.Name}
+	return &s, nil
+}
+func (m *mockKeyManagerClient) DeleteSecret(ctx context.Context, id string) error {
+	m.deleted = append(m.deleted, id)
+	return nil
+}
+func (m *mockKeyManagerClient) ListSecretContainers(ctx context.Context) ([]client.SecretContainer, error) {
+	return m.containers, nil
+}
+
+func key(s string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)} }
+
+func TestPayloadRevealNeedsConfirmation(t *testing.T) {
+	mock := &mockKeyManagerClient{
+		secrets: []client.Secret{{SecretRef: refBase + "secrets/sec-1", Name: "db-password", SecretType: "passphrase"}},
+		containers: []client.SecretContainer{{ContainerRef: refBase + "containers/ct-1", Name: "www-tls", Type: "certificate",
+			SecretRefs: []containers.SecretRef{{Name: "private_key", SecretRef: refBase + "secrets/sec-1"}}}},
+	}
+	m := NewSecretsModel(mock)
+	updated, _ := m.Update(m.loadCmd()())
+	m = updated.(SecretsModel)
+	if strings.Contains(m.View(), "hunter2") || mock.payloadCalls != 0 {
+		t.Fatalf("the payload must not be fetched by the list")
+	}
+	if !strings.Contains(m.View(), "In containers: www-tls (private_key)") {
+		t.Fatalf("expected the referencing container, got %q", m.View())
+	}
+
+	updated, _ = m.Update(key("v"))
+	m = updated.(SecretsModel)
+	updated, cmd := m.Update(key("n"))
+	m = updated.(SecretsModel)
+	if cmd != nil || mock.payloadCalls != 0 || m.CapturingInput() {
+		t.Fatalf("declining the prompt must not fetch the payload")
+	}
+
+	updated, _ = m.Update(key("v"))
+	m = updated.(SecretsModel)
+	updated, cmd = m.Update(key("y"))
+	m = updated.(SecretsModel)
+	updated, _ = m.Update(cmd())
+	m = updated.(SecretsModel)
+	if !strings.Contains(m.View(), "hunter2") {
+		t.Fatalf("expected the revealed payload, got %q", m.View())
+	}
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = updated.(SecretsModel)
+	if strings.Contains(m.View(), "hunter2") {
+		t.Fatalf("expected esc to hide the payload")
+	}
+
+	updated, _ = m.Update(key("x"))
+	m = updated.(SecretsModel)
+	if !strings.Contains(m.View(), "still referenced by www-tls") {
+		t.Fatalf("expected a warning about the container, got %q", m.View())
+	}
+	_, cmd = m.Update(key("y"))
+	cmd()
+	if len(mock.deleted) != 1 || mock.deleted[0] != "sec-1" {
+		t.Fatalf("unexpected deletes %v", mock.deleted)
+	}
+}
+
+func TestSecretFromForm(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	in, err := secretFromForm([]string{"tls", "Certificate", `-----BEGIN CERTIFICATE-----\nMIIB\n-----END CERTIFICATE-----`, "", "", "2027-01-31"}, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if in.SecretType != "certificate" || strings.Count(in.Payload, "\n") != 2 || in.Expiration == nil {
+		t.Errorf("unexpected input %+v", in)
+	}
+	for _, v := range [][]string{
+		{"a", "password", "x", "", "", ""},
+		{"a", "opaque", "", "", "", ""},
+		{"a", "opaque", "x", "", "-1", ""},
+		{"a", "opaque", "x", "", "", "2025-12-31"},
+	} {
+		if _, err := secretFromForm(v, now); err == nil {
+			t.Errorf("expected an error for %v", v)
+		}
+	}
+}
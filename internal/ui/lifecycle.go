@@ -0,0 +1,154 @@
+package ui
+
+import (
+	"reflect"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"ostui/internal/ui/common"
+	"ostui/internal/ui/search"
+	"ostui/internal/ui/shell"
+	"ostui/internal/ui/topology"
+)
+
+// Every open view gets a lifecycle token. The messages its commands return
+// come back tagged with it, so a slow load finishing after the user moved on
+// reaches the view that started it, even while covered by another one, and
+// is dropped once that view is closed instead of landing on its successor.
+
+// viewMsg is a message returned by a command of the view with token.
+type viewMsg struct {
+	token int
+	msg   tea.Msg
+}
+
+// appPackages hold messages that belong to the app rather than to a view,
+// e.g. the due jobs of the scheduler, and are never tagged. Operations go
+// to the queue of the app and must finish after their view is closed.
+var appPackages = map[string]bool{
+	"ostui/internal/ui/jobs": true,
+	"ostui/internal/ui/ops":  true,
+}
+
+// viewOwned reports whether msg is private to a view: defined by one of
+// the view packages under internal/ui, not by bubbletea or the app.
+func viewOwned(msg tea.Msg) bool {
+	t := reflect.TypeOf(msg)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	pkg := t.PkgPath()
+	return strings.HasPrefix(pkg, "ostui/internal/ui/") && !appPackages[pkg]
+}
+
+// tagCmd tags the view messages returned by cmd, and by the commands of a
+// batch it returns, with token. Token 0 leaves cmd as is.
+func tagCmd(token int, cmd tea.Cmd) tea.Cmd {
+	if cmd == nil || token == 0 {
+		return cmd
+	}
+	return func() tea.Msg {
+		switch msg := cmd().(type) {
+		case nil:
+			return nil
+		case tea.BatchMsg:
+			tagged := make(tea.BatchMsg, len(msg))
+			for i, c := range msg {
+				tagged[i] = tagCmd(token, c)
+			}
+			return tagged
+		case viewMsg:
+			return msg
+		default:
+			if !viewOwned(msg) {
+				return msg
+			}
+			return viewMsg{token: token, msg: msg}
+		}
+	}
+}
+
+// openToken gives the view now opened in state a new token.
+func (m *AppModel) openToken(state string) int {
+	if m.tokens == nil {
+		m.tokens = map[string]int{}
+	}
+	m.lastToken++
+	m.tokens[state] = m.lastToken
+	return m.lastToken
+}
+
+// activeToken returns the token of the view the user is on; the help and
+// command mode overlays leave the view below active.
+func (m *AppModel) activeToken() int {
+	state := m.state
+	if state == stateHelp || state == stateCommand {
+		state = m.prevState
+	}
+	return m.tokens[state]
+}
+
+// dispose releases v, a view that is gone for good or a dropped message
+// holding a resource.
+func dispose(v any) {
+	if d, ok := v.(common.Disposer); ok {
+		d.Dispose()
+	}
+}
+
+// routeViewMsg delivers a tagged message to its view: through the usual
+// path when it is the active one, straight to its model when it is covered.
+// The messages of closed views are dropped.
+func (m AppModel) routeViewMsg(msg viewMsg) (tea.Model, tea.Cmd) {
+	if msg.token == m.activeToken() {
+		updated, cmd := m.update(msg.msg)
+		return updated, tagCmd(msg.token, cmd)
+	}
+	for state, token := range m.tokens {
+		if token == msg.token && m.viewModel(state) != nil {
+			return m, tagCmd(token, m.updateView(state, msg.msg))
+		}
+	}
+	// A view replaced by one of the same kind (detail → detail) waits on
+	// the stack.
+	for i, v := range m.navStack {
+		if v.token == msg.token && v.model != nil {
+			var cmd tea.Cmd
+			m.navStack[i].model, cmd = v.model.Update(msg.msg)
+			return m, tagCmd(msg.token, cmd)
+		}
+	}
+	dispose(msg.msg)
+	return m, nil
+}
+
+// updateView passes msg to the model of state, which need not be active.
+func (m *AppModel) updateView(state string, msg tea.Msg) tea.Cmd {
+	switch {
+	case state == stateTopology && m.topologyModel != nil:
+		updated, cmd := m.topologyModel.Update(msg)
+		if tm, ok := updated.(topology.TopologyModel); ok {
+			*m.topologyModel = tm
+		}
+		return cmd
+	case state == stateSearch && m.searchModel != nil:
+		updated, cmd := m.searchModel.Update(msg)
+		if sm, ok := updated.(search.SearchModel); ok {
+			m.searchModel = &sm
+		}
+		return cmd
+	case state == stateShell && m.shellModel != nil:
+		updated, cmd := m.shellModel.Update(msg)
+		if sm, ok := updated.(shell.ShellModel); ok {
+			m.shellModel = &sm
+		}
+		return cmd
+	}
+	model := m.viewModel(state)
+	if model == nil {
+		return nil
+	}
+	updated, cmd := model.Update(msg)
+	m.setViewModel(state, updated)
+	return cmd
+}
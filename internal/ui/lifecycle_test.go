@@ -0,0 +1,108 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"ostui/internal/ui/common"
+	"ostui/internal/ui/ops"
+)
+
+// loadStub is a view whose Init load returns seq; it records the loads it
+// receives and whether it was disposed.
+type loadStub struct {
+	name     string
+	seq      int
+	got      *[]string
+	disposed *bool
+}
+
+func (s loadStub) Init() tea.Cmd {
+	return func() tea.Msg { return common.FlashMsg{Seq: s.seq} }
+}
+func (s loadStub) View() string { return s.name }
+func (s loadStub) Dispose()     { *s.disposed = true }
+func (s loadStub) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(common.FlashMsg); ok {
+		*s.got = append(*s.got, fmt.Sprintf("%s:%d", s.name, msg.Seq))
+	}
+	return s, nil
+}
+
+func TestStaleResultsFollowTheirView(t *testing.T) {
+	var got []string
+	var listDisposed, detailDisposed bool
+	m := AppModel{state: stateSidebar}
+	listLoad := m.pushView(stateMain, loadStub{name: "list", seq: 1, got: &got, disposed: &listDisposed})
+	detailLoad := m.pushView(stateDetail, loadStub{name: "detail", seq: 2, got: &got, disposed: &detailDisposed})
+
+	// The list finishes loading behind the detail: it gets its own data.
+	updated, _ := m.Update(listLoad())
+	m = updated.(AppModel)
+	if len(got) != 1 || got[0] != "list:1" {
+		t.Fatalf("the covered list should get its load, got %v", got)
+	}
+
+	// The detail is closed before its load returns: the result is dropped
+	// rather than applied to the list.
+	m.popView()
+	if !detailDisposed || listDisposed {
+		t.Fatalf("only the closed detail should be disposed (detail %v, list %v)", detailDisposed, listDisposed)
+	}
+	updated, _ = m.Update(detailLoad())
+	m = updated.(AppModel)
+	if len(got) != 1 {
+		t.Fatalf("the result of a closed view should be dropped, got %v", got)
+	}
+
+	// A batch is tagged command by command; messages of the app pass as is.
+	batch := tagCmd(m.activeToken(), tea.Batch(loadStub{seq: 3}.Init(), func() tea.Msg { return tokenTickMsg{} }))
+	msgs, ok := batch().(tea.BatchMsg)
+	if !ok || len(msgs) != 2 {
+		t.Fatalf("expected a batch of two, got %#v", msgs)
+	}
+	if vm, ok := msgs[0]().(viewMsg); !ok || vm.token != m.activeToken() {
+		t.Errorf("the view message should carry the list token, got %#v", vm)
+	}
+	if _, ok := msgs[1]().(tokenTickMsg); !ok {
+		t.Errorf("app messages should not be tagged")
+	}
+}
+
+func TestOperationsOutliveTheirView(t *testing.T) {
+	var got []string
+	var disposed bool
+	m := AppModel{state: stateSidebar, operations: ops.NewQueue()}
+	m.pushView(stateMain, loadStub{name: "list", got: &got, disposed: &disposed})
+	m.pushView(stateDetail, loadStub{name: "detail", got: &got, disposed: &disposed})
+	enqueue := tagCmd(m.activeToken(), ops.Enqueue("Delete server web", "s1", func(context.Context) (string, ops.Poll, error) {
+		return "deleted", nil, nil
+	}))
+
+	// The detail that queued the delete is closed before the queue sees it.
+	m.popView()
+	pending := []tea.Msg{enqueue()}
+	for len(pending) > 0 {
+		msg := pending[0]
+		pending = pending[1:]
+		if batch, ok := msg.(tea.BatchMsg); ok {
+			for _, c := range batch {
+				if c != nil {
+					pending = append(pending, c())
+				}
+			}
+			continue
+		}
+		updated, cmd := m.Update(msg)
+		m = updated.(AppModel)
+		if cmd != nil {
+			pending = append(pending, cmd())
+		}
+	}
+	done := m.operations.Operations()
+	if len(done) != 1 || done[0].State != ops.StateDone || m.operations.Active() != 0 {
+		t.Fatalf("expected the delete done after its view closed, got %+v", done)
+	}
+}
@@ -3,11 +3,15 @@ package loadbalancer
 import (
 	"context"
 	"fmt"
+	"time"
+
 	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/table"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"ostui/internal/client"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/common"
 	"ostui/internal/ui/uiconst"
 )
 
@@ -20,6 +24,7 @@ type LoadBalancerDetailModel struct {
 	err            error
 	spinner        spinner.Model
 	client         client.LoadBalancerClient
+	keys           client.KeyManagerClient
 	lbID           string
 	lbName         string
 	// mode indicates which table is currently visible: "listeners" or "pools".
@@ -27,6 +32,8 @@ type LoadBalancerDetailModel struct {
 	// stored data for inspect view.
 	listeners []client.Listener
 	pools     []client.Pool
+	// certs are the resolved certificates of TLS listeners, by listener ID.
+	certs map[string]listenerCert
 	// Inspect view fields.
 	inspectView     string
 	inspectViewport viewport.Model
@@ -41,17 +48,20 @@ func (m LoadBalancerDetailModel) ResourceName() string { return m.lbName }
 type loadBalancerDetailDataLoadedMsg struct {
 	listeners []client.Listener
 	pools     []client.Pool
+	certs     map[string]listenerCert
 	err       error
 }
 
-// NewLoadBalancerDetailModel creates a new detail model for the given load balancer.
-func NewLoadBalancerDetailModel(lc client.LoadBalancerClient, lbID string, lbName string) LoadBalancerDetailModel {
+// NewLoadBalancerDetailModel creates a new detail model for the given load
+// balancer. km resolves the certificates of TLS listeners; nil shows only
+// their references.
+func NewLoadBalancerDetailModel(lc client.LoadBalancerClient, km client.KeyManagerClient, lbID string, lbName string) LoadBalancerDetailModel {
 	s := spinner.New()
 	s.Spinner = spinner.Dot
-	return LoadBalancerDetailModel{client: lc, loading: true, spinner: s, lbID: lbID, lbName: lbName, mode: "listeners"}
+	return LoadBalancerDetailModel{client: lc, keys: km, loading: true, spinner: s, lbID: lbID, lbName: lbName, mode: "listeners"}
 }
 
-// Init starts async loading of listeners and pools.
+// Init starts async loading of listeners, their certificates and pools.
 func (m LoadBalancerDetailModel) Init() tea.Cmd {
 	return func() tea.Msg {
 		// Load listeners.
@@ -64,8 +74,17 @@ func (m LoadBalancerDetailModel) Init() tea.Cmd {
 		if err != nil {
 			return loadBalancerDetailDataLoadedMsg{err: err}
 		}
-		return loadBalancerDetailDataLoadedMsg{listeners: lst, pools: p}
+		certs := resolveListenerCerts(context.Background(), m.keys, lst)
+		return loadBalancerDetailDataLoadedMsg{listeners: lst, pools: p, certs: certs}
+	}
+}
+
+// Reload re-fetches the listeners and pools, keeping the selected rows.
+func (m LoadBalancerDetailModel) Reload() tea.Cmd {
+	if m.loading {
+		return nil
 	}
+	return m.Init()
 }
 
 // Update processes messages and user input.
@@ -79,11 +98,19 @@ func (m LoadBalancerDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		m.listeners = msg.listeners
 		m.pools = msg.pools
+		m.certs = msg.certs
 		// Build listeners table.
-		lcols := []table.Column{{Title: "ID", Width: uiconst.ColWidthUUID}, {Title: "Name", Width: uiconst.ColWidthNameLong}, {Title: "Protocol", Width: uiconst.ColWidthProtocol}, {Title: "Port", Width: uiconst.ColWidthPort}, {Title: "Status", Width: uiconst.ColWidthStatusLong}}
+		lcols := []table.Column{{Title: "ID", Width: uiconst.ColWidthUUID}, {Title: i18n.T("Name"), Width: uiconst.ColWidthNameLong}, {Title: i18n.T("Protocol"), Width: 16}, {Title: i18n.T("Port"), Width: uiconst.ColWidthPort}, {Title: i18n.T("Status"), Width: uiconst.ColWidthStatusLong}, {Title: i18n.T("TLS Container"), Width: uiconst.ColWidthUUID}, {Title: i18n.T("Cert CN"), Width: uiconst.ColWidthName}, {Title: i18n.T("Expires"), Width: 20}}
 		lrows := []table.Row{}
+		now := time.Now()
 		for _, l := range m.listeners {
-			lrows = append(lrows, table.Row{l.ID, l.Name, l.Protocol, fmt.Sprintf("%d", l.ProtocolPort), l.ProvisioningStatus})
+			var tlsRef string
+			if l.DefaultTLSContainerRef != "" {
+				tlsRef = client.BarbicanID(l.DefaultTLSContainerRef)
+			}
+			cert, resolved := m.certs[l.ID]
+			cn, expires := certCells(l, cert, resolved, now)
+			lrows = append(lrows, table.Row{l.ID, l.Name, l.Protocol, fmt.Sprintf("%d", l.ProtocolPort), l.ProvisioningStatus, tlsRef, cn, expires})
 		}
 		lt := table.New(
 			table.WithColumns(lcols),
@@ -91,9 +118,9 @@ func (m LoadBalancerDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			table.WithFocused(true),
 		)
 		lt.SetStyles(table.DefaultStyles())
-		m.listenersTable = lt
+		m.listenersTable = common.Reloaded(m.listenersTable, lt)
 		// Build pools table.
-		pcols := []table.Column{{Title: "ID", Width: uiconst.ColWidthUUID}, {Title: "Name", Width: uiconst.ColWidthNameLong}, {Title: "Protocol", Width: uiconst.ColWidthProtocol}, {Title: "Algorithm", Width: uiconst.ColWidthAlgorithm}, {Title: "Status", Width: uiconst.ColWidthStatusLong}}
+		pcols := []table.Column{{Title: "ID", Width: uiconst.ColWidthUUID}, {Title: i18n.T("Name"), Width: uiconst.ColWidthNameLong}, {Title: i18n.T("Protocol"), Width: uiconst.ColWidthProtocol}, {Title: i18n.T("Algorithm"), Width: uiconst.ColWidthAlgorithm}, {Title: i18n.T("Status"), Width: uiconst.ColWidthStatusLong}}
 		prows := []table.Row{}
 		for _, p := range m.pools {
 			prows = append(prows, table.Row{p.ID, p.Name, p.Protocol, p.LBAlgorithm, p.ProvisioningStatus})
@@ -104,7 +131,7 @@ func (m LoadBalancerDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			table.WithFocused(true),
 		)
 		pt.SetStyles(table.DefaultStyles())
-		m.poolsTable = pt
+		m.poolsTable = common.Reloaded(m.poolsTable, pt)
 		return m, nil
 	case tea.WindowSizeMsg:
 		// Adjust table widths for both tables.
@@ -188,7 +215,8 @@ func (m LoadBalancerDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				if l == nil {
 					return m, nil
 				}
-				content := fmt.Sprintf("=== Listener: %s ===\nID: %s\nName: %s\nProtocol: %s\nPort: %d\nStatus: %s", l.Name, l.ID, l.Name, l.Protocol, l.ProtocolPort, l.ProvisioningStatus)
+				cert, resolved := m.certs[l.ID]
+				content := i18n.T("=== Listener: %s ===\nID: %s\nName: %s\nProtocol: %s\nPort: %d\nStatus: %s", l.Name, l.ID, l.Name, l.Protocol, l.ProtocolPort, l.ProvisioningStatus) + tlsInspect(*l, cert, resolved, time.Now())
 				m.inspectView = content
 				m.inspectViewport = viewport.New(80, 24)
 				m.inspectViewport.SetContent(m.inspectView)
@@ -210,7 +238,7 @@ func (m LoadBalancerDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if p == nil {
 				return m, nil
 			}
-			content := fmt.Sprintf("=== Pool: %s ===\nID: %s\nName: %s\nProtocol: %s\nAlgorithm: %s\nStatus: %s", p.Name, p.ID, p.Name, p.Protocol, p.LBAlgorithm, p.ProvisioningStatus)
+			content := i18n.T("=== Pool: %s ===\nID: %s\nName: %s\nProtocol: %s\nAlgorithm: %s\nStatus: %s", p.Name, p.ID, p.Name, p.Protocol, p.LBAlgorithm, p.ProvisioningStatus)
 			m.inspectView = content
 			m.inspectViewport = viewport.New(80, 24)
 			m.inspectViewport.SetContent(m.inspectView)
@@ -240,10 +268,10 @@ func (m LoadBalancerDetailModel) View() string {
 		return m.spinner.View()
 	}
 	if m.err != nil {
-		return fmt.Sprintf("Error: %s", m.err)
+		return i18n.T("Error: %s", m.err)
 	}
 	if m.inspectView != "" {
-		return fmt.Sprintf("%s\n %3.f%% | [j/k] scroll  [esc] close", m.inspectViewport.View(), m.inspectViewport.ScrollPercent()*100)
+		return i18n.T("%s\n %3.f%% | [j/k] scroll  [esc] close", m.inspectViewport.View(), m.inspectViewport.ScrollPercent()*100)
 	}
 	// Show the active table with a hint.
 	var tableView string
@@ -253,7 +281,7 @@ func (m LoadBalancerDetailModel) View() string {
 		tableView = m.poolsTable.View()
 	}
 	// Hint line.
-	hint := "[tab] switch  [i] inspect  [g] graph  [esc] back"
+	hint := i18n.T("[tab] switch  [i] inspect  [g] graph  [esc] back")
 	return fmt.Sprintf("%s\n%s", tableView, hint)
 }
 
@@ -0,0 +1,130 @@
+package loadbalancer
+
+import (
+	"context"
+	"fmt"
+
+	"ostui/internal/client"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/iac"
+)
+
+// ExportSpec implements iac.Exportable for the load balancer view,
+// exporting the load balancer with its pools, members and listeners.
+func (m LoadBalancerDetailModel) ExportSpec() iac.Spec {
+	c, id, name := m.client, m.lbID, m.lbName
+	if name == "" {
+		name = id
+	}
+	return iac.Spec{
+		Title: i18n.T("load balancer %s", name),
+		File:  name,
+		Load: func() (iac.Code, error) {
+			ctx := context.Background()
+			lbs, err := c.ListLoadBalancers(ctx)
+			if err != nil {
+				return iac.Code{}, err
+			}
+			for _, lb := range lbs {
+				if lb.ID != id {
+					continue
+				}
+				lst, err := c.ListListeners(ctx, id)
+				if err != nil {
+					return iac.Code{}, err
+				}
+				pools, err := c.ListPools(ctx, id)
+				if err != nil {
+					return iac.Code{}, err
+				}
+				// Members come from the status tree, which not every
+				// deployment allows; the export goes on without them.
+				members, _ := c.ListMemberStatuses(ctx, id)
+				return exportLoadBalancer(lb, lst, pools, members), nil
+			}
+			return iac.Code{}, fmt.Errorf("load balancer %s not found", id)
+		},
+	}
+}
+
+// exportLoadBalancer renders a load balancer. Pools are created before the
+// listeners so that a listener can name its default pool; members have no
+// subnet in the status tree and are added on the VIP subnet.
+func exportLoadBalancer(lb client.LoadBalancer, lst []client.Listener, pools []client.Pool, members []client.MemberStatus) iac.Code {
+	names := iac.Namer{}
+	const lbType, poolType, memberType, listenerType = "openstack_lb_loadbalancer_v2", "openstack_lb_pool_v2", "openstack_lb_member_v2", "openstack_lb_listener_v2"
+	lbBlk := iac.Block{Type: lbType, Name: names.Name(lbType, lb.Name, "loadbalancer"), ID: lb.ID, Attrs: []iac.Attr{
+		{Name: "name", Value: lb.Name},
+		{Name: "description", Value: lb.Description},
+		{Name: "vip_subnet_id", Value: lb.VipSubnetID},
+		{Name: "vip_address", Value: lb.VipAddress},
+	}}
+	args := []string{"loadbalancer", "create", "--name", lb.Name, "--vip-subnet-id", lb.VipSubnetID}
+	if lb.Description != "" {
+		args = append(args, "--description", lb.Description)
+	}
+	code := iac.Code{Resources: []iac.Block{lbBlk}, Commands: []string{iac.Command(append(args, "--wait")...)}}
+	lbRef := iac.Ref(lbBlk.Address() + ".id")
+
+	poolRefs := map[string]iac.Ref{}
+	poolNames := map[string]string{}
+	for _, p := range pools {
+		blk := iac.Block{Type: poolType, Name: names.Name(poolType, p.Name, "pool"), ID: p.ID, Attrs: []iac.Attr{
+			{Name: "name", Value: p.Name},
+			{Name: "loadbalancer_id", Value: lbRef},
+			{Name: "protocol", Value: p.Protocol},
+			{Name: "lb_method", Value: p.LBAlgorithm},
+		}}
+		poolRefs[p.ID] = iac.Ref(blk.Address() + ".id")
+		poolNames[p.ID] = nameOr(p.Name, p.ID)
+		code.Resources = append(code.Resources, blk)
+		code.Commands = append(code.Commands, iac.Command("loadbalancer", "pool", "create", "--name", p.Name, "--loadbalancer", lb.Name, "--protocol", p.Protocol, "--lb-algorithm", p.LBAlgorithm, "--wait"))
+	}
+	for _, mb := range members {
+		ref, ok := poolRefs[mb.PoolID]
+		if !ok {
+			continue
+		}
+		blk := iac.Block{Type: memberType, Name: names.Name(memberType, mb.Name, "member"), ID: mb.PoolID + "/" + mb.ID, Attrs: []iac.Attr{
+			{Name: "name", Value: mb.Name},
+			{Name: "pool_id", Value: ref},
+			{Name: "address", Value: mb.Address},
+			{Name: "protocol_port", Value: mb.ProtocolPort},
+			{Name: "subnet_id", Value: lb.VipSubnetID},
+		}}
+		code.Resources = append(code.Resources, blk)
+		code.Commands = append(code.Commands, iac.Command("loadbalancer", "member", "create", "--name", mb.Name, "--address", mb.Address, "--protocol-port", fmt.Sprint(mb.ProtocolPort), "--subnet-id", lb.VipSubnetID, "--wait", poolNames[mb.PoolID]))
+	}
+	for _, l := range lst {
+		blk := iac.Block{Type: listenerType, Name: names.Name(listenerType, l.Name, "listener"), ID: l.ID, Attrs: []iac.Attr{
+			{Name: "name", Value: l.Name},
+			{Name: "loadbalancer_id", Value: lbRef},
+			{Name: "protocol", Value: l.Protocol},
+			{Name: "protocol_port", Value: l.ProtocolPort},
+			{Name: "default_pool_id", Value: poolRefs[l.DefaultPoolID]},
+			{Name: "default_tls_container_ref", Value: l.DefaultTLSContainerRef},
+			{Name: "sni_container_refs", Value: l.SNIContainerRefs},
+		}}
+		args := []string{"loadbalancer", "listener", "create", "--name", l.Name, "--protocol", l.Protocol, "--protocol-port", fmt.Sprint(l.ProtocolPort)}
+		if p, ok := poolNames[l.DefaultPoolID]; ok {
+			args = append(args, "--default-pool", p)
+		}
+		if l.DefaultTLSContainerRef != "" {
+			args = append(args, "--default-tls-container-ref", l.DefaultTLSContainerRef)
+		}
+		for _, ref := range l.SNIContainerRefs {
+			args = append(args, "--sni-container-refs", ref)
+		}
+		code.Resources = append(code.Resources, blk)
+		code.Commands = append(code.Commands, iac.Command(append(args, "--wait", lb.Name)...))
+	}
+	return code
+}
+
+// nameOr returns name, or the ID of an unnamed resource.
+func nameOr(name, id string) string {
+	if name != "" {
+		return name
+	}
+	return id
+}
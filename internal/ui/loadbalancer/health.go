@@ -0,0 +1,79 @@
+package loadbalancer
+
+import (
+	"context"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"ostui/internal/client"
+	"ostui/internal/i18n"
+)
+
+// healthCol is the index of the member health column of the list.
+const healthCol = 5
+
+// healthPending is the health cell while the status trees load.
+const healthPending = "…"
+
+// loadBalancersHealthMsg carries the member health of each load balancer,
+// keyed by ID.
+type loadBalancersHealthMsg struct {
+	health map[string]string
+}
+
+// memberHealth rolls the operating status of the pool members up to one
+// cell, e.g. "3/4 members up". Members without a health monitor are
+// counted apart since octavia does not know whether they are up; the cell
+// is marked "!" when a monitored member is not ONLINE.
+func memberHealth(members []client.MemberStatus) string {
+	if len(members) == 0 {
+		return "no members"
+	}
+	up, unmonitored := 0, 0
+	for _, mb := range members {
+		switch mb.OperatingStatus {
+		case "ONLINE":
+			up++
+		case "NO_MONITOR":
+			unmonitored++
+		}
+	}
+	if unmonitored == len(members) {
+		return i18n.T("%d members, no monitor", len(members))
+	}
+	cell := i18n.T("%d/%d members up", up, len(members)-unmonitored)
+	if unmonitored > 0 {
+		cell += i18n.T(" +%d unmonitored", unmonitored)
+	}
+	if up < len(members)-unmonitored {
+		cell = "! " + cell
+	}
+	return cell
+}
+
+// healthCmd fetches the status tree of every load balancer in parallel and
+// rolls up its member health. A failed fetch shows as "?".
+func healthCmd(lc client.LoadBalancerClient, ids []string) tea.Cmd {
+	return func() tea.Msg {
+		cells := make([]string, len(ids))
+		var wg sync.WaitGroup
+		for i, id := range ids {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				members, err := lc.ListMemberStatuses(context.Background(), id)
+				if err != nil {
+					cells[i] = "?"
+					return
+				}
+				cells[i] = memberHealth(members)
+			}()
+		}
+		wg.Wait()
+		health := map[string]string{}
+		for i, id := range ids {
+			health[id] = cells[i]
+		}
+		return loadBalancersHealthMsg{health: health}
+	}
+}
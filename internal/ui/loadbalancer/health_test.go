@@ -0,0 +1,59 @@
+package loadbalancer
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"ostui/internal/client"
+	"ostui/internal/demo"
+)
+
+func TestMemberHealth(t *testing.T) {
+	mb := func(statuses ...string) []client.MemberStatus {
+		var out []client.MemberStatus
+		for _, s := range statuses {
+			out = append(out, client.MemberStatus{OperatingStatus: s})
+		}
+		return out
+	}
+	for _, tc := range []struct {
+		members []client.MemberStatus
+		want    string
+	}{
+		{nil, "no members"},
+		{mb("ONLINE", "ONLINE"), "2/2 members up"},
+		{mb("ONLINE", "ERROR", "ONLINE", "ONLINE"), "! 3/4 members up"},
+		{mb("NO_MONITOR", "NO_MONITOR"), "2 members, no monitor"},
+		{mb("ONLINE", "NO_MONITOR"), "1/1 members up +1 unmonitored"},
+		{mb("OFFLINE", "DRAINING"), "! 0/2 members up"},
+	} {
+		if got := memberHealth(tc.members); got != tc.want {
+			t.Errorf("memberHealth(%v) = %q, want %q", tc.members, got, tc.want)
+		}
+	}
+}
+
+func TestLoadBalancersHealthColumn(t *testing.T) {
+	c := demo.New(1, demo.DefaultSize)
+	var m tea.Model = NewLoadBalancersModel(c.LoadBalancer(), c.KeyManager())
+	m, cmd := m.Update(m.Init()())
+	if cmd == nil {
+		t.Fatal("expected the member health to be fetched after the list")
+	}
+	if got := m.(LoadBalancersModel).Table().Rows()[0][healthCol]; got != healthPending {
+		t.Fatalf("expected a pending health cell, got %q", got)
+	}
+	m, _ = m.Update(cmd())
+	var degraded string
+	for _, r := range m.(LoadBalancersModel).Table().Rows() {
+		if r[healthCol] == healthPending {
+			t.Fatalf("health of %s not filled in", r[1])
+		}
+		if r[1] == "staging-web" {
+			degraded = r[healthCol]
+		}
+	}
+	if degraded != "! 3/4 members up" {
+		t.Errorf("expected the failing backend of staging-web to show, got %q", degraded)
+	}
+}
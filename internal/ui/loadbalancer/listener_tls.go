@@ -0,0 +1,115 @@
+package loadbalancer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"ostui/internal/client"
+	"ostui/internal/i18n"
+)
+
+// protocolTerminatedHTTPS is the listener protocol that terminates TLS with
+// a certificate stored in Barbican.
+const protocolTerminatedHTTPS = "TERMINATED_HTTPS"
+
+// certWarnDays flags listener certificates that expire within this many
+// days.
+const certWarnDays = 30
+
+// listenerCert is the resolved default certificate of a listener.
+type listenerCert struct {
+	info client.CertInfo
+	err  error
+}
+
+// resolveListenerCerts resolves the default certificate of the
+// TERMINATED_HTTPS listeners through Barbican, keyed by listener ID. Without
+// a key manager no certificate is resolved and only the references show.
+func resolveListenerCerts(ctx context.Context, km client.KeyManagerClient, lst []client.Listener) map[string]listenerCert {
+	certs := map[string]listenerCert{}
+	if km == nil {
+		return certs
+	}
+	var conts []client.SecretContainer
+	var contErr error
+	listed := false
+	for _, l := range lst {
+		ref := l.DefaultTLSContainerRef
+		if l.Protocol != protocolTerminatedHTTPS || ref == "" {
+			continue
+		}
+		if strings.Contains(ref, "/containers/") {
+			if !listed {
+				conts, contErr = km.ListSecretContainers(ctx)
+				listed = true
+			}
+			if contErr != nil {
+				certs[l.ID] = listenerCert{err: fmt.Errorf("barbican: %w", contErr)}
+				continue
+			}
+		}
+		info, err := client.ResolveTLSCert(ctx, km, ref, conts)
+		certs[l.ID] = listenerCert{info: info, err: err}
+	}
+	return certs
+}
+
+// certCells renders the certificate columns of a listener: the common name
+// and the expiry, marked "!" within certWarnDays and EXPIRED after it.
+func certCells(l client.Listener, cert listenerCert, resolved bool, now time.Time) (cn, expires string) {
+	switch {
+	case l.Protocol != protocolTerminatedHTTPS:
+		return "", ""
+	case l.DefaultTLSContainerRef == "":
+		return "no certificate", ""
+	case !resolved:
+		return "–", "–"
+	case cert.err != nil:
+		return "?", "?"
+	}
+	cn = cert.info.CommonName
+	if cn == "" && len(cert.info.SANs) > 0 {
+		cn = cert.info.SANs[0]
+	}
+	left := cert.info.NotAfter.Sub(now)
+	date := cert.info.NotAfter.Format("2006-01-02")
+	switch {
+	case left <= 0:
+		return cn, "EXPIRED " + date
+	case left < certWarnDays*24*time.Hour:
+		return cn, fmt.Sprintf("! %s (%dd)", date, int(left.Hours()/24))
+	}
+	return cn, date
+}
+
+// tlsInspect renders the TLS section of the listener inspect view.
+func tlsInspect(l client.Listener, cert listenerCert, resolved bool, now time.Time) string {
+	if l.Protocol != protocolTerminatedHTTPS {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString("\n\n--- TLS ---\n")
+	ref := l.DefaultTLSContainerRef
+	if ref == "" {
+		ref = "none"
+	}
+	b.WriteString(i18n.T("Default certificate: %s", ref) + "\n")
+	for _, sni := range l.SNIContainerRefs {
+		b.WriteString(i18n.T("SNI certificate: %s", sni) + "\n")
+	}
+	switch {
+	case l.DefaultTLSContainerRef == "":
+	case !resolved:
+		b.WriteString(i18n.T("Certificate details: key manager unavailable") + "\n")
+	case cert.err != nil:
+		b.WriteString(i18n.T("Certificate details: %s", cert.err) + "\n")
+	default:
+		_, expires := certCells(l, cert, resolved, now)
+		b.WriteString(i18n.T("Common name: %s", cert.info.CommonName) + "\n")
+		b.WriteString(i18n.T("SANs: %s", strings.Join(cert.info.SANs, ", ")) + "\n")
+		b.WriteString(i18n.T("Expires: %s", expires) + "\n")
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
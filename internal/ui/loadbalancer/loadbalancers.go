@@ -8,6 +8,9 @@ import (
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"ostui/internal/client"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/common"
+	"ostui/internal/ui/theme"
 	"ostui/internal/ui/uiconst"
 	"strings"
 )
@@ -19,6 +22,7 @@ type LoadBalancersModel struct {
 	err         error
 	spinner     spinner.Model
 	client      client.LoadBalancerClient
+	keys        client.KeyManagerClient
 	width       int
 	height      int
 	allRows     []table.Row
@@ -30,18 +34,20 @@ type LoadBalancersModel struct {
 	detailModel tea.Model
 }
 
-// NewLoadBalancersModel creates a new LoadBalancersModel with the given client.
-func NewLoadBalancersModel(lc client.LoadBalancerClient) LoadBalancersModel {
+// NewLoadBalancersModel creates a new LoadBalancersModel with the given
+// clients; km resolves listener certificates in the detail view.
+func NewLoadBalancersModel(lc client.LoadBalancerClient, km client.KeyManagerClient) LoadBalancersModel {
 	s := spinner.New()
 	s.Spinner = spinner.Dot
 	ti := textinput.New()
 	ti.Placeholder = "filter..."
-	return LoadBalancersModel{client: lc, loading: true, spinner: s, filter: ti, mode: "list", width: 120, height: 30}
+	return LoadBalancersModel{client: lc, keys: km, loading: true, spinner: s, filter: ti, mode: "list", width: 120, height: 30}
 }
 
 type loadBalancersDataLoadedMsg struct {
 	tbl  table.Model
 	rows []table.Row
+	ids  []string
 	err  error
 }
 
@@ -52,22 +58,33 @@ func (m LoadBalancersModel) Init() tea.Cmd {
 		if err != nil {
 			return loadBalancersDataLoadedMsg{err: err}
 		}
-		cols := []table.Column{{Title: "ID", Width: uiconst.ColWidthUUID}, {Title: "Name", Width: uiconst.ColWidthNameLong}, {Title: "VIP Address", Width: uiconst.ColWidthVIPAddress}, {Title: "Provisioning", Width: uiconst.ColWidthProvisioning}, {Title: "Operating", Width: uiconst.ColWidthOperating}}
+		cols := []table.Column{{Title: "ID", Width: uiconst.ColWidthUUID}, {Title: i18n.T("Name"), Width: uiconst.ColWidthNameLong}, {Title: i18n.T("VIP Address"), Width: uiconst.ColWidthVIPAddress}, {Title: i18n.T("Provisioning"), Width: uiconst.ColWidthProvisioning}, {Title: i18n.T("Operating"), Width: uiconst.ColWidthOperating}, {Title: i18n.T("Health"), Width: healthColWidth}}
 		rows := []table.Row{}
+		var ids []string
 		for _, lb := range lbs {
-			rows = append(rows, table.Row{lb.ID, lb.Name, lb.VipAddress, lb.ProvisioningStatus, lb.OperatingStatus})
+			rows = append(rows, table.Row{lb.ID, lb.Name, lb.VipAddress, theme.Mark(lb.ProvisioningStatus), theme.Mark(lb.OperatingStatus), healthPending})
+			ids = append(ids, lb.ID)
 		}
+		rows = common.TerraformRows(rows)
 		t := table.New(
-			table.WithColumns(cols),
+			table.WithColumns(common.TerraformColumns(cols)),
 			table.WithRows(rows),
 			table.WithFocused(true),
 			table.WithHeight(m.height-uiconst.TableHeightOffset),
 		)
 		t.SetStyles(table.DefaultStyles())
-		return loadBalancersDataLoadedMsg{tbl: t, rows: rows}
+		return loadBalancersDataLoadedMsg{tbl: t, rows: rows, ids: ids}
 	}
 }
 
+// Reload re-fetches the load balancers, keeping the selected row.
+func (m LoadBalancersModel) Reload() tea.Cmd {
+	if m.loading || m.filterMode {
+		return nil
+	}
+	return m.Init()
+}
+
 // Update processes messages and user input.
 func (m LoadBalancersModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
@@ -77,10 +94,21 @@ func (m LoadBalancersModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.err = msg.err
 			return m, nil
 		}
-		m.table = msg.tbl
+		m.table = common.Reloaded(m.table, msg.tbl)
 		m.updateTableColumns()
 		m.table.SetHeight(m.height - uiconst.TableHeightOffset)
 		m.allRows = msg.rows
+		if len(msg.ids) == 0 {
+			return m, nil
+		}
+		return m, healthCmd(m.client, msg.ids)
+	case loadBalancersHealthMsg:
+		for _, r := range m.allRows {
+			if h, ok := msg.health[r[0]]; ok {
+				r[healthCol] = h
+			}
+		}
+		m.applyFilter()
 		return m, nil
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
@@ -126,22 +154,7 @@ func (m LoadBalancersModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.filterMode {
 			var cmd tea.Cmd
 			m.filter, cmd = m.filter.Update(msg)
-			filterVal := m.filter.Value()
-			if filterVal == "" {
-				m.table.SetRows(m.allRows)
-			} else {
-				lower := strings.ToLower(filterVal)
-				filtered := []table.Row{}
-				for _, r := range m.allRows {
-					for _, c := range r {
-						if strings.Contains(strings.ToLower(c), lower) {
-							filtered = append(filtered, r)
-							break
-						}
-					}
-				}
-				m.table.SetRows(filtered)
-			}
+			m.applyFilter()
 			return m, cmd
 		}
 		// Normal navigation.
@@ -151,7 +164,7 @@ func (m LoadBalancersModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.lbID = row[0]
 				m.lbName = row[1]
 				m.mode = "detail"
-				m.detailModel = NewLoadBalancerDetailModel(m.client, m.lbID, m.lbName)
+				m.detailModel = NewLoadBalancerDetailModel(m.client, m.keys, m.lbID, m.lbName)
 				return m, m.detailModel.Init()
 			}
 			return m, nil
@@ -175,13 +188,13 @@ func (m LoadBalancersModel) View() string {
 		return m.spinner.View()
 	}
 	if m.err != nil {
-		return fmt.Sprintf("Error: %s", m.err)
+		return i18n.T("Error: %s", m.err)
 	}
 	if m.mode == "detail" && m.detailModel != nil {
 		return m.detailModel.View()
 	}
 	if m.filterMode {
-		filterLine := fmt.Sprintf("Filter: %s", m.filter.View())
+		filterLine := i18n.T("Filter: %s", m.filter.View())
 		footer := "esc: clear"
 		return fmt.Sprintf("%s\n%s\n%s", filterLine, m.table.View(), footer)
 	}
@@ -191,16 +204,42 @@ func (m LoadBalancersModel) View() string {
 // Table returns the primary table model (list view).
 func (m LoadBalancersModel) Table() table.Model { return m.table }
 
+// Filtering reports whether keys are typed into the filter.
+func (m LoadBalancersModel) Filtering() bool { return m.filterMode }
+
+// applyFilter shows the rows matching the filter, or all rows without one.
+func (m *LoadBalancersModel) applyFilter() {
+	filterVal := m.filter.Value()
+	if filterVal == "" {
+		m.table.SetRows(m.allRows)
+		return
+	}
+	lower := strings.ToLower(filterVal)
+	filtered := []table.Row{}
+	for _, r := range m.allRows {
+		for _, c := range r {
+			if strings.Contains(strings.ToLower(c), lower) {
+				filtered = append(filtered, r)
+				break
+			}
+		}
+	}
+	m.table.SetRows(filtered)
+}
+
+// healthColWidth fits a health cell such as "! 3/4 members up +1 unmonitored".
+const healthColWidth = 32
+
 func (m *LoadBalancersModel) updateTableColumns() {
 	idW := uiconst.ColWidthUUID
 	vipW := uiconst.ColWidthVIPAddress
 	provW := uiconst.ColWidthProvisioning
 	operW := uiconst.ColWidthOperating
-	nameW := m.width - idW - vipW - provW - operW - 6
+	nameW := m.width - idW - vipW - provW - operW - healthColWidth - 7 - common.TerraformWidth()
 	if nameW < 10 {
 		nameW = 10
 	}
-	m.table.SetColumns([]table.Column{{Title: "ID", Width: idW}, {Title: "Name", Width: nameW}, {Title: "VIP Address", Width: vipW}, {Title: "Provisioning", Width: provW}, {Title: "Operating", Width: operW}})
+	m.table.SetColumns(common.TerraformColumns([]table.Column{{Title: "ID", Width: idW}, {Title: i18n.T("Name"), Width: nameW}, {Title: i18n.T("VIP Address"), Width: vipW}, {Title: i18n.T("Provisioning"), Width: provW}, {Title: i18n.T("Operating"), Width: operW}, {Title: i18n.T("Health"), Width: healthColWidth}}))
 }
 
 var _ tea.Model = (*LoadBalancersModel)(nil)
@@ -0,0 +1,165 @@
+package macro
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"ostui/internal/config"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/theme"
+	"ostui/internal/ui/uiconst"
+)
+
+// MacrosModel lists the stored macros and deletes them.
+type MacrosModel struct {
+	table   table.Model
+	path    string
+	macros  []config.Macro
+	loading bool
+	err     error
+	// confirm is set while a delete waits for y.
+	confirm bool
+	status  string
+
+	width  int
+	height int
+}
+
+// NewMacrosModel lists the macros stored at path.
+func NewMacrosModel(path string) MacrosModel {
+	return MacrosModel{path: path, loading: true, width: 120, height: 30}
+}
+
+type macrosLoadedMsg struct {
+	macros []config.Macro
+	err    error
+}
+
+type macroDeletedMsg struct {
+	name string
+	err  error
+}
+
+// Init reads the macros file.
+func (m MacrosModel) Init() tea.Cmd { return m.loadCmd() }
+
+// Reload reads the macros file again; refreshTable keeps the cursor.
+func (m MacrosModel) Reload() tea.Cmd {
+	if m.loading || m.confirm {
+		return nil
+	}
+	return m.loadCmd()
+}
+
+func (m MacrosModel) loadCmd() tea.Cmd {
+	path := m.path
+	return func() tea.Msg {
+		list, err := config.LoadMacros(path)
+		return macrosLoadedMsg{macros: list, err: err}
+	}
+}
+
+// Update handles messages for the model.
+func (m MacrosModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case macrosLoadedMsg:
+		m.loading, m.err, m.macros = false, msg.err, msg.macros
+		m.refreshTable()
+		return m, nil
+	case macroDeletedMsg:
+		if msg.err != nil {
+			m.status = i18n.T("Delete failed: %s", msg.err)
+			return m, nil
+		}
+		m.status = i18n.T("Deleted macro %s", msg.name)
+		return m, m.loadCmd()
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.refreshTable()
+		return m, nil
+	case tea.KeyMsg:
+		if m.loading || m.err != nil {
+			return m, nil
+		}
+		if m.confirm {
+			m.confirm = false
+			if msg.String() != "y" {
+				m.status = ""
+				return m, nil
+			}
+			name, path := m.selected().Name, m.path
+			return m, func() tea.Msg { return macroDeletedMsg{name: name, err: config.DeleteMacro(path, name)} }
+		}
+		if msg.String() == "x" && len(m.macros) > 0 {
+			m.confirm = true
+			m.status = ""
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.table, cmd = m.table.Update(msg)
+		return m, cmd
+	}
+	return m, nil
+}
+
+// selected returns the highlighted macro.
+func (m MacrosModel) selected() config.Macro {
+	i := m.table.Cursor()
+	if i < 0 || i >= len(m.macros) {
+		return config.Macro{}
+	}
+	return m.macros[i]
+}
+
+func (m *MacrosModel) refreshTable() {
+	nameW, kindW := 24, 8
+	rest := m.width - nameW - kindW - uiconst.TableHeightOffset
+	if rest < 40 {
+		rest = 40
+	}
+	cols := []table.Column{{Title: i18n.T("Name"), Width: nameW}, {Title: i18n.T("Kind"), Width: kindW}, {Title: i18n.T("Steps"), Width: rest}}
+	rows := make([]table.Row, 0, len(m.macros))
+	for _, mac := range m.macros {
+		rows = append(rows, table.Row{mac.Name, mac.Kind, strings.Join(mac.Steps, " → ")})
+	}
+	cursor := m.table.Cursor()
+	m.table = table.New(table.WithColumns(cols), table.WithFocused(true))
+	m.table.SetStyles(table.DefaultStyles())
+	m.table.SetRows(rows)
+	m.table.SetHeight(m.height - uiconst.TableHeightOffset - 4)
+	if cursor < len(rows) {
+		m.table.SetCursor(cursor)
+	}
+}
+
+// View renders the list of macros.
+func (m MacrosModel) View() string {
+	out := lipgloss.NewStyle().Bold(true).Foreground(theme.Accent).Render("Macros in "+m.path) + "\n"
+	if m.loading {
+		return out + i18n.T("Loading…")
+	}
+	if m.err != nil {
+		return out + i18n.T("Error: %s", m.err) + "\n" + i18n.T("[esc] back")
+	}
+	if len(m.macros) == 0 {
+		return out + i18n.T("No macros yet. Select a server or volume and run :macro record <name>.\n[esc] back")
+	}
+	out += m.table.View() + "\n"
+	if m.confirm {
+		return out + i18n.T("Delete macro %s? [y/N]", m.selected().Name)
+	}
+	if m.status != "" {
+		out += m.status + "\n"
+	}
+	return out + i18n.T("Replay one with :macro <name> on a selected resource.\n[x] delete  [esc] back")
+}
+
+// CapturingInput reports whether a delete waits for confirmation.
+func (m MacrosModel) CapturingInput() bool { return m.confirm }
+
+// Table returns the macros table.
+func (m MacrosModel) Table() table.Model { return m.table }
+
+var _ tea.Model = (*MacrosModel)(nil)
@@ -0,0 +1,427 @@
+// Package macro records named sequences of server and volume actions into
+// the macros file and replays them against the selected resource.
+package macro
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"ostui/internal/config"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/common"
+	"ostui/internal/ui/policy"
+	"ostui/internal/ui/theme"
+	"ostui/internal/ui/uiconst"
+)
+
+// State of one step of a run.
+const (
+	statePending = "pending"
+	stateRunning = "running"
+	stateDone    = "done"
+	stateFailed  = "failed"
+	stateSkipped = "skipped"
+)
+
+// pollInterval is how often wait and snapshot steps check their resource.
+var pollInterval = 3 * time.Second
+
+// runStep is a step with its progress.
+type runStep struct {
+	step   Step
+	state  string
+	detail string
+}
+
+// MacroModel records a macro by running actions on a resource, or replays a
+// stored macro against one. A replay stops at the first failed step.
+type MacroModel struct {
+	table     table.Model
+	clients   Clients
+	path      string
+	name      string
+	target    Target
+	recording bool
+	loading   bool
+	err       error
+	steps     []runStep
+
+	// form asks for the argument of a verb being recorded.
+	form *common.FormModel
+	// pendingVerb is the verb the form is for.
+	pendingVerb verb
+	// running is set while a step is in flight; current is its index.
+	running   bool
+	current   int
+	check     checkFunc
+	status    string
+	statusErr bool
+
+	width  int
+	height int
+}
+
+// NewRecordModel starts recording the macro called name on target. Every
+// action picked from the menu runs at once and is added on success.
+func NewRecordModel(cs Clients, path, name string, target Target) MacroModel {
+	m := MacroModel{clients: cs, path: path, name: name, target: target, recording: true, current: -1, width: 120, height: 30}
+	m.refreshTable()
+	return m
+}
+
+// NewReplayModel replays the stored macro called name against target.
+func NewReplayModel(cs Clients, path, name string, target Target) MacroModel {
+	return MacroModel{clients: cs, path: path, name: name, target: target, loading: true, current: -1, width: 120, height: 30}
+}
+
+type macroLoadedMsg struct {
+	steps []runStep
+	err   error
+}
+
+// stepMsg reports progress of the step at index. check is set when the step
+// was sent and finishes later; done is false while it is still in progress.
+type stepMsg struct {
+	index  int
+	check  checkFunc
+	done   bool
+	detail string
+	err    error
+}
+
+type macroSavedMsg struct {
+	err error
+}
+
+// Init loads the macro of a replay.
+func (m MacroModel) Init() tea.Cmd {
+	if m.recording {
+		return nil
+	}
+	path, name, kind := m.path, m.name, m.target.Kind
+	return func() tea.Msg {
+		list, err := config.LoadMacros(path)
+		if err != nil {
+			return macroLoadedMsg{err: err}
+		}
+		for _, mac := range list {
+			if mac.Name != name {
+				continue
+			}
+			if mac.Kind != kind {
+				return macroLoadedMsg{err: fmt.Errorf("macro %s runs on a %s, not a %s", name, mac.Kind, kind)}
+			}
+			var steps []runStep
+			for i, line := range mac.Steps {
+				s, err := ParseStep(kind, line)
+				if err != nil {
+					return macroLoadedMsg{err: fmt.Errorf("step %d: %w", i+1, err)}
+				}
+				steps = append(steps, runStep{step: s, state: statePending})
+			}
+			if len(steps) == 0 {
+				return macroLoadedMsg{err: fmt.Errorf("macro %s has no steps", name)}
+			}
+			return macroLoadedMsg{steps: steps}
+		}
+		return macroLoadedMsg{err: fmt.Errorf("no macro named %q in %s", name, path)}
+	}
+}
+
+// Update handles messages for the model.
+func (m MacroModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case macroLoadedMsg:
+		m.loading = false
+		m.err = msg.err
+		m.steps = msg.steps
+		m.refreshTable()
+		return m, nil
+	case stepMsg:
+		if !m.running || msg.index != m.current {
+			return m, nil
+		}
+		return m.stepProgress(msg)
+	case macroSavedMsg:
+		if msg.err != nil {
+			m.status, m.statusErr = i18n.T("Save failed: %s", msg.err), true
+			return m, nil
+		}
+		m.status, m.statusErr = i18n.T("Saved macro %s with %d steps; replay it with :macro %s", m.name, len(m.steps), m.name), false
+		return m, nil
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.refreshTable()
+		return m, nil
+	case tea.KeyMsg:
+		if m.form != nil {
+			return m.updateForm(msg)
+		}
+		if m.loading || m.running || m.err != nil {
+			return m, nil
+		}
+		if m.recording {
+			return m.updateRecording(msg)
+		}
+		if msg.String() == "y" {
+			if m.steps[0].state != statePending {
+				return m, nil
+			}
+			if err := policy.Check(policy.Member, "running macros"); err != nil {
+				m.status, m.statusErr = err.Error(), true
+				return m, nil
+			}
+			return m.start(0)
+		}
+		var cmd tea.Cmd
+		m.table, cmd = m.table.Update(msg)
+		return m, cmd
+	}
+	return m, nil
+}
+
+// updateRecording handles the keys of the recording menu.
+func (m MacroModel) updateRecording(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		list := verbs[m.target.Kind]
+		i := m.table.Cursor()
+		if i < 0 || i >= len(list) {
+			return m, nil
+		}
+		if err := policy.Check(policy.Member, "running macros"); err != nil {
+			m.status, m.statusErr = err.Error(), true
+			return m, nil
+		}
+		v := list[i]
+		if v.arg == argNone {
+			return m.record(Step{Verb: v.name})
+		}
+		label := "Status [timeout]"
+		if v.arg == argName {
+			label = "Snapshot name (empty for {name}-{date})"
+		}
+		f := common.NewForm([]string{label})
+		m.form, m.pendingVerb = &f, v
+		return m, f.Init()
+	case "u":
+		if len(m.steps) > 0 {
+			last := m.steps[len(m.steps)-1]
+			m.steps = m.steps[:len(m.steps)-1]
+			m.status, m.statusErr = i18n.T("Removed %s from the macro; the action itself is not undone", last.step), false
+		}
+		return m, nil
+	case "s":
+		if len(m.steps) == 0 {
+			m.status, m.statusErr = i18n.T("Nothing recorded yet"), true
+			return m, nil
+		}
+		mac := config.Macro{Name: m.name, Kind: m.target.Kind}
+		for _, st := range m.steps {
+			mac.Steps = append(mac.Steps, st.step.String())
+		}
+		path := m.path
+		return m, func() tea.Msg { return macroSavedMsg{err: config.SaveMacro(path, mac)} }
+	}
+	var cmd tea.Cmd
+	m.table, cmd = m.table.Update(msg)
+	return m, cmd
+}
+
+// updateForm handles keys for the argument form of a recorded verb.
+func (m MacroModel) updateForm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	fm, cmd := m.form.Update(msg)
+	f := fm.(common.FormModel)
+	m.form = &f
+	switch {
+	case f.Cancelled():
+		m.form = nil
+	case f.Submitted():
+		arg := f.Values()[0]
+		if m.pendingVerb.arg == argStatus && arg == "" {
+			m.form.SetError(errors.New("the status to wait for is required"))
+			return m, nil
+		}
+		s, err := ParseStep(m.target.Kind, m.pendingVerb.name+" "+arg)
+		if err != nil {
+			m.form.SetError(err)
+			return m, nil
+		}
+		m.form = nil
+		return m.record(s)
+	}
+	return m, cmd
+}
+
+// record runs s on the target as the next step of the recording.
+func (m MacroModel) record(s Step) (tea.Model, tea.Cmd) {
+	m.steps = append(m.steps, runStep{step: s, state: statePending})
+	return m.start(len(m.steps) - 1)
+}
+
+// start sends the step at i.
+func (m MacroModel) start(i int) (tea.Model, tea.Cmd) {
+	m.running, m.current, m.check, m.status, m.statusErr = true, i, nil, "", false
+	m.steps[i].state = stateRunning
+	m.refreshTable()
+	cs, t, s := m.clients, m.target, m.steps[i].step
+	return m, func() tea.Msg {
+		check, detail, err := run(cs, t, s, time.Now())
+		if err != nil {
+			return stepMsg{index: i, err: err}
+		}
+		return stepMsg{index: i, check: check, done: check == nil, detail: detail}
+	}
+}
+
+// stepProgress records the outcome of a step, polls it again, or moves on.
+func (m MacroModel) stepProgress(msg stepMsg) (tea.Model, tea.Cmd) {
+	st := &m.steps[msg.index]
+	if msg.detail != "" {
+		st.detail = msg.detail
+	}
+	if msg.check != nil {
+		m.check = msg.check
+	}
+	switch {
+	case msg.err != nil:
+		policy.Record("running macros", msg.err)
+		m.running, m.check = false, nil
+		if m.recording {
+			// A failed action is not added to the macro.
+			m.steps = m.steps[:msg.index]
+			m.status, m.statusErr = i18n.T("%s failed, not recorded: %s", st.step, msg.err), true
+			m.refreshTable()
+			return m, nil
+		}
+		st.state, st.detail = stateFailed, msg.err.Error()
+		for i := msg.index + 1; i < len(m.steps); i++ {
+			m.steps[i].state = stateSkipped
+		}
+		m.status, m.statusErr = i18n.T("Stopped at step %d of %d", msg.index+1, len(m.steps)), true
+		m.refreshTable()
+		return m, nil
+	case !msg.done:
+		i, check := msg.index, m.check
+		return m, tea.Tick(pollInterval, func(time.Time) tea.Msg {
+			done, err := check()
+			return stepMsg{index: i, done: done, err: err}
+		})
+	}
+	st.state = stateDone
+	m.running, m.check = false, nil
+	if !m.recording && msg.index+1 < len(m.steps) {
+		return m.start(msg.index + 1)
+	}
+	if !m.recording {
+		m.status = i18n.T("Macro %s finished on %s", m.name, m.target.Label())
+	}
+	m.refreshTable()
+	return m, nil
+}
+
+// refreshTable rebuilds the verb menu of a recording or the steps of a replay.
+func (m *MacroModel) refreshTable() {
+	rest := m.width - uiconst.TableHeightOffset
+	if rest < 60 {
+		rest = 60
+	}
+	var cols []table.Column
+	var rows []table.Row
+	height := m.height - uiconst.TableHeightOffset - 6
+	if m.recording {
+		verbW := 12
+		cols = []table.Column{{Title: i18n.T("Action"), Width: verbW}, {Title: i18n.T("Description"), Width: rest - verbW}}
+		for _, v := range verbs[m.target.Kind] {
+			rows = append(rows, table.Row{v.name, v.help})
+		}
+		height -= len(m.steps) + 2
+	} else {
+		numW, stepW := 4, 24
+		cols = []table.Column{{Title: "#", Width: numW}, {Title: i18n.T("Step"), Width: stepW}, {Title: i18n.T("State"), Width: rest - numW - stepW}}
+		for i, st := range m.steps {
+			state := st.state
+			if st.detail != "" {
+				state += ": " + st.detail
+			}
+			rows = append(rows, table.Row{strconv.Itoa(i + 1), st.step.String(), state})
+		}
+	}
+	cursor := m.table.Cursor()
+	m.table = table.New(table.WithColumns(cols), table.WithFocused(true))
+	m.table.SetStyles(table.DefaultStyles())
+	m.table.SetRows(rows)
+	m.table.SetHeight(height)
+	if cursor < len(rows) {
+		m.table.SetCursor(cursor)
+	}
+}
+
+// View renders the menu and the recorded steps, or the replay's progress.
+func (m MacroModel) View() string {
+	title := lipgloss.NewStyle().Bold(true).Foreground(theme.Accent).Render
+	warn := lipgloss.NewStyle().Foreground(theme.Error)
+	ok := lipgloss.NewStyle().Foreground(theme.OK)
+	verb := "Replaying"
+	if m.recording {
+		verb = "Recording"
+	}
+	out := title(i18n.T("%s macro %s on %s", verb, m.name, m.target.Label())) + "\n"
+	if m.form != nil {
+		return out + "\n" + m.pendingVerb.help + "\n\n" + m.form.View() + "\n" + i18n.T("[enter] run and record  [esc] cancel")
+	}
+	if m.loading {
+		return out + i18n.T("Loading…")
+	}
+	if m.err != nil {
+		return out + warn.Render(i18n.T("Error: %s", m.err)) + "\n" + i18n.T("[esc] back")
+	}
+	out += m.table.View() + "\n"
+	if m.recording {
+		out += "\nRecorded:"
+		if len(m.steps) == 0 {
+			out += " nothing yet"
+		}
+		out += "\n"
+		for i, st := range m.steps {
+			line := fmt.Sprintf("  %d. %s", i+1, st.step)
+			if st.state == stateRunning {
+				line += " …"
+			} else if st.detail != "" {
+				line += " (" + st.detail + ")"
+			}
+			out += line + "\n"
+		}
+	}
+	switch {
+	case m.running:
+		out += i18n.T("Running %s…", m.steps[m.current].step) + "\n"
+	case m.statusErr:
+		out += warn.Render(m.status) + "\n"
+	case m.status != "":
+		out += ok.Render(m.status) + "\n"
+	}
+	if m.recording {
+		return out + policy.Key(policy.Member, i18n.T("[enter] run and record"), "running macros") + "  " + i18n.T("[u] drop last step  [s] save  [esc] back")
+	}
+	if !m.running && m.steps[0].state == statePending {
+		return out + i18n.T("Run %d steps on %s?", len(m.steps), m.target.Label()) + " " + policy.Key(policy.Member, i18n.T("[y] run"), "running macros") + "  " + i18n.T("[esc] back")
+	}
+	return out + i18n.T("[esc] back")
+}
+
+// CapturingInput reports whether the argument form is open or a step runs.
+func (m MacroModel) CapturingInput() bool { return m.form != nil || m.running }
+
+// Recording reports whether the model records a macro rather than replays one.
+func (m MacroModel) Recording() bool { return m.recording }
+
+// Table returns the menu or the steps table.
+func (m MacroModel) Table() table.Model { return m.table }
+
+var _ tea.Model = (*MacroModel)(nil)
@@ -0,0 +1,196 @@
+package macro
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/gophercloud/gophercloud/openstack/blockstorage/v3/snapshots"
+	"github.com/gophercloud/gophercloud/openstack/blockstorage/v3/volumes"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
+	"ostui/internal/client"
+	"ostui/internal/config"
+)
+
+func TestParseStep(t *testing.T) {
+	for _, tc := range []struct {
+		kind, line string
+		want       Step
+		err        string
+	}{
+		{KindServer, "stop", Step{Verb: "stop"}, ""},
+		{KindServer, "wait  SHUTOFF   2m", Step{Verb: "wait", Arg: "SHUTOFF 2m"}, ""},
+		{KindVolume, "snapshot {name}-nightly", Step{Verb: "snapshot", Arg: "{name}-nightly"}, ""},
+		{KindVolume, "snapshot", Step{Verb: "snapshot"}, ""},
+		{KindServer, "stop now", Step{}, "stop takes no argument"},
+		{KindServer, "wait", Step{}, "usage: wait"},
+		{KindVolume, "wait available soon", Step{}, `invalid timeout "soon"`},
+		{KindVolume, "reboot", Step{}, `unknown volume step "reboot": use snapshot, detach, wait`},
+		{"port", "wait up", Step{}, "macros cannot run on a port"},
+	} {
+		got, err := ParseStep(tc.kind, tc.line)
+		if tc.err != "" {
+			if err == nil || !strings.HasPrefix(err.Error(), tc.err) {
+				t.Errorf("ParseStep(%q, %q) error = %v, want %q", tc.kind, tc.line, err, tc.err)
+			}
+			continue
+		}
+		if err != nil || got != tc.want {
+			t.Errorf("ParseStep(%q, %q) = %+v, %v, want %+v", tc.kind, tc.line, got, err, tc.want)
+		}
+	}
+}
+
+// mockStorage and mockCompute implement the calls made by the volume steps;
+// the embedded interfaces panic on anything else.
+type mockStorage struct {
+	client.StorageClient
+	volume   volumes.Volume
+	snapshot snapshots.Snapshot
+	calls    []string
+}
+
+func (m *mockStorage) GetVolume(id string) (volumes.Volume, error) {
+	return m.volume, nil
+}
+
+func (m *mockStorage) CreateSnapshot(opts snapshots.CreateOptsBuilder) (snapshots.Snapshot, error) {
+	o := opts.(snapshots.CreateOpts)
+	m.calls = append(m.calls, "snapshot "+o.Name)
+	m.snapshot = snapshots.Snapshot{ID: "snap-1", Name: o.Name, Status: "creating"}
+	return m.snapshot, nil
+}
+
+// ListSnapshots reports the snapshot available on the second poll.
+func (m *mockStorage) ListSnapshots() ([]snapshots.Snapshot, error) {
+	s := m.snapshot
+	m.snapshot.Status = "available"
+	return []snapshots.Snapshot{s}, nil
+}
+
+type mockCompute struct {
+	client.ComputeClient
+	storage *mockStorage
+	fail    error
+}
+
+func (m *mockCompute) DetachVolume(ctx context.Context, serverID, volumeID string) error {
+	if m.fail != nil {
+		return m.fail
+	}
+	m.storage.calls = append(m.storage.calls, "detach "+serverID)
+	m.storage.volume.Status, m.storage.volume.Attachments = "available", nil
+	return nil
+}
+
+func (m *mockCompute) StopInstance(id string) error { return nil }
+func (m *mockCompute) GetInstance(id string) (servers.Server, error) {
+	return servers.Server{ID: id, Status: "SHUTOFF"}, nil
+}
+
+// drive sends the command of a key and the commands that follow it.
+func drive(t *testing.T, m MacroModel, cmd tea.Cmd) MacroModel {
+	t.Helper()
+	for cmd != nil {
+		updated, next := m.Update(cmd())
+		m, cmd = updated.(MacroModel), next
+	}
+	return m
+}
+
+func press(t *testing.T, m MacroModel, key string) MacroModel {
+	t.Helper()
+	msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+	if key == "enter" {
+		msg = tea.KeyMsg{Type: tea.KeyEnter}
+	}
+	updated, cmd := m.Update(msg)
+	return drive(t, updated.(MacroModel), cmd)
+}
+
+// replay loads the macro called name for a replay on target.
+func replay(t *testing.T, cs Clients, path, name string, target Target) MacroModel {
+	t.Helper()
+	m := NewReplayModel(cs, path, name, target)
+	return drive(t, m, m.Init())
+}
+
+func testClients() (Clients, *mockStorage, *mockCompute) {
+	sc := &mockStorage{volume: volumes.Volume{ID: "vol-1", Name: "data", Status: "in-use", Attachments: []volumes.Attachment{{ServerID: "srv-1"}}}}
+	cc := &mockCompute{storage: sc}
+	return Clients{Compute: cc, Storage: sc}, sc, cc
+}
+
+func TestReplay(t *testing.T) {
+	pollInterval = time.Millisecond
+	path := filepath.Join(t.TempDir(), "macros.yaml")
+	if err := config.SaveMacro(path, config.Macro{Name: "release", Kind: KindVolume, Steps: []string{"snapshot {name}-pre", "detach", "wait available"}}); err != nil {
+		t.Fatal(err)
+	}
+	target := Target{Kind: KindVolume, ID: "vol-1", Name: "data"}
+
+	cs, sc, _ := testClients()
+	m := replay(t, cs, path, "release", target)
+	if m.err != nil || len(m.steps) != 3 || !strings.Contains(m.View(), "Run 3 steps on volume data?") {
+		t.Fatalf("unexpected load: err %v, steps %+v", m.err, m.steps)
+	}
+	m = press(t, m, "y")
+	if got := strings.Join(sc.calls, ","); got != "snapshot data-pre,detach srv-1" {
+		t.Errorf("calls %s", got)
+	}
+	for i, st := range m.steps {
+		if st.state != stateDone {
+			t.Errorf("step %d is %s", i+1, st.state)
+		}
+	}
+	if m.steps[0].detail != "snapshot snap-1" || m.status != "Macro release finished on volume data" {
+		t.Errorf("unexpected run: %+v, status %q", m.steps, m.status)
+	}
+
+	// A failed step skips the rest.
+	cs, _, cc := testClients()
+	cc.fail = errors.New("volume is busy")
+	m = replay(t, cs, path, "release", target)
+	m = press(t, m, "y")
+	if m.steps[0].state != stateDone || m.steps[1].state != stateFailed || m.steps[1].detail != "volume is busy" || m.steps[2].state != stateSkipped {
+		t.Fatalf("unexpected failed run: %+v", m.steps)
+	}
+
+	// Macros only replay on their kind.
+	m = replay(t, cs, path, "release", Target{Kind: KindServer, ID: "srv-1"})
+	if m.err == nil || !strings.Contains(m.err.Error(), "runs on a volume, not a server") {
+		t.Errorf("expected a kind error, got %v", m.err)
+	}
+}
+
+func TestRecord(t *testing.T) {
+	pollInterval = time.Millisecond
+	path := filepath.Join(t.TempDir(), "macros.yaml")
+	cs, _, _ := testClients()
+	m := NewRecordModel(cs, path, "halt", Target{Kind: KindServer, ID: "srv-1", Name: "web"})
+
+	// stop is the second action of the menu, wait the last one.
+	m = press(t, m, "j")
+	m = press(t, m, "enter")
+	for range verbs[KindServer] {
+		m = press(t, m, "j")
+	}
+	m = press(t, m, "enter")
+	if m.form == nil {
+		t.Fatal("expected the wait form")
+	}
+	m.form.SetValue(0, "SHUTOFF 1m")
+	m = press(t, m, "enter")
+	if m.form != nil || len(m.steps) != 2 || m.steps[1].state != stateDone {
+		t.Fatalf("unexpected recording: %+v", m.steps)
+	}
+	m = press(t, m, "s")
+	list, err := config.LoadMacros(path)
+	if err != nil || len(list) != 1 || list[0].Kind != KindServer || strings.Join(list[0].Steps, ",") != "stop,wait SHUTOFF 1m" {
+		t.Fatalf("saved %+v, %v", list, err)
+	}
+}
@@ -0,0 +1,259 @@
+package macro
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gophercloud/gophercloud/openstack/blockstorage/v3/snapshots"
+	"ostui/internal/client"
+)
+
+// Resource kinds a macro runs against.
+const (
+	KindServer = "server"
+	KindVolume = "volume"
+)
+
+// Target is the resource a macro is recorded on or replayed against.
+type Target struct {
+	Kind string
+	ID   string
+	Name string
+}
+
+// Label names the target for titles, e.g. "volume data-1".
+func (t Target) Label() string {
+	if t.Name != "" {
+		return t.Kind + " " + t.Name
+	}
+	return t.Kind + " " + t.ID
+}
+
+// Clients holds the clients macro steps call.
+type Clients struct {
+	Compute client.ComputeClient
+	Storage client.StorageClient
+}
+
+// Argument kinds of a verb.
+const (
+	argNone   = ""
+	argStatus = "status"
+	argName   = "name"
+)
+
+// verb is an action a macro step can take.
+type verb struct {
+	name string
+	arg  string
+	help string
+}
+
+// verbs lists the actions available for each kind, in menu order.
+var verbs = map[string][]verb{
+	KindServer: {
+		{"start", argNone, "Start the server"},
+		{"stop", argNone, "Stop the server"},
+		{"reboot", argNone, "Soft-reboot the server"},
+		{"pause", argNone, "Pause the server"},
+		{"unpause", argNone, "Unpause the server"},
+		{"suspend", argNone, "Suspend the server"},
+		{"resume", argNone, "Resume the server"},
+		{"shelve", argNone, "Shelve the server"},
+		{"unshelve", argNone, "Unshelve the server"},
+		{"lock", argNone, "Lock the server"},
+		{"unlock", argNone, "Unlock the server"},
+		{"wait", argStatus, "Wait for a status, e.g. SHUTOFF or ACTIVE 10m"},
+	},
+	KindVolume: {
+		{"snapshot", argName, "Snapshot the volume and wait until the snapshot is available; the name may use {name} and {date}"},
+		{"detach", argNone, "Detach the volume from every server"},
+		{"wait", argStatus, "Wait for a status, e.g. available or in-use 10m"},
+	},
+}
+
+// DefaultWaitTimeout bounds a wait step without an explicit timeout.
+const DefaultWaitTimeout = 10 * time.Minute
+
+// Step is one parsed macro step.
+type Step struct {
+	Verb string
+	Arg  string
+}
+
+// String renders the step the way it is stored.
+func (s Step) String() string {
+	if s.Arg == "" {
+		return s.Verb
+	}
+	return s.Verb + " " + s.Arg
+}
+
+// ParseStep reads a stored step such as "wait available 5m" for a kind.
+func ParseStep(kind, line string) (Step, error) {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return Step{}, errors.New("empty step")
+	}
+	for _, v := range verbs[kind] {
+		if v.name != fields[0] {
+			continue
+		}
+		s := Step{Verb: v.name, Arg: strings.Join(fields[1:], " ")}
+		switch v.arg {
+		case argNone:
+			if s.Arg != "" {
+				return Step{}, fmt.Errorf("%s takes no argument", v.name)
+			}
+		case argStatus:
+			if _, _, err := parseWait(s.Arg); err != nil {
+				return Step{}, err
+			}
+		}
+		return s, nil
+	}
+	var names []string
+	for _, v := range verbs[kind] {
+		names = append(names, v.name)
+	}
+	if len(names) == 0 {
+		return Step{}, fmt.Errorf("macros cannot run on a %s", kind)
+	}
+	return Step{}, fmt.Errorf("unknown %s step %q: use %s", kind, fields[0], strings.Join(names, ", "))
+}
+
+// parseWait reads the argument of a wait step: a status and an optional
+// timeout.
+func parseWait(arg string) (string, time.Duration, error) {
+	fields := strings.Fields(arg)
+	switch len(fields) {
+	case 1:
+		return fields[0], DefaultWaitTimeout, nil
+	case 2:
+		d, err := time.ParseDuration(fields[1])
+		if err != nil || d <= 0 {
+			return "", 0, fmt.Errorf("invalid timeout %q, expected e.g. 30s or 10m", fields[1])
+		}
+		return fields[0], d, nil
+	}
+	return "", 0, errors.New("usage: wait <status> [timeout]")
+}
+
+// checkFunc reports whether a step that finishes asynchronously is done.
+type checkFunc func() (bool, error)
+
+// run sends the request of a step. Steps that complete later, such as a
+// wait or a snapshot, return a check to poll; detail describes what was
+// done, e.g. the ID of a snapshot.
+func run(cs Clients, t Target, s Step, now time.Time) (checkFunc, string, error) {
+	ctx := context.Background()
+	if s.Verb == "wait" {
+		status, timeout, err := parseWait(s.Arg)
+		if err != nil {
+			return nil, "", err
+		}
+		deadline := now.Add(timeout)
+		return func() (bool, error) {
+			current, err := currentStatus(cs, t)
+			if err != nil {
+				return false, err
+			}
+			if strings.EqualFold(current, status) {
+				return true, nil
+			}
+			if strings.EqualFold(current, "error") {
+				return false, fmt.Errorf("the %s went to %s", t.Kind, current)
+			}
+			if time.Now().After(deadline) {
+				return false, fmt.Errorf("still %s after %s", current, timeout)
+			}
+			return false, nil
+		}, "", nil
+	}
+	if t.Kind == KindVolume {
+		return runVolume(ctx, cs, t, s, now)
+	}
+	cc, id := cs.Compute, t.ID
+	actions := map[string]func() error{
+		"start":    func() error { return cc.StartInstance(id) },
+		"stop":     func() error { return cc.StopInstance(id) },
+		"reboot":   func() error { return cc.RebootInstance(ctx, id, false) },
+		"pause":    func() error { return cc.PauseInstance(ctx, id) },
+		"unpause":  func() error { return cc.UnpauseInstance(ctx, id) },
+		"suspend":  func() error { return cc.SuspendInstance(ctx, id) },
+		"resume":   func() error { return cc.ResumeInstance(ctx, id) },
+		"shelve":   func() error { return cc.ShelveInstance(ctx, id) },
+		"unshelve": func() error { return cc.UnshelveInstance(ctx, id) },
+		"lock":     func() error { return cc.LockInstance(ctx, id) },
+		"unlock":   func() error { return cc.UnlockInstance(ctx, id) },
+	}
+	action, ok := actions[s.Verb]
+	if !ok {
+		return nil, "", fmt.Errorf("unknown server step %q", s.Verb)
+	}
+	return nil, "", action()
+}
+
+// runVolume runs the snapshot and detach steps.
+func runVolume(ctx context.Context, cs Clients, t Target, s Step, now time.Time) (checkFunc, string, error) {
+	v, err := cs.Storage.GetVolume(t.ID)
+	if err != nil {
+		return nil, "", err
+	}
+	switch s.Verb {
+	case "snapshot":
+		name := s.Arg
+		if name == "" {
+			name = "{name}-{date}"
+		}
+		name = strings.NewReplacer("{name}", v.Name, "{date}", now.Format("20060102-1504")).Replace(name)
+		if _, err := client.VolumeTransition(v, client.VolumeSnapshot); err != nil {
+			return nil, "", err
+		}
+		snap, err := cs.Storage.CreateSnapshot(snapshots.CreateOpts{VolumeID: v.ID, Name: name, Force: client.SnapshotNeedsForce(v)})
+		if err != nil {
+			return nil, "", err
+		}
+		return func() (bool, error) {
+			list, err := cs.Storage.ListSnapshots()
+			if err != nil {
+				return false, err
+			}
+			for _, sn := range list {
+				if sn.ID == snap.ID {
+					if sn.Status == "error" {
+						return false, fmt.Errorf("snapshot %s went to error", snap.ID)
+					}
+					return sn.Status == "available", nil
+				}
+			}
+			return false, fmt.Errorf("snapshot %s disappeared", snap.ID)
+		}, "snapshot " + snap.ID, nil
+	case "detach":
+		if _, err := client.VolumeTransition(v, client.VolumeDetach); err != nil {
+			return nil, "", err
+		}
+		var servers []string
+		for _, a := range v.Attachments {
+			if err := cs.Compute.DetachVolume(ctx, a.ServerID, v.ID); err != nil {
+				return nil, "", err
+			}
+			servers = append(servers, a.ServerID)
+		}
+		return nil, "from " + strings.Join(servers, ", "), nil
+	}
+	return nil, "", fmt.Errorf("unknown volume step %q", s.Verb)
+}
+
+// currentStatus returns the status of the target.
+func currentStatus(cs Clients, t Target) (string, error) {
+	if t.Kind == KindVolume {
+		v, err := cs.Storage.GetVolume(t.ID)
+		return v.Status, err
+	}
+	s, err := cs.Compute.GetInstance(t.ID)
+	return s.Status, err
+}
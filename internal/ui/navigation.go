@@ -0,0 +1,124 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"ostui/internal/ui/search"
+	"ostui/internal/ui/shell"
+	"ostui/internal/ui/topology"
+)
+
+// view is one level of the navigation stack: the UI state and the model
+// rendering it when the next view was pushed on top, with its lifecycle
+// token.
+type view struct {
+	state string
+	model tea.Model
+	token int
+}
+
+// viewModel returns the model backing state, or nil when the state has none.
+func (m *AppModel) viewModel(state string) tea.Model {
+	switch state {
+	case stateMain:
+		return m.mainModel
+	case stateDetail:
+		return m.detailModel
+	case stateGraph:
+		return m.graphModel
+	case stateLogs:
+		return m.logsModel
+	case stateDiff:
+		return m.diffModel
+	case stateEditor:
+		return m.editorModel
+	case stateTopology:
+		if m.topologyModel != nil {
+			return m.topologyModel
+		}
+	case stateSearch:
+		if m.searchModel != nil {
+			return m.searchModel
+		}
+	case stateShell:
+		if m.shellModel != nil {
+			return m.shellModel
+		}
+	}
+	return nil
+}
+
+// setViewModel stores model as the model backing state; nil clears it.
+func (m *AppModel) setViewModel(state string, model tea.Model) {
+	switch state {
+	case stateMain:
+		m.mainModel = model
+	case stateDetail:
+		m.detailModel = model
+	case stateGraph:
+		m.graphModel = model
+	case stateLogs:
+		m.logsModel = model
+	case stateDiff:
+		m.diffModel = model
+	case stateEditor:
+		m.editorModel = model
+	case stateTopology:
+		m.topologyModel, _ = model.(*topology.TopologyModel)
+	case stateSearch:
+		m.searchModel, _ = model.(*search.SearchModel)
+	case stateShell:
+		m.shellModel, _ = model.(*shell.ShellModel)
+	}
+}
+
+// pushView opens state with model on top of the current view and returns the
+// model's Init command. esc pops it again, see popView.
+func (m *AppModel) pushView(state string, model tea.Model) tea.Cmd {
+	// Help and command mode are overlays, not levels of their own.
+	if m.state == stateHelp || m.state == stateCommand {
+		m.state = m.prevState
+		m.prevState = ""
+	}
+	m.navStack = append(m.navStack, view{state: m.state, model: m.viewModel(m.state), token: m.tokens[m.state]})
+	m.state = state
+	m.setViewModel(state, model)
+	if model == nil {
+		return nil
+	}
+	return tagCmd(m.openToken(state), model.Init())
+}
+
+// popView closes the current view and returns exactly one level. Models of
+// views below are kept in place while covered, so a view is only restored
+// from the stack when the popped one had replaced it (e.g. detail → detail).
+// The popped view is disposed of and its pending results are dropped.
+func (m *AppModel) popView() {
+	if m.state == stateMain {
+		m.section = ""
+	}
+	dispose(m.viewModel(m.state))
+	m.setViewModel(m.state, nil)
+	delete(m.tokens, m.state)
+	m.modalActive = false
+	if len(m.navStack) == 0 {
+		m.state = stateSidebar
+		return
+	}
+	top := m.navStack[len(m.navStack)-1]
+	m.navStack = m.navStack[:len(m.navStack)-1]
+	m.state = top.state
+	if m.viewModel(top.state) == nil {
+		m.setViewModel(top.state, top.model)
+		if top.token != 0 {
+			m.tokens[top.state] = top.token
+		}
+	}
+}
+
+// resetNav drops every open view and returns to the sidebar, the root of
+// the navigation stack.
+func (m *AppModel) resetNav() {
+	for len(m.navStack) > 0 || m.state != stateSidebar {
+		m.popView()
+	}
+}
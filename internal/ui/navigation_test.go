@@ -0,0 +1,101 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"ostui/internal/ui/common"
+	"ostui/internal/ui/compute"
+)
+
+type stubModel struct{ name string }
+
+func (s stubModel) Init() tea.Cmd                       { return nil }
+func (s stubModel) Update(tea.Msg) (tea.Model, tea.Cmd) { return s, nil }
+func (s stubModel) View() string                        { return s.name }
+
+func TestPopViewReturnsExactlyOneLevel(t *testing.T) {
+	m := AppModel{state: stateSidebar}
+	m.pushView(stateMain, stubModel{"servers"})
+	m.pushView(stateDetail, stubModel{"server"})
+	m.pushView(stateDetail, stubModel{"volume"})
+	m.pushView(stateLogs, stubModel{"logs"})
+
+	steps := []struct{ state, view string }{
+		{stateDetail, "volume"},
+		{stateDetail, "server"},
+		{stateMain, "servers"},
+		{stateSidebar, ""},
+	}
+	for i, want := range steps {
+		m.popView()
+		if m.state != want.state {
+			t.Fatalf("pop %d: state %q, want %q", i+1, m.state, want.state)
+		}
+		if v := m.viewModel(m.state); want.view != "" && (v == nil || v.View() != want.view) {
+			t.Fatalf("pop %d: view %v, want %q", i+1, v, want.view)
+		}
+	}
+	if m.logsModel != nil || m.detailModel != nil || m.mainModel != nil || len(m.navStack) != 0 {
+		t.Errorf("popped views must be released: %+v", m.navStack)
+	}
+}
+
+func TestPushViewSkipsOverlays(t *testing.T) {
+	m := AppModel{state: stateSidebar}
+	m.pushView(stateMain, stubModel{"servers"})
+	m.prevState, m.state = m.state, stateCommand
+	m.pushView(stateShell, nil)
+	m.popView()
+	if m.state != stateMain || m.prevState != "" {
+		t.Fatalf("expected to return to main below the command bar, got %q (prev %q)", m.state, m.prevState)
+	}
+}
+
+func TestNavigateToResetsStack(t *testing.T) {
+	m := AppModel{state: stateSidebar}
+	m.pushView(stateMain, stubModel{"servers"})
+	m.pushView(stateDetail, stubModel{"server"})
+	m.navigateTo("Unknown")
+	if m.state != stateMain || len(m.navStack) != 1 || m.detailModel != nil {
+		t.Fatalf("expected a single main view above the sidebar, got %q %+v", m.state, m.navStack)
+	}
+}
+
+func TestScheduledRefreshReloadsInPlace(t *testing.T) {
+	h := newHarness(t).command("servers").keys("down")
+	m := h.m.(AppModel)
+	cursor := m.mainModel.(compute.InstancesModel).Table().Cursor()
+	result, cmd := m.refreshSection("Servers")
+	if result != "refreshed Servers" || cmd == nil {
+		t.Fatalf("expected the servers reloaded, got %q", result)
+	}
+	if got := m.mainModel.(compute.InstancesModel).Table().Cursor(); got != cursor || got == 0 {
+		t.Fatalf("expected the list kept with its cursor on row %d, got %d", cursor, got)
+	}
+
+	// A list that cannot reload in place is skipped and cannot be scheduled.
+	h.command("events")
+	m = h.m.(AppModel)
+	if result, cmd := m.refreshSection("Events"); cmd != nil || !strings.Contains(result, "skipped") {
+		t.Fatalf("expected the events skipped, got %q", result)
+	}
+	h.command("every 5m refresh events")
+	if err := h.m.(AppModel).commandErr; !strings.Contains(err, "unknown section") {
+		t.Fatalf("expected :every to refuse the events, got %q", err)
+	}
+}
+
+func TestReloadableSectionsReload(t *testing.T) {
+	m := newHarness(t).m.(AppModel)
+	for section, constructor := range m.navigationMap() {
+		if section == "Topology" {
+			// The topology opens in a view of its own, not as the list.
+			continue
+		}
+		if _, ok := constructor().(common.Reloader); ok != reloadableSections[section] {
+			t.Errorf("%s: reloads in place %v, listed as reloadable %v", section, ok, reloadableSections[section])
+		}
+	}
+}
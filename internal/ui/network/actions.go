@@ -1,10 +1,10 @@
 package network
 
 import (
-	"fmt"
 	"github.com/charmbracelet/bubbles/table"
 	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/layer3/floatingips"
 	"ostui/internal/client"
+	"ostui/internal/i18n"
 	"ostui/internal/ui/common"
 	"ostui/internal/ui/uiconst"
 )
@@ -15,12 +15,12 @@ func AllocateFloatingIP(nc client.NetworkClient, networkID string) string {
 	opts := floatingips.CreateOpts{FloatingNetworkID: networkID}
 	fip, err := nc.AllocateFloatingIP(opts)
 	if err != nil {
-		cols := []table.Column{{Title: "Error", Width: uiconst.ColWidthError}}
+		cols := []table.Column{{Title: i18n.T("Error"), Width: uiconst.ColWidthError}}
 		rows := []table.Row{{"Failed to allocate floating IP: " + err.Error()}}
 		return common.NewTable(cols, rows).View()
 	}
 	// Show allocated floating IP details.
-	cols := []table.Column{{Title: "ID", Width: uiconst.ColWidthUUID}, {Title: "FloatingNetworkID", Width: uiconst.ColWidthUUID}, {Title: "FixedIP", Width: uiconst.ColWidthFixed}, {Title: "PortID", Width: uiconst.ColWidthUUID}, {Title: "Status", Width: uiconst.ColWidthStatus}}
+	cols := []table.Column{{Title: "ID", Width: uiconst.ColWidthUUID}, {Title: i18n.T("FloatingNetworkID"), Width: uiconst.ColWidthUUID}, {Title: i18n.T("FixedIP"), Width: uiconst.ColWidthFixed}, {Title: i18n.T("PortID"), Width: uiconst.ColWidthUUID}, {Title: i18n.T("Status"), Width: uiconst.ColWidthStatus}}
 	rows := []table.Row{{fip.ID, fip.FloatingNetworkID, fip.FixedIP, fip.PortID, fip.Status}}
 	return common.NewTable(cols, rows).View()
 }
@@ -30,11 +30,11 @@ func AllocateFloatingIP(nc client.NetworkClient, networkID string) string {
 func ReleaseFloatingIP(nc client.NetworkClient, fipID string) string {
 	err := nc.ReleaseFloatingIP(fipID)
 	if err != nil {
-		cols := []table.Column{{Title: "Error", Width: uiconst.ColWidthError}}
+		cols := []table.Column{{Title: i18n.T("Error"), Width: uiconst.ColWidthError}}
 		rows := []table.Row{{"Failed to release floating IP: " + err.Error()}}
 		return common.NewTable(cols, rows).View()
 	}
-	return fmt.Sprintf("Floating IP %s released successfully.", fipID)
+	return i18n.T("Floating IP %s released successfully.", fipID)
 }
 
 // AssociateFloatingIP associates a floating IP with a port.
@@ -42,11 +42,11 @@ func ReleaseFloatingIP(nc client.NetworkClient, fipID string) string {
 func AssociateFloatingIP(nc client.NetworkClient, fipID, portID string) string {
 	fip, err := nc.AssociateFloatingIP(fipID, portID)
 	if err != nil {
-		cols := []table.Column{{Title: "Error", Width: uiconst.ColWidthError}}
+		cols := []table.Column{{Title: i18n.T("Error"), Width: uiconst.ColWidthError}}
 		rows := []table.Row{{"Failed to associate floating IP: " + err.Error()}}
 		return common.NewTable(cols, rows).View()
 	}
-	cols := []table.Column{{Title: "ID", Width: uiconst.ColWidthUUID}, {Title: "FloatingNetworkID", Width: uiconst.ColWidthUUID}, {Title: "FixedIP", Width: uiconst.ColWidthFixed}, {Title: "PortID", Width: uiconst.ColWidthUUID}, {Title: "Status", Width: uiconst.ColWidthStatus}}
+	cols := []table.Column{{Title: "ID", Width: uiconst.ColWidthUUID}, {Title: i18n.T("FloatingNetworkID"), Width: uiconst.ColWidthUUID}, {Title: i18n.T("FixedIP"), Width: uiconst.ColWidthFixed}, {Title: i18n.T("PortID"), Width: uiconst.ColWidthUUID}, {Title: i18n.T("Status"), Width: uiconst.ColWidthStatus}}
 	rows := []table.Row{{fip.ID, fip.FloatingNetworkID, fip.FixedIP, fip.PortID, fip.Status}}
 	return common.NewTable(cols, rows).View()
 }
@@ -56,11 +56,11 @@ func AssociateFloatingIP(nc client.NetworkClient, fipID, portID string) string {
 func DisassociateFloatingIP(nc client.NetworkClient, fipID string) string {
 	fip, err := nc.DisassociateFloatingIP(fipID)
 	if err != nil {
-		cols := []table.Column{{Title: "Error", Width: uiconst.ColWidthError}}
+		cols := []table.Column{{Title: i18n.T("Error"), Width: uiconst.ColWidthError}}
 		rows := []table.Row{{"Failed to disassociate floating IP: " + err.Error()}}
 		return common.NewTable(cols, rows).View()
 	}
-	cols := []table.Column{{Title: "ID", Width: uiconst.ColWidthUUID}, {Title: "FloatingNetworkID", Width: uiconst.ColWidthUUID}, {Title: "FixedIP", Width: uiconst.ColWidthFixed}, {Title: "PortID", Width: uiconst.ColWidthUUID}, {Title: "Status", Width: uiconst.ColWidthStatus}}
+	cols := []table.Column{{Title: "ID", Width: uiconst.ColWidthUUID}, {Title: i18n.T("FloatingNetworkID"), Width: uiconst.ColWidthUUID}, {Title: i18n.T("FixedIP"), Width: uiconst.ColWidthFixed}, {Title: i18n.T("PortID"), Width: uiconst.ColWidthUUID}, {Title: i18n.T("Status"), Width: uiconst.ColWidthStatus}}
 	rows := []table.Row{{fip.ID, fip.FloatingNetworkID, fip.FixedIP, fip.PortID, fip.Status}}
 	return common.NewTable(cols, rows).View()
 }
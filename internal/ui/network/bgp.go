@@ -0,0 +1,518 @@
+package network
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/gophercloud/gophercloud"
+	"ostui/internal/client"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/common"
+	"ostui/internal/ui/theme"
+	"ostui/internal/ui/uiconst"
+)
+
+// bgpError explains the usual failures of the admin-only BGP API.
+func bgpError(err error) error {
+	var forbidden gophercloud.ErrDefault403
+	var missing gophercloud.ErrDefault404
+	switch {
+	case errors.As(err, &forbidden):
+		return fmt.Errorf("%w (BGP dynamic routing requires admin credentials)", err)
+	case errors.As(err, &missing):
+		return fmt.Errorf("%w (is the bgp extension of neutron-dynamic-routing enabled?)", err)
+	}
+	return err
+}
+
+// agentAlive renders the liveness of an agent.
+func agentAlive(a client.NetworkAgent) string {
+	if a.Alive {
+		return "✓ alive"
+	}
+	return "✗ dead"
+}
+
+// BGPModel lists the BGP speakers with their peers and hosting dragents.
+type BGPModel struct {
+	table    table.Model
+	loading  bool
+	err      error
+	spinner  spinner.Model
+	client   client.NetworkClient
+	speakers []client.BGPSpeaker
+
+	width  int
+	height int
+}
+
+// NewBGPModel creates the BGP speaker list.
+func NewBGPModel(nc client.NetworkClient) BGPModel {
+	s := spinner.New()
+	s.Spinner = spinner.Dot
+	return BGPModel{client: nc, loading: true, spinner: s, width: 120, height: 30}
+}
+
+type bgpLoadedMsg struct {
+	speakers []client.BGPSpeaker
+	peers    map[string]string                // peer ID -> "name (IP, AS n)"
+	agents   map[string][]client.NetworkAgent // speaker ID -> hosting dragents
+	err      error
+}
+
+// Init loads the speakers, peers and dragent scheduling.
+func (m BGPModel) Init() tea.Cmd {
+	return tea.Batch(m.spinner.Tick, m.loadCmd())
+}
+
+// Reload re-fetches the speakers, keeping the selected row.
+func (m BGPModel) Reload() tea.Cmd {
+	if m.loading {
+		return nil
+	}
+	return m.loadCmd()
+}
+
+func (m BGPModel) loadCmd() tea.Cmd {
+	nc := m.client
+	return func() tea.Msg {
+		ctx := context.Background()
+		speakers, err := nc.ListBGPSpeakers(ctx)
+		if err != nil {
+			return bgpLoadedMsg{err: bgpError(err)}
+		}
+		msg := bgpLoadedMsg{speakers: speakers, peers: map[string]string{}, agents: map[string][]client.NetworkAgent{}}
+		if peers, err := nc.ListBGPPeers(ctx); err == nil {
+			for _, p := range peers {
+				msg.peers[p.ID] = fmt.Sprintf("%s (%s, AS %d)", nameOrID(p.Name, p.ID), p.PeerIP, p.RemoteAS)
+			}
+		}
+		for _, s := range speakers {
+			if agents, err := nc.ListBGPSpeakerAgents(ctx, s.ID); err == nil {
+				msg.agents[s.ID] = agents
+			}
+		}
+		return msg
+	}
+}
+
+// Update handles messages for the model.
+func (m BGPModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case bgpLoadedMsg:
+		m.loading = false
+		m.err = msg.err
+		if msg.err != nil {
+			return m, nil
+		}
+		m.speakers = msg.speakers
+		rows := make([]table.Row, 0, len(msg.speakers))
+		for _, s := range msg.speakers {
+			var peers, hosts []string
+			for _, id := range s.Peers {
+				if label, ok := msg.peers[id]; ok {
+					peers = append(peers, label)
+				} else {
+					peers = append(peers, id)
+				}
+			}
+			for _, a := range msg.agents[s.ID] {
+				host := a.Host
+				if !a.Alive {
+					host += " (dead)"
+				}
+				hosts = append(hosts, host)
+			}
+			if len(hosts) == 0 {
+				hosts = []string{"unscheduled"}
+			}
+			var adv []string
+			if s.AdvertiseTenantNetworks {
+				adv = append(adv, i18n.T("tenant nets"))
+			}
+			if s.AdvertiseFloatingIPHostRoutes {
+				adv = append(adv, "FIP /32")
+			}
+			rows = append(rows, table.Row{s.ID, s.Name, fmt.Sprintf("%d", s.LocalAS), i18n.T("IPv%d", s.IPVersion), strings.Join(adv, ", "), strings.Join(peers, ", "), strings.Join(hosts, ", ")})
+		}
+		prev := m.table
+		m.table = table.New(table.WithFocused(true))
+		m.table.SetStyles(table.DefaultStyles())
+		m.updateTableColumns()
+		m.table.SetRows(rows)
+		m.table = common.Reloaded(prev, m.table)
+		return m, nil
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		if m.table.Columns() != nil {
+			m.updateTableColumns()
+		}
+		return m, nil
+	case tea.KeyMsg:
+		if m.loading || m.err != nil {
+			return m, nil
+		}
+		if msg.String() == "r" {
+			m.loading = true
+			return m, tea.Batch(m.spinner.Tick, m.loadCmd())
+		}
+		var cmd tea.Cmd
+		m.table, cmd = m.table.Update(msg)
+		return m, cmd
+	default:
+		if m.loading {
+			var cmd tea.Cmd
+			m.spinner, cmd = m.spinner.Update(msg)
+			return m, cmd
+		}
+	}
+	return m, nil
+}
+
+func (m *BGPModel) updateTableColumns() {
+	idW := uiconst.ColWidthUUID
+	rest := m.width - idW - 8 - 6 - 20 - uiconst.TableHeightOffset
+	if rest < 45 {
+		rest = 45
+	}
+	w := rest / 3
+	m.table.SetColumns([]table.Column{{Title: "ID", Width: idW}, {Title: i18n.T("Name"), Width: w}, {Title: i18n.T("Local AS"), Width: 8}, {Title: "IP", Width: 6}, {Title: i18n.T("Advertises"), Width: 20}, {Title: i18n.T("Peers"), Width: w}, {Title: i18n.T("DR agents"), Width: rest - 2*w}})
+	m.table.SetHeight(m.height - uiconst.TableHeightOffset - 2)
+}
+
+// Selected returns the speaker under the cursor.
+func (m BGPModel) Selected() (client.BGPSpeaker, bool) {
+	row := m.table.SelectedRow()
+	if len(row) == 0 {
+		return client.BGPSpeaker{}, false
+	}
+	for _, s := range m.speakers {
+		if s.ID == row[0] {
+			return s, true
+		}
+	}
+	return client.BGPSpeaker{}, false
+}
+
+// View renders the speaker list.
+func (m BGPModel) View() string {
+	if m.loading {
+		return m.spinner.View()
+	}
+	if m.err != nil {
+		return i18n.T("Error: %s", m.err)
+	}
+	return m.table.View() + "\n" + i18n.T("[enter] routes, peers and dragents  [r] refresh")
+}
+
+// Table returns the underlying table.
+func (m BGPModel) Table() table.Model { return m.table }
+
+// BGP speaker detail modes, cycled with tab.
+const (
+	bgpModeRoutes = "routes"
+	bgpModePeers  = "peers"
+	bgpModeAgents = "agents"
+)
+
+var bgpModes = []string{bgpModeRoutes, bgpModePeers, bgpModeAgents}
+
+var bgpModeTitles = map[string]string{
+	bgpModeRoutes: "Advertised routes",
+	bgpModePeers:  "Peers",
+	bgpModeAgents: "DR agents",
+}
+
+// BGPSpeakerDetailModel shows the advertised routes, peers and dragent
+// scheduling of a BGP speaker; s schedules or unschedules the speaker on the
+// selected dragent.
+type BGPSpeakerDetailModel struct {
+	table   table.Model
+	loading bool
+	err     error
+	spinner spinner.Model
+	client  client.NetworkClient
+	speaker client.BGPSpeaker
+	mode    string
+
+	routes  []client.BGPRoute
+	peers   []client.BGPPeer
+	agents  []client.NetworkAgent
+	hosting map[string]bool // dragent ID -> hosts the speaker
+
+	pendingUnschedule string
+	status            string
+	statusErr         bool
+
+	width  int
+	height int
+}
+
+// NewBGPSpeakerDetailModel creates the detail view of a BGP speaker.
+func NewBGPSpeakerDetailModel(nc client.NetworkClient, speaker client.BGPSpeaker) BGPSpeakerDetailModel {
+	s := spinner.New()
+	s.Spinner = spinner.Dot
+	return BGPSpeakerDetailModel{client: nc, loading: true, spinner: s, speaker: speaker, mode: bgpModeRoutes, width: 120, height: 30}
+}
+
+// ResourceID returns the speaker ID.
+func (m BGPSpeakerDetailModel) ResourceID() string { return m.speaker.ID }
+
+type bgpSpeakerLoadedMsg struct {
+	routes  []client.BGPRoute
+	peers   []client.BGPPeer
+	agents  []client.NetworkAgent
+	hosting map[string]bool
+	err     error
+}
+
+// Init loads the routes, peers and dragents.
+func (m BGPSpeakerDetailModel) Init() tea.Cmd {
+	return tea.Batch(m.spinner.Tick, m.loadCmd())
+}
+
+// Reload re-fetches the routes, peers and dragents, keeping the mode and
+// the selected row.
+func (m BGPSpeakerDetailModel) Reload() tea.Cmd {
+	if m.loading {
+		return nil
+	}
+	return m.loadCmd()
+}
+
+func (m BGPSpeakerDetailModel) loadCmd() tea.Cmd {
+	nc, spk := m.client, m.speaker
+	return func() tea.Msg {
+		ctx := context.Background()
+		routes, err := nc.ListBGPAdvertisedRoutes(ctx, spk.ID)
+		if err != nil {
+			return bgpSpeakerLoadedMsg{err: bgpError(err)}
+		}
+		msg := bgpSpeakerLoadedMsg{routes: routes, hosting: map[string]bool{}}
+		if all, err := nc.ListBGPPeers(ctx); err == nil {
+			for _, p := range all {
+				for _, id := range spk.Peers {
+					if p.ID == id {
+						msg.peers = append(msg.peers, p)
+					}
+				}
+			}
+		}
+		hosts, err := nc.ListBGPSpeakerAgents(ctx, spk.ID)
+		if err != nil {
+			return bgpSpeakerLoadedMsg{err: bgpError(err)}
+		}
+		for _, a := range hosts {
+			msg.hosting[a.ID] = true
+		}
+		// Every dragent is listed so the speaker can be scheduled on it.
+		msg.agents, err = nc.ListNetworkAgents(ctx, client.AgentTypeBGP)
+		if err != nil {
+			msg.agents = hosts
+		}
+		return msg
+	}
+}
+
+// Update handles messages for the model.
+func (m BGPSpeakerDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case bgpSpeakerLoadedMsg:
+		m.loading = false
+		m.err = msg.err
+		if msg.err != nil {
+			return m, nil
+		}
+		m.routes, m.peers, m.agents, m.hosting = msg.routes, msg.peers, msg.agents, msg.hosting
+		prev := m.table
+		m.refreshTable()
+		m.table = common.Reloaded(prev, m.table)
+		return m, nil
+	case changeDoneMsg:
+		m.status, m.statusErr = msg.status, msg.err != nil
+		if msg.err != nil {
+			m.status = msg.err.Error()
+			return m, nil
+		}
+		return m, m.loadCmd()
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		if !m.loading && m.err == nil {
+			m.refreshTable()
+		}
+		return m, nil
+	case tea.KeyMsg:
+		if m.pendingUnschedule != "" {
+			agentID := m.pendingUnschedule
+			m.pendingUnschedule = ""
+			if msg.String() != "y" {
+				return m, nil
+			}
+			return m, m.scheduleCmd(agentID, false)
+		}
+		if m.loading || m.err != nil {
+			return m, nil
+		}
+		switch msg.String() {
+		case "tab", "shift+tab":
+			step := 1
+			if msg.String() == "shift+tab" {
+				step = len(bgpModes) - 1
+			}
+			for i, mode := range bgpModes {
+				if mode == m.mode {
+					m.mode = bgpModes[(i+step)%len(bgpModes)]
+					break
+				}
+			}
+			m.refreshTable()
+			return m, nil
+		case "s":
+			row := m.table.SelectedRow()
+			if m.mode != bgpModeAgents || len(row) == 0 {
+				return m, nil
+			}
+			if m.hosting[row[0]] {
+				// Unscheduling can stop the announcements; confirm first.
+				m.pendingUnschedule = row[0]
+				return m, nil
+			}
+			return m, m.scheduleCmd(row[0], true)
+		case "r":
+			m.loading = true
+			return m, tea.Batch(m.spinner.Tick, m.loadCmd())
+		}
+		var cmd tea.Cmd
+		m.table, cmd = m.table.Update(msg)
+		return m, cmd
+	default:
+		if m.loading {
+			var cmd tea.Cmd
+			m.spinner, cmd = m.spinner.Update(msg)
+			return m, cmd
+		}
+	}
+	return m, nil
+}
+
+// scheduleCmd schedules the speaker on a dragent, or removes it from one.
+func (m BGPSpeakerDetailModel) scheduleCmd(agentID string, schedule bool) tea.Cmd {
+	nc, speakerID := m.client, m.speaker.ID
+	return func() tea.Msg {
+		ctx := context.Background()
+		if schedule {
+			err := nc.ScheduleBGPSpeaker(ctx, agentID, speakerID)
+			return changeDoneMsg{status: i18n.T("Scheduled speaker on agent %s", agentID), err: bgpError(err)}
+		}
+		err := nc.UnscheduleBGPSpeaker(ctx, agentID, speakerID)
+		return changeDoneMsg{status: i18n.T("Removed speaker from agent %s", agentID), err: bgpError(err)}
+	}
+}
+
+// refreshTable rebuilds the table for the current mode.
+func (m *BGPSpeakerDetailModel) refreshTable() {
+	rest := m.width - uiconst.TableHeightOffset
+	if rest < 60 {
+		rest = 60
+	}
+	var cols []table.Column
+	var rows []table.Row
+	switch m.mode {
+	case bgpModeRoutes:
+		cols = []table.Column{{Title: i18n.T("Destination"), Width: rest / 2}, {Title: i18n.T("Next hop"), Width: rest - rest/2}}
+		for _, r := range m.routes {
+			rows = append(rows, table.Row{r.Destination, r.NextHop})
+		}
+	case bgpModePeers:
+		w := (rest - uiconst.ColWidthUUID) / 4
+		cols = []table.Column{{Title: "ID", Width: uiconst.ColWidthUUID}, {Title: i18n.T("Name"), Width: w}, {Title: i18n.T("Peer IP"), Width: w}, {Title: i18n.T("Remote AS"), Width: w}, {Title: i18n.T("Auth"), Width: rest - uiconst.ColWidthUUID - 3*w}}
+		for _, p := range m.peers {
+			rows = append(rows, table.Row{p.ID, p.Name, p.PeerIP, fmt.Sprintf("%d", p.RemoteAS), p.AuthType})
+		}
+	case bgpModeAgents:
+		w := (rest - uiconst.ColWidthUUID) / 4
+		cols = []table.Column{{Title: "ID", Width: uiconst.ColWidthUUID}, {Title: i18n.T("Host"), Width: w}, {Title: i18n.T("Alive"), Width: w}, {Title: i18n.T("Admin state"), Width: w}, {Title: i18n.T("Hosts speaker"), Width: rest - uiconst.ColWidthUUID - 3*w}}
+		for _, a := range m.agents {
+			admin := "UP"
+			if !a.AdminStateUp {
+				admin = "DOWN"
+			}
+			hosts := ""
+			if m.hosting[a.ID] {
+				hosts = "yes"
+			}
+			rows = append(rows, table.Row{a.ID, a.Host, agentAlive(a), admin, hosts})
+		}
+	}
+	m.table = table.New(table.WithColumns(cols), table.WithFocused(true))
+	m.table.SetStyles(table.DefaultStyles())
+	m.table.SetRows(rows)
+	m.table.SetHeight(m.height - uiconst.TableHeightOffset - 6)
+}
+
+// announcing reports whether an alive dragent hosts the speaker; without
+// one the speaker has no BGP sessions and announces nothing.
+func (m BGPSpeakerDetailModel) announcing() bool {
+	for _, a := range m.agents {
+		if m.hosting[a.ID] && a.Alive && a.AdminStateUp {
+			return true
+		}
+	}
+	return false
+}
+
+// View renders the speaker summary, the tab bar and the current table.
+func (m BGPSpeakerDetailModel) View() string {
+	title := lipgloss.NewStyle().Bold(true).Foreground(theme.Accent).Render
+	dim := lipgloss.NewStyle().Foreground(theme.Muted)
+	head := title("BGP speaker "+nameOrID(m.speaker.Name, m.speaker.ID)) + dim.Render(i18n.T("  AS %d, IPv%d, %d networks", m.speaker.LocalAS, m.speaker.IPVersion, len(m.speaker.Networks))) + "\n"
+	if m.loading {
+		return head + m.spinner.View()
+	}
+	if m.err != nil {
+		return head + i18n.T("Error: %s", m.err)
+	}
+	if !m.announcing() {
+		head += lipgloss.NewStyle().Foreground(theme.Error).Render(i18n.T("⚠ no alive DR agent hosts this speaker: its routes are not announced")) + "\n"
+	}
+	active := lipgloss.NewStyle().Bold(true).Foreground(theme.Accent)
+	var tabs []string
+	for _, mode := range bgpModes {
+		if mode == m.mode {
+			tabs = append(tabs, active.Render("["+i18n.T(bgpModeTitles[mode])+"]"))
+		} else {
+			tabs = append(tabs, dim.Render(" "+i18n.T(bgpModeTitles[mode])+" "))
+		}
+	}
+	out := head + strings.Join(tabs, " ") + "\n" + m.table.View()
+	if m.pendingUnschedule != "" {
+		out += "\n" + i18n.T("Remove the speaker from agent %s? [y/N]", m.pendingUnschedule)
+	} else {
+		out += changeStatusLine(m.status, m.statusErr, "", "")
+	}
+	help := "\n" + i18n.T("[tab] switch list  [r] refresh  [esc] back")
+	if m.mode == bgpModeAgents {
+		help = "\n" + i18n.T("[s] schedule / unschedule on agent") + "  " + help[1:]
+	}
+	return out + help
+}
+
+// CapturingInput reports whether the unschedule prompt is open.
+func (m BGPSpeakerDetailModel) CapturingInput() bool { return m.pendingUnschedule != "" }
+
+// ConfirmingDestructive reports whether the unschedule prompt is open.
+func (m BGPSpeakerDetailModel) ConfirmingDestructive() bool { return m.pendingUnschedule != "" }
+
+// Table returns the table of the current mode.
+func (m BGPSpeakerDetailModel) Table() table.Model { return m.table }
+
+var (
+	_ tea.Model = (*BGPModel)(nil)
+	_ tea.Model = (*BGPSpeakerDetailModel)(nil)
+)
@@ -0,0 +1,331 @@
+package network
+
+import (
+	"context"
+	"net/netip"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/subnets"
+	"ostui/internal/client"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/common"
+	"ostui/internal/ui/theme"
+	"ostui/internal/ui/uiconst"
+)
+
+// OpenNetworkDHCPMsg asks the app to open the DHCP view of a network.
+type OpenNetworkDHCPMsg struct {
+	NetworkID string
+}
+
+const (
+	dhcpModeAgents = "agents"
+	dhcpModeLeases = "leases"
+)
+
+var dhcpModes = []string{dhcpModeAgents, dhcpModeLeases}
+
+var dhcpModeTitles = map[string]string{
+	dhcpModeAgents: "DHCP agents",
+	dhcpModeLeases: "Leases",
+}
+
+// dnsmasqHostname is the host name the DHCP agent hands out for an address
+// when the port has no DNS name, as in host-10-0-0-5.
+func dnsmasqHostname(ip string) string {
+	return "host-" + strings.NewReplacer(".", "-", ":", "-").Replace(ip)
+}
+
+// dhcpData is what the DHCP view of a network shows. agentsErr is set when
+// the agents could not be listed, usually for lack of the admin role; the
+// leases come from the ports and stay visible.
+type dhcpData struct {
+	agents    []client.NetworkAgent
+	agentsErr error
+	dhcpPorts []client.DHCPPort
+	ports     []client.Port
+	subnets   []subnets.Subnet
+}
+
+// dhcpEnabled reports whether any subnet of the network runs DHCP.
+func (d dhcpData) dhcpEnabled() bool {
+	for _, s := range d.subnets {
+		if s.EnableDHCP {
+			return true
+		}
+	}
+	return false
+}
+
+// portIPs returns the addresses of the DHCP ports bound to host.
+func (d dhcpData) portIPs(host string) []string {
+	var out []string
+	for _, p := range d.dhcpPorts {
+		if p.HostID == host {
+			for _, ip := range p.FixedIPs {
+				out = append(out, ip.IPAddress)
+			}
+		}
+	}
+	return out
+}
+
+// warnings lists the reasons an instance on the network may get no address.
+func (d dhcpData) warnings() []string {
+	if !d.dhcpEnabled() {
+		return []string{"DHCP is disabled on every subnet: instances need static addressing or a config drive"}
+	}
+	if d.agentsErr != nil {
+		return nil
+	}
+	var out []string
+	alive := 0
+	for _, a := range d.agents {
+		if !a.Alive || !a.AdminStateUp {
+			out = append(out, i18n.T("the DHCP agent on %s is down", a.Host))
+			continue
+		}
+		alive++
+		// DHCP ports without a visible binding cannot be matched to hosts.
+		if len(d.portIPs(a.Host)) == 0 && len(d.portIPs("")) == 0 {
+			out = append(out, i18n.T("the DHCP agent on %s has no DHCP port on the network yet", a.Host))
+		}
+	}
+	if alive == 0 {
+		out = append(out, i18n.T("no alive DHCP agent serves this network: instances get no address"))
+	}
+	return out
+}
+
+// NetworkDHCPModel shows the DHCP agents serving a network and the address
+// assignments dnsmasq hands out for its ports.
+type NetworkDHCPModel struct {
+	table     table.Model
+	loading   bool
+	err       error
+	spinner   spinner.Model
+	client    client.NetworkClient
+	networkID string
+	mode      string
+	data      dhcpData
+
+	width  int
+	height int
+}
+
+// NewNetworkDHCPModel creates the DHCP view of a network.
+func NewNetworkDHCPModel(nc client.NetworkClient, networkID string) NetworkDHCPModel {
+	s := spinner.New()
+	s.Spinner = spinner.Dot
+	return NetworkDHCPModel{client: nc, loading: true, spinner: s, networkID: networkID, mode: dhcpModeAgents, width: 120, height: 30}
+}
+
+// ResourceID returns the network ID.
+func (m NetworkDHCPModel) ResourceID() string { return m.networkID }
+
+type networkDHCPLoadedMsg struct {
+	data dhcpData
+	err  error
+}
+
+// Init loads the agents, the ports and the subnets.
+func (m NetworkDHCPModel) Init() tea.Cmd {
+	return tea.Batch(m.spinner.Tick, m.loadCmd())
+}
+
+// Reload re-fetches the DHCP agents and leases, keeping the mode and the
+// selected row.
+func (m NetworkDHCPModel) Reload() tea.Cmd {
+	if m.loading {
+		return nil
+	}
+	return m.loadCmd()
+}
+
+func (m NetworkDHCPModel) loadCmd() tea.Cmd {
+	nc, networkID := m.client, m.networkID
+	return func() tea.Msg {
+		ctx := context.Background()
+		ports, err := nc.ListPortsByNetwork(ctx, networkID)
+		if err != nil {
+			return networkDHCPLoadedMsg{err: err}
+		}
+		all, err := nc.ListSubnets()
+		if err != nil {
+			return networkDHCPLoadedMsg{err: err}
+		}
+		d := dhcpData{ports: ports}
+		for _, s := range all {
+			if s.NetworkID == networkID {
+				d.subnets = append(d.subnets, s)
+			}
+		}
+		d.agents, d.agentsErr = nc.ListNetworkDHCPAgents(ctx, networkID)
+		if dp, err := nc.ListDHCPPorts(ctx, networkID); err == nil {
+			d.dhcpPorts = dp
+		}
+		return networkDHCPLoadedMsg{data: d}
+	}
+}
+
+// Update handles messages for the model.
+func (m NetworkDHCPModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case networkDHCPLoadedMsg:
+		m.loading = false
+		m.err = msg.err
+		if msg.err != nil {
+			return m, nil
+		}
+		m.data = msg.data
+		prev := m.table
+		m.refreshTable()
+		m.table = common.Reloaded(prev, m.table)
+		return m, nil
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		if !m.loading && m.err == nil {
+			m.refreshTable()
+		}
+		return m, nil
+	case tea.KeyMsg:
+		if m.loading || m.err != nil {
+			return m, nil
+		}
+		switch msg.String() {
+		case "tab", "shift+tab":
+			if m.mode == dhcpModeAgents {
+				m.mode = dhcpModeLeases
+			} else {
+				m.mode = dhcpModeAgents
+			}
+			m.refreshTable()
+			return m, nil
+		case "r":
+			m.loading = true
+			return m, tea.Batch(m.spinner.Tick, m.loadCmd())
+		}
+		var cmd tea.Cmd
+		m.table, cmd = m.table.Update(msg)
+		return m, cmd
+	default:
+		if m.loading {
+			var cmd tea.Cmd
+			m.spinner, cmd = m.spinner.Update(msg)
+			return m, cmd
+		}
+	}
+	return m, nil
+}
+
+// leaseRows lists an address per row for every port except the DHCP ports,
+// sorted by address, with whether its subnet runs DHCP.
+func (m NetworkDHCPModel) leaseRows() []table.Row {
+	dhcp := map[string]bool{}
+	for _, s := range m.data.subnets {
+		dhcp[s.ID] = s.EnableDHCP
+	}
+	var rows []table.Row
+	for _, p := range m.data.ports {
+		if p.DeviceOwner == client.DeviceOwnerDHCP {
+			continue
+		}
+		owner := p.DeviceOwner
+		if owner == "" {
+			owner = "-"
+		}
+		for _, ip := range p.FixedIPs {
+			served := "no"
+			if dhcp[ip.SubnetID] {
+				served = "yes"
+			}
+			rows = append(rows, table.Row{ip.IPAddress, p.MACAddress, dnsmasqHostname(ip.IPAddress), owner, p.DeviceID, p.Status, served})
+		}
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		a, errA := netip.ParseAddr(rows[i][0])
+		b, errB := netip.ParseAddr(rows[j][0])
+		if errA != nil || errB != nil {
+			return rows[i][0] < rows[j][0]
+		}
+		return a.Less(b)
+	})
+	return rows
+}
+
+// refreshTable rebuilds the table for the current mode.
+func (m *NetworkDHCPModel) refreshTable() {
+	rest := m.width - uiconst.TableHeightOffset
+	if rest < 80 {
+		rest = 80
+	}
+	var cols []table.Column
+	var rows []table.Row
+	switch m.mode {
+	case dhcpModeAgents:
+		w := (rest - uiconst.ColWidthUUID) / 5
+		cols = []table.Column{{Title: "ID", Width: uiconst.ColWidthUUID}, {Title: i18n.T("Host"), Width: w}, {Title: i18n.T("Zone"), Width: w}, {Title: i18n.T("Alive"), Width: w},
+			{Title: i18n.T("Admin state"), Width: w}, {Title: i18n.T("DHCP port"), Width: rest - uiconst.ColWidthUUID - 4*w}}
+		for _, a := range m.data.agents {
+			admin := "UP"
+			if !a.AdminStateUp {
+				admin = "DOWN"
+			}
+			ips := strings.Join(m.data.portIPs(a.Host), ", ")
+			if ips == "" {
+				ips = "-"
+			}
+			rows = append(rows, table.Row{a.ID, a.Host, a.AvailabilityZone, agentAlive(a), admin, ips})
+		}
+	case dhcpModeLeases:
+		w := (rest - uiconst.ColWidthUUID) / 6
+		cols = []table.Column{{Title: "IP", Width: w}, {Title: "MAC", Width: w}, {Title: i18n.T("Hostname"), Width: w}, {Title: i18n.T("Owner"), Width: w},
+			{Title: i18n.T("Device"), Width: uiconst.ColWidthUUID}, {Title: i18n.T("Status"), Width: w}, {Title: "DHCP", Width: rest - uiconst.ColWidthUUID - 5*w}}
+		rows = m.leaseRows()
+	}
+	m.table = table.New(table.WithColumns(cols), table.WithFocused(true))
+	m.table.SetStyles(table.DefaultStyles())
+	m.table.SetRows(rows)
+	m.table.SetHeight(m.height - uiconst.TableHeightOffset - 6)
+}
+
+// View renders the warnings, the tab bar and the current table.
+func (m NetworkDHCPModel) View() string {
+	title := lipgloss.NewStyle().Bold(true).Foreground(theme.Accent).Render
+	dim := lipgloss.NewStyle().Foreground(theme.Muted)
+	head := title("DHCP of network "+m.networkID) + "\n"
+	if m.loading {
+		return head + m.spinner.View()
+	}
+	if m.err != nil {
+		return head + i18n.T("Error: %s", m.err)
+	}
+	warn := lipgloss.NewStyle().Foreground(theme.Error)
+	for _, w := range m.data.warnings() {
+		head += warn.Render("⚠ "+w) + "\n"
+	}
+	active := lipgloss.NewStyle().Bold(true).Foreground(theme.Accent)
+	var tabs []string
+	for _, mode := range dhcpModes {
+		if mode == m.mode {
+			tabs = append(tabs, active.Render("["+i18n.T(dhcpModeTitles[mode])+"]"))
+		} else {
+			tabs = append(tabs, dim.Render(" "+i18n.T(dhcpModeTitles[mode])+" "))
+		}
+	}
+	out := head + strings.Join(tabs, " ") + "\n"
+	if m.mode == dhcpModeAgents && m.data.agentsErr != nil {
+		out += dim.Render("DHCP agents need the admin role to list: "+m.data.agentsErr.Error()) + "\n"
+	}
+	return out + m.table.View() + "\n" + i18n.T("[tab] agents / leases  [r] refresh  [esc] back")
+}
+
+// Table returns the table of the current mode.
+func (m NetworkDHCPModel) Table() table.Model { return m.table }
+
+var _ tea.Model = (*NetworkDHCPModel)(nil)
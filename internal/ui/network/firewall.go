@@ -0,0 +1,464 @@
+package network
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/gophercloud/gophercloud"
+	fwrules "github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/fwaas_v2/rules"
+	"ostui/internal/client"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/common"
+	"ostui/internal/ui/policy"
+	"ostui/internal/ui/theme"
+	"ostui/internal/ui/uiconst"
+)
+
+// Firewall view modes, cycled with tab.
+const (
+	fwModeGroups   = "groups"
+	fwModePolicies = "policies"
+	fwModeRules    = "rules"
+)
+
+var fwModes = []string{fwModeGroups, fwModePolicies, fwModeRules}
+
+var fwModeTitles = map[string]string{
+	fwModeGroups:   "Firewall groups",
+	fwModePolicies: "Policies",
+	fwModeRules:    "Rules",
+}
+
+// fwData holds the FWaaS v2 resources and the port labels of the groups.
+type fwData struct {
+	groups   []client.FirewallGroup
+	policies []client.FirewallPolicy
+	rules    []client.FirewallRule
+	ports    map[string]string
+}
+
+func (d fwData) policyName(id string) string {
+	if id == "" {
+		return "–"
+	}
+	for _, p := range d.policies {
+		if p.ID == id {
+			return nameOrID(p.Name, p.ID)
+		}
+	}
+	return id
+}
+
+func (d fwData) ruleName(id string) string {
+	for _, r := range d.rules {
+		if r.ID == id {
+			return nameOrID(r.Name, r.ID)
+		}
+	}
+	return id
+}
+
+// policyGroups returns the names of the groups using a policy, marked with
+// the direction it is applied in.
+func (d fwData) policyGroups(id string) []string {
+	var out []string
+	for _, g := range d.groups {
+		name := nameOrID(g.Name, g.ID)
+		if g.IngressFirewallPolicyID == id {
+			out = append(out, name+" (in)")
+		}
+		if g.EgressFirewallPolicyID == id {
+			out = append(out, name+" (out)")
+		}
+	}
+	return out
+}
+
+// fwEndpoint renders the address and port of a rule side, "any" when unset.
+func fwEndpoint(ip, port string) string {
+	if ip == "" {
+		ip = "any"
+	}
+	if port != "" {
+		return ip + ":" + port
+	}
+	return ip
+}
+
+// FirewallModel lists FWaaS v2 firewall groups, policies and rules; tab
+// switches between them and rules can be created and deleted.
+type FirewallModel struct {
+	table   table.Model
+	loading bool
+	err     error
+	spinner spinner.Model
+	client  client.NetworkClient
+	data    fwData
+	mode    string
+
+	form          *common.FormModel
+	pendingDelete string
+	status        string
+	statusErr     bool
+
+	width  int
+	height int
+}
+
+// NewFirewallModel creates the firewall view, showing groups first.
+func NewFirewallModel(nc client.NetworkClient) FirewallModel {
+	s := spinner.New()
+	s.Spinner = spinner.Dot
+	return FirewallModel{client: nc, loading: true, spinner: s, mode: fwModeGroups, width: 120, height: 30}
+}
+
+type fwLoadedMsg struct {
+	data fwData
+	err  error
+}
+
+// Init loads the firewall resources.
+func (m FirewallModel) Init() tea.Cmd {
+	return tea.Batch(m.spinner.Tick, m.loadCmd())
+}
+
+// Reload re-fetches the groups, policies and rules, keeping the mode and
+// the selected row.
+func (m FirewallModel) Reload() tea.Cmd {
+	if m.loading {
+		return nil
+	}
+	return m.loadCmd()
+}
+
+func (m FirewallModel) loadCmd() tea.Cmd {
+	nc := m.client
+	return func() tea.Msg {
+		ctx := context.Background()
+		var d fwData
+		var err error
+		if d.groups, err = nc.ListFirewallGroups(ctx); err != nil {
+			return fwLoadedMsg{err: fmt.Errorf("%w (is the fwaas_v2 extension enabled?)", err)}
+		}
+		if d.policies, err = nc.ListFirewallPolicies(ctx); err != nil {
+			return fwLoadedMsg{err: err}
+		}
+		if d.rules, err = nc.ListFirewallRules(ctx); err != nil {
+			return fwLoadedMsg{err: err}
+		}
+		d.ports = portLabels(ctx, nc)
+		return fwLoadedMsg{data: d}
+	}
+}
+
+// Update handles messages for the model.
+func (m FirewallModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case fwLoadedMsg:
+		m.loading = false
+		m.err = msg.err
+		m.data = msg.data
+		if msg.err == nil {
+			prev := m.table
+			m.refreshTable()
+			m.table = common.Reloaded(prev, m.table)
+		}
+		return m, nil
+	case changeDoneMsg:
+		m.status, m.statusErr = msg.status, msg.err != nil
+		if msg.err != nil {
+			policy.Record(msg.action, msg.err)
+			m.status = msg.err.Error()
+			return m, nil
+		}
+		return m, m.loadCmd()
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		if !m.loading && m.err == nil {
+			m.refreshTable()
+		}
+		return m, nil
+	case tea.KeyMsg:
+		if m.form != nil {
+			return m.updateForm(msg)
+		}
+		if m.pendingDelete != "" {
+			id := m.pendingDelete
+			m.pendingDelete = ""
+			if msg.String() != "y" {
+				return m, nil
+			}
+			nc := m.client
+			return m, func() tea.Msg {
+				err := nc.DeleteFirewallRule(context.Background(), id)
+				return changeDoneMsg{status: i18n.T("Deleted firewall rule %s", id), err: err}
+			}
+		}
+		if m.loading || m.err != nil {
+			return m, nil
+		}
+		switch msg.String() {
+		case "tab", "shift+tab":
+			step := 1
+			if msg.String() == "shift+tab" {
+				step = len(fwModes) - 1
+			}
+			for i, mode := range fwModes {
+				if mode == m.mode {
+					m.mode = fwModes[(i+step)%len(fwModes)]
+					break
+				}
+			}
+			m.refreshTable()
+			return m, nil
+		case "n":
+			if err := policy.Check(policy.Member, "creating a firewall rule"); err != nil {
+				m.status, m.statusErr = err.Error(), true
+				return m, nil
+			}
+			f := common.NewForm([]string{i18n.T("Name"), i18n.T("Action (allow, deny, reject)"), i18n.T("Protocol (tcp, udp, icmp, any)"), i18n.T("Source CIDR"), i18n.T("Source port"), i18n.T("Destination CIDR"), i18n.T("Destination port"), i18n.T("IP version"), i18n.T("Append to policy (name or ID)")})
+			f.SetValue(1, string(fwrules.ActionAllow))
+			f.SetValue(2, string(fwrules.ProtocolTCP))
+			f.SetValue(7, "4")
+			if m.mode == fwModePolicies {
+				if row := m.table.SelectedRow(); len(row) > 0 {
+					f.SetValue(8, row[1])
+				}
+			}
+			m.form = &f
+			return m, f.Init()
+		case "x":
+			if row := m.table.SelectedRow(); m.mode == fwModeRules && len(row) > 0 {
+				m.pendingDelete = row[0]
+			}
+			return m, nil
+		case "r":
+			m.loading = true
+			return m, tea.Batch(m.spinner.Tick, m.loadCmd())
+		}
+		var cmd tea.Cmd
+		m.table, cmd = m.table.Update(msg)
+		return m, cmd
+	default:
+		if m.loading {
+			var cmd tea.Cmd
+			m.spinner, cmd = m.spinner.Update(msg)
+			return m, cmd
+		}
+	}
+	return m, nil
+}
+
+// firewallRuleFromForm validates the new rule form values and resolves the
+// policy to append the rule to.
+func firewallRuleFromForm(v []string, policies []client.FirewallPolicy) (client.FirewallRuleInput, string, error) {
+	in := client.FirewallRuleInput{Name: v[0], Action: fwrules.Action(strings.ToLower(v[1])), Protocol: fwrules.Protocol(strings.ToLower(v[2])), SourceIPAddress: v[3], SourcePort: v[4], DestinationIPAddress: v[5], DestinationPort: v[6]}
+	switch in.Action {
+	case fwrules.ActionAllow, fwrules.ActionDeny, fwrules.ActionReject:
+	default:
+		return in, "", errors.New("action must be allow, deny or reject")
+	}
+	switch in.Protocol {
+	case fwrules.ProtocolTCP, fwrules.ProtocolUDP:
+	case fwrules.ProtocolICMP, fwrules.ProtocolAny, "":
+		if in.SourcePort != "" || in.DestinationPort != "" {
+			return in, "", errors.New("ports need protocol tcp or udp")
+		}
+		if in.Protocol == "" {
+			in.Protocol = fwrules.ProtocolAny
+		}
+	default:
+		return in, "", errors.New("protocol must be tcp, udp, icmp or any")
+	}
+	switch v[7] {
+	case "4", "":
+		in.IPVersion = gophercloud.IPv4
+	case "6":
+		in.IPVersion = gophercloud.IPv6
+	default:
+		return in, "", errors.New("IP version must be 4 or 6")
+	}
+	for _, cidr := range []string{in.SourceIPAddress, in.DestinationIPAddress} {
+		if cidr == "" {
+			continue
+		}
+		if _, _, err := net.ParseCIDR(cidr); err != nil && net.ParseIP(cidr) == nil {
+			return in, "", fmt.Errorf("%q is not an IP address or CIDR", cidr)
+		}
+	}
+	if v[8] == "" {
+		return in, "", nil
+	}
+	for _, p := range policies {
+		if p.ID == v[8] || p.Name == v[8] {
+			return in, p.ID, nil
+		}
+	}
+	return in, "", fmt.Errorf("firewall policy %s not found", v[8])
+}
+
+// updateForm handles keys for the new rule form.
+func (m FirewallModel) updateForm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	fm, cmd := m.form.Update(msg)
+	f := fm.(common.FormModel)
+	m.form = &f
+	switch {
+	case f.Cancelled():
+		m.form = nil
+	case f.Submitted():
+		in, policyID, err := firewallRuleFromForm(f.Values(), m.data.policies)
+		if err != nil {
+			m.form.SetError(err)
+			return m, nil
+		}
+		m.form = nil
+		nc := m.client
+		return m, func() tea.Msg {
+			created, err := nc.CreateFirewallRule(context.Background(), in, policyID)
+			if err != nil {
+				return changeDoneMsg{action: "creating a firewall rule", err: err}
+			}
+			return changeDoneMsg{status: i18n.T("Created firewall rule %s", created.ID)}
+		}
+	}
+	return m, cmd
+}
+
+// CapturingInput reports whether the form or the delete prompt is open.
+func (m FirewallModel) CapturingInput() bool { return m.form != nil || m.pendingDelete != "" }
+
+// ConfirmingDestructive reports whether the delete prompt is open.
+func (m FirewallModel) ConfirmingDestructive() bool { return m.pendingDelete != "" }
+
+// refreshTable rebuilds the table for the current mode.
+func (m *FirewallModel) refreshTable() {
+	idW, statusW := uiconst.ColWidthUUID, uiconst.ColWidthStatus
+	rest := m.width - idW - statusW - uiconst.TableHeightOffset
+	if rest < 60 {
+		rest = 60
+	}
+	var cols []table.Column
+	var rows []table.Row
+	switch m.mode {
+	case fwModeGroups:
+		w := rest / 4
+		cols = []table.Column{{Title: "ID", Width: idW}, {Title: i18n.T("Name"), Width: w}, {Title: i18n.T("Ingress policy"), Width: w}, {Title: i18n.T("Egress policy"), Width: w}, {Title: i18n.T("Ports"), Width: rest - 3*w}, {Title: i18n.T("Status"), Width: statusW}}
+		for _, g := range m.data.groups {
+			ports := make([]string, 0, len(g.Ports))
+			for _, p := range g.Ports {
+				ports = append(ports, portLabel(m.data.ports, p))
+			}
+			rows = append(rows, table.Row{g.ID, g.Name, m.data.policyName(g.IngressFirewallPolicyID), m.data.policyName(g.EgressFirewallPolicyID), strings.Join(ports, ", "), g.Status})
+		}
+	case fwModePolicies:
+		w := rest / 3
+		cols = []table.Column{{Title: "ID", Width: idW}, {Title: i18n.T("Name"), Width: w}, {Title: i18n.T("Rules"), Width: 6}, {Title: i18n.T("Audited"), Width: 8}, {Title: i18n.T("Used by"), Width: rest - w - 14 + statusW}}
+		for _, p := range m.data.policies {
+			used := strings.Join(m.data.policyGroups(p.ID), ", ")
+			if p.Shared {
+				used = strings.TrimPrefix(used+", shared", ", ")
+			}
+			rows = append(rows, table.Row{p.ID, p.Name, fmt.Sprintf("%d", len(p.Rules)), fmt.Sprintf("%t", p.Audited), used})
+		}
+	case fwModeRules:
+		w := rest / 5
+		cols = []table.Column{{Title: "ID", Width: idW}, {Title: i18n.T("Name"), Width: w}, {Title: i18n.T("Action"), Width: 7}, {Title: i18n.T("Protocol"), Width: 8}, {Title: i18n.T("Source"), Width: w}, {Title: i18n.T("Destination"), Width: w}, {Title: i18n.T("Policies"), Width: rest - 3*w - 15}, {Title: i18n.T("Enabled"), Width: statusW}}
+		for _, r := range m.data.rules {
+			proto := r.Protocol
+			if proto == "" {
+				proto = "any"
+			}
+			var pols []string
+			for _, id := range r.FirewallPolicyID {
+				pols = append(pols, m.data.policyName(id))
+			}
+			rows = append(rows, table.Row{r.ID, r.Name, r.Action, proto, fwEndpoint(r.SourceIPAddress, r.SourcePort), fwEndpoint(r.DestinationIPAddress, r.DestinationPort), strings.Join(pols, ", "), fmt.Sprintf("%t", r.Enabled)})
+		}
+	}
+	m.table = table.New(table.WithColumns(cols), table.WithFocused(true))
+	m.table.SetStyles(table.DefaultStyles())
+	m.table.SetRows(rows)
+	m.table.SetHeight(m.height - uiconst.TableHeightOffset - 4)
+}
+
+// selectionLine details the selected row: the ports of a group, or the
+// ordered rules of a policy.
+func (m FirewallModel) selectionLine() string {
+	row := m.table.SelectedRow()
+	if len(row) == 0 {
+		return ""
+	}
+	switch m.mode {
+	case fwModeGroups:
+		for _, g := range m.data.groups {
+			if g.ID != row[0] {
+				continue
+			}
+			if len(g.Ports) == 0 {
+				return "Not applied to any port"
+			}
+			ports := make([]string, 0, len(g.Ports))
+			for _, p := range g.Ports {
+				ports = append(ports, portLabel(m.data.ports, p))
+			}
+			return "Applied to: " + strings.Join(ports, ", ")
+		}
+	case fwModePolicies:
+		for _, p := range m.data.policies {
+			if p.ID != row[0] {
+				continue
+			}
+			if len(p.Rules) == 0 {
+				return i18n.T("No rules")
+			}
+			rules := make([]string, 0, len(p.Rules))
+			for i, id := range p.Rules {
+				rules = append(rules, fmt.Sprintf("%d. %s", i+1, m.data.ruleName(id)))
+			}
+			return "Rules in order: " + strings.Join(rules, "  ")
+		}
+	}
+	return ""
+}
+
+// View renders the tab bar, the current table and the selection details.
+func (m FirewallModel) View() string {
+	if m.form != nil {
+		return i18n.T("New firewall rule") + "\n\n" + m.form.View() + "\n" + i18n.T("[tab] next field  [enter] next/create  [esc] cancel")
+	}
+	if m.loading {
+		return m.spinner.View()
+	}
+	if m.err != nil {
+		return i18n.T("Error: %s", m.err)
+	}
+	active := lipgloss.NewStyle().Bold(true).Foreground(theme.Accent)
+	dim := lipgloss.NewStyle().Foreground(theme.Muted)
+	var tabs []string
+	for _, mode := range fwModes {
+		if mode == m.mode {
+			tabs = append(tabs, active.Render("["+i18n.T(fwModeTitles[mode])+"]"))
+		} else {
+			tabs = append(tabs, dim.Render(" "+i18n.T(fwModeTitles[mode])+" "))
+		}
+	}
+	out := strings.Join(tabs, " ") + "\n" + m.table.View() + "\n" + dim.Render(m.selectionLine())
+	out += changeStatusLine(m.status, m.statusErr, m.pendingDelete, "firewall rule")
+	help := i18n.T("[tab] switch list") + "  " + policy.Key(policy.Member, i18n.T("[n] new rule"), "creating a firewall rule") + "  " + i18n.T("[r] refresh")
+	if m.mode == fwModeRules {
+		help = i18n.T("[tab] switch list") + "  " + policy.Key(policy.Member, i18n.T("[n] new rule"), "creating a firewall rule") + "  " + i18n.T("[x] delete rule  [r] refresh")
+	}
+	return out + "\n" + help
+}
+
+// Table returns the table of the current mode.
+func (m FirewallModel) Table() table.Model { return m.table }
+
+var _ tea.Model = (*FirewallModel)(nil)
@@ -1,6 +1,7 @@
 package network
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/charmbracelet/bubbles/spinner"
@@ -8,6 +9,8 @@ import (
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"ostui/internal/client"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/common"
 	"ostui/internal/ui/uiconst"
 )
 
@@ -17,6 +20,9 @@ type floatingIPInfo struct {
 	FixedIP           string `json:"fixed_ip"`
 	PortID            string `json:"port_id"`
 	Status            string `json:"status"`
+	Description       string `json:"description"`
+	DNSName           string `json:"dns_name"`
+	DNSDomain         string `json:"dns_domain"`
 }
 
 type FloatingIPDetailModel struct {
@@ -42,6 +48,17 @@ func (m FloatingIPDetailModel) ResourceID() string { return m.fipID }
 // ResourceName returns a display name for the floating IP (using ID).
 func (m FloatingIPDetailModel) ResourceName() string { return m.fipID }
 
+// Actions lists the views of the floating IP for the action palette.
+func (m FloatingIPDetailModel) Actions() []common.Action {
+	if m.loading || m.err != nil {
+		return nil
+	}
+	return []common.Action{
+		{Key: "i", Name: "inspect", Help: "Inspect the floating IP"},
+		{Key: "y", Name: "json", Help: "JSON view"},
+	}
+}
+
 type floatingIPDetailDataLoadedMsg struct {
 	tbl     table.Model
 	err     error
@@ -58,36 +75,23 @@ func NewFloatingIPDetailModel(nc client.NetworkClient, fipID string) FloatingIPD
 // Init starts async loading of floating IP details.
 func (m FloatingIPDetailModel) Init() tea.Cmd {
 	return func() tea.Msg {
-		fipList, err := m.client.ListFloatingIPs()
+		fipList, err := m.client.ListFloatingIPDetails(context.Background())
 		if err != nil {
 			return floatingIPDetailDataLoadedMsg{err: err}
 		}
-		var fip *struct {
-			ID                string
-			FloatingNetworkID string
-			FixedIP           string
-			PortID            string
-			Status            string
-		}
+		var fip *client.FloatingIP
 		// Find the floating IP with matching ID.
-		for _, f := range fipList {
-			if f.ID == m.fipID {
-				// Use a temporary struct to hold needed fields.
-				fip = &struct {
-					ID                string
-					FloatingNetworkID string
-					FixedIP           string
-					PortID            string
-					Status            string
-				}{ID: f.ID, FloatingNetworkID: f.FloatingNetworkID, FixedIP: f.FixedIP, PortID: f.PortID, Status: f.Status}
+		for i := range fipList {
+			if fipList[i].ID == m.fipID {
+				fip = &fipList[i]
 				break
 			}
 		}
 		if fip == nil {
 			return floatingIPDetailDataLoadedMsg{err: fmt.Errorf("floating IP %s not found", m.fipID)}
 		}
-		cols := []table.Column{{Title: "Field", Width: uiconst.ColWidthField}, {Title: "Value", Width: uiconst.ColWidthValueShort}, {Title: "Field", Width: uiconst.ColWidthField}, {Title: "Value", Width: uiconst.ColWidthValueShort}}
-		rows := []table.Row{{"ID", fip.ID}, {"FloatingNetworkID", fip.FloatingNetworkID}, {"FixedIP", fip.FixedIP}, {"PortID", fip.PortID}, {"Status", fip.Status}}
+		cols := []table.Column{{Title: i18n.T("Field"), Width: uiconst.ColWidthField}, {Title: i18n.T("Value"), Width: uiconst.ColWidthValueShort}, {Title: i18n.T("Field"), Width: uiconst.ColWidthField}, {Title: i18n.T("Value"), Width: uiconst.ColWidthValueShort}}
+		rows := []table.Row{{"ID", fip.ID}, {"FloatingIP", fip.FloatingIP.FloatingIP}, {"FloatingNetworkID", fip.FloatingNetworkID}, {"FixedIP", fip.FixedIP}, {"PortID", fip.PortID}, {"Status", fip.Status}, {"Description", fip.Description}, {"DNSName", fip.DNSName}, {"DNSDomain", fip.DNSDomain}}
 		half := (len(rows) + 1) / 2
 		newRows := []table.Row{}
 		for i := 0; i < half; i++ {
@@ -106,11 +110,23 @@ func (m FloatingIPDetailModel) Init() tea.Cmd {
 			table.WithFocused(true),
 		)
 		t.SetStyles(table.DefaultStyles())
-		fipInfo := floatingIPInfo{ID: fip.ID, FloatingNetworkID: fip.FloatingNetworkID, FixedIP: fip.FixedIP, PortID: fip.PortID, Status: fip.Status}
+		fipInfo := floatingIPInfo{ID: fip.ID, FloatingNetworkID: fip.FloatingNetworkID, FixedIP: fip.FixedIP, PortID: fip.PortID, Status: fip.Status, Description: fip.Description, DNSName: fip.DNSName, DNSDomain: fip.DNSDomain}
 		return floatingIPDetailDataLoadedMsg{tbl: t, fipInfo: fipInfo}
 	}
 }
 
+// Reload re-fetches the floating IP, keeping the selected row.
+func (m FloatingIPDetailModel) Reload() tea.Cmd {
+	if m.loading {
+		return nil
+	}
+	return m.Init()
+}
+
+// floatingIPLinks opens the port a floating IP is associated with and its
+// external network.
+var floatingIPLinks = common.Links{"PortID": common.KindPort, "FloatingNetworkID": common.KindNetwork}
+
 // Update handles messages.
 func (m FloatingIPDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
@@ -120,7 +136,7 @@ func (m FloatingIPDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.err = msg.err
 			return m, nil
 		}
-		m.table = msg.tbl
+		m.table = common.Reloaded(m.table, msg.tbl)
 		m.fipInfo = msg.fipInfo
 		return m, nil
 	case tea.WindowSizeMsg:
@@ -176,9 +192,12 @@ func (m FloatingIPDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.loading || m.err != nil {
 			return m, nil
 		}
+		if msg.String() == "enter" {
+			return m, floatingIPLinks.Open(m.table)
+		}
 		if msg.String() == "i" {
 			// Build inspect view for floating IP.
-			content := fmt.Sprintf("=== Floating IP: %s ===\nID: %s\nFloatingNetworkID: %s\nFixedIP: %s\nPortID: %s\nStatus: %s", m.fipInfo.ID, m.fipInfo.ID, m.fipInfo.FloatingNetworkID, m.fipInfo.FixedIP, m.fipInfo.PortID, m.fipInfo.Status)
+			content := i18n.T("=== Floating IP: %s ===\nID: %s\nFloatingNetworkID: %s\nFixedIP: %s\nPortID: %s\nStatus: %s\nDescription: %s\nDNSName: %s\nDNSDomain: %s", m.fipInfo.ID, m.fipInfo.ID, m.fipInfo.FloatingNetworkID, m.fipInfo.FixedIP, m.fipInfo.PortID, m.fipInfo.Status, m.fipInfo.Description, m.fipInfo.DNSName, m.fipInfo.DNSDomain)
 			m.inspectView = content
 			m.inspectViewport = viewport.New(80, 24)
 			m.inspectViewport.SetContent(m.inspectView)
@@ -214,18 +233,22 @@ func (m FloatingIPDetailModel) View() string {
 		return m.spinner.View()
 	}
 	if m.inspectView != "" {
-		return fmt.Sprintf("%s\n %3.f%% | [j/k] scroll  [esc] close", m.inspectViewport.View(), m.inspectViewport.ScrollPercent()*100)
+		return i18n.T("%s\n %3.f%% | [j/k] scroll  [esc] close", m.inspectViewport.View(), m.inspectViewport.ScrollPercent()*100)
 	}
 	if m.jsonView != "" {
-		return fmt.Sprintf("%s\nPress 'y' or 'esc' to close", m.jsonViewport.View())
+		return i18n.T("%s\nPress 'y' or 'esc' to close", m.jsonViewport.View())
 	}
 
 	if m.err != nil {
-		cols := []table.Column{{Title: "Error", Width: 80}}
+		cols := []table.Column{{Title: i18n.T("Error"), Width: 80}}
 		rows := []table.Row{{"Failed to load floating IP: " + m.err.Error()}}
 		return table.New(table.WithColumns(cols), table.WithRows(rows)).View()
 	}
-	return fmt.Sprintf("%s\n[y] json  [i] inspect  [g] graph  [esc] back", m.table.View())
+	hints := i18n.T("[y] json  [i] inspect  [g] graph  [E] edit  [esc] back")
+	if h := floatingIPLinks.Hint(m.table); h != "" {
+		hints = h + "  " + hints
+	}
+	return fmt.Sprintf("%s\n%s", m.table.View(), hints)
 }
 
 // Table returns the underlying table model.
@@ -0,0 +1,74 @@
+package network
+
+import (
+	"context"
+	"fmt"
+
+	"ostui/internal/client"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/editor"
+)
+
+// floatingIPEdit holds the mutable fields of a floating IP as edited in YAML.
+type floatingIPEdit struct {
+	Description string `yaml:"description"`
+	DNSName     string `yaml:"dns_name"`
+	DNSDomain   string `yaml:"dns_domain"`
+}
+
+// EditSpec implements editor.Editable for the floating IP detail view.
+func (m FloatingIPDetailModel) EditSpec() editor.Spec {
+	c, id := m.client, m.fipID
+	return editor.Spec{
+		Title: i18n.T("floating IP %s", id),
+		Load: func() (string, error) {
+			fips, err := c.ListFloatingIPDetails(context.Background())
+			if err != nil {
+				return "", err
+			}
+			for _, f := range fips {
+				if f.ID == id {
+					return editor.Encode(floatingIPEdit{Description: f.Description, DNSName: f.DNSName, DNSDomain: f.DNSDomain})
+				}
+			}
+			return "", fmt.Errorf("floating IP %s not found", id)
+		},
+		Apply: func(original, edited string) (string, error) {
+			return applyFloatingIPEdit(c, id, original, edited)
+		},
+	}
+}
+
+// applyFloatingIPEdit validates edited and sends the changed fields in one
+// update. Neutron only accepts DNS changes with the dns-integration
+// extension; its error is shown in the editor otherwise.
+func applyFloatingIPEdit(c client.NetworkClient, id, original, edited string) (string, error) {
+	var before, after floatingIPEdit
+	if err := editor.Decode(original, &before); err != nil {
+		return "", err
+	}
+	if err := editor.Decode(edited, &after); err != nil {
+		return "", err
+	}
+	changed := editor.ChangedFields(before, after)
+	if len(changed) == 0 {
+		return editor.Summary(nil), nil
+	}
+	if after.DNSDomain != "" && after.DNSDomain[len(after.DNSDomain)-1] != '.' {
+		return "", fmt.Errorf("dns_domain must be fully qualified and end with a dot, e.g. %q", after.DNSDomain+".")
+	}
+	var opts client.FloatingIPUpdate
+	if after.Description != before.Description {
+		opts.Description = &after.Description
+	}
+	if after.DNSName != before.DNSName {
+		opts.DNSName = &after.DNSName
+	}
+	if after.DNSDomain != before.DNSDomain {
+		opts.DNSDomain = &after.DNSDomain
+	}
+	if err := c.UpdateFloatingIP(context.Background(), id, opts); err != nil {
+		return "", err
+	}
+	return editor.Summary(changed), nil
+}
@@ -0,0 +1,89 @@
+package network
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/layer3/floatingips"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/networks"
+	"ostui/internal/client"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/ops"
+	"ostui/internal/ui/quota"
+)
+
+// OpenAllocateFloatingIPMsg asks the app to open the floating IP allocation
+// form.
+type OpenAllocateFloatingIPMsg struct{}
+
+// AllocateFloatingIPRequest is the floating IP allocation form: the external
+// network, by name or ID, and a description, checked against the floating
+// IP quota. The network may be left empty when there is only one.
+func AllocateFloatingIPRequest(nc client.NetworkClient) quota.Request {
+	var external []networks.Network
+	return quota.Request{
+		Title:  i18n.T("Allocate a floating IP"),
+		Action: "allocating a floating IP",
+		Fields: []string{"External network (name or ID)", "Description (optional)"},
+		Keys:   []string{quota.FloatingIPs},
+		Prepare: func(ctx context.Context) error {
+			var err error
+			external, err = nc.ListExternalNetworks(ctx)
+			return err
+		},
+		Need: func(v []string) (map[string]int, error) {
+			if _, err := externalNetwork(external, v[0]); err != nil {
+				return nil, err
+			}
+			return map[string]int{quota.FloatingIPs: 1}, nil
+		},
+		Create: func(ctx context.Context, v []string) (string, ops.Poll, error) {
+			net, err := externalNetwork(external, v[0])
+			if err != nil {
+				return "", nil, err
+			}
+			fip, err := nc.AllocateFloatingIP(floatingips.CreateOpts{FloatingNetworkID: net.ID, Description: v[1]})
+			if err != nil {
+				return "", nil, err
+			}
+			// The address is usable as soon as it is allocated.
+			return i18n.T("Allocated %s from %s", fip.FloatingIP, net.Name), nil, nil
+		},
+	}
+}
+
+// externalNetwork resolves ref against the external networks; an empty ref
+// picks the only one.
+func externalNetwork(list []networks.Network, ref string) (networks.Network, error) {
+	if ref == "" {
+		if len(list) == 1 {
+			return list[0], nil
+		}
+		if len(list) == 0 {
+			return networks.Network{}, errors.New("no external network to allocate from")
+		}
+		names := make([]string, len(list))
+		for i, n := range list {
+			names[i] = n.Name
+		}
+		return networks.Network{}, fmt.Errorf("name the external network: %s", strings.Join(names, ", "))
+	}
+	var found []networks.Network
+	for _, n := range list {
+		if n.ID == ref {
+			return n, nil
+		}
+		if n.Name == ref {
+			found = append(found, n)
+		}
+	}
+	switch len(found) {
+	case 0:
+		return networks.Network{}, fmt.Errorf("no external network %q", ref)
+	case 1:
+		return found[0], nil
+	}
+	return networks.Network{}, fmt.Errorf("%d external networks are named %q: use the ID", len(found), ref)
+}
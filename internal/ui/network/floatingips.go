@@ -1,24 +1,27 @@
 package network
 
 import (
+	"context"
 	"fmt"
 	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/table"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"ostui/internal/client"
+	"ostui/internal/i18n"
 	"ostui/internal/ui/common"
+	"ostui/internal/ui/search"
+	"ostui/internal/ui/theme"
 	"ostui/internal/ui/uiconst"
-	"strings"
+	"time"
 )
 
 type FloatingIPsModel struct {
-	table      table.Model
+	table      common.VirtualTable
 	loading    bool
 	err        error
 	spinner    spinner.Model
 	client     client.NetworkClient
-	allRows    []table.Row
 	filterMode bool
 	filter     textinput.Model
 
@@ -28,9 +31,9 @@ type FloatingIPsModel struct {
 }
 
 type floatingIPsDataLoadedMsg struct {
-	tbl  table.Model
-	rows []table.Row
-	err  error
+	rows    []table.Row
+	created []time.Time
+	err     error
 }
 
 // NewFloatingIPsModel creates a new FloatingIPsModel.
@@ -39,30 +42,46 @@ func NewFloatingIPsModel(nc client.NetworkClient) FloatingIPsModel {
 	s.Spinner = spinner.Dot
 	ti := textinput.New()
 	ti.Placeholder = "filter..."
-	return FloatingIPsModel{client: nc, loading: true, spinner: s, filter: ti, width: 120, height: 30}
+	m := FloatingIPsModel{client: nc, loading: true, spinner: s, filter: ti, width: 120, height: 30}
+	t := table.New(table.WithFocused(true), table.WithHeight(m.height-uiconst.TableHeightOffset))
+	t.SetStyles(table.DefaultStyles())
+	m.table = common.NewVirtualTable(t, styleFloatingIPRow)
+	m.updateTableColumns()
+	return m
+}
+
+// styleFloatingIPRow marks the status of a floating IP row on screen.
+func styleFloatingIPRow(r table.Row) table.Row {
+	out := append(table.Row(nil), r...)
+	out[4] = theme.Mark(out[4])
+	return out
 }
 
 // Init starts async loading of floating IPs.
 func (m FloatingIPsModel) Init() tea.Cmd {
 	return func() tea.Msg {
-		fipList, err := m.client.ListFloatingIPs()
+		fipList, err := m.client.ListFloatingIPDetails(context.Background())
 		if err != nil {
 			return floatingIPsDataLoadedMsg{err: err}
 		}
-		cols := []table.Column{{Title: "ID", Width: uiconst.ColWidthUUID}, {Title: "FloatingNetworkID", Width: uiconst.ColWidthUUID}, {Title: "FixedIP", Width: uiconst.ColWidthFixed}, {Title: "PortID", Width: uiconst.ColWidthUUID}, {Title: "Status", Width: uiconst.ColWidthStatus}}
-		rows := []table.Row{}
+		search.IndexFloatingIPs(fipList)
+		rows := make([]table.Row, 0, len(fipList))
+		created := make([]time.Time, 0, len(fipList))
+		now := time.Now()
 		for _, f := range fipList {
-			rows = append(rows, table.Row{f.ID, f.FloatingNetworkID, f.FixedIP, f.PortID, f.Status})
+			rows = append(rows, table.Row{f.ID, f.FloatingNetworkID, f.FixedIP, f.PortID, f.Status, common.Age(f.CreatedAt, now), fipDNSName(f), f.Description})
+			created = append(created, f.CreatedAt)
 		}
-		t := table.New(
-			table.WithColumns(cols),
-			table.WithRows(rows),
-			table.WithFocused(true),
-			table.WithHeight(m.height-uiconst.TableHeightOffset),
-		)
-		t.SetStyles(table.DefaultStyles())
-		return floatingIPsDataLoadedMsg{tbl: t, rows: rows}
+		return floatingIPsDataLoadedMsg{rows: common.TerraformRows(rows), created: created}
+	}
+}
+
+// Reload re-fetches the floating IPs, keeping the selected row.
+func (m FloatingIPsModel) Reload() tea.Cmd {
+	if m.loading || m.filterMode {
+		return nil
 	}
+	return m.Init()
 }
 
 // Update handles messages.
@@ -74,18 +93,13 @@ func (m FloatingIPsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.err = msg.err
 			return m, nil
 		}
-		m.table = msg.tbl
-		m.allRows = msg.rows
-		m.updateTableColumns()
-		m.table.SetHeight(m.height - uiconst.TableHeightOffset)
+		m.table.SetRowsCreated(msg.rows, msg.created)
 		return m, nil
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
-		if m.table.Columns() != nil {
-			m.table.SetHeight(m.height - uiconst.TableHeightOffset)
-			m.updateTableColumns()
-		}
+		m.table.SetHeight(m.height - uiconst.TableHeightOffset)
+		m.updateTableColumns()
 		return m, nil
 	case tea.KeyMsg:
 		if m.loading || m.err != nil {
@@ -102,30 +116,18 @@ func (m FloatingIPsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.filterMode = false
 			m.filter.Blur()
 			m.filter.SetValue("")
-			m.table.SetRows(m.allRows)
+			m.table.Filter("")
 			return m, nil
 		}
 		if m.filterMode {
 			var cmd tea.Cmd
 			m.filter, cmd = m.filter.Update(msg)
-			filterVal := m.filter.Value()
-			if filterVal == "" {
-				m.table.SetRows(m.allRows)
-			} else {
-				lower := strings.ToLower(filterVal)
-				filtered := []table.Row{}
-				for _, r := range m.allRows {
-					for _, c := range r {
-						if strings.Contains(strings.ToLower(c), lower) {
-							filtered = append(filtered, r)
-							break
-						}
-					}
-				}
-				m.table.SetRows(filtered)
-			}
+			m.table.Filter(m.filter.Value())
 			return m, cmd
 		}
+		if msg.String() == "n" {
+			return m, func() tea.Msg { return OpenAllocateFloatingIPMsg{} }
+		}
 		var cmd tea.Cmd
 		m.table, cmd = m.table.Update(msg)
 		return m, cmd
@@ -145,13 +147,13 @@ func (m FloatingIPsModel) View() string {
 		return m.spinner.View()
 	}
 	if m.err != nil {
-		cols := []table.Column{{Title: "Error", Width: uiconst.ColWidthError}}
+		cols := []table.Column{{Title: i18n.T("Error"), Width: uiconst.ColWidthError}}
 		rows := []table.Row{{"Failed to list floating IPs: " + m.err.Error()}}
 		return common.NewTable(cols, rows).View()
 	}
 	if m.filterMode {
-		filterLine := fmt.Sprintf("Filter: %s", m.filter.View())
-		footer := "esc: clear"
+		filterLine := i18n.T("Filter: %s", m.filter.View())
+		footer := i18n.T("%d of %d  esc: clear", m.table.Len(), m.table.Total())
 		return fmt.Sprintf("%s\n%s\n%s", filterLine, m.table.View(), footer)
 	}
 	return m.table.View()
@@ -163,16 +165,48 @@ func (m *FloatingIPsModel) updateTableColumns() {
 	fnetW := uiconst.ColWidthUUID
 	portIDW := uiconst.ColWidthUUID
 	statusW := uiconst.ColWidthStatus
-	// FixedIP column gets remaining space
-	fixedIPW := m.width - idW - fnetW - portIDW - statusW - uiconst.TableHeightOffset
-	if fixedIPW < 10 {
-		fixedIPW = 10
+	ageW := uiconst.ColWidthAge
+	fixedIPW := uiconst.ColWidthFixed
+	dnsW := uiconst.ColWidthName
+	// Description column gets remaining space
+	descW := m.width - idW - fnetW - fixedIPW - portIDW - statusW - ageW - dnsW - uiconst.TableHeightOffset - common.TerraformWidth()
+	if descW < 10 {
+		descW = 10
 	}
-	m.table.SetColumns([]table.Column{{Title: "ID", Width: idW}, {Title: "FloatingNetworkID", Width: fnetW}, {Title: "FixedIP", Width: fixedIPW}, {Title: "PortID", Width: portIDW}, {Title: "Status", Width: statusW}})
+	m.table.SetColumns(common.TerraformColumns([]table.Column{{Title: "ID", Width: idW}, {Title: i18n.T("FloatingNetworkID"), Width: fnetW}, {Title: i18n.T("FixedIP"), Width: fixedIPW}, {Title: i18n.T("PortID"), Width: portIDW}, {Title: i18n.T("Status"), Width: statusW}, {Title: common.AgeTitle(), Width: ageW}, {Title: "DNS", Width: dnsW}, {Title: i18n.T("Description"), Width: descW}}))
+}
+
+// fipDNSName joins the DNS name and domain of a floating IP into a FQDN.
+func fipDNSName(f client.FloatingIP) string {
+	if f.DNSName == "" {
+		return f.DNSDomain
+	}
+	if f.DNSDomain == "" {
+		return f.DNSName
+	}
+	return f.DNSName + "." + f.DNSDomain
 }
 
 // Ensure FloatingIPsModel implements tea.Model.
 // Table returns the underlying table model.
-func (m FloatingIPsModel) Table() table.Model { return m.table }
+func (m FloatingIPsModel) Table() table.Model { return m.table.Table() }
+
+// FullTable returns all the listed floating IPs, for the quick jump.
+func (m FloatingIPsModel) FullTable() table.Model { return m.table.FullTable() }
+
+// Filtering reports whether keys are typed into the filter.
+func (m FloatingIPsModel) Filtering() bool { return m.filterMode }
+
+// Actions lists what can be done to the selected floating IP for the action
+// palette.
+func (m FloatingIPsModel) Actions() []common.Action {
+	if m.loading || m.err != nil || m.filterMode || len(m.table.SelectedRow()) == 0 {
+		return nil
+	}
+	return []common.Action{
+		{Key: "enter", Name: "open", Help: "Open the floating IP detail"},
+		{Key: "/", Name: "filter", Help: "Filter the floating IPs"},
+	}
+}
 
 var _ tea.Model = (*FloatingIPsModel)(nil)
@@ -0,0 +1,78 @@
+package network
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/lipgloss"
+	"ostui/internal/client"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/theme"
+)
+
+// azList renders a list of zones, or what an empty list means.
+func azList(zones []string, empty string) string {
+	if len(zones) == 0 {
+		return empty
+	}
+	return strings.Join(zones, ", ")
+}
+
+// unscheduledHints returns the hinted zones the resource was not scheduled
+// to, e.g. because the zone has no agents left.
+func unscheduledHints(p client.AZPlacement) []string {
+	var out []string
+	for _, h := range p.Hints {
+		if !slices.Contains(p.Zones, h) {
+			out = append(out, h)
+		}
+	}
+	return out
+}
+
+// azPlacementRows lists the zone hints and scheduled zones for a detail
+// table.
+func azPlacementRows(p client.AZPlacement) []table.Row {
+	zones := azList(p.Zones, i18n.T("none yet"))
+	if missing := unscheduledHints(p); len(missing) > 0 {
+		zones += "  (not in hinted " + strings.Join(missing, ", ") + ")"
+	}
+	return []table.Row{
+		{"AZ hints", azList(p.Hints, i18n.T("none (any zone)"))},
+		{"Availability zones", zones},
+	}
+}
+
+// azPlacementLine renders the zone hints and scheduled zones on one line,
+// in the warning color when a hinted zone was not scheduled.
+func azPlacementLine(p client.AZPlacement) string {
+	line := i18n.T("AZ hints: %s  Scheduled in: %s", azList(p.Hints, i18n.T("none (any zone)")), azList(p.Zones, i18n.T("none yet")))
+	if missing := unscheduledHints(p); len(missing) > 0 {
+		return lipgloss.NewStyle().Foreground(theme.Warn).Render(line + "  (not in hinted " + strings.Join(missing, ", ") + ")")
+	}
+	return lipgloss.NewStyle().Foreground(theme.Muted).Render(line)
+}
+
+// parseAZHints splits comma-separated zone hints and checks them against
+// the zones with agents for resource ("network" or "router"). Listing the
+// zones may be refused, so they are only checked when known.
+func parseAZHints(s string, zones []client.NetworkAZ, resource string) ([]string, error) {
+	var hints, known []string
+	for _, az := range zones {
+		if az.Resource == resource {
+			known = append(known, az.Name)
+		}
+	}
+	for _, h := range strings.Split(s, ",") {
+		if h = strings.TrimSpace(h); h == "" {
+			continue
+		}
+		if len(zones) > 0 && !slices.Contains(known, h) {
+			return nil, fmt.Errorf("zone %s has no %s agents; known zones: %s", h, map[string]string{"network": "DHCP", "router": "L3"}[resource], azList(known, "none"))
+		}
+		hints = append(hints, h)
+	}
+	return hints, nil
+}
@@ -0,0 +1,75 @@
+package network
+
+import (
+	"context"
+
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/networks"
+	"ostui/internal/client"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/editor"
+)
+
+// networkEdit holds the mutable fields of a network as edited in YAML.
+type networkEdit struct {
+	Name         string `yaml:"name"`
+	Description  string `yaml:"description"`
+	AdminStateUp bool   `yaml:"admin_state_up"`
+	Shared       bool   `yaml:"shared"`
+}
+
+// EditSpec implements editor.Editable for the network view.
+func (m NetworkSubnetsModel) EditSpec() editor.Spec {
+	c, id := m.client, m.networkID
+	// revision is captured at load time so a concurrent change makes the
+	// update fail instead of being overwritten.
+	revision := new(int)
+	return editor.Spec{
+		Title: i18n.T("network %s", id),
+		Load: func() (string, error) {
+			n, err := c.GetNetwork(context.Background(), id)
+			if err != nil {
+				return "", err
+			}
+			*revision = n.RevisionNumber
+			return editor.Encode(networkEdit{Name: n.Name, Description: n.Description, AdminStateUp: n.AdminStateUp, Shared: n.Shared})
+		},
+		Apply: func(original, edited string) (string, error) {
+			return applyNetworkEdit(c, id, *revision, original, edited)
+		},
+	}
+}
+
+// applyNetworkEdit validates edited and sends the changed fields in one update.
+func applyNetworkEdit(c client.NetworkClient, id string, revision int, original, edited string) (string, error) {
+	var before, after networkEdit
+	if err := editor.Decode(original, &before); err != nil {
+		return "", err
+	}
+	if err := editor.Decode(edited, &after); err != nil {
+		return "", err
+	}
+	changed := editor.ChangedFields(before, after)
+	if len(changed) == 0 {
+		return editor.Summary(nil), nil
+	}
+	var opts networks.UpdateOpts
+	if after.Name != before.Name {
+		opts.Name = &after.Name
+	}
+	if after.Description != before.Description {
+		opts.Description = &after.Description
+	}
+	if after.AdminStateUp != before.AdminStateUp {
+		opts.AdminStateUp = &after.AdminStateUp
+	}
+	if after.Shared != before.Shared {
+		opts.Shared = &after.Shared
+	}
+	if revision > 0 {
+		opts.RevisionNumber = &revision
+	}
+	if err := c.UpdateNetwork(context.Background(), id, opts); err != nil {
+		return "", err
+	}
+	return editor.Summary(changed), nil
+}
@@ -0,0 +1,116 @@
+package network
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/networks"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/subnets"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/iac"
+)
+
+// ExportSpec implements iac.Exportable for the network view, exporting the
+// network with its subnets.
+func (m NetworkSubnetsModel) ExportSpec() iac.Spec {
+	c, id := m.client, m.networkID
+	return iac.Spec{
+		Title: i18n.T("network %s", id),
+		File:  id,
+		Load: func() (iac.Code, error) {
+			n, err := c.GetNetwork(context.Background(), id)
+			if err != nil {
+				return iac.Code{}, err
+			}
+			all, err := c.ListSubnets()
+			if err != nil {
+				return iac.Code{}, err
+			}
+			var subs []subnets.Subnet
+			for _, s := range all {
+				if s.NetworkID == id {
+					subs = append(subs, s)
+				}
+			}
+			return exportNetwork(*n, subs), nil
+		},
+	}
+}
+
+// exportNetwork renders a network and its subnets; the subnets refer to the
+// network block, so the two are created together.
+func exportNetwork(n networks.Network, subs []subnets.Subnet) iac.Code {
+	names := iac.Namer{}
+	const netType, subType = "openstack_networking_network_v2", "openstack_networking_subnet_v2"
+	netBlk := iac.Block{Type: netType, Name: names.Name(netType, n.Name, "network"), ID: n.ID, Attrs: []iac.Attr{
+		{Name: "name", Value: n.Name},
+		{Name: "description", Value: n.Description},
+		{Name: "admin_state_up", Value: n.AdminStateUp},
+		{Name: "availability_zone_hints", Value: n.AvailabilityZoneHints},
+		{Name: "tags", Value: n.Tags},
+	}}
+	args := []string{"network", "create"}
+	if n.Description != "" {
+		args = append(args, "--description", n.Description)
+	}
+	if !n.AdminStateUp {
+		args = append(args, "--disable")
+	}
+	// Sharing takes an admin; it is kept only when set.
+	if n.Shared {
+		netBlk.Attrs = append(netBlk.Attrs, iac.Attr{Name: "shared", Value: true})
+		args = append(args, "--share")
+	}
+	for _, z := range n.AvailabilityZoneHints {
+		args = append(args, "--availability-zone-hint", z)
+	}
+	for _, t := range n.Tags {
+		args = append(args, "--tag", t)
+	}
+	code := iac.Code{Resources: []iac.Block{netBlk}, Commands: []string{iac.Command(append(args, n.Name)...)}}
+
+	for _, s := range subs {
+		blk := iac.Block{Type: subType, Name: names.Name(subType, s.Name, "subnet"), ID: s.ID, Attrs: []iac.Attr{
+			{Name: "name", Value: s.Name},
+			{Name: "description", Value: s.Description},
+			{Name: "network_id", Value: iac.Ref(netBlk.Address() + ".id")},
+			{Name: "cidr", Value: s.CIDR},
+			{Name: "ip_version", Value: s.IPVersion},
+			{Name: "gateway_ip", Value: s.GatewayIP},
+			{Name: "enable_dhcp", Value: s.EnableDHCP},
+			{Name: "dns_nameservers", Value: s.DNSNameservers},
+			{Name: "ipv6_address_mode", Value: s.IPv6AddressMode},
+			{Name: "ipv6_ra_mode", Value: s.IPv6RAMode},
+			{Name: "tags", Value: s.Tags},
+		}}
+		args := []string{"subnet", "create", "--network", n.Name, "--subnet-range", s.CIDR, "--ip-version", fmt.Sprint(s.IPVersion)}
+		if s.GatewayIP == "" {
+			blk.Attrs = append(blk.Attrs, iac.Attr{Name: "no_gateway", Value: true})
+			args = append(args, "--gateway", "none")
+		} else {
+			args = append(args, "--gateway", s.GatewayIP)
+		}
+		if !s.EnableDHCP {
+			args = append(args, "--no-dhcp")
+		}
+		for _, d := range s.DNSNameservers {
+			args = append(args, "--dns-nameserver", d)
+		}
+		if s.IPv6AddressMode != "" {
+			args = append(args, "--ipv6-address-mode", s.IPv6AddressMode)
+		}
+		if s.IPv6RAMode != "" {
+			args = append(args, "--ipv6-ra-mode", s.IPv6RAMode)
+		}
+		for _, p := range s.AllocationPools {
+			blk.Blocks = append(blk.Blocks, iac.Block{Type: "allocation_pool", Attrs: []iac.Attr{{Name: "start", Value: p.Start}, {Name: "end", Value: p.End}}})
+			args = append(args, "--allocation-pool", "start="+p.Start+",end="+p.End)
+		}
+		for _, r := range s.HostRoutes {
+			args = append(args, "--host-route", "destination="+r.DestinationCIDR+",gateway="+r.NextHop)
+		}
+		code.Resources = append(code.Resources, blk)
+		code.Commands = append(code.Commands, iac.Command(append(args, s.Name)...))
+	}
+	return code
+}
@@ -1,16 +1,32 @@
 package network
 
 import (
+	"context"
 	"fmt"
 	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/table"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/networks"
 	"ostui/internal/client"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/common"
+	"ostui/internal/ui/policy"
+	"ostui/internal/ui/theme"
 	"ostui/internal/ui/uiconst"
 	"strings"
 )
 
+// Tabs of the network view.
+const (
+	tabNetworkSubnets = iota
+	tabNetworkDetails
+)
+
+// NetworkSubnetsModel shows a network: its subnets, and on the second tab
+// its attributes, from the MTU to the provider segmentation and RBAC
+// grants.
 type NetworkSubnetsModel struct {
 	table      table.Model
 	loading    bool
@@ -23,6 +39,20 @@ type NetworkSubnetsModel struct {
 	filter     textinput.Model
 	width      int
 	height     int
+	// azs is the zone placement of the network, when it could be read.
+	azs *client.AZPlacement
+
+	tab     int
+	details table.Model
+	// network and detail are nil when they could not be read; ports is -1
+	// then.
+	network *networks.Network
+	detail  *client.NetworkDetail
+	ports   int
+	// confirmAdmin is set while the admin state toggle waits for y.
+	confirmAdmin bool
+	status       string
+	statusErr    bool
 }
 
 // ResourceID returns the network ID.
@@ -32,9 +62,13 @@ func (m NetworkSubnetsModel) ResourceID() string { return m.networkID }
 func (m NetworkSubnetsModel) ResourceName() string { return m.networkID }
 
 type networkSubnetsDataLoadedMsg struct {
-	tbl  table.Model
-	rows []table.Row
-	err  error
+	tbl     table.Model
+	rows    []table.Row
+	azs     *client.AZPlacement
+	network *networks.Network
+	detail  *client.NetworkDetail
+	ports   int
+	err     error
 }
 
 // NewNetworkSubnetsModel creates a new NetworkSubnetsModel for the given network ID.
@@ -54,7 +88,7 @@ func (m NetworkSubnetsModel) Init() tea.Cmd {
 			return networkSubnetsDataLoadedMsg{err: err}
 		}
 		// Filter subnets belonging to the network.
-		cols := []table.Column{{Title: "ID", Width: uiconst.ColWidthUUID}, {Title: "Name", Width: uiconst.ColWidthName}, {Title: "CIDR", Width: uiconst.ColWidthCIDR}, {Title: "IPVer", Width: uiconst.ColWidthIPVersion}}
+		cols := []table.Column{{Title: "ID", Width: uiconst.ColWidthUUID}, {Title: i18n.T("Name"), Width: uiconst.ColWidthName}, {Title: "CIDR", Width: uiconst.ColWidthCIDR}, {Title: i18n.T("IPVer"), Width: uiconst.ColWidthIPVersion}}
 		rows := []table.Row{}
 		for _, s := range subList {
 			if s.NetworkID == m.networkID {
@@ -68,8 +102,31 @@ func (m NetworkSubnetsModel) Init() tea.Cmd {
 			table.WithHeight(m.height-uiconst.TableHeightOffset),
 		)
 		t.SetStyles(table.DefaultStyles())
-		return networkSubnetsDataLoadedMsg{tbl: t, rows: rows}
+		msg := networkSubnetsDataLoadedMsg{tbl: t, rows: rows}
+		if azs, err := m.client.GetNetworkAZs(context.Background(), m.networkID); err == nil {
+			msg.azs = &azs
+		}
+		// The details tab shows what could be read.
+		msg.ports = -1
+		if n, err := m.client.GetNetwork(context.Background(), m.networkID); err == nil {
+			msg.network = n
+		}
+		if d, err := m.client.GetNetworkDetail(context.Background(), m.networkID); err == nil {
+			msg.detail = &d
+		}
+		if ps, err := m.client.ListPortsByNetwork(context.Background(), m.networkID); err == nil {
+			msg.ports = len(ps)
+		}
+		return msg
+	}
+}
+
+// Reload re-fetches the subnets of the network, keeping the selected row.
+func (m NetworkSubnetsModel) Reload() tea.Cmd {
+	if m.loading || m.filterMode {
+		return nil
 	}
+	return m.Init()
 }
 
 // Update handles messages.
@@ -81,23 +138,70 @@ func (m NetworkSubnetsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.err = msg.err
 			return m, nil
 		}
-		m.table = msg.tbl
+		m.table = common.Reloaded(m.table, msg.tbl)
 		m.allRows = msg.rows
+		m.azs = msg.azs
+		m.network, m.detail, m.ports = msg.network, msg.detail, msg.ports
 		m.updateTableColumns()
 		m.table.SetHeight(m.height - 6)
+		m.refreshDetails()
 		return m, nil
+	case changeDoneMsg:
+		m.status, m.statusErr = msg.status, msg.err != nil
+		if msg.err != nil {
+			policy.Record(msg.action, msg.err)
+			m.status = msg.err.Error()
+			return m, nil
+		}
+		m.loading = true
+		return m, tea.Batch(m.spinner.Tick, m.Init())
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
 		if !m.loading {
 			m.updateTableColumns()
 			m.table.SetHeight(m.height - uiconst.TableHeightOffset)
+			m.refreshDetails()
 		}
 		return m, nil
 	case tea.KeyMsg:
 		if m.loading || m.err != nil {
 			return m, nil
 		}
+		if m.confirmAdmin {
+			m.confirmAdmin = false
+			if msg.String() != "y" {
+				m.status, m.statusErr = "", false
+				return m, nil
+			}
+			up := !m.network.AdminStateUp
+			m.status, m.statusErr = i18n.T("Setting the admin state %s…", adminState(up)), false
+			return m, setNetworkAdminStateCmd(m.client, *m.network, up)
+		}
+		if m.tab == tabNetworkDetails {
+			switch msg.String() {
+			case "tab":
+				m.tab = tabNetworkSubnets
+				return m, nil
+			case "a":
+				if m.network == nil {
+					return m, nil
+				}
+				if err := policy.Check(policy.Member, "changing the admin state of a network"); err != nil {
+					m.status, m.statusErr = err.Error(), true
+					return m, nil
+				}
+				m.confirmAdmin = true
+				return m, nil
+			}
+			var cmd tea.Cmd
+			m.details, cmd = m.details.Update(msg)
+			return m, cmd
+		}
+		if !m.filterMode && msg.String() == "tab" {
+			m.tab = tabNetworkDetails
+			return m, nil
+		}
 		// Filter mode handling
 		if !m.filterMode && msg.String() == "/" {
 			m.filterMode = true
@@ -133,6 +237,11 @@ func (m NetworkSubnetsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			return m, cmd
 		}
+		// Open the DHCP agents and leases of the network.
+		if msg.String() == "d" {
+			open := OpenNetworkDHCPMsg{NetworkID: m.networkID}
+			return m, func() tea.Msg { return open }
+		}
 		var cmd tea.Cmd
 		m.table, cmd = m.table.Update(msg)
 		return m, cmd
@@ -152,15 +261,43 @@ func (m NetworkSubnetsModel) View() string {
 		return m.spinner.View()
 	}
 	if m.err != nil {
-		cols := []table.Column{{Title: "Error", Width: uiconst.ColWidthError}}
+		cols := []table.Column{{Title: i18n.T("Error"), Width: uiconst.ColWidthError}}
 		rows := []table.Row{{"Failed to list subnets: " + m.err.Error()}}
 		return table.New(table.WithColumns(cols), table.WithRows(rows)).View()
 	}
-	return fmt.Sprintf("%s\n[g] graph  [esc] back", m.table.View())
+	if m.tab == tabNetworkDetails {
+		out := "Details  " + networkTabs() + "\n" + m.details.View()
+		if m.confirmAdmin {
+			return out + "\n" + i18n.T("Set the admin state of network %s %s? [y/N]", m.network.Name, adminState(!m.network.AdminStateUp))
+		}
+		return out + changeStatusLine(m.status, m.statusErr, "", "") + "\n" + policy.Key(policy.Member, i18n.T("[a] toggle admin state"), "changing the admin state of a network") + "  " + i18n.T("[g] graph  [d] DHCP  [esc] back")
+	}
+	out := m.table.View()
+	if m.azs != nil {
+		out = azPlacementLine(*m.azs) + "\n" + out
+	}
+	out = "Subnets  " + networkTabs() + "\n" + out
+	return i18n.T("%s\n[g] graph  [d] DHCP  [esc] back", out)
+}
+
+// networkTabs names the tabs of the network view.
+func networkTabs() string {
+	return lipgloss.NewStyle().Foreground(theme.Muted).Render(i18n.T("[tab] subnets / details"))
+}
+
+// Table returns the table of the current tab.
+func (m NetworkSubnetsModel) Table() table.Model {
+	if m.tab == tabNetworkDetails {
+		return m.details
+	}
+	return m.table
 }
 
-// Table returns the underlying table model.
-func (m NetworkSubnetsModel) Table() table.Model { return m.table }
+// CapturingInput reports whether the admin state toggle waits for y.
+func (m NetworkSubnetsModel) CapturingInput() bool { return m.confirmAdmin }
+
+// Filtering reports whether keys are typed into the filter.
+func (m NetworkSubnetsModel) Filtering() bool { return m.filterMode }
 
 func (m *NetworkSubnetsModel) updateTableColumns() {
 	if len(m.table.Columns()) > 0 {
@@ -173,8 +310,113 @@ func (m *NetworkSubnetsModel) updateTableColumns() {
 		if nameW < 10 {
 			nameW = 10
 		}
-		m.table.SetColumns([]table.Column{{Title: "ID", Width: idW}, {Title: "Name", Width: nameW}, {Title: "CIDR", Width: cidrW}, {Title: "IPVer", Width: ipverW}})
+		m.table.SetColumns([]table.Column{{Title: "ID", Width: idW}, {Title: i18n.T("Name"), Width: nameW}, {Title: "CIDR", Width: cidrW}, {Title: i18n.T("IPVer"), Width: ipverW}})
 	}
 }
 
 var _ tea.Model = (*NetworkSubnetsModel)(nil)
+
+// adminState renders an admin state.
+func adminState(up bool) string {
+	if up {
+		return "up"
+	}
+	return "down"
+}
+
+// flag renders a flag of the details tab.
+func flag(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
+
+// networkDetailRows lists the attributes of a network for the details tab.
+// ports is -1 when the ports could not be listed.
+func networkDetailRows(n *networks.Network, d *client.NetworkDetail, ports int, azs *client.AZPlacement) []table.Row {
+	var rows []table.Row
+	if n != nil {
+		rows = append(rows,
+			table.Row{"ID", n.ID},
+			table.Row{i18n.T("Name"), n.Name},
+			table.Row{i18n.T("Status"), n.Status},
+			table.Row{i18n.T("Admin state"), adminState(n.AdminStateUp)},
+			table.Row{i18n.T("Shared"), flag(n.Shared)},
+		)
+	}
+	if d != nil {
+		mtu := "unknown"
+		if d.MTU > 0 {
+			mtu = fmt.Sprintf("%d", d.MTU)
+		}
+		rows = append(rows, table.Row{i18n.T("External (router:external)"), flag(d.External)}, table.Row{"MTU", mtu})
+		if d.NetworkType == "" {
+			rows = append(rows, table.Row{i18n.T("Provider"), i18n.T("hidden (admin only)")})
+		} else {
+			segment := "none"
+			if d.SegmentationID != nil {
+				segment = fmt.Sprintf("%d", *d.SegmentationID)
+			}
+			physical := d.PhysicalNetwork
+			if physical == "" {
+				physical = "none"
+			}
+			rows = append(rows,
+				table.Row{i18n.T("Network type"), d.NetworkType},
+				table.Row{i18n.T("Physical network"), physical},
+				table.Row{i18n.T("Segmentation ID"), segment},
+			)
+		}
+		if len(d.Grants) == 0 {
+			rows = append(rows, table.Row{"RBAC", i18n.T("no grants listed")})
+		}
+		for _, g := range d.Grants {
+			target := g.Target
+			if target == "*" {
+				target = "all projects"
+			}
+			rows = append(rows, table.Row{"RBAC", g.Action + " → " + target})
+		}
+	}
+	if ports >= 0 {
+		rows = append(rows, table.Row{i18n.T("Ports"), fmt.Sprintf("%d", ports)})
+	}
+	if azs != nil {
+		rows = append(rows, azPlacementRows(*azs)...)
+	}
+	return rows
+}
+
+// refreshDetails rebuilds the details table, keeping its cursor.
+func (m *NetworkSubnetsModel) refreshDetails() {
+	valueW := m.width - 30 - 6
+	if valueW < 20 {
+		valueW = 20
+	}
+	cursor := m.details.Cursor()
+	m.details = table.New(
+		table.WithColumns([]table.Column{{Title: i18n.T("Field"), Width: 30}, {Title: i18n.T("Value"), Width: valueW}}),
+		table.WithRows(networkDetailRows(m.network, m.detail, m.ports, m.azs)),
+		table.WithFocused(true),
+		table.WithHeight(m.height-uiconst.TableHeightOffset-1),
+	)
+	m.details.SetStyles(table.DefaultStyles())
+	m.details.SetCursor(cursor)
+}
+
+// setNetworkAdminStateCmd sets the admin state of n, failing when the
+// network changed since it was read.
+func setNetworkAdminStateCmd(nc client.NetworkClient, n networks.Network, up bool) tea.Cmd {
+	return func() tea.Msg {
+		opts := networks.UpdateOpts{AdminStateUp: &up}
+		if n.RevisionNumber > 0 {
+			rev := n.RevisionNumber
+			opts.RevisionNumber = &rev
+		}
+		if err := nc.UpdateNetwork(context.Background(), n.ID, opts); err != nil {
+			return changeDoneMsg{action: "changing the admin state of a network", err: err}
+		}
+		return changeDoneMsg{status: i18n.T("Network %s is administratively %s", n.Name, adminState(up))}
+	}
+}
@@ -4,10 +4,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/gophercloud/gophercloud"
+
 	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/layer3/floatingips"
 	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/layer3/routers"
 	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/security/groups"
@@ -15,214 +20,910 @@ import (
 	"github.com/gophercloud/gophercloud/openstack/networking/v2/networks"
 	"github.com/gophercloud/gophercloud/openstack/networking/v2/ports"
 	"github.com/gophercloud/gophercloud/openstack/networking/v2/subnets"
+	"ostui/internal/client"
+	"ostui/internal/client/clienttest"
+	"ostui/internal/ui/common"
+	"ostui/internal/ui/policy"
 )
 
-type mockNetworkClient struct {
-	networks []networks.Network
-	netErr   error
-
-	subnets []subnets.Subnet
-	subErr  error
+func TestRenderNetworksSuccess(t *testing.T) {
+	out := RenderNetworks(clienttest.NewNetwork(networks.Network{ID: "net-1", Name: "net1", Status: "ACTIVE"}))
+	if !strings.Contains(out, "net1") {
+		t.Fatalf("expected network name in output, got %s", out)
+	}
+}
 
-	floatingIPs []floatingips.FloatingIP
-	fipErr      error
+func TestRenderNetworksError(t *testing.T) {
+	nc := clienttest.NewNetwork()
+	nc.Fail("ListNetworks", errors.New("list error"))
+	out := RenderNetworks(nc)
+	if !strings.Contains(out, "Failed to list networks") {
+		t.Fatalf("expected error message, got %s", out)
+	}
+}
 
-	allocated floatingips.FloatingIP
-	allocErr  error
+func TestRenderSubnetsSuccess(t *testing.T) {
+	out := RenderSubnets(clienttest.NewNetwork().WithSubnets(subnets.Subnet{ID: "sub-1", Name: "sub1", CIDR: "10.0.0.0/24", IPVersion: 4}))
+	if !strings.Contains(out, "sub1") {
+		t.Fatalf("expected subnet name in output, got %s", out)
+	}
+}
 
-	releaseErr error
+func TestRenderSubnetsError(t *testing.T) {
+	nc := clienttest.NewNetwork()
+	nc.Fail("ListSubnets", errors.New("list error"))
+	out := RenderSubnets(nc)
+	if !strings.Contains(out, "Failed to list subnets") {
+		t.Fatalf("expected error message, got %s", out)
+	}
+}
 
-	associate floatingips.FloatingIP
-	assocErr  error
+func TestRenderFloatingIPsSuccess(t *testing.T) {
+	nc := &clienttest.Network{FloatingIPs: []client.FloatingIP{{FloatingIP: floatingips.FloatingIP{ID: "fip-1", FloatingNetworkID: "net-1", FixedIP: "10.0.0.5", PortID: "port-1", Status: "ACTIVE"}}}}
+	out := RenderFloatingIPs(nc)
+	if !strings.Contains(out, "fip-1") {
+		t.Fatalf("expected floating IP ID in output, got %s", out)
+	}
+}
 
-	disassociate floatingips.FloatingIP
-	disassocErr  error
+func TestRenderFloatingIPsError(t *testing.T) {
+	nc := clienttest.NewNetwork()
+	nc.Fail("ListFloatingIPs", errors.New("list error"))
+	out := RenderFloatingIPs(nc)
+	if !strings.Contains(out, "Failed to list floating IPs") {
+		t.Fatalf("expected error message, got %s", out)
+	}
+}
 
-	secGroups []groups.SecGroup
-	secErr    error
+func TestRenderSecurityGroupsSuccess(t *testing.T) {
+	out := RenderSecurityGroups(&clienttest.Network{SecurityGroups: []groups.SecGroup{{ID: "sg-1", Name: "sg1", Description: "desc", Stateful: true}}})
+	if !strings.Contains(out, "sg1") {
+		t.Fatalf("expected security group name in output, got %s", out)
+	}
 }
 
-func (m *mockNetworkClient) ListNetworks() ([]networks.Network, error) {
-	return m.networks, m.netErr
+func TestRenderSecurityGroupsError(t *testing.T) {
+	nc := clienttest.NewNetwork()
+	nc.Fail("ListSecurityGroups", errors.New("list error"))
+	out := RenderSecurityGroups(nc)
+	if !strings.Contains(out, "Failed to list security groups") {
+		t.Fatalf("expected error message, got %s", out)
+	}
 }
-func (m *mockNetworkClient) ListSubnets() ([]subnets.Subnet, error) {
-	return m.subnets, m.subErr
+
+func TestRenderSecurityGroupDetailSuccess(t *testing.T) {
+	nc := &clienttest.Network{SecurityGroups: []groups.SecGroup{{ID: "sg-1", Name: "sg1", Description: "desc", Stateful: true, TenantID: "tenant", ProjectID: "proj", CreatedAt: time.Now(), UpdatedAt: time.Now(), Tags: []string{"tag"}}}}
+	out := RenderSecurityGroupDetail(nc, "sg-1")
+	if !strings.Contains(out, "Security Group Details") {
+		t.Fatalf("expected detail title, got %s", out)
+	}
+	if !strings.Contains(out, "sg1") {
+		t.Fatalf("expected security group name, got %s", out)
+	}
 }
 
-// GetSubnet returns a subnet by ID from the mock data.
-func (m *mockNetworkClient) GetSubnet(ctx context.Context, subnetID string) (*subnets.Subnet, error) {
-	for _, s := range m.subnets {
-		if s.ID == subnetID {
-			subCopy := s
-			return &subCopy, nil
-		}
+func TestRenderSecurityGroupDetailNotFound(t *testing.T) {
+	out := RenderSecurityGroupDetail(&clienttest.Network{SecurityGroups: []groups.SecGroup{{ID: "sg-2", Name: "sg2"}}}, "sg-1")
+	if !strings.Contains(out, "Security group not found") {
+		t.Fatalf("expected not found message, got %s", out)
 	}
-	return nil, fmt.Errorf("subnet not found")
 }
-func (m *mockNetworkClient) ListFloatingIPs() ([]floatingips.FloatingIP, error) {
-	return m.floatingIPs, m.fipErr
+
+func TestRenderAllocateFloatingIPForm(t *testing.T) {
+	out := RenderAllocateFloatingIPForm()
+	if !strings.Contains(out, "FloatingNetworkID:") {
+		t.Fatalf("expected form field, got %s", out)
+	}
 }
-func (m *mockNetworkClient) AllocateFloatingIP(opts floatingips.CreateOptsBuilder) (floatingips.FloatingIP, error) {
-	return m.allocated, m.allocErr
+
+func TestPlanRuleImport(t *testing.T) {
+	existing := []rules.SecGroupRule{{Direction: "ingress", EtherType: "IPv4", Protocol: "tcp", PortRangeMin: 22, PortRangeMax: 22, RemoteIPPrefix: "0.0.0.0/0"}}
+	specs := []ruleSpec{
+		{Direction: "ingress", Protocol: "tcp", PortRangeMin: 22, PortRangeMax: 22, RemoteIPPrefix: "0.0.0.0/0"},
+		{Direction: "ingress", Protocol: "tcp", PortRangeMin: 443, PortRangeMax: 443, RemoteIPPrefix: "0.0.0.0/0"},
+		{Direction: "Ingress", Protocol: "TCP", PortRangeMin: 443, PortRangeMax: 443, RemoteIPPrefix: "0.0.0.0/0"},
+	}
+	toCreate, skipped, err := planRuleImport(existing, specs)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(toCreate) != 1 || skipped != 2 {
+		t.Fatalf("expected 1 rule to create and 2 skipped, got %d and %d", len(toCreate), skipped)
+	}
+	if toCreate[0].EtherType != "IPv4" {
+		t.Fatalf("expected default ethertype IPv4, got %s", toCreate[0].EtherType)
+	}
+
+	bad := []ruleSpec{{Direction: "ingress", RemoteIPPrefix: "::/0", EtherType: "IPv4"}}
+	if _, _, err := planRuleImport(nil, bad); err == nil {
+		t.Fatalf("expected validation error for mismatched ethertype")
+	}
 }
-func (m *mockNetworkClient) ReleaseFloatingIP(id string) error {
-	return m.releaseErr
+
+func TestResolveSGUsage(t *testing.T) {
+	portList := []ports.Port{
+		{ID: "p1", DeviceOwner: "compute:nova", DeviceID: "srv-1", SecurityGroups: []string{"sg-1"}, FixedIPs: []ports.IP{{IPAddress: "10.0.0.5"}}},
+		{ID: "p2", DeviceOwner: "Octavia", DeviceID: "lb-lb-1", SecurityGroups: []string{"sg-1", "sg-2"}},
+		{ID: "p3", DeviceOwner: "compute:nova", DeviceID: "srv-2", SecurityGroups: []string{"sg-2"}},
+		{ID: "p4", SecurityGroups: []string{"sg-1"}, FixedIPs: []ports.IP{{IPAddress: "10.0.0.9"}}},
+	}
+	usage := resolveSGUsage(portList, "sg-1", map[string]string{"srv-1": "web"}, []client.LoadBalancer{{ID: "lb-1", Name: "front", VipAddress: "10.0.0.9"}})
+	if len(usage) != 3 {
+		t.Fatalf("expected 3 ports using sg-1, got %d", len(usage))
+	}
+	byPort := map[string]sgUsage{}
+	for _, u := range usage {
+		byPort[u.PortID] = u
+	}
+	if byPort["p1"].Kind != "server" || byPort["p1"].ResourceName != "web" {
+		t.Fatalf("expected p1 resolved to server web, got %+v", byPort["p1"])
+	}
+	if byPort["p2"].ResourceID != "lb-1" || byPort["p2"].ResourceName != "front" {
+		t.Fatalf("expected p2 resolved to load balancer front, got %+v", byPort["p2"])
+	}
+	if byPort["p4"].Kind != "load balancer" {
+		t.Fatalf("expected p4 matched to load balancer by VIP, got %+v", byPort["p4"])
+	}
 }
-func (m *mockNetworkClient) AssociateFloatingIP(fipID string, portID string) (floatingips.FloatingIP, error) {
-	return m.associate, m.assocErr
+
+func TestApplyFloatingIPEdit(t *testing.T) {
+	nc := clienttest.NewNetwork()
+	original := "description: \"\"\ndns_name: \"\"\ndns_domain: \"\"\n"
+	status, err := applyFloatingIPEdit(nc, "fip-1", original, "description: web\ndns_name: www\ndns_domain: example.org.\n")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if status != "Updated description, dns_name, dns_domain" {
+		t.Errorf("unexpected status %q", status)
+	}
+	if len(nc.FloatingIPUpdates) != 1 || *nc.FloatingIPUpdates[0].DNSName != "www" || *nc.FloatingIPUpdates[0].DNSDomain != "example.org." {
+		t.Errorf("unexpected updates %+v", nc.FloatingIPUpdates)
+	}
+
+	nc = clienttest.NewNetwork()
+	if _, err := applyFloatingIPEdit(nc, "fip-1", original, "dns_domain: example.org\n"); err == nil || len(nc.Calls()) != 0 {
+		t.Errorf("expected relative dns_domain to be rejected, got %v", err)
+	}
 }
-func (m *mockNetworkClient) DisassociateFloatingIP(fipID string) (floatingips.FloatingIP, error) {
-	return m.disassociate, m.disassocErr
+
+func TestTapServicesCreateAndDelete(t *testing.T) {
+	nc := clienttest.NewNetwork().WithPorts(ports.Port{ID: "port-1", Name: "probe"})
+	m := NewTapServicesModel(nc)
+	updated, _ := m.Update(m.loadCmd()())
+	m = updated.(TapServicesModel)
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	m = updated.(TapServicesModel)
+	if !m.CapturingInput() {
+		t.Fatalf("expected the form to capture input")
+	}
+	m.form.SetValue(0, "mirror")
+	m.form.SetValue(1, "missing")
+	for i := 0; i < 3; i++ {
+		updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		m = updated.(TapServicesModel)
+	}
+	if m.form == nil || !strings.Contains(m.View(), "does not exist") {
+		t.Fatalf("expected an unknown port error, got %q", m.View())
+	}
+
+	m.form.SetValue(1, "port-1")
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(TapServicesModel)
+	if m.form != nil || cmd == nil {
+		t.Fatalf("expected the form to submit")
+	}
+	updated, cmd = m.Update(cmd())
+	m = updated.(TapServicesModel)
+	updated, _ = m.Update(cmd())
+	m = updated.(TapServicesModel)
+	if len(nc.TapServices) != 1 || nc.TapServices[0].PortID != "port-1" {
+		t.Fatalf("unexpected tap services %+v", nc.TapServices)
+	}
+	if !strings.Contains(m.View(), "probe") {
+		t.Fatalf("expected the port name in the view, got %q", m.View())
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	m = updated.(TapServicesModel)
+	updated, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	m = updated.(TapServicesModel)
+	m.Update(cmd())
+	if len(nc.TapServices) != 0 || !nc.Called("DeleteTapService ts-1") {
+		t.Fatalf("expected the tap service to be deleted, got %+v", nc.TapServices)
+	}
 }
-func (m *mockNetworkClient) ListSecurityGroups() ([]groups.SecGroup, error) {
-	return m.secGroups, m.secErr
+
+func TestTapFlowFromForm(t *testing.T) {
+	known := map[string]string{"port-1": "vm"}
+	tf, err := tapFlowFromForm([]string{"f", "port-1", "in", "10"}, "ts-1", known)
+	if err != nil || tf.Direction != "IN" || tf.TapServiceID != "ts-1" {
+		t.Fatalf("unexpected flow %+v, err %v", tf, err)
+	}
+	if _, err := tapFlowFromForm([]string{"f", "port-1", "sideways", ""}, "ts-1", known); err == nil {
+		t.Fatalf("expected an invalid direction error")
+	}
+	if _, err := tapFlowFromForm([]string{"f", "port-2", "BOTH", ""}, "ts-1", known); err == nil {
+		t.Fatalf("expected an unknown port error")
+	}
 }
 
-// Stub implementations for new NetworkClient methods.
-func (m *mockNetworkClient) ListRouters(ctx context.Context) ([]routers.Router, error) {
-	return []routers.Router{}, nil
+func TestVPNConnectionPeerCIDRsFromEndpointGroup(t *testing.T) {
+	conn := client.VPNConnection{ID: "c-1", Name: "partner", VPNServiceID: "vpn-1", PeerEPGroupID: "eg-1", Status: "DOWN"}
+	conn.DPD.Action, conn.DPD.Interval, conn.DPD.Timeout = "hold", 30, 120
+	nc := &clienttest.Network{
+		VPNServices:       []client.VPNService{{ID: "vpn-1", Name: "prod-vpn", ExternalV4IP: "203.0.113.2"}},
+		VPNConnections:    []client.VPNConnection{conn, {ID: "c-2", Name: "branch", VPNServiceID: "vpn-1", PeerCIDRs: []string{"192.168.10.0/24"}, Status: "ACTIVE"}},
+		VPNEndpointGroups: []client.VPNEndpointGroup{{ID: "eg-1", Name: "peer", Type: "cidr", Endpoints: []string{"172.20.0.0/16"}}},
+	}
+	m := NewVPNModel(nc)
+	updated, _ := m.Update(m.loadCmd()())
+	m = updated.(VPNModel)
+	view := m.View()
+	for _, want := range []string{"172.20.0.0/16", "192.168.10.0/24", "prod-vpn", "1/2 connections ACTIVE", "partner (DOWN)"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected %q in view:\n%s", want, view)
+		}
+	}
+	if _, ok := m.SelectedConnection(); !ok {
+		t.Fatalf("expected a selected connection")
+	}
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if _, ok := updated.(VPNModel).SelectedConnection(); ok {
+		t.Fatalf("expected no connection outside the connections list")
+	}
+
+	d := NewVPNConnectionDetailModel(nc, "c-1")
+	msgs := d.Init()().(tea.BatchMsg)
+	updatedDetail, _ := d.Update(msgs[1]())
+	view = updatedDetail.View()
+	for _, want := range []string{"172.20.0.0/16", "action hold, interval 30s, timeout 120s", "prod-vpn (203.0.113.2)"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected %q in detail view:\n%s", want, view)
+		}
+	}
 }
-func (m *mockNetworkClient) GetRouter(ctx context.Context, id string) (*routers.Router, error) {
-	return nil, nil
+
+func TestFirewallRuleFromForm(t *testing.T) {
+	policies := []client.FirewallPolicy{{ID: "fwp-1", Name: "prod-ingress"}}
+	in, policyID, err := firewallRuleFromForm([]string{"allow-ssh", "ALLOW", "tcp", "198.51.100.0/24", "", "", "22", "4", "prod-ingress"}, policies)
+	if err != nil || policyID != "fwp-1" || in.Action != "allow" || in.DestinationPort != "22" {
+		t.Fatalf("unexpected rule %+v, policy %q, err %v", in, policyID, err)
+	}
+	for _, v := range [][]string{
+		{"r", "drop", "tcp", "", "", "", "", "4", ""},
+		{"r", "allow", "icmp", "", "", "", "22", "4", ""},
+		{"r", "allow", "tcp", "not-a-cidr", "", "", "", "4", ""},
+		{"r", "allow", "tcp", "", "", "", "", "5", ""},
+		{"r", "allow", "tcp", "", "", "", "", "4", "missing"},
+	} {
+		if _, _, err := firewallRuleFromForm(v, policies); err == nil {
+			t.Errorf("expected an error for %v", v)
+		}
+	}
 }
-func (m *mockNetworkClient) GetRouterInterfaces(ctx context.Context, id string) ([]ports.Port, error) {
-	return []ports.Port{}, nil
+
+func TestFirewallGroupsShowPortsAndDeleteRule(t *testing.T) {
+	nc := &clienttest.Network{
+		Ports:            []ports.Port{{ID: "port-1", Name: "router-if", FixedIPs: []ports.IP{{IPAddress: "10.1.0.1"}}}},
+		FirewallGroups:   []client.FirewallGroup{{ID: "fwg-1", Name: "prod", IngressFirewallPolicyID: "fwp-1", Ports: []string{"port-1"}, Status: "ACTIVE"}},
+		FirewallPolicies: []client.FirewallPolicy{{ID: "fwp-1", Name: "prod-ingress", Rules: []string{"fwr-a"}}},
+		FirewallRules:    []client.FirewallRule{{ID: "fwr-a", Name: "allow-https", Action: "allow", Protocol: "tcp", DestinationPort: "443", FirewallPolicyID: []string{"fwp-1"}}},
+	}
+	m := NewFirewallModel(nc)
+	updated, _ := m.Update(m.loadCmd()())
+	m = updated.(FirewallModel)
+	if view := m.View(); !strings.Contains(view, "Applied to: router-if (10.1.0.1)") || !strings.Contains(view, "prod-ingress") {
+		t.Fatalf("expected the group's policy and ports, got:\n%s", view)
+	}
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	m = updated.(FirewallModel)
+	if view := m.View(); !strings.Contains(view, "1. allow-https") {
+		t.Fatalf("expected the ordered policy rules, got:\n%s", view)
+	}
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	m = updated.(FirewallModel)
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	m = updated.(FirewallModel)
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	cmd()
+	if calls := nc.Calls(); len(calls) != 1 || calls[0] != "DeleteFirewallRule fwr-a" {
+		t.Fatalf("expected fwr-a to be deleted, got %v", calls)
+	}
 }
-func (m *mockNetworkClient) CreateRouter(ctx context.Context, name, externalNetID string) (*routers.Router, error) {
-	return nil, nil
+
+func TestFirewallReloadKeepsModeAndSelection(t *testing.T) {
+	nc := &clienttest.Network{FirewallRules: []client.FirewallRule{{ID: "fwr-a", Name: "allow-https"}, {ID: "fwr-b", Name: "allow-ssh"}, {ID: "fwr-c", Name: "deny-all"}}}
+	var m tea.Model = NewFirewallModel(nc)
+	m, _ = m.Update(m.(FirewallModel).loadCmd()())
+	for _, k := range []tea.KeyType{tea.KeyTab, tea.KeyTab, tea.KeyDown, tea.KeyDown} {
+		m, _ = m.Update(tea.KeyMsg{Type: k})
+	}
+	// allow-https is deleted elsewhere: the cursor stays on deny-all.
+	nc.FirewallRules = nc.FirewallRules[1:]
+	m, _ = m.Update(m.(FirewallModel).Reload()())
+	if row := m.(FirewallModel).Table().SelectedRow(); len(row) == 0 || row[0] != "fwr-c" {
+		t.Fatalf("expected the cursor kept on deny-all in the rules, got %v", row)
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	if !m.(FirewallModel).CapturingInput() {
+		t.Fatal("expected the delete prompt to take r")
+	}
 }
-func (m *mockNetworkClient) DeleteRouter(ctx context.Context, id string) error {
-	return nil
+
+func TestBGPSpeakerScheduling(t *testing.T) {
+	spk := client.BGPSpeaker{ID: "spk-1", Name: "edge", LocalAS: 64512, IPVersion: 4}
+	nc := &clienttest.Network{
+		Agents:      []client.NetworkAgent{{ID: "ag-1", Host: "network-01", AgentType: client.AgentTypeBGP, AdminStateUp: true}, {ID: "ag-2", Host: "network-02", AgentType: client.AgentTypeBGP, Alive: true, AdminStateUp: true}},
+		BGPSpeakers: []client.BGPSpeaker{spk},
+		BGPRoutes:   map[string][]client.BGPRoute{"spk-1": {{Destination: "10.1.0.0/24", NextHop: "203.0.113.2"}}},
+		BGPAgents:   map[string][]string{"spk-1": {"ag-1"}},
+	}
+	m := NewBGPModel(nc)
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 200, Height: 30})
+	updated, _ = updated.Update(m.loadCmd()())
+	if view := updated.View(); !strings.Contains(view, "network-01 (dead)") {
+		t.Fatalf("expected the dead hosting agent in the list, got:\n%s", view)
+	}
+
+	d := NewBGPSpeakerDetailModel(nc, spk)
+	updated, _ = d.Update(d.loadCmd()())
+	d = updated.(BGPSpeakerDetailModel)
+	if view := d.View(); !strings.Contains(view, "no alive DR agent") || !strings.Contains(view, "10.1.0.0/24") {
+		t.Fatalf("expected the routes and the not-announcing warning, got:\n%s", view)
+	}
+	for i := 0; i < 2; i++ {
+		updated, _ = d.Update(tea.KeyMsg{Type: tea.KeyTab})
+		d = updated.(BGPSpeakerDetailModel)
+	}
+	// Schedule on the alive agent (second row).
+	updated, _ = d.Update(tea.KeyMsg{Type: tea.KeyDown})
+	d = updated.(BGPSpeakerDetailModel)
+	updated, cmd := d.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	d = updated.(BGPSpeakerDetailModel)
+	updated, cmd = d.Update(cmd())
+	d = updated.(BGPSpeakerDetailModel)
+	updated, _ = d.Update(cmd())
+	d = updated.(BGPSpeakerDetailModel)
+	if !d.announcing() || len(nc.BGPAgents["spk-1"]) != 2 {
+		t.Fatalf("expected the speaker on both agents, got %v", nc.BGPAgents)
+	}
+
+	// Unscheduling from the dead agent asks first.
+	updated, _ = d.Update(tea.KeyMsg{Type: tea.KeyUp})
+	d = updated.(BGPSpeakerDetailModel)
+	updated, _ = d.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	d = updated.(BGPSpeakerDetailModel)
+	if !d.CapturingInput() {
+		t.Fatalf("expected a confirmation prompt")
+	}
+	_, cmd = d.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	cmd()
+	if got := nc.BGPAgents["spk-1"]; len(got) != 1 || got[0] != "ag-2" || !nc.Called("UnscheduleBGPSpeaker ag-1 spk-1") {
+		t.Fatalf("expected only ag-2 to host the speaker, got %v", got)
+	}
 }
-func (m *mockNetworkClient) AddRouterInterface(ctx context.Context, routerID, subnetID string) error {
-	return nil
+
+func TestBGPErrorExplainsForbidden(t *testing.T) {
+	err := bgpError(gophercloud.ErrDefault403{})
+	if !strings.Contains(err.Error(), "admin credentials") {
+		t.Fatalf("unexpected error %q", err)
+	}
+	if bgpError(nil) != nil {
+		t.Fatalf("expected nil for nil")
+	}
 }
-func (m *mockNetworkClient) RemoveRouterInterface(ctx context.Context, routerID, subnetID string) error {
-	return nil
+
+func TestSubnetFromForm(t *testing.T) {
+	pools := []client.SubnetPool{{ID: "pool-4", Name: "shared-v4", IPversion: 4, DefaultPrefixLen: 24, MinPrefixLen: 16, MaxPrefixLen: 28}}
+	opts, err := subnetFromForm([]string{"net-1", "app", "", "shared-v4", "/26"}, pools)
+	if err != nil || opts.SubnetPoolID != "pool-4" || opts.Prefixlen != 26 || opts.IPVersion != 4 || opts.CIDR != "" {
+		t.Fatalf("unexpected %+v, %v", opts, err)
+	}
+	opts, err = subnetFromForm([]string{"net-1", "app", "fd00::/64", "", ""}, pools)
+	if err != nil || opts.IPVersion != 6 || opts.SubnetPoolID != "" {
+		t.Fatalf("unexpected %+v, %v", opts, err)
+	}
+	for _, v := range [][]string{
+		{"", "app", "10.0.0.0/24", "", ""},
+		{"net-1", "app", "", "", ""},
+		{"net-1", "app", "10.0.0.0/24", "", "24"},
+		{"net-1", "app", "", "missing", ""},
+		{"net-1", "app", "", "shared-v4", "30"},
+		{"net-1", "app", "fd00::/64", "shared-v4", ""},
+		{"net-1", "app", "not-a-cidr", "", ""},
+	} {
+		if _, err := subnetFromForm(v, pools); err == nil {
+			t.Errorf("expected %v to be rejected", v)
+		}
+	}
 }
-func (m *mockNetworkClient) ListPorts(ctx context.Context) ([]ports.Port, error) {
-	return []ports.Port{}, nil
+
+func TestSubnetPoolsModel(t *testing.T) {
+	nc := &clienttest.Network{
+		SubnetPools:   []client.SubnetPool{{ID: "pool-4", Name: "shared-v4", Prefixes: []string{"10.128.0.0/12"}, IPversion: 4, DefaultPrefixLen: 24, MinPrefixLen: 16, MaxPrefixLen: 28, AddressScopeID: "scope-4"}},
+		AddressScopes: []client.AddressScope{{ID: "scope-4", Name: "corp-v4", IPVersion: 4}},
+		Subnets:       []subnets.Subnet{{ID: "s1", Name: "app", CIDR: "10.128.0.0/24", SubnetPoolID: "pool-4"}, {ID: "s2", CIDR: "10.1.0.0/24"}},
+	}
+	m := NewSubnetPoolsModel(nc)
+	updated, _ := m.Update(m.loadCmd()())
+	m = updated.(SubnetPoolsModel)
+	if view := m.View(); !strings.Contains(view, "corp-v4") || !strings.Contains(view, "/24 (/16-/28)") {
+		t.Fatalf("expected the scope name and prefix lengths, got %s", view)
+	}
+	p, ok := m.SelectedPool()
+	if !ok {
+		t.Fatal("expected a selected pool")
+	}
+	d, _ := loadPoolData(context.Background(), nc)
+	rows := subnetPoolRows(p, d)
+	if last := rows[len(rows)-1]; last[0] != "Subnets (1)" || !strings.Contains(last[1], "10.128.0.0/24") {
+		t.Fatalf("expected the allocated subnet, got %v", last)
+	}
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	m = updated.(SubnetPoolsModel)
+	if _, ok := m.SelectedPool(); ok || !strings.Contains(m.View(), "shared-v4") {
+		t.Fatalf("expected the scopes with their pools, got %s", m.View())
+	}
 }
 
-// ListPortsByServer returns ports for a given server ID (mock implementation).
-func (m *mockNetworkClient) ListPortsByServer(ctx context.Context, serverID string) ([]ports.Port, error) {
-	return []ports.Port{}, nil
+func TestParseAZHints(t *testing.T) {
+	zones := []client.NetworkAZ{{Name: "az1", Resource: "network"}, {Name: "az2", Resource: "network"}, {Name: "az1", Resource: "router"}}
+	hints, err := parseAZHints(" az1, az2 ,", zones, "network")
+	if err != nil || len(hints) != 2 || hints[1] != "az2" {
+		t.Fatalf("unexpected %v, %v", hints, err)
+	}
+	if _, err := parseAZHints("az2", zones, "router"); err == nil || !strings.Contains(err.Error(), "no L3 agents") {
+		t.Fatalf("expected az2 refused for routers, got %v", err)
+	}
+	// Unknown zones are not checked.
+	if hints, err := parseAZHints("az9", nil, "network"); err != nil || len(hints) != 1 {
+		t.Fatalf("expected hints accepted without known zones, got %v, %v", hints, err)
+	}
 }
 
-// ListPortsByNetwork returns ports for a given network ID (mock implementation).
-func (m *mockNetworkClient) ListPortsByNetwork(ctx context.Context, networkID string) ([]ports.Port, error) {
-	return []ports.Port{}, nil
+func TestNetworkDetailShowsAZPlacement(t *testing.T) {
+	nc := &clienttest.Network{Placements: map[string]client.AZPlacement{"net-1": {Hints: []string{"az2"}, Zones: []string{"az1"}}}}
+	m := NewNetworkSubnetsModel(nc, "net-1")
+	updated, _ := m.Update(m.Init()())
+	view := updated.(NetworkSubnetsModel).View()
+	if !strings.Contains(view, "AZ hints: az2") || !strings.Contains(view, "Scheduled in: az1") || !strings.Contains(view, "not in hinted az2") {
+		t.Fatalf("expected the zone placement with the unscheduled hint, got %s", view)
+	}
+	rows := azPlacementRows(client.AZPlacement{})
+	if rows[0][1] != "none (any zone)" || rows[1][1] != "none yet" {
+		t.Fatalf("unexpected rows %v", rows)
+	}
 }
 
-// GetNetwork returns a network by ID from the mock data.
-func (m *mockNetworkClient) GetNetwork(ctx context.Context, id string) (*networks.Network, error) {
-	for _, n := range m.networks {
-		if n.ID == id {
-			netCopy := n
-			return &netCopy, nil
+func TestL3Warnings(t *testing.T) {
+	up := func(host, state string) client.L3Agent {
+		return client.L3Agent{Host: host, Alive: true, AdminStateUp: true, HAState: state}
+	}
+	down := up("network-03", "active")
+	down.Alive = false
+	for _, tc := range []struct {
+		hosts []client.L3Agent
+		want  []string
+	}{
+		{nil, []string{"not scheduled"}},
+		{[]client.L3Agent{up("network-01", "")}, nil},
+		{[]client.L3Agent{up("network-01", "active"), up("network-02", "standby")}, nil},
+		{[]client.L3Agent{up("network-01", "active"), up("network-02", "active")}, []string{"2 agents report active"}},
+		{[]client.L3Agent{down, up("network-02", "standby")}, []string{"network-03 is down but still reported active", "no alive agent is active"}},
+	} {
+		got := l3Warnings(tc.hosts)
+		if len(got) != len(tc.want) {
+			t.Fatalf("%v: got %q", tc.hosts, got)
+		}
+		for i := range got {
+			if !strings.Contains(got[i], tc.want[i]) {
+				t.Errorf("%v: got %q, want %q", tc.hosts, got[i], tc.want[i])
+			}
 		}
 	}
-	return nil, fmt.Errorf("network not found")
-}
-func (m *mockNetworkClient) GetPort(ctx context.Context, id string) (*ports.Port, error) {
-	return nil, nil
-}
-func (m *mockNetworkClient) ListSecurityGroupRules(ctx context.Context, sgID string) ([]rules.SecGroupRule, error) {
-	return []rules.SecGroupRule{}, nil
 }
-func (m *mockNetworkClient) CreateSecurityGroupRule(ctx context.Context, sgID string, rule rules.CreateOpts) (*rules.SecGroupRule, error) {
-	return nil, nil
-}
-func (m *mockNetworkClient) DeleteSecurityGroupRule(ctx context.Context, id string) error {
-	return nil
+
+func TestRoutersAgeFilter(t *testing.T) {
+	now := time.Now()
+	nc := &clienttest.Network{Routers: []client.RouterDetail{
+		{Router: routers.Router{ID: "r-1", Name: "edge"}, CreatedAt: now.AddDate(0, 0, -12)},
+		{Router: routers.Router{ID: "r-2", Name: "lab"}},
+		{Router: routers.Router{ID: "r-3", Name: "old-lab"}, CreatedAt: now.AddDate(-2, 0, 0)},
+	}}
+	var m tea.Model = NewRoutersModel(nc)
+	m, _ = m.Update(m.Init()())
+	if v := m.View(); !strings.Contains(v, "Age") || !strings.Contains(v, "12d") || !strings.Contains(v, "730d") {
+		t.Fatalf("expected the age of the routers, got %q", v)
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+	for _, r := range "lab age>90d" {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	// lab has no creation time, so no age to compare.
+	if rows := m.(RouterModel).table.Rows(); len(rows) != 1 || rows[0][0] != "r-3" {
+		t.Fatalf("expected only old-lab, got %v", rows)
+	}
 }
 
-func TestRenderNetworksSuccess(t *testing.T) {
-	mock := &mockNetworkClient{networks: []networks.Network{{ID: "net-1", Name: "net1", Status: "ACTIVE"}}}
-	out := RenderNetworks(mock)
-	if !strings.Contains(out, "net1") {
-		t.Fatalf("expected network name in output, got %s", out)
+func TestRouterL3AgentsMove(t *testing.T) {
+	nc := clienttest.NewNetwork().WithAgents(
+		client.NetworkAgent{ID: "l3-1", Host: "network-01", AgentType: client.AgentTypeL3},
+		client.NetworkAgent{ID: "l3-2", Host: "network-02", AgentType: client.AgentTypeL3, Alive: true, AdminStateUp: true},
+	)
+	nc.L3Hosts = map[string][]client.L3Agent{"rt-1": {{ID: "l3-1", Host: "network-01"}}}
+	m := NewRouterL3AgentsModel(nc, "rt-1", "edge")
+	updated, _ := m.Update(m.loadCmd()())
+	m = updated.(RouterL3AgentsModel)
+	if view := m.View(); !strings.Contains(view, "network-01 is down") || !strings.Contains(view, "legacy") {
+		t.Fatalf("expected the dead legacy host flagged, got:\n%s", view)
+	}
+
+	// A legacy router is moved with m on its agent and enter on the target.
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("m")})
+	m = updated.(RouterL3AgentsModel)
+	if !m.CapturingInput() {
+		t.Fatal("expected the target picker")
+	}
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m = updated.(RouterL3AgentsModel)
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(RouterL3AgentsModel)
+	updated, cmd = m.Update(cmd())
+	m = updated.(RouterL3AgentsModel)
+	updated, _ = m.Update(cmd())
+	m = updated.(RouterL3AgentsModel)
+	if got := nc.L3Hosts["rt-1"]; len(got) != 1 || got[0].ID != "l3-2" {
+		t.Fatalf("expected the router on l3-2 only, got %v", got)
+	}
+	if view := m.View(); !strings.Contains(view, "Moved the router from network-01 to network-02") || strings.Contains(view, "⚠") {
+		t.Fatalf("expected a clean move, got:\n%s", view)
+	}
+
+	// When the target refuses the router, it is put back on its agent.
+	nc.Fail("ScheduleRouter l3-1 rt-1", errors.New("agent refused the router"))
+	if msg := m.moveCmd("l3-2", "l3-1")().(changeDoneMsg); msg.err == nil {
+		t.Fatal("expected the refusal reported")
+	}
+	if got := nc.L3Hosts["rt-1"]; len(got) != 1 || got[0].ID != "l3-2" {
+		t.Fatalf("expected the router back on l3-2, got %v", got)
 	}
 }
 
-func TestRenderNetworksError(t *testing.T) {
-	mock := &mockNetworkClient{netErr: errors.New("list error")}
-	out := RenderNetworks(mock)
-	if !strings.Contains(out, "Failed to list networks") {
-		t.Fatalf("expected error message, got %s", out)
+func TestNetworkDHCPModel(t *testing.T) {
+	fixed := func(subnet, ip string) []ports.IP { return []ports.IP{{SubnetID: subnet, IPAddress: ip}} }
+	dhcpPort := ports.Port{ID: "p-dhcp", NetworkID: "net-1", DeviceOwner: client.DeviceOwnerDHCP, FixedIPs: fixed("sub-1", "10.0.0.2")}
+	nc := &clienttest.Network{
+		Subnets: []subnets.Subnet{{ID: "sub-1", NetworkID: "net-1", CIDR: "10.0.0.0/24", EnableDHCP: true}},
+		Ports: []ports.Port{
+			dhcpPort,
+			{ID: "p-vm2", NetworkID: "net-1", DeviceOwner: "compute:az1", DeviceID: "vm-2", MACAddress: "fa:16:3e:00:00:02", Status: "DOWN", FixedIPs: fixed("sub-1", "10.0.0.10")},
+			{ID: "p-vm1", NetworkID: "net-1", DeviceOwner: "compute:az1", DeviceID: "vm-1", MACAddress: "fa:16:3e:00:00:01", Status: "ACTIVE", FixedIPs: fixed("sub-1", "10.0.0.9")},
+		},
+		DHCPAgents: map[string][]client.NetworkAgent{"net-1": {{ID: "dh-1", Host: "network-01", AvailabilityZone: "az1", AdminStateUp: true}}},
+		DHCPPorts:  []client.DHCPPort{{Port: dhcpPort}},
+	}
+	nc.DHCPPorts[0].HostID = "network-01"
+	m := NewNetworkDHCPModel(nc, "net-1")
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 200, Height: 30})
+	updated, _ = updated.Update(m.loadCmd()())
+	m = updated.(NetworkDHCPModel)
+	view := m.View()
+	if !strings.Contains(view, "no alive DHCP agent") || !strings.Contains(view, "10.0.0.2") {
+		t.Fatalf("expected the dead agent with its port flagged, got:\n%s", view)
+	}
+
+	// The leases skip the DHCP port and sort by address, not as text.
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	m = updated.(NetworkDHCPModel)
+	rows := m.Table().Rows()
+	if len(rows) != 2 || rows[0][0] != "10.0.0.9" || rows[0][2] != "host-10-0-0-9" || rows[1][6] != "yes" {
+		t.Fatalf("unexpected leases %v", rows)
+	}
+
+	// Without the admin role the leases stay visible and the agents explain.
+	nc.Fail("ListNetworkDHCPAgents", gophercloud.ErrDefault403{})
+	updated, _ = NewNetworkDHCPModel(nc, "net-1").Update(m.loadCmd()())
+	if view := updated.View(); !strings.Contains(view, "need the admin role") || strings.Contains(view, "⚠") {
+		t.Fatalf("expected the agents explained without warnings, got:\n%s", view)
 	}
 }
 
-func TestRenderSubnetsSuccess(t *testing.T) {
-	mock := &mockNetworkClient{subnets: []subnets.Subnet{{ID: "sub-1", Name: "sub1", CIDR: "10.0.0.0/24", IPVersion: 4}}}
-	out := RenderSubnets(mock)
-	if !strings.Contains(out, "sub1") {
-		t.Fatalf("expected subnet name in output, got %s", out)
+// feed runs cmd, flattening batches, and sends the messages to m.
+func feed(m tea.Model, cmd tea.Cmd) (tea.Model, tea.Cmd) {
+	if cmd == nil {
+		return m, nil
+	}
+	msg := cmd()
+	if batch, ok := msg.(tea.BatchMsg); ok {
+		var last tea.Cmd
+		for _, c := range batch {
+			m, last = feed(m, c)
+		}
+		return m, last
 	}
+	return m.Update(msg)
 }
 
-func TestRenderSubnetsError(t *testing.T) {
-	mock := &mockNetworkClient{subErr: errors.New("list error")}
-	out := RenderSubnets(mock)
-	if !strings.Contains(out, "Failed to list subnets") {
-		t.Fatalf("expected error message, got %s", out)
+func TestRouterCreateAndDelete(t *testing.T) {
+	policy.SetRoles(nil)
+	key := func(k string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)} }
+	enter := tea.KeyMsg{Type: tea.KeyEnter}
+	iface := ports.Port{ID: "p-1", DeviceID: "r-1", DeviceOwner: "network:router_interface", FixedIPs: []ports.IP{{SubnetID: "sub-1", IPAddress: "10.0.0.1"}}}
+	nc := clienttest.NewNetwork().WithPorts(iface)
+	nc.Routers = []client.RouterDetail{{Router: routers.Router{ID: "r-1", Name: "edge"}}}
+	nc.External = []networks.Network{clienttest.Net("ext-1", "public")}
+	var m tea.Model = NewRoutersModel(nc)
+	m, _ = m.Update(m.Init()())
+
+	// n picks the gateway, then opens the form; SNAT defaults to yes.
+	m, cmd := m.Update(key("n"))
+	m, _ = feed(m, cmd)
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m, _ = m.Update(enter)
+	for _, v := range []string{"edge-2", "", "", "no", "yes"} {
+		for _, r := range v {
+			m, _ = m.Update(key(string(r)))
+		}
+		m, cmd = m.Update(enter)
+	}
+	m, cmd = feed(m, cmd)
+	m, _ = feed(m, cmd) // reload
+	if len(nc.RouterCreates) != 1 {
+		t.Fatalf("expected a router to be created, view:\n%s", m.View())
+	}
+	got := nc.RouterCreates[0]
+	if got.Name != "edge-2" || got.ExternalNetworkID != "ext-1" || got.EnableSNAT == nil || !*got.EnableSNAT || got.Distributed == nil || *got.Distributed || got.HA == nil || !*got.HA {
+		t.Fatalf("unexpected create options %+v", got)
+	}
+	if _, err := routerOptsFromForm([]string{"r", "yes", ""}, "", nil); err == nil {
+		t.Error("SNAT without a gateway must be refused")
+	}
+
+	// d previews the interfaces removed before the delete.
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyHome})
+	before := len(nc.Calls())
+	m, cmd = m.Update(key("d"))
+	m, _ = feed(m, cmd)
+	if view := m.View(); !strings.Contains(view, "remove the interface 10.0.0.1 on subnet sub-1") || !strings.Contains(view, "[y/N]") {
+		t.Fatalf("expected the dependency preview, got:\n%s", view)
+	}
+	m, cmd = m.Update(key("y"))
+	m, cmd = feed(m, cmd)
+	m, _ = feed(m, cmd) // reload
+	if want, got := []string{"RemoveRouterInterface r-1 sub-1", "DeleteRouter r-1"}, nc.Calls()[before:]; strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+
+	// A floating IP routed through the router blocks the delete.
+	left := m.(RouterModel).table.SelectedRow()
+	if len(left) == 0 || left[1] != "edge-2" {
+		t.Fatalf("expected edge-2 left, got %v", left)
+	}
+	before = len(nc.Calls())
+	nc.FloatingIPs = []client.FloatingIP{{FloatingIP: floatingips.FloatingIP{FloatingIP: "203.0.113.9", FixedIP: "10.0.0.5", PortID: "p-vm", RouterID: left[0]}}}
+	m, cmd = m.Update(key("d"))
+	m, _ = feed(m, cmd)
+	if view := m.View(); !strings.Contains(view, "In use") || !strings.Contains(view, "203.0.113.9") {
+		t.Fatalf("expected the floating IP to block the delete, got:\n%s", view)
+	}
+	if _, cmd = m.Update(key("y")); cmd != nil || len(nc.Calls()) != before {
+		t.Fatal("a blocked router must not be deleted")
 	}
 }
 
-func TestRenderFloatingIPsSuccess(t *testing.T) {
-	mock := &mockNetworkClient{floatingIPs: []floatingips.FloatingIP{{ID: "fip-1", FloatingNetworkID: "net-1", FixedIP: "10.0.0.5", PortID: "port-1", Status: "ACTIVE"}}}
-	out := RenderFloatingIPs(mock)
-	if !strings.Contains(out, "fip-1") {
-		t.Fatalf("expected floating IP ID in output, got %s", out)
+func TestExportSecurityGroup(t *testing.T) {
+	g := groups.SecGroup{ID: "sg-1", Name: "web", Description: "web tier", Stateful: true}
+	rs := []rules.SecGroupRule{
+		{ID: "r-1", Direction: "egress", EtherType: "IPv4"},
+		{ID: "r-2", Direction: "ingress", EtherType: "IPv4", Protocol: "tcp", PortRangeMin: 443, PortRangeMax: 443, RemoteIPPrefix: "0.0.0.0/0"},
+		{ID: "r-3", Direction: "ingress", EtherType: "IPv4", RemoteGroupID: "sg-1"},
+	}
+	code := exportSecurityGroup(g, rs)
+	if len(code.Resources) != 4 {
+		t.Fatalf("expected the group and 3 rules, got %d resources", len(code.Resources))
+	}
+	if code.Resources[2].Name != "web_ingress" || code.Resources[3].Name != "web_ingress_2" {
+		t.Fatalf("rule names not unique: %s, %s", code.Resources[2].Name, code.Resources[3].Name)
+	}
+	// The default egress rule comes with the group on the CLI.
+	if len(code.Commands) != 3 {
+		t.Fatalf("expected 3 commands, got %q", code.Commands)
+	}
+	if want := "openstack security group rule create --ingress --ethertype IPv4 --remote-group web web"; code.Commands[2] != want {
+		t.Fatalf("command = %q, want %q", code.Commands[2], want)
 	}
 }
 
-func TestRenderFloatingIPsError(t *testing.T) {
-	mock := &mockNetworkClient{fipErr: errors.New("list error")}
-	out := RenderFloatingIPs(mock)
-	if !strings.Contains(out, "Failed to list floating IPs") {
-		t.Fatalf("expected error message, got %s", out)
+func TestPortDetailLinks(t *testing.T) {
+	nc := clienttest.NewNetwork().WithPorts(ports.Port{ID: "p1", NetworkID: "net-1", DeviceOwner: "compute:nova", DeviceID: "srv-1"})
+	var m tea.Model = NewPortDetailModel(nc, "p1")
+	m, _ = m.Update(m.Init()())
+
+	// The ID row links nowhere.
+	if _, cmd := m.Update(common.KeyFor("enter")); cmd != nil {
+		t.Fatalf("expected no link on the ID row, got %v", cmd())
+	}
+	open := func(row int) common.OpenResourceMsg {
+		t.Helper()
+		var dm tea.Model = m
+		for i := 0; i < row; i++ {
+			dm, _ = dm.Update(tea.KeyMsg{Type: tea.KeyDown})
+		}
+		_, cmd := dm.Update(common.KeyFor("enter"))
+		if cmd == nil {
+			t.Fatalf("expected a link on row %d", row)
+		}
+		return cmd().(common.OpenResourceMsg)
+	}
+	if got := open(3); got.Kind != common.KindNetwork || got.ID != "net-1" {
+		t.Errorf("NetworkID opened %+v", got)
+	}
+	if got := open(6); got.Kind != common.KindServer || got.ID != "srv-1" {
+		t.Errorf("DeviceID opened %+v", got)
+	}
+
+	// The device of a DHCP port is no resource the views show.
+	if links := portLinks(&ports.Port{DeviceOwner: "network:dhcp"}); links["DeviceID"] != "" {
+		t.Errorf("DHCP device linked to %q", links["DeviceID"])
+	}
+	if links := portLinks(&ports.Port{DeviceOwner: "network:router_interface_distributed"}); links["DeviceID"] != common.KindRouter {
+		t.Errorf("router interface device linked to %q", links["DeviceID"])
 	}
 }
 
-func TestRenderSecurityGroupsSuccess(t *testing.T) {
-	mock := &mockNetworkClient{secGroups: []groups.SecGroup{{ID: "sg-1", Name: "sg1", Description: "desc", Stateful: true}}}
-	out := RenderSecurityGroups(mock)
-	if !strings.Contains(out, "sg1") {
-		t.Fatalf("expected security group name in output, got %s", out)
+func TestPortsListKeepsOnlyVisibleRows(t *testing.T) {
+	var list []client.Port
+	for i := range 20000 {
+		list = append(list, client.Port{ID: fmt.Sprintf("port-%05d", i), Name: fmt.Sprintf("p%d", i), NetworkID: "net-1", Status: "ACTIVE"})
+	}
+	list[12345].Name = "needle"
+	var m tea.Model = NewPortsModel(clienttest.NewNetwork().WithPorts(list...))
+	m, _ = m.Update(m.Init()())
+	pm := m.(PortsModel)
+	if n := len(pm.Table().Rows()); n == 0 || n > 30 || pm.table.Total() != 20000 {
+		t.Fatalf("expected only the rows on screen in the table, got %d of %d", n, pm.table.Total())
+	}
+
+	m, _ = m.Update(common.KeyFor("G"))
+	if row := m.(PortsModel).Table().SelectedRow(); row[0] != "port-19999" {
+		t.Fatalf("expected G to select the last port, got %v", row)
+	}
+	m, _ = m.Update(common.KeyFor("/"))
+	for _, r := range "NEED" {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	pm = m.(PortsModel)
+	if pm.table.Len() != 1 || pm.Table().SelectedRow()[0] != "port-12345" || !strings.Contains(pm.View(), "1 of 20000") {
+		t.Fatalf("expected the filter to find the needle, got %d rows", pm.table.Len())
+	}
+
+	// A reload keeps the filter and the selected port.
+	m, _ = m.Update(common.KeyFor("esc"))
+	m, _ = m.Update(common.KeyFor("down"))
+	selected := m.(PortsModel).Table().SelectedRow()[0]
+	m, _ = m.Update(m.Init()())
+	if got := m.(PortsModel).Table().SelectedRow()[0]; got != selected {
+		t.Errorf("expected %s still selected after a reload, got %s", selected, got)
+	}
+	if full := m.(PortsModel).FullTable(); len(full.Rows()) != 20000 || full.Cursor() != 1 {
+		t.Errorf("expected the jump to see every port, got %d rows at %d", len(full.Rows()), full.Cursor())
 	}
 }
 
-func TestRenderSecurityGroupsError(t *testing.T) {
-	mock := &mockNetworkClient{secErr: errors.New("list error")}
-	out := RenderSecurityGroups(mock)
-	if !strings.Contains(out, "Failed to list security groups") {
-		t.Fatalf("expected error message, got %s", out)
+func TestSubnetDetailShowsDHCPSettings(t *testing.T) {
+	nc := clienttest.NewNetwork().WithSubnets(subnets.Subnet{
+		ID: "sub-1", CIDR: "10.0.0.0/24", GatewayIP: "10.0.0.1", EnableDHCP: true,
+		DNSNameservers:  []string{"1.1.1.1", "9.9.9.9"},
+		HostRoutes:      []subnets.HostRoute{{DestinationCIDR: "10.1.0.0/16", NextHop: "10.0.0.254"}, {DestinationCIDR: "10.2.0.0/16", NextHop: "10.0.0.253"}},
+		AllocationPools: []subnets.AllocationPool{{Start: "10.0.0.10", End: "10.0.0.200"}},
+	})
+	var m tea.Model = NewSubnetDetailModel(nc, "sub-1")
+	m, _ = m.Update(m.Init()())
+	out := m.View()
+	for _, want := range []string{"1.1.1.1, 9.9.9.9", "10.1.0.0/16 via 10.0.0.254", "10.2.0.0/16 via 10.0.0.253", "10.0.0.10 – 10.0.0.200", "[E] edit"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in\n%s", want, out)
+		}
 	}
 }
 
-func TestRenderSecurityGroupDetailSuccess(t *testing.T) {
-	mock := &mockNetworkClient{secGroups: []groups.SecGroup{{ID: "sg-1", Name: "sg1", Description: "desc", Stateful: true, TenantID: "tenant", ProjectID: "proj", CreatedAt: time.Now(), UpdatedAt: time.Now(), Tags: []string{"tag"}}}}
-	out := RenderSecurityGroupDetail(mock, "sg-1")
-	if !strings.Contains(out, "Security Group Details") {
-		t.Fatalf("expected detail title, got %s", out)
+func TestApplySubnetEdit(t *testing.T) {
+	nc := clienttest.NewNetwork()
+	original := "dns_nameservers:\n- 1.1.1.1\nhost_routes:\n- destination: 10.1.0.0/16\n  nexthop: 10.0.0.254\n"
+	if _, err := applySubnetEdit(nc, "sub-1", 3, original, "dns_nameservers: [1.1.1]\nhost_routes: []\n"); err == nil || !strings.Contains(err.Error(), "1.1.1") || len(nc.Calls()) != 0 {
+		t.Fatalf("expected the bad nameserver refused before the update, got %v", err)
 	}
-	if !strings.Contains(out, "sg1") {
-		t.Fatalf("expected security group name, got %s", out)
+	status, err := applySubnetEdit(nc, "sub-1", 3, original, "dns_nameservers:\n- 1.1.1.1\nhost_routes: []\n")
+	if err != nil || status != "Updated host_routes" || !nc.Called("UpdateSubnet sub-1") {
+		t.Fatalf("unexpected result %q, %v", status, err)
+	}
+	opts := nc.SubnetUpdates[0]
+	if opts.DNSNameservers != nil || opts.HostRoutes == nil || len(*opts.HostRoutes) != 0 || *opts.RevisionNumber != 3 {
+		t.Errorf("expected only the routes cleared at revision 3, got %+v", opts)
 	}
 }
 
-func TestRenderSecurityGroupDetailNotFound(t *testing.T) {
-	mock := &mockNetworkClient{secGroups: []groups.SecGroup{{ID: "sg-2", Name: "sg2"}}}
-	out := RenderSecurityGroupDetail(mock, "sg-1")
-	if !strings.Contains(out, "Security group not found") {
-		t.Fatalf("expected not found message, got %s", out)
+func TestNetworkDetailsTabTogglesAdminState(t *testing.T) {
+	policy.SetRoles(nil)
+	vlan := 101
+	app := clienttest.Net("net-1", "app")
+	app.RevisionNumber = 4
+	nc := clienttest.NewNetwork(app).WithPorts(ports.Port{ID: "p-1", NetworkID: "net-1"}, ports.Port{ID: "p-2", NetworkID: "net-1"}, ports.Port{ID: "p-3", NetworkID: "net-2"})
+	nc.Details = map[string]client.NetworkDetail{"net-1": {MTU: 1450, NetworkType: "vlan", PhysicalNetwork: "physnet1", SegmentationID: &vlan, Grants: []client.NetworkGrant{{Action: "access_as_shared", Target: "proj-b"}}}}
+	var m tea.Model = NewNetworkSubnetsModel(nc, "net-1")
+	m, _ = m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	m, _ = m.Update(m.Init()())
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyTab})
+	view := m.View()
+	for _, want := range []string{"MTU", "1450", "vlan", "physnet1", "101", "access_as_shared → proj-b", "External (router:external)"} {
+		if !strings.Contains(view, want) {
+			t.Fatalf("expected %q in the details, got:\n%s", want, view)
+		}
+	}
+	var portsRow table.Row
+	for _, r := range m.(NetworkSubnetsModel).Table().Rows() {
+		if r[0] == "Ports" {
+			portsRow = r
+		}
+	}
+	if len(portsRow) == 0 || portsRow[1] != "2" {
+		t.Fatalf("expected two ports counted, got %v", portsRow)
+	}
+
+	// The toggle is confirmed, then sent with the revision read.
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	if !m.(NetworkSubnetsModel).CapturingInput() || !strings.Contains(m.View(), "Set the admin state of network app down? [y/N]") {
+		t.Fatalf("expected the toggle confirmed first, got:\n%s", m.View())
+	}
+	m, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	m, _ = m.Update(cmd())
+	if calls := nc.Calls(); len(calls) != 1 || calls[0] != "UpdateNetwork net-1" {
+		t.Fatalf("expected one update, got %v", calls)
+	}
+	opts := nc.NetworkUpdates[0]
+	if opts.AdminStateUp == nil || *opts.AdminStateUp || opts.RevisionNumber == nil || *opts.RevisionNumber != 4 {
+		t.Fatalf("unexpected update %+v", opts)
+	}
+
+	// Without the provider attributes, they are said to be hidden.
+	rows := networkDetailRows(nil, &client.NetworkDetail{}, -1, nil)
+	if rows[2][1] != "hidden (admin only)" || len(rows) != 4 {
+		t.Fatalf("unexpected rows %v", rows)
 	}
 }
 
-func TestRenderAllocateFloatingIPForm(t *testing.T) {
-	out := RenderAllocateFloatingIPForm()
-	if !strings.Contains(out, "FloatingNetworkID:") {
-		t.Fatalf("expected form field, got %s", out)
+func TestRuleImportResolvesRemoteGroups(t *testing.T) {
+	src := []groups.SecGroup{{ID: "web-a", Name: "web"}, {ID: "db-a", Name: "db"}}
+	path := filepath.Join(t.TempDir(), "web.yaml")
+	err := exportRules([]rules.SecGroupRule{
+		{Direction: "ingress", EtherType: "IPv4", Protocol: "tcp", PortRangeMin: 80, PortRangeMax: 80, RemoteGroupID: "web-a"},
+		{Direction: "egress", EtherType: "IPv4", Protocol: "tcp", PortRangeMin: 5432, PortRangeMax: 5432, RemoteGroupID: "db-a"},
+	}, "web-a", src, path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	specs, err := parseRuleFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// In another project the groups have other IDs.
+	dst := []groups.SecGroup{{ID: "web-b", Name: "web"}, {ID: "db-b", Name: "db"}}
+	got, err := resolveRemoteGroups(specs, "web-b", dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got[0].RemoteGroupID != "web-b" || got[1].RemoteGroupID != "db-b" {
+		t.Fatalf("expected the rules to point at the groups of the target, got %+v", got)
+	}
+
+	// Without a group of that name nothing is created.
+	if _, err := resolveRemoteGroups(specs, "web-b", dst[:1]); err == nil || !strings.Contains(err.Error(), `"db"`) {
+		t.Fatalf("expected the missing db group reported, got %v", err)
+	}
+	// A bare ID from another project is refused too.
+	if _, err := resolveRemoteGroups([]ruleSpec{{Direction: "ingress", RemoteGroupID: "db-a"}}, "web-b", dst); err == nil {
+		t.Fatal("expected an unknown remote group ID refused")
 	}
 }
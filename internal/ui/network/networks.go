@@ -1,12 +1,20 @@
 package network
 
 import (
+	"context"
 	"fmt"
 	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/table"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/networks"
 	"ostui/internal/client"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/common"
+	"ostui/internal/ui/ops"
+	"ostui/internal/ui/policy"
+	"ostui/internal/ui/search"
+	"ostui/internal/ui/theme"
 	"ostui/internal/ui/uiconst"
 	"strings"
 )
@@ -23,6 +31,12 @@ type NetworksModel struct {
 	allRows    []table.Row
 	filterMode bool
 	filter     textinput.Model
+	// zones checks the zone hints of the new network form.
+	zones []client.NetworkAZ
+
+	form      *common.FormModel
+	status    string
+	statusErr bool
 }
 
 // NewNetworksModel creates a new NetworksModel with the given network client.
@@ -36,9 +50,10 @@ func NewNetworksModel(nc client.NetworkClient) NetworksModel {
 
 // dataLoadedMsg is sent when network data has been fetched.
 type dataLoadedMsg struct {
-	tbl  table.Model
-	rows []table.Row
-	err  error
+	tbl   table.Model
+	rows  []table.Row
+	zones []client.NetworkAZ
+	err   error
 }
 
 // Init starts the async data loading.
@@ -48,22 +63,34 @@ func (m NetworksModel) Init() tea.Cmd {
 		if err != nil {
 			return dataLoadedMsg{err: err}
 		}
-		cols := []table.Column{{Title: "ID", Width: uiconst.ColWidthUUID}, {Title: "Name", Width: uiconst.ColWidthName}, {Title: "Status", Width: uiconst.ColWidthStatus}}
+		search.IndexNetworks(netList)
+		cols := []table.Column{{Title: "ID", Width: uiconst.ColWidthUUID}, {Title: i18n.T("Name"), Width: uiconst.ColWidthName}, {Title: i18n.T("Status"), Width: uiconst.ColWidthStatus}}
 		rows := []table.Row{}
 		for _, n := range netList {
-			rows = append(rows, table.Row{n.ID, n.Name, n.Status})
+			rows = append(rows, table.Row{n.ID, n.Name, theme.Mark(n.Status)})
 		}
+		rows = common.TerraformRows(rows)
 		t := table.New(
-			table.WithColumns(cols),
+			table.WithColumns(common.TerraformColumns(cols)),
 			table.WithRows(rows),
 			table.WithFocused(true),
 			table.WithHeight(m.height-uiconst.TableHeightOffset),
 		)
 		t.SetStyles(table.DefaultStyles())
-		return dataLoadedMsg{tbl: t, rows: rows}
+		// Listing the zones may be refused; hints are not checked then.
+		zones, _ := m.client.ListNetworkAZs(context.Background())
+		return dataLoadedMsg{tbl: t, rows: rows, zones: zones}
 	}
 }
 
+// Reload re-fetches the networks, keeping the selected row.
+func (m NetworksModel) Reload() tea.Cmd {
+	if m.loading || m.filterMode {
+		return nil
+	}
+	return m.Init()
+}
+
 // Update handles messages for the model.
 func (m NetworksModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
@@ -73,20 +100,24 @@ func (m NetworksModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.err = msg.err
 			return m, nil
 		}
-		m.table = msg.tbl
+		m.table = common.Reloaded(m.table, msg.tbl)
 		m.updateTableColumns()
-		m.table.SetHeight(m.height - uiconst.TableHeightOffset)
+		m.table.SetHeight(m.height - uiconst.TableHeightOffset - 2)
 		m.allRows = msg.rows
+		m.zones = msg.zones
 		return m, nil
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
 		if m.table.Columns() != nil {
-			m.table.SetHeight(m.height - uiconst.TableHeightOffset)
+			m.table.SetHeight(m.height - uiconst.TableHeightOffset - 2)
 			m.updateTableColumns()
 		}
 		return m, nil
 	case tea.KeyMsg:
+		if m.form != nil {
+			return m.updateForm(msg)
+		}
 		if m.loading || m.err != nil {
 			// ignore key input while loading or on error
 			return m, nil
@@ -126,6 +157,15 @@ func (m NetworksModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			return m, cmd
 		}
+		if msg.String() == "n" {
+			if err := policy.Check(policy.Member, "creating a network"); err != nil {
+				m.status, m.statusErr = err.Error(), true
+				return m, nil
+			}
+			f := common.NewForm([]string{i18n.T("Name"), i18n.T("AZ hints (comma-separated, empty for any zone)")})
+			m.form = &f
+			return m, f.Init()
+		}
 		// Normal table navigation
 		var cmd tea.Cmd
 		m.table, cmd = m.table.Update(msg)
@@ -140,35 +180,82 @@ func (m NetworksModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// updateForm handles keys for the new network form.
+func (m NetworksModel) updateForm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	fm, cmd := m.form.Update(msg)
+	f := fm.(common.FormModel)
+	m.form = &f
+	switch {
+	case f.Cancelled():
+		m.form = nil
+	case f.Submitted():
+		v := f.Values()
+		hints, err := parseAZHints(v[1], m.zones, "network")
+		if err != nil {
+			m.form.SetError(err)
+			return m, nil
+		}
+		m.form = nil
+		m.status, m.statusErr = i18n.T("Queued new network %s; follow it with :jobs", v[0]), false
+		nc := m.client
+		opts := networks.CreateOpts{Name: v[0], AvailabilityZoneHints: hints}
+		return m, ops.Enqueue("New network: "+v[0], "", func(ctx context.Context) (string, ops.Poll, error) {
+			created, err := nc.CreateNetwork(ctx, opts)
+			if err != nil {
+				policy.Record("creating a network", err)
+				return "", nil, err
+			}
+			return "Created network " + created.ID, nil, nil
+		})
+	}
+	return m, cmd
+}
+
+// CapturingInput reports whether the new network form is open.
+func (m NetworksModel) CapturingInput() bool { return m.form != nil }
+
 // View renders the appropriate UI based on state.
 func (m NetworksModel) View() string {
+	if m.form != nil {
+		known := []string{}
+		for _, az := range m.zones {
+			if az.Resource == "network" {
+				known = append(known, az.Name)
+			}
+		}
+		return i18n.T("New network – AZ hints pin its DHCP agents to zones (known: %s)", azList(known, "unknown")) + "\n\n" + m.form.View() +
+			"\n" + i18n.T("[tab] next field  [enter] next/create  [esc] cancel")
+	}
 	if m.loading {
 		return m.spinner.View()
 	}
 	if m.err != nil {
-		return fmt.Sprintf("Error: %s", m.err)
+		return i18n.T("Error: %s", m.err)
 	}
 	if m.filterMode {
-		filterLine := fmt.Sprintf("Filter: %s", m.filter.View())
+		filterLine := i18n.T("Filter: %s", m.filter.View())
 		footer := "esc: clear"
 		return fmt.Sprintf("%s\n%s\n%s", filterLine, m.table.View(), footer)
 	}
-	return m.table.View()
+	return m.table.View() + changeStatusLine(m.status, m.statusErr, "", "") + "\n" + i18n.T("[enter] subnets  [/] filter") + "  " + policy.Key(policy.Member, i18n.T("[n] new network"), "creating a network")
 }
 
 // Ensure NetworksModel implements tea.Model.
 // Table returns the underlying table model.
 func (m NetworksModel) Table() table.Model { return m.table }
 
+// Filtering reports whether keys are typed into the filter.
+func (m NetworksModel) Filtering() bool { return m.filterMode }
+
 // updateTableColumns adjusts column widths based on the current width.
 func (m *NetworksModel) updateTableColumns() {
 	idW := uiconst.ColWidthUUID
 	statusW := uiconst.ColWidthStatus
-	nameW := m.width - idW - statusW - 6
+	nameW := m.width - idW - statusW - 6 - common.TerraformWidth()
 	if nameW < 10 {
 		nameW = 10
 	}
-	m.table.SetColumns([]table.Column{{Title: "ID", Width: idW}, {Title: "Name", Width: nameW}, {Title: "Status", Width: statusW}})
+	m.table.SetColumns(common.TerraformColumns([]table.Column{{Title: "ID", Width: idW}, {Title: i18n.T("Name"), Width: nameW}, {Title: i18n.T("Status"), Width: statusW}}))
 }
 
 var _ tea.Model = (*NetworksModel)(nil)
@@ -3,10 +3,14 @@ package network
 import (
 	"context"
 	"fmt"
+	"strings"
+
 	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/table"
 	tea "github.com/charmbracelet/bubbletea"
 	"ostui/internal/client"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/common"
 	"ostui/internal/ui/uiconst"
 )
 
@@ -19,13 +23,32 @@ type PortDetailModel struct {
 	spinner spinner.Model
 	client  client.NetworkClient
 	portID  string
+	// links opens the network and the device of the port.
+	links common.Links
 }
 
 type portDetailDataLoadedMsg struct {
-	tbl table.Model
-	err error
+	tbl   table.Model
+	links common.Links
+	err   error
+}
+
+// portLinks links the network of p, and its device when it is a server or
+// a router.
+func portLinks(p *client.Port) common.Links {
+	links := common.Links{"NetworkID": common.KindNetwork}
+	switch {
+	case strings.HasPrefix(p.DeviceOwner, "compute:"):
+		links["DeviceID"] = common.KindServer
+	case strings.HasPrefix(p.DeviceOwner, "network:router_interface"), p.DeviceOwner == "network:router_gateway":
+		links["DeviceID"] = common.KindRouter
+	}
+	return links
 }
 
+// ResourceID returns the port ID.
+func (m PortDetailModel) ResourceID() string { return m.portID }
+
 // NewPortDetailModel creates a new PortDetailModel for the given port ID.
 func NewPortDetailModel(nc client.NetworkClient, portID string) PortDetailModel {
 	s := spinner.New()
@@ -49,18 +72,26 @@ func (m PortDetailModel) Init() tea.Cmd {
 			}
 			fixedIPs = fmt.Sprintf("%s", fmt.Sprint(parts))
 		}
-		cols := []table.Column{{Title: "Field", Width: uiconst.ColWidthField}, {Title: "Value", Width: uiconst.ColWidthValue}}
-		rows := []table.Row{{"ID", p.ID}, {"Name", p.Name}, {"Status", fmt.Sprintf("%v", p.Status)}, {"NetworkID", p.NetworkID}, {"MACAddress", p.MACAddress}, {"DeviceOwner", p.DeviceOwner}, {"FixedIPs", fixedIPs}}
+		cols := []table.Column{{Title: i18n.T("Field"), Width: uiconst.ColWidthField}, {Title: i18n.T("Value"), Width: uiconst.ColWidthValue}}
+		rows := []table.Row{{"ID", p.ID}, {"Name", p.Name}, {"Status", fmt.Sprintf("%v", p.Status)}, {"NetworkID", p.NetworkID}, {"MACAddress", p.MACAddress}, {"DeviceOwner", p.DeviceOwner}, {"DeviceID", p.DeviceID}, {"FixedIPs", fixedIPs}}
 		t := table.New(
 			table.WithColumns(cols),
 			table.WithRows(rows),
 			table.WithFocused(true),
 		)
 		t.SetStyles(table.DefaultStyles())
-		return portDetailDataLoadedMsg{tbl: t}
+		return portDetailDataLoadedMsg{tbl: t, links: portLinks(p)}
 	}
 }
 
+// Reload re-fetches the port, keeping the selected row.
+func (m PortDetailModel) Reload() tea.Cmd {
+	if m.loading {
+		return nil
+	}
+	return m.Init()
+}
+
 // Update handles messages.
 func (m PortDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
@@ -70,7 +101,8 @@ func (m PortDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.err = msg.err
 			return m, nil
 		}
-		m.table = msg.tbl
+		m.table = common.Reloaded(m.table, msg.tbl)
+		m.links = msg.links
 		return m, nil
 	case tea.WindowSizeMsg:
 		if !m.loading && len(m.table.Columns()) > 0 {
@@ -94,6 +126,9 @@ func (m PortDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.loading || m.err != nil {
 			return m, nil
 		}
+		if msg.String() == "enter" {
+			return m, m.links.Open(m.table)
+		}
 		var cmd tea.Cmd
 		m.table, cmd = m.table.Update(msg)
 		return m, cmd
@@ -113,9 +148,13 @@ func (m PortDetailModel) View() string {
 		return m.spinner.View()
 	}
 	if m.err != nil {
-		return fmt.Sprintf("Error: %s", m.err)
+		return i18n.T("Error: %s", m.err)
+	}
+	hints := i18n.T("[esc] back")
+	if h := m.links.Hint(m.table); h != "" {
+		hints = h + "  " + hints
 	}
-	return fmt.Sprintf("%s\n[esc] back", m.table.View())
+	return fmt.Sprintf("%s\n%s", m.table.View(), hints)
 }
 
 // Table returns the underlying table model.
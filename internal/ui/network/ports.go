@@ -9,15 +9,17 @@ import (
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"ostui/internal/client"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/common"
+	"ostui/internal/ui/theme"
 	"ostui/internal/ui/uiconst"
-	"strings"
 )
 
 // PortsModel implements a view that lists ports and shows a read‑only detail view for a selected port.
 type PortsModel struct {
 	// UI components
-	table       table.Model // list view
-	detailTable table.Model // detail view
+	table       common.VirtualTable // list view; projects hold many ports
+	detailTable table.Model         // detail view
 	loading     bool
 	err         error
 	spinner     spinner.Model
@@ -32,7 +34,6 @@ type PortsModel struct {
 	// State management
 	mode       string // "list" or "detail"
 	portID     string // selected port ID for detail view
-	allRows    []table.Row
 	filterMode bool
 	filter     textinput.Model
 
@@ -47,12 +48,23 @@ func NewPortsModel(nc client.NetworkClient) PortsModel {
 	s.Spinner = spinner.Dot
 	ti := textinput.New()
 	ti.Placeholder = "filter..."
-	return PortsModel{client: nc, loading: true, spinner: s, filter: ti, mode: "list", width: 120, height: 30}
+	m := PortsModel{client: nc, loading: true, spinner: s, filter: ti, mode: "list", width: 120, height: 30}
+	t := table.New(table.WithFocused(true), table.WithHeight(m.height-uiconst.TableHeightOffset))
+	t.SetStyles(table.DefaultStyles())
+	m.table = common.NewVirtualTable(t, stylePortRow)
+	m.updateTableColumns()
+	return m
+}
+
+// stylePortRow marks the status of a port row on screen.
+func stylePortRow(r table.Row) table.Row {
+	out := append(table.Row(nil), r...)
+	out[3] = theme.Mark(out[3])
+	return out
 }
 
 // portsListMsg is emitted when the list of ports has been fetched.
 type portsListMsg struct {
-	tbl  table.Model
 	rows []table.Row
 	err  error
 }
@@ -71,20 +83,20 @@ func (m PortsModel) Init() tea.Cmd {
 		if err != nil {
 			return portsListMsg{err: err}
 		}
-		cols := []table.Column{{Title: "ID", Width: uiconst.ColWidthUUID}, {Title: "Name", Width: uiconst.ColWidthName}, {Title: "Network ID", Width: uiconst.ColWidthUUID}, {Title: "Status", Width: uiconst.ColWidthStatus}}
-		rows := []table.Row{}
+		rows := make([]table.Row, 0, len(ports))
 		for _, p := range ports {
-			rows = append(rows, table.Row{p.ID, p.Name, p.NetworkID, fmt.Sprintf("%v", p.Status)})
+			rows = append(rows, table.Row{p.ID, p.Name, p.NetworkID, p.Status})
 		}
-		t := table.New(
-			table.WithColumns(cols),
-			table.WithRows(rows),
-			table.WithFocused(true),
-			table.WithHeight(m.height-uiconst.TableHeightOffset),
-		)
-		t.SetStyles(table.DefaultStyles())
-		return portsListMsg{tbl: t, rows: rows}
+		return portsListMsg{rows: common.TerraformRows(rows)}
+	}
+}
+
+// Reload re-fetches the ports, keeping the selected row.
+func (m PortsModel) Reload() tea.Cmd {
+	if m.loading || m.filterMode {
+		return nil
 	}
+	return m.Init()
 }
 
 // loadPortDetailCmd returns a command that fetches details for the given port.
@@ -94,7 +106,7 @@ func (m PortsModel) loadPortDetailCmd(portID string) tea.Cmd {
 		if err != nil {
 			return portDetailMsg{err: err}
 		}
-		cols := []table.Column{{Title: "Field", Width: uiconst.ColWidthField}, {Title: "Value", Width: uiconst.ColWidthValue}}
+		cols := []table.Column{{Title: i18n.T("Field"), Width: uiconst.ColWidthField}, {Title: i18n.T("Value"), Width: uiconst.ColWidthValue}}
 		rows := []table.Row{{"ID", p.ID}, {"Name", p.Name}, {"Network ID", p.NetworkID}, {"Status", fmt.Sprintf("%v", p.Status)}, {"MAC Address", p.MACAddress}, {"Device ID", p.DeviceID}}
 		t := table.New(
 			table.WithColumns(cols),
@@ -115,10 +127,7 @@ func (m PortsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.err = msg.err
 			return m, nil
 		}
-		m.table = msg.tbl
-		m.allRows = msg.rows
-		m.updateTableColumns()
-		m.table.SetHeight(m.height - uiconst.TableHeightOffset)
+		m.table.SetRows(msg.rows)
 		return m, nil
 	case portDetailMsg:
 		m.loading = false
@@ -133,10 +142,8 @@ func (m PortsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
-		if m.table.Columns() != nil {
-			m.table.SetHeight(m.height - uiconst.TableHeightOffset)
-			m.updateTableColumns()
-		}
+		m.table.SetHeight(m.height - uiconst.TableHeightOffset)
+		m.updateTableColumns()
 		return m, nil
 	case tea.KeyMsg:
 		// If Inspect view is active, handle its keys.
@@ -172,28 +179,13 @@ func (m PortsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.filterMode = false
 				m.filter.Blur()
 				m.filter.SetValue("")
-				m.table.SetRows(m.allRows)
+				m.table.Filter("")
 				return m, nil
 			}
 			if m.filterMode {
 				var cmd tea.Cmd
 				m.filter, cmd = m.filter.Update(msg)
-				filterVal := m.filter.Value()
-				if filterVal == "" {
-					m.table.SetRows(m.allRows)
-				} else {
-					lower := strings.ToLower(filterVal)
-					filtered := []table.Row{}
-					for _, r := range m.allRows {
-						for _, c := range r {
-							if strings.Contains(strings.ToLower(c), lower) {
-								filtered = append(filtered, r)
-								break
-							}
-						}
-					}
-					m.table.SetRows(filtered)
-				}
+				m.table.Filter(m.filter.Value())
 				return m, cmd
 			}
 			if msg.String() == "enter" {
@@ -212,7 +204,7 @@ func (m PortsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.mode == "detail" {
 			if msg.String() == "i" {
 				// Build inspect view for the selected port.
-				content := fmt.Sprintf("=== Port: %s ===\nID: %s\nName: %s\nNetworkID: %s\nStatus: %v\nMACAddress: %s\nDeviceID: %s",
+				content := i18n.T("=== Port: %s ===\nID: %s\nName: %s\nNetworkID: %s\nStatus: %v\nMACAddress: %s\nDeviceID: %s",
 					m.port.Name, m.port.ID, m.port.Name, m.port.NetworkID, m.port.Status, m.port.MACAddress, m.port.DeviceID)
 				m.inspectView = content
 				m.inspectViewport = viewport.New(80, 24)
@@ -239,21 +231,21 @@ func (m PortsModel) View() string {
 		return m.spinner.View()
 	}
 	if m.err != nil {
-		return fmt.Sprintf("Error: %s", m.err)
+		return i18n.T("Error: %s", m.err)
 	}
 	if m.inspectView != "" {
-		return fmt.Sprintf("%s\n %3.f%% | [j/k] scroll  [esc] close", m.inspectViewport.View(), m.inspectViewport.ScrollPercent()*100)
+		return i18n.T("%s\n %3.f%% | [j/k] scroll  [esc] close", m.inspectViewport.View(), m.inspectViewport.ScrollPercent()*100)
 	}
 	if m.mode == "list" {
 		if m.filterMode {
-			filterLine := fmt.Sprintf("Filter: %s", m.filter.View())
-			footer := "esc: clear"
+			filterLine := i18n.T("Filter: %s", m.filter.View())
+			footer := i18n.T("%d of %d  esc: clear", m.table.Len(), m.table.Total())
 			return fmt.Sprintf("%s\n%s\n%s", filterLine, m.table.View(), footer)
 		}
 		return m.table.View()
 	}
 	// Detail view
-	header := fmt.Sprintf("Port %s details (press esc to go back)", m.portID)
+	header := i18n.T("Port %s details (press esc to go back)", m.portID)
 	return fmt.Sprintf("%s\n%s", header, m.detailTable.View())
 }
 
@@ -262,14 +254,20 @@ func (m *PortsModel) updateTableColumns() {
 	idW := uiconst.ColWidthUUID
 	netIDW := uiconst.ColWidthUUID
 	statusW := uiconst.ColWidthStatus
-	nameW := m.width - idW - netIDW - statusW - uiconst.TableHeightOffset
+	nameW := m.width - idW - netIDW - statusW - uiconst.TableHeightOffset - common.TerraformWidth()
 	if nameW < 10 {
 		nameW = 10
 	}
-	m.table.SetColumns([]table.Column{{Title: "ID", Width: idW}, {Title: "Name", Width: nameW}, {Title: "Network ID", Width: netIDW}, {Title: "Status", Width: statusW}})
+	m.table.SetColumns(common.TerraformColumns([]table.Column{{Title: "ID", Width: idW}, {Title: i18n.T("Name"), Width: nameW}, {Title: i18n.T("Network ID"), Width: netIDW}, {Title: i18n.T("Status"), Width: statusW}}))
 }
 
 // Table returns the primary table (list view) – useful for navigation.
-func (m PortsModel) Table() table.Model { return m.table }
+func (m PortsModel) Table() table.Model { return m.table.Table() }
+
+// FullTable returns all the listed ports, for the quick jump.
+func (m PortsModel) FullTable() table.Model { return m.table.FullTable() }
+
+// Filtering reports whether keys are typed into the filter.
+func (m PortsModel) Filtering() bool { return m.filterMode }
 
 var _ tea.Model = (*PortsModel)(nil)
@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"github.com/charmbracelet/bubbles/table"
 	"ostui/internal/client"
+	"ostui/internal/i18n"
 	"ostui/internal/ui/common"
+	"ostui/internal/ui/theme"
 	"ostui/internal/ui/uiconst"
 )
 
@@ -12,12 +14,12 @@ import (
 func RenderNetworks(nc client.NetworkClient) string {
 	netList, err := nc.ListNetworks()
 	if err != nil {
-		return fmt.Sprintf("Failed to list networks: %s", err)
+		return i18n.T("Failed to list networks: %s", err)
 	}
-	cols := []table.Column{{Title: "ID", Width: uiconst.ColWidthUUID}, {Title: "Name", Width: uiconst.ColWidthName}, {Title: "Status", Width: uiconst.ColWidthStatus}}
+	cols := []table.Column{{Title: "ID", Width: uiconst.ColWidthUUID}, {Title: i18n.T("Name"), Width: uiconst.ColWidthName}, {Title: i18n.T("Status"), Width: uiconst.ColWidthStatus}}
 	rows := []table.Row{}
 	for _, n := range netList {
-		rows = append(rows, table.Row{n.ID, n.Name, n.Status})
+		rows = append(rows, table.Row{n.ID, n.Name, theme.Mark(n.Status)})
 	}
 	t := table.New(
 		table.WithColumns(cols),
@@ -33,9 +35,9 @@ func RenderNetworks(nc client.NetworkClient) string {
 func RenderSubnets(nc client.NetworkClient) string {
 	subList, err := nc.ListSubnets()
 	if err != nil {
-		return fmt.Sprintf("Failed to list subnets: %s", err)
+		return i18n.T("Failed to list subnets: %s", err)
 	}
-	cols := []table.Column{{Title: "ID", Width: uiconst.ColWidthUUID}, {Title: "Name", Width: uiconst.ColWidthName}, {Title: "CIDR", Width: uiconst.ColWidthCIDR}, {Title: "IPVer", Width: uiconst.ColWidthIPVersion}}
+	cols := []table.Column{{Title: "ID", Width: uiconst.ColWidthUUID}, {Title: i18n.T("Name"), Width: uiconst.ColWidthName}, {Title: "CIDR", Width: uiconst.ColWidthCIDR}, {Title: i18n.T("IPVer"), Width: uiconst.ColWidthIPVersion}}
 	rows := []table.Row{}
 	for _, s := range subList {
 		rows = append(rows, table.Row{s.ID, s.Name, s.CIDR, fmt.Sprintf("%d", s.IPVersion)})
@@ -54,12 +56,12 @@ func RenderSubnets(nc client.NetworkClient) string {
 func RenderFloatingIPs(nc client.NetworkClient) string {
 	fipList, err := nc.ListFloatingIPs()
 	if err != nil {
-		return fmt.Sprintf("Failed to list floating IPs: %s", err)
+		return i18n.T("Failed to list floating IPs: %s", err)
 	}
-	cols := []table.Column{{Title: "ID", Width: uiconst.ColWidthUUID}, {Title: "FloatingNetworkID", Width: uiconst.ColWidthUUID}, {Title: "FixedIP", Width: uiconst.ColWidthFixed}, {Title: "PortID", Width: uiconst.ColWidthUUID}, {Title: "Status", Width: uiconst.ColWidthStatus}}
+	cols := []table.Column{{Title: "ID", Width: uiconst.ColWidthUUID}, {Title: i18n.T("FloatingNetworkID"), Width: uiconst.ColWidthUUID}, {Title: i18n.T("FixedIP"), Width: uiconst.ColWidthFixed}, {Title: i18n.T("PortID"), Width: uiconst.ColWidthUUID}, {Title: i18n.T("Status"), Width: uiconst.ColWidthStatus}}
 	rows := []table.Row{}
 	for _, f := range fipList {
-		rows = append(rows, table.Row{f.ID, f.FloatingNetworkID, f.FixedIP, f.PortID, f.Status})
+		rows = append(rows, table.Row{f.ID, f.FloatingNetworkID, f.FixedIP, f.PortID, theme.Mark(f.Status)})
 	}
 	t := table.New(
 		table.WithColumns(cols),
@@ -75,9 +77,9 @@ func RenderFloatingIPs(nc client.NetworkClient) string {
 func RenderSecurityGroups(nc client.NetworkClient) string {
 	sgList, err := nc.ListSecurityGroups()
 	if err != nil {
-		return fmt.Sprintf("Failed to list security groups: %s", err)
+		return i18n.T("Failed to list security groups: %s", err)
 	}
-	cols := []table.Column{{Title: "ID", Width: uiconst.ColWidthUUID}, {Title: "Name", Width: uiconst.ColWidthName}, {Title: "Description", Width: uiconst.ColWidthDescription}, {Title: "Stateful", Width: uiconst.ColWidthStateful}}
+	cols := []table.Column{{Title: "ID", Width: uiconst.ColWidthUUID}, {Title: i18n.T("Name"), Width: uiconst.ColWidthName}, {Title: i18n.T("Description"), Width: uiconst.ColWidthDescription}, {Title: i18n.T("Stateful"), Width: uiconst.ColWidthStateful}}
 	rows := []table.Row{}
 	for _, sg := range sgList {
 		rows = append(rows, table.Row{sg.ID, sg.Name, sg.Description, fmt.Sprintf("%v", sg.Stateful)})
@@ -96,7 +98,7 @@ func RenderSecurityGroups(nc client.NetworkClient) string {
 func RenderSecurityGroupDetail(nc client.NetworkClient, sgID string) string {
 	sgList, err := nc.ListSecurityGroups()
 	if err != nil {
-		return fmt.Sprintf("Failed to list security groups: %s", err)
+		return i18n.T("Failed to list security groups: %s", err)
 	}
 	var sg *struct {
 		ID          string
@@ -119,16 +121,16 @@ func RenderSecurityGroupDetail(nc client.NetworkClient, sgID string) string {
 		return "Security group not found"
 	}
 	fields := map[string]string{
-		"ID":          sg.ID,
-		"Name":        sg.Name,
-		"Description": sg.Description,
-		"Stateful":    fmt.Sprintf("%v", sg.Stateful),
+		"ID":                  sg.ID,
+		i18n.T("Name"):        sg.Name,
+		i18n.T("Description"): sg.Description,
+		i18n.T("Stateful"):    fmt.Sprintf("%v", sg.Stateful),
 	}
-	return common.NewDetail("Security Group Details", fields).View()
+	return common.NewDetail(i18n.T("Security Group Details"), fields).View()
 }
 
 // RenderAllocateFloatingIPForm returns a simple form for allocating a floating IP.
 func RenderAllocateFloatingIPForm() string {
 	// The form only requires the FloatingNetworkID field for the test.
-	return common.NewForm([]string{"FloatingNetworkID"}).View()
+	return common.NewForm([]string{i18n.T("FloatingNetworkID")}).View()
 }
@@ -0,0 +1,240 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/layer3/floatingips"
+	"ostui/internal/client"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/common"
+	"ostui/internal/ui/policy"
+	"ostui/internal/ui/theme"
+)
+
+// Fields of the new router form. Distributed and HA are only asked for
+// admins, who may set them by default policy.
+const (
+	routerFieldName = iota
+	routerFieldSNAT
+	routerFieldAZ
+	routerFieldDistributed
+	routerFieldHA
+)
+
+// routerZonesMsg carries the zones with L3 agents, checked against the AZ
+// hints of a new router.
+type routerZonesMsg struct{ zones []client.NetworkAZ }
+
+// noGateway is the picker item of a router without an external gateway.
+const noGateway = "none"
+
+// newGatewayPicker lists the external networks a new router can use as its
+// gateway, after the choice of none.
+func newGatewayPicker(nc client.NetworkClient) common.PickerModel {
+	cols := []common.PickerColumn{{Title: i18n.T("Name"), Width: 24}, {Title: i18n.T("Subnets"), Width: 8}, {Title: "ID", Width: 36}}
+	return common.NewPicker(i18n.T("External network (router gateway)"), cols, func() ([]common.PickerItem, error) {
+		nets, err := nc.ListExternalNetworks(context.Background())
+		if err != nil {
+			return nil, err
+		}
+		items := []common.PickerItem{{ID: noGateway, Cells: []string{"(no gateway)", "", ""}}}
+		for _, n := range nets {
+			items = append(items, common.PickerItem{ID: n.ID, Cells: []string{n.Name, fmt.Sprintf("%d", len(n.Subnets)), n.ID}})
+		}
+		return items, nil
+	})
+}
+
+// newRouterForm returns the form of a new router behind gateway, with the
+// admin-only flags when the user is expected to be an admin.
+func newRouterForm(gateway string) common.FormModel {
+	fields := []string{"Name", "Enable SNAT (yes/no)", "AZ hints (comma-separated, empty for any zone)"}
+	if policy.Allowed(policy.Admin) {
+		fields = append(fields, i18n.T("Distributed (yes/no, empty for default)"), i18n.T("HA (yes/no, empty for default)"))
+	}
+	f := common.NewForm(fields)
+	if gateway != "" {
+		f.SetValue(routerFieldSNAT, "yes")
+	}
+	return f
+}
+
+// yesNo parses an optional yes/no form field; empty leaves the default.
+func yesNo(s, field string) (*bool, error) {
+	switch strings.ToLower(s) {
+	case "":
+		return nil, nil
+	case "yes", "y", "true":
+		v := true
+		return &v, nil
+	case "no", "n", "false":
+		v := false
+		return &v, nil
+	}
+	return nil, fmt.Errorf("%s must be yes or no", field)
+}
+
+// routerOptsFromForm validates the new router form values.
+func routerOptsFromForm(v []string, gateway string, zones []client.NetworkAZ) (client.RouterCreateOpts, error) {
+	opts := client.RouterCreateOpts{Name: v[routerFieldName], ExternalNetworkID: gateway}
+	snat, err := yesNo(v[routerFieldSNAT], "SNAT")
+	if err != nil {
+		return opts, err
+	}
+	if snat != nil && gateway == "" {
+		if *snat {
+			return opts, fmt.Errorf("SNAT needs an external gateway; pick a network or leave SNAT empty")
+		}
+		snat = nil
+	}
+	opts.EnableSNAT = snat
+	if opts.AZHints, err = parseAZHints(v[routerFieldAZ], zones, "router"); err != nil {
+		return opts, err
+	}
+	if len(v) > routerFieldHA {
+		if opts.Distributed, err = yesNo(v[routerFieldDistributed], "distributed"); err != nil {
+			return opts, err
+		}
+		if opts.HA, err = yesNo(v[routerFieldHA], "HA"); err != nil {
+			return opts, err
+		}
+	}
+	return opts, nil
+}
+
+// createRouterCmd creates the router.
+func createRouterCmd(nc client.NetworkClient, opts client.RouterCreateOpts) tea.Cmd {
+	return func() tea.Msg {
+		created, err := nc.CreateRouter(context.Background(), opts)
+		if err != nil {
+			return changeDoneMsg{action: "creating a router", err: err}
+		}
+		return changeDoneMsg{status: i18n.T("Created router %s", created.ID)}
+	}
+}
+
+// routerPreflight lists what depends on a router before it is deleted.
+// Interfaces are removed by the delete; floating IPs and VPN services that
+// use the router block it, as Neutron would refuse.
+type routerPreflight struct {
+	routerID string
+	name     string
+	// gateway describes the external gateway, empty without one.
+	gateway string
+	ifaces  []client.Port
+	fips    []floatingips.FloatingIP
+	vpns    []client.VPNService
+	// errs are lookups that failed; the preview is then incomplete.
+	errs []string
+}
+
+// routerPreflightMsg carries the dependency preview of a router delete.
+type routerPreflightMsg struct{ preflight routerPreflight }
+
+// loadRouterPreflightCmd gathers the interfaces, floating IPs and VPN
+// services of a router. A failed lookup is shown rather than blocking.
+func loadRouterPreflightCmd(nc client.NetworkClient, routerID, name string) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		p := routerPreflight{routerID: routerID, name: name}
+		if r, err := nc.GetRouter(ctx, routerID); err != nil {
+			p.errs = append(p.errs, "gateway: "+err.Error())
+		} else if r != nil && r.GatewayInfo.NetworkID != "" {
+			p.gateway = "network " + r.GatewayInfo.NetworkID
+			if ips := r.GatewayInfo.ExternalFixedIPs; len(ips) > 0 {
+				p.gateway = ips[0].IPAddress + " on " + p.gateway
+			}
+		}
+		ports, err := nc.ListPortsByServer(ctx, routerID)
+		if err != nil {
+			p.errs = append(p.errs, "interfaces: "+err.Error())
+		}
+		for _, port := range ports {
+			if strings.HasPrefix(port.DeviceOwner, "network:router_interface") {
+				p.ifaces = append(p.ifaces, port)
+			}
+		}
+		fips, err := nc.ListFloatingIPs()
+		if err != nil {
+			p.errs = append(p.errs, "floating IPs: "+err.Error())
+		}
+		for _, f := range fips {
+			if f.RouterID == routerID && f.PortID != "" {
+				p.fips = append(p.fips, f)
+			}
+		}
+		// VPNaaS may not be deployed; a failure then says nothing.
+		vpns, _ := nc.ListVPNServices(ctx)
+		for _, s := range vpns {
+			if s.RouterID == routerID {
+				p.vpns = append(p.vpns, s)
+			}
+		}
+		return routerPreflightMsg{preflight: p}
+	}
+}
+
+// blocked reports whether the router cannot be deleted as it is.
+func (p routerPreflight) blocked() bool { return len(p.fips) > 0 || len(p.vpns) > 0 }
+
+// render summarises what deleting the router does or what stops it.
+func (p routerPreflight) render() string {
+	danger := lipgloss.NewStyle().Foreground(theme.Error)
+	warn := lipgloss.NewStyle().Foreground(theme.Warn)
+	var b strings.Builder
+	b.WriteString(i18n.T("Deleting router %s will:", p.name) + "\n")
+	for _, port := range p.ifaces {
+		subnet, ip := port.NetworkID, ""
+		if len(port.FixedIPs) > 0 {
+			subnet, ip = port.FixedIPs[0].SubnetID, port.FixedIPs[0].IPAddress
+		}
+		b.WriteString(i18n.T("  remove the interface %s on subnet %s", ip, subnet) + "\n")
+	}
+	if len(p.ifaces) == 0 {
+		b.WriteString("  " + i18n.T("remove no interfaces") + "\n")
+	}
+	if p.gateway != "" {
+		b.WriteString(i18n.T("  release its external gateway %s", p.gateway) + "\n")
+	}
+	for _, e := range p.errs {
+		b.WriteString(warn.Render("  could not check "+e) + "\n")
+	}
+	if p.blocked() {
+		b.WriteString(danger.Render("\n"+i18n.T("In use – Neutron refuses the delete until these are gone:")) + "\n")
+		for _, f := range p.fips {
+			b.WriteString(danger.Render(i18n.T("  floating IP %s → %s", f.FloatingIP, f.FixedIP)) + "\n")
+		}
+		for _, s := range p.vpns {
+			b.WriteString(danger.Render(i18n.T("  VPN service %s", s.Name)) + "\n")
+		}
+		return b.String() + "\n" + i18n.T("[esc] back")
+	}
+	return b.String() + "\nDelete? [y/N]"
+}
+
+// deleteRouterCmd removes the interfaces of a router, then the router.
+func deleteRouterCmd(nc client.NetworkClient, p routerPreflight) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		removed := map[string]bool{}
+		for _, port := range p.ifaces {
+			for _, ip := range port.FixedIPs {
+				if removed[ip.SubnetID] {
+					continue
+				}
+				removed[ip.SubnetID] = true
+				if err := nc.RemoveRouterInterface(ctx, p.routerID, ip.SubnetID); err != nil {
+					return changeDoneMsg{action: "deleting a router", err: fmt.Errorf("remove interface on subnet %s: %w", ip.SubnetID, err)}
+				}
+			}
+		}
+		if err := nc.DeleteRouter(ctx, p.routerID); err != nil {
+			return changeDoneMsg{action: "deleting a router", err: err}
+		}
+		return changeDoneMsg{status: i18n.T("Deleted router %s", p.name)}
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"github.com/charmbracelet/bubbles/table"
 	tea "github.com/charmbracelet/bubbletea"
 	"ostui/internal/client"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/common"
 	"ostui/internal/ui/uiconst"
 )
 
@@ -19,11 +21,24 @@ type RouterDetailModel struct {
 	spinner  spinner.Model
 	client   client.NetworkClient
 	routerID string
+	name     string
 }
 
 type routerDetailDataLoadedMsg struct {
-	tbl table.Model
-	err error
+	tbl  table.Model
+	name string
+	err  error
+}
+
+// ResourceID returns the router ID.
+func (m RouterDetailModel) ResourceID() string { return m.routerID }
+
+// Actions lists what can be done to the router for the action palette.
+func (m RouterDetailModel) Actions() []common.Action {
+	if m.loading || m.err != nil {
+		return nil
+	}
+	return []common.Action{{Key: "L", Name: "l3 agents", Help: "L3 agents hosting the router, with HA state (admin)"}}
 }
 
 // NewRouterDetailModel creates a new RouterDetailModel for the given router ID.
@@ -45,18 +60,29 @@ func (m RouterDetailModel) Init() tea.Cmd {
 		if r != nil {
 			external = r.GatewayInfo.NetworkID
 		}
-		cols := []table.Column{{Title: "Field", Width: uiconst.ColWidthField}, {Title: "Value", Width: uiconst.ColWidthValue}}
+		cols := []table.Column{{Title: i18n.T("Field"), Width: uiconst.ColWidthField}, {Title: i18n.T("Value"), Width: uiconst.ColWidthValue}}
 		rows := []table.Row{{"ID", r.ID}, {"Name", r.Name}, {"Status", fmt.Sprintf("%v", r.Status)}, {"AdminStateUp", fmt.Sprintf("%v", r.AdminStateUp)}, {"ExternalGateway", external}}
+		if azs, err := m.client.GetRouterAZs(context.Background(), m.routerID); err == nil {
+			rows = append(rows, azPlacementRows(azs)...)
+		}
 		t := table.New(
 			table.WithColumns(cols),
 			table.WithRows(rows),
 			table.WithFocused(true),
 		)
 		t.SetStyles(table.DefaultStyles())
-		return routerDetailDataLoadedMsg{tbl: t}
+		return routerDetailDataLoadedMsg{tbl: t, name: r.Name}
 	}
 }
 
+// Reload re-fetches the router, keeping the selected row.
+func (m RouterDetailModel) Reload() tea.Cmd {
+	if m.loading {
+		return nil
+	}
+	return m.Init()
+}
+
 // Update handles messages.
 func (m RouterDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
@@ -66,7 +92,7 @@ func (m RouterDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.err = msg.err
 			return m, nil
 		}
-		m.table = msg.tbl
+		m.table, m.name = common.Reloaded(m.table, msg.tbl), msg.name
 		return m, nil
 	case tea.WindowSizeMsg:
 		if !m.loading && len(m.table.Columns()) > 0 {
@@ -90,6 +116,10 @@ func (m RouterDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.loading || m.err != nil {
 			return m, nil
 		}
+		if msg.String() == "L" {
+			open := OpenRouterL3AgentsMsg{RouterID: m.routerID, Name: m.name}
+			return m, func() tea.Msg { return open }
+		}
 		var cmd tea.Cmd
 		m.table, cmd = m.table.Update(msg)
 		return m, cmd
@@ -109,9 +139,9 @@ func (m RouterDetailModel) View() string {
 		return m.spinner.View()
 	}
 	if m.err != nil {
-		return fmt.Sprintf("Error: %s", m.err)
+		return i18n.T("Error: %s", m.err)
 	}
-	return fmt.Sprintf("%s\n[esc] back", m.table.View())
+	return i18n.T("%s\n[L] L3 agents  [esc] back", m.table.View())
 }
 
 // Table returns the underlying table model.
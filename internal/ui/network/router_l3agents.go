@@ -0,0 +1,413 @@
+package network
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/gophercloud/gophercloud"
+	"ostui/internal/client"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/policy"
+	"ostui/internal/ui/theme"
+	"ostui/internal/ui/uiconst"
+)
+
+// OpenRouterL3AgentsMsg asks the app to open the L3 agent scheduling view
+// of a router.
+type OpenRouterL3AgentsMsg struct {
+	RouterID string
+	Name     string
+}
+
+// l3Error explains the usual failures of the admin-only L3 agent scheduler
+// API.
+func l3Error(err error) error {
+	var forbidden gophercloud.ErrDefault403
+	var missing gophercloud.ErrDefault404
+	switch {
+	case errors.As(err, &forbidden):
+		return fmt.Errorf("%w (L3 agent scheduling requires admin credentials)", err)
+	case errors.As(err, &missing):
+		return fmt.Errorf("%w (is the l3_agent_scheduler extension enabled?)", err)
+	}
+	return err
+}
+
+// l3Warnings explains what is wrong with the placement of a router on its
+// L3 agents: nowhere to forward, a dead legacy host, or HA instances that
+// disagree on which one is active, which shows up as asymmetric routing.
+func l3Warnings(hosts []client.L3Agent) []string {
+	if len(hosts) == 0 {
+		return []string{"not scheduled on any L3 agent: the router does not forward"}
+	}
+	var out []string
+	ha, active := false, 0
+	for _, a := range hosts {
+		up := a.Alive && a.AdminStateUp
+		switch a.HAState {
+		case "":
+			if !up {
+				out = append(out, i18n.T("the hosting agent on %s is down: the router does not forward", a.Host))
+			}
+		case "active":
+			ha = true
+			if up {
+				active++
+			} else {
+				out = append(out, i18n.T("the agent on %s is down but still reported active", a.Host))
+			}
+		default:
+			ha = true
+			if !up {
+				out = append(out, i18n.T("the standby on %s is down: no failover there", a.Host))
+			}
+		}
+	}
+	switch {
+	case ha && active == 0:
+		out = append(out, i18n.T("no alive agent is active: the HA router does not forward"))
+	case active > 1:
+		out = append(out, i18n.T("%d agents report active: traffic may take asymmetric paths", active))
+	}
+	return out
+}
+
+// RouterL3AgentsModel lists the L3 agents with the HA state of the router
+// on the ones hosting it. s schedules or unschedules the router on the
+// selected agent; m moves it from the selected agent to another one.
+type RouterL3AgentsModel struct {
+	table    table.Model
+	loading  bool
+	err      error
+	spinner  spinner.Model
+	client   client.NetworkClient
+	routerID string
+	name     string
+
+	agents  []client.NetworkAgent
+	hosting map[string]client.L3Agent // agent ID -> agent hosting the router
+
+	// moveFrom is the hosting agent being moved away from while the target
+	// is picked.
+	moveFrom          string
+	pendingUnschedule string
+	status            string
+	statusErr         bool
+
+	width  int
+	height int
+}
+
+// NewRouterL3AgentsModel creates the L3 agent view of a router.
+func NewRouterL3AgentsModel(nc client.NetworkClient, routerID, name string) RouterL3AgentsModel {
+	s := spinner.New()
+	s.Spinner = spinner.Dot
+	return RouterL3AgentsModel{client: nc, loading: true, spinner: s, routerID: routerID, name: name, width: 120, height: 30}
+}
+
+// ResourceID returns the router ID.
+func (m RouterL3AgentsModel) ResourceID() string { return m.routerID }
+
+type routerL3AgentsLoadedMsg struct {
+	agents  []client.NetworkAgent
+	hosting map[string]client.L3Agent
+	err     error
+}
+
+// Init loads the agents.
+func (m RouterL3AgentsModel) Init() tea.Cmd {
+	return tea.Batch(m.spinner.Tick, m.loadCmd())
+}
+
+func (m RouterL3AgentsModel) loadCmd() tea.Cmd {
+	nc, routerID := m.client, m.routerID
+	return func() tea.Msg {
+		ctx := context.Background()
+		hosts, err := nc.ListRouterL3Agents(ctx, routerID)
+		if err != nil {
+			return routerL3AgentsLoadedMsg{err: l3Error(err)}
+		}
+		msg := routerL3AgentsLoadedMsg{hosting: map[string]client.L3Agent{}}
+		for _, a := range hosts {
+			msg.hosting[a.ID] = a
+		}
+		// The hosting agents come first, then every other L3 agent as a
+		// target for scheduling.
+		all, err := nc.ListNetworkAgents(ctx, client.AgentTypeL3)
+		for _, a := range hosts {
+			msg.agents = append(msg.agents, client.NetworkAgent{ID: a.ID, AgentType: a.AgentType, Host: a.Host, AvailabilityZone: a.AvailabilityZone,
+				Alive: a.Alive, AdminStateUp: a.AdminStateUp, HeartbeatTimestamp: a.HeartbeatTimestamp})
+		}
+		if err == nil {
+			for _, a := range all {
+				if _, ok := msg.hosting[a.ID]; !ok {
+					msg.agents = append(msg.agents, a)
+				}
+			}
+		}
+		return msg
+	}
+}
+
+// Update handles messages for the model.
+func (m RouterL3AgentsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case routerL3AgentsLoadedMsg:
+		m.loading = false
+		m.err = msg.err
+		if msg.err != nil {
+			return m, nil
+		}
+		m.agents, m.hosting = msg.agents, msg.hosting
+		m.refreshTable()
+		return m, nil
+	case changeDoneMsg:
+		m.status, m.statusErr = msg.status, msg.err != nil
+		if msg.err != nil {
+			policy.Record(msg.action, msg.err)
+			m.status = msg.err.Error()
+		}
+		// A failed move may have half happened; reload either way.
+		return m, m.loadCmd()
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		if !m.loading && m.err == nil {
+			m.refreshTable()
+		}
+		return m, nil
+	case tea.KeyMsg:
+		if m.pendingUnschedule != "" {
+			agentID := m.pendingUnschedule
+			m.pendingUnschedule = ""
+			if msg.String() != "y" {
+				return m, nil
+			}
+			return m, m.unscheduleCmd(agentID)
+		}
+		if m.loading || m.err != nil {
+			return m, nil
+		}
+		row := m.table.SelectedRow()
+		if m.moveFrom != "" {
+			switch msg.String() {
+			case "esc":
+				m.moveFrom = ""
+				return m, nil
+			case "enter", "m":
+				if len(row) == 0 {
+					return m, nil
+				}
+				if _, ok := m.hosting[row[0]]; ok {
+					m.status, m.statusErr = i18n.T("Pick an agent that does not host the router"), true
+					return m, nil
+				}
+				from := m.moveFrom
+				m.moveFrom = ""
+				return m, m.moveCmd(from, row[0])
+			}
+			var cmd tea.Cmd
+			m.table, cmd = m.table.Update(msg)
+			return m, cmd
+		}
+		switch msg.String() {
+		case "s":
+			if len(row) == 0 {
+				return m, nil
+			}
+			if err := policy.Check(policy.Admin, "scheduling a router"); err != nil {
+				m.status, m.statusErr = err.Error(), true
+				return m, nil
+			}
+			if _, ok := m.hosting[row[0]]; ok {
+				// Unscheduling can stop the forwarding; confirm first.
+				m.pendingUnschedule = row[0]
+				return m, nil
+			}
+			return m, m.scheduleCmd(row[0])
+		case "m":
+			if len(row) == 0 {
+				return m, nil
+			}
+			if _, ok := m.hosting[row[0]]; !ok {
+				m.status, m.statusErr = i18n.T("Select an agent hosting the router to move it away"), true
+				return m, nil
+			}
+			if err := policy.Check(policy.Admin, "rescheduling a router"); err != nil {
+				m.status, m.statusErr = err.Error(), true
+				return m, nil
+			}
+			m.moveFrom, m.status = row[0], ""
+			return m, nil
+		case "r":
+			m.loading = true
+			return m, tea.Batch(m.spinner.Tick, m.loadCmd())
+		}
+		var cmd tea.Cmd
+		m.table, cmd = m.table.Update(msg)
+		return m, cmd
+	default:
+		if m.loading {
+			var cmd tea.Cmd
+			m.spinner, cmd = m.spinner.Update(msg)
+			return m, cmd
+		}
+	}
+	return m, nil
+}
+
+// ha reports whether the router runs as an HA router.
+func (m RouterL3AgentsModel) ha() bool {
+	for _, a := range m.hosting {
+		if a.HAState != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func (m RouterL3AgentsModel) host(agentID string) string {
+	for _, a := range m.agents {
+		if a.ID == agentID {
+			return a.Host
+		}
+	}
+	return agentID
+}
+
+func (m RouterL3AgentsModel) scheduleCmd(agentID string) tea.Cmd {
+	nc, routerID, host := m.client, m.routerID, m.host(agentID)
+	return func() tea.Msg {
+		err := nc.ScheduleRouter(context.Background(), agentID, routerID)
+		return changeDoneMsg{action: "scheduling a router", status: i18n.T("Scheduled the router on %s", host), err: l3Error(err)}
+	}
+}
+
+func (m RouterL3AgentsModel) unscheduleCmd(agentID string) tea.Cmd {
+	nc, routerID, host := m.client, m.routerID, m.host(agentID)
+	return func() tea.Msg {
+		err := nc.UnscheduleRouter(context.Background(), agentID, routerID)
+		return changeDoneMsg{action: "scheduling a router", status: i18n.T("Removed the router from %s", host), err: l3Error(err)}
+	}
+}
+
+// moveCmd reschedules the router from one agent to another. An HA router
+// gains the new instance before losing the old one. A legacy router runs on
+// a single agent, so it is removed first and put back if the target refuses
+// it.
+func (m RouterL3AgentsModel) moveCmd(from, to string) tea.Cmd {
+	nc, routerID, ha := m.client, m.routerID, m.ha()
+	status := i18n.T("Moved the router from %s to %s", m.host(from), m.host(to))
+	return func() tea.Msg {
+		ctx := context.Background()
+		if ha {
+			if err := nc.ScheduleRouter(ctx, to, routerID); err != nil {
+				return changeDoneMsg{action: "rescheduling a router", err: l3Error(err)}
+			}
+			return changeDoneMsg{action: "rescheduling a router", status: status, err: l3Error(nc.UnscheduleRouter(ctx, from, routerID))}
+		}
+		if err := nc.UnscheduleRouter(ctx, from, routerID); err != nil {
+			return changeDoneMsg{action: "rescheduling a router", err: l3Error(err)}
+		}
+		if err := nc.ScheduleRouter(ctx, to, routerID); err != nil {
+			if back := nc.ScheduleRouter(ctx, from, routerID); back != nil {
+				return changeDoneMsg{action: "rescheduling a router", err: fmt.Errorf("%w; putting the router back also failed: %v", l3Error(err), back)}
+			}
+			return changeDoneMsg{action: "rescheduling a router", err: l3Error(err)}
+		}
+		return changeDoneMsg{status: status}
+	}
+}
+
+// refreshTable rebuilds the agent table.
+func (m *RouterL3AgentsModel) refreshTable() {
+	rest := m.width - uiconst.ColWidthUUID - uiconst.TableHeightOffset
+	if rest < 60 {
+		rest = 60
+	}
+	w := rest / 5
+	cols := []table.Column{{Title: "ID", Width: uiconst.ColWidthUUID}, {Title: i18n.T("Host"), Width: w}, {Title: i18n.T("Zone"), Width: w}, {Title: i18n.T("Alive"), Width: w},
+		{Title: i18n.T("Admin state"), Width: w}, {Title: i18n.T("Router"), Width: rest - 4*w}}
+	var rows []table.Row
+	for _, a := range m.agents {
+		admin := "UP"
+		if !a.AdminStateUp {
+			admin = "DOWN"
+		}
+		hosts := ""
+		if h, ok := m.hosting[a.ID]; ok {
+			hosts = "hosted"
+			if h.HAState != "" {
+				hosts = h.HAState
+			}
+		}
+		rows = append(rows, table.Row{a.ID, a.Host, a.AvailabilityZone, agentAlive(a), admin, hosts})
+	}
+	cursor := m.table.Cursor()
+	m.table = table.New(table.WithColumns(cols), table.WithFocused(true))
+	m.table.SetStyles(table.DefaultStyles())
+	m.table.SetRows(rows)
+	m.table.SetHeight(m.height - uiconst.TableHeightOffset - 6)
+	if cursor < len(rows) {
+		m.table.SetCursor(cursor)
+	}
+}
+
+// View renders the router summary, the placement warnings and the agents.
+func (m RouterL3AgentsModel) View() string {
+	title := lipgloss.NewStyle().Bold(true).Foreground(theme.Accent).Render
+	dim := lipgloss.NewStyle().Foreground(theme.Muted)
+	head := title("L3 agents of router " + nameOrID(m.name, m.routerID))
+	if m.loading {
+		return head + "\n" + m.spinner.View()
+	}
+	if m.err != nil {
+		return head + "\n" + i18n.T("Error: %s", m.err)
+	}
+	kind := "legacy"
+	if m.ha() {
+		kind = "HA"
+	}
+	head += dim.Render(i18n.T("  %s, on %d agents", kind, len(m.hosting))) + "\n"
+	hosts := make([]client.L3Agent, 0, len(m.hosting))
+	for _, a := range m.agents {
+		if h, ok := m.hosting[a.ID]; ok {
+			hosts = append(hosts, h)
+		}
+	}
+	warn := lipgloss.NewStyle().Foreground(theme.Error)
+	for _, w := range l3Warnings(hosts) {
+		head += warn.Render("⚠ "+w) + "\n"
+	}
+	out := head + m.table.View()
+	switch {
+	case m.pendingUnschedule != "":
+		out += "\n" + i18n.T("Remove the router from the agent on %s? [y/N]", m.host(m.pendingUnschedule))
+	case m.moveFrom != "":
+		out += "\n" + i18n.T("Moving the router away from %s: select the target agent  [enter] move  [esc] cancel", m.host(m.moveFrom))
+	default:
+		out += changeStatusLine(m.status, m.statusErr, "", "")
+	}
+	keys := []string{policy.Key(policy.Admin, i18n.T("[s] schedule / unschedule"), "scheduling a router"), policy.Key(policy.Admin, i18n.T("[m] move to another agent"), "rescheduling a router"), i18n.T("[r] refresh"), i18n.T("[esc] back")}
+	return out + "\n" + strings.Join(keys, "  ")
+}
+
+// CapturingInput reports whether the unschedule prompt or the move target
+// picker is open.
+func (m RouterL3AgentsModel) CapturingInput() bool {
+	return m.pendingUnschedule != "" || m.moveFrom != ""
+}
+
+// ConfirmingDestructive reports whether removing the router from an agent
+// waits for y.
+func (m RouterL3AgentsModel) ConfirmingDestructive() bool { return m.pendingUnschedule != "" }
+
+// Table returns the agent table.
+func (m RouterL3AgentsModel) Table() table.Model { return m.table }
+
+var _ tea.Model = (*RouterL3AgentsModel)(nil)
@@ -9,8 +9,13 @@ import (
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"ostui/internal/client"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/common"
+	"ostui/internal/ui/policy"
+	"ostui/internal/ui/search"
+	"ostui/internal/ui/theme"
 	"ostui/internal/ui/uiconst"
-	"strings"
+	"time"
 )
 
 // RouterModel implements a view that lists routers and, on selection, shows the
@@ -35,11 +40,26 @@ type RouterModel struct {
 	routerStatus string
 
 	// State management
-	mode       string // "list" or "detail"
-	routerID   string // selected router ID when in detail mode
-	allRows    []table.Row
+	mode     string // "list" or "detail"
+	routerID string // selected router ID when in detail mode
+	allRows  []table.Row
+	// created maps router IDs to their creation time, for the age terms
+	// of the filter.
+	created    map[string]time.Time
 	filterMode bool
 	filter     textinput.Model
+
+	// A new router (n) picks its gateway first, then fills in the form.
+	picker  *common.PickerModel
+	form    *common.FormModel
+	gateway string
+	// zones checks the AZ hints of the new router form.
+	zones []client.NetworkAZ
+	// preflight is the dependency preview of a delete (d), shown until it
+	// is confirmed or dismissed.
+	preflight *routerPreflight
+	status    string
+	statusErr bool
 }
 
 // NewRoutersModel creates a RouterModel ready to load router data.
@@ -53,9 +73,10 @@ func NewRoutersModel(nc client.NetworkClient) RouterModel {
 
 // routersListMsg is emitted when the list of routers has been fetched.
 type routersListMsg struct {
-	tbl  table.Model
-	rows []table.Row
-	err  error
+	tbl     table.Model
+	rows    []table.Row
+	created map[string]time.Time
+	err     error
 }
 
 // routerIfacesMsg is emitted when router interfaces have been fetched.
@@ -67,25 +88,32 @@ type routerIfacesMsg struct {
 // Init starts the asynchronous loading of routers.
 func (m RouterModel) Init() tea.Cmd {
 	return func() tea.Msg {
-		routers, err := m.client.ListRouters(context.Background())
+		details, err := m.client.ListRouterDetails(context.Background())
 		if err != nil {
 			return routersListMsg{err: err}
 		}
-		cols := []table.Column{{Title: "ID", Width: uiconst.ColWidthUUID}, {Title: "Name", Width: uiconst.ColWidthName}, {Title: "Status", Width: uiconst.ColWidthStatus}}
+		routers := make([]client.Router, len(details))
+		for i, r := range details {
+			routers[i] = r.Router
+		}
+		search.IndexRouters(routers)
+		cols := routerColumns(uiconst.ColWidthName)
 		rows := []table.Row{}
-		for _, r := range routers {
-			// The Router type is an alias for gophercloud's routers.Router which has a Status field.
-			// Use fmt.Sprintf to safely handle any zero values.
-			rows = append(rows, table.Row{r.ID, r.Name, fmt.Sprintf("%v", r.Status)})
+		created := make(map[string]time.Time, len(details))
+		now := time.Now()
+		for _, r := range details {
+			rows = append(rows, table.Row{r.ID, r.Name, theme.Mark(r.Status), common.Age(r.CreatedAt, now)})
+			created[r.ID] = r.CreatedAt
 		}
+		rows = common.TerraformRows(rows)
 		t := table.New(
-			table.WithColumns(cols),
+			table.WithColumns(common.TerraformColumns(cols)),
 			table.WithRows(rows),
 			table.WithFocused(true),
 			table.WithHeight(m.height-uiconst.TableHeightOffset),
 		)
 		t.SetStyles(table.DefaultStyles())
-		return routersListMsg{tbl: t, rows: rows}
+		return routersListMsg{tbl: t, rows: rows, created: created}
 	}
 }
 
@@ -97,7 +125,7 @@ func (m RouterModel) loadInterfacesCmd(routerID string) tea.Cmd {
 			return routerIfacesMsg{err: err}
 		}
 		// Build a simple table: Interface ID and Subnet ID.
-		cols := []table.Column{{Title: "Interface ID", Width: uiconst.ColWidthUUID}, {Title: "Subnet ID", Width: uiconst.ColWidthUUID}}
+		cols := []table.Column{{Title: i18n.T("Interface ID"), Width: uiconst.ColWidthUUID}, {Title: i18n.T("Subnet ID"), Width: uiconst.ColWidthUUID}}
 		rows := []table.Row{}
 		for _, i := range ifaces {
 			rows = append(rows, table.Row{i.ID, i.NetworkID})
@@ -113,19 +141,68 @@ func (m RouterModel) loadInterfacesCmd(routerID string) tea.Cmd {
 	}
 }
 
+// Reload re-fetches the routers, or the interfaces of the router shown,
+// keeping the selected row.
+func (m RouterModel) Reload() tea.Cmd {
+	switch {
+	case m.loading || m.filterMode:
+		return nil
+	case m.mode == "detail":
+		return m.loadInterfacesCmd(m.routerID)
+	}
+	return m.Init()
+}
+
 // Update processes incoming messages and user input.
 func (m RouterModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	// The gateway picker takes every message while it is open.
+	if m.picker != nil {
+		if _, ok := msg.(routersListMsg); !ok {
+			newModel, cmd := m.picker.Update(msg)
+			picker := newModel.(common.PickerModel)
+			m.picker = &picker
+			if picker.Done() {
+				m.picker = nil
+				if it, ok := picker.Selected(); ok {
+					m.gateway = it.ID
+					if m.gateway == noGateway {
+						m.gateway = ""
+					}
+					f := newRouterForm(m.gateway)
+					m.form = &f
+					return m, f.Init()
+				}
+			}
+			return m, cmd
+		}
+	}
 	switch msg := msg.(type) {
+	case routerZonesMsg:
+		m.zones = msg.zones
+		return m, nil
+	case routerPreflightMsg:
+		m.preflight = &msg.preflight
+		m.status = ""
+		return m, nil
+	case changeDoneMsg:
+		m.status, m.statusErr = msg.status, msg.err != nil
+		if msg.err != nil {
+			policy.Record(msg.action, msg.err)
+			m.status = msg.err.Error()
+			return m, nil
+		}
+		m.loading = true
+		return m, tea.Batch(m.spinner.Tick, m.Init())
 	case routersListMsg:
 		m.loading = false
 		if msg.err != nil {
 			m.err = msg.err
 			return m, nil
 		}
-		m.table = msg.tbl
+		m.table = common.Reloaded(m.table, msg.tbl)
 		m.updateTableColumns()
 		m.table.SetHeight(m.height - uiconst.TableHeightOffset)
-		m.allRows = msg.rows
+		m.allRows, m.created = msg.rows, msg.created
 		return m, nil
 	case routerIfacesMsg:
 		// Switch to detail mode after interfaces are loaded.
@@ -134,7 +211,7 @@ func (m RouterModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.err = msg.err
 			return m, nil
 		}
-		m.ifaceTable = msg.tbl
+		m.ifaceTable = common.Reloaded(m.ifaceTable, msg.tbl)
 		m.mode = "detail"
 		return m, nil
 	case tea.WindowSizeMsg:
@@ -146,6 +223,18 @@ func (m RouterModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 	case tea.KeyMsg:
+		if m.form != nil {
+			return m.updateForm(msg)
+		}
+		if m.preflight != nil {
+			p := *m.preflight
+			m.preflight = nil
+			if msg.String() == "y" && !p.blocked() {
+				m.status, m.statusErr = i18n.T("Deleting router %s…", p.name), false
+				return m, deleteRouterCmd(m.client, p)
+			}
+			return m, nil
+		}
 		// Global escape handling: return to list view.
 		if msg.String() == "esc" && m.mode == "detail" {
 			// Reset to list view.
@@ -179,20 +268,53 @@ func (m RouterModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				if filterVal == "" {
 					m.table.SetRows(m.allRows)
 				} else {
-					lower := strings.ToLower(filterVal)
+					q := common.ParseAgeQuery(filterVal)
+					now := time.Now()
 					filtered := []table.Row{}
 					for _, r := range m.allRows {
-						for _, c := range r {
-							if strings.Contains(strings.ToLower(c), lower) {
-								filtered = append(filtered, r)
-								break
-							}
+						if common.MatchRow(r, m.created[r[0]], q, now) {
+							filtered = append(filtered, r)
 						}
 					}
 					m.table.SetRows(filtered)
 				}
 				return m, cmd
 			}
+			// Open the L3 agents hosting the selected router.
+			if msg.String() == "L" {
+				if row := m.table.SelectedRow(); len(row) > 1 {
+					open := OpenRouterL3AgentsMsg{RouterID: row[0], Name: row[1]}
+					return m, func() tea.Msg { return open }
+				}
+				return m, nil
+			}
+			switch msg.String() {
+			case "n":
+				if err := policy.Check(policy.Member, "creating a router"); err != nil {
+					m.status, m.statusErr = err.Error(), true
+					return m, nil
+				}
+				picker := newGatewayPicker(m.client)
+				picker.SetPageSize(m.height - 10)
+				m.picker = &picker
+				nc := m.client
+				return m, tea.Batch(picker.Init(), func() tea.Msg {
+					// Listing the zones may be refused; hints are not
+					// checked then.
+					zones, _ := nc.ListNetworkAZs(context.Background())
+					return routerZonesMsg{zones: zones}
+				})
+			case "d":
+				if err := policy.Check(policy.Member, "deleting a router"); err != nil {
+					m.status, m.statusErr = err.Error(), true
+					return m, nil
+				}
+				if row := m.table.SelectedRow(); len(row) > 1 {
+					m.status, m.statusErr = i18n.T("Checking what uses router %s…", row[1]), false
+					return m, loadRouterPreflightCmd(m.client, row[0], row[1])
+				}
+				return m, nil
+			}
 			// Normal navigation / selection.
 			if msg.String() == "enter" {
 				// User selected a router – load its interfaces.
@@ -226,37 +348,113 @@ func (m RouterModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 // View renders the appropriate UI based on the current mode.
 func (m RouterModel) View() string {
+	if m.picker != nil {
+		return m.picker.View()
+	}
+	if m.form != nil {
+		gw := "no gateway"
+		if m.gateway != "" {
+			gw = "gateway " + m.gateway
+		}
+		known := []string{}
+		for _, az := range m.zones {
+			if az.Resource == "router" {
+				known = append(known, az.Name)
+			}
+		}
+		return i18n.T("New router – %s; AZ hints pin it to L3 agents in those zones (known: %s)", gw, azList(known, "unknown")) + "\n\n" + m.form.View() +
+			"\n" + i18n.T("[tab] next field  [enter] next/create  [esc] cancel")
+	}
 	if m.loading {
 		return m.spinner.View()
 	}
 	if m.err != nil {
-		return fmt.Sprintf("Error: %s", m.err)
+		return i18n.T("Error: %s", m.err)
 	}
 	if m.mode == "list" {
 		if m.filterMode {
-			filterLine := fmt.Sprintf("Filter: %s", m.filter.View())
+			filterLine := i18n.T("Filter: %s", m.filter.View())
 			footer := "esc: clear"
 			return fmt.Sprintf("%s\n%s\n%s", filterLine, m.table.View(), footer)
 		}
-		return m.table.View()
+		if m.preflight != nil {
+			return m.table.View() + "\n\n" + m.preflight.render()
+		}
+		return m.table.View() + changeStatusLine(m.status, m.statusErr, "", "") + "\n" + i18n.T("[enter] details  [L] L3 agents  [/] filter") + "  " + policy.Key(policy.Member, i18n.T("[n] new router"), "creating a router") + "  " + policy.Key(policy.Member, i18n.T("[d] delete"), "deleting a router")
 	}
 	// Detail view – show router interfaces.
-	header := fmt.Sprintf("Router %s interfaces (press esc to go back)", m.routerID)
+	header := i18n.T("Router %s interfaces (press esc to go back)", m.routerID)
 	return fmt.Sprintf("%s\n%s", header, m.ifaceTable.View())
 }
 
+// updateForm handles keys for the new router form.
+func (m RouterModel) updateForm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	fm, cmd := m.form.Update(msg)
+	f := fm.(common.FormModel)
+	m.form = &f
+	switch {
+	case f.Cancelled():
+		m.form = nil
+	case f.Submitted():
+		opts, err := routerOptsFromForm(f.Values(), m.gateway, m.zones)
+		if err != nil {
+			m.form.SetError(err)
+			return m, nil
+		}
+		m.form = nil
+		return m, createRouterCmd(m.client, opts)
+	}
+	return m, cmd
+}
+
+// CapturingInput reports whether the new router picker or form, or the
+// delete preview, is open.
+func (m RouterModel) CapturingInput() bool {
+	return m.picker != nil || m.form != nil || m.preflight != nil
+}
+
+// ConfirmingDestructive reports whether a delete preview that Neutron would
+// accept is shown.
+func (m RouterModel) ConfirmingDestructive() bool {
+	return m.preflight != nil && !m.preflight.blocked()
+}
+
 // Table returns the primary table (list view) – useful for navigation.
 func (m RouterModel) Table() table.Model { return m.table }
 
+// Filtering reports whether keys are typed into the filter.
+func (m RouterModel) Filtering() bool { return m.filterMode }
+
+// Actions lists what can be done to the selected router for the action
+// palette.
+func (m RouterModel) Actions() []common.Action {
+	if m.loading || m.err != nil || m.filterMode || len(m.table.SelectedRow()) == 0 {
+		return nil
+	}
+	return []common.Action{
+		{Key: "enter", Name: "open", Help: "Open the router detail"},
+		{Key: "L", Name: "l3 agents", Help: "L3 agents hosting the router, with HA state (admin)"},
+		{Key: "n", Name: "new router", Help: "Create a router: gateway network, SNAT, AZ hints"},
+		{Key: "d", Name: "delete", Help: "Delete the router after a dependency preview"},
+		{Key: "/", Name: "filter", Help: "Filter the routers"},
+	}
+}
+
 // updateTableColumns adjusts column widths based on the current width.
 func (m *RouterModel) updateTableColumns() {
 	idW := uiconst.ColWidthUUID
 	statusW := uiconst.ColWidthStatus
-	nameW := m.width - idW - statusW - uiconst.TableHeightOffset
+	nameW := m.width - idW - statusW - uiconst.ColWidthAge - uiconst.TableHeightOffset - common.TerraformWidth()
 	if nameW < 10 {
 		nameW = 10
 	}
-	m.table.SetColumns([]table.Column{{Title: "ID", Width: idW}, {Title: "Name", Width: nameW}, {Title: "Status", Width: statusW}})
+	m.table.SetColumns(common.TerraformColumns(routerColumns(nameW)))
+}
+
+// routerColumns are the columns of the router list, the name nameW wide.
+// Neutron does not say who created a router, only its project.
+func routerColumns(nameW int) []table.Column {
+	return []table.Column{{Title: "ID", Width: uiconst.ColWidthUUID}, {Title: i18n.T("Name"), Width: nameW}, {Title: i18n.T("Status"), Width: uiconst.ColWidthStatus}, {Title: common.AgeTitle(), Width: uiconst.ColWidthAge}}
 }
 
 var _ tea.Model = (*RouterModel)(nil)
@@ -6,10 +6,14 @@ import (
 	"fmt"
 	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"ostui/internal/client"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/common"
 	"ostui/internal/ui/uiconst"
+	"strings"
 )
 
 type securityGroupJSON struct {
@@ -29,6 +33,8 @@ type SecurityGroupDetailModel struct {
 	err        error
 	spinner    spinner.Model
 	client     client.NetworkClient
+	compute    client.ComputeClient
+	lb         client.LoadBalancerClient
 	sgID       string
 	// JSON view fields
 	jsonView     string
@@ -40,8 +46,28 @@ type SecurityGroupDetailModel struct {
 	sgJSON securityGroupJSON
 	width  int
 	height int
+	// Rule import/export
+	ioMode    string // "import", "export" or ""
+	pathInput textinput.Model
+	ioStatus  string
+	// "Used by" tab
+	showUsage    bool
+	usageLoading bool
+	usageLoaded  bool
+	usageErr     error
+	usageCount   int
+	usageTable   table.Model
 }
 
+// rulesIODoneMsg is emitted when a rule import or export has finished.
+type rulesIODoneMsg struct {
+	status string
+	reload bool
+}
+
+// CapturingInput reports whether the file path prompt is open.
+func (m SecurityGroupDetailModel) CapturingInput() bool { return m.ioMode != "" }
+
 type securityGroupDetailDataLoadedMsg struct {
 	groupTbl table.Model
 	rulesTbl table.Model
@@ -49,11 +75,15 @@ type securityGroupDetailDataLoadedMsg struct {
 	sgJSON   securityGroupJSON
 }
 
+// ResourceID returns the security group ID.
+func (m SecurityGroupDetailModel) ResourceID() string { return m.sgID }
+
 // NewSecurityGroupDetailModel creates a new SecurityGroupDetailModel for the given security group ID.
-func NewSecurityGroupDetailModel(nc client.NetworkClient, sgID string) SecurityGroupDetailModel {
+// The compute and load balancer clients are used to resolve the "used by" tab and may be nil.
+func NewSecurityGroupDetailModel(nc client.NetworkClient, cc client.ComputeClient, lbc client.LoadBalancerClient, sgID string) SecurityGroupDetailModel {
 	s := spinner.New()
 	s.Spinner = spinner.Dot
-	return SecurityGroupDetailModel{client: nc, loading: true, spinner: s, sgID: sgID, width: 120, height: 30}
+	return SecurityGroupDetailModel{client: nc, compute: cc, lb: lbc, loading: true, spinner: s, sgID: sgID, width: 120, height: 30}
 }
 
 // Init starts async loading of security group details.
@@ -85,7 +115,7 @@ func (m SecurityGroupDetailModel) Init() tea.Cmd {
 			return securityGroupDetailDataLoadedMsg{err: fmt.Errorf("security group %s not found", m.sgID)}
 		}
 		// Build group details table.
-		groupCols := []table.Column{{Title: "Field", Width: uiconst.ColWidthField}, {Title: "Value", Width: uiconst.ColWidthValue}}
+		groupCols := []table.Column{{Title: i18n.T("Field"), Width: uiconst.ColWidthField}, {Title: i18n.T("Value"), Width: uiconst.ColWidthValue}}
 		groupRows := []table.Row{{"ID", sg.ID}, {"Name", sg.Name}, {"Description", sg.Description}, {"Stateful", fmt.Sprintf("%v", sg.Stateful)}}
 		groupTbl := table.New(
 			table.WithColumns(groupCols),
@@ -98,11 +128,11 @@ func (m SecurityGroupDetailModel) Init() tea.Cmd {
 		var rulesTbl table.Model
 		if rErr != nil {
 			// If rule loading fails, create an empty table with error row.
-			cols := []table.Column{{Title: "Error", Width: uiconst.ColWidthError}}
+			cols := []table.Column{{Title: i18n.T("Error"), Width: uiconst.ColWidthError}}
 			rows := []table.Row{{"Failed to load rules: " + rErr.Error()}}
 			rulesTbl = table.New(table.WithColumns(cols), table.WithRows(rows))
 		} else {
-			ruleCols := []table.Column{{Title: "ID", Width: uiconst.ColWidthUUID}, {Title: "Direction", Width: uiconst.ColWidthDirection}, {Title: "EtherType", Width: uiconst.ColWidthEtherType}, {Title: "Protocol", Width: uiconst.ColWidthProtocol}, {Title: "PortRange", Width: uiconst.ColWidthPortRange}, {Title: "RemoteIP", Width: uiconst.ColWidthRemoteIP}, {Title: "RemoteGroup", Width: uiconst.ColWidthUUID}}
+			ruleCols := []table.Column{{Title: "ID", Width: uiconst.ColWidthUUID}, {Title: i18n.T("Direction"), Width: uiconst.ColWidthDirection}, {Title: i18n.T("EtherType"), Width: uiconst.ColWidthEtherType}, {Title: i18n.T("Protocol"), Width: uiconst.ColWidthProtocol}, {Title: i18n.T("PortRange"), Width: uiconst.ColWidthPortRange}, {Title: i18n.T("RemoteIP"), Width: uiconst.ColWidthRemoteIP}, {Title: i18n.T("RemoteGroup"), Width: uiconst.ColWidthUUID}}
 			ruleRows := []table.Row{}
 			for _, r := range rulesList {
 				portRange := ""
@@ -129,6 +159,15 @@ func (m SecurityGroupDetailModel) Init() tea.Cmd {
 	}
 }
 
+// Reload re-fetches the group and its rules, keeping the selected rule.
+// The usage view refreshes itself with r.
+func (m SecurityGroupDetailModel) Reload() tea.Cmd {
+	if m.loading || m.showUsage {
+		return nil
+	}
+	return m.Init()
+}
+
 // Update handles messages.
 func (m SecurityGroupDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
@@ -138,13 +177,27 @@ func (m SecurityGroupDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.err = msg.err
 			return m, nil
 		}
-		m.table = msg.groupTbl
-		m.rulesTable = msg.rulesTbl
+		m.table = common.Reloaded(m.table, msg.groupTbl)
+		m.rulesTable = common.Reloaded(m.rulesTable, msg.rulesTbl)
 		m.sgJSON = msg.sgJSON
 		m.updateTableColumns()
 		m.table.SetHeight(m.height - uiconst.TableHeightOffset)
 		m.rulesTable.SetHeight(m.height - uiconst.TableHeightOffset)
 		return m, nil
+	case sgUsageLoadedMsg:
+		m.usageLoading = false
+		m.usageLoaded = msg.err == nil
+		m.usageErr = msg.err
+		m.usageCount = len(msg.usage)
+		m.usageTable = newSGUsageTable(msg.usage, m.height-uiconst.TableHeightOffset)
+		return m, nil
+	case rulesIODoneMsg:
+		m.ioStatus = msg.status
+		if msg.reload {
+			m.loading = true
+			return m, m.Init()
+		}
+		return m, nil
 	case tea.WindowSizeMsg:
 		if m.jsonView != "" {
 			m.jsonViewport.Width = msg.Width
@@ -161,6 +214,26 @@ func (m SecurityGroupDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 	case tea.KeyMsg:
+		// File path prompt for rule import/export.
+		if m.ioMode != "" {
+			switch msg.String() {
+			case "esc":
+				m.ioMode = ""
+				m.pathInput.Blur()
+				return m, nil
+			case "enter":
+				mode, path := m.ioMode, strings.TrimSpace(m.pathInput.Value())
+				m.ioMode = ""
+				m.pathInput.Blur()
+				if path == "" {
+					return m, nil
+				}
+				return m, m.rulesIOCmd(mode, path)
+			}
+			var cmd tea.Cmd
+			m.pathInput, cmd = m.pathInput.Update(msg)
+			return m, cmd
+		}
 		// If Inspect view is active, handle its keys.
 		if m.inspectView != "" {
 			if msg.String() == "i" || msg.String() == "esc" {
@@ -186,6 +259,38 @@ func (m SecurityGroupDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.loading || m.err != nil {
 			return m, nil
 		}
+		if msg.String() == "u" {
+			m.showUsage = !m.showUsage
+			if m.showUsage && !m.usageLoaded && !m.usageLoading {
+				m.usageLoading = true
+				return m, tea.Batch(m.spinner.Tick, loadSGUsageCmd(m.client, m.compute, m.lb, m.sgID))
+			}
+			return m, nil
+		}
+		if m.showUsage {
+			if msg.String() == "r" && !m.usageLoading {
+				m.usageLoading = true
+				return m, tea.Batch(m.spinner.Tick, loadSGUsageCmd(m.client, m.compute, m.lb, m.sgID))
+			}
+			var cmd tea.Cmd
+			m.usageTable, cmd = m.usageTable.Update(msg)
+			return m, cmd
+		}
+		if msg.String() == "I" || msg.String() == "E" {
+			ti := textinput.New()
+			ti.Placeholder = "rules.yaml or rules.json"
+			ti.Width = 60
+			if msg.String() == "E" {
+				m.ioMode = "export"
+				ti.SetValue(fmt.Sprintf("%s-rules.yaml", m.sgJSON.Group.Name))
+			} else {
+				m.ioMode = "import"
+			}
+			ti.Focus()
+			m.pathInput = ti
+			m.ioStatus = ""
+			return m, textinput.Blink
+		}
 		// Handle new/delete actions (currently no-op).
 		if msg.String() == "n" || msg.String() == "d" {
 			// Placeholder for future implementation.
@@ -193,7 +298,7 @@ func (m SecurityGroupDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		if msg.String() == "i" {
 			// Build inspect view for security group.
-			content := fmt.Sprintf("=== Security Group: %s ===\nID: %s\nName: %s\nDescription: %s\nStateful: %v\nRules: %d", m.sgJSON.Group.Name, m.sgJSON.Group.ID, m.sgJSON.Group.Name, m.sgJSON.Group.Description, m.sgJSON.Group.Stateful, len(m.sgJSON.Rules))
+			content := i18n.T("=== Security Group: %s ===\nID: %s\nName: %s\nDescription: %s\nStateful: %v\nRules: %d", m.sgJSON.Group.Name, m.sgJSON.Group.ID, m.sgJSON.Group.Name, m.sgJSON.Group.Description, m.sgJSON.Group.Stateful, len(m.sgJSON.Rules))
 			m.inspectView = content
 			m.inspectViewport = viewport.New(80, 24)
 			m.inspectViewport.SetContent(m.inspectView)
@@ -215,7 +320,7 @@ func (m SecurityGroupDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.rulesTable, cmd = m.rulesTable.Update(msg)
 		return m, cmd
 	default:
-		if m.loading {
+		if m.loading || m.usageLoading {
 			var cmd tea.Cmd
 			m.spinner, cmd = m.spinner.Update(msg)
 			return m, cmd
@@ -230,21 +335,66 @@ func (m SecurityGroupDetailModel) View() string {
 		return m.spinner.View()
 	}
 	if m.inspectView != "" {
-		return fmt.Sprintf("%s\n %3.f%% | [j/k] scroll  [esc] close", m.inspectViewport.View(), m.inspectViewport.ScrollPercent()*100)
+		return i18n.T("%s\n %3.f%% | [j/k] scroll  [esc] close", m.inspectViewport.View(), m.inspectViewport.ScrollPercent()*100)
 	}
 	if m.jsonView != "" {
-		return fmt.Sprintf("%s\nPress 'y' or 'esc' to close", m.jsonViewport.View())
+		return i18n.T("%s\nPress 'y' or 'esc' to close", m.jsonViewport.View())
 	}
 	if m.err != nil {
-		cols := []table.Column{{Title: "Error", Width: 80}}
+		cols := []table.Column{{Title: i18n.T("Error"), Width: 80}}
 		rows := []table.Row{{"Failed to load security group: " + m.err.Error()}}
 		return table.New(table.WithColumns(cols), table.WithRows(rows)).View()
 	}
 	// Render group details and rules.
 	groupView := m.table.View()
+	if m.showUsage {
+		var body string
+		switch {
+		case m.usageLoading:
+			body = m.spinner.View() + " " + i18n.T("Loading ports...")
+		case m.usageErr != nil:
+			body = "Failed to load ports: " + m.usageErr.Error()
+		case m.usageCount == 0:
+			body = i18n.T("No ports use this security group.")
+		default:
+			body = m.usageTable.View()
+		}
+		return i18n.T("%s\n\nUsed by (%d ports):\n%s\n[u] rules [r] refresh [esc] back", groupView, m.usageCount, body)
+	}
 	rulesView := m.rulesTable.View()
-	footer := "[n]ew [d]elete [y] json [i] inspect [esc] back"
-	return fmt.Sprintf("%s\n\nRules:\n%s\n%s", groupView, rulesView, footer)
+	footer := i18n.T("[n]ew [d]elete [I]mport [E]xport [u]sed by [y] json [i] inspect [esc] back")
+	if m.ioMode != "" {
+		label := "Import"
+		if m.ioMode == "export" {
+			label = "Export"
+		}
+		footer = i18n.T("%s rules file: %s\n[enter] confirm  [esc] cancel", label, m.pathInput.View())
+	} else if m.ioStatus != "" {
+		footer = m.ioStatus + "\n" + footer
+	}
+	return i18n.T("%s\n\nRules:\n%s\n%s", groupView, rulesView, footer)
+}
+
+// rulesIOCmd returns a command that imports rules from or exports rules to path.
+func (m SecurityGroupDetailModel) rulesIOCmd(mode, path string) tea.Cmd {
+	rulesList := m.sgJSON.Rules
+	return func() tea.Msg {
+		if mode == "export" {
+			sgs, err := m.client.ListSecurityGroups()
+			if err != nil {
+				return rulesIODoneMsg{status: i18n.T("Export failed: %s", err)}
+			}
+			if err := exportRules(rulesList, m.sgID, sgs, path); err != nil {
+				return rulesIODoneMsg{status: i18n.T("Export failed: %s", err)}
+			}
+			return rulesIODoneMsg{status: i18n.T("Exported %d rules to %s", len(rulesList), path)}
+		}
+		created, skipped, err := importRules(m.client, m.sgID, path)
+		if err != nil {
+			return rulesIODoneMsg{status: i18n.T("Import failed after %d rules: %s", created, err), reload: created > 0}
+		}
+		return rulesIODoneMsg{status: i18n.T("Imported %d rules, skipped %d duplicates", created, skipped), reload: true}
+	}
 }
 
 // Table returns the underlying table model.
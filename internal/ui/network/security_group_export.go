@@ -0,0 +1,106 @@
+package network
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/security/groups"
+	"ostui/internal/client"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/iac"
+)
+
+// ExportSpec implements iac.Exportable for the security group view,
+// exporting the group with its rules.
+func (m SecurityGroupDetailModel) ExportSpec() iac.Spec {
+	c, id := m.client, m.sgID
+	return iac.Spec{
+		Title: i18n.T("security group %s", id),
+		File:  id,
+		Load: func() (iac.Code, error) {
+			all, err := c.ListSecurityGroups()
+			if err != nil {
+				return iac.Code{}, err
+			}
+			for _, g := range all {
+				if g.ID != id {
+					continue
+				}
+				rules, err := c.ListSecurityGroupRules(context.Background(), id)
+				if err != nil {
+					return iac.Code{}, err
+				}
+				return exportSecurityGroup(g, rules), nil
+			}
+			return iac.Code{}, fmt.Errorf("security group %s not found", id)
+		},
+	}
+}
+
+// exportSecurityGroup renders a group and its rules. The Terraform group
+// drops the default rules Neutron adds, since every rule is exported; the
+// CLI keeps them and so skips the default egress rules.
+func exportSecurityGroup(g groups.SecGroup, rules []client.SecurityGroupRule) iac.Code {
+	names := iac.Namer{}
+	const sgType, ruleType = "openstack_networking_secgroup_v2", "openstack_networking_secgroup_rule_v2"
+	sgBlk := iac.Block{Type: sgType, Name: names.Name(sgType, g.Name, "secgroup"), ID: g.ID, Attrs: []iac.Attr{
+		{Name: "name", Value: g.Name},
+		{Name: "description", Value: g.Description},
+		{Name: "stateful", Value: g.Stateful},
+		{Name: "delete_default_rules", Value: true},
+	}}
+	args := []string{"security", "group", "create"}
+	if g.Description != "" {
+		args = append(args, "--description", g.Description)
+	}
+	if !g.Stateful {
+		args = append(args, "--stateless")
+	}
+	code := iac.Code{Resources: []iac.Block{sgBlk}, Commands: []string{iac.Command(append(args, g.Name)...)}}
+	self := iac.Ref(sgBlk.Address() + ".id")
+
+	for _, r := range rules {
+		var remoteGroup any = r.RemoteGroupID
+		if r.RemoteGroupID == g.ID {
+			remoteGroup = self
+		}
+		blk := iac.Block{Type: ruleType, Name: names.Name(ruleType, sgBlk.Name+"_"+r.Direction, "rule"), ID: r.ID, Attrs: []iac.Attr{
+			{Name: "security_group_id", Value: self},
+			{Name: "description", Value: r.Description},
+			{Name: "direction", Value: r.Direction},
+			{Name: "ethertype", Value: r.EtherType},
+			{Name: "protocol", Value: r.Protocol},
+		}}
+		if r.PortRangeMin != 0 || r.PortRangeMax != 0 {
+			blk.Attrs = append(blk.Attrs, iac.Attr{Name: "port_range_min", Value: r.PortRangeMin}, iac.Attr{Name: "port_range_max", Value: r.PortRangeMax})
+		}
+		blk.Attrs = append(blk.Attrs, iac.Attr{Name: "remote_ip_prefix", Value: r.RemoteIPPrefix}, iac.Attr{Name: "remote_group_id", Value: remoteGroup})
+		code.Resources = append(code.Resources, blk)
+
+		if r.Direction == "egress" && r.Protocol == "" && r.RemoteIPPrefix == "" && r.RemoteGroupID == "" && r.PortRangeMin == 0 {
+			continue
+		}
+		args := []string{"security", "group", "rule", "create", "--" + r.Direction, "--ethertype", r.EtherType}
+		if r.Protocol != "" {
+			args = append(args, "--protocol", r.Protocol)
+		}
+		if r.PortRangeMin != 0 || r.PortRangeMax != 0 {
+			args = append(args, "--dst-port", fmt.Sprintf("%d:%d", r.PortRangeMin, r.PortRangeMax))
+		}
+		if r.RemoteIPPrefix != "" {
+			args = append(args, "--remote-ip", r.RemoteIPPrefix)
+		}
+		if r.RemoteGroupID != "" {
+			remote := r.RemoteGroupID
+			if remote == g.ID {
+				remote = g.Name
+			}
+			args = append(args, "--remote-group", remote)
+		}
+		if r.Description != "" {
+			args = append(args, "--description", r.Description)
+		}
+		code.Commands = append(code.Commands, iac.Command(append(args, g.Name)...))
+	}
+	return code
+}
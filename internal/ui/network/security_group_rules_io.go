@@ -0,0 +1,280 @@
+package network
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/security/groups"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/security/rules"
+	"gopkg.in/yaml.v2"
+	"ostui/internal/client"
+)
+
+// ruleSpec is the portable representation of a security group rule used for
+// import and export. IDs and the owning group are deliberately omitted so a
+// ruleset can be copied between groups and clouds. A remote group is carried
+// by name, or as the group itself, and resolved again on import.
+type ruleSpec struct {
+	Direction      string `json:"direction" yaml:"direction"`
+	EtherType      string `json:"ethertype,omitempty" yaml:"ethertype,omitempty"`
+	Protocol       string `json:"protocol,omitempty" yaml:"protocol,omitempty"`
+	PortRangeMin   int    `json:"port_range_min,omitempty" yaml:"port_range_min,omitempty"`
+	PortRangeMax   int    `json:"port_range_max,omitempty" yaml:"port_range_max,omitempty"`
+	RemoteIPPrefix string `json:"remote_ip_prefix,omitempty" yaml:"remote_ip_prefix,omitempty"`
+	RemoteGroupID  string `json:"remote_group_id,omitempty" yaml:"remote_group_id,omitempty"`
+	// RemoteGroupName is the name of the remote group; RemoteGroupSelf is
+	// set instead when the rule refers to the group it belongs to.
+	RemoteGroupName string `json:"remote_group_name,omitempty" yaml:"remote_group_name,omitempty"`
+	RemoteGroupSelf bool   `json:"remote_group_self,omitempty" yaml:"remote_group_self,omitempty"`
+	Description     string `json:"description,omitempty" yaml:"description,omitempty"`
+}
+
+// ruleFile is the top-level document of a rules file.
+type ruleFile struct {
+	Rules []ruleSpec `json:"rules" yaml:"rules"`
+}
+
+// ruleSpecFromRule converts an existing rule into its portable form.
+func ruleSpecFromRule(r client.SecurityGroupRule) ruleSpec {
+	return ruleSpec{
+		Direction:      r.Direction,
+		EtherType:      r.EtherType,
+		Protocol:       r.Protocol,
+		PortRangeMin:   r.PortRangeMin,
+		PortRangeMax:   r.PortRangeMax,
+		RemoteIPPrefix: r.RemoteIPPrefix,
+		RemoteGroupID:  r.RemoteGroupID,
+		Description:    r.Description,
+	}
+}
+
+// isJSONPath reports whether the file should be read or written as JSON.
+func isJSONPath(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), ".json")
+}
+
+// exportRules writes the rules of group sgID to path as JSON (".json") or
+// YAML (anything else), naming their remote groups from sgs.
+func exportRules(ruleList []client.SecurityGroupRule, sgID string, sgs []groups.SecGroup, path string) error {
+	names := map[string]string{}
+	for _, g := range sgs {
+		names[g.ID] = g.Name
+	}
+	doc := ruleFile{Rules: []ruleSpec{}}
+	for _, r := range ruleList {
+		s := ruleSpecFromRule(r)
+		if s.RemoteGroupID == sgID && sgID != "" {
+			s.RemoteGroupSelf = true
+		} else if s.RemoteGroupID != "" {
+			s.RemoteGroupName = names[s.RemoteGroupID]
+		}
+		doc.Rules = append(doc.Rules, s)
+	}
+	var (
+		b   []byte
+		err error
+	)
+	if isJSONPath(path) {
+		b, err = json.MarshalIndent(doc, "", "  ")
+	} else {
+		b, err = yaml.Marshal(doc)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to encode rules: %w", err)
+	}
+	if err := os.WriteFile(path, b, 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// parseRuleFile reads a JSON or YAML rules file.
+func parseRuleFile(path string) ([]ruleSpec, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	var doc ruleFile
+	if isJSONPath(path) {
+		err = json.Unmarshal(b, &doc)
+	} else {
+		err = yaml.Unmarshal(b, &doc)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return doc.Rules, nil
+}
+
+// normalize fills defaults and lower-cases fields so rules can be compared.
+func (r ruleSpec) normalize() ruleSpec {
+	r.Direction = strings.ToLower(strings.TrimSpace(r.Direction))
+	r.Protocol = strings.ToLower(strings.TrimSpace(r.Protocol))
+	r.RemoteIPPrefix = strings.TrimSpace(r.RemoteIPPrefix)
+	r.RemoteGroupID = strings.TrimSpace(r.RemoteGroupID)
+	if r.EtherType == "" {
+		r.EtherType = "IPv4"
+		if ip, _, err := net.ParseCIDR(r.RemoteIPPrefix); err == nil && ip.To4() == nil {
+			r.EtherType = "IPv6"
+		}
+	}
+	if strings.EqualFold(r.EtherType, "ipv4") {
+		r.EtherType = "IPv4"
+	} else if strings.EqualFold(r.EtherType, "ipv6") {
+		r.EtherType = "IPv6"
+	}
+	if r.Protocol == "any" {
+		r.Protocol = ""
+	}
+	return r
+}
+
+// key identifies a rule independently of its ID and description.
+func (r ruleSpec) key() string {
+	return fmt.Sprintf("%s|%s|%s|%d|%d|%s|%s", r.Direction, r.EtherType, r.Protocol, r.PortRangeMin, r.PortRangeMax, r.RemoteIPPrefix, r.RemoteGroupID)
+}
+
+// validate checks a normalized rule for obvious mistakes before it is sent to Neutron.
+func (r ruleSpec) validate() error {
+	if r.Direction != "ingress" && r.Direction != "egress" {
+		return fmt.Errorf("direction must be ingress or egress, got %q", r.Direction)
+	}
+	if r.EtherType != "IPv4" && r.EtherType != "IPv6" {
+		return fmt.Errorf("ethertype must be IPv4 or IPv6, got %q", r.EtherType)
+	}
+	if r.PortRangeMin < 0 || r.PortRangeMax > 65535 || r.PortRangeMin > r.PortRangeMax {
+		return fmt.Errorf("invalid port range %d-%d", r.PortRangeMin, r.PortRangeMax)
+	}
+	if (r.PortRangeMin != 0 || r.PortRangeMax != 0) && r.Protocol == "" {
+		return fmt.Errorf("port range %d-%d requires a protocol", r.PortRangeMin, r.PortRangeMax)
+	}
+	if r.RemoteIPPrefix != "" && r.RemoteGroupID != "" {
+		return fmt.Errorf("remote_ip_prefix and remote_group_id are mutually exclusive")
+	}
+	if r.RemoteIPPrefix != "" {
+		ip, _, err := net.ParseCIDR(r.RemoteIPPrefix)
+		if err != nil {
+			return fmt.Errorf("invalid remote_ip_prefix %q", r.RemoteIPPrefix)
+		}
+		if (ip.To4() != nil) != (r.EtherType == "IPv4") {
+			return fmt.Errorf("remote_ip_prefix %s does not match ethertype %s", r.RemoteIPPrefix, r.EtherType)
+		}
+	}
+	return nil
+}
+
+// planRuleImport validates the rules from a file and drops those that already
+// exist in the group or appear twice in the file. It returns the rules to create
+// and the number of duplicates skipped; any invalid rule aborts the plan.
+func planRuleImport(existing []client.SecurityGroupRule, specs []ruleSpec) ([]ruleSpec, int, error) {
+	seen := map[string]bool{}
+	for _, r := range existing {
+		seen[ruleSpecFromRule(r).normalize().key()] = true
+	}
+	var toCreate []ruleSpec
+	skipped := 0
+	for i, s := range specs {
+		n := s.normalize()
+		if err := n.validate(); err != nil {
+			return nil, 0, fmt.Errorf("rule %d: %w", i+1, err)
+		}
+		k := n.key()
+		if seen[k] {
+			skipped++
+			continue
+		}
+		seen[k] = true
+		toCreate = append(toCreate, n)
+	}
+	return toCreate, skipped, nil
+}
+
+// resolveRemoteGroups points the remote groups of specs at the groups of
+// the project importing them: the group sgID itself, or the group of the
+// same name. An exported ID is kept only when it exists here; any remote
+// group without a match aborts the import rather than creating a rule that
+// refers to a missing or unrelated group.
+func resolveRemoteGroups(specs []ruleSpec, sgID string, sgs []groups.SecGroup) ([]ruleSpec, error) {
+	out := make([]ruleSpec, len(specs))
+	for i, s := range specs {
+		switch {
+		case s.RemoteGroupSelf:
+			s.RemoteGroupID = sgID
+		case s.RemoteGroupName != "":
+			var ids []string
+			for _, g := range sgs {
+				if g.Name == s.RemoteGroupName {
+					ids = append(ids, g.ID)
+				}
+			}
+			switch len(ids) {
+			case 0:
+				return nil, fmt.Errorf("rule %d: no security group named %q here", i+1, s.RemoteGroupName)
+			case 1:
+				s.RemoteGroupID = ids[0]
+			default:
+				return nil, fmt.Errorf("rule %d: %d security groups are named %q", i+1, len(ids), s.RemoteGroupName)
+			}
+		case s.RemoteGroupID != "":
+			found := false
+			for _, g := range sgs {
+				found = found || g.ID == strings.TrimSpace(s.RemoteGroupID)
+			}
+			if !found {
+				return nil, fmt.Errorf("rule %d: remote group %s does not exist here; give remote_group_name instead", i+1, s.RemoteGroupID)
+			}
+		}
+		s.RemoteGroupName, s.RemoteGroupSelf = "", false
+		out[i] = s
+	}
+	return out, nil
+}
+
+// importRules creates the rules from path in the given security group,
+// skipping duplicates. It returns the number of rules created and skipped.
+func importRules(nc client.NetworkClient, sgID, path string) (int, int, error) {
+	specs, err := parseRuleFile(path)
+	if err != nil {
+		return 0, 0, err
+	}
+	sgs, err := nc.ListSecurityGroups()
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to list security groups: %w", err)
+	}
+	if specs, err = resolveRemoteGroups(specs, sgID, sgs); err != nil {
+		return 0, 0, err
+	}
+	ctx := context.Background()
+	existing, err := nc.ListSecurityGroupRules(ctx, sgID)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to list existing rules: %w", err)
+	}
+	toCreate, skipped, err := planRuleImport(existing, specs)
+	if err != nil {
+		return 0, 0, err
+	}
+	created := 0
+	for _, s := range toCreate {
+		opts := client.SecurityGroupRuleInput{
+			Direction:      rules.RuleDirection(s.Direction),
+			EtherType:      rules.RuleEtherType(s.EtherType),
+			SecGroupID:     sgID,
+			Protocol:       rules.RuleProtocol(s.Protocol),
+			PortRangeMin:   s.PortRangeMin,
+			PortRangeMax:   s.PortRangeMax,
+			RemoteIPPrefix: s.RemoteIPPrefix,
+			RemoteGroupID:  s.RemoteGroupID,
+			Description:    s.Description,
+		}
+		if _, err := nc.CreateSecurityGroupRule(ctx, sgID, opts); err != nil {
+			return created, skipped, fmt.Errorf("failed to create rule %s: %w", s.key(), err)
+		}
+		created++
+	}
+	return created, skipped, nil
+}
@@ -0,0 +1,148 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"ostui/internal/client"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/uiconst"
+)
+
+// sgUsage describes one port that references a security group, together with
+// the resource the port is attached to.
+type sgUsage struct {
+	PortID       string
+	PortName     string
+	FixedIPs     string
+	Kind         string // "server", "load balancer", "router", "dhcp", "other" or "unbound"
+	ResourceID   string
+	ResourceName string
+}
+
+// sgUsageLoadedMsg carries the ports using a security group.
+type sgUsageLoadedMsg struct {
+	usage []sgUsage
+	err   error
+}
+
+// usageKind classifies a port by its device owner.
+func usageKind(p client.Port) string {
+	switch {
+	case p.DeviceOwner == "" && p.DeviceID == "":
+		return "unbound"
+	case strings.HasPrefix(p.DeviceOwner, "compute:"):
+		return "server"
+	case p.DeviceOwner == "Octavia" || strings.Contains(p.DeviceOwner, "LOADBALANCER"):
+		return "load balancer"
+	case strings.HasPrefix(p.DeviceOwner, "network:router"):
+		return "router"
+	case p.DeviceOwner == "network:dhcp":
+		return "dhcp"
+	default:
+		return "other"
+	}
+}
+
+// resolveSGUsage returns the ports referencing sgID, resolving the attached
+// server or load balancer names from the given lookups. Load balancers are
+// matched by the "lb-<id>" device ID Octavia uses or by VIP address.
+func resolveSGUsage(ports []client.Port, sgID string, serverNames map[string]string, lbs []client.LoadBalancer) []sgUsage {
+	lbByID := map[string]client.LoadBalancer{}
+	lbByVIP := map[string]client.LoadBalancer{}
+	for _, lb := range lbs {
+		lbByID[lb.ID] = lb
+		if lb.VipAddress != "" {
+			lbByVIP[lb.VipAddress] = lb
+		}
+	}
+	var out []sgUsage
+	for _, p := range ports {
+		uses := false
+		for _, id := range p.SecurityGroups {
+			if id == sgID {
+				uses = true
+				break
+			}
+		}
+		if !uses {
+			continue
+		}
+		var ips []string
+		for _, ip := range p.FixedIPs {
+			ips = append(ips, ip.IPAddress)
+		}
+		u := sgUsage{PortID: p.ID, PortName: p.Name, FixedIPs: strings.Join(ips, ", "), Kind: usageKind(p), ResourceID: p.DeviceID}
+		switch u.Kind {
+		case "server":
+			u.ResourceName = serverNames[p.DeviceID]
+		case "load balancer":
+			if lb, ok := lbByID[strings.TrimPrefix(p.DeviceID, "lb-")]; ok {
+				u.ResourceID, u.ResourceName = lb.ID, lb.Name
+			}
+		}
+		// VIP ports are sometimes left without a device owner; match them by address.
+		if u.ResourceName == "" {
+			for _, ip := range ips {
+				if lb, ok := lbByVIP[ip]; ok {
+					u.Kind, u.ResourceID, u.ResourceName = "load balancer", lb.ID, lb.Name
+					break
+				}
+			}
+		}
+		out = append(out, u)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Kind != out[j].Kind {
+			return out[i].Kind < out[j].Kind
+		}
+		return out[i].ResourceName < out[j].ResourceName
+	})
+	return out
+}
+
+// loadSGUsageCmd lists ports and resolves the servers and load balancers using
+// the security group. The compute and load balancer clients are optional.
+func loadSGUsageCmd(nc client.NetworkClient, cc client.ComputeClient, lbc client.LoadBalancerClient, sgID string) tea.Cmd {
+	return func() tea.Msg {
+		ctx := context.Background()
+		ports, err := nc.ListPorts(ctx)
+		if err != nil {
+			return sgUsageLoadedMsg{err: fmt.Errorf("failed to list ports: %w", err)}
+		}
+		serverNames := map[string]string{}
+		if cc != nil {
+			if srvs, err := cc.ListInstances(); err == nil {
+				for _, s := range srvs {
+					serverNames[s.ID] = s.Name
+				}
+			}
+		}
+		var lbs []client.LoadBalancer
+		if lbc != nil {
+			lbs, _ = lbc.ListLoadBalancers(ctx)
+		}
+		return sgUsageLoadedMsg{usage: resolveSGUsage(ports, sgID, serverNames, lbs)}
+	}
+}
+
+// newSGUsageTable builds the "used by" table.
+func newSGUsageTable(usage []sgUsage, height int) table.Model {
+	cols := []table.Column{{Title: i18n.T("Kind"), Width: uiconst.ColWidthStatus}, {Title: i18n.T("Resource"), Width: uiconst.ColWidthName}, {Title: i18n.T("Resource ID"), Width: uiconst.ColWidthUUID}, {Title: i18n.T("Port"), Width: uiconst.ColWidthUUID}, {Title: i18n.T("Fixed IPs"), Width: uiconst.ColWidthFixed}}
+	rows := []table.Row{}
+	for _, u := range usage {
+		rows = append(rows, table.Row{u.Kind, u.ResourceName, u.ResourceID, u.PortID, u.FixedIPs})
+	}
+	t := table.New(
+		table.WithColumns(cols),
+		table.WithRows(rows),
+		table.WithFocused(true),
+		table.WithHeight(height),
+	)
+	t.SetStyles(table.DefaultStyles())
+	return t
+}
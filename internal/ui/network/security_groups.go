@@ -7,6 +7,7 @@ import (
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"ostui/internal/client"
+	"ostui/internal/i18n"
 	"ostui/internal/ui/common"
 	"ostui/internal/ui/uiconst"
 	"strings"
@@ -49,13 +50,14 @@ func (m SecurityGroupsModel) Init() tea.Cmd {
 		if err != nil {
 			return securityGroupsDataLoadedMsg{err: err}
 		}
-		cols := []table.Column{{Title: "ID", Width: uiconst.ColWidthUUID}, {Title: "Name", Width: uiconst.ColWidthName}, {Title: "Description", Width: uiconst.ColWidthDescription}, {Title: "Stateful", Width: uiconst.ColWidthStateful}}
+		cols := []table.Column{{Title: "ID", Width: uiconst.ColWidthUUID}, {Title: i18n.T("Name"), Width: uiconst.ColWidthName}, {Title: i18n.T("Description"), Width: uiconst.ColWidthDescription}, {Title: i18n.T("Stateful"), Width: uiconst.ColWidthStateful}}
 		rows := []table.Row{}
 		for _, sg := range sgList {
 			rows = append(rows, table.Row{sg.ID, sg.Name, sg.Description, fmt.Sprintf("%v", sg.Stateful)})
 		}
+		rows = common.TerraformRows(rows)
 		t := table.New(
-			table.WithColumns(cols),
+			table.WithColumns(common.TerraformColumns(cols)),
 			table.WithRows(rows),
 			table.WithFocused(true),
 			table.WithHeight(m.height-uiconst.TableHeightOffset),
@@ -65,6 +67,14 @@ func (m SecurityGroupsModel) Init() tea.Cmd {
 	}
 }
 
+// Reload re-fetches the security groups, keeping the selected row.
+func (m SecurityGroupsModel) Reload() tea.Cmd {
+	if m.loading || m.filterMode {
+		return nil
+	}
+	return m.Init()
+}
+
 // Update handles messages.
 func (m SecurityGroupsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
@@ -74,7 +84,7 @@ func (m SecurityGroupsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.err = msg.err
 			return m, nil
 		}
-		m.table = msg.tbl
+		m.table = common.Reloaded(m.table, msg.tbl)
 		m.allRows = msg.rows
 		m.updateTableColumns()
 		m.table.SetHeight(m.height - uiconst.TableHeightOffset)
@@ -145,12 +155,12 @@ func (m SecurityGroupsModel) View() string {
 		return m.spinner.View()
 	}
 	if m.err != nil {
-		cols := []table.Column{{Title: "Error", Width: uiconst.ColWidthError}}
+		cols := []table.Column{{Title: i18n.T("Error"), Width: uiconst.ColWidthError}}
 		rows := []table.Row{{"Failed to list security groups: " + m.err.Error()}}
 		return common.NewTable(cols, rows).View()
 	}
 	if m.filterMode {
-		filterLine := fmt.Sprintf("Filter: %s", m.filter.View())
+		filterLine := i18n.T("Filter: %s", m.filter.View())
 		footer := "esc: clear"
 		return fmt.Sprintf("%s\n%s\n%s", filterLine, m.table.View(), footer)
 	}
@@ -162,7 +172,7 @@ func (m *SecurityGroupsModel) updateTableColumns() {
 	idW := uiconst.ColWidthUUID
 	statefulW := uiconst.ColWidthStateful
 	// Remaining width for Name and Description
-	remaining := m.width - idW - statefulW - 6
+	remaining := m.width - idW - statefulW - 6 - common.TerraformWidth()
 	if remaining < 20 {
 		remaining = 20
 	}
@@ -174,11 +184,14 @@ func (m *SecurityGroupsModel) updateTableColumns() {
 	if descW < 10 {
 		descW = 10
 	}
-	m.table.SetColumns([]table.Column{{Title: "ID", Width: idW}, {Title: "Name", Width: nameW}, {Title: "Description", Width: descW}, {Title: "Stateful", Width: statefulW}})
+	m.table.SetColumns(common.TerraformColumns([]table.Column{{Title: "ID", Width: idW}, {Title: i18n.T("Name"), Width: nameW}, {Title: i18n.T("Description"), Width: descW}, {Title: i18n.T("Stateful"), Width: statefulW}}))
 }
 
 // Ensure SecurityGroupsModel implements tea.Model.
 // Table returns the underlying table model.
 func (m SecurityGroupsModel) Table() table.Model { return m.table }
 
+// Filtering reports whether keys are typed into the filter.
+func (m SecurityGroupsModel) Filtering() bool { return m.filterMode }
+
 var _ tea.Model = (*SecurityGroupsModel)(nil)
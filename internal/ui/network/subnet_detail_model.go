@@ -3,10 +3,15 @@ package network
 import (
 	"context"
 	"fmt"
+	"strings"
+
 	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/table"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/subnets"
 	"ostui/internal/client"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/common"
 	"ostui/internal/ui/uiconst"
 )
 
@@ -26,6 +31,9 @@ type subnetDetailDataLoadedMsg struct {
 	err error
 }
 
+// ResourceID returns the subnet ID.
+func (m SubnetDetailModel) ResourceID() string { return m.subnetID }
+
 // NewSubnetDetailModel creates a new SubnetDetailModel for the given subnet ID.
 func NewSubnetDetailModel(nc client.NetworkClient, subnetID string) SubnetDetailModel {
 	s := spinner.New()
@@ -40,11 +48,10 @@ func (m SubnetDetailModel) Init() tea.Cmd {
 		if err != nil {
 			return subnetDetailDataLoadedMsg{err: err}
 		}
-		cols := []table.Column{{Title: "Field", Width: uiconst.ColWidthField}, {Title: "Value", Width: uiconst.ColWidthValue}}
-		rows := []table.Row{{"ID", s.ID}, {"Name", s.Name}, {"NetworkID", s.NetworkID}, {"CIDR", s.CIDR}, {"IPVersion", fmt.Sprintf("%d", s.IPVersion)}, {"GatewayIP", s.GatewayIP}, {"EnableDHCP", fmt.Sprintf("%v", s.EnableDHCP)}}
+		cols := []table.Column{{Title: i18n.T("Field"), Width: uiconst.ColWidthField}, {Title: i18n.T("Value"), Width: uiconst.ColWidthValue}}
 		t := table.New(
 			table.WithColumns(cols),
-			table.WithRows(rows),
+			table.WithRows(subnetRows(s)),
 			table.WithFocused(true),
 		)
 		t.SetStyles(table.DefaultStyles())
@@ -52,6 +59,48 @@ func (m SubnetDetailModel) Init() tea.Cmd {
 	}
 }
 
+// subnetRows lists the fields of a subnet, with what DHCP hands its ports:
+// the nameservers, host routes and the pools addresses are taken from. Each
+// route and pool gets a row of its own.
+func subnetRows(s *subnets.Subnet) []table.Row {
+	gateway := s.GatewayIP
+	if gateway == "" {
+		gateway = "none"
+	}
+	rows := []table.Row{{"ID", s.ID}, {"Name", s.Name}, {"NetworkID", s.NetworkID}, {"CIDR", s.CIDR}, {"IPVersion", fmt.Sprintf("%d", s.IPVersion)}, {"GatewayIP", gateway}, {"EnableDHCP", fmt.Sprintf("%v", s.EnableDHCP)}}
+	ns := strings.Join(s.DNSNameservers, ", ")
+	if ns == "" {
+		ns = "none (the DHCP agent's resolver)"
+	}
+	rows = append(rows, table.Row{i18n.T("DNSNameservers"), ns})
+	if len(s.HostRoutes) == 0 {
+		rows = append(rows, table.Row{i18n.T("HostRoutes"), i18n.T("none")})
+	}
+	for i, r := range s.HostRoutes {
+		field := ""
+		if i == 0 {
+			field = "HostRoutes"
+		}
+		rows = append(rows, table.Row{field, i18n.T("%s via %s", r.DestinationCIDR, r.NextHop)})
+	}
+	for i, p := range s.AllocationPools {
+		field := ""
+		if i == 0 {
+			field = "AllocationPools"
+		}
+		rows = append(rows, table.Row{field, p.Start + " – " + p.End})
+	}
+	return rows
+}
+
+// Reload re-fetches the subnet, keeping the selected row.
+func (m SubnetDetailModel) Reload() tea.Cmd {
+	if m.loading {
+		return nil
+	}
+	return m.Init()
+}
+
 // Update handles messages.
 func (m SubnetDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
@@ -61,7 +110,7 @@ func (m SubnetDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.err = msg.err
 			return m, nil
 		}
-		m.table = msg.tbl
+		m.table = common.Reloaded(m.table, msg.tbl)
 		return m, nil
 	case tea.WindowSizeMsg:
 		if !m.loading && len(m.table.Columns()) > 0 {
@@ -104,9 +153,9 @@ func (m SubnetDetailModel) View() string {
 		return m.spinner.View()
 	}
 	if m.err != nil {
-		return fmt.Sprintf("Error: %s", m.err)
+		return i18n.T("Error: %s", m.err)
 	}
-	return fmt.Sprintf("%s\n[esc] back", m.table.View())
+	return i18n.T("%s\n[E] edit nameservers and routes  [esc] back", m.table.View())
 }
 
 // Table returns the underlying table model.
@@ -0,0 +1,108 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"slices"
+
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/subnets"
+	"ostui/internal/client"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/editor"
+)
+
+// hostRouteEdit is a host route as edited in YAML.
+type hostRouteEdit struct {
+	Destination string `yaml:"destination"`
+	NextHop     string `yaml:"nexthop"`
+}
+
+// subnetEdit holds the DHCP settings of a subnet edited in YAML: what its
+// ports are handed besides their address.
+type subnetEdit struct {
+	DNSNameservers []string        `yaml:"dns_nameservers"`
+	HostRoutes     []hostRouteEdit `yaml:"host_routes"`
+}
+
+// EditSpec implements editor.Editable for the subnet detail view.
+func (m SubnetDetailModel) EditSpec() editor.Spec {
+	c, id := m.client, m.subnetID
+	revision := new(int)
+	return editor.Spec{
+		Title: i18n.T("subnet %s", id),
+		Load: func() (string, error) {
+			s, err := c.GetSubnet(context.Background(), id)
+			if err != nil {
+				return "", err
+			}
+			*revision = s.RevisionNumber
+			e := subnetEdit{DNSNameservers: s.DNSNameservers}
+			for _, r := range s.HostRoutes {
+				e.HostRoutes = append(e.HostRoutes, hostRouteEdit{Destination: r.DestinationCIDR, NextHop: r.NextHop})
+			}
+			return editor.Encode(e)
+		},
+		Apply: func(original, edited string) (string, error) {
+			return applySubnetEdit(c, id, *revision, original, edited)
+		},
+	}
+}
+
+// check validates the addresses of e, so a typo is shown in the editor
+// rather than as a Neutron 400.
+func (e subnetEdit) check() error {
+	for _, ns := range e.DNSNameservers {
+		if net.ParseIP(ns) == nil {
+			return fmt.Errorf("dns_nameservers: %q is not an IP address", ns)
+		}
+	}
+	for _, r := range e.HostRoutes {
+		if _, _, err := net.ParseCIDR(r.Destination); err != nil {
+			return fmt.Errorf("host_routes: destination %q is not a CIDR, e.g. 10.1.0.0/16", r.Destination)
+		}
+		if net.ParseIP(r.NextHop) == nil {
+			return fmt.Errorf("host_routes: nexthop %q is not an IP address", r.NextHop)
+		}
+	}
+	return nil
+}
+
+// applySubnetEdit validates edited and sends the changed fields in one
+// update. A changed list is sent whole, as Neutron replaces it; an empty
+// one clears it.
+func applySubnetEdit(c client.NetworkClient, id string, revision int, original, edited string) (string, error) {
+	var before, after subnetEdit
+	if err := editor.Decode(original, &before); err != nil {
+		return "", err
+	}
+	if err := editor.Decode(edited, &after); err != nil {
+		return "", err
+	}
+	changed := editor.ChangedFields(before, after)
+	if len(changed) == 0 {
+		return editor.Summary(nil), nil
+	}
+	if err := after.check(); err != nil {
+		return "", err
+	}
+	var opts subnets.UpdateOpts
+	if slices.Contains(changed, "dns_nameservers") {
+		ns := append([]string{}, after.DNSNameservers...)
+		opts.DNSNameservers = &ns
+	}
+	if slices.Contains(changed, "host_routes") {
+		routes := []subnets.HostRoute{}
+		for _, r := range after.HostRoutes {
+			routes = append(routes, subnets.HostRoute{DestinationCIDR: r.Destination, NextHop: r.NextHop})
+		}
+		opts.HostRoutes = &routes
+	}
+	if revision > 0 {
+		opts.RevisionNumber = &revision
+	}
+	if err := c.UpdateSubnet(context.Background(), id, opts); err != nil {
+		return "", err
+	}
+	return editor.Summary(changed), nil
+}
@@ -0,0 +1,445 @@
+package network
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/subnets"
+	"ostui/internal/client"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/common"
+	"ostui/internal/ui/theme"
+	"ostui/internal/ui/uiconst"
+)
+
+// Subnet pool view modes, switched with tab.
+const (
+	poolModePools  = "pools"
+	poolModeScopes = "scopes"
+)
+
+// poolData holds the subnet pools, the address scopes they belong to and
+// the subnets allocated from them.
+type poolData struct {
+	pools   []client.SubnetPool
+	scopes  []client.AddressScope
+	subnets []subnets.Subnet
+}
+
+// loadPoolData lists the pools, scopes and subnets. Address scopes are
+// optional, as the address-scope extension may be disabled.
+func loadPoolData(ctx context.Context, nc client.NetworkClient) (poolData, error) {
+	var d poolData
+	var err error
+	if d.pools, err = nc.ListSubnetPools(ctx); err != nil {
+		return d, fmt.Errorf("%w (is the subnet_allocation extension enabled?)", err)
+	}
+	if d.subnets, err = nc.ListSubnets(); err != nil {
+		return d, err
+	}
+	d.scopes, _ = nc.ListAddressScopes(ctx)
+	return d, nil
+}
+
+func (d poolData) scopeName(id string) string {
+	if id == "" {
+		return "-"
+	}
+	for _, s := range d.scopes {
+		if s.ID == id {
+			return nameOrID(s.Name, s.ID)
+		}
+	}
+	return id
+}
+
+// poolSubnets returns the subnets allocated from pool id.
+func (d poolData) poolSubnets(id string) []subnets.Subnet {
+	var out []subnets.Subnet
+	for _, s := range d.subnets {
+		if s.SubnetPoolID == id {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// prefixLens renders the default prefix length and the allowed range.
+func prefixLens(p client.SubnetPool) string {
+	return fmt.Sprintf("/%d (/%d-/%d)", p.DefaultPrefixLen, p.MinPrefixLen, p.MaxPrefixLen)
+}
+
+// poolFlags renders the shared and default flags of a pool.
+func poolFlags(p client.SubnetPool) string {
+	var flags []string
+	if p.IsDefault {
+		flags = append(flags, "default")
+	}
+	if p.Shared {
+		flags = append(flags, "shared")
+	}
+	return strings.Join(flags, ", ")
+}
+
+// SubnetPoolsModel lists subnet pools and address scopes; tab switches
+// between them.
+type SubnetPoolsModel struct {
+	table   table.Model
+	loading bool
+	err     error
+	spinner spinner.Model
+	client  client.NetworkClient
+	data    poolData
+	mode    string
+
+	width  int
+	height int
+}
+
+// NewSubnetPoolsModel creates the subnet pool view, showing pools first.
+func NewSubnetPoolsModel(nc client.NetworkClient) SubnetPoolsModel {
+	s := spinner.New()
+	s.Spinner = spinner.Dot
+	return SubnetPoolsModel{client: nc, loading: true, spinner: s, mode: poolModePools, width: 120, height: 30}
+}
+
+type poolsLoadedMsg struct {
+	data poolData
+	err  error
+}
+
+// Init loads the pools and scopes.
+func (m SubnetPoolsModel) Init() tea.Cmd {
+	return tea.Batch(m.spinner.Tick, m.loadCmd())
+}
+
+// Reload re-fetches the pools and address scopes, keeping the mode and the
+// selected row.
+func (m SubnetPoolsModel) Reload() tea.Cmd {
+	if m.loading {
+		return nil
+	}
+	return m.loadCmd()
+}
+
+func (m SubnetPoolsModel) loadCmd() tea.Cmd {
+	nc := m.client
+	return func() tea.Msg {
+		d, err := loadPoolData(context.Background(), nc)
+		return poolsLoadedMsg{data: d, err: err}
+	}
+}
+
+// Update handles messages for the model.
+func (m SubnetPoolsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case poolsLoadedMsg:
+		m.loading = false
+		m.err = msg.err
+		m.data = msg.data
+		if msg.err == nil {
+			prev := m.table
+			m.refreshTable()
+			m.table = common.Reloaded(prev, m.table)
+		}
+		return m, nil
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		if !m.loading && m.err == nil {
+			m.refreshTable()
+		}
+		return m, nil
+	case tea.KeyMsg:
+		if m.loading || m.err != nil {
+			return m, nil
+		}
+		switch msg.String() {
+		case "tab", "shift+tab":
+			if m.mode == poolModePools {
+				m.mode = poolModeScopes
+			} else {
+				m.mode = poolModePools
+			}
+			m.refreshTable()
+			return m, nil
+		case "r":
+			m.loading = true
+			return m, tea.Batch(m.spinner.Tick, m.loadCmd())
+		}
+		var cmd tea.Cmd
+		m.table, cmd = m.table.Update(msg)
+		return m, cmd
+	default:
+		if m.loading {
+			var cmd tea.Cmd
+			m.spinner, cmd = m.spinner.Update(msg)
+			return m, cmd
+		}
+	}
+	return m, nil
+}
+
+// refreshTable rebuilds the table for the current mode.
+func (m *SubnetPoolsModel) refreshTable() {
+	idW := uiconst.ColWidthUUID
+	rest := m.width - idW - uiconst.TableHeightOffset
+	if rest < 60 {
+		rest = 60
+	}
+	var cols []table.Column
+	var rows []table.Row
+	switch m.mode {
+	case poolModePools:
+		lenW, verW, countW, flagsW := 16, 5, 7, 15
+		w := (rest - lenW - verW - countW - flagsW) / 3
+		cols = []table.Column{{Title: "ID", Width: idW}, {Title: i18n.T("Name"), Width: w}, {Title: i18n.T("Prefixes"), Width: w}, {Title: i18n.T("Prefix len"), Width: lenW}, {Title: i18n.T("IPVer"), Width: verW},
+			{Title: i18n.T("Address scope"), Width: rest - 2*w - lenW - verW - countW - flagsW}, {Title: i18n.T("Subnets"), Width: countW}, {Title: i18n.T("Flags"), Width: flagsW}}
+		for _, p := range m.data.pools {
+			rows = append(rows, table.Row{p.ID, p.Name, strings.Join(p.Prefixes, ", "), prefixLens(p), fmt.Sprintf("%d", p.IPversion),
+				m.data.scopeName(p.AddressScopeID), fmt.Sprintf("%d", len(m.data.poolSubnets(p.ID))), poolFlags(p)})
+		}
+	case poolModeScopes:
+		verW, sharedW := 5, 7
+		cols = []table.Column{{Title: "ID", Width: idW}, {Title: i18n.T("Name"), Width: rest / 3}, {Title: i18n.T("IPVer"), Width: verW}, {Title: i18n.T("Shared"), Width: sharedW}, {Title: i18n.T("Subnet pools"), Width: rest - rest/3 - verW - sharedW}}
+		for _, s := range m.data.scopes {
+			var pools []string
+			for _, p := range m.data.pools {
+				if p.AddressScopeID == s.ID {
+					pools = append(pools, nameOrID(p.Name, p.ID))
+				}
+			}
+			rows = append(rows, table.Row{s.ID, s.Name, fmt.Sprintf("%d", s.IPVersion), fmt.Sprintf("%t", s.Shared), strings.Join(pools, ", ")})
+		}
+	}
+	m.table = table.New(table.WithColumns(cols), table.WithFocused(true))
+	m.table.SetStyles(table.DefaultStyles())
+	m.table.SetRows(rows)
+	m.table.SetHeight(m.height - uiconst.TableHeightOffset - 2)
+}
+
+// SelectedPool returns the subnet pool under the cursor, when pools are
+// shown.
+func (m SubnetPoolsModel) SelectedPool() (client.SubnetPool, bool) {
+	row := m.table.SelectedRow()
+	if m.mode != poolModePools || len(row) == 0 {
+		return client.SubnetPool{}, false
+	}
+	for _, p := range m.data.pools {
+		if p.ID == row[0] {
+			return p, true
+		}
+	}
+	return client.SubnetPool{}, false
+}
+
+// View renders the current table with a tab bar.
+func (m SubnetPoolsModel) View() string {
+	if m.loading {
+		return m.spinner.View()
+	}
+	if m.err != nil {
+		return i18n.T("Error: %s", m.err)
+	}
+	active := lipgloss.NewStyle().Bold(true).Foreground(theme.Accent)
+	dim := lipgloss.NewStyle().Foreground(theme.Muted)
+	pools, scopes := i18n.T("Subnet pools"), i18n.T("Address scopes")
+	tabs := active.Render("["+pools+"]") + " " + dim.Render(" "+scopes+" ")
+	help := i18n.T("[enter] pool detail  [tab] switch list  [r] refresh")
+	if m.mode == poolModeScopes {
+		tabs = dim.Render(" "+pools+" ") + " " + active.Render("["+scopes+"]")
+		help = i18n.T("[tab] switch list  [r] refresh")
+	}
+	return tabs + "\n" + m.table.View() + "\n" + help
+}
+
+// Table returns the table of the current mode.
+func (m SubnetPoolsModel) Table() table.Model { return m.table }
+
+// SubnetPoolDetailModel shows one subnet pool with its prefixes, prefix
+// lengths and the subnets allocated from it.
+type SubnetPoolDetailModel struct {
+	table   table.Model
+	loading bool
+	err     error
+	spinner spinner.Model
+	client  client.NetworkClient
+	pool    client.SubnetPool
+}
+
+// NewSubnetPoolDetailModel creates the detail view of a subnet pool.
+func NewSubnetPoolDetailModel(nc client.NetworkClient, pool client.SubnetPool) SubnetPoolDetailModel {
+	s := spinner.New()
+	s.Spinner = spinner.Dot
+	return SubnetPoolDetailModel{client: nc, loading: true, spinner: s, pool: pool}
+}
+
+// ResourceID returns the subnet pool ID.
+func (m SubnetPoolDetailModel) ResourceID() string { return m.pool.ID }
+
+type poolDetailLoadedMsg struct {
+	rows []table.Row
+	err  error
+}
+
+// Init loads the subnets and scopes the pool references.
+func (m SubnetPoolDetailModel) Init() tea.Cmd {
+	nc, pool := m.client, m.pool
+	return tea.Batch(m.spinner.Tick, func() tea.Msg {
+		d, err := loadPoolData(context.Background(), nc)
+		if err != nil {
+			return poolDetailLoadedMsg{err: err}
+		}
+		return poolDetailLoadedMsg{rows: subnetPoolRows(pool, d)}
+	})
+}
+
+// subnetPoolRows lists the fields of a pool followed by one row per subnet
+// allocated from it.
+func subnetPoolRows(p client.SubnetPool, d poolData) []table.Row {
+	quota := "unlimited"
+	if p.DefaultQuota > 0 {
+		quota = i18n.T("%d addresses per project", p.DefaultQuota)
+	}
+	rows := []table.Row{
+		{"ID", p.ID},
+		{"Name", p.Name},
+		{"Description", p.Description},
+		{"IP version", fmt.Sprintf("%d", p.IPversion)},
+		{"Prefixes", strings.Join(p.Prefixes, ", ")},
+		{"Default prefix length", fmt.Sprintf("/%d", p.DefaultPrefixLen)},
+		{"Prefix length range", fmt.Sprintf("/%d to /%d", p.MinPrefixLen, p.MaxPrefixLen)},
+		{"Address scope", d.scopeName(p.AddressScopeID)},
+		{"Shared", fmt.Sprintf("%t", p.Shared)},
+		{"Default pool", fmt.Sprintf("%t", p.IsDefault)},
+		{"Quota", quota},
+		{"Project", p.ProjectID},
+	}
+	allocated := d.poolSubnets(p.ID)
+	if len(allocated) == 0 {
+		return append(rows, table.Row{i18n.T("Subnets"), i18n.T("none allocated")})
+	}
+	for i, s := range allocated {
+		label := ""
+		if i == 0 {
+			label = i18n.T("Subnets (%d)", len(allocated))
+		}
+		rows = append(rows, table.Row{label, i18n.T("%s  %s  network %s", s.CIDR, nameOrID(s.Name, s.ID), s.NetworkID)})
+	}
+	return rows
+}
+
+// Update handles messages for the model.
+func (m SubnetPoolDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case poolDetailLoadedMsg:
+		m.loading = false
+		m.err = msg.err
+		if msg.err != nil {
+			return m, nil
+		}
+		m.table = table.New(table.WithColumns([]table.Column{{Title: i18n.T("Field"), Width: uiconst.ColWidthField}, {Title: i18n.T("Value"), Width: uiconst.ColWidthValue}}), table.WithFocused(true))
+		m.table.SetStyles(table.DefaultStyles())
+		m.table.SetRows(msg.rows)
+		m.table.SetHeight(len(msg.rows) + 1)
+		return m, nil
+	case tea.KeyMsg:
+		if m.loading || m.err != nil {
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.table, cmd = m.table.Update(msg)
+		return m, cmd
+	default:
+		if m.loading {
+			var cmd tea.Cmd
+			m.spinner, cmd = m.spinner.Update(msg)
+			return m, cmd
+		}
+	}
+	return m, nil
+}
+
+// View renders the pool fields.
+func (m SubnetPoolDetailModel) View() string {
+	if m.loading {
+		return m.spinner.View()
+	}
+	if m.err != nil {
+		return i18n.T("Error: %s", m.err)
+	}
+	return m.table.View() + "\n" + i18n.T("[esc] back")
+}
+
+// Table returns the underlying table model.
+func (m SubnetPoolDetailModel) Table() table.Model { return m.table }
+
+// subnetFromForm validates the new subnet form: a network, and either an
+// explicit CIDR or a subnet pool (by name or ID) to allocate from, with an
+// optional prefix length within the pool's range.
+func subnetFromForm(v []string, pools []client.SubnetPool) (subnets.CreateOpts, error) {
+	opts := subnets.CreateOpts{NetworkID: v[0], Name: v[1], CIDR: v[2]}
+	if opts.NetworkID == "" {
+		return opts, errors.New("the network ID is required")
+	}
+	if v[2] != "" {
+		prefix, err := netip.ParsePrefix(v[2])
+		if err != nil {
+			return opts, fmt.Errorf("invalid CIDR %q", v[2])
+		}
+		opts.IPVersion = gophercloud.IPv4
+		if prefix.Addr().Is6() {
+			opts.IPVersion = gophercloud.IPv6
+		}
+	}
+	if v[3] == "" {
+		if v[2] == "" {
+			return opts, errors.New("enter a CIDR or a subnet pool to allocate from")
+		}
+		if v[4] != "" {
+			return opts, errors.New("a prefix length needs a subnet pool")
+		}
+		return opts, nil
+	}
+	var pool *client.SubnetPool
+	for i, p := range pools {
+		if p.ID == v[3] || p.Name == v[3] {
+			if pool != nil {
+				return opts, fmt.Errorf("several subnet pools are named %s; enter the pool ID", v[3])
+			}
+			pool = &pools[i]
+		}
+	}
+	if pool == nil {
+		return opts, fmt.Errorf("subnet pool %s does not exist", v[3])
+	}
+	opts.SubnetPoolID = pool.ID
+	if v[2] != "" && int(opts.IPVersion) != pool.IPversion {
+		return opts, fmt.Errorf("the CIDR is not IPv%d like subnet pool %s", pool.IPversion, nameOrID(pool.Name, pool.ID))
+	}
+	opts.IPVersion = gophercloud.IPVersion(pool.IPversion)
+	if v[4] != "" {
+		if v[2] != "" {
+			return opts, errors.New("enter either a CIDR or a prefix length")
+		}
+		n, err := strconv.Atoi(strings.TrimPrefix(v[4], "/"))
+		if err != nil || n < pool.MinPrefixLen || n > pool.MaxPrefixLen {
+			return opts, fmt.Errorf("the prefix length must be between /%d and /%d", pool.MinPrefixLen, pool.MaxPrefixLen)
+		}
+		opts.Prefixlen = n
+	}
+	return opts, nil
+}
+
+var (
+	_ tea.Model = (*SubnetPoolsModel)(nil)
+	_ tea.Model = (*SubnetPoolDetailModel)(nil)
+)
@@ -1,13 +1,17 @@
 package network
 
 import (
+	"context"
 	"fmt"
 	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/table"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"ostui/internal/client"
+	"ostui/internal/i18n"
 	"ostui/internal/ui/common"
+	"ostui/internal/ui/policy"
+	"ostui/internal/ui/search"
 	"ostui/internal/ui/uiconst"
 )
 
@@ -20,11 +24,18 @@ type SubnetsModel struct {
 	width   int
 	height  int
 	filter  textinput.Model
+	// pools are offered by the new subnet form.
+	pools []client.SubnetPool
+
+	form      *common.FormModel
+	status    string
+	statusErr bool
 }
 
 type subnetsDataLoadedMsg struct {
-	tbl table.Model
-	err error
+	tbl   table.Model
+	pools []client.SubnetPool
+	err   error
 }
 
 // NewSubnetsModel creates a new SubnetsModel.
@@ -43,20 +54,32 @@ func (m SubnetsModel) Init() tea.Cmd {
 		if err != nil {
 			return subnetsDataLoadedMsg{err: err}
 		}
-		cols := []table.Column{{Title: "ID", Width: uiconst.ColWidthUUID}, {Title: "Name", Width: uiconst.ColWidthName}, {Title: "CIDR", Width: uiconst.ColWidthCIDR}, {Title: "IPVer", Width: uiconst.ColWidthIPVersion}}
+		search.IndexSubnets(subList)
+		cols := []table.Column{{Title: "ID", Width: uiconst.ColWidthUUID}, {Title: i18n.T("Name"), Width: uiconst.ColWidthName}, {Title: "CIDR", Width: uiconst.ColWidthCIDR}, {Title: i18n.T("IPVer"), Width: uiconst.ColWidthIPVersion}}
 		rows := []table.Row{}
 		for _, s := range subList {
 			rows = append(rows, table.Row{s.ID, s.Name, s.CIDR, fmt.Sprintf("%d", s.IPVersion)})
 		}
+		rows = common.TerraformRows(rows)
 		t := table.New(
-			table.WithColumns(cols),
+			table.WithColumns(common.TerraformColumns(cols)),
 			table.WithRows(rows),
 			table.WithFocused(true),
 			table.WithHeight(m.height-uiconst.TableHeightOffset),
 		)
 		t.SetStyles(table.DefaultStyles())
-		return subnetsDataLoadedMsg{tbl: t}
+		// Subnet pools are optional: without them subnets need a CIDR.
+		pools, _ := m.client.ListSubnetPools(context.Background())
+		return subnetsDataLoadedMsg{tbl: t, pools: pools}
+	}
+}
+
+// Reload re-fetches the subnets, keeping the selected row.
+func (m SubnetsModel) Reload() tea.Cmd {
+	if m.loading {
+		return nil
 	}
+	return m.Init()
 }
 
 // Update handles messages.
@@ -68,22 +91,50 @@ func (m SubnetsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.err = msg.err
 			return m, nil
 		}
-		m.table = msg.tbl
+		m.table = common.Reloaded(m.table, msg.tbl)
+		m.pools = msg.pools
 		m.updateTableColumns()
-		m.table.SetHeight(m.height - uiconst.TableHeightOffset)
+		m.table.SetHeight(m.height - uiconst.TableHeightOffset - 2)
 		return m, nil
+	case changeDoneMsg:
+		m.status, m.statusErr = msg.status, msg.err != nil
+		if msg.err != nil {
+			policy.Record(msg.action, msg.err)
+			m.status = msg.err.Error()
+			return m, nil
+		}
+		m.loading = true
+		return m, tea.Batch(m.spinner.Tick, m.Init())
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
 		if m.table.Columns() != nil {
-			m.table.SetHeight(m.height - uiconst.TableHeightOffset)
+			m.table.SetHeight(m.height - uiconst.TableHeightOffset - 2)
 			m.updateTableColumns()
 		}
 		return m, nil
 	case tea.KeyMsg:
+		if m.form != nil {
+			return m.updateForm(msg)
+		}
 		if m.loading || m.err != nil {
 			return m, nil
 		}
+		if msg.String() == "n" {
+			if err := policy.Check(policy.Member, "creating a subnet"); err != nil {
+				m.status, m.statusErr = err.Error(), true
+				return m, nil
+			}
+			f := common.NewForm([]string{i18n.T("Network ID"), i18n.T("Name"), i18n.T("CIDR (empty to allocate from a pool)"), i18n.T("Subnet pool (name or ID)"), i18n.T("Prefix length (empty for the pool default)")})
+			for _, p := range m.pools {
+				if p.IsDefault {
+					f.SetValue(3, p.Name)
+					break
+				}
+			}
+			m.form = &f
+			return m, f.Init()
+		}
 		var cmd tea.Cmd
 		m.table, cmd = m.table.Update(msg)
 		return m, cmd
@@ -97,17 +148,51 @@ func (m SubnetsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// updateForm handles keys for the new subnet form.
+func (m SubnetsModel) updateForm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	fm, cmd := m.form.Update(msg)
+	f := fm.(common.FormModel)
+	m.form = &f
+	switch {
+	case f.Cancelled():
+		m.form = nil
+	case f.Submitted():
+		opts, err := subnetFromForm(f.Values(), m.pools)
+		if err != nil {
+			m.form.SetError(err)
+			return m, nil
+		}
+		m.form = nil
+		nc := m.client
+		return m, func() tea.Msg {
+			created, err := nc.CreateSubnet(context.Background(), opts)
+			if err != nil {
+				return changeDoneMsg{action: "creating a subnet", err: err}
+			}
+			return changeDoneMsg{status: i18n.T("Created subnet %s (%s)", created.ID, created.CIDR)}
+		}
+	}
+	return m, cmd
+}
+
+// CapturingInput reports whether the new subnet form is open.
+func (m SubnetsModel) CapturingInput() bool { return m.form != nil }
+
 // View renders the subnets view.
 func (m SubnetsModel) View() string {
+	if m.form != nil {
+		return "New subnet – give a CIDR, or a subnet pool to allocate the next free prefix from\n\n" + m.form.View() +
+			"\n" + i18n.T("[tab] next field  [enter] next/create  [esc] cancel")
+	}
 	if m.loading {
 		return m.spinner.View()
 	}
 	if m.err != nil {
-		cols := []table.Column{{Title: "Error", Width: uiconst.ColWidthError}}
+		cols := []table.Column{{Title: i18n.T("Error"), Width: uiconst.ColWidthError}}
 		rows := []table.Row{{"Failed to list subnets: " + m.err.Error()}}
 		return common.NewTable(cols, rows).View()
 	}
-	return m.table.View()
+	return m.table.View() + changeStatusLine(m.status, m.statusErr, "", "") + "\n" + i18n.T("[enter] detail") + "  " + policy.Key(policy.Member, i18n.T("[n] new subnet"), "creating a subnet")
 }
 
 // Ensure SubnetsModel implements tea.Model.
@@ -119,11 +204,11 @@ func (m *SubnetsModel) updateTableColumns() {
 	idW := uiconst.ColWidthUUID
 	cidrW := uiconst.ColWidthCIDR
 	ipverW := uiconst.ColWidthIPVersion
-	nameW := m.width - idW - cidrW - ipverW - 6
+	nameW := m.width - idW - cidrW - ipverW - 6 - common.TerraformWidth()
 	if nameW < 10 {
 		nameW = 10
 	}
-	m.table.SetColumns([]table.Column{{Title: "ID", Width: idW}, {Title: "Name", Width: nameW}, {Title: "CIDR", Width: cidrW}, {Title: "IPVer", Width: ipverW}})
+	m.table.SetColumns(common.TerraformColumns([]table.Column{{Title: "ID", Width: idW}, {Title: i18n.T("Name"), Width: nameW}, {Title: "CIDR", Width: cidrW}, {Title: i18n.T("IPVer"), Width: ipverW}}))
 }
 
 var _ tea.Model = (*SubnetsModel)(nil)
@@ -0,0 +1,540 @@
+package network
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"ostui/internal/client"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/common"
+	"ostui/internal/ui/policy"
+	"ostui/internal/ui/theme"
+	"ostui/internal/ui/uiconst"
+)
+
+// portLabels maps port IDs to "name (fixed IP)" for tap service views.
+func portLabels(ctx context.Context, nc client.NetworkClient) map[string]string {
+	ports, err := nc.ListPorts(ctx)
+	if err != nil {
+		return nil
+	}
+	out := make(map[string]string, len(ports))
+	for _, p := range ports {
+		label := p.Name
+		if label == "" {
+			label = p.ID
+		}
+		if len(p.FixedIPs) > 0 {
+			label += " (" + p.FixedIPs[0].IPAddress + ")"
+		}
+		out[p.ID] = label
+	}
+	return out
+}
+
+// portLabel returns the label of a port, or its ID when unknown.
+func portLabel(labels map[string]string, id string) string {
+	if l, ok := labels[id]; ok {
+		return l
+	}
+	return id
+}
+
+// checkPort validates a port ID typed in a tap form against the known ports.
+func checkPort(labels map[string]string, id, field string) error {
+	if id == "" {
+		return fmt.Errorf("%s is required", field)
+	}
+	if labels != nil {
+		if _, ok := labels[id]; !ok {
+			return fmt.Errorf("%s %s does not exist", field, id)
+		}
+	}
+	return nil
+}
+
+// changeStatusLine renders the outcome of the last change, or the delete
+// prompt while one is pending.
+func changeStatusLine(status string, statusErr bool, pendingDelete, kind string) string {
+	switch {
+	case pendingDelete != "":
+		return "\n" + i18n.T("Delete %s %s? [y/N]", kind, pendingDelete)
+	case statusErr:
+		return "\n" + lipgloss.NewStyle().Foreground(theme.Error).Render(status)
+	case status != "":
+		return "\n" + lipgloss.NewStyle().Foreground(theme.OK).Render(status)
+	}
+	return ""
+}
+
+// changeDoneMsg reports the outcome of a create or delete.
+type changeDoneMsg struct {
+	status string
+	// action names a failed change as checked with policy.Check.
+	action string
+	err    error
+}
+
+// TapServicesModel lists tap-as-a-service mirror destinations.
+type TapServicesModel struct {
+	table    table.Model
+	loading  bool
+	err      error
+	spinner  spinner.Model
+	client   client.NetworkClient
+	services []client.TapService
+	ports    map[string]string
+
+	form          *common.FormModel
+	pendingDelete string
+	status        string
+	statusErr     bool
+
+	width  int
+	height int
+}
+
+// NewTapServicesModel creates the tap service list.
+func NewTapServicesModel(nc client.NetworkClient) TapServicesModel {
+	s := spinner.New()
+	s.Spinner = spinner.Dot
+	return TapServicesModel{client: nc, loading: true, spinner: s, width: 120, height: 30}
+}
+
+type tapServicesLoadedMsg struct {
+	services []client.TapService
+	flows    map[string]int
+	ports    map[string]string
+	err      error
+}
+
+// Init loads the tap services, their flow counts and the port names.
+func (m TapServicesModel) Init() tea.Cmd {
+	return tea.Batch(m.spinner.Tick, m.loadCmd())
+}
+
+// Reload re-fetches the tap services, keeping the selected row.
+func (m TapServicesModel) Reload() tea.Cmd {
+	if m.loading {
+		return nil
+	}
+	return m.loadCmd()
+}
+
+func (m TapServicesModel) loadCmd() tea.Cmd {
+	nc := m.client
+	return func() tea.Msg {
+		ctx := context.Background()
+		services, err := nc.ListTapServices(ctx)
+		if err != nil {
+			return tapServicesLoadedMsg{err: fmt.Errorf("%w (is the tap-as-a-service extension enabled?)", err)}
+		}
+		flows := map[string]int{}
+		if fl, err := nc.ListTapFlows(ctx, ""); err == nil {
+			for _, f := range fl {
+				flows[f.TapServiceID]++
+			}
+		}
+		return tapServicesLoadedMsg{services: services, flows: flows, ports: portLabels(ctx, nc)}
+	}
+}
+
+// Update handles messages for the model.
+func (m TapServicesModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tapServicesLoadedMsg:
+		m.loading = false
+		m.err = msg.err
+		if msg.err != nil {
+			return m, nil
+		}
+		m.services = msg.services
+		m.ports = msg.ports
+		rows := make([]table.Row, 0, len(msg.services))
+		for _, ts := range msg.services {
+			rows = append(rows, table.Row{ts.ID, ts.Name, portLabel(m.ports, ts.PortID), fmt.Sprintf("%d", msg.flows[ts.ID]), ts.Status})
+		}
+		prev := m.table
+		m.table = table.New(table.WithFocused(true))
+		m.table.SetStyles(table.DefaultStyles())
+		m.updateTableColumns()
+		m.table.SetRows(rows)
+		m.table = common.Reloaded(prev, m.table)
+		return m, nil
+	case changeDoneMsg:
+		m.status, m.statusErr = msg.status, msg.err != nil
+		if msg.err != nil {
+			policy.Record(msg.action, msg.err)
+			m.status = msg.err.Error()
+			return m, nil
+		}
+		return m, m.loadCmd()
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		if m.table.Columns() != nil {
+			m.updateTableColumns()
+		}
+		return m, nil
+	case tea.KeyMsg:
+		if m.form != nil {
+			return m.updateForm(msg)
+		}
+		if m.pendingDelete != "" {
+			id := m.pendingDelete
+			m.pendingDelete = ""
+			if msg.String() != "y" {
+				return m, nil
+			}
+			nc := m.client
+			return m, func() tea.Msg {
+				err := nc.DeleteTapService(context.Background(), id)
+				return changeDoneMsg{status: i18n.T("Deleted tap service %s", id), err: err}
+			}
+		}
+		if m.loading || m.err != nil {
+			return m, nil
+		}
+		switch msg.String() {
+		case "n":
+			if err := policy.Check(policy.Member, "creating a tap service"); err != nil {
+				m.status, m.statusErr = err.Error(), true
+				return m, nil
+			}
+			f := common.NewForm([]string{i18n.T("Name"), i18n.T("Destination port ID"), i18n.T("Description")})
+			m.form = &f
+			return m, f.Init()
+		case "x":
+			if row := m.table.SelectedRow(); len(row) > 0 {
+				m.pendingDelete = row[0]
+			}
+			return m, nil
+		case "r":
+			m.loading = true
+			return m, tea.Batch(m.spinner.Tick, m.loadCmd())
+		}
+		var cmd tea.Cmd
+		m.table, cmd = m.table.Update(msg)
+		return m, cmd
+	default:
+		if m.loading {
+			var cmd tea.Cmd
+			m.spinner, cmd = m.spinner.Update(msg)
+			return m, cmd
+		}
+	}
+	return m, nil
+}
+
+// updateForm handles keys for the new tap service form.
+func (m TapServicesModel) updateForm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	fm, cmd := m.form.Update(msg)
+	f := fm.(common.FormModel)
+	m.form = &f
+	switch {
+	case f.Cancelled():
+		m.form = nil
+	case f.Submitted():
+		v := f.Values()
+		ts := client.TapService{Name: v[0], PortID: v[1], Description: v[2]}
+		if err := checkPort(m.ports, ts.PortID, "destination port"); err != nil {
+			m.form.SetError(err)
+			return m, nil
+		}
+		m.form = nil
+		nc := m.client
+		return m, func() tea.Msg {
+			created, err := nc.CreateTapService(context.Background(), ts)
+			if err != nil {
+				return changeDoneMsg{action: "creating a tap service", err: err}
+			}
+			return changeDoneMsg{status: i18n.T("Created tap service %s", created.ID)}
+		}
+	}
+	return m, cmd
+}
+
+// CapturingInput reports whether the form or the delete prompt is open.
+func (m TapServicesModel) CapturingInput() bool { return m.form != nil || m.pendingDelete != "" }
+
+// Selected returns the tap service under the cursor.
+func (m TapServicesModel) Selected() (client.TapService, bool) {
+	row := m.table.SelectedRow()
+	if len(row) == 0 {
+		return client.TapService{}, false
+	}
+	for _, ts := range m.services {
+		if ts.ID == row[0] {
+			return ts, true
+		}
+	}
+	return client.TapService{}, false
+}
+
+// ConfirmingDestructive reports whether a tap service delete waits for y.
+func (m TapServicesModel) ConfirmingDestructive() bool { return m.pendingDelete != "" }
+
+// View renders the list, the form or the delete prompt.
+func (m TapServicesModel) View() string {
+	if m.form != nil {
+		return "New tap service – mirrored traffic is delivered to the destination port\n\n" + m.form.View() +
+			"\n" + i18n.T("[tab] next field  [enter] next/create  [esc] cancel")
+	}
+	if m.loading {
+		return m.spinner.View()
+	}
+	if m.err != nil {
+		return i18n.T("Error: %s", m.err)
+	}
+	out := m.table.View() + changeStatusLine(m.status, m.statusErr, m.pendingDelete, "tap service")
+	return out + "\n" + i18n.T("[enter] tap flows") + "  " + policy.Key(policy.Member, i18n.T("[n] new tap service"), "creating a tap service") + "  " + i18n.T("[x] delete  [r] refresh")
+}
+
+func (m *TapServicesModel) updateTableColumns() {
+	idW := uiconst.ColWidthUUID
+	flowsW := 6
+	statusW := uiconst.ColWidthStatus
+	rest := m.width - idW - flowsW - statusW - uiconst.TableHeightOffset
+	if rest < 30 {
+		rest = 30
+	}
+	m.table.SetColumns([]table.Column{{Title: "ID", Width: idW}, {Title: i18n.T("Name"), Width: rest / 2}, {Title: i18n.T("Destination port"), Width: rest - rest/2}, {Title: i18n.T("Flows"), Width: flowsW}, {Title: i18n.T("Status"), Width: statusW}})
+	m.table.SetHeight(m.height - uiconst.TableHeightOffset - 2)
+}
+
+// Table returns the underlying table.
+func (m TapServicesModel) Table() table.Model { return m.table }
+
+// TapFlowsModel lists and manages the tap flows of one tap service.
+type TapFlowsModel struct {
+	table   table.Model
+	loading bool
+	err     error
+	spinner spinner.Model
+	client  client.NetworkClient
+	service client.TapService
+	ports   map[string]string
+
+	form          *common.FormModel
+	pendingDelete string
+	status        string
+	statusErr     bool
+
+	width  int
+	height int
+}
+
+// NewTapFlowsModel creates the flow list of a tap service.
+func NewTapFlowsModel(nc client.NetworkClient, ts client.TapService) TapFlowsModel {
+	s := spinner.New()
+	s.Spinner = spinner.Dot
+	return TapFlowsModel{client: nc, service: ts, loading: true, spinner: s, width: 120, height: 30}
+}
+
+type tapFlowsLoadedMsg struct {
+	flows []client.TapFlow
+	ports map[string]string
+	err   error
+}
+
+// Init loads the tap flows.
+func (m TapFlowsModel) Init() tea.Cmd {
+	return tea.Batch(m.spinner.Tick, m.loadCmd())
+}
+
+// Reload re-fetches the tap flows, keeping the selected row.
+func (m TapFlowsModel) Reload() tea.Cmd {
+	if m.loading {
+		return nil
+	}
+	return m.loadCmd()
+}
+
+func (m TapFlowsModel) loadCmd() tea.Cmd {
+	nc, id := m.client, m.service.ID
+	return func() tea.Msg {
+		ctx := context.Background()
+		flows, err := nc.ListTapFlows(ctx, id)
+		if err != nil {
+			return tapFlowsLoadedMsg{err: err}
+		}
+		return tapFlowsLoadedMsg{flows: flows, ports: portLabels(ctx, nc)}
+	}
+}
+
+// ResourceID returns the tap service ID.
+func (m TapFlowsModel) ResourceID() string { return m.service.ID }
+
+// Update handles messages for the model.
+func (m TapFlowsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tapFlowsLoadedMsg:
+		m.loading = false
+		m.err = msg.err
+		if msg.err != nil {
+			return m, nil
+		}
+		m.ports = msg.ports
+		rows := make([]table.Row, 0, len(msg.flows))
+		for _, f := range msg.flows {
+			rows = append(rows, table.Row{f.ID, f.Name, portLabel(m.ports, f.SourcePort), f.Direction, f.VLANFilter, f.Status})
+		}
+		prev := m.table
+		m.table = table.New(table.WithFocused(true))
+		m.table.SetStyles(table.DefaultStyles())
+		m.updateTableColumns()
+		m.table.SetRows(rows)
+		m.table = common.Reloaded(prev, m.table)
+		return m, nil
+	case changeDoneMsg:
+		m.status, m.statusErr = msg.status, msg.err != nil
+		if msg.err != nil {
+			policy.Record(msg.action, msg.err)
+			m.status = msg.err.Error()
+			return m, nil
+		}
+		return m, m.loadCmd()
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		if m.table.Columns() != nil {
+			m.updateTableColumns()
+		}
+		return m, nil
+	case tea.KeyMsg:
+		if m.form != nil {
+			return m.updateForm(msg)
+		}
+		if m.pendingDelete != "" {
+			id := m.pendingDelete
+			m.pendingDelete = ""
+			if msg.String() != "y" {
+				return m, nil
+			}
+			nc := m.client
+			return m, func() tea.Msg {
+				err := nc.DeleteTapFlow(context.Background(), id)
+				return changeDoneMsg{status: i18n.T("Deleted tap flow %s", id), err: err}
+			}
+		}
+		if m.loading || m.err != nil {
+			return m, nil
+		}
+		switch msg.String() {
+		case "n":
+			if err := policy.Check(policy.Member, "creating a tap flow"); err != nil {
+				m.status, m.statusErr = err.Error(), true
+				return m, nil
+			}
+			f := common.NewForm([]string{i18n.T("Name"), i18n.T("Source port ID"), i18n.T("Direction (BOTH, IN, OUT)"), i18n.T("VLAN filter (e.g. 10,20-25)")})
+			f.SetValue(2, "BOTH")
+			m.form = &f
+			return m, f.Init()
+		case "x":
+			if row := m.table.SelectedRow(); len(row) > 0 {
+				m.pendingDelete = row[0]
+			}
+			return m, nil
+		case "r":
+			m.loading = true
+			return m, tea.Batch(m.spinner.Tick, m.loadCmd())
+		}
+		var cmd tea.Cmd
+		m.table, cmd = m.table.Update(msg)
+		return m, cmd
+	default:
+		if m.loading {
+			var cmd tea.Cmd
+			m.spinner, cmd = m.spinner.Update(msg)
+			return m, cmd
+		}
+	}
+	return m, nil
+}
+
+// tapFlowFromForm validates the new tap flow form values.
+func tapFlowFromForm(v []string, serviceID string, ports map[string]string) (client.TapFlow, error) {
+	tf := client.TapFlow{Name: v[0], SourcePort: v[1], Direction: strings.ToUpper(v[2]), VLANFilter: v[3], TapServiceID: serviceID}
+	if err := checkPort(ports, tf.SourcePort, "source port"); err != nil {
+		return tf, err
+	}
+	for _, d := range client.TapFlowDirections {
+		if tf.Direction == d {
+			return tf, nil
+		}
+	}
+	return tf, errors.New("direction must be BOTH, IN or OUT")
+}
+
+// updateForm handles keys for the new tap flow form.
+func (m TapFlowsModel) updateForm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	fm, cmd := m.form.Update(msg)
+	f := fm.(common.FormModel)
+	m.form = &f
+	switch {
+	case f.Cancelled():
+		m.form = nil
+	case f.Submitted():
+		tf, err := tapFlowFromForm(f.Values(), m.service.ID, m.ports)
+		if err != nil {
+			m.form.SetError(err)
+			return m, nil
+		}
+		m.form = nil
+		nc := m.client
+		return m, func() tea.Msg {
+			created, err := nc.CreateTapFlow(context.Background(), tf)
+			if err != nil {
+				return changeDoneMsg{action: "creating a tap flow", err: err}
+			}
+			return changeDoneMsg{status: i18n.T("Created tap flow %s", created.ID)}
+		}
+	}
+	return m, cmd
+}
+
+// CapturingInput reports whether the form or the delete prompt is open.
+func (m TapFlowsModel) CapturingInput() bool { return m.form != nil || m.pendingDelete != "" }
+
+// View renders the flows, the form or the delete prompt.
+func (m TapFlowsModel) View() string {
+	title := lipgloss.NewStyle().Bold(true).Foreground(theme.Accent).Render
+	head := title("Tap service "+m.service.Name) + fmt.Sprintf("  → %s\n", portLabel(m.ports, m.service.PortID))
+	if m.form != nil {
+		return head + "\nNew tap flow – mirrors the source port's traffic into this tap service\n\n" + m.form.View() +
+			"\n" + i18n.T("[tab] next field  [enter] next/create  [esc] cancel")
+	}
+	if m.loading {
+		return head + m.spinner.View()
+	}
+	if m.err != nil {
+		return head + i18n.T("Error: %s", m.err)
+	}
+	out := head + m.table.View() + changeStatusLine(m.status, m.statusErr, m.pendingDelete, "tap flow")
+	return out + "\n" + policy.Key(policy.Member, i18n.T("[n] new tap flow"), "creating a tap flow") + "  " + i18n.T("[x] delete  [r] refresh  [esc] back")
+}
+
+// ConfirmingDestructive reports whether a tap flow delete waits for y.
+func (m TapFlowsModel) ConfirmingDestructive() bool { return m.pendingDelete != "" }
+
+func (m *TapFlowsModel) updateTableColumns() {
+	idW := uiconst.ColWidthUUID
+	dirW := 9
+	vlanW := 14
+	statusW := uiconst.ColWidthStatus
+	rest := m.width - idW - dirW - vlanW - statusW - uiconst.TableHeightOffset
+	if rest < 30 {
+		rest = 30
+	}
+	m.table.SetColumns([]table.Column{{Title: "ID", Width: idW}, {Title: i18n.T("Name"), Width: rest / 2}, {Title: i18n.T("Source port"), Width: rest - rest/2}, {Title: i18n.T("Direction"), Width: dirW}, {Title: i18n.T("VLAN filter"), Width: vlanW}, {Title: i18n.T("Status"), Width: statusW}})
+	m.table.SetHeight(m.height - uiconst.TableHeightOffset - 3)
+}
+
+var (
+	_ tea.Model = (*TapServicesModel)(nil)
+	_ tea.Model = (*TapFlowsModel)(nil)
+)
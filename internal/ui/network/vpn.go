@@ -0,0 +1,476 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"ostui/internal/client"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/common"
+	"ostui/internal/ui/theme"
+	"ostui/internal/ui/uiconst"
+)
+
+// VPN view modes, cycled with tab.
+const (
+	vpnModeConnections = "connections"
+	vpnModeServices    = "services"
+	vpnModeIKE         = "ike"
+	vpnModeIPSec       = "ipsec"
+)
+
+var vpnModes = []string{vpnModeConnections, vpnModeServices, vpnModeIKE, vpnModeIPSec}
+
+var vpnModeTitles = map[string]string{
+	vpnModeConnections: "Site connections",
+	vpnModeServices:    "VPN services",
+	vpnModeIKE:         "IKE policies",
+	vpnModeIPSec:       "IPsec policies",
+}
+
+// vpnData holds everything the VPN views show; policies and endpoint groups
+// are used to resolve the IDs referenced by connections.
+type vpnData struct {
+	services    []client.VPNService
+	ikePolicies []client.IKEPolicy
+	ipsec       []client.IPSecPolicy
+	conns       []client.VPNConnection
+	groups      []client.VPNEndpointGroup
+}
+
+// loadVPNData lists the VPNaaS resources. Endpoint groups are optional, as
+// older deployments only support peer_cidrs.
+func loadVPNData(ctx context.Context, nc client.NetworkClient) (vpnData, error) {
+	var d vpnData
+	var err error
+	if d.conns, err = nc.ListVPNConnections(ctx); err != nil {
+		return d, fmt.Errorf("%w (is the vpnaas extension enabled?)", err)
+	}
+	if d.services, err = nc.ListVPNServices(ctx); err != nil {
+		return d, err
+	}
+	if d.ikePolicies, err = nc.ListIKEPolicies(ctx); err != nil {
+		return d, err
+	}
+	if d.ipsec, err = nc.ListIPSecPolicies(ctx); err != nil {
+		return d, err
+	}
+	d.groups, _ = nc.ListVPNEndpointGroups(ctx)
+	return d, nil
+}
+
+func (d vpnData) serviceName(id string) string {
+	for _, s := range d.services {
+		if s.ID == id {
+			return nameOrID(s.Name, s.ID)
+		}
+	}
+	return id
+}
+
+func (d vpnData) group(id string) (client.VPNEndpointGroup, bool) {
+	for _, g := range d.groups {
+		if g.ID == id {
+			return g, true
+		}
+	}
+	return client.VPNEndpointGroup{}, false
+}
+
+// peerCIDRs returns the peer CIDRs of a connection, from peer_cidrs or from
+// its peer endpoint group.
+func (d vpnData) peerCIDRs(c client.VPNConnection) []string {
+	if len(c.PeerCIDRs) > 0 || c.PeerEPGroupID == "" {
+		return c.PeerCIDRs
+	}
+	g, _ := d.group(c.PeerEPGroupID)
+	return g.Endpoints
+}
+
+func nameOrID(name, id string) string {
+	if name != "" {
+		return name
+	}
+	return id
+}
+
+// lifetime renders an IKE or IPsec policy lifetime, e.g. "3600 seconds".
+func lifetime(value int, units string) string {
+	if value == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d %s", value, units)
+}
+
+// VPNModel lists VPN site connections, VPN services, and IKE and IPsec
+// policies; tab switches between them.
+type VPNModel struct {
+	table   table.Model
+	loading bool
+	err     error
+	spinner spinner.Model
+	client  client.NetworkClient
+	data    vpnData
+	mode    string
+
+	width  int
+	height int
+}
+
+// NewVPNModel creates the VPN view, showing site connections first.
+func NewVPNModel(nc client.NetworkClient) VPNModel {
+	s := spinner.New()
+	s.Spinner = spinner.Dot
+	return VPNModel{client: nc, loading: true, spinner: s, mode: vpnModeConnections, width: 120, height: 30}
+}
+
+type vpnLoadedMsg struct {
+	data vpnData
+	err  error
+}
+
+// Init loads the VPNaaS resources.
+func (m VPNModel) Init() tea.Cmd {
+	return tea.Batch(m.spinner.Tick, m.loadCmd())
+}
+
+// Reload re-fetches the VPN services and connections, keeping the mode and
+// the selected row.
+func (m VPNModel) Reload() tea.Cmd {
+	if m.loading {
+		return nil
+	}
+	return m.loadCmd()
+}
+
+func (m VPNModel) loadCmd() tea.Cmd {
+	nc := m.client
+	return func() tea.Msg {
+		d, err := loadVPNData(context.Background(), nc)
+		return vpnLoadedMsg{data: d, err: err}
+	}
+}
+
+// Update handles messages for the model.
+func (m VPNModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case vpnLoadedMsg:
+		m.loading = false
+		m.err = msg.err
+		m.data = msg.data
+		if msg.err == nil {
+			prev := m.table
+			m.refreshTable()
+			m.table = common.Reloaded(prev, m.table)
+		}
+		return m, nil
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		if !m.loading && m.err == nil {
+			m.refreshTable()
+		}
+		return m, nil
+	case tea.KeyMsg:
+		if m.loading || m.err != nil {
+			return m, nil
+		}
+		switch msg.String() {
+		case "tab", "shift+tab":
+			step := 1
+			if msg.String() == "shift+tab" {
+				step = len(vpnModes) - 1
+			}
+			for i, mode := range vpnModes {
+				if mode == m.mode {
+					m.mode = vpnModes[(i+step)%len(vpnModes)]
+					break
+				}
+			}
+			m.refreshTable()
+			return m, nil
+		case "r":
+			m.loading = true
+			return m, tea.Batch(m.spinner.Tick, m.loadCmd())
+		}
+		var cmd tea.Cmd
+		m.table, cmd = m.table.Update(msg)
+		return m, cmd
+	default:
+		if m.loading {
+			var cmd tea.Cmd
+			m.spinner, cmd = m.spinner.Update(msg)
+			return m, cmd
+		}
+	}
+	return m, nil
+}
+
+// refreshTable rebuilds the table for the current mode.
+func (m *VPNModel) refreshTable() {
+	idW, statusW := uiconst.ColWidthUUID, uiconst.ColWidthStatus
+	rest := m.width - idW - statusW - uiconst.TableHeightOffset
+	if rest < 60 {
+		rest = 60
+	}
+	var cols []table.Column
+	var rows []table.Row
+	switch m.mode {
+	case vpnModeConnections:
+		w := rest / 4
+		cols = []table.Column{{Title: "ID", Width: idW}, {Title: i18n.T("Name"), Width: w}, {Title: i18n.T("VPN service"), Width: w}, {Title: i18n.T("Peer"), Width: w / 2}, {Title: i18n.T("Peer CIDRs"), Width: rest - 3*w + w/2}, {Title: i18n.T("Status"), Width: statusW}}
+		for _, c := range m.data.conns {
+			rows = append(rows, table.Row{c.ID, c.Name, m.data.serviceName(c.VPNServiceID), c.PeerAddress, strings.Join(m.data.peerCIDRs(c), ", "), c.Status})
+		}
+	case vpnModeServices:
+		w := rest / 3
+		cols = []table.Column{{Title: "ID", Width: idW}, {Title: i18n.T("Name"), Width: w}, {Title: i18n.T("Router"), Width: idW}, {Title: i18n.T("External IP"), Width: rest - w - idW}, {Title: i18n.T("Status"), Width: statusW}}
+		for _, s := range m.data.services {
+			ip := s.ExternalV4IP
+			if ip == "" {
+				ip = s.ExternalV6IP
+			}
+			rows = append(rows, table.Row{s.ID, s.Name, s.RouterID, ip, s.Status})
+		}
+	case vpnModeIKE:
+		w := rest / 6
+		cols = []table.Column{{Title: "ID", Width: idW}, {Title: i18n.T("Name"), Width: rest - 5*w}, {Title: i18n.T("Version"), Width: w}, {Title: i18n.T("Encryption"), Width: w}, {Title: i18n.T("Auth"), Width: w}, {Title: "PFS", Width: w}, {Title: i18n.T("Lifetime"), Width: w + statusW}}
+		for _, p := range m.data.ikePolicies {
+			rows = append(rows, table.Row{p.ID, p.Name, p.IKEVersion, p.EncryptionAlgorithm, p.AuthAlgorithm, p.PFS, lifetime(p.Lifetime.Value, p.Lifetime.Units)})
+		}
+	case vpnModeIPSec:
+		w := rest / 6
+		cols = []table.Column{{Title: "ID", Width: idW}, {Title: i18n.T("Name"), Width: rest - 5*w}, {Title: i18n.T("Transform"), Width: w}, {Title: i18n.T("Encryption"), Width: w}, {Title: i18n.T("Auth"), Width: w}, {Title: "PFS", Width: w}, {Title: i18n.T("Lifetime"), Width: w + statusW}}
+		for _, p := range m.data.ipsec {
+			rows = append(rows, table.Row{p.ID, p.Name, p.TransformProtocol, p.EncryptionAlgorithm, p.AuthAlgorithm, p.PFS, lifetime(p.Lifetime.Value, p.Lifetime.Units)})
+		}
+	}
+	m.table = table.New(table.WithColumns(cols), table.WithFocused(true))
+	m.table.SetStyles(table.DefaultStyles())
+	m.table.SetRows(rows)
+	m.table.SetHeight(m.height - uiconst.TableHeightOffset - 3)
+}
+
+// SelectedConnection returns the site connection under the cursor, when
+// connections are shown.
+func (m VPNModel) SelectedConnection() (client.VPNConnection, bool) {
+	row := m.table.SelectedRow()
+	if m.mode != vpnModeConnections || len(row) == 0 {
+		return client.VPNConnection{}, false
+	}
+	for _, c := range m.data.conns {
+		if c.ID == row[0] {
+			return c, true
+		}
+	}
+	return client.VPNConnection{}, false
+}
+
+// healthLine summarizes the site connection states, highlighting the ones
+// that are not ACTIVE.
+func (m VPNModel) healthLine() string {
+	if len(m.data.conns) == 0 {
+		return i18n.T("No site connections")
+	}
+	var down []string
+	for _, c := range m.data.conns {
+		if c.Status != "ACTIVE" {
+			down = append(down, fmt.Sprintf("%s (%s)", nameOrID(c.Name, c.ID), c.Status))
+		}
+	}
+	ok := lipgloss.NewStyle().Foreground(theme.OK).Render
+	bad := lipgloss.NewStyle().Foreground(theme.Error).Render
+	line := ok(i18n.T("%d/%d connections ACTIVE", len(m.data.conns)-len(down), len(m.data.conns)))
+	if len(down) > 0 {
+		line += "  " + bad("not active: "+strings.Join(down, ", "))
+	}
+	return line
+}
+
+// View renders the current table with a tab bar and the connection health.
+func (m VPNModel) View() string {
+	if m.loading {
+		return m.spinner.View()
+	}
+	if m.err != nil {
+		return i18n.T("Error: %s", m.err)
+	}
+	active := lipgloss.NewStyle().Bold(true).Foreground(theme.Accent)
+	dim := lipgloss.NewStyle().Foreground(theme.Muted)
+	var tabs []string
+	for _, mode := range vpnModes {
+		if mode == m.mode {
+			tabs = append(tabs, active.Render("["+i18n.T(vpnModeTitles[mode])+"]"))
+		} else {
+			tabs = append(tabs, dim.Render(" "+i18n.T(vpnModeTitles[mode])+" "))
+		}
+	}
+	help := i18n.T("[tab] switch list  [r] refresh")
+	if m.mode == vpnModeConnections {
+		help = i18n.T("[enter] connection detail") + "  " + help
+	}
+	return strings.Join(tabs, " ") + "\n" + m.healthLine() + "\n" + m.table.View() + "\n" + help
+}
+
+// Table returns the table of the current mode.
+func (m VPNModel) Table() table.Model { return m.table }
+
+// VPNConnectionDetailModel shows one IPsec site connection with its peer
+// CIDRs, local endpoints, policies and dead peer detection settings.
+type VPNConnectionDetailModel struct {
+	table   table.Model
+	loading bool
+	err     error
+	spinner spinner.Model
+	client  client.NetworkClient
+	connID  string
+	status  string
+}
+
+// NewVPNConnectionDetailModel creates the detail view of a site connection.
+func NewVPNConnectionDetailModel(nc client.NetworkClient, connID string) VPNConnectionDetailModel {
+	s := spinner.New()
+	s.Spinner = spinner.Dot
+	return VPNConnectionDetailModel{client: nc, loading: true, spinner: s, connID: connID}
+}
+
+// ResourceID returns the site connection ID.
+func (m VPNConnectionDetailModel) ResourceID() string { return m.connID }
+
+type vpnConnectionLoadedMsg struct {
+	rows   []table.Row
+	status string
+	err    error
+}
+
+// Init loads the connection and the resources it references.
+func (m VPNConnectionDetailModel) Init() tea.Cmd {
+	nc, id := m.client, m.connID
+	return tea.Batch(m.spinner.Tick, func() tea.Msg {
+		ctx := context.Background()
+		c, err := nc.GetVPNConnection(ctx, id)
+		if err != nil {
+			return vpnConnectionLoadedMsg{err: err}
+		}
+		d, _ := loadVPNData(ctx, nc)
+		return vpnConnectionLoadedMsg{rows: vpnConnectionRows(*c, d), status: c.Status}
+	})
+}
+
+// vpnConnectionRows lists the fields of a connection, resolving references
+// through d where possible.
+func vpnConnectionRows(c client.VPNConnection, d vpnData) []table.Row {
+	ike, ipsec := c.IKEPolicyID, c.IPSecPolicyID
+	for _, p := range d.ikePolicies {
+		if p.ID == c.IKEPolicyID {
+			ike = fmt.Sprintf("%s (%s, %s/%s, %s)", nameOrID(p.Name, p.ID), p.IKEVersion, p.EncryptionAlgorithm, p.AuthAlgorithm, p.PFS)
+		}
+	}
+	for _, p := range d.ipsec {
+		if p.ID == c.IPSecPolicyID {
+			ipsec = fmt.Sprintf("%s (%s, %s/%s, %s)", nameOrID(p.Name, p.ID), p.TransformProtocol, p.EncryptionAlgorithm, p.AuthAlgorithm, p.PFS)
+		}
+	}
+	service := d.serviceName(c.VPNServiceID)
+	for _, s := range d.services {
+		if s.ID == c.VPNServiceID && s.ExternalV4IP != "" {
+			service += " (" + s.ExternalV4IP + ")"
+		}
+	}
+	peerCIDRs := strings.Join(d.peerCIDRs(c), ", ")
+	if len(c.PeerCIDRs) == 0 && c.PeerEPGroupID != "" {
+		g, ok := d.group(c.PeerEPGroupID)
+		if !ok {
+			peerCIDRs = "endpoint group " + c.PeerEPGroupID
+		} else {
+			peerCIDRs += "  (endpoint group " + nameOrID(g.Name, g.ID) + ")"
+		}
+	}
+	local := "all subnets of the VPN service router"
+	if c.LocalEPGroupID != "" {
+		local = "endpoint group " + c.LocalEPGroupID
+		if g, ok := d.group(c.LocalEPGroupID); ok {
+			local = fmt.Sprintf("%s: %s (%s)", g.Type, strings.Join(g.Endpoints, ", "), nameOrID(g.Name, g.ID))
+		}
+	}
+	dpd := i18n.T("action %s, interval %ds, timeout %ds", c.DPD.Action, c.DPD.Interval, c.DPD.Timeout)
+	return []table.Row{
+		{"ID", c.ID},
+		{"Name", c.Name},
+		{"Status", c.Status},
+		{"Admin state", map[bool]string{true: "UP", false: "DOWN"}[c.AdminStateUp]},
+		{"VPN service", service},
+		{"Peer address", c.PeerAddress},
+		{"Peer ID", c.PeerID},
+		{"Peer CIDRs", peerCIDRs},
+		{"Local endpoints", local},
+		{"Dead peer detection", dpd},
+		{"IKE policy", ike},
+		{"IPsec policy", ipsec},
+		{"Route mode", c.RouteMode},
+		{"Initiator", c.Initiator},
+		{"Auth mode", c.AuthMode},
+		{"MTU", fmt.Sprintf("%d", c.MTU)},
+		{"Description", c.Description},
+	}
+}
+
+// Update handles messages for the model.
+func (m VPNConnectionDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case vpnConnectionLoadedMsg:
+		m.loading = false
+		m.err = msg.err
+		if msg.err != nil {
+			return m, nil
+		}
+		m.status = msg.status
+		m.table = table.New(table.WithColumns([]table.Column{{Title: i18n.T("Field"), Width: uiconst.ColWidthField}, {Title: i18n.T("Value"), Width: uiconst.ColWidthValue}}), table.WithFocused(true))
+		m.table.SetStyles(table.DefaultStyles())
+		m.table.SetRows(msg.rows)
+		m.table.SetHeight(len(msg.rows) + 1)
+		return m, nil
+	case tea.KeyMsg:
+		if m.loading || m.err != nil {
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.table, cmd = m.table.Update(msg)
+		return m, cmd
+	default:
+		if m.loading {
+			var cmd tea.Cmd
+			m.spinner, cmd = m.spinner.Update(msg)
+			return m, cmd
+		}
+	}
+	return m, nil
+}
+
+// View renders the connection fields.
+func (m VPNConnectionDetailModel) View() string {
+	if m.loading {
+		return m.spinner.View()
+	}
+	if m.err != nil {
+		return i18n.T("Error: %s", m.err)
+	}
+	color := theme.OK
+	if m.status != "ACTIVE" {
+		color = theme.Error
+	}
+	status := lipgloss.NewStyle().Bold(true).Foreground(color).Render("● " + m.status)
+	return status + "\n" + m.table.View() + "\n" + i18n.T("[esc] back")
+}
+
+// Table returns the underlying table model.
+func (m VPNConnectionDetailModel) Table() table.Model { return m.table }
+
+var (
+	_ tea.Model = (*VPNModel)(nil)
+	_ tea.Model = (*VPNConnectionDetailModel)(nil)
+)
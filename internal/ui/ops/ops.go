@@ -0,0 +1,310 @@
+// Package ops runs the mutations of the views as tracked operations: a
+// create, delete or resize is queued, sent, then followed until the cloud
+// reports it finished, so the views never wait on a slow request and a
+// failure stays listed in the jobs view, with its log, until retried.
+package ops
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/gophercloud/gophercloud"
+
+	"ostui/internal/i18n"
+)
+
+// Operation states.
+const (
+	StateQueued  = "queued"
+	StateRunning = "running"
+	StatePolling = "polling"
+	StateDone    = "done"
+	StateFailed  = "failed"
+)
+
+// MaxRunning is how many requests are sent at once; the others wait in
+// the queue. Operations being polled do not count.
+const MaxRunning = 3
+
+// PollInterval is the time between two polls of an operation.
+const PollInterval = 3 * time.Second
+
+// MaxPolls bounds how long an operation is followed: half an hour at the
+// poll interval.
+const MaxPolls = 600
+
+// MaxPollErrors is how many polls in a row may fail, e.g. on a network
+// blip, before the operation is given up. Each failure doubles the wait
+// before the next poll, up to MaxPollBackoff.
+const (
+	MaxPollErrors  = 5
+	MaxPollBackoff = time.Minute
+)
+
+// Poll checks on an operation the cloud carries on with after accepting
+// its request. It reports the status reached, and done once it is final;
+// errors are retried, and fail the operation after MaxPollErrors in a row.
+type Poll func(ctx context.Context) (done bool, status string, err error)
+
+// Gone reports whether err is the 404 of a resource, e.g. once a delete
+// went through.
+func Gone(err error) bool {
+	var missing gophercloud.ErrDefault404
+	return errors.As(err, &missing)
+}
+
+// Work sends the request of an operation. It returns what was done and,
+// when the cloud finishes it asynchronously, the poll that follows it.
+type Work func(ctx context.Context) (result string, poll Poll, err error)
+
+// EnqueueMsg asks the app to queue an operation.
+type EnqueueMsg struct {
+	Title string
+	// Resource is the ID of the resource the operation is on, for the
+	// view showing it; empty for a create.
+	Resource string
+	Work     Work
+}
+
+// Enqueue returns the command queueing an operation.
+func Enqueue(title, resource string, work Work) tea.Cmd {
+	return func() tea.Msg { return EnqueueMsg{Title: title, Resource: resource, Work: work} }
+}
+
+// FinishedMsg reports an operation that is done or failed, so the views
+// showing its resource can reload.
+type FinishedMsg struct {
+	Title    string
+	Resource string
+	Result   string
+	Err      error
+}
+
+// Msg carries the outcome of a request or a poll back to the queue.
+type Msg struct {
+	id      int
+	attempt int
+	polled  bool
+	result  string
+	poll    Poll
+	done    bool
+	status  string
+	err     error
+}
+
+// LogLine is one entry of the log of an operation.
+type LogLine struct {
+	Time time.Time
+	Text string
+}
+
+// Operation is one queued mutation.
+type Operation struct {
+	ID       int
+	Title    string
+	Resource string
+	State    string
+	Attempts int
+	// Status is the last status polled, or the result once done.
+	Status   string
+	Err      error
+	Created  time.Time
+	Finished time.Time
+	Log      []LogLine
+
+	work     Work
+	poll     Poll
+	polls    int
+	pollErrs int
+}
+
+// Queue holds the operations of the session. It is shared by pointer
+// between the app, which feeds it the messages, and the jobs view.
+type Queue struct {
+	ops    []*Operation
+	nextID int
+	// last is the most recent outcome, shown in the footer.
+	last string
+}
+
+// NewQueue creates an empty queue.
+func NewQueue() *Queue { return &Queue{nextID: 1} }
+
+// log appends a line to the log of op.
+func (op *Operation) log(now time.Time, text string) {
+	op.Log = append(op.Log, LogLine{Time: now, Text: text})
+}
+
+// Add queues the operation of msg and returns the command sending the
+// requests that may start.
+func (q *Queue) Add(msg EnqueueMsg, now time.Time) tea.Cmd {
+	op := &Operation{ID: q.nextID, Title: msg.Title, Resource: msg.Resource, State: StateQueued, Created: now, work: msg.Work}
+	q.nextID++
+	op.log(now, i18n.T("queued"))
+	q.ops = append(q.ops, op)
+	return q.dispatch(now)
+}
+
+// dispatch starts queued operations, oldest first, while fewer than
+// MaxRunning requests are out.
+func (q *Queue) dispatch(now time.Time) tea.Cmd {
+	running := 0
+	for _, op := range q.ops {
+		if op.State == StateRunning {
+			running++
+		}
+	}
+	var cmds []tea.Cmd
+	for _, op := range q.ops {
+		if running >= MaxRunning {
+			break
+		}
+		if op.State != StateQueued {
+			continue
+		}
+		running++
+		op.State, op.Attempts, op.polls, op.Err = StateRunning, op.Attempts+1, 0, nil
+		op.log(now, i18n.T("attempt %d: sending the request", op.Attempts))
+		id, attempt, work := op.ID, op.Attempts, op.work
+		cmds = append(cmds, func() tea.Msg {
+			result, poll, err := work(context.Background())
+			return Msg{id: id, attempt: attempt, result: result, poll: poll, err: err}
+		})
+	}
+	return tea.Batch(cmds...)
+}
+
+// get returns an operation by ID.
+func (q *Queue) get(id int) (*Operation, bool) {
+	for _, op := range q.ops {
+		if op.ID == id {
+			return op, true
+		}
+	}
+	return nil, false
+}
+
+// Update records the outcome of a request or a poll and returns what
+// follows: the next poll, the requests that may start now, and the
+// FinishedMsg of an operation that ended.
+func (q *Queue) Update(msg Msg, now time.Time) tea.Cmd {
+	op, ok := q.get(msg.id)
+	if !ok || op.Attempts != msg.attempt || (op.State != StateRunning && op.State != StatePolling) {
+		return nil
+	}
+	if msg.err != nil && msg.polled && op.pollErrs < MaxPollErrors {
+		// The request went through; a failed poll says nothing about it.
+		op.pollErrs++
+		delay := min(PollInterval<<op.pollErrs, MaxPollBackoff)
+		op.log(now, i18n.T("poll failed: %s; polling again in %s", msg.err, delay))
+		return q.wait(op, delay)
+	}
+	if msg.err != nil {
+		return q.finish(op, now, msg.err)
+	}
+	if !msg.polled {
+		op.Status = msg.result
+		if msg.result != "" {
+			op.log(now, msg.result)
+		}
+		if msg.poll == nil {
+			return q.finish(op, now, nil)
+		}
+		op.State, op.poll = StatePolling, msg.poll
+		return tea.Batch(q.wait(op, PollInterval), q.dispatch(now))
+	}
+	op.polls, op.pollErrs = op.polls+1, 0
+	if msg.status != "" && msg.status != op.Status {
+		op.Status = msg.status
+		op.log(now, i18n.T("status %s", msg.status))
+	}
+	switch {
+	case msg.done:
+		return q.finish(op, now, nil)
+	case op.polls >= MaxPolls:
+		return q.finish(op, now, fmt.Errorf("still %s after %s; stopped following it", op.Status, time.Duration(MaxPolls)*PollInterval))
+	}
+	return q.wait(op, PollInterval)
+}
+
+// wait polls op once delay has passed.
+func (q *Queue) wait(op *Operation, delay time.Duration) tea.Cmd {
+	id, attempt, poll := op.ID, op.Attempts, op.poll
+	return tea.Tick(delay, func(time.Time) tea.Msg {
+		done, status, err := poll(context.Background())
+		return Msg{id: id, attempt: attempt, polled: true, done: done, status: status, err: err}
+	})
+}
+
+// finish ends op, failed when err is set, and dispatches the queue.
+func (q *Queue) finish(op *Operation, now time.Time, err error) tea.Cmd {
+	op.Finished, op.Err = now, err
+	if err != nil {
+		op.State = StateFailed
+		op.log(now, i18n.T("failed: %s", err))
+		q.last = i18n.T("%s failed", op.Title)
+	} else {
+		op.State = StateDone
+		op.log(now, i18n.T("done"))
+		q.last = i18n.T("%s done", op.Title)
+	}
+	done := FinishedMsg{Title: op.Title, Resource: op.Resource, Result: op.Status, Err: err}
+	return tea.Batch(q.dispatch(now), func() tea.Msg { return done })
+}
+
+// Retry queues a failed operation again. An operation whose request the
+// cloud accepted, e.g. a create that failed while followed, is not sent
+// again: following its resource resumes. ok is false when it has not
+// failed.
+func (q *Queue) Retry(id int, now time.Time) (cmd tea.Cmd, ok bool) {
+	op, found := q.get(id)
+	if !found || op.State != StateFailed {
+		return nil, false
+	}
+	if op.poll != nil {
+		op.State, op.Finished, op.Err, op.polls, op.pollErrs = StatePolling, time.Time{}, nil, 0, 0
+		op.log(now, i18n.T("retry requested: following it again"))
+		return q.wait(op, 0), true
+	}
+	op.State, op.Finished = StateQueued, time.Time{}
+	op.log(now, i18n.T("retry requested"))
+	return q.dispatch(now), true
+}
+
+// Operations returns copies of the operations, oldest first.
+func (q *Queue) Operations() []Operation {
+	out := make([]Operation, 0, len(q.ops))
+	for _, op := range q.ops {
+		c := *op
+		c.Log = append([]LogLine(nil), op.Log...)
+		out = append(out, c)
+	}
+	return out
+}
+
+// Active returns the number of operations queued, running or polled.
+func (q *Queue) Active() int {
+	n := 0
+	for _, op := range q.ops {
+		if op.State != StateDone && op.State != StateFailed {
+			n++
+		}
+	}
+	return n
+}
+
+// FooterLabel summarises the queue for the status line, or "" when no
+// operation was queued yet.
+func (q *Queue) FooterLabel() string {
+	if len(q.ops) == 0 {
+		return ""
+	}
+	label := fmt.Sprintf("⚙ %d ops", q.Active())
+	if q.last != "" {
+		label += " · " + q.last
+	}
+	return label
+}
@@ -0,0 +1,135 @@
+package ops
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func states(q *Queue) string {
+	var out []string
+	for _, op := range q.Operations() {
+		out = append(out, op.State)
+	}
+	return strings.Join(out, ",")
+}
+
+func TestQueueRunsAtMostMaxRunning(t *testing.T) {
+	q := NewQueue()
+	now := time.Now()
+	work := func(context.Context) (string, Poll, error) { return "ok", nil, nil }
+	for i := 0; i < MaxRunning+1; i++ {
+		q.Add(EnqueueMsg{Title: "create", Work: work}, now)
+	}
+	if got := states(q); got != "running,running,running,queued" {
+		t.Fatalf("expected the last operation to wait, got %s", got)
+	}
+
+	// A finished request lets the waiting one start.
+	q.Update(Msg{id: 1, attempt: 1, result: "ok"}, now)
+	if got := states(q); got != "done,running,running,running" {
+		t.Fatalf("expected the queued operation started, got %s", got)
+	}
+	if q.Active() != 3 || !strings.HasPrefix(q.FooterLabel(), "⚙ 3 ops · create done") {
+		t.Errorf("unexpected footer %q", q.FooterLabel())
+	}
+}
+
+func TestQueueFollowsPollUntilDone(t *testing.T) {
+	q := NewQueue()
+	now := time.Now()
+	poll := func(context.Context) (bool, string, error) { return false, "BUILD", nil }
+	q.Add(EnqueueMsg{Title: "New server: web", Work: func(context.Context) (string, Poll, error) { return "building", poll, nil }}, now)
+
+	if cmd := q.Update(Msg{id: 1, attempt: 1, result: "building", poll: poll}, now); cmd == nil {
+		t.Fatal("expected a poll to follow")
+	}
+	q.Update(Msg{id: 1, attempt: 1, polled: true, status: "BUILD"}, now)
+	if got := states(q); got != StatePolling {
+		t.Fatalf("expected the server followed, got %s", got)
+	}
+	q.Update(Msg{id: 1, attempt: 1, polled: true, done: true, status: "ACTIVE"}, now.Add(time.Minute))
+	op := q.Operations()[0]
+	if op.State != StateDone || op.Status != "ACTIVE" || op.Finished.IsZero() {
+		t.Fatalf("expected the server done, got %+v", op)
+	}
+	var log []string
+	for _, l := range op.Log {
+		log = append(log, l.Text)
+	}
+	if got := strings.Join(log, "|"); got != "queued|attempt 1: sending the request|building|status BUILD|status ACTIVE|done" {
+		t.Errorf("unexpected log %s", got)
+	}
+}
+
+func TestQueueRetriesFailedOperations(t *testing.T) {
+	q := NewQueue()
+	now := time.Now()
+	q.Add(EnqueueMsg{Title: "Delete volume data", Resource: "vol-1", Work: func(context.Context) (string, Poll, error) { return "", nil, nil }}, now)
+
+	if _, ok := q.Retry(1, now); ok {
+		t.Fatal("expected a running operation not to be retried")
+	}
+	cmd := q.Update(Msg{id: 1, attempt: 1, err: errors.New("409 conflict")}, now)
+	if op := q.Operations()[0]; op.State != StateFailed || op.Err == nil {
+		t.Fatalf("expected the operation failed, got %+v", op)
+	}
+	if cmd == nil {
+		t.Fatal("expected the failure reported")
+	}
+
+	if _, ok := q.Retry(1, now); !ok {
+		t.Fatal("expected the failed operation retried")
+	}
+	op := q.Operations()[0]
+	if op.State != StateRunning || op.Attempts != 2 || op.Err != nil {
+		t.Fatalf("expected a second attempt, got %+v", op)
+	}
+	// A late outcome of the first attempt is dropped.
+	q.Update(Msg{id: 1, attempt: 1, err: errors.New("late")}, now)
+	if got := states(q); got != StateRunning {
+		t.Errorf("expected the stale outcome ignored, got %s", got)
+	}
+}
+
+func TestQueueFollowsCreatesThroughPollErrors(t *testing.T) {
+	q := NewQueue()
+	now := time.Now()
+	creates := 0
+	poll := func(context.Context) (bool, string, error) { return true, "ACTIVE", nil }
+	q.Add(EnqueueMsg{Title: "New server: web", Work: func(context.Context) (string, Poll, error) {
+		creates++
+		return "building", poll, nil
+	}}, now)
+	q.Update(Msg{id: 1, attempt: 1, result: "building", poll: poll}, now)
+
+	// A blip while polling is retried with a longer wait.
+	blip := errors.New("connection reset")
+	for i := 0; i < MaxPollErrors; i++ {
+		if cmd := q.Update(Msg{id: 1, attempt: 1, polled: true, err: blip}, now); cmd == nil {
+			t.Fatalf("poll error %d: expected another poll", i+1)
+		}
+		if got := states(q); got != StatePolling {
+			t.Fatalf("poll error %d: expected the server still followed, got %s", i+1, got)
+		}
+	}
+	q.Update(Msg{id: 1, attempt: 1, polled: true, err: blip}, now)
+	if got := states(q); got != StateFailed {
+		t.Fatalf("expected the operation given up after %d poll errors, got %s", MaxPollErrors+1, got)
+	}
+
+	// Retrying follows the server created rather than creating another.
+	cmd, ok := q.Retry(1, now)
+	if !ok || cmd == nil {
+		t.Fatal("expected the failed operation retried")
+	}
+	if op := q.Operations()[0]; op.State != StatePolling || op.Attempts != 1 {
+		t.Fatalf("expected the server followed again, got %+v", op)
+	}
+	q.Update(cmd().(Msg), now)
+	if op := q.Operations()[0]; op.State != StateDone || creates != 0 {
+		t.Fatalf("expected the server done without a second create (%d creates), got %+v", creates, op)
+	}
+}
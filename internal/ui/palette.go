@@ -0,0 +1,90 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/common"
+	"ostui/internal/ui/editor"
+	"ostui/internal/ui/loadbalancer"
+	"ostui/internal/ui/network"
+	"ostui/internal/ui/storage"
+)
+
+// actionQueuer is implemented by detail views that can run an action chosen
+// from the palette of their list once they have loaded.
+type actionQueuer interface {
+	QueueAction(a common.Action) tea.Model
+}
+
+// paletteActions returns the actions of the current list or detail view,
+// adding those the app handles for the view (graph, YAML edit).
+func (m AppModel) paletteActions() []common.Action {
+	if m.state != stateMain && m.state != stateDetail {
+		return nil
+	}
+	model := m.viewModel(m.state)
+	var acts []common.Action
+	if ap, ok := model.(common.ActionProvider); ok {
+		acts = ap.Actions()
+	}
+	if m.state != stateDetail || len(acts) == 0 {
+		return acts
+	}
+	switch model.(type) {
+	case network.FloatingIPDetailModel, storage.VolumeDetailModel, network.NetworkSubnetsModel, loadbalancer.LoadBalancerDetailModel:
+		acts = append(acts, common.Action{Key: "g", Name: "graph", Help: i18n.T("Relationship graph")})
+	}
+	if _, ok := model.(editor.Editable); ok {
+		acts = append(acts, common.Action{Key: "E", Name: "edit", Help: i18n.T("Edit mutable fields as YAML")})
+	}
+	return acts
+}
+
+// updatePalette forwards a key to the open palette and runs the action it
+// closes with.
+func (m AppModel) updatePalette(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	updated, cmd := m.palette.Update(msg)
+	p := updated.(common.PaletteModel)
+	m.palette = &p
+	if !p.Done() {
+		return m, cmd
+	}
+	m.palette = nil
+	a, ok := p.Selected()
+	if !ok {
+		return m, nil
+	}
+	return m.runAction(a)
+}
+
+// runAction runs a palette action like its shortcut would. Actions of a
+// list row that belong to its detail open the detail first; actions without
+// a key reach the view as a common.ActionMsg.
+func (m AppModel) runAction(a common.Action) (tea.Model, tea.Cmd) {
+	switch {
+	case a.Detail:
+		updated, cmd := m.Update(common.KeyFor("enter"))
+		am := updated.(AppModel)
+		if q, ok := am.detailModel.(actionQueuer); ok && am.state == stateDetail {
+			am.detailModel = q.QueueAction(a)
+		}
+		return am, cmd
+	case a.Key != "":
+		return m.Update(common.KeyFor(a.Key))
+	}
+	model := m.viewModel(m.state)
+	if model == nil {
+		return m, nil
+	}
+	updated, cmd := model.Update(common.ActionMsg{Action: a})
+	m.setViewModel(m.state, updated)
+	return m, cmd
+}
+
+// paletteView renders the open palette below the view.
+func (m AppModel) paletteView() string {
+	if m.palette == nil {
+		return ""
+	}
+	return "\n" + m.palette.View()
+}
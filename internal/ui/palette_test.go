@@ -0,0 +1,63 @@
+package ui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"ostui/internal/ui/common"
+)
+
+// actionStub offers a keyed and a palette-only action and records what it
+// receives.
+type actionStub struct{ got *[]string }
+
+func (s actionStub) Init() tea.Cmd { return nil }
+func (s actionStub) View() string  { return "" }
+func (s actionStub) Actions() []common.Action {
+	return []common.Action{
+		{Key: "x", Name: "delete", Help: "Delete the row"},
+		{Name: "snapshot", Help: "Snapshot the row"},
+	}
+}
+func (s actionStub) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		*s.got = append(*s.got, "key "+msg.String())
+	case common.ActionMsg:
+		*s.got = append(*s.got, "action "+msg.Action.Name)
+	}
+	return s, nil
+}
+
+func typeKeys(m tea.Model, keys ...string) tea.Model {
+	for _, k := range keys {
+		m, _ = m.Update(common.KeyFor(k))
+	}
+	return m
+}
+
+func TestActionPalette(t *testing.T) {
+	var got []string
+	var m tea.Model = AppModel{state: stateMain, mainModel: actionStub{got: &got}}
+	m = typeKeys(m, ".", "s", "n", "a", "p", "enter")
+	m = typeKeys(m, ".", "d", "l", "enter")
+	m = typeKeys(m, ".", "esc")
+	if am := m.(AppModel); am.palette != nil || am.state != stateMain {
+		t.Fatalf("palette should be closed on the list, got %+v in %q", am.palette, am.state)
+	}
+	want := []string{"action snapshot", "key x"}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestFuzzyScoreRanksWordStarts(t *testing.T) {
+	a, ok := common.FuzzyScore("sh", "shelve")
+	b, ok2 := common.FuzzyScore("sh", "unshelve")
+	if !ok || !ok2 || a <= b {
+		t.Fatalf("shelve should rank above unshelve: %d vs %d", a, b)
+	}
+	if _, ok := common.FuzzyScore("xz", "shelve"); ok {
+		t.Fatal("unrelated pattern must not match")
+	}
+}
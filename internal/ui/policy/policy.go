@@ -0,0 +1,157 @@
+// Package policy predicts whether the current user may perform an action,
+// so views can gray out actions and refuse them up front instead of letting
+// a form be filled in only to fail with a 403. OpenStack services do not
+// publish their policies, so the prediction follows the default policies
+// from the roles in the token, and remembers the actions the APIs refused
+// with a 403.
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/lipgloss"
+	"ostui/internal/ui/theme"
+)
+
+// Rule is what an action requires under the default policies.
+type Rule string
+
+const (
+	// Member covers creating and changing resources of the project.
+	Member Rule = "member"
+	// Admin covers cloud administration: hosts, quotas, other projects.
+	Admin Rule = "admin"
+	// SecretCreator covers creating Barbican secrets, which the member
+	// role alone does not allow.
+	SecretCreator Rule = "creator"
+)
+
+// grants lists the roles satisfying each rule. Keystone puts the implied
+// roles in the token (admin implies member, member implies reader), and
+// older clouds name the member role _member_.
+var grants = map[Rule][]string{
+	Member:        {"admin", "manager", "member", "_member_"},
+	Admin:         {"admin"},
+	SecretCreator: {"admin", "creator"},
+}
+
+// knownRoles are the roles of the default policies. A token with any other
+// role may be granted more by a custom policy, so no action is refused for
+// it until the API says so.
+var knownRoles = map[string]bool{
+	"admin": true, "manager": true, "member": true, "_member_": true, "reader": true,
+	"service": true, "creator": true, "observer": true, "audit": true,
+	"load-balancer_member": true, "load-balancer_observer": true, "load-balancer_global_observer": true,
+	"heat_stack_owner": true, "heat_stack_user": true, "swiftoperator": true,
+}
+
+var (
+	mu    sync.Mutex
+	roles map[string]bool
+	// denied records the actions an API refused with a 403.
+	denied = map[string]bool{}
+)
+
+// SetRoles records the roles of the current token. With nil roles nothing
+// is known and every action is allowed.
+func SetRoles(names []string) {
+	mu.Lock()
+	defer mu.Unlock()
+	denied = map[string]bool{}
+	if names == nil {
+		roles = nil
+		return
+	}
+	roles = make(map[string]bool, len(names))
+	for _, n := range names {
+		roles[n] = true
+	}
+}
+
+// Allowed reports whether the current user is expected to satisfy r.
+func Allowed(r Rule) bool {
+	mu.Lock()
+	defer mu.Unlock()
+	return allowed(r)
+}
+
+func allowed(r Rule) bool {
+	if roles == nil {
+		return true
+	}
+	for _, g := range grants[r] {
+		if roles[g] {
+			return true
+		}
+	}
+	for role := range roles {
+		if !knownRoles[role] {
+			return true
+		}
+	}
+	return false
+}
+
+// Check returns why action is expected to be refused, or nil.
+func Check(r Rule, action string) error {
+	mu.Lock()
+	defer mu.Unlock()
+	if denied[action] {
+		return fmt.Errorf("%s is not permitted: the cloud refused it before", action)
+	}
+	if allowed(r) {
+		return nil
+	}
+	held := make([]string, 0, len(roles))
+	for role := range roles {
+		held = append(held, role)
+	}
+	sort.Strings(held)
+	return fmt.Errorf("%s needs the %s role; the token has %s", action, r, strings.Join(held, ", "))
+}
+
+// Record remembers that the cloud refused action, as named to Check, when
+// err is a 403, so it is refused up front and grayed out from then on.
+// Other actions of the same rule stay allowed: the policy may single one
+// out. Errors of both gophercloud versions carry the status code.
+func Record(action string, err error) {
+	var sc interface{ GetStatusCode() int }
+	if action == "" || !errors.As(err, &sc) || sc.GetStatusCode() != http.StatusForbidden || overQuota(err) {
+		return
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	denied[action] = true
+}
+
+// overQuota reports whether a 403 refuses a request over a quota or limit,
+// as Nova and Octavia do, rather than for want of permission.
+func overQuota(err error) bool {
+	msg := strings.ToLower(err.Error())
+	for _, s := range []string{"quota exceeded", "exceeded quota", "over quota", "quota has been met", "limit exceeded", "limitexceeded", "over limit", "overlimit"} {
+		if strings.Contains(msg, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// Key renders a key hint, grayed out when r is not expected to be allowed
+// or the cloud refused one of the actions of the hint.
+func Key(r Rule, hint string, actions ...string) string {
+	mu.Lock()
+	gray := !allowed(r)
+	for _, a := range actions {
+		gray = gray || denied[a]
+	}
+	mu.Unlock()
+	if !gray {
+		return hint
+	}
+	return lipgloss.NewStyle().Foreground(theme.Muted).Render(hint)
+}
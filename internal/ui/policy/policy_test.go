@@ -0,0 +1,71 @@
+package policy
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gophercloud/gophercloud"
+)
+
+func TestAllowed(t *testing.T) {
+	t.Cleanup(func() { SetRoles(nil) })
+	for _, tc := range []struct {
+		roles               []string
+		member, admin, sets bool
+	}{
+		{nil, true, true, true},
+		{[]string{"reader"}, false, false, false},
+		{[]string{"member", "reader"}, true, false, false},
+		{[]string{"_member_"}, true, false, false},
+		{[]string{"creator", "member", "reader"}, true, false, true},
+		{[]string{"admin", "member", "reader"}, true, true, true},
+		// A custom role may be granted anything by a custom policy.
+		{[]string{"reader", "netops"}, true, true, true},
+	} {
+		SetRoles(tc.roles)
+		if Allowed(Member) != tc.member || Allowed(Admin) != tc.admin || Allowed(SecretCreator) != tc.sets {
+			t.Errorf("%v: got member=%v admin=%v creator=%v", tc.roles, Allowed(Member), Allowed(Admin), Allowed(SecretCreator))
+		}
+	}
+}
+
+func TestCheckAndRecord(t *testing.T) {
+	t.Cleanup(func() { SetRoles(nil) })
+	SetRoles([]string{"reader", "member"})
+	err := Check(Admin, "evacuate")
+	if err == nil || err.Error() != "evacuate needs the admin role; the token has member, reader" {
+		t.Fatalf("unexpected %v", err)
+	}
+	if Check(Member, "resize") != nil {
+		t.Fatal("expected member actions allowed")
+	}
+	Record("resize", gophercloud.ErrDefault404{})
+	if Check(Member, "resize") != nil {
+		t.Fatal("expected a 404 not to deny anything")
+	}
+	Record("resize", gophercloud.ErrDefault403{ErrUnexpectedResponseCode: gophercloud.ErrUnexpectedResponseCode{Actual: 403}})
+	if err := Check(Member, "resize"); err == nil || !strings.Contains(err.Error(), "refused") {
+		t.Fatalf("expected a 403 to deny the action, got %v", err)
+	}
+	if Check(Member, "rebuild") != nil || !Allowed(Member) {
+		t.Fatal("expected a 403 to deny only the action refused")
+	}
+	SetRoles([]string{"member"})
+	if Check(Member, "resize") != nil {
+		t.Fatal("expected new roles to forget the 403s")
+	}
+}
+
+func TestRecordIgnoresQuotaErrors(t *testing.T) {
+	t.Cleanup(func() { SetRoles(nil) })
+	SetRoles([]string{"member"})
+	// Nova refuses a server over the quota with a 403.
+	over := gophercloud.ErrDefault403{ErrUnexpectedResponseCode: gophercloud.ErrUnexpectedResponseCode{
+		Actual: 403,
+		Body:   []byte(`{"forbidden": {"code": 403, "message": "Quota exceeded for cores: Requested 8, but already used 20 of 24 cores"}}`),
+	}}
+	Record("creating a server", over)
+	if err := Check(Member, "creating a server"); err != nil {
+		t.Fatalf("expected an over-quota create to leave the action allowed, got %v", err)
+	}
+}
@@ -0,0 +1,419 @@
+// Package problems gathers everything unhealthy in the project into one
+// list: servers and volumes in error, failing load balancers, down ports of
+// running servers and dead agents.
+package problems
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/gophercloud/gophercloud/openstack/blockstorage/v3/volumes"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/hypervisors"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
+	"golang.org/x/sync/errgroup"
+	"ostui/internal/client"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/theme"
+	"ostui/internal/ui/uiconst"
+)
+
+// ClientSet holds the clients the problems are read from. LoadBalancer may
+// be nil when the cloud has no Octavia.
+type ClientSet struct {
+	Compute      client.ComputeClient
+	Network      client.NetworkClient
+	Storage      client.StorageClient
+	LoadBalancer client.LoadBalancerClient
+}
+
+// Kinds of problems, in the order they are listed. The values are the
+// section titles of the resources' views.
+const (
+	KindServer       = "Servers"
+	KindVolume       = "Volumes"
+	KindLoadBalancer = "Load Balancers"
+	KindPort         = "Ports"
+	KindAgent        = "Agents"
+)
+
+var kindOrder = map[string]int{KindServer: 0, KindVolume: 1, KindLoadBalancer: 2, KindPort: 3, KindAgent: 4}
+
+// Problem is one unhealthy resource.
+type Problem struct {
+	Kind   string
+	ID     string
+	Name   string
+	Status string
+	Detail string
+	// Category and TargetID name the detail view the problem opens, when it
+	// has one: a dead agent opens the hypervisor of its host.
+	Category string
+	TargetID string
+}
+
+// OpenMsg asks the app to open the detail view of a problem.
+type OpenMsg struct {
+	Category string
+	ID       string
+	Name     string
+}
+
+// report is what one load found.
+type report struct {
+	problems []Problem
+	// skipped explains the checks that could not run, e.g. agents without
+	// the admin role.
+	skipped []string
+}
+
+// lbUnhealthy are the load balancer statuses reported as problems.
+var lbUnhealthy = map[string]bool{"ERROR": true, "DEGRADED": true}
+
+// serverProblems returns the servers in ERROR with their fault, and the
+// ports that are DOWN on ACTIVE servers.
+func serverProblems(srvs []servers.Server, ports []client.Port) []Problem {
+	var out []Problem
+	active := map[string]string{}
+	for _, s := range srvs {
+		switch s.Status {
+		case "ERROR":
+			detail := s.Fault.Message
+			if s.Fault.Code != 0 {
+				detail = i18n.T("fault %d: %s", s.Fault.Code, s.Fault.Message)
+			}
+			out = append(out, Problem{Kind: KindServer, ID: s.ID, Name: s.Name, Status: s.Status, Detail: detail, Category: KindServer, TargetID: s.ID})
+		case "ACTIVE":
+			active[s.ID] = s.Name
+		}
+	}
+	for _, p := range ports {
+		name, ok := active[p.DeviceID]
+		if !ok || p.Status != "DOWN" || !strings.HasPrefix(p.DeviceOwner, "compute:") {
+			continue
+		}
+		detail := "on ACTIVE server " + name
+		if !p.AdminStateUp {
+			detail += " (admin state down)"
+		}
+		out = append(out, Problem{Kind: KindPort, ID: p.ID, Name: p.Name, Status: p.Status, Detail: detail, Category: KindPort, TargetID: p.ID})
+	}
+	return out
+}
+
+// volumeProblems returns the volumes in one of the error states.
+func volumeProblems(vols []volumes.Volume) []Problem {
+	var out []Problem
+	for _, v := range vols {
+		if strings.HasPrefix(v.Status, "error") {
+			out = append(out, Problem{Kind: KindVolume, ID: v.ID, Name: v.Name, Status: v.Status, Detail: fmt.Sprintf("%d GB", v.Size), Category: KindVolume, TargetID: v.ID})
+		}
+	}
+	return out
+}
+
+// lbProblems returns the load balancers whose provisioning or operating
+// status is ERROR or DEGRADED.
+func lbProblems(lbs []client.LoadBalancer) []Problem {
+	var out []Problem
+	for _, lb := range lbs {
+		if !lbUnhealthy[lb.ProvisioningStatus] && !lbUnhealthy[lb.OperatingStatus] {
+			continue
+		}
+		out = append(out, Problem{Kind: KindLoadBalancer, ID: lb.ID, Name: lb.Name, Status: lb.ProvisioningStatus + "/" + lb.OperatingStatus,
+			Detail: "VIP " + lb.VipAddress, Category: KindLoadBalancer, TargetID: lb.ID})
+	}
+	return out
+}
+
+// agentProblems returns the nova-compute services and neutron agents that
+// are down, linked to the hypervisor of their host when there is one.
+func agentProblems(svcs []client.ComputeService, agents []client.NetworkAgent, hvs []hypervisors.Hypervisor, now time.Time) []Problem {
+	hostHV := map[string]string{}
+	for _, hv := range hvs {
+		hostHV[hv.Service.Host] = hv.ID
+	}
+	since := func(t time.Time) string {
+		if t.IsZero() {
+			return ""
+		}
+		return ", last seen " + now.Sub(t).Round(time.Second).String() + " ago"
+	}
+	link := func(p Problem, host string) Problem {
+		if id, ok := hostHV[host]; ok {
+			p.Category, p.TargetID = "Hypervisors", id
+		}
+		return p
+	}
+	var out []Problem
+	for _, s := range svcs {
+		if s.State != "down" {
+			continue
+		}
+		detail := "host " + s.Host + since(s.UpdatedAt)
+		if s.Status == "disabled" {
+			detail += " (disabled"
+			if s.DisabledReason != "" {
+				detail += ": " + s.DisabledReason
+			}
+			detail += ")"
+		}
+		out = append(out, link(Problem{Kind: KindAgent, ID: s.ID, Name: s.Binary, Status: "down", Detail: detail}, s.Host))
+	}
+	for _, a := range agents {
+		if a.Alive {
+			continue
+		}
+		detail := "host " + a.Host + since(a.HeartbeatTimestamp)
+		if !a.AdminStateUp {
+			detail += " (admin state down)"
+		}
+		out = append(out, link(Problem{Kind: KindAgent, ID: a.ID, Name: a.Binary, Status: "down", Detail: detail}, a.Host))
+	}
+	return out
+}
+
+// load runs every check in parallel. A check that fails is reported as
+// skipped rather than failing the whole view.
+func load(cs ClientSet, now time.Time) report {
+	ctx := context.Background()
+	var (
+		mu  sync.Mutex
+		rep report
+		g   errgroup.Group
+	)
+	add := func(list []Problem) {
+		mu.Lock()
+		defer mu.Unlock()
+		rep.problems = append(rep.problems, list...)
+	}
+	skip := func(what string, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		rep.skipped = append(rep.skipped, i18n.T("%s not checked: %s", what, err))
+	}
+	g.Go(func() error {
+		srvs, err := cs.Compute.ListInstances()
+		if err != nil {
+			skip(i18n.T("servers and ports"), err)
+			return nil
+		}
+		ports, err := cs.Network.ListPorts(ctx)
+		if err != nil {
+			skip(i18n.T("ports"), err)
+		}
+		add(serverProblems(srvs, ports))
+		return nil
+	})
+	g.Go(func() error {
+		vols, err := cs.Storage.ListVolumes()
+		if err != nil {
+			skip(i18n.T("volumes"), err)
+			return nil
+		}
+		add(volumeProblems(vols))
+		return nil
+	})
+	if cs.LoadBalancer != nil {
+		g.Go(func() error {
+			lbs, err := cs.LoadBalancer.ListLoadBalancers(ctx)
+			if err != nil {
+				skip(i18n.T("load balancers"), err)
+				return nil
+			}
+			add(lbProblems(lbs))
+			return nil
+		})
+	}
+	g.Go(func() error {
+		// Agents and services are admin-only; either may be missing.
+		svcs, svcErr := cs.Compute.ListComputeServices(ctx, "")
+		if svcErr != nil {
+			skip(i18n.T("compute services"), svcErr)
+		}
+		agents, agentErr := cs.Network.ListNetworkAgents(ctx, "")
+		if agentErr != nil {
+			skip(i18n.T("network agents"), agentErr)
+		}
+		if svcErr != nil && agentErr != nil {
+			return nil
+		}
+		hvs, _ := cs.Compute.ListHypervisors(ctx)
+		add(agentProblems(svcs, agents, hvs, now))
+		return nil
+	})
+	_ = g.Wait()
+	sort.SliceStable(rep.problems, func(i, j int) bool {
+		a, b := rep.problems[i], rep.problems[j]
+		if a.Kind != b.Kind {
+			return kindOrder[a.Kind] < kindOrder[b.Kind]
+		}
+		return a.Name < b.Name
+	})
+	sort.Strings(rep.skipped)
+	return rep
+}
+
+// ProblemsModel lists the unhealthy resources; enter opens one.
+type ProblemsModel struct {
+	table   table.Model
+	loading bool
+	spinner spinner.Model
+	clients ClientSet
+	report  report
+	status  string
+
+	width  int
+	height int
+}
+
+// NewProblemsModel creates the problems view.
+func NewProblemsModel(cs ClientSet) ProblemsModel {
+	s := spinner.New()
+	s.Spinner = spinner.Dot
+	return ProblemsModel{clients: cs, loading: true, spinner: s, width: 120, height: 30}
+}
+
+type problemsLoadedMsg struct {
+	report report
+}
+
+// Init runs the checks.
+func (m ProblemsModel) Init() tea.Cmd {
+	return tea.Batch(m.spinner.Tick, m.loadCmd())
+}
+
+// Reload runs the checks again; refreshTable keeps the cursor.
+func (m ProblemsModel) Reload() tea.Cmd {
+	if m.loading {
+		return nil
+	}
+	return m.loadCmd()
+}
+
+func (m ProblemsModel) loadCmd() tea.Cmd {
+	cs := m.clients
+	return func() tea.Msg { return problemsLoadedMsg{report: load(cs, time.Now())} }
+}
+
+// Update handles messages for the model.
+func (m ProblemsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case problemsLoadedMsg:
+		m.loading = false
+		m.report = msg.report
+		m.refreshTable()
+		return m, nil
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		if !m.loading {
+			m.refreshTable()
+		}
+		return m, nil
+	case tea.KeyMsg:
+		if m.loading {
+			return m, nil
+		}
+		switch msg.String() {
+		case "r":
+			m.loading, m.status = true, ""
+			return m, tea.Batch(m.spinner.Tick, m.loadCmd())
+		case "enter":
+			i := m.table.Cursor()
+			if i < 0 || i >= len(m.report.problems) {
+				return m, nil
+			}
+			p := m.report.problems[i]
+			if p.Category == "" {
+				m.status = i18n.T("No detail view for %s on a host without a hypervisor", p.Name)
+				return m, nil
+			}
+			open := OpenMsg{Category: p.Category, ID: p.TargetID, Name: p.Name}
+			return m, func() tea.Msg { return open }
+		}
+		var cmd tea.Cmd
+		m.table, cmd = m.table.Update(msg)
+		return m, cmd
+	default:
+		if m.loading {
+			var cmd tea.Cmd
+			m.spinner, cmd = m.spinner.Update(msg)
+			return m, cmd
+		}
+	}
+	return m, nil
+}
+
+// refreshTable rebuilds the table from the report.
+func (m *ProblemsModel) refreshTable() {
+	kindW, statusW, nameW := 14, 18, 24
+	rest := m.width - kindW - statusW - nameW - uiconst.ColWidthUUID - uiconst.TableHeightOffset
+	if rest < 30 {
+		rest = 30
+	}
+	cols := []table.Column{{Title: i18n.T("Kind"), Width: kindW}, {Title: i18n.T("Name"), Width: nameW}, {Title: "ID", Width: uiconst.ColWidthUUID},
+		{Title: i18n.T("Status"), Width: statusW}, {Title: i18n.T("Detail"), Width: rest}}
+	rows := make([]table.Row, 0, len(m.report.problems))
+	for _, p := range m.report.problems {
+		rows = append(rows, table.Row{p.Kind, p.Name, p.ID, theme.Mark(p.Status), p.Detail})
+	}
+	cursor := m.table.Cursor()
+	m.table = table.New(table.WithColumns(cols), table.WithFocused(true))
+	m.table.SetStyles(table.DefaultStyles())
+	m.table.SetRows(rows)
+	m.table.SetHeight(m.height - uiconst.TableHeightOffset - 3 - len(m.report.skipped))
+	if cursor < len(rows) {
+		m.table.SetCursor(cursor)
+	}
+}
+
+// summary counts the problems of each kind, e.g. "Servers: 2 · Agents: 1".
+func (m ProblemsModel) summary() string {
+	n := map[string]int{}
+	for _, p := range m.report.problems {
+		n[p.Kind]++
+	}
+	var parts []string
+	for _, k := range []string{KindServer, KindVolume, KindLoadBalancer, KindPort, KindAgent} {
+		if n[k] > 0 {
+			parts = append(parts, fmt.Sprintf("%s: %d", k, n[k]))
+		}
+	}
+	return strings.Join(parts, " · ")
+}
+
+// View renders the problems.
+func (m ProblemsModel) View() string {
+	if m.loading {
+		return m.spinner.View() + " Checking servers, volumes, load balancers, ports and agents…"
+	}
+	dim := lipgloss.NewStyle().Foreground(theme.Muted)
+	out := ""
+	if len(m.report.problems) == 0 {
+		out = lipgloss.NewStyle().Foreground(theme.OK).Render(i18n.T("✔ Nothing unhealthy found")) + "\n"
+	} else {
+		out = lipgloss.NewStyle().Foreground(theme.Error).Render("✖ "+m.summary()) + "\n"
+	}
+	for _, s := range m.report.skipped {
+		out += dim.Render("⚠ "+s) + "\n"
+	}
+	if len(m.report.problems) > 0 {
+		out += m.table.View() + "\n"
+	}
+	if m.status != "" {
+		out += m.status + "\n"
+	}
+	return out + i18n.T("[enter] open  [r] recheck")
+}
+
+// Table returns the problems table.
+func (m ProblemsModel) Table() table.Model { return m.table }
+
+var _ tea.Model = (*ProblemsModel)(nil)
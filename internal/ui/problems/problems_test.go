@@ -0,0 +1,85 @@
+package problems
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/extensions/hypervisors"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
+	"ostui/internal/client"
+	"ostui/internal/client/clienttest"
+)
+
+// clients returns fakes holding a few healthy and unhealthy resources; the
+// agents cannot be listed.
+func clients() ClientSet {
+	broken := clienttest.Server("s2", "db-1", "ERROR")
+	broken.Fault = servers.Fault{Code: 500, Message: "No valid host was found."}
+	compute := clienttest.NewCompute(clienttest.Server("s1", "web-1", "ACTIVE"), broken, clienttest.Server("s3", "batch", "SHUTOFF")).
+		WithServices(
+			client.ComputeService{ID: "svc-1", Binary: "nova-compute", Host: "cmp-1", State: "up"},
+			client.ComputeService{ID: "svc-2", Binary: "nova-compute", Host: "cmp-2", State: "down", Status: "disabled", DisabledReason: "disk swap"},
+		).
+		WithHypervisors(hypervisors.Hypervisor{ID: "hv-2", Service: hypervisors.Service{Host: "cmp-2"}})
+	// p3 and p4 are down because they were set so.
+	p3, p4 := clienttest.Port("p3", "s3", "compute:nova", "DOWN"), clienttest.Port("p4", "r1", "network:router_interface", "DOWN")
+	p3.AdminStateUp, p4.AdminStateUp = false, false
+	network := clienttest.NewNetwork().WithPorts(clienttest.Port("p1", "s1", "compute:nova", "DOWN"), clienttest.Port("p2", "s1", "compute:nova", "ACTIVE"), p3, p4)
+	network.Fail("ListNetworkAgents", errors.New("Forbidden"))
+	storage := clienttest.NewStorage(clienttest.Volume("v1", "data", "error_extending", 50), clienttest.Volume("v2", "", "in-use", 0))
+	lb := clienttest.NewLoadBalancer(
+		client.LoadBalancer{ID: "lb1", Name: "edge", ProvisioningStatus: "ACTIVE", OperatingStatus: "DEGRADED"},
+		client.LoadBalancer{ID: "lb2", Name: "api", ProvisioningStatus: "ACTIVE", OperatingStatus: "ONLINE"},
+	)
+	return ClientSet{Compute: compute, Network: network, Storage: storage, LoadBalancer: lb}
+}
+
+func TestLoad(t *testing.T) {
+	rep := load(clients(), time.Now())
+	var got []string
+	for _, p := range rep.problems {
+		got = append(got, p.Kind+"/"+p.ID+"/"+p.Category+":"+p.TargetID)
+	}
+	want := "Servers/s2/Servers:s2,Volumes/v1/Volumes:v1,Load Balancers/lb1/Load Balancers:lb1,Ports/p1/Ports:p1,Agents/svc-2/Hypervisors:hv-2"
+	if strings.Join(got, ",") != want {
+		t.Errorf("got %s\nwant %s", strings.Join(got, ","), want)
+	}
+	if rep.problems[0].Detail != "fault 500: No valid host was found." || rep.problems[3].Detail != "on ACTIVE server web-1" ||
+		!strings.HasSuffix(rep.problems[4].Detail, "(disabled: disk swap)") {
+		t.Errorf("unexpected details %+v", rep.problems)
+	}
+	if len(rep.skipped) != 1 || rep.skipped[0] != "network agents not checked: Forbidden" {
+		t.Errorf("unexpected skipped %v", rep.skipped)
+	}
+}
+
+func TestOpenProblem(t *testing.T) {
+	m := NewProblemsModel(ClientSet{})
+	updated, _ := m.Update(problemsLoadedMsg{report: report{problems: []Problem{
+		{Kind: KindAgent, ID: "a1", Name: "neutron-l3-agent", Status: "down"},
+		{Kind: KindVolume, ID: "v1", Name: "data", Status: "error", Category: KindVolume, TargetID: "v1"},
+	}}})
+	m = updated.(ProblemsModel)
+	if v := m.View(); !strings.Contains(v, "Volumes: 1 · Agents: 1") {
+		t.Errorf("unexpected summary in %q", v)
+	}
+
+	// An agent on a host without a hypervisor has nowhere to go.
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(ProblemsModel)
+	if cmd != nil || !strings.Contains(m.status, "No detail view") {
+		t.Errorf("expected no detail for the agent, got status %q", m.status)
+	}
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	m = updated.(ProblemsModel)
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("expected an open command")
+	}
+	if msg, ok := cmd().(OpenMsg); !ok || msg.Category != KindVolume || msg.ID != "v1" {
+		t.Errorf("unexpected message %+v", msg)
+	}
+}
@@ -0,0 +1,218 @@
+package quota
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/common"
+	"ostui/internal/ui/ops"
+	"ostui/internal/ui/policy"
+	"ostui/internal/ui/theme"
+)
+
+// Request describes a create form checked against the quotas.
+type Request struct {
+	Title string
+	// Action names the request for the role check, e.g. "creating a volume".
+	Action string
+	Fields []string
+	// Keys are the quotas the request uses, shown above the form.
+	Keys []string
+	// Prepare, when set, loads what Need and Create resolve the values
+	// against, e.g. the flavors of a server.
+	Prepare func(ctx context.Context) error
+	// Need returns what the values add to each quota, or why they are
+	// invalid.
+	Need func(values []string) (map[string]int, error)
+	// Validate, when set, checks the values on submit, before the request
+	// is queued.
+	Validate func(values []string) error
+	// Create sends the request and returns what was created, with the poll
+	// following it when the cloud finishes it asynchronously. It runs as an
+	// operation of the jobs view.
+	Create func(ctx context.Context, values []string) (string, ops.Poll, error)
+}
+
+// CreateModel runs the form of a Request. The quotas are read when it
+// opens; while the form is filled in, it warns of every quota the values
+// would exceed, and refuses to submit until they fit. A submitted form is
+// queued as an operation and the form is free for the next request.
+type CreateModel struct {
+	req     Request
+	src     Source
+	set     Set
+	loading bool
+	err     error
+	spinner spinner.Model
+	form    *common.FormModel
+	// warnings are the quotas the current values would exceed.
+	warnings []string
+	status   string
+}
+
+// NewCreateModel creates the form of req, checked against the quotas of src.
+// A token whose roles cannot create gets the refusal instead of the form.
+func NewCreateModel(req Request, src Source) CreateModel {
+	s := spinner.New()
+	s.Spinner = spinner.Dot
+	if err := policy.Check(policy.Member, req.Action); err != nil {
+		return CreateModel{req: req, src: src, spinner: s, err: err}
+	}
+	f := common.NewForm(req.Fields)
+	return CreateModel{req: req, src: src, loading: true, spinner: s, form: &f}
+}
+
+type loadedMsg struct {
+	set Set
+	err error
+}
+
+// Init reads the quotas and prepares the request.
+func (m CreateModel) Init() tea.Cmd {
+	if m.err != nil {
+		return nil
+	}
+	req, src := m.req, m.src
+	load := func() tea.Msg {
+		ctx := context.Background()
+		if req.Prepare != nil {
+			if err := req.Prepare(ctx); err != nil {
+				return loadedMsg{err: err}
+			}
+		}
+		return loadedMsg{set: Load(ctx, src)}
+	}
+	cmds := []tea.Cmd{m.spinner.Tick, load}
+	if m.form != nil {
+		cmds = append(cmds, m.form.Init())
+	}
+	return tea.Batch(cmds...)
+}
+
+// CapturingInput reports whether the form is open.
+func (m CreateModel) CapturingInput() bool { return m.form != nil && !m.loading && m.err == nil }
+
+// Warnings returns the quotas the values typed so far would exceed.
+func (m CreateModel) Warnings() []string { return m.warnings }
+
+// check recomputes the warnings for the values of the form. Values that do
+// not parse yet warn of nothing.
+func (m *CreateModel) check() error {
+	need, err := m.req.Need(m.form.Values())
+	if err != nil {
+		m.warnings = nil
+		return err
+	}
+	m.warnings = m.set.Exceeded(need)
+	return nil
+}
+
+// Update handles messages for the model.
+func (m CreateModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case loadedMsg:
+		m.loading, m.err, m.set = false, msg.err, msg.set
+		if m.form != nil {
+			_ = m.check()
+		}
+		return m, nil
+	case tea.KeyMsg:
+		if m.loading || m.err != nil {
+			return m, nil
+		}
+		if m.form == nil {
+			if msg.String() == "n" {
+				// The next form is checked against the quotas as they are
+				// now, with the queued requests counted once they ran.
+				f := common.NewForm(m.req.Fields)
+				m.form, m.status, m.loading = &f, "", true
+				return m, m.Init()
+			}
+			return m, nil
+		}
+		fm, cmd := m.form.Update(msg)
+		f := fm.(common.FormModel)
+		m.form = &f
+		switch {
+		case f.Cancelled():
+			m.form, m.warnings = nil, nil
+			return m, nil
+		case !f.Submitted():
+			_ = m.check()
+			return m, cmd
+		}
+		if err := m.check(); err != nil {
+			m.form.SetError(err)
+			return m, nil
+		}
+		if len(m.warnings) > 0 {
+			m.form.SetError(errors.New(strings.Join(m.warnings, "; ")))
+			return m, nil
+		}
+		values := m.form.Values()
+		if m.req.Validate != nil {
+			if err := m.req.Validate(values); err != nil {
+				m.form.SetError(err)
+				return m, nil
+			}
+		}
+		create, title, action := m.req.Create, m.req.Title, m.req.Action
+		if values[0] != "" {
+			title += ": " + values[0]
+		}
+		m.form, m.warnings = nil, nil
+		m.status = i18n.T("Queued %s; follow it with :jobs", title)
+		return m, ops.Enqueue(title, "", func(ctx context.Context) (string, ops.Poll, error) {
+			result, poll, err := create(ctx, values)
+			if err != nil {
+				policy.Record(action, err)
+			}
+			return result, poll, err
+		})
+	default:
+		if m.loading {
+			var cmd tea.Cmd
+			m.spinner, cmd = m.spinner.Update(msg)
+			return m, cmd
+		}
+	}
+	return m, nil
+}
+
+// View renders the quotas, the form and its warnings.
+func (m CreateModel) View() string {
+	if m.err != nil {
+		return m.req.Title + "\n\n" + i18n.T("Error: %s", m.err) + "\n" + i18n.T("[esc] back")
+	}
+	dim := lipgloss.NewStyle().Foreground(theme.Muted)
+	out := m.req.Title + "\n"
+	if m.loading {
+		return out + m.spinner.View() + " reading quotas"
+	}
+	if line := m.set.Summary(m.req.Keys...); line != "" {
+		out += dim.Render("Quota: "+line) + "\n"
+	}
+	for _, w := range m.set.Warnings {
+		out += dim.Render(w) + "\n"
+	}
+	if m.form == nil {
+		if m.status == "" {
+			return out + "\n" + i18n.T("[n] new  [esc] back")
+		}
+		ok := lipgloss.NewStyle().Foreground(theme.OK)
+		return out + "\n" + ok.Render(m.status) + "\n" + i18n.T("[n] create another  [esc] back")
+	}
+	out += "\n" + m.form.View()
+	warn := lipgloss.NewStyle().Foreground(theme.Warn)
+	for _, w := range m.warnings {
+		out += "\n" + warn.Render("! "+w)
+	}
+	return out + "\n" + i18n.T("[tab] next field  [enter] next/create  [esc] cancel")
+}
+
+var _ tea.Model = (*CreateModel)(nil)
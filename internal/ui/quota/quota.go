@@ -0,0 +1,138 @@
+// Package quota reads the project's usage of the quotas a create form
+// consumes, so the form can warn that a request will exceed one before it
+// is sent rather than after the API refuses it.
+package quota
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"ostui/internal/client"
+	"ostui/internal/i18n"
+)
+
+// Quota keys.
+const (
+	Instances   = "instances"
+	Cores       = "cores"
+	RAM         = "ram"
+	Volumes     = "volumes"
+	Gigabytes   = "gigabytes"
+	FloatingIPs = "floatingip"
+)
+
+// order is the order quotas are listed in.
+var order = []string{Instances, Cores, RAM, Volumes, Gigabytes, FloatingIPs}
+
+// Usage is the usage and limit of one quota; a limit of -1 is unlimited.
+type Usage struct {
+	Label string
+	Unit  string
+	Used  int
+	Limit int
+}
+
+// Source holds the clients the quotas are read from.
+type Source struct {
+	Limits   client.LimitsClient
+	Network  client.NetworkClient
+	Identity client.IdentityClient
+}
+
+// Set is the quota usage of the project. A quota missing from it could not
+// be read and is not checked; Warnings say why.
+type Set struct {
+	usage    map[string]Usage
+	Warnings []string
+}
+
+// Load reads the compute and volume quotas from the limits and the floating
+// IP quota from Neutron. Errors leave the quotas concerned unchecked.
+func Load(ctx context.Context, src Source) Set {
+	s := Set{usage: map[string]Usage{}}
+	if src.Limits != nil {
+		if l, err := src.Limits.GetLimits(ctx); err != nil {
+			s.Warnings = append(s.Warnings, "compute and volume quotas not checked: "+err.Error())
+		} else {
+			if l.Compute != nil {
+				c := l.Compute.Absolute
+				s.usage[Instances] = Usage{"instances", "", c.TotalInstancesUsed, c.MaxTotalInstances}
+				s.usage[Cores] = Usage{"vCPUs", "", c.TotalCoresUsed, c.MaxTotalCores}
+				s.usage[RAM] = Usage{"RAM", "MiB", c.TotalRAMUsed, c.MaxTotalRAMSize}
+			}
+			if l.Volume != nil {
+				v := l.Volume.Absolute
+				s.usage[Volumes] = Usage{"volumes", "", v.TotalVolumesUsed, v.MaxTotalVolumes}
+				s.usage[Gigabytes] = Usage{"volume gigabytes", "GB", v.TotalGigabytesUsed, v.MaxTotalVolumeGigabytes}
+			}
+		}
+	}
+	if src.Network != nil && src.Identity != nil {
+		if err := s.loadFloatingIPs(ctx, src); err != nil {
+			s.Warnings = append(s.Warnings, "floating IP quota not checked: "+err.Error())
+		}
+	}
+	return s
+}
+
+// loadFloatingIPs reads the floating IP quota of the current project.
+func (s *Set) loadFloatingIPs(ctx context.Context, src Source) error {
+	p, err := src.Identity.GetCurrentProject()
+	if err != nil {
+		return err
+	}
+	nq, err := src.Network.GetQuota(ctx, p.ID)
+	if err != nil {
+		return err
+	}
+	s.usage[FloatingIPs] = Usage{"floating IPs", "", nq.FloatingIP.Used, nq.FloatingIP.Limit}
+	return nil
+}
+
+// NewSet builds a set from known usage, for tests and callers that read
+// the quotas themselves.
+func NewSet(usage map[string]Usage) Set {
+	return Set{usage: usage}
+}
+
+// Get returns the usage of the quota key, if it was read.
+func (s Set) Get(key string) (Usage, bool) {
+	u, ok := s.usage[key]
+	return u, ok
+}
+
+// Exceeded returns a warning for every quota that need, the amounts a
+// request adds by quota key, would take past its limit, e.g. "this will
+// exceed your volume gigabytes quota by 40GB".
+func (s Set) Exceeded(need map[string]int) []string {
+	var out []string
+	for _, key := range order {
+		u, ok := s.usage[key]
+		if !ok || need[key] <= 0 || u.Limit < 0 {
+			continue
+		}
+		if over := u.Used + need[key] - u.Limit; over > 0 {
+			out = append(out, i18n.T("this will exceed your %s quota by %d%s", u.Label, over, u.Unit))
+		}
+	}
+	return out
+}
+
+// Summary renders the usage of the quota keys that were read, e.g.
+// "volumes 9 of 10 · volume gigabytes 460 of 500GB".
+func (s Set) Summary(keys ...string) string {
+	var parts []string
+	for _, key := range keys {
+		u, ok := s.usage[key]
+		if !ok {
+			continue
+		}
+		limit := "unlimited"
+		if u.Limit >= 0 {
+			limit = fmt.Sprintf("%d%s", u.Limit, u.Unit)
+		}
+		parts = append(parts, fmt.Sprintf("%s %d of %s", u.Label, u.Used, limit))
+	}
+	return strings.Join(parts, " · ")
+}
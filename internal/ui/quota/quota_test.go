@@ -0,0 +1,128 @@
+package quota
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/gophercloud/gophercloud"
+	"ostui/internal/demo"
+	"ostui/internal/ui/ops"
+	"ostui/internal/ui/policy"
+)
+
+func TestExceeded(t *testing.T) {
+	s := NewSet(map[string]Usage{
+		Volumes:   {"volumes", "", 9, 10},
+		Gigabytes: {"volume gigabytes", "GB", 460, 500},
+		Cores:     {"vCPUs", "", 100, -1},
+	})
+	got := s.Exceeded(map[string]int{Volumes: 1, Gigabytes: 80, Cores: 64, Instances: 1})
+	want := []string{"this will exceed your volume gigabytes quota by 40GB"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("Exceeded = %q, want %q", got, want)
+	}
+	if got := s.Summary(Volumes, Gigabytes, Cores, RAM); got != "volumes 9 of 10 · volume gigabytes 460 of 500GB · vCPUs 100 of unlimited" {
+		t.Errorf("unexpected summary %q", got)
+	}
+}
+
+func TestCreateModelWarnsBeforeSubmitting(t *testing.T) {
+	c := demo.New(1, demo.DefaultSize)
+	var created []int
+	req := Request{
+		Title:  "New volume",
+		Action: "creating a volume",
+		Fields: []string{"Size (GB)"},
+		Keys:   []string{Volumes, Gigabytes},
+		Need: func(v []string) (map[string]int, error) {
+			n, err := strconv.Atoi(v[0])
+			if err != nil {
+				return nil, errors.New("enter the size")
+			}
+			return map[string]int{Volumes: 1, Gigabytes: n}, nil
+		},
+		Create: func(ctx context.Context, v []string) (string, ops.Poll, error) {
+			n, _ := strconv.Atoi(v[0])
+			created = append(created, n)
+			return "created", nil, nil
+		},
+	}
+	m := NewCreateModel(req, Source{Limits: c.Limits()})
+	updated, _ := m.Update(loadedMsg{set: Load(context.Background(), m.src)})
+	m = updated.(CreateModel)
+	if !m.CapturingInput() {
+		t.Fatal("expected the form to take the keys")
+	}
+	gb, _ := m.set.Get(Gigabytes)
+	over := gb.Limit - gb.Used + 40
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(strconv.Itoa(over))})
+	m = updated.(CreateModel)
+	want := "this will exceed your volume gigabytes quota by 40GB"
+	if len(m.Warnings()) != 1 || m.Warnings()[0] != want || !strings.Contains(m.View(), want) {
+		t.Fatalf("expected the warning inline, got %q", m.Warnings())
+	}
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(CreateModel)
+	if cmd != nil || m.form.Submitted() || len(created) > 0 {
+		t.Fatal("expected the form to refuse a request over the quota")
+	}
+
+	for range strconv.Itoa(over) {
+		updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+		m = updated.(CreateModel)
+	}
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("10")})
+	m = updated.(CreateModel)
+	if len(m.Warnings()) != 0 {
+		t.Fatalf("expected no warning for 10 GB, got %q", m.Warnings())
+	}
+	updated, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(CreateModel)
+	if cmd == nil {
+		t.Fatal("expected the volume to be queued")
+	}
+	enq, ok := cmd().(ops.EnqueueMsg)
+	if !ok || enq.Title != "New volume: 10" || m.form != nil || !strings.Contains(m.status, "Queued") {
+		t.Fatalf("expected the form to queue the request and close, got %+v (%q)", enq, m.status)
+	}
+	if result, _, err := enq.Work(context.Background()); err != nil || result != "created" || len(created) != 1 || created[0] != 10 {
+		t.Errorf("expected one 10 GB volume, got %v (%q, %v)", created, result, err)
+	}
+}
+
+func TestOverQuotaCreateKeepsActionsAllowed(t *testing.T) {
+	policy.SetRoles([]string{"member"})
+	t.Cleanup(func() { policy.SetRoles(nil) })
+	// The quota moved between the check and the request: Nova answers 403.
+	over := gophercloud.ErrDefault403{ErrUnexpectedResponseCode: gophercloud.ErrUnexpectedResponseCode{
+		Actual: 403,
+		Body:   []byte(`{"forbidden": {"code": 403, "message": "Quota exceeded for instances: Requested 1, but already used 10 of 10 instances"}}`),
+	}}
+	req := Request{
+		Title:  "New server",
+		Action: "creating a server",
+		Fields: []string{"Name"},
+		Keys:   []string{Instances},
+		Need:   func([]string) (map[string]int, error) { return map[string]int{Instances: 1}, nil },
+		Create: func(context.Context, []string) (string, ops.Poll, error) { return "", nil, over },
+	}
+	m := NewCreateModel(req, Source{Limits: demo.New(1, demo.DefaultSize).Limits()})
+	updated, _ := m.Update(loadedMsg{set: Load(context.Background(), m.src)})
+	updated, _ = updated.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("web")})
+	_, cmd := updated.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("expected the server to be queued")
+	}
+	if _, _, err := cmd().(ops.EnqueueMsg).Work(context.Background()); err == nil {
+		t.Fatal("expected the create to fail")
+	}
+	for _, action := range []string{"creating a server", "creating a volume"} {
+		if err := policy.Check(policy.Member, action); err != nil {
+			t.Errorf("an over-quota create must not refuse %s: %v", action, err)
+		}
+	}
+}
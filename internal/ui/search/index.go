@@ -0,0 +1,258 @@
+package search
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gophercloud/gophercloud/openstack/blockstorage/v3/volumes"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/networks"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/subnets"
+	"ostui/internal/client"
+)
+
+// indexMaxAge is how old a category of the index may get before opening
+// the search refreshes it.
+const indexMaxAge = time.Minute
+
+// Entry is a resource in the index. Terms are further strings it is found
+// by besides its name and ID, such as its IP addresses.
+type Entry struct {
+	SearchResult
+	Terms []string `json:",omitempty"`
+}
+
+// indexedCategory holds the resources of one category as last listed.
+type indexedCategory struct {
+	Updated time.Time
+	Entries []Entry
+}
+
+// Index holds the names, IDs and addresses of the resources of a cloud, so
+// the global search matches as the user types instead of listing every
+// service again. List views record what they load into it and the search
+// refreshes the categories gone stale when it opens.
+type Index struct {
+	mu         sync.Mutex
+	categories map[string]indexedCategory
+	// path, when set, is the file the index is saved to after each change.
+	path string
+}
+
+// Active is the index of the session.
+var Active = NewIndex()
+
+// NewIndex returns an empty index kept in memory only.
+func NewIndex() *Index {
+	return &Index{categories: map[string]indexedCategory{}}
+}
+
+// IndexPath returns the file the index of cloud is kept in between
+// sessions, in the ostui cache directory.
+func IndexPath(cloud string) (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("cannot determine cache directory: %w", err)
+	}
+	return filepath.Join(dir, "ostui", "search-"+cloud+".json"), nil
+}
+
+// LoadIndex returns the index saved at path, which it saves to from then
+// on. A missing file yields an empty index; an unreadable one is started
+// over, as it is only a cache.
+func LoadIndex(path string) *Index {
+	ix := NewIndex()
+	ix.path = path
+	if b, err := os.ReadFile(path); err == nil {
+		var cats map[string]indexedCategory
+		if json.Unmarshal(b, &cats) == nil && cats != nil {
+			ix.categories = cats
+		}
+	}
+	return ix
+}
+
+// Put replaces the resources of category, e.g. after a list view loaded
+// them.
+func (ix *Index) Put(category string, entries []Entry, now time.Time) {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+	ix.categories[category] = indexedCategory{Updated: now, Entries: entries}
+	if ix.path != "" {
+		_ = ix.save()
+	}
+}
+
+// save writes the index to its file; the caller holds the lock.
+func (ix *Index) save() error {
+	b, err := json.Marshal(ix.categories)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(ix.path), 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(ix.path, b, 0o600)
+}
+
+// Empty reports whether nothing was indexed yet.
+func (ix *Index) Empty() bool {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+	return len(ix.categories) == 0
+}
+
+// Stale returns those of categories never indexed or indexed more than
+// maxAge before now.
+func (ix *Index) Stale(categories []string, maxAge time.Duration, now time.Time) []string {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+	var out []string
+	for _, c := range categories {
+		if ic, ok := ix.categories[c]; !ok || now.Sub(ic.Updated) > maxAge {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// Match returns the resources whose name, ID or terms contain query,
+// ignoring case, by category then name.
+func (ix *Index) Match(query string) []SearchResult {
+	q := strings.ToLower(strings.TrimSpace(query))
+	if q == "" {
+		return nil
+	}
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+	var out []SearchResult
+	for _, ic := range ix.categories {
+		for _, e := range ic.Entries {
+			if e.matches(q) {
+				out = append(out, e.SearchResult)
+			}
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Category != out[j].Category {
+			return out[i].Category < out[j].Category
+		}
+		return out[i].Name < out[j].Name
+	})
+	return out
+}
+
+// matches reports whether the lower-case query q is in e.
+func (e Entry) matches(q string) bool {
+	if strings.Contains(strings.ToLower(e.Name), q) || strings.Contains(strings.ToLower(e.ID), q) {
+		return true
+	}
+	for _, t := range e.Terms {
+		if strings.Contains(strings.ToLower(t), q) {
+			return true
+		}
+	}
+	return false
+}
+
+// The categories the index covers, as the search shows them.
+const (
+	categoryServers     = "Servers"
+	categoryNetworks    = "Networks"
+	categorySubnets     = "Subnets"
+	categoryVolumes     = "Volumes"
+	categoryFloatingIPs = "Floating IPs"
+	categoryRouters     = "Routers"
+)
+
+// indexedCategories lists the categories in the order they are refreshed.
+var indexedCategories = []string{categoryServers, categoryNetworks, categorySubnets, categoryVolumes, categoryFloatingIPs, categoryRouters}
+
+// IndexServers records the servers of a list in the active index, with
+// their addresses.
+func IndexServers(list []servers.Server) {
+	entries := make([]Entry, 0, len(list))
+	for _, s := range list {
+		entries = append(entries, Entry{SearchResult: SearchResult{Category: categoryServers, ID: s.ID, Name: s.Name, Extra: s.Status}, Terms: serverAddresses(s)})
+	}
+	Active.Put(categoryServers, entries, time.Now())
+}
+
+// serverAddresses returns the fixed and floating addresses of s.
+func serverAddresses(s servers.Server) []string {
+	var out []string
+	for _, v := range s.Addresses {
+		addrs, _ := v.([]interface{})
+		for _, a := range addrs {
+			if m, ok := a.(map[string]interface{}); ok {
+				if addr, ok := m["addr"].(string); ok {
+					out = append(out, addr)
+				}
+			}
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// IndexNetworks records the networks of a list in the active index.
+func IndexNetworks(list []networks.Network) {
+	entries := make([]Entry, 0, len(list))
+	for _, n := range list {
+		entries = append(entries, Entry{SearchResult: SearchResult{Category: categoryNetworks, ID: n.ID, Name: n.Name, Extra: n.Status}})
+	}
+	Active.Put(categoryNetworks, entries, time.Now())
+}
+
+// IndexSubnets records the subnets of a list in the active index, found
+// by their CIDR too.
+func IndexSubnets(list []subnets.Subnet) {
+	entries := make([]Entry, 0, len(list))
+	for _, s := range list {
+		entries = append(entries, Entry{SearchResult: SearchResult{Category: categorySubnets, ID: s.ID, Name: s.Name, Extra: s.CIDR}, Terms: []string{s.CIDR}})
+	}
+	Active.Put(categorySubnets, entries, time.Now())
+}
+
+// IndexVolumes records the volumes of a list in the active index.
+func IndexVolumes(list []volumes.Volume) {
+	entries := make([]Entry, 0, len(list))
+	for _, v := range list {
+		entries = append(entries, Entry{SearchResult: SearchResult{Category: categoryVolumes, ID: v.ID, Name: v.Name, Extra: fmt.Sprintf("%dGB %s", v.Size, v.Status)}})
+	}
+	Active.Put(categoryVolumes, entries, time.Now())
+}
+
+// IndexFloatingIPs records the floating IPs of a list in the active
+// index, found by the fixed IP they point to too.
+func IndexFloatingIPs(list []client.FloatingIP) {
+	entries := make([]Entry, 0, len(list))
+	for _, f := range list {
+		e := Entry{SearchResult: SearchResult{Category: categoryFloatingIPs, ID: f.ID, Name: f.FloatingIP.FloatingIP, Extra: f.Status}}
+		if f.FixedIP != "" {
+			e.Terms = []string{f.FixedIP}
+		}
+		entries = append(entries, e)
+	}
+	Active.Put(categoryFloatingIPs, entries, time.Now())
+}
+
+// IndexRouters records the routers of a list in the active index, found
+// by their gateway addresses too.
+func IndexRouters(list []client.Router) {
+	entries := make([]Entry, 0, len(list))
+	for _, r := range list {
+		e := Entry{SearchResult: SearchResult{Category: categoryRouters, ID: r.ID, Name: r.Name, Extra: r.Status}}
+		for _, ip := range r.GatewayInfo.ExternalFixedIPs {
+			e.Terms = append(e.Terms, ip.IPAddress)
+		}
+		entries = append(entries, e)
+	}
+	Active.Put(categoryRouters, entries, time.Now())
+}
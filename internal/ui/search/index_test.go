@@ -0,0 +1,88 @@
+package search
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/subnets"
+)
+
+func TestIndexMatchesNamesIDsAndAddresses(t *testing.T) {
+	prev := Active
+	defer func() { Active = prev }()
+	Active = NewIndex()
+
+	IndexServers([]servers.Server{
+		{ID: "srv-1", Name: "web-1", Status: "ACTIVE", Addresses: map[string]interface{}{"private": []interface{}{map[string]interface{}{"addr": "10.0.0.5"}}}},
+		{ID: "srv-2", Name: "db", Status: "SHUTOFF"},
+	})
+	IndexSubnets([]subnets.Subnet{{ID: "sub-1", Name: "web-net", CIDR: "10.0.0.0/24"}})
+
+	for query, want := range map[string][]string{
+		"WEB":      {"srv-1", "sub-1"},
+		"srv-2":    {"srv-2"},
+		"10.0.0.5": {"srv-1"},
+		"10.0.0.":  {"srv-1", "sub-1"},
+		"nothing":  nil,
+	} {
+		got := Active.Match(query)
+		if len(got) != len(want) {
+			t.Errorf("Match(%q) = %v, want %v", query, got, want)
+			continue
+		}
+		for i, r := range got {
+			if r.ID != want[i] {
+				t.Errorf("Match(%q)[%d] = %s, want %s", query, i, r.ID, want[i])
+			}
+		}
+	}
+
+	now := time.Now()
+	if got := Active.Stale([]string{categoryServers, categoryVolumes}, time.Minute, now); len(got) != 1 || got[0] != categoryVolumes {
+		t.Errorf("expected only the volumes stale, got %v", got)
+	}
+	if got := Active.Stale([]string{categoryServers}, time.Minute, now.Add(2*time.Minute)); len(got) != 1 {
+		t.Errorf("expected the servers stale after two minutes, got %v", got)
+	}
+}
+
+func TestIndexKeptOnDisk(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "search-test.json")
+	ix := LoadIndex(path)
+	if !ix.Empty() {
+		t.Fatal("expected an empty index without a file")
+	}
+	updated := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	ix.Put(categoryNetworks, []Entry{{SearchResult: SearchResult{Category: categoryNetworks, ID: "net-1", Name: "public"}}}, updated)
+
+	again := LoadIndex(path)
+	if got := again.Match("pub"); len(got) != 1 || got[0].ID != "net-1" {
+		t.Fatalf("expected the saved network, got %v", got)
+	}
+	if got := again.Stale([]string{categoryNetworks}, time.Minute, updated.Add(30*time.Second)); len(got) != 0 {
+		t.Errorf("expected the saved time kept, got stale %v", got)
+	}
+}
+
+func TestSearchAnswersFromIndex(t *testing.T) {
+	prev := Active
+	defer func() { Active = prev }()
+	Active = NewIndex()
+	IndexServers([]servers.Server{{ID: "srv-1", Name: "web-1", Status: "ACTIVE"}})
+
+	// Nothing is listed: the clients are nil, so a list call would panic.
+	var m tea.Model = NewSearchModel(nil, nil, nil, nil, 80, 24)
+	for _, r := range "web" {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	sm := m.(SearchModel)
+	if len(sm.results) != 1 || sm.results[0].ID != "srv-1" || sm.loading {
+		t.Fatalf("expected the server found at once, got %+v (loading %v)", sm.results, sm.loading)
+	}
+	if !sm.refreshing || len(sm.stale) != len(indexedCategories)-1 {
+		t.Errorf("expected the other categories refreshed, got %v", sm.stale)
+	}
+}
@@ -0,0 +1,156 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"golang.org/x/sync/errgroup"
+	"ostui/internal/client"
+	"ostui/internal/i18n"
+)
+
+// NewIPSearchModel creates a SearchModel that looks up which resources own the
+// given IP address: fixed IPs on ports, floating IPs, load balancer VIPs and
+// router gateways. Selecting a result opens the owning resource's detail view.
+func NewIPSearchModel(cc client.ComputeClient, nc client.NetworkClient, lbc client.LoadBalancerClient, ip string, w, h int) SearchModel {
+	m := NewSearchModel(cc, nc, nil, nil, w, h)
+	m.ipMode = true
+	m.stale, m.refreshing = nil, false
+	m.lbClient = lbc
+	m.input.Placeholder = "ip address"
+	m.input.SetValue(ip)
+	m.input.CursorEnd()
+	m.query = ip
+	m.loading = strings.TrimSpace(ip) != ""
+	return m
+}
+
+// ipLookupCmd searches all IP-bearing resources in parallel for an exact address match.
+func (m SearchModel) ipLookupCmd(ip string) tea.Cmd {
+	return func() tea.Msg {
+		ip = strings.TrimSpace(ip)
+		if ip == "" {
+			return searchResultsMsg{results: nil}
+		}
+		ctx := context.Background()
+		var (
+			portList    []client.Port
+			routerList  []client.Router
+			lbList      []client.LoadBalancer
+			serverNames = map[string]string{}
+			results     []SearchResult
+		)
+		var g errgroup.Group
+		g.Go(func() error {
+			var err error
+			portList, err = m.networkClient.ListPorts(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to list ports: %w", err)
+			}
+			return nil
+		})
+		g.Go(func() error {
+			routerList, _ = m.networkClient.ListRouters(ctx)
+			return nil
+		})
+		g.Go(func() error {
+			lbList, _ = m.lbClient.ListLoadBalancers(ctx)
+			return nil
+		})
+		g.Go(func() error {
+			if srvList, err := m.computeClient.ListInstances(); err == nil {
+				for _, s := range srvList {
+					serverNames[s.ID] = s.Name
+				}
+			}
+			return nil
+		})
+		g.Go(func() error {
+			fipList, err := m.networkClient.ListFloatingIPs()
+			if err != nil {
+				return nil
+			}
+			for _, f := range fipList {
+				if f.FloatingIP == ip || f.FixedIP == ip {
+					extra := "floating IP"
+					if f.FixedIP == ip {
+						extra = i18n.T("fixed IP behind %s", f.FloatingIP)
+					}
+					results = append(results, SearchResult{Category: "Floating IPs", ID: f.ID, Name: f.FloatingIP, Extra: extra})
+				}
+			}
+			return nil
+		})
+		if err := g.Wait(); err != nil {
+			return searchResultsMsg{err: err}
+		}
+		results = append(results, matchIPOwners(ip, portList, routerList, lbList, serverNames)...)
+
+		// Drop duplicates (e.g. a router gateway found via its port and its gateway info).
+		seen := map[string]bool{}
+		var out []SearchResult
+		for _, r := range results {
+			k := r.Category + "|" + r.ID
+			if seen[k] {
+				continue
+			}
+			seen[k] = true
+			out = append(out, r)
+		}
+		sort.Slice(out, func(i, j int) bool {
+			if out[i].Category != out[j].Category {
+				return out[i].Category < out[j].Category
+			}
+			return out[i].Name < out[j].Name
+		})
+		return searchResultsMsg{results: out}
+	}
+}
+
+// matchIPOwners returns the servers, routers, load balancers and ports that hold ip.
+func matchIPOwners(ip string, portList []client.Port, routerList []client.Router, lbList []client.LoadBalancer, serverNames map[string]string) []SearchResult {
+	var results []SearchResult
+	routerNames := map[string]string{}
+	for _, r := range routerList {
+		routerNames[r.ID] = r.Name
+		for _, efip := range r.GatewayInfo.ExternalFixedIPs {
+			if efip.IPAddress == ip {
+				results = append(results, SearchResult{Category: "Routers", ID: r.ID, Name: r.Name, Extra: "gateway IP"})
+			}
+		}
+	}
+	lbNames := map[string]string{}
+	for _, lb := range lbList {
+		lbNames[lb.ID] = lb.Name
+		if lb.VipAddress == ip {
+			results = append(results, SearchResult{Category: "Load Balancers", ID: lb.ID, Name: lb.Name, Extra: "VIP"})
+		}
+	}
+	for _, p := range portList {
+		for _, fixed := range p.FixedIPs {
+			if fixed.IPAddress != ip {
+				continue
+			}
+			extra := i18n.T("fixed IP on port %s", p.ID)
+			switch {
+			case strings.HasPrefix(p.DeviceOwner, "compute:"):
+				results = append(results, SearchResult{Category: "Servers", ID: p.DeviceID, Name: serverNames[p.DeviceID], Extra: extra})
+			case strings.HasPrefix(p.DeviceOwner, "network:router"):
+				results = append(results, SearchResult{Category: "Routers", ID: p.DeviceID, Name: routerNames[p.DeviceID], Extra: extra})
+			case p.DeviceOwner == "Octavia" && strings.HasPrefix(p.DeviceID, "lb-"):
+				id := strings.TrimPrefix(p.DeviceID, "lb-")
+				results = append(results, SearchResult{Category: "Load Balancers", ID: id, Name: lbNames[id], Extra: extra})
+			default:
+				owner := p.DeviceOwner
+				if owner == "" {
+					owner = "unbound"
+				}
+				results = append(results, SearchResult{Category: "Ports", ID: p.ID, Name: p.Name, Extra: i18n.T("fixed IP (%s)", owner)})
+			}
+		}
+	}
+	return results
+}
@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-	"sync"
 	"time"
 
 	"github.com/charmbracelet/bubbles/spinner"
@@ -14,6 +13,8 @@ import (
 	"github.com/charmbracelet/lipgloss"
 	"golang.org/x/sync/errgroup"
 	"ostui/internal/client"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/theme"
 )
 
 // SearchResult represents a single search result.
@@ -34,10 +35,16 @@ type searchQueryMsg struct {
 	query string
 }
 
+// indexRefreshedMsg reports that the stale categories of the index were
+// listed again.
+type indexRefreshedMsg struct{}
+
 type SearchDoneMsg struct{}
 
 type SearchSelectedMsg struct {
 	Result SearchResult
+	// OpenDetail asks the app to open the resource's detail view rather than its list.
+	OpenDetail bool
 }
 
 // SearchModel holds the state for the global search UI.
@@ -55,6 +62,13 @@ type SearchModel struct {
 	networkClient client.NetworkClient
 	storageClient client.StorageClient
 	imageClient   client.ImageClient
+	lbClient      client.LoadBalancerClient
+	// ipMode switches the model to exact IP address lookup (see NewIPSearchModel).
+	ipMode bool
+	// stale are the categories of the index listed again on Init; results
+	// come from the index meanwhile, with refreshing set.
+	stale      []string
+	refreshing bool
 }
 
 // NewSearchModel creates a new SearchModel.
@@ -63,7 +77,8 @@ func NewSearchModel(cc client.ComputeClient, nc client.NetworkClient, sc client.
 	ti.Placeholder = "search"
 	ti.Focus()
 	sp := spinner.New()
-	sp.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("205"))
+	sp.Style = lipgloss.NewStyle().Foreground(theme.Accent)
+	stale := Active.Stale(indexedCategories, indexMaxAge, time.Now())
 	return SearchModel{
 		input:         ti,
 		spinner:       sp,
@@ -73,12 +88,20 @@ func NewSearchModel(cc client.ComputeClient, nc client.NetworkClient, sc client.
 		networkClient: nc,
 		storageClient: sc,
 		imageClient:   ic,
+		stale:         stale,
+		refreshing:    len(stale) > 0,
 	}
 }
 
 // Init focuses the text input and starts the spinner.
 func (m SearchModel) Init() tea.Cmd {
 	// Start spinner tick and blink cursor.
+	if m.ipMode && m.query != "" {
+		return tea.Batch(textinput.Blink, spinner.Tick, m.ipLookupCmd(m.query))
+	}
+	if len(m.stale) > 0 {
+		return tea.Batch(textinput.Blink, spinner.Tick, m.refreshCmd(m.stale))
+	}
 	return tea.Batch(textinput.Blink, spinner.Tick)
 }
 
@@ -97,7 +120,7 @@ func (m SearchModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, func() tea.Msg { return SearchDoneMsg{} }
 		case "enter":
 			if m.cursor >= 0 && m.cursor < len(m.results) {
-				return m, func() tea.Msg { return SearchSelectedMsg{Result: m.results[m.cursor]} }
+				return m, func() tea.Msg { return SearchSelectedMsg{Result: m.results[m.cursor], OpenDetail: m.ipMode} }
 			}
 			return m, nil
 		case "j", "down":
@@ -117,6 +140,13 @@ func (m SearchModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.input, cmd = m.input.Update(msg)
 			cmds = append(cmds, cmd)
 			newVal := m.input.Value()
+			if newVal != oldVal && !m.ipMode {
+				// Names are matched in the index right away.
+				m.cursor = 0
+				m.query = newVal
+				m.results = Active.Match(newVal)
+				return m, tea.Batch(cmds...)
+			}
 			if newVal != oldVal {
 				// Reset cursor and schedule debounce.
 				m.cursor = 0
@@ -130,13 +160,19 @@ func (m SearchModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 	case searchQueryMsg:
 		// Only fire if the query hasn't changed during debounce.
-		if msg.query == m.input.Value() {
+		if msg.query == m.input.Value() && m.ipMode {
 			m.query = msg.query
-			// Trigger live search.
-			return m, m.searchCmd(msg.query)
+			return m, m.ipLookupCmd(msg.query)
 		}
 		// Query changed, ignore.
 		return m, nil
+	case indexRefreshedMsg:
+		m.refreshing = false
+		m.results = Active.Match(m.query)
+		if m.cursor >= len(m.results) {
+			m.cursor = max(len(m.results)-1, 0)
+		}
+		return m, nil
 	case searchResultsMsg:
 		m.results = msg.results
 		m.loading = false
@@ -151,139 +187,77 @@ func (m SearchModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
-// searchCmd performs the parallel live search across OpenStack services.
-func (m SearchModel) searchCmd(query string) tea.Cmd {
+// refreshCmd lists the stale categories of the index in parallel and
+// records them. A category whose list fails keeps its last entries.
+func (m SearchModel) refreshCmd(categories []string) tea.Cmd {
 	return func() tea.Msg {
-		if strings.TrimSpace(query) == "" {
-			return searchResultsMsg{results: nil}
-		}
-		q := strings.ToLower(query)
-		var mu sync.Mutex
-		var allResults []SearchResult
+		ctx := context.Background()
 		var g errgroup.Group
-
-		// Servers
-		g.Go(func() error {
-			srvList, err := m.computeClient.ListInstances()
-			if err != nil {
-				return nil
-			}
-			for _, s := range srvList {
-				if strings.Contains(strings.ToLower(s.Name), q) || strings.Contains(strings.ToLower(s.ID), q) {
-					mu.Lock()
-					allResults = append(allResults, SearchResult{Category: "Servers", ID: s.ID, Name: s.Name, Extra: s.Status})
-					mu.Unlock()
-				}
-			}
-			return nil
-		})
-
-		// Networks
-		g.Go(func() error {
-			netList, err := m.networkClient.ListNetworks()
-			if err != nil {
-				return nil
-			}
-			for _, n := range netList {
-				if strings.Contains(strings.ToLower(n.Name), q) || strings.Contains(strings.ToLower(n.ID), q) {
-					mu.Lock()
-					allResults = append(allResults, SearchResult{Category: "Networks", ID: n.ID, Name: n.Name, Extra: n.Status})
-					mu.Unlock()
-				}
+		for _, c := range categories {
+			switch c {
+			case categoryServers:
+				g.Go(func() error {
+					if list, err := m.computeClient.ListInstances(); err == nil {
+						IndexServers(list)
+					}
+					return nil
+				})
+			case categoryNetworks:
+				g.Go(func() error {
+					if list, err := m.networkClient.ListNetworks(); err == nil {
+						IndexNetworks(list)
+					}
+					return nil
+				})
+			case categorySubnets:
+				g.Go(func() error {
+					if list, err := m.networkClient.ListSubnets(); err == nil {
+						IndexSubnets(list)
+					}
+					return nil
+				})
+			case categoryVolumes:
+				g.Go(func() error {
+					if list, err := m.storageClient.ListVolumes(); err == nil {
+						IndexVolumes(list)
+					}
+					return nil
+				})
+			case categoryFloatingIPs:
+				g.Go(func() error {
+					if list, err := m.networkClient.ListFloatingIPDetails(ctx); err == nil {
+						IndexFloatingIPs(list)
+					}
+					return nil
+				})
+			case categoryRouters:
+				g.Go(func() error {
+					if list, err := m.networkClient.ListRouters(ctx); err == nil {
+						IndexRouters(list)
+					}
+					return nil
+				})
 			}
-			return nil
-		})
-
-		// Volumes
-		g.Go(func() error {
-			volList, err := m.storageClient.ListVolumes()
-			if err != nil {
-				return nil
-			}
-			for _, v := range volList {
-				if strings.Contains(strings.ToLower(v.Name), q) || strings.Contains(strings.ToLower(v.ID), q) {
-					mu.Lock()
-					allResults = append(allResults, SearchResult{Category: "Volumes", ID: v.ID, Name: v.Name, Extra: fmt.Sprintf("%dGB %s", v.Size, v.Status)})
-					mu.Unlock()
-				}
-			}
-			return nil
-		})
-
-		// Floating IPs
-		g.Go(func() error {
-			fipList, err := m.networkClient.ListFloatingIPs()
-			if err != nil {
-				return nil
-			}
-			for _, f := range fipList {
-				if strings.Contains(strings.ToLower(f.FloatingIP), q) || strings.Contains(strings.ToLower(f.ID), q) {
-					mu.Lock()
-					allResults = append(allResults, SearchResult{Category: "Floating IPs", ID: f.ID, Name: f.FloatingIP, Extra: f.Status})
-					mu.Unlock()
-				}
-			}
-			return nil
-		})
-
-		// Routers
-		g.Go(func() error {
-			ctx := context.Background()
-			routerList, err := m.networkClient.ListRouters(ctx)
-			if err != nil {
-				return nil
-			}
-			for _, r := range routerList {
-				if strings.Contains(strings.ToLower(r.Name), q) || strings.Contains(strings.ToLower(r.ID), q) {
-					mu.Lock()
-					allResults = append(allResults, SearchResult{Category: "Routers", ID: r.ID, Name: r.Name, Extra: r.Status})
-					mu.Unlock()
-				}
-			}
-			return nil
-		})
-
-		// Subnets
-		g.Go(func() error {
-			subList, err := m.networkClient.ListSubnets()
-			if err != nil {
-				return nil
-			}
-			for _, s := range subList {
-				if strings.Contains(strings.ToLower(s.Name), q) || strings.Contains(strings.ToLower(s.ID), q) || strings.Contains(s.CIDR, q) {
-					mu.Lock()
-					allResults = append(allResults, SearchResult{Category: "Subnets", ID: s.ID, Name: s.Name, Extra: s.CIDR})
-					mu.Unlock()
-				}
-			}
-			return nil
-		})
-
-		// Wait for all goroutines.
+		}
 		_ = g.Wait()
-
-		// Sort by Category then Name.
-		sort.Slice(allResults, func(i, j int) bool {
-			if allResults[i].Category != allResults[j].Category {
-				return allResults[i].Category < allResults[j].Category
-			}
-			return allResults[i].Name < allResults[j].Name
-		})
-
-		return searchResultsMsg{results: allResults}
+		return indexRefreshedMsg{}
 	}
 }
 
 // View renders the search UI.
 func (m SearchModel) View() string {
 	// Header
-	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("205"))
+	headerStyle := lipgloss.NewStyle().Bold(true).Foreground(theme.Accent)
 	var b strings.Builder
-	b.WriteString(headerStyle.Render("Global Search"))
+	title := i18n.T("Global Search")
+	if m.ipMode {
+		title = i18n.T("IP Lookup")
+	}
+	b.WriteString(headerStyle.Render(title))
 	b.WriteString("\n")
 
 	// Input line with optional spinner.
-	if m.loading {
+	if m.loading || m.refreshing {
 		b.WriteString(m.spinner.View())
 		b.WriteString(" ")
 	}
@@ -293,8 +267,12 @@ func (m SearchModel) View() string {
 	// Content
 	if m.loading {
 		// Show nothing else while loading.
+	} else if m.err != nil {
+		b.WriteString(i18n.T("Error: %s", m.err))
+	} else if len(m.results) == 0 && strings.TrimSpace(m.query) != "" && m.refreshing {
+		b.WriteString(i18n.T("Indexing the project…"))
 	} else if len(m.results) == 0 && strings.TrimSpace(m.query) != "" {
-		b.WriteString(fmt.Sprintf("No results for '%s'", m.query))
+		b.WriteString(i18n.T("No results for '%s'", m.query))
 	} else if len(m.results) > 0 {
 		// Group results by category.
 		groups := make(map[string][]SearchResult)
@@ -311,13 +289,17 @@ func (m SearchModel) View() string {
 		for _, cat := range order {
 			items := groups[cat]
 			// Category header
-			catHeader := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("205"))
+			catHeader := lipgloss.NewStyle().Bold(true).Foreground(theme.Accent)
 			b.WriteString(catHeader.Render(fmt.Sprintf("%s (%d)", cat, len(items))))
 			b.WriteString("\n")
 			for _, res := range items {
 				// Build line.
 				extraStyled := lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render(res.Extra)
-				line := fmt.Sprintf("%s  %s", res.Name, extraStyled)
+				name := res.Name
+				if name == "" {
+					name = res.ID
+				}
+				line := fmt.Sprintf("%s  %s", name, extraStyled)
 				if idx == m.cursor {
 					// Highlight selected line.
 					line = lipgloss.NewStyle().Background(lipgloss.Color("236")).Render(line)
@@ -333,7 +315,7 @@ func (m SearchModel) View() string {
 	// Wrap with border.
 	border := lipgloss.NewStyle().
 		Border(lipgloss.NormalBorder()).
-		BorderForeground(lipgloss.Color("205"))
+		BorderForeground(theme.Accent)
 	return border.Render(b.String())
 }
 
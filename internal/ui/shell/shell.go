@@ -8,6 +8,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"os"
 	"os/exec"
+	"ostui/internal/i18n"
 )
 
 type ShellModel struct {
@@ -83,7 +84,7 @@ func (m ShellModel) View() string {
 		return m.spinner.View() + " Running: openstack " + m.command
 	}
 	header := fmt.Sprintf("openstack %s", m.command)
-	footer := fmt.Sprintf(" %3.f%% | [j/k] scroll  [esc] close", m.viewport.ScrollPercent()*100)
+	footer := i18n.T(" %3.f%% | [j/k] scroll  [esc] close", m.viewport.ScrollPercent()*100)
 	return header + "\n" + m.viewport.View() + "\n" + footer
 }
 
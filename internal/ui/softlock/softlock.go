@@ -0,0 +1,203 @@
+// Package softlock masks sensitive values such as token IDs and secret
+// payloads until the user reveals them. With the soft-lock on, revealing
+// first asks for the account password or a PIN, so a value cannot be read
+// off an unattended or shared screen.
+package softlock
+
+import (
+	"crypto/subtle"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/theme"
+)
+
+// Mask replaces a hidden value. It has a fixed width so the length of the
+// value does not leak.
+const Mask = "••••••••••••"
+
+var (
+	// Enabled turns the soft-lock on (--soft-lock).
+	Enabled bool
+	// UnlockFor is how long a correct password or PIN keeps the lock open,
+	// so revealing several values does not ask every time.
+	UnlockFor = 2 * time.Minute
+	// Verify checks an entered password or PIN; main sets it.
+	Verify func(secret string) error
+	// IdleLock hides the screen after this long without input, until a key
+	// (and, with the soft-lock on, the password or PIN) resumes; 0 never.
+	IdleLock time.Duration
+	// IdleExit quits after this long without input; 0 never.
+	IdleExit time.Duration
+)
+
+var (
+	mu            sync.Mutex
+	unlockedUntil time.Time
+)
+
+// ErrWrongSecret is returned by a PIN verifier for a wrong PIN.
+var ErrWrongSecret = errors.New("wrong password or PIN")
+
+// PIN returns a verifier accepting only pin.
+func PIN(pin string) func(string) error {
+	return func(secret string) error {
+		if subtle.ConstantTimeCompare([]byte(secret), []byte(pin)) != 1 {
+			return ErrWrongSecret
+		}
+		return nil
+	}
+}
+
+// Required reports whether revealing a value needs the password or PIN now.
+func Required(now time.Time) bool {
+	mu.Lock()
+	defer mu.Unlock()
+	return Enabled && !now.Before(unlockedUntil)
+}
+
+// unlock opens the lock for UnlockFor.
+func unlock(now time.Time) {
+	mu.Lock()
+	defer mu.Unlock()
+	unlockedUntil = now.Add(UnlockFor)
+}
+
+// Lock closes the lock before UnlockFor is over, so the next reveal asks
+// again; the session locks it when it goes idle.
+func Lock() {
+	mu.Lock()
+	defer mu.Unlock()
+	unlockedUntil = time.Time{}
+}
+
+// CheckedMsg reports the result of verifying the entered secret; views
+// pass it to their Guard.
+type CheckedMsg struct{ err error }
+
+// Guard masks one sensitive value in a view. Views keep a Guard, render
+// their value through Value and forward messages to Update; while Prompting
+// reports true they must forward every key.
+type Guard struct {
+	revealed  bool
+	prompting bool
+	checking  bool
+	input     textinput.Model
+	err       error
+}
+
+// NewGuard returns a Guard with the value masked.
+func NewGuard() Guard {
+	ti := textinput.New()
+	ti.Prompt = "Password or PIN: "
+	ti.EchoMode = textinput.EchoPassword
+	ti.CharLimit = 256
+	return Guard{input: ti}
+}
+
+// Revealed reports whether the value is shown.
+func (g Guard) Revealed() bool { return g.revealed }
+
+// Prompting reports whether the password or PIN is being typed or checked.
+func (g Guard) Prompting() bool { return g.prompting || g.checking }
+
+// Value returns value when revealed and Mask otherwise.
+func (g Guard) Value(value string) string {
+	if g.revealed {
+		return value
+	}
+	return Mask
+}
+
+// Err returns why the last unlock failed, or nil.
+func (g Guard) Err() error { return g.err }
+
+// Hide masks the value again.
+func (g *Guard) Hide() { g.revealed = false }
+
+// Toggle hides a revealed value or reveals a masked one. Revealing asks for
+// the password or PIN first when the soft-lock requires it.
+func (g *Guard) Toggle() tea.Cmd {
+	if g.revealed {
+		g.revealed = false
+		return nil
+	}
+	return g.Reveal()
+}
+
+// Reveal shows the value, after the password or PIN when required.
+func (g *Guard) Reveal() tea.Cmd {
+	g.err = nil
+	if !Required(time.Now()) {
+		g.revealed = true
+		return nil
+	}
+	g.prompting = true
+	g.input.SetValue("")
+	return g.input.Focus()
+}
+
+// Update handles the prompt keys and the verification result.
+func (g Guard) Update(msg tea.Msg) (Guard, tea.Cmd) {
+	switch msg := msg.(type) {
+	case CheckedMsg:
+		g.checking = false
+		if msg.err != nil {
+			g.err = msg.err
+			return g, nil
+		}
+		unlock(time.Now())
+		g.revealed = true
+		return g, nil
+	case tea.KeyMsg:
+		if !g.prompting {
+			return g, nil
+		}
+		switch msg.Type {
+		case tea.KeyEsc:
+			g.prompting = false
+			g.input.Blur()
+			return g, nil
+		case tea.KeyEnter:
+			g.prompting, g.checking = false, true
+			g.input.Blur()
+			secret := g.input.Value()
+			g.input.SetValue("")
+			return g, check(secret)
+		}
+		var cmd tea.Cmd
+		g.input, cmd = g.input.Update(msg)
+		return g, cmd
+	}
+	return g, nil
+}
+
+// check verifies secret off the UI goroutine; verifying a password
+// authenticates against Keystone.
+func check(secret string) tea.Cmd {
+	return func() tea.Msg {
+		if Verify == nil {
+			return CheckedMsg{err: errors.New("no password or PIN to check against; set OSTUI_LOCK_PIN")}
+		}
+		return CheckedMsg{err: Verify(secret)}
+	}
+}
+
+// View renders the prompt or the last error, or "" when idle.
+func (g Guard) View() string {
+	warn := lipgloss.NewStyle().Foreground(theme.Warn)
+	switch {
+	case g.prompting:
+		return warn.Render(i18n.T("Sensitive value locked.")) + " " + g.input.View() + "  " + i18n.T("[enter] unlock  [esc] cancel")
+	case g.checking:
+		return warn.Render(i18n.T("Checking…"))
+	case g.err != nil:
+		return lipgloss.NewStyle().Foreground(theme.Error).Render("Unlock failed: " + g.err.Error())
+	}
+	return ""
+}
@@ -0,0 +1,79 @@
+package softlock
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func withLock(t *testing.T, pin string) {
+	t.Helper()
+	Enabled, Verify = true, PIN(pin)
+	mu.Lock()
+	unlockedUntil = time.Time{}
+	mu.Unlock()
+	t.Cleanup(func() { Enabled, Verify = false, nil })
+}
+
+// enter types s and presses enter, running the verification.
+func enter(g Guard, s string) Guard {
+	g, _ = g.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)})
+	g, cmd := g.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	g, _ = g.Update(cmd())
+	return g
+}
+
+func TestGuardWithoutLock(t *testing.T) {
+	g := NewGuard()
+	if g.Value("tok") != Mask {
+		t.Fatal("expected the value masked by default")
+	}
+	g.Toggle()
+	if g.Prompting() || g.Value("tok") != "tok" {
+		t.Fatal("expected an unlocked reveal without a prompt")
+	}
+	g.Toggle()
+	if g.Revealed() {
+		t.Fatal("expected toggle to hide the value again")
+	}
+}
+
+func TestGuardAsksForPIN(t *testing.T) {
+	withLock(t, "4711")
+	g := NewGuard()
+	g.Toggle()
+	if !g.Prompting() || g.Revealed() {
+		t.Fatal("expected the PIN prompt")
+	}
+	g = enter(g, "0000")
+	if g.Revealed() || g.Err() == nil || !strings.Contains(g.View(), "wrong password or PIN") {
+		t.Fatalf("expected a wrong PIN to keep the value masked, got %q", g.View())
+	}
+	g.Toggle()
+	g = enter(g, "4711")
+	if !g.Revealed() || g.Prompting() {
+		t.Fatal("expected the right PIN to reveal the value")
+	}
+
+	// The unlock window spares a second prompt, but not forever.
+	other := NewGuard()
+	other.Toggle()
+	if !other.Revealed() {
+		t.Fatal("expected no prompt right after unlocking")
+	}
+	if !Required(time.Now().Add(UnlockFor)) {
+		t.Fatal("expected the lock to close after UnlockFor")
+	}
+}
+
+func TestGuardEscCancels(t *testing.T) {
+	withLock(t, "4711")
+	g := NewGuard()
+	g.Toggle()
+	g, _ = g.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if g.Prompting() || g.Revealed() {
+		t.Fatal("expected esc to cancel the prompt")
+	}
+}
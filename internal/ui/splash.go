@@ -0,0 +1,214 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"ostui/internal/client"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/theme"
+)
+
+// splashStepMsg reports the outcome of one startup step.
+type splashStepMsg struct {
+	index   int
+	err     error
+	elapsed time.Duration
+}
+
+// splashStep is the display state of one startup step.
+type splashStep struct {
+	step    client.InitStep
+	running bool
+	done    bool
+	err     error
+	elapsed time.Duration
+}
+
+// SplashModel is shown while authenticating and creating service clients.
+// It runs the first ServiceSet init step, the login the others need, then
+// the others at once, showing the progress and errors of each, and then
+// hands over to the main AppModel.
+type SplashModel struct {
+	cloudName string
+	services  *client.ServiceSet
+	opts      Options
+	steps     []splashStep
+	// pending counts the steps not done yet.
+	pending int
+	spinner spinner.Model
+	// fatal is set when a required step failed; the app cannot start.
+	fatal  bool
+	failed bool
+	width  int
+	height int
+}
+
+// NewSplashModel creates the startup model for the given cloud.
+func NewSplashModel(cloudName string, services *client.ServiceSet, opts Options) SplashModel {
+	s := spinner.New()
+	s.Spinner = spinner.Dot
+	var steps []splashStep
+	for _, st := range services.InitSteps() {
+		steps = append(steps, splashStep{step: st})
+	}
+	return SplashModel{cloudName: cloudName, services: services, opts: opts, steps: steps, pending: len(steps), spinner: s}
+}
+
+// Init switches to the alternate screen and starts the first step.
+func (m SplashModel) Init() tea.Cmd {
+	if len(m.steps) > 0 {
+		m.steps[0].running = true
+	}
+	return tea.Batch(tea.EnterAltScreen, m.spinner.Tick, m.runStep(0))
+}
+
+// runStep runs step i in the background.
+func (m SplashModel) runStep(i int) tea.Cmd {
+	if i >= len(m.steps) {
+		return nil
+	}
+	run := m.steps[i].step.Run
+	return func() tea.Msg {
+		start := time.Now()
+		err := run()
+		return splashStepMsg{index: i, err: err, elapsed: time.Since(start)}
+	}
+}
+
+// runRest starts every step after the first together: each creates the
+// client of one service from the session the first opened.
+func (m *SplashModel) runRest() tea.Cmd {
+	var cmds []tea.Cmd
+	for i := 1; i < len(m.steps); i++ {
+		m.steps[i].running = true
+		cmds = append(cmds, m.runStep(i))
+	}
+	return tea.Batch(cmds...)
+}
+
+// finished reports whether every step has run (or startup was aborted).
+func (m SplashModel) finished() bool {
+	return m.fatal || m.pending == 0
+}
+
+// Err returns the error of the required step that aborted startup, if any.
+func (m SplashModel) Err() error {
+	for _, st := range m.steps {
+		if st.err != nil && st.step.Required {
+			return st.err
+		}
+	}
+	return nil
+}
+
+// start replaces the splash screen with the main application model.
+func (m SplashModel) start() (tea.Model, tea.Cmd) {
+	app := NewModel(m.cloudName, m.services, m.opts)
+	var model tea.Model = app
+	if m.width > 0 {
+		model, _ = app.Update(tea.WindowSizeMsg{Width: m.width, Height: m.height})
+	}
+	return model, model.Init()
+}
+
+// Update handles step results and keys.
+func (m SplashModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		return m, nil
+	case splashStepMsg:
+		if m.fatal {
+			return m, nil
+		}
+		st := &m.steps[msg.index]
+		st.running, st.done, st.err, st.elapsed = false, true, msg.err, msg.elapsed
+		m.pending--
+		if msg.err != nil {
+			m.failed = true
+			if st.step.Required {
+				m.fatal = true
+				return m, nil
+			}
+		}
+		if msg.index == 0 && m.pending > 0 {
+			return m, m.runRest()
+		}
+		if m.pending > 0 {
+			return m, nil
+		}
+		if !m.failed {
+			return m.start()
+		}
+		// Optional services failed: keep the errors on screen until dismissed.
+		return m, nil
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "q":
+			return m, tea.Quit
+		case "enter":
+			if m.finished() && !m.fatal {
+				return m.start()
+			}
+		}
+		return m, nil
+	}
+	if len(m.steps) == 0 {
+		// Nothing to initialise (e.g. demo mode).
+		return m.start()
+	}
+	if !m.finished() {
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+	}
+	return m, nil
+}
+
+// View renders the list of steps with their status.
+func (m SplashModel) View() string {
+	title := lipgloss.NewStyle().Bold(true).Foreground(theme.Accent).Render("OSTUI – OpenStack TUI")
+	okStyle := lipgloss.NewStyle().Foreground(theme.OK)
+	errStyle := lipgloss.NewStyle().Foreground(theme.Error)
+	dim := lipgloss.NewStyle().Foreground(theme.Muted)
+
+	var b strings.Builder
+	b.WriteString(title + "\n")
+	b.WriteString(dim.Render("Connecting to "+m.cloudName) + "\n")
+	if client.InsecureTLS {
+		b.WriteString(insecureBanner() + " certificates are not checked; traffic can be intercepted\n")
+	}
+	b.WriteString("\n")
+	for _, st := range m.steps {
+		switch {
+		case st.done && st.err == nil:
+			b.WriteString(fmt.Sprintf(" %s %s %s\n", okStyle.Render("✓"), st.step.Name, dim.Render(st.elapsed.Round(time.Millisecond).String())))
+		case st.done:
+			b.WriteString(fmt.Sprintf(" %s %s\n", errStyle.Render("✗"), st.step.Name))
+			b.WriteString("   " + errStyle.Render(st.err.Error()) + "\n")
+		case st.running && !m.fatal:
+			b.WriteString(fmt.Sprintf(" %s %s\n", m.spinner.View(), st.step.Name))
+		default:
+			b.WriteString(dim.Render("   "+st.step.Name) + "\n")
+		}
+	}
+	b.WriteString("\n")
+	switch {
+	case m.fatal:
+		b.WriteString(errStyle.Render(i18n.T("Startup failed.")) + dim.Render("  "+i18n.T("[q] quit")) + "\n")
+	case m.finished():
+		b.WriteString(dim.Render(i18n.T("Some services are unavailable.  [enter] continue  [q] quit")) + "\n")
+	default:
+		b.WriteString(dim.Render(i18n.T("[q] quit")) + "\n")
+	}
+	return lipgloss.NewStyle().Padding(1, 2).Render(b.String())
+}
+
+// Ensure SplashModel implements tea.Model.
+var _ tea.Model = (*SplashModel)(nil)
@@ -0,0 +1,57 @@
+package ui
+
+import (
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"ostui/internal/client"
+	"ostui/internal/demo"
+)
+
+func TestSplashRunsServiceStepsTogether(t *testing.T) {
+	// Each service step waits until every one of them has started, which
+	// only completes when they run at once.
+	const stepCount = 4
+	var started sync.WaitGroup
+	started.Add(stepCount)
+	steps := []splashStep{{step: client.InitStep{Name: "Authenticating with Keystone", Required: true, Run: func() error { return nil }}}}
+	for i := 0; i < stepCount; i++ {
+		steps = append(steps, splashStep{step: client.InitStep{Name: "service", Run: func() error {
+			started.Done()
+			started.Wait()
+			return nil
+		}}})
+	}
+	t.Setenv("OSTUI_SCHEDULES_FILE", filepath.Join(t.TempDir(), "schedules.yaml"))
+	dc := demo.New(1, demo.DefaultSize)
+	services := client.NewServiceSetFromClients(client.Clients{Compute: dc.Compute(), Network: dc.Network(), Storage: dc.Storage(), Identity: dc.Identity(), Image: dc.Image(), Limits: dc.Limits(), DNS: dc.DNS(), LoadBalancer: dc.LoadBalancer(), SharedFS: dc.SharedFS(), KeyManager: dc.KeyManager(), ContainerInfra: dc.ContainerInfra()})
+	m := SplashModel{cloudName: "demo", services: services, steps: steps, pending: len(steps)}
+
+	updated, cmd := m.Update(m.runStep(0)())
+	m = updated.(SplashModel)
+	batch, ok := cmd().(tea.BatchMsg)
+	if !ok || len(batch) != stepCount {
+		t.Fatalf("expected the %d service steps started together after the login, got %#v", stepCount, cmd())
+	}
+	results := make(chan tea.Msg, stepCount)
+	for _, c := range batch {
+		go func(c tea.Cmd) { results <- c() }(c)
+	}
+	for i := 0; i < stepCount; i++ {
+		select {
+		case msg := <-results:
+			updated, _ = m.Update(msg)
+			if sm, ok := updated.(SplashModel); ok {
+				m = sm
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("the service steps did not run concurrently")
+		}
+	}
+	if _, ok := updated.(AppModel); !ok {
+		t.Fatalf("expected the app to start once every step is done, got %T", updated)
+	}
+}
@@ -1,10 +1,10 @@
 package storage
 
 import (
-	"fmt"
 	"github.com/charmbracelet/bubbles/table"
 	"github.com/gophercloud/gophercloud/openstack/blockstorage/v3/snapshots"
 	"ostui/internal/client"
+	"ostui/internal/i18n"
 	"ostui/internal/ui/common"
 	"ostui/internal/ui/uiconst"
 )
@@ -14,11 +14,11 @@ import (
 func DeleteVolume(sc client.StorageClient, volumeID string) string {
 	err := sc.DeleteVolume(volumeID)
 	if err != nil {
-		cols := []table.Column{{Title: "Error", Width: uiconst.ColWidthError}}
+		cols := []table.Column{{Title: i18n.T("Error"), Width: uiconst.ColWidthError}}
 		rows := []table.Row{{"Failed to delete volume: " + err.Error()}}
 		return common.NewTable(cols, rows).View()
 	}
-	return fmt.Sprintf("Volume %s deleted successfully.", volumeID)
+	return i18n.T("Volume %s deleted successfully.", volumeID)
 }
 
 // CreateSnapshot creates a snapshot for the given volume ID with the provided name.
@@ -27,12 +27,12 @@ func CreateSnapshot(sc client.StorageClient, volumeID, name string) string {
 	opts := snapshots.CreateOpts{VolumeID: volumeID, Name: name}
 	snap, err := sc.CreateSnapshot(opts)
 	if err != nil {
-		cols := []table.Column{{Title: "Error", Width: uiconst.ColWidthError}}
+		cols := []table.Column{{Title: i18n.T("Error"), Width: uiconst.ColWidthError}}
 		rows := []table.Row{{"Failed to create snapshot: " + err.Error()}}
 		return common.NewTable(cols, rows).View()
 	}
 	// Show snapshot details in a table.
-	cols := []table.Column{{Title: "ID", Width: uiconst.ColWidthUUID}, {Title: "Name", Width: uiconst.ColWidthName}, {Title: "VolumeID", Width: uiconst.ColWidthUUID}, {Title: "Status", Width: uiconst.ColWidthStatus}, {Title: "Created", Width: uiconst.ColWidthField}}
+	cols := []table.Column{{Title: "ID", Width: uiconst.ColWidthUUID}, {Title: i18n.T("Name"), Width: uiconst.ColWidthName}, {Title: i18n.T("VolumeID"), Width: uiconst.ColWidthUUID}, {Title: i18n.T("Status"), Width: uiconst.ColWidthStatus}, {Title: i18n.T("Created"), Width: uiconst.ColWidthField}}
 	rows := []table.Row{{snap.ID, snap.Name, snap.VolumeID, snap.Status, snap.CreatedAt.Format("2006-01-02 15:04:05")}}
 	return common.NewTable(cols, rows).View()
 }
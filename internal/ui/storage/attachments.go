@@ -0,0 +1,74 @@
+package storage
+
+import (
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/gophercloud/gophercloud/openstack/blockstorage/v3/volumes"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/common"
+	"ostui/internal/ui/uiconst"
+)
+
+// attachedBadge summarises a volume's attachments for list views: "-" when
+// detached, the server count otherwise, and a MULTI badge when the volume is
+// attached to more than one server.
+func attachedBadge(v volumes.Volume) string {
+	switch n := len(v.Attachments); {
+	case n == 0:
+		return "-"
+	case n == 1:
+		return "1"
+	default:
+		return i18n.T("MULTI(%d)", n)
+	}
+}
+
+// newAttachmentsTable lists every attachment of a volume.
+func newAttachmentsTable(v volumes.Volume) table.Model {
+	cols := []table.Column{{Title: i18n.T("Server ID"), Width: uiconst.ColWidthUUID}, {Title: i18n.T("Device"), Width: uiconst.ColWidthName}, {Title: i18n.T("Host"), Width: uiconst.ColWidthName}, {Title: i18n.T("Attached"), Width: uiconst.ColWidthField}}
+	rows := []table.Row{}
+	for _, a := range v.Attachments {
+		attached := ""
+		if !a.AttachedAt.IsZero() {
+			attached = a.AttachedAt.Format("2006-01-02 15:04:05")
+		}
+		rows = append(rows, table.Row{a.ServerID, a.Device, a.HostName, attached})
+	}
+	t := table.New(table.WithColumns(cols), table.WithRows(rows), table.WithHeight(len(rows)+1))
+	t.SetStyles(table.DefaultStyles())
+	return t
+}
+
+// attachmentsHeader is the title line above the attachments table.
+func attachmentsHeader(v volumes.Volume) string {
+	if len(v.Attachments) > 1 {
+		return i18n.T("Attachments (%d) – MULTI-ATTACH: detaching or migrating affects every server below", len(v.Attachments))
+	}
+	return i18n.T("Attachments (%d)", len(v.Attachments))
+}
+
+// newAttachmentPicker lists the servers v is attached to, to open one.
+func newAttachmentPicker(v volumes.Volume) common.PickerModel {
+	cols := []common.PickerColumn{{Title: i18n.T("Server ID"), Width: 36}, {Title: i18n.T("Device"), Width: 12}, {Title: i18n.T("Host"), Width: 24}}
+	items := make([]common.PickerItem, 0, len(v.Attachments))
+	for _, a := range v.Attachments {
+		items = append(items, common.PickerItem{ID: a.ServerID, Cells: []string{a.ServerID, a.Device, a.HostName}})
+	}
+	return common.NewPicker("Open a server of "+volumeName(v), cols, func() ([]common.PickerItem, error) {
+		return items, nil
+	})
+}
+
+// openAttachment opens the server v is attached to, or a picker of its
+// servers when it has several.
+func (m VolumeDetailModel) openAttachment() (tea.Model, tea.Cmd) {
+	switch len(m.volume.Attachments) {
+	case 0:
+		return m, nil
+	case 1:
+		return m, common.OpenResource(common.KindServer, m.volume.Attachments[0].ServerID)
+	}
+	picker := newAttachmentPicker(m.volume)
+	m.picker, m.pickServer = &picker, true
+	return m, picker.Init()
+}
@@ -0,0 +1,170 @@
+package storage
+
+import (
+	"strings"
+
+	"github.com/gophercloud/gophercloud/openstack/blockstorage/v3/snapshots"
+	"github.com/gophercloud/gophercloud/openstack/blockstorage/v3/volumes"
+	"ostui/internal/client"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/theme"
+)
+
+// Tree characters
+const (
+	branch     = "├── "
+	lastBranch = "└── "
+	indent     = "│   "
+	blank      = "    "
+)
+
+// lineageData is what the lineage tree is built from: the volume and
+// snapshot lists the detail views load anyway.
+type lineageData struct {
+	volumes   []volumes.Volume
+	snapshots []snapshots.Snapshot
+}
+
+// loadLineage lists volumes and snapshots for the lineage tree. The tree is
+// an extra, so a failing list leaves it empty rather than failing the view.
+func loadLineage(sc client.StorageClient) lineageData {
+	vols, _ := sc.ListVolumes()
+	snaps, _ := sc.ListSnapshots()
+	return lineageData{volumes: vols, snapshots: snaps}
+}
+
+// rootOf returns the volume a lineage starts from: the source volume of the
+// snapshot a volume was restored from, or the volume it was cloned from.
+func (d lineageData) rootOf(volumeID string) string {
+	seen := map[string]bool{}
+	for !seen[volumeID] {
+		seen[volumeID] = true
+		v, ok := d.volume(volumeID)
+		if !ok {
+			break
+		}
+		switch {
+		case v.SnapshotID != "":
+			s, ok := d.snapshot(v.SnapshotID)
+			if !ok {
+				return volumeID
+			}
+			volumeID = s.VolumeID
+		case v.SourceVolID != "":
+			volumeID = v.SourceVolID
+		default:
+			return volumeID
+		}
+	}
+	return volumeID
+}
+
+func (d lineageData) volume(id string) (volumes.Volume, bool) {
+	for _, v := range d.volumes {
+		if v.ID == id {
+			return v, true
+		}
+	}
+	return volumes.Volume{}, false
+}
+
+func (d lineageData) snapshot(id string) (snapshots.Snapshot, bool) {
+	for _, s := range d.snapshots {
+		if s.ID == id {
+			return s, true
+		}
+	}
+	return snapshots.Snapshot{}, false
+}
+
+// renderLineage draws the tree rooted at volumeID: its snapshots, the volumes
+// restored from each snapshot and the volumes cloned from it, recursively.
+// The node with id current is marked. It returns "" when the volume has
+// neither snapshots nor derived volumes, as there is nothing to show.
+func renderLineage(d lineageData, volumeID, current string) string {
+	var b strings.Builder
+	seen := map[string]bool{}
+	var walk func(volumeID, prefix string)
+	walk = func(volumeID, prefix string) {
+		seen[volumeID] = true
+		var snaps []snapshots.Snapshot
+		for _, s := range d.snapshots {
+			if s.VolumeID == volumeID {
+				snaps = append(snaps, s)
+			}
+		}
+		var clones []volumes.Volume
+		for _, v := range d.volumes {
+			if v.SourceVolID == volumeID && v.SnapshotID == "" && !seen[v.ID] {
+				clones = append(clones, v)
+			}
+		}
+		n := len(snaps) + len(clones)
+		child := func(i int) (string, string) {
+			if i == n-1 {
+				return prefix + lastBranch, prefix + blank
+			}
+			return prefix + branch, prefix + indent
+		}
+		for i, s := range snaps {
+			line, next := child(i)
+			b.WriteString(line + snapshotLabel(s, current) + "\n")
+			var restored []volumes.Volume
+			for _, v := range d.volumes {
+				if v.SnapshotID == s.ID && !seen[v.ID] {
+					restored = append(restored, v)
+				}
+			}
+			for j, v := range restored {
+				if j == len(restored)-1 {
+					b.WriteString(next + lastBranch + volumeLabel(v, current) + "\n")
+					walk(v.ID, next+blank)
+				} else {
+					b.WriteString(next + branch + volumeLabel(v, current) + "\n")
+					walk(v.ID, next+indent)
+				}
+			}
+		}
+		for i, v := range clones {
+			line, next := child(len(snaps) + i)
+			b.WriteString(line + "clone " + volumeLabel(v, current) + "\n")
+			walk(v.ID, next)
+		}
+	}
+	walk(volumeID, "")
+	if b.Len() == 0 {
+		return ""
+	}
+	root := i18n.T("volume %s (deleted)", volumeID)
+	if v, ok := d.volume(volumeID); ok {
+		root = volumeLabel(v, current)
+	}
+	return root + "\n" + b.String()
+}
+
+func volumeLabel(v volumes.Volume, current string) string {
+	name := v.Name
+	if name == "" {
+		name = v.ID
+	}
+	label := i18n.T("volume %s  %d GB  %s", name, v.Size, theme.Mark(v.Status))
+	if len(v.Attachments) > 0 {
+		label += "  attached"
+	}
+	if v.ID == current {
+		label += "  ◀"
+	}
+	return label
+}
+
+func snapshotLabel(s snapshots.Snapshot, current string) string {
+	name := s.Name
+	if name == "" {
+		name = s.ID
+	}
+	label := i18n.T("snapshot %s  %d GB  %s", name, s.Size, theme.Mark(s.Status))
+	if s.ID == current {
+		label += "  ◀"
+	}
+	return label
+}
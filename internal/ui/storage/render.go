@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"github.com/charmbracelet/bubbles/table"
 	"ostui/internal/client"
+	"ostui/internal/i18n"
 	"ostui/internal/ui/common"
+	"ostui/internal/ui/theme"
 	"ostui/internal/ui/uiconst"
 )
 
@@ -12,12 +14,12 @@ import (
 func RenderVolumes(sc client.StorageClient) string {
 	volList, err := sc.ListVolumes()
 	if err != nil {
-		return fmt.Sprintf("Failed to list volumes: %s", err)
+		return i18n.T("Failed to list volumes: %s", err)
 	}
-	cols := []table.Column{{Title: "ID", Width: uiconst.ColWidthUUID}, {Title: "Name", Width: uiconst.ColWidthName}, {Title: "Size", Width: uiconst.ColWidthSize}, {Title: "Status", Width: uiconst.ColWidthStatus}}
+	cols := []table.Column{{Title: "ID", Width: uiconst.ColWidthUUID}, {Title: i18n.T("Name"), Width: uiconst.ColWidthName}, {Title: i18n.T("Size"), Width: uiconst.ColWidthSize}, {Title: i18n.T("Status"), Width: uiconst.ColWidthStatus}, {Title: i18n.T("Attached"), Width: uiconst.ColWidthType}}
 	rows := []table.Row{}
 	for _, v := range volList {
-		rows = append(rows, table.Row{v.ID, v.Name, fmt.Sprintf("%d", v.Size), v.Status})
+		rows = append(rows, table.Row{v.ID, v.Name, fmt.Sprintf("%d", v.Size), theme.Mark(v.Status), attachedBadge(v)})
 	}
 	t := table.New(
 		table.WithColumns(cols),
@@ -33,28 +35,33 @@ func RenderVolumes(sc client.StorageClient) string {
 func RenderVolumeDetail(sc client.StorageClient, volumeID string) string {
 	vol, err := sc.GetVolume(volumeID)
 	if err != nil {
-		return fmt.Sprintf("Failed to get volume: %s", err)
+		return i18n.T("Failed to get volume: %s", err)
 	}
 	fields := map[string]string{
-		"ID":          vol.ID,
-		"Name":        vol.Name,
-		"Size":        fmt.Sprintf("%d", vol.Size),
-		"Status":      vol.Status,
-		"Description": vol.Description,
+		"ID":                  vol.ID,
+		i18n.T("Name"):        vol.Name,
+		i18n.T("Size"):        fmt.Sprintf("%d", vol.Size),
+		i18n.T("Status"):      vol.Status,
+		i18n.T("Description"): vol.Description,
+		i18n.T("Attached"):    attachedBadge(vol),
 	}
-	return common.NewDetail("Volume Details", fields).View()
+	out := common.NewDetail(i18n.T("Volume Details"), fields).View()
+	if len(vol.Attachments) > 0 {
+		out += "\n" + attachmentsHeader(vol) + "\n" + newAttachmentsTable(vol).View()
+	}
+	return out
 }
 
 // RenderSnapshots returns a string representation of the list of snapshots.
 func RenderSnapshots(sc client.StorageClient) string {
 	snapList, err := sc.ListSnapshots()
 	if err != nil {
-		return fmt.Sprintf("Failed to list snapshots: %s", err)
+		return i18n.T("Failed to list snapshots: %s", err)
 	}
-	cols := []table.Column{{Title: "ID", Width: uiconst.ColWidthUUID}, {Title: "Name", Width: uiconst.ColWidthName}, {Title: "VolumeID", Width: uiconst.ColWidthUUID}, {Title: "Size", Width: uiconst.ColWidthSize}, {Title: "Status", Width: uiconst.ColWidthStatus}, {Title: "Created", Width: uiconst.ColWidthField}}
+	cols := []table.Column{{Title: "ID", Width: uiconst.ColWidthUUID}, {Title: i18n.T("Name"), Width: uiconst.ColWidthName}, {Title: i18n.T("VolumeID"), Width: uiconst.ColWidthUUID}, {Title: i18n.T("Size"), Width: uiconst.ColWidthSize}, {Title: i18n.T("Status"), Width: uiconst.ColWidthStatus}, {Title: i18n.T("Created"), Width: uiconst.ColWidthField}}
 	rows := []table.Row{}
 	for _, snap := range snapList {
-		rows = append(rows, table.Row{snap.ID, snap.Name, snap.VolumeID, fmt.Sprintf("%d", snap.Size), snap.Status, snap.CreatedAt.Format("2006-01-02 15:04:05")})
+		rows = append(rows, table.Row{snap.ID, snap.Name, snap.VolumeID, fmt.Sprintf("%d", snap.Size), theme.Mark(snap.Status), snap.CreatedAt.Format("2006-01-02 15:04:05")})
 	}
 	t := table.New(
 		table.WithColumns(cols),
@@ -70,9 +77,9 @@ func RenderSnapshots(sc client.StorageClient) string {
 func RenderBuckets(osc client.ObjectStorageClient) string {
 	bucketList, err := osc.ListBuckets()
 	if err != nil {
-		return fmt.Sprintf("Failed to list buckets: %s", err)
+		return i18n.T("Failed to list buckets: %s", err)
 	}
-	cols := []table.Column{{Title: "Name", Width: uiconst.ColWidthName}, {Title: "Count", Width: uiconst.ColWidthSize}, {Title: "Bytes", Width: uiconst.ColWidthStatus}}
+	cols := []table.Column{{Title: i18n.T("Name"), Width: uiconst.ColWidthName}, {Title: i18n.T("Count"), Width: uiconst.ColWidthSize}, {Title: i18n.T("Bytes"), Width: uiconst.ColWidthStatus}}
 	rows := []table.Row{}
 	for _, b := range bucketList {
 		rows = append(rows, table.Row{b.Name, fmt.Sprintf("%d", b.Count), fmt.Sprintf("%d", b.Bytes)})
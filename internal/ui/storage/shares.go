@@ -0,0 +1,604 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/table"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"ostui/internal/client"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/common"
+	"ostui/internal/ui/policy"
+	"ostui/internal/ui/theme"
+	"ostui/internal/ui/uiconst"
+)
+
+// shareProtocols are the protocols offered by the new share form.
+var shareProtocols = []string{"CEPHFS", "NFS", "CIFS", "GLUSTERFS", "HDFS", "MAPRFS"}
+
+// shareAccessTypes are the Manila access rule types.
+var shareAccessTypes = []string{"ip", "cephx", "user", "cert"}
+
+// shareChangeMsg reports the outcome of a create, delete, grant or revoke.
+type shareChangeMsg struct {
+	status string
+	// action names a failed change as checked with policy.Check.
+	action string
+	err    error
+}
+
+// shareStatusLine renders the outcome of the last change, or the
+// confirmation prompt while one is pending.
+func shareStatusLine(status string, statusErr bool, prompt string) string {
+	switch {
+	case prompt != "":
+		return "\n" + prompt + " [y/N]"
+	case statusErr:
+		return "\n" + lipgloss.NewStyle().Foreground(theme.Error).Render(status)
+	case status != "":
+		return "\n" + lipgloss.NewStyle().Foreground(theme.OK).Render(status)
+	}
+	return ""
+}
+
+// SharesModel lists the Manila shares of the project.
+type SharesModel struct {
+	table   table.Model
+	loading bool
+	err     error
+	spinner spinner.Model
+	client  client.SharedFSClient
+	shares  []client.Share
+
+	form          *common.FormModel
+	pendingDelete string
+	status        string
+	statusErr     bool
+
+	width  int
+	height int
+}
+
+// NewSharesModel creates the share list.
+func NewSharesModel(sfs client.SharedFSClient) SharesModel {
+	s := spinner.New()
+	s.Spinner = spinner.Dot
+	return SharesModel{client: sfs, loading: true, spinner: s, width: 120, height: 30}
+}
+
+type sharesLoadedMsg struct {
+	shares []client.Share
+	err    error
+}
+
+// Init loads the shares.
+func (m SharesModel) Init() tea.Cmd {
+	return tea.Batch(m.spinner.Tick, m.loadCmd())
+}
+
+// Reload re-fetches the shares, keeping the selected row.
+func (m SharesModel) Reload() tea.Cmd {
+	if m.loading {
+		return nil
+	}
+	return m.loadCmd()
+}
+
+func (m SharesModel) loadCmd() tea.Cmd {
+	sfs := m.client
+	return func() tea.Msg {
+		shares, err := sfs.ListShares(context.Background())
+		if err != nil {
+			return sharesLoadedMsg{err: fmt.Errorf("%w (is the Shared File Systems service available?)", err)}
+		}
+		return sharesLoadedMsg{shares: shares}
+	}
+}
+
+// Update handles messages for the model.
+func (m SharesModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case sharesLoadedMsg:
+		m.loading = false
+		m.err = msg.err
+		if msg.err != nil {
+			return m, nil
+		}
+		m.shares = msg.shares
+		rows := make([]table.Row, 0, len(msg.shares))
+		for _, s := range msg.shares {
+			rows = append(rows, table.Row{s.ID, s.Name, s.ShareProto, fmt.Sprintf("%d", s.Size), s.ShareTypeName, s.Status})
+		}
+		prev := m.table
+		m.table = table.New(table.WithFocused(true))
+		m.table.SetStyles(table.DefaultStyles())
+		m.updateTableColumns()
+		m.table.SetRows(rows)
+		m.table = common.Reloaded(prev, m.table)
+		return m, nil
+	case shareChangeMsg:
+		m.status, m.statusErr = msg.status, msg.err != nil
+		if msg.err != nil {
+			policy.Record(msg.action, msg.err)
+			m.status = msg.err.Error()
+			return m, nil
+		}
+		return m, m.loadCmd()
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		if m.table.Columns() != nil {
+			m.updateTableColumns()
+		}
+		return m, nil
+	case tea.KeyMsg:
+		if m.form != nil {
+			return m.updateForm(msg)
+		}
+		if m.pendingDelete != "" {
+			id := m.pendingDelete
+			m.pendingDelete = ""
+			if msg.String() != "y" {
+				return m, nil
+			}
+			sfs := m.client
+			return m, func() tea.Msg {
+				err := sfs.DeleteShare(context.Background(), id)
+				return shareChangeMsg{status: i18n.T("Deleting share %s", id), err: err}
+			}
+		}
+		if m.loading || m.err != nil {
+			return m, nil
+		}
+		switch msg.String() {
+		case "n":
+			if err := policy.Check(policy.Member, "creating a share"); err != nil {
+				m.status, m.statusErr = err.Error(), true
+				return m, nil
+			}
+			f := common.NewForm([]string{i18n.T("Name"), i18n.T("Protocol (%s)", strings.Join(shareProtocols, ", ")), i18n.T("Size (GB)"), i18n.T("Share type (empty = default)"), i18n.T("Description")})
+			f.SetValue(1, "CEPHFS")
+			m.form = &f
+			return m, f.Init()
+		case "x":
+			if row := m.table.SelectedRow(); len(row) > 0 {
+				m.pendingDelete = row[0]
+			}
+			return m, nil
+		case "r":
+			m.loading = true
+			return m, tea.Batch(m.spinner.Tick, m.loadCmd())
+		}
+		var cmd tea.Cmd
+		m.table, cmd = m.table.Update(msg)
+		return m, cmd
+	default:
+		if m.loading {
+			var cmd tea.Cmd
+			m.spinner, cmd = m.spinner.Update(msg)
+			return m, cmd
+		}
+	}
+	return m, nil
+}
+
+// shareFromForm validates the new share form values.
+func shareFromForm(v []string) (client.ShareCreateInput, error) {
+	in := client.ShareCreateInput{Name: v[0], ShareProto: strings.ToUpper(v[1]), ShareType: v[3], Description: v[4]}
+	known := false
+	for _, p := range shareProtocols {
+		known = known || in.ShareProto == p
+	}
+	if !known {
+		return in, fmt.Errorf("protocol must be one of %s", strings.Join(shareProtocols, ", "))
+	}
+	size, err := strconv.Atoi(v[2])
+	if err != nil || size < 1 {
+		return in, errors.New("size must be a whole number of GB, at least 1")
+	}
+	in.Size = size
+	return in, nil
+}
+
+// updateForm handles keys for the new share form.
+func (m SharesModel) updateForm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	fm, cmd := m.form.Update(msg)
+	f := fm.(common.FormModel)
+	m.form = &f
+	switch {
+	case f.Cancelled():
+		m.form = nil
+	case f.Submitted():
+		in, err := shareFromForm(f.Values())
+		if err != nil {
+			m.form.SetError(err)
+			return m, nil
+		}
+		m.form = nil
+		sfs := m.client
+		return m, func() tea.Msg {
+			created, err := sfs.CreateShare(context.Background(), in)
+			if err != nil {
+				return shareChangeMsg{action: "creating a share", err: err}
+			}
+			return shareChangeMsg{status: i18n.T("Created share %s (%s)", created.ID, created.Status)}
+		}
+	}
+	return m, cmd
+}
+
+// CapturingInput reports whether the form or the delete prompt is open.
+func (m SharesModel) CapturingInput() bool { return m.form != nil || m.pendingDelete != "" }
+
+// ConfirmingDestructive reports whether a share delete waits for y.
+func (m SharesModel) ConfirmingDestructive() bool { return m.pendingDelete != "" }
+
+// Selected returns the share under the cursor.
+func (m SharesModel) Selected() (client.Share, bool) {
+	row := m.table.SelectedRow()
+	if len(row) == 0 {
+		return client.Share{}, false
+	}
+	for _, s := range m.shares {
+		if s.ID == row[0] {
+			return s, true
+		}
+	}
+	return client.Share{}, false
+}
+
+// View renders the list, the form or the delete prompt.
+func (m SharesModel) View() string {
+	if m.form != nil {
+		return i18n.T("New share") + "\n\n" + m.form.View() + "\n" + i18n.T("[tab] next field  [enter] next/create  [esc] cancel")
+	}
+	if m.loading {
+		return m.spinner.View()
+	}
+	if m.err != nil {
+		return i18n.T("Error: %s", m.err)
+	}
+	prompt := ""
+	if m.pendingDelete != "" {
+		prompt = "Delete share " + m.pendingDelete + " and its data?"
+	}
+	out := m.table.View() + shareStatusLine(m.status, m.statusErr, prompt)
+	return out + "\n" + i18n.T("[enter] exports & access") + "  " + policy.Key(policy.Member, i18n.T("[n] new share"), "creating a share") + "  " + i18n.T("[x] delete  [r] refresh")
+}
+
+func (m *SharesModel) updateTableColumns() {
+	idW := uiconst.ColWidthUUID
+	protoW := 10
+	sizeW := uiconst.ColWidthSize
+	statusW := uiconst.ColWidthStatus
+	rest := m.width - idW - protoW - sizeW - statusW - uiconst.TableHeightOffset
+	if rest < 30 {
+		rest = 30
+	}
+	m.table.SetColumns([]table.Column{{Title: "ID", Width: idW}, {Title: i18n.T("Name"), Width: rest * 2 / 3}, {Title: i18n.T("Protocol"), Width: protoW}, {Title: i18n.T("Size"), Width: sizeW}, {Title: i18n.T("Type"), Width: rest - rest*2/3}, {Title: i18n.T("Status"), Width: statusW}})
+	m.table.SetHeight(m.height - uiconst.TableHeightOffset - 2)
+}
+
+// Table returns the underlying table.
+func (m SharesModel) Table() table.Model { return m.table }
+
+// ShareDetailModel shows the export locations of a share and manages its
+// access rules.
+type ShareDetailModel struct {
+	table   table.Model
+	loading bool
+	err     error
+	spinner spinner.Model
+	client  client.SharedFSClient
+	share   client.Share
+	exports []client.ShareExportLocation
+	rules   []client.ShareAccessRule
+
+	form          *common.FormModel
+	pendingRevoke string
+	status        string
+	statusErr     bool
+
+	width  int
+	height int
+}
+
+// NewShareDetailModel creates the detail view of a share.
+func NewShareDetailModel(sfs client.SharedFSClient, share client.Share) ShareDetailModel {
+	s := spinner.New()
+	s.Spinner = spinner.Dot
+	return ShareDetailModel{client: sfs, share: share, loading: true, spinner: s, width: 120, height: 30}
+}
+
+type shareDetailLoadedMsg struct {
+	share   *client.Share
+	exports []client.ShareExportLocation
+	rules   []client.ShareAccessRule
+	err     error
+}
+
+// Init loads the share, its export locations and access rules.
+func (m ShareDetailModel) Init() tea.Cmd {
+	return tea.Batch(m.spinner.Tick, m.loadCmd())
+}
+
+// Reload re-fetches the share and its access rules, keeping the selected
+// rule.
+func (m ShareDetailModel) Reload() tea.Cmd {
+	if m.loading {
+		return nil
+	}
+	return m.loadCmd()
+}
+
+func (m ShareDetailModel) loadCmd() tea.Cmd {
+	sfs, id := m.client, m.share.ID
+	return func() tea.Msg {
+		ctx := context.Background()
+		share, err := sfs.GetShare(ctx, id)
+		if err != nil {
+			return shareDetailLoadedMsg{err: err}
+		}
+		exports, err := sfs.ListExportLocations(ctx, id)
+		if err != nil {
+			return shareDetailLoadedMsg{err: fmt.Errorf("export locations: %w", err)}
+		}
+		rules, err := sfs.ListAccessRules(ctx, id)
+		if err != nil {
+			return shareDetailLoadedMsg{err: fmt.Errorf("access rules: %w", err)}
+		}
+		return shareDetailLoadedMsg{share: share, exports: exports, rules: rules}
+	}
+}
+
+// ResourceID returns the share ID.
+func (m ShareDetailModel) ResourceID() string { return m.share.ID }
+
+// ResourceName returns the share name.
+func (m ShareDetailModel) ResourceName() string { return m.share.Name }
+
+// Update handles messages for the model.
+func (m ShareDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case shareDetailLoadedMsg:
+		m.loading = false
+		m.err = msg.err
+		if msg.err != nil {
+			return m, nil
+		}
+		m.share = *msg.share
+		m.exports = msg.exports
+		m.rules = msg.rules
+		rows := make([]table.Row, 0, len(msg.rules))
+		for _, r := range msg.rules {
+			rows = append(rows, table.Row{r.ID, r.AccessType, r.AccessTo, r.AccessLevel, r.State})
+		}
+		prev := m.table
+		m.table = table.New(table.WithFocused(true))
+		m.table.SetStyles(table.DefaultStyles())
+		m.updateTableColumns()
+		m.table.SetRows(rows)
+		m.table = common.Reloaded(prev, m.table)
+		return m, nil
+	case shareChangeMsg:
+		m.status, m.statusErr = msg.status, msg.err != nil
+		if msg.err != nil {
+			policy.Record(msg.action, msg.err)
+			m.status = msg.err.Error()
+			return m, nil
+		}
+		return m, m.loadCmd()
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		if m.table.Columns() != nil {
+			m.updateTableColumns()
+		}
+		return m, nil
+	case tea.KeyMsg:
+		if m.form != nil {
+			return m.updateForm(msg)
+		}
+		if m.pendingRevoke != "" {
+			id := m.pendingRevoke
+			m.pendingRevoke = ""
+			if msg.String() != "y" {
+				return m, nil
+			}
+			sfs, shareID := m.client, m.share.ID
+			return m, func() tea.Msg {
+				err := sfs.RevokeAccess(context.Background(), shareID, id)
+				return shareChangeMsg{status: i18n.T("Revoked access rule %s", id), err: err}
+			}
+		}
+		if m.loading || m.err != nil {
+			return m, nil
+		}
+		switch msg.String() {
+		case "a":
+			if err := policy.Check(policy.Member, "granting access"); err != nil {
+				m.status, m.statusErr = err.Error(), true
+				return m, nil
+			}
+			f := common.NewForm([]string{i18n.T("Access type (%s)", strings.Join(shareAccessTypes, ", ")), i18n.T("Access to (IP/CIDR, cephx user, ...)"), i18n.T("Level (rw, ro)")})
+			f.SetValue(0, "ip")
+			if strings.EqualFold(m.share.ShareProto, "CEPHFS") {
+				f.SetValue(0, "cephx")
+			}
+			f.SetValue(2, "rw")
+			m.form = &f
+			return m, f.Init()
+		case "x":
+			if row := m.table.SelectedRow(); len(row) > 0 {
+				m.pendingRevoke = row[0]
+			}
+			return m, nil
+		case "r":
+			m.loading = true
+			return m, tea.Batch(m.spinner.Tick, m.loadCmd())
+		}
+		var cmd tea.Cmd
+		m.table, cmd = m.table.Update(msg)
+		return m, cmd
+	default:
+		if m.loading {
+			var cmd tea.Cmd
+			m.spinner, cmd = m.spinner.Update(msg)
+			return m, cmd
+		}
+	}
+	return m, nil
+}
+
+// accessRuleFromForm validates the grant access form values and returns the
+// access type, target and level.
+func accessRuleFromForm(v []string) (string, string, string, error) {
+	typ, to, level := strings.ToLower(v[0]), v[1], strings.ToLower(v[2])
+	known := false
+	for _, t := range shareAccessTypes {
+		known = known || typ == t
+	}
+	switch {
+	case !known:
+		return "", "", "", fmt.Errorf("access type must be one of %s", strings.Join(shareAccessTypes, ", "))
+	case to == "":
+		return "", "", "", errors.New("access to is required")
+	case level != "rw" && level != "ro":
+		return "", "", "", errors.New("level must be rw or ro")
+	}
+	if typ == "ip" && net.ParseIP(to) == nil {
+		if _, _, err := net.ParseCIDR(to); err != nil {
+			return "", "", "", fmt.Errorf("%q is not an IP address or CIDR", to)
+		}
+	}
+	return typ, to, level, nil
+}
+
+// updateForm handles keys for the grant access form.
+func (m ShareDetailModel) updateForm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	fm, cmd := m.form.Update(msg)
+	f := fm.(common.FormModel)
+	m.form = &f
+	switch {
+	case f.Cancelled():
+		m.form = nil
+	case f.Submitted():
+		typ, to, level, err := accessRuleFromForm(f.Values())
+		if err != nil {
+			m.form.SetError(err)
+			return m, nil
+		}
+		m.form = nil
+		sfs, shareID := m.client, m.share.ID
+		return m, func() tea.Msg {
+			r, err := sfs.GrantAccess(context.Background(), shareID, typ, to, level)
+			if err != nil {
+				return shareChangeMsg{action: "granting access", err: err}
+			}
+			return shareChangeMsg{status: i18n.T("Granted %s access to %s (%s)", r.AccessLevel, r.AccessTo, r.State)}
+		}
+	}
+	return m, cmd
+}
+
+// CapturingInput reports whether the form or the revoke prompt is open.
+func (m ShareDetailModel) CapturingInput() bool { return m.form != nil || m.pendingRevoke != "" }
+
+// ConfirmingDestructive reports whether an access rule revoke waits for y.
+func (m ShareDetailModel) ConfirmingDestructive() bool { return m.pendingRevoke != "" }
+
+// selectedRule returns the access rule under the cursor.
+func (m ShareDetailModel) selectedRule() (client.ShareAccessRule, bool) {
+	row := m.table.SelectedRow()
+	if len(row) == 0 {
+		return client.ShareAccessRule{}, false
+	}
+	for _, r := range m.rules {
+		if r.ID == row[0] {
+			return r, true
+		}
+	}
+	return client.ShareAccessRule{}, false
+}
+
+// View renders the export locations and the access rules.
+func (m ShareDetailModel) View() string {
+	title := lipgloss.NewStyle().Bold(true).Foreground(theme.Accent).Render
+	dim := lipgloss.NewStyle().Foreground(theme.Muted).Render
+	head := title("Share "+m.share.Name) + dim(fmt.Sprintf("  %s, %d GB, %s", m.share.ShareProto, m.share.Size, m.share.Status)) + "\n"
+	if m.form != nil {
+		return head + "\nGrant access to the share\n\n" + m.form.View() +
+			"\n" + i18n.T("[tab] next field  [enter] next/grant  [esc] cancel")
+	}
+	if m.loading {
+		return head + m.spinner.View()
+	}
+	if m.err != nil {
+		return head + i18n.T("Error: %s", m.err)
+	}
+
+	var b strings.Builder
+	b.WriteString(head + "\nExport locations\n")
+	if len(m.exports) == 0 {
+		b.WriteString(dim("  "+i18n.T("none yet – the share is not exported")) + "\n")
+	}
+	for _, e := range m.exports {
+		var tags []string
+		if e.Preferred {
+			tags = append(tags, "preferred")
+		}
+		if e.IsAdminOnly {
+			tags = append(tags, i18n.T("admin only"))
+		}
+		line := "  " + e.Path
+		if len(tags) > 0 {
+			line += dim("  (" + strings.Join(tags, ", ") + ")")
+		}
+		b.WriteString(line + "\n")
+	}
+	b.WriteString("\n" + i18n.T("Access rules") + "\n")
+	if len(m.rules) == 0 {
+		b.WriteString(dim("  " + i18n.T("none – nobody can mount the share")))
+	} else {
+		b.WriteString(m.table.View())
+		if r, ok := m.selectedRule(); ok && r.AccessKey != "" {
+			b.WriteString("\n" + i18n.T("cephx key of %s: %s", r.AccessTo, r.AccessKey))
+		}
+	}
+	prompt := ""
+	if m.pendingRevoke != "" {
+		prompt = "Revoke access rule " + m.pendingRevoke + "?"
+	}
+	b.WriteString(shareStatusLine(m.status, m.statusErr, prompt))
+	return b.String() + "\n" + policy.Key(policy.Member, i18n.T("[a] grant access"), "granting access") + "  " + i18n.T("[x] revoke  [r] refresh  [esc] back")
+}
+
+func (m *ShareDetailModel) updateTableColumns() {
+	idW := uiconst.ColWidthUUID
+	typeW := 8
+	levelW := 6
+	stateW := uiconst.ColWidthStatus
+	toW := m.width - idW - typeW - levelW - stateW - uiconst.TableHeightOffset
+	if toW < 20 {
+		toW = 20
+	}
+	m.table.SetColumns([]table.Column{{Title: "ID", Width: idW}, {Title: i18n.T("Type"), Width: typeW}, {Title: i18n.T("Access to"), Width: toW}, {Title: i18n.T("Level"), Width: levelW}, {Title: i18n.T("State"), Width: stateW}})
+	h := m.height - uiconst.TableHeightOffset - 6 - len(m.exports)
+	if h < 3 {
+		h = 3
+	}
+	m.table.SetHeight(h)
+}
+
+// Table returns the access rules table.
+func (m ShareDetailModel) Table() table.Model { return m.table }
+
+var (
+	_ tea.Model = (*SharesModel)(nil)
+	_ tea.Model = (*ShareDetailModel)(nil)
+)
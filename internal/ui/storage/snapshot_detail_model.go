@@ -9,6 +9,8 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/gophercloud/gophercloud/openstack/blockstorage/v3/snapshots"
 	"ostui/internal/client"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/common"
 	"ostui/internal/ui/uiconst"
 )
 
@@ -25,6 +27,8 @@ type SnapshotDetailModel struct {
 	// Inspect view fields
 	inspectView     string
 	inspectViewport viewport.Model
+	// lineage is the snapshot tree the snapshot belongs to, or ""
+	lineage string
 	// stored snapshot for JSON marshaling
 	snapshot snapshots.Snapshot
 }
@@ -33,6 +37,7 @@ type snapshotDetailDataLoadedMsg struct {
 	tbl      table.Model
 	err      error
 	snapshot snapshots.Snapshot
+	lineage  string
 }
 
 // NewSnapshotDetailModel creates a new SnapshotDetailModel for the given snapshot ID.
@@ -59,7 +64,7 @@ func (m SnapshotDetailModel) Init() tea.Cmd {
 		if snap == nil {
 			return snapshotDetailDataLoadedMsg{err: fmt.Errorf("snapshot %s not found", m.snapshotID)}
 		}
-		cols := []table.Column{{Title: "Field", Width: uiconst.ColWidthField}, {Title: "Value", Width: uiconst.ColWidthValueShort}, {Title: "Field", Width: uiconst.ColWidthField}, {Title: "Value", Width: uiconst.ColWidthValueShort}}
+		cols := []table.Column{{Title: i18n.T("Field"), Width: uiconst.ColWidthField}, {Title: i18n.T("Value"), Width: uiconst.ColWidthValueShort}, {Title: i18n.T("Field"), Width: uiconst.ColWidthField}, {Title: i18n.T("Value"), Width: uiconst.ColWidthValueShort}}
 		rows := []table.Row{{"ID", snap.ID}, {"Name", snap.Name}, {"VolumeID", snap.VolumeID}, {"Size", fmt.Sprintf("%d", snap.Size)}, {"Status", snap.Status}, {"CreatedAt", snap.CreatedAt.Format("2006-01-02 15:04:05")}}
 		half := (len(rows) + 1) / 2
 		newRows := []table.Row{}
@@ -79,10 +84,20 @@ func (m SnapshotDetailModel) Init() tea.Cmd {
 			table.WithFocused(true),
 		)
 		t.SetStyles(table.DefaultStyles())
-		return snapshotDetailDataLoadedMsg{tbl: t, snapshot: *snap}
+		d := lineageData{snapshots: snapList}
+		d.volumes, _ = m.client.ListVolumes()
+		return snapshotDetailDataLoadedMsg{tbl: t, snapshot: *snap, lineage: renderLineage(d, d.rootOf(snap.VolumeID), snap.ID)}
 	}
 }
 
+// Reload re-fetches the snapshot, keeping the selected row.
+func (m SnapshotDetailModel) Reload() tea.Cmd {
+	if m.loading {
+		return nil
+	}
+	return m.Init()
+}
+
 // Update handles messages.
 func (m SnapshotDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
@@ -92,8 +107,9 @@ func (m SnapshotDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.err = msg.err
 			return m, nil
 		}
-		m.table = msg.tbl
+		m.table = common.Reloaded(m.table, msg.tbl)
 		m.snapshot = msg.snapshot
+		m.lineage = msg.lineage
 		return m, nil
 	case tea.WindowSizeMsg:
 		if m.jsonView != "" {
@@ -150,7 +166,7 @@ func (m SnapshotDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		if msg.String() == "i" {
 			// Build inspect view for snapshot.
-			content := fmt.Sprintf("=== Snapshot: %s ===\nID: %s\nName: %s\nVolumeID: %s\nSize: %d\nStatus: %s\nCreatedAt: %s", m.snapshot.Name, m.snapshot.ID, m.snapshot.Name, m.snapshot.VolumeID, m.snapshot.Size, m.snapshot.Status, m.snapshot.CreatedAt.Format("2006-01-02 15:04:05"))
+			content := i18n.T("=== Snapshot: %s ===\nID: %s\nName: %s\nVolumeID: %s\nSize: %d\nStatus: %s\nCreatedAt: %s", m.snapshot.Name, m.snapshot.ID, m.snapshot.Name, m.snapshot.VolumeID, m.snapshot.Size, m.snapshot.Status, m.snapshot.CreatedAt.Format("2006-01-02 15:04:05"))
 			m.inspectView = content
 			m.inspectViewport = viewport.New(80, 24)
 			m.inspectViewport.SetContent(m.inspectView)
@@ -186,20 +202,35 @@ func (m SnapshotDetailModel) View() string {
 		return m.spinner.View()
 	}
 	if m.inspectView != "" {
-		return fmt.Sprintf("%s\n %3.f%% | [j/k] scroll  [esc] close", m.inspectViewport.View(), m.inspectViewport.ScrollPercent()*100)
+		return i18n.T("%s\n %3.f%% | [j/k] scroll  [esc] close", m.inspectViewport.View(), m.inspectViewport.ScrollPercent()*100)
 	}
 	if m.jsonView != "" {
-		return fmt.Sprintf("%s\nPress 'y' or 'esc' to close", m.jsonViewport.View())
+		return i18n.T("%s\nPress 'y' or 'esc' to close", m.jsonViewport.View())
 	}
 	if m.err != nil {
-		cols := []table.Column{{Title: "Error", Width: uiconst.ColWidthError}}
+		cols := []table.Column{{Title: i18n.T("Error"), Width: uiconst.ColWidthError}}
 		rows := []table.Row{{"Failed to load snapshot: " + m.err.Error()}}
 		return table.New(table.WithColumns(cols), table.WithRows(rows)).View()
 	}
-	return fmt.Sprintf("%s\n[y] json  [i] inspect  [esc] back", m.table.View())
+	body := m.table.View()
+	if m.lineage != "" {
+		body += "\n\nLineage\n" + m.lineage
+	}
+	return i18n.T("%s\n[y] json  [i] inspect  [esc] back", body)
 }
 
 // Table returns the underlying table model.
 func (m SnapshotDetailModel) Table() table.Model { return m.table }
 
+// Actions lists the views of the snapshot for the action palette.
+func (m SnapshotDetailModel) Actions() []common.Action {
+	if m.loading || m.err != nil {
+		return nil
+	}
+	return []common.Action{
+		{Key: "i", Name: "inspect", Help: "Inspect the snapshot"},
+		{Key: "y", Name: "json", Help: "JSON view"},
+	}
+}
+
 var _ tea.Model = (*SnapshotDetailModel)(nil)
@@ -6,7 +6,9 @@ import (
 	"github.com/charmbracelet/bubbles/table"
 	tea "github.com/charmbracelet/bubbletea"
 	"ostui/internal/client"
+	"ostui/internal/i18n"
 	"ostui/internal/ui/common"
+	"ostui/internal/ui/theme"
 	"ostui/internal/ui/uiconst"
 )
 
@@ -39,10 +41,10 @@ func (m SnapshotsModel) Init() tea.Cmd {
 		if err != nil {
 			return snapshotsDataLoadedMsg{err: err}
 		}
-		cols := []table.Column{{Title: "ID", Width: uiconst.ColWidthUUID}, {Title: "Name", Width: uiconst.ColWidthName}, {Title: "VolumeID", Width: uiconst.ColWidthUUID}, {Title: "Size", Width: uiconst.ColWidthProtocol}, {Title: "Status", Width: uiconst.ColWidthStatus}, {Title: "Created", Width: uiconst.ColWidthName}}
+		cols := []table.Column{{Title: "ID", Width: uiconst.ColWidthUUID}, {Title: i18n.T("Name"), Width: uiconst.ColWidthName}, {Title: i18n.T("VolumeID"), Width: uiconst.ColWidthUUID}, {Title: i18n.T("Size"), Width: uiconst.ColWidthProtocol}, {Title: i18n.T("Status"), Width: uiconst.ColWidthStatus}, {Title: i18n.T("Created"), Width: uiconst.ColWidthName}}
 		rows := []table.Row{}
 		for _, s := range snapList {
-			rows = append(rows, table.Row{s.ID, s.Name, s.VolumeID, fmt.Sprintf("%d", s.Size), s.Status, s.CreatedAt.Format("2006-01-02 15:04:05")})
+			rows = append(rows, table.Row{s.ID, s.Name, s.VolumeID, fmt.Sprintf("%d", s.Size), theme.Mark(s.Status), s.CreatedAt.Format("2006-01-02 15:04:05")})
 		}
 		t := table.New(
 			table.WithColumns(cols),
@@ -55,6 +57,14 @@ func (m SnapshotsModel) Init() tea.Cmd {
 	}
 }
 
+// Reload re-fetches the snapshots, keeping the selected row.
+func (m SnapshotsModel) Reload() tea.Cmd {
+	if m.loading {
+		return nil
+	}
+	return m.Init()
+}
+
 // Update handles messages.
 func (m SnapshotsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
@@ -64,7 +74,7 @@ func (m SnapshotsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.err = msg.err
 			return m, nil
 		}
-		m.table = msg.tbl
+		m.table = common.Reloaded(m.table, msg.tbl)
 		m.updateTableColumns()
 		m.table.SetHeight(m.height - uiconst.TableHeightOffset)
 		return m, nil
@@ -99,7 +109,7 @@ func (m SnapshotsModel) View() string {
 		return m.spinner.View()
 	}
 	if m.err != nil {
-		cols := []table.Column{{Title: "Error", Width: uiconst.ColWidthError}}
+		cols := []table.Column{{Title: i18n.T("Error"), Width: uiconst.ColWidthError}}
 		rows := []table.Row{{"Failed to list snapshots: " + m.err.Error()}}
 		return common.NewTable(cols, rows).View()
 	}
@@ -126,7 +136,7 @@ func (m *SnapshotsModel) updateTableColumns() {
 	if createdW < 10 {
 		createdW = 10
 	}
-	m.table.SetColumns([]table.Column{{Title: "ID", Width: idW}, {Title: "Name", Width: nameW}, {Title: "VolumeID", Width: volIDW}, {Title: "Size", Width: sizeW}, {Title: "Status", Width: statusW}, {Title: "Created", Width: createdW}})
+	m.table.SetColumns([]table.Column{{Title: "ID", Width: idW}, {Title: i18n.T("Name"), Width: nameW}, {Title: i18n.T("VolumeID"), Width: volIDW}, {Title: i18n.T("Size"), Width: sizeW}, {Title: i18n.T("Status"), Width: statusW}, {Title: i18n.T("Created"), Width: createdW}})
 }
 
 var _ tea.Model = (*SnapshotsModel)(nil)
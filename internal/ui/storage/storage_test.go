@@ -1,11 +1,20 @@
 package storage
 
 import (
+	"context"
 	"errors"
 	"strings"
 	"testing"
 	"time"
 
+	tea "github.com/charmbracelet/bubbletea"
+	"ostui/internal/client"
+	"ostui/internal/ui/common"
+	"ostui/internal/ui/ops"
+	"ostui/internal/ui/policy"
+
+	"github.com/gophercloud/gophercloud"
+	"github.com/gophercloud/gophercloud/openstack/blockstorage/extensions/quotasets"
 	"github.com/gophercloud/gophercloud/openstack/blockstorage/v3/snapshots"
 	"github.com/gophercloud/gophercloud/openstack/blockstorage/v3/volumes"
 	"github.com/gophercloud/gophercloud/openstack/objectstorage/v1/containers"
@@ -19,12 +28,16 @@ type mockStorageClient struct {
 	getErr error
 
 	deleteErr error
+	deleted   []string
 
 	snapshots []snapshots.Snapshot
 	snapErr   error
 
 	createdSnapshot snapshots.Snapshot
 	createSnapErr   error
+	snapshotOpts    *snapshots.CreateOpts
+
+	updated *volumes.UpdateOpts
 }
 
 func (m *mockStorageClient) ListVolumes() ([]volumes.Volume, error) {
@@ -33,15 +46,38 @@ func (m *mockStorageClient) ListVolumes() ([]volumes.Volume, error) {
 func (m *mockStorageClient) GetVolume(id string) (volumes.Volume, error) {
 	return m.volume, m.getErr
 }
+func (m *mockStorageClient) CreateVolume(opts volumes.CreateOpts) (volumes.Volume, error) {
+	return volumes.Volume{}, nil
+}
 func (m *mockStorageClient) DeleteVolume(id string) error {
+	if m.deleteErr == nil {
+		m.deleted = append(m.deleted, id)
+	}
 	return m.deleteErr
 }
+func (m *mockStorageClient) ForceDeleteVolume(id string) error {
+	m.deleted = append(m.deleted, "force "+id)
+	return nil
+}
+func (m *mockStorageClient) UpdateVolume(id string, opts volumes.UpdateOpts) error {
+	m.updated = &opts
+	return nil
+}
 func (m *mockStorageClient) ListSnapshots() ([]snapshots.Snapshot, error) {
 	return m.snapshots, m.snapErr
 }
 func (m *mockStorageClient) CreateSnapshot(opts snapshots.CreateOptsBuilder) (snapshots.Snapshot, error) {
+	if o, ok := opts.(snapshots.CreateOpts); ok {
+		m.snapshotOpts = &o
+	}
 	return m.createdSnapshot, m.createSnapErr
 }
+func (m *mockStorageClient) GetQuotaSet(projectID string) (quotasets.QuotaUsageSet, error) {
+	return quotasets.QuotaUsageSet{}, nil
+}
+func (m *mockStorageClient) UpdateQuotaSet(projectID string, opts quotasets.UpdateOpts) error {
+	return nil
+}
 
 type mockObjectStorageClient struct {
 	buckets   []containers.Container
@@ -150,3 +186,312 @@ func TestCreateSnapshotError(t *testing.T) {
 		t.Fatalf("expected error message, got %s", out)
 	}
 }
+
+func TestRenderVolumeDetailMultiAttach(t *testing.T) {
+	mock := &mockStorageClient{volume: volumes.Volume{ID: "vol-1", Name: "shared", Status: "in-use", Multiattach: true, Attachments: []volumes.Attachment{
+		{ServerID: "srv-a", Device: "/dev/vdb"},
+		{ServerID: "srv-b", Device: "/dev/vdc"},
+	}}}
+	out := RenderVolumeDetail(mock, "vol-1")
+	for _, want := range []string{"MULTI(2)", "MULTI-ATTACH", "srv-a", "srv-b", "/dev/vdc"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output, got %s", want, out)
+		}
+	}
+}
+
+func TestVolumeDetailOpensAttachedServer(t *testing.T) {
+	vol := volumes.Volume{ID: "vol-1", Name: "data", Status: "in-use", Attachments: []volumes.Attachment{{ServerID: "srv-1"}}}
+	mock := &mockStorageClient{volume: vol}
+	var m tea.Model = NewVolumeDetailModel(mock, nil, "vol-1")
+	m, _ = m.Update(m.Init()())
+	if _, cmd := m.Update(common.KeyFor("enter")); cmd != nil {
+		t.Fatal("expected enter to do nothing off the Attached row")
+	}
+	for i := 0; i < 2; i++ {
+		m, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	}
+	_, cmd := m.Update(common.KeyFor("enter"))
+	if cmd == nil {
+		t.Fatal("expected enter on Attached to open the server")
+	}
+	if got, ok := cmd().(common.OpenResourceMsg); !ok || got.Kind != common.KindServer || got.ID != "srv-1" {
+		t.Fatalf("expected the server srv-1 opened, got %+v", got)
+	}
+
+	// With several servers, one is picked first.
+	mock.volume.Attachments = append(mock.volume.Attachments, volumes.Attachment{ServerID: "srv-2"})
+	m, _ = m.Update(m.(VolumeDetailModel).Init()())
+	m, _ = m.Update(common.KeyFor("enter"))
+	if !strings.Contains(m.View(), "Open a server of data") {
+		t.Fatalf("expected the attachment picker, got:\n%s", m.View())
+	}
+}
+
+func TestVolumeDetailLineage(t *testing.T) {
+	vols := []volumes.Volume{
+		{ID: "vol-1", Name: "data", Size: 10, Status: "available"},
+		{ID: "vol-2", Name: "restored", Size: 10, Status: "in-use", SnapshotID: "snap-1"},
+		{ID: "vol-3", Name: "copy", Size: 10, Status: "available", SourceVolID: "vol-1"},
+	}
+	snaps := []snapshots.Snapshot{{ID: "snap-1", Name: "nightly", VolumeID: "vol-1", Size: 10, Status: "available"}, {ID: "snap-2", Name: "weekly", VolumeID: "vol-1", Size: 10, Status: "available"}}
+	mock := &mockStorageClient{volume: vols[1], volumes: vols, snapshots: snaps}
+	m := NewVolumeDetailModel(mock, nil, "vol-2")
+	updated, _ := m.Update(m.Init()())
+	want := strings.Join([]string{
+		"volume data  10 GB  available",
+		"├── snapshot nightly  10 GB  available",
+		"│   └── volume restored  10 GB  in-use  ◀",
+		"├── snapshot weekly  10 GB  available",
+		"└── clone volume copy  10 GB  available",
+	}, "\n")
+	if out := updated.View(); !strings.Contains(out, want) {
+		t.Fatalf("expected the lineage tree, got:\n%s", out)
+	}
+
+	if got := renderLineage(lineageData{volumes: vols, snapshots: snaps}, "vol-3", "vol-3"); got != "" {
+		t.Errorf("expected no tree for a volume without descendants, got:\n%s", got)
+	}
+}
+
+func TestVolumeActionsFollowStatus(t *testing.T) {
+	policy.SetRoles(nil)
+	vol := volumes.Volume{ID: "vol-1", Name: "data", Status: "in-use", Attachments: []volumes.Attachment{{ServerID: "srv-1"}}}
+	mock := &mockStorageClient{volume: vol, createdSnapshot: snapshots.Snapshot{ID: "snap-9"}}
+	var m tea.Model = NewVolumeDetailModel(mock, nil, "vol-1")
+	m, _ = m.Update(m.Init()())
+
+	// Deleting an attached volume is refused before Cinder is called.
+	m, cmd := m.Update(common.KeyFor("d"))
+	if cmd != nil || len(mock.deleted) != 0 || !strings.Contains(m.View(), "detach it first") {
+		t.Fatalf("expected the delete refused with a hint, got:\n%s", m.View())
+	}
+	var names []string
+	for _, a := range m.(VolumeDetailModel).Actions() {
+		names = append(names, a.Name)
+	}
+	if got := strings.Join(names, ","); got != "force delete,snapshot,inspect,json" {
+		t.Errorf("palette actions of an in-use volume = %s", got)
+	}
+
+	// A snapshot of an attached volume is forced.
+	m, _ = m.Update(common.KeyFor("s"))
+	m, cmd = m.Update(common.KeyFor("enter"))
+	m, _ = m.Update(cmd())
+	if o := mock.snapshotOpts; o == nil || !o.Force || o.Name != "data-snap" {
+		t.Fatalf("expected a forced snapshot data-snap, got %+v", o)
+	}
+
+	// A force delete is confirmed and queued, and the volume shows as
+	// deleting.
+	m, _ = m.Update(common.KeyFor("D"))
+	m, cmd = m.Update(common.KeyFor("y"))
+	if dm := m.(VolumeDetailModel); dm.volume.Status != "deleting" || checkVolumeAction(dm.volume, volumeActions[0]) == nil {
+		t.Errorf("the volume should be deleting with the delete disabled, got %q", dm.volume.Status)
+	}
+	enq, ok := cmd().(ops.EnqueueMsg)
+	if !ok || enq.Resource != "vol-1" || enq.Title != "Force delete volume data" {
+		t.Fatalf("expected the force delete queued, got %#v", enq)
+	}
+	_, poll, err := enq.Work(context.Background())
+	if err != nil || len(mock.deleted) != 1 || mock.deleted[0] != "force vol-1" {
+		t.Fatalf("expected a force delete, got %v (%v)", mock.deleted, err)
+	}
+	mock.getErr = gophercloud.ErrDefault404{}
+	if done, status, err := poll(context.Background()); !done || status != "deleted" || err != nil {
+		t.Errorf("expected the delete done once the volume is gone, got %v %q %v", done, status, err)
+	}
+	m, _ = m.Update(ops.FinishedMsg{Title: enq.Title, Resource: "vol-1", Result: "deleted"})
+	if !strings.Contains(m.View(), "Force delete volume data done") {
+		t.Errorf("expected the delete reported, got:\n%s", m.View())
+	}
+}
+
+func TestVolumesAgeFilter(t *testing.T) {
+	now := time.Now()
+	mock := &mockStorageClient{volumes: []volumes.Volume{
+		{ID: "v-old", Name: "backup-2023", Status: "available", CreatedAt: now.AddDate(0, 0, -400), UserID: "u-alice"},
+		{ID: "v-mid", Name: "backup-q2", Status: "available", CreatedAt: now.AddDate(0, 0, -120), UserID: "u-bob"},
+		// Just over 90 days: the cell shows 90d, the filter sees the time.
+		{ID: "v-edge", Name: "edge", Status: "available", CreatedAt: now.Add(-90*24*time.Hour - 2*time.Hour), UserID: "u-carol"},
+		{ID: "v-new", Name: "scratch", Status: "in-use", CreatedAt: now.Add(-5 * time.Hour), UserID: "u-alice"},
+		{ID: "v-unknown", Name: "legacy", Status: "available"},
+	}}
+	var m tea.Model = NewVolumesModel(mock)
+	m, _ = m.Update(m.Init()())
+	if v := m.View(); !strings.Contains(v, "400d") || !strings.Contains(v, "90d") || !strings.Contains(v, "5h") || !strings.Contains(v, "Created by") {
+		t.Fatalf("expected the age and creator columns, got %q", v)
+	}
+	filter := func(q string) []string {
+		var ids []string
+		vm := m.(VolumesModel)
+		vm.table.Filter(q)
+		for _, r := range vm.FullTable().Rows() {
+			ids = append(ids, r[0])
+		}
+		return ids
+	}
+	for q, want := range map[string]string{
+		"age>90d":                "v-old,v-mid,v-edge",
+		"edge age<=90d":          "",
+		"edge age<91d":           "v-edge",
+		"age>1y":                 "v-old",
+		"age<=2d":                "v-new",
+		"backup age<52w":         "v-mid",
+		"u-alice age>9":          "v-old,v-new",
+		"AGE>90d age<200d":       "v-mid,v-edge",
+		"legacy":                 "v-unknown",
+		"age>90d u-bob":          "v-mid",
+		"age>90d nothing-to-see": "",
+	} {
+		if got := strings.Join(filter(q), ","); got != want {
+			t.Errorf("filter %q: got %q, want %q", q, got, want)
+		}
+	}
+}
+
+func TestApplyVolumeEditSendsOnlyChangedFields(t *testing.T) {
+	mock := &mockStorageClient{}
+	original := "name: data\ndescription: old\nmetadata:\n  tier: gold\n"
+	edited := "name: data\ndescription: new\nmetadata:\n  tier: gold\n"
+	status, err := applyVolumeEdit(mock, "vol-1", original, edited)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if status != "Updated description" {
+		t.Errorf("unexpected status %q", status)
+	}
+	if mock.updated == nil || mock.updated.Name != nil || mock.updated.Metadata != nil ||
+		mock.updated.Description == nil || *mock.updated.Description != "new" {
+		t.Errorf("unexpected update opts %+v", mock.updated)
+	}
+}
+
+func TestApplyVolumeEditRejectsUnknownField(t *testing.T) {
+	mock := &mockStorageClient{}
+	_, err := applyVolumeEdit(mock, "vol-1", "name: data\n", "name: data\nsize: 20\n")
+	if err == nil || !strings.Contains(err.Error(), "invalid YAML") {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+	if mock.updated != nil {
+		t.Errorf("no update expected on invalid input")
+	}
+}
+
+type mockSharedFSClient struct {
+	shares []client.Share
+	rules  []client.ShareAccessRule
+}
+
+func (m *mockSharedFSClient) ListShares(ctx context.Context) ([]client.Share, error) {
+	return m.shares, nil
+}
+func (m *mockSharedFSClient) GetShare(ctx context.Context, id string) (*client.Share, error) {
+	for _, s := range m.shares {
+		if s.ID == id {
+			return &s, nil
+		}
+	}
+	return nil, errors.New("not found")
+}
+func (m *mockSharedFSClient) CreateShare(ctx context.Context, in client.ShareCreateInput) (*client.Share, error) {
+	s := client.Share{ID: "share-new", Name: in.Name, ShareProto: in.ShareProto, Size: in.Size, Status: "creating"}
+	m.shares = append(m.shares, s)
+	return &s, nil
+}
+func (m *mockSharedFSClient) DeleteShare(ctx context.Context, id string) error {
+	for i, s := range m.shares {
+		if s.ID == id {
+			m.shares = append(m.shares[:i], m.shares[i+1:]...)
+			return nil
+		}
+	}
+	return errors.New("not found")
+}
+func (m *mockSharedFSClient) ListExportLocations(ctx context.Context, shareID string) ([]client.ShareExportLocation, error) {
+	return []client.ShareExportLocation{{Path: "10.0.0.1:6789:/volumes/_nogroup/" + shareID, Preferred: true}}, nil
+}
+func (m *mockSharedFSClient) ListAccessRules(ctx context.Context, shareID string) ([]client.ShareAccessRule, error) {
+	return m.rules, nil
+}
+func (m *mockSharedFSClient) GrantAccess(ctx context.Context, shareID, accessType, accessTo, level string) (*client.ShareAccessRule, error) {
+	r := client.ShareAccessRule{ID: "rule-new", ShareID: shareID, AccessType: accessType, AccessTo: accessTo, AccessLevel: level, AccessKey: "AQDsecret==", State: "active"}
+	m.rules = append(m.rules, r)
+	return &r, nil
+}
+func (m *mockSharedFSClient) RevokeAccess(ctx context.Context, shareID, ruleID string) error {
+	for i, r := range m.rules {
+		if r.ID == ruleID {
+			m.rules = append(m.rules[:i], m.rules[i+1:]...)
+			return nil
+		}
+	}
+	return errors.New("not found")
+}
+
+func TestShareFromForm(t *testing.T) {
+	in, err := shareFromForm([]string{"data", "cephfs", "50", "", ""})
+	if err != nil || in.ShareProto != "CEPHFS" || in.Size != 50 {
+		t.Fatalf("unexpected input %+v (%v)", in, err)
+	}
+	if _, err := shareFromForm([]string{"data", "SMB", "50", "", ""}); err == nil {
+		t.Errorf("expected an unknown protocol error")
+	}
+	if _, err := shareFromForm([]string{"data", "NFS", "0", "", ""}); err == nil {
+		t.Errorf("expected a size error")
+	}
+}
+
+func TestAccessRuleFromForm(t *testing.T) {
+	if _, _, _, err := accessRuleFromForm([]string{"ip", "10.0.0.0/24", "ro"}); err != nil {
+		t.Errorf("unexpected error for a CIDR: %v", err)
+	}
+	if _, _, _, err := accessRuleFromForm([]string{"ip", "k8s", "rw"}); err == nil {
+		t.Errorf("expected an invalid IP error")
+	}
+	if _, _, _, err := accessRuleFromForm([]string{"cephx", "k8s", "rwx"}); err == nil {
+		t.Errorf("expected an invalid level error")
+	}
+}
+
+func TestShareDetailGrantAndRevoke(t *testing.T) {
+	share := client.Share{ID: "share-1", Name: "k8s-volumes", ShareProto: "CEPHFS", Size: 100, Status: "available"}
+	mock := &mockSharedFSClient{shares: []client.Share{share}}
+	m := NewShareDetailModel(mock, share)
+	updated, _ := m.Update(m.loadCmd()())
+	m = updated.(ShareDetailModel)
+	if !strings.Contains(m.View(), "/volumes/_nogroup/share-1") || !strings.Contains(m.View(), "nobody can mount") {
+		t.Fatalf("expected the export location and no rules, got %q", m.View())
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	m = updated.(ShareDetailModel)
+	if v := m.form.Values(); v[0] != "cephx" || v[2] != "rw" {
+		t.Fatalf("expected cephx/rw defaults for a CephFS share, got %v", v)
+	}
+	m.form.SetValue(1, "k8s-prod")
+	var cmd tea.Cmd
+	for i := 0; i < 3; i++ {
+		updated, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		m = updated.(ShareDetailModel)
+	}
+	if m.form != nil || cmd == nil {
+		t.Fatalf("expected the form to submit")
+	}
+	updated, cmd = m.Update(cmd())
+	m = updated.(ShareDetailModel)
+	updated, _ = m.Update(cmd())
+	m = updated.(ShareDetailModel)
+	if !strings.Contains(m.View(), "cephx key of k8s-prod: AQDsecret==") {
+		t.Fatalf("expected the cephx key of the new rule, got %q", m.View())
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")})
+	m = updated.(ShareDetailModel)
+	updated, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	m = updated.(ShareDetailModel)
+	m.Update(cmd())
+	if len(mock.rules) != 0 {
+		t.Fatalf("expected the rule to be revoked, got %+v", mock.rules)
+	}
+}
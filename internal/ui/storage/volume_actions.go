@@ -0,0 +1,175 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/gophercloud/gophercloud/openstack/blockstorage/v3/snapshots"
+	"github.com/gophercloud/gophercloud/openstack/blockstorage/v3/volumes"
+	"ostui/internal/client"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/common"
+	"ostui/internal/ui/ops"
+	"ostui/internal/ui/policy"
+	"ostui/internal/ui/theme"
+)
+
+// volumeAction is a change of the volume offered by the detail view.
+type volumeAction struct {
+	key    string
+	action client.VolumeAction
+	help   string
+}
+
+// volumeActions are checked against the volume status with
+// client.VolumeTransition, so a refused action is grayed out instead of
+// failing in Cinder.
+var volumeActions = []volumeAction{
+	{key: "d", action: client.VolumeDelete, help: "Delete the volume"},
+	{key: "D", action: client.VolumeForceDelete, help: "Delete the volume whatever its status (admin)"},
+	{key: "a", action: client.VolumeAttach, help: "Attach the volume to a server"},
+	{key: "s", action: client.VolumeSnapshot, help: "Snapshot the volume"},
+}
+
+// volumeActionFor returns the volume action bound to key.
+func volumeActionFor(key string) (volumeAction, bool) {
+	for _, a := range volumeActions {
+		if a.key == key {
+			return a, true
+		}
+	}
+	return volumeAction{}, false
+}
+
+// checkVolumeAction returns why a cannot be applied to v, or nil.
+func checkVolumeAction(v volumes.Volume, a volumeAction) error {
+	if a.action == client.VolumeForceDelete {
+		return policy.Check(policy.Admin, "force deleting a volume")
+	}
+	_, err := client.VolumeTransition(v, a.action)
+	return err
+}
+
+// paletteVolumeActions lists the actions valid for v, for the action
+// palette of the list (detail set) and of the detail view.
+func paletteVolumeActions(v volumes.Volume, detail bool) []common.Action {
+	var out []common.Action
+	for _, a := range volumeActions {
+		if checkVolumeAction(v, a) == nil {
+			out = append(out, common.Action{Key: a.key, Name: string(a.action), Help: a.help, Detail: detail})
+		}
+	}
+	return out
+}
+
+// volumeActionHints renders the key hints of the volume actions, grayed out
+// when the status of v does not allow them.
+func volumeActionHints(v volumes.Volume) string {
+	muted := lipgloss.NewStyle().Foreground(theme.Muted)
+	parts := make([]string, 0, len(volumeActions))
+	for _, a := range volumeActions {
+		hint := fmt.Sprintf("[%s] %s", a.key, a.action)
+		if checkVolumeAction(v, a) != nil {
+			hint = muted.Render(hint)
+		}
+		parts = append(parts, hint)
+	}
+	return strings.Join(parts, "  ")
+}
+
+// volumeChangeMsg reports the outcome of a volume action; next is the
+// status the volume moves to.
+type volumeChangeMsg struct {
+	action client.VolumeAction
+	next   string
+	status string
+	err    error
+}
+
+// newAttachPicker lists the servers v can be attached to: those it is not
+// attached to already.
+func newAttachPicker(cc client.ComputeClient, v volumes.Volume) common.PickerModel {
+	attached := map[string]bool{}
+	for _, a := range v.Attachments {
+		attached[a.ServerID] = true
+	}
+	cols := []common.PickerColumn{{Title: i18n.T("Name"), Width: 24}, {Title: i18n.T("Status"), Width: 10}, {Title: "ID", Width: 36}}
+	return common.NewPicker("Attach "+volumeName(v)+" to server", cols, func() ([]common.PickerItem, error) {
+		list, err := cc.ListInstances()
+		if err != nil {
+			return nil, err
+		}
+		var items []common.PickerItem
+		for _, s := range list {
+			if !attached[s.ID] {
+				items = append(items, common.PickerItem{ID: s.ID, Cells: []string{s.Name, s.Status, s.ID}})
+			}
+		}
+		return items, nil
+	})
+}
+
+// volumeName names a volume by its name, or its ID when unnamed.
+func volumeName(v volumes.Volume) string {
+	if v.Name != "" {
+		return v.Name
+	}
+	return v.ID
+}
+
+// queueVolumeDelete queues the delete, or force delete, of v as an
+// operation, followed until the volume is gone.
+func queueVolumeDelete(sc client.StorageClient, v volumes.Volume, a client.VolumeAction) tea.Cmd {
+	title := i18n.T("Delete volume %s", volumeName(v))
+	if a == client.VolumeForceDelete {
+		title = i18n.T("Force delete volume %s", volumeName(v))
+	}
+	id := v.ID
+	return ops.Enqueue(title, id, func(ctx context.Context) (string, ops.Poll, error) {
+		var err error
+		if a == client.VolumeForceDelete {
+			if err = sc.ForceDeleteVolume(id); err != nil {
+				policy.Record("force deleting a volume", err)
+			}
+		} else {
+			err = sc.DeleteVolume(id)
+		}
+		if err != nil {
+			return "", nil, err
+		}
+		return "delete requested", func(ctx context.Context) (bool, string, error) {
+			vol, err := sc.GetVolume(id)
+			switch {
+			case ops.Gone(err):
+				return true, "deleted", nil
+			case err != nil:
+				return false, "", err
+			case vol.Status == "error_deleting":
+				return false, vol.Status, fmt.Errorf("volume %s failed to delete", id)
+			}
+			return false, vol.Status, nil
+		}, nil
+	})
+}
+
+// runVolumeAction applies a to v; for an attach, serverID is the chosen
+// server and for a snapshot, name the snapshot name.
+func runVolumeAction(sc client.StorageClient, cc client.ComputeClient, v volumes.Volume, a client.VolumeAction, serverID, name string) tea.Cmd {
+	next, _ := client.VolumeTransition(v, a)
+	return func() tea.Msg {
+		msg := volumeChangeMsg{action: a, next: next}
+		switch a {
+		case client.VolumeAttach:
+			msg.err = cc.AttachVolume(context.Background(), serverID, v.ID)
+			msg.status = i18n.T("Attaching volume %s to server %s", volumeName(v), serverID)
+		case client.VolumeSnapshot:
+			snap, err := sc.CreateSnapshot(snapshots.CreateOpts{VolumeID: v.ID, Name: name, Force: client.SnapshotNeedsForce(v)})
+			msg.err = err
+			msg.status = i18n.T("Creating snapshot %s", snap.ID)
+		}
+		return msg
+	}
+}
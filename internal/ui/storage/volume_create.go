@@ -0,0 +1,77 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/gophercloud/gophercloud/openstack/blockstorage/v3/volumes"
+	"ostui/internal/client"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/ops"
+	"ostui/internal/ui/quota"
+)
+
+// OpenCreateVolumeMsg asks the app to open the new volume form.
+type OpenCreateVolumeMsg struct{}
+
+// CreateVolumeRequest is the new volume form: a name, a size in GB and an
+// optional availability zone, checked against the volume and gigabyte
+// quotas.
+func CreateVolumeRequest(sc client.StorageClient) quota.Request {
+	return quota.Request{
+		Title:  i18n.T("New volume"),
+		Action: "creating a volume",
+		Fields: []string{"Name", "Size (GB)", "Availability zone (empty for the default)"},
+		Keys:   []string{quota.Volumes, quota.Gigabytes},
+		Need: func(v []string) (map[string]int, error) {
+			size, err := volumeSize(v[1])
+			if err != nil {
+				return nil, err
+			}
+			return map[string]int{quota.Volumes: 1, quota.Gigabytes: size}, nil
+		},
+		Create: func(ctx context.Context, v []string) (string, ops.Poll, error) {
+			size, err := volumeSize(v[1])
+			if err != nil {
+				return "", nil, err
+			}
+			vol, err := sc.CreateVolume(volumes.CreateOpts{Name: v[0], Size: size, AvailabilityZone: v[2]})
+			if err != nil {
+				return "", nil, err
+			}
+			return i18n.T("Created volume %s (%d GB)", vol.ID, vol.Size), volumeAvailable(sc, vol.ID), nil
+		},
+	}
+}
+
+// volumeAvailable polls a new volume until it is available, or failed in
+// error.
+func volumeAvailable(sc client.StorageClient, id string) ops.Poll {
+	return func(ctx context.Context) (bool, string, error) {
+		vol, err := sc.GetVolume(id)
+		if err != nil {
+			return false, "", err
+		}
+		switch vol.Status {
+		case "available", "in-use":
+			return true, vol.Status, nil
+		case "error":
+			return false, vol.Status, fmt.Errorf("volume %s went into error", id)
+		}
+		return false, vol.Status, nil
+	}
+}
+
+// volumeSize reads the size field of the new volume form.
+func volumeSize(s string) (int, error) {
+	if s == "" {
+		return 0, errors.New("enter the size in GB")
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid size %q: enter a whole number of GB", s)
+	}
+	return n, nil
+}
@@ -3,12 +3,17 @@ package storage
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
+
 	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/table"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/gophercloud/gophercloud/openstack/blockstorage/v3/volumes"
 	"ostui/internal/client"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/common"
+	"ostui/internal/ui/ops"
 	"ostui/internal/ui/uiconst"
 )
 
@@ -18,6 +23,7 @@ type VolumeDetailModel struct {
 	err      error
 	spinner  spinner.Model
 	client   client.StorageClient
+	compute  client.ComputeClient
 	volumeID string
 	// JSON view fields
 	jsonView     string
@@ -25,8 +31,21 @@ type VolumeDetailModel struct {
 	// Inspect view fields
 	inspectView     string
 	inspectViewport viewport.Model
+	// lineage is the snapshot tree the volume belongs to, or ""
+	lineage string
 	// stored volume for JSON marshaling
 	volume volumes.Volume
+	// pending is the delete waiting for confirmation.
+	pending client.VolumeAction
+	// picker chooses the server to attach to, or the attached server to
+	// open when pickServer is set; form names a snapshot.
+	picker     *common.PickerModel
+	pickServer bool
+	form       *common.FormModel
+	// queued is a palette action of the volume list, run once loaded.
+	queued    *common.Action
+	status    string
+	statusErr bool
 }
 
 // ResourceID returns the volume ID.
@@ -35,17 +54,48 @@ func (m VolumeDetailModel) ResourceID() string { return m.volumeID }
 // ResourceName returns the volume name.
 func (m VolumeDetailModel) ResourceName() string { return m.volume.Name }
 
+// Actions lists the actions valid in the volume's status and its views, for
+// the action palette.
+func (m VolumeDetailModel) Actions() []common.Action {
+	if m.loading || m.err != nil {
+		return nil
+	}
+	return append(paletteVolumeActions(m.volume, false),
+		common.Action{Key: "i", Name: "inspect", Help: "Inspect the volume"},
+		common.Action{Key: "y", Name: "json", Help: "JSON view"},
+	)
+}
+
+// QueueAction runs a from the palette of the volume list once the volume
+// has loaded.
+func (m VolumeDetailModel) QueueAction(a common.Action) tea.Model {
+	m.queued = &a
+	return m
+}
+
+// CapturingInput reports whether the attach picker, the snapshot form or
+// the delete prompt is open.
+func (m VolumeDetailModel) CapturingInput() bool {
+	return m.picker != nil || m.form != nil || m.pending != ""
+}
+
+// ConfirmingDestructive reports whether a detach, delete or other pending
+// action waits for y.
+func (m VolumeDetailModel) ConfirmingDestructive() bool { return m.pending != "" }
+
 type volumeDetailDataLoadedMsg struct {
-	tbl    table.Model
-	err    error
-	volume volumes.Volume
+	tbl     table.Model
+	err     error
+	volume  volumes.Volume
+	lineage string
 }
 
-// NewVolumeDetailModel creates a new VolumeDetailModel for the given volume ID.
-func NewVolumeDetailModel(sc client.StorageClient, volumeID string) VolumeDetailModel {
+// NewVolumeDetailModel creates a new VolumeDetailModel for the given volume
+// ID; cc lists the servers it can be attached to.
+func NewVolumeDetailModel(sc client.StorageClient, cc client.ComputeClient, volumeID string) VolumeDetailModel {
 	s := spinner.New()
 	s.Spinner = spinner.Dot
-	return VolumeDetailModel{client: sc, loading: true, spinner: s, volumeID: volumeID}
+	return VolumeDetailModel{client: sc, compute: cc, loading: true, spinner: s, volumeID: volumeID}
 }
 
 // Init starts async loading of volume details.
@@ -55,32 +105,68 @@ func (m VolumeDetailModel) Init() tea.Cmd {
 		if err != nil {
 			return volumeDetailDataLoadedMsg{err: err}
 		}
-		cols := []table.Column{{Title: "Field", Width: uiconst.ColWidthField}, {Title: "Value", Width: uiconst.ColWidthValueShort}, {Title: "Field", Width: uiconst.ColWidthField}, {Title: "Value", Width: uiconst.ColWidthValueShort}}
-		rows := []table.Row{{"ID", vol.ID}, {"Name", vol.Name}, {"Size", fmt.Sprintf("%d", vol.Size)}, {"Status", vol.Status}, {"Description", vol.Description}}
-		half := (len(rows) + 1) / 2
-		newRows := []table.Row{}
-		for i := 0; i < half; i++ {
-			left := rows[i]
-			var right table.Row
-			if i+half < len(rows) {
-				right = rows[i+half]
-			} else {
-				right = table.Row{"", ""}
-			}
-			newRows = append(newRows, table.Row{left[0], left[1], right[0], right[1]})
+		d := loadLineage(m.client)
+		return volumeDetailDataLoadedMsg{tbl: volumeTable(vol), volume: vol, lineage: renderLineage(d, d.rootOf(vol.ID), vol.ID)}
+	}
+}
+
+// volumeTable lays the fields of vol out in two columns.
+func volumeTable(vol volumes.Volume) table.Model {
+	cols := []table.Column{{Title: i18n.T("Field"), Width: uiconst.ColWidthField}, {Title: i18n.T("Value"), Width: uiconst.ColWidthValueShort}, {Title: i18n.T("Field"), Width: uiconst.ColWidthField}, {Title: i18n.T("Value"), Width: uiconst.ColWidthValueShort}}
+	rows := []table.Row{{"ID", vol.ID}, {"Name", vol.Name}, {"Size", fmt.Sprintf("%d", vol.Size)}, {"Status", vol.Status}, {"Description", vol.Description}, {"Multiattach", fmt.Sprintf("%t", vol.Multiattach)}, {"Attached", attachedBadge(vol)}}
+	half := (len(rows) + 1) / 2
+	newRows := []table.Row{}
+	for i := 0; i < half; i++ {
+		left := rows[i]
+		var right table.Row
+		if i+half < len(rows) {
+			right = rows[i+half]
+		} else {
+			right = table.Row{"", ""}
 		}
-		t := table.New(
-			table.WithColumns(cols),
-			table.WithRows(newRows),
-			table.WithFocused(true),
-		)
-		t.SetStyles(table.DefaultStyles())
-		return volumeDetailDataLoadedMsg{tbl: t, volume: vol}
+		newRows = append(newRows, table.Row{left[0], left[1], right[0], right[1]})
 	}
+	t := table.New(
+		table.WithColumns(cols),
+		table.WithRows(newRows),
+		table.WithFocused(true),
+	)
+	t.SetStyles(table.DefaultStyles())
+	return t
+}
+
+// Reload re-fetches the volume, keeping the selected row.
+func (m VolumeDetailModel) Reload() tea.Cmd {
+	if m.loading {
+		return nil
+	}
+	return m.Init()
 }
 
 // Update handles messages.
 func (m VolumeDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	// The attach picker takes every message while it is open.
+	if m.picker != nil {
+		if _, ok := msg.(volumeDetailDataLoadedMsg); !ok {
+			newModel, cmd := m.picker.Update(msg)
+			picker := newModel.(common.PickerModel)
+			m.picker = &picker
+			if picker.Done() {
+				m.picker = nil
+				if m.pickServer {
+					m.pickServer = false
+					if it, ok := picker.Selected(); ok {
+						return m, common.OpenResource(common.KindServer, it.ID)
+					}
+					return m, nil
+				}
+				if it, ok := picker.Selected(); ok {
+					return m, runVolumeAction(m.client, m.compute, m.volume, client.VolumeAttach, it.ID, "")
+				}
+			}
+			return m, cmd
+		}
+	}
 	switch msg := msg.(type) {
 	case volumeDetailDataLoadedMsg:
 		m.loading = false
@@ -88,9 +174,41 @@ func (m VolumeDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.err = msg.err
 			return m, nil
 		}
-		m.table = msg.tbl
+		m.table = common.Reloaded(m.table, msg.tbl)
 		m.volume = msg.volume
+		m.lineage = msg.lineage
+		if m.queued != nil {
+			a := *m.queued
+			m.queued = nil
+			return m.Update(common.KeyFor(a.Key))
+		}
 		return m, nil
+	case volumeChangeMsg:
+		m.status, m.statusErr = msg.status, msg.err != nil
+		if msg.err != nil {
+			m.status = msg.err.Error()
+			return m, nil
+		}
+		// Show the status the volume moves to right away.
+		m.volume.Status = msg.next
+		m.table = common.Reloaded(m.table, volumeTable(m.volume))
+		return m, m.Init()
+	case ops.FinishedMsg:
+		if msg.Resource != m.volumeID {
+			return m, nil
+		}
+		if msg.Err != nil {
+			// The volume is shown as it is after the failed delete.
+			m.status, m.statusErr = i18n.T("%s failed: %s", msg.Title, msg.Err), true
+			return m, m.Init()
+		}
+		m.status, m.statusErr = i18n.T("%s done", msg.Title), false
+		if msg.Result == "deleted" {
+			m.volume.Status = "deleted"
+			m.table = common.Reloaded(m.table, volumeTable(m.volume))
+			return m, nil
+		}
+		return m, m.Init()
 	case tea.WindowSizeMsg:
 		if m.jsonView != "" {
 			m.jsonViewport.Width = msg.Width
@@ -141,12 +259,39 @@ func (m VolumeDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			// ignore other keys while JSON view is active
 			return m, nil
 		}
+		if m.form != nil {
+			return m.updateForm(msg)
+		}
+		if m.pending != "" {
+			a := m.pending
+			m.pending = ""
+			if msg.String() != "y" {
+				return m, nil
+			}
+			if a == client.VolumeDelete || a == client.VolumeForceDelete {
+				// Show the status the volume moves to right away, and
+				// follow the delete from the jobs view.
+				next, _ := client.VolumeTransition(m.volume, a)
+				cmd := queueVolumeDelete(m.client, m.volume, a)
+				m.volume.Status = next
+				m.table = common.Reloaded(m.table, volumeTable(m.volume))
+				m.status, m.statusErr = i18n.T("%s queued; follow it with :jobs", a), false
+				return m, cmd
+			}
+			return m, runVolumeAction(m.client, m.compute, m.volume, a, "", "")
+		}
 		if m.loading || m.err != nil {
 			return m, nil
 		}
+		if a, ok := volumeActionFor(msg.String()); ok {
+			return m.startAction(a)
+		}
+		if msg.String() == "enter" && m.onAttachedRow() {
+			return m.openAttachment()
+		}
 		if msg.String() == "i" {
 			// Build inspect view for volume.
-			content := fmt.Sprintf("=== Volume: %s ===\nID: %s\nName: %s\nSize: %d\nStatus: %s\nDescription: %s", m.volume.Name, m.volume.ID, m.volume.Name, m.volume.Size, m.volume.Status, m.volume.Description)
+			content := i18n.T("=== Volume: %s ===\nID: %s\nName: %s\nSize: %d\nStatus: %s\nDescription: %s", m.volume.Name, m.volume.ID, m.volume.Name, m.volume.Size, m.volume.Status, m.volume.Description)
 			m.inspectView = content
 			m.inspectViewport = viewport.New(80, 24)
 			m.inspectViewport.SetContent(m.inspectView)
@@ -176,23 +321,103 @@ func (m VolumeDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// startAction checks a against the volume status and opens what it needs:
+// a confirmation, the server picker or the snapshot form.
+func (m VolumeDetailModel) startAction(a volumeAction) (tea.Model, tea.Cmd) {
+	if err := checkVolumeAction(m.volume, a); err != nil {
+		m.status, m.statusErr = err.Error(), true
+		return m, nil
+	}
+	m.status, m.statusErr = "", false
+	switch a.action {
+	case client.VolumeAttach:
+		picker := newAttachPicker(m.compute, m.volume)
+		m.picker = &picker
+		return m, picker.Init()
+	case client.VolumeSnapshot:
+		f := common.NewForm([]string{i18n.T("Snapshot name")})
+		f.SetValue(0, volumeName(m.volume)+"-snap")
+		m.form = &f
+		return m, f.Init()
+	}
+	m.pending = a.action
+	return m, nil
+}
+
+// updateForm handles keys for the snapshot form.
+func (m VolumeDetailModel) updateForm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	fm, cmd := m.form.Update(msg)
+	f := fm.(common.FormModel)
+	m.form = &f
+	switch {
+	case f.Cancelled():
+		m.form = nil
+	case f.Submitted():
+		name := strings.TrimSpace(f.Values()[0])
+		if name == "" {
+			m.form.SetError(fmt.Errorf("the snapshot needs a name"))
+			return m, nil
+		}
+		m.form = nil
+		return m, runVolumeAction(m.client, m.compute, m.volume, client.VolumeSnapshot, "", name)
+	}
+	return m, cmd
+}
+
 // View renders the volume detail view.
 func (m VolumeDetailModel) View() string {
+	if m.picker != nil {
+		return m.picker.View()
+	}
+	if m.form != nil {
+		return i18n.T("Snapshot %s", volumeName(m.volume)) + "\n\n" + m.form.View() + "\n" + i18n.T("[enter] create  [esc] cancel")
+	}
 	if m.loading {
 		return m.spinner.View()
 	}
 	if m.jsonView != "" {
-		return fmt.Sprintf("%s\nPress 'y' or 'esc' to close", m.jsonViewport.View())
+		return i18n.T("%s\nPress 'y' or 'esc' to close", m.jsonViewport.View())
 	}
 	if m.inspectView != "" {
-		return fmt.Sprintf("%s\n %3.f%% | [j/k] scroll  [esc] close", m.inspectViewport.View(), m.inspectViewport.ScrollPercent()*100)
+		return i18n.T("%s\n %3.f%% | [j/k] scroll  [esc] close", m.inspectViewport.View(), m.inspectViewport.ScrollPercent()*100)
 	}
 	if m.err != nil {
-		cols := []table.Column{{Title: "Error", Width: uiconst.ColWidthError}}
+		cols := []table.Column{{Title: i18n.T("Error"), Width: uiconst.ColWidthError}}
 		rows := []table.Row{{"Failed to load volume: " + m.err.Error()}}
 		return table.New(table.WithColumns(cols), table.WithRows(rows)).View()
 	}
-	return fmt.Sprintf("%s\n[y] json  [i] inspect  [g] graph  [esc] back", m.table.View())
+	body := m.table.View()
+	if len(m.volume.Attachments) > 0 {
+		body += "\n\n" + attachmentsHeader(m.volume) + "\n" + newAttachmentsTable(m.volume).View()
+	}
+	if m.lineage != "" {
+		body += "\n\nLineage\n" + m.lineage
+	}
+	prompt := ""
+	switch m.pending {
+	case client.VolumeDelete:
+		prompt = "Delete volume " + volumeName(m.volume) + "?"
+	case client.VolumeForceDelete:
+		prompt = "Force delete volume " + volumeName(m.volume) + " (" + m.volume.Status + "), detaching it from its servers?"
+	}
+	body += shareStatusLine(m.status, m.statusErr, prompt)
+	hints := volumeActionHints(m.volume) + "  " + i18n.T("[y] json  [i] inspect  [g] graph  [esc] back")
+	if m.onAttachedRow() && len(m.volume.Attachments) > 0 {
+		hints = i18n.T("[enter] open server") + "  " + hints
+	}
+	return fmt.Sprintf("%s\n%s", body, hints)
+}
+
+// onAttachedRow reports whether the selected row holds the Attached field,
+// in either of its two field/value pairs.
+func (m VolumeDetailModel) onAttachedRow() bool {
+	row := m.table.SelectedRow()
+	for i := 0; i < len(row); i += 2 {
+		if row[i] == "Attached" {
+			return true
+		}
+	}
+	return false
 }
 
 // Table returns the underlying table model.
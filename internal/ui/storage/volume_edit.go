@@ -0,0 +1,75 @@
+package storage
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/gophercloud/gophercloud/openstack/blockstorage/v3/volumes"
+	"ostui/internal/client"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/editor"
+)
+
+// volumeEdit holds the mutable fields of a volume as edited in YAML.
+type volumeEdit struct {
+	Name        string            `yaml:"name"`
+	Description string            `yaml:"description"`
+	Metadata    map[string]string `yaml:"metadata"`
+}
+
+// EditSpec implements editor.Editable for the volume detail view.
+func (m VolumeDetailModel) EditSpec() editor.Spec {
+	c, id := m.client, m.volumeID
+	return editor.Spec{
+		Title: i18n.T("volume %s", id),
+		Load: func() (string, error) {
+			vol, err := c.GetVolume(id)
+			if err != nil {
+				return "", err
+			}
+			return editor.Encode(volumeEdit{Name: vol.Name, Description: vol.Description, Metadata: vol.Metadata})
+		},
+		Apply: func(original, edited string) (string, error) {
+			return applyVolumeEdit(c, id, original, edited)
+		},
+	}
+}
+
+// applyVolumeEdit validates edited and sends the changed fields in one update.
+func applyVolumeEdit(c client.StorageClient, id, original, edited string) (string, error) {
+	var before, after volumeEdit
+	if err := editor.Decode(original, &before); err != nil {
+		return "", err
+	}
+	if err := editor.Decode(edited, &after); err != nil {
+		return "", err
+	}
+	if before.Metadata == nil {
+		before.Metadata = map[string]string{}
+	}
+	if after.Metadata == nil {
+		after.Metadata = map[string]string{}
+	}
+	changed := editor.ChangedFields(before, after)
+	if len(changed) == 0 {
+		return editor.Summary(nil), nil
+	}
+	var opts volumes.UpdateOpts
+	if after.Name != before.Name {
+		opts.Name = &after.Name
+	}
+	if after.Description != before.Description {
+		opts.Description = &after.Description
+	}
+	if !reflect.DeepEqual(before.Metadata, after.Metadata) {
+		// The update body omits an empty map, so it cannot clear metadata.
+		if len(after.Metadata) == 0 {
+			return "", fmt.Errorf("removing all metadata is not supported; keep at least one key")
+		}
+		opts.Metadata = after.Metadata
+	}
+	if err := c.UpdateVolume(id, opts); err != nil {
+		return "", err
+	}
+	return editor.Summary(changed), nil
+}
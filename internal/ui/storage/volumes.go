@@ -6,19 +6,26 @@ import (
 	"github.com/charmbracelet/bubbles/table"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/gophercloud/gophercloud/openstack/blockstorage/v3/volumes"
 	"ostui/internal/client"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/common"
+	"ostui/internal/ui/search"
+	"ostui/internal/ui/theme"
 	"ostui/internal/ui/uiconst"
-	"strings"
+	"time"
 )
 
 // VolumesModel implements a subview for listing storage volumes.
 type VolumesModel struct {
-	table      table.Model
-	loading    bool
-	err        error
-	spinner    spinner.Model
-	client     client.StorageClient
-	allRows    []table.Row
+	table   common.VirtualTable
+	loading bool
+	err     error
+	spinner spinner.Model
+	client  client.StorageClient
+	// volumes maps the listed volumes by ID, for the actions their status
+	// allows.
+	volumes    map[string]volumes.Volume
 	filterMode bool
 	filter     textinput.Model
 	width      int
@@ -31,14 +38,27 @@ func NewVolumesModel(sc client.StorageClient) VolumesModel {
 	s.Spinner = spinner.Dot
 	ti := textinput.New()
 	ti.Placeholder = "filter..."
-	return VolumesModel{client: sc, loading: true, spinner: s, filter: ti, width: 120, height: 30}
+	m := VolumesModel{client: sc, loading: true, spinner: s, filter: ti, width: 120, height: 30}
+	t := table.New(table.WithFocused(true), table.WithHeight(m.height-6))
+	t.SetStyles(table.DefaultStyles())
+	m.table = common.NewVirtualTable(t, styleVolumeRow)
+	m.updateTableColumns()
+	return m
+}
+
+// styleVolumeRow marks the status of a volume row on screen.
+func styleVolumeRow(r table.Row) table.Row {
+	out := append(table.Row(nil), r...)
+	out[3] = theme.Mark(out[3])
+	return out
 }
 
 // dataLoadedMsg is sent when volume data has been fetched.
 type dataLoadedMsg struct {
-	tbl  table.Model
-	rows []table.Row
-	err  error
+	rows    []table.Row
+	created []time.Time
+	volumes map[string]volumes.Volume
+	err     error
 }
 
 // Init starts the async data loading.
@@ -48,20 +68,26 @@ func (m VolumesModel) Init() tea.Cmd {
 		if err != nil {
 			return dataLoadedMsg{err: err}
 		}
-		cols := []table.Column{{Title: "ID", Width: uiconst.ColWidthUUID}, {Title: "Name", Width: uiconst.ColWidthName}, {Title: "Size", Width: uiconst.ColWidthSize}, {Title: "Status", Width: uiconst.ColWidthStatus}}
-		rows := []table.Row{}
+		search.IndexVolumes(volList)
+		rows := make([]table.Row, 0, len(volList))
+		created := make([]time.Time, 0, len(volList))
+		byID := make(map[string]volumes.Volume, len(volList))
+		now := time.Now()
 		for _, v := range volList {
-			rows = append(rows, table.Row{v.ID, v.Name, fmt.Sprintf("%d", v.Size), v.Status})
+			rows = append(rows, table.Row{v.ID, v.Name, fmt.Sprintf("%d", v.Size), v.Status, attachedBadge(v), common.Age(v.CreatedAt, now), v.UserID})
+			created = append(created, v.CreatedAt)
+			byID[v.ID] = v
 		}
-		t := table.New(
-			table.WithColumns(cols),
-			table.WithRows(rows),
-			table.WithFocused(true),
-			table.WithHeight(m.height-uiconst.TableHeightOffset),
-		)
-		t.SetStyles(table.DefaultStyles())
-		return dataLoadedMsg{tbl: t, rows: rows}
+		return dataLoadedMsg{rows: common.TerraformRows(rows), created: created, volumes: byID}
+	}
+}
+
+// Reload re-fetches the volumes, keeping the selected row.
+func (m VolumesModel) Reload() tea.Cmd {
+	if m.loading || m.filterMode {
+		return nil
 	}
+	return m.Init()
 }
 
 // Update handles messages for the model.
@@ -73,18 +99,14 @@ func (m VolumesModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.err = msg.err
 			return m, nil
 		}
-		m.table = msg.tbl
-		m.allRows = msg.rows
-		m.updateTableColumns()
-		m.table.SetHeight(m.height - 6)
+		m.table.SetRowsCreated(msg.rows, msg.created)
+		m.volumes = msg.volumes
 		return m, nil
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
-		if m.table.Columns() != nil {
-			m.table.SetHeight(m.height - 6)
-			m.updateTableColumns()
-		}
+		m.table.SetHeight(m.height - 6)
+		m.updateTableColumns()
 		return m, nil
 	case tea.KeyMsg:
 		if m.loading || m.err != nil {
@@ -102,30 +124,18 @@ func (m VolumesModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.filterMode = false
 			m.filter.Blur()
 			m.filter.SetValue("")
-			m.table.SetRows(m.allRows)
+			m.table.Filter("")
 			return m, nil
 		}
 		if m.filterMode {
 			var cmd tea.Cmd
 			m.filter, cmd = m.filter.Update(msg)
-			filterVal := m.filter.Value()
-			if filterVal == "" {
-				m.table.SetRows(m.allRows)
-			} else {
-				lower := strings.ToLower(filterVal)
-				filtered := []table.Row{}
-				for _, r := range m.allRows {
-					for _, c := range r {
-						if strings.Contains(strings.ToLower(c), lower) {
-							filtered = append(filtered, r)
-							break
-						}
-					}
-				}
-				m.table.SetRows(filtered)
-			}
+			m.table.Filter(m.filter.Value())
 			return m, cmd
 		}
+		if msg.String() == "n" {
+			return m, func() tea.Msg { return OpenCreateVolumeMsg{} }
+		}
 		// Normal table navigation
 		var cmd tea.Cmd
 		m.table, cmd = m.table.Update(msg)
@@ -146,11 +156,11 @@ func (m VolumesModel) View() string {
 		return m.spinner.View()
 	}
 	if m.err != nil {
-		return fmt.Sprintf("Error: %s", m.err)
+		return i18n.T("Error: %s", m.err)
 	}
 	if m.filterMode {
-		filterLine := fmt.Sprintf("Filter: %s", m.filter.View())
-		footer := "esc: clear"
+		filterLine := i18n.T("Filter: %s", m.filter.View())
+		footer := i18n.T("%d of %d  esc: clear", m.table.Len(), m.table.Total())
 		return fmt.Sprintf("%s\n%s\n%s", filterLine, m.table.View(), footer)
 	}
 	return m.table.View()
@@ -161,15 +171,38 @@ func (m *VolumesModel) updateTableColumns() {
 	idW := uiconst.ColWidthUUID
 	sizeW := uiconst.ColWidthSize
 	statusW := uiconst.ColWidthStatus
-	nameW := m.width - idW - sizeW - statusW - uiconst.TableHeightOffset
+	attachedW := uiconst.ColWidthType
+	ageW := uiconst.ColWidthAge
+	userW := uiconst.ColWidthCreatedBy
+	nameW := m.width - idW - sizeW - statusW - attachedW - ageW - userW - uiconst.TableHeightOffset - common.TerraformWidth()
 	if nameW < 10 {
 		nameW = 10
 	}
-	m.table.SetColumns([]table.Column{{Title: "ID", Width: idW}, {Title: "Name", Width: nameW}, {Title: "Size", Width: sizeW}, {Title: "Status", Width: statusW}})
+	m.table.SetColumns(common.TerraformColumns([]table.Column{{Title: "ID", Width: idW}, {Title: i18n.T("Name"), Width: nameW}, {Title: i18n.T("Size"), Width: sizeW}, {Title: i18n.T("Status"), Width: statusW}, {Title: i18n.T("Attached"), Width: attachedW}, {Title: common.AgeTitle(), Width: ageW}, {Title: i18n.T("Created by"), Width: userW}}))
 }
 
 // Ensure VolumesModel implements tea.Model.
 // Table returns the underlying table model.
-func (m VolumesModel) Table() table.Model { return m.table }
+func (m VolumesModel) Table() table.Model { return m.table.Table() }
+
+// FullTable returns all the listed volumes, for the quick jump.
+func (m VolumesModel) FullTable() table.Model { return m.table.FullTable() }
+
+// Filtering reports whether keys are typed into the filter.
+func (m VolumesModel) Filtering() bool { return m.filterMode }
+
+// Actions lists what can be done to the selected volume for the action
+// palette. The volume actions valid in its status run in its detail.
+func (m VolumesModel) Actions() []common.Action {
+	row := m.table.SelectedRow()
+	if m.loading || m.err != nil || m.filterMode || len(row) == 0 {
+		return nil
+	}
+	out := []common.Action{{Key: "enter", Name: "open", Help: "Open the volume detail"}}
+	if v, ok := m.volumes[row[0]]; ok {
+		out = append(out, paletteVolumeActions(v, true)...)
+	}
+	return append(out, common.Action{Key: "/", Name: "filter", Help: "Filter the volumes"})
+}
 
 var _ tea.Model = (*VolumesModel)(nil)
@@ -0,0 +1,193 @@
+// Package theme holds the colors views use to convey meaning, and the
+// palette modes: full color, monochrome (also chosen by NO_COLOR) and a
+// color-blind safe palette where statuses also carry a symbol.
+package theme
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// Palette modes.
+const (
+	ModeColor      = "color"
+	ModeMono       = "mono"
+	ModeColorBlind = "colorblind"
+)
+
+// Colors by meaning. Apply changes them; views read them when rendering.
+var (
+	OK     = lipgloss.Color("#5CB85C")
+	Error  = lipgloss.Color("#D9534F")
+	Warn   = lipgloss.Color("#F0AD4E")
+	Info   = lipgloss.Color("#5BC0DE")
+	Muted  = lipgloss.Color("#666666")
+	Accent = lipgloss.Color("205")
+)
+
+// mode is the applied palette mode.
+var mode = ModeColor
+
+// Mode returns the applied palette mode.
+func Mode() string { return mode }
+
+// Apply switches to a palette mode. An empty mode is monochrome when the
+// NO_COLOR environment variable is set (https://no-color.org) and full color
+// otherwise.
+func Apply(m string) error {
+	if m == "" {
+		m = ModeColor
+		if os.Getenv("NO_COLOR") != "" {
+			m = ModeMono
+		}
+	}
+	switch m {
+	case ModeColor:
+	case ModeMono:
+		// Bold, underline and reverse still work without color.
+		lipgloss.SetColorProfile(termenv.Ascii)
+	case ModeColorBlind:
+		// The Okabe-Ito palette: blue and vermillion stay apart for the
+		// common kinds of color blindness, unlike green and red.
+		OK = lipgloss.Color("#0072B2")
+		Error = lipgloss.Color("#D55E00")
+		Warn = lipgloss.Color("#E69F00")
+		Info = lipgloss.Color("#56B4E9")
+	default:
+		return fmt.Errorf("unknown palette %q: use %s, %s or %s", m, ModeColor, ModeMono, ModeColorBlind)
+	}
+	mode = m
+	return nil
+}
+
+// cloudColors are the color names accepted for a cloud.
+var cloudColors = map[string]lipgloss.Color{
+	"red": "#D9534F", "orange": "#F28C28", "yellow": "#F0C419", "green": "#5CB85C",
+	"cyan": "#5BC0DE", "blue": "#337AB7", "purple": "#8E44AD", "magenta": "205",
+}
+
+// Cloud is the color given to the session's cloud, empty when it has none.
+var Cloud lipgloss.Color
+
+// defaultAccent is the accent of a cloud without a color.
+var defaultAccent = Accent
+
+// guarded is set for a red cloud, see Guarded.
+var guarded bool
+
+// SetCloudColor colors the session's cloud: the accent of titles and the
+// border and cloud badge of the layout take the color. c is a color name
+// (red, orange, yellow, green, cyan, blue, purple, magenta), a #RRGGBB
+// value or an ANSI color number; empty leaves the default accent. A red
+// cloud is guarded: destructive confirmations need an extra step.
+func SetCloudColor(c string) error {
+	c = strings.ToLower(strings.TrimSpace(c))
+	Cloud, Accent, guarded = "", defaultAccent, false
+	if c == "" {
+		return nil
+	}
+	color, ok := cloudColors[c]
+	if !ok {
+		if !validColor(c) {
+			return fmt.Errorf("unknown color %q: use a name such as red or green, #RRGGBB or an ANSI number", c)
+		}
+		color = lipgloss.Color(c)
+	}
+	Cloud, Accent, guarded = color, color, c == "red"
+	return nil
+}
+
+// validColor reports whether c is a #RRGGBB value or an ANSI color number.
+func validColor(c string) bool {
+	if rest, ok := strings.CutPrefix(c, "#"); ok {
+		if len(rest) != 6 {
+			return false
+		}
+		for _, r := range rest {
+			if !strings.ContainsRune("0123456789abcdef", r) {
+				return false
+			}
+		}
+		return true
+	}
+	n, err := strconv.Atoi(c)
+	return err == nil && n >= 0 && n <= 255
+}
+
+// Guarded reports whether the session's cloud is red, so destructive
+// confirmations ask for its name as well.
+func Guarded() bool { return guarded }
+
+// Symbols reports whether statuses carry a symbol besides their color.
+func Symbols() bool { return mode != ModeColor }
+
+// Status classes.
+const (
+	classOther = iota
+	classOK
+	classError
+	classBusy
+)
+
+// classes maps the statuses OpenStack services report to a class. Statuses
+// ending in _FAILED, _IN_PROGRESS or starting with error are matched by
+// classify.
+var classes = map[string]int{
+	"ACTIVE": classOK, "ONLINE": classOK, "UP": classOK, "AVAILABLE": classOK, "IN-USE": classOK, "ENABLED": classOK,
+	"ERROR": classError, "DEGRADED": classError, "DOWN": classError, "OFFLINE": classError, "FAILED": classError, "KILLED": classError,
+	"BUILD": classBusy, "REBUILD": classBusy, "RESIZE": classBusy, "VERIFY_RESIZE": classBusy, "MIGRATING": classBusy, "REBOOT": classBusy,
+	"HARD_REBOOT": classBusy, "PENDING_CREATE": classBusy, "PENDING_UPDATE": classBusy, "PENDING_DELETE": classBusy, "CREATING": classBusy,
+	"DELETING": classBusy, "ATTACHING": classBusy, "DETACHING": classBusy, "EXTENDING": classBusy, "DOWNLOADING": classBusy,
+	"UPLOADING": classBusy, "QUEUED": classBusy, "SAVING": classBusy, "IMPORTING": classBusy, "BACKING-UP": classBusy, "RESTORING-BACKUP": classBusy,
+}
+
+func classify(status string) int {
+	s := strings.ToUpper(status)
+	if c, ok := classes[s]; ok {
+		return c
+	}
+	switch {
+	case strings.HasPrefix(s, "ERROR"), strings.HasSuffix(s, "_FAILED"):
+		return classError
+	case strings.HasSuffix(s, "_IN_PROGRESS"):
+		return classBusy
+	}
+	return classOther
+}
+
+// Mark prefixes a status with ✓, ✗ or ~ when symbols are on, e.g.
+// "✗ ERROR", so it does not rely on color alone. Other statuses, such as
+// SHUTOFF, get two spaces to keep columns aligned.
+func Mark(status string) string {
+	if !Symbols() || status == "" {
+		return status
+	}
+	switch classify(status) {
+	case classOK:
+		return "✓ " + status
+	case classError:
+		return "✗ " + status
+	case classBusy:
+		return "~ " + status
+	}
+	return "  " + status
+}
+
+// StatusColor returns the color of a status: OK, Error, Warn for statuses in
+// progress, or Muted.
+func StatusColor(status string) lipgloss.Color {
+	switch classify(status) {
+	case classOK:
+		return OK
+	case classError:
+		return Error
+	case classBusy:
+		return Warn
+	}
+	return Muted
+}
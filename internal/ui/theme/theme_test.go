@@ -0,0 +1,81 @@
+package theme
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// reset restores the full color palette after a test.
+func reset(t *testing.T) {
+	ok, bad, warn, info, profile := OK, Error, Warn, Info, lipgloss.ColorProfile()
+	t.Cleanup(func() {
+		OK, Error, Warn, Info, mode = ok, bad, warn, info, ModeColor
+		lipgloss.SetColorProfile(profile)
+	})
+}
+
+func TestApply(t *testing.T) {
+	reset(t)
+	if err := Apply("sepia"); err == nil {
+		t.Error("expected an error for an unknown palette")
+	}
+	if Mode() != ModeColor || Mark("ACTIVE") != "ACTIVE" {
+		t.Errorf("full color should not mark statuses, got mode %s", Mode())
+	}
+
+	t.Setenv("NO_COLOR", "1")
+	if err := Apply(""); err != nil || Mode() != ModeMono {
+		t.Errorf("NO_COLOR should select mono, got %s (%v)", Mode(), err)
+	}
+
+	if err := Apply(ModeColorBlind); err != nil {
+		t.Fatal(err)
+	}
+	if OK == lipgloss.Color("#5CB85C") || Error == lipgloss.Color("#D9534F") {
+		t.Error("colorblind mode kept the green/red palette")
+	}
+}
+
+func TestSetCloudColor(t *testing.T) {
+	t.Cleanup(func() { _ = SetCloudColor("") })
+	if err := SetCloudColor("Red"); err != nil || !Guarded() || Accent != Cloud || Cloud == "" {
+		t.Fatalf("expected a guarded red cloud, got %q guarded=%v (%v)", Cloud, Guarded(), err)
+	}
+	if err := SetCloudColor("#1e90ff"); err != nil || Guarded() || Cloud != lipgloss.Color("#1e90ff") {
+		t.Errorf("expected an unguarded hex color, got %q guarded=%v (%v)", Cloud, Guarded(), err)
+	}
+	for _, bad := range []string{"crimson", "#12345", "256"} {
+		if err := SetCloudColor(bad); err == nil {
+			t.Errorf("expected an error for %q", bad)
+		}
+	}
+	if err := SetCloudColor(""); err != nil || Cloud != "" || Accent != defaultAccent || Guarded() {
+		t.Errorf("expected the default accent back, got %q (%v)", Accent, err)
+	}
+}
+
+func TestMark(t *testing.T) {
+	reset(t)
+	if err := Apply(ModeColorBlind); err != nil {
+		t.Fatal(err)
+	}
+	for status, want := range map[string]string{
+		"ACTIVE":             "✓ ACTIVE",
+		"in-use":             "✓ in-use",
+		"ERROR":              "✗ ERROR",
+		"error_extending":    "✗ error_extending",
+		"CREATE_FAILED":      "✗ CREATE_FAILED",
+		"BUILD":              "~ BUILD",
+		"UPDATE_IN_PROGRESS": "~ UPDATE_IN_PROGRESS",
+		"SHUTOFF":            "  SHUTOFF",
+		"":                   "",
+	} {
+		if got := Mark(status); got != want {
+			t.Errorf("Mark(%q) = %q, want %q", status, got, want)
+		}
+	}
+	if StatusColor("DEGRADED") != Error || StatusColor("PAUSED") != Muted {
+		t.Error("unexpected status colors")
+	}
+}
@@ -0,0 +1,103 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"ostui/internal/client"
+	"ostui/internal/ui/theme"
+)
+
+// tokenRenewRetry is the wait before retrying a failed token renewal.
+const tokenRenewRetry = time.Minute
+
+// tokenTickMsg drives the token expiry countdown in the footer.
+type tokenTickMsg struct{}
+
+// tokenRenewedMsg reports the outcome of a background token renewal.
+type tokenRenewedMsg struct {
+	err error
+}
+
+// rolesLoadedMsg carries the roles of the token for the policy predictions.
+type rolesLoadedMsg struct{ roles []string }
+
+// loadRolesCmd fetches the roles of the token. When they cannot be read,
+// nothing is known and no action is grayed out.
+func loadRolesCmd(ic client.IdentityClient) tea.Cmd {
+	if ic == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		roles, err := ic.GetTokenRoles()
+		if err != nil {
+			return rolesLoadedMsg{}
+		}
+		return rolesLoadedMsg{roles: roles}
+	}
+}
+
+func tokenTick() tea.Cmd {
+	return tea.Tick(time.Second, func(time.Time) tea.Msg { return tokenTickMsg{} })
+}
+
+// checkToken starts a background renewal when the token is about to expire.
+func (m AppModel) checkToken(now time.Time) (AppModel, tea.Cmd) {
+	if m.services == nil || m.tokenRenewing || now.Before(m.tokenRetryAt) {
+		return m, nil
+	}
+	expires, ok := m.services.TokenExpiry()
+	if !ok || expires.Sub(now) > client.TokenRenewBefore {
+		return m, nil
+	}
+	if errors.Is(m.tokenErr, client.ErrTokenNotRenewable) {
+		return m, nil
+	}
+	m.tokenRenewing = true
+	services := m.services
+	return m, func() tea.Msg { return tokenRenewedMsg{err: services.RenewToken()} }
+}
+
+// tokenLabel renders the time left on the token for the footer.
+func (m AppModel) tokenLabel(now time.Time) string {
+	if m.services == nil {
+		return ""
+	}
+	expires, ok := m.services.TokenExpiry()
+	if !ok {
+		return ""
+	}
+	left := expires.Sub(now)
+	text := "token " + formatTokenTTL(left)
+	color := theme.OK
+	switch {
+	case left <= 0:
+		text, color = "token expired", theme.Error
+	case m.tokenRenewing:
+		text += " (renewing…)"
+		color = theme.Warn
+	case m.tokenErr != nil:
+		text += " (renewal failed: " + m.tokenErr.Error() + ")"
+		color = theme.Error
+	case left <= client.TokenRenewBefore:
+		color = theme.Warn
+	}
+	return lipgloss.NewStyle().Foreground(color).Render(text)
+}
+
+// formatTokenTTL formats a remaining lifetime as "1h05m", "12m" or "45s".
+func formatTokenTTL(d time.Duration) string {
+	switch {
+	case d >= time.Hour:
+		return fmt.Sprintf("%dh%02dm", int(d.Hours()), int(d.Minutes())%60)
+	case d >= 5*time.Minute:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d >= time.Minute:
+		return fmt.Sprintf("%dm%02ds", int(d.Minutes()), int(d.Seconds())%60)
+	}
+	return fmt.Sprintf("%ds", max(int(d.Seconds()), 0))
+}
@@ -0,0 +1,295 @@
+package topology
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"golang.org/x/sync/errgroup"
+	"ostui/internal/client"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/theme"
+)
+
+// ClientSet groups the clients needed to build an inventory for one context.
+type ClientSet struct {
+	Compute client.ComputeClient
+	Network client.NetworkClient
+	Storage client.StorageClient
+}
+
+// ContextLoader creates the clients for a diff target such as "prod" or "prod/project".
+type ContextLoader func(target string) (ClientSet, error)
+
+// inventoryKinds lists the resource kinds compared by the diff, in display order.
+var inventoryKinds = []string{"Networks", "Subnets", "Routers", "Servers", "Volumes"}
+
+// inventory counts resources by kind and name.
+type inventory map[string]map[string]int
+
+// diffEntry describes a name whose count differs between the two contexts.
+type diffEntry struct {
+	kind string
+	name string
+	a    int
+	b    int
+}
+
+// DiffModel compares the resources of two clouds or projects by name.
+type DiffModel struct {
+	clientsA ClientSet
+	labelA   string
+	labelB   string
+	loader   ContextLoader
+	loading  bool
+	err      error
+	content  string
+	viewport viewport.Model
+	spinner  spinner.Model
+}
+
+type diffDataMsg struct {
+	content string
+	err     error
+}
+
+// NewDiffModel creates a DiffModel comparing the current context (a) with target,
+// whose clients are created through loader.
+func NewDiffModel(a ClientSet, labelA, target string, loader ContextLoader) DiffModel {
+	s := spinner.New()
+	s.Spinner = spinner.Dot
+	return DiffModel{clientsA: a, labelA: labelA, labelB: target, loader: loader, loading: true, spinner: s, viewport: viewport.New(80, 24)}
+}
+
+// Init loads both inventories and renders the diff.
+func (m DiffModel) Init() tea.Cmd {
+	return tea.Batch(m.spinner.Tick, func() tea.Msg {
+		clientsB, err := m.loader(m.labelB)
+		if err != nil {
+			return diffDataMsg{err: fmt.Errorf("failed to connect to %s: %w", m.labelB, err)}
+		}
+		var invA, invB inventory
+		g, _ := errgroup.WithContext(context.Background())
+		g.Go(func() error {
+			var err error
+			invA, err = collectInventory(m.clientsA)
+			if err != nil {
+				return fmt.Errorf("%s: %w", m.labelA, err)
+			}
+			return nil
+		})
+		g.Go(func() error {
+			var err error
+			invB, err = collectInventory(clientsB)
+			if err != nil {
+				return fmt.Errorf("%s: %w", m.labelB, err)
+			}
+			return nil
+		})
+		if err := g.Wait(); err != nil {
+			return diffDataMsg{err: err}
+		}
+		return diffDataMsg{content: renderDiff(m.labelA, m.labelB, diffInventories(invA, invB))}
+	})
+}
+
+// collectInventory lists the compared resources for one context in parallel.
+func collectInventory(cs ClientSet) (inventory, error) {
+	inv := inventory{}
+	for _, k := range inventoryKinds {
+		inv[k] = map[string]int{}
+	}
+	add := func(kind, name string) {
+		if name == "" {
+			name = "(unnamed)"
+		}
+		inv[kind][name]++
+	}
+	var (
+		srvNames, netNames, subNames, routerNames, volNames []string
+	)
+	g, ctx := errgroup.WithContext(context.Background())
+	g.Go(func() error {
+		list, err := cs.Compute.ListInstances()
+		if err != nil {
+			return fmt.Errorf("list instances: %w", err)
+		}
+		for _, s := range list {
+			srvNames = append(srvNames, s.Name)
+		}
+		return nil
+	})
+	g.Go(func() error {
+		list, err := cs.Network.ListNetworks()
+		if err != nil {
+			return fmt.Errorf("list networks: %w", err)
+		}
+		for _, n := range list {
+			netNames = append(netNames, n.Name)
+		}
+		return nil
+	})
+	g.Go(func() error {
+		list, err := cs.Network.ListSubnets()
+		if err != nil {
+			return fmt.Errorf("list subnets: %w", err)
+		}
+		for _, s := range list {
+			subNames = append(subNames, s.Name)
+		}
+		return nil
+	})
+	g.Go(func() error {
+		list, err := cs.Network.ListRouters(ctx)
+		if err != nil {
+			return fmt.Errorf("list routers: %w", err)
+		}
+		for _, r := range list {
+			routerNames = append(routerNames, r.Name)
+		}
+		return nil
+	})
+	g.Go(func() error {
+		list, err := cs.Storage.ListVolumes()
+		if err != nil {
+			return fmt.Errorf("list volumes: %w", err)
+		}
+		for _, v := range list {
+			volNames = append(volNames, v.Name)
+		}
+		return nil
+	})
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	for _, n := range netNames {
+		add("Networks", n)
+	}
+	for _, n := range subNames {
+		add("Subnets", n)
+	}
+	for _, n := range routerNames {
+		add("Routers", n)
+	}
+	for _, n := range srvNames {
+		add("Servers", n)
+	}
+	for _, n := range volNames {
+		add("Volumes", n)
+	}
+	return inv, nil
+}
+
+// diffInventories returns the names whose counts differ between a and b,
+// ordered by kind and name.
+func diffInventories(a, b inventory) []diffEntry {
+	var out []diffEntry
+	for _, kind := range inventoryKinds {
+		names := map[string]bool{}
+		for n := range a[kind] {
+			names[n] = true
+		}
+		for n := range b[kind] {
+			names[n] = true
+		}
+		sorted := make([]string, 0, len(names))
+		for n := range names {
+			sorted = append(sorted, n)
+		}
+		sort.Strings(sorted)
+		for _, n := range sorted {
+			if a[kind][n] != b[kind][n] {
+				out = append(out, diffEntry{kind: kind, name: n, a: a[kind][n], b: b[kind][n]})
+			}
+		}
+	}
+	return out
+}
+
+// renderDiff renders the differences grouped by kind.
+func renderDiff(labelA, labelB string, entries []diffEntry) string {
+	kindStyle := lipgloss.NewStyle().Bold(true).Foreground(theme.Info)
+	onlyAStyle := lipgloss.NewStyle().Foreground(theme.Error)
+	onlyBStyle := lipgloss.NewStyle().Foreground(theme.OK)
+	countStyle := lipgloss.NewStyle().Foreground(theme.Warn)
+	dimStyle := lipgloss.NewStyle().Foreground(theme.Muted)
+
+	var sb strings.Builder
+	sb.WriteString(dimStyle.Render(i18n.T("- only in %s   + only in %s   ~ count differs", labelA, labelB)) + "\n\n")
+	if len(entries) == 0 {
+		sb.WriteString(onlyBStyle.Render(i18n.T("No differences: both contexts have the same resource names.")) + "\n")
+		return sb.String()
+	}
+	lastKind := ""
+	for _, e := range entries {
+		if e.kind != lastKind {
+			if lastKind != "" {
+				sb.WriteString("\n")
+			}
+			sb.WriteString(kindStyle.Render(i18n.T(e.kind)) + "\n")
+			lastKind = e.kind
+		}
+		switch {
+		case e.b == 0:
+			sb.WriteString(onlyAStyle.Render(fmt.Sprintf("  - %s", e.name)) + "\n")
+		case e.a == 0:
+			sb.WriteString(onlyBStyle.Render(fmt.Sprintf("  + %s", e.name)) + "\n")
+		default:
+			sb.WriteString(countStyle.Render(fmt.Sprintf("  ~ %s (%d vs %d)", e.name, e.a, e.b)) + "\n")
+		}
+	}
+	return sb.String()
+}
+
+// Update handles messages.
+func (m DiffModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case diffDataMsg:
+		m.loading = false
+		m.content = msg.content
+		m.err = msg.err
+		m.viewport.SetContent(m.content)
+		return m, nil
+	case tea.WindowSizeMsg:
+		m.viewport.Width = msg.Width
+		m.viewport.Height = msg.Height - 3
+		m.viewport.SetContent(m.content)
+		return m, nil
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "esc":
+			return m, func() tea.Msg { return CloseMsg{} }
+		default:
+			var cmd tea.Cmd
+			m.viewport, cmd = m.viewport.Update(msg)
+			return m, cmd
+		}
+	default:
+		if m.loading {
+			var cmd tea.Cmd
+			m.spinner, cmd = m.spinner.Update(msg)
+			return m, cmd
+		}
+	}
+	return m, nil
+}
+
+// View renders the diff.
+func (m DiffModel) View() string {
+	if m.loading {
+		return m.spinner.View() + i18n.T(" Comparing %s with %s...", m.labelA, m.labelB)
+	}
+	if m.err != nil {
+		return i18n.T("Error: %s\n[esc] close", m.err)
+	}
+	header := i18n.T("Topology diff: %s ↔ %s", m.labelA, m.labelB)
+	footer := i18n.T(" %3.f%% | [j/k] scroll  [esc] close", m.viewport.ScrollPercent()*100)
+	return header + "\n" + m.viewport.View() + "\n" + footer
+}
+
+var _ tea.Model = (*DiffModel)(nil)
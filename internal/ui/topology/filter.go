@@ -0,0 +1,153 @@
+package topology
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
+
+	"ostui/internal/i18n"
+)
+
+// filter restricts the topology tree. Empty fields match everything.
+type filter struct {
+	network string // network ID
+	project string
+	zone    string
+	status  string
+	// pattern matches server, network and volume names case-insensitively.
+	pattern string
+	// hideEmpty folds networks without visible servers into one line.
+	hideEmpty bool
+	// byStatus sorts servers by status before name.
+	byStatus bool
+}
+
+// withoutPattern returns f with the name pattern cleared.
+func (f filter) withoutPattern() filter {
+	f.pattern = ""
+	return f
+}
+
+// active reports whether any filter narrows the tree.
+func (f filter) active() bool {
+	return f.network != "" || f.project != "" || f.zone != "" || f.status != "" || f.pattern != "" || f.hideEmpty
+}
+
+// describe renders the active filters for the header, resolving the
+// network ID to its name.
+func (f filter) describe(d topologyData) string {
+	var parts []string
+	if f.network != "" {
+		name := f.network
+		for _, n := range d.networks {
+			if n.ID == f.network {
+				name = n.Name
+			}
+		}
+		parts = append(parts, "network="+name)
+	}
+	if f.project != "" {
+		parts = append(parts, "project="+f.project)
+	}
+	if f.zone != "" {
+		parts = append(parts, "zone="+f.zone)
+	}
+	if f.status != "" {
+		parts = append(parts, "status="+f.status)
+	}
+	if f.pattern != "" {
+		parts = append(parts, "match="+f.pattern)
+	}
+	if f.hideEmpty {
+		parts = append(parts, i18n.T("collapsed"))
+	}
+	if f.byStatus {
+		parts = append(parts, i18n.T("by status"))
+	}
+	return strings.Join(parts, "  ")
+}
+
+// matches reports whether name contains pattern, ignoring case.
+func matches(name, pattern string) bool {
+	return strings.Contains(strings.ToLower(name), strings.ToLower(pattern))
+}
+
+// visibleServers returns the IDs of the servers passing f. A server matches
+// the pattern by its own name or the name of an attached volume.
+func (d topologyData) visibleServers(f filter) map[string]bool {
+	volNames := make(map[string][]string)
+	for _, v := range d.volumes {
+		for _, att := range v.Attachments {
+			volNames[att.ServerID] = append(volNames[att.ServerID], v.Name)
+		}
+	}
+	out := make(map[string]bool)
+	for _, s := range d.servers {
+		if f.status != "" && s.Status != f.status {
+			continue
+		}
+		if f.project != "" && s.TenantID != f.project {
+			continue
+		}
+		if f.zone != "" && d.zones[s.ID] != f.zone {
+			continue
+		}
+		if f.pattern != "" && !matches(s.Name, f.pattern) {
+			hit := false
+			for _, name := range volNames[s.ID] {
+				hit = hit || matches(name, f.pattern)
+			}
+			if !hit {
+				continue
+			}
+		}
+		out[s.ID] = true
+	}
+	return out
+}
+
+// networkIDs returns the network IDs sorted by name, the order the network
+// filter cycles through.
+func (d topologyData) networkIDs() []string {
+	nets := append(d.networks[:0:0], d.networks...)
+	sort.Slice(nets, func(i, j int) bool { return nets[i].Name < nets[j].Name })
+	ids := make([]string, 0, len(nets))
+	for _, n := range nets {
+		ids = append(ids, n.ID)
+	}
+	return ids
+}
+
+// serverValues returns the sorted distinct non-empty values of one server
+// attribute, the options a filter cycles through.
+func (d topologyData) serverValues(attr func(servers.Server) string) []string {
+	seen := make(map[string]bool)
+	for _, s := range d.servers {
+		if v := attr(s); v != "" {
+			seen[v] = true
+		}
+	}
+	out := make([]string, 0, len(seen))
+	for v := range seen {
+		out = append(out, v)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// cycle returns the option after cur, or "" (no filter) after the last.
+func cycle(options []string, cur string) string {
+	if cur == "" {
+		if len(options) == 0 {
+			return ""
+		}
+		return options[0]
+	}
+	for i, o := range options {
+		if o == cur && i+1 < len(options) {
+			return options[i+1]
+		}
+	}
+	return ""
+}
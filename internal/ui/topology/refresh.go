@@ -0,0 +1,111 @@
+package topology
+
+import (
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/common"
+)
+
+// topologyRefreshTickMsg starts an auto-refresh; seq drops the ticks of an
+// auto-refresh that was switched off.
+type topologyRefreshTickMsg struct{ seq int }
+
+// topologyRefreshMsg delivers a complete new inventory, so that the
+// changes are computed against a consistent snapshot. A list that failed
+// keeps its previous content and its error is in errs.
+type topologyRefreshMsg struct {
+	data topologyData
+	errs map[string]error
+}
+
+func refreshTick(seq int) tea.Cmd {
+	return tea.Tick(common.AutoRefreshInterval, func(time.Time) tea.Msg { return topologyRefreshTickMsg{seq: seq} })
+}
+
+// refreshCmd re-lists everything in parallel and delivers it at once.
+func (m TopologyModel) refreshCmd() tea.Cmd {
+	cmds := m.fetchCmds()
+	d := m.data
+	return func() tea.Msg {
+		msgs := make([]tea.Msg, len(cmds))
+		var wg sync.WaitGroup
+		for i, cmd := range cmds {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				msgs[i] = cmd()
+			}()
+		}
+		wg.Wait()
+		errs := make(map[string]error)
+		for _, msg := range msgs {
+			p := msg.(topologyPartMsg)
+			if p.err != nil {
+				errs[p.name] = p.err
+				continue
+			}
+			p.apply(&d)
+		}
+		return topologyRefreshMsg{data: d, errs: errs}
+	}
+}
+
+// nodesOf lists the resources of the tree with their status.
+func nodesOf(d topologyData) map[string]common.Node {
+	nodes := map[string]common.Node{}
+	for _, n := range d.networks {
+		nodes["network:"+n.ID] = common.Node{Label: "Network " + n.Name, Status: n.Status}
+	}
+	for _, s := range d.servers {
+		nodes["server:"+s.ID] = common.Node{Label: "Server " + s.Name, Status: s.Status}
+	}
+	for _, p := range d.ports {
+		label := "Port " + p.ID
+		if len(p.FixedIPs) > 0 {
+			label = "Port " + p.FixedIPs[0].IPAddress
+		}
+		nodes["port:"+p.ID] = common.Node{Label: label, Status: p.Status}
+	}
+	for _, f := range d.fips {
+		nodes["fip:"+f.ID] = common.Node{Label: "FIP " + f.FloatingIP, Status: f.Status}
+	}
+	for _, v := range d.volumes {
+		name := v.Name
+		if name == "" {
+			name = v.ID
+		}
+		nodes["volume:"+v.ID] = common.Node{Label: "Volume " + name, Status: v.Status}
+	}
+	for _, r := range d.routers {
+		nodes["router:"+r.ID] = common.Node{Label: "Router " + r.Name, Status: r.Status}
+	}
+	return nodes
+}
+
+// markChanges sets the change markers of the lines and lists the resources
+// that are gone at the end of the tree.
+func markChanges(lines []line, c common.Changes) []line {
+	if c.Empty() {
+		return lines
+	}
+	for i := range lines {
+		if lines[i].key == "" {
+			continue
+		}
+		lines[i].mark = c.Mark(lines[i].key)
+		lines[i].was = c.Changed[lines[i].key]
+	}
+	if len(c.Removed) > 0 {
+		if n := len(lines); n > 0 && lines[n-1].text != "" {
+			lines = append(lines, line{})
+		}
+		lines = append(lines, line{text: i18n.T("Gone since the last refresh (%d):", len(c.Removed)), kind: kindDim})
+		for _, n := range c.Removed {
+			lines = append(lines, line{text: n.Label, kind: kindDim, mark: "-"})
+		}
+	}
+	return lines
+}
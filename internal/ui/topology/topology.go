@@ -3,12 +3,10 @@ package topology
 import (
 	"context"
 	"fmt"
-	"sort"
 	"strings"
-	"sync"
 
 	"github.com/charmbracelet/bubbles/spinner"
-	"github.com/charmbracelet/bubbles/viewport"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/gophercloud/gophercloud/openstack/blockstorage/v3/volumes"
@@ -18,374 +16,499 @@ import (
 	"github.com/gophercloud/gophercloud/openstack/networking/v2/ports"
 	"github.com/gophercloud/gophercloud/openstack/networking/v2/subnets"
 	"ostui/internal/client"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/common"
+	"ostui/internal/ui/theme"
 )
 
+// TopologyModel renders the project as a tree grouped by network. The
+// resource lists load independently and the tree fills in as each arrives;
+// the filters re-render it locally. Only the lines on screen are styled, so
+// a project with thousands of ports stays cheap to scroll.
 type TopologyModel struct {
-	compute  client.ComputeClient
-	network  client.NetworkClient
-	storage  client.StorageClient
-	loading  bool
-	err      error
-	content  string
-	viewport viewport.Model
-	spinner  spinner.Model
+	compute client.ComputeClient
+	network client.NetworkClient
+	storage client.StorageClient
+	loading bool
+	// errs holds the error of each list that failed; the tree is drawn
+	// from the lists that loaded.
+	errs    map[string]error
+	data    topologyData
+	filter  filter
+	spinner spinner.Model
+	// pending names the lists still loading.
+	pending []string
+
+	lines []line
+	// expanded overrides the default open state of a section.
+	expanded map[string]bool
+	cursor   int
+	offset   int
+	width    int
+	height   int
+
+	// editing is true while the name pattern is typed; prevPattern is
+	// restored by esc.
+	editing     bool
+	input       textinput.Model
+	prevPattern string
+
+	// Auto-refresh re-lists everything on an interval and marks what
+	// changed; nodes is the inventory of the last refresh and flash counts
+	// down the frames changed lines blink for.
+	autoRefresh bool
+	refreshing  bool
+	refreshSeq  int
+	nodes       map[string]common.Node
+	changes     common.Changes
+	flash       int
+	flashSeq    int
+}
+
+// topologyData is the raw inventory the tree is rendered from.
+type topologyData struct {
+	servers  []servers.Server
+	networks []networks.Network
+	subnets  []subnets.Subnet
+	ports    []ports.Port
+	fips     []floatingips.FloatingIP
+	volumes  []volumes.Volume
+	routers  []client.Router
+	// morePorts is set when the project has more than topologyPortLimit
+	// ports and the rest were not listed.
+	morePorts bool
+	// zones maps server IDs to availability zones; empty when the cloud
+	// does not report them.
+	zones map[string]string
 }
 
-type topologyDataMsg struct {
-	content string
-	err     error
+// topologyPartMsg delivers one resource list; apply stores it in the data.
+type topologyPartMsg struct {
+	name  string
+	apply func(*topologyData)
+	err   error
 }
 
+// topologySources names the resource lists, in the order their errors
+// are shown.
+var topologySources = []string{"networks", "subnets", "servers", "ports", "floating IPs", "volumes", "routers", "zones"}
+
+// topologyPortLimit caps the port listing; the ports past it are left out
+// of the tree with a warning.
+const topologyPortLimit = 5000
+
 func NewTopologyModel(cc client.ComputeClient, nc client.NetworkClient, sc client.StorageClient) TopologyModel {
 	s := spinner.New()
 	s.Spinner = spinner.Dot
-	return TopologyModel{compute: cc, network: nc, storage: sc, loading: true, spinner: s, viewport: viewport.New(80, 24)}
+	ti := textinput.New()
+	ti.Prompt = "match: "
+	ti.CharLimit = 64
+	return TopologyModel{
+		compute: cc, network: nc, storage: sc, loading: true, spinner: s, input: ti,
+		pending: append([]string(nil), topologySources...),
+		errs:    make(map[string]error), expanded: make(map[string]bool), width: 80, height: 24,
+	}
 }
 
 func (m TopologyModel) Init() tea.Cmd {
-	return tea.Batch(m.spinner.Tick, func() tea.Msg {
-		content, err := m.buildTopology()
-		return topologyDataMsg{content: content, err: err}
-	})
+	return tea.Batch(append(m.fetchCmds(), m.spinner.Tick)...)
 }
 
-func (m *TopologyModel) buildTopology() (string, error) {
-	ctx := context.Background()
-	var (
-		srvList    []servers.Server
-		netList    []networks.Network
-		subList    []subnets.Subnet
-		portList   []ports.Port
-		fipList    []floatingips.FloatingIP
-		volList    []volumes.Volume
-		routerList []client.Router
-	)
-	errChan := make(chan error, 7)
-	var wg sync.WaitGroup
-	wg.Add(7)
-	go func() {
-		defer wg.Done()
-		var err error
-		srvList, err = m.compute.ListInstances()
-		if err != nil {
-			errChan <- fmt.Errorf("list instances: %w", err)
-		}
-	}()
-	go func() {
-		defer wg.Done()
-		var err error
-		netList, err = m.network.ListNetworks()
-		if err != nil {
-			errChan <- fmt.Errorf("list networks: %w", err)
-		}
-	}()
-	go func() {
-		defer wg.Done()
-		var err error
-		subList, err = m.network.ListSubnets()
-		if err != nil {
-			errChan <- fmt.Errorf("list subnets: %w", err)
-		}
-	}()
-	go func() {
-		defer wg.Done()
-		var err error
-		portList, err = m.network.ListPorts(ctx)
-		if err != nil {
-			errChan <- fmt.Errorf("list ports: %w", err)
-		}
-	}()
-	go func() {
-		defer wg.Done()
-		var err error
-		fipList, err = m.network.ListFloatingIPs()
-		if err != nil {
-			errChan <- fmt.Errorf("list floating IPs: %w", err)
-		}
-	}()
-	go func() {
-		defer wg.Done()
-		var err error
-		volList, err = m.storage.ListVolumes()
-		if err != nil {
-			errChan <- fmt.Errorf("list volumes: %w", err)
-		}
-	}()
-	go func() {
-		defer wg.Done()
-		var err error
-		routerList, err = m.network.ListRouters(ctx)
-		if err != nil {
-			errChan <- fmt.Errorf("list routers: %w", err)
-		}
-	}()
-	wg.Wait()
-	close(errChan)
-	for e := range errChan {
-		if e != nil {
-			return "", e
-		}
+// Reload re-lists everything and marks what changed, as r does.
+func (m TopologyModel) Reload() tea.Cmd {
+	if m.loading || m.refreshing || m.editing {
+		return nil
 	}
+	return m.refreshCmd()
+}
 
-	// Build lookup maps
-	netMap := make(map[string]networks.Network)
-	for _, n := range netList {
-		netMap[n.ID] = n
-	}
-	subnetMap := make(map[string]subnets.Subnet)
-	for _, s := range subList {
-		subnetMap[s.ID] = s
-	}
-	// server map
-	serverMap := make(map[string]servers.Server)
-	for _, s := range srvList {
-		serverMap[s.ID] = s
-	}
-	// ports per server and per network
-	netServers := make(map[string]map[string]bool) // networkID -> set of server IDs
-	serverPorts := make(map[string][]ports.Port)
-	for _, p := range portList {
-		if p.DeviceID != "" {
-			serverPorts[p.DeviceID] = append(serverPorts[p.DeviceID], p)
-			if _, ok := netServers[p.NetworkID]; !ok {
-				netServers[p.NetworkID] = make(map[string]bool)
-			}
-			netServers[p.NetworkID][p.DeviceID] = true
-		}
-	}
-	// floating IPs per port
-	portFIPs := make(map[string][]floatingips.FloatingIP)
-	for _, f := range fipList {
-		if f.PortID != "" {
-			portFIPs[f.PortID] = append(portFIPs[f.PortID], f)
-		}
-	}
-	// volumes per server
-	serverVolumes := make(map[string][]volumes.Volume)
-	for _, v := range volList {
-		for _, att := range v.Attachments {
-			if att.ServerID != "" {
-				serverVolumes[att.ServerID] = append(serverVolumes[att.ServerID], v)
-			}
-		}
-	}
-	// routers per network (using external gateway network ID)
-	netRouters := make(map[string][]client.Router)
-	for _, r := range routerList {
-		if r.GatewayInfo.NetworkID != "" {
-			netRouters[r.GatewayInfo.NetworkID] = append(netRouters[r.GatewayInfo.NetworkID], r)
+// part wraps the fetch of one resource list.
+func part(name string, fetch func() (func(*topologyData), error)) tea.Cmd {
+	return func() tea.Msg {
+		apply, err := fetch()
+		if err != nil {
+			return topologyPartMsg{name: name, err: fmt.Errorf("list %s: %w", name, err)}
 		}
+		return topologyPartMsg{name: name, apply: apply}
 	}
+}
 
-	// Styles
-	networkStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#5BC0DE"))
-	activeStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#5CB85C"))
-	shutoffStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#F0AD4E"))
-	errorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#E74C3C"))
-	fipStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#C678DD"))
-	volStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#9B59B6"))
-	dimStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#666666"))
-
-	// Helper for server status style
-	serverStatusStyle := func(status string) lipgloss.Style {
-		switch status {
-		case "ACTIVE":
-			return activeStyle
-		case "SHUTOFF":
-			return shutoffStyle
-		case "ERROR":
-			return errorStyle
-		default:
-			return dimStyle
-		}
+// fetchCmds lists every resource shown by the tree, one command per list.
+func (m TopologyModel) fetchCmds() []tea.Cmd {
+	ctx := context.Background()
+	cc, nc, sc := m.compute, m.network, m.storage
+	return []tea.Cmd{
+		part("networks", func() (func(*topologyData), error) {
+			l, err := nc.ListNetworks()
+			return func(d *topologyData) { d.networks = l }, err
+		}),
+		part("subnets", func() (func(*topologyData), error) {
+			l, err := nc.ListSubnets()
+			return func(d *topologyData) { d.subnets = l }, err
+		}),
+		part("servers", func() (func(*topologyData), error) {
+			l, err := cc.ListInstances()
+			return func(d *topologyData) { d.servers = l }, err
+		}),
+		part("ports", func() (func(*topologyData), error) {
+			l, more, err := nc.ListPortsUpTo(ctx, topologyPortLimit)
+			return func(d *topologyData) { d.ports, d.morePorts = l, more }, err
+		}),
+		part("floating IPs", func() (func(*topologyData), error) {
+			l, err := nc.ListFloatingIPs()
+			return func(d *topologyData) { d.fips = l }, err
+		}),
+		part("volumes", func() (func(*topologyData), error) {
+			l, err := sc.ListVolumes()
+			return func(d *topologyData) { d.volumes = l }, err
+		}),
+		part("routers", func() (func(*topologyData), error) {
+			l, err := nc.ListRouters(ctx)
+			return func(d *topologyData) { d.routers = l }, err
+		}),
+		part("zones", func() (func(*topologyData), error) {
+			// Zones only feed the zone filter; a cloud without them still
+			// renders.
+			z, _ := cc.ListServerZones(ctx)
+			return func(d *topologyData) { d.zones = z }, nil
+		}),
 	}
+}
 
-	// Tree characters
-	branch := dimStyle.Render("├── ")
-	lastBranch := dimStyle.Render("└── ")
-	indent := dimStyle.Render("│   ")
+// isOpen reports whether a section is expanded. Sections of a large
+// topology start collapsed.
+func (m TopologyModel) isOpen(section string) bool {
+	if open, ok := m.expanded[section]; ok {
+		return open
+	}
+	return len(m.data.ports) <= largeTopology
+}
 
-	var sb strings.Builder
-	// Sort networks by name for deterministic output
-	netIDs := make([]string, 0, len(netList))
-	for _, n := range netList {
-		netIDs = append(netIDs, n.ID)
+// viewHeight is the number of tree lines on screen.
+func (m TopologyModel) viewHeight() int {
+	h := m.height - 3
+	if m.warning() != "" {
+		h--
+	}
+	if h > 1 {
+		return h
 	}
-	sort.Slice(netIDs, func(i, j int) bool {
-		return netMap[netIDs[i]].Name < netMap[netIDs[j]].Name
-	})
+	return 1
+}
 
-	for _, nid := range netIDs {
-		n := netMap[nid]
-		// Determine CIDR from first subnet if available
-		cidr := ""
-		if len(n.Subnets) > 0 {
-			if s, ok := subnetMap[n.Subnets[0]]; ok {
-				cidr = s.CIDR
+func (m TopologyModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case topologyPartMsg:
+		rest := m.pending[:0:0]
+		for _, p := range m.pending {
+			if p != msg.name {
+				rest = append(rest, p)
 			}
 		}
-		header := fmt.Sprintf("Network: %s (%s)", n.Name, cidr)
-		sb.WriteString(networkStyle.Render(header))
-		sb.WriteString("\n")
-		// Servers in this network
-		serverSet := netServers[nid]
-		// Convert set to slice
-		srvIDs := make([]string, 0, len(serverSet))
-		for sid := range serverSet {
-			srvIDs = append(srvIDs, sid)
+		m.pending = rest
+		if m.errs == nil {
+			m.errs = make(map[string]error)
 		}
-		sort.Slice(srvIDs, func(i, j int) bool {
-			return serverMap[srvIDs[i]].Name < serverMap[srvIDs[j]].Name
-		})
-		for si, sid := range srvIDs {
-			srv := serverMap[sid]
-			// Determine prefix for server line
-			isLastServer := si == len(srvIDs)-1 && len(netRouters[nid]) == 0
-			prefix := branch
-			if isLastServer {
-				prefix = lastBranch
-			}
-			srvLine := fmt.Sprintf("Server: %s [%s]", srv.Name, srv.Status)
-			sb.WriteString(prefix + serverStatusStyle(srv.Status).Render(srvLine))
-			sb.WriteString("\n")
-			// Ports for server
-			ports := serverPorts[srv.ID]
-			sort.Slice(ports, func(i, j int) bool { return ports[i].ID < ports[j].ID })
-			for pi, p := range ports {
-				// Determine prefix for port line
-				portIsLast := pi == len(ports)-1 && len(serverVolumes[srv.ID]) == 0 && len(portFIPs[p.ID]) == 0
-				portPrefix := indent
-				if portIsLast {
-					portPrefix += lastBranch
-				} else {
-					portPrefix += branch
-				}
-				ip := ""
-				if len(p.FixedIPs) > 0 {
-					ip = p.FixedIPs[0].IPAddress
-				}
-				sb.WriteString(portPrefix + fmt.Sprintf("Port: %s", ip))
-				sb.WriteString("\n")
-				// Floating IPs attached to this port
-				fips := portFIPs[p.ID]
-				for fi, f := range fips {
-					fipPrefix := indent + "    "
-					if fi == len(fips)-1 {
-						fipPrefix += lastBranch
-					} else {
-						fipPrefix += branch
-					}
-					sb.WriteString(fipPrefix + fipStyle.Render(fmt.Sprintf("FIP: %s", f.FloatingIP)))
-					sb.WriteString("\n")
-				}
-			}
-			// Volumes attached to server
-			vols := serverVolumes[srv.ID]
-			for vi, v := range vols {
-				volIsLast := vi == len(vols)-1
-				volPrefix := indent
-				if volIsLast {
-					volPrefix += lastBranch
-				} else {
-					volPrefix += branch
-				}
-				device := ""
-				if len(v.Attachments) > 0 {
-					device = v.Attachments[0].Device
-				}
-				sb.WriteString(volPrefix + volStyle.Render(fmt.Sprintf("Vol: %s %dGB", device, v.Size)))
-				sb.WriteString("\n")
-			}
+		if msg.err != nil {
+			m.errs[msg.name] = msg.err
+		} else {
+			delete(m.errs, msg.name)
+			msg.apply(&m.data)
 		}
-		// Routers for this network
-		routers := netRouters[nid]
-		for ri, r := range routers {
-			routerIsLast := ri == len(routers)-1
-			routerPrefix := branch
-			if routerIsLast {
-				routerPrefix = lastBranch
-			}
-			sb.WriteString(routerPrefix + fmt.Sprintf("Router: %s", r.Name))
-			sb.WriteString("\n")
+		// The tree can be drawn once the networks are known, or failed to
+		// load; the rest fills in as it arrives.
+		if msg.name == "networks" {
+			m.loading = false
 		}
-		sb.WriteString("\n")
-	}
-	// Unattached resources
-	var unattachedFIPs []floatingips.FloatingIP
-	for _, f := range fipList {
-		if f.PortID == "" {
-			unattachedFIPs = append(unattachedFIPs, f)
+		if len(m.pending) == 0 && m.nodes == nil {
+			m.nodes = nodesOf(m.data)
 		}
-	}
-	var unattachedVols []volumes.Volume
-	for _, v := range volList {
-		if len(v.Attachments) == 0 {
-			unattachedVols = append(unattachedVols, v)
+		m.rerender()
+		return m, nil
+	case topologyRefreshTickMsg:
+		if !m.autoRefresh || msg.seq != m.refreshSeq || m.refreshing {
+			return m, nil
 		}
-	}
-	if len(unattachedFIPs) > 0 || len(unattachedVols) > 0 {
-		sb.WriteString("Unattached resources:\n")
-		for i, f := range unattachedFIPs {
-			isLast := i == len(unattachedFIPs)-1 && len(unattachedVols) == 0
-			prefix := branch
-			if isLast {
-				prefix = lastBranch
-			}
-			sb.WriteString(prefix + fipStyle.Render(fmt.Sprintf("FIP: %s (not associated)", f.FloatingIP)))
-			sb.WriteString("\n")
+		m.refreshing = true
+		return m, m.refreshCmd()
+	case topologyRefreshMsg:
+		m.refreshing = false
+		var cmds []tea.Cmd
+		if m.autoRefresh {
+			cmds = append(cmds, refreshTick(m.refreshSeq))
 		}
-		for i, v := range unattachedVols {
-			isLast := i == len(unattachedVols)-1
-			prefix := branch
-			if isLast {
-				prefix = lastBranch
-			}
-			sb.WriteString(prefix + volStyle.Render(fmt.Sprintf("Vol: %s %dGB (available)", v.Name, v.Size)))
-			sb.WriteString("\n")
+		m.errs = msg.errs
+		nodes := nodesOf(msg.data)
+		m.changes = common.DiffNodes(m.nodes, nodes)
+		m.nodes, m.data = nodes, msg.data
+		m.rerender()
+		if !m.changes.Empty() {
+			m.flash = common.FlashFrames
+			m.flashSeq++
+			cmds = append(cmds, common.FlashCmd(m.flashSeq))
+		}
+		return m, tea.Batch(cmds...)
+	case common.FlashMsg:
+		if msg.Seq != m.flashSeq || m.flash == 0 {
+			return m, nil
+		}
+		m.flash--
+		if m.flash > 0 {
+			return m, common.FlashCmd(m.flashSeq)
 		}
-	}
-	return sb.String(), nil
-}
-
-func (m TopologyModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
-	switch msg := msg.(type) {
-	case topologyDataMsg:
-		m.loading = false
-		m.content = msg.content
-		m.err = msg.err
-		m.viewport.SetContent(m.content)
 		return m, nil
 	case tea.WindowSizeMsg:
-		m.viewport.Width = msg.Width
-		m.viewport.Height = msg.Height - 3
-		m.viewport.SetContent(m.content)
+		m.width = msg.Width
+		m.height = msg.Height
+		m.scrollToCursor()
 		return m, nil
 	case tea.KeyMsg:
+		if m.editing {
+			return m.updatePattern(msg)
+		}
 		switch msg.String() {
 		case "q", "esc":
 			return m, func() tea.Msg { return CloseMsg{} }
+		case "j", "down":
+			m.moveCursor(1)
+			return m, nil
+		case "k", "up":
+			m.moveCursor(-1)
+			return m, nil
+		case "pgdown":
+			m.moveCursor(m.viewHeight())
+			return m, nil
+		case "pgup":
+			m.moveCursor(-m.viewHeight())
+			return m, nil
+		case "home":
+			m.moveCursor(-len(m.lines))
+			return m, nil
+		case "end", "G":
+			m.moveCursor(len(m.lines))
+			return m, nil
+		case "enter":
+			m.toggleSection()
+			return m, nil
+		case "+", "-":
+			open := msg.String() == "+"
+			for _, l := range buildLines(m.data, m.filter, func(string) bool { return false }) {
+				if l.section != "" {
+					m.expanded[l.section] = open
+				}
+			}
+			m.rerender()
+			return m, nil
+		case "n":
+			m.filter.network = cycle(m.data.networkIDs(), m.filter.network)
+		case "s":
+			m.filter.status = cycle(m.data.serverValues(func(s servers.Server) string { return s.Status }), m.filter.status)
+		case "p":
+			m.filter.project = cycle(m.data.serverValues(func(s servers.Server) string { return s.TenantID }), m.filter.project)
+		case "z":
+			m.filter.zone = cycle(m.data.serverValues(func(s servers.Server) string { return m.data.zones[s.ID] }), m.filter.zone)
+		case "e":
+			m.filter.hideEmpty = !m.filter.hideEmpty
+		case "o":
+			m.filter.byStatus = !m.filter.byStatus
+		case "a":
+			m.autoRefresh = !m.autoRefresh
+			m.refreshSeq++
+			if m.autoRefresh {
+				return m, refreshTick(m.refreshSeq)
+			}
+			return m, nil
+		case "r":
+			if m.refreshing {
+				return m, nil
+			}
+			m.refreshing = true
+			return m, m.refreshCmd()
+		case "x":
+			m.filter = filter{}
+		case "/":
+			m.editing = true
+			m.prevPattern = m.filter.pattern
+			m.input.SetValue(m.filter.pattern)
+			m.input.CursorEnd()
+			return m, m.input.Focus()
 		default:
-			var cmd tea.Cmd
-			m.viewport, cmd = m.viewport.Update(msg)
-			return m, cmd
+			return m, nil
 		}
+		m.cursor, m.offset = 0, 0
+		m.rerender()
+		return m, nil
 	default:
-		if m.loading {
+		var cmds []tea.Cmd
+		if len(m.pending) > 0 {
 			var cmd tea.Cmd
 			m.spinner, cmd = m.spinner.Update(msg)
-			return m, cmd
+			cmds = append(cmds, cmd)
+		}
+		if m.editing {
+			var cmd tea.Cmd
+			m.input, cmd = m.input.Update(msg)
+			cmds = append(cmds, cmd)
 		}
+		return m, tea.Batch(cmds...)
+	}
+}
+
+// updatePattern edits the name pattern, re-rendering on every keystroke.
+// Enter keeps the pattern; esc restores the previous one.
+func (m TopologyModel) updatePattern(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEnter:
+		m.editing = false
+		m.input.Blur()
+		return m, nil
+	case tea.KeyEsc:
+		m.editing = false
+		m.input.Blur()
+		m.filter.pattern = m.prevPattern
+		m.rerender()
+		return m, nil
 	}
-	return m, nil
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	m.filter.pattern = strings.TrimSpace(m.input.Value())
+	m.cursor, m.offset = 0, 0
+	m.rerender()
+	return m, cmd
 }
 
+// rerender lays out the tree after the data, a filter or the expansion
+// state changed.
+func (m *TopologyModel) rerender() {
+	m.lines = markChanges(buildLines(m.data, m.filter, m.isOpen), m.changes)
+	if m.cursor >= len(m.lines) {
+		m.cursor = len(m.lines) - 1
+	}
+	if m.cursor < 0 {
+		m.cursor = 0
+	}
+	m.scrollToCursor()
+}
+
+// moveCursor moves the cursor by delta lines, clamped to the tree.
+func (m *TopologyModel) moveCursor(delta int) {
+	m.cursor += delta
+	if m.cursor >= len(m.lines) {
+		m.cursor = len(m.lines) - 1
+	}
+	if m.cursor < 0 {
+		m.cursor = 0
+	}
+	m.scrollToCursor()
+}
+
+// scrollToCursor keeps the cursor inside the visible window.
+func (m *TopologyModel) scrollToCursor() {
+	h := m.viewHeight()
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if m.cursor >= m.offset+h {
+		m.offset = m.cursor - h + 1
+	}
+	if last := len(m.lines) - h; m.offset > last {
+		m.offset = last
+	}
+	if m.offset < 0 {
+		m.offset = 0
+	}
+}
+
+// toggleSection expands or collapses the section the cursor is in.
+func (m *TopologyModel) toggleSection() {
+	for i := m.cursor; i >= 0 && i < len(m.lines); i-- {
+		if s := m.lines[i].section; s != "" {
+			m.expanded[s] = !m.isOpen(s)
+			m.cursor = i
+			m.rerender()
+			return
+		}
+	}
+}
+
+// CapturingInput reports whether the name pattern is being typed, so the
+// app forwards every key.
+func (m TopologyModel) CapturingInput() bool { return m.editing }
+
 func (m TopologyModel) View() string {
 	if m.loading {
-		return m.spinner.View() + " Loading topology..."
+		return m.spinner.View() + " " + i18n.T("Loading topology...")
+	}
+	header := i18n.T("Topology")
+	if m.filter.active() || m.filter.byStatus {
+		header += i18n.T("  %d/%d servers  ", len(m.data.visibleServers(m.filter)), len(m.data.servers)) +
+			lipgloss.NewStyle().Foreground(theme.Warn).Render(m.filter.describe(m.data))
+	}
+	if len(m.pending) > 0 {
+		header += "  " + m.spinner.View() + " loading " + strings.Join(m.pending, ", ")
+	}
+	header += m.refreshStatus()
+	if w := m.warning(); w != "" {
+		header += "\n" + lipgloss.NewStyle().Foreground(theme.Warn).Render(w)
+	}
+	var b strings.Builder
+	h := m.viewHeight()
+	cursorStyle := lipgloss.NewStyle().Reverse(true)
+	for i := m.offset; i < m.offset+h; i++ {
+		if i < len(m.lines) {
+			if i == m.cursor {
+				b.WriteString(cursorStyle.Render(m.lines[i].tree + m.lines[i].text))
+			} else if m.flash%2 == 1 && m.lines[i].mark != "" {
+				b.WriteString(m.lines[i].tree + markStyle(m.lines[i].mark).Reverse(true).Render(m.lines[i].mark+" "+m.lines[i].text))
+			} else {
+				b.WriteString(m.lines[i].render())
+			}
+		}
+		b.WriteString("\n")
+	}
+	pct := 100.0
+	if last := len(m.lines) - h; last > 0 {
+		pct = float64(m.offset) / float64(last) * 100
+	}
+	footer := i18n.T(" %3.f%% | [j/k] move  [enter] expand  [+/-] all  [n]etwork [s]tatus [p]roject [z]one  [/] match  [e] collapse empty  [o] order  [x] clear  [a] auto-refresh  [r] refresh  [esc] close", pct)
+	if m.editing {
+		footer = " " + m.input.View() + "  " + i18n.T("[enter] keep  [esc] cancel")
+	}
+	return header + "\n" + b.String() + footer
+}
+
+// refreshStatus describes auto-refresh and the changes of the last
+// refresh for the header.
+func (m TopologyModel) refreshStatus() string {
+	var out string
+	if m.autoRefresh {
+		out += lipgloss.NewStyle().Foreground(theme.Info).Render(i18n.T("  ⟳ every %s", common.AutoRefreshInterval))
+	}
+	switch {
+	case m.refreshing:
+		out += "  refreshing…"
+	case !m.changes.Empty():
+		out += lipgloss.NewStyle().Foreground(theme.Warn).Render("  Δ " + m.changes.Summary())
+	}
+	return out
+}
+
+// warning lists the resource lists that failed to load and a cut port
+// listing, which leave the tree partial.
+func (m TopologyModel) warning() string {
+	var parts []string
+	for _, name := range topologySources {
+		if err := m.errs[name]; err != nil {
+			parts = append(parts, err.Error())
+		}
+	}
+	if m.data.morePorts {
+		parts = append(parts, i18n.T("only the first %d ports are shown", topologyPortLimit))
+	}
+	if len(parts) == 0 {
+		return ""
 	}
-	header := "Topology"
-	footer := fmt.Sprintf(" %3.f%% | [j/k] scroll  [esc] close", m.viewport.ScrollPercent()*100)
-	return header + "\n" + m.viewport.View() + "\n" + footer
+	return i18n.T("⚠ Partial topology: %s", strings.Join(parts, "; "))
 }
 
 type CloseMsg struct{}
@@ -0,0 +1,241 @@
+package topology
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/gophercloud/gophercloud/openstack/blockstorage/v3/volumes"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/networks"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/ports"
+	"ostui/internal/ui/common"
+)
+
+// render lays out d fully expanded as plain text.
+func render(d topologyData, f filter) string {
+	return text(buildLines(d, f, func(string) bool { return true }))
+}
+
+func text(lines []line) string {
+	var b strings.Builder
+	for _, l := range lines {
+		b.WriteString(l.tree + l.text + "\n")
+	}
+	return b.String()
+}
+
+func testData() topologyData {
+	return topologyData{
+		servers: []servers.Server{
+			{ID: "s1", Name: "web-1", Status: "ACTIVE", TenantID: "p1"},
+			{ID: "s2", Name: "web-2", Status: "SHUTOFF", TenantID: "p1"},
+			{ID: "s3", Name: "db-1", Status: "ACTIVE", TenantID: "p2"},
+		},
+		networks: []networks.Network{{ID: "n1", Name: "frontend"}, {ID: "n2", Name: "backend"}, {ID: "n3", Name: "spare"}},
+		ports: []ports.Port{
+			{ID: "p-a", NetworkID: "n1", DeviceID: "s1", FixedIPs: []ports.IP{{IPAddress: "10.0.0.11"}}},
+			{ID: "p-b", NetworkID: "n1", DeviceID: "s2", FixedIPs: []ports.IP{{IPAddress: "10.0.0.12"}}},
+			{ID: "p-c", NetworkID: "n2", DeviceID: "s3", FixedIPs: []ports.IP{{IPAddress: "10.1.0.5"}}},
+		},
+		volumes: []volumes.Volume{
+			{ID: "v1", Name: "pgdata", Size: 50, Attachments: []volumes.Attachment{{ServerID: "s3", Device: "/dev/vdb"}}},
+			{ID: "v2", Name: "scratch", Size: 10},
+		},
+		zones: map[string]string{"s1": "az1", "s2": "az2", "s3": "az1"},
+	}
+}
+
+func TestRenderFilters(t *testing.T) {
+	d := testData()
+	for _, tc := range []struct {
+		name    string
+		f       filter
+		want    []string
+		notWant []string
+	}{
+		{"none", filter{}, []string{"web-1", "web-2", "db-1", "spare", "scratch"}, nil},
+		{"network", filter{network: "n2"}, []string{"db-1"}, []string{"web-1", "frontend", "scratch"}},
+		{"status", filter{status: "ACTIVE"}, []string{"web-1", "db-1"}, []string{"web-2", "scratch"}},
+		{"project", filter{project: "p2"}, []string{"db-1"}, []string{"web-1", "scratch"}},
+		{"zone", filter{zone: "az2"}, []string{"web-2"}, []string{"web-1", "db-1"}},
+		{"server name", filter{pattern: "WEB"}, []string{"web-1", "web-2"}, []string{"backend", "spare", "scratch"}},
+		{"volume name", filter{pattern: "pgdata"}, []string{"db-1"}, []string{"web-1"}},
+		{"network name", filter{pattern: "front"}, []string{"web-1", "web-2"}, []string{"db-1"}},
+		{"collapse", filter{hideEmpty: true}, []string{"1 networks without servers: spare"}, []string{"Network: spare"}},
+	} {
+		out := render(d, tc.f)
+		for _, w := range tc.want {
+			if !strings.Contains(out, w) {
+				t.Errorf("%s: expected %q in\n%s", tc.name, w, out)
+			}
+		}
+		for _, w := range tc.notWant {
+			if strings.Contains(out, w) {
+				t.Errorf("%s: unexpected %q in\n%s", tc.name, w, out)
+			}
+		}
+	}
+	if out := render(d, filter{pattern: "nothing"}); !strings.Contains(out, "Nothing matches") {
+		t.Errorf("expected the empty notice, got %q", out)
+	}
+}
+
+func TestCycle(t *testing.T) {
+	opts := []string{"a", "b"}
+	cur := ""
+	var got []string
+	for i := 0; i < 3; i++ {
+		cur = cycle(opts, cur)
+		got = append(got, cur)
+	}
+	if strings.Join(got, ",") != "a,b," {
+		t.Errorf("unexpected cycle %v", got)
+	}
+}
+
+func TestPatternInput(t *testing.T) {
+	m := NewTopologyModel(nil, nil, nil)
+	m = load(m, testData())
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+	m = updated.(TopologyModel)
+	if !m.CapturingInput() {
+		t.Fatal("expected / to start the pattern input")
+	}
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("db")})
+	m = updated.(TopologyModel)
+	if strings.Contains(text(m.lines), "web-1") || !strings.Contains(text(m.lines), "db-1") {
+		t.Fatalf("expected the tree to follow the typed pattern, got\n%s", text(m.lines))
+	}
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = updated.(TopologyModel)
+	if m.CapturingInput() || m.filter.pattern != "" || !strings.Contains(text(m.lines), "web-1") {
+		t.Fatalf("expected esc to restore the previous pattern")
+	}
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	m = updated.(TopologyModel)
+	if m.filter.status != "ACTIVE" || !strings.Contains(m.View(), "2/3 servers") {
+		t.Fatalf("expected s to filter ACTIVE servers, got %q", m.View())
+	}
+}
+
+// load delivers d to m the way the fetch commands do.
+func load(m TopologyModel, d topologyData) TopologyModel {
+	parts := map[string]func(*topologyData){
+		"networks":     func(t *topologyData) { t.networks = d.networks },
+		"subnets":      func(t *topologyData) { t.subnets = d.subnets },
+		"servers":      func(t *topologyData) { t.servers = d.servers },
+		"ports":        func(t *topologyData) { t.ports = d.ports },
+		"floating IPs": func(t *topologyData) { t.fips = d.fips },
+		"volumes":      func(t *topologyData) { t.volumes = d.volumes },
+		"routers":      func(t *topologyData) { t.routers = d.routers },
+		"zones":        func(t *topologyData) { t.zones = d.zones },
+	}
+	for name, apply := range parts {
+		updated, _ := m.Update(topologyPartMsg{name: name, apply: apply})
+		m = updated.(TopologyModel)
+	}
+	return m
+}
+
+func TestIncrementalLoad(t *testing.T) {
+	d := testData()
+	m := NewTopologyModel(nil, nil, nil)
+	updated, _ := m.Update(topologyPartMsg{name: "networks", apply: func(t *topologyData) { t.networks = d.networks }})
+	m = updated.(TopologyModel)
+	if m.loading || !strings.Contains(m.View(), "frontend") || !strings.Contains(m.View(), "loading subnets") {
+		t.Fatalf("expected the networks before the rest, got %q", m.View())
+	}
+	m = load(m, d)
+	if len(m.pending) != 0 || strings.Contains(m.View(), "loading") || !strings.Contains(m.View(), "web-1") {
+		t.Fatalf("expected the full tree, got %q", m.View())
+	}
+}
+
+func TestPartialLoad(t *testing.T) {
+	d := testData()
+	d.volumes = nil
+	m := load(NewTopologyModel(nil, nil, nil), d)
+	updated, _ := m.Update(topologyPartMsg{name: "volumes", err: fmt.Errorf("list volumes: forbidden")})
+	m = updated.(TopologyModel)
+	out := m.View()
+	if !strings.Contains(out, "frontend") || !strings.Contains(out, "web-1") || !strings.Contains(out, "⚠ Partial topology: list volumes: forbidden") {
+		t.Fatalf("expected the loaded lists with a warning, got %q", out)
+	}
+
+	// The errors of a refresh replace those of the load.
+	next := testData()
+	next.morePorts = true
+	updated, _ = m.Update(topologyRefreshMsg{data: next, errs: map[string]error{"servers": fmt.Errorf("list servers: 503")}})
+	m = updated.(TopologyModel)
+	out = m.View()
+	if !strings.Contains(out, "list servers: 503; only the first 5000 ports are shown") || strings.Contains(out, "forbidden") {
+		t.Fatalf("expected the refresh errors to replace the load errors, got %q", out)
+	}
+}
+
+func TestLargeTopologyStartsCollapsed(t *testing.T) {
+	d := testData()
+	for i := 0; i <= largeTopology; i++ {
+		d.ports = append(d.ports, ports.Port{ID: fmt.Sprintf("x%d", i), NetworkID: "n3"})
+	}
+	m := load(NewTopologyModel(nil, nil, nil), d)
+	out := text(m.lines)
+	if strings.Contains(out, "web-1") || !strings.Contains(out, "▸ Network: frontend ()  2 servers, 0 routers") {
+		t.Fatalf("expected collapsed networks, got\n%s", out)
+	}
+	// backend, frontend, spare: move to frontend and expand it.
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	m = updated.(TopologyModel)
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(TopologyModel)
+	out = text(m.lines)
+	if !strings.Contains(out, "web-1") || strings.Contains(out, "db-1") {
+		t.Fatalf("expected only frontend expanded, got\n%s", out)
+	}
+	// A height of 5 leaves the header and two tree lines.
+	m.height = 5
+	if got := strings.Count(m.View(), "\n"); got != 1+2 {
+		t.Fatalf("expected only the visible window to render, got %d lines", got)
+	}
+}
+
+func TestRefreshMarksChanges(t *testing.T) {
+	m := TopologyModel{expanded: map[string]bool{}, height: 40}
+	m.data = testData()
+	m.nodes = nodesOf(m.data)
+
+	next := testData()
+	next.servers[1].Status = "ACTIVE"
+	next.servers = append(next.servers[:2], servers.Server{ID: "s4", Name: "web-3", Status: "BUILD"})
+	next.ports = append(next.ports[:2], ports.Port{ID: "p-d", NetworkID: "n1", DeviceID: "s4", FixedIPs: []ports.IP{{IPAddress: "10.0.0.13"}}})
+	updated, cmd := m.Update(topologyRefreshMsg{data: next})
+	m = updated.(TopologyModel)
+	if cmd == nil || m.flash != common.FlashFrames {
+		t.Fatal("expected the changes to blink")
+	}
+	if got := m.changes.Summary(); got != "2 new, 1 changed, 2 gone" {
+		t.Errorf("summary = %q", got)
+	}
+	out := text(m.lines)
+	for _, want := range []string{"Gone since the last refresh (2):", "Server db-1", "Port 10.1.0.5"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in:\n%s", want, out)
+		}
+	}
+	marks := map[string]string{}
+	for _, l := range m.lines {
+		if l.mark != "" {
+			marks[l.text] = l.mark + l.was
+		}
+	}
+	if marks["Server: web-3 [BUILD]"] != "+" || marks["Port: 10.0.0.13"] != "+" || marks["Server: web-2 [ACTIVE]"] != "~SHUTOFF" {
+		t.Errorf("unexpected marks %v", marks)
+	}
+
+	updated, _ = m.Update(topologyRefreshMsg{data: next})
+	if m = updated.(TopologyModel); !m.changes.Empty() || strings.Contains(text(m.lines), "Gone") {
+		t.Error("expected an unchanged refresh to clear the marks")
+	}
+}
@@ -0,0 +1,355 @@
+package topology
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/gophercloud/gophercloud/openstack/blockstorage/v3/volumes"
+	"github.com/gophercloud/gophercloud/openstack/compute/v2/servers"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/extensions/layer3/floatingips"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/networks"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/ports"
+	"github.com/gophercloud/gophercloud/openstack/networking/v2/subnets"
+	"ostui/internal/client"
+	"ostui/internal/i18n"
+	"ostui/internal/ui/theme"
+)
+
+// largeTopology is the port count above which networks start collapsed, so
+// the first screen of a big project is one line per network.
+const largeTopology = 300
+
+// unattachedKey identifies the "Unattached resources" section in the
+// expansion state; it cannot collide with a network ID.
+const unattachedKey = "\x00unattached"
+
+// lineKind selects the style of a tree line. Styles are applied when a line
+// scrolls into view, so the tree itself holds only plain text.
+type lineKind int
+
+const (
+	kindPlain lineKind = iota
+	kindNetwork
+	kindActive
+	kindShutoff
+	kindError
+	kindDim
+	kindFIP
+	kindVolume
+)
+
+// kindStyle returns the style of a kind of line. It is built on each call
+// so the palette chosen at startup applies.
+func kindStyle(k lineKind) lipgloss.Style {
+	return map[lineKind]lipgloss.Style{
+		kindPlain:   lipgloss.NewStyle(),
+		kindNetwork: lipgloss.NewStyle().Bold(true).Foreground(theme.Info),
+		kindActive:  lipgloss.NewStyle().Foreground(theme.OK),
+		kindShutoff: lipgloss.NewStyle().Foreground(theme.Warn),
+		kindError:   lipgloss.NewStyle().Foreground(theme.Error),
+		kindDim:     lipgloss.NewStyle().Foreground(theme.Muted),
+		kindFIP:     lipgloss.NewStyle().Foreground(lipgloss.Color("#C678DD")),
+		kindVolume:  lipgloss.NewStyle().Foreground(lipgloss.Color("#9B59B6")),
+	}[k]
+}
+
+// line is one row of the tree.
+type line struct {
+	// tree holds the branch characters drawn before the text.
+	tree string
+	text string
+	kind lineKind
+	// section is set on section headers: a network ID or unattachedKey.
+	section string
+	// key identifies the resource on the line for change tracking, e.g.
+	// "server:<id>"; mark is its change marker and was its previous status.
+	key  string
+	mark string
+	was  string
+}
+
+// render styles the line for display.
+func (l line) render() string {
+	out := kindStyle(l.kind).Render(l.text)
+	if l.was != "" {
+		out += kindStyle(kindDim).Render(" (was " + l.was + ")")
+	}
+	if l.mark != "" {
+		out = markStyle(l.mark).Render(l.mark) + " " + out
+	}
+	if l.tree != "" {
+		out = kindStyle(kindDim).Render(l.tree) + out
+	}
+	return out
+}
+
+// markStyle colors the change markers: green for new, yellow for changed
+// and red for gone.
+func markStyle(mark string) lipgloss.Style {
+	switch mark {
+	case "+":
+		return lipgloss.NewStyle().Bold(true).Foreground(theme.OK)
+	case "~":
+		return lipgloss.NewStyle().Bold(true).Foreground(theme.Warn)
+	}
+	return lipgloss.NewStyle().Bold(true).Foreground(theme.Error)
+}
+
+// serverKind maps a server status to its line style.
+func serverKind(status string) lineKind {
+	switch status {
+	case "ACTIVE":
+		return kindActive
+	case "SHUTOFF":
+		return kindShutoff
+	case "ERROR":
+		return kindError
+	default:
+		return kindDim
+	}
+}
+
+// Tree characters
+const (
+	branch     = "├── "
+	lastBranch = "└── "
+	indent     = "│   "
+)
+
+// buildLines lays out the tree of d restricted by f. open reports whether a
+// section is expanded; a collapsed section is a single summary line.
+func buildLines(d topologyData, f filter, open func(section string) bool) []line {
+	srvList, netList, subList, portList, fipList, volList, routerList := d.servers, d.networks, d.subnets, d.ports, d.fips, d.volumes, d.routers
+	visible := d.visibleServers(f)
+	// A network whose own name matches the pattern keeps all its servers.
+	unmatched := d.visibleServers(f.withoutPattern())
+
+	// Build lookup maps
+	netMap := make(map[string]networks.Network)
+	for _, n := range netList {
+		netMap[n.ID] = n
+	}
+	subnetMap := make(map[string]subnets.Subnet)
+	for _, s := range subList {
+		subnetMap[s.ID] = s
+	}
+	// server map
+	serverMap := make(map[string]servers.Server)
+	for _, s := range srvList {
+		serverMap[s.ID] = s
+	}
+	// ports per server and per network
+	netServers := make(map[string]map[string]bool) // networkID -> set of server IDs
+	serverPorts := make(map[string][]ports.Port)
+	for _, p := range portList {
+		if p.DeviceID != "" {
+			serverPorts[p.DeviceID] = append(serverPorts[p.DeviceID], p)
+			if _, ok := netServers[p.NetworkID]; !ok {
+				netServers[p.NetworkID] = make(map[string]bool)
+			}
+			netServers[p.NetworkID][p.DeviceID] = true
+		}
+	}
+	// floating IPs per port
+	portFIPs := make(map[string][]floatingips.FloatingIP)
+	for _, f := range fipList {
+		if f.PortID != "" {
+			portFIPs[f.PortID] = append(portFIPs[f.PortID], f)
+		}
+	}
+	// volumes per server
+	serverVolumes := make(map[string][]volumes.Volume)
+	for _, v := range volList {
+		for _, att := range v.Attachments {
+			if att.ServerID != "" {
+				serverVolumes[att.ServerID] = append(serverVolumes[att.ServerID], v)
+			}
+		}
+	}
+	// routers per network (using external gateway network ID)
+	netRouters := make(map[string][]client.Router)
+	for _, r := range routerList {
+		if r.GatewayInfo.NetworkID != "" {
+			netRouters[r.GatewayInfo.NetworkID] = append(netRouters[r.GatewayInfo.NetworkID], r)
+		}
+	}
+
+	var out []line
+	// Sort networks by name for deterministic output
+	netIDs := make([]string, 0, len(netList))
+	for _, n := range netList {
+		netIDs = append(netIDs, n.ID)
+	}
+	sort.Slice(netIDs, func(i, j int) bool {
+		return netMap[netIDs[i]].Name < netMap[netIDs[j]].Name
+	})
+
+	var emptyNets []string
+	for _, nid := range netIDs {
+		n := netMap[nid]
+		if f.network != "" && nid != f.network {
+			continue
+		}
+		shown := visible
+		if f.pattern != "" && matches(n.Name, f.pattern) {
+			shown = unmatched
+		}
+		// Servers in this network
+		srvIDs := make([]string, 0, len(netServers[nid]))
+		for sid := range netServers[nid] {
+			if shown[sid] {
+				srvIDs = append(srvIDs, sid)
+			}
+		}
+		if len(srvIDs) == 0 {
+			if f.pattern != "" && !matches(n.Name, f.pattern) {
+				continue
+			}
+			if f.hideEmpty {
+				emptyNets = append(emptyNets, n.Name)
+				continue
+			}
+		}
+		// Determine CIDR from first subnet if available
+		cidr := ""
+		if len(n.Subnets) > 0 {
+			if s, ok := subnetMap[n.Subnets[0]]; ok {
+				cidr = s.CIDR
+			}
+		}
+		routers := netRouters[nid]
+		header := i18n.T("Network: %s (%s)", n.Name, cidr)
+		if !open(nid) {
+			out = append(out, line{text: i18n.T("▸ %s  %d servers, %d routers", header, len(srvIDs), len(routers)), kind: kindNetwork, section: nid, key: "network:" + nid})
+			continue
+		}
+		out = append(out, line{text: "▾ " + header, kind: kindNetwork, section: nid, key: "network:" + nid})
+		sort.Slice(srvIDs, func(i, j int) bool {
+			a, b := serverMap[srvIDs[i]], serverMap[srvIDs[j]]
+			if f.byStatus && a.Status != b.Status {
+				return a.Status < b.Status
+			}
+			return a.Name < b.Name
+		})
+		for si, sid := range srvIDs {
+			srv := serverMap[sid]
+			// Determine prefix for server line
+			isLastServer := si == len(srvIDs)-1 && len(routers) == 0
+			prefix := branch
+			if isLastServer {
+				prefix = lastBranch
+			}
+			out = append(out, line{tree: prefix, text: i18n.T("Server: %s [%s]", srv.Name, srv.Status), kind: serverKind(srv.Status), key: "server:" + srv.ID})
+			// Ports for server
+			ports := serverPorts[srv.ID]
+			sort.Slice(ports, func(i, j int) bool { return ports[i].ID < ports[j].ID })
+			for pi, p := range ports {
+				// Determine prefix for port line
+				portIsLast := pi == len(ports)-1 && len(serverVolumes[srv.ID]) == 0 && len(portFIPs[p.ID]) == 0
+				portPrefix := indent
+				if portIsLast {
+					portPrefix += lastBranch
+				} else {
+					portPrefix += branch
+				}
+				ip := ""
+				if len(p.FixedIPs) > 0 {
+					ip = p.FixedIPs[0].IPAddress
+				}
+				out = append(out, line{tree: portPrefix, text: i18n.T("Port: %s", ip), key: "port:" + p.ID})
+				// Floating IPs attached to this port
+				fips := portFIPs[p.ID]
+				for fi, f := range fips {
+					fipPrefix := indent + "    "
+					if fi == len(fips)-1 {
+						fipPrefix += lastBranch
+					} else {
+						fipPrefix += branch
+					}
+					out = append(out, line{tree: fipPrefix, text: fmt.Sprintf("FIP: %s", f.FloatingIP), kind: kindFIP, key: "fip:" + f.ID})
+				}
+			}
+			// Volumes attached to server
+			vols := serverVolumes[srv.ID]
+			for vi, v := range vols {
+				volIsLast := vi == len(vols)-1
+				volPrefix := indent
+				if volIsLast {
+					volPrefix += lastBranch
+				} else {
+					volPrefix += branch
+				}
+				// Use this server's attachment; multi-attach volumes have one per server.
+				device := ""
+				for _, att := range v.Attachments {
+					if att.ServerID == srv.ID {
+						device = att.Device
+					}
+				}
+				label := fmt.Sprintf("Vol: %s %dGB", device, v.Size)
+				if len(v.Attachments) > 1 {
+					label += i18n.T(" MULTI(%d)", len(v.Attachments))
+				}
+				out = append(out, line{tree: volPrefix, text: label, kind: kindVolume, key: "volume:" + v.ID})
+			}
+		}
+		// Routers for this network
+		for ri, r := range routers {
+			routerIsLast := ri == len(routers)-1
+			routerPrefix := branch
+			if routerIsLast {
+				routerPrefix = lastBranch
+			}
+			out = append(out, line{tree: routerPrefix, text: i18n.T("Router: %s", r.Name), key: "router:" + r.ID})
+		}
+		out = append(out, line{})
+	}
+	if len(emptyNets) > 0 {
+		out = append(out, line{text: i18n.T("%d networks without servers: %s", len(emptyNets), strings.Join(emptyNets, ", ")), kind: kindDim}, line{})
+	}
+	// Unattached resources belong to no network, server, zone or status.
+	var unattachedFIPs []floatingips.FloatingIP
+	var unattachedVols []volumes.Volume
+	if f.network == "" && f.status == "" && f.zone == "" {
+		for _, fip := range fipList {
+			if fip.PortID == "" && (f.project == "" || fip.ProjectID == f.project) && (f.pattern == "" || matches(fip.FloatingIP, f.pattern)) {
+				unattachedFIPs = append(unattachedFIPs, fip)
+			}
+		}
+		// Volumes carry no project, so a project filter hides them.
+		for _, v := range volList {
+			if len(v.Attachments) == 0 && f.project == "" && (f.pattern == "" || matches(v.Name, f.pattern)) {
+				unattachedVols = append(unattachedVols, v)
+			}
+		}
+	}
+	if len(unattachedFIPs) > 0 || len(unattachedVols) > 0 {
+		if !open(unattachedKey) {
+			out = append(out, line{text: i18n.T("▸ Unattached resources  %d FIPs, %d volumes", len(unattachedFIPs), len(unattachedVols)), section: unattachedKey})
+			return out
+		}
+		out = append(out, line{text: "▾ Unattached resources:", section: unattachedKey})
+		for i, f := range unattachedFIPs {
+			isLast := i == len(unattachedFIPs)-1 && len(unattachedVols) == 0
+			prefix := branch
+			if isLast {
+				prefix = lastBranch
+			}
+			out = append(out, line{tree: prefix, text: i18n.T("FIP: %s (not associated)", f.FloatingIP), kind: kindFIP, key: "fip:" + f.ID})
+		}
+		for i, v := range unattachedVols {
+			isLast := i == len(unattachedVols)-1
+			prefix := branch
+			if isLast {
+				prefix = lastBranch
+			}
+			out = append(out, line{tree: prefix, text: i18n.T("Vol: %s %dGB (available)", v.Name, v.Size), kind: kindVolume, key: "volume:" + v.ID})
+		}
+	}
+	if len(out) == 0 {
+		return []line{{text: "Nothing matches the filters. Press x to clear them.", kind: kindDim}}
+	}
+	return out
+}
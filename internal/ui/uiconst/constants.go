@@ -35,6 +35,8 @@ const (
 	ColWidthStatusLong   = 14 // Longer status column width (e.g., load balancer status)
 	ColWidthRAMUsed      = 9  // RAM used column width
 	ColWidthDiskUsed     = 9  // Disk used column width
+	ColWidthAge          = 6  // Age column width (e.g. 365d)
+	ColWidthCreatedBy    = 12 // Creator column width; user IDs are cut short
 )
 
 // Table height constants